@@ -0,0 +1,296 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik
+ *
+ * This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Affero General Public License as published
+ *  by the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ *  along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// adminClient calls the admin HTTP API for the operations which have no gRPC counterpart.
+type adminClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+func newAdminClient(opts *options) *adminClient {
+	return &adminClient{
+		baseURL: strings.TrimSuffix(opts.httpAddr, "/") + "/api/v1/admin",
+		token:   opts.token,
+		http:    &http.Client{Timeout: opts.timeout},
+	}
+}
+
+// errorResponse is the error body of the admin API.
+type errorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Details string `json:"details"`
+	} `json:"error"`
+}
+
+// do sends the request with in as the JSON body unless it is nil, and decodes the JSON response body
+// into out unless it is nil.
+func (c *adminClient) do(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		var e errorResponse
+		if json.Unmarshal(resBody, &e) != nil || e.Error.Code == "" {
+			return fmt.Errorf("%s %s: %s", method, path, res.Status)
+		}
+		if e.Error.Details != "" {
+			return fmt.Errorf("%s: %s (%s)", e.Error.Code, e.Error.Message, e.Error.Details)
+		}
+		return fmt.Errorf("%s: %s", e.Error.Code, e.Error.Message)
+	}
+	if out == nil || len(resBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resBody, out)
+}
+
+func (c *adminClient) reprocess(ctx context.Context, id string) error {
+	var res struct {
+		Asset json.RawMessage `json:"asset"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/mux/assets/"+url.PathEscape(id)+"/reprocess", nil, nil, &res); err != nil {
+		return err
+	}
+	return printJSON(res.Asset, true)
+}
+
+func (c *adminClient) cleanup(ctx context.Context, dryRun bool) error {
+	req := map[string]bool{"dry_run": dryRun}
+	var res struct {
+		Records []json.RawMessage `json:"records"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/retention/run", nil, req, &res); err != nil {
+		return err
+	}
+	return printLines(res.Records)
+}
+
+func (c *adminClient) webhookErrors(ctx context.Context, provider string, limit int) error {
+	query := url.Values{}
+	if provider != "" {
+		query.Set("provider", provider)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	var res struct {
+		Events []json.RawMessage `json:"events"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/webhooks/errors", query, nil, &res); err != nil {
+		return err
+	}
+	return printLines(res.Events)
+}
+
+func (c *adminClient) replayWebhook(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, "/webhooks/"+url.PathEscape(id)+"/replay", nil, nil, nil)
+}
+
+// loadFixtures loads the fixture set and prints the report.
+func (c *adminClient) loadFixtures(ctx context.Context, muxAssets, cloudinaryImages int) error {
+	req := map[string]int{"mux_assets": muxAssets, "cloudinary_images": cloudinaryImages}
+	var res struct {
+		Report json.RawMessage `json:"report"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/seed", nil, req, &res); err != nil {
+		return err
+	}
+	return printJSON(res.Report, true)
+}
+
+// wipeFixtures deletes every fixture, the instance refuses unless it runs in dev mode.
+func (c *adminClient) wipeFixtures(ctx context.Context) error {
+	var res struct {
+		Report json.RawMessage `json:"report"`
+	}
+	if err := c.do(ctx, http.MethodDelete, "/seed", nil, nil, &res); err != nil {
+		return err
+	}
+	return printJSON(res.Report, true)
+}
+
+// auditEntry holds the fields of an audit log entry the tail needs, the entry is printed as received.
+type auditEntry struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	raw       json.RawMessage
+}
+
+// tailAudit prints the new audit log entries, oldest first, until ctx is cancelled. The log is
+// listed newest first, so every poll reads all pages since the newest entry printed. Entries
+// created in the same instant as that one are printed once.
+func (c *adminClient) tailAudit(ctx context.Context, opts *options) error {
+	from := time.Now().Add(-opts.since)
+	seen := make(map[string]time.Time)
+
+	ticker := time.NewTicker(opts.interval)
+	defer ticker.Stop()
+	for {
+		entries, err := c.listAuditSince(ctx, opts, from)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if _, ok := seen[entry.ID]; ok {
+				continue
+			}
+			seen[entry.ID] = entry.CreatedAt
+			if entry.CreatedAt.After(from) {
+				from = entry.CreatedAt
+			}
+			if err := printJSON(entry.raw, false); err != nil {
+				return err
+			}
+		}
+		for id, createdAt := range seen {
+			if createdAt.Before(from) {
+				delete(seen, id)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *adminClient) listAuditSince(ctx context.Context, opts *options, from time.Time) ([]auditEntry, error) {
+	query := url.Values{}
+	query.Set("from", from.UTC().Format(time.RFC3339Nano))
+	query.Set("page_size", strconv.Itoa(opts.pageSize))
+	if opts.assetID != "" {
+		query.Set("asset_id", opts.assetID)
+	}
+	for _, action := range opts.action {
+		query.Add("action", action)
+	}
+
+	var entries []auditEntry
+	for {
+		var res struct {
+			Entries       []json.RawMessage `json:"entries"`
+			NextPageToken string            `json:"next_page_token"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/audit", query, nil, &res); err != nil {
+			return nil, err
+		}
+		for _, raw := range res.Entries {
+			entry := auditEntry{raw: raw}
+			if err := json.Unmarshal(raw, &entry); err != nil {
+				return nil, fmt.Errorf("failed to decode audit entry: %w", err)
+			}
+			entries = append(entries, entry)
+		}
+		if res.NextPageToken == "" {
+			break
+		}
+		query.Set("page_token", res.NextPageToken)
+	}
+	slices.Reverse(entries)
+	return entries, nil
+}
+
+var protoMarshal = protojson.MarshalOptions{UseProtoNames: true}
+
+// printProto prints the message as JSON, indented or on a single line for line-oriented output.
+func printProto(m proto.Message, indent bool) error {
+	b, err := protoMarshal.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return printJSON(b, indent)
+}
+
+func printJSON(b []byte, indent bool) error {
+	var buf bytes.Buffer
+	var err error
+	if indent {
+		err = json.Indent(&buf, b, "", "  ")
+	} else {
+		err = json.Compact(&buf, b)
+	}
+	if err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err = buf.WriteTo(os.Stdout)
+	return err
+}
+
+// printLines prints one JSON document per line.
+func printLines(items []json.RawMessage) error {
+	for _, item := range items {
+		if err := printJSON(item, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,386 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik
+ *
+ * This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Affero General Public License as published
+ *  by the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ *  along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	cldassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/asset/v1"
+	muxassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/asset/v1"
+	"github.com/mikhail5545/media-service-go/pkg/client"
+	cldclient "github.com/mikhail5545/media-service-go/pkg/client/cloudinary"
+	muxclient "github.com/mikhail5545/media-service-go/pkg/client/mux"
+	mediaerrors "github.com/mikhail5545/media-service-go/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// listKind selects the assets listed or read by the asset commands.
+type listKind int
+
+const (
+	kindActive listKind = iota
+	kindArchived
+	kindBroken
+)
+
+// changeState is the admin identity and note recorded with an asset state change.
+type changeState struct {
+	id        []byte
+	adminID   []byte
+	adminName string
+	note      string
+}
+
+// assetClient hides the differences between the gRPC APIs of the asset providers.
+type assetClient interface {
+	ping(ctx context.Context) error
+	get(ctx context.Context, id []byte, kind listKind) (proto.Message, error)
+	// list returns the assets of the page and the token of the next one, empty on the last page.
+	list(ctx context.Context, kind listKind, pageSize int32, pageToken string) ([]proto.Message, string, error)
+	uploadURL(ctx context.Context, opts *options, adminID []byte) (proto.Message, error)
+	archive(ctx context.Context, req *changeState) error
+	restore(ctx context.Context, req *changeState) error
+	delete(ctx context.Context, req *changeState) error
+}
+
+func assetsCommand(ctx context.Context, opts *options, args []string) error {
+	const usage = "mediactl assets list|get ID|upload-url|archive ID|restore ID|delete ID|reprocess ID [flags]"
+	if len(args) == 0 {
+		return usageError(usage)
+	}
+	kind := kindActive
+	switch {
+	case opts.archived && opts.broken:
+		return usageError("--archived and --broken are mutually exclusive")
+	case opts.archived:
+		kind = kindArchived
+	case opts.broken:
+		kind = kindBroken
+	}
+
+	switch cmd := args[0]; {
+	case cmd == "list" && len(args) == 1:
+		return withAssets(ctx, opts, func(c assetClient) error { return listAssets(ctx, c, opts, kind) })
+	case cmd == "get" && len(args) == 2:
+		id, err := parseID(args[1])
+		if err != nil {
+			return err
+		}
+		return withAssets(ctx, opts, func(c assetClient) error {
+			details, err := c.get(ctx, id, kind)
+			if err != nil {
+				return err
+			}
+			return printProto(details, true)
+		})
+	case cmd == "upload-url" && len(args) == 1:
+		adminID, err := parseAdminID(opts.adminID)
+		if err != nil {
+			return err
+		}
+		return withAssets(ctx, opts, func(c assetClient) error {
+			res, err := c.uploadURL(ctx, opts, adminID)
+			if err != nil {
+				return err
+			}
+			return printProto(res, true)
+		})
+	case (cmd == "archive" || cmd == "restore" || cmd == "delete") && len(args) == 2:
+		req, err := newChangeState(opts, args[1])
+		if err != nil {
+			return err
+		}
+		return withAssets(ctx, opts, func(c assetClient) error {
+			op := map[string]func(context.Context, *changeState) error{
+				"archive": c.archive,
+				"restore": c.restore,
+				"delete":  c.delete,
+			}[cmd]
+			return op(ctx, req)
+		})
+	case cmd == "reprocess" && len(args) == 2:
+		if p := opts.provider; p != "" && p != "mux" {
+			return usageError("reprocess is only supported for MUX assets")
+		}
+		return newAdminClient(opts).reprocess(ctx, args[1])
+	default:
+		return usageError(usage)
+	}
+}
+
+func listAssets(ctx context.Context, c assetClient, opts *options, kind listKind) error {
+	token := opts.pageToken
+	for {
+		assets, next, err := c.list(ctx, kind, int32(opts.pageSize), token)
+		if err != nil {
+			return err
+		}
+		for _, asset := range assets {
+			if err := printProto(asset, false); err != nil {
+				return err
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		if !opts.all {
+			// The token goes to stderr, so stdout stays one asset per line.
+			_, _ = fmt.Fprintf(os.Stderr, "next page token: %s\n", next)
+			return nil
+		}
+		token = next
+	}
+}
+
+// withAssets connects to the gRPC API of the selected provider, runs fn and closes the connection.
+func withAssets(ctx context.Context, opts *options, fn func(assetClient) error) error {
+	connOpts, err := grpcConnOptions(opts)
+	if err != nil {
+		return err
+	}
+	callOpts := []client.Option{client.WithTimeout(int64(opts.timeout/time.Millisecond), time.Millisecond)}
+
+	var (
+		c       assetClient
+		closeFn func() error
+	)
+	switch opts.provider {
+	case "", "mux":
+		mc, _ := muxclient.NewAssetServiceClient(callOpts...)
+		if err := mc.Connect(ctx, opts.grpcAddr, connOpts...); err != nil {
+			return fmt.Errorf("failed to connect to %s: %w", opts.grpcAddr, err)
+		}
+		c, closeFn = &muxAssets{c: mc}, mc.Close
+	case "cloudinary":
+		cc, _ := cldclient.NewAssetServiceClient(callOpts...)
+		if err := cc.Connect(ctx, opts.grpcAddr, connOpts...); err != nil {
+			return fmt.Errorf("failed to connect to %s: %w", opts.grpcAddr, err)
+		}
+		c, closeFn = &cldAssets{c: cc}, cc.Close
+	default:
+		return usageError(fmt.Sprintf("unknown provider %q, expected mux or cloudinary", opts.provider))
+	}
+	defer func() { _ = closeFn() }()
+	return mediaerrors.FromGRPC(fn(c))
+}
+
+func grpcConnOptions(opts *options) ([]client.ConnOption, error) {
+	var connOpts []client.ConnOption
+	switch {
+	case opts.insecure:
+		connOpts = append(connOpts, client.WithInsecure())
+	case opts.caFile != "" || opts.certFile != "":
+		connOpts = append(connOpts, client.WithTLSFromFiles(opts.caFile, opts.certFile, opts.keyFile))
+	}
+	switch {
+	case opts.apiKey != "" && opts.token != "":
+		return nil, usageError("--api-key and --token are mutually exclusive")
+	case opts.apiKey != "":
+		connOpts = append(connOpts, client.WithPerRPCCredentials(client.NewAPIKeyCredentials(opts.apiKey, !opts.insecure)))
+	case opts.token != "":
+		connOpts = append(connOpts, client.WithPerRPCCredentials(bearerCredentials{token: opts.token, requireTLS: !opts.insecure}))
+	}
+	return connOpts, nil
+}
+
+// bearerCredentials sends the admin token in the "authorization" metadata.
+type bearerCredentials struct {
+	token      string
+	requireTLS bool
+}
+
+func (c bearerCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.token}, nil
+}
+
+func (c bearerCredentials) RequireTransportSecurity() bool {
+	return c.requireTLS
+}
+
+func parseID(s string) ([]byte, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return nil, usageError(fmt.Sprintf("invalid asset id %q: %v", s, err))
+	}
+	return id[:], nil
+}
+
+// parseAdminID returns nil if s is empty, the server then records the authenticated caller.
+func parseAdminID(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return nil, usageError(fmt.Sprintf("invalid admin id %q: %v", s, err))
+	}
+	return id[:], nil
+}
+
+func newChangeState(opts *options, rawID string) (*changeState, error) {
+	id, err := parseID(rawID)
+	if err != nil {
+		return nil, err
+	}
+	adminID, err := parseAdminID(opts.adminID)
+	if err != nil {
+		return nil, err
+	}
+	return &changeState{id: id, adminID: adminID, adminName: opts.adminName, note: opts.note}, nil
+}
+
+type muxAssets struct {
+	c *muxclient.AssetServiceClient
+}
+
+func (m *muxAssets) ping(ctx context.Context) error {
+	_, err := m.c.Ping(ctx, &muxassetpbv1.PingRequest{})
+	return err
+}
+
+func (m *muxAssets) get(ctx context.Context, id []byte, kind listKind) (proto.Message, error) {
+	switch kind {
+	case kindArchived:
+		res, err := m.c.GetWithArchived(ctx, &muxassetpbv1.GetWithArchivedRequest{Uuid: id})
+		return res.GetDetails(), err
+	case kindBroken:
+		res, err := m.c.GetWithBroken(ctx, &muxassetpbv1.GetWithBrokenRequest{Uuid: id})
+		return res.GetDetails(), err
+	default:
+		res, err := m.c.Get(ctx, &muxassetpbv1.GetRequest{Uuid: id})
+		return res.GetDetails(), err
+	}
+}
+
+func (m *muxAssets) list(ctx context.Context, kind listKind, pageSize int32, pageToken string) ([]proto.Message, string, error) {
+	var token *string
+	if pageToken != "" {
+		token = &pageToken
+	}
+	switch kind {
+	case kindArchived:
+		res, err := m.c.ListArchived(ctx, &muxassetpbv1.ListArchivedRequest{PageSize: pageSize, NextPageToken: token})
+		return messages(res.GetDetails()), res.GetNextPageToken(), err
+	case kindBroken:
+		res, err := m.c.ListBroken(ctx, &muxassetpbv1.ListBrokenRequest{PageSize: pageSize, NextPageToken: token})
+		return messages(res.GetDetails()), res.GetNextPageToken(), err
+	default:
+		res, err := m.c.List(ctx, &muxassetpbv1.ListRequest{PageSize: pageSize, NextPageToken: token})
+		return messages(res.GetDetails()), res.GetNextPageToken(), err
+	}
+}
+
+func (m *muxAssets) uploadURL(ctx context.Context, opts *options, adminID []byte) (proto.Message, error) {
+	return m.c.CreateUploadURL(ctx, &muxassetpbv1.CreateUploadURLRequest{
+		Title:     opts.title,
+		AdminUuid: adminID,
+		AdminName: opts.adminName,
+	})
+}
+
+func (m *muxAssets) archive(ctx context.Context, req *changeState) error {
+	_, err := m.c.Archive(ctx, &muxassetpbv1.ArchiveRequest{Uuid: req.id, AdminUuid: req.adminID, AdminName: req.adminName, Note: req.note})
+	return err
+}
+
+func (m *muxAssets) restore(ctx context.Context, req *changeState) error {
+	_, err := m.c.Restore(ctx, &muxassetpbv1.RestoreRequest{Uuid: req.id, AdminUuid: req.adminID, AdminName: req.adminName, Note: req.note})
+	return err
+}
+
+func (m *muxAssets) delete(ctx context.Context, req *changeState) error {
+	_, err := m.c.Delete(ctx, &muxassetpbv1.DeleteRequest{Uuid: req.id, AdminUuid: req.adminID, AdminName: req.adminName, Note: req.note})
+	return err
+}
+
+type cldAssets struct {
+	c *cldclient.AssetServiceClient
+}
+
+func (cl *cldAssets) ping(ctx context.Context) error {
+	_, err := cl.c.Ping(ctx, &cldassetpbv1.PingRequest{})
+	return err
+}
+
+func (cl *cldAssets) get(ctx context.Context, id []byte, kind listKind) (proto.Message, error) {
+	switch kind {
+	case kindArchived:
+		res, err := cl.c.GetWithArchived(ctx, &cldassetpbv1.GetWithArchivedRequest{Uuid: id})
+		return res.GetDetails(), err
+	case kindBroken:
+		res, err := cl.c.GetWithBroken(ctx, &cldassetpbv1.GetWithBrokenRequest{Uuid: id})
+		return res.GetDetails(), err
+	default:
+		res, err := cl.c.Get(ctx, &cldassetpbv1.GetRequest{Uuid: id})
+		return res.GetDetails(), err
+	}
+}
+
+func (cl *cldAssets) list(ctx context.Context, kind listKind, pageSize int32, pageToken string) ([]proto.Message, string, error) {
+	switch kind {
+	case kindArchived:
+		res, err := cl.c.ListArchived(ctx, &cldassetpbv1.ListArchivedRequest{PageSize: pageSize, PageToken: pageToken})
+		return messages(res.GetDetails()), res.GetNextPageToken(), err
+	case kindBroken:
+		res, err := cl.c.ListBroken(ctx, &cldassetpbv1.ListBrokenRequest{PageSize: pageSize, PageToken: pageToken})
+		return messages(res.GetDetails()), res.GetNextPageToken(), err
+	default:
+		res, err := cl.c.List(ctx, &cldassetpbv1.ListRequest{PageSize: pageSize, PageToken: pageToken})
+		return messages(res.GetDetails()), res.GetNextPageToken(), err
+	}
+}
+
+func (cl *cldAssets) uploadURL(ctx context.Context, opts *options, adminID []byte) (proto.Message, error) {
+	req := &cldassetpbv1.CreateSignedUploadURLRequest{
+		PublicId:  opts.publicID,
+		File:      opts.file,
+		AdminUuid: adminID,
+		AdminName: opts.adminName,
+	}
+	if opts.note != "" {
+		req.Note = &opts.note
+	}
+	return cl.c.CreateSignedUploadURL(ctx, req)
+}
+
+func (cl *cldAssets) archive(ctx context.Context, req *changeState) error {
+	_, err := cl.c.Archive(ctx, &cldassetpbv1.ArchiveRequest{Uuid: req.id, AdminUuid: req.adminID, AdminName: req.adminName, Note: req.note})
+	return err
+}
+
+func (cl *cldAssets) restore(ctx context.Context, req *changeState) error {
+	_, err := cl.c.Restore(ctx, &cldassetpbv1.RestoreRequest{Uuid: req.id, AdminUuid: req.adminID, AdminName: req.adminName, Note: req.note})
+	return err
+}
+
+func (cl *cldAssets) delete(ctx context.Context, req *changeState) error {
+	_, err := cl.c.Delete(ctx, &cldassetpbv1.DeleteRequest{Uuid: req.id, AdminUuid: req.adminID, AdminName: req.adminName, Note: req.note})
+	return err
+}
+
+func messages[T proto.Message](items []T) []proto.Message {
+	out := make([]proto.Message, len(items))
+	for i, item := range items {
+		out[i] = item
+	}
+	return out
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik
+ *
+ * This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Affero General Public License as published
+ *  by the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ *  along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Command mediactl administers a media service instance from scripts and during incidents, without
+// the admin UI. The asset commands call the gRPC API, the operational commands which have no gRPC
+// counterpart call the admin HTTP API.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Usage:
+//
+//	mediactl ping [flags]
+//	mediactl assets list [--archived|--broken] [flags]
+//	mediactl assets get ID [flags]
+//	mediactl assets upload-url [flags]
+//	mediactl assets archive|restore|delete ID [flags]
+//	mediactl assets reprocess ID [flags]
+//	mediactl cleanup [--dry-run] [flags]
+//	mediactl webhooks errors [flags]
+//	mediactl webhooks replay ID [flags]
+//	mediactl audit tail [flags]
+//	mediactl seed load [--mux-assets N] [--cloudinary-images N] [flags]
+//	mediactl seed wipe [flags]
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	opts, args, err := parseFlags(os.Args[0], os.Args[1:])
+	if errors.Is(err, pflag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	os.Exit(run(ctx, opts, args))
+}
+
+// options holds the flags of all commands, each command only reads the ones it documents.
+type options struct {
+	grpcAddr  string
+	httpAddr  string
+	apiKey    string
+	token     string
+	insecure  bool
+	caFile    string
+	certFile  string
+	keyFile   string
+	timeout   time.Duration
+	provider  string
+	adminID   string
+	adminName string
+	note      string
+
+	archived  bool
+	broken    bool
+	pageSize  int
+	pageToken string
+	all       bool
+
+	title    string
+	publicID string
+	file     string
+
+	dryRun   bool
+	limit    int
+	interval time.Duration
+	since    time.Duration
+	action   []string
+	assetID  string
+
+	muxAssets        int
+	cloudinaryImages int
+}
+
+func parseFlags(name string, args []string) (*options, []string, error) {
+	o := &options{}
+	fs := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	fs.StringVar(&o.grpcAddr, "grpc-addr", envOr("MEDIACTL_GRPC_ADDR", "localhost:50051"), "gRPC API address (MEDIACTL_GRPC_ADDR)")
+	fs.StringVar(&o.httpAddr, "http-addr", envOr("MEDIACTL_HTTP_ADDR", "http://localhost:8080"), "HTTP API base URL (MEDIACTL_HTTP_ADDR)")
+	fs.StringVar(&o.apiKey, "api-key", os.Getenv("MEDIACTL_API_KEY"), "API key authenticating the gRPC calls (MEDIACTL_API_KEY)")
+	fs.StringVar(&o.token, "token", os.Getenv("MEDIACTL_TOKEN"), "admin bearer token authenticating the HTTP and gRPC calls (MEDIACTL_TOKEN)")
+	fs.BoolVar(&o.insecure, "insecure", false, "connect to the gRPC API without TLS")
+	fs.StringVar(&o.caFile, "tls-ca", "", "CA file verifying the gRPC server certificate")
+	fs.StringVar(&o.certFile, "tls-cert", "", "client certificate file for mutual TLS")
+	fs.StringVar(&o.keyFile, "tls-key", "", "client key file for mutual TLS")
+	fs.DurationVar(&o.timeout, "timeout", 30*time.Second, "timeout of a single call")
+	fs.StringVarP(&o.provider, "provider", "p", "", "asset provider: mux or cloudinary (assets, default mux); webhook provider filter (webhooks errors)")
+	fs.StringVar(&o.adminID, "admin-id", os.Getenv("MEDIACTL_ADMIN_ID"), "UUID of the admin recorded in the audit log (MEDIACTL_ADMIN_ID)")
+	fs.StringVar(&o.adminName, "admin-name", os.Getenv("MEDIACTL_ADMIN_NAME"), "name of the admin recorded in the audit log (MEDIACTL_ADMIN_NAME)")
+	fs.StringVar(&o.note, "note", "", "note recorded in the audit log")
+
+	fs.BoolVar(&o.archived, "archived", false, "list the archived assets")
+	fs.BoolVar(&o.broken, "broken", false, "list the broken assets")
+	fs.IntVar(&o.pageSize, "page-size", 50, "number of assets or audit entries per page")
+	fs.StringVar(&o.pageToken, "page-token", "", "token of the page to list")
+	fs.BoolVar(&o.all, "all", false, "list all pages")
+
+	fs.StringVar(&o.title, "title", "", "title of the MUX asset (assets upload-url)")
+	fs.StringVar(&o.publicID, "public-id", "", "public ID of the Cloudinary asset (assets upload-url)")
+	fs.StringVar(&o.file, "file", "", "file name of the Cloudinary asset (assets upload-url)")
+
+	fs.BoolVar(&o.dryRun, "dry-run", false, "only report the assets that would be purged (cleanup)")
+	fs.IntVar(&o.limit, "limit", 0, "maximum number of events listed (webhooks errors)")
+	fs.DurationVar(&o.interval, "interval", 5*time.Second, "poll interval (audit tail)")
+	fs.DurationVar(&o.since, "since", 0, "also print the entries of this period before now (audit tail)")
+	fs.StringSliceVar(&o.action, "action", nil, "audit actions to print (audit tail)")
+	fs.StringVar(&o.assetID, "asset-id", "", "asset whose audit entries are printed (audit tail)")
+	fs.IntVar(&o.muxAssets, "mux-assets", 0, "number of MUX fixtures, 0 loads the default set (seed load)")
+	fs.IntVar(&o.cloudinaryImages, "cloudinary-images", 0, "number of Cloudinary fixtures, 0 loads the default set (seed load)")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	return o, fs.Args(), nil
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// run executes the command and returns the exit code: 0 on success, 1 if the command failed and
+// 2 on invalid usage.
+func run(ctx context.Context, opts *options, args []string) int {
+	err := dispatch(ctx, opts, args)
+	var usageErr usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &usageErr):
+		_, _ = fmt.Fprintln(os.Stderr, "usage: "+string(usageErr))
+		return 2
+	case errors.Is(err, context.Canceled):
+		return 0
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+}
+
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+func dispatch(ctx context.Context, opts *options, args []string) error {
+	if len(args) == 0 {
+		return usageError("mediactl ping|assets|cleanup|webhooks|audit|seed ... [flags]")
+	}
+	switch args[0] {
+	case "ping":
+		return withAssets(ctx, opts, func(c assetClient) error { return c.ping(ctx) })
+	case "assets":
+		return assetsCommand(ctx, opts, args[1:])
+	case "cleanup":
+		return newAdminClient(opts).cleanup(ctx, opts.dryRun)
+	case "webhooks":
+		return webhooksCommand(ctx, opts, args[1:])
+	case "audit":
+		if len(args) != 2 || args[1] != "tail" {
+			return usageError("mediactl audit tail [flags]")
+		}
+		return newAdminClient(opts).tailAudit(ctx, opts)
+	case "seed":
+		return seedCommand(ctx, opts, args[1:])
+	default:
+		return usageError(fmt.Sprintf("unknown command %q, expected ping, assets, cleanup, webhooks, audit or seed", args[0]))
+	}
+}
+
+func webhooksCommand(ctx context.Context, opts *options, args []string) error {
+	c := newAdminClient(opts)
+	switch {
+	case len(args) == 1 && args[0] == "errors":
+		return c.webhookErrors(ctx, opts.provider, opts.limit)
+	case len(args) == 2 && args[0] == "replay":
+		return c.replayWebhook(ctx, args[1])
+	default:
+		return usageError("mediactl webhooks errors|replay ID [flags]")
+	}
+}
+
+func seedCommand(ctx context.Context, opts *options, args []string) error {
+	c := newAdminClient(opts)
+	switch {
+	case len(args) == 1 && args[0] == "load":
+		return c.loadFixtures(ctx, opts.muxAssets, opts.cloudinaryImages)
+	case len(args) == 1 && args[0] == "wipe":
+		return c.wipeFixtures(ctx)
+	default:
+		return usageError("mediactl seed load|wipe [flags]")
+	}
+}
@@ -19,51 +19,109 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/mikhail5545/media-service-go/internal/app"
+	"github.com/mikhail5545/media-service-go/internal/config"
 	"github.com/spf13/pflag"
 )
 
+// Usage:
+//
+//	media-service [flags]
+//	media-service migrate up|down [N]|force VERSION|version [flags]
+//	media-service migrate metadata FROM TO [flags]
+//	media-service migrate listings [flags]
 func main() {
 	ctx := context.Background()
-	cfg := parseArguments()
+	args := os.Args[1:]
 
-	cfg.Log.AppName = "media-service"
+	var migrateArgs []string
+	if len(args) > 0 && args[0] == "migrate" {
+		migrateArgs, args = splitPositional(args[1:])
+		if len(migrateArgs) == 0 {
+			_, _ = fmt.Fprintln(os.Stderr, "usage: media-service migrate up|down [N]|force VERSION|version|metadata FROM TO|listings [flags]")
+			os.Exit(2)
+		}
+	}
+
+	cfg, err := config.Load(os.Args[0], args)
+	if errors.Is(err, pflag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+		os.Exit(2)
+	}
 
-	application, err := app.New(ctx, &cfg)
+	application, err := app.New(ctx, cfg)
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "failed to initialize application: %v\n", err)
 		os.Exit(1)
 	}
 
-	if err := application.Init(ctx); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "failed to initialize application components: %v\n", err)
+	if migrateArgs != nil {
+		os.Exit(migrate(ctx, application, migrateArgs))
 	}
+	os.Exit(run(ctx, application))
+}
 
-	if err := application.Run(ctx); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "application runtime error: %v\n", err)
-		os.Exit(1)
+// splitPositional splits args into the leading positional arguments and the flags that follow.
+func splitPositional(args []string) (positional, rest []string) {
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return args[:i], args[i:]
+		}
 	}
+	return args, nil
+}
+
+// migrate runs a database migrations command and returns the process exit code.
+func migrate(ctx context.Context, application *app.App, args []string) (code int) {
 	defer func() {
 		if err := application.Close(); err != nil {
 			_, _ = fmt.Fprintf(os.Stderr, "failed to close application: %v\n", err)
+			code = 1
 		}
 	}()
+
+	var err error
+	switch args[0] {
+	case "metadata":
+		err = application.MigrateMetadata(ctx, args[1:])
+	case "listings":
+		err = application.RebuildListings(ctx)
+	default:
+		err = application.Migrate(ctx, args[0], args[1:])
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "migration failed: %v\n", err)
+		return 1
+	}
+	return 0
 }
 
-func parseArguments() app.Config {
-	cfg := app.Config{}
+// run initializes and runs the application and returns the process exit code. Resources are
+// released before returning, so the deferred Close runs even on failure.
+func run(ctx context.Context, application *app.App) (code int) {
+	defer func() {
+		if err := application.Close(); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "failed to close application: %v\n", err)
+			code = 1
+		}
+	}()
 
-	pflag.Int64VarP(&cfg.GRPC.Port, "grpc-port", "g", 50052, "gRPC server port")
-	pflag.Int64VarP(&cfg.HTTP.Port, "http-port", "p", 8082, "HTTP server port")
-	pflag.IntVarP(&cfg.GracefulShutdownTimeoutSeconds, "graceful-shutdown-timeout", "t", 15, "Graceful shutdown timeout in seconds")
-	pflag.StringVarP(&cfg.Log.Directory, "log-directory", "l", "./logs", "Directory to store log files")
-	pflag.BoolVarP(&cfg.Log.UseTimestamp, "log-use-timestamp", "", true, "Whether to use timestamp in log file names")
-	pflag.BoolVarP(&cfg.Mux.TestMode, "mux-test-mode", "", false, "Enable Mux test mode")
-	pflag.StringVarP(&cfg.Mux.CORSOrigin, "mux-cors-origin", "", "", "Mux CORS origin")
-	pflag.Parse()
+	if err := application.Init(ctx); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to initialize application components: %v\n", err)
+		return 1
+	}
 
-	return cfg
+	if err := application.Run(ctx); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "application runtime error: %v\n", err)
+		return 1
+	}
+	return 0
 }
@@ -0,0 +1,361 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package cfstream is a minimal client of the Cloudflare Stream API. It only implements the calls
+// used by the service: direct creator uploads, video inspection and deletion, webhook verification
+// and signed playback tokens.
+package cfstream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/mikhail5545/media-service-go/internal/apiclients/resilience"
+	"github.com/mikhail5545/media-service-go/internal/deadline"
+	"github.com/mikhail5545/media-service-go/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultBaseURL is the base URL of the Cloudflare API.
+const DefaultBaseURL = "https://api.cloudflare.com/client/v4"
+
+// ErrNotFound is returned when the video does not exist.
+var ErrNotFound = errors.New("cfstream: not found")
+
+type APIClient interface {
+	CreateDirectUpload(ctx context.Context, opts DirectUploadOptions) (*DirectUpload, error)
+	GetVideo(ctx context.Context, uid string) (*Video, error)
+	DeleteVideo(ctx context.Context, uid string) error
+	VerifiesWebhooks() bool
+	VerifyWebhookSignature(payload []byte, header string) error
+	GeneratePlaybackToken(opts PlaybackTokenOptions) (string, error)
+}
+
+// DirectUploadOptions restrict a direct creator upload.
+type DirectUploadOptions struct {
+	// MaxDurationSeconds is the maximum duration of the uploaded video, Cloudflare rejects longer videos.
+	MaxDurationSeconds int
+	// RequireSignedURLs makes the video playable only with a signed playback token.
+	RequireSignedURLs bool
+	// Meta is stored with the video and sent back in the webhooks.
+	Meta map[string]string
+	// Expires bounds the validity of the upload URL, Cloudflare defaults to 30 minutes.
+	Expires time.Duration
+}
+
+// DirectUpload is a one-time upload URL of a new video.
+type DirectUpload struct {
+	UID       string `json:"uid"`
+	UploadURL string `json:"uploadURL"`
+}
+
+// Video states reported by Cloudflare Stream.
+const (
+	StateReady = "ready"
+	StateError = "error"
+)
+
+// Video is a video stored in Cloudflare Stream. Webhooks carry the same representation.
+type Video struct {
+	UID           string            `json:"uid"`
+	ReadyToStream bool              `json:"readyToStream"`
+	Status        VideoStatus       `json:"status"`
+	Meta          map[string]string `json:"meta"`
+	// Duration is the duration in seconds, it is -1 until the video is processed.
+	Duration  float64 `json:"duration"`
+	Size      int64   `json:"size"`
+	Thumbnail string  `json:"thumbnail"`
+	Input     struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	} `json:"input"`
+	Playback struct {
+		HLS  string `json:"hls"`
+		DASH string `json:"dash"`
+	} `json:"playback"`
+	RequireSignedURLs bool `json:"requireSignedURLs"`
+}
+
+type VideoStatus struct {
+	State           string `json:"state"`
+	ErrorReasonCode string `json:"errorReasonCode"`
+	ErrorReasonText string `json:"errorReasonText"`
+}
+
+// APIError is an error response of the Cloudflare API.
+type APIError struct {
+	StatusCode int
+	Code       int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("cfstream: status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("cfstream: status %d: %d: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// envelope is the common shape of the Cloudflare API responses.
+type envelope struct {
+	Success bool            `json:"success"`
+	Result  json.RawMessage `json:"result"`
+	Errors  []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type Client struct {
+	baseURL   string
+	accountID string
+	apiToken  string
+	cfg       config
+	// exec retries the idempotent calls and short-circuits all calls while the API is down.
+	exec *resilience.Executor
+}
+
+var _ APIClient = (*Client)(nil)
+
+// New creates a client of the Stream videos of the account, authenticated with an API token having
+// the Stream:Edit permission.
+func New(accountID, apiToken string, opt ...Option) (*Client, error) {
+	if accountID == "" || apiToken == "" {
+		return nil, fmt.Errorf("account ID or API token is empty")
+	}
+
+	cfg := &config{baseURL: DefaultBaseURL}
+	for _, o := range opt {
+		if err := o(cfg); err != nil {
+			return nil, fmt.Errorf("error applying option: %w", err)
+		}
+	}
+	if cfg.httpClient == nil {
+		cfg.httpClient = &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		}
+	}
+
+	var exec *resilience.Executor
+	if cfg.resilience != nil {
+		exec = resilience.New(*cfg.resilience, transient, cfg.resilienceHooks)
+	}
+
+	return &Client{
+		baseURL:   strings.TrimSuffix(cfg.baseURL, "/"),
+		accountID: accountID,
+		apiToken:  apiToken,
+		cfg:       *cfg,
+		exec:      exec,
+	}, nil
+}
+
+// transient reports whether the API error is worth retrying: a server error or a rate limit.
+func transient(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode >= 500 || apiErr.StatusCode == http.StatusTooManyRequests)
+}
+
+// track starts a client span for the API call and bounds it by the call timeout. The returned
+// function ends the span and notifies the configured observer, it must be called with a pointer to
+// the call error.
+func (c *Client) track(ctx context.Context, operation string) (context.Context, func(err *error)) {
+	start := time.Now()
+	ctx, cancel := deadline.Sub(ctx, c.cfg.callTimeout, c.cfg.callReserve)
+	ctx, span := tracing.Start(ctx, "cfstream."+operation, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, func(err *error) {
+		cancel()
+		tracing.End(span, *err)
+		if c.cfg.observer != nil {
+			c.cfg.observer(operation, time.Since(start), *err)
+		}
+	}
+}
+
+// CreateDirectUpload creates a video awaiting its content and returns the one-time URL the content
+// is uploaded to. It is not retried, as every call creates a new video.
+func (c *Client) CreateDirectUpload(ctx context.Context, opts DirectUploadOptions) (_ *DirectUpload, err error) {
+	ctx, done := c.track(ctx, "create_direct_upload")
+	defer done(&err)
+
+	body := map[string]any{
+		"requireSignedURLs": opts.RequireSignedURLs,
+	}
+	if opts.MaxDurationSeconds > 0 {
+		body["maxDurationSeconds"] = opts.MaxDurationSeconds
+	}
+	if len(opts.Meta) > 0 {
+		body["meta"] = opts.Meta
+	}
+	if opts.Expires > 0 {
+		body["expiry"] = time.Now().Add(opts.Expires).UTC().Format(time.RFC3339)
+	}
+
+	var upload DirectUpload
+	err = c.exec.Do(ctx, "create_direct_upload", false, func(ctx context.Context) error {
+		return c.do(ctx, http.MethodPost, "/direct_upload", body, &upload)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if upload.UID == "" || upload.UploadURL == "" {
+		return nil, fmt.Errorf("cfstream: direct upload response is missing the video ID or the upload URL")
+	}
+	return &upload, nil
+}
+
+// GetVideo returns the video, or ErrNotFound if it does not exist.
+func (c *Client) GetVideo(ctx context.Context, uid string) (_ *Video, err error) {
+	ctx, done := c.track(ctx, "get_video")
+	defer done(&err)
+
+	var video Video
+	err = c.exec.Do(ctx, "get_video", true, func(ctx context.Context) error {
+		return c.do(ctx, http.MethodGet, "/"+url.PathEscape(uid), nil, &video)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &video, nil
+}
+
+// DeleteVideo deletes the video. Deleting a video that does not exist succeeds.
+func (c *Client) DeleteVideo(ctx context.Context, uid string) (err error) {
+	ctx, done := c.track(ctx, "delete_video")
+	defer done(&err)
+
+	return c.exec.Do(ctx, "delete_video", true, func(ctx context.Context) error {
+		if err := c.do(ctx, http.MethodDelete, "/"+url.PathEscape(uid), nil, nil); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		return nil
+	})
+}
+
+// Ping checks that the API is reachable and the token is accepted. It bypasses the retries and the
+// circuit breaker, so health checks observe the current API state.
+func (c *Client) Ping(ctx context.Context) (err error) {
+	ctx, done := c.track(ctx, "ping")
+	defer done(&err)
+
+	var videos []Video
+	if err := c.do(ctx, http.MethodGet, "?limit=1", nil, &videos); err != nil {
+		return fmt.Errorf("failed to reach cloudflare stream api: %w", err)
+	}
+	return nil
+}
+
+// do sends an authenticated request to the Stream API of the account and decodes the result of the
+// response into out, if it is not nil. Error responses are returned as errors.
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+"/accounts/"+url.PathEscape(c.accountID)+"/stream"+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.cfg.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	var env envelope
+	// Deletions answer with an empty body.
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &env); err != nil && resp.StatusCode < 300 {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	} else {
+		env.Success = true
+	}
+	if resp.StatusCode >= 300 || !env.Success {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if len(env.Errors) > 0 {
+			apiErr.Code = env.Errors[0].Code
+			apiErr.Message = env.Errors[0].Message
+		}
+		return apiErr
+	}
+	if out != nil && len(env.Result) > 0 {
+		if err := json.Unmarshal(env.Result, out); err != nil {
+			return fmt.Errorf("failed to decode response result: %w", err)
+		}
+	}
+	return nil
+}
+
+// PlaybackTokenOptions describe a signed playback token of a video.
+type PlaybackTokenOptions struct {
+	UID string
+	// Expiration is the unix time the token expires at.
+	Expiration int64
+}
+
+// GeneratePlaybackToken signs a playback token of a video requiring signed URLs. The token replaces
+// the video ID in the playback URLs.
+func (c *Client) GeneratePlaybackToken(opts PlaybackTokenOptions) (string, error) {
+	if len(c.cfg.signingKeyPrivateKey) == 0 || c.cfg.signingKeyID == "" {
+		return "", fmt.Errorf("signing key is not configured")
+	}
+	signKey, err := jwt.ParseRSAPrivateKeyFromPEM(c.cfg.signingKeyPrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse signing key: %w", err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": opts.UID,
+		"kid": c.cfg.signingKeyID,
+		"exp": opts.Expiration,
+	})
+	token.Header["kid"] = c.cfg.signingKeyID
+
+	signedToken, err := token.SignedString(signKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signedToken, nil
+}
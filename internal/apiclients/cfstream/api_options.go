@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cfstream
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mikhail5545/media-service-go/internal/apiclients/resilience"
+)
+
+// CallObserver is notified after every Cloudflare Stream API call with the operation name, its
+// duration and error.
+type CallObserver func(operation string, duration time.Duration, err error)
+
+type config struct {
+	baseURL              string
+	httpClient           *http.Client
+	signingKeyID         string
+	signingKeyPrivateKey []byte
+	webhookSecret        string
+	webhookTolerance     time.Duration
+	observer             CallObserver
+	resilience           *resilience.Config
+	resilienceHooks      resilience.Hooks
+	callTimeout          time.Duration
+	callReserve          time.Duration
+}
+
+type Option func(*config) error
+
+// WithBaseURL replaces the base URL of the Cloudflare API, e.g. to use an emulator.
+func WithBaseURL(baseURL string) Option {
+	return func(c *config) error {
+		c.baseURL = baseURL
+		return nil
+	}
+}
+
+// WithHTTPClient replaces the HTTP client of the API calls.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) error {
+		c.httpClient = client
+		return nil
+	}
+}
+
+// WithSigningKey sets the Stream signing key used to sign playback tokens. The key is the base64
+// encoded PEM returned by the Cloudflare API when the key is created.
+func WithSigningKey(keyID string, b64key string) Option {
+	return func(c *config) error {
+		if keyID == "" || b64key == "" {
+			return fmt.Errorf("missing cloudflare stream signing key ID or private key")
+		}
+
+		privateKeyBytes, err := base64.StdEncoding.DecodeString(b64key)
+		if err != nil {
+			return fmt.Errorf("failed to decode cloudflare stream signing private key: %w", err)
+		}
+
+		c.signingKeyID = keyID
+		c.signingKeyPrivateKey = privateKeyBytes
+		return nil
+	}
+}
+
+// WithWebhookSecret sets the webhook signing secret and the maximum accepted webhook age.
+// Signatures are not verified when the secret is empty.
+func WithWebhookSecret(secret string, tolerance time.Duration) Option {
+	return func(c *config) error {
+		c.webhookSecret = secret
+		c.webhookTolerance = tolerance
+		return nil
+	}
+}
+
+// WithObserver sets the observer notified about every API call, e.g. to record latency metrics.
+func WithObserver(observer CallObserver) Option {
+	return func(c *config) error {
+		c.observer = observer
+		return nil
+	}
+}
+
+// WithResilience enables the retries of idempotent calls and the circuit breaker of all API calls.
+// The hooks are notified about retries and breaker state changes.
+func WithResilience(cfg resilience.Config, hooks resilience.Hooks) Option {
+	return func(c *config) error {
+		c.resilience = &cfg
+		c.resilienceHooks = hooks
+		return nil
+	}
+}
+
+// WithCallTimeout bounds every API call by timeout and leaves reserve of the caller's deadline to the
+// caller, so it can clean up when the call times out.
+func WithCallTimeout(timeout, reserve time.Duration) Option {
+	return func(c *config) error {
+		c.callTimeout = timeout
+		c.callReserve = reserve
+		return nil
+	}
+}
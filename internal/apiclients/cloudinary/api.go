@@ -19,28 +19,52 @@ package cloudinary
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/cloudinary/cloudinary-go/v2"
 	"github.com/cloudinary/cloudinary-go/v2/api"
 	"github.com/cloudinary/cloudinary-go/v2/api/admin"
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/mikhail5545/media-service-go/internal/apiclients/resilience"
+	"github.com/mikhail5545/media-service-go/internal/deadline"
+	"github.com/mikhail5545/media-service-go/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type APIClient interface {
 	SignUploadParams(ctx context.Context, params url.Values) (string, error)
 	VerifyNotificationSignature(ctx context.Context, params *VerificationParams) bool
-	GetApiKey() string
+	GetApiKey(ctx context.Context) string
+	AddTags(ctx context.Context, publicID, resourceType string, tags []string) error
+	RemoveTags(ctx context.Context, publicID, resourceType string, tags []string) error
+	UpdateModeration(ctx context.Context, publicID, resourceType, status string) error
+	DeleteAsset(ctx context.Context, publicID string, resourceType string) error
+	ListAssets(ctx context.Context, resourceType string, maxResults int, nextCursor string) (*admin.AssetsResult, error)
+	Upload(ctx context.Context, file io.Reader, params *UploadParams) (*uploader.UploadResult, error)
+	Enrich(ctx context.Context, publicID, resourceType string, params *EnrichParams) (*EnrichResult, error)
+	ListBackupVersions(ctx context.Context, publicID, resourceType string) ([]BackupVersion, error)
+	RestoreAsset(ctx context.Context, publicID, resourceType, versionID string) (*api.BriefAssetResult, error)
+	GetUsage(ctx context.Context, date time.Time) (*admin.UsageResult, error)
 }
 
 type Client struct {
 	client *cloudinary.Cloudinary
+	cfg    config
+	// exec retries the idempotent calls and short-circuits all calls while Cloudinary is down.
+	exec *resilience.Executor
 }
 
 var _ APIClient = (*Client)(nil)
 
-func New(cloudName, apiKey, apiSecret string) (*Client, error) {
+func New(cloudName, apiKey, apiSecret string, opt ...Option) (*Client, error) {
 	if cloudName == "" {
 		return nil, fmt.Errorf("cloud name is required")
 	}
@@ -53,11 +77,55 @@ func New(cloudName, apiKey, apiSecret string) (*Client, error) {
 		return nil, fmt.Errorf("failed to create Cloudinary client: %w", err)
 	}
 
+	// Outgoing requests carry the trace context and produce HTTP client spans.
+	cld.Admin.Client.Transport = otelhttp.NewTransport(http.DefaultTransport)
+	cld.Upload.Client.Transport = otelhttp.NewTransport(http.DefaultTransport)
+
+	cfg := config{}
+	for _, o := range opt {
+		o(&cfg)
+	}
+
+	var exec *resilience.Executor
+	if cfg.resilience != nil {
+		exec = resilience.New(*cfg.resilience, transient, cfg.resilienceHooks)
+	}
+
 	return &Client{
 		client: cld,
+		cfg:    cfg,
+		exec:   exec,
 	}, nil
 }
 
+// transient reports whether the Cloudinary API error is worth retrying. The SDK does not expose
+// the response status, but error pages of an unavailable API are not JSON and fail to decode.
+func transient(err error) bool {
+	var syntaxErr *json.SyntaxError
+	return errors.As(err, &syntaxErr)
+}
+
+// track starts a client span for the API call and bounds it by the call timeout. The returned
+// function ends the span and notifies the configured observer, it must be called with a pointer to
+// the call error.
+func (c *Client) track(ctx context.Context, operation string) (context.Context, func(err *error)) {
+	return c.trackWithin(ctx, operation, c.cfg.callTimeout)
+}
+
+// trackWithin is [Client.track] with another timeout, 0 only keeps the reserve of the caller's deadline.
+func (c *Client) trackWithin(ctx context.Context, operation string, timeout time.Duration) (context.Context, func(err *error)) {
+	start := time.Now()
+	ctx, cancel := deadline.Sub(ctx, timeout, c.cfg.callReserve)
+	ctx, span := tracing.Start(ctx, "cloudinary."+operation, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, func(err *error) {
+		cancel()
+		tracing.End(span, *err)
+		if c.cfg.observer != nil {
+			c.cfg.observer(operation, time.Since(start), *err)
+		}
+	}
+}
+
 func (c *Client) SignUploadParams(ctx context.Context, params url.Values) (string, error) {
 	signature, err := api.SignParameters(params, c.client.Config.Cloud.APISecret)
 	if err != nil {
@@ -78,7 +146,10 @@ func (c *Client) VerifyNotificationSignature(ctx context.Context, params *Verifi
 	return c.client.Upload.VerifyNotificationSignature(params.Payload, params.Timestamp, params.ReceivedSignature, params.ValidFor)
 }
 
-func (c *Client) DeleteAsset(ctx context.Context, publicID string, resourceType string) error {
+func (c *Client) DeleteAsset(ctx context.Context, publicID string, resourceType string) (err error) {
+	ctx, done := c.track(ctx, "delete_asset")
+	defer done(&err)
+
 	if publicID == "" {
 		return fmt.Errorf("publicID is required")
 	}
@@ -86,9 +157,12 @@ func (c *Client) DeleteAsset(ctx context.Context, publicID string, resourceType
 		return fmt.Errorf("resourceType is required")
 	}
 
-	_, err := c.client.Upload.Destroy(ctx, uploader.DestroyParams{
-		PublicID:     publicID,
-		ResourceType: resourceType,
+	err = c.exec.Do(ctx, "delete_asset", true, func(ctx context.Context) error {
+		_, err := c.client.Upload.Destroy(ctx, uploader.DestroyParams{
+			PublicID:     publicID,
+			ResourceType: resourceType,
+		})
+		return err
 	})
 
 	if err != nil {
@@ -98,7 +172,10 @@ func (c *Client) DeleteAsset(ctx context.Context, publicID string, resourceType
 	return nil
 }
 
-func (c *Client) DeleteAssets(ctx context.Context, assetType string, publicIDs []string) error {
+func (c *Client) DeleteAssets(ctx context.Context, assetType string, publicIDs []string) (err error) {
+	ctx, done := c.track(ctx, "delete_assets")
+	defer done(&err)
+
 	ids := api.CldAPIArray{}
 	ids = append(ids, publicIDs...)
 
@@ -109,9 +186,12 @@ func (c *Client) DeleteAssets(ctx context.Context, assetType string, publicIDs [
 		return fmt.Errorf("public ids length cannot be greater that 100")
 	}
 
-	_, err := c.client.Admin.DeleteAssets(ctx, admin.DeleteAssetsParams{
-		AssetType: api.AssetType(assetType),
-		PublicIDs: ids,
+	err = c.exec.Do(ctx, "delete_assets", true, func(ctx context.Context) error {
+		_, err := c.client.Admin.DeleteAssets(ctx, admin.DeleteAssetsParams{
+			AssetType: api.AssetType(assetType),
+			PublicIDs: ids,
+		})
+		return err
 	})
 	if err != nil {
 		return fmt.Errorf("failed to delete assets %w", err)
@@ -119,31 +199,52 @@ func (c *Client) DeleteAssets(ctx context.Context, assetType string, publicIDs [
 	return nil
 }
 
-func (c *Client) CreateFolder(ctx context.Context, folder string) (bool, error) {
+func (c *Client) CreateFolder(ctx context.Context, folder string) (_ bool, err error) {
+	ctx, done := c.track(ctx, "create_folder")
+	defer done(&err)
+
 	if folder == "" {
 		return false, fmt.Errorf("folder is required")
 	}
-	res, err := c.client.Admin.CreateFolder(ctx, admin.CreateFolderParams{Folder: folder})
+	var res *admin.CreateFolderResult
+	err = c.exec.Do(ctx, "create_folder", true, func(ctx context.Context) (err error) {
+		res, err = c.client.Admin.CreateFolder(ctx, admin.CreateFolderParams{Folder: folder})
+		return err
+	})
 	if err != nil {
 		return false, fmt.Errorf("failed to create folder: %w", err)
 	}
 	return res.Success, nil
 }
 
-func (c *Client) GetRootFolders(ctx context.Context, maxResults int) (*admin.FoldersResult, error) {
-	res, err := c.client.Admin.RootFolders(ctx, admin.RootFoldersParams{MaxResults: maxResults})
+func (c *Client) GetRootFolders(ctx context.Context, maxResults int) (_ *admin.FoldersResult, err error) {
+	ctx, done := c.track(ctx, "get_root_folders")
+	defer done(&err)
+
+	var res *admin.FoldersResult
+	err = c.exec.Do(ctx, "get_root_folders", true, func(ctx context.Context) (err error) {
+		res, err = c.client.Admin.RootFolders(ctx, admin.RootFoldersParams{MaxResults: maxResults})
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve root folders: %w", err)
 	}
 	return res, nil
 }
 
-func (c *Client) ListAssetsByFolder(ctx context.Context, folder string) ([]api.BriefAssetResult, error) {
+func (c *Client) ListAssetsByFolder(ctx context.Context, folder string) (_ []api.BriefAssetResult, err error) {
+	ctx, done := c.track(ctx, "list_assets_by_folder")
+	defer done(&err)
+
 	if folder == "" {
 		return nil, fmt.Errorf("folder is required")
 	}
-	res, err := c.client.Admin.AssetsByAssetFolder(ctx, admin.AssetsByAssetFolderParams{
-		AssetFolder: folder,
+	var res *admin.AssetsResult
+	err = c.exec.Do(ctx, "list_assets_by_folder", true, func(ctx context.Context) (err error) {
+		res, err = c.client.Admin.AssetsByAssetFolder(ctx, admin.AssetsByAssetFolderParams{
+			AssetFolder: folder,
+		})
+		return err
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve assets by folder: %w", err)
@@ -151,6 +252,160 @@ func (c *Client) ListAssetsByFolder(ctx context.Context, folder string) ([]api.B
 	return res.Assets, nil
 }
 
-func (c *Client) GetApiKey() string {
+// ListAssets retrieves a page of the uploaded assets of the resource type with their tags and
+// context. The next cursor of the result is empty on the last page.
+func (c *Client) ListAssets(ctx context.Context, resourceType string, maxResults int, nextCursor string) (_ *admin.AssetsResult, err error) {
+	ctx, done := c.track(ctx, "list_assets")
+	defer done(&err)
+
+	withDetails := true
+	var res *admin.AssetsResult
+	err = c.exec.Do(ctx, "list_assets", true, func(ctx context.Context) (err error) {
+		res, err = c.client.Admin.Assets(ctx, admin.AssetsParams{
+			AssetType:    api.AssetType(resourceType),
+			DeliveryType: "upload",
+			MaxResults:   maxResults,
+			NextCursor:   nextCursor,
+			Tags:         &withDetails,
+			Context:      &withDetails,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assets: %w", err)
+	}
+	if res.Error.Message != "" {
+		return nil, fmt.Errorf("failed to list assets: %s", res.Error.Message)
+	}
+	return res, nil
+}
+
+// AddTags adds the tags to the asset. Cloudinary accepts several comma separated tags in a single call.
+func (c *Client) AddTags(ctx context.Context, publicID, resourceType string, tags []string) (err error) {
+	ctx, done := c.track(ctx, "add_tags")
+	defer done(&err)
+
+	var res *uploader.AddTagResult
+	err = c.exec.Do(ctx, "add_tags", true, func(ctx context.Context) (err error) {
+		res, err = c.client.Upload.AddTag(ctx, uploader.AddTagParams{
+			Tag:          strings.Join(tags, ","),
+			PublicIDs:    []string{publicID},
+			ResourceType: resourceType,
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add tags: %w", err)
+	}
+	if res.Error.Message != "" {
+		return fmt.Errorf("failed to add tags: %s", res.Error.Message)
+	}
+	return nil
+}
+
+// RemoveTags removes the tags from the asset.
+func (c *Client) RemoveTags(ctx context.Context, publicID, resourceType string, tags []string) (err error) {
+	ctx, done := c.track(ctx, "remove_tags")
+	defer done(&err)
+
+	var res *uploader.RemoveTagResult
+	err = c.exec.Do(ctx, "remove_tags", true, func(ctx context.Context) (err error) {
+		res, err = c.client.Upload.RemoveTag(ctx, uploader.RemoveTagParams{
+			Tag:          strings.Join(tags, ","),
+			PublicIDs:    []string{publicID},
+			ResourceType: resourceType,
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove tags: %w", err)
+	}
+	if res.Error.Message != "" {
+		return fmt.Errorf("failed to remove tags: %s", res.Error.Message)
+	}
+	return nil
+}
+
+// UpdateModeration sets the manual moderation status ("approved" or "rejected") of the asset.
+func (c *Client) UpdateModeration(ctx context.Context, publicID, resourceType, status string) (err error) {
+	ctx, done := c.track(ctx, "update_moderation")
+	defer done(&err)
+
+	var res *admin.AssetResult
+	err = c.exec.Do(ctx, "update_moderation", true, func(ctx context.Context) (err error) {
+		res, err = c.client.Admin.UpdateAsset(ctx, admin.UpdateAssetParams{
+			AssetType:        api.AssetType(resourceType),
+			PublicID:         publicID,
+			ModerationStatus: api.ModerationStatus(status),
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update moderation status: %w", err)
+	}
+	if res.Error.Message != "" {
+		return fmt.Errorf("failed to update moderation status: %s", res.Error.Message)
+	}
+	return nil
+}
+
+// UploadParams holds the parameters of a server-side upload.
+type UploadParams struct {
+	PublicID string
+	// Transformation is the incoming transformation applied before the asset is stored.
+	Transformation string
+	// Eager lists the derived transformations generated on upload, separated by "|".
+	Eager string
+	// Moderation is the moderation add-on requested for the asset.
+	Moderation string
+}
+
+// Upload streams the file to Cloudinary as a signed upload. Existing assets with the same public ID
+// are not overwritten. The perceptual hash of the image is always requested.
+func (c *Client) Upload(ctx context.Context, file io.Reader, params *UploadParams) (_ *uploader.UploadResult, err error) {
+	// The file is streamed from the request, so the upload is only bounded by the request deadline
+	ctx, done := c.trackWithin(ctx, "upload", 0)
+	defer done(&err)
+
+	overwrite, phash := false, true
+	// The file is streamed, so it cannot be sent again and the upload is attempted once.
+	var res *uploader.UploadResult
+	err = c.exec.Do(ctx, "upload", false, func(ctx context.Context) (err error) {
+		res, err = c.client.Upload.Upload(ctx, file, uploader.UploadParams{
+			PublicID:       params.PublicID,
+			Transformation: params.Transformation,
+			Eager:          params.Eager,
+			Moderation:     params.Moderation,
+			Overwrite:      &overwrite,
+			Phash:          &phash,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload asset: %w", err)
+	}
+	if res.Error.Message != "" {
+		return nil, fmt.Errorf("failed to upload asset: %s", res.Error.Message)
+	}
+	return res, nil
+}
+
+// Ping checks that the Cloudinary Admin API is reachable and the credentials are accepted.
+// It bypasses the retries and the circuit breaker, so health checks observe the current API state.
+func (c *Client) Ping(ctx context.Context) (err error) {
+	ctx, done := c.track(ctx, "ping")
+	defer done(&err)
+
+	res, err := c.client.Admin.Ping(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reach cloudinary api: %w", err)
+	}
+	if res.Error.Message != "" {
+		return fmt.Errorf("cloudinary api ping failed: %s", res.Error.Message)
+	}
+	return nil
+}
+
+func (c *Client) GetApiKey(context.Context) string {
 	return c.client.Config.Cloud.APIKey
 }
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"time"
+
+	"github.com/mikhail5545/media-service-go/internal/apiclients/resilience"
+)
+
+// CallObserver is notified after every Cloudinary API call with the operation name, its duration and error.
+type CallObserver func(operation string, duration time.Duration, err error)
+
+type config struct {
+	observer        CallObserver
+	resilience      *resilience.Config
+	resilienceHooks resilience.Hooks
+	callTimeout     time.Duration
+	callReserve     time.Duration
+}
+
+type Option func(*config)
+
+// WithObserver sets the observer notified about every Cloudinary API call, e.g. to record latency metrics.
+func WithObserver(observer CallObserver) Option {
+	return func(c *config) {
+		c.observer = observer
+	}
+}
+
+// WithResilience enables the retries of idempotent calls and the circuit breaker of all API calls.
+// The hooks are notified about retries and breaker state changes.
+func WithResilience(cfg resilience.Config, hooks resilience.Hooks) Option {
+	return func(c *config) {
+		c.resilience = &cfg
+		c.resilienceHooks = hooks
+	}
+}
+
+// WithCallTimeout bounds every API call by timeout and leaves reserve of the caller's deadline to the
+// caller, so it can clean up when the call times out.
+func WithCallTimeout(timeout, reserve time.Duration) Option {
+	return func(c *config) {
+		c.callTimeout = timeout
+		c.callReserve = reserve
+	}
+}
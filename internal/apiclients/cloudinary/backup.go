@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package cloudinary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+)
+
+// BackupVersion is a version of an asset kept by the Cloudinary backup.
+type BackupVersion struct {
+	VersionID  string    `json:"version_id"`
+	Version    int64     `json:"version"`
+	Format     string    `json:"format"`
+	Bytes      int64     `json:"size"`
+	CreatedAt  time.Time `json:"time"`
+	Restorable bool      `json:"restorable"`
+}
+
+// ListBackupVersions lists the backed up versions of the asset, newest first. Assets destroyed with a
+// backup are still reported by the Admin API as placeholders, their versions can be restored by
+// [Client.RestoreAsset]. Assets without a backup have no versions.
+func (c *Client) ListBackupVersions(ctx context.Context, publicID, resourceType string) (_ []BackupVersion, err error) {
+	ctx, done := c.track(ctx, "list_backup_versions")
+	defer done(&err)
+
+	versions := true
+	var res *admin.AssetResult
+	err = c.exec.Do(ctx, "list_backup_versions", true, func(ctx context.Context) (err error) {
+		res, err = c.client.Admin.Asset(ctx, admin.AssetParams{
+			AssetType: api.AssetType(resourceType),
+			PublicID:  publicID,
+			Versions:  &versions,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backup versions: %w", err)
+	}
+	if res.Error.Message != "" {
+		return nil, fmt.Errorf("failed to list backup versions: %s", res.Error.Message)
+	}
+	return parseBackupVersions(res.Response)
+}
+
+// parseBackupVersions decodes the versions of the raw asset response, the SDK result does not
+// expose them.
+func parseBackupVersions(raw any) ([]BackupVersion, error) {
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode asset response: %w", err)
+	}
+	var res struct {
+		Versions []BackupVersion `json:"versions"`
+	}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, fmt.Errorf("failed to decode backup versions: %w", err)
+	}
+	return res.Versions, nil
+}
+
+// RestoreAsset restores the asset from its backup. The latest backed up version is restored if
+// versionID is empty.
+func (c *Client) RestoreAsset(ctx context.Context, publicID, resourceType, versionID string) (_ *api.BriefAssetResult, err error) {
+	ctx, done := c.track(ctx, "restore_asset")
+	defer done(&err)
+
+	params := admin.RestoreAssetsParams{
+		AssetType:    api.AssetType(resourceType),
+		DeliveryType: "upload",
+		PublicIDs:    api.CldAPIArray{publicID},
+	}
+	if versionID != "" {
+		params.Versions = api.CldAPIArray{versionID}
+	}
+	var res *admin.RestoreAssetsResult
+	// Restoring a restored version only creates the same version again, so the call is retried.
+	err = c.exec.Do(ctx, "restore_asset", true, func(ctx context.Context) (err error) {
+		res, err = c.client.Admin.RestoreAssets(ctx, params)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore asset: %w", err)
+	}
+	restored, ok := (*res)[publicID]
+	if !ok {
+		return nil, fmt.Errorf("failed to restore asset: asset %s is missing from the response", publicID)
+	}
+	if restored.Error != "" {
+		return nil, fmt.Errorf("failed to restore asset: %s", restored.Error)
+	}
+	return &restored, nil
+}
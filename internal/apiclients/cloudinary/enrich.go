@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+)
+
+// maxDominantColors limits the number of dominant colors returned by [Client.Enrich].
+const maxDominantColors = 5
+
+// EnrichParams selects the Cloudinary add-ons used to analyze the asset.
+type EnrichParams struct {
+	// Categorization is the tagging add-on, e.g. "google_tagging" or "aws_rek_tagging". Labels are not
+	// requested if it is empty.
+	Categorization string
+	// MinConfidence is the minimum confidence of the returned labels.
+	MinConfidence float64
+	// OCR requests text extraction by the advanced OCR add-on.
+	OCR bool
+}
+
+// EnrichResult holds the data derived from the asset content.
+type EnrichResult struct {
+	Labels         []string
+	DominantColors []string
+	OCRText        string
+}
+
+// ErrAnalysisPending is returned by [Client.Enrich] when an add-on has not completed the analysis yet.
+var ErrAnalysisPending = errors.New("asset analysis is pending")
+
+// Enrich runs the configured add-ons on the asset and returns the derived labels, dominant colors
+// and OCR text.
+func (c *Client) Enrich(ctx context.Context, publicID, resourceType string, params *EnrichParams) (_ *EnrichResult, err error) {
+	ctx, done := c.track(ctx, "enrich")
+	defer done(&err)
+
+	result := &EnrichResult{}
+	if params.Categorization != "" || params.OCR {
+		update := admin.UpdateAssetParams{
+			AssetType:      api.AssetType(resourceType),
+			PublicID:       publicID,
+			Categorization: params.Categorization,
+		}
+		if params.OCR {
+			update.OCR = "adv_ocr"
+		}
+		// Add-on analyses are billed per request, so the update is not retried.
+		var res *admin.AssetResult
+		err := c.exec.Do(ctx, "analyze_asset", false, func(ctx context.Context) (err error) {
+			res, err = c.client.Admin.UpdateAsset(ctx, update)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze asset: %w", err)
+		}
+		if res.Error.Message != "" {
+			return nil, fmt.Errorf("failed to analyze asset: %s", res.Error.Message)
+		}
+		if err := parseAnalysisInfo(res.Info, params, result); err != nil {
+			return nil, err
+		}
+	}
+
+	colors := true
+	var res *admin.AssetResult
+	err = c.exec.Do(ctx, "get_asset", true, func(ctx context.Context) (err error) {
+		res, err = c.client.Admin.Asset(ctx, admin.AssetParams{
+			AssetType: api.AssetType(resourceType),
+			PublicID:  publicID,
+			Colors:    &colors,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve asset colors: %w", err)
+	}
+	if res.Error.Message != "" {
+		return nil, fmt.Errorf("failed to retrieve asset colors: %s", res.Error.Message)
+	}
+	for _, color := range res.Colors {
+		if len(result.DominantColors) == maxDominantColors {
+			break
+		}
+		if len(color) > 0 {
+			if hex, ok := color[0].(string); ok {
+				result.DominantColors = append(result.DominantColors, hex)
+			}
+		}
+	}
+	return result, nil
+}
+
+// analysisInfo is the part of the asset info object filled by the tagging and OCR add-ons.
+type analysisInfo struct {
+	Categorization map[string]struct {
+		Status string `json:"status"`
+		Data   []struct {
+			Tag        string  `json:"tag"`
+			Confidence float64 `json:"confidence"`
+		} `json:"data"`
+	} `json:"categorization"`
+	OCR map[string]struct {
+		Status string `json:"status"`
+		Data   []struct {
+			FullTextAnnotation struct {
+				Text string `json:"text"`
+			} `json:"fullTextAnnotation"`
+		} `json:"data"`
+	} `json:"ocr"`
+}
+
+func parseAnalysisInfo(raw any, params *EnrichParams, result *EnrichResult) error {
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("failed to encode asset info: %w", err)
+	}
+	var info analysisInfo
+	if err := json.Unmarshal(b, &info); err != nil {
+		return fmt.Errorf("failed to decode asset info: %w", err)
+	}
+
+	if params.Categorization != "" {
+		categorization := info.Categorization[params.Categorization]
+		if categorization.Status == "pending" {
+			return ErrAnalysisPending
+		}
+		for _, label := range categorization.Data {
+			if label.Confidence >= params.MinConfidence {
+				result.Labels = append(result.Labels, label.Tag)
+			}
+		}
+	}
+	if params.OCR {
+		ocr := info.OCR["adv_ocr"]
+		if ocr.Status == "pending" {
+			return ErrAnalysisPending
+		}
+		for _, data := range ocr.Data {
+			result.OCRText += data.FullTextAnnotation.Text
+		}
+	}
+	return nil
+}
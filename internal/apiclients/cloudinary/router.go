@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/mikhail5545/media-service-go/internal/environment"
+)
+
+// Router is an [APIClient] which dispatches every call to the client of the environment stored in
+// the call context. Calls without an environment, e.g. by the background workers, use the client
+// of the [environment.Default] environment.
+type Router struct {
+	clients map[string]*Client
+}
+
+var _ APIClient = (*Router)(nil)
+
+// NewRouter returns a router of the clients keyed by the environment name.
+func NewRouter(clients map[string]*Client) (*Router, error) {
+	if clients[environment.Default] == nil {
+		return nil, fmt.Errorf("client of the %q environment is required", environment.Default)
+	}
+	return &Router{clients: clients}, nil
+}
+
+// Environments returns the names of the routed environments.
+func (r *Router) Environments() []string {
+	return slices.Sorted(maps.Keys(r.clients))
+}
+
+func (r *Router) client(ctx context.Context) *Client {
+	if c, ok := r.clients[environment.Name(ctx)]; ok {
+		return c
+	}
+	return r.clients[environment.Default]
+}
+
+// Ping checks the API of every environment.
+func (r *Router) Ping(ctx context.Context) error {
+	var errs []error
+	for _, name := range r.Environments() {
+		if err := r.clients[name].Ping(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("environment %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Router) SignUploadParams(ctx context.Context, params url.Values) (string, error) {
+	return r.client(ctx).SignUploadParams(ctx, params)
+}
+
+// VerifyNotificationSignature verifies the notification against the secret of the context environment.
+// The notifications do not name the product environment they were sent from, the caller tries each
+// environment to find the one the notification belongs to.
+func (r *Router) VerifyNotificationSignature(ctx context.Context, params *VerificationParams) bool {
+	return r.client(ctx).VerifyNotificationSignature(ctx, params)
+}
+
+func (r *Router) GetApiKey(ctx context.Context) string {
+	return r.client(ctx).GetApiKey(ctx)
+}
+
+func (r *Router) AddTags(ctx context.Context, publicID, resourceType string, tags []string) error {
+	return r.client(ctx).AddTags(ctx, publicID, resourceType, tags)
+}
+
+func (r *Router) RemoveTags(ctx context.Context, publicID, resourceType string, tags []string) error {
+	return r.client(ctx).RemoveTags(ctx, publicID, resourceType, tags)
+}
+
+func (r *Router) UpdateModeration(ctx context.Context, publicID, resourceType, status string) error {
+	return r.client(ctx).UpdateModeration(ctx, publicID, resourceType, status)
+}
+
+func (r *Router) DeleteAsset(ctx context.Context, publicID string, resourceType string) error {
+	return r.client(ctx).DeleteAsset(ctx, publicID, resourceType)
+}
+
+func (r *Router) ListAssets(ctx context.Context, resourceType string, maxResults int, nextCursor string) (*admin.AssetsResult, error) {
+	return r.client(ctx).ListAssets(ctx, resourceType, maxResults, nextCursor)
+}
+
+func (r *Router) Upload(ctx context.Context, file io.Reader, params *UploadParams) (*uploader.UploadResult, error) {
+	return r.client(ctx).Upload(ctx, file, params)
+}
+
+func (r *Router) Enrich(ctx context.Context, publicID, resourceType string, params *EnrichParams) (*EnrichResult, error) {
+	return r.client(ctx).Enrich(ctx, publicID, resourceType, params)
+}
+
+func (r *Router) ListBackupVersions(ctx context.Context, publicID, resourceType string) ([]BackupVersion, error) {
+	return r.client(ctx).ListBackupVersions(ctx, publicID, resourceType)
+}
+
+func (r *Router) RestoreAsset(ctx context.Context, publicID, resourceType, versionID string) (*api.BriefAssetResult, error) {
+	return r.client(ctx).RestoreAsset(ctx, publicID, resourceType, versionID)
+}
+
+func (r *Router) GetUsage(ctx context.Context, date time.Time) (*admin.UsageResult, error) {
+	return r.client(ctx).GetUsage(ctx, date)
+}
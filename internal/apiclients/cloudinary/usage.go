@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package cloudinary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+)
+
+// GetUsage retrieves the usage report of the product environment for the date, which must be
+// within the last three months. The current usage is reported if date is zero.
+func (c *Client) GetUsage(ctx context.Context, date time.Time) (_ *admin.UsageResult, err error) {
+	ctx, done := c.track(ctx, "get_usage")
+	defer done(&err)
+
+	var res *admin.UsageResult
+	err = c.exec.Do(ctx, "get_usage", true, func(ctx context.Context) (err error) {
+		res, err = c.client.Admin.Usage(ctx, admin.UsageParams{Date: date})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+	if res.Error.Message != "" {
+		return nil, fmt.Errorf("failed to get usage: %s", res.Error.Message)
+	}
+	return res, nil
+}
@@ -18,22 +18,54 @@
 package mux
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/apiclients/resilience"
+	"github.com/mikhail5545/media-service-go/internal/deadline"
+	"github.com/mikhail5545/media-service-go/internal/tracing"
 	mux "github.com/muxinc/mux-go/v6"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type APIClient interface {
-	CreateDirectUploadURL(ctx context.Context, meta *mux.AssetMetadata, policies ...mux.PlaybackPolicy) (*mux.UploadResponse, error)
+	CreateDirectUploadURL(ctx context.Context, meta *mux.AssetMetadata, drmConfigurationID string, overlays []mux.InputSettings, encoding EncodingSettings, policies ...mux.PlaybackPolicy) (*mux.UploadResponse, error)
 	DeleteAsset(ctx context.Context, assetID string) error
+	UpdateAsset(ctx context.Context, assetID string, update *mux.UpdateAssetRequest) error
+	CreatePlaybackID(ctx context.Context, assetID string, policy mux.PlaybackPolicy) (*mux.PlaybackId, error)
+	DeletePlaybackID(ctx context.Context, assetID, playbackID string) error
+	GetAsset(ctx context.Context, assetID string) (*mux.Asset, error)
+	GetDirectUpload(ctx context.Context, uploadID string) (*mux.Upload, error)
+	CancelDirectUpload(ctx context.Context, uploadID string) error
+	ListAssets(ctx context.Context, limit, page int32) ([]mux.Asset, error)
+	ListAssetViews(ctx context.Context, from, to time.Time, limit, page int32) ([]mux.BreakdownValue, error)
+	GetTranscript(ctx context.Context, playbackID, trackID string) (string, error)
+	CreatePlaybackRestriction(ctx context.Context, referrer mux.ReferrerDomainRestriction) (*mux.PlaybackRestriction, error)
+	UpdatePlaybackRestrictionReferrer(ctx context.Context, restrictionID string, referrer mux.ReferrerDomainRestriction) error
+	DeletePlaybackRestriction(ctx context.Context, restrictionID string) error
+	CreateSigningKey(ctx context.Context) (*mux.SigningKey, error)
+	DeleteSigningKey(ctx context.Context, keyID string) error
+	GeneratePlaybackJWTToken(ctx context.Context, opts GeneratePlaybackTokenOptions) (string, error)
+	GenerateDRMLicenseJWTToken(ctx context.Context, opts GeneratePlaybackTokenOptions) (string, error)
+	GenerateThumbnailJWTToken(ctx context.Context, opts GeneratePlaybackTokenOptions) (string, error)
+	VerifiesWebhooks() bool
+	VerifyWebhookSignature(payload []byte, header string) error
 }
 
 type Client struct {
 	client *mux.APIClient
 	cfg    config
+	// exec retries the idempotent calls and short-circuits all calls while MUX is down.
+	exec *resilience.Executor
 }
 
 var _ APIClient = (*Client)(nil)
@@ -54,20 +86,84 @@ func New(apiKey, secretKey string, opt ...Option) (*Client, error) {
 		}
 	}
 
+	var exec *resilience.Executor
+	if cfg.resilience != nil {
+		exec = resilience.New(*cfg.resilience, transient, cfg.resilienceHooks)
+	}
+
 	return &Client{
 		client: client,
 		cfg:    *cfg,
+		exec:   exec,
 	}, nil
 }
 
-func (c *Client) CreateDirectUploadURL(ctx context.Context, meta *mux.AssetMetadata, policies ...mux.PlaybackPolicy) (*mux.UploadResponse, error) {
+// transient reports whether the MUX API error is worth retrying: a server error or a rate limit.
+func transient(err error) bool {
+	var serviceErr mux.ServiceError
+	var rateErr mux.TooManyRequestsError
+	return errors.As(err, &serviceErr) || errors.As(err, &rateErr)
+}
+
+// track starts a client span for the API call and bounds it by the call timeout. The returned
+// function ends the span and notifies the configured observer, it must be called with a pointer to
+// the call error.
+func (c *Client) track(ctx context.Context, operation string) (context.Context, func(err *error)) {
+	start := time.Now()
+	ctx, cancel := deadline.Sub(ctx, c.cfg.callTimeout, c.cfg.callReserve)
+	ctx, span := tracing.Start(ctx, "mux."+operation, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, func(err *error) {
+		cancel()
+		tracing.End(span, *err)
+		if c.cfg.observer != nil {
+			c.cfg.observer(operation, time.Since(start), *err)
+		}
+	}
+}
+
+// EncodingSettings control how the asset of a MUX Direct Upload is encoded. Empty fields are left to the
+// defaults of MUX.
+type EncodingSettings struct {
+	MaxResolutionTier string
+	VideoQuality      string
+	MP4Support        string
+	NormalizeAudio    bool
+}
+
+// CreateDirectUploadURL creates a MUX Direct Upload whose asset gets a playback ID for each of the policies.
+// If drmConfigurationID is not empty, the asset only gets a DRM playback ID protected with that configuration,
+// so it cannot be played without a license, and no policies may be requested.
+// The overlays, e.g. a watermark, are burned into the uploaded video.
+func (c *Client) CreateDirectUploadURL(ctx context.Context, meta *mux.AssetMetadata, drmConfigurationID string, overlays []mux.InputSettings, encoding EncodingSettings, policies ...mux.PlaybackPolicy) (_ *mux.UploadResponse, err error) {
+	ctx, done := c.track(ctx, "create_direct_upload")
+	defer done(&err)
+
 	assetReq := mux.CreateAssetRequest{
-		PlaybackPolicy: policies,
-		VideoQuality:   "basic",
+		MaxResolutionTier: encoding.MaxResolutionTier,
+		VideoQuality:      encoding.VideoQuality,
+		Mp4Support:        encoding.MP4Support,
+		NormalizeAudio:    encoding.NormalizeAudio,
+	}
+	if drmConfigurationID == "" {
+		assetReq.PlaybackPolicy = policies
+	} else {
+		// A public or signed playback ID would let the asset be played without a license.
+		if len(policies) > 0 {
+			return nil, fmt.Errorf("playback policies cannot be combined with a DRM configuration")
+		}
+		// MUX only accepts DRM playback IDs through the advanced playback policies.
+		assetReq.AdvancedPlaybackPolicies = []mux.CreatePlaybackIdRequest{{
+			Policy:             mux.DRM,
+			DrmConfigurationId: drmConfigurationID,
+		}}
 	}
 	if meta != nil {
 		assetReq.Meta = *meta
 	}
+	if len(overlays) > 0 {
+		// The first input stands for the uploaded file, so it has no URL.
+		assetReq.Input = append([]mux.InputSettings{{}}, overlays...)
+	}
 
 	if c.cfg.corsOrigin == "" {
 		c.cfg.corsOrigin = "*"
@@ -78,26 +174,324 @@ func (c *Client) CreateDirectUploadURL(ctx context.Context, meta *mux.AssetMetad
 		Test:             c.cfg.test,
 	}
 
-	resp, err := c.client.DirectUploadsApi.CreateDirectUpload(uploadRequest, mux.WithContext(ctx))
+	// A retried upload creation could leave an orphaned direct upload behind, so it is attempted once.
+	var resp mux.UploadResponse
+	err = c.exec.Do(ctx, "create_direct_upload", false, func(ctx context.Context) (err error) {
+		resp, err = c.client.DirectUploadsApi.CreateDirectUpload(uploadRequest, mux.WithContext(ctx))
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create direct upload url: %w", err)
 	}
 	return &resp, nil
 }
 
-func (c *Client) DeleteAsset(ctx context.Context, assetID string) error {
-	if err := c.client.AssetsApi.DeleteAsset(assetID, mux.WithContext(ctx)); err != nil {
+func (c *Client) DeleteAsset(ctx context.Context, assetID string) (err error) {
+	ctx, done := c.track(ctx, "delete_asset")
+	defer done(&err)
+
+	err = c.exec.Do(ctx, "delete_asset", true, func(ctx context.Context) error {
+		return c.client.AssetsApi.DeleteAsset(assetID, mux.WithContext(ctx))
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete asset: %w", err)
 	}
 	return nil
 }
 
+// UpdateAsset updates the meta object and the passthrough of the MUX asset. The meta object is
+// always replaced, the passthrough only when it is not empty.
+func (c *Client) UpdateAsset(ctx context.Context, assetID string, update *mux.UpdateAssetRequest) (err error) {
+	ctx, done := c.track(ctx, "update_asset")
+	defer done(&err)
+
+	err = c.exec.Do(ctx, "update_asset", true, func(ctx context.Context) error {
+		_, err := c.client.AssetsApi.UpdateAsset(assetID, *update, mux.WithContext(ctx))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update asset: %w", err)
+	}
+	return nil
+}
+
+// CreatePlaybackID adds a playback ID with the policy to the MUX asset.
+func (c *Client) CreatePlaybackID(ctx context.Context, assetID string, policy mux.PlaybackPolicy) (_ *mux.PlaybackId, err error) {
+	ctx, done := c.track(ctx, "create_playback_id")
+	defer done(&err)
+
+	var resp mux.CreatePlaybackIdResponse
+	err = c.exec.Do(ctx, "create_playback_id", false, func(ctx context.Context) (err error) {
+		resp, err = c.client.AssetsApi.CreateAssetPlaybackId(assetID, mux.CreatePlaybackIdRequest{Policy: policy}, mux.WithContext(ctx))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create playback id: %w", err)
+	}
+	return &resp.Data, nil
+}
+
+// DeletePlaybackID removes the playback ID from the MUX asset. The playback ID stops working immediately.
+func (c *Client) DeletePlaybackID(ctx context.Context, assetID, playbackID string) (err error) {
+	ctx, done := c.track(ctx, "delete_playback_id")
+	defer done(&err)
+
+	err = c.exec.Do(ctx, "delete_playback_id", true, func(ctx context.Context) error {
+		return c.client.AssetsApi.DeleteAssetPlaybackId(assetID, playbackID, mux.WithContext(ctx))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete playback id: %w", err)
+	}
+	return nil
+}
+
+// GetAsset retrieves the MUX asset.
+func (c *Client) GetAsset(ctx context.Context, assetID string) (_ *mux.Asset, err error) {
+	ctx, done := c.track(ctx, "get_asset")
+	defer done(&err)
+
+	var resp mux.AssetResponse
+	err = c.exec.Do(ctx, "get_asset", true, func(ctx context.Context) (err error) {
+		resp, err = c.client.AssetsApi.GetAsset(assetID, mux.WithContext(ctx))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve asset: %w", err)
+	}
+	return &resp.Data, nil
+}
+
+// GetDirectUpload retrieves the MUX direct upload.
+func (c *Client) GetDirectUpload(ctx context.Context, uploadID string) (_ *mux.Upload, err error) {
+	ctx, done := c.track(ctx, "get_direct_upload")
+	defer done(&err)
+
+	var resp mux.UploadResponse
+	err = c.exec.Do(ctx, "get_direct_upload", true, func(ctx context.Context) (err error) {
+		resp, err = c.client.DirectUploadsApi.GetDirectUpload(uploadID, mux.WithContext(ctx))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve direct upload: %w", err)
+	}
+	return &resp.Data, nil
+}
+
+// CancelDirectUpload cancels the MUX direct upload, so no asset is created from it.
+func (c *Client) CancelDirectUpload(ctx context.Context, uploadID string) (err error) {
+	ctx, done := c.track(ctx, "cancel_direct_upload")
+	defer done(&err)
+
+	err = c.exec.Do(ctx, "cancel_direct_upload", true, func(ctx context.Context) error {
+		_, err := c.client.DirectUploadsApi.CancelDirectUpload(uploadID, mux.WithContext(ctx))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cancel direct upload: %w", err)
+	}
+	return nil
+}
+
+// ListAssets retrieves a page of the MUX assets, newest first. Pages are numbered from 1, a page
+// shorter than limit is the last one.
+func (c *Client) ListAssets(ctx context.Context, limit, page int32) (_ []mux.Asset, err error) {
+	ctx, done := c.track(ctx, "list_assets")
+	defer done(&err)
+
+	var resp mux.ListAssetsResponse
+	err = c.exec.Do(ctx, "list_assets", true, func(ctx context.Context) (err error) {
+		resp, err = c.client.AssetsApi.ListAssets(mux.WithParams(&mux.ListAssetsParams{Limit: limit, Page: page}), mux.WithContext(ctx))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assets: %w", err)
+	}
+	return resp.Data, nil
+}
+
+// ListAssetViews retrieves a page of the MUX Data views in the timeframe grouped by the MUX asset ID,
+// most viewed first. Pages are numbered from 1, a page shorter than limit is the last one.
+func (c *Client) ListAssetViews(ctx context.Context, from, to time.Time, limit, page int32) (_ []mux.BreakdownValue, err error) {
+	ctx, done := c.track(ctx, "list_asset_views")
+	defer done(&err)
+
+	params := &mux.ListBreakdownValuesParams{
+		GroupBy:   "asset_id",
+		Limit:     limit,
+		Page:      page,
+		Timeframe: []string{strconv.FormatInt(from.Unix(), 10), strconv.FormatInt(to.Unix(), 10)},
+	}
+	var resp mux.ListBreakdownValuesResponse
+	err = c.exec.Do(ctx, "list_asset_views", true, func(ctx context.Context) (err error) {
+		resp, err = c.client.MetricsApi.ListBreakdownValues("views", mux.WithParams(params), mux.WithContext(ctx))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list asset views: %w", err)
+	}
+	return resp.Data, nil
+}
+
+// CreatePlaybackRestriction creates a MUX playback restriction limiting the referrer domains of the
+// playback requests. Tokens carrying its ID in the playback_restriction_id claim are subject to it.
+func (c *Client) CreatePlaybackRestriction(ctx context.Context, referrer mux.ReferrerDomainRestriction) (_ *mux.PlaybackRestriction, err error) {
+	ctx, done := c.track(ctx, "create_playback_restriction")
+	defer done(&err)
+
+	// A retried creation could leave an orphaned playback restriction behind, so it is attempted once.
+	var resp mux.PlaybackRestrictionResponse
+	err = c.exec.Do(ctx, "create_playback_restriction", false, func(ctx context.Context) (err error) {
+		resp, err = c.client.PlaybackRestrictionsApi.CreatePlaybackRestriction(mux.CreatePlaybackRestrictionRequest{
+			Referrer: referrer,
+		}, mux.WithContext(ctx))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create playback restriction: %w", err)
+	}
+	return &resp.Data, nil
+}
+
+// UpdatePlaybackRestrictionReferrer replaces the referrer domain rules of the MUX playback restriction.
+func (c *Client) UpdatePlaybackRestrictionReferrer(ctx context.Context, restrictionID string, referrer mux.ReferrerDomainRestriction) (err error) {
+	ctx, done := c.track(ctx, "update_playback_restriction")
+	defer done(&err)
+
+	err = c.exec.Do(ctx, "update_playback_restriction", true, func(ctx context.Context) error {
+		_, err := c.client.PlaybackRestrictionsApi.UpdateReferrerDomainRestriction(restrictionID, mux.UpdateReferrerDomainRestrictionRequest{
+			AllowedDomains:  referrer.AllowedDomains,
+			AllowNoReferrer: referrer.AllowNoReferrer,
+		}, mux.WithContext(ctx))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update playback restriction: %w", err)
+	}
+	return nil
+}
+
+// DeletePlaybackRestriction deletes the MUX playback restriction. Tokens referencing it stop working.
+func (c *Client) DeletePlaybackRestriction(ctx context.Context, restrictionID string) (err error) {
+	ctx, done := c.track(ctx, "delete_playback_restriction")
+	defer done(&err)
+
+	err = c.exec.Do(ctx, "delete_playback_restriction", true, func(ctx context.Context) error {
+		return c.client.PlaybackRestrictionsApi.DeletePlaybackRestriction(restrictionID, mux.WithContext(ctx))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete playback restriction: %w", err)
+	}
+	return nil
+}
+
+// maxTranscriptSize limits the size of a transcript retrieved by [Client.GetTranscript].
+const maxTranscriptSize = 1 << 20
+
+// GetTranscript retrieves the plain text transcript of a text track through a public playback ID.
+func (c *Client) GetTranscript(ctx context.Context, playbackID, trackID string) (_ string, err error) {
+	ctx, done := c.track(ctx, "get_transcript")
+	defer done(&err)
+
+	url := fmt.Sprintf("https://stream.mux.com/%s/text/%s.txt", playbackID, trackID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create transcript request: %w", err)
+	}
+	resp, err := transcriptClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve transcript: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to retrieve transcript: unexpected status %d", resp.StatusCode)
+	}
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxTranscriptSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read transcript: %w", err)
+	}
+	return string(b), nil
+}
+
+// transcriptClient retrieves transcripts from the MUX stream domain, outside the MUX API.
+var transcriptClient = &http.Client{
+	Timeout:   30 * time.Second,
+	Transport: otelhttp.NewTransport(http.DefaultTransport),
+}
+
+// Ping checks that the MUX API is reachable and the credentials are accepted by listing a single asset.
+// It bypasses the retries and the circuit breaker, so health checks observe the current API state.
+func (c *Client) Ping(ctx context.Context) (err error) {
+	ctx, done := c.track(ctx, "ping")
+	defer done(&err)
+
+	if _, err := c.client.AssetsApi.ListAssets(mux.WithParams(&mux.ListAssetsParams{Limit: 1}), mux.WithContext(ctx)); err != nil {
+		return fmt.Errorf("failed to reach mux api: %w", err)
+	}
+	return nil
+}
+
+// Token audiences accepted by MUX, see https://docs.mux.com/guides/secure-video-playback.
+const (
+	audienceVideo = "v"
+	// audienceDRMLicense is the audience of the tokens used to acquire Widevine and FairPlay licenses.
+	audienceDRMLicense = "d"
+	// audienceThumbnail is the audience of the tokens used to fetch thumbnails of signed playback IDs.
+	audienceThumbnail = "t"
+)
+
+// CreateSigningKey creates a MUX URL signing key. Its private key is only returned by this call.
+func (c *Client) CreateSigningKey(ctx context.Context) (_ *mux.SigningKey, err error) {
+	ctx, done := c.track(ctx, "create_signing_key")
+	defer done(&err)
+
+	// A retried creation could leave an orphaned signing key behind, so it is attempted once.
+	var resp mux.SigningKeyResponse
+	err = c.exec.Do(ctx, "create_signing_key", false, func(ctx context.Context) (err error) {
+		resp, err = c.client.SigningKeysApi.CreateSigningKey(mux.WithContext(ctx))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signing key: %w", err)
+	}
+	return &resp.Data, nil
+}
+
+// DeleteSigningKey deletes the MUX URL signing key. Tokens signed with it stop working.
+func (c *Client) DeleteSigningKey(ctx context.Context, keyID string) (err error) {
+	ctx, done := c.track(ctx, "delete_signing_key")
+	defer done(&err)
+
+	err = c.exec.Do(ctx, "delete_signing_key", true, func(ctx context.Context) error {
+		return c.client.SigningKeysApi.DeleteSigningKey(keyID, mux.WithContext(ctx))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete signing key: %w", err)
+	}
+	return nil
+}
+
+// SigningKey is a MUX URL signing key tokens can be signed with instead of the configured one.
+type SigningKey struct {
+	ID string
+	// PrivateKey is the PEM encoded RSA private key.
+	PrivateKey []byte
+}
+
 type GeneratePlaybackTokenOptions struct {
 	UserID     uuid.UUID
 	PlaybackID string
 	Expiration int64      // in seconds
 	UserAgent  *string    // optional
 	SessionID  *uuid.UUID // optional
+	// Params are signed into the token as claims, e.g. the time of a thumbnail. MUX ignores the query
+	// parameters of signed URLs. Optional.
+	Params map[string]string
+	// PlaybackRestrictionID is the MUX playback restriction the token is subject to. Optional, the
+	// configured playback restriction applies if it is empty.
+	PlaybackRestrictionID string
+	// SigningKey is the key the token is signed with. Optional, the configured signing key is used if
+	// it is nil.
+	SigningKey *SigningKey
 }
 
 func populateCustomClaims(opts GeneratePlaybackTokenOptions) map[string]any {
@@ -114,21 +508,45 @@ func populateCustomClaims(opts GeneratePlaybackTokenOptions) map[string]any {
 	return custom
 }
 
-func (c *Client) GeneratePlaybackJWTToken(opts GeneratePlaybackTokenOptions) (string, error) {
-	if len(c.cfg.signingKeyPrivateKey) == 0 || c.cfg.signingKeyID == "" {
+func (c *Client) GeneratePlaybackJWTToken(_ context.Context, opts GeneratePlaybackTokenOptions) (string, error) {
+	return c.signToken(opts, audienceVideo)
+}
+
+// GenerateDRMLicenseJWTToken generates a token for the Widevine and FairPlay license requests of a DRM playback ID.
+func (c *Client) GenerateDRMLicenseJWTToken(_ context.Context, opts GeneratePlaybackTokenOptions) (string, error) {
+	return c.signToken(opts, audienceDRMLicense)
+}
+
+// GenerateThumbnailJWTToken generates a token for the thumbnails and posters of a signed playback ID.
+func (c *Client) GenerateThumbnailJWTToken(_ context.Context, opts GeneratePlaybackTokenOptions) (string, error) {
+	return c.signToken(opts, audienceThumbnail)
+}
+
+func (c *Client) signToken(opts GeneratePlaybackTokenOptions, audience string) (string, error) {
+	keyID, privateKey := c.cfg.signingKeyID, c.cfg.signingKeyPrivateKey
+	if opts.SigningKey != nil {
+		keyID, privateKey = opts.SigningKey.ID, opts.SigningKey.PrivateKey
+	}
+	if len(privateKey) == 0 || keyID == "" {
 		return "", fmt.Errorf("signing key is not configured")
 	}
-	signKey, err := jwt.ParseRSAPrivateKeyFromPEM(c.cfg.signingKeyPrivateKey)
+	signKey, err := jwt.ParseRSAPrivateKeyFromPEM(privateKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse signing key: %w", err)
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
 		"sub": opts.PlaybackID,
-		"aud": "v",
+		"aud": audience,
 		"exp": opts.Expiration,
-		"kid": c.cfg.signingKeyID,
+		"kid": keyID,
 	})
+	if restrictionID := cmp.Or(opts.PlaybackRestrictionID, c.cfg.playbackRestrictionID); restrictionID != "" {
+		token.Claims.(jwt.MapClaims)["playback_restriction_id"] = restrictionID
+	}
+	for param, value := range opts.Params {
+		token.Claims.(jwt.MapClaims)[param] = value
+	}
 
 	custom := populateCustomClaims(opts)
 	if len(custom) > 0 {
@@ -20,14 +20,27 @@ package mux
 import (
 	"encoding/base64"
 	"fmt"
+	"time"
+
+	"github.com/mikhail5545/media-service-go/internal/apiclients/resilience"
 )
 
+// CallObserver is notified after every Mux API call with the operation name, its duration and error.
+type CallObserver func(operation string, duration time.Duration, err error)
+
 type config struct {
 	corsOrigin            string
 	test                  bool
 	signingKeyID          string
 	signingKeyPrivateKey  []byte
 	playbackRestrictionID string
+	observer              CallObserver
+	webhookSecret         string
+	webhookTolerance      time.Duration
+	resilience            *resilience.Config
+	resilienceHooks       resilience.Hooks
+	callTimeout           time.Duration
+	callReserve           time.Duration
 }
 
 type Option func(*config) error
@@ -69,3 +82,41 @@ func WithSigningKey(keyID string, b64key string) Option {
 		return nil
 	}
 }
+
+// WithObserver sets the observer notified about every Mux API call, e.g. to record latency metrics.
+func WithObserver(observer CallObserver) Option {
+	return func(c *config) error {
+		c.observer = observer
+		return nil
+	}
+}
+
+// WithWebhookSecret sets the webhook signing secret and the maximum accepted webhook age.
+// Signatures are not verified when the secret is empty.
+func WithWebhookSecret(secret string, tolerance time.Duration) Option {
+	return func(c *config) error {
+		c.webhookSecret = secret
+		c.webhookTolerance = tolerance
+		return nil
+	}
+}
+
+// WithResilience enables the retries of idempotent calls and the circuit breaker of all API calls.
+// The hooks are notified about retries and breaker state changes.
+func WithResilience(cfg resilience.Config, hooks resilience.Hooks) Option {
+	return func(c *config) error {
+		c.resilience = &cfg
+		c.resilienceHooks = hooks
+		return nil
+	}
+}
+
+// WithCallTimeout bounds every API call by timeout and leaves reserve of the caller's deadline to the
+// caller, so it can clean up when the call times out.
+func WithCallTimeout(timeout, reserve time.Duration) Option {
+	return func(c *config) error {
+		c.callTimeout = timeout
+		c.callReserve = reserve
+		return nil
+	}
+}
@@ -0,0 +1,189 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/mikhail5545/media-service-go/internal/environment"
+	mux "github.com/muxinc/mux-go/v6"
+)
+
+// Router is an [APIClient] which dispatches every call to the client of the environment stored in
+// the call context. Calls without an environment, e.g. by the background workers, use the client
+// of the [environment.Default] environment.
+type Router struct {
+	clients map[string]*Client
+	// muxEnvironments maps the MUX environment IDs to the environment names. Webhooks are verified
+	// by the client of the environment they were sent from.
+	muxEnvironments map[string]string
+}
+
+var _ APIClient = (*Router)(nil)
+
+// NewRouter returns a router of the clients keyed by the environment name. muxEnvironments maps
+// the MUX environment IDs to the environment names, it may be empty when there is only one.
+func NewRouter(clients map[string]*Client, muxEnvironments map[string]string) (*Router, error) {
+	if clients[environment.Default] == nil {
+		return nil, fmt.Errorf("client of the %q environment is required", environment.Default)
+	}
+	for id, name := range muxEnvironments {
+		if clients[name] == nil {
+			return nil, fmt.Errorf("MUX environment %s is mapped to unknown environment %q", id, name)
+		}
+	}
+	return &Router{clients: clients, muxEnvironments: muxEnvironments}, nil
+}
+
+// Environments returns the names of the routed environments.
+func (r *Router) Environments() []string {
+	return slices.Sorted(maps.Keys(r.clients))
+}
+
+// webhookEnvironment returns the environment name of the MUX environment ID, or
+// [environment.Default] when the ID is not mapped.
+func (r *Router) webhookEnvironment(muxEnvironmentID string) string {
+	if name, ok := r.muxEnvironments[muxEnvironmentID]; ok {
+		return name
+	}
+	return environment.Default
+}
+
+func (r *Router) client(ctx context.Context) *Client {
+	if c, ok := r.clients[environment.Name(ctx)]; ok {
+		return c
+	}
+	return r.clients[environment.Default]
+}
+
+// Ping checks the API of every environment.
+func (r *Router) Ping(ctx context.Context) error {
+	var errs []error
+	for _, name := range r.Environments() {
+		if err := r.clients[name].Ping(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("environment %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Router) CreateDirectUploadURL(ctx context.Context, meta *mux.AssetMetadata, drmConfigurationID string, overlays []mux.InputSettings, encoding EncodingSettings, policies ...mux.PlaybackPolicy) (*mux.UploadResponse, error) {
+	return r.client(ctx).CreateDirectUploadURL(ctx, meta, drmConfigurationID, overlays, encoding, policies...)
+}
+
+func (r *Router) DeleteAsset(ctx context.Context, assetID string) error {
+	return r.client(ctx).DeleteAsset(ctx, assetID)
+}
+
+func (r *Router) UpdateAsset(ctx context.Context, assetID string, update *mux.UpdateAssetRequest) error {
+	return r.client(ctx).UpdateAsset(ctx, assetID, update)
+}
+
+func (r *Router) CreatePlaybackID(ctx context.Context, assetID string, policy mux.PlaybackPolicy) (*mux.PlaybackId, error) {
+	return r.client(ctx).CreatePlaybackID(ctx, assetID, policy)
+}
+
+func (r *Router) DeletePlaybackID(ctx context.Context, assetID, playbackID string) error {
+	return r.client(ctx).DeletePlaybackID(ctx, assetID, playbackID)
+}
+
+func (r *Router) GetAsset(ctx context.Context, assetID string) (*mux.Asset, error) {
+	return r.client(ctx).GetAsset(ctx, assetID)
+}
+
+func (r *Router) GetDirectUpload(ctx context.Context, uploadID string) (*mux.Upload, error) {
+	return r.client(ctx).GetDirectUpload(ctx, uploadID)
+}
+
+func (r *Router) CancelDirectUpload(ctx context.Context, uploadID string) error {
+	return r.client(ctx).CancelDirectUpload(ctx, uploadID)
+}
+
+func (r *Router) ListAssets(ctx context.Context, limit, page int32) ([]mux.Asset, error) {
+	return r.client(ctx).ListAssets(ctx, limit, page)
+}
+
+func (r *Router) ListAssetViews(ctx context.Context, from, to time.Time, limit, page int32) ([]mux.BreakdownValue, error) {
+	return r.client(ctx).ListAssetViews(ctx, from, to, limit, page)
+}
+
+func (r *Router) GetTranscript(ctx context.Context, playbackID, trackID string) (string, error) {
+	return r.client(ctx).GetTranscript(ctx, playbackID, trackID)
+}
+
+func (r *Router) CreatePlaybackRestriction(ctx context.Context, referrer mux.ReferrerDomainRestriction) (*mux.PlaybackRestriction, error) {
+	return r.client(ctx).CreatePlaybackRestriction(ctx, referrer)
+}
+
+func (r *Router) UpdatePlaybackRestrictionReferrer(ctx context.Context, restrictionID string, referrer mux.ReferrerDomainRestriction) error {
+	return r.client(ctx).UpdatePlaybackRestrictionReferrer(ctx, restrictionID, referrer)
+}
+
+func (r *Router) DeletePlaybackRestriction(ctx context.Context, restrictionID string) error {
+	return r.client(ctx).DeletePlaybackRestriction(ctx, restrictionID)
+}
+
+func (r *Router) CreateSigningKey(ctx context.Context) (*mux.SigningKey, error) {
+	return r.client(ctx).CreateSigningKey(ctx)
+}
+
+func (r *Router) DeleteSigningKey(ctx context.Context, keyID string) error {
+	return r.client(ctx).DeleteSigningKey(ctx, keyID)
+}
+
+func (r *Router) GeneratePlaybackJWTToken(ctx context.Context, opts GeneratePlaybackTokenOptions) (string, error) {
+	return r.client(ctx).GeneratePlaybackJWTToken(ctx, opts)
+}
+
+func (r *Router) GenerateDRMLicenseJWTToken(ctx context.Context, opts GeneratePlaybackTokenOptions) (string, error) {
+	return r.client(ctx).GenerateDRMLicenseJWTToken(ctx, opts)
+}
+
+func (r *Router) GenerateThumbnailJWTToken(ctx context.Context, opts GeneratePlaybackTokenOptions) (string, error) {
+	return r.client(ctx).GenerateThumbnailJWTToken(ctx, opts)
+}
+
+// VerifiesWebhooks reports whether a webhook signing secret is configured in any environment.
+func (r *Router) VerifiesWebhooks() bool {
+	for _, c := range r.clients {
+		if c.VerifiesWebhooks() {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyWebhookSignature verifies the webhook with the secret of the environment it was sent from,
+// which is resolved from the environment ID of the payload.
+func (r *Router) VerifyWebhookSignature(payload []byte, header string) error {
+	var head struct {
+		Environment struct {
+			ID string `json:"id"`
+		} `json:"environment"`
+	}
+	if err := json.Unmarshal(payload, &head); err != nil {
+		return fmt.Errorf("%w: malformed payload", ErrInvalidWebhookSignature)
+	}
+	return r.clients[r.webhookEnvironment(head.Environment.ID)].VerifyWebhookSignature(payload, header)
+}
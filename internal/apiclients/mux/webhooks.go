@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidWebhookSignature is returned when a webhook signature is missing, malformed, expired or does not match.
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+// VerifiesWebhooks reports whether a webhook signing secret is configured.
+func (c *Client) VerifiesWebhooks() bool {
+	return c.cfg.webhookSecret != ""
+}
+
+// VerifyWebhookSignature verifies the Mux-Signature header ("t=TIMESTAMP,v1=HEX") of a webhook
+// against the raw request body. It always succeeds when no webhook secret is configured.
+func (c *Client) VerifyWebhookSignature(payload []byte, header string) error {
+	if c.cfg.webhookSecret == "" {
+		return nil
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return fmt.Errorf("%w: malformed header", ErrInvalidWebhookSignature)
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp", ErrInvalidWebhookSignature)
+	}
+	if c.cfg.webhookTolerance > 0 {
+		if age := time.Since(time.Unix(unix, 0)); age > c.cfg.webhookTolerance || age < -c.cfg.webhookTolerance {
+			return fmt.Errorf("%w: timestamp outside of tolerance", ErrInvalidWebhookSignature)
+		}
+	}
+
+	mac := hmac.New(sha256.New, []byte(c.cfg.webhookSecret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+	for _, sig := range signatures {
+		received, err := hex.DecodeString(sig)
+		if err == nil && hmac.Equal(received, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidWebhookSignature
+}
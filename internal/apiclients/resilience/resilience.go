@@ -0,0 +1,247 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package resilience wraps provider API calls with retries and a circuit breaker. Idempotent calls
+// failing with a transient error are retried with exponential backoff and full jitter. Transient
+// failures of all calls feed a circuit breaker that short-circuits the calls once the provider is
+// considered down, failing them with [serviceerrors.ErrUnavailable] until a probe call succeeds.
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"time"
+
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+)
+
+// ErrOpen is returned without calling the provider while the circuit breaker is open.
+var ErrOpen = fmt.Errorf("circuit breaker is open: %w", serviceerrors.ErrUnavailable)
+
+// State is the state of a circuit breaker.
+type State int
+
+const (
+	// StateClosed lets all calls through.
+	StateClosed State = iota
+	// StateHalfOpen lets a single probe call through, its result closes or reopens the breaker.
+	StateHalfOpen
+	// StateOpen fails all calls with [ErrOpen].
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half_open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// Config configures the retries and the circuit breaker.
+type Config struct {
+	// MaxAttempts is the maximum number of attempts of an idempotent call, 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry, it doubles with every further retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff between retries.
+	MaxDelay time.Duration
+	// FailureThreshold is the number of consecutive transient failures opening the breaker,
+	// 0 disables the breaker.
+	FailureThreshold int
+	// OpenTimeout is the time the breaker stays open before a probe call is let through.
+	OpenTimeout time.Duration
+}
+
+// Hooks are notified about retries and breaker state changes, e.g. to record metrics. Both are optional.
+type Hooks struct {
+	OnRetry       func(operation string)
+	OnStateChange func(state State)
+}
+
+// Executor executes the calls of a single provider. It is safe for concurrent use.
+type Executor struct {
+	cfg       Config
+	transient func(err error) bool
+	hooks     Hooks
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// New creates an executor. transient reports whether a provider error is worth retrying and
+// counts as a provider failure, it is combined with the network errors detected by [Transient].
+func New(cfg Config, transient func(err error) bool, hooks Hooks) *Executor {
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	return &Executor{
+		cfg:       cfg,
+		transient: transient,
+		hooks:     hooks,
+	}
+}
+
+// Do executes fn. Idempotent calls are retried on transient errors, other calls are attempted once.
+// It returns [ErrOpen] without calling fn while the breaker is open. A nil executor executes fn once.
+func (e *Executor) Do(ctx context.Context, operation string, idempotent bool, fn func(ctx context.Context) error) error {
+	if e == nil {
+		return fn(ctx)
+	}
+	attempts := 1
+	if idempotent {
+		attempts = e.cfg.MaxAttempts
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if e.hooks.OnRetry != nil {
+				e.hooks.OnRetry(operation)
+			}
+			timer := time.NewTimer(e.backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+		if !e.allow() {
+			return ErrOpen
+		}
+		err = fn(ctx)
+		transient := err != nil && ctx.Err() == nil && e.isTransient(err)
+		e.record(err == nil, transient)
+		if !transient {
+			return err
+		}
+	}
+	return err
+}
+
+// State returns the current state of the breaker.
+func (e *Executor) State() State {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.state
+}
+
+func (e *Executor) isTransient(err error) bool {
+	return Transient(err) || (e.transient != nil && e.transient(err))
+}
+
+// backoff returns the delay before the given retry: a random duration up to BaseDelay*2^(attempt-1),
+// capped at MaxDelay.
+func (e *Executor) backoff(attempt int) time.Duration {
+	delay := e.cfg.BaseDelay << (attempt - 1)
+	if delay <= 0 || (e.cfg.MaxDelay > 0 && delay > e.cfg.MaxDelay) {
+		delay = e.cfg.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return rand.N(delay)
+}
+
+// allow reports whether a call may be made, moving an expired open breaker to half-open.
+func (e *Executor) allow() bool {
+	if e.cfg.FailureThreshold <= 0 {
+		return true
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	switch e.state {
+	case StateOpen:
+		if time.Since(e.openedAt) < e.cfg.OpenTimeout {
+			return false
+		}
+		e.setState(StateHalfOpen)
+		e.probing = true
+		return true
+	case StateHalfOpen:
+		// Only a single probe call is let through at a time.
+		if e.probing {
+			return false
+		}
+		e.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record updates the breaker with the result of a call. Non-transient errors, e.g. a missing asset,
+// show that the provider is up and count as successes.
+func (e *Executor) record(success, transient bool) {
+	if e.cfg.FailureThreshold <= 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.state == StateHalfOpen {
+		e.probing = false
+	}
+	if success || !transient {
+		e.failures = 0
+		if e.state != StateClosed {
+			e.setState(StateClosed)
+		}
+		return
+	}
+
+	e.failures++
+	if e.state == StateHalfOpen || e.failures >= e.cfg.FailureThreshold {
+		e.openedAt = time.Now()
+		if e.state != StateOpen {
+			e.setState(StateOpen)
+		}
+	}
+}
+
+// setState must be called with e.mu held.
+func (e *Executor) setState(state State) {
+	e.state = state
+	if e.hooks.OnStateChange != nil {
+		e.hooks.OnStateChange(state)
+	}
+}
+
+// Transient reports whether err is a network error worth retrying: a timeout, a refused or reset
+// connection or a connection closed before the response was complete.
+func Transient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
@@ -0,0 +1,338 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package s3 is a minimal client of the S3 API, compatible with AWS S3, MinIO and other
+// S3-compatible object stores. It only implements the calls used by the service: presigned object
+// uploads and downloads, object inspection and deletion.
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mikhail5545/media-service-go/internal/apiclients/resilience"
+	"github.com/mikhail5545/media-service-go/internal/deadline"
+	"github.com/mikhail5545/media-service-go/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrNotFound is returned when the object or the bucket does not exist.
+var ErrNotFound = errors.New("s3: not found")
+
+type APIClient interface {
+	PresignPutObject(key string, opts PutOptions) (*PresignedRequest, error)
+	PresignGetObject(key string, opts GetOptions) (*PresignedRequest, error)
+	HeadObject(ctx context.Context, key string) (*ObjectInfo, error)
+	DeleteObject(ctx context.Context, key string) error
+}
+
+// PutOptions restrict a presigned upload. Every set value is signed, S3 rejects uploads sending a
+// different value.
+type PutOptions struct {
+	ContentType   string
+	ContentLength int64
+	// ChecksumSHA256 is the base64 encoded SHA-256 of the content. S3 verifies the uploaded content
+	// against it.
+	ChecksumSHA256 string
+	Expires        time.Duration
+}
+
+type GetOptions struct {
+	// Filename makes browsers save the object under this name instead of displaying it.
+	Filename string
+	Expires  time.Duration
+}
+
+// PresignedRequest is a request that can be sent by a client without credentials until ExpiresAt.
+type PresignedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// Headers must be sent with exactly these values.
+	Headers   map[string]string `json:"headers,omitempty"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+	// ChecksumSHA256 is empty unless the object was uploaded with a SHA-256 checksum.
+	ChecksumSHA256 string
+}
+
+// APIError is an error response of the S3 API.
+type APIError struct {
+	StatusCode int
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("s3: status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("s3: status %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+type Client struct {
+	endpoint *url.URL
+	bucket   string
+	signer   *signer
+	cfg      config
+	// exec retries the idempotent calls and short-circuits all calls while the object store is down.
+	exec *resilience.Executor
+}
+
+var _ APIClient = (*Client)(nil)
+
+// New creates a client of the bucket. The endpoint is the base URL of the S3 API, e.g.
+// "https://s3.eu-central-1.amazonaws.com" or "http://minio:9000".
+func New(endpoint, region, bucket, accessKeyID, secretAccessKey string, opt ...Option) (*Client, error) {
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, fmt.Errorf("access key ID or secret access key is empty")
+	}
+	if region == "" || bucket == "" {
+		return nil, fmt.Errorf("region or bucket is empty")
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid endpoint %q", endpoint)
+	}
+
+	cfg := &config{}
+	for _, o := range opt {
+		if err := o(cfg); err != nil {
+			return nil, fmt.Errorf("error applying option: %w", err)
+		}
+	}
+	if cfg.httpClient == nil {
+		cfg.httpClient = &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		}
+	}
+
+	var exec *resilience.Executor
+	if cfg.resilience != nil {
+		exec = resilience.New(*cfg.resilience, transient, cfg.resilienceHooks)
+	}
+
+	return &Client{
+		endpoint: u,
+		bucket:   bucket,
+		signer: &signer{
+			accessKeyID:     accessKeyID,
+			secretAccessKey: secretAccessKey,
+			region:          region,
+		},
+		cfg:  *cfg,
+		exec: exec,
+	}, nil
+}
+
+// transient reports whether the S3 API error is worth retrying: a server error or a rate limit.
+func transient(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode >= 500 || apiErr.StatusCode == http.StatusTooManyRequests)
+}
+
+// track starts a client span for the API call and bounds it by the call timeout. The returned
+// function ends the span and notifies the configured observer, it must be called with a pointer to
+// the call error.
+func (c *Client) track(ctx context.Context, operation string) (context.Context, func(err *error)) {
+	start := time.Now()
+	ctx, cancel := deadline.Sub(ctx, c.cfg.callTimeout, c.cfg.callReserve)
+	ctx, span := tracing.Start(ctx, "s3."+operation, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, func(err *error) {
+		cancel()
+		tracing.End(span, *err)
+		if c.cfg.observer != nil {
+			c.cfg.observer(operation, time.Since(start), *err)
+		}
+	}
+}
+
+// objectURL returns the URL of the object, or of the bucket if key is empty.
+func (c *Client) objectURL(key string) *url.URL {
+	u := *c.endpoint
+	base := strings.TrimSuffix(u.Path, "/")
+	if c.cfg.pathStyle {
+		u.Path = base + "/" + c.bucket + "/" + key
+	} else {
+		u.Host = c.bucket + "." + u.Host
+		u.Path = base + "/" + key
+	}
+	u.RawPath = encodePath(u.Path)
+	u.RawQuery = ""
+	return &u
+}
+
+// PresignPutObject returns a presigned upload of the object.
+func (c *Client) PresignPutObject(key string, opts PutOptions) (*PresignedRequest, error) {
+	if key == "" {
+		return nil, fmt.Errorf("object key is empty")
+	}
+	headers := make(http.Header)
+	if opts.ContentType != "" {
+		headers.Set("Content-Type", opts.ContentType)
+	}
+	if opts.ContentLength > 0 {
+		headers.Set("Content-Length", strconv.FormatInt(opts.ContentLength, 10))
+	}
+	if opts.ChecksumSHA256 != "" {
+		headers.Set("X-Amz-Checksum-Sha256", opts.ChecksumSHA256)
+	}
+	return c.presign(http.MethodPut, key, headers, nil, opts.Expires)
+}
+
+// PresignGetObject returns a presigned download of the object.
+func (c *Client) PresignGetObject(key string, opts GetOptions) (*PresignedRequest, error) {
+	if key == "" {
+		return nil, fmt.Errorf("object key is empty")
+	}
+	query := make(url.Values)
+	if opts.Filename != "" {
+		query.Set("response-content-disposition", mime.FormatMediaType("attachment", map[string]string{"filename": opts.Filename}))
+	}
+	return c.presign(http.MethodGet, key, nil, query, opts.Expires)
+}
+
+func (c *Client) presign(method, key string, headers http.Header, query url.Values, expires time.Duration) (*PresignedRequest, error) {
+	// S3 accepts presigned requests valid for up to 7 days.
+	if expires <= 0 || expires > 7*24*time.Hour {
+		return nil, fmt.Errorf("invalid presigned request expiration %s", expires)
+	}
+	now := time.Now()
+	u := c.objectURL(key)
+	u.RawQuery = query.Encode()
+	c.signer.presign(method, u, headers, expires, now)
+
+	req := &PresignedRequest{
+		Method:    method,
+		URL:       u.String(),
+		ExpiresAt: now.Add(expires).UTC(),
+	}
+	if len(headers) > 0 {
+		req.Headers = make(map[string]string, len(headers))
+		for name := range headers {
+			req.Headers[name] = headers.Get(name)
+		}
+	}
+	return req, nil
+}
+
+// HeadObject returns the description of the object, or ErrNotFound if it does not exist.
+func (c *Client) HeadObject(ctx context.Context, key string) (_ *ObjectInfo, err error) {
+	ctx, done := c.track(ctx, "head_object")
+	defer done(&err)
+
+	var info *ObjectInfo
+	err = c.exec.Do(ctx, "head_object", true, func(ctx context.Context) error {
+		resp, err := c.do(ctx, http.MethodHead, key, http.Header{"X-Amz-Checksum-Mode": {"ENABLED"}})
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		lastModified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+		info = &ObjectInfo{
+			Size:           resp.ContentLength,
+			ContentType:    resp.Header.Get("Content-Type"),
+			ETag:           strings.Trim(resp.Header.Get("ETag"), `"`),
+			LastModified:   lastModified,
+			ChecksumSHA256: resp.Header.Get("X-Amz-Checksum-Sha256"),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// DeleteObject deletes the object. Deleting an object that does not exist succeeds.
+func (c *Client) DeleteObject(ctx context.Context, key string) (err error) {
+	ctx, done := c.track(ctx, "delete_object")
+	defer done(&err)
+
+	return c.exec.Do(ctx, "delete_object", true, func(ctx context.Context) error {
+		resp, err := c.do(ctx, http.MethodDelete, key, nil)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		return resp.Body.Close()
+	})
+}
+
+// Ping checks that the bucket is reachable and the credentials are accepted. It bypasses the retries
+// and the circuit breaker, so health checks observe the current API state.
+func (c *Client) Ping(ctx context.Context) (err error) {
+	ctx, done := c.track(ctx, "ping")
+	defer done(&err)
+
+	resp, err := c.do(ctx, http.MethodHead, "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to reach s3 bucket: %w", err)
+	}
+	return resp.Body.Close()
+}
+
+// do sends a signed request without a body. Error responses are returned as errors.
+func (c *Client) do(ctx context.Context, method, key string, headers http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	c.signer.sign(req, time.Now())
+
+	resp, err := c.cfg.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	// HEAD responses have no body, the status code is all there is.
+	if body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10)); err == nil && len(body) > 0 {
+		_ = xml.Unmarshal(body, apiErr)
+	}
+	return nil, apiErr
+}
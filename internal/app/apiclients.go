@@ -18,50 +18,236 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	cfstreamapiclient "github.com/mikhail5545/media-service-go/internal/apiclients/cfstream"
 	cldapiclient "github.com/mikhail5545/media-service-go/internal/apiclients/cloudinary"
 	muxapiclient "github.com/mikhail5545/media-service-go/internal/apiclients/mux"
+	"github.com/mikhail5545/media-service-go/internal/apiclients/resilience"
+	s3apiclient "github.com/mikhail5545/media-service-go/internal/apiclients/s3"
+	"github.com/mikhail5545/media-service-go/internal/app/credentials"
+	"github.com/mikhail5545/media-service-go/internal/deadline"
+	"github.com/mikhail5545/media-service-go/internal/emulator"
+	"github.com/mikhail5545/media-service-go/internal/environment"
 	"go.uber.org/zap"
 )
 
+// muxAPI and cloudinaryAPI are implemented by the environment routers and by the emulators.
+type (
+	muxAPI interface {
+		muxapiclient.APIClient
+		Ping(ctx context.Context) error
+	}
+	cloudinaryAPI interface {
+		cldapiclient.APIClient
+		Ping(ctx context.Context) error
+	}
+)
+
 type ApiClients struct {
-	MuxClient *muxapiclient.Client
-	CldClient *cldapiclient.Client
+	// MuxClient and CldClient dispatch the calls to the clients of the request environment, or to
+	// the emulators in the dev mode.
+	MuxClient muxAPI
+	CldClient cloudinaryAPI
+	// Emulators is nil unless the dev mode is enabled.
+	Emulators *emulator.Emulators
+	// S3Client is nil unless the object storage is enabled.
+	S3Client *s3apiclient.Client
+	// CfStreamClient is nil unless Cloudflare Stream is enabled.
+	CfStreamClient *cfstreamapiclient.Client
 }
 
 func (a *App) setupApiClients() (*ApiClients, error) {
-	muxClient, err := a.setupMuxApi()
-	if err != nil {
-		a.logger.Error("failed to setup Mux API client", zap.Error(err))
-		return nil, err
+	clients := &ApiClients{}
+	if a.Cfg.Dev.Enabled {
+		emulators := emulator.New(emulator.Config{BaseURL: a.devBaseURL()})
+		a.logger.Warn("Dev mode is enabled, the MUX and Cloudinary APIs are emulated. Never enable it in production.",
+			zap.String("base_url", a.devBaseURL()))
+		clients.MuxClient = emulators.Mux
+		clients.CldClient = emulators.Cloudinary
+		clients.Emulators = emulators
+	} else {
+		muxClient, err := a.setupMuxApi()
+		if err != nil {
+			a.logger.Error("failed to setup Mux API client", zap.Error(err))
+			return nil, err
+		}
+		cldClient, err := a.setupCloudinaryApi()
+		if err != nil {
+			a.logger.Error("failed to setup Cloudinary API client", zap.Error(err))
+			return nil, err
+		}
+		clients.MuxClient = muxClient
+		clients.CldClient = cldClient
 	}
-	cldClient, err := a.setupCloudinaryApi()
+	if a.Cfg.S3.Enabled {
+		s3Client, err := a.setupS3Api()
+		if err != nil {
+			a.logger.Error("failed to setup S3 API client", zap.Error(err))
+			return nil, err
+		}
+		clients.S3Client = s3Client
+	}
+	if a.Cfg.CFStream.Enabled {
+		cfStreamClient, err := a.setupCfStreamApi()
+		if err != nil {
+			a.logger.Error("failed to setup Cloudflare Stream API client", zap.Error(err))
+			return nil, err
+		}
+		clients.CfStreamClient = cfStreamClient
+	}
+	return clients, nil
+}
+
+// setupMuxApi builds the Mux API client of every environment and the router dispatching to them.
+func (a *App) setupMuxApi() (*muxapiclient.Router, error) {
+	defaultClient, err := a.newMuxApi(a.manager.Credentials.MuxAPI, a.Cfg.Mux.WebhookSecret)
 	if err != nil {
-		a.logger.Error("failed to setup Cloudinary API client", zap.Error(err))
 		return nil, err
 	}
-	return &ApiClients{
-		MuxClient: muxClient,
-		CldClient: cldClient,
-	}, nil
+	clients := map[string]*muxapiclient.Client{environment.Default: defaultClient}
+	for name, env := range a.Cfg.Environments {
+		client, err := a.newMuxApi(a.manager.Credentials.Environments[name].MuxAPI, env.MuxWebhookSecret)
+		if err != nil {
+			return nil, fmt.Errorf("environment %s: %w", name, err)
+		}
+		clients[name] = client
+	}
+	return muxapiclient.NewRouter(clients, a.muxEnvironments())
 }
 
-func (a *App) setupMuxApi() (*muxapiclient.Client, error) {
-	muxClient, err := muxapiclient.New(
-		a.manager.Credentials.MuxAPI.APIToken,
-		a.manager.Credentials.MuxAPI.SecretKey,
-		muxapiclient.WithSigningKey(a.manager.Credentials.MuxAPI.SigningKeyID, a.manager.Credentials.MuxAPI.SigningKeyPrivate),
+func (a *App) newMuxApi(creds *credentials.MuxAPICredentials, webhookSecret string) (*muxapiclient.Client, error) {
+	opts := []muxapiclient.Option{
+		muxapiclient.WithSigningKey(creds.SigningKeyID, creds.SigningKeyPrivate),
 		muxapiclient.WithCORSOrigin(a.Cfg.Mux.CORSOrigin),
 		muxapiclient.WithTestMode(a.Cfg.Mux.TestMode),
-		muxapiclient.WithPlaybackRestrictionID(a.manager.Credentials.MuxAPI.PlaybackRestrictionID),
-	)
-	return muxClient, err
+		muxapiclient.WithPlaybackRestrictionID(creds.PlaybackRestrictionID),
+		muxapiclient.WithObserver(a.metrics.APICallObserver("mux")),
+		muxapiclient.WithWebhookSecret(webhookSecret, a.Cfg.Mux.WebhookTolerance),
+		muxapiclient.WithCallTimeout(a.Cfg.Timeouts.API, a.Cfg.Timeouts.Reserve),
+	}
+	if a.Cfg.APIResilience.Enabled {
+		opts = append(opts, muxapiclient.WithResilience(a.resilienceConfig(), a.metrics.APIResilienceHooks("mux")))
+	}
+	return muxapiclient.New(creds.APIToken, creds.SecretKey, opts...)
+}
+
+// devBaseURL returns the URL of the HTTP API the emulated upload and delivery URLs point to.
+func (a *App) devBaseURL() string {
+	if a.Cfg.Dev.BaseURL != "" {
+		return a.Cfg.Dev.BaseURL
+	}
+	return fmt.Sprintf("http://localhost:%d%s", a.Cfg.HTTP.Port, httpBasePath)
+}
+
+// muxEnvironments maps the configured MUX environment IDs to the environment names.
+func (a *App) muxEnvironments() map[string]string {
+	ids := make(map[string]string, len(a.Cfg.Environments)+1)
+	if a.Cfg.Mux.EnvironmentID != "" {
+		ids[a.Cfg.Mux.EnvironmentID] = environment.Default
+	}
+	for name, env := range a.Cfg.Environments {
+		ids[env.MuxEnvironmentID] = name
+	}
+	return ids
 }
 
-func (a *App) setupCloudinaryApi() (*cldapiclient.Client, error) {
-	cldClient, err := cldapiclient.New(
-		a.manager.Credentials.CloudinaryAPI.CloudName,
-		a.manager.Credentials.CloudinaryAPI.APIKey,
-		a.manager.Credentials.CloudinaryAPI.APISecret,
+// setupCloudinaryApi builds the Cloudinary API client of every environment and the router
+// dispatching to them.
+func (a *App) setupCloudinaryApi() (*cldapiclient.Router, error) {
+	defaultClient, err := a.newCloudinaryApi(a.manager.Credentials.CloudinaryAPI)
+	if err != nil {
+		return nil, err
+	}
+	clients := map[string]*cldapiclient.Client{environment.Default: defaultClient}
+	for name := range a.Cfg.Environments {
+		client, err := a.newCloudinaryApi(a.manager.Credentials.Environments[name].CloudinaryAPI)
+		if err != nil {
+			return nil, fmt.Errorf("environment %s: %w", name, err)
+		}
+		clients[name] = client
+	}
+	return cldapiclient.NewRouter(clients)
+}
+
+func (a *App) newCloudinaryApi(creds *credentials.CloudinaryAPICredentials) (*cldapiclient.Client, error) {
+	opts := []cldapiclient.Option{
+		cldapiclient.WithObserver(a.metrics.APICallObserver("cloudinary")),
+		cldapiclient.WithCallTimeout(a.Cfg.Timeouts.API, a.Cfg.Timeouts.Reserve),
+	}
+	if a.Cfg.APIResilience.Enabled {
+		opts = append(opts, cldapiclient.WithResilience(a.resilienceConfig(), a.metrics.APIResilienceHooks("cloudinary")))
+	}
+	return cldapiclient.New(creds.CloudName, creds.APIKey, creds.APISecret, opts...)
+}
+
+// environmentNames returns the names of the served environments, including the default one.
+func (a *App) environmentNames() []string {
+	return append([]string{environment.Default}, slices.Sorted(maps.Keys(a.Cfg.Environments))...)
+}
+
+func (a *App) setupS3Api() (*s3apiclient.Client, error) {
+	opts := []s3apiclient.Option{
+		s3apiclient.WithPathStyle(a.Cfg.S3.PathStyle),
+		s3apiclient.WithObserver(a.metrics.APICallObserver("s3")),
+		s3apiclient.WithCallTimeout(a.Cfg.Timeouts.API, a.Cfg.Timeouts.Reserve),
+	}
+	if a.Cfg.APIResilience.Enabled {
+		opts = append(opts, s3apiclient.WithResilience(a.resilienceConfig(), a.metrics.APIResilienceHooks("s3")))
+	}
+	return s3apiclient.New(
+		a.Cfg.S3.Endpoint,
+		a.Cfg.S3.Region,
+		a.Cfg.S3.Bucket,
+		a.manager.Credentials.S3.AccessKeyID,
+		a.manager.Credentials.S3.SecretAccessKey,
+		opts...,
 	)
-	return cldClient, err
+}
+
+func (a *App) setupCfStreamApi() (*cfstreamapiclient.Client, error) {
+	creds := a.manager.Credentials.CFStream
+	opts := []cfstreamapiclient.Option{
+		cfstreamapiclient.WithObserver(a.metrics.APICallObserver("cfstream")),
+		cfstreamapiclient.WithWebhookSecret(a.Cfg.CFStream.WebhookSecret, a.Cfg.CFStream.WebhookTolerance),
+		cfstreamapiclient.WithCallTimeout(a.Cfg.Timeouts.API, a.Cfg.Timeouts.Reserve),
+	}
+	if creds.SigningKeyID != "" {
+		opts = append(opts, cfstreamapiclient.WithSigningKey(creds.SigningKeyID, creds.SigningKeyPrivate))
+	}
+	if a.Cfg.APIResilience.Enabled {
+		opts = append(opts, cfstreamapiclient.WithResilience(a.resilienceConfig(), a.metrics.APIResilienceHooks("cfstream")))
+	}
+	return cfstreamapiclient.New(a.Cfg.CFStream.AccountID, creds.APIToken, opts...)
+}
+
+// streamingRoutes stream their responses until the client disconnects or the stream ends on its own,
+// they have no deadline unless one is configured.
+var streamingRoutes = []string{
+	"GET /api/v1/admin/mux/assets/:id/watch",
+	"GET /api/v1/admin/ws",
+}
+
+// timeoutRoutes returns the request deadlines of a transport with the configured route overrides.
+func (a *App) timeoutRoutes(fallback time.Duration) deadline.Routes {
+	overrides := make(map[string]time.Duration, len(a.Cfg.Timeouts.Routes)+len(streamingRoutes))
+	for _, route := range streamingRoutes {
+		overrides[route] = 0
+	}
+	maps.Copy(overrides, a.Cfg.Timeouts.Routes)
+	return deadline.Routes{Default: fallback, Overrides: overrides}
+}
+
+func (a *App) resilienceConfig() resilience.Config {
+	return resilience.Config{
+		MaxAttempts:      a.Cfg.APIResilience.MaxAttempts,
+		BaseDelay:        a.Cfg.APIResilience.BaseDelay,
+		MaxDelay:         a.Cfg.APIResilience.MaxDelay,
+		FailureThreshold: a.Cfg.APIResilience.BreakerThreshold,
+		OpenTimeout:      a.Cfg.APIResilience.BreakerOpenTimeout,
+	}
 }
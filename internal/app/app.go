@@ -19,45 +19,70 @@ package app
 
 import (
 	"context"
-	"os"
-	"os/signal"
-	"syscall"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/1password/onepassword-sdk-go"
+	"github.com/arangodb/go-driver/v2/arangodb"
 	"github.com/labstack/echo/v4"
 	"github.com/mikhail5545/media-service-go/internal/app/credentials"
+	"github.com/mikhail5545/media-service-go/internal/bus"
+	"github.com/mikhail5545/media-service-go/internal/cache"
+	"github.com/mikhail5545/media-service-go/internal/config"
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/grpc/interceptors"
+	"github.com/mikhail5545/media-service-go/internal/health"
+	"github.com/mikhail5545/media-service-go/internal/lifecycle"
+	"github.com/mikhail5545/media-service-go/internal/metrics"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.uber.org/zap"
-	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
 	"gorm.io/gorm"
 )
 
 type App struct {
-	Cfg         *Config
-	manager     *credentials.Manager
-	logger      *zap.Logger
-	postgresDB  *gorm.DB
-	mongoDB     *mongo.Database
+	Cfg        *config.Config
+	manager    *credentials.Manager
+	logger     *zap.Logger
+	postgresDB *gorm.DB
+	// postgresReplica is nil when read routing is disabled.
+	postgresReplica *gorm.DB
+	mongoDB         *mongo.Database
+	// arangoDB is nil unless the metadata is stored in ArangoDB.
+	arangoDB    arangodb.Database
 	opClient    *onepassword.Client
 	repos       *Repositories
 	apiClients  *ApiClients
 	services    *Services
 	grpcClients *GRPCClients
+	workers     *Workers
+	// publisher delivers the events to the message broker, it subscribes to bus.
+	publisher events.Publisher
+	bus       *bus.Bus
+	// hub delivers the events to the asset status watches and the live admin event stream.
+	hub         *events.Hub
+	cache       cache.Cache
+	grpcMetrics *interceptors.Metrics
+	auth        *Auth
+	metrics     *metrics.Metrics
+	health      *health.Checker
+	grpcHealth  *grpchealth.Server
+	tracingStop func(context.Context) error
 	cleanup     func()
 }
 
-func New(ctx context.Context, cfg *Config) (*App, error) {
+func New(ctx context.Context, cfg *config.Config) (*App, error) {
 	logger, cleanup, err := newLogger(cfg.Log)
 	if err != nil {
 		return nil, err
 	}
 
-	token := os.Getenv("OP_SERVICE_ACCOUNT_TOKEN")
 	manager, err := credentials.New(
 		ctx,
-		credentials.LoadSources(),
-		token,
+		secretSources(cfg),
+		cfg.Secrets.OnePasswordToken,
 		logger,
 	)
 	if err != nil {
@@ -76,6 +101,12 @@ func (a *App) Init(ctx context.Context) error {
 	if err := a.manager.ResolveAll(ctx); err != nil {
 		return err
 	}
+	a.metrics = a.setupMetrics()
+	tracingStop, err := a.setupTracing(ctx)
+	if err != nil {
+		return err
+	}
+	a.tracingStop = tracingStop
 
 	postgresDB, err := a.setupPostgresDB(ctx)
 	if err != nil {
@@ -85,93 +116,171 @@ func (a *App) Init(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	a.postgresDB = postgresDB
+	a.mongoDB = mongoDB
+	if a.Cfg.Metadata.Backend == string(dbmetadata.BackendArango) {
+		arangoDB, err := a.setupArangoDB(ctx)
+		if err != nil {
+			return err
+		}
+		a.arangoDB = arangoDB
+	}
+
+	postgresReplica, err := a.setupPostgresReplica(ctx)
+	if err != nil {
+		return err
+	}
+	a.postgresReplica = postgresReplica
+
+	assetCache, err := a.setupCache(ctx)
+	if err != nil {
+		return err
+	}
+	a.cache = assetCache
+
+	authCfg, err := a.setupAuth(ctx)
+	if err != nil {
+		return err
+	}
+	a.auth = authCfg
 
-	repos := a.setupRepositories()
+	repos, err := a.setupRepositories(ctx)
+	if err != nil {
+		return err
+	}
 
 	apiClients, err := a.setupApiClients()
 	if err != nil {
 		return err
 	}
 
-	grpcClients, err := a.setupGRPCClients(ctx)
+	grpcClients, err := a.setupGRPCClients()
 	if err != nil {
 		return err
 	}
+	// Assigned right away, so the connections are closed even if the remaining setup fails.
+	a.grpcClients = grpcClients
 
-	services := a.setupServices(repos, apiClients, grpcClients, a.logger)
+	checker, err := a.setupHealth(apiClients, grpcClients)
+	if err != nil {
+		return err
+	}
+	a.health = checker
+
+	publisher, err := a.setupEventPublisher(repos)
+	if err != nil {
+		return err
+	}
+	a.publisher = publisher
+
+	a.hub = events.NewHub()
+	eventBus, err := a.setupEventBus(repos, publisher)
+	if err != nil {
+		return err
+	}
+	a.bus = eventBus
+
+	services, err := a.setupServices(repos, apiClients, grpcClients, eventBus, a.logger)
+	if err != nil {
+		return err
+	}
+	services.MuxSvc.Subscribe(eventBus)
+
+	workers, err := a.setupWorkers(repos, services)
+	if err != nil {
+		return err
+	}
 
-	a.postgresDB = postgresDB
-	a.mongoDB = mongoDB
 	a.repos = repos
 	a.apiClients = apiClients
 	a.services = services
+	a.workers = workers
 
 	return nil
 }
 
+// Run starts the servers and workers and blocks until a shutdown signal is received, ctx is done or
+// a server fails. On shutdown the servers stop accepting new requests and drain in-flight ones,
+// then the workers are stopped and the outbox is flushed. Connections are released by [App.Close].
 func (a *App) Run(ctx context.Context) error {
 	grpcServer, listener, err := a.prepareGRPCServer()
 	if err != nil {
 		return err
 	}
 
-	grpcErrChan := make(chan error, 1)
-	go runGRPCServer(grpcErrChan, grpcServer, listener, a.logger)
-
-	e := echo.New()
-	integrateWithEcho(e, a.logger)
-	setupRouters(e, a.services)
+	lc := lifecycle.New(time.Duration(a.Cfg.GracefulShutdownTimeoutSeconds)*time.Second, a.logger)
 
-	httpErrChan := make(chan error, 1)
-	go runHTTPServer(e, a.Cfg.HTTP.Port, a.logger, httpErrChan)
+	lc.OnShutdown("outbox", a.flushOutbox)
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
+	workersCtx, stopWorkers := context.WithCancel(ctx)
+	waitWorkers := a.runWorkers(workersCtx)
+	a.runHealthWatcher(workersCtx, a.grpcHealth)
+	lc.OnShutdown("workers", func(shutdownCtx context.Context) error {
+		stopWorkers()
+		return waitWorkers(shutdownCtx)
+	})
 
-	select {
-	case sig := <-quit:
-		a.logger.Info("received shutdown signal", zap.String("signal", sig.String()))
-	case <-ctx.Done():
-		a.logger.Info("received shutdown signal via context cancellation")
-	case err := <-grpcErrChan:
-		a.logger.Error("gRPC server stopped unexpectedly with an error", zap.Error(err))
-	case err := <-httpErrChan:
-		a.logger.Error("gRPC server stopped unexpectedly with an error", zap.Error(err))
-	}
+	lc.Go("grpc", func() error { return runGRPCServer(grpcServer, listener, a.logger) })
+	lc.OnShutdown("grpc", func(shutdownCtx context.Context) error {
+		// Report NOT_SERVING first, so load balancers stop routing new calls during the drain.
+		a.grpcHealth.Shutdown()
+		return shutdownGRPCServer(shutdownCtx, grpcServer, a.logger)
+	})
 
-	a.gracefulShutdown(e, grpcServer, a.logger)
-	return nil
-}
-
-func (a *App) gracefulShutdown(e *echo.Echo, grpcServer *grpc.Server, logger *zap.Logger) {
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(a.Cfg.GracefulShutdownTimeoutSeconds)*time.Second)
-	defer cancel()
-
-	shutdownHTTPServer(shutdownCtx, e, logger)
+	e := echo.New()
+	e.HideBanner = true
+	integrateWithEcho(e, a.logger)
+	a.setupRouters(e)
 
-	done := make(chan struct{})
-	go shutdownGRPCServer(grpcServer, done)
+	lc.Go("http", func() error { return runHTTPServer(e, a.Cfg.HTTP.Port, a.logger) })
+	lc.OnShutdown("http", func(shutdownCtx context.Context) error {
+		return shutdownHTTPServer(shutdownCtx, e, a.logger)
+	})
 
-	select {
-	case <-done:
-		logger.Info("gRPC server shutdown complete")
-	case <-shutdownCtx.Done():
-		logger.Warn("gRPC graceful shutdown timed out, forcing stop")
-		grpcServer.Stop()
-	}
+	return lc.Run(ctx)
 }
 
+// Close releases the resources acquired by [App.New] and [App.Init] in dependency order: the event
+// bus, which delivers the queued events, the event publisher and outgoing gRPC connections first, then
+// the cache, the databases, the tracer and the logger.
 func (a *App) Close() error {
-	if a.cleanup != nil {
-		a.cleanup()
+	var errs []error
+	if a.bus != nil {
+		if err := a.bus.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close event bus: %w", err))
+		}
+	}
+	if a.publisher != nil {
+		if err := a.publisher.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close event publisher: %w", err))
+		}
 	}
 	if a.grpcClients != nil {
-		if err := a.grpcClients.VideoSvcClient.Close(); err != nil {
-			return err
+		if err := a.grpcClients.Pool.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close grpc clients: %w", err))
 		}
-		if err := a.grpcClients.ImageSvcClient.Close(); err != nil {
-			return err
+	}
+	if a.cache != nil {
+		if err := a.cache.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close cache: %w", err))
 		}
 	}
-	return nil
+	if err := a.closeDatabases(); err != nil {
+		errs = append(errs, err)
+	}
+	if a.tracingStop != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := a.tracingStop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop tracing: %w", err))
+		}
+	}
+	err := errors.Join(errs...)
+	if err != nil {
+		a.logger.Error("failed to release application resources", zap.Error(err))
+	}
+	if a.cleanup != nil {
+		a.cleanup()
+	}
+	return err
 }
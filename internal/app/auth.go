@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/auth"
+	cldassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/asset/v1"
+	muxassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/asset/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const httpBasePath = "/api/v1"
+
+// Auth holds the authenticators and the access policies for both transports.
+// It is nil when neither bearer token nor API key authentication is configured.
+type Auth struct {
+	// Authenticator verifies bearer tokens. It is nil when JWT authentication is disabled.
+	Authenticator *auth.Authenticator
+	// APIKeys validates service-to-service gRPC API keys. It may be empty.
+	APIKeys    *auth.APIKeys
+	HTTPPolicy *auth.Policy
+	GRPCPolicy *auth.Policy
+}
+
+func (a *App) setupAuth(ctx context.Context) (*Auth, error) {
+	cfg := a.Cfg.Auth
+
+	parsedKeys, err := auth.ParseAPIKeys(cfg.APIKeys)
+	if err != nil {
+		return nil, err
+	}
+	apiKeys, err := auth.NewAPIKeys(parsedKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.Enabled && apiKeys.Len() == 0 {
+		a.logger.Warn("authentication is disabled, admin HTTP and gRPC APIs are open")
+		return nil, nil
+	}
+
+	var authenticator *auth.Authenticator
+	if cfg.Enabled {
+		authenticator, err = auth.New(ctx, auth.Config{
+			Secret:   cfg.JWTSecret,
+			JWKSURL:  cfg.JWKSURL,
+			Issuer:   cfg.Issuer,
+			Audience: cfg.Audience,
+		})
+		if err != nil {
+			a.logger.Error("failed to set up authenticator", zap.Error(err))
+			return nil, fmt.Errorf("failed to set up authenticator: %w", err)
+		}
+	} else {
+		a.logger.Warn("bearer token authentication is disabled, admin HTTP API is open and gRPC API accepts API keys only")
+	}
+
+	httpRules, err := auth.ParseRules(cfg.HTTPRules)
+	if err != nil {
+		return nil, err
+	}
+	grpcRules, err := auth.ParseRules(cfg.GRPCRules)
+	if err != nil {
+		return nil, err
+	}
+	grpcDefault := auth.AccessAdmin
+	if cfg.GRPCDefaultAccess != "" {
+		grpcDefault = auth.Access(cfg.GRPCDefaultAccess)
+		if !grpcDefault.IsValid() {
+			return nil, fmt.Errorf("invalid gRPC default access level %q", cfg.GRPCDefaultAccess)
+		}
+	}
+
+	return &Auth{
+		Authenticator: authenticator,
+		APIKeys:       apiKeys,
+		HTTPPolicy:    auth.NewHTTPPolicy(auth.AccessPublic, httpRules, auth.DefaultHTTPRules(httpBasePath)),
+		GRPCPolicy: auth.NewPolicy(grpcDefault, grpcRules, append(auth.DefaultGRPCRules(
+			muxassetpbv1.AssetService_ServiceDesc.ServiceName,
+			cldassetpbv1.AssetService_ServiceDesc.ServiceName,
+		), auth.Rule{Prefix: "/" + healthpb.Health_ServiceDesc.ServiceName + "/", Access: auth.AccessPublic})),
+	}, nil
+}
+
+// httpMiddleware returns the HTTP auth middleware, or nil if bearer token authentication is disabled.
+func (au *Auth) httpMiddleware() echo.MiddlewareFunc {
+	if au == nil || au.Authenticator == nil {
+		return nil
+	}
+	return auth.EchoMiddleware(au.Authenticator, au.HTTPPolicy)
+}
+
+// grpcInterceptor returns the gRPC auth interceptor, or nil if authentication is disabled.
+func (au *Auth) grpcInterceptor() grpc.UnaryServerInterceptor {
+	if au == nil {
+		return nil
+	}
+	return auth.UnaryServerInterceptor(au.Authenticator, au.APIKeys, au.GRPCPolicy)
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikhail5545/media-service-go/internal/cache"
+	"go.uber.org/zap"
+)
+
+// setupCache connects to Redis when caching is enabled. Otherwise a no-op cache is returned and every
+// lookup hits the databases.
+func (a *App) setupCache(ctx context.Context) (cache.Cache, error) {
+	cfg := a.Cfg.Cache
+	if !cfg.Enabled {
+		return cache.Noop{}, nil
+	}
+	redisCache, err := cache.NewRedis(ctx, cache.RedisConfig{
+		Address:   cfg.Address,
+		Password:  cfg.Password,
+		DB:        cfg.DB,
+		KeyPrefix: cfg.KeyPrefix,
+	})
+	if err != nil {
+		a.logger.Error("failed to setup cache", zap.Error(err))
+		return nil, fmt.Errorf("failed to setup cache: %w", err)
+	}
+	a.logger.Info("cache connection established.", zap.String("address", cfg.Address))
+	return redisCache, nil
+}
+
+func (a *App) cacheTTL() cache.TTL {
+	return cache.TTL{
+		Asset:    a.Cfg.Cache.AssetTTL,
+		Metadata: a.Cfg.Cache.MetadataTTL,
+	}
+}
@@ -22,12 +22,22 @@ import (
 )
 
 type Credentials struct {
-	PostgresDB    *PostgresDBCredentials
-	MongoDB       *MongoDBCredentials
+	PostgresDB *PostgresDBCredentials
+	MongoDB    *MongoDBCredentials
+	// ArangoDB is nil unless the metadata is stored in ArangoDB.
+	ArangoDB      *ArangoDBCredentials
 	GRPCServer    *GRPCServerCredentials
 	GRPCClient    *GRPCClientCredentials
 	MuxAPI        *MuxAPICredentials
 	CloudinaryAPI *CloudinaryAPICredentials
+	// S3 is nil unless the object storage is enabled.
+	S3 *S3Credentials
+	// CFStream is nil unless Cloudflare Stream is enabled.
+	CFStream *CFStreamCredentials
+	// SigningKeys is nil unless the MUX signing key rotation is enabled.
+	SigningKeys *SigningKeysCredentials
+	// Environments holds the API credentials of the tenant environments besides the default one.
+	Environments map[string]*EnvironmentCredentials
 }
 
 type PostgresDBCredentials struct {
@@ -36,6 +46,9 @@ type PostgresDBCredentials struct {
 	User     string
 	Password string
 	DBName   string
+	// ReplicaHost and ReplicaPort are empty when no read replica is configured.
+	ReplicaHost string
+	ReplicaPort string
 }
 
 type MongoDBCredentials struct {
@@ -43,6 +56,11 @@ type MongoDBCredentials struct {
 	DBName           string
 }
 
+type ArangoDBCredentials struct {
+	User     string
+	Password string
+}
+
 type GRPCServerCredentials struct {
 	Credentials credentials.TransportCredentials
 }
@@ -65,3 +83,25 @@ type CloudinaryAPICredentials struct {
 	APIKey    string
 	APISecret string
 }
+
+type EnvironmentCredentials struct {
+	MuxAPI        *MuxAPICredentials
+	CloudinaryAPI *CloudinaryAPICredentials
+}
+
+type S3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// SigningKeysCredentials hold the base64 encoded AES key the rotated MUX signing keys are sealed with.
+type SigningKeysCredentials struct {
+	EncryptionKey string
+}
+
+type CFStreamCredentials struct {
+	APIToken string
+	// SigningKeyID and SigningKeyPrivate are empty when no signing key is configured.
+	SigningKeyID      string
+	SigningKeyPrivate string
+}
@@ -65,18 +65,46 @@ func (m *Manager) ResolveAll(ctx context.Context) error {
 	if err := m.ResolveMongoDBCredentials(ctx); err != nil {
 		return err
 	}
+	if m.src.ArangoDB.PasswordRef != "" {
+		if err := m.ResolveArangoDBCredentials(ctx); err != nil {
+			return err
+		}
+	}
 	if err := m.ResolveGRPCServerCredentials(ctx); err != nil {
 		return err
 	}
 	if err := m.ResolveGRPCClientCredentials(ctx); err != nil {
 		return err
 	}
-	if err := m.ResolveMuxAPICredentials(ctx); err != nil {
-		return err
+	// The provider references are empty in the dev mode, the emulators need no credentials.
+	if m.src.MuxAPI.APITokenRef != "" {
+		if err := m.ResolveMuxAPICredentials(ctx); err != nil {
+			return err
+		}
+	}
+	if m.src.CloudinaryAPI.CloudNameRef != "" {
+		if err := m.ResolveCloudinaryAPICredentials(ctx); err != nil {
+			return err
+		}
 	}
-	if err := m.ResolveCloudinaryAPICredentials(ctx); err != nil {
+	if err := m.ResolveEnvironmentCredentials(ctx); err != nil {
 		return err
 	}
+	if m.src.S3.AccessKeyIDRef != "" {
+		if err := m.ResolveS3Credentials(ctx); err != nil {
+			return err
+		}
+	}
+	if m.src.CFStream.APITokenRef != "" {
+		if err := m.ResolveCFStreamCredentials(ctx); err != nil {
+			return err
+		}
+	}
+	if m.src.SigningKeys.EncryptionKeyRef != "" {
+		if err := m.ResolveSigningKeysCredentials(ctx); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -129,35 +157,118 @@ func (m *Manager) extractItem(ctx context.Context, vaultRef, itemRef string) (on
 }
 
 func (m *Manager) ResolveMuxAPICredentials(ctx context.Context) error {
-	resolved, err := m.resolve(ctx, []string{
-		m.src.MuxAPI.APITokenRef, m.src.MuxAPI.SecretKeyRef,
-		m.src.MuxAPI.PlaybackRestrictionIDRef,
-		m.src.MuxAPI.SigningKeyIDRef, m.src.MuxAPI.SigningKeyPrivateRef,
-	})
+	creds, err := m.resolveMuxAPI(ctx, m.src.MuxAPI)
 	if err != nil {
 		m.logger.Error("failed to resolve Mux API credentials", zap.Error(err))
 		return err
 	}
-	m.Credentials.MuxAPI = &MuxAPICredentials{
-		APIToken:              resolved[m.src.MuxAPI.APITokenRef],
-		SecretKey:             resolved[m.src.MuxAPI.SecretKeyRef],
-		PlaybackRestrictionID: resolved[m.src.MuxAPI.PlaybackRestrictionIDRef],
-		SigningKeyID:          resolved[m.src.MuxAPI.SigningKeyIDRef],
-		SigningKeyPrivate:     resolved[m.src.MuxAPI.SigningKeyPrivateRef],
-	}
+	m.Credentials.MuxAPI = creds
 	return nil
 }
 
 func (m *Manager) ResolveCloudinaryAPICredentials(ctx context.Context) error {
-	resolved, err := m.resolve(ctx, []string{m.src.CloudinaryAPI.CloudNameRef, m.src.CloudinaryAPI.APIKeyRef, m.src.CloudinaryAPI.APISecretRef})
+	creds, err := m.resolveCloudinaryAPI(ctx, m.src.CloudinaryAPI)
 	if err != nil {
 		m.logger.Error("failed to resolve Cloudinary API credentials", zap.Error(err))
 		return err
 	}
-	m.Credentials.CloudinaryAPI = &CloudinaryAPICredentials{
-		CloudName: resolved[m.src.CloudinaryAPI.CloudNameRef],
-		APIKey:    resolved[m.src.CloudinaryAPI.APIKeyRef],
-		APISecret: resolved[m.src.CloudinaryAPI.APISecretRef],
+	m.Credentials.CloudinaryAPI = creds
+	return nil
+}
+
+// ResolveEnvironmentCredentials resolves the Mux and Cloudinary API credentials of every tenant
+// environment besides the default one.
+func (m *Manager) ResolveEnvironmentCredentials(ctx context.Context) error {
+	envs := make(map[string]*EnvironmentCredentials, len(m.src.Environments))
+	for name, refs := range m.src.Environments {
+		muxCreds, err := m.resolveMuxAPI(ctx, refs.MuxAPI)
+		if err != nil {
+			m.logger.Error("failed to resolve Mux API credentials", zap.Error(err), zap.String("environment", name))
+			return fmt.Errorf("failed to resolve Mux API credentials of environment %s: %w", name, err)
+		}
+		cldCreds, err := m.resolveCloudinaryAPI(ctx, refs.CloudinaryAPI)
+		if err != nil {
+			m.logger.Error("failed to resolve Cloudinary API credentials", zap.Error(err), zap.String("environment", name))
+			return fmt.Errorf("failed to resolve Cloudinary API credentials of environment %s: %w", name, err)
+		}
+		envs[name] = &EnvironmentCredentials{MuxAPI: muxCreds, CloudinaryAPI: cldCreds}
+	}
+	m.Credentials.Environments = envs
+	return nil
+}
+
+func (m *Manager) resolveMuxAPI(ctx context.Context, refs MuxAPIRefs) (*MuxAPICredentials, error) {
+	resolved, err := m.resolve(ctx, []string{
+		refs.APITokenRef, refs.SecretKeyRef,
+		refs.PlaybackRestrictionIDRef,
+		refs.SigningKeyIDRef, refs.SigningKeyPrivateRef,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &MuxAPICredentials{
+		APIToken:              resolved[refs.APITokenRef],
+		SecretKey:             resolved[refs.SecretKeyRef],
+		PlaybackRestrictionID: resolved[refs.PlaybackRestrictionIDRef],
+		SigningKeyID:          resolved[refs.SigningKeyIDRef],
+		SigningKeyPrivate:     resolved[refs.SigningKeyPrivateRef],
+	}, nil
+}
+
+func (m *Manager) resolveCloudinaryAPI(ctx context.Context, refs CloudinaryAPRefs) (*CloudinaryAPICredentials, error) {
+	resolved, err := m.resolve(ctx, []string{refs.CloudNameRef, refs.APIKeyRef, refs.APISecretRef})
+	if err != nil {
+		return nil, err
+	}
+	return &CloudinaryAPICredentials{
+		CloudName: resolved[refs.CloudNameRef],
+		APIKey:    resolved[refs.APIKeyRef],
+		APISecret: resolved[refs.APISecretRef],
+	}, nil
+}
+
+func (m *Manager) ResolveS3Credentials(ctx context.Context) error {
+	resolved, err := m.resolve(ctx, []string{m.src.S3.AccessKeyIDRef, m.src.S3.SecretAccessKeyRef})
+	if err != nil {
+		m.logger.Error("failed to resolve S3 credentials", zap.Error(err))
+		return err
+	}
+	m.Credentials.S3 = &S3Credentials{
+		AccessKeyID:     resolved[m.src.S3.AccessKeyIDRef],
+		SecretAccessKey: resolved[m.src.S3.SecretAccessKeyRef],
+	}
+	return nil
+}
+
+func (m *Manager) ResolveCFStreamCredentials(ctx context.Context) error {
+	refs := []string{m.src.CFStream.APITokenRef}
+	withSigningKey := m.src.CFStream.SigningKeyIDRef != "" && m.src.CFStream.SigningKeyPrivateRef != ""
+	if withSigningKey {
+		refs = append(refs, m.src.CFStream.SigningKeyIDRef, m.src.CFStream.SigningKeyPrivateRef)
+	}
+	resolved, err := m.resolve(ctx, refs)
+	if err != nil {
+		m.logger.Error("failed to resolve Cloudflare Stream credentials", zap.Error(err))
+		return err
+	}
+	m.Credentials.CFStream = &CFStreamCredentials{
+		APIToken: resolved[m.src.CFStream.APITokenRef],
+	}
+	if withSigningKey {
+		m.Credentials.CFStream.SigningKeyID = resolved[m.src.CFStream.SigningKeyIDRef]
+		m.Credentials.CFStream.SigningKeyPrivate = resolved[m.src.CFStream.SigningKeyPrivateRef]
+	}
+	return nil
+}
+
+func (m *Manager) ResolveSigningKeysCredentials(ctx context.Context) error {
+	key, err := m.opClient.SecretsAPI.Resolve(ctx, m.src.SigningKeys.EncryptionKeyRef)
+	if err != nil {
+		m.logger.Error("failed to resolve signing keys encryption key", zap.Error(err))
+		return err
+	}
+	m.Credentials.SigningKeys = &SigningKeysCredentials{
+		EncryptionKey: key,
 	}
 	return nil
 }
@@ -179,6 +290,16 @@ func (m *Manager) ResolvePostgresDBCredentials(ctx context.Context) error {
 		Password: resolved[m.src.PostgresDB.PasswordRef],
 		DBName:   resolved[m.src.PostgresDB.DBNameRef],
 	}
+	if m.src.PostgresDB.ReplicaHostRef == "" || m.src.PostgresDB.ReplicaPortRef == "" {
+		return nil
+	}
+	replica, err := m.resolve(ctx, []string{m.src.PostgresDB.ReplicaHostRef, m.src.PostgresDB.ReplicaPortRef})
+	if err != nil {
+		m.logger.Error("failed to resolve Postgres read replica credentials", zap.Error(err))
+		return err
+	}
+	m.Credentials.PostgresDB.ReplicaHost = replica[m.src.PostgresDB.ReplicaHostRef]
+	m.Credentials.PostgresDB.ReplicaPort = replica[m.src.PostgresDB.ReplicaPortRef]
 	return nil
 }
 
@@ -194,7 +315,25 @@ func (m *Manager) ResolveMongoDBCredentials(ctx context.Context) error {
 	return nil
 }
 
+func (m *Manager) ResolveArangoDBCredentials(ctx context.Context) error {
+	resolved, err := m.resolve(ctx, []string{m.src.ArangoDB.UserRef, m.src.ArangoDB.PasswordRef})
+	if err != nil {
+		m.logger.Error("failed to resolve ArangoDB credentials", zap.Error(err))
+		return err
+	}
+	m.Credentials.ArangoDB = &ArangoDBCredentials{
+		User:     resolved[m.src.ArangoDB.UserRef],
+		Password: resolved[m.src.ArangoDB.PasswordRef],
+	}
+	return nil
+}
+
+// ResolveGRPCServerCredentials reads the gRPC server certificates. It is a no-op when no references
+// are configured, i.e. when file based TLS is used instead.
 func (m *Manager) ResolveGRPCServerCredentials(ctx context.Context) error {
+	if m.src.GRPCServer.CertVaultRef == "" && m.src.GRPCServer.CertItemRef == "" {
+		return nil
+	}
 	item, err := m.extractItem(ctx, m.src.GRPCServer.CertVaultRef, m.src.GRPCServer.CertItemRef)
 	if err != nil {
 		m.logger.Error("failed to extract gRPC server cert item", zap.Error(err))
@@ -216,30 +355,36 @@ func (m *Manager) ResolveGRPCServerCredentials(ctx context.Context) error {
 	return nil
 }
 
+// ResolveGRPCClientCredentials reads the gRPC client certificates and the server address. Each part
+// is skipped when its references are not configured, i.e. when it is set in the configuration instead.
 func (m *Manager) ResolveGRPCClientCredentials(ctx context.Context) error {
-	item, err := m.extractItem(ctx, m.src.GRPCClient.CertVaultRef, m.src.GRPCClient.CertItemRef)
-	if err != nil {
-		m.logger.Error("failed to extract gRPC client cert item", zap.Error(err))
-		return err
-	}
-	files, err := m.readItemFiles(ctx, item, []string{"ca.pem", "server.crt", "server.key"})
-	if err != nil {
-		m.logger.Error("failed to read gRPC client cert files", zap.Error(err))
-		return err
-	}
-	tlsConfig, err := buildTLSConfig(files["ca.pem"], files["server.crt"], files["server.key"])
-	if err != nil {
-		m.logger.Error("failed to create TLS config for gRPC client", zap.Error(err))
-		return err
-	}
-	address, err := m.opClient.SecretsAPI.Resolve(ctx, m.src.GRPCClient.AddressRef)
-	if err != nil {
-		m.logger.Error("failed to resolve gRPC client address", zap.Error(err))
-		return err
+	resolved := &GRPCClientCredentials{}
+	if m.src.GRPCClient.CertVaultRef != "" || m.src.GRPCClient.CertItemRef != "" {
+		item, err := m.extractItem(ctx, m.src.GRPCClient.CertVaultRef, m.src.GRPCClient.CertItemRef)
+		if err != nil {
+			m.logger.Error("failed to extract gRPC client cert item", zap.Error(err))
+			return err
+		}
+		files, err := m.readItemFiles(ctx, item, []string{"ca.pem", "server.crt", "server.key"})
+		if err != nil {
+			m.logger.Error("failed to read gRPC client cert files", zap.Error(err))
+			return err
+		}
+		tlsConfig, err := buildTLSConfig(files["ca.pem"], files["server.crt"], files["server.key"])
+		if err != nil {
+			m.logger.Error("failed to create TLS config for gRPC client", zap.Error(err))
+			return err
+		}
+		resolved.Credentials = credentials.NewTLS(tlsConfig)
 	}
-	m.Credentials.GRPCClient = &GRPCClientCredentials{
-		Address:     address,
-		Credentials: credentials.NewTLS(tlsConfig),
+	if m.src.GRPCClient.AddressRef != "" {
+		address, err := m.opClient.SecretsAPI.Resolve(ctx, m.src.GRPCClient.AddressRef)
+		if err != nil {
+			m.logger.Error("failed to resolve gRPC client address", zap.Error(err))
+			return err
+		}
+		resolved.Address = address
 	}
+	m.Credentials.GRPCClient = resolved
 	return nil
 }
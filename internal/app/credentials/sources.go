@@ -17,15 +17,24 @@
 
 package credentials
 
-import "os"
-
 type Sources struct {
-	GRPCServer    GRPCServerRefs
-	GRPCClient    GRPCClientRefs
-	PostgresDB    PostgresDBRefs
-	MongoDB       MongoDBRefs
+	GRPCServer GRPCServerRefs
+	GRPCClient GRPCClientRefs
+	PostgresDB PostgresDBRefs
+	MongoDB    MongoDBRefs
+	// ArangoDB refs are empty unless the metadata is stored in ArangoDB.
+	ArangoDB      ArangoDBRefs
 	MuxAPI        MuxAPIRefs
 	CloudinaryAPI CloudinaryAPRefs
+	// S3 refs are empty unless the object storage is enabled.
+	S3 S3Refs
+	// CFStream refs are empty unless Cloudflare Stream is enabled.
+	CFStream CFStreamRefs
+	// SigningKeys refs are empty unless the MUX signing key rotation is enabled.
+	SigningKeys SigningKeysRefs
+	// Environments holds the API refs of the tenant environments besides the default one, which
+	// uses MuxAPI and CloudinaryAPI.
+	Environments map[string]EnvironmentRefs
 }
 
 type GRPCServerRefs struct {
@@ -45,12 +54,21 @@ type PostgresDBRefs struct {
 	UserRef     string
 	PasswordRef string
 	DBNameRef   string
+	// ReplicaHostRef and ReplicaPortRef locate the read replica. They are optional, the replica is
+	// only resolved when both are set.
+	ReplicaHostRef string
+	ReplicaPortRef string
 }
 
 type MongoDBRefs struct {
 	ConnectionStringRef string
 }
 
+type ArangoDBRefs struct {
+	UserRef     string
+	PasswordRef string
+}
+
 type MuxAPIRefs struct {
 	APITokenRef              string
 	SecretKeyRef             string
@@ -65,38 +83,24 @@ type CloudinaryAPRefs struct {
 	APISecretRef string
 }
 
-func LoadSources() *Sources {
-	return &Sources{
-		GRPCServer: GRPCServerRefs{
-			CertVaultRef: os.Getenv("GRPC_SERVER_CERT_VAULT_REF"),
-			CertItemRef:  os.Getenv("GRPC_SERVER_CERT_ITEM_REF"),
-		},
-		GRPCClient: GRPCClientRefs{
-			AddressRef:   os.Getenv("GRPC_CLIENT_ADDRESS_REF"),
-			CertVaultRef: os.Getenv("GRPC_CLIENT_CERT_VAULT_REF"),
-			CertItemRef:  os.Getenv("GRPC_CLIENT_CERT_ITEM_REF"),
-		},
-		PostgresDB: PostgresDBRefs{
-			HostRef:     os.Getenv("POSTGRES_HOST_REF"),
-			PortRef:     os.Getenv("POSTGRES_PORT_REF"),
-			UserRef:     os.Getenv("POSTGRES_USER_REF"),
-			PasswordRef: os.Getenv("POSTGRES_PASSWORD_REF"),
-			DBNameRef:   os.Getenv("POSTGRES_DBNAME_REF"),
-		},
-		MongoDB: MongoDBRefs{
-			ConnectionStringRef: os.Getenv("MONGO_CONNECTION_STRING_REF"),
-		},
-		MuxAPI: MuxAPIRefs{
-			APITokenRef:              os.Getenv("MUX_API_TOKEN_REF"),
-			SecretKeyRef:             os.Getenv("MUX_SECRET_KEY_REF"),
-			SigningKeyIDRef:          os.Getenv("MUX_SIGNING_KEY_ID_REF"),
-			SigningKeyPrivateRef:     os.Getenv("MUX_SIGNING_KEY_PRIVATE_REF"),
-			PlaybackRestrictionIDRef: os.Getenv("MUX_PLAYBACK_RESTRICTION_ID_REF"),
-		},
-		CloudinaryAPI: CloudinaryAPRefs{
-			CloudNameRef: os.Getenv("CLD_CLOUD_NAME_REF"),
-			APIKeyRef:    os.Getenv("CLD_API_KEY_REF"),
-			APISecretRef: os.Getenv("CLD_API_SECRET_REF"),
-		},
-	}
+type EnvironmentRefs struct {
+	MuxAPI        MuxAPIRefs
+	CloudinaryAPI CloudinaryAPRefs
+}
+
+type S3Refs struct {
+	AccessKeyIDRef     string
+	SecretAccessKeyRef string
+}
+
+// CFStreamRefs locate the Cloudflare Stream secrets. The signing key refs are optional, the signing
+// key is only resolved when both are set.
+type CFStreamRefs struct {
+	APITokenRef          string
+	SigningKeyIDRef      string
+	SigningKeyPrivateRef string
+}
+
+type SigningKeysRefs struct {
+	EncryptionKeyRef string
 }
@@ -19,43 +19,153 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/mikhail5545/media-service-go/internal/database/arango"
 	mongodb "github.com/mikhail5545/media-service-go/internal/database/mongo"
 	"github.com/mikhail5545/media-service-go/internal/database/postgres"
+	"github.com/mikhail5545/media-service-go/internal/tracing"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
 )
 
+// postgresDSN builds the PostgreSQL DSN from the resolved credentials.
+func (a *App) postgresDSN() string {
+	pgCfg := a.manager.Credentials.PostgresDB
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", pgCfg.Host, pgCfg.Port, pgCfg.User, pgCfg.Password, pgCfg.DBName)
+}
+
+// postgresReplicaDSN builds the DSN of the read replica, which shares the primary's user, password
+// and database name.
+func (a *App) postgresReplicaDSN() string {
+	pgCfg := a.manager.Credentials.PostgresDB
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", pgCfg.ReplicaHost, pgCfg.ReplicaPort, pgCfg.User, pgCfg.Password, pgCfg.DBName)
+}
+
 func (a *App) setupPostgresDB(ctx context.Context) (*gorm.DB, error) {
+	dsn := a.postgresDSN()
+	if err := a.ensureSchema(dsn); err != nil {
+		return nil, err
+	}
+
+	db, err := a.openPostgres(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+	a.logger.Info("database connection established.")
+	return db, nil
+}
+
+// setupPostgresReplica connects to the read replica. It returns a nil DB when read routing is
+// disabled, in which case the repositories read from the primary.
+func (a *App) setupPostgresReplica(ctx context.Context) (*gorm.DB, error) {
+	if !a.Cfg.ReadReplica.Enabled {
+		return nil, nil
+	}
 	pgCfg := a.manager.Credentials.PostgresDB
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", pgCfg.Host, pgCfg.Port, pgCfg.User, pgCfg.User, pgCfg.Password)
-	a.logger.Info("database DSN prepared", zap.String("dsn", dsn))
+	if pgCfg.ReplicaHost == "" || pgCfg.ReplicaPort == "" {
+		a.logger.Error("read replica is enabled but its address is not resolved")
+		return nil, fmt.Errorf("read replica is enabled but its address is not resolved")
+	}
+	db, err := a.openPostgres(ctx, a.postgresReplicaDSN())
+	if err != nil {
+		return nil, err
+	}
+	a.logger.Info("read replica connection established.", zap.String("host", pgCfg.ReplicaHost))
+	return db, nil
+}
 
+// openPostgres connects to dsn and registers the environment, tracing and metrics plugins.
+func (a *App) openPostgres(ctx context.Context, dsn string) (*gorm.DB, error) {
 	db, err := postgres.NewPostgresDB(ctx, dsn)
 	if err != nil {
 		a.logger.Error("Failed to connect to database", zap.Error(err))
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
-	a.logger.Info("database connection established.")
+	if err := db.Use(postgres.EnvironmentPlugin{}); err != nil {
+		a.logger.Error("failed to register database environment plugin", zap.Error(err))
+		return nil, fmt.Errorf("failed to register database environment plugin: %w", err)
+	}
+	if err := db.Use(gormtracing.NewPlugin(gormtracing.WithoutMetrics(), gormtracing.WithoutQueryVariables())); err != nil {
+		a.logger.Error("failed to register database tracing plugin", zap.Error(err))
+		return nil, fmt.Errorf("failed to register database tracing plugin: %w", err)
+	}
+	if a.metrics != nil {
+		if err := db.Use(a.metrics.GormPlugin()); err != nil {
+			a.logger.Error("failed to register database metrics plugin", zap.Error(err))
+			return nil, fmt.Errorf("failed to register database metrics plugin: %w", err)
+		}
+	}
 	return db, nil
 }
 
 func (a *App) setupMongoDB(ctx context.Context) (*mongo.Database, error) {
 	serverAPI := options.ServerAPI(options.ServerAPIVersion1)
-	opts := options.Client().ApplyURI(a.manager.Credentials.MongoDB.ConnectionString).SetServerAPIOptions(serverAPI)
+	opts := options.Client().
+		ApplyURI(a.manager.Credentials.MongoDB.ConnectionString).
+		SetServerAPIOptions(serverAPI).
+		SetMonitor(tracing.MongoMonitor())
 	client, err := mongo.Connect(opts)
 	if err != nil {
 		a.logger.Error("Failed to connect to MongoDB", zap.Error(err))
 		return nil, err
 	}
 
-	db, err := mongodb.NewMongoDB(ctx, client, a.manager.Credentials.MongoDB.DBName)
+	db, err := mongodb.NewMongoDB(ctx, client, a.Cfg.MongoDB.DbName)
 	if err != nil {
 		a.logger.Error("Failed to ping MongoDB", zap.Error(err))
 		return nil, err
 	}
 	return db, nil
 }
+
+// setupArangoDB connects to ArangoDB and creates the metadata collections which do not exist yet.
+func (a *App) setupArangoDB(ctx context.Context) (arangodb.Database, error) {
+	creds := a.manager.Credentials.ArangoDB
+	db, err := arango.NewArangoDB(ctx, a.Cfg.ArangoDB.Endpoints, creds.User, creds.Password, a.Cfg.ArangoDB.DbName)
+	if err != nil {
+		a.logger.Error("Failed to connect to ArangoDB", zap.Error(err))
+		return nil, err
+	}
+	for _, name := range []string{muxMetadataCollection, cldMetadataCollection} {
+		if err := arango.EnsureCollection(ctx, db, name); err != nil {
+			a.logger.Error("failed to create ArangoDB collection", zap.Error(err), zap.String("collection", name))
+			return nil, fmt.Errorf("failed to create ArangoDB collection %q: %w", name, err)
+		}
+	}
+	a.logger.Info("ArangoDB connection established.", zap.String("database", a.Cfg.ArangoDB.DbName))
+	return db, nil
+}
+
+// closeDatabases closes the PostgreSQL connection pools and disconnects the MongoDB client.
+func (a *App) closeDatabases() error {
+	var errs []error
+	if a.postgresReplica != nil {
+		if sqlDB, err := a.postgresReplica.DB(); err == nil {
+			if err := sqlDB.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close read replica: %w", err))
+			}
+		}
+	}
+	if a.postgresDB != nil {
+		if sqlDB, err := a.postgresDB.DB(); err == nil {
+			if err := sqlDB.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close database: %w", err))
+			}
+		}
+	}
+	if a.mongoDB != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := a.mongoDB.Client().Disconnect(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to disconnect from MongoDB: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -17,39 +17,12 @@
 
 package app
 
-type Config struct {
-	HTTP                           HTTPConfig
-	GRPC                           GRPCConfig
-	GRPCClient                     GRPCClientConfig
-	Log                            LogConfig
-	MongoDB                        MongoDBConfig
-	GracefulShutdownTimeoutSeconds int
-	Mux                            MuxAPIConfig
-}
-
-type HTTPConfig struct {
-	Port int64
-}
-
-type GRPCConfig struct {
-	Port int64
-}
-
-type LogConfig struct {
-	Directory    string
-	UseTimestamp bool
-	AppName      string
-}
-
-type MuxAPIConfig struct {
-	TestMode   bool
-	CORSOrigin string
-}
-
-type MongoDBConfig struct {
-	DbName string
-}
-
-type GRPCClientConfig struct {
-	Address string
+import "github.com/mikhail5545/media-service-go/internal/entitlement"
+
+// entitlements returns the checker of the end user media endpoints, nil unless they are enabled.
+func (a *App) entitlements() entitlement.Checker {
+	if !a.Cfg.Delivery.Enabled {
+		return nil
+	}
+	return entitlement.NewUserOwners(a.Cfg.Delivery.UserOwnerTypes...)
 }
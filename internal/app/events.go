@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/bus"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/notify"
+	"github.com/mikhail5545/media-service-go/internal/services/subscription"
+	"go.uber.org/zap"
+)
+
+// setupEventPublisher returns the event publisher of the configured broker, decorated with the
+// outgoing webhook deliveries if they are enabled.
+func (a *App) setupEventPublisher(repos *Repositories) (events.Publisher, error) {
+	topics := make(map[events.Type]string, len(a.Cfg.Events.Topics))
+	for eventType, topic := range a.Cfg.Events.Topics {
+		topics[events.Type(eventType)] = topic
+	}
+	publisher, err := events.New(events.Config{
+		Broker:      events.Broker(a.Cfg.Events.Broker),
+		URLs:        a.Cfg.Events.URLs,
+		Encoding:    events.Encoding(a.Cfg.Events.Encoding),
+		TopicPrefix: a.Cfg.Events.TopicPrefix,
+		Topics:      topics,
+	}, a.logger)
+	if err != nil {
+		a.logger.Error("failed to setup event publisher", zap.Error(err))
+		return nil, err
+	}
+	if a.Cfg.OutgoingWebhooks.Enabled {
+		publisher = subscription.NewPublisher(publisher, repos.Postgres.SubscriptionRepo, a.logger)
+	}
+	return publisher, nil
+}
+
+// setupEventBus returns the event bus the services publish to. The broker publisher, the notifier and
+// the hub of the status watches and the live admin stream subscribe to it asynchronously, the services
+// register their own subscribers once they are created.
+func (a *App) setupEventBus(repos *Repositories, publisher events.Publisher) (*bus.Bus, error) {
+	eventBus := bus.New(a.logger)
+	eventBus.SubscribeAsync("broker", publisher.Publish)
+	if a.Cfg.Notifications.Enabled {
+		notifier, err := notify.New(a.notificationConfig(repos), a.logger)
+		if err != nil {
+			a.logger.Error("failed to setup notifications", zap.Error(err))
+			_ = eventBus.Close()
+			return nil, err
+		}
+		eventBus.SubscribeAsync("notifications", notifier.Handle)
+	}
+	eventBus.SubscribeAsync("hub", a.hub.Handle)
+	return eventBus, nil
+}
+
+func (a *App) notificationConfig(repos *Repositories) notify.Config {
+	cfg := a.Cfg.Notifications
+	client := &http.Client{Timeout: cfg.Timeout}
+
+	sinks := make(map[string]notify.Sink, len(cfg.Sinks))
+	for name, sink := range cfg.Sinks {
+		switch sink.Type {
+		case "smtp":
+			sinks[name] = &notify.SMTPSink{
+				Host:     sink.Host,
+				Port:     sink.Port,
+				Username: sink.Username,
+				Password: sink.Password,
+				From:     sink.From,
+				To:       sink.To,
+			}
+		case "slack":
+			sinks[name] = &notify.SlackSink{URL: sink.URL, Client: client}
+		case "webhook":
+			sinks[name] = &notify.WebhookSink{URL: sink.URL, Secret: sink.Secret, Client: client}
+		}
+	}
+
+	rules := make([]notify.Rule, 0, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		r := notify.Rule{OwnerTypes: rule.OwnerTypes, Sinks: rule.Sinks}
+		for _, t := range rule.EventTypes {
+			r.EventTypes = append(r.EventTypes, events.Type(t))
+		}
+		for _, p := range rule.Providers {
+			r.Providers = append(r.Providers, events.Provider(p))
+		}
+		rules = append(rules, r)
+	}
+
+	return notify.Config{
+		Sinks:   sinks,
+		Rules:   rules,
+		Timeout: cfg.Timeout,
+		Owners:  assetOwnerResolver(repos.Metadata),
+	}
+}
+
+// assetOwnerResolver looks up the owners of the asset in the metadata store of its provider.
+func assetOwnerResolver(repos *MetadataRepositories) notify.OwnerResolver {
+	return func(ctx context.Context, provider events.Provider, assetID uuid.UUID) ([]events.Owner, error) {
+		var owners []events.Owner
+		switch provider {
+		case events.ProviderMux:
+			metadata, err := repos.MuxMetaRepo.Get(ctx, assetID.String(), "owners")
+			if err != nil {
+				return nil, err
+			}
+			for _, o := range metadata.Owners {
+				owners = append(owners, events.Owner{OwnerID: o.OwnerID, OwnerType: o.OwnerType})
+			}
+		case events.ProviderCloudinary:
+			metadata, err := repos.CldMetaRepo.Get(ctx, assetID.String(), "owners")
+			if err != nil {
+				return nil, err
+			}
+			for _, o := range metadata.Owners {
+				owners = append(owners, events.Owner{OwnerID: o.OwnerID, OwnerType: o.OwnerType})
+			}
+		default:
+			metadata, err := repos.MediaMetaRepo.Get(ctx, assetID.String())
+			if err != nil {
+				return nil, err
+			}
+			for _, o := range metadata.Owners {
+				owners = append(owners, events.Owner{OwnerID: o.OwnerID, OwnerType: o.OwnerType})
+			}
+		}
+		return owners, nil
+	}
+}
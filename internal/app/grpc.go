@@ -18,19 +18,78 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strconv"
 
+	"github.com/mikhail5545/media-service-go/internal/deadline"
+	"github.com/mikhail5545/media-service-go/internal/environment"
 	"github.com/mikhail5545/media-service-go/internal/grpc/cloudinary"
+	"github.com/mikhail5545/media-service-go/internal/grpc/interceptors"
 	"github.com/mikhail5545/media-service-go/internal/grpc/mux"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
-func registerGRPCServices(server *grpc.Server, services *Services, logger *zap.Logger) {
+type grpcServerConfig struct {
+	serverOpts []grpc.ServerOption
+	health     *grpchealth.Server
+	reflection bool
+}
+
+// GRPCServerOption configures a server created by NewGRPCServer.
+type GRPCServerOption func(*grpcServerConfig)
+
+// WithGRPCServerOptions passes options to the underlying gRPC server, e.g. credentials and
+// interceptors.
+func WithGRPCServerOptions(opts ...grpc.ServerOption) GRPCServerOption {
+	return func(c *grpcServerConfig) {
+		c.serverOpts = append(c.serverOpts, opts...)
+	}
+}
+
+// WithGRPCHealth registers the health server instead of a new one, so that its serving status can be
+// updated by the caller.
+func WithGRPCHealth(health *grpchealth.Server) GRPCServerOption {
+	return func(c *grpcServerConfig) {
+		c.health = health
+	}
+}
+
+// WithGRPCReflection registers the server reflection service, which lets tools like grpcurl
+// discover the services without the proto files. The reflection stream is not authenticated, it
+// should only be enabled on servers which are not reachable by untrusted clients.
+func WithGRPCReflection(enabled bool) GRPCServerOption {
+	return func(c *grpcServerConfig) {
+		c.reflection = enabled
+	}
+}
+
+// NewGRPCServer creates a gRPC server with the MUX and Cloudinary asset services and the health
+// service registered. It does not listen, the caller serves it on any listener, e.g. an in-memory
+// listener in tests.
+func NewGRPCServer(services *Services, logger *zap.Logger, opts ...GRPCServerOption) *grpc.Server {
+	cfg := &grpcServerConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.health == nil {
+		cfg.health = grpchealth.NewServer()
+	}
+
+	server := grpc.NewServer(cfg.serverOpts...)
 	mux.Register(server, services.MuxSvc, logger)
 	cloudinary.Register(server, services.CldSvc, logger)
+	healthpb.RegisterHealthServer(server, cfg.health)
+	if cfg.reflection {
+		reflection.Register(server)
+	}
+	return server
 }
 
 func (a *App) prepareGRPCServer() (*grpc.Server, net.Listener, error) {
@@ -41,21 +100,70 @@ func (a *App) prepareGRPCServer() (*grpc.Server, net.Listener, error) {
 		return nil, nil, fmt.Errorf("failed to listen on gRPC address %s: %w", grpcListenAddr, err)
 	}
 
-	grpcServer := grpc.NewServer(grpc.Creds(a.manager.Credentials.GRPCServer.Credentials))
-	registerGRPCServices(grpcServer, a.services, a.logger)
+	creds, err := a.grpcServerCredentials()
+	if err != nil {
+		_ = list.Close()
+		return nil, nil, err
+	}
+
+	serverOpts := []grpc.ServerOption{
+		grpc.Creds(creds),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+	}
+	serverOpts = append(serverOpts, interceptors.ServerOptions(a.interceptorOptions(), a.logger)...)
+
+	a.grpcHealth = grpchealth.NewServer()
+	grpcServer := NewGRPCServer(a.services, a.logger,
+		WithGRPCServerOptions(serverOpts...),
+		WithGRPCHealth(a.grpcHealth),
+		WithGRPCReflection(a.Cfg.GRPC.Reflection),
+	)
 	return grpcServer, list, nil
 }
 
-func runGRPCServer(errChan chan<- error, grpcServer *grpc.Server, listener net.Listener, logger *zap.Logger) {
-	logger.Info("starting gRPC server", zap.String("address", listener.Addr().String()))
-	if err := grpcServer.Serve(listener); err != nil {
-		errChan <- err
-	} else {
-		errChan <- nil
+// interceptorOptions builds the interceptor chain options from the gRPC configuration.
+// Request ID propagation and panic recovery are always enabled.
+func (a *App) interceptorOptions() interceptors.Options {
+	opts := interceptors.Options{
+		RequestID:   true,
+		Recovery:    true,
+		Logging:     a.Cfg.GRPC.LogRequests,
+		Auth:        a.auth.grpcInterceptor(),
+		Environment: environment.UnaryInterceptor(a.environmentNames()),
+		Deadline:    deadline.UnaryInterceptor(a.timeoutRoutes(a.Cfg.Timeouts.GRPC)),
+	}
+	if a.metrics != nil {
+		opts.Metrics = a.metrics
+	} else if a.Cfg.GRPC.Metrics {
+		if a.grpcMetrics == nil {
+			a.grpcMetrics = interceptors.NewMetrics()
+		}
+		opts.Metrics = a.grpcMetrics
 	}
+	return opts
 }
 
-func shutdownGRPCServer(grpcServer *grpc.Server, done chan<- struct{}) {
-	grpcServer.GracefulStop()
-	close(done)
+func runGRPCServer(grpcServer *grpc.Server, listener net.Listener, logger *zap.Logger) error {
+	logger.Info("starting gRPC server", zap.String("address", listener.Addr().String()))
+	return grpcServer.Serve(listener)
+}
+
+// shutdownGRPCServer stops accepting new RPCs and waits for pending ones. If ctx is done first,
+// the remaining RPCs are cancelled.
+func shutdownGRPCServer(ctx context.Context, grpcServer *grpc.Server, logger *zap.Logger) error {
+	done := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logger.Info("gRPC server shutdown complete")
+		return nil
+	case <-ctx.Done():
+		logger.Warn("gRPC graceful shutdown timed out, forcing stop")
+		grpcServer.Stop()
+		return fmt.Errorf("gRPC graceful shutdown timed out: %w", ctx.Err())
+	}
 }
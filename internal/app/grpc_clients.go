@@ -14,50 +14,75 @@
  * You should have received a copy of the GNU Affero General Public License
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
-
 package app
 
 import (
-	"context"
-	"time"
-
-	"github.com/mikhail5545/product-service-client/client"
+	"github.com/mikhail5545/media-service-go/internal/config"
+	"github.com/mikhail5545/media-service-go/internal/grpc/clientpool"
+	"github.com/mikhail5545/media-service-go/internal/grpc/interceptors"
+	imagepbv1 "github.com/mikhail5545/product-service-client/pb/product_service/image/v1"
+	videopbv1 "github.com/mikhail5545/product-service-client/pb/product_service/video/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// Names of the pooled downstream connections, used in logs, metrics and health checks.
+const (
+	videoServiceConn = "video_service"
+	imageServiceConn = "image_service"
 )
 
 type GRPCClients struct {
-	VideoSvcClient *client.VideoServiceClient
-	ImageSvcClient *client.ImageServiceClient
+	VideoSvcClient videopbv1.VideoServiceClient
+	ImageSvcClient imagepbv1.ImageServiceClient
+	// Pool owns the connections of the clients.
+	Pool *clientpool.Pool
 }
 
-func (a *App) setupGRPCClients(ctx context.Context) (*GRPCClients, error) {
-	videoClient, err := client.NewVideoServiceClient(client.WithTimeout(10, time.Second))
+func (a *App) setupGRPCClients() (*GRPCClients, error) {
+	creds, err := a.grpcClientCredentials()
+	if err != nil {
+		return nil, err
+	}
+	pool := clientpool.New(a.logger, a.metrics.GRPCConnStateObserver(),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(interceptors.UnaryClientRequestID()),
+	)
+
+	videoConn, err := pool.Dial(videoServiceConn, a.downstreamTarget(a.Cfg.GRPCClient.Video))
 	if err != nil {
 		a.logger.Error("failed to create Video Service gRPC client", zap.Error(err))
+		_ = pool.Close()
 		return nil, err
 	}
-	imageClient, err := client.NewImageServiceClient(client.WithTimeout(10, time.Second))
+	imageConn, err := pool.Dial(imageServiceConn, a.downstreamTarget(a.Cfg.GRPCClient.Image))
 	if err != nil {
 		a.logger.Error("failed to create Image Service gRPC client", zap.Error(err))
+		_ = pool.Close()
 		return nil, err
 	}
+	return &GRPCClients{
+		VideoSvcClient: videopbv1.NewVideoServiceClient(videoConn),
+		ImageSvcClient: imagepbv1.NewImageServiceClient(imageConn),
+		Pool:           pool,
+	}, nil
+}
 
-	if err := videoClient.Connect(ctx,
-		a.manager.Credentials.GRPCClient.Address,
-		client.WithTransportCredentials(a.manager.Credentials.GRPCClient.Credentials),
-	); err != nil {
-		a.logger.Error("failed to connect to Video Service gRPC server", zap.Error(err))
-		return nil, err
+// downstreamTarget falls back to the shared product service address, which may be resolved from 1Password.
+func (a *App) downstreamTarget(cfg config.DownstreamConfig) clientpool.Target {
+	address := cfg.Address
+	if address == "" {
+		address = a.Cfg.GRPCClient.Address
 	}
-	if err := imageClient.Connect(ctx,
-		a.manager.Credentials.GRPCClient.Address,
-		client.WithTransportCredentials(a.manager.Credentials.GRPCClient.Credentials),
-	); err != nil {
-		a.logger.Error("failed to connect to Image Service gRPC server", zap.Error(err))
-		return nil, err
+	if address == "" {
+		address = a.manager.Credentials.GRPCClient.Address
+	}
+	return clientpool.Target{
+		Address:      address,
+		Timeout:      cfg.Timeout,
+		Reserve:      a.Cfg.Timeouts.Reserve,
+		WaitForReady: cfg.WaitForReady,
 	}
-	return &GRPCClients{
-		VideoSvcClient: videoClient,
-		ImageSvcClient: imageClient,
-	}, nil
 }
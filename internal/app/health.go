@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikhail5545/media-service-go/internal/health"
+	cldassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/asset/v1"
+	muxassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/asset/v1"
+	"go.uber.org/zap"
+	grpchealth "google.golang.org/grpc/health"
+)
+
+// setupHealth builds the readiness checks. The databases are critical, the provider APIs and product
+// service only degrade the service because reads keep working while they are unreachable. The
+// provider API results are cached, every probe would otherwise call the rate limited APIs.
+func (a *App) setupHealth(apiClients *ApiClients, grpcClients *GRPCClients) (*health.Checker, error) {
+	cfg := a.Cfg.Health
+	checks := []health.Check{
+		{
+			Name:     "postgres",
+			Timeout:  cfg.PostgresTimeout,
+			Critical: true,
+			Probe: func(ctx context.Context) error {
+				sqlDB, err := a.postgresDB.DB()
+				if err != nil {
+					return err
+				}
+				return sqlDB.PingContext(ctx)
+			},
+		},
+		{
+			Name:     "mongo",
+			Timeout:  cfg.MongoTimeout,
+			Critical: true,
+			Probe: func(ctx context.Context) error {
+				return a.mongoDB.Client().Ping(ctx, nil)
+			},
+		},
+		{
+			Name:     "mux_api",
+			Timeout:  cfg.MuxTimeout,
+			CacheFor: cfg.ProviderCacheTTL,
+			Probe:    apiClients.MuxClient.Ping,
+		},
+		{
+			Name:     "cloudinary_api",
+			Timeout:  cfg.CloudinaryTimeout,
+			CacheFor: cfg.ProviderCacheTTL,
+			Probe:    apiClients.CldClient.Ping,
+		},
+		// The connection states are monitored by the pool, so the probes do not call product service.
+		{
+			Name:  videoServiceConn,
+			Probe: grpcClients.Pool.Probe(videoServiceConn),
+		},
+		{
+			Name:  imageServiceConn,
+			Probe: grpcClients.Pool.Probe(imageServiceConn),
+		},
+	}
+	if a.arangoDB != nil {
+		checks = append(checks, health.Check{
+			Name:     "arango",
+			Timeout:  cfg.ArangoTimeout,
+			Critical: true,
+			Probe: func(ctx context.Context) error {
+				_, err := a.arangoDB.Info(ctx)
+				return err
+			},
+		})
+	}
+	if a.postgresReplica != nil {
+		// A lagging or unreachable replica only degrades the service, writes keep working.
+		checks = append(checks, health.Check{
+			Name:    "postgres_replica",
+			Timeout: cfg.PostgresTimeout,
+			Probe: func(ctx context.Context) error {
+				sqlDB, err := a.postgresReplica.DB()
+				if err != nil {
+					return err
+				}
+				return sqlDB.PingContext(ctx)
+			},
+		})
+	}
+	if apiClients.S3Client != nil {
+		checks = append(checks, health.Check{
+			Name:     "s3_api",
+			Timeout:  cfg.S3Timeout,
+			CacheFor: cfg.ProviderCacheTTL,
+			Probe:    apiClients.S3Client.Ping,
+		})
+	}
+	if apiClients.CfStreamClient != nil {
+		checks = append(checks, health.Check{
+			Name:     "cfstream_api",
+			Timeout:  cfg.CFStreamTimeout,
+			CacheFor: cfg.ProviderCacheTTL,
+			Probe:    apiClients.CfStreamClient.Ping,
+		})
+	}
+	if a.Cfg.Cache.Enabled {
+		// Lookups fall back to the databases while Redis is unreachable.
+		checks = append(checks, health.Check{
+			Name:    "redis",
+			Timeout: cfg.RedisTimeout,
+			Probe:   a.cache.Ping,
+		})
+	}
+	checker, err := health.New(a.logger, checks...)
+	if err != nil {
+		a.logger.Error("failed to setup health checks", zap.Error(err))
+		return nil, fmt.Errorf("failed to setup health checks: %w", err)
+	}
+	return checker, nil
+}
+
+// runHealthWatcher publishes the check results to the gRPC health service until ctx is done.
+func (a *App) runHealthWatcher(ctx context.Context, srv *grpchealth.Server) {
+	go a.health.Watch(ctx, srv, a.Cfg.Health.GRPCInterval,
+		muxassetpbv1.AssetService_ServiceDesc.ServiceName,
+		cldassetpbv1.AssetService_ServiceDesc.ServiceName,
+	)
+}
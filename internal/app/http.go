@@ -18,51 +18,201 @@
 package app
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
-	"time"
+	"net/http"
+	"slices"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	"github.com/mikhail5545/media-service-go/internal/deadline"
+	"github.com/mikhail5545/media-service-go/internal/emulator"
+	"github.com/mikhail5545/media-service-go/internal/environment"
+	cldgrpc "github.com/mikhail5545/media-service-go/internal/grpc/cloudinary"
+	muxgrpc "github.com/mikhail5545/media-service-go/internal/grpc/mux"
+	deliveryhandler "github.com/mikhail5545/media-service-go/internal/handlers/delivery"
+	devhandler "github.com/mikhail5545/media-service-go/internal/handlers/dev"
 	errorhandler "github.com/mikhail5545/media-service-go/internal/handlers/errors"
+	healthhandler "github.com/mikhail5545/media-service-go/internal/handlers/health"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	"github.com/mikhail5545/media-service-go/internal/openapi"
+	"github.com/mikhail5545/media-service-go/internal/payload"
 	"github.com/mikhail5545/media-service-go/internal/routers"
 	"github.com/mikhail5545/media-service-go/internal/routers/admin"
+	"github.com/mikhail5545/media-service-go/internal/routers/delivery"
+	"github.com/mikhail5545/media-service-go/internal/routers/gateway"
+	"github.com/mikhail5545/media-service-go/internal/routers/webhooks"
+	"github.com/mikhail5545/media-service-go/internal/viewer"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 	"go.uber.org/zap"
 )
 
-func setupRouters(e *echo.Echo, services *Services) {
+func (a *App) setupRouters(e *echo.Echo) {
+	services := a.services
+
+	use := []echo.MiddlewareFunc{otelecho.Middleware(a.Cfg.Log.AppName)}
+	if a.metrics != nil {
+		use = append(use, a.metrics.EchoMiddleware())
+		e.GET(a.Cfg.Metrics.Path, echo.WrapHandler(a.metrics.Handler()))
+	}
+	use = append(use,
+		logging.EchoMiddleware(a.logger),
+		environment.EchoMiddleware(a.environmentNames()),
+		middleware.Recover(),
+		deadline.EchoMiddleware(a.timeoutRoutes(a.Cfg.Timeouts.HTTP)),
+		payload.EchoMiddleware(a.payloadPolicy()),
+	)
+	// The admin, end user and gateway routes are authenticated on top of the shared chain. The
+	// webhooks are not, the providers are verified by the webhook signatures instead.
+	authenticated := []echo.MiddlewareFunc{audit.EchoMiddleware()}
+	if authMiddleware := a.auth.httpMiddleware(); authMiddleware != nil {
+		authenticated = append(authenticated, authMiddleware)
+	}
+	e.JSONSerializer = payload.JSONSerializer{MaxDepth: a.Cfg.Payload.MaxJSONDepth}
+
+	healthHandler := healthhandler.New(a.health)
+	e.GET("/healthz", healthHandler.Liveness)
+	e.GET("/readyz", healthHandler.Readiness)
+
 	baseGroup := routers.Init(e, routers.Config{
-		Api: "/api",
-		Ver: "/v1",
-		Use: []echo.MiddlewareFunc{
-			middleware.Logger(),
-			middleware.Recover(),
-			middleware.ContextTimeout(60 * time.Second),
-		},
+		Api:              "/api",
+		Ver:              "/v1",
+		Use:              use,
 		HTTPErrorHandler: errorhandler.HTTPErrorHandler,
 	})
 
 	adminRtr := admin.New(admin.Dependencies{
-		CldSvc: services.CldSvc,
-		MuxSvc: services.MuxSvc,
+		CldSvc:          services.CldSvc,
+		MuxSvc:          services.MuxSvc,
+		CollectionSvc:   services.CollectionSvc,
+		WatermarkSvc:    services.WatermarkSvc,
+		AuditSvc:        services.AuditSvc,
+		PlaybackSvc:     services.PlaybackSvc,
+		UsageSvc:        services.UsageSvc,
+		CostSvc:         services.CostSvc,
+		MediaRegistry:   services.MediaRegistry,
+		CatalogSvc:      services.CatalogSvc,
+		S3Svc:           services.S3Svc,
+		CfStreamSvc:     services.CfStreamSvc,
+		UploadProxySvc:  services.UploadProxySvc,
+		ExportSvc:       services.ExportSvc,
+		ImportSvc:       services.ImportSvc,
+		WebhookQueue:    services.WebhookQueue,
+		RetentionWorker: a.workers.RetentionWorker,
+		SubscriptionSvc: services.SubscriptionSvc,
+		SigningKeySvc:   services.SigningKeySvc,
+		SeedSvc:         services.SeedSvc,
+		Hub:             a.hub,
+	})
+	adminRtr.Register(baseGroup, authenticated...)
+
+	webhooksRtr := webhooks.New(webhooks.Dependencies{
+		CldSvc:      services.CldSvc,
+		MuxSvc:      services.MuxSvc,
+		CfStreamSvc: services.CfStreamSvc,
+		Queue:       services.WebhookQueue,
+		Metrics:     a.metrics,
 	})
-	adminRtr.Setup(baseGroup)
+	webhooksRtr.Register(baseGroup)
+
+	// The emulated provider endpoints stand in for the provider APIs, which are not authenticated
+	// with the credentials of the service either.
+	if emulators := a.apiClients.Emulators; emulators != nil {
+		devhandler.New(emulators, services.WebhookQueue).Register(baseGroup)
+	}
+
+	if a.Cfg.Delivery.Enabled {
+		deliveryRtr := delivery.New(delivery.Dependencies{
+			MuxSvc: services.MuxSvc,
+			CldSvc: services.CldSvc,
+			Tokens: deliveryhandler.TokenDelivery{
+				CookieName:   a.Cfg.Delivery.TokenCookie.Name,
+				CookieDomain: a.Cfg.Delivery.TokenCookie.Domain,
+				CookiePath:   cmp.Or(a.Cfg.Delivery.TokenCookie.Path, "/"),
+				Header:       a.Cfg.Delivery.TokenHeader,
+			},
+		})
+		// The viewer is checked against the playback restrictions of the requested asset.
+		deliveryRtr.Register(baseGroup, slices.Concat(authenticated, []echo.MiddlewareFunc{
+			viewer.EchoMiddleware(a.Cfg.Playback.Restrictions.CountryHeader),
+		})...)
+	}
+
+	if a.Cfg.HTTP.Gateway {
+		gatewayRtr := gateway.New(gateway.Dependencies{
+			MuxServer: muxgrpc.New(services.MuxSvc, a.logger),
+			CldServer: cldgrpc.New(services.CldSvc, a.logger),
+		})
+		gatewayRtr.Register(baseGroup, authenticated...)
+	}
+
+	if a.Cfg.HTTP.Docs {
+		a.setupDocs(e)
+	}
+}
+
+// payloadPolicy accepts JSON bodies up to the configured size, the rules of the routes streaming
+// uploads and receiving webhooks come from [payload.DefaultRules].
+func (a *App) payloadPolicy() *payload.Policy {
+	cfg := a.Cfg.Payload
+	def := payload.Rule{MaxBodySize: cfg.MaxBodySize, ContentTypes: []string{echo.MIMEApplicationJSON}}
+	rules := payload.DefaultRules(httpBasePath, payload.Limits{
+		MaxBodySize:        cfg.MaxBodySize,
+		WebhookMaxBodySize: cfg.WebhookMaxBodySize,
+	})
+	if a.Cfg.Dev.Enabled {
+		// The emulated uploads accept files of any type and size, like the provider APIs.
+		rules = append(rules,
+			payload.Rule{Method: http.MethodPut, Prefix: httpBasePath + emulator.MuxUploadPath, ContentTypes: []string{payload.AnyContentType}},
+			payload.Rule{Method: http.MethodPost, Prefix: httpBasePath + emulator.CloudinaryUploadPath, ContentTypes: []string{echo.MIMEMultipartForm}},
+		)
+	}
+	return payload.NewPolicy(def, rules)
+}
+
+// setupDocs serves the OpenAPI document of the registered routes and Swagger UI. It must run after
+// all other routes are registered.
+func (a *App) setupDocs(e *echo.Echo) {
+	opts := openapi.Options{
+		Info: openapi.Info{
+			Title:       a.Cfg.Log.AppName,
+			Description: "Admin, end user, webhook and gRPC gateway HTTP API of the media service.",
+			Version:     "v1",
+		},
+		BasePath: httpBasePath,
+	}
+	if a.metrics != nil {
+		opts.Skip = append(opts.Skip, a.Cfg.Metrics.Path)
+	}
+	doc, undocumented := openapi.Build(e.Routes(), opts)
+	if len(undocumented) > 0 {
+		a.logger.Warn("HTTP routes are missing from the OpenAPI documentation", zap.Strings("routes", undocumented))
+	}
+	if err := openapi.Register(e, doc); err != nil {
+		a.logger.Error("Failed to encode the OpenAPI document", zap.Error(err))
+	}
 }
 
-func runHTTPServer(e *echo.Echo, port int64, logger *zap.Logger, errChan chan<- error) {
+func runHTTPServer(e *echo.Echo, port int64, logger *zap.Logger) error {
 	httpListenAddr := fmt.Sprintf(":%d", port)
 	logger.Info("Starting HTTP server", zap.String("address", httpListenAddr))
-	if err := e.Start(httpListenAddr); err != nil {
-		errChan <- err
-	} else {
-		errChan <- nil
+	if err := e.Start(httpListenAddr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
 	}
+	return nil
 }
 
-func shutdownHTTPServer(shutdownContext context.Context, e *echo.Echo, logger *zap.Logger) {
+// shutdownHTTPServer stops accepting new connections and waits for in-flight requests until
+// shutdownContext is done, then closes the remaining connections.
+func shutdownHTTPServer(shutdownContext context.Context, e *echo.Echo, logger *zap.Logger) error {
 	if err := e.Shutdown(shutdownContext); err != nil {
 		logger.Error("Error during HTTP server shutdown", zap.Error(err))
-	} else {
-		logger.Info("HTTP server shutdown completed")
+		_ = e.Close()
+		return err
 	}
+	logger.Info("HTTP server shutdown completed")
+	return nil
 }
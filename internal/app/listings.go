@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	"go.uber.org/zap"
+)
+
+// listingBatchSize is the number of assets whose listings are rebuilt at once.
+const listingBatchSize = 500
+
+// RebuildListings rebuilds the listing read models of the MUX and Cloudinary assets from the
+// metadata of the configured backend. The listings are upserted, so the rebuild can run while the
+// service is serving and be repeated after a failure. Only the PostgreSQL and metadata backend
+// credentials are resolved.
+func (a *App) RebuildListings(ctx context.Context) error {
+	if err := a.manager.ResolvePostgresDBCredentials(ctx); err != nil {
+		return err
+	}
+	if err := a.manager.ResolveMongoDBCredentials(ctx); err != nil {
+		return err
+	}
+	backend := dbmetadata.Backend(a.Cfg.Metadata.Backend)
+	if backend == dbmetadata.BackendArango {
+		if err := a.manager.ResolveArangoDBCredentials(ctx); err != nil {
+			return err
+		}
+	}
+
+	postgresDB, err := a.setupPostgresDB(ctx)
+	if err != nil {
+		return err
+	}
+	a.postgresDB = postgresDB
+	mongoDB, err := a.setupMongoDB(ctx)
+	if err != nil {
+		return err
+	}
+	a.mongoDB = mongoDB
+	if backend == dbmetadata.BackendArango {
+		arangoDB, err := a.setupArangoDB(ctx)
+		if err != nil {
+			return err
+		}
+		a.arangoDB = arangoDB
+	}
+
+	postgresRepos := setupPostgresRepositories(a.postgresDB, nil)
+	metadataRepos, err := setupMetadataRepositories(backend, a.mongoDB, a.arangoDB)
+	if err != nil {
+		return err
+	}
+
+	muxCount, err := rebuildListings(ctx, postgresRepos.MuxRepo.ListIDs, metadataRepos.MuxMetaRepo,
+		muxassetmodel.NewListing, postgresRepos.MuxRepo.SaveListings, muxassetmodel.ListingFields)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild mux listings: %w", err)
+	}
+	a.logger.Info("asset listings rebuilt", zap.String("provider", "mux"), zap.Int("rebuilt", muxCount))
+	cldCount, err := rebuildListings(ctx, postgresRepos.CldRepo.ListIDs, metadataRepos.CldMetaRepo,
+		cldassetmodel.NewListing, postgresRepos.CldRepo.SaveListings, cldassetmodel.ListingFields)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild cloudinary listings: %w", err)
+	}
+	a.logger.Info("asset listings rebuilt", zap.String("provider", "cloudinary"), zap.Int("rebuilt", cldCount))
+	return nil
+}
+
+// rebuildListings pages through the asset IDs and saves a listing for every asset with metadata. It
+// returns the number of saved listings.
+func rebuildListings[M, O, L any](
+	ctx context.Context,
+	listIDs func(ctx context.Context, afterID uuid.UUID, limit int) (uuid.UUIDs, error),
+	metadataRepo dbmetadata.Repository[M, O],
+	newListing func(metadata *M) (*L, error),
+	save func(ctx context.Context, listings ...*L) error,
+	fields []string,
+) (int, error) {
+	rebuilt := 0
+	afterID := uuid.Nil
+	for {
+		ids, err := listIDs(ctx, afterID, listingBatchSize)
+		if err != nil {
+			return rebuilt, err
+		}
+		if len(ids) == 0 {
+			return rebuilt, nil
+		}
+		afterID = ids[len(ids)-1]
+
+		metadata, err := metadataRepo.ListByKeys(ctx, ids.Strings(), fields...)
+		if err != nil {
+			return rebuilt, err
+		}
+		listings := make([]*L, 0, len(metadata))
+		for _, m := range metadata {
+			listing, err := newListing(m)
+			if err != nil {
+				return rebuilt, err
+			}
+			listings = append(listings, listing)
+		}
+		if len(listings) > 0 {
+			if err := save(ctx, listings...); err != nil {
+				return rebuilt, err
+			}
+		}
+		rebuilt += len(listings)
+	}
+}
@@ -26,11 +26,12 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/config"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-func fallbackLogDir(logCfg LogConfig, filename string) (*os.File, error) {
+func fallbackLogDir(logCfg config.LogConfig, filename string) (*os.File, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user home dir: %w", err)
@@ -47,7 +48,7 @@ func fallbackLogDir(logCfg LogConfig, filename string) (*os.File, error) {
 	return file, nil
 }
 
-func openLogFile(logCfg LogConfig) (*os.File, error) {
+func openLogFile(logCfg config.LogConfig) (*os.File, error) {
 	var filename string
 	if logCfg.UseTimestamp {
 		now := time.Now()
@@ -77,9 +78,9 @@ func openLogFile(logCfg LogConfig) (*os.File, error) {
 	return file, nil
 }
 
-// newLogger creates a new zap.Logger based on the provided LogConfig.
+// newLogger creates a new zap.Logger based on the provided config.LogConfig.
 // Make sure to call the returned cleanup function to close file handles to prevent potential recourse leak.
-func newLogger(logCfg LogConfig) (*zap.Logger, func(), error) {
+func newLogger(logCfg config.LogConfig) (*zap.Logger, func(), error) {
 	f, err := openLogFile(logCfg)
 	if err != nil {
 		return nil, nil, err
@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	"go.uber.org/zap"
+)
+
+// MigrateMetadata copies the MUX and Cloudinary metadata from one backend to another, e.g.
+// "mongo arango". Documents which already exist in the target backend are left unchanged, so the
+// migration can be repeated after a failure. Only the MongoDB and ArangoDB credentials are resolved.
+func (a *App) MigrateMetadata(ctx context.Context, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("metadata migration requires the source and the target backend")
+	}
+	from, to := dbmetadata.Backend(args[0]), dbmetadata.Backend(args[1])
+	for _, backend := range []dbmetadata.Backend{from, to} {
+		if !slices.Contains(dbmetadata.Backends, backend) {
+			return fmt.Errorf("unknown metadata backend %q, expected one of %v", backend, dbmetadata.Backends)
+		}
+	}
+	if from == to {
+		return fmt.Errorf("source and target backend must differ")
+	}
+
+	if err := a.manager.ResolveMongoDBCredentials(ctx); err != nil {
+		return err
+	}
+	if err := a.manager.ResolveArangoDBCredentials(ctx); err != nil {
+		return err
+	}
+	mongoDB, err := a.setupMongoDB(ctx)
+	if err != nil {
+		return err
+	}
+	a.mongoDB = mongoDB
+	arangoDB, err := a.setupArangoDB(ctx)
+	if err != nil {
+		return err
+	}
+	a.arangoDB = arangoDB
+
+	src, err := setupMetadataRepositories(from, a.mongoDB, a.arangoDB)
+	if err != nil {
+		return err
+	}
+	dst, err := setupMetadataRepositories(to, a.mongoDB, a.arangoDB)
+	if err != nil {
+		return err
+	}
+	if err := dst.ensureIndexes(ctx); err != nil {
+		return err
+	}
+
+	muxResult, err := dbmetadata.Copy(ctx, src.MuxMetaRepo, dst.MuxMetaRepo)
+	if err != nil {
+		return fmt.Errorf("failed to migrate mux metadata: %w", err)
+	}
+	a.logMetadataMigration(muxMetadataCollection, from, to, muxResult)
+	cldResult, err := dbmetadata.Copy(ctx, src.CldMetaRepo, dst.CldMetaRepo)
+	if err != nil {
+		return fmt.Errorf("failed to migrate cloudinary metadata: %w", err)
+	}
+	a.logMetadataMigration(cldMetadataCollection, from, to, cldResult)
+	return nil
+}
+
+func (a *App) logMetadataMigration(collection string, from, to dbmetadata.Backend, result *dbmetadata.CopyResult) {
+	a.logger.Info("metadata migrated",
+		zap.String("collection", collection),
+		zap.String("from", string(from)),
+		zap.String("to", string(to)),
+		zap.Int("copied", result.Copied),
+		zap.Int("skipped", result.Skipped),
+	)
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+
+	"github.com/mikhail5545/media-service-go/internal/metrics"
+	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	"github.com/mikhail5545/media-service-go/internal/services/assetstats"
+)
+
+func (a *App) setupMetrics() *metrics.Metrics {
+	if !a.Cfg.Metrics.Enabled {
+		return nil
+	}
+	return metrics.New()
+}
+
+// assetStatsSources returns the counting functions feeding the asset count gauges.
+func assetStatsSources(repos *Repositories) map[string]assetstats.Source {
+	return map[string]assetstats.Source{
+		"mux": func(ctx context.Context) (map[string]int64, error) {
+			unowned, err := repos.Metadata.MuxMetaRepo.CountUnowned(ctx)
+			if err != nil {
+				return nil, err
+			}
+			archived, err := repos.Postgres.MuxRepo.CountByStatus(ctx, muxassetmodel.StatusArchived)
+			if err != nil {
+				return nil, err
+			}
+			broken, err := repos.Postgres.MuxRepo.CountByStatus(ctx, muxassetmodel.StatusBroken)
+			if err != nil {
+				return nil, err
+			}
+			pendingDelete, err := repos.Postgres.MuxRepo.CountByStatus(ctx, muxassetmodel.StatusPendingDelete)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]int64{"unowned": unowned, "archived": archived, "broken": broken, "pending_delete": pendingDelete}, nil
+		},
+		"cloudinary": func(ctx context.Context) (map[string]int64, error) {
+			unowned, err := repos.Metadata.CldMetaRepo.CountUnowned(ctx)
+			if err != nil {
+				return nil, err
+			}
+			archived, err := repos.Postgres.CldRepo.CountByStatus(ctx, cldassetmodel.StatusArchived)
+			if err != nil {
+				return nil, err
+			}
+			broken, err := repos.Postgres.CldRepo.CountByStatus(ctx, cldassetmodel.StatusBroken)
+			if err != nil {
+				return nil, err
+			}
+			pendingDelete, err := repos.Postgres.CldRepo.CountByStatus(ctx, cldassetmodel.StatusPendingDelete)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]int64{"unowned": unowned, "archived": archived, "broken": broken, "pending_delete": pendingDelete}, nil
+		},
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/mikhail5545/media-service-go/internal/database/postgres/migrations"
+	"go.uber.org/zap"
+)
+
+// ensureSchema applies pending migrations when auto migration is enabled and refuses to continue
+// when the schema is still behind the migrations embedded in the binary.
+func (a *App) ensureSchema(dsn string) error {
+	m, err := migrations.New(dsn)
+	if err != nil {
+		a.logger.Error("failed to prepare database migrations", zap.Error(err))
+		return err
+	}
+	defer func() { _ = m.Close() }()
+
+	if a.Cfg.Migrations.AutoMigrate {
+		if err := m.Up(); err != nil {
+			a.logger.Error("failed to apply database migrations", zap.Error(err))
+			return err
+		}
+	}
+
+	status, err := m.Check()
+	if err != nil {
+		a.logger.Error("database schema check failed",
+			zap.Uint("current_version", status.Current),
+			zap.Uint("latest_version", status.Latest),
+			zap.Bool("dirty", status.Dirty),
+			zap.Error(err),
+		)
+		if errors.Is(err, migrations.ErrSchemaBehind) {
+			return fmt.Errorf("%w, run the migrate up command or enable auto migration", err)
+		}
+		return err
+	}
+	if status.Current > status.Latest {
+		a.logger.Warn("database schema is newer than the service",
+			zap.Uint("current_version", status.Current),
+			zap.Uint("latest_version", status.Latest),
+		)
+	}
+	a.logger.Info("database schema is up to date", zap.Uint("version", status.Current))
+	return nil
+}
+
+// Migrate runs a migrations command against the PostgreSQL database: "up", "down [N]" (default 1),
+// "force VERSION" or "version". Only the PostgreSQL credentials are resolved.
+func (a *App) Migrate(ctx context.Context, command string, args []string) error {
+	if err := a.manager.ResolvePostgresDBCredentials(ctx); err != nil {
+		return err
+	}
+	m, err := migrations.New(a.postgresDSN())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = m.Close() }()
+
+	switch command {
+	case "up":
+		err = m.Up()
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			if steps, err = strconv.Atoi(args[0]); err != nil {
+				return fmt.Errorf("invalid number of steps %q: %w", args[0], err)
+			}
+		}
+		err = m.Down(steps)
+	case "force":
+		if len(args) == 0 {
+			return fmt.Errorf("force requires a version")
+		}
+		version, convErr := strconv.Atoi(args[0])
+		if convErr != nil {
+			return fmt.Errorf("invalid version %q: %w", args[0], convErr)
+		}
+		err = m.Force(version)
+	case "version":
+	default:
+		return fmt.Errorf("unknown migrate command %q, expected up, down, force or version", command)
+	}
+	if err != nil {
+		return err
+	}
+
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+	a.logger.Info("database schema version",
+		zap.Uint("current_version", status.Current),
+		zap.Uint("latest_version", status.Latest),
+		zap.Bool("dirty", status.Dirty),
+	)
+	return nil
+}
@@ -18,48 +18,148 @@
 package app
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	arangometarepo "github.com/mikhail5545/media-service-go/internal/database/arango/metadata"
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
 	cldmetarepo "github.com/mikhail5545/media-service-go/internal/database/mongo/cloudinary/metadata"
+	mediametarepo "github.com/mikhail5545/media-service-go/internal/database/mongo/media/metadata"
 	muxmetarepo "github.com/mikhail5545/media-service-go/internal/database/mongo/mux/metadata"
+	auditrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/audit"
 	cldassetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
+	collectionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/collection"
+	mediaassetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/media/asset"
 	muxassetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	outboxrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/outbox"
+	playbackrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/playback"
+	restrictionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/restriction"
+	retentionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/retention"
+	sagarepo "github.com/mikhail5545/media-service-go/internal/database/postgres/saga"
+	signingkeyrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/signingkey"
+	subscriptionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/subscription"
+	versionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/versions"
+	watermarkrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/watermark"
+	webhookrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/webhook"
+	cldmetadatamodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/metadata"
+	muxmetadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
 type Repositories struct {
 	Postgres *PostgresRepositories
-	Mongo    *MongoRepositories
+	Metadata *MetadataRepositories
 }
 
 type PostgresRepositories struct {
-	MuxRepo *muxassetrepo.Repository
-	CldRepo *cldassetrepo.Repository
+	MuxRepo        *muxassetrepo.Repository
+	CldRepo        *cldassetrepo.Repository
+	RetentionRepo  *retentionrepo.Repository
+	OutboxRepo     *outboxrepo.Repository
+	CollectionRepo *collectionrepo.Repository
+	AuditRepo      *auditrepo.Repository
+	PlaybackRepo   *playbackrepo.Repository
+	SagaRepo       *sagarepo.Repository
+	MediaRepo      *mediaassetrepo.Repository
+	WebhookRepo    *webhookrepo.Repository
+	WatermarkRepo  *watermarkrepo.Repository
+	VersionRepo    *versionrepo.Repository
+	// RestrictionRepo holds the MUX playback restrictions the playback restriction policies are synchronized to.
+	RestrictionRepo *restrictionrepo.Repository
+	// SigningKeyRepo holds the rotated MUX signing keys.
+	SigningKeyRepo *signingkeyrepo.Repository
+	// SubscriptionRepo holds the outgoing webhooks and their deliveries.
+	SubscriptionRepo *subscriptionrepo.Repository
 }
 
-type MongoRepositories struct {
-	MuxMetaRepo *muxmetarepo.Repository
-	CldMetaRepo *cldmetarepo.Repository
+type (
+	muxMetadataRepository = dbmetadata.Repository[muxmetadatamodel.AssetMetadata, muxmetadatamodel.Owner]
+	cldMetadataRepository = dbmetadata.Repository[cldmetadatamodel.AssetMetadata, cldmetadatamodel.Owner]
+)
+
+// MetadataRepositories hold the metadata repositories of the configured backend. The metadata of the
+// shared asset core is always stored in MongoDB.
+type MetadataRepositories struct {
+	MuxMetaRepo   muxMetadataRepository
+	CldMetaRepo   cldMetadataRepository
+	MediaMetaRepo *mediametarepo.Repository
 }
 
-func (a *App) setupRepositories() *Repositories {
-	postgresRepos := setupPostgresRepositories(a.postgresDB)
-	mongoRepos := setupMongoRepositories(a.mongoDB)
+func (a *App) setupRepositories(ctx context.Context) (*Repositories, error) {
+	postgresRepos := setupPostgresRepositories(a.postgresDB, a.postgresReplica)
+	metadataRepos, err := setupMetadataRepositories(dbmetadata.Backend(a.Cfg.Metadata.Backend), a.mongoDB, a.arangoDB)
+	if err != nil {
+		return nil, err
+	}
+	if err := metadataRepos.ensureIndexes(ctx); err != nil {
+		a.logger.Error("failed to ensure metadata indexes", zap.Error(err))
+		return nil, err
+	}
 	return &Repositories{
 		Postgres: postgresRepos,
-		Mongo:    mongoRepos,
-	}
+		Metadata: metadataRepos,
+	}, nil
 }
 
-func setupPostgresRepositories(db *gorm.DB) *PostgresRepositories {
+// setupPostgresRepositories wires the repositories. Only the asset repositories route reads to the
+// replica, the retention and outbox workers claim rows and must read from the primary.
+func setupPostgresRepositories(db, replica *gorm.DB) *PostgresRepositories {
 	return &PostgresRepositories{
-		MuxRepo: muxassetrepo.New(db),
-		CldRepo: cldassetrepo.New(db),
+		MuxRepo:          muxassetrepo.NewWithReplica(db, replica),
+		CldRepo:          cldassetrepo.NewWithReplica(db, replica),
+		RetentionRepo:    retentionrepo.New(db),
+		OutboxRepo:       outboxrepo.New(db),
+		CollectionRepo:   collectionrepo.New(db),
+		AuditRepo:        auditrepo.New(db),
+		PlaybackRepo:     playbackrepo.New(db),
+		SagaRepo:         sagarepo.New(db),
+		MediaRepo:        mediaassetrepo.New(db),
+		WebhookRepo:      webhookrepo.New(db),
+		WatermarkRepo:    watermarkrepo.New(db),
+		SubscriptionRepo: subscriptionrepo.New(db),
+		VersionRepo:      versionrepo.New(db),
+		RestrictionRepo:  restrictionrepo.New(db),
+		SigningKeyRepo:   signingkeyrepo.New(db),
 	}
 }
 
-func setupMongoRepositories(db *mongo.Database) *MongoRepositories {
-	return &MongoRepositories{
-		MuxMetaRepo: muxmetarepo.New(db, "mux_metadata"),
-		CldMetaRepo: cldmetarepo.New(db, "cloudinary_metadata"),
+// Collections of the metadata, shared by all backends.
+const (
+	muxMetadataCollection   = "mux_metadata"
+	cldMetadataCollection   = "cloudinary_metadata"
+	mediaMetadataCollection = "media_metadata"
+)
+
+func setupMetadataRepositories(backend dbmetadata.Backend, mongoDB *mongo.Database, arangoDB arangodb.Database) (*MetadataRepositories, error) {
+	repos := &MetadataRepositories{
+		MediaMetaRepo: mediametarepo.New(mongoDB, mediaMetadataCollection),
+	}
+	switch backend {
+	case dbmetadata.BackendMongo:
+		repos.MuxMetaRepo = muxmetarepo.New(mongoDB, muxMetadataCollection)
+		repos.CldMetaRepo = cldmetarepo.New(mongoDB, cldMetadataCollection)
+	case dbmetadata.BackendArango:
+		if arangoDB == nil {
+			return nil, fmt.Errorf("metadata backend %q is not connected", backend)
+		}
+		repos.MuxMetaRepo = arangometarepo.New[muxmetadatamodel.AssetMetadata, muxmetadatamodel.Owner](arangoDB, muxMetadataCollection)
+		repos.CldMetaRepo = arangometarepo.New[cldmetadatamodel.AssetMetadata, cldmetadatamodel.Owner](arangoDB, cldMetadataCollection)
+	default:
+		return nil, fmt.Errorf("unknown metadata backend %q", backend)
+	}
+	return repos, nil
+}
+
+// ensureIndexes creates the indexes of the metadata of the configured backend.
+func (r *MetadataRepositories) ensureIndexes(ctx context.Context) error {
+	if err := r.MuxMetaRepo.EnsureIndexes(ctx); err != nil {
+		return fmt.Errorf("failed to ensure mux metadata indexes: %w", err)
+	}
+	if err := r.CldMetaRepo.EnsureIndexes(ctx); err != nil {
+		return fmt.Errorf("failed to ensure cloudinary metadata indexes: %w", err)
 	}
+	return nil
 }
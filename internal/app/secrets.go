@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"github.com/mikhail5545/media-service-go/internal/app/credentials"
+	"github.com/mikhail5545/media-service-go/internal/config"
+)
+
+// secretSources maps the configured 1Password references to the credentials manager sources.
+func secretSources(c *config.Config) *credentials.Sources {
+	cfg := c.Secrets
+	src := &credentials.Sources{
+		GRPCServer: credentials.GRPCServerRefs{
+			CertVaultRef: cfg.GRPCServerCertVaultRef,
+			CertItemRef:  cfg.GRPCServerCertItemRef,
+		},
+		GRPCClient: credentials.GRPCClientRefs{
+			AddressRef:   cfg.GRPCClientAddressRef,
+			CertVaultRef: cfg.GRPCClientCertVaultRef,
+			CertItemRef:  cfg.GRPCClientCertItemRef,
+		},
+		PostgresDB: credentials.PostgresDBRefs{
+			HostRef:     cfg.PostgresHostRef,
+			PortRef:     cfg.PostgresPortRef,
+			UserRef:     cfg.PostgresUserRef,
+			PasswordRef: cfg.PostgresPasswordRef,
+			DBNameRef:   cfg.PostgresDBNameRef,
+
+			ReplicaHostRef: cfg.PostgresReplicaHostRef,
+			ReplicaPortRef: cfg.PostgresReplicaPortRef,
+		},
+		MongoDB: credentials.MongoDBRefs{
+			ConnectionStringRef: cfg.MongoConnectionStringRef,
+		},
+		ArangoDB: credentials.ArangoDBRefs{
+			UserRef:     cfg.ArangoUserRef,
+			PasswordRef: cfg.ArangoPasswordRef,
+		},
+		MuxAPI: credentials.MuxAPIRefs{
+			APITokenRef:              cfg.MuxAPITokenRef,
+			SecretKeyRef:             cfg.MuxSecretKeyRef,
+			SigningKeyIDRef:          cfg.MuxSigningKeyIDRef,
+			SigningKeyPrivateRef:     cfg.MuxSigningKeyPrivateRef,
+			PlaybackRestrictionIDRef: cfg.MuxPlaybackRestrictionIDRef,
+		},
+		CloudinaryAPI: credentials.CloudinaryAPRefs{
+			CloudNameRef: cfg.CloudinaryCloudNameRef,
+			APIKeyRef:    cfg.CloudinaryAPIKeyRef,
+			APISecretRef: cfg.CloudinaryAPISecretRef,
+		},
+	}
+	if len(c.Environments) > 0 {
+		src.Environments = make(map[string]credentials.EnvironmentRefs, len(c.Environments))
+		for name, env := range c.Environments {
+			src.Environments[name] = credentials.EnvironmentRefs{
+				MuxAPI: credentials.MuxAPIRefs{
+					APITokenRef:              env.MuxAPITokenRef,
+					SecretKeyRef:             env.MuxSecretKeyRef,
+					SigningKeyIDRef:          env.MuxSigningKeyIDRef,
+					SigningKeyPrivateRef:     env.MuxSigningKeyPrivateRef,
+					PlaybackRestrictionIDRef: env.MuxPlaybackRestrictionIDRef,
+				},
+				CloudinaryAPI: credentials.CloudinaryAPRefs{
+					CloudNameRef: env.CloudinaryCloudNameRef,
+					APIKeyRef:    env.CloudinaryAPIKeyRef,
+					APISecretRef: env.CloudinaryAPISecretRef,
+				},
+			}
+		}
+	}
+	// The emulators of the dev mode need no provider credentials.
+	if c.Dev.Enabled {
+		src.MuxAPI = credentials.MuxAPIRefs{}
+		src.CloudinaryAPI = credentials.CloudinaryAPRefs{}
+	}
+	if c.S3.Enabled {
+		src.S3 = credentials.S3Refs{
+			AccessKeyIDRef:     cfg.S3AccessKeyIDRef,
+			SecretAccessKeyRef: cfg.S3SecretAccessKeyRef,
+		}
+	}
+	if c.CFStream.Enabled {
+		src.CFStream = credentials.CFStreamRefs{
+			APITokenRef:          cfg.CFStreamAPITokenRef,
+			SigningKeyIDRef:      cfg.CFStreamSigningKeyIDRef,
+			SigningKeyPrivateRef: cfg.CFStreamSigningKeyPrivateRef,
+		}
+	}
+	if c.SigningKeys.Enabled {
+		src.SigningKeys = credentials.SigningKeysRefs{
+			EncryptionKeyRef: cfg.SigningKeysEncryptionKeyRef,
+		}
+	}
+	return src
+}
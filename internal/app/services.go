@@ -18,32 +18,574 @@
 package app
 
 import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	cldapiclient "github.com/mikhail5545/media-service-go/internal/apiclients/cloudinary"
+	"github.com/mikhail5545/media-service-go/internal/config"
+	"github.com/mikhail5545/media-service-go/internal/database/postgres/pagination"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	cfstreamprovider "github.com/mikhail5545/media-service-go/internal/mediaprovider/cfstream"
+	s3provider "github.com/mikhail5545/media-service-go/internal/mediaprovider/s3"
+	assetimportmodel "github.com/mikhail5545/media-service-go/internal/models/assetimport"
+	cldasset "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	muxasset "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	restrictionmodel "github.com/mikhail5545/media-service-go/internal/models/restriction"
+	seedmodel "github.com/mikhail5545/media-service-go/internal/models/seed"
+	uploadproxymodel "github.com/mikhail5545/media-service-go/internal/models/uploadproxy"
+	"github.com/mikhail5545/media-service-go/internal/ownertypes"
+	"github.com/mikhail5545/media-service-go/internal/seed"
+	assetimportservice "github.com/mikhail5545/media-service-go/internal/services/assetimport"
+	auditservice "github.com/mikhail5545/media-service-go/internal/services/audit"
+	catalogservice "github.com/mikhail5545/media-service-go/internal/services/catalog"
+	cfstreamservice "github.com/mikhail5545/media-service-go/internal/services/cfstream"
 	cldservice "github.com/mikhail5545/media-service-go/internal/services/cloudinary"
+	collectionservice "github.com/mikhail5545/media-service-go/internal/services/collection"
+	costservice "github.com/mikhail5545/media-service-go/internal/services/cost"
+	exportservice "github.com/mikhail5545/media-service-go/internal/services/export"
+	"github.com/mikhail5545/media-service-go/internal/services/mediacore"
 	muxservice "github.com/mikhail5545/media-service-go/internal/services/mux"
+	playbackservice "github.com/mikhail5545/media-service-go/internal/services/playback"
+	s3service "github.com/mikhail5545/media-service-go/internal/services/s3"
+	sagaservice "github.com/mikhail5545/media-service-go/internal/services/saga"
+	signingkeyservice "github.com/mikhail5545/media-service-go/internal/services/signingkey"
+	subscriptionservice "github.com/mikhail5545/media-service-go/internal/services/subscription"
+	uploadproxyservice "github.com/mikhail5545/media-service-go/internal/services/uploadproxy"
+	usageservice "github.com/mikhail5545/media-service-go/internal/services/usage"
+	watermarkservice "github.com/mikhail5545/media-service-go/internal/services/watermark"
+	webhookservice "github.com/mikhail5545/media-service-go/internal/services/webhook"
 	"go.uber.org/zap"
 )
 
 type Services struct {
-	MuxSvc *muxservice.Service
-	CldSvc *cldservice.Service
+	MuxSvc        *muxservice.Service
+	CldSvc        *cldservice.Service
+	CollectionSvc *collectionservice.Service
+	WatermarkSvc  *watermarkservice.Service
+	AuditSvc      *auditservice.Service
+	PlaybackSvc   *playbackservice.Service
+	UsageSvc      *usageservice.Service
+	CostSvc       *costservice.Service
+	SagaExecutor  *sagaservice.Executor
+	// MediaRegistry holds the services of the backends built on the shared asset core, backends
+	// register their services in setupServices.
+	MediaRegistry *mediacore.Registry
+	// CatalogSvc lists the assets of all backends, it is created once all backends are set up.
+	CatalogSvc *catalogservice.Service
+	// UploadProxySvc is nil unless the upload proxy is enabled.
+	UploadProxySvc *uploadproxyservice.Service
+	// S3Svc is nil unless the object storage is enabled.
+	S3Svc *s3service.Service
+	// CfStreamSvc is nil unless Cloudflare Stream is enabled.
+	CfStreamSvc *cfstreamservice.Service
+	// ExportSvc is nil unless exports are enabled.
+	ExportSvc *exportservice.Service
+	// ImportSvc is nil unless imports are enabled.
+	ImportSvc *assetimportservice.Service
+	// SeedSvc is nil unless seeding is enabled or the dev mode is.
+	SeedSvc *seed.Service
+	// WebhookQueue persists the verified provider webhooks and processes them asynchronously.
+	WebhookQueue *webhookservice.Queue
+	// SubscriptionSvc is nil unless outgoing webhooks are enabled.
+	SubscriptionSvc *subscriptionservice.Service
+	// SigningKeySvc is nil unless the MUX signing key rotation is enabled.
+	SigningKeySvc *signingkeyservice.Service
 }
 
-func (a *App) setupServices(repos *Repositories, apiClients *ApiClients, grpcClients *GRPCClients, logger *zap.Logger) *Services {
-	return &Services{
+func (a *App) setupServices(repos *Repositories, apiClients *ApiClients, grpcClients *GRPCClients, publisher events.Publisher, logger *zap.Logger) (*Services, error) {
+	// Owner requests of both services are validated against the configured registries.
+	muxasset.OwnerTypes.Set(a.Cfg.OwnerTypes.Mux...)
+	cldasset.OwnerTypes.Set(a.Cfg.OwnerTypes.Cloudinary...)
+
+	playbackSvc := playbackservice.New(&playbackservice.NewParams{
+		Repo:               repos.Postgres.PlaybackRepo,
+		MaxSessionsPerUser: a.Cfg.Playback.MaxSessionsPerUser,
+	}, logger)
+
+	// The executor is shared by the services starting sagas, they register their definitions below.
+	sagaExecutor := sagaservice.New(&sagaservice.NewParams{
+		Config: sagaservice.DefaultConfig(),
+		Repo:   repos.Postgres.SagaRepo,
+	}, logger)
+
+	counters := listCounters(a.Cfg.Counts)
+	entitlements := a.entitlements()
+	watermarkSvc := watermarkservice.New(repos.Postgres.WatermarkRepo, logger)
+	signingKeySvc, err := a.setupSigningKeyService(repos, apiClients, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	services := &Services{
 		MuxSvc: muxservice.New(
 			&muxservice.NewParams{
-				Repo:         repos.Postgres.MuxRepo,
-				MetadataRepo: repos.Mongo.MuxMetaRepo,
-				ApiClient:    apiClients.MuxClient,
-				VideoClient:  grpcClients.VideoSvcClient,
+				Repo:               repos.Postgres.MuxRepo,
+				MetadataRepo:       repos.Metadata.MuxMetaRepo,
+				OutboxRepo:         repos.Postgres.OutboxRepo,
+				CollectionRepo:     repos.Postgres.CollectionRepo,
+				AuditRepo:          repos.Postgres.AuditRepo,
+				VersionRepo:        repos.Postgres.VersionRepo,
+				ApiClient:          apiClients.MuxClient,
+				VideoClient:        grpcClients.VideoSvcClient,
+				Publisher:          publisher,
+				Cache:              a.cache,
+				CacheTTL:           a.cacheTTL(),
+				Ownership:          ownershipPolicies(a.Cfg.Ownership.Mux),
+				Enrichment:         a.Cfg.Enrichment.Enabled,
+				Encoding:           muxEncodingPolicy(a.Cfg.Mux.Encoding),
+				DRMConfigurationID: a.Cfg.Mux.DRMConfigurationID,
+				Environments:       a.muxEnvironments(),
+				Sessions:           playbackSvc,
+				SigningKeys:        signingKeySvc,
+				Sagas:              sagaExecutor,
+				Counters:           counters,
+				Quota:              a.muxQuota(),
+				Entitlements:       entitlements,
+				DeliveryTokenTTL:   a.Cfg.Delivery.TokenTTL,
+				Restrictions:       playbackRestrictions(a.Cfg.Playback.Restrictions),
+				RestrictionRepo:    repos.Postgres.RestrictionRepo,
+				Watermarks:         watermarkSvc,
+				ImageRepo:          repos.Postgres.CldRepo,
+				Watches:            a.hub,
 			},
 			logger),
 		CldSvc: cldservice.New(
 			&cldservice.NewParams{
 				Repo:               repos.Postgres.CldRepo,
-				MetadataRepo:       repos.Mongo.CldMetaRepo,
+				MetadataRepo:       repos.Metadata.CldMetaRepo,
+				OutboxRepo:         repos.Postgres.OutboxRepo,
+				CollectionRepo:     repos.Postgres.CollectionRepo,
+				AuditRepo:          repos.Postgres.AuditRepo,
+				VersionRepo:        repos.Postgres.VersionRepo,
 				ApiClient:          apiClients.CldClient,
 				ImageServiceClient: grpcClients.ImageSvcClient,
+				Publisher:          publisher,
+				Cache:              a.cache,
+				CacheTTL:           a.cacheTTL(),
+				Ownership:          ownershipPolicies(a.Cfg.Ownership.Cloudinary),
+				Upload:             a.cloudinaryUploadConfig(),
+				UploadPolicy:       cloudinaryUploadPolicy(a.Cfg.UploadPolicy),
+				Moderation:         a.Cfg.Moderation.Cloudinary,
+				BlockDuplicates:    a.Cfg.Duplicates.Policy == "block",
+				Enrichment:         a.cloudinaryEnrichParams(),
+				Sagas:              sagaExecutor,
+				Counters:           counters,
+				Quota:              a.cloudinaryQuota(),
+				Entitlements:       entitlements,
+				Watermarks:         watermarkSvc,
+				Environments:       a.environmentNames(),
 			}, logger),
+		CollectionSvc: collectionservice.New(
+			&collectionservice.NewParams{
+				Repo:    repos.Postgres.CollectionRepo,
+				MuxRepo: repos.Postgres.MuxRepo,
+				CldRepo: repos.Postgres.CldRepo,
+			}, logger),
+		WatermarkSvc: watermarkSvc,
+		AuditSvc:     auditservice.New(repos.Postgres.AuditRepo, logger),
+		PlaybackSvc:  playbackSvc,
+		UsageSvc: usageservice.New(&usageservice.NewParams{
+			Sources: usageSources(repos),
+		}, logger),
+		CostSvc:       a.costService(repos, apiClients, logger),
+		SagaExecutor:  sagaExecutor,
+		MediaRegistry: mediacore.NewRegistry(),
+		SigningKeySvc: signingKeySvc,
+	}
+	if err := sagaExecutor.Register(services.MuxSvc, services.CldSvc); err != nil {
+		return nil, err
+	}
+
+	if apiClients.S3Client != nil {
+		s3Svc, err := a.setupS3Service(repos, apiClients, publisher, services.MediaRegistry, logger)
+		if err != nil {
+			return nil, err
+		}
+		services.S3Svc = s3Svc
+	}
+	if apiClients.CfStreamClient != nil {
+		cfStreamSvc, err := a.setupCfStreamService(repos, apiClients, publisher, services.MediaRegistry, logger)
+		if err != nil {
+			return nil, err
+		}
+		services.CfStreamSvc = cfStreamSvc
+	}
+	services.CatalogSvc = a.setupCatalogService(services, logger)
+
+	webhookQueue, err := a.setupWebhookQueue(repos, services, logger)
+	if err != nil {
+		return nil, err
+	}
+	services.WebhookQueue = webhookQueue
+
+	if a.Cfg.UploadProxy.Enabled {
+		uploadProxy, err := uploadproxyservice.New(&uploadproxyservice.NewParams{
+			Config: uploadproxyservice.Config{
+				MaxFileSize: a.Cfg.UploadProxy.MaxFileSize,
+				ChunkSize:   a.Cfg.UploadProxy.ChunkSize,
+				SessionTTL:  a.Cfg.UploadProxy.SessionTTL,
+			},
+			Creators: map[uploadproxymodel.Provider]uploadproxyservice.TargetCreator{
+				uploadproxymodel.ProviderMux: services.MuxSvc,
+			},
+		}, logger)
+		if err != nil {
+			return nil, err
+		}
+		services.UploadProxySvc = uploadProxy
+	}
+	if a.Cfg.Export.Enabled {
+		exportSvc, err := a.setupExportService(services, logger)
+		if err != nil {
+			return nil, err
+		}
+		services.ExportSvc = exportSvc
+	}
+	if a.Cfg.OutgoingWebhooks.Enabled {
+		subscriptionSvc, err := subscriptionservice.New(&subscriptionservice.NewParams{
+			Config: subscriptionservice.Config{
+				PollInterval: a.Cfg.OutgoingWebhooks.PollInterval,
+				BatchSize:    a.Cfg.OutgoingWebhooks.BatchSize,
+				Timeout:      a.Cfg.OutgoingWebhooks.Timeout,
+				MaxAttempts:  a.Cfg.OutgoingWebhooks.MaxAttempts,
+				BaseBackoff:  a.Cfg.OutgoingWebhooks.BaseBackoff,
+				MaxBackoff:   a.Cfg.OutgoingWebhooks.MaxBackoff,
+				Retention:    a.Cfg.OutgoingWebhooks.Retention,
+			},
+			Repo: repos.Postgres.SubscriptionRepo,
+		}, logger)
+		if err != nil {
+			return nil, err
+		}
+		services.SubscriptionSvc = subscriptionSvc
+	}
+	if a.Cfg.Import.Enabled {
+		importSvc, err := assetimportservice.New(&assetimportservice.NewParams{
+			Config: assetimportservice.Config{
+				Retention:  a.Cfg.Import.Retention,
+				MaxPending: a.Cfg.Import.MaxPending,
+			},
+			Importers: map[assetimportmodel.Provider]assetimportservice.Importer{
+				assetimportmodel.ProviderMux:        services.MuxSvc,
+				assetimportmodel.ProviderCloudinary: services.CldSvc,
+			},
+		}, logger)
+		if err != nil {
+			return nil, err
+		}
+		services.ImportSvc = importSvc
+	}
+	if a.Cfg.Seed.Enabled || a.Cfg.Dev.Enabled {
+		services.SeedSvc = seed.New(&seed.NewParams{
+			Config: seed.Config{AllowWipe: a.Cfg.Dev.Enabled},
+			Sets: map[seedmodel.Provider]seed.Set{
+				seedmodel.ProviderMux:        {Loader: services.MuxSvc, OwnerTypes: muxasset.OwnerTypes.List},
+				seedmodel.ProviderCloudinary: {Loader: services.CldSvc, OwnerTypes: cldasset.OwnerTypes.List},
+			},
+		}, logger)
+	}
+	return services, nil
+}
+
+// setupCatalogService creates the catalog of the MUX and Cloudinary services and of all backends
+// registered in the media registry.
+func (a *App) setupCatalogService(services *Services, logger *zap.Logger) *catalogservice.Service {
+	sources := []catalogservice.Source{
+		catalogservice.MuxSource(services.MuxSvc),
+		catalogservice.CloudinarySource(services.CldSvc),
+	}
+	for _, name := range services.MediaRegistry.Names() {
+		core, _ := services.MediaRegistry.Get(name)
+		sources = append(sources, catalogservice.CoreSource(core))
+	}
+	return catalogservice.New(sources, logger)
+}
+
+// setupWebhookQueue creates the queue of the webhooks of the MUX, Cloudinary and, if enabled,
+// Cloudflare Stream services.
+func (a *App) setupWebhookQueue(repos *Repositories, services *Services, logger *zap.Logger) (*webhookservice.Queue, error) {
+	providers := []webhookservice.HandlerProvider{services.MuxSvc, services.CldSvc}
+	if services.CfStreamSvc != nil {
+		providers = append(providers, services.CfStreamSvc)
+	}
+	return webhookservice.New(&webhookservice.NewParams{
+		Config:    webhookQueueConfig(a.Cfg.Webhooks),
+		Repo:      repos.Postgres.WebhookRepo,
+		Providers: providers,
+		Metrics:   a.metrics,
+	}, logger)
+}
+
+// webhookQueueConfig converts the webhook configuration, unset fields of the retry overrides fall
+// back to the default retry policy.
+func webhookQueueConfig(cfg config.WebhooksConfig) webhookservice.Config {
+	retry := webhookservice.RetryPolicy{
+		MaxAttempts: cfg.MaxAttempts,
+		BaseBackoff: cfg.BaseBackoff,
+		MaxBackoff:  cfg.MaxBackoff,
+	}
+	policies := make(map[string]webhookservice.RetryPolicy, len(cfg.Retries))
+	for key, override := range cfg.Retries {
+		policy := retry
+		if override.MaxAttempts > 0 {
+			policy.MaxAttempts = override.MaxAttempts
+		}
+		if override.BaseBackoff > 0 {
+			policy.BaseBackoff = override.BaseBackoff
+		}
+		if override.MaxBackoff > 0 {
+			policy.MaxBackoff = override.MaxBackoff
+		}
+		policies[key] = policy
+	}
+	return webhookservice.Config{
+		PollInterval:   cfg.PollInterval,
+		BatchSize:      cfg.BatchSize,
+		Concurrency:    cfg.Concurrency,
+		ProcessTimeout: cfg.Timeout,
+		Retry:          retry,
+		Policies:       policies,
+	}
+}
+
+// setupExportService creates the exports of the assets of the MUX and Cloudinary services and of
+// all backends registered in the media registry.
+func (a *App) setupExportService(services *Services, logger *zap.Logger) (*exportservice.Service, error) {
+	sources := []exportservice.Source{
+		exportservice.MuxSource(services.MuxSvc),
+		exportservice.CloudinarySource(services.CldSvc),
+	}
+	for _, name := range services.MediaRegistry.Names() {
+		core, _ := services.MediaRegistry.Get(name)
+		sources = append(sources, exportservice.CoreSource(core))
+	}
+	return exportservice.New(&exportservice.NewParams{
+		Config: exportservice.Config{
+			Dir:        a.Cfg.Export.Dir,
+			Retention:  a.Cfg.Export.Retention,
+			MaxPending: a.Cfg.Export.MaxPending,
+		},
+		Sources: sources,
+	}, logger)
+}
+
+// setupSigningKeyService creates the rotation of the MUX signing keys of all environments, it returns
+// nil unless the rotation is enabled.
+func (a *App) setupSigningKeyService(repos *Repositories, apiClients *ApiClients, logger *zap.Logger) (*signingkeyservice.Service, error) {
+	if !a.Cfg.SigningKeys.Enabled {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(a.manager.Credentials.SigningKeys.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signing keys encryption key: %w", err)
+	}
+	return signingkeyservice.New(&signingkeyservice.NewParams{
+		Config: signingkeyservice.Config{
+			RotationInterval: a.Cfg.SigningKeys.RotationInterval,
+			RetireAfter:      a.Cfg.SigningKeys.RetireAfter,
+			CheckInterval:    a.Cfg.SigningKeys.CheckInterval,
+			Environments:     a.environmentNames(),
+		},
+		Repo:          repos.Postgres.SigningKeyRepo,
+		APIClient:     apiClients.MuxClient,
+		EncryptionKey: key,
+	}, logger)
+}
+
+// setupS3Service creates the asset core of the file assets, registers it and wraps it with the
+// object storage endpoints.
+func (a *App) setupS3Service(repos *Repositories, apiClients *ApiClients, publisher events.Publisher, registry *mediacore.Registry, logger *zap.Logger) (*s3service.Service, error) {
+	core, err := mediacore.New(&mediacore.NewParams{
+		Provider:     s3provider.New(apiClients.S3Client),
+		Repo:         repos.Postgres.MediaRepo,
+		MetadataRepo: repos.Metadata.MediaMetaRepo,
+		OutboxRepo:   repos.Postgres.OutboxRepo,
+		AuditRepo:    repos.Postgres.AuditRepo,
+		Publisher:    publisher,
+		OwnerTypes:   ownertypes.NewRegistry(a.Cfg.OwnerTypes.S3...),
+		Ownership:    ownershipPolicies(a.Cfg.Ownership.S3),
+	}, logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := registry.Register(core); err != nil {
+		return nil, err
+	}
+	return s3service.New(&s3service.NewParams{
+		Core:      core,
+		ApiClient: apiClients.S3Client,
+		Config: s3service.Config{
+			KeyPrefix:           a.Cfg.S3.KeyPrefix,
+			UploadURLTTL:        a.Cfg.S3.UploadURLTTL,
+			DownloadURLTTL:      a.Cfg.S3.DownloadURLTTL,
+			MaxFileSize:         a.Cfg.S3.MaxFileSize,
+			AllowedContentTypes: a.Cfg.S3.AllowedContentTypes,
+		},
+	}, logger), nil
+}
+
+// setupCfStreamService creates the asset core of the Cloudflare Stream videos, registers it and wraps
+// it with the upload, webhook and playback endpoints.
+func (a *App) setupCfStreamService(repos *Repositories, apiClients *ApiClients, publisher events.Publisher, registry *mediacore.Registry, logger *zap.Logger) (*cfstreamservice.Service, error) {
+	core, err := mediacore.New(&mediacore.NewParams{
+		Provider:     cfstreamprovider.New(apiClients.CfStreamClient),
+		Repo:         repos.Postgres.MediaRepo,
+		MetadataRepo: repos.Metadata.MediaMetaRepo,
+		OutboxRepo:   repos.Postgres.OutboxRepo,
+		AuditRepo:    repos.Postgres.AuditRepo,
+		Publisher:    publisher,
+		OwnerTypes:   ownertypes.NewRegistry(a.Cfg.OwnerTypes.CFStream...),
+		Ownership:    ownershipPolicies(a.Cfg.Ownership.CFStream),
+	}, logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := registry.Register(core); err != nil {
+		return nil, err
+	}
+	return cfstreamservice.New(&cfstreamservice.NewParams{
+		Core:      core,
+		ApiClient: apiClients.CfStreamClient,
+		Config: cfstreamservice.Config{
+			MaxDurationSeconds: a.Cfg.CFStream.MaxDurationSeconds,
+			RequireSignedURLs:  a.Cfg.CFStream.RequireSignedURLs,
+			UploadURLTTL:       a.Cfg.CFStream.UploadURLTTL,
+			PlaybackTokenTTL:   a.Cfg.CFStream.PlaybackTokenTTL,
+		},
+	}, logger), nil
+}
+
+// cloudinaryEnrichParams returns nil unless the enrichment pipeline is enabled.
+func (a *App) cloudinaryEnrichParams() *cldapiclient.EnrichParams {
+	if !a.Cfg.Enrichment.Enabled {
+		return nil
+	}
+	return &cldapiclient.EnrichParams{
+		Categorization: a.Cfg.Enrichment.Categorization,
+		MinConfidence:  a.Cfg.Enrichment.MinConfidence,
+		OCR:            a.Cfg.Enrichment.OCR,
+	}
+}
+
+// cloudinaryUploadConfig returns nil unless the upload proxy is enabled.
+func (a *App) cloudinaryUploadConfig() *cldservice.UploadConfig {
+	if !a.Cfg.UploadProxy.Enabled {
+		return nil
+	}
+	return &cldservice.UploadConfig{
+		MaxFileSize:    a.Cfg.UploadProxy.Cloudinary.MaxFileSize,
+		Transformation: a.Cfg.UploadProxy.Cloudinary.Transformation,
+		Eager:          a.Cfg.UploadProxy.Cloudinary.Eager,
+	}
+}
+
+func cloudinaryUploadPolicy(cfg config.UploadPolicyConfig) *cldservice.UploadPolicy {
+	policy := &cldservice.UploadPolicy{
+		Folders:          cfg.Folders,
+		CreatorFolder:    cfg.CreatorFolder,
+		MaxFileSize:      cfg.MaxFileSize,
+		MaxWidth:         cfg.MaxWidth,
+		MaxHeight:        cfg.MaxHeight,
+		Formats:          lowerAll(cfg.Formats),
+		OwnerTypeFormats: make(map[string][]string, len(cfg.OwnerTypeFormats)),
+	}
+	for ownerType, formats := range cfg.OwnerTypeFormats {
+		policy.OwnerTypeFormats[ownerType] = lowerAll(formats)
+	}
+	return policy
+}
+
+func lowerAll(values []string) []string {
+	lowered := make([]string, 0, len(values))
+	for _, v := range values {
+		lowered = append(lowered, strings.ToLower(v))
+	}
+	return lowered
+}
+
+// playbackRestrictions returns nil unless one of the policies restricts the playback tokens.
+func playbackRestrictions(cfg config.PlaybackRestrictionsConfig) *restrictionmodel.Policies {
+	policies := &restrictionmodel.Policies{
+		Default: restrictionmodel.Policy{
+			AllowedCountries: upperAll(cfg.AllowedCountries),
+			AllowedReferrers: cfg.AllowedReferrers,
+			AllowNoReferrer:  cfg.AllowNoReferrer,
+		},
+		OwnerTypes: make(map[string]restrictionmodel.Policy, len(cfg.OwnerTypes)),
+	}
+	restricted := len(cfg.AllowedCountries) > 0 || len(cfg.AllowedReferrers) > 0
+	for ownerType, policy := range cfg.OwnerTypes {
+		policies.OwnerTypes[ownerType] = restrictionmodel.Policy{
+			AllowedCountries: upperAll(policy.AllowedCountries),
+			AllowedReferrers: policy.AllowedReferrers,
+			AllowNoReferrer:  policy.AllowNoReferrer,
+		}
+		restricted = restricted || len(policy.AllowedCountries) > 0 || len(policy.AllowedReferrers) > 0
+	}
+	if !restricted {
+		return nil
+	}
+	return policies
+}
+
+func upperAll(values []string) []string {
+	uppered := make([]string, 0, len(values))
+	for _, v := range values {
+		uppered = append(uppered, strings.ToUpper(v))
+	}
+	return uppered
+}
+
+func muxEncodingPolicy(cfg config.MuxEncodingConfig) *muxasset.EncodingPolicy {
+	policy := &muxasset.EncodingPolicy{
+		Default: muxasset.EncodingSettings{
+			MaxResolutionTier: cfg.MaxResolutionTier,
+			VideoQuality:      cfg.VideoQuality,
+			MP4Support:        cfg.MP4Support,
+			PlaybackPolicies:  cfg.PlaybackPolicies,
+		},
+		OwnerTypes: make(map[string]muxasset.EncodingSettings, len(cfg.OwnerTypes)),
+		Allowed: muxasset.EncodingAllowlist{
+			MaxResolutionTiers: cfg.AllowedMaxResolutionTiers,
+			VideoQualities:     cfg.AllowedVideoQualities,
+			MP4Supports:        cfg.AllowedMP4Supports,
+			PlaybackPolicies:   cfg.AllowedPlaybackPolicies,
+		},
+	}
+	if cfg.NormalizeAudio {
+		policy.Default.NormalizeAudio = &cfg.NormalizeAudio
+	}
+	for ownerType, settings := range cfg.OwnerTypes {
+		policy.OwnerTypes[ownerType] = muxasset.EncodingSettings{
+			MaxResolutionTier: settings.MaxResolutionTier,
+			VideoQuality:      settings.VideoQuality,
+			MP4Support:        settings.MP4Support,
+			NormalizeAudio:    settings.NormalizeAudio,
+			PlaybackPolicies:  settings.PlaybackPolicies,
+		}
+	}
+	return policy
+}
+
+func ownershipPolicies(cfg config.OwnershipPolicyConfig) *ownertypes.Policies {
+	policies := &ownertypes.Policies{
+		MaxOwnersPerAsset: cfg.MaxOwnersPerAsset,
+		ByType:            make(map[string]ownertypes.Policy, len(cfg.OwnerTypes)),
+	}
+	for ownerType, policy := range cfg.OwnerTypes {
+		policies.ByType[ownerType] = ownertypes.Policy{
+			MaxAssets: policy.MaxAssets,
+			Exclusive: policy.Exclusive,
+		}
+	}
+	return policies
+}
+
+func listCounters(cfg config.CountsConfig) *pagination.Counters {
+	overrides := make(map[string]pagination.CountStrategy, len(cfg.Endpoints))
+	for endpoint, strategy := range cfg.Endpoints {
+		overrides[endpoint] = pagination.CountStrategy(strategy)
 	}
+	return pagination.NewCounters(pagination.CountStrategy(cfg.Strategy), cfg.TTL, overrides)
 }
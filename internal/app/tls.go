@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mikhail5545/media-service-go/pkg/tlsconfig"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+)
+
+// grpcServerCredentials returns file based TLS credentials if a server certificate is configured,
+// and the credentials resolved by the credentials manager otherwise.
+func (a *App) grpcServerCredentials() (credentials.TransportCredentials, error) {
+	cfg := a.Cfg.GRPC.TLS
+	if cfg.CertFile == "" {
+		return a.manager.Credentials.GRPCServer.Credentials, nil
+	}
+	r, err := tlsconfig.NewReloader(tlsconfig.Files{
+		CertFile: cfg.CertFile,
+		KeyFile:  cfg.KeyFile,
+		CAFile:   cfg.CAFile,
+	}, cfg.ReloadInterval)
+	if err != nil {
+		a.logger.Error("failed to load gRPC server TLS files", zap.Error(err))
+		return nil, fmt.Errorf("failed to load gRPC server TLS files: %w", err)
+	}
+	tlsCfg, err := tlsconfig.Server(r, tlsconfig.ClientAuth(cfg.ClientAuth))
+	if err != nil {
+		a.logger.Error("failed to build gRPC server TLS config", zap.Error(err))
+		return nil, fmt.Errorf("failed to build gRPC server TLS config: %w", err)
+	}
+	a.logger.Info("using file based gRPC server TLS", zap.String("client_auth", cfg.ClientAuth))
+	return credentials.NewTLS(tlsCfg), nil
+}
+
+// grpcClientCredentials returns file based TLS credentials if a CA file is configured for outgoing
+// connections, and the credentials resolved by the credentials manager otherwise.
+func (a *App) grpcClientCredentials() (credentials.TransportCredentials, error) {
+	cfg := a.Cfg.GRPCClient.TLS
+	if cfg.CAFile == "" {
+		return a.manager.Credentials.GRPCClient.Credentials, nil
+	}
+	r, err := tlsconfig.NewReloader(tlsconfig.Files{
+		CertFile: cfg.CertFile,
+		KeyFile:  cfg.KeyFile,
+		CAFile:   cfg.CAFile,
+	}, cfg.ReloadInterval)
+	if err != nil {
+		a.logger.Error("failed to load gRPC client TLS files", zap.Error(err))
+		return nil, fmt.Errorf("failed to load gRPC client TLS files: %w", err)
+	}
+	return credentials.NewTLS(tlsconfig.Client(r, "")), nil
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikhail5545/media-service-go/internal/tracing"
+	"go.uber.org/zap"
+)
+
+func (a *App) setupTracing(ctx context.Context) (func(context.Context) error, error) {
+	shutdown, err := tracing.Setup(ctx, tracing.Config{
+		Enabled:     a.Cfg.Tracing.Enabled,
+		Endpoint:    a.Cfg.Tracing.Endpoint,
+		Insecure:    a.Cfg.Tracing.Insecure,
+		ServiceName: a.Cfg.Log.AppName,
+		SampleRatio: a.Cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		a.logger.Error("failed to set up tracing", zap.Error(err))
+		return nil, fmt.Errorf("failed to set up tracing: %w", err)
+	}
+	if a.Cfg.Tracing.Enabled {
+		a.logger.Info("tracing enabled", zap.String("endpoint", a.Cfg.Tracing.Endpoint), zap.Float64("sample_ratio", a.Cfg.Tracing.SampleRatio))
+	}
+	return shutdown, nil
+}
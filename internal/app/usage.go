@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+
+	costmodel "github.com/mikhail5545/media-service-go/internal/models/cost"
+	usagemodel "github.com/mikhail5545/media-service-go/internal/models/usage"
+	"github.com/mikhail5545/media-service-go/internal/quota"
+	costservice "github.com/mikhail5545/media-service-go/internal/services/cost"
+	"github.com/mikhail5545/media-service-go/internal/services/usage"
+	"go.uber.org/zap"
+)
+
+func (a *App) muxQuota() quota.Limits {
+	return quota.Limits{
+		MaxAssets:   a.Cfg.Quota.Mux.MaxAssets,
+		MaxDuration: a.Cfg.Quota.Mux.MaxDuration,
+	}
+}
+
+func (a *App) cloudinaryQuota() quota.Limits {
+	return quota.Limits{
+		MaxAssets: a.Cfg.Quota.Cloudinary.MaxAssets,
+		MaxBytes:  a.Cfg.Quota.Cloudinary.MaxBytes,
+	}
+}
+
+// usageSources returns the usage sources of the usage report. Usage is read from PostgreSQL,
+// the owner types of the assets from their MongoDB metadata.
+func usageSources(repos *Repositories) map[usagemodel.Provider]usage.Source {
+	return map[usagemodel.Provider]usage.Source{
+		usagemodel.ProviderMux: {
+			ByCreator: repos.Postgres.MuxRepo.UsageByCreator,
+			List:      repos.Postgres.MuxRepo.ListUsage,
+			OwnerTypes: func(ctx context.Context, ids []string) (map[string][]string, error) {
+				metas, err := repos.Metadata.MuxMetaRepo.ListByKeys(ctx, ids)
+				if err != nil {
+					return nil, err
+				}
+				types := make(map[string][]string, len(metas))
+				for key, meta := range metas {
+					for _, owner := range meta.Owners {
+						types[key] = appendDistinct(types[key], owner.OwnerType)
+					}
+				}
+				return types, nil
+			},
+		},
+		usagemodel.ProviderCloudinary: {
+			ByCreator: repos.Postgres.CldRepo.UsageByCreator,
+			List:      repos.Postgres.CldRepo.ListUsage,
+			OwnerTypes: func(ctx context.Context, ids []string) (map[string][]string, error) {
+				metas, err := repos.Metadata.CldMetaRepo.ListByKeys(ctx, ids)
+				if err != nil {
+					return nil, err
+				}
+				types := make(map[string][]string, len(metas))
+				for key, meta := range metas {
+					for _, owner := range meta.Owners {
+						types[key] = appendDistinct(types[key], owner.OwnerType)
+					}
+				}
+				return types, nil
+			},
+		},
+	}
+}
+
+// costService returns the service of the cost report. The owner types of the assets are resolved like
+// those of the usage report, the analytics are read through the API clients of the request environment.
+func (a *App) costService(repos *Repositories, apiClients *ApiClients, logger *zap.Logger) *costservice.Service {
+	sources := usageSources(repos)
+	return costservice.New(&costservice.NewParams{
+		Mux: costservice.Source{
+			List:       repos.Postgres.MuxRepo.ListCostUsage,
+			OwnerTypes: sources[usagemodel.ProviderMux].OwnerTypes,
+		},
+		Cloudinary: costservice.Source{
+			List:       repos.Postgres.CldRepo.ListCostUsage,
+			OwnerTypes: sources[usagemodel.ProviderCloudinary].OwnerTypes,
+		},
+		MuxClient:        apiClients.MuxClient,
+		CloudinaryClient: apiClients.CldClient,
+		Rates: costmodel.Rates{
+			MuxEncodingPerMinute:     a.Cfg.Costs.Mux.EncodingPerMinute,
+			MuxStoragePerMinute:      a.Cfg.Costs.Mux.StoragePerMinute,
+			MuxStreamingPerMinute:    a.Cfg.Costs.Mux.StreamingPerMinute,
+			MuxResolutionMultipliers: a.Cfg.Costs.Mux.ResolutionMultipliers,
+			CloudinaryStoragePerGB:   a.Cfg.Costs.Cloudinary.StoragePerGB,
+			CloudinaryBandwidthPerGB: a.Cfg.Costs.Cloudinary.BandwidthPerGB,
+		},
+		Currency: a.Cfg.Costs.Currency,
+	}, logger)
+}
+
+func appendDistinct(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
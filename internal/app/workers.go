@@ -0,0 +1,208 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	publishingmodel "github.com/mikhail5545/media-service-go/internal/models/publishing"
+	retentionmodel "github.com/mikhail5545/media-service-go/internal/models/retention"
+	"github.com/mikhail5545/media-service-go/internal/services/assetimport"
+	"github.com/mikhail5545/media-service-go/internal/services/assetstats"
+	"github.com/mikhail5545/media-service-go/internal/services/export"
+	"github.com/mikhail5545/media-service-go/internal/services/outbox"
+	"github.com/mikhail5545/media-service-go/internal/services/playback"
+	"github.com/mikhail5545/media-service-go/internal/services/publishing"
+	"github.com/mikhail5545/media-service-go/internal/services/retention"
+	"github.com/mikhail5545/media-service-go/internal/services/saga"
+	"github.com/mikhail5545/media-service-go/internal/services/signingkey"
+	"github.com/mikhail5545/media-service-go/internal/services/subscription"
+	"github.com/mikhail5545/media-service-go/internal/services/uploadproxy"
+	"github.com/mikhail5545/media-service-go/internal/services/webhook"
+)
+
+type Workers struct {
+	RetentionWorker  *retention.Worker
+	OutboxDispatcher *outbox.Dispatcher
+	PublishingWorker *publishing.Worker
+	AssetStatsWorker *assetstats.Worker
+	// UploadProxy discards the expired upload sessions.
+	UploadProxy *uploadproxy.Service
+	// Playback deletes the expired playback tokens.
+	Playback *playback.Service
+	// Sagas resumes the interrupted sagas.
+	Sagas *saga.Executor
+	// Exports runs the export jobs and discards the expired ones.
+	Exports *export.Service
+	// Imports runs the import jobs and discards the expired ones.
+	Imports *assetimport.Service
+	// Webhooks processes the queued provider webhooks.
+	Webhooks *webhook.Queue
+	// Subscriptions sends the pending outgoing webhook deliveries, it is nil unless outgoing webhooks are enabled.
+	Subscriptions *subscription.Service
+	// SigningKeys rotates the MUX signing keys, it is nil unless the rotation is enabled.
+	SigningKeys *signingkey.Service
+}
+
+func (a *App) setupWorkers(repos *Repositories, services *Services) (*Workers, error) {
+	workers := &Workers{UploadProxy: services.UploadProxySvc, Playback: services.PlaybackSvc, Sagas: services.SagaExecutor, Exports: services.ExportSvc, Imports: services.ImportSvc, Webhooks: services.WebhookQueue, Subscriptions: services.SubscriptionSvc, SigningKeys: services.SigningKeySvc}
+	if a.Cfg.Retention.Enabled {
+		worker, err := retention.New(&retention.NewParams{
+			Config: retention.Config{
+				Enabled:      a.Cfg.Retention.Enabled,
+				TTL:          a.Cfg.Retention.TTL,
+				Interval:     a.Cfg.Retention.Interval,
+				BatchSize:    a.Cfg.Retention.BatchSize,
+				RetryBackoff: a.Cfg.Retention.RetryBackoff,
+				DryRun:       a.Cfg.Retention.DryRun,
+			},
+			Purgers: map[retentionmodel.Provider]retention.Purger{
+				retentionmodel.ProviderMux:        services.MuxSvc,
+				retentionmodel.ProviderCloudinary: services.CldSvc,
+			},
+			Repo: repos.Postgres.RetentionRepo,
+		}, a.logger)
+		if err != nil {
+			return nil, err
+		}
+		workers.RetentionWorker = worker
+	}
+
+	publishingWorker, err := publishing.New(&publishing.NewParams{
+		Config: publishing.Config{
+			Interval:  a.Cfg.Publishing.Interval,
+			BatchSize: a.Cfg.Publishing.BatchSize,
+		},
+		Transitioners: map[publishingmodel.Provider]publishing.Transitioner{
+			publishingmodel.ProviderMux:        services.MuxSvc,
+			publishingmodel.ProviderCloudinary: services.CldSvc,
+		},
+	}, a.logger)
+	if err != nil {
+		return nil, err
+	}
+	workers.PublishingWorker = publishingWorker
+
+	outboxCfg := outbox.DefaultConfig()
+	if a.Cfg.Outbox.PollInterval > 0 {
+		outboxCfg.PollInterval = a.Cfg.Outbox.PollInterval
+	}
+	if a.Cfg.Outbox.BatchSize > 0 {
+		outboxCfg.BatchSize = a.Cfg.Outbox.BatchSize
+	}
+	if a.Cfg.Outbox.MaxAttempts > 0 {
+		outboxCfg.MaxAttempts = a.Cfg.Outbox.MaxAttempts
+	}
+	dispatcher, err := outbox.New(&outbox.NewParams{
+		Config:    outboxCfg,
+		Repo:      repos.Postgres.OutboxRepo,
+		Providers: []outbox.HandlerProvider{services.MuxSvc, services.CldSvc, services.MediaRegistry},
+	}, a.logger)
+	if err != nil {
+		return nil, err
+	}
+	workers.OutboxDispatcher = dispatcher
+
+	if a.metrics != nil {
+		statsWorker, err := assetstats.New(&assetstats.NewParams{
+			Interval: a.Cfg.Metrics.AssetStatsInterval,
+			Sources:  assetStatsSources(repos),
+			Metrics:  a.metrics,
+		}, a.logger)
+		if err != nil {
+			return nil, err
+		}
+		workers.AssetStatsWorker = statsWorker
+	}
+	return workers, nil
+}
+
+// runWorkers starts the configured workers. They stop when ctx is cancelled; the returned function
+// waits for them to return or for its own context to be done.
+func (a *App) runWorkers(ctx context.Context) func(context.Context) error {
+	var wg sync.WaitGroup
+	run := func(fn func(context.Context)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(ctx)
+		}()
+	}
+	if a.workers != nil {
+		if a.workers.RetentionWorker != nil {
+			run(a.workers.RetentionWorker.Run)
+		}
+		if a.workers.OutboxDispatcher != nil {
+			run(a.workers.OutboxDispatcher.Run)
+		}
+		if a.workers.AssetStatsWorker != nil {
+			run(a.workers.AssetStatsWorker.Run)
+		}
+		if a.workers.PublishingWorker != nil {
+			run(a.workers.PublishingWorker.Run)
+		}
+		if a.workers.UploadProxy != nil {
+			run(a.workers.UploadProxy.Run)
+		}
+		if a.workers.Playback != nil {
+			run(a.workers.Playback.Run)
+		}
+		if a.workers.Sagas != nil {
+			run(a.workers.Sagas.Run)
+		}
+		if a.workers.Exports != nil {
+			run(a.workers.Exports.Run)
+		}
+		if a.workers.Imports != nil {
+			run(a.workers.Imports.Run)
+		}
+		if a.workers.Webhooks != nil {
+			run(a.workers.Webhooks.Run)
+		}
+		if a.workers.Subscriptions != nil {
+			run(a.workers.Subscriptions.Run)
+		}
+		if a.workers.SigningKeys != nil {
+			run(a.workers.SigningKeys.Run)
+		}
+	}
+
+	return func(waitCtx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return nil
+		case <-waitCtx.Done():
+			return fmt.Errorf("workers did not stop in time: %w", waitCtx.Err())
+		}
+	}
+}
+
+// flushOutbox delivers the outbox events enqueued by the last drained requests.
+func (a *App) flushOutbox(ctx context.Context) error {
+	if a.workers == nil || a.workers.OutboxDispatcher == nil {
+		return nil
+	}
+	return a.workers.OutboxDispatcher.Flush(ctx)
+}
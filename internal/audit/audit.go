@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package audit builds audit log entries of admin mutations. Transport middlewares store the
+// request source in the context, the admin is resolved from the authenticated principal unless
+// the request names one explicitly.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/auth"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+)
+
+type sourceKey struct{}
+
+// WithSource returns a copy of ctx carrying the request source.
+func WithSource(ctx context.Context, source auditmodel.Source) context.Context {
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+// SourceFromContext returns the request source stored in ctx, or [auditmodel.SourceSystem].
+func SourceFromContext(ctx context.Context) auditmodel.Source {
+	if source, ok := ctx.Value(sourceKey{}).(auditmodel.Source); ok {
+		return source
+	}
+	return auditmodel.SourceSystem
+}
+
+// EchoMiddleware marks the request context with [auditmodel.SourceHTTP].
+func EchoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(WithSource(req.Context(), auditmodel.SourceHTTP)))
+			return next(c)
+		}
+	}
+}
+
+// EntryOption customizes an audit log entry built by [NewEntry].
+type EntryOption func(*auditmodel.Entry)
+
+// WithAdmin overrides the admin resolved from the authenticated principal. It is used by
+// requests which carry the admin explicitly. Empty values and invalid IDs are ignored.
+func WithAdmin(adminID, adminName string) EntryOption {
+	return func(e *auditmodel.Entry) {
+		if id, err := uuid.Parse(adminID); err == nil && id != uuid.Nil {
+			e.AdminID = &id
+		}
+		if adminName != "" {
+			e.AdminName = &adminName
+		}
+	}
+}
+
+// WithNote attaches the admin note to the entry. An empty note is ignored.
+func WithNote(note string) EntryOption {
+	return func(e *auditmodel.Entry) {
+		if note != "" {
+			e.Note = &note
+		}
+	}
+}
+
+// WithEvent marks the entry as caused by the provider webhook of the given type and ID.
+func WithEvent(eventType, eventID string) EntryOption {
+	return func(e *auditmodel.Entry) {
+		e.Source = auditmodel.SourceWebhook
+		if eventType != "" {
+			e.EventType = &eventType
+		}
+		if eventID != "" {
+			e.EventID = &eventID
+		}
+	}
+}
+
+// NewEntry builds an audit log entry of the action with the before and after snapshots encoded as JSON.
+// Nil snapshots are left empty, e.g. there is nothing before an asset is created.
+func NewEntry(
+	ctx context.Context,
+	provider auditmodel.Provider,
+	action auditmodel.Action,
+	assetID uuid.UUID,
+	before, after any,
+	opts ...EntryOption,
+) (*auditmodel.Entry, error) {
+	entry := &auditmodel.Entry{
+		Provider: provider,
+		AssetID:  assetID,
+		Action:   action,
+		Source:   SourceFromContext(ctx),
+	}
+	if id := logging.RequestID(ctx); id != "" {
+		entry.RequestID = &id
+	}
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		if id, err := uuid.Parse(p.Subject); err == nil {
+			entry.AdminID = &id
+		}
+		if name := p.Name; name != "" {
+			entry.AdminName = &name
+		}
+	}
+	for _, opt := range opts {
+		opt(entry)
+	}
+
+	var err error
+	if entry.Before, err = snapshot(before); err != nil {
+		return nil, err
+	}
+	if entry.After, err = snapshot(after); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func snapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit snapshot: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+)
+
+// APIKey is a service-to-service credential with the set of gRPC methods it may call.
+type APIKey struct {
+	// Name identifies the calling service in logs and in the resulting principal.
+	Name string
+	Key  string
+	// Scopes are full gRPC method names, prefixes ending with "*" or "*" for every method.
+	Scopes []string
+}
+
+// ParseAPIKey parses a key in the "NAME:KEY:SCOPE[,SCOPE...]" form, e.g.
+// "product-service:s3cr3t:/media_service.mux.asset.v1.AssetService/Get*".
+func ParseAPIKey(s string) (APIKey, error) {
+	parts := strings.SplitN(s, ":", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return APIKey{}, fmt.Errorf("invalid API key definition: expected NAME:KEY:SCOPE[,SCOPE...]")
+	}
+	key := APIKey{Name: parts[0], Key: parts[1]}
+	for _, scope := range strings.Split(parts[2], ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			key.Scopes = append(key.Scopes, scope)
+		}
+	}
+	if len(key.Scopes) == 0 {
+		return APIKey{}, fmt.Errorf("invalid API key definition for %q: no scopes", key.Name)
+	}
+	return key, nil
+}
+
+// ParseAPIKeys parses every key in ss, see [ParseAPIKey].
+func ParseAPIKeys(ss []string) ([]APIKey, error) {
+	keys := make([]APIKey, 0, len(ss))
+	for _, s := range ss {
+		key, err := ParseAPIKey(s)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// allows reports whether the key is scoped to the full gRPC method name.
+func (k *APIKey) allows(method string) bool {
+	for _, scope := range k.Scopes {
+		if prefix, ok := strings.CutSuffix(scope, "*"); ok {
+			if strings.HasPrefix(method, prefix) {
+				return true
+			}
+		} else if scope == method {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeys validates API keys presented by calling services. Keys are stored as SHA-256 digests
+// and compared in constant time.
+type APIKeys struct {
+	keys    []APIKey
+	digests [][sha256.Size]byte
+}
+
+// NewAPIKeys creates a key set. Duplicate keys are rejected so a key always maps to a single scope set.
+func NewAPIKeys(keys []APIKey) (*APIKeys, error) {
+	set := &APIKeys{
+		keys:    make([]APIKey, 0, len(keys)),
+		digests: make([][sha256.Size]byte, 0, len(keys)),
+	}
+	for _, key := range keys {
+		digest := sha256.Sum256([]byte(key.Key))
+		for i, existing := range set.digests {
+			if existing == digest {
+				return nil, fmt.Errorf("API key %q duplicates key %q", key.Name, set.keys[i].Name)
+			}
+		}
+		key.Key = ""
+		set.keys = append(set.keys, key)
+		set.digests = append(set.digests, digest)
+	}
+	return set, nil
+}
+
+// Len returns the number of configured keys.
+func (s *APIKeys) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.keys)
+}
+
+// Authorize validates the raw key and checks that it is scoped to the method.
+func (s *APIKeys) Authorize(raw, method string) (*Principal, error) {
+	if s == nil || raw == "" {
+		return nil, serviceerrors.NewUnauthenticatedError("missing API key")
+	}
+	digest := sha256.Sum256([]byte(raw))
+	for i := range s.digests {
+		if subtle.ConstantTimeCompare(digest[:], s.digests[i][:]) != 1 {
+			continue
+		}
+		key := &s.keys[i]
+		if !key.allows(method) {
+			return nil, serviceerrors.NewPermissionDeniedError(fmt.Sprintf("API key %q is not allowed to call %s", key.Name, method))
+		}
+		return &Principal{Subject: "service:" + key.Name, Name: key.Name, Service: true}, nil
+	}
+	return nil, serviceerrors.NewUnauthenticatedError("invalid API key")
+}
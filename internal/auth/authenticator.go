@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+)
+
+// Config holds token verification settings. Exactly one of Secret or JWKSURL must be set.
+type Config struct {
+	// Secret is the shared HMAC secret used to verify HS256/HS384/HS512 tokens.
+	Secret string
+	// JWKSURL is the URL of the JSON Web Key Set used to verify asymmetrically signed tokens.
+	JWKSURL string
+	// Issuer, if set, must match the "iss" claim.
+	Issuer string
+	// Audience, if set, must be present in the "aud" claim.
+	Audience string
+	// Leeway is the allowed clock skew when validating time based claims.
+	Leeway time.Duration
+}
+
+// Claims are the JWT claims understood by the service.
+type Claims struct {
+	jwt.RegisteredClaims
+	Name  string `json:"name,omitempty"`
+	Roles []Role `json:"roles,omitempty"`
+}
+
+// Authenticator verifies bearer tokens and converts them into principals.
+type Authenticator struct {
+	keyFunc jwt.Keyfunc
+	parser  *jwt.Parser
+}
+
+// New creates an authenticator. When JWKSURL is configured, the key set is fetched and
+// refreshed in background until ctx is cancelled.
+func New(ctx context.Context, cfg Config) (*Authenticator, error) {
+	var (
+		keyFunc jwt.Keyfunc
+		methods []string
+	)
+	switch {
+	case cfg.Secret != "" && cfg.JWKSURL != "":
+		return nil, errors.New("only one of JWT secret or JWKS URL can be configured")
+	case cfg.Secret != "":
+		secret := []byte(cfg.Secret)
+		keyFunc = func(*jwt.Token) (any, error) { return secret, nil }
+		methods = []string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodHS384.Alg(), jwt.SigningMethodHS512.Alg()}
+	case cfg.JWKSURL != "":
+		jwks, err := keyfunc.NewDefaultCtx(ctx, []string{cfg.JWKSURL})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load JWKS from %s: %w", cfg.JWKSURL, err)
+		}
+		keyFunc = jwks.Keyfunc
+		methods = []string{
+			jwt.SigningMethodRS256.Alg(), jwt.SigningMethodRS384.Alg(), jwt.SigningMethodRS512.Alg(),
+			jwt.SigningMethodPS256.Alg(), jwt.SigningMethodPS384.Alg(), jwt.SigningMethodPS512.Alg(),
+			jwt.SigningMethodES256.Alg(), jwt.SigningMethodES384.Alg(), jwt.SigningMethodES512.Alg(),
+			jwt.SigningMethodEdDSA.Alg(),
+		}
+	default:
+		return nil, errors.New("either JWT secret or JWKS URL must be configured")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.Leeway),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &Authenticator{
+		keyFunc: keyFunc,
+		parser:  jwt.NewParser(opts...),
+	}, nil
+}
+
+// Authenticate verifies the raw token and returns the principal it describes.
+// It returns [serviceerrors.ErrUnauthenticated] if the token is missing or invalid.
+func (a *Authenticator) Authenticate(raw string) (*Principal, error) {
+	if raw == "" {
+		return nil, serviceerrors.NewUnauthenticatedError("missing bearer token")
+	}
+	claims := &Claims{}
+	if _, err := a.parser.ParseWithClaims(raw, claims, a.keyFunc); err != nil {
+		return nil, serviceerrors.NewUnauthenticatedError(fmt.Sprintf("invalid bearer token: %v", err))
+	}
+	return &Principal{
+		Subject: claims.Subject,
+		Name:    claims.Name,
+		Roles:   claims.Roles,
+	}, nil
+}
+
+// authorize authenticates the token (unless the access is public) and checks the principal
+// against the required access level. For public access a valid token is still attached to the
+// result, but a missing or invalid one is not an error.
+func (a *Authenticator) authorize(raw string, access Access) (*Principal, error) {
+	if access == AccessPublic {
+		if raw == "" {
+			return nil, nil
+		}
+		p, err := a.Authenticate(raw)
+		if err != nil {
+			return nil, nil
+		}
+		return p, nil
+	}
+	p, err := a.Authenticate(raw)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Allows(access) {
+		return nil, serviceerrors.NewPermissionDeniedError(fmt.Sprintf("%s access required", access))
+	}
+	return p, nil
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header value.
+func bearerToken(header string) string {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
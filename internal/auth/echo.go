@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WebSocketTokenPrefix prefixes the bearer token sent as a WebSocket subprotocol, browsers cannot
+// set the Authorization header of WebSocket connections. The endpoint must select another
+// subprotocol offered by the client.
+const WebSocketTokenPrefix = "bearer."
+
+// EchoMiddleware authenticates and authorizes HTTP requests according to the policy.
+// The resolved principal is stored in the request context.
+func EchoMiddleware(a *Authenticator, policy *Policy) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			access := policy.Resolve(req.Method, req.URL.Path)
+
+			token := bearerToken(req.Header.Get(echo.HeaderAuthorization))
+			if token == "" {
+				token = webSocketToken(req)
+			}
+			p, err := a.authorize(token, access)
+			if err != nil {
+				return err
+			}
+			if p != nil {
+				c.SetRequest(req.WithContext(ContextWithPrincipal(req.Context(), p)))
+			}
+			return next(c)
+		}
+	}
+}
+
+// webSocketToken extracts the token from the subprotocols of a WebSocket handshake.
+func webSocketToken(req *http.Request) string {
+	if !strings.EqualFold(req.Header.Get(echo.HeaderUpgrade), "websocket") {
+		return ""
+	}
+	for _, header := range req.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(header, ",") {
+			if token, ok := strings.CutPrefix(strings.TrimSpace(protocol), WebSocketTokenPrefix); ok {
+				return token
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package auth
+
+import (
+	"context"
+
+	errutil "github.com/mikhail5545/media-service-go/internal/util/errors"
+	"github.com/mikhail5545/media-service-go/pkg/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor authenticates and authorizes unary gRPC calls according to the policy.
+// Calls carrying an API key in the [client.APIKeyHeader] metadata are authorized against the key
+// scopes; other calls must carry a bearer token in the "authorization" metadata whose roles satisfy
+// the policy. Either a or keys may be nil to disable the corresponding credential type.
+// The resolved principal is stored in the handler context.
+func UnaryServerInterceptor(a *Authenticator, keys *APIKeys, policy *Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		access := policy.Resolve("", info.FullMethod)
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		var (
+			p   *Principal
+			err error
+		)
+		switch apiKey := firstValue(md, client.APIKeyHeader); {
+		case apiKey != "" && keys.Len() > 0:
+			p, err = keys.Authorize(apiKey, info.FullMethod)
+			if err != nil && access == AccessPublic {
+				p, err = nil, nil
+			}
+		case a != nil:
+			p, err = a.authorize(bearerToken(firstValue(md, "authorization")), access)
+		case access != AccessPublic:
+			p, err = keys.Authorize("", info.FullMethod)
+		}
+		if err != nil {
+			return nil, errutil.ToGRPCCode(err)
+		}
+		if p != nil {
+			ctx = ContextWithPrincipal(ctx, p)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
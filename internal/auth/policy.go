@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package auth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule maps a path (HTTP) or full method name (gRPC) prefix to the access level it requires.
+type Rule struct {
+	// Method optionally restricts the rule to a single HTTP method, e.g. "GET". Ignored for gRPC.
+	Method string
+	// Prefix is matched against the request path or the full gRPC method name. HTTP policies match
+	// whole path segments only, see [NewHTTPPolicy].
+	Prefix string
+	Access Access
+}
+
+// ParseRule parses a rule in the "[METHOD ]PREFIX=ACCESS" form, e.g. "GET /api/v1/admin=read_only"
+// or "/media_service.mux.asset.v1.AssetService/Ping=public".
+func ParseRule(s string) (Rule, error) {
+	target, access, ok := strings.Cut(s, "=")
+	if !ok {
+		return Rule{}, fmt.Errorf("invalid auth rule %q: expected [METHOD ]PREFIX=ACCESS", s)
+	}
+	rule := Rule{Access: Access(strings.TrimSpace(access))}
+	if !rule.Access.IsValid() {
+		return Rule{}, fmt.Errorf("invalid auth rule %q: unknown access level %q", s, rule.Access)
+	}
+	target = strings.TrimSpace(target)
+	if method, prefix, ok := strings.Cut(target, " "); ok {
+		rule.Method = strings.ToUpper(method)
+		target = strings.TrimSpace(prefix)
+	}
+	if target == "" {
+		return Rule{}, fmt.Errorf("invalid auth rule %q: empty prefix", s)
+	}
+	rule.Prefix = target
+	return rule, nil
+}
+
+// ParseRules parses every rule in ss, see [ParseRule].
+func ParseRules(ss []string) ([]Rule, error) {
+	rules := make([]Rule, 0, len(ss))
+	for _, s := range ss {
+		rule, err := ParseRule(s)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Policy resolves the access level required by a request. The most specific rule wins: the one
+// with the longest matching prefix, preferring method-specific rules on ties. Among equally specific
+// rules the one defined first wins. Requests not matching any rule require the default access.
+type Policy struct {
+	Rules   []Rule
+	Default Access
+	// Segments restricts prefixes to whole path segments: the path must equal the prefix or continue
+	// with "/" after it, unless the prefix itself ends with "/".
+	Segments bool
+}
+
+// NewPolicy creates a policy with overrides taking precedence over base rules of the same specificity.
+func NewPolicy(def Access, overrides []Rule, base []Rule) *Policy {
+	rules := make([]Rule, 0, len(overrides)+len(base))
+	rules = append(rules, overrides...)
+	rules = append(rules, base...)
+	return &Policy{Rules: rules, Default: def}
+}
+
+// NewHTTPPolicy creates a policy like [NewPolicy] matching prefixes on path segment boundaries, so
+// that "/admin" covers "/admin" and "/admin/assets" but not "/adminfoo".
+func NewHTTPPolicy(def Access, overrides []Rule, base []Rule) *Policy {
+	p := NewPolicy(def, overrides, base)
+	p.Segments = true
+	return p
+}
+
+// Resolve returns the access level required for the given method and path.
+func (p *Policy) Resolve(method, path string) Access {
+	var (
+		best  *Rule
+		score = -1
+	)
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Method != "" && !strings.EqualFold(rule.Method, method) {
+			continue
+		}
+		if !p.matches(path, rule.Prefix) {
+			continue
+		}
+		s := len(rule.Prefix) * 2
+		if rule.Method != "" {
+			s++
+		}
+		if s > score {
+			best, score = rule, s
+		}
+	}
+	if best == nil {
+		return p.Default
+	}
+	return best.Access
+}
+
+func (p *Policy) matches(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	if !p.Segments || len(path) == len(prefix) || strings.HasSuffix(prefix, "/") {
+		return true
+	}
+	return path[len(prefix)] == '/'
+}
+
+// DefaultHTTPRules keeps webhooks and health checks public, lets read-only principals use
+// the admin read endpoints and requires admin role for everything else under /admin. The audit log
+// names the admins, so reading it requires admin role as well. Playback token validation only reads,
+// so other services can call it with read-only role. The gRPC gateway follows the same split: reads
+// require read-only role and other RPCs admin role. The end-user media endpoints only require an
+// authenticated user, they check the access to the asset themselves.
+func DefaultHTTPRules(basePath string) []Rule {
+	return []Rule{
+		{Prefix: basePath + "/webhooks", Access: AccessPublic},
+		{Prefix: basePath + "/media", Access: AccessUser},
+		{Prefix: basePath + "/admin/health", Access: AccessPublic},
+		{Method: "GET", Prefix: basePath + "/admin", Access: AccessReadOnly},
+		{Prefix: basePath + "/admin/audit", Access: AccessAdmin},
+		{Method: "POST", Prefix: basePath + "/admin/playback-sessions/validate", Access: AccessReadOnly},
+		{Prefix: basePath + "/admin", Access: AccessAdmin},
+		{Method: "GET", Prefix: basePath + "/gateway", Access: AccessReadOnly},
+		{Prefix: basePath + "/gateway", Access: AccessAdmin},
+	}
+}
+
+// DefaultGRPCRules keeps Ping public and allows read-only principals to call Get* and List* methods
+// of the given services. Other methods fall back to the policy default.
+func DefaultGRPCRules(serviceNames ...string) []Rule {
+	rules := make([]Rule, 0, len(serviceNames)*3)
+	for _, name := range serviceNames {
+		prefix := "/" + name + "/"
+		rules = append(rules,
+			Rule{Prefix: prefix + "Ping", Access: AccessPublic},
+			Rule{Prefix: prefix + "Get", Access: AccessReadOnly},
+			Rule{Prefix: prefix + "List", Access: AccessReadOnly},
+		)
+	}
+	return rules
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package auth implements bearer token authentication and role-based authorization for the
+// admin HTTP API and the gRPC API.
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// Role is a role granted to an authenticated principal.
+type Role string
+
+const (
+	// RoleAdmin grants full access to asset management.
+	RoleAdmin Role = "admin"
+	// RoleReadOnly grants access to read-only endpoints.
+	RoleReadOnly Role = "read_only"
+)
+
+// Access is the access level a route or method requires.
+type Access string
+
+const (
+	// AccessPublic routes do not require authentication.
+	AccessPublic Access = "public"
+	// AccessUser routes require an authenticated principal with any or no role, e.g. an end user.
+	AccessUser Access = "user"
+	// AccessReadOnly routes require either read-only or admin role.
+	AccessReadOnly Access = "read_only"
+	// AccessAdmin routes require admin role.
+	AccessAdmin Access = "admin"
+)
+
+// IsValid reports whether the access level is known.
+func (a Access) IsValid() bool {
+	switch a {
+	case AccessPublic, AccessUser, AccessReadOnly, AccessAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// Principal is the authenticated caller.
+type Principal struct {
+	Subject string
+	Name    string
+	Roles   []Role
+	// Service is set for callers authenticated with an API key.
+	Service bool
+}
+
+// HasRole reports whether the principal was granted the role.
+func (p *Principal) HasRole(role Role) bool {
+	return p != nil && slices.Contains(p.Roles, role)
+}
+
+// Allows reports whether the principal satisfies the required access level.
+// Admins satisfy every access level.
+func (p *Principal) Allows(access Access) bool {
+	switch access {
+	case AccessPublic:
+		return true
+	case AccessUser:
+		return p != nil
+	case AccessReadOnly:
+		return p.HasRole(RoleReadOnly) || p.HasRole(RoleAdmin)
+	case AccessAdmin:
+		return p.HasRole(RoleAdmin)
+	default:
+		return false
+	}
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying the principal.
+func ContextWithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal stored in ctx, if any.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
@@ -0,0 +1,228 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package bus dispatches the asset events published by the services to the in-process subscribers,
+// which carry out the side effects of the events, e.g. the outbox entries, the cache invalidation,
+// the notifications and the deliveries to the message broker.
+//
+// Synchronous subscribers are called by [Bus.Publish] before it returns, inside the transaction of
+// the publisher if the event is published in one: their errors are returned to the publisher, which
+// rolls the transaction back. They reach the transaction with [TxFromContext] and can defer their
+// work until it committed with [crossstore.AfterCommit].
+//
+// Asynchronous subscribers receive the events in the background once the [crossstore.Transaction] the
+// event was published in committed, or right away outside of one. Their failures are only logged.
+//
+//	err := crossstore.Transaction(ctx, db, func(ctx context.Context, tx *gorm.DB) error {
+//		...
+//		return b.Publish(bus.ContextWithTx(ctx, tx), event)
+//	})
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// defaultQueueSize is the number of events queued for an asynchronous subscriber.
+const defaultQueueSize = 1024
+
+// Handler handles an event delivered to a subscriber.
+type Handler func(ctx context.Context, event *events.Event) error
+
+type subscriber struct {
+	name    string
+	types   []events.Type
+	handler Handler
+	// queue buffers the events of an asynchronous subscriber, it is nil for synchronous ones.
+	queue chan delivery
+}
+
+type delivery struct {
+	ctx   context.Context
+	event *events.Event
+}
+
+func (s *subscriber) matches(event *events.Event) bool {
+	return len(s.types) == 0 || slices.Contains(s.types, event.Type)
+}
+
+// Bus is an [events.Publisher] dispatching the events to the subscribers.
+type Bus struct {
+	queueSize int
+
+	mu     sync.RWMutex
+	sync   []*subscriber
+	async  []*subscriber
+	closed bool
+	// workers tracks the goroutines of the asynchronous subscribers, Close waits for them.
+	workers sync.WaitGroup
+	logger  *zap.Logger
+}
+
+var _ events.Publisher = (*Bus)(nil)
+
+type Option func(*Bus)
+
+// WithQueueSize sets the number of events queued for each asynchronous subscriber. Events published
+// while the queue of a subscriber is full are dropped for it.
+func WithQueueSize(size int) Option {
+	return func(b *Bus) {
+		if size > 0 {
+			b.queueSize = size
+		}
+	}
+}
+
+// New returns a bus without subscribers.
+func New(logger *zap.Logger, opts ...Option) *Bus {
+	b := &Bus{
+		queueSize: defaultQueueSize,
+		logger:    logger.With(zap.String("layer", "bus")),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Subscribe registers a synchronous subscriber of the events of the types, or of every event if no
+// type is given. Subscribers are called in the order they were registered.
+func (b *Bus) Subscribe(name string, handler Handler, types ...events.Type) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sync = append(b.sync, &subscriber{name: name, types: types, handler: handler})
+}
+
+// SubscribeAsync registers an asynchronous subscriber of the events of the types, or of every event if
+// no type is given. The subscriber receives the events one by one in the order they were dispatched.
+func (b *Bus) SubscribeAsync(name string, handler Handler, types ...events.Type) {
+	sub := &subscriber{name: name, types: types, handler: handler, queue: make(chan delivery, b.queueSize)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.async = append(b.async, sub)
+	b.workers.Add(1)
+	go func() {
+		defer b.workers.Done()
+		b.run(sub)
+	}()
+}
+
+// Publish calls the synchronous subscribers of the event and returns their errors, then dispatches the
+// event to the asynchronous subscribers once the transaction of ctx committed.
+func (b *Bus) Publish(ctx context.Context, event *events.Event) error {
+	b.mu.RLock()
+	subs := b.sync
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subs {
+		if !sub.matches(event) {
+			continue
+		}
+		if err := sub.handler(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("event subscriber %s failed: %w", sub.name, err))
+		}
+	}
+	// Outside of a transaction the event is dispatched right away.
+	_ = crossstore.AfterCommit(ctx, func(ctx context.Context) error {
+		b.dispatch(ctx, event)
+		return nil
+	})
+	return errors.Join(errs...)
+}
+
+// Close stops accepting events and waits until the asynchronous subscribers handled the queued ones.
+func (b *Bus) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	for _, sub := range b.async {
+		close(sub.queue)
+	}
+	b.mu.Unlock()
+
+	b.workers.Wait()
+	return nil
+}
+
+func (b *Bus) dispatch(ctx context.Context, event *events.Event) {
+	ctx = context.WithoutCancel(ctx)
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		b.logger.Warn("event published after the bus was closed", zap.String("event_type", string(event.Type)))
+		return
+	}
+	for _, sub := range b.async {
+		if !sub.matches(event) {
+			continue
+		}
+		select {
+		case sub.queue <- delivery{ctx: ctx, event: event}:
+		default:
+			b.logger.Warn("event subscriber queue is full, event dropped",
+				zap.String("subscriber", sub.name),
+				zap.String("event_type", string(event.Type)),
+				zap.Stringer("event_id", event.ID),
+			)
+		}
+	}
+}
+
+func (b *Bus) run(sub *subscriber) {
+	for d := range sub.queue {
+		if err := sub.handler(d.ctx, d.event); err != nil {
+			b.logger.Warn("event subscriber failed",
+				zap.Error(err),
+				zap.String("subscriber", sub.name),
+				zap.String("event_type", string(d.event.Type)),
+				zap.Stringer("event_id", d.event.ID),
+			)
+		}
+	}
+}
+
+type txKey struct{}
+
+// ContextWithTx returns a copy of ctx carrying the transaction the events published with it are
+// published in.
+func ContextWithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext returns the transaction the event was published in, if any.
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok && tx != nil
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package cache provides a read-through cache for hot asset lookups. Values are stored as JSON so
+// that cached entries survive service restarts and can be shared between replicas.
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Cache stores JSON encoded values under string keys.
+type Cache interface {
+	// Get decodes the value stored under key into dst. It reports whether the key was found.
+	Get(ctx context.Context, key string, dst any) (bool, error)
+	// Set stores value under key for the provided ttl.
+	Set(ctx context.Context, key string, value any, ttl time.Duration) error
+	// Delete removes the keys. Missing keys are ignored.
+	Delete(ctx context.Context, keys ...string) error
+	// Ping verifies that the cache backend is reachable.
+	Ping(ctx context.Context) error
+	// Close releases the backend connections.
+	Close() error
+}
+
+// TTL holds the lifetimes of the cached lookups.
+type TTL struct {
+	Asset    time.Duration
+	Metadata time.Duration
+}
+
+// Key joins the parts into a cache key, e.g. Key("mux", "asset", id) -> "mux:asset:<id>".
+func Key(parts ...string) string {
+	return strings.Join(parts, ":")
+}
+
+// Noop never stores anything. It is used when caching is disabled.
+type Noop struct{}
+
+var _ Cache = Noop{}
+
+func (Noop) Get(context.Context, string, any) (bool, error) { return false, nil }
+
+func (Noop) Set(context.Context, string, any, time.Duration) error { return nil }
+
+func (Noop) Delete(context.Context, ...string) error { return nil }
+
+func (Noop) Ping(context.Context) error { return nil }
+
+func (Noop) Close() error { return nil }
@@ -0,0 +1,1015 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package config defines the typed service configuration. Values are loaded from defaults, an
+// optional YAML file, environment variables and command line flags, in increasing precedence,
+// and validated before any dependency is constructed.
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type Config struct {
+	HTTP       HTTPConfig       `yaml:"http"`
+	GRPC       GRPCConfig       `yaml:"grpc"`
+	GRPCClient GRPCClientConfig `yaml:"grpc_client"`
+	Log        LogConfig        `yaml:"log"`
+	MongoDB    MongoDBConfig    `yaml:"mongodb"`
+	ArangoDB   ArangoDBConfig   `yaml:"arangodb"`
+	Metadata   MetadataConfig   `yaml:"metadata"`
+	Counts     CountsConfig     `yaml:"counts"`
+	// GracefulShutdownTimeoutSeconds bounds draining requests and stopping workers on shutdown.
+	GracefulShutdownTimeoutSeconds int                    `yaml:"graceful_shutdown_timeout_seconds" env:"MEDIA_GRACEFUL_SHUTDOWN_TIMEOUT_SECONDS"`
+	Mux                            MuxAPIConfig           `yaml:"mux"`
+	Retention                      RetentionConfig        `yaml:"retention"`
+	Publishing                     PublishingConfig       `yaml:"publishing"`
+	Outbox                         OutboxConfig           `yaml:"outbox"`
+	Webhooks                       WebhooksConfig         `yaml:"webhooks"`
+	Events                         EventsConfig           `yaml:"events"`
+	Notifications                  NotificationsConfig    `yaml:"notifications"`
+	OutgoingWebhooks               OutgoingWebhooksConfig `yaml:"outgoing_webhooks"`
+	Auth                           AuthConfig             `yaml:"auth"`
+	Metrics                        MetricsConfig          `yaml:"metrics"`
+	Tracing                        TracingConfig          `yaml:"tracing"`
+	Health                         HealthConfig           `yaml:"health"`
+	Timeouts                       TimeoutsConfig         `yaml:"timeouts"`
+	Payload                        PayloadConfig          `yaml:"payload"`
+	Secrets                        SecretsConfig          `yaml:"secrets"`
+	Migrations                     MigrationsConfig       `yaml:"migrations"`
+	ReadReplica                    ReadReplicaConfig      `yaml:"read_replica"`
+	Cache                          CacheConfig            `yaml:"cache"`
+	OwnerTypes                     OwnerTypesConfig       `yaml:"owner_types"`
+	Ownership                      OwnershipConfig        `yaml:"ownership"`
+	UploadProxy                    UploadProxyConfig      `yaml:"upload_proxy"`
+	UploadPolicy                   UploadPolicyConfig     `yaml:"upload_policy"`
+	Moderation                     ModerationConfig       `yaml:"moderation"`
+	Duplicates                     DuplicatesConfig       `yaml:"duplicates"`
+	Enrichment                     EnrichmentConfig       `yaml:"enrichment"`
+	Playback                       PlaybackConfig         `yaml:"playback"`
+	Delivery                       DeliveryConfig         `yaml:"delivery"`
+	SigningKeys                    SigningKeysConfig      `yaml:"signing_keys"`
+	Quota                          QuotaConfig            `yaml:"quota"`
+	Costs                          CostsConfig            `yaml:"costs"`
+	APIResilience                  APIResilienceConfig    `yaml:"api_resilience"`
+	S3                             S3Config               `yaml:"s3"`
+	CFStream                       CFStreamConfig         `yaml:"cfstream"`
+	Export                         ExportConfig           `yaml:"export"`
+	Import                         ImportConfig           `yaml:"import"`
+	Dev                            DevConfig              `yaml:"dev"`
+	Seed                           SeedConfig             `yaml:"seed"`
+
+	// Environments are the tenant environments served besides the default one, keyed by name.
+	Environments map[string]EnvironmentConfig `yaml:"environments"`
+}
+
+type HTTPConfig struct {
+	Port int64 `yaml:"port" env:"MEDIA_HTTP_PORT"`
+	// Docs serves the OpenAPI document at /openapi.json and Swagger UI at /docs.
+	Docs bool `yaml:"docs" env:"MEDIA_HTTP_DOCS"`
+	// Gateway serves the v1 gRPC services as JSON/REST under /api/v1/gateway.
+	Gateway bool `yaml:"gateway" env:"MEDIA_HTTP_GATEWAY"`
+}
+
+type GRPCConfig struct {
+	Port int64 `yaml:"port" env:"MEDIA_GRPC_PORT"`
+	// TLS configures file based server TLS. When no certificate file is set, the credentials
+	// resolved from 1Password are used.
+	TLS TLSConfig `yaml:"tls" env:"MEDIA_GRPC_TLS"`
+	// LogRequests enables per-request logging in the gRPC interceptor chain.
+	LogRequests bool `yaml:"log_requests" env:"MEDIA_GRPC_LOG_REQUESTS"`
+	// Metrics enables per-method latency and error metrics collection.
+	Metrics bool `yaml:"metrics" env:"MEDIA_GRPC_METRICS"`
+	// Reflection registers the server reflection service, used by tools like grpcurl. It is off by
+	// default, the authentication only covers the unary calls and reflection is a streaming service.
+	Reflection bool `yaml:"reflection" env:"MEDIA_GRPC_REFLECTION"`
+}
+
+type LogConfig struct {
+	Directory    string `yaml:"directory" env:"MEDIA_LOG_DIRECTORY"`
+	UseTimestamp bool   `yaml:"use_timestamp" env:"MEDIA_LOG_USE_TIMESTAMP"`
+	AppName      string `yaml:"app_name" env:"MEDIA_LOG_APP_NAME"`
+}
+
+type MuxAPIConfig struct {
+	TestMode   bool   `yaml:"test_mode" env:"MEDIA_MUX_TEST_MODE"`
+	CORSOrigin string `yaml:"cors_origin" env:"MEDIA_MUX_CORS_ORIGIN"`
+	// WebhookSecret is the Mux webhook signing secret. Webhook signatures are not verified when it is empty.
+	WebhookSecret string `yaml:"webhook_secret" env:"MUX_WEBHOOK_SECRET"`
+	// WebhookTolerance is the maximum accepted age of a signed webhook.
+	WebhookTolerance time.Duration `yaml:"webhook_tolerance" env:"MEDIA_MUX_WEBHOOK_TOLERANCE"`
+	// DRMConfigurationID is the DRM configuration of uploads that do not select one. Uploads are not
+	// DRM protected when both are empty.
+	DRMConfigurationID string `yaml:"drm_configuration_id" env:"MEDIA_MUX_DRM_CONFIGURATION_ID"`
+	// EnvironmentID is the MUX environment of the default environment. Webhooks of MUX environments
+	// which are not mapped to an environment are processed in the default one.
+	EnvironmentID string `yaml:"environment_id" env:"MEDIA_MUX_ENVIRONMENT_ID"`
+	// Encoding controls the MUX new asset settings of the uploads.
+	Encoding MuxEncodingConfig `yaml:"encoding" env:"MEDIA_MUX_ENCODING"`
+}
+
+// MuxEncodingConfig holds the default encoding settings of the uploads, the settings overriding them
+// for the uploads of owner types and the allowlists the settings of every upload are checked against.
+// Empty settings are left to the built-in defaults, the basic video quality with a signed and a public
+// playback ID, and then to MUX. Empty allowlists allow any option.
+//
+// The env tags of nested fields are suffixes appended to the env tag of the parent field.
+type MuxEncodingConfig struct {
+	// MaxResolutionTier is one of 1080p, 1440p and 2160p.
+	MaxResolutionTier string `yaml:"max_resolution_tier" env:"_MAX_RESOLUTION_TIER"`
+	// VideoQuality is one of basic, plus and premium.
+	VideoQuality string `yaml:"video_quality" env:"_VIDEO_QUALITY"`
+	// MP4Support is one of none, capped-1080p, audio-only and "audio-only,capped-1080p".
+	MP4Support       string   `yaml:"mp4_support" env:"_MP4_SUPPORT"`
+	NormalizeAudio   bool     `yaml:"normalize_audio" env:"_NORMALIZE_AUDIO"`
+	PlaybackPolicies []string `yaml:"playback_policies" env:"_PLAYBACK_POLICIES"`
+
+	AllowedMaxResolutionTiers []string `yaml:"allowed_max_resolution_tiers" env:"_ALLOWED_MAX_RESOLUTION_TIERS"`
+	AllowedVideoQualities     []string `yaml:"allowed_video_qualities" env:"_ALLOWED_VIDEO_QUALITIES"`
+	AllowedMP4Supports        []string `yaml:"allowed_mp4_supports" env:"_ALLOWED_MP4_SUPPORTS"`
+	AllowedPlaybackPolicies   []string `yaml:"allowed_playback_policies" env:"_ALLOWED_PLAYBACK_POLICIES"`
+
+	// OwnerTypes holds the settings of the uploads of registered owner types, e.g.
+	// "lesson": {max_resolution_tier: 2160p}. They are configured in the YAML file only.
+	OwnerTypes map[string]MuxEncodingSettingsConfig `yaml:"owner_types"`
+}
+
+type MuxEncodingSettingsConfig struct {
+	MaxResolutionTier string   `yaml:"max_resolution_tier"`
+	VideoQuality      string   `yaml:"video_quality"`
+	MP4Support        string   `yaml:"mp4_support"`
+	NormalizeAudio    *bool    `yaml:"normalize_audio"`
+	PlaybackPolicies  []string `yaml:"playback_policies"`
+}
+
+// EnvironmentConfig is a tenant environment (e.g. staging) with its own MUX environment and
+// Cloudinary product environment. Requests select it by the X-Media-Environment header, the assets
+// created in it are only visible in it. The default environment uses the top-level credentials.
+type EnvironmentConfig struct {
+	// MuxEnvironmentID routes the webhooks sent from the MUX environment.
+	MuxEnvironmentID string `yaml:"mux_environment_id"`
+	// MuxWebhookSecret is the webhook signing secret of the MUX environment. Webhook signatures are
+	// not verified when it is empty.
+	MuxWebhookSecret string `yaml:"mux_webhook_secret"`
+
+	MuxAPITokenRef              string `yaml:"mux_api_token_ref"`
+	MuxSecretKeyRef             string `yaml:"mux_secret_key_ref"`
+	MuxSigningKeyIDRef          string `yaml:"mux_signing_key_id_ref"`
+	MuxSigningKeyPrivateRef     string `yaml:"mux_signing_key_private_ref"`
+	MuxPlaybackRestrictionIDRef string `yaml:"mux_playback_restriction_id_ref"`
+
+	CloudinaryCloudNameRef string `yaml:"cloudinary_cloud_name_ref"`
+	CloudinaryAPIKeyRef    string `yaml:"cloudinary_api_key_ref"`
+	CloudinaryAPISecretRef string `yaml:"cloudinary_api_secret_ref"`
+}
+
+// S3Config holds configuration for the S3-compatible object storage of raw file assets, e.g.
+// documents and archives. The access keys are resolved from 1Password.
+type S3Config struct {
+	Enabled bool `yaml:"enabled" env:"MEDIA_S3_ENABLED"`
+	// Endpoint is the base URL of the S3 API, e.g. "https://s3.eu-central-1.amazonaws.com" or "http://minio:9000".
+	Endpoint string `yaml:"endpoint" env:"MEDIA_S3_ENDPOINT"`
+	Region   string `yaml:"region" env:"MEDIA_S3_REGION"`
+	Bucket   string `yaml:"bucket" env:"MEDIA_S3_BUCKET"`
+	// PathStyle addresses the bucket in the URL path, as required by MinIO.
+	PathStyle bool `yaml:"path_style" env:"MEDIA_S3_PATH_STYLE"`
+	// KeyPrefix is prepended to the keys of all objects, e.g. "media/".
+	KeyPrefix      string        `yaml:"key_prefix" env:"MEDIA_S3_KEY_PREFIX"`
+	UploadURLTTL   time.Duration `yaml:"upload_url_ttl" env:"MEDIA_S3_UPLOAD_URL_TTL"`
+	DownloadURLTTL time.Duration `yaml:"download_url_ttl" env:"MEDIA_S3_DOWNLOAD_URL_TTL"`
+	// MaxFileSize is the maximum size of a single file in bytes.
+	MaxFileSize int64 `yaml:"max_file_size" env:"MEDIA_S3_MAX_FILE_SIZE"`
+	// AllowedContentTypes lists the accepted content types, any content type is accepted if it is empty.
+	AllowedContentTypes []string `yaml:"allowed_content_types" env:"MEDIA_S3_ALLOWED_CONTENT_TYPES"`
+}
+
+// CFStreamConfig holds configuration for Cloudflare Stream, the alternative video backend selected
+// per video when its upload URL is created. The API token and the signing key are resolved from
+// 1Password.
+type CFStreamConfig struct {
+	Enabled   bool   `yaml:"enabled" env:"MEDIA_CFSTREAM_ENABLED"`
+	AccountID string `yaml:"account_id" env:"MEDIA_CFSTREAM_ACCOUNT_ID"`
+	// WebhookSecret is the Stream webhook signing secret. Webhook signatures are not verified when it is empty.
+	WebhookSecret string `yaml:"webhook_secret" env:"CFSTREAM_WEBHOOK_SECRET"`
+	// WebhookTolerance is the maximum accepted age of a signed webhook.
+	WebhookTolerance time.Duration `yaml:"webhook_tolerance" env:"MEDIA_CFSTREAM_WEBHOOK_TOLERANCE"`
+	// RequireSignedURLs makes new videos playable only with a signed token, it requires the signing key.
+	RequireSignedURLs bool `yaml:"require_signed_urls" env:"MEDIA_CFSTREAM_REQUIRE_SIGNED_URLS"`
+	// MaxDurationSeconds is the default maximum duration of uploaded videos.
+	MaxDurationSeconds int           `yaml:"max_duration_seconds" env:"MEDIA_CFSTREAM_MAX_DURATION_SECONDS"`
+	UploadURLTTL       time.Duration `yaml:"upload_url_ttl" env:"MEDIA_CFSTREAM_UPLOAD_URL_TTL"`
+	PlaybackTokenTTL   time.Duration `yaml:"playback_token_ttl" env:"MEDIA_CFSTREAM_PLAYBACK_TOKEN_TTL"`
+}
+
+type MongoDBConfig struct {
+	DbName string `yaml:"db_name" env:"MEDIA_MONGODB_DB_NAME"`
+}
+
+// ArangoDBConfig holds the connection of ArangoDB, which is only used when it stores the metadata.
+// The user and the password are resolved from 1Password.
+type ArangoDBConfig struct {
+	// Endpoints are the URLs of the coordinators, e.g. "http://localhost:8529".
+	Endpoints []string `yaml:"endpoints" env:"MEDIA_ARANGODB_ENDPOINTS"`
+	DbName    string   `yaml:"db_name" env:"MEDIA_ARANGODB_DB_NAME"`
+}
+
+// MetadataConfig selects the document store of the MUX and Cloudinary asset metadata, "mongo" or
+// "arango". The metadata of the shared asset core is always stored in MongoDB.
+type MetadataConfig struct {
+	Backend string `yaml:"backend" env:"MEDIA_METADATA_BACKEND"`
+}
+
+// CountsConfig selects how the totals of the asset list endpoints are computed: "exact" runs
+// COUNT(*) on every request, "cached" reuses the count of the same filter for TTL, and "estimate"
+// uses the row estimate of the Postgres planner.
+type CountsConfig struct {
+	Strategy string        `yaml:"strategy" env:"MEDIA_COUNTS_STRATEGY"`
+	TTL      time.Duration `yaml:"ttl" env:"MEDIA_COUNTS_TTL"`
+	// Endpoints overrides the strategy of individual endpoints, e.g. "mux.list_archived": "estimate".
+	// They are configured in the YAML file only.
+	Endpoints map[string]string `yaml:"endpoints"`
+}
+
+type GRPCClientConfig struct {
+	Address string `yaml:"address" env:"MEDIA_GRPC_CLIENT_ADDRESS"`
+	// TLS configures file based client TLS. When no CA file is set, the credentials
+	// resolved from 1Password are used.
+	TLS TLSConfig `yaml:"tls" env:"MEDIA_GRPC_CLIENT_TLS"`
+	// Video and Image configure the connections to the video and image services of product service.
+	Video DownstreamConfig `yaml:"video" env:"MEDIA_GRPC_CLIENT_VIDEO"`
+	Image DownstreamConfig `yaml:"image" env:"MEDIA_GRPC_CLIENT_IMAGE"`
+}
+
+// DownstreamConfig configures the connection to a single downstream gRPC service.
+//
+// The env tags of the fields are suffixes appended to the env tag of the parent field.
+type DownstreamConfig struct {
+	// Address overrides the shared client address.
+	Address string `yaml:"address" env:"_ADDRESS"`
+	// Timeout is the deadline of calls without an earlier deadline.
+	Timeout time.Duration `yaml:"timeout" env:"_TIMEOUT"`
+	// WaitForReady makes calls wait, up to their deadline, for the service to become reachable
+	// instead of failing immediately, e.g. while it restarts.
+	WaitForReady bool `yaml:"wait_for_ready" env:"_WAIT_FOR_READY"`
+}
+
+// TLSConfig points to PEM encoded certificate files. Files are re-read when they change,
+// so rotated certificates are used by new connections without a restart.
+//
+// The env tags of nested TLS fields are suffixes appended to the env tag of the parent field.
+type TLSConfig struct {
+	CertFile string `yaml:"cert_file" env:"_CERT_FILE"`
+	KeyFile  string `yaml:"key_file" env:"_KEY_FILE"`
+	CAFile   string `yaml:"ca_file" env:"_CA_FILE"`
+	// ClientAuth is the server client certificate verification mode: "none", "request" or "require".
+	ClientAuth     string        `yaml:"client_auth" env:"_CLIENT_AUTH"`
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"_RELOAD_INTERVAL"`
+}
+
+// RetentionConfig holds configuration for the retention policy worker, which permanently
+// deletes archived assets after the TTL expires.
+type RetentionConfig struct {
+	Enabled      bool          `yaml:"enabled" env:"MEDIA_RETENTION_ENABLED"`
+	TTL          time.Duration `yaml:"ttl" env:"MEDIA_RETENTION_TTL"`
+	Interval     time.Duration `yaml:"interval" env:"MEDIA_RETENTION_INTERVAL"`
+	BatchSize    int           `yaml:"batch_size" env:"MEDIA_RETENTION_BATCH_SIZE"`
+	RetryBackoff time.Duration `yaml:"retry_backoff" env:"MEDIA_RETENTION_RETRY_BACKOFF"`
+	DryRun       bool          `yaml:"dry_run" env:"MEDIA_RETENTION_DRY_RUN"`
+}
+
+// PublishingConfig holds configuration for the publishing worker, which publishes and unpublishes the
+// assets following their publication schedule.
+type PublishingConfig struct {
+	Interval  time.Duration `yaml:"interval" env:"MEDIA_PUBLISHING_INTERVAL"`
+	BatchSize int           `yaml:"batch_size" env:"MEDIA_PUBLISHING_BATCH_SIZE"`
+}
+
+// OutboxConfig holds configuration for the transactional outbox dispatcher.
+type OutboxConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval" env:"MEDIA_OUTBOX_POLL_INTERVAL"`
+	BatchSize    int           `yaml:"batch_size" env:"MEDIA_OUTBOX_BATCH_SIZE"`
+	MaxAttempts  int           `yaml:"max_attempts" env:"MEDIA_OUTBOX_MAX_ATTEMPTS"`
+}
+
+// WebhooksConfig holds configuration for the webhook ingestion queue. MaxAttempts, BaseBackoff and
+// MaxBackoff make up the default retry policy of failed webhooks.
+type WebhooksConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval" env:"MEDIA_WEBHOOKS_POLL_INTERVAL"`
+	BatchSize    int           `yaml:"batch_size" env:"MEDIA_WEBHOOKS_BATCH_SIZE"`
+	// Concurrency limits the number of webhooks processed at the same time by one instance.
+	Concurrency int `yaml:"concurrency" env:"MEDIA_WEBHOOKS_CONCURRENCY"`
+	// Timeout limits the duration of a single processing attempt.
+	Timeout     time.Duration `yaml:"timeout" env:"MEDIA_WEBHOOKS_TIMEOUT"`
+	MaxAttempts int           `yaml:"max_attempts" env:"MEDIA_WEBHOOKS_MAX_ATTEMPTS"`
+	BaseBackoff time.Duration `yaml:"base_backoff" env:"MEDIA_WEBHOOKS_BASE_BACKOFF"`
+	MaxBackoff  time.Duration `yaml:"max_backoff" env:"MEDIA_WEBHOOKS_MAX_BACKOFF"`
+	// Retries overrides the retry policy per provider, e.g. "cloudinary", or per event type of a
+	// provider, e.g. "mux:video.asset.ready". Unset fields fall back to the default policy. They are
+	// configured in the YAML file only.
+	Retries map[string]WebhookRetryConfig `yaml:"retries"`
+}
+
+// WebhookRetryConfig is the retry policy of the webhooks of a provider or event type.
+type WebhookRetryConfig struct {
+	MaxAttempts int           `yaml:"max_attempts"`
+	BaseBackoff time.Duration `yaml:"base_backoff"`
+	MaxBackoff  time.Duration `yaml:"max_backoff"`
+}
+
+// EventsConfig holds configuration for the asset event publisher.
+type EventsConfig struct {
+	// Broker is the message broker to publish events to: "nats", "kafka" or "none".
+	Broker      string   `yaml:"broker" env:"MEDIA_EVENTS_BROKER"`
+	URLs        []string `yaml:"urls" env:"MEDIA_EVENTS_URLS"`
+	Encoding    string   `yaml:"encoding" env:"MEDIA_EVENTS_ENCODING"`
+	TopicPrefix string   `yaml:"topic_prefix" env:"MEDIA_EVENTS_TOPIC_PREFIX"`
+	// Topics overrides topic names per event type, e.g. "asset.created" -> "media-asset-created".
+	Topics map[string]string `yaml:"topics" env:"MEDIA_EVENTS_TOPICS"`
+}
+
+// NotificationsConfig holds configuration for notifications about asset events. Sinks and rules are
+// configured in the YAML file only.
+type NotificationsConfig struct {
+	Enabled bool `yaml:"enabled" env:"MEDIA_NOTIFICATIONS_ENABLED"`
+	// Timeout limits the delivery of a single notification to a sink.
+	Timeout time.Duration                     `yaml:"timeout" env:"MEDIA_NOTIFICATIONS_TIMEOUT"`
+	Sinks   map[string]NotificationSinkConfig `yaml:"sinks"`
+	Rules   []NotificationRuleConfig          `yaml:"rules"`
+}
+
+// OutgoingWebhooksConfig holds configuration for the outgoing webhooks registered by third-party
+// integrations. MaxAttempts, BaseBackoff and MaxBackoff make up the retry policy of failed deliveries.
+type OutgoingWebhooksConfig struct {
+	Enabled      bool          `yaml:"enabled" env:"MEDIA_OUTGOING_WEBHOOKS_ENABLED"`
+	PollInterval time.Duration `yaml:"poll_interval" env:"MEDIA_OUTGOING_WEBHOOKS_POLL_INTERVAL"`
+	BatchSize    int           `yaml:"batch_size" env:"MEDIA_OUTGOING_WEBHOOKS_BATCH_SIZE"`
+	// Timeout limits the duration of a single delivery attempt.
+	Timeout     time.Duration `yaml:"timeout" env:"MEDIA_OUTGOING_WEBHOOKS_TIMEOUT"`
+	MaxAttempts int           `yaml:"max_attempts" env:"MEDIA_OUTGOING_WEBHOOKS_MAX_ATTEMPTS"`
+	BaseBackoff time.Duration `yaml:"base_backoff" env:"MEDIA_OUTGOING_WEBHOOKS_BASE_BACKOFF"`
+	MaxBackoff  time.Duration `yaml:"max_backoff" env:"MEDIA_OUTGOING_WEBHOOKS_MAX_BACKOFF"`
+	// Retention is the time finished deliveries are kept in the delivery log.
+	Retention time.Duration `yaml:"retention" env:"MEDIA_OUTGOING_WEBHOOKS_RETENTION"`
+}
+
+// NotificationSinkConfig is a notification destination. Type is "smtp", "slack" or "webhook". URL is
+// used by the slack and webhook sinks, Secret signs the webhook requests, the rest configures the smtp sink.
+type NotificationSinkConfig struct {
+	Type     string   `yaml:"type"`
+	URL      string   `yaml:"url"`
+	Secret   string   `yaml:"secret"`
+	Host     string   `yaml:"host"`
+	Port     int      `yaml:"port"`
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+}
+
+// NotificationRuleConfig selects the asset events sent to the sinks. Empty filters match every event.
+type NotificationRuleConfig struct {
+	EventTypes []string `yaml:"event_types"`
+	Providers  []string `yaml:"providers"`
+	OwnerTypes []string `yaml:"owner_types"`
+	Sinks      []string `yaml:"sinks"`
+}
+
+// AuthConfig holds configuration for authentication of the admin HTTP API and the gRPC API.
+type AuthConfig struct {
+	Enabled bool `yaml:"enabled" env:"MEDIA_AUTH_ENABLED"`
+	// JWTSecret is the shared HMAC secret.
+	JWTSecret string `yaml:"jwt_secret" env:"AUTH_JWT_SECRET"`
+	JWKSURL   string `yaml:"jwks_url" env:"MEDIA_AUTH_JWKS_URL"`
+	Issuer    string `yaml:"issuer" env:"MEDIA_AUTH_ISSUER"`
+	Audience  string `yaml:"audience" env:"MEDIA_AUTH_AUDIENCE"`
+	// HTTPRules and GRPCRules are policy overrides in the "[METHOD ]PREFIX=ACCESS" form.
+	HTTPRules []string `yaml:"http_rules" env:"MEDIA_AUTH_HTTP_RULES"`
+	GRPCRules []string `yaml:"grpc_rules" env:"MEDIA_AUTH_GRPC_RULES"`
+	// GRPCDefaultAccess is the access level required by gRPC methods not matched by any rule.
+	GRPCDefaultAccess string `yaml:"grpc_default_access" env:"MEDIA_AUTH_GRPC_DEFAULT_ACCESS"`
+	// APIKeys are service-to-service gRPC keys in the "NAME:KEY:SCOPE[,SCOPE...]" form.
+	// The AUTH_API_KEYS environment variable separates keys with semicolons.
+	APIKeys []string `yaml:"api_keys" env:"AUTH_API_KEYS" sep:";"`
+}
+
+// MetricsConfig holds configuration for the Prometheus metrics endpoint.
+type MetricsConfig struct {
+	Enabled bool `yaml:"enabled" env:"MEDIA_METRICS_ENABLED"`
+	// Path is the HTTP path the metrics are exposed on.
+	Path string `yaml:"path" env:"MEDIA_METRICS_PATH"`
+	// AssetStatsInterval is the interval between refreshes of the asset count gauges.
+	AssetStatsInterval time.Duration `yaml:"asset_stats_interval" env:"MEDIA_METRICS_ASSET_STATS_INTERVAL"`
+}
+
+// TracingConfig holds configuration for OpenTelemetry tracing.
+type TracingConfig struct {
+	Enabled bool `yaml:"enabled" env:"MEDIA_TRACING_ENABLED"`
+	// Endpoint is the OTLP gRPC collector address.
+	Endpoint    string  `yaml:"endpoint" env:"MEDIA_TRACING_ENDPOINT"`
+	Insecure    bool    `yaml:"insecure" env:"MEDIA_TRACING_INSECURE"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"MEDIA_TRACING_SAMPLE_RATIO"`
+}
+
+// HealthConfig holds the per-dependency timeouts of the readiness checks.
+type HealthConfig struct {
+	PostgresTimeout   time.Duration `yaml:"postgres_timeout" env:"MEDIA_HEALTH_POSTGRES_TIMEOUT"`
+	MongoTimeout      time.Duration `yaml:"mongo_timeout" env:"MEDIA_HEALTH_MONGO_TIMEOUT"`
+	ArangoTimeout     time.Duration `yaml:"arango_timeout" env:"MEDIA_HEALTH_ARANGO_TIMEOUT"`
+	MuxTimeout        time.Duration `yaml:"mux_timeout" env:"MEDIA_HEALTH_MUX_TIMEOUT"`
+	CloudinaryTimeout time.Duration `yaml:"cloudinary_timeout" env:"MEDIA_HEALTH_CLOUDINARY_TIMEOUT"`
+	S3Timeout         time.Duration `yaml:"s3_timeout" env:"MEDIA_HEALTH_S3_TIMEOUT"`
+	CFStreamTimeout   time.Duration `yaml:"cfstream_timeout" env:"MEDIA_HEALTH_CFSTREAM_TIMEOUT"`
+	RedisTimeout      time.Duration `yaml:"redis_timeout" env:"MEDIA_HEALTH_REDIS_TIMEOUT"`
+	// GRPCInterval is the interval between checks published to the gRPC health service.
+	GRPCInterval time.Duration `yaml:"grpc_interval" env:"MEDIA_HEALTH_GRPC_INTERVAL"`
+	// ProviderCacheTTL is how long the result of a provider API check is reused, the providers rate
+	// limit their APIs and the readiness probes run every few seconds.
+	ProviderCacheTTL time.Duration `yaml:"provider_cache_ttl" env:"MEDIA_HEALTH_PROVIDER_CACHE_TTL"`
+}
+
+// TimeoutsConfig holds the request deadlines and the budgets of the calls made by the requests.
+type TimeoutsConfig struct {
+	// HTTP is the deadline of HTTP requests whose route has no deadline in Routes.
+	HTTP time.Duration `yaml:"http" env:"MEDIA_TIMEOUTS_HTTP"`
+	// GRPC is the deadline of gRPC calls whose method has no deadline in Routes. Callers may set an
+	// earlier one.
+	GRPC time.Duration `yaml:"grpc" env:"MEDIA_TIMEOUTS_GRPC"`
+	// Routes overrides the deadlines of HTTP routes, e.g. "POST /api/v1/admin/cloudinary/upload", and
+	// of full gRPC method names. 0 disables the deadline of the route.
+	Routes map[string]time.Duration `yaml:"routes"`
+	// API bounds a single call of the provider APIs. Uploads streamed to a provider are only bounded
+	// by the request deadline.
+	API time.Duration `yaml:"api" env:"MEDIA_TIMEOUTS_API"`
+	// Reserve is the part of the request deadline kept back from the provider API and downstream gRPC
+	// calls, so the request can still clean up and respond when a call times out.
+	Reserve time.Duration `yaml:"reserve" env:"MEDIA_TIMEOUTS_RESERVE"`
+}
+
+// PayloadConfig limits the request bodies accepted by the HTTP server. Streamed uploads are limited
+// by the upload settings of their provider instead.
+type PayloadConfig struct {
+	// MaxBodySize is the maximum size of a request body in bytes.
+	MaxBodySize int64 `yaml:"max_body_size" env:"MEDIA_PAYLOAD_MAX_BODY_SIZE"`
+	// WebhookMaxBodySize is the maximum size of a provider webhook body in bytes.
+	WebhookMaxBodySize int64 `yaml:"webhook_max_body_size" env:"MEDIA_PAYLOAD_WEBHOOK_MAX_BODY_SIZE"`
+	// MaxJSONDepth is the maximum nesting of the arrays and objects of a JSON body.
+	MaxJSONDepth int `yaml:"max_json_depth" env:"MEDIA_PAYLOAD_MAX_JSON_DEPTH"`
+}
+
+// MigrationsConfig controls how the PostgreSQL schema is brought up to date.
+type MigrationsConfig struct {
+	// AutoMigrate applies pending migrations on startup. When disabled, the service refuses to
+	// start until the schema is migrated with the migrate subcommand.
+	AutoMigrate bool `yaml:"auto_migrate" env:"MEDIA_MIGRATIONS_AUTO_MIGRATE"`
+}
+
+// ReadReplicaConfig controls routing of repository reads to a PostgreSQL read replica.
+type ReadReplicaConfig struct {
+	// Enabled sends Get, List and Count queries of the asset repositories to the replica. The
+	// replica shares the user, password and database name of the primary.
+	Enabled bool `yaml:"enabled" env:"MEDIA_READ_REPLICA_ENABLED"`
+}
+
+// CacheConfig holds configuration for the Redis cache of asset and metadata lookups. Entries are
+// invalidated on every write, the TTLs bound the staleness left by reads racing with a write.
+type CacheConfig struct {
+	Enabled  bool   `yaml:"enabled" env:"MEDIA_CACHE_ENABLED"`
+	Address  string `yaml:"address" env:"MEDIA_CACHE_REDIS_ADDRESS"`
+	Password string `yaml:"password" env:"REDIS_PASSWORD"`
+	DB       int    `yaml:"db" env:"MEDIA_CACHE_REDIS_DB"`
+	// KeyPrefix is prepended to every key, so that several deployments can share one Redis.
+	KeyPrefix   string        `yaml:"key_prefix" env:"MEDIA_CACHE_KEY_PREFIX"`
+	AssetTTL    time.Duration `yaml:"asset_ttl" env:"MEDIA_CACHE_ASSET_TTL"`
+	MetadataTTL time.Duration `yaml:"metadata_ttl" env:"MEDIA_CACHE_METADATA_TTL"`
+}
+
+// OwnerTypesConfig lists the owner types assets of each provider can be associated with. Owner
+// requests naming any other type are rejected.
+type OwnerTypesConfig struct {
+	Mux        []string `yaml:"mux" env:"MEDIA_OWNER_TYPES_MUX"`
+	Cloudinary []string `yaml:"cloudinary" env:"MEDIA_OWNER_TYPES_CLOUDINARY"`
+	S3         []string `yaml:"s3" env:"MEDIA_OWNER_TYPES_S3"`
+	CFStream   []string `yaml:"cfstream" env:"MEDIA_OWNER_TYPES_CFSTREAM"`
+}
+
+// OwnershipConfig holds the ownership policies enforced when an owner is added to an asset.
+type OwnershipConfig struct {
+	Mux        OwnershipPolicyConfig `yaml:"mux" env:"MEDIA_OWNERSHIP_MUX"`
+	Cloudinary OwnershipPolicyConfig `yaml:"cloudinary" env:"MEDIA_OWNERSHIP_CLOUDINARY"`
+	S3         OwnershipPolicyConfig `yaml:"s3" env:"MEDIA_OWNERSHIP_S3"`
+	CFStream   OwnershipPolicyConfig `yaml:"cfstream" env:"MEDIA_OWNERSHIP_CFSTREAM"`
+}
+
+// OwnershipPolicyConfig holds the ownership policies of a provider. Zero limits mean unlimited.
+//
+// The env tags of nested fields are suffixes appended to the env tag of the parent field.
+type OwnershipPolicyConfig struct {
+	MaxOwnersPerAsset int `yaml:"max_owners_per_asset" env:"_MAX_OWNERS_PER_ASSET"`
+	// OwnerTypes holds the policies of individual registered owner types, e.g.
+	// "course_part": {max_assets: 1, exclusive: true}. They are configured in the YAML file only.
+	OwnerTypes map[string]OwnerTypePolicyConfig `yaml:"owner_types"`
+}
+
+type OwnerTypePolicyConfig struct {
+	// MaxAssets is the maximum number of assets a single owner of the type may own.
+	MaxAssets int `yaml:"max_assets"`
+	// Exclusive assets of an owner of the type can not be shared with any other owner.
+	Exclusive bool `yaml:"exclusive"`
+}
+
+// UploadProxyConfig holds configuration for the upload proxy, which accepts resumable uploads
+// and relays them to the provider, for deployments where browsers can not reach the provider.
+type UploadProxyConfig struct {
+	Enabled bool `yaml:"enabled" env:"MEDIA_UPLOAD_PROXY_ENABLED"`
+	// MaxFileSize is the maximum size of a single upload in bytes.
+	MaxFileSize int64 `yaml:"max_file_size" env:"MEDIA_UPLOAD_PROXY_MAX_FILE_SIZE"`
+	// ChunkSize is the size of the chunks relayed to the provider, a multiple of 256 KiB.
+	ChunkSize int64 `yaml:"chunk_size" env:"MEDIA_UPLOAD_PROXY_CHUNK_SIZE"`
+	// SessionTTL is the time an idle upload session is kept before it is discarded.
+	SessionTTL time.Duration `yaml:"session_ttl" env:"MEDIA_UPLOAD_PROXY_SESSION_TTL"`
+	// Cloudinary configures the image upload endpoint, which is enabled along with the upload proxy.
+	Cloudinary CloudinaryUploadConfig `yaml:"cloudinary" env:"MEDIA_UPLOAD_PROXY_CLOUDINARY"`
+}
+
+// UploadPolicyConfig holds the preflight rules of Cloudinary image uploads, which are checked before
+// the upload parameters are signed. Zero limits and empty lists allow any upload.
+type UploadPolicyConfig struct {
+	// Folders lists the folders public IDs may be placed in, along with their subfolders. An empty
+	// entry allows the root folder.
+	Folders []string `yaml:"folders" env:"MEDIA_UPLOAD_POLICY_FOLDERS"`
+	// CreatorFolder is prefixed to the public IDs, "{creator}" is replaced with the ID of the uploading
+	// admin, e.g. "creators/{creator}". Folders are checked before the prefix is added.
+	CreatorFolder string `yaml:"creator_folder" env:"MEDIA_UPLOAD_POLICY_CREATOR_FOLDER"`
+	// MaxFileSize is the maximum declared size of an image in bytes.
+	MaxFileSize int64 `yaml:"max_file_size" env:"MEDIA_UPLOAD_POLICY_MAX_FILE_SIZE"`
+	MaxWidth    int   `yaml:"max_width" env:"MEDIA_UPLOAD_POLICY_MAX_WIDTH"`
+	MaxHeight   int   `yaml:"max_height" env:"MEDIA_UPLOAD_POLICY_MAX_HEIGHT"`
+	// Formats lists the allowed image formats, e.g. jpg, png and webp. They are signed along with the
+	// upload, so Cloudinary rejects files of other formats.
+	Formats []string `yaml:"formats" env:"MEDIA_UPLOAD_POLICY_FORMATS"`
+	// OwnerTypeFormats overrides Formats for the uploads of an owner type, e.g. "product": [jpg, webp].
+	// It is configured in the YAML file only.
+	OwnerTypeFormats map[string][]string `yaml:"owner_type_formats"`
+}
+
+// ExportConfig holds configuration for the asset inventory exports. Export jobs and their files are
+// local to the instance which created them.
+type ExportConfig struct {
+	Enabled bool `yaml:"enabled" env:"MEDIA_EXPORT_ENABLED"`
+	// Dir is the directory the export files are written to.
+	Dir string `yaml:"dir" env:"MEDIA_EXPORT_DIR"`
+	// Retention is the time a finished export and its file are kept.
+	Retention time.Duration `yaml:"retention" env:"MEDIA_EXPORT_RETENTION"`
+	// MaxPending is the maximum number of exports waiting to be run.
+	MaxPending int `yaml:"max_pending" env:"MEDIA_EXPORT_MAX_PENDING"`
+}
+
+// ImportConfig holds configuration for the imports of the assets created in MUX and Cloudinary
+// outside of the service. Import jobs are local to the instance which created them.
+type ImportConfig struct {
+	Enabled bool `yaml:"enabled" env:"MEDIA_IMPORT_ENABLED"`
+	// Retention is the time a finished import is kept.
+	Retention time.Duration `yaml:"retention" env:"MEDIA_IMPORT_RETENTION"`
+	// MaxPending is the maximum number of imports waiting to be run.
+	MaxPending int `yaml:"max_pending" env:"MEDIA_IMPORT_MAX_PENDING"`
+}
+
+// CloudinaryUploadConfig configures images uploaded through the service.
+//
+// The env tags of nested fields are suffixes appended to the env tag of the parent field.
+type CloudinaryUploadConfig struct {
+	// MaxFileSize is the maximum size of a single image in bytes.
+	MaxFileSize int64 `yaml:"max_file_size" env:"_MAX_FILE_SIZE"`
+	// Transformation is the incoming transformation applied before the image is stored, e.g. "c_limit,w_4096,h_4096".
+	Transformation string `yaml:"transformation" env:"_TRANSFORMATION"`
+	// Eager lists the derived transformations generated on upload, separated by "|".
+	Eager string `yaml:"eager" env:"_EAGER"`
+}
+
+// ModerationConfig holds configuration for moderation of uploaded images.
+type ModerationConfig struct {
+	// Cloudinary is the Cloudinary moderation add-on requested for every image upload: "manual",
+	// "aws_rek" or empty to disable moderation.
+	Cloudinary string `yaml:"cloudinary" env:"MEDIA_MODERATION_CLOUDINARY"`
+}
+
+// DuplicatesConfig holds configuration for the handling of duplicate uploads.
+type DuplicatesConfig struct {
+	// Policy is applied to uploaded images byte-identical to an active image: "allow" keeps them and
+	// only reports them as duplicates, "block" marks them as broken and deletes them from Cloudinary.
+	Policy string `yaml:"policy" env:"MEDIA_DUPLICATES_POLICY"`
+}
+
+// EnrichmentConfig holds configuration for the enrichment pipeline, which derives labels, dominant colors
+// and texts from uploaded images and ready videos and stores them in the asset metadata.
+type EnrichmentConfig struct {
+	Enabled bool `yaml:"enabled" env:"MEDIA_ENRICHMENT_ENABLED"`
+	// Categorization is the Cloudinary tagging add-on, e.g. "google_tagging" or "aws_rek_tagging".
+	// Images are not labeled if it is empty.
+	Categorization string `yaml:"categorization" env:"MEDIA_ENRICHMENT_CATEGORIZATION"`
+	// MinConfidence is the minimum confidence (0-1) of the stored labels.
+	MinConfidence float64 `yaml:"min_confidence" env:"MEDIA_ENRICHMENT_MIN_CONFIDENCE"`
+	// OCR enables text extraction by the Cloudinary advanced OCR add-on.
+	OCR bool `yaml:"ocr" env:"MEDIA_ENRICHMENT_OCR"`
+}
+
+// PlaybackConfig holds configuration for the playback sessions, which record the issued playback tokens.
+type PlaybackConfig struct {
+	// MaxSessionsPerUser limits the concurrent playback sessions of a user, 0 means unlimited.
+	MaxSessionsPerUser int `yaml:"max_sessions_per_user" env:"MEDIA_PLAYBACK_MAX_SESSIONS_PER_USER"`
+	// Restrictions limit the countries and the referrer domains the MUX playback tokens may be used from.
+	Restrictions PlaybackRestrictionsConfig `yaml:"restrictions" env:"MEDIA_PLAYBACK_RESTRICTIONS"`
+}
+
+// PlaybackRestrictionsConfig holds the default playback restriction policy of the MUX assets and the
+// policies overriding it for the assets of owner types. Countries are checked when a token is issued,
+// referrer domains are enforced by the MUX playback restrictions the policies are synchronized to.
+//
+// The env tags of nested fields are suffixes appended to the env tag of the parent field.
+type PlaybackRestrictionsConfig struct {
+	// CountryHeader is the request header carrying the country of the end user, set by the CDN or the
+	// load balancer in front of the service, e.g. CF-IPCountry.
+	CountryHeader string `yaml:"country_header" env:"_COUNTRY_HEADER"`
+	// AllowedCountries are ISO 3166-1 alpha-2 country codes, empty allows every country.
+	AllowedCountries []string `yaml:"allowed_countries" env:"_ALLOWED_COUNTRIES"`
+	// AllowedReferrers are the domains the players may be embedded in, e.g. "*.example.com". Empty does
+	// not restrict the referrer.
+	AllowedReferrers []string `yaml:"allowed_referrers" env:"_ALLOWED_REFERRERS"`
+	// AllowNoReferrer lets the playback requests without a Referer header through, e.g. of native apps.
+	AllowNoReferrer bool `yaml:"allow_no_referrer" env:"_ALLOW_NO_REFERRER"`
+	// OwnerTypes holds the policies of the assets of registered owner types, e.g.
+	// "course": {allowed_countries: [DE, AT]}. They are configured in the YAML file only.
+	OwnerTypes map[string]PlaybackRestrictionPolicyConfig `yaml:"owner_types"`
+}
+
+type PlaybackRestrictionPolicyConfig struct {
+	AllowedCountries []string `yaml:"allowed_countries"`
+	AllowedReferrers []string `yaml:"allowed_referrers"`
+	AllowNoReferrer  bool     `yaml:"allow_no_referrer"`
+}
+
+// DeliveryConfig holds configuration for the end-user media endpoints, which serve the playback
+// details of videos and the delivery URLs of images to the users allowed to access them.
+type DeliveryConfig struct {
+	Enabled bool `yaml:"enabled" env:"MEDIA_DELIVERY_ENABLED"`
+	// TokenTTL is the validity of the playback tokens issued to end users.
+	TokenTTL time.Duration `yaml:"token_ttl" env:"MEDIA_DELIVERY_TOKEN_TTL"`
+	// UserOwnerTypes are the owner types whose owner ID is a user ID, e.g. "profile". Users may
+	// access the assets they own.
+	UserOwnerTypes []string `yaml:"user_owner_types" env:"MEDIA_DELIVERY_USER_OWNER_TYPES"`
+	// TokenCookie is the cookie the playback token is set as for the players which cannot append it to
+	// every segment request. The CDN in front of MUX has to pass it on as the token query parameter.
+	TokenCookie DeliveryTokenCookieConfig `yaml:"token_cookie" env:"MEDIA_DELIVERY_TOKEN_COOKIE"`
+	// TokenHeader is the request header the CDN in front of MUX reads the playback token from, for the
+	// players which attach it as a header.
+	TokenHeader string `yaml:"token_header" env:"MEDIA_DELIVERY_TOKEN_HEADER"`
+}
+
+// DeliveryTokenCookieConfig holds the attributes of the playback token cookie. The cookie is always
+// Secure and HttpOnly.
+//
+// The env tags of nested fields are suffixes appended to the env tag of the parent field.
+type DeliveryTokenCookieConfig struct {
+	Name string `yaml:"name" env:"_NAME"`
+	// Domain is the domain of the CDN serving the streams, e.g. stream.example.com. Empty limits the
+	// cookie to the host of the service.
+	Domain string `yaml:"domain" env:"_DOMAIN"`
+	// Path may contain the {playback_id} placeholder, which limits the cookie to the stream of the
+	// asset, e.g. "/{playback_id}/". It defaults to "/", where the cookie of the last played asset wins.
+	Path string `yaml:"path" env:"_PATH"`
+}
+
+// SigningKeysConfig holds configuration for the rotation of the MUX URL signing keys. While enabled, the
+// playback tokens are signed with the rotated keys, the configured signing key is only used until the
+// first key of an environment was created.
+type SigningKeysConfig struct {
+	Enabled bool `yaml:"enabled" env:"MEDIA_SIGNING_KEYS_ENABLED"`
+	// RotationInterval is the age at which the signing key of an environment is replaced.
+	RotationInterval time.Duration `yaml:"rotation_interval" env:"MEDIA_SIGNING_KEYS_ROTATION_INTERVAL"`
+	// RetireAfter is how long a replaced key is kept in MUX, it must exceed the validity of the tokens.
+	RetireAfter   time.Duration `yaml:"retire_after" env:"MEDIA_SIGNING_KEYS_RETIRE_AFTER"`
+	CheckInterval time.Duration `yaml:"check_interval" env:"MEDIA_SIGNING_KEYS_CHECK_INTERVAL"`
+}
+
+// DevConfig holds configuration for the local development mode, which replaces the MUX and
+// Cloudinary API clients with in-memory emulators. Uploads are received by the service itself and
+// the provider webhooks are fired through /dev/webhooks, no provider account is needed.
+type DevConfig struct {
+	Enabled bool `yaml:"enabled" env:"MEDIA_DEV_ENABLED"`
+	// BaseURL is the URL of the HTTP API as seen by the clients, the emulated upload and delivery
+	// URLs point to it. It defaults to http://localhost:{http.port}/api/v1.
+	BaseURL string `yaml:"base_url" env:"MEDIA_DEV_BASE_URL"`
+}
+
+// SeedConfig holds configuration for the fixture loading endpoint. The fixtures can only be wiped in
+// dev mode, the endpoint is always served then.
+type SeedConfig struct {
+	Enabled bool `yaml:"enabled" env:"MEDIA_SEED_ENABLED"`
+}
+
+// APIResilienceConfig holds configuration for the retries and the circuit breakers of the Mux and
+// Cloudinary API clients. Each provider has its own breaker.
+type APIResilienceConfig struct {
+	Enabled bool `yaml:"enabled" env:"MEDIA_API_RESILIENCE_ENABLED"`
+	// MaxAttempts is the maximum number of attempts of an idempotent call, 1 disables retries.
+	MaxAttempts int           `yaml:"max_attempts" env:"MEDIA_API_RESILIENCE_MAX_ATTEMPTS"`
+	BaseDelay   time.Duration `yaml:"base_delay" env:"MEDIA_API_RESILIENCE_BASE_DELAY"`
+	MaxDelay    time.Duration `yaml:"max_delay" env:"MEDIA_API_RESILIENCE_MAX_DELAY"`
+	// BreakerThreshold is the number of consecutive failures opening the breaker, 0 disables the breaker.
+	BreakerThreshold int `yaml:"breaker_threshold" env:"MEDIA_API_RESILIENCE_BREAKER_THRESHOLD"`
+	// BreakerOpenTimeout is the time the breaker stays open before a probe call is let through.
+	BreakerOpenTimeout time.Duration `yaml:"breaker_open_timeout" env:"MEDIA_API_RESILIENCE_BREAKER_OPEN_TIMEOUT"`
+}
+
+// QuotaConfig holds the per-creator storage quotas checked before new uploads. Zero disables a quota.
+type QuotaConfig struct {
+	Mux        MuxQuotaConfig        `yaml:"mux"`
+	Cloudinary CloudinaryQuotaConfig `yaml:"cloudinary"`
+}
+
+type MuxQuotaConfig struct {
+	MaxAssets int64 `yaml:"max_assets" env:"MEDIA_QUOTA_MUX_MAX_ASSETS"`
+	// MaxDuration limits the total duration of the videos of a creator.
+	MaxDuration time.Duration `yaml:"max_duration" env:"MEDIA_QUOTA_MUX_MAX_DURATION"`
+}
+
+type CloudinaryQuotaConfig struct {
+	MaxAssets int64 `yaml:"max_assets" env:"MEDIA_QUOTA_CLOUDINARY_MAX_ASSETS"`
+	// MaxBytes limits the total size of the images of a creator.
+	MaxBytes int64 `yaml:"max_bytes" env:"MEDIA_QUOTA_CLOUDINARY_MAX_BYTES"`
+}
+
+// CostsConfig holds the rates of the monthly cost report. The defaults approximate the list prices
+// of the providers, they should be replaced with the contracted ones.
+type CostsConfig struct {
+	// Currency is the ISO 4217 code of the currency of the rates, e.g. USD.
+	Currency   string                `yaml:"currency" env:"MEDIA_COSTS_CURRENCY"`
+	Mux        MuxCostsConfig        `yaml:"mux"`
+	Cloudinary CloudinaryCostsConfig `yaml:"cloudinary"`
+}
+
+type MuxCostsConfig struct {
+	// EncodingPerMinute is charged once for each minute of video encoded.
+	EncodingPerMinute float64 `yaml:"encoding_per_minute" env:"MEDIA_COSTS_MUX_ENCODING_PER_MINUTE"`
+	// StoragePerMinute is charged for each minute of video stored for a month.
+	StoragePerMinute   float64 `yaml:"storage_per_minute" env:"MEDIA_COSTS_MUX_STORAGE_PER_MINUTE"`
+	StreamingPerMinute float64 `yaml:"streaming_per_minute" env:"MEDIA_COSTS_MUX_STREAMING_PER_MINUTE"`
+	// ResolutionMultipliers scale the rates of the videos by their resolution tier, e.g. "2160p": 2.
+	// They are configured in the YAML file only.
+	ResolutionMultipliers map[string]float64 `yaml:"resolution_multipliers"`
+}
+
+type CloudinaryCostsConfig struct {
+	// StoragePerGB is charged for each GB stored for a month.
+	StoragePerGB   float64 `yaml:"storage_per_gb" env:"MEDIA_COSTS_CLOUDINARY_STORAGE_PER_GB"`
+	BandwidthPerGB float64 `yaml:"bandwidth_per_gb" env:"MEDIA_COSTS_CLOUDINARY_BANDWIDTH_PER_GB"`
+}
+
+// SecretsConfig holds the 1Password service account token and the secret references resolved at
+// startup. The references keep their historical environment variable names.
+type SecretsConfig struct {
+	OnePasswordToken string `yaml:"onepassword_token" env:"OP_SERVICE_ACCOUNT_TOKEN"`
+
+	GRPCServerCertVaultRef string `yaml:"grpc_server_cert_vault_ref" env:"GRPC_SERVER_CERT_VAULT_REF"`
+	GRPCServerCertItemRef  string `yaml:"grpc_server_cert_item_ref" env:"GRPC_SERVER_CERT_ITEM_REF"`
+
+	GRPCClientAddressRef   string `yaml:"grpc_client_address_ref" env:"GRPC_CLIENT_ADDRESS_REF"`
+	GRPCClientCertVaultRef string `yaml:"grpc_client_cert_vault_ref" env:"GRPC_CLIENT_CERT_VAULT_REF"`
+	GRPCClientCertItemRef  string `yaml:"grpc_client_cert_item_ref" env:"GRPC_CLIENT_CERT_ITEM_REF"`
+
+	PostgresHostRef     string `yaml:"postgres_host_ref" env:"POSTGRES_HOST_REF"`
+	PostgresPortRef     string `yaml:"postgres_port_ref" env:"POSTGRES_PORT_REF"`
+	PostgresUserRef     string `yaml:"postgres_user_ref" env:"POSTGRES_USER_REF"`
+	PostgresPasswordRef string `yaml:"postgres_password_ref" env:"POSTGRES_PASSWORD_REF"`
+	PostgresDBNameRef   string `yaml:"postgres_dbname_ref" env:"POSTGRES_DBNAME_REF"`
+
+	PostgresReplicaHostRef string `yaml:"postgres_replica_host_ref" env:"POSTGRES_REPLICA_HOST_REF"`
+	PostgresReplicaPortRef string `yaml:"postgres_replica_port_ref" env:"POSTGRES_REPLICA_PORT_REF"`
+
+	MongoConnectionStringRef string `yaml:"mongo_connection_string_ref" env:"MONGO_CONNECTION_STRING_REF"`
+
+	// The ArangoDB credentials are required when the metadata is stored in ArangoDB, and by the
+	// metadata migration.
+	ArangoUserRef     string `yaml:"arango_user_ref" env:"ARANGO_USER_REF"`
+	ArangoPasswordRef string `yaml:"arango_password_ref" env:"ARANGO_PASSWORD_REF"`
+
+	MuxAPITokenRef              string `yaml:"mux_api_token_ref" env:"MUX_API_TOKEN_REF"`
+	MuxSecretKeyRef             string `yaml:"mux_secret_key_ref" env:"MUX_SECRET_KEY_REF"`
+	MuxSigningKeyIDRef          string `yaml:"mux_signing_key_id_ref" env:"MUX_SIGNING_KEY_ID_REF"`
+	MuxSigningKeyPrivateRef     string `yaml:"mux_signing_key_private_ref" env:"MUX_SIGNING_KEY_PRIVATE_REF"`
+	MuxPlaybackRestrictionIDRef string `yaml:"mux_playback_restriction_id_ref" env:"MUX_PLAYBACK_RESTRICTION_ID_REF"`
+
+	CloudinaryCloudNameRef string `yaml:"cloudinary_cloud_name_ref" env:"CLD_CLOUD_NAME_REF"`
+	CloudinaryAPIKeyRef    string `yaml:"cloudinary_api_key_ref" env:"CLD_API_KEY_REF"`
+	CloudinaryAPISecretRef string `yaml:"cloudinary_api_secret_ref" env:"CLD_API_SECRET_REF"`
+
+	// The S3 access keys are only resolved when the object storage is enabled.
+	S3AccessKeyIDRef     string `yaml:"s3_access_key_id_ref" env:"S3_ACCESS_KEY_ID_REF"`
+	S3SecretAccessKeyRef string `yaml:"s3_secret_access_key_ref" env:"S3_SECRET_ACCESS_KEY_REF"`
+
+	// The Cloudflare Stream secrets are only resolved when Cloudflare Stream is enabled, the signing
+	// key is optional unless signed URLs are required.
+	CFStreamAPITokenRef          string `yaml:"cfstream_api_token_ref" env:"CFSTREAM_API_TOKEN_REF"`
+	CFStreamSigningKeyIDRef      string `yaml:"cfstream_signing_key_id_ref" env:"CFSTREAM_SIGNING_KEY_ID_REF"`
+	CFStreamSigningKeyPrivateRef string `yaml:"cfstream_signing_key_private_ref" env:"CFSTREAM_SIGNING_KEY_PRIVATE_REF"`
+
+	// The encryption key of the rotated MUX signing keys is only resolved when the rotation is enabled.
+	// It is the base64 encoded 32 byte AES key the private keys are stored sealed with.
+	SigningKeysEncryptionKeyRef string `yaml:"signing_keys_encryption_key_ref" env:"SIGNING_KEYS_ENCRYPTION_KEY_REF"`
+}
+
+// Default returns the configuration with all defaults applied.
+func Default() *Config {
+	reload := 30 * time.Second
+	return &Config{
+		HTTP: HTTPConfig{Port: 8082, Docs: true, Gateway: true},
+		GRPC: GRPCConfig{
+			Port:        50052,
+			TLS:         TLSConfig{ClientAuth: "none", ReloadInterval: reload},
+			LogRequests: true,
+			Metrics:     true,
+		},
+		GRPCClient: GRPCClientConfig{
+			TLS:   TLSConfig{ReloadInterval: reload},
+			Video: DownstreamConfig{Timeout: 10 * time.Second, WaitForReady: true},
+			Image: DownstreamConfig{Timeout: 10 * time.Second, WaitForReady: true},
+		},
+		Log: LogConfig{
+			Directory:    "./logs",
+			UseTimestamp: true,
+			AppName:      "media-service",
+		},
+		MongoDB:                        MongoDBConfig{DbName: "media_service"},
+		ArangoDB:                       ArangoDBConfig{DbName: "media_service"},
+		Metadata:                       MetadataConfig{Backend: "mongo"},
+		Counts:                         CountsConfig{Strategy: "exact", TTL: time.Minute},
+		GracefulShutdownTimeoutSeconds: 15,
+		Mux:                            MuxAPIConfig{WebhookTolerance: 5 * time.Minute},
+		Retention: RetentionConfig{
+			TTL:          30 * 24 * time.Hour,
+			Interval:     time.Hour,
+			BatchSize:    100,
+			RetryBackoff: 24 * time.Hour,
+		},
+		Publishing: PublishingConfig{
+			Interval:  time.Minute,
+			BatchSize: 100,
+		},
+		Outbox: OutboxConfig{
+			PollInterval: time.Second,
+			BatchSize:    50,
+			MaxAttempts:  10,
+		},
+		Webhooks: WebhooksConfig{
+			PollInterval: time.Second,
+			BatchSize:    50,
+			Concurrency:  8,
+			Timeout:      30 * time.Second,
+			MaxAttempts:  10,
+			BaseBackoff:  time.Second,
+			MaxBackoff:   10 * time.Minute,
+		},
+		Events: EventsConfig{
+			Broker:      "none",
+			Encoding:    "json",
+			TopicPrefix: "media.",
+		},
+		Notifications: NotificationsConfig{
+			Timeout: 10 * time.Second,
+		},
+		OutgoingWebhooks: OutgoingWebhooksConfig{
+			PollInterval: time.Second,
+			BatchSize:    50,
+			Timeout:      10 * time.Second,
+			MaxAttempts:  10,
+			BaseBackoff:  10 * time.Second,
+			MaxBackoff:   time.Hour,
+			Retention:    30 * 24 * time.Hour,
+		},
+		Auth: AuthConfig{GRPCDefaultAccess: "admin"},
+		Metrics: MetricsConfig{
+			Enabled:            true,
+			Path:               "/metrics",
+			AssetStatsInterval: 5 * time.Minute,
+		},
+		Tracing: TracingConfig{
+			Endpoint:    "localhost:4317",
+			SampleRatio: 1,
+		},
+		Health: HealthConfig{
+			PostgresTimeout:   2 * time.Second,
+			MongoTimeout:      2 * time.Second,
+			ArangoTimeout:     2 * time.Second,
+			MuxTimeout:        5 * time.Second,
+			CloudinaryTimeout: 5 * time.Second,
+			S3Timeout:         5 * time.Second,
+			CFStreamTimeout:   5 * time.Second,
+			RedisTimeout:      time.Second,
+			GRPCInterval:      15 * time.Second,
+			ProviderCacheTTL:  time.Minute,
+		},
+		Timeouts: TimeoutsConfig{
+			HTTP:    60 * time.Second,
+			GRPC:    30 * time.Second,
+			API:     20 * time.Second,
+			Reserve: 2 * time.Second,
+		},
+		Payload: PayloadConfig{
+			MaxBodySize:        1 << 20,
+			WebhookMaxBodySize: 1 << 20,
+			MaxJSONDepth:       32,
+		},
+		Cache: CacheConfig{
+			Address:     "localhost:6379",
+			KeyPrefix:   "media:",
+			AssetTTL:    5 * time.Minute,
+			MetadataTTL: 5 * time.Minute,
+		},
+		OwnerTypes: OwnerTypesConfig{
+			Mux:        []string{"lesson"},
+			Cloudinary: []string{"product"},
+			S3:         []string{"product", "lesson"},
+			CFStream:   []string{"lesson"},
+		},
+		UploadProxy: UploadProxyConfig{
+			MaxFileSize: 5 << 30,
+			ChunkSize:   8 << 20,
+			SessionTTL:  24 * time.Hour,
+			Cloudinary:  CloudinaryUploadConfig{MaxFileSize: 20 << 20},
+		},
+		Export: ExportConfig{
+			Dir:        filepath.Join(os.TempDir(), "media-service-exports"),
+			Retention:  24 * time.Hour,
+			MaxPending: 10,
+		},
+		Import: ImportConfig{
+			Retention:  24 * time.Hour,
+			MaxPending: 5,
+		},
+		Duplicates: DuplicatesConfig{
+			Policy: "allow",
+		},
+		Enrichment: EnrichmentConfig{
+			Categorization: "google_tagging",
+			MinConfidence:  0.6,
+		},
+		Delivery: DeliveryConfig{
+			TokenTTL:    5 * time.Minute,
+			TokenCookie: DeliveryTokenCookieConfig{Name: "mux_playback_token", Path: "/"},
+			TokenHeader: "X-Playback-Token",
+		},
+		SigningKeys: SigningKeysConfig{
+			RotationInterval: 30 * 24 * time.Hour,
+			RetireAfter:      48 * time.Hour,
+			CheckInterval:    time.Hour,
+		},
+		Costs: CostsConfig{
+			Currency: "USD",
+			Mux: MuxCostsConfig{
+				EncodingPerMinute:     0.03,
+				StoragePerMinute:      0.003,
+				StreamingPerMinute:    0.0008,
+				ResolutionMultipliers: map[string]float64{"1440p": 1.5, "2160p": 2},
+			},
+			Cloudinary: CloudinaryCostsConfig{
+				StoragePerGB:   0.02,
+				BandwidthPerGB: 0.04,
+			},
+		},
+		APIResilience: APIResilienceConfig{
+			Enabled:            true,
+			MaxAttempts:        3,
+			BaseDelay:          200 * time.Millisecond,
+			MaxDelay:           2 * time.Second,
+			BreakerThreshold:   5,
+			BreakerOpenTimeout: 30 * time.Second,
+		},
+		S3: S3Config{
+			Region:         "us-east-1",
+			UploadURLTTL:   15 * time.Minute,
+			DownloadURLTTL: 5 * time.Minute,
+			MaxFileSize:    1 << 30,
+		},
+		CFStream: CFStreamConfig{
+			WebhookTolerance: 5 * time.Minute,
+			UploadURLTTL:     30 * time.Minute,
+			PlaybackTokenTTL: time.Hour,
+		},
+	}
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import "github.com/spf13/pflag"
+
+// bindFlags registers the command line flags. The current values of cfg, i.e. the defaults
+// overridden by the configuration file and the environment, are used as flag defaults, so only
+// flags set explicitly take precedence.
+func bindFlags(fs *pflag.FlagSet, cfg *Config) {
+	fs.Int64VarP(&cfg.GRPC.Port, "grpc-port", "g", cfg.GRPC.Port, "gRPC server port")
+	fs.BoolVarP(&cfg.GRPC.LogRequests, "grpc-log-requests", "", cfg.GRPC.LogRequests, "Log every gRPC request")
+	fs.BoolVarP(&cfg.GRPC.Metrics, "grpc-metrics", "", cfg.GRPC.Metrics, "Collect per-method gRPC latency and error metrics")
+	fs.BoolVarP(&cfg.GRPC.Reflection, "grpc-reflection", "", cfg.GRPC.Reflection, "Register the gRPC server reflection service")
+	fs.StringVarP(&cfg.GRPC.TLS.CertFile, "grpc-tls-cert", "", cfg.GRPC.TLS.CertFile, "gRPC server certificate file (overrides 1Password credentials)")
+	fs.StringVarP(&cfg.GRPC.TLS.KeyFile, "grpc-tls-key", "", cfg.GRPC.TLS.KeyFile, "gRPC server private key file")
+	fs.StringVarP(&cfg.GRPC.TLS.CAFile, "grpc-tls-client-ca", "", cfg.GRPC.TLS.CAFile, "CA bundle used to verify gRPC client certificates")
+	fs.StringVarP(&cfg.GRPC.TLS.ClientAuth, "grpc-tls-client-auth", "", cfg.GRPC.TLS.ClientAuth, "gRPC client certificate verification (none, request, require)")
+	fs.DurationVarP(&cfg.GRPC.TLS.ReloadInterval, "grpc-tls-reload-interval", "", cfg.GRPC.TLS.ReloadInterval, "Interval between checks for rotated gRPC server certificates")
+	fs.StringVarP(&cfg.GRPCClient.TLS.CAFile, "grpc-client-tls-ca", "", cfg.GRPCClient.TLS.CAFile, "CA bundle used to verify product service certificates (overrides 1Password credentials)")
+	fs.StringVarP(&cfg.GRPCClient.TLS.CertFile, "grpc-client-tls-cert", "", cfg.GRPCClient.TLS.CertFile, "Client certificate file presented to product service")
+	fs.StringVarP(&cfg.GRPCClient.TLS.KeyFile, "grpc-client-tls-key", "", cfg.GRPCClient.TLS.KeyFile, "Client private key file presented to product service")
+	fs.DurationVarP(&cfg.GRPCClient.TLS.ReloadInterval, "grpc-client-tls-reload-interval", "", cfg.GRPCClient.TLS.ReloadInterval, "Interval between checks for rotated gRPC client certificates")
+	fs.StringVarP(&cfg.GRPCClient.Video.Address, "grpc-client-video-address", "", cfg.GRPCClient.Video.Address, "Video service address (overrides the shared product service address)")
+	fs.DurationVarP(&cfg.GRPCClient.Video.Timeout, "grpc-client-video-timeout", "", cfg.GRPCClient.Video.Timeout, "Deadline of video service calls")
+	fs.BoolVarP(&cfg.GRPCClient.Video.WaitForReady, "grpc-client-video-wait-for-ready", "", cfg.GRPCClient.Video.WaitForReady, "Wait for the video service to become reachable until the call deadline")
+	fs.StringVarP(&cfg.GRPCClient.Image.Address, "grpc-client-image-address", "", cfg.GRPCClient.Image.Address, "Image service address (overrides the shared product service address)")
+	fs.DurationVarP(&cfg.GRPCClient.Image.Timeout, "grpc-client-image-timeout", "", cfg.GRPCClient.Image.Timeout, "Deadline of image service calls")
+	fs.BoolVarP(&cfg.GRPCClient.Image.WaitForReady, "grpc-client-image-wait-for-ready", "", cfg.GRPCClient.Image.WaitForReady, "Wait for the image service to become reachable until the call deadline")
+	fs.Int64VarP(&cfg.HTTP.Port, "http-port", "p", cfg.HTTP.Port, "HTTP server port")
+	fs.BoolVarP(&cfg.HTTP.Docs, "http-docs", "", cfg.HTTP.Docs, "Serve the OpenAPI document and Swagger UI")
+	fs.BoolVarP(&cfg.HTTP.Gateway, "http-gateway", "", cfg.HTTP.Gateway, "Serve the v1 gRPC services as JSON/REST")
+	fs.BoolVarP(&cfg.Dev.Enabled, "dev", "", cfg.Dev.Enabled, "Run against in-memory MUX and Cloudinary emulators, for local development only")
+	fs.StringVarP(&cfg.Dev.BaseURL, "dev-base-url", "", cfg.Dev.BaseURL, "URL of the HTTP API the emulated upload and delivery URLs point to")
+	fs.BoolVarP(&cfg.Seed.Enabled, "seed-enabled", "", cfg.Seed.Enabled, "Serve the admin endpoint loading the demo fixtures, always served in dev mode")
+	fs.IntVarP(&cfg.GracefulShutdownTimeoutSeconds, "graceful-shutdown-timeout", "t", cfg.GracefulShutdownTimeoutSeconds, "Graceful shutdown timeout in seconds")
+	fs.StringVarP(&cfg.Log.Directory, "log-directory", "l", cfg.Log.Directory, "Directory to store log files")
+	fs.BoolVarP(&cfg.Log.UseTimestamp, "log-use-timestamp", "", cfg.Log.UseTimestamp, "Whether to use timestamp in log file names")
+	fs.StringVarP(&cfg.MongoDB.DbName, "mongodb-db-name", "", cfg.MongoDB.DbName, "MongoDB database name")
+	fs.StringSliceVarP(&cfg.ArangoDB.Endpoints, "arangodb-endpoints", "", cfg.ArangoDB.Endpoints, "ArangoDB coordinator URLs")
+	fs.StringVarP(&cfg.ArangoDB.DbName, "arangodb-db-name", "", cfg.ArangoDB.DbName, "ArangoDB database name")
+	fs.StringVarP(&cfg.Metadata.Backend, "metadata-backend", "", cfg.Metadata.Backend, "Document store of the MUX and Cloudinary metadata: mongo or arango")
+	fs.StringVarP(&cfg.Counts.Strategy, "counts-strategy", "", cfg.Counts.Strategy, "Total count strategy of the asset list endpoints: exact, cached or estimate")
+	fs.DurationVarP(&cfg.Counts.TTL, "counts-ttl", "", cfg.Counts.TTL, "Time a cached list total is reused")
+	fs.BoolVarP(&cfg.Migrations.AutoMigrate, "migrations-auto", "", cfg.Migrations.AutoMigrate, "Apply pending database migrations on startup")
+	fs.BoolVarP(&cfg.ReadReplica.Enabled, "read-replica-enabled", "", cfg.ReadReplica.Enabled, "Route asset repository reads to the PostgreSQL read replica")
+	fs.BoolVarP(&cfg.Mux.TestMode, "mux-test-mode", "", cfg.Mux.TestMode, "Enable Mux test mode")
+	fs.StringVarP(&cfg.Mux.CORSOrigin, "mux-cors-origin", "", cfg.Mux.CORSOrigin, "Mux CORS origin")
+	fs.StringVarP(&cfg.Mux.WebhookSecret, "mux-webhook-secret", "", cfg.Mux.WebhookSecret, "Mux webhook signing secret (env MUX_WEBHOOK_SECRET)")
+	fs.DurationVarP(&cfg.Mux.WebhookTolerance, "mux-webhook-tolerance", "", cfg.Mux.WebhookTolerance, "Maximum accepted age of a signed Mux webhook")
+	fs.StringVarP(&cfg.Mux.DRMConfigurationID, "mux-drm-configuration-id", "", cfg.Mux.DRMConfigurationID, "Default Mux DRM configuration of new uploads, empty disables DRM")
+	fs.StringVarP(&cfg.Mux.EnvironmentID, "mux-environment-id", "", cfg.Mux.EnvironmentID, "Mux environment ID of the default environment")
+	fs.StringVarP(&cfg.Mux.Encoding.MaxResolutionTier, "mux-encoding-max-resolution-tier", "", cfg.Mux.Encoding.MaxResolutionTier, "Default maximum resolution tier of new uploads (1080p, 1440p or 2160p)")
+	fs.StringVarP(&cfg.Mux.Encoding.VideoQuality, "mux-encoding-video-quality", "", cfg.Mux.Encoding.VideoQuality, "Default video quality of new uploads (basic, plus or premium)")
+	fs.StringVarP(&cfg.Mux.Encoding.MP4Support, "mux-encoding-mp4-support", "", cfg.Mux.Encoding.MP4Support, "Default MP4 support of new uploads")
+	fs.BoolVarP(&cfg.Mux.Encoding.NormalizeAudio, "mux-encoding-normalize-audio", "", cfg.Mux.Encoding.NormalizeAudio, "Normalize the audio loudness of new uploads by default")
+	fs.StringSliceVarP(&cfg.Mux.Encoding.PlaybackPolicies, "mux-encoding-playback-policies", "", cfg.Mux.Encoding.PlaybackPolicies, "Default playback policies of new uploads")
+	fs.StringSliceVarP(&cfg.Mux.Encoding.AllowedMaxResolutionTiers, "mux-encoding-allowed-max-resolution-tiers", "", cfg.Mux.Encoding.AllowedMaxResolutionTiers, "Maximum resolution tiers uploads may select, empty allows any")
+	fs.StringSliceVarP(&cfg.Mux.Encoding.AllowedVideoQualities, "mux-encoding-allowed-video-qualities", "", cfg.Mux.Encoding.AllowedVideoQualities, "Video qualities uploads may select, empty allows any")
+	fs.StringSliceVarP(&cfg.Mux.Encoding.AllowedMP4Supports, "mux-encoding-allowed-mp4-supports", "", cfg.Mux.Encoding.AllowedMP4Supports, "MP4 support options uploads may select, empty allows any")
+	fs.StringSliceVarP(&cfg.Mux.Encoding.AllowedPlaybackPolicies, "mux-encoding-allowed-playback-policies", "", cfg.Mux.Encoding.AllowedPlaybackPolicies, "Playback policies uploads may select, empty allows any")
+	fs.BoolVarP(&cfg.Retention.Enabled, "retention-enabled", "", cfg.Retention.Enabled, "Enable automatic purge of archived assets")
+	fs.DurationVarP(&cfg.Retention.TTL, "retention-ttl", "", cfg.Retention.TTL, "Time after soft deletion when archived assets are permanently deleted")
+	fs.DurationVarP(&cfg.Retention.Interval, "retention-interval", "", cfg.Retention.Interval, "Interval between retention worker runs")
+	fs.IntVarP(&cfg.Retention.BatchSize, "retention-batch-size", "", cfg.Retention.BatchSize, "Maximum number of assets purged per provider in a single run")
+	fs.DurationVarP(&cfg.Retention.RetryBackoff, "retention-retry-backoff", "", cfg.Retention.RetryBackoff, "Time before an asset whose purge failed is selected again")
+	fs.BoolVarP(&cfg.Retention.DryRun, "retention-dry-run", "", cfg.Retention.DryRun, "Only record assets that would be purged without deleting them")
+	fs.DurationVarP(&cfg.Publishing.Interval, "publishing-interval", "", cfg.Publishing.Interval, "Interval between publishing worker runs")
+	fs.IntVarP(&cfg.Publishing.BatchSize, "publishing-batch-size", "", cfg.Publishing.BatchSize, "Maximum number of assets published or unpublished per provider in a single batch")
+	fs.DurationVarP(&cfg.Outbox.PollInterval, "outbox-poll-interval", "", cfg.Outbox.PollInterval, "Interval between outbox dispatcher polls")
+	fs.IntVarP(&cfg.Outbox.BatchSize, "outbox-batch-size", "", cfg.Outbox.BatchSize, "Maximum number of outbox events delivered in a single poll")
+	fs.IntVarP(&cfg.Outbox.MaxAttempts, "outbox-max-attempts", "", cfg.Outbox.MaxAttempts, "Number of delivery attempts after which outbox event is marked as failed")
+	fs.IntVarP(&cfg.Webhooks.Concurrency, "webhooks-concurrency", "", cfg.Webhooks.Concurrency, "Maximum number of webhooks processed at the same time")
+	fs.IntVarP(&cfg.Webhooks.MaxAttempts, "webhooks-max-attempts", "", cfg.Webhooks.MaxAttempts, "Number of processing attempts after which a webhook is marked as failed")
+	fs.StringVarP(&cfg.Events.Broker, "events-broker", "", cfg.Events.Broker, "Message broker for asset events (nats, kafka, none)")
+	fs.StringSliceVarP(&cfg.Events.URLs, "events-urls", "", cfg.Events.URLs, "Message broker addresses")
+	fs.StringVarP(&cfg.Events.Encoding, "events-encoding", "", cfg.Events.Encoding, "Asset event payload encoding (json, protobuf)")
+	fs.StringVarP(&cfg.Events.TopicPrefix, "events-topic-prefix", "", cfg.Events.TopicPrefix, "Prefix for default asset event topic names")
+	fs.StringToStringVarP(&cfg.Events.Topics, "events-topic", "", cfg.Events.Topics, "Per-event topic overrides, e.g. asset.created=media-asset-created")
+	fs.BoolVarP(&cfg.Notifications.Enabled, "notifications-enabled", "", cfg.Notifications.Enabled, "Send notifications about asset events to the configured sinks")
+	fs.DurationVarP(&cfg.Notifications.Timeout, "notifications-timeout", "", cfg.Notifications.Timeout, "Maximum duration of a single notification delivery")
+	fs.BoolVarP(&cfg.OutgoingWebhooks.Enabled, "outgoing-webhooks-enabled", "", cfg.OutgoingWebhooks.Enabled, "Deliver asset events to the registered outgoing webhooks")
+	fs.IntVarP(&cfg.OutgoingWebhooks.MaxAttempts, "outgoing-webhooks-max-attempts", "", cfg.OutgoingWebhooks.MaxAttempts, "Number of attempts after which an outgoing webhook delivery is marked as failed")
+	fs.DurationVarP(&cfg.OutgoingWebhooks.Retention, "outgoing-webhooks-retention", "", cfg.OutgoingWebhooks.Retention, "Time finished outgoing webhook deliveries are kept in the delivery log")
+	fs.BoolVarP(&cfg.Auth.Enabled, "auth-enabled", "", cfg.Auth.Enabled, "Require bearer token authentication for admin HTTP and gRPC APIs")
+	fs.StringVarP(&cfg.Auth.JWTSecret, "auth-jwt-secret", "", cfg.Auth.JWTSecret, "Shared HMAC secret for JWT validation (env AUTH_JWT_SECRET)")
+	fs.StringVarP(&cfg.Auth.JWKSURL, "auth-jwks-url", "", cfg.Auth.JWKSURL, "JWKS URL for JWT validation")
+	fs.StringVarP(&cfg.Auth.Issuer, "auth-issuer", "", cfg.Auth.Issuer, "Required JWT issuer")
+	fs.StringVarP(&cfg.Auth.Audience, "auth-audience", "", cfg.Auth.Audience, "Required JWT audience")
+	fs.StringArrayVarP(&cfg.Auth.HTTPRules, "auth-http-rule", "", cfg.Auth.HTTPRules, "HTTP access rule override, e.g. \"GET /api/v1/admin=read_only\"")
+	fs.StringArrayVarP(&cfg.Auth.GRPCRules, "auth-grpc-rule", "", cfg.Auth.GRPCRules, "gRPC access rule override, e.g. \"/media_service.mux.asset.v1.AssetService/Ping=public\"")
+	fs.StringVarP(&cfg.Auth.GRPCDefaultAccess, "auth-grpc-default-access", "", cfg.Auth.GRPCDefaultAccess, "Access level for gRPC methods not matched by any rule (public, user, read_only, admin)")
+	fs.StringArrayVarP(&cfg.Auth.APIKeys, "auth-api-key", "", cfg.Auth.APIKeys, "Service gRPC API key as NAME:KEY:SCOPE[,SCOPE...] (env AUTH_API_KEYS)")
+	fs.BoolVarP(&cfg.Metrics.Enabled, "metrics-enabled", "", cfg.Metrics.Enabled, "Expose Prometheus metrics")
+	fs.StringVarP(&cfg.Metrics.Path, "metrics-path", "", cfg.Metrics.Path, "HTTP path of the Prometheus metrics endpoint")
+	fs.DurationVarP(&cfg.Metrics.AssetStatsInterval, "metrics-asset-stats-interval", "", cfg.Metrics.AssetStatsInterval, "Interval between refreshes of the asset count gauges")
+	fs.BoolVarP(&cfg.Tracing.Enabled, "tracing-enabled", "", cfg.Tracing.Enabled, "Export OpenTelemetry traces over OTLP")
+	fs.StringVarP(&cfg.Tracing.Endpoint, "tracing-endpoint", "", cfg.Tracing.Endpoint, "OTLP gRPC collector endpoint")
+	fs.BoolVarP(&cfg.Tracing.Insecure, "tracing-insecure", "", cfg.Tracing.Insecure, "Connect to the OTLP collector without TLS")
+	fs.Float64VarP(&cfg.Tracing.SampleRatio, "tracing-sample-ratio", "", cfg.Tracing.SampleRatio, "Fraction of root traces sampled (0-1)")
+	fs.DurationVarP(&cfg.Health.PostgresTimeout, "health-postgres-timeout", "", cfg.Health.PostgresTimeout, "Timeout of the PostgreSQL readiness check")
+	fs.DurationVarP(&cfg.Health.MongoTimeout, "health-mongo-timeout", "", cfg.Health.MongoTimeout, "Timeout of the MongoDB readiness check")
+	fs.DurationVarP(&cfg.Health.ArangoTimeout, "health-arango-timeout", "", cfg.Health.ArangoTimeout, "Timeout of the ArangoDB readiness check")
+	fs.DurationVarP(&cfg.Health.MuxTimeout, "health-mux-timeout", "", cfg.Health.MuxTimeout, "Timeout of the Mux API reachability check")
+	fs.DurationVarP(&cfg.Health.CloudinaryTimeout, "health-cloudinary-timeout", "", cfg.Health.CloudinaryTimeout, "Timeout of the Cloudinary API reachability check")
+	fs.DurationVarP(&cfg.Health.S3Timeout, "health-s3-timeout", "", cfg.Health.S3Timeout, "Timeout of the S3 bucket reachability check")
+	fs.DurationVarP(&cfg.Health.CFStreamTimeout, "health-cfstream-timeout", "", cfg.Health.CFStreamTimeout, "Timeout of the Cloudflare Stream API reachability check")
+	fs.DurationVarP(&cfg.Health.RedisTimeout, "health-redis-timeout", "", cfg.Health.RedisTimeout, "Timeout of the Redis cache reachability check")
+	fs.DurationVarP(&cfg.Health.GRPCInterval, "health-grpc-interval", "", cfg.Health.GRPCInterval, "Interval between checks published to the gRPC health service")
+	fs.DurationVarP(&cfg.Health.ProviderCacheTTL, "health-provider-cache-ttl", "", cfg.Health.ProviderCacheTTL, "Duration the result of a provider API check is reused")
+	fs.DurationVarP(&cfg.Timeouts.HTTP, "timeouts-http", "", cfg.Timeouts.HTTP, "Default deadline of HTTP requests")
+	fs.DurationVarP(&cfg.Timeouts.GRPC, "timeouts-grpc", "", cfg.Timeouts.GRPC, "Default deadline of gRPC calls")
+	fs.DurationVarP(&cfg.Timeouts.API, "timeouts-api", "", cfg.Timeouts.API, "Timeout of a single provider API call")
+	fs.DurationVarP(&cfg.Timeouts.Reserve, "timeouts-reserve", "", cfg.Timeouts.Reserve, "Part of the request deadline kept back from provider and downstream calls")
+	fs.Int64VarP(&cfg.Payload.MaxBodySize, "payload-max-body-size", "", cfg.Payload.MaxBodySize, "Maximum size of a request body in bytes")
+	fs.Int64VarP(&cfg.Payload.WebhookMaxBodySize, "payload-webhook-max-body-size", "", cfg.Payload.WebhookMaxBodySize, "Maximum size of a provider webhook body in bytes")
+	fs.IntVarP(&cfg.Payload.MaxJSONDepth, "payload-max-json-depth", "", cfg.Payload.MaxJSONDepth, "Maximum nesting of a JSON request body")
+	fs.BoolVarP(&cfg.Cache.Enabled, "cache-enabled", "", cfg.Cache.Enabled, "Cache asset and metadata lookups in Redis")
+	fs.StringVarP(&cfg.Cache.Address, "cache-redis-address", "", cfg.Cache.Address, "Redis server address")
+	fs.StringVarP(&cfg.Cache.Password, "cache-redis-password", "", cfg.Cache.Password, "Redis password (env REDIS_PASSWORD)")
+	fs.IntVarP(&cfg.Cache.DB, "cache-redis-db", "", cfg.Cache.DB, "Redis database number")
+	fs.StringVarP(&cfg.Cache.KeyPrefix, "cache-key-prefix", "", cfg.Cache.KeyPrefix, "Prefix of all cache keys")
+	fs.DurationVarP(&cfg.Cache.AssetTTL, "cache-asset-ttl", "", cfg.Cache.AssetTTL, "Time an asset record stays cached")
+	fs.DurationVarP(&cfg.Cache.MetadataTTL, "cache-metadata-ttl", "", cfg.Cache.MetadataTTL, "Time asset metadata stays cached")
+
+	fs.StringSliceVarP(&cfg.OwnerTypes.Mux, "owner-types-mux", "", cfg.OwnerTypes.Mux, "Owner types MUX assets can be associated with")
+	fs.StringSliceVarP(&cfg.OwnerTypes.Cloudinary, "owner-types-cloudinary", "", cfg.OwnerTypes.Cloudinary, "Owner types Cloudinary assets can be associated with")
+	fs.StringSliceVarP(&cfg.OwnerTypes.S3, "owner-types-s3", "", cfg.OwnerTypes.S3, "Owner types file assets can be associated with")
+	fs.StringSliceVarP(&cfg.OwnerTypes.CFStream, "owner-types-cfstream", "", cfg.OwnerTypes.CFStream, "Owner types Cloudflare Stream assets can be associated with")
+	fs.IntVarP(&cfg.Ownership.Mux.MaxOwnersPerAsset, "ownership-mux-max-owners-per-asset", "", cfg.Ownership.Mux.MaxOwnersPerAsset, "Maximum owners of a MUX asset, 0 means unlimited")
+	fs.IntVarP(&cfg.Ownership.Cloudinary.MaxOwnersPerAsset, "ownership-cloudinary-max-owners-per-asset", "", cfg.Ownership.Cloudinary.MaxOwnersPerAsset, "Maximum owners of a Cloudinary asset, 0 means unlimited")
+	fs.BoolVarP(&cfg.UploadProxy.Enabled, "upload-proxy-enabled", "", cfg.UploadProxy.Enabled, "Accept resumable uploads and relay them to the provider")
+	fs.Int64VarP(&cfg.UploadProxy.MaxFileSize, "upload-proxy-max-file-size", "", cfg.UploadProxy.MaxFileSize, "Maximum size of a proxied upload in bytes")
+	fs.Int64VarP(&cfg.UploadProxy.ChunkSize, "upload-proxy-chunk-size", "", cfg.UploadProxy.ChunkSize, "Size of the chunks relayed to the provider in bytes, a multiple of 256 KiB")
+	fs.DurationVarP(&cfg.UploadProxy.SessionTTL, "upload-proxy-session-ttl", "", cfg.UploadProxy.SessionTTL, "Time an idle upload session is kept")
+	fs.Int64VarP(&cfg.UploadProxy.Cloudinary.MaxFileSize, "upload-proxy-cloudinary-max-file-size", "", cfg.UploadProxy.Cloudinary.MaxFileSize, "Maximum size of an image uploaded through the service in bytes")
+	fs.StringVarP(&cfg.UploadProxy.Cloudinary.Transformation, "upload-proxy-cloudinary-transformation", "", cfg.UploadProxy.Cloudinary.Transformation, "Incoming Cloudinary transformation applied to uploaded images")
+	fs.StringSliceVarP(&cfg.UploadPolicy.Folders, "upload-policy-folders", "", cfg.UploadPolicy.Folders, "Folders Cloudinary public IDs may be placed in, empty allows any folder")
+	fs.StringVarP(&cfg.UploadPolicy.CreatorFolder, "upload-policy-creator-folder", "", cfg.UploadPolicy.CreatorFolder, "Folder prefixed to Cloudinary public IDs, {creator} is replaced with the admin ID")
+	fs.Int64VarP(&cfg.UploadPolicy.MaxFileSize, "upload-policy-max-file-size", "", cfg.UploadPolicy.MaxFileSize, "Maximum declared size of a signed image upload in bytes, 0 means unlimited")
+	fs.IntVarP(&cfg.UploadPolicy.MaxWidth, "upload-policy-max-width", "", cfg.UploadPolicy.MaxWidth, "Maximum declared width of a signed image upload, 0 means unlimited")
+	fs.IntVarP(&cfg.UploadPolicy.MaxHeight, "upload-policy-max-height", "", cfg.UploadPolicy.MaxHeight, "Maximum declared height of a signed image upload, 0 means unlimited")
+	fs.StringSliceVarP(&cfg.UploadPolicy.Formats, "upload-policy-formats", "", cfg.UploadPolicy.Formats, "Allowed formats of uploaded images, empty allows any format")
+	fs.StringVarP(&cfg.UploadProxy.Cloudinary.Eager, "upload-proxy-cloudinary-eager", "", cfg.UploadProxy.Cloudinary.Eager, "Eager Cloudinary transformations generated for uploaded images")
+	fs.BoolVarP(&cfg.Export.Enabled, "export-enabled", "", cfg.Export.Enabled, "Serve the asset inventory exports")
+	fs.StringVarP(&cfg.Export.Dir, "export-dir", "", cfg.Export.Dir, "Directory the export files are written to")
+	fs.DurationVarP(&cfg.Export.Retention, "export-retention", "", cfg.Export.Retention, "Time a finished export and its file are kept")
+	fs.IntVarP(&cfg.Export.MaxPending, "export-max-pending", "", cfg.Export.MaxPending, "Maximum number of exports waiting to be run")
+	fs.BoolVarP(&cfg.Import.Enabled, "import-enabled", "", cfg.Import.Enabled, "Serve the imports of existing MUX and Cloudinary assets")
+	fs.DurationVarP(&cfg.Import.Retention, "import-retention", "", cfg.Import.Retention, "Time a finished import is kept")
+	fs.IntVarP(&cfg.Import.MaxPending, "import-max-pending", "", cfg.Import.MaxPending, "Maximum number of imports waiting to be run")
+	fs.BoolVarP(&cfg.Enrichment.Enabled, "enrichment-enabled", "", cfg.Enrichment.Enabled, "Derive labels, colors and texts from uploaded images and ready videos")
+	fs.StringVarP(&cfg.Enrichment.Categorization, "enrichment-categorization", "", cfg.Enrichment.Categorization, "Cloudinary tagging add-on used to label images, empty disables labels")
+	fs.Float64VarP(&cfg.Enrichment.MinConfidence, "enrichment-min-confidence", "", cfg.Enrichment.MinConfidence, "Minimum confidence (0-1) of stored image labels")
+	fs.BoolVarP(&cfg.Enrichment.OCR, "enrichment-ocr", "", cfg.Enrichment.OCR, "Extract image texts with the Cloudinary OCR add-on")
+	fs.IntVarP(&cfg.Playback.MaxSessionsPerUser, "playback-max-sessions-per-user", "", cfg.Playback.MaxSessionsPerUser, "Maximum concurrent playback sessions of a user, 0 means unlimited")
+	fs.StringVarP(&cfg.Playback.Restrictions.CountryHeader, "playback-restrictions-country-header", "", cfg.Playback.Restrictions.CountryHeader, "Request header carrying the country of the end user, e.g. CF-IPCountry")
+	fs.StringSliceVarP(&cfg.Playback.Restrictions.AllowedCountries, "playback-restrictions-allowed-countries", "", cfg.Playback.Restrictions.AllowedCountries, "Countries playback tokens may be issued to, empty allows every country")
+	fs.StringSliceVarP(&cfg.Playback.Restrictions.AllowedReferrers, "playback-restrictions-allowed-referrers", "", cfg.Playback.Restrictions.AllowedReferrers, "Referrer domains MUX playback is allowed from, empty does not restrict the referrer")
+	fs.BoolVarP(&cfg.Playback.Restrictions.AllowNoReferrer, "playback-restrictions-allow-no-referrer", "", cfg.Playback.Restrictions.AllowNoReferrer, "Allow MUX playback requests without a Referer header")
+	fs.BoolVarP(&cfg.Delivery.Enabled, "delivery-enabled", "", cfg.Delivery.Enabled, "Serve the end-user video playback and image delivery endpoints")
+	fs.DurationVarP(&cfg.Delivery.TokenTTL, "delivery-token-ttl", "", cfg.Delivery.TokenTTL, "Validity of the playback tokens issued to end users")
+	fs.StringVarP(&cfg.Delivery.TokenCookie.Name, "delivery-token-cookie-name", "", cfg.Delivery.TokenCookie.Name, "Name of the cookie the playback token is set as")
+	fs.StringVarP(&cfg.Delivery.TokenCookie.Domain, "delivery-token-cookie-domain", "", cfg.Delivery.TokenCookie.Domain, "Domain of the playback token cookie, e.g. the CDN serving the streams")
+	fs.StringVarP(&cfg.Delivery.TokenCookie.Path, "delivery-token-cookie-path", "", cfg.Delivery.TokenCookie.Path, "Path of the playback token cookie, {playback_id} is replaced with the playback ID")
+	fs.StringVarP(&cfg.Delivery.TokenHeader, "delivery-token-header", "", cfg.Delivery.TokenHeader, "Request header the CDN reads the playback token from")
+	fs.BoolVarP(&cfg.SigningKeys.Enabled, "signing-keys-enabled", "", cfg.SigningKeys.Enabled, "Rotate the MUX URL signing keys the playback tokens are signed with")
+	fs.DurationVarP(&cfg.SigningKeys.RotationInterval, "signing-keys-rotation-interval", "", cfg.SigningKeys.RotationInterval, "Age at which a MUX signing key is replaced")
+	fs.DurationVarP(&cfg.SigningKeys.RetireAfter, "signing-keys-retire-after", "", cfg.SigningKeys.RetireAfter, "Time a replaced MUX signing key keeps being accepted")
+	fs.DurationVarP(&cfg.SigningKeys.CheckInterval, "signing-keys-check-interval", "", cfg.SigningKeys.CheckInterval, "Interval the MUX signing keys are checked for rotation at")
+	fs.Int64VarP(&cfg.Quota.Mux.MaxAssets, "quota-mux-max-assets", "", cfg.Quota.Mux.MaxAssets, "Maximum MUX assets of a creator, 0 means unlimited")
+	fs.DurationVarP(&cfg.Quota.Mux.MaxDuration, "quota-mux-max-duration", "", cfg.Quota.Mux.MaxDuration, "Maximum total duration of the MUX assets of a creator, 0 means unlimited")
+	fs.Int64VarP(&cfg.Quota.Cloudinary.MaxAssets, "quota-cloudinary-max-assets", "", cfg.Quota.Cloudinary.MaxAssets, "Maximum Cloudinary assets of a creator, 0 means unlimited")
+	fs.BoolVarP(&cfg.APIResilience.Enabled, "api-resilience-enabled", "", cfg.APIResilience.Enabled, "Retry idempotent Mux and Cloudinary API calls and short-circuit calls while a provider is down")
+	fs.IntVarP(&cfg.APIResilience.MaxAttempts, "api-resilience-max-attempts", "", cfg.APIResilience.MaxAttempts, "Maximum attempts of an idempotent provider API call")
+	fs.DurationVarP(&cfg.APIResilience.BaseDelay, "api-resilience-base-delay", "", cfg.APIResilience.BaseDelay, "Backoff before the first retry of a provider API call")
+	fs.DurationVarP(&cfg.APIResilience.MaxDelay, "api-resilience-max-delay", "", cfg.APIResilience.MaxDelay, "Maximum backoff between retries of a provider API call")
+	fs.IntVarP(&cfg.APIResilience.BreakerThreshold, "api-resilience-breaker-threshold", "", cfg.APIResilience.BreakerThreshold, "Consecutive provider failures opening the circuit breaker, 0 disables the breaker")
+	fs.DurationVarP(&cfg.APIResilience.BreakerOpenTimeout, "api-resilience-breaker-open-timeout", "", cfg.APIResilience.BreakerOpenTimeout, "Time the circuit breaker stays open before a probe call")
+	fs.Int64VarP(&cfg.Quota.Cloudinary.MaxBytes, "quota-cloudinary-max-bytes", "", cfg.Quota.Cloudinary.MaxBytes, "Maximum total size of the Cloudinary assets of a creator in bytes, 0 means unlimited")
+	fs.StringVarP(&cfg.Costs.Currency, "costs-currency", "", cfg.Costs.Currency, "ISO 4217 currency of the cost rates")
+	fs.Float64VarP(&cfg.Costs.Mux.EncodingPerMinute, "costs-mux-encoding-per-minute", "", cfg.Costs.Mux.EncodingPerMinute, "Cost of encoding a minute of MUX video")
+	fs.Float64VarP(&cfg.Costs.Mux.StoragePerMinute, "costs-mux-storage-per-minute", "", cfg.Costs.Mux.StoragePerMinute, "Cost of storing a minute of MUX video for a month")
+	fs.Float64VarP(&cfg.Costs.Mux.StreamingPerMinute, "costs-mux-streaming-per-minute", "", cfg.Costs.Mux.StreamingPerMinute, "Cost of streaming a minute of MUX video")
+	fs.Float64VarP(&cfg.Costs.Cloudinary.StoragePerGB, "costs-cloudinary-storage-per-gb", "", cfg.Costs.Cloudinary.StoragePerGB, "Cost of storing a GB of Cloudinary images for a month")
+	fs.Float64VarP(&cfg.Costs.Cloudinary.BandwidthPerGB, "costs-cloudinary-bandwidth-per-gb", "", cfg.Costs.Cloudinary.BandwidthPerGB, "Cost of delivering a GB of Cloudinary images")
+	fs.BoolVarP(&cfg.S3.Enabled, "s3-enabled", "", cfg.S3.Enabled, "Store raw file assets in an S3-compatible object storage")
+	fs.StringVarP(&cfg.S3.Endpoint, "s3-endpoint", "", cfg.S3.Endpoint, "Base URL of the S3 API")
+	fs.StringVarP(&cfg.S3.Region, "s3-region", "", cfg.S3.Region, "Region of the S3 bucket")
+	fs.StringVarP(&cfg.S3.Bucket, "s3-bucket", "", cfg.S3.Bucket, "S3 bucket of the file assets")
+	fs.BoolVarP(&cfg.S3.PathStyle, "s3-path-style", "", cfg.S3.PathStyle, "Address the S3 bucket in the URL path, as required by MinIO")
+	fs.StringVarP(&cfg.S3.KeyPrefix, "s3-key-prefix", "", cfg.S3.KeyPrefix, "Prefix of the keys of all objects")
+	fs.DurationVarP(&cfg.S3.UploadURLTTL, "s3-upload-url-ttl", "", cfg.S3.UploadURLTTL, "Validity of presigned file uploads")
+	fs.DurationVarP(&cfg.S3.DownloadURLTTL, "s3-download-url-ttl", "", cfg.S3.DownloadURLTTL, "Validity of presigned file downloads")
+	fs.Int64VarP(&cfg.S3.MaxFileSize, "s3-max-file-size", "", cfg.S3.MaxFileSize, "Maximum size of a file asset in bytes")
+	fs.BoolVarP(&cfg.CFStream.Enabled, "cfstream-enabled", "", cfg.CFStream.Enabled, "Offer Cloudflare Stream as an alternative video backend")
+	fs.StringVarP(&cfg.CFStream.AccountID, "cfstream-account-id", "", cfg.CFStream.AccountID, "Cloudflare account ID of the Stream videos")
+	fs.StringVarP(&cfg.CFStream.WebhookSecret, "cfstream-webhook-secret", "", cfg.CFStream.WebhookSecret, "Cloudflare Stream webhook signing secret (env CFSTREAM_WEBHOOK_SECRET)")
+	fs.DurationVarP(&cfg.CFStream.WebhookTolerance, "cfstream-webhook-tolerance", "", cfg.CFStream.WebhookTolerance, "Maximum accepted age of a signed Cloudflare Stream webhook")
+	fs.BoolVarP(&cfg.CFStream.RequireSignedURLs, "cfstream-require-signed-urls", "", cfg.CFStream.RequireSignedURLs, "Make new Cloudflare Stream videos playable only with a signed token")
+	fs.IntVarP(&cfg.CFStream.MaxDurationSeconds, "cfstream-max-duration-seconds", "", cfg.CFStream.MaxDurationSeconds, "Default maximum duration of uploaded Cloudflare Stream videos, 0 means the Cloudflare limit")
+	fs.DurationVarP(&cfg.CFStream.UploadURLTTL, "cfstream-upload-url-ttl", "", cfg.CFStream.UploadURLTTL, "Validity of Cloudflare Stream upload URLs")
+	fs.DurationVarP(&cfg.CFStream.PlaybackTokenTTL, "cfstream-playback-token-ttl", "", cfg.CFStream.PlaybackTokenTTL, "Default validity of signed Cloudflare Stream playback tokens")
+	fs.StringVarP(&cfg.Moderation.Cloudinary, "moderation-cloudinary", "", cfg.Moderation.Cloudinary, "Cloudinary moderation add-on requested for image uploads (manual, aws_rek), empty disables moderation")
+	fs.StringVarP(&cfg.Duplicates.Policy, "duplicates-policy", "", cfg.Duplicates.Policy, "Handling of uploaded images identical to an active image (allow, block)")
+
+	// Secrets must not be printed as flag defaults in the usage message.
+	for _, name := range []string{"auth-jwt-secret", "auth-api-key", "mux-webhook-secret", "cfstream-webhook-secret", "cache-redis-password"} {
+		fs.Lookup(name).DefValue = ""
+	}
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv is the environment variable pointing to the YAML configuration file. The --config flag takes precedence.
+const FileEnv = "MEDIA_CONFIG_FILE"
+
+// Load builds the configuration from defaults, the optional YAML file, environment variables and
+// the given command line arguments, in increasing precedence, and validates the result.
+func Load(name string, args []string) (*Config, error) {
+	cfg := Default()
+
+	path, err := filePath(args)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			return nil, err
+		}
+	}
+	if err := loadEnv(cfg, os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	fs := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	fs.String("config", path, "Path to a YAML configuration file (env "+FileEnv+")")
+	bindFlags(fs, cfg)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// filePath returns the configuration file path from the --config flag or the [FileEnv] variable.
+func filePath(args []string) (string, error) {
+	fs := pflag.NewFlagSet("config", pflag.ContinueOnError)
+	fs.ParseErrorsAllowlist.UnknownFlags = true
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {}
+	path := fs.String("config", os.Getenv(FileEnv), "")
+	if err := fs.Parse(args); err != nil && !errors.Is(err, pflag.ErrHelp) {
+		return "", err
+	}
+	return *path, nil
+}
+
+func loadFile(path string, cfg *Config) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadEnv overrides fields tagged with `env` by the variables that are set. Struct fields with an
+// env tag use it as the prefix of their nested fields' tags.
+func loadEnv(cfg *Config, lookup func(string) (string, bool)) error {
+	return loadEnvStruct(reflect.ValueOf(cfg).Elem(), "", lookup)
+}
+
+func loadEnvStruct(v reflect.Value, prefix string, lookup func(string) (string, bool)) error {
+	t := v.Type()
+	var errs []error
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("env")
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct {
+			if err := loadEnvStruct(fv, prefix+tag, lookup); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if tag == "" {
+			continue
+		}
+		name := prefix + tag
+		raw, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		if err := setValue(fv, raw, field.Tag.Get("sep")); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value of %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func setValue(v reflect.Value, raw, sep string) error {
+	if sep == "" {
+		sep = ","
+	}
+	switch v.Interface().(type) {
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	case []string:
+		v.Set(reflect.ValueOf(splitList(raw, sep)))
+		return nil
+	case map[string]string:
+		m := make(map[string]string)
+		for _, pair := range splitList(raw, sep) {
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return fmt.Errorf("expected key=value, got %q", pair)
+			}
+			m[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+		v.Set(reflect.ValueOf(m))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+func splitList(raw, sep string) []string {
+	var out []string
+	for _, item := range strings.Split(raw, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,706 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Validate checks the configuration and returns all problems found, one per line.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.port("http.port", c.HTTP.Port)
+	v.port("grpc.port", c.GRPC.Port)
+	if c.HTTP.Port == c.GRPC.Port {
+		v.add("grpc.port", "must differ from http.port")
+	}
+	v.tls("grpc.tls", c.GRPC.TLS, true)
+	v.tls("grpc_client.tls", c.GRPCClient.TLS, false)
+	// Calls without a deadline would hang while the downstream service is unreachable.
+	v.positive("grpc_client.video.timeout", c.GRPCClient.Video.Timeout)
+	v.positive("grpc_client.image.timeout", c.GRPCClient.Image.Timeout)
+
+	v.required("mongodb.db_name", c.MongoDB.DbName)
+	v.oneOf("metadata.backend", c.Metadata.Backend, "mongo", "arango")
+	if c.Metadata.Backend == "arango" {
+		if len(c.ArangoDB.Endpoints) == 0 {
+			v.add("arangodb.endpoints", "is required when metadata.backend is arango")
+		}
+		v.required("arangodb.db_name", c.ArangoDB.DbName)
+	}
+	v.counts("counts", c.Counts)
+	v.required("log.directory", c.Log.Directory)
+	v.required("log.app_name", c.Log.AppName)
+	v.positiveInt("graceful_shutdown_timeout_seconds", c.GracefulShutdownTimeoutSeconds)
+	v.positive("mux.webhook_tolerance", c.Mux.WebhookTolerance)
+	v.environments("environments", c.Environments, c.Mux.EnvironmentID)
+	if c.Dev.Enabled {
+		// The emulators serve a single account.
+		if len(c.Environments) > 0 {
+			v.add("environments", "must be empty when dev is enabled")
+		}
+		if c.Dev.BaseURL != "" && !strings.HasPrefix(c.Dev.BaseURL, "http://") && !strings.HasPrefix(c.Dev.BaseURL, "https://") {
+			v.add("dev.base_url", "must be an http or https URL")
+		}
+	}
+
+	if c.Retention.Enabled {
+		v.positive("retention.ttl", c.Retention.TTL)
+		v.positive("retention.interval", c.Retention.Interval)
+		v.positiveInt("retention.batch_size", c.Retention.BatchSize)
+		v.positive("retention.retry_backoff", c.Retention.RetryBackoff)
+	}
+	v.positive("publishing.interval", c.Publishing.Interval)
+	v.positiveInt("publishing.batch_size", c.Publishing.BatchSize)
+	v.positive("outbox.poll_interval", c.Outbox.PollInterval)
+	v.positiveInt("outbox.batch_size", c.Outbox.BatchSize)
+	v.positiveInt("outbox.max_attempts", c.Outbox.MaxAttempts)
+	v.webhooks("webhooks", c.Webhooks)
+
+	v.oneOf("events.broker", c.Events.Broker, "none", "nats", "kafka")
+	v.oneOf("events.encoding", c.Events.Encoding, "json", "protobuf")
+	if c.Events.Broker != "none" && len(c.Events.URLs) == 0 {
+		v.add("events.urls", "is required when events.broker is "+c.Events.Broker)
+	}
+	if c.Notifications.Enabled {
+		v.notifications("notifications", c.Notifications)
+	}
+	if c.OutgoingWebhooks.Enabled {
+		v.positive("outgoing_webhooks.poll_interval", c.OutgoingWebhooks.PollInterval)
+		v.positiveInt("outgoing_webhooks.batch_size", c.OutgoingWebhooks.BatchSize)
+		v.positive("outgoing_webhooks.timeout", c.OutgoingWebhooks.Timeout)
+		v.positiveInt("outgoing_webhooks.max_attempts", c.OutgoingWebhooks.MaxAttempts)
+		v.positive("outgoing_webhooks.base_backoff", c.OutgoingWebhooks.BaseBackoff)
+		v.positive("outgoing_webhooks.max_backoff", c.OutgoingWebhooks.MaxBackoff)
+		v.positive("outgoing_webhooks.retention", c.OutgoingWebhooks.Retention)
+	}
+
+	if c.Auth.Enabled && c.Auth.JWTSecret == "" && c.Auth.JWKSURL == "" {
+		v.add("auth", "jwt_secret (AUTH_JWT_SECRET) or jwks_url is required when auth is enabled")
+	}
+	v.oneOf("auth.grpc_default_access", c.Auth.GRPCDefaultAccess, "public", "user", "read_only", "admin")
+
+	if c.Metrics.Enabled {
+		if !strings.HasPrefix(c.Metrics.Path, "/") {
+			v.add("metrics.path", "must start with /")
+		}
+		v.positive("metrics.asset_stats_interval", c.Metrics.AssetStatsInterval)
+	}
+	if c.Tracing.Enabled {
+		v.required("tracing.endpoint", c.Tracing.Endpoint)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		v.add("tracing.sample_ratio", "must be between 0 and 1")
+	}
+
+	v.positive("health.postgres_timeout", c.Health.PostgresTimeout)
+	v.positive("health.mongo_timeout", c.Health.MongoTimeout)
+	v.positive("health.arango_timeout", c.Health.ArangoTimeout)
+	v.positive("health.mux_timeout", c.Health.MuxTimeout)
+	v.positive("health.cloudinary_timeout", c.Health.CloudinaryTimeout)
+	v.positive("health.s3_timeout", c.Health.S3Timeout)
+	v.positive("health.cfstream_timeout", c.Health.CFStreamTimeout)
+	v.positive("health.redis_timeout", c.Health.RedisTimeout)
+	v.positive("health.grpc_interval", c.Health.GRPCInterval)
+	v.positive("health.provider_cache_ttl", c.Health.ProviderCacheTTL)
+
+	v.positive("timeouts.http", c.Timeouts.HTTP)
+	v.positive("timeouts.grpc", c.Timeouts.GRPC)
+	v.positive("timeouts.api", c.Timeouts.API)
+	if c.Timeouts.Reserve < 0 || c.Timeouts.Reserve >= c.Timeouts.API {
+		v.add("timeouts.reserve", "must not be negative and must be shorter than timeouts.api")
+	}
+	for _, route := range slices.Sorted(maps.Keys(c.Timeouts.Routes)) {
+		if c.Timeouts.Routes[route] < 0 {
+			v.add("timeouts.routes."+route, "must not be negative")
+		}
+	}
+
+	if c.Payload.MaxBodySize <= 0 {
+		v.add("payload.max_body_size", "must be positive")
+	}
+	if c.Payload.WebhookMaxBodySize <= 0 {
+		v.add("payload.webhook_max_body_size", "must be positive")
+	}
+	v.positiveInt("payload.max_json_depth", c.Payload.MaxJSONDepth)
+
+	if c.Cache.Enabled {
+		v.required("cache.address", c.Cache.Address)
+		v.positive("cache.asset_ttl", c.Cache.AssetTTL)
+		v.positive("cache.metadata_ttl", c.Cache.MetadataTTL)
+	}
+
+	v.ownerTypes("owner_types.mux", c.OwnerTypes.Mux)
+	v.ownerTypes("owner_types.cloudinary", c.OwnerTypes.Cloudinary)
+	v.ownership("ownership.mux", c.Ownership.Mux, c.OwnerTypes.Mux)
+	v.ownership("ownership.cloudinary", c.Ownership.Cloudinary, c.OwnerTypes.Cloudinary)
+	v.ownerTypes("owner_types.s3", c.OwnerTypes.S3)
+	v.ownership("ownership.s3", c.Ownership.S3, c.OwnerTypes.S3)
+	v.ownerTypes("owner_types.cfstream", c.OwnerTypes.CFStream)
+	v.ownership("ownership.cfstream", c.Ownership.CFStream, c.OwnerTypes.CFStream)
+
+	if c.S3.Enabled {
+		if !strings.HasPrefix(c.S3.Endpoint, "http://") && !strings.HasPrefix(c.S3.Endpoint, "https://") {
+			v.add("s3.endpoint", "must be an http or https URL")
+		}
+		v.required("s3.region", c.S3.Region)
+		v.required("s3.bucket", c.S3.Bucket)
+		v.positive("s3.upload_url_ttl", c.S3.UploadURLTTL)
+		v.positive("s3.download_url_ttl", c.S3.DownloadURLTTL)
+		// S3 rejects presigned requests valid for longer than 7 days.
+		if c.S3.UploadURLTTL > 7*24*time.Hour {
+			v.add("s3.upload_url_ttl", "must not exceed 168h")
+		}
+		if c.S3.DownloadURLTTL > 7*24*time.Hour {
+			v.add("s3.download_url_ttl", "must not exceed 168h")
+		}
+		if c.S3.MaxFileSize <= 0 {
+			v.add("s3.max_file_size", "must be positive")
+		}
+	}
+
+	if c.CFStream.Enabled {
+		v.required("cfstream.account_id", c.CFStream.AccountID)
+		v.positive("cfstream.webhook_tolerance", c.CFStream.WebhookTolerance)
+		v.positive("cfstream.upload_url_ttl", c.CFStream.UploadURLTTL)
+		v.positive("cfstream.playback_token_ttl", c.CFStream.PlaybackTokenTTL)
+		// Cloudflare Stream rejects longer videos and upload URLs valid for longer than 6 hours.
+		if c.CFStream.MaxDurationSeconds < 0 || c.CFStream.MaxDurationSeconds > 6*60*60 {
+			v.add("cfstream.max_duration_seconds", "must be between 0 and 21600")
+		}
+		if c.CFStream.UploadURLTTL > 6*time.Hour {
+			v.add("cfstream.upload_url_ttl", "must not exceed 6h")
+		}
+	}
+
+	if c.UploadProxy.Enabled {
+		if c.UploadProxy.MaxFileSize <= 0 {
+			v.add("upload_proxy.max_file_size", "must be positive")
+		}
+		if c.UploadProxy.ChunkSize <= 0 || c.UploadProxy.ChunkSize%(256<<10) != 0 {
+			v.add("upload_proxy.chunk_size", "must be a positive multiple of 256 KiB")
+		}
+		v.positive("upload_proxy.session_ttl", c.UploadProxy.SessionTTL)
+		if c.UploadProxy.Cloudinary.MaxFileSize <= 0 {
+			v.add("upload_proxy.cloudinary.max_file_size", "must be positive")
+		}
+	}
+	v.uploadPolicy("upload_policy", c.UploadPolicy, c.OwnerTypes.Cloudinary)
+	if c.Export.Enabled {
+		v.required("export.dir", c.Export.Dir)
+		v.positive("export.retention", c.Export.Retention)
+		v.positiveInt("export.max_pending", c.Export.MaxPending)
+	}
+	if c.Import.Enabled {
+		v.positive("import.retention", c.Import.Retention)
+		v.positiveInt("import.max_pending", c.Import.MaxPending)
+	}
+
+	v.oneOf("moderation.cloudinary", c.Moderation.Cloudinary, "", "manual", "aws_rek")
+	v.oneOf("duplicates.policy", c.Duplicates.Policy, "allow", "block")
+	if c.Enrichment.Enabled && (c.Enrichment.MinConfidence < 0 || c.Enrichment.MinConfidence > 1) {
+		v.add("enrichment.min_confidence", "must be between 0 and 1")
+	}
+	if c.Playback.MaxSessionsPerUser < 0 {
+		v.add("playback.max_sessions_per_user", "must not be negative")
+	}
+	v.playbackRestrictions("playback.restrictions", c.Playback.Restrictions, c.OwnerTypes.Mux, c.Delivery.Enabled)
+	v.muxEncoding("mux.encoding", c.Mux.Encoding, c.OwnerTypes.Mux)
+	if c.Delivery.Enabled {
+		v.positive("delivery.token_ttl", c.Delivery.TokenTTL)
+		if len(c.Delivery.UserOwnerTypes) == 0 {
+			v.add("delivery.user_owner_types", "at least one owner type is required when delivery is enabled")
+		}
+		if !c.Auth.Enabled {
+			v.add("delivery.enabled", "requires auth.enabled, the endpoints identify the user by the bearer token")
+		}
+		v.required("delivery.token_cookie.name", c.Delivery.TokenCookie.Name)
+		v.required("delivery.token_header", c.Delivery.TokenHeader)
+		if p := c.Delivery.TokenCookie.Path; p != "" && !strings.HasPrefix(p, "/") {
+			v.add("delivery.token_cookie.path", "must start with /")
+		}
+	}
+	if c.SigningKeys.Enabled {
+		v.positive("signing_keys.rotation_interval", c.SigningKeys.RotationInterval)
+		v.positive("signing_keys.retire_after", c.SigningKeys.RetireAfter)
+		v.positive("signing_keys.check_interval", c.SigningKeys.CheckInterval)
+		if c.Delivery.Enabled && c.SigningKeys.RetireAfter <= c.Delivery.TokenTTL {
+			v.add("signing_keys.retire_after", "must exceed delivery.token_ttl, replaced keys must outlive the tokens they signed")
+		}
+	}
+	if c.APIResilience.Enabled {
+		v.positiveInt("api_resilience.max_attempts", c.APIResilience.MaxAttempts)
+		v.positive("api_resilience.base_delay", c.APIResilience.BaseDelay)
+		v.positive("api_resilience.max_delay", c.APIResilience.MaxDelay)
+		if c.APIResilience.BreakerThreshold < 0 {
+			v.add("api_resilience.breaker_threshold", "must not be negative")
+		}
+		if c.APIResilience.BreakerThreshold > 0 {
+			v.positive("api_resilience.breaker_open_timeout", c.APIResilience.BreakerOpenTimeout)
+		}
+	}
+	if c.Quota.Mux.MaxAssets < 0 {
+		v.add("quota.mux.max_assets", "must not be negative")
+	}
+	if c.Quota.Mux.MaxDuration < 0 {
+		v.add("quota.mux.max_duration", "must not be negative")
+	}
+	if c.Quota.Cloudinary.MaxAssets < 0 {
+		v.add("quota.cloudinary.max_assets", "must not be negative")
+	}
+	if c.Quota.Cloudinary.MaxBytes < 0 {
+		v.add("quota.cloudinary.max_bytes", "must not be negative")
+	}
+	v.costs("costs", c.Costs)
+
+	c.Secrets.validate(v, c)
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid configuration:\n%w", errors.Join(v.errs...))
+}
+
+func (s *SecretsConfig) validate(v *validator, c *Config) {
+	v.secret("secrets.onepassword_token", "OP_SERVICE_ACCOUNT_TOKEN", s.OnePasswordToken)
+	// Certificates are read from 1Password only when no file based TLS is configured.
+	if c.GRPC.TLS.CertFile == "" {
+		v.secret("secrets.grpc_server_cert_vault_ref", "GRPC_SERVER_CERT_VAULT_REF", s.GRPCServerCertVaultRef)
+		v.secret("secrets.grpc_server_cert_item_ref", "GRPC_SERVER_CERT_ITEM_REF", s.GRPCServerCertItemRef)
+	}
+	if c.GRPCClient.TLS.CAFile == "" {
+		v.secret("secrets.grpc_client_cert_vault_ref", "GRPC_CLIENT_CERT_VAULT_REF", s.GRPCClientCertVaultRef)
+		v.secret("secrets.grpc_client_cert_item_ref", "GRPC_CLIENT_CERT_ITEM_REF", s.GRPCClientCertItemRef)
+	}
+	// The shared address is only needed by the downstream services without their own address.
+	if c.GRPCClient.Address == "" && (c.GRPCClient.Video.Address == "" || c.GRPCClient.Image.Address == "") {
+		v.secret("secrets.grpc_client_address_ref", "GRPC_CLIENT_ADDRESS_REF", s.GRPCClientAddressRef)
+	}
+	v.secret("secrets.postgres_host_ref", "POSTGRES_HOST_REF", s.PostgresHostRef)
+	v.secret("secrets.postgres_port_ref", "POSTGRES_PORT_REF", s.PostgresPortRef)
+	v.secret("secrets.postgres_user_ref", "POSTGRES_USER_REF", s.PostgresUserRef)
+	v.secret("secrets.postgres_password_ref", "POSTGRES_PASSWORD_REF", s.PostgresPasswordRef)
+	v.secret("secrets.postgres_dbname_ref", "POSTGRES_DBNAME_REF", s.PostgresDBNameRef)
+	if c.ReadReplica.Enabled {
+		v.secret("secrets.postgres_replica_host_ref", "POSTGRES_REPLICA_HOST_REF", s.PostgresReplicaHostRef)
+		v.secret("secrets.postgres_replica_port_ref", "POSTGRES_REPLICA_PORT_REF", s.PostgresReplicaPortRef)
+	}
+	v.secret("secrets.mongo_connection_string_ref", "MONGO_CONNECTION_STRING_REF", s.MongoConnectionStringRef)
+	if c.Metadata.Backend == "arango" {
+		v.secret("secrets.arango_user_ref", "ARANGO_USER_REF", s.ArangoUserRef)
+		v.secret("secrets.arango_password_ref", "ARANGO_PASSWORD_REF", s.ArangoPasswordRef)
+	}
+	// The provider credentials are not used by the emulators of the dev mode.
+	if !c.Dev.Enabled {
+		v.secret("secrets.mux_api_token_ref", "MUX_API_TOKEN_REF", s.MuxAPITokenRef)
+		v.secret("secrets.mux_secret_key_ref", "MUX_SECRET_KEY_REF", s.MuxSecretKeyRef)
+		v.secret("secrets.mux_signing_key_id_ref", "MUX_SIGNING_KEY_ID_REF", s.MuxSigningKeyIDRef)
+		v.secret("secrets.mux_signing_key_private_ref", "MUX_SIGNING_KEY_PRIVATE_REF", s.MuxSigningKeyPrivateRef)
+		v.secret("secrets.mux_playback_restriction_id_ref", "MUX_PLAYBACK_RESTRICTION_ID_REF", s.MuxPlaybackRestrictionIDRef)
+		v.secret("secrets.cloudinary_cloud_name_ref", "CLD_CLOUD_NAME_REF", s.CloudinaryCloudNameRef)
+		v.secret("secrets.cloudinary_api_key_ref", "CLD_API_KEY_REF", s.CloudinaryAPIKeyRef)
+		v.secret("secrets.cloudinary_api_secret_ref", "CLD_API_SECRET_REF", s.CloudinaryAPISecretRef)
+	}
+	if c.S3.Enabled {
+		v.secret("secrets.s3_access_key_id_ref", "S3_ACCESS_KEY_ID_REF", s.S3AccessKeyIDRef)
+		v.secret("secrets.s3_secret_access_key_ref", "S3_SECRET_ACCESS_KEY_REF", s.S3SecretAccessKeyRef)
+	}
+	if c.CFStream.Enabled {
+		v.secret("secrets.cfstream_api_token_ref", "CFSTREAM_API_TOKEN_REF", s.CFStreamAPITokenRef)
+		if c.CFStream.RequireSignedURLs {
+			v.secret("secrets.cfstream_signing_key_id_ref", "CFSTREAM_SIGNING_KEY_ID_REF", s.CFStreamSigningKeyIDRef)
+			v.secret("secrets.cfstream_signing_key_private_ref", "CFSTREAM_SIGNING_KEY_PRIVATE_REF", s.CFStreamSigningKeyPrivateRef)
+		}
+	}
+	if c.SigningKeys.Enabled {
+		v.secret("secrets.signing_keys_encryption_key_ref", "SIGNING_KEYS_ENCRYPTION_KEY_REF", s.SigningKeysEncryptionKeyRef)
+	}
+}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) add(field, msg string) {
+	v.errs = append(v.errs, fmt.Errorf("  %s: %s", field, msg))
+}
+
+func (v *validator) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "is required")
+	}
+}
+
+func (v *validator) secret(field, env, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "is required (env "+env+")")
+	}
+}
+
+func (v *validator) port(field string, port int64) {
+	if port < 1 || port > 65535 {
+		v.add(field, fmt.Sprintf("must be between 1 and 65535, got %d", port))
+	}
+}
+
+func (v *validator) positive(field string, d time.Duration) {
+	if d <= 0 {
+		v.add(field, "must be positive")
+	}
+}
+
+func (v *validator) positiveInt(field string, n int) {
+	if n <= 0 {
+		v.add(field, "must be positive")
+	}
+}
+
+func (v *validator) oneOf(field, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.add(field, fmt.Sprintf("must be one of %s, got %q", strings.Join(allowed, ", "), value))
+}
+
+// countEndpoints lists the list endpoints whose count strategy can be overridden.
+var countEndpoints = []string{
+	"mux.list", "mux.list_archived", "mux.list_broken",
+	"cloudinary.list", "cloudinary.list_archived", "cloudinary.list_broken",
+}
+
+func (v *validator) counts(field string, c CountsConfig) {
+	strategies := []string{"exact", "cached", "estimate"}
+	v.oneOf(field+".strategy", c.Strategy, strategies...)
+	cached := c.Strategy == "cached"
+	for _, endpoint := range slices.Sorted(maps.Keys(c.Endpoints)) {
+		if !slices.Contains(countEndpoints, endpoint) {
+			v.add(field+".endpoints", fmt.Sprintf("unknown endpoint %q", endpoint))
+			continue
+		}
+		v.oneOf(field+".endpoints."+endpoint, c.Endpoints[endpoint], strategies...)
+		cached = cached || c.Endpoints[endpoint] == "cached"
+	}
+	if cached {
+		v.positive(field+".ttl", c.TTL)
+	}
+}
+
+// webhookProviders lists the providers whose webhook retry policy can be overridden.
+var webhookProviders = []string{"mux", "cloudinary", "cfstream"}
+
+func (v *validator) webhooks(field string, c WebhooksConfig) {
+	v.positive(field+".poll_interval", c.PollInterval)
+	v.positiveInt(field+".batch_size", c.BatchSize)
+	v.positiveInt(field+".concurrency", c.Concurrency)
+	v.positive(field+".timeout", c.Timeout)
+	v.positiveInt(field+".max_attempts", c.MaxAttempts)
+	v.positive(field+".base_backoff", c.BaseBackoff)
+	v.positive(field+".max_backoff", c.MaxBackoff)
+	for _, key := range slices.Sorted(maps.Keys(c.Retries)) {
+		provider, _, _ := strings.Cut(key, ":")
+		if !slices.Contains(webhookProviders, provider) {
+			v.add(field+".retries", fmt.Sprintf("unknown provider %q", provider))
+			continue
+		}
+		retry := c.Retries[key]
+		if retry.MaxAttempts < 0 || retry.BaseBackoff < 0 || retry.MaxBackoff < 0 {
+			v.add(field+".retries."+key, "must not be negative")
+		}
+	}
+}
+
+// notificationEventTypes lists the asset event types notification rules can filter.
+var notificationEventTypes = []string{
+	"asset.created", "asset.ready", "asset.errored", "asset.deleted", "asset.owners_changed", "asset.updated",
+	"asset.published", "asset.unpublished",
+}
+
+func (v *validator) notifications(field string, c NotificationsConfig) {
+	v.positive(field+".timeout", c.Timeout)
+	for _, name := range slices.Sorted(maps.Keys(c.Sinks)) {
+		sink, sinkField := c.Sinks[name], field+".sinks."+name
+		switch sink.Type {
+		case "slack", "webhook":
+			if !strings.HasPrefix(sink.URL, "http://") && !strings.HasPrefix(sink.URL, "https://") {
+				v.add(sinkField+".url", "must be an http or https URL")
+			}
+		case "smtp":
+			v.required(sinkField+".host", sink.Host)
+			v.port(sinkField+".port", int64(sink.Port))
+			v.required(sinkField+".from", sink.From)
+			if len(sink.To) == 0 {
+				v.add(sinkField+".to", "at least one recipient is required")
+			}
+		default:
+			v.add(sinkField+".type", fmt.Sprintf("must be one of smtp, slack, webhook, got %q", sink.Type))
+		}
+	}
+	if len(c.Rules) == 0 {
+		v.add(field+".rules", "at least one rule is required when notifications are enabled")
+	}
+	for i, rule := range c.Rules {
+		ruleField := fmt.Sprintf("%s.rules[%d]", field, i)
+		for _, t := range rule.EventTypes {
+			if !slices.Contains(notificationEventTypes, t) {
+				v.add(ruleField+".event_types", fmt.Sprintf("unknown event type %q", t))
+			}
+		}
+		if len(rule.Sinks) == 0 {
+			v.add(ruleField+".sinks", "at least one sink is required")
+		}
+		for _, name := range rule.Sinks {
+			if _, ok := c.Sinks[name]; !ok {
+				v.add(ruleField+".sinks", fmt.Sprintf("unknown sink %q", name))
+			}
+		}
+	}
+}
+
+// environmentNamePattern matches the environment names, which are stored in the asset rows.
+var environmentNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,63}$`)
+
+func (v *validator) environments(field string, envs map[string]EnvironmentConfig, defaultMuxID string) {
+	muxIDs := map[string]string{}
+	if defaultMuxID != "" {
+		muxIDs[defaultMuxID] = "mux.environment_id"
+	}
+	for _, name := range slices.Sorted(maps.Keys(envs)) {
+		env, envField := envs[name], field+"."+name
+		if name == "default" || !environmentNamePattern.MatchString(name) {
+			v.add(envField, "name must be lowercase letters, digits, - and _, and must not be default")
+		}
+		v.required(envField+".mux_api_token_ref", env.MuxAPITokenRef)
+		v.required(envField+".mux_secret_key_ref", env.MuxSecretKeyRef)
+		v.required(envField+".mux_signing_key_id_ref", env.MuxSigningKeyIDRef)
+		v.required(envField+".mux_signing_key_private_ref", env.MuxSigningKeyPrivateRef)
+		v.required(envField+".mux_playback_restriction_id_ref", env.MuxPlaybackRestrictionIDRef)
+		v.required(envField+".cloudinary_cloud_name_ref", env.CloudinaryCloudNameRef)
+		v.required(envField+".cloudinary_api_key_ref", env.CloudinaryAPIKeyRef)
+		v.required(envField+".cloudinary_api_secret_ref", env.CloudinaryAPISecretRef)
+		// Webhooks could not be routed to the environment without its MUX environment ID
+		v.required(envField+".mux_environment_id", env.MuxEnvironmentID)
+		if other, ok := muxIDs[env.MuxEnvironmentID]; ok && env.MuxEnvironmentID != "" {
+			v.add(envField+".mux_environment_id", "is already used by "+other)
+		}
+		muxIDs[env.MuxEnvironmentID] = envField
+	}
+}
+
+var ownerTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+func (v *validator) ownerTypes(field string, types []string) {
+	if len(types) == 0 {
+		v.add(field, "at least one owner type is required")
+	}
+	for _, t := range types {
+		if !ownerTypePattern.MatchString(t) {
+			v.add(field, fmt.Sprintf("%q must be lower snake case and at most 50 characters long", t))
+		}
+	}
+}
+
+func (v *validator) ownership(field string, o OwnershipPolicyConfig, registered []string) {
+	if o.MaxOwnersPerAsset < 0 {
+		v.add(field+".max_owners_per_asset", "must not be negative")
+	}
+	for _, ownerType := range slices.Sorted(maps.Keys(o.OwnerTypes)) {
+		policy := o.OwnerTypes[ownerType]
+		if !slices.Contains(registered, ownerType) {
+			v.add(field+".owner_types", fmt.Sprintf("%q is not a registered owner type", ownerType))
+		}
+		if policy.MaxAssets < 0 {
+			v.add(field+".owner_types."+ownerType+".max_assets", "must not be negative")
+		}
+	}
+}
+
+// maxReferrerDomains is the maximum number of domains of a MUX playback restriction.
+const maxReferrerDomains = 10
+
+var countryCodePattern = regexp.MustCompile(`^[A-Za-z]{2}$`)
+
+// playbackRestrictions checks the policies. The country header is only required if delivery is enabled,
+// the countries of the tokens requested by other services are sent along with the requests.
+func (v *validator) playbackRestrictions(field string, r PlaybackRestrictionsConfig, registered []string, delivery bool) {
+	policies := map[string]PlaybackRestrictionPolicyConfig{
+		field: {AllowedCountries: r.AllowedCountries, AllowedReferrers: r.AllowedReferrers},
+	}
+	for _, ownerType := range slices.Sorted(maps.Keys(r.OwnerTypes)) {
+		if !slices.Contains(registered, ownerType) {
+			v.add(field+".owner_types", fmt.Sprintf("%q is not a registered owner type", ownerType))
+		}
+		policies[field+".owner_types."+ownerType] = r.OwnerTypes[ownerType]
+	}
+	restrictsCountries := false
+	for _, policyField := range slices.Sorted(maps.Keys(policies)) {
+		policy := policies[policyField]
+		for _, country := range policy.AllowedCountries {
+			if !countryCodePattern.MatchString(country) {
+				v.add(policyField+".allowed_countries", fmt.Sprintf("%q is not an ISO 3166-1 alpha-2 country code", country))
+			}
+		}
+		if len(policy.AllowedReferrers) > maxReferrerDomains {
+			v.add(policyField+".allowed_referrers", fmt.Sprintf("at most %d domains are allowed", maxReferrerDomains))
+		}
+		if slices.Contains(policy.AllowedReferrers, "") {
+			v.add(policyField+".allowed_referrers", "domains must not be empty")
+		}
+		restrictsCountries = restrictsCountries || len(policy.AllowedCountries) > 0
+	}
+	if delivery && restrictsCountries && r.CountryHeader == "" {
+		v.add(field+".country_header", "is required when delivery is enabled and a policy restricts the countries")
+	}
+}
+
+// The options of the MUX new asset settings, see the encoding settings of the MUX asset model.
+var (
+	muxMaxResolutionTiers = []string{"1080p", "1440p", "2160p"}
+	muxVideoQualities     = []string{"basic", "plus", "premium"}
+	muxMP4Supports        = []string{"none", "capped-1080p", "audio-only", "audio-only,capped-1080p"}
+	muxPlaybackPolicies   = []string{"public", "signed"}
+)
+
+// muxEncoding checks the settings and the allowlists. The configured settings must be allowed by the
+// allowlists, otherwise every upload they apply to would be rejected.
+func (v *validator) muxEncoding(field string, e MuxEncodingConfig, registered []string) {
+	allowlists := map[string]struct {
+		values, options []string
+	}{
+		"allowed_max_resolution_tiers": {e.AllowedMaxResolutionTiers, muxMaxResolutionTiers},
+		"allowed_video_qualities":      {e.AllowedVideoQualities, muxVideoQualities},
+		"allowed_mp4_supports":         {e.AllowedMP4Supports, muxMP4Supports},
+		"allowed_playback_policies":    {e.AllowedPlaybackPolicies, muxPlaybackPolicies},
+	}
+	for _, name := range slices.Sorted(maps.Keys(allowlists)) {
+		for _, value := range allowlists[name].values {
+			v.oneOf(field+"."+name, value, allowlists[name].options...)
+		}
+	}
+
+	// The built-in defaults apply unless they are configured, so they must be allowed as well.
+	policies := e.PlaybackPolicies
+	if len(policies) == 0 {
+		policies = []string{"signed", "public"}
+	}
+	settings := map[string]MuxEncodingSettingsConfig{
+		field: {
+			MaxResolutionTier: e.MaxResolutionTier,
+			VideoQuality:      cmp.Or(e.VideoQuality, "basic"),
+			MP4Support:        e.MP4Support,
+			PlaybackPolicies:  policies,
+		},
+	}
+	for _, ownerType := range slices.Sorted(maps.Keys(e.OwnerTypes)) {
+		if !slices.Contains(registered, ownerType) {
+			v.add(field+".owner_types", fmt.Sprintf("%q is not a registered owner type", ownerType))
+		}
+		settings[field+".owner_types."+ownerType] = e.OwnerTypes[ownerType]
+	}
+	check := func(field, value string, options, allowed []string) {
+		if value == "" {
+			return
+		}
+		v.oneOf(field, value, options...)
+		if len(allowed) > 0 && slices.Contains(options, value) && !slices.Contains(allowed, value) {
+			v.add(field, fmt.Sprintf("%q is not in the allowlist", value))
+		}
+	}
+	for _, settingsField := range slices.Sorted(maps.Keys(settings)) {
+		s := settings[settingsField]
+		check(settingsField+".max_resolution_tier", s.MaxResolutionTier, muxMaxResolutionTiers, e.AllowedMaxResolutionTiers)
+		check(settingsField+".video_quality", s.VideoQuality, muxVideoQualities, e.AllowedVideoQualities)
+		check(settingsField+".mp4_support", s.MP4Support, muxMP4Supports, e.AllowedMP4Supports)
+		for _, policy := range s.PlaybackPolicies {
+			check(settingsField+".playback_policies", policy, muxPlaybackPolicies, e.AllowedPlaybackPolicies)
+		}
+	}
+}
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+func (v *validator) costs(field string, c CostsConfig) {
+	if !currencyPattern.MatchString(c.Currency) {
+		v.add(field+".currency", "must be an ISO 4217 currency code, e.g. USD")
+	}
+	rates := map[string]float64{
+		"mux.encoding_per_minute":     c.Mux.EncodingPerMinute,
+		"mux.storage_per_minute":      c.Mux.StoragePerMinute,
+		"mux.streaming_per_minute":    c.Mux.StreamingPerMinute,
+		"cloudinary.storage_per_gb":   c.Cloudinary.StoragePerGB,
+		"cloudinary.bandwidth_per_gb": c.Cloudinary.BandwidthPerGB,
+	}
+	for _, name := range slices.Sorted(maps.Keys(rates)) {
+		if rates[name] < 0 {
+			v.add(field+"."+name, "must not be negative")
+		}
+	}
+	for _, tier := range slices.Sorted(maps.Keys(c.Mux.ResolutionMultipliers)) {
+		v.oneOf(field+".mux.resolution_multipliers", tier, muxMaxResolutionTiers...)
+		if c.Mux.ResolutionMultipliers[tier] <= 0 {
+			v.add(field+".mux.resolution_multipliers."+tier, "must be positive")
+		}
+	}
+}
+
+func (v *validator) uploadPolicy(field string, p UploadPolicyConfig, registered []string) {
+	if p.MaxFileSize < 0 {
+		v.add(field+".max_file_size", "must not be negative")
+	}
+	if p.MaxWidth < 0 {
+		v.add(field+".max_width", "must not be negative")
+	}
+	if p.MaxHeight < 0 {
+		v.add(field+".max_height", "must not be negative")
+	}
+	if strings.Contains(p.CreatorFolder, "..") {
+		v.add(field+".creator_folder", "must not contain relative path segments")
+	}
+	for _, ownerType := range slices.Sorted(maps.Keys(p.OwnerTypeFormats)) {
+		if !slices.Contains(registered, ownerType) {
+			v.add(field+".owner_type_formats", fmt.Sprintf("%q is not a registered owner type", ownerType))
+		}
+		if len(p.OwnerTypeFormats[ownerType]) == 0 {
+			v.add(field+".owner_type_formats."+ownerType, "at least one format is required")
+		}
+	}
+}
+
+func (v *validator) tls(field string, t TLSConfig, server bool) {
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		v.add(field, "cert_file and key_file must be set together")
+	}
+	if server {
+		v.oneOf(field+".client_auth", t.ClientAuth, "none", "request", "require")
+		if t.ClientAuth == "require" && t.CAFile == "" {
+			v.add(field+".ca_file", "is required when client_auth is require")
+		}
+	}
+	if t.CertFile != "" || t.CAFile != "" {
+		v.positive(field+".reload_interval", t.ReloadInterval)
+	}
+}
@@ -0,0 +1,38 @@
+// Package arango connects to ArangoDB, the alternative document store of the asset metadata.
+package arango
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/arangodb/go-driver/v2/connection"
+)
+
+// NewArangoDB connects to the endpoints and opens the database, which is created if it does not exist.
+func NewArangoDB(ctx context.Context, endpoints []string, user, password, dbName string) (arangodb.Database, error) {
+	conn := connection.NewHttpConnection(connection.DefaultHTTPConfigurationWrapper(connection.NewRoundRobinEndpoints(endpoints), false))
+	if err := conn.SetAuthentication(connection.NewBasicAuth(user, password)); err != nil {
+		return nil, fmt.Errorf("failed to set authentication: %w", err)
+	}
+	client := arangodb.NewClient(conn)
+
+	exists, err := client.DatabaseExists(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return client.CreateDatabase(ctx, dbName, nil)
+	}
+	return client.GetDatabase(ctx, dbName, nil)
+}
+
+// EnsureCollection creates the document collection if it does not exist.
+func EnsureCollection(ctx context.Context, db arangodb.Database, name string) error {
+	exists, err := db.CollectionExists(ctx, name)
+	if err != nil || exists {
+		return err
+	}
+	_, err = db.CreateCollectionV2(ctx, name, nil)
+	return err
+}
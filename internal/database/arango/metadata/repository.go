@@ -0,0 +1,372 @@
+// Package metadata implements the metadata repository on ArangoDB. A single generic implementation
+// serves all providers, the documents are stored with the JSON encoding of the metadata models,
+// which key them by "_key".
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/arangodb/go-driver/v2/arangodb/shared"
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	"github.com/mikhail5545/media-service-go/internal/models/enrichment"
+)
+
+// Repository stores the metadata M with owners O in a document collection.
+type Repository[M, O any] struct {
+	db             arangodb.Database
+	collectionName string
+}
+
+// New returns a repository of the collection, which must exist, see [arango.EnsureCollection].
+func New[M, O any](db arangodb.Database, collectionName string) *Repository[M, O] {
+	return &Repository[M, O]{db: db, collectionName: collectionName}
+}
+
+var _ dbmetadata.Repository[struct{}, struct{}] = (*Repository[struct{}, struct{}])(nil)
+
+// keyed is a document returned together with its key, which the generic repository cannot read from M.
+type keyed[M any] struct {
+	Key string `json:"key"`
+	Doc *M     `json:"doc"`
+}
+
+// incRevision is the update expression incrementing the revision, added to every update of the metadata.
+const incRevision = `revision: (d.revision || 0) + 1`
+
+func (r *Repository[M, O]) Create(ctx context.Context, data *M) error {
+	_, err := query[any](ctx, r.db,
+		`INSERT MERGE(@doc, { owner_count: LENGTH(@doc.owners || []) }) INTO @@collection`,
+		r.bindVars(map[string]any{"doc": data}),
+	)
+	return err
+}
+
+// Get retrieves the metadata of the asset. If fields are provided, only those fields and the key are loaded.
+func (r *Repository[M, O]) Get(ctx context.Context, key string, fields ...string) (*M, error) {
+	vars := map[string]any{"key": key}
+	results, err := query[*M](ctx, r.db,
+		`FOR d IN @@collection FILTER d._key == @key LIMIT 1 RETURN `+projection(fields, vars),
+		r.bindVars(vars),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, dbmetadata.ErrNotFound
+	}
+	return results[0], nil
+}
+
+func (r *Repository[M, O]) GetByOwner(ctx context.Context, key string, owner *O) (*M, error) {
+	results, err := query[*M](ctx, r.db,
+		`FOR d IN @@collection FILTER d._key == @key AND @owner IN d.owners LIMIT 1 RETURN d`,
+		r.bindVars(map[string]any{"key": key, "owner": owner}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, dbmetadata.ErrNotFound
+	}
+	return results[0], nil
+}
+
+func (r *Repository[M, O]) Update(ctx context.Context, key string, data *M) error {
+	return r.update(ctx, key, "",
+		`MERGE(UNSET(@data, "_key", "_id", "_rev", "revision"), { owner_count: LENGTH(@data.owners || []), `+incRevision+` })`,
+		map[string]any{"data": data},
+	)
+}
+
+func (r *Repository[M, O]) Delete(ctx context.Context, key string) error {
+	_, err := query[any](ctx, r.db,
+		`FOR d IN @@collection FILTER d._key == @key REMOVE d IN @@collection`,
+		r.bindVars(map[string]any{"key": key}),
+	)
+	return err
+}
+
+// ListUnownedIDs returns a page of keys of assets without owners, ordered by key. The page starts
+// after the afterKey cursor, an empty cursor starts from the beginning. The returned cursor is empty
+// when there are no more pages.
+func (r *Repository[M, O]) ListUnownedIDs(ctx context.Context, limit int, afterKey string) ([]string, string, error) {
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("limit must be positive, got %d", limit)
+	}
+	keys, err := query[string](ctx, r.db,
+		`FOR d IN @@collection FILTER d.owner_count == 0 AND d._key > @afterKey SORT d._key LIMIT @limit RETURN d._key`,
+		r.bindVars(map[string]any{"afterKey": afterKey, "limit": limit + 1}),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	var nextKey string
+	if len(keys) > limit {
+		keys = keys[:limit]
+		nextKey = keys[limit-1]
+	}
+	return keys, nextKey, nil
+}
+
+// ListByOwner returns a page of metadata of the assets associated with the owner, ordered by key.
+// Pagination works the same way as in [Repository.ListUnownedIDs].
+func (r *Repository[M, O]) ListByOwner(ctx context.Context, owner *O, limit int, afterKey string) ([]*M, string, error) {
+	return r.page(ctx, ownerFilter, map[string]any{"owner": owner}, limit, afterKey)
+}
+
+// Search returns a page of metadata of the assets matching the query and the label, ordered by key.
+// The query is matched case-insensitively as a substring of the title, the labels and the extracted
+// texts, the label must match exactly. Pagination works the same way as in [Repository.ListUnownedIDs].
+func (r *Repository[M, O]) Search(ctx context.Context, query, label string, limit int, afterKey string) ([]*M, string, error) {
+	filter := `(@label == "" OR @label IN d.enrichment.labels) AND (@pattern == "" ` +
+		`OR LIKE(d.title || "", @pattern, true) ` +
+		`OR LIKE(d.enrichment.ocr_text || "", @pattern, true) ` +
+		`OR LIKE(d.enrichment.transcript || "", @pattern, true) ` +
+		`OR LENGTH(FOR l IN d.enrichment.labels || [] FILTER LIKE(l, @pattern, true) LIMIT 1 RETURN l) > 0)`
+	pattern := ""
+	if query != "" {
+		pattern = likePattern(query)
+	}
+	return r.page(ctx, filter, map[string]any{"label": label, "pattern": pattern}, limit, afterKey)
+}
+
+// SetEnrichment replaces the enrichment data of the asset, leaving the rest of the metadata untouched.
+func (r *Repository[M, O]) SetEnrichment(ctx context.Context, key string, data *enrichment.Enrichment) error {
+	return r.update(ctx, key, "", `{ enrichment: @enrichment, `+incRevision+` }`, map[string]any{"enrichment": data})
+}
+
+// AddOwner atomically associates the owner with the asset. Adding an owner that is already associated
+// is a no-op, so the operation can be safely repeated.
+func (r *Repository[M, O]) AddOwner(ctx context.Context, key string, owner *O) error {
+	return r.updateOwners(ctx, key, `APPEND(d.owners || [], [@owner], true)`, map[string]any{"owner": owner})
+}
+
+// RemoveOwner atomically disassociates the owner from the asset. Removing an owner that is not
+// associated is a no-op, so the operation can be safely repeated.
+func (r *Repository[M, O]) RemoveOwner(ctx context.Context, key string, owner *O) error {
+	return r.updateOwners(ctx, key, `REMOVE_VALUE(d.owners || [], @owner)`, map[string]any{"owner": owner})
+}
+
+// IncrementRevision atomically increments the revision of the metadata if it is the expected one and
+// returns the new revision. If the revision is another one, it returns the current revision and false.
+// It returns [dbmetadata.ErrNotFound] if the metadata does not exist.
+func (r *Repository[M, O]) IncrementRevision(ctx context.Context, key string, expected int64) (int64, bool, error) {
+	updated, err := query[int64](ctx, r.db,
+		`FOR d IN @@collection FILTER d._key == @key AND (d.revision || 0) == @expected `+
+			`UPDATE d WITH { `+incRevision+` } IN @@collection RETURN NEW.revision`,
+		r.bindVars(map[string]any{"key": key, "expected": expected}),
+	)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(updated) > 0 {
+		return updated[0], true, nil
+	}
+	current, err := query[int64](ctx, r.db,
+		`FOR d IN @@collection FILTER d._key == @key LIMIT 1 RETURN d.revision || 0`,
+		r.bindVars(map[string]any{"key": key}),
+	)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(current) == 0 {
+		return 0, false, dbmetadata.ErrNotFound
+	}
+	return current[0], false, nil
+}
+
+func (r *Repository[M, O]) CountUnowned(ctx context.Context) (int64, error) {
+	return r.count(ctx, `d.owner_count == 0`, nil)
+}
+
+// ListKeysByOwner returns the keys of all assets associated with the owner.
+func (r *Repository[M, O]) ListKeysByOwner(ctx context.Context, owner *O) ([]string, error) {
+	return query[string](ctx, r.db,
+		`FOR d IN @@collection FILTER `+ownerFilter+` RETURN d._key`,
+		r.bindVars(map[string]any{"owner": owner}),
+	)
+}
+
+func (r *Repository[M, O]) List(ctx context.Context) ([]*M, error) {
+	return query[*M](ctx, r.db, `FOR d IN @@collection SORT d._key RETURN d`, r.bindVars(nil))
+}
+
+// ListByKeys retrieves the metadata of the assets mapped by key. If fields are provided, only those
+// fields and the key are loaded.
+func (r *Repository[M, O]) ListByKeys(ctx context.Context, keys []string, fields ...string) (map[string]*M, error) {
+	vars := map[string]any{"keys": keys}
+	results, err := query[keyed[M]](ctx, r.db,
+		`FOR d IN @@collection FILTER d._key IN @keys RETURN { key: d._key, doc: `+projection(fields, vars)+` }`,
+		r.bindVars(vars),
+	)
+	if err != nil {
+		return nil, err
+	}
+	metadataMap := make(map[string]*M, len(results))
+	for _, res := range results {
+		metadataMap[res.Key] = res.Doc
+	}
+	return metadataMap, nil
+}
+
+func (r *Repository[M, O]) DeleteByKeys(ctx context.Context, keys []string) (int64, error) {
+	removed, err := query[any](ctx, r.db,
+		`FOR d IN @@collection FILTER d._key IN @keys REMOVE d IN @@collection RETURN 1`,
+		r.bindVars(map[string]any{"keys": keys}),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(removed)), nil
+}
+
+// EnsureIndexes creates the indexes of the owner lookups and fills in the owner count of documents
+// written before it was maintained. It is safe to call on every startup.
+func (r *Repository[M, O]) EnsureIndexes(ctx context.Context) error {
+	collection, err := r.db.GetCollection(ctx, r.collectionName, nil)
+	if err != nil {
+		return err
+	}
+	if _, _, err := collection.EnsurePersistentIndex(ctx, []string{"owners[*].owner_id"},
+		&arangodb.CreatePersistentIndexOptions{Name: "owners"},
+	); err != nil {
+		return fmt.Errorf("failed to create owners index: %w", err)
+	}
+	if _, _, err := collection.EnsurePersistentIndex(ctx, []string{"owner_count"},
+		&arangodb.CreatePersistentIndexOptions{Name: "owner_count"},
+	); err != nil {
+		return fmt.Errorf("failed to create owner count index: %w", err)
+	}
+	if _, err := query[any](ctx, r.db,
+		`FOR d IN @@collection FILTER d.owner_count == null `+
+			`UPDATE d WITH { owner_count: LENGTH(d.owners || []) } IN @@collection`,
+		r.bindVars(nil),
+	); err != nil {
+		return fmt.Errorf("failed to fill in owner counts: %w", err)
+	}
+	return nil
+}
+
+// ownerFilter matches the documents associated with the bound @owner. The owner ID is matched first,
+// which is served by the owners index.
+const ownerFilter = `@owner.owner_id IN d.owners[*].owner_id AND @owner IN d.owners`
+
+// updateOwners replaces the owners of the document of the key with the owners expression, which
+// maintains the owner count.
+func (r *Repository[M, O]) updateOwners(ctx context.Context, key, owners string, vars map[string]any) error {
+	return r.update(ctx, key, `LET owners = `+owners,
+		`{ owners: owners, owner_count: LENGTH(owners), `+incRevision+` }`, vars,
+	)
+}
+
+// update applies the update expression to the document of the key, after the optional LET statements.
+// Sub-documents are replaced, not merged, to match the $set semantics of the MongoDB driver.
+func (r *Repository[M, O]) update(ctx context.Context, key, lets, expr string, vars map[string]any) error {
+	vars["key"] = key
+	updated, err := query[string](ctx, r.db,
+		`FOR d IN @@collection FILTER d._key == @key `+lets+` `+
+			`UPDATE d WITH `+expr+` IN @@collection OPTIONS { mergeObjects: false } RETURN NEW._key`,
+		r.bindVars(vars),
+	)
+	if err != nil {
+		return err
+	}
+	if len(updated) == 0 {
+		return dbmetadata.ErrNotFound
+	}
+	return nil
+}
+
+// page returns a page of the documents matching the filter expression, ordered by key. One document
+// more than limit is fetched, which tells whether there is a next page.
+func (r *Repository[M, O]) page(ctx context.Context, filter string, vars map[string]any, limit int, afterKey string) ([]*M, string, error) {
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("limit must be positive, got %d", limit)
+	}
+	vars["afterKey"] = afterKey
+	vars["limit"] = limit + 1
+	results, err := query[keyed[M]](ctx, r.db,
+		`FOR d IN @@collection FILTER `+filter+` AND d._key > @afterKey SORT d._key LIMIT @limit `+
+			`RETURN { key: d._key, doc: d }`,
+		r.bindVars(vars),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	var nextKey string
+	if len(results) > limit {
+		results = results[:limit]
+		nextKey = results[limit-1].Key
+	}
+	docs := make([]*M, len(results))
+	for i, res := range results {
+		docs[i] = res.Doc
+	}
+	return docs, nextKey, nil
+}
+
+func (r *Repository[M, O]) count(ctx context.Context, filter string, vars map[string]any) (int64, error) {
+	counts, err := query[int64](ctx, r.db,
+		`FOR d IN @@collection FILTER `+filter+` COLLECT WITH COUNT INTO n RETURN n`,
+		r.bindVars(vars),
+	)
+	if err != nil || len(counts) == 0 {
+		return 0, err
+	}
+	return counts[0], nil
+}
+
+// bindVars adds the collection to the bind variables of a query.
+func (r *Repository[M, O]) bindVars(vars map[string]any) map[string]any {
+	if vars == nil {
+		vars = make(map[string]any, 1)
+	}
+	vars["@collection"] = r.collectionName
+	return vars
+}
+
+// query runs the AQL query and decodes all results.
+func query[T any](ctx context.Context, db arangodb.Database, q string, bindVars map[string]any) ([]T, error) {
+	cursor, err := db.Query(ctx, q, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer cursor.Close()
+
+	var results []T
+	for cursor.HasMore() {
+		var doc T
+		if _, err := cursor.ReadDocument(ctx, &doc); err != nil {
+			return nil, err
+		}
+		results = append(results, doc)
+	}
+	return results, nil
+}
+
+// translateError maps the errors of the driver to the sentinels of the metadata package.
+func translateError(err error) error {
+	if shared.IsArangoErrorWithErrorNum(err, shared.ErrArangoUniqueConstraintViolated) {
+		return fmt.Errorf("%w: %w", dbmetadata.ErrDuplicateKey, err)
+	}
+	return err
+}
+
+// projection returns the expression of the loaded document, which keeps only the fields and the key
+// if any fields are provided. The fields are added to vars, ArangoDB rejects unused bind variables.
+func projection(fields []string, vars map[string]any) string {
+	if len(fields) == 0 {
+		return "d"
+	}
+	vars["fields"] = append([]string{"_key"}, fields...)
+	return "KEEP(d, @fields)"
+}
+
+// likePattern matches the query as a substring in LIKE, with the wildcards of the query escaped.
+func likePattern(query string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(query)
+	return "%" + escaped + "%"
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package crossstore coordinates writes to the document stores, which cannot take part in PostgreSQL
+// transactions, with a transaction. Writes applied inside the transaction register a compensation,
+// which undoes them if the transaction rolls back, so a failed flow does not leave orphaned documents
+// behind. Writes that must not be visible before the transaction commits, e.g. deletions, are staged
+// and applied once it committed.
+//
+// The coordinator is carried by the context passed to the function of [Transaction]:
+//
+//	err := crossstore.Transaction(ctx, db, func(ctx context.Context, tx *gorm.DB) error {
+//		if err := crossstore.Apply(ctx, create, remove); err != nil {
+//			return err
+//		}
+//		return repo.WithTx(tx).Create(ctx, record)
+//	})
+package crossstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// Write is a write to a document store.
+type Write func(ctx context.Context) error
+
+type coordinator struct {
+	mu            sync.Mutex
+	compensations []Write
+	afterCommit   []Write
+}
+
+type coordinatorKey struct{}
+
+// Transaction runs fn in a transaction of db. If the transaction rolls back, including when the commit
+// fails, the compensations of the writes applied by fn are executed in reverse order and their errors
+// are joined to the error of the transaction. Once the transaction committed, the staged writes are
+// applied in order, an error of a staged write is returned even though the transaction committed.
+//
+// Compensations and staged writes are executed even if ctx is canceled. Every transaction is coordinated
+// on its own, writes applied in a nested transaction of the same connection are not compensated when
+// only the outer one rolls back.
+func Transaction(ctx context.Context, db *gorm.DB, fn func(ctx context.Context, tx *gorm.DB) error) error {
+	c := &coordinator{}
+	txCtx := context.WithValue(ctx, coordinatorKey{}, c)
+
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		return fn(txCtx, tx)
+	}); err != nil {
+		if compErr := c.compensate(context.WithoutCancel(ctx)); compErr != nil {
+			return errors.Join(err, compErr)
+		}
+		return err
+	}
+	if err := c.commit(context.WithoutCancel(ctx)); err != nil {
+		return fmt.Errorf("transaction committed, but a staged write failed: %w", err)
+	}
+	return nil
+}
+
+// Apply applies the write immediately and registers compensate to undo it if the transaction of ctx
+// rolls back. A nil compensate registers nothing. Outside of a [Transaction] the write is applied alone.
+func Apply(ctx context.Context, write, compensate Write) error {
+	if err := write(ctx); err != nil {
+		return err
+	}
+	if c, ok := ctx.Value(coordinatorKey{}).(*coordinator); ok && compensate != nil {
+		c.mu.Lock()
+		c.compensations = append(c.compensations, compensate)
+		c.mu.Unlock()
+	}
+	return nil
+}
+
+// AfterCommit stages the write until the transaction of ctx committed, it is dropped if the transaction
+// rolls back. Outside of a [Transaction] the write is applied immediately.
+func AfterCommit(ctx context.Context, write Write) error {
+	c, ok := ctx.Value(coordinatorKey{}).(*coordinator)
+	if !ok {
+		return write(ctx)
+	}
+	c.mu.Lock()
+	c.afterCommit = append(c.afterCommit, write)
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *coordinator) compensate(ctx context.Context) error {
+	var errs []error
+	for _, compensate := range slices.Backward(c.compensations) {
+		if err := compensate(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to compensate document write: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *coordinator) commit(ctx context.Context) error {
+	var errs []error
+	for _, write := range c.afterCommit {
+		if err := write(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package metadata defines the storage of the asset metadata independently of the document store
+// holding it. The MongoDB and ArangoDB drivers implement [Repository], the driver is selected by
+// configuration. Drivers report missing and duplicate documents with the sentinels of this package.
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mikhail5545/media-service-go/internal/models/enrichment"
+)
+
+var (
+	// ErrNotFound is returned when the metadata of an asset does not exist.
+	ErrNotFound = errors.New("metadata not found")
+	// ErrDuplicateKey is returned when metadata is created for an asset which already has metadata.
+	ErrDuplicateKey = errors.New("metadata already exists")
+)
+
+// Backend names a document store holding the metadata.
+type Backend string
+
+const (
+	BackendMongo  Backend = "mongo"
+	BackendArango Backend = "arango"
+)
+
+// Backends lists the supported backends.
+var Backends = []Backend{BackendMongo, BackendArango}
+
+// Repository stores the metadata M of the assets of a provider, keyed by the asset ID. O is the owner
+// type of the metadata.
+//
+// Paged methods return the documents ordered by key, starting after the afterKey cursor, an empty
+// cursor starts from the beginning. The returned cursor is empty when there are no more pages.
+type Repository[M, O any] interface {
+	Create(ctx context.Context, data *M) error
+	// Get retrieves the metadata of the asset. If fields are provided, only those fields and the key are loaded.
+	Get(ctx context.Context, key string, fields ...string) (*M, error)
+	GetByOwner(ctx context.Context, key string, owner *O) (*M, error)
+	// Update replaces the metadata of the asset, except for the revision, which is incremented.
+	Update(ctx context.Context, key string, data *M) error
+	Delete(ctx context.Context, key string) error
+	ListUnownedIDs(ctx context.Context, limit int, afterKey string) ([]string, string, error)
+	ListByOwner(ctx context.Context, owner *O, limit int, afterKey string) ([]*M, string, error)
+	// Search returns a page of metadata of the assets matching the query and the label. The query is
+	// matched case-insensitively as a substring of the title, the labels and the extracted texts, the
+	// label must match exactly.
+	Search(ctx context.Context, query, label string, limit int, afterKey string) ([]*M, string, error)
+	// SetEnrichment replaces the enrichment data of the asset, leaving the rest of the metadata untouched.
+	SetEnrichment(ctx context.Context, key string, data *enrichment.Enrichment) error
+	// AddOwner atomically associates the owner with the asset, adding an associated owner is a no-op.
+	AddOwner(ctx context.Context, key string, owner *O) error
+	// RemoveOwner atomically disassociates the owner from the asset, removing an owner that is not
+	// associated is a no-op.
+	RemoveOwner(ctx context.Context, key string, owner *O) error
+	// IncrementRevision atomically increments the revision of the metadata if it is the expected one
+	// and returns the new revision. If the revision is another one, it returns the current revision
+	// and false.
+	IncrementRevision(ctx context.Context, key string, expected int64) (int64, bool, error)
+	CountUnowned(ctx context.Context) (int64, error)
+	// ListKeysByOwner returns the keys of all assets associated with the owner.
+	ListKeysByOwner(ctx context.Context, owner *O) ([]string, error)
+	List(ctx context.Context) ([]*M, error)
+	// ListByKeys retrieves the metadata of the assets mapped by key. If fields are provided, only those
+	// fields and the key are loaded.
+	ListByKeys(ctx context.Context, keys []string, fields ...string) (map[string]*M, error)
+	DeleteByKeys(ctx context.Context, keys []string) (int64, error)
+	// EnsureIndexes creates the indexes of the owner lookups and fills in the owner count of documents
+	// written before it was maintained. It is safe to call on every startup.
+	EnsureIndexes(ctx context.Context) error
+}
+
+// CopyResult reports the documents processed by [Copy].
+type CopyResult struct {
+	Copied  int
+	Skipped int
+}
+
+// Copy copies the metadata of all assets from src to dst, e.g. when moving to another backend.
+// Documents that already exist in dst are skipped and left unchanged, so an interrupted copy can
+// be repeated.
+func Copy[M, O any](ctx context.Context, src, dst Repository[M, O]) (*CopyResult, error) {
+	docs, err := src.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source metadata: %w", err)
+	}
+	result := &CopyResult{}
+	for _, doc := range docs {
+		if err := dst.Create(ctx, doc); err != nil {
+			if errors.Is(err, ErrDuplicateKey) {
+				result.Skipped++
+				continue
+			}
+			return result, fmt.Errorf("failed to copy metadata: %w", err)
+		}
+		result.Copied++
+	}
+	return result, nil
+}
@@ -0,0 +1,189 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	mediamodel "github.com/mikhail5545/media-service-go/internal/models/media"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Repository stores the metadata of the assets of all backends built on the shared asset core in a
+// single collection. Keys are asset IDs, which are unique across providers. It is only implemented
+// for MongoDB, missing and duplicate documents are reported with the sentinels of the metadata package.
+type Repository struct {
+	db             *mongo.Database
+	collectionName string
+}
+
+func New(db *mongo.Database, collectionName string) *Repository {
+	return &Repository{db: db, collectionName: collectionName}
+}
+
+func (r *Repository) Create(ctx context.Context, data *mediamodel.Metadata) error {
+	collection := r.db.Collection(r.collectionName)
+	_, err := collection.InsertOne(ctx, data)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%w: %w", dbmetadata.ErrDuplicateKey, err)
+	}
+	return err
+}
+
+func (r *Repository) Get(ctx context.Context, key string) (*mediamodel.Metadata, error) {
+	collection := r.db.Collection(r.collectionName)
+	filter := bson.D{{Key: "_id", Value: key}}
+
+	var result mediamodel.Metadata
+	if err := collection.FindOne(ctx, filter).Decode(&result); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %w", dbmetadata.ErrNotFound, err)
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListByKeys retrieves the metadata of the assets with the keys, keyed by the asset key. Keys without
+// metadata are missing from the result.
+func (r *Repository) ListByKeys(ctx context.Context, keys []string) (map[string]*mediamodel.Metadata, error) {
+	results := make(map[string]*mediamodel.Metadata, len(keys))
+	if len(keys) == 0 {
+		return results, nil
+	}
+	collection := r.db.Collection(r.collectionName)
+	filter := bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: keys}}}}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var metadata []*mediamodel.Metadata
+	if err := cursor.All(ctx, &metadata); err != nil {
+		return nil, err
+	}
+	for _, m := range metadata {
+		results[m.Key] = m
+	}
+	return results, nil
+}
+
+func (r *Repository) Delete(ctx context.Context, key string) error {
+	collection := r.db.Collection(r.collectionName)
+	filter := bson.D{{Key: "_id", Value: key}}
+	_, err := collection.DeleteOne(ctx, filter)
+	return err
+}
+
+// AddOwner atomically associates the owner with the asset. Adding an owner that is already associated
+// is a no-op, so the operation can be safely repeated.
+func (r *Repository) AddOwner(ctx context.Context, key string, owner *mediamodel.Owner) error {
+	collection := r.db.Collection(r.collectionName)
+
+	filter := bson.D{{Key: "_id", Value: key}}
+	update := bson.D{{Key: "$addToSet", Value: bson.D{{Key: "owners", Value: owner}}}}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return dbmetadata.ErrNotFound
+	}
+	return nil
+}
+
+// RemoveOwner atomically disassociates the owner from the asset. It reports whether the owner was
+// associated with the asset.
+func (r *Repository) RemoveOwner(ctx context.Context, key string, owner *mediamodel.Owner) (bool, error) {
+	collection := r.db.Collection(r.collectionName)
+
+	filter := bson.D{{Key: "_id", Value: key}}
+	update := bson.D{{Key: "$pull", Value: bson.D{{Key: "owners", Value: bson.D{
+		{Key: "owner_id", Value: owner.OwnerID},
+		{Key: "owner_type", Value: owner.OwnerType},
+	}}}}}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	if result.MatchedCount == 0 {
+		return false, dbmetadata.ErrNotFound
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// ListByOwner returns a page of metadata of the assets of the provider associated with the owner,
+// ordered by key. The page starts after the afterKey cursor, an empty cursor starts from the beginning.
+// The returned cursor is empty when there are no more pages.
+func (r *Repository) ListByOwner(ctx context.Context, provider string, owner *mediamodel.Owner, limit int, afterKey string) ([]*mediamodel.Metadata, string, error) {
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("limit must be positive, got %d", limit)
+	}
+	collection := r.db.Collection(r.collectionName)
+
+	filter := ownerFilter(provider, owner)
+	if afterKey != "" {
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: afterKey}}})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit + 1))
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*mediamodel.Metadata
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, "", err
+	}
+
+	var nextKey string
+	if len(results) > limit {
+		results = results[:limit]
+		nextKey = results[limit-1].Key
+	}
+	return results, nextKey, nil
+}
+
+// ListKeysByOwner returns the keys of all assets of the provider associated with the owner.
+func (r *Repository) ListKeysByOwner(ctx context.Context, provider string, owner *mediamodel.Owner) ([]string, error) {
+	collection := r.db.Collection(r.collectionName)
+
+	cursor, err := collection.Find(ctx, ownerFilter(provider, owner), options.Find().SetProjection(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Key string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(results))
+	for i, res := range results {
+		keys[i] = res.Key
+	}
+	return keys, nil
+}
+
+func ownerFilter(provider string, owner *mediamodel.Owner) bson.D {
+	return bson.D{
+		{Key: "provider", Value: provider},
+		{Key: "owners", Value: bson.D{
+			{Key: "$elemMatch", Value: bson.D{
+				{Key: "owner_id", Value: owner.OwnerID},
+				{Key: "owner_type", Value: owner.OwnerType},
+			}},
+		}},
+	}
+}
@@ -2,30 +2,25 @@ package metadata
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
 
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	"github.com/mikhail5545/media-service-go/internal/models/enrichment"
 	"github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
-type MongoRepository interface {
-	Create(ctx context.Context, data *metadata.AssetMetadata) error
-	Get(ctx context.Context, key string) (*metadata.AssetMetadata, error)
-	GetByOwner(ctx context.Context, key string, owner *metadata.Owner) (*metadata.AssetMetadata, error)
-	Update(ctx context.Context, key string, data *metadata.AssetMetadata) error
-	Delete(ctx context.Context, key string) error
-	ListUnownedIDs(ctx context.Context) ([]string, error)
-	List(ctx context.Context) ([]*metadata.AssetMetadata, error)
-	ListByKeys(ctx context.Context, keys []string) (map[string]*metadata.AssetMetadata, error)
-}
-
 type Repository struct {
 	db             *mongo.Database
 	collectionName string
 }
 
-var _ MongoRepository = (*Repository)(nil)
+var _ dbmetadata.Repository[metadata.AssetMetadata, metadata.Owner] = (*Repository)(nil)
 
 func New(db *mongo.Database, collectionName string) *Repository {
 	return &Repository{db: db, collectionName: collectionName}
@@ -33,18 +28,24 @@ func New(db *mongo.Database, collectionName string) *Repository {
 
 func (r *Repository) Create(ctx context.Context, data *metadata.AssetMetadata) error {
 	collection := r.db.Collection(r.collectionName)
+	data.OwnerCount = len(data.Owners)
 	_, err := collection.InsertOne(ctx, data)
-	return err
+	return translateError(err)
 }
 
-func (r *Repository) Get(ctx context.Context, key string) (*metadata.AssetMetadata, error) {
+// Get retrieves the metadata of the asset. If fields are provided, only those fields and the key are loaded.
+func (r *Repository) Get(ctx context.Context, key string, fields ...string) (*metadata.AssetMetadata, error) {
 	collection := r.db.Collection(r.collectionName)
 	filter := bson.D{{Key: "_id", Value: key}}
+	opts := options.FindOne()
+	if len(fields) > 0 {
+		opts.SetProjection(projection(fields))
+	}
 
 	var result metadata.AssetMetadata
-	err := collection.FindOne(ctx, filter).Decode(&result)
+	err := collection.FindOne(ctx, filter, opts).Decode(&result)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	return &result, nil
 }
@@ -64,16 +65,21 @@ func (r *Repository) GetByOwner(ctx context.Context, key string, owner *metadata
 	var result metadata.AssetMetadata
 	err := collection.FindOne(ctx, filter).Decode(&result)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	return &result, nil
 }
 
 func (r *Repository) Update(ctx context.Context, key string, data *metadata.AssetMetadata) error {
 	collection := r.db.Collection(r.collectionName)
+	data.OwnerCount = len(data.Owners)
 
+	fields, err := setFields(data)
+	if err != nil {
+		return err
+	}
 	filter := bson.D{{Key: "_id", Value: key}}
-	update := bson.D{{Key: "$set", Value: data}}
+	update := bson.D{{Key: "$set", Value: fields}, incRevision}
 	opts := options.UpdateOne().SetUpsert(false)
 
 	result, err := collection.UpdateOne(ctx, filter, update, opts)
@@ -81,7 +87,7 @@ func (r *Repository) Update(ctx context.Context, key string, data *metadata.Asse
 		return err
 	}
 	if result.MatchedCount == 0 {
-		return mongo.ErrNoDocuments
+		return dbmetadata.ErrNotFound
 	}
 	return nil
 }
@@ -93,15 +99,21 @@ func (r *Repository) Delete(ctx context.Context, key string) error {
 	return err
 }
 
-func (r *Repository) ListUnownedIDs(ctx context.Context) ([]string, error) {
+// ListUnownedIDs returns a page of keys of assets without owners, ordered by key. The page starts
+// after the afterKey cursor, an empty cursor starts from the beginning. The returned cursor is empty
+// when there are no more pages.
+func (r *Repository) ListUnownedIDs(ctx context.Context, limit int, afterKey string) ([]string, string, error) {
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("limit must be positive, got %d", limit)
+	}
 	collection := r.db.Collection(r.collectionName)
 
-	filter := bson.D{{Key: "owners", Value: bson.D{{Key: "$size", Value: 0}}}}
-	opts := options.Find().SetProjection(bson.D{{Key: "_id", Value: 1}})
+	filter, opts := keysetPage(bson.D{{Key: "owner_count", Value: 0}}, limit, afterKey)
+	opts = opts.SetProjection(bson.D{{Key: "_id", Value: 1}})
 
 	cursor, err := collection.Find(ctx, filter, opts)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer cursor.Close(ctx)
 
@@ -110,14 +122,328 @@ func (r *Repository) ListUnownedIDs(ctx context.Context) ([]string, error) {
 	}
 
 	if err := cursor.All(ctx, &results); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
+	var nextKey string
+	if len(results) > limit {
+		results = results[:limit]
+		nextKey = results[limit-1].Key
+	}
 	ids := make([]string, len(results))
 	for i, res := range results {
 		ids[i] = res.Key
 	}
-	return ids, nil
+	return ids, nextKey, nil
+}
+
+// ListByOwner returns a page of metadata of the assets associated with the owner, ordered by key.
+// Pagination works the same way as in [Repository.ListUnownedIDs].
+func (r *Repository) ListByOwner(ctx context.Context, owner *metadata.Owner, limit int, afterKey string) ([]*metadata.AssetMetadata, string, error) {
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("limit must be positive, got %d", limit)
+	}
+	collection := r.db.Collection(r.collectionName)
+
+	filter, opts := keysetPage(ownerFilter(owner), limit, afterKey)
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*metadata.AssetMetadata
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, "", err
+	}
+
+	var nextKey string
+	if len(results) > limit {
+		results = results[:limit]
+		nextKey = results[limit-1].Key
+	}
+	return results, nextKey, nil
+}
+
+// Search returns a page of metadata of the assets matching the query and the label, ordered by key.
+// The query is matched case-insensitively as a substring of the title, the labels and the extracted
+// texts, the label must match exactly. Pagination works the same way as in [Repository.ListUnownedIDs].
+func (r *Repository) Search(ctx context.Context, query, label string, limit int, afterKey string) ([]*metadata.AssetMetadata, string, error) {
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("limit must be positive, got %d", limit)
+	}
+	collection := r.db.Collection(r.collectionName)
+
+	filter, opts := keysetPage(searchFilter(query, label), limit, afterKey)
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*metadata.AssetMetadata
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, "", err
+	}
+
+	var nextKey string
+	if len(results) > limit {
+		results = results[:limit]
+		nextKey = results[limit-1].Key
+	}
+	return results, nextKey, nil
+}
+
+// SetEnrichment replaces the enrichment data of the asset, leaving the rest of the metadata untouched.
+func (r *Repository) SetEnrichment(ctx context.Context, key string, data *enrichment.Enrichment) error {
+	collection := r.db.Collection(r.collectionName)
+
+	filter := bson.D{{Key: "_id", Value: key}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "enrichment", Value: data}}}, incRevision}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return dbmetadata.ErrNotFound
+	}
+	return nil
+}
+
+// AddOwner atomically associates the owner with the asset. Adding an owner that is already associated
+// is a no-op, so the operation can be safely repeated.
+func (r *Repository) AddOwner(ctx context.Context, key string, owner *metadata.Owner) error {
+	collection := r.db.Collection(r.collectionName)
+
+	filter := bson.D{{Key: "_id", Value: key}}
+	// Literals, so the values of the owner are never taken for field paths.
+	newOwner := bson.D{{Key: "$literal", Value: owner}}
+	owners := bson.D{{Key: "$cond", Value: bson.A{
+		bson.D{{Key: "$in", Value: bson.A{newOwner, currentOwners}}},
+		currentOwners,
+		bson.D{{Key: "$concatArrays", Value: bson.A{currentOwners, bson.A{newOwner}}}},
+	}}}
+
+	result, err := collection.UpdateOne(ctx, filter, ownersUpdate(owners))
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return dbmetadata.ErrNotFound
+	}
+	return nil
+}
+
+// RemoveOwner atomically disassociates the owner from the asset. Removing an owner that is not
+// associated is a no-op, so the operation can be safely repeated.
+func (r *Repository) RemoveOwner(ctx context.Context, key string, owner *metadata.Owner) error {
+	collection := r.db.Collection(r.collectionName)
+
+	filter := bson.D{{Key: "_id", Value: key}}
+	owners := bson.D{{Key: "$filter", Value: bson.D{
+		{Key: "input", Value: currentOwners},
+		{Key: "cond", Value: bson.D{{Key: "$not", Value: bson.A{bson.D{{Key: "$and", Value: bson.A{
+			bson.D{{Key: "$eq", Value: bson.A{"$$this.owner_id", bson.D{{Key: "$literal", Value: owner.OwnerID}}}}},
+			bson.D{{Key: "$eq", Value: bson.A{"$$this.owner_type", bson.D{{Key: "$literal", Value: owner.OwnerType}}}}},
+		}}}}}}},
+	}}}
+
+	result, err := collection.UpdateOne(ctx, filter, ownersUpdate(owners))
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return dbmetadata.ErrNotFound
+	}
+	return nil
+}
+
+// IncrementRevision atomically increments the revision of the metadata if it is the expected one and
+// returns the new revision. If the revision is another one, it returns the current revision and false.
+// It returns [dbmetadata.ErrNotFound] if the metadata does not exist.
+func (r *Repository) IncrementRevision(ctx context.Context, key string, expected int64) (int64, bool, error) {
+	collection := r.db.Collection(r.collectionName)
+
+	revisionFilter := bson.E{Key: "revision", Value: expected}
+	if expected == 0 {
+		revisionFilter = bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "revision", Value: 0}},
+			bson.D{{Key: "revision", Value: bson.D{{Key: "$exists", Value: false}}}},
+		}}
+	}
+	filter := bson.D{{Key: "_id", Value: key}, revisionFilter}
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.D{{Key: "revision", Value: 1}})
+
+	var updated metadata.AssetMetadata
+	err := collection.FindOneAndUpdate(ctx, filter, bson.D{incRevision}, opts).Decode(&updated)
+	if err == nil {
+		return updated.Revision, true, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, false, err
+	}
+	current, err := r.Get(ctx, key, "revision")
+	if err != nil {
+		return 0, false, err
+	}
+	return current.Revision, false, nil
+}
+
+// translateError maps the errors of the driver to the sentinels of the metadata package.
+func translateError(err error) error {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return fmt.Errorf("%w: %w", dbmetadata.ErrNotFound, err)
+	case mongo.IsDuplicateKeyError(err):
+		return fmt.Errorf("%w: %w", dbmetadata.ErrDuplicateKey, err)
+	}
+	return err
+}
+
+// incRevision is the update operator incrementing the revision, added to every update of the metadata.
+var incRevision = bson.E{Key: "$inc", Value: bson.D{{Key: "revision", Value: 1}}}
+
+// currentOwners is the aggregation expression of the owners of a document, documents without owners
+// have an empty list.
+var currentOwners = bson.D{{Key: "$ifNull", Value: bson.A{"$owners", bson.A{}}}}
+
+// ownersUpdate returns the update pipeline replacing the owners with the owners expression, which
+// maintains the owner count and increments the revision.
+func ownersUpdate(owners bson.D) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{{Key: "owners", Value: owners}}}},
+		{{Key: "$set", Value: bson.D{
+			{Key: "owner_count", Value: bson.D{{Key: "$size", Value: "$owners"}}},
+			{Key: "revision", Value: bson.D{{Key: "$add", Value: bson.A{bson.D{{Key: "$ifNull", Value: bson.A{"$revision", 0}}}, 1}}}},
+		}}},
+	}
+}
+
+// EnsureIndexes creates the indexes of the owner lookups and fills in the owner count of documents
+// written before it was maintained. It is safe to call on every startup.
+func (r *Repository) EnsureIndexes(ctx context.Context) error {
+	collection := r.db.Collection(r.collectionName)
+
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "owners.owner_id", Value: 1}, {Key: "owners.owner_type", Value: 1}},
+			Options: options.Index().SetName("owners"),
+		},
+		{
+			Keys:    bson.D{{Key: "owner_count", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("owner_count"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+	_, err = collection.UpdateMany(ctx,
+		bson.D{{Key: "owner_count", Value: bson.D{{Key: "$exists", Value: false}}}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.D{{Key: "owner_count", Value: bson.D{{Key: "$size", Value: currentOwners}}}}}}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to fill in owner counts: %w", err)
+	}
+	return nil
+}
+
+// setFields returns the fields of data changed by $set. The revision is left out, it is only ever
+// incremented.
+func setFields(data *metadata.AssetMetadata) (bson.D, error) {
+	raw, err := bson.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var fields bson.D
+	if err := bson.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(fields, func(e bson.E) bool { return e.Key == "revision" }), nil
+}
+
+// searchFilter matches the metadata by the query and the label, empty values match everything.
+func searchFilter(query, label string) bson.D {
+	filter := bson.D{}
+	if label != "" {
+		filter = append(filter, bson.E{Key: "enrichment.labels", Value: label})
+	}
+	if query != "" {
+		pattern := bson.Regex{Pattern: regexp.QuoteMeta(query), Options: "i"}
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "title", Value: pattern}},
+			bson.D{{Key: "enrichment.labels", Value: pattern}},
+			bson.D{{Key: "enrichment.ocr_text", Value: pattern}},
+			bson.D{{Key: "enrichment.transcript", Value: pattern}},
+		}})
+	}
+	return filter
+}
+
+// projection includes the fields in the loaded documents, the _id is always included.
+func projection(fields []string) bson.D {
+	proj := make(bson.D, 0, len(fields))
+	for _, field := range fields {
+		proj = append(proj, bson.E{Key: field, Value: 1})
+	}
+	return proj
+}
+
+// ownerFilter matches the metadata of the assets associated with the owner.
+func ownerFilter(owner *metadata.Owner) bson.D {
+	return bson.D{
+		{Key: "owners", Value: bson.D{
+			{Key: "$elemMatch", Value: bson.D{
+				{Key: "owner_id", Value: owner.OwnerID},
+				{Key: "owner_type", Value: owner.OwnerType},
+			}},
+		}},
+	}
+}
+
+// keysetPage narrows the filter to documents with keys after afterKey and returns find options that
+// sort by key and fetch one document more than limit, which tells whether there is a next page.
+func keysetPage(filter bson.D, limit int, afterKey string) (bson.D, *options.FindOptionsBuilder) {
+	if afterKey != "" {
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: afterKey}}})
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit + 1))
+	return filter, opts
+}
+
+func (r *Repository) CountUnowned(ctx context.Context) (int64, error) {
+	collection := r.db.Collection(r.collectionName)
+	filter := bson.D{{Key: "owner_count", Value: 0}}
+	return collection.CountDocuments(ctx, filter)
+}
+
+// ListKeysByOwner returns the keys of all assets associated with the owner.
+func (r *Repository) ListKeysByOwner(ctx context.Context, owner *metadata.Owner) ([]string, error) {
+	collection := r.db.Collection(r.collectionName)
+
+	cursor, err := collection.Find(ctx, ownerFilter(owner), options.Find().SetProjection(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Key string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(results))
+	for i, res := range results {
+		keys[i] = res.Key
+	}
+	return keys, nil
 }
 
 func (r *Repository) List(ctx context.Context) ([]*metadata.AssetMetadata, error) {
@@ -136,11 +462,17 @@ func (r *Repository) List(ctx context.Context) ([]*metadata.AssetMetadata, error
 	return metadataList, nil
 }
 
-func (r *Repository) ListByKeys(ctx context.Context, keys []string) (map[string]*metadata.AssetMetadata, error) {
+// ListByKeys retrieves the metadata of the assets mapped by key. If fields are provided, only those
+// fields and the key are loaded.
+func (r *Repository) ListByKeys(ctx context.Context, keys []string, fields ...string) (map[string]*metadata.AssetMetadata, error) {
 	collection := r.db.Collection(r.collectionName)
 
 	filter := bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: keys}}}}
-	cursor, err := collection.Find(ctx, filter)
+	opts := options.Find()
+	if len(fields) > 0 {
+		opts.SetProjection(projection(fields))
+	}
+	cursor, err := collection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -159,3 +491,13 @@ func (r *Repository) ListByKeys(ctx context.Context, keys []string) (map[string]
 	}
 	return metadataMap, nil
 }
+
+func (r *Repository) DeleteByKeys(ctx context.Context, keys []string) (int64, error) {
+	collection := r.db.Collection(r.collectionName)
+	filter := bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: keys}}}}
+	res, err := collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, err
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/database/postgres/pagination"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	"gorm.io/gorm"
+)
+
+type GormRepository interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) *Repository
+	// Create persists audit log entries. It should be called using the transactional repository
+	// (see WithTx), so entries are stored atomically with the mutation they describe.
+	Create(ctx context.Context, entries ...*auditmodel.Entry) error
+	// List retrieves a page of audit log entries matching the filter, newest first.
+	List(ctx context.Context, filter *Filter) ([]*auditmodel.Entry, string, error)
+	// ListByAsset retrieves the latest audit log entries of an asset, at most limit, oldest first.
+	ListByAsset(ctx context.Context, provider auditmodel.Provider, assetID uuid.UUID, limit int) ([]*auditmodel.Entry, error)
+}
+
+// Filter narrows down the listed audit log entries. Zero values are ignored.
+type Filter struct {
+	Provider auditmodel.Provider
+	AssetID  uuid.UUID
+	AdminID  uuid.UUID
+	Actions  []auditmodel.Action
+	Source   auditmodel.Source
+	From     time.Time
+	To       time.Time
+
+	PageSize  int
+	PageToken string
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+var _ GormRepository = (*Repository)(nil)
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *Repository) WithTx(tx *gorm.DB) *Repository {
+	return &Repository{db: tx}
+}
+
+// Create persists audit log entries. It should be called using the transactional repository
+// (see WithTx), so entries are stored atomically with the mutation they describe.
+func (r *Repository) Create(ctx context.Context, entries ...*auditmodel.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(entries).Error
+}
+
+// List retrieves a page of audit log entries matching the filter, newest first.
+func (r *Repository) List(ctx context.Context, filter *Filter) ([]*auditmodel.Entry, string, error) {
+	if filter.PageSize <= 0 {
+		return nil, "", fmt.Errorf("page size must be positive")
+	}
+	db, err := pagination.ApplyCursor(applyFilter(r.db.WithContext(ctx), filter), pagination.ApplyCursorParams{
+		PageSize:   filter.PageSize,
+		PageToken:  filter.PageToken,
+		OrderField: "created_at",
+		OrderDir:   "DESC",
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to apply pagination: %w", err)
+	}
+
+	var entries []*auditmodel.Entry
+	if err := db.Find(&entries).Error; err != nil {
+		return nil, "", err
+	}
+
+	var nextToken string
+	if len(entries) == filter.PageSize+1 {
+		last := entries[filter.PageSize-1]
+		nextToken = pagination.EncodePageToken(last.CreatedAt, last.ID)
+		entries = entries[:filter.PageSize]
+	}
+	return entries, nextToken, nil
+}
+
+// ListByAsset retrieves the latest audit log entries of an asset, at most limit, oldest first.
+func (r *Repository) ListByAsset(ctx context.Context, provider auditmodel.Provider, assetID uuid.UUID, limit int) ([]*auditmodel.Entry, error) {
+	var entries []*auditmodel.Entry
+	if err := r.db.WithContext(ctx).
+		Where("provider = ? AND asset_id = ?", provider, assetID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	slices.Reverse(entries)
+	return entries, nil
+}
+
+func applyFilter(db *gorm.DB, filter *Filter) *gorm.DB {
+	if filter.Provider != "" {
+		db = db.Where("provider = ?", filter.Provider)
+	}
+	if filter.AssetID != uuid.Nil {
+		db = db.Where("asset_id = ?", filter.AssetID)
+	}
+	if filter.AdminID != uuid.Nil {
+		db = db.Where("admin_id = ?", filter.AdminID)
+	}
+	if len(filter.Actions) > 0 {
+		db = db.Where("action IN ?", filter.Actions)
+	}
+	if filter.Source != "" {
+		db = db.Where("source = ?", filter.Source)
+	}
+	if !filter.From.IsZero() {
+		db = db.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		db = db.Where("created_at < ?", filter.To)
+	}
+	return db
+}
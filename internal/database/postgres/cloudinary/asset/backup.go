@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+)
+
+// MarkDestroyed records the moment the files of the cloudinary assets were destroyed in Cloudinary, in
+// any status but pending deletion, including a soft-deleted one. The backup availability is reset until
+// it is checked again.
+func (r *Repository) MarkDestroyed(ctx context.Context, publicIDs []string, at time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Model(&cldassetmodel.Asset{}).
+		Where("cloudinary_public_id IN ? AND status <> ?", publicIDs, cldassetmodel.StatusPendingDelete).
+		Updates(map[string]any{
+			"cloudinary_destroyed_at": at,
+			"backup_available":        nil,
+		})
+	return res.RowsAffected, res.Error
+}
+
+// SaveBackupAvailability stores whether Cloudinary keeps a backup of the destroyed file of the cloudinary asset.
+func (r *Repository) SaveBackupAvailability(ctx context.Context, id uuid.UUID, available bool) error {
+	return r.db.WithContext(ctx).Unscoped().
+		Model(&cldassetmodel.Asset{}).
+		Where("id = ? AND cloudinary_destroyed_at IS NOT NULL", id).
+		Update("backup_available", available).Error
+}
+
+// MarkRestoredFromBackup applies the file details of the restored version to the destroyed cloudinary
+// asset and clears its destroyed state. The status of the asset is not changed.
+func (r *Repository) MarkRestoredFromBackup(ctx context.Context, id uuid.UUID, updates map[string]any) (int64, error) {
+	updates["cloudinary_destroyed_at"] = nil
+	updates["backup_available"] = nil
+	res := r.db.WithContext(ctx).Unscoped().
+		Model(&cldassetmodel.Asset{}).
+		Where("id = ? AND cloudinary_destroyed_at IS NOT NULL", id).
+		Updates(updates)
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+)
+
+// duplicateColumns maps the duplicate kinds to the columns holding the hash.
+var duplicateColumns = map[cldassetmodel.DuplicateKind]string{
+	cldassetmodel.DuplicateKindEtag:  "etag",
+	cldassetmodel.DuplicateKindPhash: "phash",
+}
+
+// duplicateRow is a group of assets sharing a hash, IDs holds the comma separated asset IDs.
+type duplicateRow struct {
+	Hash string
+	IDs  string
+}
+
+// ListDuplicates retrieves up to limit groups of active cloudinary assets sharing the hash of the kind,
+// largest groups first. The asset IDs of a group are ordered by creation time.
+func (r *Repository) ListDuplicates(ctx context.Context, kind cldassetmodel.DuplicateKind, limit int) ([]*cldassetmodel.DuplicateGroup, error) {
+	column, ok := duplicateColumns[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown duplicate kind %q", kind)
+	}
+	var rows []*duplicateRow
+	err := r.read.WithContext(ctx).Model(&cldassetmodel.Asset{}).
+		Select(column+" AS hash, string_agg(id::text, ',' ORDER BY created_at) AS ids").
+		Where(column+" IS NOT NULL AND status = ?", cldassetmodel.StatusActive).
+		Group(column).
+		Having("COUNT(*) > 1").
+		Order("COUNT(*) DESC, " + column).
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*cldassetmodel.DuplicateGroup, 0, len(rows))
+	for _, row := range rows {
+		ids, err := parseIDs(row.IDs)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, &cldassetmodel.DuplicateGroup{Kind: kind, Hash: row.Hash, AssetIDs: ids})
+	}
+	return groups, nil
+}
+
+// GetByEtag retrieves the oldest active cloudinary asset with the etag, other than the excluded one.
+// It reads from the primary, so it can be used within the transaction completing an upload.
+func (r *Repository) GetByEtag(ctx context.Context, etag string, excludeID uuid.UUID) (*cldassetmodel.Asset, error) {
+	var asset cldassetmodel.Asset
+	err := r.db.WithContext(ctx).
+		Where("etag = ? AND id <> ? AND status = ?", etag, excludeID, cldassetmodel.StatusActive).
+		Order("created_at ASC").
+		First(&asset).Error
+	if err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+func parseIDs(joined string) (uuid.UUIDs, error) {
+	parts := strings.Split(joined, ",")
+	ids := make(uuid.UUIDs, 0, len(parts))
+	for _, part := range parts {
+		id, err := uuid.Parse(part)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse asset ID %q: %w", part, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -3,6 +3,7 @@ package asset
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/mikhail5545/media-service-go/internal/database/postgres/pagination"
 	"github.com/mikhail5545/media-service-go/internal/database/types"
@@ -19,16 +20,13 @@ func (r *Repository) get(ctx context.Context, filter *Filter) (*cldassetmodel.As
 		return nil, fmt.Errorf("at least one identifying filter must be provided")
 	}
 
-	db := r.db.WithContext(ctx)
-	db = applyStatusFilter(db, filter.Statuses)
+	db := r.read.WithContext(ctx)
+	db = applyFilter(db, filter)
 
 	if len(filter.Fields) > 0 {
 		db = db.Select(filter.Fields)
 	}
 
-	db = applyIdentifyingFilters(db, filter)
-	db = applySpecificFilters(db, filter)
-
 	var asset cldassetmodel.Asset
 	err := db.First(&asset).Error
 	return &asset, err
@@ -40,16 +38,14 @@ func (r *Repository) list(ctx context.Context, filter *Filter) ([]*cldassetmodel
 		return nil, "", fmt.Errorf("invalid filter: %w", err)
 	}
 
-	db := r.db.WithContext(ctx)
-	db = applyStatusFilter(db, filter.Statuses)
+	db := r.read.WithContext(ctx)
+	db = applyFilter(db, filter)
+	db = applyListing(db, filter.ListingFilter, filter.WithListing)
 
 	if len(filter.Fields) > 0 {
-		db = db.Select(filter.Fields)
+		db = db.Select(pageFields(filter))
 	}
 
-	db = applyIdentifyingFilters(db, filter)
-	db = applySpecificFilters(db, filter)
-
 	db, err := pagination.ApplyCursor(db, pagination.ApplyCursorParams{
 		PageSize:   filter.PageSize,
 		PageToken:  filter.PageToken,
@@ -81,15 +77,12 @@ func (r *Repository) listAll(ctx context.Context, filter *Filter) ([]*cldassetmo
 		return nil, fmt.Errorf("invalid filter: %w", err)
 	}
 
-	db := r.db.WithContext(ctx)
-	db = applyStatusFilter(db, filter.Statuses)
+	db := r.read.WithContext(ctx)
+	db = applyFilter(db, filter)
 
 	if len(filter.Fields) > 0 {
 		db = db.Select(filter.Fields)
 	}
-
-	db = applyIdentifyingFilters(db, filter)
-	db = applySpecificFilters(db, filter)
 	db = applyOrdering(db, filter)
 
 	var assets []*cldassetmodel.Asset
@@ -120,6 +113,25 @@ func (r *Repository) restore(ctx context.Context, filter *Filter, opts *types.Au
 	return res.RowsAffected, res.Error
 }
 
+func (r *Repository) count(ctx context.Context, filter *Filter) (int64, error) {
+	total, err := r.total(ctx, filter, nil)
+	return total.Count, err
+}
+
+func (r *Repository) total(ctx context.Context, filter *Filter, counter *pagination.Counter) (pagination.Total, error) {
+	cleanFilter(filter)
+	if filter == nil {
+		return pagination.Total{}, nil
+	}
+	if err := filter.Validate(); err != nil {
+		return pagination.Total{}, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	db := applyFilter(r.read.WithContext(ctx).Model(&cldassetmodel.Asset{}), filter)
+	db = applyListing(db, filter.ListingFilter, false)
+	return counter.Count(db)
+}
+
 func (r *Repository) update(ctx context.Context, filter *Filter, updates map[string]any) (int64, error) {
 	cleanFilter(filter)
 	if filter == nil {
@@ -197,7 +209,8 @@ func (r *Repository) delete(ctx context.Context, filter *Filter) (int64, error)
 	db = applyIdentifyingFilters(db, filter)
 	db = applySpecificFilters(db, filter)
 
-	db = db.Where("deleted_at IS NOT NULL AND status = ?", cldassetmodel.StatusArchived) // only delete archived records
+	// only delete archived records or records pending deletion
+	db = db.Where("deleted_at IS NOT NULL AND status IN ?", []cldassetmodel.Status{cldassetmodel.StatusArchived, cldassetmodel.StatusPendingDelete})
 
 	res := db.Delete(&cldassetmodel.Asset{})
 	return res.RowsAffected, res.Error
@@ -226,3 +239,32 @@ func (r *Repository) markAsBroken(ctx context.Context, filter *Filter, auditOpts
 	res := db.Updates(updates)
 	return res.RowsAffected, res.Error
 }
+
+func (r *Repository) listExpired(ctx context.Context, cutoff, failedAfter time.Time, limit int) ([]*cldassetmodel.Asset, error) {
+	if cutoff.IsZero() {
+		return nil, fmt.Errorf("cutoff must be provided")
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	db := r.db.WithContext(ctx).Unscoped().Model(&cldassetmodel.Asset{})
+	db = db.Where("deleted_at IS NOT NULL AND deleted_at < ? AND status = ?", cutoff, cldassetmodel.StatusArchived)
+	if !failedAfter.IsZero() {
+		db = db.Where(`NOT EXISTS (
+			SELECT 1 FROM purge_audit_log p
+			WHERE p.asset_id = cloudinary_assets.id AND NOT p.dry_run AND NOT p.succeeded AND p.created_at > ?
+		)`, failedAfter)
+	}
+	db = db.Order("deleted_at ASC, id ASC").Limit(limit)
+
+	var assets []*cldassetmodel.Asset
+	err := db.Find(&assets).Error
+	return assets, err
+}
+
+func (r *Repository) countByStatus(ctx context.Context, status cldassetmodel.Status) (int64, error) {
+	var count int64
+	err := r.read.WithContext(ctx).Unscoped().Model(&cldassetmodel.Asset{}).Where("status = ?", status).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset
+
+import (
+	"context"
+
+	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+)
+
+// ListKnown retrieves the IDs, Cloudinary asset IDs and public IDs of the cloudinary assets in any
+// status, including soft-deleted ones, with one of the Cloudinary asset IDs or one of the public IDs.
+// It reads from the primary, so assets created right before are reported.
+func (r *Repository) ListKnown(ctx context.Context, cloudinaryAssetIDs, publicIDs []string) ([]*cldassetmodel.Asset, error) {
+	if len(cloudinaryAssetIDs) == 0 && len(publicIDs) == 0 {
+		return nil, nil
+	}
+	if cloudinaryAssetIDs == nil {
+		cloudinaryAssetIDs = []string{}
+	}
+	if publicIDs == nil {
+		publicIDs = []string{}
+	}
+	var assets []*cldassetmodel.Asset
+	err := r.db.WithContext(ctx).Unscoped().
+		Select("id", "cloudinary_asset_id", "cloudinary_public_id").
+		Where("cloudinary_asset_id IN ? OR cloudinary_public_id IN ?", cloudinaryAssetIDs, publicIDs).
+		Find(&assets).Error
+	return assets, err
+}
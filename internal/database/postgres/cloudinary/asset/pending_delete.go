@@ -0,0 +1,63 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+)
+
+// MarkPendingDelete marks archived cloudinary assets matching the provided state operation options as pending
+// deletion. The records are kept until the deletion from Cloudinary completes.
+func (r *Repository) MarkPendingDelete(ctx context.Context, opts StateOperationOptions) (int64, error) {
+	filter := populateFromStateOperationOptions(&opts)
+	cleanFilter(filter)
+	if !hasIdentifyingFilters(filter) {
+		return 0, fmt.Errorf("filter does not contain identifying fields")
+	}
+	if err := filter.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	db := r.db.WithContext(ctx).Unscoped().Model(&cldassetmodel.Asset{})
+	db = applyIdentifyingFilters(db, filter)
+	db = applySpecificFilters(db, filter)
+	db = db.Where("deleted_at IS NOT NULL AND status = ?", cldassetmodel.StatusArchived) // only archived records
+
+	res := db.Updates(map[string]any{
+		"status":              cldassetmodel.StatusPendingDelete,
+		"delete_requested_at": time.Now(),
+	})
+	return res.RowsAffected, res.Error
+}
+
+// GetPendingDelete retrieves the cloudinary asset pending deletion. It reads from the primary, the record
+// has just been marked by the deleting request.
+func (r *Repository) GetPendingDelete(ctx context.Context, id uuid.UUID) (*cldassetmodel.Asset, error) {
+	var asset cldassetmodel.Asset
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("id = ? AND status = ?", id, cldassetmodel.StatusPendingDelete).
+		First(&asset).Error
+	if err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// ListPendingDelete retrieves cloudinary assets marked as pending deletion before the provided cutoff, ordered
+// by the time of the request. At most limit records are returned.
+func (r *Repository) ListPendingDelete(ctx context.Context, cutoff time.Time, limit int) ([]*cldassetmodel.Asset, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	var assets []*cldassetmodel.Asset
+	err := r.read.WithContext(ctx).Unscoped().
+		Where("status = ? AND delete_requested_at < ?", cldassetmodel.StatusPendingDelete, cutoff).
+		Order("delete_requested_at ASC, id ASC").
+		Limit(limit).
+		Find(&assets).Error
+	return assets, err
+}
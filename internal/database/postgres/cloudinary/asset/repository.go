@@ -2,10 +2,15 @@ package asset
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/database/postgres/pagination"
 	"github.com/mikhail5545/media-service-go/internal/database/types"
 	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	costmodel "github.com/mikhail5545/media-service-go/internal/models/cost"
+	"github.com/mikhail5545/media-service-go/internal/models/publishing"
+	usagemodel "github.com/mikhail5545/media-service-go/internal/models/usage"
 	"gorm.io/gorm"
 )
 
@@ -20,17 +25,85 @@ type GormRepository interface {
 	Archive(ctx context.Context, opts StateOperationOptions, auditOpts *types.AuditTrailOptions) (int64, error)
 	Restore(ctx context.Context, opts StateOperationOptions, auditOpts *types.AuditTrailOptions) (int64, error)
 	Delete(ctx context.Context, opts StateOperationOptions) (int64, error)
+	// MarkPendingDelete marks archived cloudinary assets matching the provided state operation options as pending deletion.
+	MarkPendingDelete(ctx context.Context, opts StateOperationOptions) (int64, error)
+	// GetPendingDelete retrieves the cloudinary asset pending deletion.
+	GetPendingDelete(ctx context.Context, id uuid.UUID) (*cldassetmodel.Asset, error)
+	// ListPendingDelete retrieves cloudinary assets marked as pending deletion before the provided cutoff.
+	ListPendingDelete(ctx context.Context, cutoff time.Time, limit int) ([]*cldassetmodel.Asset, error)
 	MarkAsBroken(ctx context.Context, opts StateOperationOptions, auditOpts *types.AuditTrailOptions) (int64, error)
+	// ListExpired retrieves archived cloudinary assets that were soft-deleted before the provided cutoff,
+	// ordered by deletion time. Assets with a failed purge attempt recorded after failedAfter are skipped,
+	// a zero failedAfter skips none. At most limit records are returned.
+	ListExpired(ctx context.Context, cutoff, failedAfter time.Time, limit int) ([]*cldassetmodel.Asset, error)
+	// CountByStatus returns the number of cloudinary assets with the provided status, including soft-deleted ones.
+	CountByStatus(ctx context.Context, status cldassetmodel.Status) (int64, error)
+	// LockOwner serializes the associations of the owner with cloudinary assets until the end of the transaction.
+	LockOwner(ctx context.Context, ownerType, ownerID string) error
+	// Count returns the number of cloudinary assets matching the provided options and scopes. It applies
+	// the same filters as List, so it can serve as the total of a paginated listing.
+	// If no scopes are provided, only active assets are considered.
+	Count(ctx context.Context, opts ListOptions, scopes ...Scope) (int64, error)
+	// Total is Count computed with the strategy of the counter, which may return an estimate.
+	Total(ctx context.Context, counter *pagination.Counter, opts ListOptions, scopes ...Scope) (pagination.Total, error)
+	// SaveListings creates or replaces the listing read model of cloudinary assets.
+	SaveListings(ctx context.Context, listings ...*cldassetmodel.Listing) error
+	// ListIDs retrieves the IDs of up to limit cloudinary assets in any status with IDs greater than afterID, ordered by ID.
+	ListIDs(ctx context.Context, afterID uuid.UUID, limit int) (uuid.UUIDs, error)
+	// CreatorUsage returns the usage of the cloudinary assets created by the creator. Archived assets are not counted.
+	CreatorUsage(ctx context.Context, creatorID uuid.UUID) (*usagemodel.Usage, error)
+	// UsageByCreator returns the usage of the cloudinary assets grouped by creator. Archived assets are not counted.
+	UsageByCreator(ctx context.Context) ([]*usagemodel.Usage, error)
+	// ListUsage retrieves the usage of up to limit cloudinary assets with IDs greater than afterID, ordered by ID.
+	ListUsage(ctx context.Context, afterID uuid.UUID, limit int) ([]*usagemodel.AssetUsage, error)
+	// ListCostUsage retrieves the usage of up to limit cloudinary assets in any status created before the
+	// time with IDs greater than afterID, ordered by ID.
+	ListCostUsage(ctx context.Context, createdBefore time.Time, afterID uuid.UUID, limit int) ([]*costmodel.AssetUsage, error)
+	// ListKnown retrieves the IDs, Cloudinary asset IDs and public IDs of the cloudinary assets in any
+	// status with one of the Cloudinary asset IDs or one of the public IDs.
+	ListKnown(ctx context.Context, cloudinaryAssetIDs, publicIDs []string) ([]*cldassetmodel.Asset, error)
+	// ListStatuses retrieves the IDs and statuses of the cloudinary assets with the IDs in any status.
+	ListStatuses(ctx context.Context, ids uuid.UUIDs) ([]*cldassetmodel.Asset, error)
+	// ListExistingIDs retrieves the IDs among ids of the cloudinary assets in any status.
+	ListExistingIDs(ctx context.Context, ids uuid.UUIDs) (uuid.UUIDs, error)
+	// DeleteFixtures permanently deletes the cloudinary assets with the IDs in any status, it is only used
+	// to wipe the seeded fixtures.
+	DeleteFixtures(ctx context.Context, ids uuid.UUIDs) (int64, error)
+	// SaveOwnershipSnapshot stores the ownership snapshot of the cloudinary asset in any status. A nil
+	// snapshot clears the stored one.
+	SaveOwnershipSnapshot(ctx context.Context, id uuid.UUID, snapshot *cldassetmodel.OwnershipSnapshot) error
+	// ListDuplicates retrieves up to limit groups of active cloudinary assets sharing the hash of the kind,
+	// largest groups first.
+	ListDuplicates(ctx context.Context, kind cldassetmodel.DuplicateKind, limit int) ([]*cldassetmodel.DuplicateGroup, error)
+	// SavePublishing stores the publication schedule and the publishing state of the cloudinary asset.
+	SavePublishing(ctx context.Context, id uuid.UUID, schedule *publishing.Schedule) (int64, error)
+	// ListDuePublishing retrieves up to limit cloudinary assets whose publishing state is due to change at now.
+	ListDuePublishing(ctx context.Context, now time.Time, limit int) ([]*cldassetmodel.Asset, error)
+	// TransitionPublishing changes the publishing state of the cloudinary asset unless it was changed concurrently.
+	TransitionPublishing(ctx context.Context, id uuid.UUID, from, to publishing.State) (int64, error)
+	// GetByEtag retrieves the oldest active cloudinary asset with the etag, other than the excluded one.
+	GetByEtag(ctx context.Context, etag string, excludeID uuid.UUID) (*cldassetmodel.Asset, error)
 }
 
 type Repository struct {
 	db *gorm.DB
+	// read serves Get, List and Count queries. It is the primary unless a read replica is configured.
+	read *gorm.DB
 }
 
 var _ GormRepository = (*Repository)(nil)
 
 func New(db *gorm.DB) *Repository {
-	return &Repository{db: db}
+	return &Repository{db: db, read: db}
+}
+
+// NewWithReplica creates a repository that sends Get, List and Count queries to replica and keeps
+// writes on primary. A nil replica falls back to primary.
+func NewWithReplica(primary, replica *gorm.DB) *Repository {
+	if replica == nil {
+		replica = primary
+	}
+	return &Repository{db: primary, read: replica}
 }
 
 func (r *Repository) DB() *gorm.DB {
@@ -38,7 +111,8 @@ func (r *Repository) DB() *gorm.DB {
 }
 
 func (r *Repository) WithTx(tx *gorm.DB) *Repository {
-	return &Repository{db: tx}
+	// Reads inside a transaction must see its own writes, so they stay on the primary.
+	return &Repository{db: tx, read: tx}
 }
 
 type Scope uint
@@ -58,6 +132,13 @@ type Filter struct {
 
 	ResourceTypes []string
 	Formats       []string
+	// Tags matches assets having all the tags.
+	Tags []string
+
+	// ListingFilter filters on the listing read model.
+	ListingFilter
+	// WithListing loads the listing read model of the assets into [cldassetmodel.Asset.Listing].
+	WithListing bool
 
 	Fields   []string
 	Statuses []cldassetmodel.Status
@@ -83,6 +164,13 @@ type ListOptions struct {
 
 	ResourceTypes []string
 	Formats       []string
+	// Tags matches assets having all the tags.
+	Tags []string
+
+	// ListingFilter filters on the listing read model.
+	ListingFilter
+	// WithListing loads the listing read model of the assets into [cldassetmodel.Asset.Listing].
+	WithListing bool
 
 	Fields   []string
 	Statuses []cldassetmodel.Status
@@ -169,3 +257,32 @@ func (r *Repository) Delete(ctx context.Context, opts StateOperationOptions) (in
 func (r *Repository) MarkAsBroken(ctx context.Context, opts StateOperationOptions, auditOpts *types.AuditTrailOptions) (int64, error) {
 	return r.markAsBroken(ctx, populateFromStateOperationOptions(&opts), auditOpts)
 }
+
+// ListExpired retrieves archived cloudinary assets that were soft-deleted before the provided cutoff,
+// ordered by deletion time. Assets with a failed purge attempt recorded after failedAfter are skipped,
+// a zero failedAfter skips none. At most limit records are returned.
+func (r *Repository) ListExpired(ctx context.Context, cutoff, failedAfter time.Time, limit int) ([]*cldassetmodel.Asset, error) {
+	return r.listExpired(ctx, cutoff, failedAfter, limit)
+}
+
+// CountByStatus returns the number of cloudinary assets with the provided status, including soft-deleted ones.
+func (r *Repository) CountByStatus(ctx context.Context, status cldassetmodel.Status) (int64, error) {
+	return r.countByStatus(ctx, status)
+}
+
+// LockOwner serializes the associations of the owner with cloudinary assets until the end of the transaction.
+func (r *Repository) LockOwner(ctx context.Context, ownerType, ownerID string) error {
+	return r.db.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "cloudinary_asset_owners:"+ownerType+":"+ownerID).Error
+}
+
+// Count returns the number of cloudinary assets matching the provided options and scopes.
+// Pagination and ordering options are ignored.
+func (r *Repository) Count(ctx context.Context, opts ListOptions, scopes ...Scope) (int64, error) {
+	return r.count(ctx, populateFromListOptions(&opts, scopes))
+}
+
+// Total returns the total of the cloudinary assets matching the provided options and scopes, computed
+// with the strategy of the counter. Pagination and ordering options are ignored.
+func (r *Repository) Total(ctx context.Context, counter *pagination.Counter, opts ListOptions, scopes ...Scope) (pagination.Total, error) {
+	return r.total(ctx, populateFromListOptions(&opts, scopes), counter)
+}
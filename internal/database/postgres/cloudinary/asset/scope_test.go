@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunRepository returns a repository which builds the queries without running them. The statements
+// of the queries are appended to the returned slice.
+func dryRunRepository(t *testing.T) (*Repository, *[]*gorm.Statement) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=media_service"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var statements []*gorm.Statement
+	capture := func(tx *gorm.DB) { statements = append(statements, tx.Statement) }
+	if err := db.Callback().Query().After("gorm:query").Register("test:capture", capture); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Callback().Row().After("gorm:row").Register("test:capture", capture); err != nil {
+		t.Fatal(err)
+	}
+	return New(db), &statements
+}
+
+// whereClause returns the WHERE clause of the statement without the ordering and the limit.
+func whereClause(stmt *gorm.Statement) string {
+	sql := stmt.SQL.String()
+	_, where, _ := strings.Cut(sql, " WHERE ")
+	where, _, _ = strings.Cut(where, " ORDER BY ")
+	return where
+}
+
+// boundStatuses returns the statuses the statement filters on.
+func boundStatuses(stmt *gorm.Statement) []cldassetmodel.Status {
+	var statuses []cldassetmodel.Status
+	for _, v := range stmt.Vars {
+		if status, ok := v.(cldassetmodel.Status); ok {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// returns reports whether the query of the statement returns an asset with the status. Archived and
+// pending deletion assets are soft-deleted, so they are only returned by unscoped queries.
+func returns(stmt *gorm.Statement, status cldassetmodel.Status, deleted bool) bool {
+	if !slices.Contains(boundStatuses(stmt), status) {
+		return false
+	}
+	return !deleted || !strings.Contains(whereClause(stmt), `"deleted_at" IS NULL`)
+}
+
+// scopeFixtures are assets in every status as the service stores them.
+var scopeFixtures = []struct {
+	status  cldassetmodel.Status
+	deleted bool
+}{
+	{status: cldassetmodel.StatusUploadURLGenerated},
+	{status: cldassetmodel.StatusActive},
+	{status: cldassetmodel.StatusBroken},
+	{status: cldassetmodel.StatusArchived, deleted: true},
+	{status: cldassetmodel.StatusPendingDelete, deleted: true},
+}
+
+func TestScopes(t *testing.T) {
+	var (
+		uploadURLGenerated = cldassetmodel.StatusUploadURLGenerated
+		active             = cldassetmodel.StatusActive
+		archived           = cldassetmodel.StatusArchived
+		broken             = cldassetmodel.StatusBroken
+	)
+	tests := []struct {
+		name   string
+		scopes []Scope
+		want   []cldassetmodel.Status
+	}{
+		{name: "no scopes", want: []cldassetmodel.Status{active}},
+		{name: "all", scopes: []Scope{ScopeAll}, want: []cldassetmodel.Status{uploadURLGenerated, active, archived, broken}},
+		{name: "active", scopes: []Scope{ScopeActive}, want: []cldassetmodel.Status{active}},
+		{name: "upload url generated", scopes: []Scope{ScopeUploadURLGenerated}, want: []cldassetmodel.Status{uploadURLGenerated}},
+		{name: "archived", scopes: []Scope{ScopeArchived}, want: []cldassetmodel.Status{archived}},
+		{name: "broken", scopes: []Scope{ScopeBroken}, want: []cldassetmodel.Status{broken}},
+		{name: "active and upload url generated", scopes: []Scope{ScopeActive, ScopeUploadURLGenerated}, want: []cldassetmodel.Status{uploadURLGenerated, active}},
+		{name: "active and archived", scopes: []Scope{ScopeActive, ScopeArchived}, want: []cldassetmodel.Status{active, archived}},
+		{name: "archived and broken", scopes: []Scope{ScopeArchived, ScopeBroken}, want: []cldassetmodel.Status{archived, broken}},
+		{name: "all but archived", scopes: []Scope{ScopeActive, ScopeUploadURLGenerated, ScopeBroken}, want: []cldassetmodel.Status{uploadURLGenerated, active, broken}},
+		{name: "every scope", scopes: []Scope{ScopeActive, ScopeUploadURLGenerated, ScopeArchived, ScopeBroken}, want: []cldassetmodel.Status{uploadURLGenerated, active, archived, broken}},
+		{name: "all and archived", scopes: []Scope{ScopeAll, ScopeArchived}, want: []cldassetmodel.Status{uploadURLGenerated, active, archived, broken}},
+		{name: "repeated scope", scopes: []Scope{ScopeBroken, ScopeBroken}, want: []cldassetmodel.Status{broken}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, statements := dryRunRepository(t)
+			ctx := context.Background()
+			if _, _, err := repo.List(ctx, ListOptions{OrderField: cldassetmodel.OrderCreatedAt, PageSize: 10}, tt.scopes...); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := repo.Count(ctx, ListOptions{}, tt.scopes...); err != nil {
+				t.Fatal(err)
+			}
+			if len(*statements) != 2 {
+				t.Fatalf("built %d queries, want 2", len(*statements))
+			}
+			list, count := (*statements)[0], (*statements)[1]
+			if whereClause(list) != whereClause(count) {
+				t.Errorf("list filters %q, count filters %q", whereClause(list), whereClause(count))
+			}
+			if !slices.Equal(boundStatuses(list), boundStatuses(count)) {
+				t.Errorf("list binds %v, count binds %v", boundStatuses(list), boundStatuses(count))
+			}
+
+			for _, fixture := range scopeFixtures {
+				want := slices.Contains(tt.want, fixture.status)
+				if got := returns(list, fixture.status, fixture.deleted); got != want {
+					t.Errorf("%s asset returned = %v, want %v (query: %s %v)", fixture.status, got, want, list.SQL.String(), list.Vars)
+				}
+				if got := ScopesInclude(tt.scopes, fixture.status); got != want {
+					t.Errorf("ScopesInclude(%s) = %v, want %v", fixture.status, got, want)
+				}
+			}
+		})
+	}
+}
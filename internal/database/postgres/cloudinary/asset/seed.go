@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+)
+
+// ListExistingIDs retrieves the IDs among ids of the cloudinary assets in any status, including soft-deleted
+// ones. It reads from the primary, so assets created right before are reported.
+func (r *Repository) ListExistingIDs(ctx context.Context, ids uuid.UUIDs) (uuid.UUIDs, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var existing uuid.UUIDs
+	err := r.db.WithContext(ctx).Unscoped().
+		Model(&cldassetmodel.Asset{}).
+		Where("id IN ?", ids).
+		Pluck("id", &existing).Error
+	return existing, err
+}
+
+// DeleteFixtures permanently deletes the cloudinary assets with the IDs in any status. Unlike Delete it does
+// not require the assets to be archived, it must only be used to wipe the seeded fixtures.
+func (r *Repository) DeleteFixtures(ctx context.Context, ids uuid.UUIDs) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	res := r.db.WithContext(ctx).Unscoped().Where("id IN ?", ids).Delete(&cldassetmodel.Asset{})
+	return res.RowsAffected, res.Error
+}
@@ -21,36 +21,56 @@ func cleanFilter(filter *Filter) {
 	filter.CloudinaryAssetIDs = parsing.CleanStrings(filter.CloudinaryAssetIDs)
 }
 
+// ScopesInclude reports whether an asset with the provided status is visible under the scopes. It
+// follows the rules of the repository queries, so no scopes means active assets only.
+func ScopesInclude(scopes []Scope, status cldassetmodel.Status) bool {
+	return slices.Contains(extractScopes(scopes), status)
+}
+
 func extractScopes(scopes []Scope) []cldassetmodel.Status {
-	var statuses []cldassetmodel.Status
-	if len(scopes) > 0 {
-		if slices.Contains(scopes, ScopeAll) {
-			statuses = []cldassetmodel.Status{
-				cldassetmodel.StatusUploadURLGenerated,
-				cldassetmodel.StatusActive,
-				cldassetmodel.StatusArchived,
-				cldassetmodel.StatusBroken,
-			}
-		}
-		statuses = make([]cldassetmodel.Status, 0, len(scopes))
-		if slices.Contains(scopes, ScopeUploadURLGenerated) {
-			statuses = append(statuses, cldassetmodel.StatusUploadURLGenerated)
-		}
-		if slices.Contains(scopes, ScopeActive) {
-			statuses = append(statuses, cldassetmodel.StatusActive)
-		}
-		if slices.Contains(scopes, ScopeArchived) {
-			statuses = append(statuses, cldassetmodel.StatusArchived)
+	if len(scopes) == 0 {
+		return []cldassetmodel.Status{cldassetmodel.StatusActive} // Only active by default
+	}
+	if slices.Contains(scopes, ScopeAll) {
+		return []cldassetmodel.Status{
+			cldassetmodel.StatusUploadURLGenerated,
+			cldassetmodel.StatusActive,
+			cldassetmodel.StatusArchived,
+			cldassetmodel.StatusBroken,
 		}
-		if slices.Contains(scopes, ScopeBroken) {
-			statuses = append(statuses, cldassetmodel.StatusBroken)
-		}
-	} else {
-		statuses = []cldassetmodel.Status{cldassetmodel.StatusActive} // Only active by default
+	}
+	statuses := make([]cldassetmodel.Status, 0, len(scopes))
+	if slices.Contains(scopes, ScopeUploadURLGenerated) {
+		statuses = append(statuses, cldassetmodel.StatusUploadURLGenerated)
+	}
+	if slices.Contains(scopes, ScopeActive) {
+		statuses = append(statuses, cldassetmodel.StatusActive)
+	}
+	if slices.Contains(scopes, ScopeArchived) {
+		statuses = append(statuses, cldassetmodel.StatusArchived)
+	}
+	if slices.Contains(scopes, ScopeBroken) {
+		statuses = append(statuses, cldassetmodel.StatusBroken)
 	}
 	return statuses
 }
 
+// pageFields returns the selected fields of a page together with the columns the cursor of the
+// next page is built from.
+func pageFields(filter *Filter) []string {
+	orderField := filter.OrderField
+	if orderField == "" {
+		orderField = cldassetmodel.OrderCreatedAt
+	}
+	fields := slices.Clone(filter.Fields)
+	for _, required := range []string{"id", string(orderField)} {
+		if !slices.Contains(fields, required) {
+			fields = append(fields, required)
+		}
+	}
+	return fields
+}
+
 func getCursorValue(asset *cldassetmodel.Asset, orderBy cldassetmodel.OrderField) any {
 	switch orderBy {
 	case cldassetmodel.OrderResourceType:
@@ -76,6 +96,15 @@ func applyStatusFilter(db *gorm.DB, statuses []cldassetmodel.Status) *gorm.DB {
 	return db.Model(&cldassetmodel.Asset{}).Where("status IN ?", statuses)
 }
 
+// applyFilter applies every condition of the filter: the statuses derived from the scopes, the
+// identifying and the specific filters. Queries that must agree with each other, such as a listing
+// and its count, go through it so that they cannot drift apart.
+func applyFilter(db *gorm.DB, filter *Filter) *gorm.DB {
+	db = applyStatusFilter(db, filter.Statuses)
+	db = applyIdentifyingFilters(db, filter)
+	return applySpecificFilters(db, filter)
+}
+
 func applyIdentifyingFilters(db *gorm.DB, filter *Filter) *gorm.DB {
 	if len(filter.IDs) > 0 {
 		db = db.Where("id IN ?", filter.IDs)
@@ -96,6 +125,15 @@ func applySpecificFilters(db *gorm.DB, filter *Filter) *gorm.DB {
 	if len(filter.Formats) > 0 {
 		db = db.Where("format IN ?", filter.Formats)
 	}
+	return applyTagFilters(db, filter.Tags)
+}
+
+// applyTagFilters matches assets having all the tags. Each tag is a separate containment check, so
+// the GIN index on the tags column can be used.
+func applyTagFilters(db *gorm.DB, tags []string) *gorm.DB {
+	for _, tag := range tags {
+		db = db.Where("tags @> ARRAY[?]::varchar[]", tag)
+	}
 	return db
 }
 
@@ -172,6 +210,9 @@ func populateFromListOptions(opts *ListOptions, scopes []Scope) *Filter {
 		CloudinaryPublicIDs: opts.CloudinaryPublicIDs,
 		ResourceTypes:       opts.ResourceTypes,
 		Formats:             opts.Formats,
+		Tags:                opts.Tags,
+		ListingFilter:       opts.ListingFilter,
+		WithListing:         opts.WithListing,
 		Fields:              opts.Fields,
 		Statuses:            extractScopes(scopes),
 		OrderDir:            opts.OrderDir,
@@ -13,6 +13,7 @@ func (f Filter) Validate() error {
 		validation.Field(&f.CloudinaryPublicIDs, validation.Each(validation.Length(2, 255))),
 		validation.Field(&f.ResourceTypes, validation.Each(validation.Length(2, 100))),
 		validation.Field(&f.Formats, validation.Each(validation.Length(1, 50))),
+		validation.Field(&f.Tags, validation.Each(validation.Length(1, 128))),
 		validation.Field(&f.OrderDir, validation.In(cldassetmodel.OrderAscending, cldassetmodel.OrderDescending)),
 		validation.Field(&f.OrderField, validation.In(
 			cldassetmodel.OrderCreatedAt,
@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+)
+
+// ListStatuses retrieves the IDs and statuses of the cloudinary assets with the IDs in any status, including
+// soft-deleted ones, with a single query. IDs without an asset are omitted.
+func (r *Repository) ListStatuses(ctx context.Context, ids uuid.UUIDs) ([]*cldassetmodel.Asset, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var assets []*cldassetmodel.Asset
+	err := r.read.WithContext(ctx).Unscoped().
+		Select("id", "status").
+		Where("id IN ?", ids).
+		Find(&assets).Error
+	return assets, err
+}
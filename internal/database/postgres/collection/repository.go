@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package collection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/database/postgres/pagination"
+	collectionmodel "github.com/mikhail5545/media-service-go/internal/models/collection"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormRepository interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) *Repository
+	// Get retrieves a single collection by its ID.
+	Get(ctx context.Context, id uuid.UUID) (*collectionmodel.Collection, error)
+	// List retrieves a page of collections, newest first.
+	List(ctx context.Context, pageSize int, pageToken string) ([]*collectionmodel.Collection, string, error)
+	// CountByName counts collections with the name, excluding the collection with excludeID.
+	CountByName(ctx context.Context, name string, excludeID uuid.UUID) (int64, error)
+	// Create persists a new collection.
+	Create(ctx context.Context, collection *collectionmodel.Collection) error
+	// Update updates the collection with the provided values.
+	Update(ctx context.Context, id uuid.UUID, updates map[string]any) (int64, error)
+	// Delete deletes the collection along with its memberships.
+	Delete(ctx context.Context, id uuid.UUID) (int64, error)
+	// AddAssets adds the assets of the provider to the collection. Assets which are already members are skipped.
+	AddAssets(ctx context.Context, id uuid.UUID, provider collectionmodel.Provider, assetIDs uuid.UUIDs) error
+	// RemoveAssets removes the assets of the provider from the collection.
+	RemoveAssets(ctx context.Context, id uuid.UUID, provider collectionmodel.Provider, assetIDs uuid.UUIDs) (int64, error)
+	// RemoveAssetFromAll removes the asset of the provider from every collection.
+	RemoveAssetFromAll(ctx context.Context, provider collectionmodel.Provider, assetID uuid.UUID) error
+	// ListAssetIDs retrieves a page of asset IDs of the provider which belong to the collection.
+	// Asset IDs are ordered ascending, limit rows after afterID are returned.
+	ListAssetIDs(ctx context.Context, id uuid.UUID, provider collectionmodel.Provider, limit int, afterID uuid.UUID) (uuid.UUIDs, error)
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+var _ GormRepository = (*Repository)(nil)
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *Repository) WithTx(tx *gorm.DB) *Repository {
+	return &Repository{db: tx}
+}
+
+// Get retrieves a single collection by its ID.
+func (r *Repository) Get(ctx context.Context, id uuid.UUID) (*collectionmodel.Collection, error) {
+	var collection collectionmodel.Collection
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&collection).Error; err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// List retrieves a page of collections, newest first.
+func (r *Repository) List(ctx context.Context, pageSize int, pageToken string) ([]*collectionmodel.Collection, string, error) {
+	db, err := pagination.ApplyCursor(r.db.WithContext(ctx), pagination.ApplyCursorParams{
+		PageSize:   pageSize,
+		PageToken:  pageToken,
+		OrderField: "created_at",
+		OrderDir:   "DESC",
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to apply pagination: %w", err)
+	}
+
+	var collections []*collectionmodel.Collection
+	if err := db.Find(&collections).Error; err != nil {
+		return nil, "", err
+	}
+
+	var nextToken string
+	if len(collections) == pageSize+1 {
+		last := collections[pageSize-1]
+		nextToken = pagination.EncodePageToken(last.CreatedAt, last.ID)
+		collections = collections[:pageSize]
+	}
+	return collections, nextToken, nil
+}
+
+// CountByName counts collections with the name, excluding the collection with excludeID.
+func (r *Repository) CountByName(ctx context.Context, name string, excludeID uuid.UUID) (int64, error) {
+	var count int64
+	db := r.db.WithContext(ctx).Model(&collectionmodel.Collection{}).Where("name = ?", name)
+	if excludeID != uuid.Nil {
+		db = db.Where("id <> ?", excludeID)
+	}
+	err := db.Count(&count).Error
+	return count, err
+}
+
+// Create persists a new collection.
+func (r *Repository) Create(ctx context.Context, collection *collectionmodel.Collection) error {
+	return r.db.WithContext(ctx).Create(collection).Error
+}
+
+// Update updates the collection with the provided values.
+func (r *Repository) Update(ctx context.Context, id uuid.UUID, updates map[string]any) (int64, error) {
+	res := r.db.WithContext(ctx).Model(&collectionmodel.Collection{}).Where("id = ?", id).Updates(updates)
+	return res.RowsAffected, res.Error
+}
+
+// Delete deletes the collection along with its memberships.
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) (int64, error) {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Delete(&collectionmodel.Collection{})
+	return res.RowsAffected, res.Error
+}
+
+// AddAssets adds the assets of the provider to the collection. Assets which are already members are skipped.
+func (r *Repository) AddAssets(ctx context.Context, id uuid.UUID, provider collectionmodel.Provider, assetIDs uuid.UUIDs) error {
+	if len(assetIDs) == 0 {
+		return nil
+	}
+	members := make([]*collectionmodel.Member, len(assetIDs))
+	for i, assetID := range assetIDs {
+		members[i] = &collectionmodel.Member{
+			CollectionID: id,
+			Provider:     provider,
+			AssetID:      assetID,
+		}
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(members).Error
+}
+
+// RemoveAssets removes the assets of the provider from the collection.
+func (r *Repository) RemoveAssets(ctx context.Context, id uuid.UUID, provider collectionmodel.Provider, assetIDs uuid.UUIDs) (int64, error) {
+	if len(assetIDs) == 0 {
+		return 0, nil
+	}
+	res := r.db.WithContext(ctx).
+		Where("collection_id = ? AND provider = ? AND asset_id IN ?", id, provider, assetIDs).
+		Delete(&collectionmodel.Member{})
+	return res.RowsAffected, res.Error
+}
+
+// RemoveAssetFromAll removes the asset of the provider from every collection.
+func (r *Repository) RemoveAssetFromAll(ctx context.Context, provider collectionmodel.Provider, assetID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("provider = ? AND asset_id = ?", provider, assetID).
+		Delete(&collectionmodel.Member{}).Error
+}
+
+// ListAssetIDs retrieves a page of asset IDs of the provider which belong to the collection.
+// Asset IDs are ordered ascending, limit rows after afterID are returned.
+func (r *Repository) ListAssetIDs(
+	ctx context.Context,
+	id uuid.UUID,
+	provider collectionmodel.Provider,
+	limit int,
+	afterID uuid.UUID,
+) (uuid.UUIDs, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+	db := r.db.WithContext(ctx).Model(&collectionmodel.Member{}).
+		Where("collection_id = ? AND provider = ?", id, provider)
+	if afterID != uuid.Nil {
+		db = db.Where("asset_id > ?", afterID)
+	}
+
+	var ids uuid.UUIDs
+	if err := db.Order("asset_id ASC").Limit(limit).Pluck("asset_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
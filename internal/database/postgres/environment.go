@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"reflect"
+
+	"github.com/mikhail5545/media-service-go/internal/environment"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// environmentField is the model field scoped by the [EnvironmentPlugin].
+const environmentField = "Environment"
+
+// EnvironmentPlugin scopes the statements of models with an Environment field to the environment
+// stored in the statement context: queries, updates and deletes are filtered by it and created
+// rows are stamped with it. Statements without an environment in the context are not scoped,
+// which lets the background workers operate on every environment. Raw SQL is never scoped.
+type EnvironmentPlugin struct{}
+
+var _ gorm.Plugin = EnvironmentPlugin{}
+
+func (EnvironmentPlugin) Name() string {
+	return "media-service:environment"
+}
+
+func (p EnvironmentPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register("environment:create", p.stamp); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("environment:query", p.scope); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("environment:update", p.scope); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("environment:delete", p.scope); err != nil {
+		return err
+	}
+	return cb.Row().Before("gorm:row").Register("environment:row", p.scope)
+}
+
+// field returns the environment field of the statement model and the context environment, if the
+// statement has to be scoped.
+func (EnvironmentPlugin) field(db *gorm.DB) (*schema.Field, string, bool) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return nil, "", false
+	}
+	field := db.Statement.Schema.LookUpField(environmentField)
+	if field == nil {
+		return nil, "", false
+	}
+	env, ok := environment.FromContext(db.Statement.Context)
+	return field, env, ok
+}
+
+func (p EnvironmentPlugin) scope(db *gorm.DB) {
+	field, env, ok := p.field(db)
+	if !ok {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: env},
+	}})
+}
+
+func (p EnvironmentPlugin) stamp(db *gorm.DB) {
+	field, env, ok := p.field(db)
+	if !ok {
+		return
+	}
+	ctx, rv := db.Statement.Context, db.Statement.ReflectValue
+	set := func(v reflect.Value) {
+		if _, zero := field.ValueOf(ctx, v); zero {
+			_ = field.Set(ctx, v, env)
+		}
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			set(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		set(rv)
+	}
+}
@@ -0,0 +1,189 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package asset provides access to the assets of the backends built on the shared asset core.
+// All queries are scoped by the provider, so the adapters never see each other's assets.
+package asset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/database/postgres/pagination"
+	mediamodel "github.com/mikhail5545/media-service-go/internal/models/media"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *Repository) WithTx(tx *gorm.DB) *Repository {
+	return &Repository{db: tx}
+}
+
+func (r *Repository) Create(ctx context.Context, asset *mediamodel.Asset) error {
+	return r.db.WithContext(ctx).Create(asset).Error
+}
+
+// Get retrieves the asset of the provider in one of the statuses, including soft-deleted ones.
+func (r *Repository) Get(ctx context.Context, provider string, id uuid.UUID, statuses ...mediamodel.Status) (*mediamodel.Asset, error) {
+	var asset mediamodel.Asset
+	err := r.scoped(ctx, provider, statuses).Where("id = ?", id).First(&asset).Error
+	if err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// GetByExternalID retrieves the asset of the provider by its backend identifier, including soft-deleted ones.
+func (r *Repository) GetByExternalID(ctx context.Context, provider, externalID string) (*mediamodel.Asset, error) {
+	var asset mediamodel.Asset
+	err := r.scoped(ctx, provider, nil).Where("external_id = ?", externalID).First(&asset).Error
+	if err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// List retrieves a page of assets of the provider in one of the statuses, newest first. The page
+// starts after the afterID cursor, a nil cursor starts from the beginning. The returned cursor is
+// nil when there are no more pages.
+func (r *Repository) List(ctx context.Context, provider string, statuses []mediamodel.Status, limit int, afterID uuid.UUID) ([]*mediamodel.Asset, uuid.UUID, error) {
+	if limit <= 0 {
+		return nil, uuid.Nil, fmt.Errorf("limit must be positive, got %d", limit)
+	}
+	db := r.scoped(ctx, provider, statuses)
+	if afterID != uuid.Nil {
+		db = db.Where("id < ?", afterID)
+	}
+
+	var assets []*mediamodel.Asset
+	if err := db.Order("id DESC").Limit(limit + 1).Find(&assets).Error; err != nil {
+		return nil, uuid.Nil, err
+	}
+	var next uuid.UUID
+	if len(assets) > limit {
+		assets = assets[:limit]
+		next = assets[limit-1].ID
+	}
+	return assets, next, nil
+}
+
+// ListByCreation retrieves a page of assets of the provider in one of the statuses, ordered by the
+// creation time, newest first. The page token has the format of [pagination.EncodePageToken] with
+// the creation time as the cursor value, so it can be shared with the listings of other providers.
+func (r *Repository) ListByCreation(ctx context.Context, provider string, statuses []mediamodel.Status, pageSize int, pageToken string) ([]*mediamodel.Asset, string, error) {
+	if pageSize <= 0 {
+		return nil, "", fmt.Errorf("page size must be positive, got %d", pageSize)
+	}
+	db, err := pagination.ApplyCursor(r.scoped(ctx, provider, statuses), pagination.ApplyCursorParams{
+		PageSize:   pageSize,
+		PageToken:  pageToken,
+		OrderField: "created_at",
+		OrderDir:   "DESC",
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to apply pagination: %w", err)
+	}
+
+	var assets []*mediamodel.Asset
+	if err := db.Find(&assets).Error; err != nil {
+		return nil, "", err
+	}
+	var nextToken string
+	if len(assets) > pageSize {
+		last := assets[pageSize-1]
+		nextToken = pagination.EncodePageToken(last.CreatedAt, last.ID)
+		assets = assets[:pageSize]
+	}
+	return assets, nextToken, nil
+}
+
+// ListByIDs retrieves the assets of the provider with the IDs in one of the statuses.
+func (r *Repository) ListByIDs(ctx context.Context, provider string, ids uuid.UUIDs, statuses ...mediamodel.Status) ([]*mediamodel.Asset, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var assets []*mediamodel.Asset
+	err := r.scoped(ctx, provider, statuses).Where("id IN ?", ids).Order("id DESC").Find(&assets).Error
+	return assets, err
+}
+
+// CountByIDs counts the assets of the provider with the IDs in one of the statuses.
+func (r *Repository) CountByIDs(ctx context.Context, provider string, ids uuid.UUIDs, statuses ...mediamodel.Status) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	var count int64
+	err := r.scoped(ctx, provider, statuses).Where("id IN ?", ids).Count(&count).Error
+	return count, err
+}
+
+// LockOwner serializes the associations of the owner with assets of the provider until the end of the transaction.
+func (r *Repository) LockOwner(ctx context.Context, provider, ownerType, ownerID string) error {
+	return r.db.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "media_asset_owners:"+provider+":"+ownerType+":"+ownerID).Error
+}
+
+// Update performs a partial update of the asset of the provider in one of the statuses.
+func (r *Repository) Update(ctx context.Context, provider string, id uuid.UUID, updates map[string]any, statuses ...mediamodel.Status) (int64, error) {
+	res := r.scoped(ctx, provider, statuses).Where("id = ?", id).Updates(updates)
+	return res.RowsAffected, res.Error
+}
+
+// Delete permanently deletes the asset of the provider. Only assets pending deletion can be deleted.
+func (r *Repository) Delete(ctx context.Context, provider string, id uuid.UUID) (int64, error) {
+	res := r.scoped(ctx, provider, []mediamodel.Status{mediamodel.StatusPendingDelete}).
+		Where("id = ?", id).
+		Delete(&mediamodel.Asset{})
+	return res.RowsAffected, res.Error
+}
+
+// ListPendingDelete retrieves assets of the provider marked as pending deletion before the provided
+// cutoff, ordered by the time of the request. At most limit records are returned.
+func (r *Repository) ListPendingDelete(ctx context.Context, provider string, cutoff time.Time, limit int) ([]*mediamodel.Asset, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+	var assets []*mediamodel.Asset
+	err := r.scoped(ctx, provider, []mediamodel.Status{mediamodel.StatusPendingDelete}).
+		Where("delete_requested_at < ?", cutoff).
+		Order("delete_requested_at ASC, id ASC").
+		Limit(limit).
+		Find(&assets).Error
+	return assets, err
+}
+
+// scoped starts a query on the assets of the provider in one of the statuses, all statuses match if none are provided.
+// Soft-deleted records are included, the status tells archived assets apart.
+func (r *Repository) scoped(ctx context.Context, provider string, statuses []mediamodel.Status) *gorm.DB {
+	db := r.db.WithContext(ctx).Unscoped().Model(&mediamodel.Asset{}).Where("provider = ?", provider)
+	if len(statuses) > 0 {
+		db = db.Where("status IN ?", statuses)
+	}
+	return db
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package migrations applies the versioned PostgreSQL schema migrations embedded in the binary.
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the "pgx" database/sql driver
+)
+
+// MigrationsTable stores the current schema version.
+const MigrationsTable = "schema_migrations"
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// ErrSchemaBehind is returned by [Migrator.Check] when the database schema is older than the
+// latest migration embedded in the binary.
+var ErrSchemaBehind = errors.New("database schema is behind")
+
+// ErrSchemaDirty is returned by [Migrator.Check] when a previous migration failed midway and the
+// schema has to be fixed manually and forced to a version.
+var ErrSchemaDirty = errors.New("database schema is dirty")
+
+// Status describes the schema version of the database compared to the embedded migrations.
+type Status struct {
+	// Current is the applied schema version, 0 if no migration was applied.
+	Current uint
+	// Latest is the version of the newest embedded migration.
+	Latest uint
+	Dirty  bool
+}
+
+// Migrator applies the embedded migrations over its own connection pool.
+type Migrator struct {
+	m      *migrate.Migrate
+	latest uint
+}
+
+// New opens a dedicated connection to the database identified by dsn and prepares the migrations.
+func New(dsn string) (*Migrator, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migrations connection: %w", err)
+	}
+	driver, err := pgx.WithInstance(db, &pgx.Config{MigrationsTable: MigrationsTable})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create migrations driver: %w", err)
+	}
+	src, err := iofs.New(files, "sql")
+	if err != nil {
+		_ = driver.Close()
+		return nil, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+	latest, err := latestVersion(src)
+	if err != nil {
+		_ = driver.Close()
+		return nil, err
+	}
+	m, err := migrate.NewWithInstance("iofs", src, "pgx5", driver)
+	if err != nil {
+		_ = driver.Close()
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+	return &Migrator{m: m, latest: latest}, nil
+}
+
+func latestVersion(src source.Driver) (uint, error) {
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read first migration: %w", err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read migration after version %d: %w", version, err)
+		}
+		version = next
+	}
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up() error {
+	if err := m.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	return nil
+}
+
+// Down rolls back the given number of migrations.
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("number of steps to roll back must be positive")
+	}
+	if err := m.m.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to roll back migrations: %w", err)
+	}
+	return nil
+}
+
+// Force sets the schema version without running migrations and clears the dirty flag.
+// It is used to recover after a failed migration was fixed manually.
+func (m *Migrator) Force(version int) error {
+	if err := m.m.Force(version); err != nil {
+		return fmt.Errorf("failed to force schema version: %w", err)
+	}
+	return nil
+}
+
+// Status returns the applied schema version and the latest embedded version.
+func (m *Migrator) Status() (Status, error) {
+	version, dirty, err := m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return Status{Latest: m.latest}, nil
+	}
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return Status{Current: version, Latest: m.latest, Dirty: dirty}, nil
+}
+
+// Check returns [ErrSchemaDirty] or [ErrSchemaBehind] if the service must not serve traffic with
+// the current schema. A schema newer than the binary is accepted, so a rollback of the service
+// does not require rolling back the database.
+func (m *Migrator) Check() (Status, error) {
+	status, err := m.Status()
+	if err != nil {
+		return status, err
+	}
+	if status.Dirty {
+		return status, fmt.Errorf("%w at version %d", ErrSchemaDirty, status.Current)
+	}
+	if status.Current < status.Latest {
+		return status, fmt.Errorf("%w: version %d, expected %d", ErrSchemaBehind, status.Current, status.Latest)
+	}
+	return status, nil
+}
+
+// Close releases the migrations connection pool.
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
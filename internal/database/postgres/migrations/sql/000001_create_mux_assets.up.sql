@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS mux_assets (
+    id                         uuid PRIMARY KEY,
+    created_at                 timestamptz,
+    updated_at                 timestamptz,
+    deleted_at                 timestamptz,
+    mux_upload_id              text,
+    mux_asset_id               text,
+    state                      text,
+    upload_status              varchar(50),
+    status                     varchar(50) NOT NULL DEFAULT 'active',
+    duration                   decimal,
+    aspect_ratio               text,
+    asset_created_at           timestamptz,
+    resolution_tier            text,
+    ingest_type                text,
+    primary_signed_playback_id varchar(255),
+    primary_public_playback_id varchar(255),
+    created_by                 uuid,
+    archived_by                uuid,
+    restored_by                uuid,
+    marked_as_broken_by        uuid,
+    created_by_name            varchar(128),
+    archived_by_name           varchar(128),
+    restored_by_name           varchar(128),
+    marked_as_broken_by_name   varchar(128),
+    note                       varchar(512),
+    archive_reason             varchar(512),
+    archive_event_id           varchar(255),
+    mux_error                  jsonb
+);
+
+CREATE INDEX IF NOT EXISTS idx_mux_assets_deleted_at ON mux_assets (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_mux_assets_primary_signed_playback_id ON mux_assets (primary_signed_playback_id);
+CREATE INDEX IF NOT EXISTS idx_mux_assets_primary_public_playback_id ON mux_assets (primary_public_playback_id);
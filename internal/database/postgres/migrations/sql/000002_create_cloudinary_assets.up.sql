@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS cloudinary_assets (
+    id                              uuid PRIMARY KEY,
+    created_at                      timestamptz,
+    updated_at                      timestamptz,
+    deleted_at                      timestamptz,
+    status                          varchar(32) DEFAULT 'active',
+    cloudinary_asset_id             text NOT NULL,
+    url                             text,
+    secure_url                      text,
+    cloudinary_public_id            varchar(512) NOT NULL,
+    resource_type                   varchar(128),
+    format                          varchar(32),
+    width                           bigint,
+    height                          bigint,
+    tags                            varchar(128)[],
+    asset_folder                    text,
+    display_name                    text,
+    note                            varchar(512),
+    archive_reason                  varchar(512),
+    created_by                      uuid,
+    archived_by                     uuid,
+    marked_as_broken_by             uuid,
+    restored_by                     uuid,
+    created_by_name                 varchar(128),
+    archived_by_name                varchar(128),
+    marked_as_broken_by_name        varchar(128),
+    restored_by_name                varchar(128),
+    archive_notification_context_id varchar(256)
+);
+
+CREATE INDEX IF NOT EXISTS idx_cloudinary_assets_deleted_at ON cloudinary_assets (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cloudinary_assets_cloudinary_asset_id ON cloudinary_assets (cloudinary_asset_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cloudinary_assets_url ON cloudinary_assets (url);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cloudinary_assets_secure_url ON cloudinary_assets (secure_url);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cloudinary_assets_cloudinary_public_id ON cloudinary_assets (cloudinary_public_id);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS purge_audit_log (
+    id          uuid PRIMARY KEY,
+    created_at  timestamptz,
+    run_id      uuid NOT NULL,
+    provider    varchar(32) NOT NULL,
+    asset_id    uuid NOT NULL,
+    external_id varchar(512),
+    archived_at timestamptz,
+    dry_run     boolean NOT NULL DEFAULT false,
+    succeeded   boolean NOT NULL DEFAULT false,
+    error       varchar(1024)
+);
+
+CREATE INDEX IF NOT EXISTS idx_purge_audit_log_run_id ON purge_audit_log (run_id);
+CREATE INDEX IF NOT EXISTS idx_purge_audit_log_provider ON purge_audit_log (provider);
+CREATE INDEX IF NOT EXISTS idx_purge_audit_log_asset_id ON purge_audit_log (asset_id);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id              uuid PRIMARY KEY,
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    type            varchar(64) NOT NULL,
+    payload         jsonb NOT NULL,
+    status          varchar(32) NOT NULL DEFAULT 'pending',
+    attempts        bigint NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    last_error      varchar(1024),
+    delivered_at    timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_type ON outbox_events (type);
+CREATE INDEX IF NOT EXISTS idx_outbox_status_next_attempt ON outbox_events (status, next_attempt_at);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_cloudinary_assets_tags;
+DROP INDEX IF EXISTS idx_mux_assets_tags;
+
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS tags;
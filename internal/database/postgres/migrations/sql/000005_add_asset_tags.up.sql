@@ -0,0 +1,4 @@
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS tags varchar(128)[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_mux_assets_tags ON mux_assets USING gin (tags);
+CREATE INDEX IF NOT EXISTS idx_cloudinary_assets_tags ON cloudinary_assets USING gin (tags);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS collection_assets;
+DROP TABLE IF EXISTS collections;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS collections (
+    id          uuid PRIMARY KEY,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    name        varchar(255) NOT NULL,
+    description varchar(1024)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_collections_name ON collections (name);
+CREATE INDEX IF NOT EXISTS idx_collections_created_at ON collections (created_at, id);
+
+CREATE TABLE IF NOT EXISTS collection_assets (
+    collection_id uuid NOT NULL REFERENCES collections (id) ON DELETE CASCADE,
+    provider      varchar(32) NOT NULL,
+    asset_id      uuid NOT NULL,
+    created_at    timestamptz,
+    PRIMARY KEY (collection_id, provider, asset_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_assets_provider_asset ON collection_assets (provider, asset_id);
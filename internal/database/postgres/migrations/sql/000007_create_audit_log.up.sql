@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+    id          uuid PRIMARY KEY,
+    created_at  timestamptz,
+    provider    varchar(32) NOT NULL,
+    asset_id    uuid NOT NULL,
+    action      varchar(64) NOT NULL,
+    admin_id    uuid,
+    admin_name  varchar(128),
+    source      varchar(16) NOT NULL,
+    request_id  varchar(128),
+    note        varchar(512),
+    before      jsonb,
+    after       jsonb
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_asset_id ON audit_log (asset_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_admin_id ON audit_log (admin_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_action ON audit_log (action);
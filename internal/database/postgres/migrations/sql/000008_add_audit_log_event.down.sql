@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_audit_log_asset_id_created_at;
+
+ALTER TABLE audit_log DROP COLUMN IF EXISTS event_id;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS event_type;
@@ -0,0 +1,4 @@
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS event_type varchar(128);
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS event_id varchar(256);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_asset_id_created_at ON audit_log (asset_id, created_at, id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_cloudinary_assets_moderation_status;
+
+ALTER TABLE cloudinary_assets DROP COLUMN IF EXISTS moderation_kind;
+ALTER TABLE cloudinary_assets DROP COLUMN IF EXISTS moderation_status;
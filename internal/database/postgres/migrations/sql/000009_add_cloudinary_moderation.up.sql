@@ -0,0 +1,4 @@
+ALTER TABLE cloudinary_assets ADD COLUMN IF NOT EXISTS moderation_status varchar(16);
+ALTER TABLE cloudinary_assets ADD COLUMN IF NOT EXISTS moderation_kind varchar(64);
+
+CREATE INDEX IF NOT EXISTS idx_cloudinary_assets_moderation_status ON cloudinary_assets (moderation_status);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_mux_assets_primary_drm_playback_id;
+
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS drm_configuration_id;
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS primary_drm_playback_id;
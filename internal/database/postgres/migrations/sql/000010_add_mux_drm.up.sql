@@ -0,0 +1,4 @@
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS primary_drm_playback_id varchar(255);
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS drm_configuration_id varchar(255);
+
+CREATE INDEX IF NOT EXISTS idx_mux_assets_primary_drm_playback_id ON mux_assets (primary_drm_playback_id);
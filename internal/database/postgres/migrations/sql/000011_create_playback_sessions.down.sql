@@ -0,0 +1 @@
+DROP TABLE IF EXISTS playback_sessions;
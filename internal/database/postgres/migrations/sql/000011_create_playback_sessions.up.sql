@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS playback_sessions (
+    id         uuid PRIMARY KEY,
+    created_at timestamptz,
+    asset_id   uuid NOT NULL,
+    user_id    uuid NOT NULL,
+    session_id uuid,
+    user_agent varchar(256),
+    token_hash varchar(64) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    revoked_at timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_playback_sessions_token_hash ON playback_sessions (token_hash);
+CREATE INDEX IF NOT EXISTS idx_playback_sessions_user_id ON playback_sessions (user_id);
+CREATE INDEX IF NOT EXISTS idx_playback_sessions_session_id ON playback_sessions (session_id);
+CREATE INDEX IF NOT EXISTS idx_playback_sessions_expires_at ON playback_sessions (expires_at);
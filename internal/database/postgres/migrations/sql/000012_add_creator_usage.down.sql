@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_cloudinary_assets_created_by;
+DROP INDEX IF EXISTS idx_mux_assets_created_by;
+
+ALTER TABLE cloudinary_assets DROP COLUMN IF EXISTS bytes;
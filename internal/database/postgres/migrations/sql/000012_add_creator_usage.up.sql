@@ -0,0 +1,4 @@
+ALTER TABLE cloudinary_assets ADD COLUMN IF NOT EXISTS bytes bigint;
+
+CREATE INDEX IF NOT EXISTS idx_mux_assets_created_by ON mux_assets (created_by);
+CREATE INDEX IF NOT EXISTS idx_cloudinary_assets_created_by ON cloudinary_assets (created_by);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS sagas (
+    id              uuid PRIMARY KEY,
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    type            varchar(64) NOT NULL,
+    asset_id        uuid NOT NULL,
+    status          varchar(32) NOT NULL,
+    steps           jsonb NOT NULL,
+    admin_id        uuid,
+    admin_name      varchar(256),
+    attempts        integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    last_error      varchar(1024)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sagas_asset_id ON sagas (asset_id);
+CREATE INDEX IF NOT EXISTS idx_sagas_status_next_attempt ON sagas (status, next_attempt_at);
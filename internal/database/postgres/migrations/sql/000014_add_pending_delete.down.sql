@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_cloudinary_assets_pending_delete;
+DROP INDEX IF EXISTS idx_mux_assets_pending_delete;
+
+ALTER TABLE cloudinary_assets DROP COLUMN IF EXISTS delete_requested_at;
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS delete_requested_at;
@@ -0,0 +1,7 @@
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS delete_requested_at timestamptz;
+ALTER TABLE cloudinary_assets ADD COLUMN IF NOT EXISTS delete_requested_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_mux_assets_pending_delete
+    ON mux_assets (delete_requested_at) WHERE status = 'pending_delete';
+CREATE INDEX IF NOT EXISTS idx_cloudinary_assets_pending_delete
+    ON cloudinary_assets (delete_requested_at) WHERE status = 'pending_delete';
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS media_assets (
+    id                  uuid PRIMARY KEY,
+    created_at          timestamptz,
+    updated_at          timestamptz,
+    deleted_at          timestamptz,
+    provider            varchar(32)  NOT NULL,
+    kind                varchar(32)  NOT NULL,
+    status              varchar(32)  NOT NULL DEFAULT 'pending',
+    external_id         varchar(512) NOT NULL,
+    title               varchar(255),
+    content_type        varchar(255),
+    bytes               bigint,
+    checksum            varchar(128),
+    attributes          jsonb,
+    created_by          uuid,
+    archived_by         uuid,
+    restored_by         uuid,
+    created_by_name     varchar(128),
+    archived_by_name    varchar(128),
+    restored_by_name    varchar(128),
+    note                varchar(512),
+    delete_requested_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_assets_provider ON media_assets (provider);
+CREATE INDEX IF NOT EXISTS idx_media_assets_deleted_at ON media_assets (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_media_assets_created_by ON media_assets (created_by);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_media_assets_provider_external_id ON media_assets (provider, external_id);
+CREATE INDEX IF NOT EXISTS idx_media_assets_pending_delete
+    ON media_assets (provider, delete_requested_at) WHERE status = 'pending_delete';
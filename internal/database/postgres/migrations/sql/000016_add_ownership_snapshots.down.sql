@@ -0,0 +1,2 @@
+ALTER TABLE cloudinary_assets DROP COLUMN IF EXISTS ownership_snapshot;
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS ownership_snapshot;
@@ -0,0 +1,2 @@
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS ownership_snapshot jsonb;
+ALTER TABLE cloudinary_assets ADD COLUMN IF NOT EXISTS ownership_snapshot jsonb;
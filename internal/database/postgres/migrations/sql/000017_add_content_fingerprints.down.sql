@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_mux_assets_fingerprint;
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS fingerprint;
+DROP INDEX IF EXISTS idx_cloudinary_assets_phash;
+DROP INDEX IF EXISTS idx_cloudinary_assets_etag;
+ALTER TABLE cloudinary_assets DROP COLUMN IF EXISTS phash;
+ALTER TABLE cloudinary_assets DROP COLUMN IF EXISTS etag;
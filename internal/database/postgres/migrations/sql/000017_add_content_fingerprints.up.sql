@@ -0,0 +1,6 @@
+ALTER TABLE cloudinary_assets ADD COLUMN IF NOT EXISTS etag varchar(64);
+ALTER TABLE cloudinary_assets ADD COLUMN IF NOT EXISTS phash varchar(64);
+CREATE INDEX IF NOT EXISTS idx_cloudinary_assets_etag ON cloudinary_assets (etag);
+CREATE INDEX IF NOT EXISTS idx_cloudinary_assets_phash ON cloudinary_assets (phash);
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS fingerprint varchar(64);
+CREATE INDEX IF NOT EXISTS idx_mux_assets_fingerprint ON mux_assets (fingerprint);
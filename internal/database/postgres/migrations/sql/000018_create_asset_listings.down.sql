@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS cloudinary_asset_listings;
+DROP TABLE IF EXISTS mux_asset_listings;
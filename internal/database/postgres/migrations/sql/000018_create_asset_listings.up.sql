@@ -0,0 +1,25 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE TABLE IF NOT EXISTS mux_asset_listings (
+    asset_id    uuid PRIMARY KEY REFERENCES mux_assets (id) ON DELETE CASCADE,
+    title       varchar(256) NOT NULL,
+    creator_id  varchar(64) NOT NULL,
+    owner_count integer NOT NULL,
+    synced_at   timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_mux_asset_listings_title ON mux_asset_listings USING gin (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_mux_asset_listings_creator_id ON mux_asset_listings (creator_id);
+CREATE INDEX IF NOT EXISTS idx_mux_asset_listings_owner_count ON mux_asset_listings (owner_count);
+
+CREATE TABLE IF NOT EXISTS cloudinary_asset_listings (
+    asset_id    uuid PRIMARY KEY REFERENCES cloudinary_assets (id) ON DELETE CASCADE,
+    title       varchar(256) NOT NULL,
+    creator_id  varchar(64) NOT NULL,
+    owner_count integer NOT NULL,
+    synced_at   timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_cloudinary_asset_listings_title ON cloudinary_asset_listings USING gin (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_cloudinary_asset_listings_creator_id ON cloudinary_asset_listings (creator_id);
+CREATE INDEX IF NOT EXISTS idx_cloudinary_asset_listings_owner_count ON cloudinary_asset_listings (owner_count);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id              uuid PRIMARY KEY,
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    provider        varchar(32) NOT NULL,
+    type            varchar(128) NOT NULL,
+    payload         jsonb NOT NULL,
+    status          varchar(32) NOT NULL DEFAULT 'pending',
+    attempts        bigint NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    last_error      varchar(1024),
+    processed_at    timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_status_next_attempt ON webhook_events (status, next_attempt_at);
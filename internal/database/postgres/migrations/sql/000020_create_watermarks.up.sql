@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS watermarks (
+    id                   uuid PRIMARY KEY,
+    created_at           timestamptz,
+    updated_at           timestamptz,
+    scope                varchar(32) NOT NULL,
+    key                  varchar(128) NOT NULL,
+    cloudinary_public_id varchar(512),
+    image_url            varchar(2048),
+    position             varchar(16) NOT NULL,
+    margin               integer NOT NULL DEFAULT 0,
+    width                integer NOT NULL DEFAULT 0,
+    opacity              integer NOT NULL DEFAULT 100
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_watermarks_scope_key ON watermarks (scope, key);
@@ -0,0 +1,3 @@
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS poster_image_url;
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS poster_image_id;
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS poster_time;
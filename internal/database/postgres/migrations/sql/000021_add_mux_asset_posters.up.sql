@@ -0,0 +1,3 @@
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS poster_time double precision;
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS poster_image_id uuid;
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS poster_image_url varchar(2048);
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id          uuid PRIMARY KEY,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    url         varchar(2048) NOT NULL,
+    secret      varchar(256) NOT NULL,
+    event_types jsonb NOT NULL DEFAULT '[]',
+    providers   jsonb NOT NULL DEFAULT '[]',
+    description varchar(1024),
+    active      boolean NOT NULL DEFAULT true
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              uuid PRIMARY KEY,
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    subscription_id uuid NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id        uuid NOT NULL,
+    event_type      varchar(64) NOT NULL,
+    payload         jsonb NOT NULL,
+    status          varchar(32) NOT NULL DEFAULT 'pending',
+    attempts        bigint NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    response_status integer,
+    last_error      varchar(1024),
+    delivered_at    timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status_next_attempt ON webhook_deliveries (status, next_attempt_at);
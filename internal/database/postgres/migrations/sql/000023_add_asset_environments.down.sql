@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_cloudinary_assets_environment;
+ALTER TABLE cloudinary_assets DROP COLUMN IF EXISTS environment;
+
+DROP INDEX IF EXISTS idx_mux_assets_environment;
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS environment;
@@ -0,0 +1,5 @@
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS environment varchar(64) NOT NULL DEFAULT 'default';
+CREATE INDEX IF NOT EXISTS idx_mux_assets_environment ON mux_assets (environment);
+
+ALTER TABLE cloudinary_assets ADD COLUMN IF NOT EXISTS environment varchar(64) NOT NULL DEFAULT 'default';
+CREATE INDEX IF NOT EXISTS idx_cloudinary_assets_environment ON cloudinary_assets (environment);
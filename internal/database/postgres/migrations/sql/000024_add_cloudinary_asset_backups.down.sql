@@ -0,0 +1,2 @@
+ALTER TABLE cloudinary_assets DROP COLUMN IF EXISTS backup_available;
+ALTER TABLE cloudinary_assets DROP COLUMN IF EXISTS cloudinary_destroyed_at;
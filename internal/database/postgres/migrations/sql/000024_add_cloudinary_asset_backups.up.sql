@@ -0,0 +1,2 @@
+ALTER TABLE cloudinary_assets ADD COLUMN IF NOT EXISTS cloudinary_destroyed_at timestamptz;
+ALTER TABLE cloudinary_assets ADD COLUMN IF NOT EXISTS backup_available boolean;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS asset_versions (
+    id             uuid PRIMARY KEY,
+    created_at     timestamptz,
+    provider       varchar(32) NOT NULL,
+    owner_type     varchar(50) NOT NULL,
+    owner_id       uuid NOT NULL,
+    number         integer NOT NULL,
+    kind           varchar(16) NOT NULL,
+    asset_id       uuid NOT NULL,
+    predecessor_id uuid,
+    successor_id   uuid,
+    superseded_at  timestamptz,
+    admin_id       uuid,
+    admin_name     varchar(128)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_asset_versions_owner_number ON asset_versions (provider, owner_type, owner_id, number);
+CREATE INDEX IF NOT EXISTS idx_asset_versions_asset_id ON asset_versions (asset_id);
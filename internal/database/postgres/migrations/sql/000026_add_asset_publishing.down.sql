@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_cloudinary_assets_publishing_state;
+ALTER TABLE cloudinary_assets DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE cloudinary_assets DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE cloudinary_assets DROP COLUMN IF EXISTS publishing_state;
+
+DROP INDEX IF EXISTS idx_mux_assets_publishing_state;
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS publishing_state;
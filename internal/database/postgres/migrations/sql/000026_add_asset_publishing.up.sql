@@ -0,0 +1,9 @@
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS publishing_state varchar(16) NOT NULL DEFAULT 'published';
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS publish_at timestamptz;
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS unpublish_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_mux_assets_publishing_state ON mux_assets (publishing_state);
+
+ALTER TABLE cloudinary_assets ADD COLUMN IF NOT EXISTS publishing_state varchar(16) NOT NULL DEFAULT 'published';
+ALTER TABLE cloudinary_assets ADD COLUMN IF NOT EXISTS publish_at timestamptz;
+ALTER TABLE cloudinary_assets ADD COLUMN IF NOT EXISTS unpublish_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_cloudinary_assets_publishing_state ON cloudinary_assets (publishing_state);
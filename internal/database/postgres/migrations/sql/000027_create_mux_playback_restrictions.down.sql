@@ -0,0 +1 @@
+DROP TABLE IF EXISTS mux_playback_restrictions;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS mux_playback_restrictions (
+    environment       varchar(64) NOT NULL,
+    policy            varchar(128) NOT NULL,
+    created_at        timestamptz,
+    updated_at        timestamptz,
+    restriction_id    varchar(255) NOT NULL,
+    allowed_domains   jsonb NOT NULL,
+    allow_no_referrer boolean NOT NULL DEFAULT false,
+    PRIMARY KEY (environment, policy)
+);
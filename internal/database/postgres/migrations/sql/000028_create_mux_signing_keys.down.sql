@@ -0,0 +1 @@
+DROP TABLE IF EXISTS mux_signing_keys;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS mux_signing_keys (
+    id          uuid PRIMARY KEY,
+    environment varchar(64) NOT NULL,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    key_id      varchar(255) NOT NULL,
+    private_key bytea,
+    status      varchar(16) NOT NULL,
+    retiring_at timestamptz,
+    revoked_at  timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mux_signing_keys_key_id ON mux_signing_keys (key_id);
+CREATE INDEX IF NOT EXISTS idx_mux_signing_keys_environment_status ON mux_signing_keys (environment, status);
@@ -0,0 +1 @@
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS encoding;
@@ -0,0 +1 @@
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS encoding jsonb;
@@ -0,0 +1,11 @@
+ALTER TABLE webhook_events DROP COLUMN IF EXISTS environment;
+
+DROP INDEX IF EXISTS idx_cloudinary_assets_environment_cloudinary_public_id;
+DROP INDEX IF EXISTS idx_cloudinary_assets_environment_secure_url;
+DROP INDEX IF EXISTS idx_cloudinary_assets_environment_url;
+DROP INDEX IF EXISTS idx_cloudinary_assets_environment_cloudinary_asset_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cloudinary_assets_cloudinary_asset_id ON cloudinary_assets (cloudinary_asset_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cloudinary_assets_url ON cloudinary_assets (url);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cloudinary_assets_secure_url ON cloudinary_assets (secure_url);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cloudinary_assets_cloudinary_public_id ON cloudinary_assets (cloudinary_public_id);
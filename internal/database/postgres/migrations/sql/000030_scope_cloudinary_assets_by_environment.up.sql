@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_cloudinary_assets_cloudinary_asset_id;
+DROP INDEX IF EXISTS idx_cloudinary_assets_url;
+DROP INDEX IF EXISTS idx_cloudinary_assets_secure_url;
+DROP INDEX IF EXISTS idx_cloudinary_assets_cloudinary_public_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cloudinary_assets_environment_cloudinary_asset_id ON cloudinary_assets (environment, cloudinary_asset_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cloudinary_assets_environment_url ON cloudinary_assets (environment, url);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cloudinary_assets_environment_secure_url ON cloudinary_assets (environment, secure_url);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cloudinary_assets_environment_cloudinary_public_id ON cloudinary_assets (environment, cloudinary_public_id);
+
+ALTER TABLE webhook_events ADD COLUMN IF NOT EXISTS environment varchar(64);
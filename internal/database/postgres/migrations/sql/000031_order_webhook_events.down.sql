@@ -0,0 +1,4 @@
+ALTER TABLE mux_assets DROP COLUMN IF EXISTS last_webhook_at;
+
+DROP INDEX IF EXISTS idx_webhook_events_pending_object;
+ALTER TABLE webhook_events DROP COLUMN IF EXISTS object_id;
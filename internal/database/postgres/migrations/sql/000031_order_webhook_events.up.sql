@@ -0,0 +1,4 @@
+ALTER TABLE webhook_events ADD COLUMN IF NOT EXISTS object_id varchar(255);
+CREATE INDEX IF NOT EXISTS idx_webhook_events_pending_object ON webhook_events (provider, object_id, id) WHERE status = 'pending';
+
+ALTER TABLE mux_assets ADD COLUMN IF NOT EXISTS last_webhook_at timestamptz;
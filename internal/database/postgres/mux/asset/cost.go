@@ -0,0 +1,23 @@
+package asset
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	costmodel "github.com/mikhail5545/media-service-go/internal/models/cost"
+	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+)
+
+// ListCostUsage retrieves the usage of up to limit mux assets created before the time with IDs greater
+// than afterID, ordered by ID. Archived assets are listed as well, they are stored until they are purged.
+func (r *Repository) ListCostUsage(ctx context.Context, createdBefore time.Time, afterID uuid.UUID, limit int) ([]*costmodel.AssetUsage, error) {
+	var usages []*costmodel.AssetUsage
+	err := r.read.WithContext(ctx).Unscoped().Model(&muxassetmodel.Asset{}).
+		Select("id, created_by AS creator_id, created_at, deleted_at, mux_asset_id, resolution_tier, COALESCE(duration, 0) AS duration").
+		Where("created_at < ? AND id > ?", createdBefore, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Scan(&usages).Error
+	return usages, err
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+)
+
+// duplicateRow is a group of assets sharing a fingerprint, IDs holds the comma separated asset IDs.
+type duplicateRow struct {
+	Fingerprint string
+	IDs         string
+}
+
+// ListDuplicates retrieves up to limit groups of active mux assets sharing the fingerprint, largest
+// groups first. The asset IDs of a group are ordered by creation time.
+func (r *Repository) ListDuplicates(ctx context.Context, limit int) ([]*muxassetmodel.DuplicateGroup, error) {
+	var rows []*duplicateRow
+	err := r.read.WithContext(ctx).Model(&muxassetmodel.Asset{}).
+		Select("fingerprint, string_agg(id::text, ',' ORDER BY created_at) AS ids").
+		Where("fingerprint IS NOT NULL AND status = ?", muxassetmodel.StatusActive).
+		Group("fingerprint").
+		Having("COUNT(*) > 1").
+		Order("COUNT(*) DESC, fingerprint").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*muxassetmodel.DuplicateGroup, 0, len(rows))
+	for _, row := range rows {
+		ids, err := parseIDs(row.IDs)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, &muxassetmodel.DuplicateGroup{Fingerprint: row.Fingerprint, AssetIDs: ids})
+	}
+	return groups, nil
+}
+
+func parseIDs(joined string) (uuid.UUIDs, error) {
+	parts := strings.Split(joined, ",")
+	ids := make(uuid.UUIDs, 0, len(parts))
+	for _, part := range parts {
+		id, err := uuid.Parse(part)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse asset ID %q: %w", part, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
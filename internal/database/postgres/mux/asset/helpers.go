@@ -3,6 +3,7 @@ package asset
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/mikhail5545/media-service-go/internal/database/postgres/pagination"
 	"github.com/mikhail5545/media-service-go/internal/database/types"
@@ -25,16 +26,13 @@ func (r *Repository) get(ctx context.Context, filter *Filter) (*muxassetmodel.As
 
 	var asset muxassetmodel.Asset
 
-	db := r.db.WithContext(ctx)
-	db = applyStatusFilters(db, filter.Statuses)
+	db := r.read.WithContext(ctx)
+	db = applyFilter(db, filter)
 
 	if len(filter.Fields) > 0 {
 		db = db.Select(filter.Fields)
 	}
 
-	db = applyIdentifyingFilters(db, filter)
-	db = applySpecificFilters(db, filter)
-
 	err := db.First(&asset).Error
 	return &asset, err
 }
@@ -49,16 +47,14 @@ func (r *Repository) list(ctx context.Context, filter *Filter) ([]*muxassetmodel
 	}
 
 	var assets []*muxassetmodel.Asset
-	db := r.db.WithContext(ctx)
-	db = applyStatusFilters(db, filter.Statuses)
+	db := r.read.WithContext(ctx)
+	db = applyFilter(db, filter)
+	db = applyListing(db, filter.ListingFilter, filter.WithListing)
 
 	if len(filter.Fields) > 0 {
-		db = db.Select(filter.Fields)
+		db = db.Select(pageFields(filter))
 	}
 
-	db = applyIdentifyingFilters(db, filter)
-	db = applySpecificFilters(db, filter)
-
 	db, err := pagination.ApplyCursor(db, pagination.ApplyCursorParams{
 		PageSize:   filter.PageSize,
 		PageToken:  filter.PageToken,
@@ -94,21 +90,37 @@ func (r *Repository) listAll(ctx context.Context, filter *Filter) ([]*muxassetmo
 	}
 
 	var assets []*muxassetmodel.Asset
-	db := r.db.WithContext(ctx)
-	db = applyStatusFilters(db, filter.Statuses)
+	db := r.read.WithContext(ctx)
+	db = applyFilter(db, filter)
 
 	if len(filter.Fields) > 0 {
 		db = db.Select(filter.Fields)
 	}
-
-	db = applyIdentifyingFilters(db, filter)
-	db = applySpecificFilters(db, filter)
 	db = applyOrdering(db, filter)
 
 	err := db.Find(&assets).Error
 	return assets, err
 }
 
+func (r *Repository) count(ctx context.Context, filter *Filter) (int64, error) {
+	total, err := r.total(ctx, filter, nil)
+	return total.Count, err
+}
+
+func (r *Repository) total(ctx context.Context, filter *Filter, counter *pagination.Counter) (pagination.Total, error) {
+	cleanFilter(filter)
+	if filter == nil {
+		return pagination.Total{}, nil
+	}
+	if err := filter.Validate(); err != nil {
+		return pagination.Total{}, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	db := applyFilter(r.read.WithContext(ctx).Model(&muxassetmodel.Asset{}), filter)
+	db = applyListing(db, filter.ListingFilter, false)
+	return counter.Count(db)
+}
+
 func (r *Repository) update(ctx context.Context, filter *Filter, updates map[string]any) (int64, error) {
 	cleanFilter(filter)
 	if filter == nil {
@@ -223,7 +235,8 @@ func (r *Repository) delete(ctx context.Context, filter *Filter) (int64, error)
 	db = applyIdentifyingFilters(db, filter)
 	db = applySpecificFilters(db, filter)
 
-	db = db.Where("deleted_at IS NOT NULL AND status = ?", assetmodel.StatusArchived) // only delete soft-deleted records
+	// only delete soft-deleted records, either archived or pending deletion
+	db = db.Where("deleted_at IS NOT NULL AND status IN ?", []assetmodel.Status{assetmodel.StatusArchived, assetmodel.StatusPendingDelete})
 
 	res := db.Delete(&muxassetmodel.Asset{})
 	return res.RowsAffected, res.Error
@@ -251,3 +264,32 @@ func (r *Repository) markAsBroken(ctx context.Context, filter *Filter, auditOpts
 	res := db.Updates(updates)
 	return res.RowsAffected, res.Error
 }
+
+func (r *Repository) listExpired(ctx context.Context, cutoff, failedAfter time.Time, limit int) ([]*muxassetmodel.Asset, error) {
+	if cutoff.IsZero() {
+		return nil, fmt.Errorf("cutoff must be provided")
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	db := r.db.WithContext(ctx).Unscoped().Model(&muxassetmodel.Asset{})
+	db = db.Where("deleted_at IS NOT NULL AND deleted_at < ? AND status = ?", cutoff, muxassetmodel.StatusArchived)
+	if !failedAfter.IsZero() {
+		db = db.Where(`NOT EXISTS (
+			SELECT 1 FROM purge_audit_log p
+			WHERE p.asset_id = mux_assets.id AND NOT p.dry_run AND NOT p.succeeded AND p.created_at > ?
+		)`, failedAfter)
+	}
+	db = db.Order("deleted_at ASC, id ASC").Limit(limit)
+
+	var assets []*muxassetmodel.Asset
+	err := db.Find(&assets).Error
+	return assets, err
+}
+
+func (r *Repository) countByStatus(ctx context.Context, status muxassetmodel.Status) (int64, error) {
+	var count int64
+	err := r.read.WithContext(ctx).Unscoped().Model(&muxassetmodel.Asset{}).Where("status = ?", status).Count(&count).Error
+	return count, err
+}
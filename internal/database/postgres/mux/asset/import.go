@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+)
+
+// ListKnown retrieves the IDs and MUX asset IDs of the mux assets in any status, including
+// soft-deleted ones, with one of the MUX asset IDs or one of the IDs. It reads from the primary,
+// so assets created right before are reported.
+func (r *Repository) ListKnown(ctx context.Context, muxAssetIDs []string, ids uuid.UUIDs) ([]*muxassetmodel.Asset, error) {
+	if len(muxAssetIDs) == 0 && len(ids) == 0 {
+		return nil, nil
+	}
+	if muxAssetIDs == nil {
+		muxAssetIDs = []string{}
+	}
+	if ids == nil {
+		ids = uuid.UUIDs{}
+	}
+	var assets []*muxassetmodel.Asset
+	err := r.db.WithContext(ctx).Unscoped().
+		Select("id", "mux_asset_id").
+		Where("mux_asset_id IN ? OR id IN ?", muxAssetIDs, ids).
+		Find(&assets).Error
+	return assets, err
+}
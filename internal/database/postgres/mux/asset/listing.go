@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// listingBatchSize bounds the rows of a single listing upsert.
+const listingBatchSize = 500
+
+// ListingFilter filters the assets on their listing read model, see [muxassetmodel.Listing].
+// Assets without a listing are not matched by any filter.
+type ListingFilter struct {
+	// Query matches titles containing it, case-insensitively.
+	Query     string
+	CreatorID string
+	// Owned matches the assets with owners if true and the assets without owners if false.
+	Owned *bool
+}
+
+func (f ListingFilter) isSet() bool {
+	return f.Query != "" || f.CreatorID != "" || f.Owned != nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// applyListing joins the listing read model if the filter uses it or load is set.
+func applyListing(db *gorm.DB, filter ListingFilter, load bool) *gorm.DB {
+	if !filter.isSet() && !load {
+		return db
+	}
+	db = db.Joins("Listing")
+	if filter.Query != "" {
+		db = db.Where(`"Listing".title ILIKE ?`, "%"+likeEscaper.Replace(filter.Query)+"%")
+	}
+	if filter.CreatorID != "" {
+		db = db.Where(`"Listing".creator_id = ?`, filter.CreatorID)
+	}
+	if filter.Owned != nil {
+		if *filter.Owned {
+			db = db.Where(`"Listing".owner_count > 0`)
+		} else {
+			db = db.Where(`"Listing".owner_count = 0`)
+		}
+	}
+	return db
+}
+
+// SaveListings creates or replaces the listing read model of mux assets. Listings of assets
+// which do not exist are rejected by the foreign key.
+func (r *Repository) SaveListings(ctx context.Context, listings ...*muxassetmodel.Listing) error {
+	if len(listings) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "asset_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"title", "creator_id", "owner_count", "synced_at"}),
+		}).
+		CreateInBatches(listings, listingBatchSize).Error
+}
+
+// ListIDs retrieves the IDs of up to limit mux assets in any status with IDs greater than afterID,
+// ordered by ID.
+func (r *Repository) ListIDs(ctx context.Context, afterID uuid.UUID, limit int) (uuid.UUIDs, error) {
+	var ids uuid.UUIDs
+	err := r.read.WithContext(ctx).Unscoped().Model(&muxassetmodel.Asset{}).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+)
+
+// SaveOwnershipSnapshot stores the ownership snapshot of the mux asset in any status, including a
+// soft-deleted one. A nil snapshot clears the stored one.
+func (r *Repository) SaveOwnershipSnapshot(ctx context.Context, id uuid.UUID, snapshot *muxassetmodel.OwnershipSnapshot) error {
+	return r.db.WithContext(ctx).Unscoped().
+		Model(&muxassetmodel.Asset{}).
+		Where("id = ?", id).
+		Update("ownership_snapshot", snapshot).Error
+}
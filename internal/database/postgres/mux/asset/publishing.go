@@ -0,0 +1,54 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	"github.com/mikhail5545/media-service-go/internal/models/publishing"
+)
+
+// SavePublishing stores the publication schedule and the publishing state of the mux asset in any
+// status but pending deletion.
+func (r *Repository) SavePublishing(ctx context.Context, id uuid.UUID, schedule *publishing.Schedule) (int64, error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Model(&muxassetmodel.Asset{}).
+		Where("id = ? AND status <> ?", id, muxassetmodel.StatusPendingDelete).
+		Updates(map[string]any{
+			"publishing_state": schedule.State,
+			"publish_at":       schedule.PublishAt,
+			"unpublish_at":     schedule.UnpublishAt,
+		})
+	return res.RowsAffected, res.Error
+}
+
+// ListDuePublishing retrieves the mux assets whose publish or unpublish time passed before now without
+// their publishing state being updated, ordered by ID. At most limit records are returned.
+func (r *Repository) ListDuePublishing(ctx context.Context, now time.Time, limit int) ([]*muxassetmodel.Asset, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	var assets []*muxassetmodel.Asset
+	err := r.db.WithContext(ctx).Unscoped().
+		Select("id", "status", "publishing_state", "publish_at", "unpublish_at").
+		Where("status <> ?", muxassetmodel.StatusPendingDelete).
+		Where("(publishing_state <> ? AND unpublish_at <= ?) OR (publishing_state = ? AND publish_at <= ? AND (unpublish_at IS NULL OR unpublish_at > ?))",
+			publishing.StateUnpublished, now, publishing.StateScheduled, now, now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&assets).Error
+	return assets, err
+}
+
+// TransitionPublishing changes the publishing state of the mux asset from the state from to the state to.
+// No record is updated if the state was changed concurrently.
+func (r *Repository) TransitionPublishing(ctx context.Context, id uuid.UUID, from, to publishing.State) (int64, error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Model(&muxassetmodel.Asset{}).
+		Where("id = ? AND publishing_state = ?", id, from).
+		Update("publishing_state", to)
+	return res.RowsAffected, res.Error
+}
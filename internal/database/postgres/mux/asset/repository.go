@@ -2,10 +2,15 @@ package asset
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/database/postgres/pagination"
 	"github.com/mikhail5545/media-service-go/internal/database/types"
+	costmodel "github.com/mikhail5545/media-service-go/internal/models/cost"
 	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	"github.com/mikhail5545/media-service-go/internal/models/publishing"
+	usagemodel "github.com/mikhail5545/media-service-go/internal/models/usage"
 	"gorm.io/gorm"
 )
 
@@ -31,19 +36,85 @@ type GormRepository interface {
 	// Archive archives mux asset matching the provided state operation options.
 	Archive(ctx context.Context, opts StateOperationOptions, auditOpts types.AuditTrailOptions) (int64, error)
 	// Delete permanently deletes mux asset matching the provided state operation options.
-	// Only currently soft-deleted (archived) assets can be permanently deleted.
+	// Only currently soft-deleted (archived or pending deletion) assets can be permanently deleted.
 	Delete(ctx context.Context, opts StateOperationOptions) (int64, error)
+	// MarkPendingDelete marks archived mux assets matching the provided state operation options as pending deletion.
+	MarkPendingDelete(ctx context.Context, opts StateOperationOptions) (int64, error)
+	// GetPendingDelete retrieves the mux asset pending deletion.
+	GetPendingDelete(ctx context.Context, id uuid.UUID) (*muxassetmodel.Asset, error)
+	// ListPendingDelete retrieves mux assets marked as pending deletion before the provided cutoff.
+	ListPendingDelete(ctx context.Context, cutoff time.Time, limit int) ([]*muxassetmodel.Asset, error)
 	MarkAsBroken(ctx context.Context, opts StateOperationOptions, auditOpts types.AuditTrailOptions) (int64, error)
+	// ListExpired retrieves archived mux assets that were soft-deleted before the provided cutoff,
+	// ordered by deletion time. Assets with a failed purge attempt recorded after failedAfter are skipped,
+	// a zero failedAfter skips none. At most limit records are returned.
+	ListExpired(ctx context.Context, cutoff, failedAfter time.Time, limit int) ([]*muxassetmodel.Asset, error)
+	// CountByStatus returns the number of mux assets with the provided status, including soft-deleted ones.
+	CountByStatus(ctx context.Context, status muxassetmodel.Status) (int64, error)
+	// LockOwner serializes the associations of the owner with mux assets until the end of the transaction.
+	LockOwner(ctx context.Context, ownerType, ownerID string) error
+	// Count returns the number of mux assets matching the provided options and scopes. It applies
+	// the same filters as List, so it can serve as the total of a paginated listing.
+	// If no scopes are provided, only active assets are considered.
+	Count(ctx context.Context, opts ListOptions, scopes ...Scope) (int64, error)
+	// Total is Count computed with the strategy of the counter, which may return an estimate.
+	Total(ctx context.Context, counter *pagination.Counter, opts ListOptions, scopes ...Scope) (pagination.Total, error)
+	// SaveListings creates or replaces the listing read model of mux assets.
+	SaveListings(ctx context.Context, listings ...*muxassetmodel.Listing) error
+	// ListIDs retrieves the IDs of up to limit mux assets in any status with IDs greater than afterID, ordered by ID.
+	ListIDs(ctx context.Context, afterID uuid.UUID, limit int) (uuid.UUIDs, error)
+	// CreatorUsage returns the usage of the mux assets created by the creator. Archived assets are not counted.
+	CreatorUsage(ctx context.Context, creatorID uuid.UUID) (*usagemodel.Usage, error)
+	// UsageByCreator returns the usage of the mux assets grouped by creator. Archived assets are not counted.
+	UsageByCreator(ctx context.Context) ([]*usagemodel.Usage, error)
+	// ListUsage retrieves the usage of up to limit mux assets with IDs greater than afterID, ordered by ID.
+	ListUsage(ctx context.Context, afterID uuid.UUID, limit int) ([]*usagemodel.AssetUsage, error)
+	// ListCostUsage retrieves the usage of up to limit mux assets in any status created before the
+	// time with IDs greater than afterID, ordered by ID.
+	ListCostUsage(ctx context.Context, createdBefore time.Time, afterID uuid.UUID, limit int) ([]*costmodel.AssetUsage, error)
+	// ListKnown retrieves the IDs and MUX asset IDs of the mux assets in any status with one of the
+	// MUX asset IDs or one of the IDs.
+	ListKnown(ctx context.Context, muxAssetIDs []string, ids uuid.UUIDs) ([]*muxassetmodel.Asset, error)
+	// ListStatuses retrieves the IDs and statuses of the mux assets with the IDs in any status.
+	ListStatuses(ctx context.Context, ids uuid.UUIDs) ([]*muxassetmodel.Asset, error)
+	// ListExistingIDs retrieves the IDs among ids of the mux assets in any status.
+	ListExistingIDs(ctx context.Context, ids uuid.UUIDs) (uuid.UUIDs, error)
+	// DeleteFixtures permanently deletes the mux assets with the IDs in any status, it is only used
+	// to wipe the seeded fixtures.
+	DeleteFixtures(ctx context.Context, ids uuid.UUIDs) (int64, error)
+	// SaveOwnershipSnapshot stores the ownership snapshot of the mux asset in any status. A nil
+	// snapshot clears the stored one.
+	SaveOwnershipSnapshot(ctx context.Context, id uuid.UUID, snapshot *muxassetmodel.OwnershipSnapshot) error
+	// ListDuplicates retrieves up to limit groups of active mux assets sharing the fingerprint, largest
+	// groups first.
+	ListDuplicates(ctx context.Context, limit int) ([]*muxassetmodel.DuplicateGroup, error)
+	// SavePublishing stores the publication schedule and the publishing state of the mux asset.
+	SavePublishing(ctx context.Context, id uuid.UUID, schedule *publishing.Schedule) (int64, error)
+	// ListDuePublishing retrieves up to limit mux assets whose publishing state is due to change at now.
+	ListDuePublishing(ctx context.Context, now time.Time, limit int) ([]*muxassetmodel.Asset, error)
+	// TransitionPublishing changes the publishing state of the mux asset unless it was changed concurrently.
+	TransitionPublishing(ctx context.Context, id uuid.UUID, from, to publishing.State) (int64, error)
 }
 
 type Repository struct {
 	db *gorm.DB
+	// read serves Get, List and Count queries. It is the primary unless a read replica is configured.
+	read *gorm.DB
 }
 
 var _ GormRepository = (*Repository)(nil)
 
 func New(db *gorm.DB) *Repository {
-	return &Repository{db: db}
+	return &Repository{db: db, read: db}
+}
+
+// NewWithReplica creates a repository that sends Get, List and Count queries to replica and keeps
+// writes on primary. A nil replica falls back to primary.
+func NewWithReplica(primary, replica *gorm.DB) *Repository {
+	if replica == nil {
+		replica = primary
+	}
+	return &Repository{db: primary, read: replica}
 }
 
 func (r *Repository) DB() *gorm.DB {
@@ -51,7 +122,8 @@ func (r *Repository) DB() *gorm.DB {
 }
 
 func (r *Repository) WithTx(tx *gorm.DB) *Repository {
-	return &Repository{db: tx}
+	// Reads inside a transaction must see its own writes, so they stay on the primary.
+	return &Repository{db: tx, read: tx}
 }
 
 type Scope uint
@@ -75,6 +147,13 @@ type Filter struct {
 	AspectRatios    []string
 	ResolutionTiers []string
 	IngestTypes     []muxassetmodel.IngestType
+	// Tags matches assets having all the tags.
+	Tags []string
+
+	// ListingFilter filters on the listing read model.
+	ListingFilter
+	// WithListing loads the listing read model of the assets into [muxassetmodel.Asset.Listing].
+	WithListing bool
 
 	Fields []string
 
@@ -102,6 +181,13 @@ type ListOptions struct {
 	AspectRatios    []string
 	ResolutionTiers []string
 	IngestTypes     []muxassetmodel.IngestType
+	// Tags matches assets having all the tags.
+	Tags []string
+
+	// ListingFilter filters on the listing read model.
+	ListingFilter
+	// WithListing loads the listing read model of the assets into [muxassetmodel.Asset.Listing].
+	WithListing bool
 
 	Fields []string
 
@@ -209,3 +295,32 @@ func (r *Repository) Delete(ctx context.Context, opts StateOperationOptions) (in
 func (r *Repository) MarkAsBroken(ctx context.Context, opts StateOperationOptions, auditOpts types.AuditTrailOptions) (int64, error) {
 	return r.markAsBroken(ctx, populateFromStateOperationOptions(opts), &auditOpts)
 }
+
+// ListExpired retrieves archived mux assets that were soft-deleted before the provided cutoff,
+// ordered by deletion time. Assets with a failed purge attempt recorded after failedAfter are skipped,
+// a zero failedAfter skips none. At most limit records are returned.
+func (r *Repository) ListExpired(ctx context.Context, cutoff, failedAfter time.Time, limit int) ([]*muxassetmodel.Asset, error) {
+	return r.listExpired(ctx, cutoff, failedAfter, limit)
+}
+
+// CountByStatus returns the number of mux assets with the provided status, including soft-deleted ones.
+func (r *Repository) CountByStatus(ctx context.Context, status muxassetmodel.Status) (int64, error) {
+	return r.countByStatus(ctx, status)
+}
+
+// LockOwner serializes the associations of the owner with mux assets until the end of the transaction.
+func (r *Repository) LockOwner(ctx context.Context, ownerType, ownerID string) error {
+	return r.db.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "mux_asset_owners:"+ownerType+":"+ownerID).Error
+}
+
+// Count returns the number of mux assets matching the provided options and scopes.
+// Pagination and ordering options are ignored.
+func (r *Repository) Count(ctx context.Context, opts ListOptions, scopes ...Scope) (int64, error) {
+	return r.count(ctx, populateFromListOptions(opts, scopes))
+}
+
+// Total returns the total of the mux assets matching the provided options and scopes, computed
+// with the strategy of the counter. Pagination and ordering options are ignored.
+func (r *Repository) Total(ctx context.Context, counter *pagination.Counter, opts ListOptions, scopes ...Scope) (pagination.Total, error) {
+	return r.total(ctx, populateFromListOptions(opts, scopes), counter)
+}
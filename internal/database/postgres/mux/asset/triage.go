@@ -0,0 +1,40 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+)
+
+// CountErrors counts the broken mux assets and the assets whose upload errored, grouped by status and
+// by the type of the MUX error. Archived assets are not counted.
+func (r *Repository) CountErrors(ctx context.Context) ([]*muxassetmodel.ErrorCount, error) {
+	var counts []*muxassetmodel.ErrorCount
+	err := r.read.WithContext(ctx).Model(&muxassetmodel.Asset{}).
+		Select("status, COALESCE(mux_error->>'type', '') AS error_type, COUNT(*) AS assets").
+		Where("status = ? OR upload_status = ?", muxassetmodel.StatusBroken, muxassetmodel.UploadStatusErrored).
+		Group("status, error_type").
+		Order("assets DESC").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// ListStuck retrieves the mux assets which have been waiting for an upload or for MUX to finish
+// processing since before the provided cutoff, ordered by their last update. At most limit records are
+// returned.
+func (r *Repository) ListStuck(ctx context.Context, cutoff time.Time, limit int) ([]*muxassetmodel.Asset, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	var assets []*muxassetmodel.Asset
+	err := r.read.WithContext(ctx).
+		Where("(status = ? OR (status = ? AND upload_status = ?)) AND updated_at < ?",
+			muxassetmodel.StatusUploadURLGenerated, muxassetmodel.StatusActive, muxassetmodel.UploadStatusPreparing, cutoff).
+		Order("updated_at ASC, id ASC").
+		Limit(limit).
+		Find(&assets).Error
+	return assets, err
+}
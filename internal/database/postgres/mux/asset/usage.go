@@ -0,0 +1,44 @@
+package asset
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	usagemodel "github.com/mikhail5545/media-service-go/internal/models/usage"
+)
+
+// CreatorUsage returns the usage of the mux assets created by the creator. Archived assets are not counted.
+// It reads from the primary, so it can be used within the transaction creating a new asset.
+func (r *Repository) CreatorUsage(ctx context.Context, creatorID uuid.UUID) (*usagemodel.Usage, error) {
+	usage := &usagemodel.Usage{CreatorID: &creatorID}
+	err := r.db.WithContext(ctx).Model(&muxassetmodel.Asset{}).
+		Select("COUNT(*) AS assets, COALESCE(SUM(duration), 0) AS duration").
+		Where("created_by = ?", creatorID).
+		Scan(usage).Error
+	return usage, err
+}
+
+// UsageByCreator returns the usage of the mux assets grouped by creator. Archived assets are not counted.
+func (r *Repository) UsageByCreator(ctx context.Context) ([]*usagemodel.Usage, error) {
+	var usages []*usagemodel.Usage
+	err := r.read.WithContext(ctx).Model(&muxassetmodel.Asset{}).
+		Select("created_by AS creator_id, COUNT(*) AS assets, COALESCE(SUM(duration), 0) AS duration").
+		Group("created_by").
+		Order("assets DESC").
+		Scan(&usages).Error
+	return usages, err
+}
+
+// ListUsage retrieves the usage of up to limit mux assets with IDs greater than afterID, ordered by ID.
+// Archived assets are not listed.
+func (r *Repository) ListUsage(ctx context.Context, afterID uuid.UUID, limit int) ([]*usagemodel.AssetUsage, error) {
+	var usages []*usagemodel.AssetUsage
+	err := r.read.WithContext(ctx).Model(&muxassetmodel.Asset{}).
+		Select("id, created_by AS creator_id, COALESCE(duration, 0) AS duration").
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Scan(&usages).Error
+	return usages, err
+}
@@ -26,36 +26,56 @@ import (
 	"gorm.io/gorm"
 )
 
+// ScopesInclude reports whether an asset with the provided status is visible under the scopes. It
+// follows the rules of the repository queries, so no scopes means active assets only.
+func ScopesInclude(scopes []Scope, status muxassetmodel.Status) bool {
+	return slices.Contains(extractScopes(scopes), status)
+}
+
 func extractScopes(scopes []Scope) []muxassetmodel.Status {
-	var statuses []muxassetmodel.Status
-	if len(scopes) > 0 {
-		if slices.Contains(scopes, ScopeAll) {
-			statuses = []muxassetmodel.Status{
-				muxassetmodel.StatusActive,
-				muxassetmodel.StatusUploadURLGenerated,
-				muxassetmodel.StatusArchived,
-				muxassetmodel.StatusBroken,
-			}
-		}
-		statuses = make([]muxassetmodel.Status, 0, len(scopes))
-		if slices.Contains(scopes, ScopeActive) {
-			statuses = append(statuses, muxassetmodel.StatusActive)
-		}
-		if slices.Contains(scopes, ScopeUploadURLGenerated) {
-			statuses = append(statuses, muxassetmodel.StatusUploadURLGenerated)
-		}
-		if slices.Contains(scopes, ScopeArchived) {
-			statuses = append(statuses, muxassetmodel.StatusArchived)
-		}
-		if slices.Contains(scopes, ScopeBroken) {
-			statuses = append(statuses, muxassetmodel.StatusBroken)
+	if len(scopes) == 0 {
+		return []muxassetmodel.Status{muxassetmodel.StatusActive} // Only active by default
+	}
+	if slices.Contains(scopes, ScopeAll) {
+		return []muxassetmodel.Status{
+			muxassetmodel.StatusActive,
+			muxassetmodel.StatusUploadURLGenerated,
+			muxassetmodel.StatusArchived,
+			muxassetmodel.StatusBroken,
 		}
-	} else {
-		statuses = []muxassetmodel.Status{muxassetmodel.StatusActive} // Only active by default
+	}
+	statuses := make([]muxassetmodel.Status, 0, len(scopes))
+	if slices.Contains(scopes, ScopeActive) {
+		statuses = append(statuses, muxassetmodel.StatusActive)
+	}
+	if slices.Contains(scopes, ScopeUploadURLGenerated) {
+		statuses = append(statuses, muxassetmodel.StatusUploadURLGenerated)
+	}
+	if slices.Contains(scopes, ScopeArchived) {
+		statuses = append(statuses, muxassetmodel.StatusArchived)
+	}
+	if slices.Contains(scopes, ScopeBroken) {
+		statuses = append(statuses, muxassetmodel.StatusBroken)
 	}
 	return statuses
 }
 
+// pageFields returns the selected fields of a page together with the columns the cursor of the
+// next page is built from.
+func pageFields(filter *Filter) []string {
+	orderBy := filter.OrderBy
+	if orderBy == "" {
+		orderBy = muxassetmodel.OrderCreatedAt
+	}
+	fields := slices.Clone(filter.Fields)
+	for _, required := range []string{"id", string(orderBy)} {
+		if !slices.Contains(fields, required) {
+			fields = append(fields, required)
+		}
+	}
+	return fields
+}
+
 func getCursorValue(asset *muxassetmodel.Asset, orderBy muxassetmodel.OrderField) any {
 	switch orderBy {
 	case muxassetmodel.OrderIngestType:
@@ -90,6 +110,15 @@ func applyStatusFilters(db *gorm.DB, statuses []muxassetmodel.Status) *gorm.DB {
 	return db.Model(muxassetmodel.Asset{}).Where("status IN ?", statuses)
 }
 
+// applyFilter applies every condition of the filter: the statuses derived from the scopes, the
+// identifying and the specific filters. Queries that must agree with each other, such as a listing
+// and its count, go through it so that they cannot drift apart.
+func applyFilter(db *gorm.DB, filter *Filter) *gorm.DB {
+	db = applyStatusFilters(db, filter.Statuses)
+	db = applyIdentifyingFilters(db, filter)
+	return applySpecificFilters(db, filter)
+}
+
 func applyIdentifyingFilters(db *gorm.DB, filter *Filter) *gorm.DB {
 	if len(filter.IDs) > 0 {
 		db = db.Where("id IN ?", filter.IDs)
@@ -113,6 +142,21 @@ func applySpecificFilters(db *gorm.DB, filter *Filter) *gorm.DB {
 	if len(filter.IngestTypes) > 0 {
 		db = db.Where("ingest_type IN ?", filter.IngestTypes)
 	}
+	if len(filter.States) > 0 {
+		db = db.Where("state IN ?", filter.States)
+	}
+	if len(filter.UploadStatuses) > 0 {
+		db = db.Where("upload_status IN ?", filter.UploadStatuses)
+	}
+	return applyTagFilters(db, filter.Tags)
+}
+
+// applyTagFilters matches assets having all the tags. Each tag is a separate containment check, so
+// the GIN index on the tags column can be used.
+func applyTagFilters(db *gorm.DB, tags []string) *gorm.DB {
+	for _, tag := range tags {
+		db = db.Where("tags @> ARRAY[?]::varchar[]", tag)
+	}
 	return db
 }
 
@@ -194,6 +238,9 @@ func populateFromListOptions(opts ListOptions, scopes []Scope) *Filter {
 		AspectRatios:    opts.AspectRatios,
 		ResolutionTiers: opts.ResolutionTiers,
 		IngestTypes:     opts.IngestTypes,
+		Tags:            opts.Tags,
+		ListingFilter:   opts.ListingFilter,
+		WithListing:     opts.WithListing,
 		Fields:          opts.Fields,
 		OrderBy:         opts.OrderBy,
 		OrderDir:        opts.OrderDir,
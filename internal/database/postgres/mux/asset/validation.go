@@ -13,6 +13,7 @@ func (f Filter) Validate() error {
 		validation.Field(&f.MuxAssetIDs, validation.Each(validation.Length(1, 255))),
 		validation.Field(&f.AspectRatios, validation.Each(validation.Length(1, 50))),
 		validation.Field(&f.ResolutionTiers, validation.Each(validation.Length(1, 50))),
+		validation.Field(&f.Tags, validation.Each(validation.Length(1, 128))),
 		validation.Field(&f.UploadStatuses, validation.Each(validation.In(
 			muxassetmodel.UploadStatusPreparing,
 			muxassetmodel.UploadStatusReady,
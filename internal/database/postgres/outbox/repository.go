@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	outboxmodel "github.com/mikhail5545/media-service-go/internal/models/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormRepository interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) *Repository
+	// Enqueue persists new outbox events. It should be called using the transactional
+	// repository (see WithTx) to guarantee that events are stored atomically with the state change.
+	Enqueue(ctx context.Context, events ...*outboxmodel.Event) error
+	// ClaimDue locks at most limit pending events which are due for delivery and postpones their next
+	// attempt by the provided lease, so concurrent dispatchers do not pick the same events.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*outboxmodel.Event, error)
+	// MarkDelivered marks the event as successfully delivered.
+	MarkDelivered(ctx context.Context, id uuid.UUID) error
+	// MarkRetry records failed delivery attempt and schedules the next one.
+	MarkRetry(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastErr string) error
+	// MarkFailed records failed delivery attempt and stops further delivery of the event.
+	MarkFailed(ctx context.Context, id uuid.UUID, lastErr string) error
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+var _ GormRepository = (*Repository)(nil)
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *Repository) WithTx(tx *gorm.DB) *Repository {
+	return &Repository{db: tx}
+}
+
+// Enqueue persists new outbox events. It should be called using the transactional
+// repository (see WithTx) to guarantee that events are stored atomically with the state change.
+func (r *Repository) Enqueue(ctx context.Context, events ...*outboxmodel.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(events).Error
+}
+
+// ClaimDue locks at most limit pending events which are due for delivery and postpones their next
+// attempt by the provided lease, so concurrent dispatchers do not pick the same events.
+func (r *Repository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*outboxmodel.Event, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	var events []*outboxmodel.Event
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", outboxmodel.StatusPending, now).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Find(&events).Error
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := make(uuid.UUIDs, len(events))
+		for i := range events {
+			ids[i] = events[i].ID
+		}
+		return tx.Model(&outboxmodel.Event{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return events, err
+}
+
+// MarkDelivered marks the event as successfully delivered.
+func (r *Repository) MarkDelivered(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&outboxmodel.Event{}).Where("id = ?", id).Updates(map[string]any{
+		"status":       outboxmodel.StatusDelivered,
+		"attempts":     gorm.Expr("attempts + 1"),
+		"delivered_at": time.Now(),
+		"last_error":   nil,
+	}).Error
+}
+
+// MarkRetry records failed delivery attempt and schedules the next one.
+func (r *Repository) MarkRetry(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastErr string) error {
+	return r.db.WithContext(ctx).Model(&outboxmodel.Event{}).Where("id = ?", id).Updates(map[string]any{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      truncate(lastErr, 1024),
+	}).Error
+}
+
+// MarkFailed records failed delivery attempt and stops further delivery of the event.
+func (r *Repository) MarkFailed(ctx context.Context, id uuid.UUID, lastErr string) error {
+	return r.db.WithContext(ctx).Model(&outboxmodel.Event{}).Where("id = ?", id).Updates(map[string]any{
+		"status":     outboxmodel.StatusFailed,
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": truncate(lastErr, 1024),
+	}).Error
+}
+
+// truncate shortens s to at most n bytes without splitting a multi-byte rune,
+// since Postgres rejects text values that are not valid UTF-8.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CountStrategy selects how the total of a list query is computed.
+type CountStrategy string
+
+const (
+	// CountExact runs COUNT(*) on every request.
+	CountExact CountStrategy = "exact"
+	// CountCached runs COUNT(*) and reuses the result for the same filter until the TTL expires.
+	CountCached CountStrategy = "cached"
+	// CountEstimate uses the row estimate of the Postgres planner, which derives it from the
+	// reltuples statistics of the table. It is cheap but only as fresh as the last ANALYZE.
+	CountEstimate CountStrategy = "estimate"
+)
+
+// CountStrategies lists the supported count strategies.
+var CountStrategies = []CountStrategy{CountExact, CountCached, CountEstimate}
+
+// Total is the total number of records matching a list query.
+type Total struct {
+	Count int64
+	// IsEstimate is set if Count is a planner estimate or a cached count that may be stale.
+	IsEstimate bool
+}
+
+// Counter computes list totals with a single strategy. It is safe for concurrent use.
+type Counter struct {
+	strategy CountStrategy
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedCount
+}
+
+type cachedCount struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// NewCounter creates a counter. ttl is only used by the cached strategy.
+func NewCounter(strategy CountStrategy, ttl time.Duration) *Counter {
+	return &Counter{
+		strategy: strategy,
+		ttl:      ttl,
+		entries:  make(map[string]cachedCount),
+	}
+}
+
+var exactCounter = NewCounter(CountExact, 0)
+
+// Count returns the total of the records selected by db. db must have its model and filters applied,
+// but no ordering or pagination.
+func (c *Counter) Count(db *gorm.DB) (Total, error) {
+	if c == nil {
+		c = exactCounter
+	}
+	switch c.strategy {
+	case CountCached:
+		return c.cached(db)
+	case CountEstimate:
+		count, err := estimate(db)
+		return Total{Count: count, IsEstimate: true}, err
+	default:
+		var count int64
+		err := db.Count(&count).Error
+		return Total{Count: count}, err
+	}
+}
+
+func (c *Counter) cached(db *gorm.DB) (Total, error) {
+	stmt := db.Session(&gorm.Session{DryRun: true}).Find(&[]map[string]any{}).Statement
+	key := fmt.Sprintf("%s %v", stmt.SQL.String(), stmt.Vars)
+	now := time.Now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return Total{Count: entry.count, IsEstimate: true}, nil
+	}
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		return Total{}, err
+	}
+	c.mu.Lock()
+	// Drop the expired entries, so that one-off filters do not accumulate.
+	for k, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cachedCount{count: count, expiresAt: now.Add(c.ttl)}
+	c.mu.Unlock()
+	return Total{Count: count}, nil
+}
+
+// estimate returns the number of rows the planner expects the query of db to return.
+func estimate(db *gorm.DB) (int64, error) {
+	stmt := db.Session(&gorm.Session{DryRun: true}).Find(&[]map[string]any{}).Statement
+	if stmt.Error != nil {
+		return 0, stmt.Error
+	}
+	var raw string
+	err := db.Session(&gorm.Session{NewDB: true}).
+		Raw("EXPLAIN (FORMAT JSON) "+stmt.SQL.String(), stmt.Vars...).
+		Row().Scan(&raw)
+	if err != nil {
+		return 0, fmt.Errorf("failed to explain query: %w", err)
+	}
+	var plans []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(raw), &plans); err != nil {
+		return 0, fmt.Errorf("failed to decode query plan: %w", err)
+	}
+	if len(plans) == 0 {
+		return 0, errors.New("empty query plan")
+	}
+	return int64(plans[0].Plan.Rows), nil
+}
+
+// Counters holds the counters of individual list endpoints, e.g. "mux.list_archived".
+// Endpoints without an override share the default strategy.
+type Counters struct {
+	strategy  CountStrategy
+	ttl       time.Duration
+	overrides map[string]CountStrategy
+
+	mu       sync.Mutex
+	counters map[string]*Counter
+}
+
+// NewCounters creates the counters of the list endpoints.
+func NewCounters(strategy CountStrategy, ttl time.Duration, overrides map[string]CountStrategy) *Counters {
+	return &Counters{
+		strategy:  strategy,
+		ttl:       ttl,
+		overrides: overrides,
+		counters:  make(map[string]*Counter),
+	}
+}
+
+// For returns the counter of the endpoint. Totals are exact if c is nil.
+func (c *Counters) For(endpoint string) *Counter {
+	if c == nil {
+		return exactCounter
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counter, ok := c.counters[endpoint]
+	if !ok {
+		strategy, ok := c.overrides[endpoint]
+		if !ok {
+			strategy = c.strategy
+		}
+		counter = NewCounter(strategy, c.ttl)
+		c.counters[endpoint] = counter
+	}
+	return counter
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package playback
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	playbackmodel "github.com/mikhail5545/media-service-go/internal/models/playback"
+	"gorm.io/gorm"
+)
+
+type GormRepository interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) *Repository
+	// LockUser serializes the issuing of tokens for the user until the end of the transaction.
+	LockUser(ctx context.Context, userID uuid.UUID) error
+	// CountActiveSessions counts the sessions of the user having tokens that are neither expired nor revoked,
+	// the session with excludeSessionID is not counted.
+	CountActiveSessions(ctx context.Context, userID uuid.UUID, excludeSessionID *uuid.UUID, now time.Time) (int64, error)
+	// Create persists the issued tokens.
+	Create(ctx context.Context, sessions []*playbackmodel.Session) error
+	// GetByTokenHash retrieves the issued token with the hash.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*playbackmodel.Session, error)
+	// Revoke revokes the tokens of the user which are neither expired nor revoked. If sessionID is not nil,
+	// only the tokens of that session are revoked.
+	Revoke(ctx context.Context, userID uuid.UUID, sessionID *uuid.UUID, now time.Time) (int64, error)
+	// DeleteExpired deletes up to limit tokens which expired before the cutoff.
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+var _ GormRepository = (*Repository)(nil)
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *Repository) WithTx(tx *gorm.DB) *Repository {
+	return &Repository{db: tx}
+}
+
+// LockUser serializes the issuing of tokens for the user until the end of the transaction.
+func (r *Repository) LockUser(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "playback_sessions:"+userID.String()).Error
+}
+
+// CountActiveSessions counts the sessions of the user having tokens that are neither expired nor revoked,
+// the session with excludeSessionID is not counted.
+func (r *Repository) CountActiveSessions(ctx context.Context, userID uuid.UUID, excludeSessionID *uuid.UUID, now time.Time) (int64, error) {
+	db := r.db.WithContext(ctx).Model(&playbackmodel.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now)
+	if excludeSessionID != nil {
+		db = db.Where("session_id IS DISTINCT FROM ?", *excludeSessionID)
+	}
+	var count int64
+	// Tokens issued without a session ID are sessions of their own.
+	err := db.Select("COUNT(DISTINCT COALESCE(session_id, id))").Scan(&count).Error
+	return count, err
+}
+
+// Create persists the issued tokens.
+func (r *Repository) Create(ctx context.Context, sessions []*playbackmodel.Session) error {
+	return r.db.WithContext(ctx).Create(sessions).Error
+}
+
+// GetByTokenHash retrieves the issued token with the hash.
+func (r *Repository) GetByTokenHash(ctx context.Context, tokenHash string) (*playbackmodel.Session, error) {
+	var session playbackmodel.Session
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Revoke revokes the tokens of the user which are neither expired nor revoked. If sessionID is not nil,
+// only the tokens of that session are revoked.
+func (r *Repository) Revoke(ctx context.Context, userID uuid.UUID, sessionID *uuid.UUID, now time.Time) (int64, error) {
+	db := r.db.WithContext(ctx).Model(&playbackmodel.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now)
+	if sessionID != nil {
+		db = db.Where("session_id = ?", *sessionID)
+	}
+	res := db.Update("revoked_at", now)
+	return res.RowsAffected, res.Error
+}
+
+// DeleteExpired deletes up to limit tokens which expired before the cutoff.
+func (r *Repository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	res := r.db.WithContext(ctx).Where(
+		"id IN (?)",
+		r.db.Model(&playbackmodel.Session{}).Select("id").Where("expires_at < ?", before).Limit(limit),
+	).Delete(&playbackmodel.Session{})
+	return res.RowsAffected, res.Error
+}
@@ -3,23 +3,22 @@ package postgres
 import (
 	"context"
 
-	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
-	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+// NewPostgresDB opens a connection pool and verifies that the database is reachable. The schema is
+// managed by the migrations package.
 func NewPostgresDB(ctx context.Context, dsn string) (*gorm.DB, error) {
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
 		return nil, err
 	}
-	err = db.WithContext(ctx).AutoMigrate(
-		&muxassetmodel.Asset{},
-		&cldassetmodel.Asset{},
-	)
+	sqlDB, err := db.DB()
 	if err != nil {
-		sqlDB, _ := db.DB()
+		return nil, err
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
 		_ = sqlDB.Close()
 		return nil, err
 	}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package restriction
+
+import (
+	"context"
+
+	restrictionmodel "github.com/mikhail5545/media-service-go/internal/models/restriction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormRepository interface {
+	DB() *gorm.DB
+	// Get retrieves the MUX playback restriction of the policy in the environment.
+	Get(ctx context.Context, env, policy string) (*restrictionmodel.MuxRestriction, error)
+	// List retrieves the MUX playback restrictions of the environment, ordered by policy.
+	List(ctx context.Context, env string) ([]*restrictionmodel.MuxRestriction, error)
+	// Create persists the restriction unless the policy already has one in the environment. It reports
+	// whether the restriction was created.
+	Create(ctx context.Context, restriction *restrictionmodel.MuxRestriction) (bool, error)
+	// Update stores the referrer rules of the restriction.
+	Update(ctx context.Context, restriction *restrictionmodel.MuxRestriction) error
+	// Delete deletes the restriction of the policy in the environment.
+	Delete(ctx context.Context, env, policy string) (int64, error)
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+var _ GormRepository = (*Repository)(nil)
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) DB() *gorm.DB {
+	return r.db
+}
+
+// Get retrieves the MUX playback restriction of the policy in the environment.
+func (r *Repository) Get(ctx context.Context, env, policy string) (*restrictionmodel.MuxRestriction, error) {
+	var restriction restrictionmodel.MuxRestriction
+	if err := r.db.WithContext(ctx).Where("environment = ? AND policy = ?", env, policy).First(&restriction).Error; err != nil {
+		return nil, err
+	}
+	return &restriction, nil
+}
+
+// List retrieves the MUX playback restrictions of the environment, ordered by policy.
+func (r *Repository) List(ctx context.Context, env string) ([]*restrictionmodel.MuxRestriction, error) {
+	var restrictions []*restrictionmodel.MuxRestriction
+	if err := r.db.WithContext(ctx).Where("environment = ?", env).Order("policy ASC").Find(&restrictions).Error; err != nil {
+		return nil, err
+	}
+	return restrictions, nil
+}
+
+// Create persists the restriction unless the policy already has one in the environment. It reports
+// whether the restriction was created.
+func (r *Repository) Create(ctx context.Context, restriction *restrictionmodel.MuxRestriction) (bool, error) {
+	res := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(restriction)
+	return res.RowsAffected > 0, res.Error
+}
+
+// Update stores the referrer rules of the restriction.
+func (r *Repository) Update(ctx context.Context, restriction *restrictionmodel.MuxRestriction) error {
+	// Updated from the struct, so the allowed domains go through their serializer.
+	return r.db.WithContext(ctx).
+		Model(restriction).
+		Select("updated_at", "restriction_id", "allowed_domains", "allow_no_referrer").
+		Updates(restriction).Error
+}
+
+// Delete deletes the restriction of the policy in the environment.
+func (r *Repository) Delete(ctx context.Context, env, policy string) (int64, error) {
+	res := r.db.WithContext(ctx).Where("environment = ? AND policy = ?", env, policy).Delete(&restrictionmodel.MuxRestriction{})
+	return res.RowsAffected, res.Error
+}
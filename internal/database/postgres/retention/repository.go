@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package retention
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	retentionmodel "github.com/mikhail5545/media-service-go/internal/models/retention"
+	"gorm.io/gorm"
+)
+
+type GormRepository interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) *Repository
+	// Create persists purge audit log records.
+	Create(ctx context.Context, records ...*retentionmodel.PurgeRecord) error
+	// ListByRun retrieves all purge audit log records produced by the specified retention worker run.
+	ListByRun(ctx context.Context, runID uuid.UUID) ([]*retentionmodel.PurgeRecord, error)
+	// ListByAsset retrieves all purge audit log records for the specified asset.
+	ListByAsset(ctx context.Context, assetID uuid.UUID) ([]*retentionmodel.PurgeRecord, error)
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+var _ GormRepository = (*Repository)(nil)
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *Repository) WithTx(tx *gorm.DB) *Repository {
+	return &Repository{db: tx}
+}
+
+// Create persists purge audit log records.
+func (r *Repository) Create(ctx context.Context, records ...*retentionmodel.PurgeRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(records).Error
+}
+
+// ListByRun retrieves all purge audit log records produced by the specified retention worker run.
+func (r *Repository) ListByRun(ctx context.Context, runID uuid.UUID) ([]*retentionmodel.PurgeRecord, error) {
+	if runID == uuid.Nil {
+		return nil, fmt.Errorf("run id must be provided")
+	}
+	var records []*retentionmodel.PurgeRecord
+	err := r.db.WithContext(ctx).Where("run_id = ?", runID).Order("created_at ASC").Find(&records).Error
+	return records, err
+}
+
+// ListByAsset retrieves all purge audit log records for the specified asset.
+func (r *Repository) ListByAsset(ctx context.Context, assetID uuid.UUID) ([]*retentionmodel.PurgeRecord, error) {
+	if assetID == uuid.Nil {
+		return nil, fmt.Errorf("asset id must be provided")
+	}
+	var records []*retentionmodel.PurgeRecord
+	err := r.db.WithContext(ctx).Where("asset_id = ?", assetID).Order("created_at DESC").Find(&records).Error
+	return records, err
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package saga
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	sagamodel "github.com/mikhail5545/media-service-go/internal/models/saga"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormRepository interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) *Repository
+	// Create persists a new saga.
+	Create(ctx context.Context, saga *sagamodel.Saga) error
+	// Save persists the status, the steps and the error of the saga and extends its lease.
+	Save(ctx context.Context, saga *sagamodel.Saga, lease time.Duration) error
+	// ClaimResumable locks at most limit running or compensating sagas whose lease expired, extends their
+	// lease and counts the attempt, so concurrent workers do not resume the same sagas.
+	ClaimResumable(ctx context.Context, limit int, lease time.Duration) ([]*sagamodel.Saga, error)
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+var _ GormRepository = (*Repository)(nil)
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *Repository) WithTx(tx *gorm.DB) *Repository {
+	return &Repository{db: tx}
+}
+
+// Create persists a new saga.
+func (r *Repository) Create(ctx context.Context, saga *sagamodel.Saga) error {
+	return r.db.WithContext(ctx).Create(saga).Error
+}
+
+// Save persists the status, the steps and the error of the saga and extends its lease.
+func (r *Repository) Save(ctx context.Context, saga *sagamodel.Saga, lease time.Duration) error {
+	saga.NextAttemptAt = time.Now().Add(lease)
+	return r.db.WithContext(ctx).Model(saga).Select("status", "steps", "next_attempt_at", "last_error").Updates(saga).Error
+}
+
+// ClaimResumable locks at most limit running or compensating sagas whose lease expired, extends their
+// lease and counts the attempt, so concurrent workers do not resume the same sagas.
+func (r *Repository) ClaimResumable(ctx context.Context, limit int, lease time.Duration) ([]*sagamodel.Saga, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	var sagas []*sagamodel.Saga
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND next_attempt_at <= ?", []sagamodel.Status{sagamodel.StatusRunning, sagamodel.StatusCompensating}, now).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Find(&sagas).Error
+		if err != nil {
+			return err
+		}
+		if len(sagas) == 0 {
+			return nil
+		}
+
+		ids := make(uuid.UUIDs, len(sagas))
+		for i := range sagas {
+			ids[i] = sagas[i].ID
+			sagas[i].Attempts++
+		}
+		return tx.Model(&sagamodel.Saga{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{
+				"next_attempt_at": now.Add(lease),
+				"attempts":        gorm.Expr("attempts + 1"),
+			}).Error
+	})
+	return sagas, err
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package signingkey
+
+import (
+	"context"
+	"time"
+
+	signingkeymodel "github.com/mikhail5545/media-service-go/internal/models/signingkey"
+	"gorm.io/gorm"
+)
+
+type GormRepository interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) *Repository
+	// LockEnvironment serializes the rotations of the environment until the end of the transaction.
+	LockEnvironment(ctx context.Context, env string) error
+	// Current retrieves the newest active key of the context environment.
+	Current(ctx context.Context) (*signingkeymodel.Key, error)
+	// List retrieves the keys of the context environment which are not revoked, newest first.
+	List(ctx context.Context) ([]*signingkeymodel.Key, error)
+	// Create persists the key.
+	Create(ctx context.Context, key *signingkeymodel.Key) error
+	// Retire marks the active keys of the context environment other than the key with exceptKeyID as retiring.
+	Retire(ctx context.Context, exceptKeyID string, now time.Time) (int64, error)
+	// ListRetired retrieves the retiring keys of the context environment superseded before the cutoff.
+	ListRetired(ctx context.Context, before time.Time) ([]*signingkeymodel.Key, error)
+	// Revoke marks the key as revoked and discards its private key.
+	Revoke(ctx context.Context, keyID string, now time.Time) error
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+var _ GormRepository = (*Repository)(nil)
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *Repository) WithTx(tx *gorm.DB) *Repository {
+	return &Repository{db: tx}
+}
+
+// LockEnvironment serializes the rotations of the environment until the end of the transaction.
+func (r *Repository) LockEnvironment(ctx context.Context, env string) error {
+	return r.db.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "mux_signing_keys:"+env).Error
+}
+
+// Current retrieves the newest active key of the context environment.
+func (r *Repository) Current(ctx context.Context) (*signingkeymodel.Key, error) {
+	var key signingkeymodel.Key
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", signingkeymodel.StatusActive).
+		Order("created_at DESC").
+		First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// List retrieves the keys of the context environment which are not revoked, newest first.
+func (r *Repository) List(ctx context.Context) ([]*signingkeymodel.Key, error) {
+	var keys []*signingkeymodel.Key
+	if err := r.db.WithContext(ctx).
+		Where("status <> ?", signingkeymodel.StatusRevoked).
+		Order("created_at DESC").
+		Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Create persists the key.
+func (r *Repository) Create(ctx context.Context, key *signingkeymodel.Key) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+// Retire marks the active keys of the context environment other than the key with exceptKeyID as retiring.
+func (r *Repository) Retire(ctx context.Context, exceptKeyID string, now time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).
+		Model(&signingkeymodel.Key{}).
+		Where("status = ? AND key_id <> ?", signingkeymodel.StatusActive, exceptKeyID).
+		Updates(map[string]any{"status": signingkeymodel.StatusRetiring, "retiring_at": now, "updated_at": now})
+	return res.RowsAffected, res.Error
+}
+
+// ListRetired retrieves the retiring keys of the context environment superseded before the cutoff.
+func (r *Repository) ListRetired(ctx context.Context, before time.Time) ([]*signingkeymodel.Key, error) {
+	var keys []*signingkeymodel.Key
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND retiring_at < ?", signingkeymodel.StatusRetiring, before).
+		Order("retiring_at ASC").
+		Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Revoke marks the key as revoked and discards its private key.
+func (r *Repository) Revoke(ctx context.Context, keyID string, now time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&signingkeymodel.Key{}).
+		Where("key_id = ?", keyID).
+		Updates(map[string]any{"status": signingkeymodel.StatusRevoked, "private_key": nil, "revoked_at": now, "updated_at": now}).Error
+}
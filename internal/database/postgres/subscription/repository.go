@@ -0,0 +1,274 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/database/postgres/pagination"
+	subscriptionmodel "github.com/mikhail5545/media-service-go/internal/models/subscription"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormRepository interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) *Repository
+	// Get retrieves the subscription with the ID.
+	Get(ctx context.Context, id uuid.UUID) (*subscriptionmodel.Subscription, error)
+	// List retrieves a page of subscriptions, newest first.
+	List(ctx context.Context, pageSize int, pageToken string) ([]*subscriptionmodel.Subscription, string, error)
+	// ListActive retrieves the active subscriptions.
+	ListActive(ctx context.Context) ([]*subscriptionmodel.Subscription, error)
+	// Create persists a new subscription.
+	Create(ctx context.Context, subscription *subscriptionmodel.Subscription) error
+	// Update updates the columns of the subscription.
+	Update(ctx context.Context, id uuid.UUID, updates map[string]any) (int64, error)
+	// Delete deletes the subscription, its deliveries are deleted by the foreign key.
+	Delete(ctx context.Context, id uuid.UUID) (int64, error)
+	// Enqueue persists new pending deliveries.
+	Enqueue(ctx context.Context, deliveries ...*subscriptionmodel.Delivery) error
+	// ListDeliveries retrieves a page of deliveries of the subscription, newest first.
+	ListDeliveries(ctx context.Context, id uuid.UUID, status subscriptionmodel.DeliveryStatus, pageSize int, pageToken string) ([]*subscriptionmodel.Delivery, string, error)
+	// ClaimDue locks at most limit pending deliveries which are due and postpones their next attempt by
+	// the provided lease, so concurrent dispatchers do not pick the same deliveries.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*subscriptionmodel.Delivery, error)
+	// MarkDelivered records the successful delivery attempt.
+	MarkDelivered(ctx context.Context, id uuid.UUID, responseStatus int) error
+	// MarkRetry records the failed delivery attempt and schedules the next one.
+	MarkRetry(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, responseStatus *int, lastErr string) error
+	// MarkFailed records the failed delivery attempt and stops further delivery.
+	MarkFailed(ctx context.Context, id uuid.UUID, responseStatus *int, lastErr string) error
+	// PurgeDeliveries deletes the delivered and failed deliveries last updated before the cutoff.
+	PurgeDeliveries(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+var _ GormRepository = (*Repository)(nil)
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *Repository) WithTx(tx *gorm.DB) *Repository {
+	return &Repository{db: tx}
+}
+
+// Get retrieves the subscription with the ID.
+func (r *Repository) Get(ctx context.Context, id uuid.UUID) (*subscriptionmodel.Subscription, error) {
+	var subscription subscriptionmodel.Subscription
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&subscription).Error; err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// List retrieves a page of subscriptions, newest first.
+func (r *Repository) List(ctx context.Context, pageSize int, pageToken string) ([]*subscriptionmodel.Subscription, string, error) {
+	db, err := pagination.ApplyCursor(r.db.WithContext(ctx), pagination.ApplyCursorParams{
+		PageSize:   pageSize,
+		PageToken:  pageToken,
+		OrderField: "created_at",
+		OrderDir:   "DESC",
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to apply pagination: %w", err)
+	}
+
+	var subscriptions []*subscriptionmodel.Subscription
+	if err := db.Find(&subscriptions).Error; err != nil {
+		return nil, "", err
+	}
+
+	var nextToken string
+	if len(subscriptions) == pageSize+1 {
+		last := subscriptions[pageSize-1]
+		nextToken = pagination.EncodePageToken(last.CreatedAt, last.ID)
+		subscriptions = subscriptions[:pageSize]
+	}
+	return subscriptions, nextToken, nil
+}
+
+// ListActive retrieves the active subscriptions.
+func (r *Repository) ListActive(ctx context.Context) ([]*subscriptionmodel.Subscription, error) {
+	var subscriptions []*subscriptionmodel.Subscription
+	if err := r.db.WithContext(ctx).Where("active").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// Create persists a new subscription.
+func (r *Repository) Create(ctx context.Context, subscription *subscriptionmodel.Subscription) error {
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+// Update updates the columns of the subscription.
+func (r *Repository) Update(ctx context.Context, id uuid.UUID, updates map[string]any) (int64, error) {
+	res := r.db.WithContext(ctx).Model(&subscriptionmodel.Subscription{}).Where("id = ?", id).Updates(updates)
+	return res.RowsAffected, res.Error
+}
+
+// Delete deletes the subscription, its deliveries are deleted by the foreign key.
+func (r *Repository) Delete(ctx context.Context, id uuid.UUID) (int64, error) {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Delete(&subscriptionmodel.Subscription{})
+	return res.RowsAffected, res.Error
+}
+
+// Enqueue persists new pending deliveries.
+func (r *Repository) Enqueue(ctx context.Context, deliveries ...*subscriptionmodel.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(deliveries).Error
+}
+
+// ListDeliveries retrieves a page of deliveries of the subscription, newest first. All statuses are
+// listed if status is empty.
+func (r *Repository) ListDeliveries(
+	ctx context.Context,
+	id uuid.UUID,
+	status subscriptionmodel.DeliveryStatus,
+	pageSize int,
+	pageToken string,
+) ([]*subscriptionmodel.Delivery, string, error) {
+	db := r.db.WithContext(ctx).Where("subscription_id = ?", id)
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+	db, err := pagination.ApplyCursor(db, pagination.ApplyCursorParams{
+		PageSize:   pageSize,
+		PageToken:  pageToken,
+		OrderField: "created_at",
+		OrderDir:   "DESC",
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to apply pagination: %w", err)
+	}
+
+	var deliveries []*subscriptionmodel.Delivery
+	if err := db.Find(&deliveries).Error; err != nil {
+		return nil, "", err
+	}
+
+	var nextToken string
+	if len(deliveries) == pageSize+1 {
+		last := deliveries[pageSize-1]
+		nextToken = pagination.EncodePageToken(last.CreatedAt, last.ID)
+		deliveries = deliveries[:pageSize]
+	}
+	return deliveries, nextToken, nil
+}
+
+// ClaimDue locks at most limit pending deliveries which are due and postpones their next attempt by
+// the provided lease, so concurrent dispatchers do not pick the same deliveries.
+func (r *Repository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*subscriptionmodel.Delivery, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	var deliveries []*subscriptionmodel.Delivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", subscriptionmodel.DeliveryStatusPending, now).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make(uuid.UUIDs, len(deliveries))
+		for i := range deliveries {
+			ids[i] = deliveries[i].ID
+		}
+		return tx.Model(&subscriptionmodel.Delivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return deliveries, err
+}
+
+// MarkDelivered records the successful delivery attempt.
+func (r *Repository) MarkDelivered(ctx context.Context, id uuid.UUID, responseStatus int) error {
+	return r.db.WithContext(ctx).Model(&subscriptionmodel.Delivery{}).Where("id = ?", id).Updates(map[string]any{
+		"status":          subscriptionmodel.DeliveryStatusDelivered,
+		"attempts":        gorm.Expr("attempts + 1"),
+		"response_status": responseStatus,
+		"delivered_at":    time.Now(),
+		"last_error":      nil,
+	}).Error
+}
+
+// MarkRetry records the failed delivery attempt and schedules the next one.
+func (r *Repository) MarkRetry(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, responseStatus *int, lastErr string) error {
+	return r.db.WithContext(ctx).Model(&subscriptionmodel.Delivery{}).Where("id = ?", id).Updates(map[string]any{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"next_attempt_at": nextAttemptAt,
+		"response_status": responseStatus,
+		"last_error":      truncate(lastErr, 1024),
+	}).Error
+}
+
+// MarkFailed records the failed delivery attempt and stops further delivery.
+func (r *Repository) MarkFailed(ctx context.Context, id uuid.UUID, responseStatus *int, lastErr string) error {
+	return r.db.WithContext(ctx).Model(&subscriptionmodel.Delivery{}).Where("id = ?", id).Updates(map[string]any{
+		"status":          subscriptionmodel.DeliveryStatusFailed,
+		"attempts":        gorm.Expr("attempts + 1"),
+		"response_status": responseStatus,
+		"last_error":      truncate(lastErr, 1024),
+	}).Error
+}
+
+// PurgeDeliveries deletes the delivered and failed deliveries last updated before the cutoff.
+func (r *Repository) PurgeDeliveries(ctx context.Context, cutoff time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).
+		Where("status IN ? AND updated_at < ?", []subscriptionmodel.DeliveryStatus{
+			subscriptionmodel.DeliveryStatusDelivered,
+			subscriptionmodel.DeliveryStatusFailed,
+		}, cutoff).
+		Delete(&subscriptionmodel.Delivery{})
+	return res.RowsAffected, res.Error
+}
+
+// truncate shortens s to at most n bytes without splitting a multi-byte rune,
+// since Postgres rejects text values that are not valid UTF-8.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
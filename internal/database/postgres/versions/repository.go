@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package versions
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	versionmodel "github.com/mikhail5545/media-service-go/internal/models/versions"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Owner identifies the owner of a version chain.
+type Owner struct {
+	Provider  versionmodel.Provider
+	OwnerType string
+	OwnerID   uuid.UUID
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *Repository) WithTx(tx *gorm.DB) *Repository {
+	return &Repository{db: tx}
+}
+
+func (r *Repository) owner(ctx context.Context, owner Owner) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Where("provider = ? AND owner_type = ? AND owner_id = ?", owner.Provider, owner.OwnerType, owner.OwnerID)
+}
+
+// List retrieves the versions of the owner, oldest first.
+func (r *Repository) List(ctx context.Context, owner Owner) ([]*versionmodel.Version, error) {
+	var versions []*versionmodel.Version
+	if err := r.owner(ctx, owner).Order("number ASC").Find(&versions).Error; err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// Get retrieves the version of the owner with the number, gorm.ErrRecordNotFound is returned if
+// there is none.
+func (r *Repository) Get(ctx context.Context, owner Owner, number int) (*versionmodel.Version, error) {
+	var version versionmodel.Version
+	if err := r.owner(ctx, owner).Where("number = ?", number).First(&version).Error; err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// LatestForUpdate retrieves and locks the current version of the owner, nil is returned if the owner
+// has no versions. It must be called using the transactional repository (see WithTx), so concurrent
+// replacements of the owner are recorded one after another.
+func (r *Repository) LatestForUpdate(ctx context.Context, owner Owner) (*versionmodel.Version, error) {
+	var version versionmodel.Version
+	err := r.owner(ctx, owner).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Order("number DESC").
+		First(&version).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// Create persists the versions.
+func (r *Repository) Create(ctx context.Context, versions ...*versionmodel.Version) error {
+	if len(versions) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(versions).Error
+}
+
+// Supersede links the version to the asset which replaced it.
+func (r *Repository) Supersede(ctx context.Context, id, successorID uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&versionmodel.Version{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"successor_id":  successorID,
+			"superseded_at": at,
+		}).Error
+}
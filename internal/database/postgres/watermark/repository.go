@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package watermark
+
+import (
+	"context"
+
+	watermarkmodel "github.com/mikhail5545/media-service-go/internal/models/watermark"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormRepository interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) *Repository
+	// Get retrieves the watermark of the scope and key.
+	Get(ctx context.Context, scope watermarkmodel.Scope, key string) (*watermarkmodel.Watermark, error)
+	// List retrieves the watermarks of the scope, or of every scope if it is empty.
+	List(ctx context.Context, scope watermarkmodel.Scope) ([]*watermarkmodel.Watermark, error)
+	// Find retrieves the watermarks of the creator and of the owner types.
+	Find(ctx context.Context, creatorID string, ownerTypes []string) ([]*watermarkmodel.Watermark, error)
+	// Upsert creates the watermark or replaces the one with the same scope and key.
+	Upsert(ctx context.Context, watermark *watermarkmodel.Watermark) error
+	// Delete deletes the watermark of the scope and key.
+	Delete(ctx context.Context, scope watermarkmodel.Scope, key string) (int64, error)
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+var _ GormRepository = (*Repository)(nil)
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *Repository) WithTx(tx *gorm.DB) *Repository {
+	return &Repository{db: tx}
+}
+
+// Get retrieves the watermark of the scope and key.
+func (r *Repository) Get(ctx context.Context, scope watermarkmodel.Scope, key string) (*watermarkmodel.Watermark, error) {
+	var watermark watermarkmodel.Watermark
+	if err := r.db.WithContext(ctx).Where("scope = ? AND key = ?", scope, key).First(&watermark).Error; err != nil {
+		return nil, err
+	}
+	return &watermark, nil
+}
+
+// List retrieves the watermarks of the scope, or of every scope if it is empty.
+func (r *Repository) List(ctx context.Context, scope watermarkmodel.Scope) ([]*watermarkmodel.Watermark, error) {
+	db := r.db.WithContext(ctx)
+	if scope != "" {
+		db = db.Where("scope = ?", scope)
+	}
+	var watermarks []*watermarkmodel.Watermark
+	if err := db.Order("scope ASC, key ASC").Find(&watermarks).Error; err != nil {
+		return nil, err
+	}
+	return watermarks, nil
+}
+
+// Find retrieves the watermarks of the creator and of the owner types.
+func (r *Repository) Find(ctx context.Context, creatorID string, ownerTypes []string) ([]*watermarkmodel.Watermark, error) {
+	cond := r.db.Where("scope = ? AND key = ?", watermarkmodel.ScopeCreator, creatorID)
+	if len(ownerTypes) > 0 {
+		cond = cond.Or("scope = ? AND key IN ?", watermarkmodel.ScopeOwnerType, ownerTypes)
+	}
+	var watermarks []*watermarkmodel.Watermark
+	if err := r.db.WithContext(ctx).Where(cond).Find(&watermarks).Error; err != nil {
+		return nil, err
+	}
+	return watermarks, nil
+}
+
+// Upsert creates the watermark or replaces the one with the same scope and key.
+func (r *Repository) Upsert(ctx context.Context, watermark *watermarkmodel.Watermark) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "scope"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"updated_at", "cloudinary_public_id", "image_url", "position", "margin", "width", "opacity",
+		}),
+	}).Create(watermark).Error
+}
+
+// Delete deletes the watermark of the scope and key.
+func (r *Repository) Delete(ctx context.Context, scope watermarkmodel.Scope, key string) (int64, error) {
+	res := r.db.WithContext(ctx).Where("scope = ? AND key = ?", scope, key).Delete(&watermarkmodel.Watermark{})
+	return res.RowsAffected, res.Error
+}
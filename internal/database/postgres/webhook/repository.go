@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	webhookmodel "github.com/mikhail5545/media-service-go/internal/models/webhook"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormRepository interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) *Repository
+	// Enqueue persists received webhook events.
+	Enqueue(ctx context.Context, events ...*webhookmodel.Event) error
+	// ClaimDue locks at most limit pending events which are due for processing and postpones their next
+	// attempt by the provided lease, so concurrent workers do not pick the same events. Only the oldest
+	// pending event of each object is claimed.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*webhookmodel.Event, error)
+	// MarkProcessed marks the event as successfully processed.
+	MarkProcessed(ctx context.Context, id uuid.UUID) error
+	// MarkRetry records failed processing attempt and schedules the next one.
+	MarkRetry(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastErr string) error
+	// MarkFailed records failed processing attempt and stops further processing of the event.
+	MarkFailed(ctx context.Context, id uuid.UUID, lastErr string) error
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+var _ GormRepository = (*Repository)(nil)
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *Repository) WithTx(tx *gorm.DB) *Repository {
+	return &Repository{db: tx}
+}
+
+// Enqueue persists received webhook events.
+func (r *Repository) Enqueue(ctx context.Context, events ...*webhookmodel.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(events).Error
+}
+
+// ClaimDue locks at most limit pending events which are due for processing and postpones their next
+// attempt by the provided lease, so concurrent workers do not pick the same events. Only the oldest
+// pending event of each object is claimed, the later ones wait until it is processed or failed, so
+// the events of an object are processed in the order they were received even when they are retried.
+func (r *Repository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*webhookmodel.Event, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	var events []*webhookmodel.Event
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", webhookmodel.StatusPending, now).
+			Where(`object_id IS NULL OR NOT EXISTS (
+				SELECT 1 FROM webhook_events e
+				WHERE e.provider = webhook_events.provider AND e.object_id = webhook_events.object_id
+					AND e.status = ? AND e.id < webhook_events.id
+			)`, webhookmodel.StatusPending).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Find(&events).Error
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := make(uuid.UUIDs, len(events))
+		for i := range events {
+			ids[i] = events[i].ID
+		}
+		return tx.Model(&webhookmodel.Event{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return events, err
+}
+
+// MarkProcessed marks the event as successfully processed.
+func (r *Repository) MarkProcessed(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&webhookmodel.Event{}).Where("id = ?", id).Updates(map[string]any{
+		"status":       webhookmodel.StatusProcessed,
+		"attempts":     gorm.Expr("attempts + 1"),
+		"processed_at": time.Now(),
+		"last_error":   nil,
+	}).Error
+}
+
+// MarkRetry records failed processing attempt and schedules the next one.
+func (r *Repository) MarkRetry(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastErr string) error {
+	return r.db.WithContext(ctx).Model(&webhookmodel.Event{}).Where("id = ?", id).Updates(map[string]any{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      truncate(lastErr, 1024),
+	}).Error
+}
+
+// MarkFailed records failed processing attempt and stops further processing of the event.
+func (r *Repository) MarkFailed(ctx context.Context, id uuid.UUID, lastErr string) error {
+	return r.db.WithContext(ctx).Model(&webhookmodel.Event{}).Where("id = ?", id).Updates(map[string]any{
+		"status":     webhookmodel.StatusFailed,
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": truncate(lastErr, 1024),
+	}).Error
+}
+
+// Requeue resets the event to pending with a fresh attempt budget, so it is claimed on the next poll
+// regardless of its previous outcome. It returns [gorm.ErrRecordNotFound] if the event does not exist.
+func (r *Repository) Requeue(ctx context.Context, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Model(&webhookmodel.Event{}).Where("id = ?", id).Updates(map[string]any{
+		"status":          webhookmodel.StatusPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+		"processed_at":    nil,
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListErrors retrieves at most limit events with a processing error, most recently updated first. The
+// events of all providers are listed if provider is empty. Payloads are not loaded.
+func (r *Repository) ListErrors(ctx context.Context, provider webhookmodel.Provider, limit int) ([]*webhookmodel.Event, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	db := r.db.WithContext(ctx).
+		Select("id, created_at, updated_at, provider, environment, object_id, type, status, attempts, next_attempt_at, last_error, processed_at").
+		Where("last_error IS NOT NULL")
+	if provider != "" {
+		db = db.Where("provider = ?", provider)
+	}
+	var events []*webhookmodel.Event
+	err := db.Order("updated_at DESC, id DESC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// truncate shortens s to at most n bytes without splitting a multi-byte rune,
+// since Postgres rejects text values that are not valid UTF-8.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package deadline splits the deadline of a request into the budgets of the calls it makes. The
+// transport middlewares attach a per-route deadline to every request, the external API and
+// downstream gRPC calls get shorter sub-deadlines, so an operation whose call timed out still has
+// time to clean up and respond.
+package deadline
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CleanupTimeout bounds the compensating actions of operations interrupted by their deadline.
+const CleanupTimeout = 10 * time.Second
+
+// Sub returns a copy of ctx whose deadline is at most timeout from now and leaves reserve of the
+// parent deadline to the caller. At least half of the remaining time is always given to the call,
+// so a short parent deadline is not used up by the reserve. A zero timeout only applies the reserve.
+func Sub(ctx context.Context, timeout, reserve time.Duration) (context.Context, context.CancelFunc) {
+	if parent, ok := ctx.Deadline(); ok {
+		remaining := time.Until(parent)
+		budget := max(remaining-reserve, remaining/2)
+		if timeout <= 0 || budget < timeout {
+			timeout = budget
+		}
+	}
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// Cleanup returns a context for the compensating actions of an operation which failed, e.g. because
+// its deadline was exceeded. It keeps the values of ctx, but neither its deadline nor its
+// cancellation, and expires after [CleanupTimeout].
+func Cleanup(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), CleanupTimeout)
+}
+
+// Exceeded reports whether err was caused by an exceeded deadline, either of the local context or
+// of a downstream gRPC call.
+func Exceeded(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	return errors.As(err, &grpcErr) && grpcErr.GRPCStatus().Code() == codes.DeadlineExceeded
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package deadline
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Routes holds the deadlines attached to the requests by the transport middlewares.
+type Routes struct {
+	// Default is the deadline of the requests whose route has none, 0 disables it.
+	Default time.Duration
+	// Overrides are keyed by the method and the Echo route, e.g. "POST /api/v1/admin/cloudinary/upload",
+	// or by the full gRPC method name. 0 disables the deadline of the route.
+	Overrides map[string]time.Duration
+}
+
+func (r Routes) timeout(route string) time.Duration {
+	if timeout, ok := r.Overrides[route]; ok {
+		return timeout
+	}
+	return r.Default
+}
+
+// EchoMiddleware attaches the deadline of the route to the request context. Requests which exceed
+// it fail with [serviceerrors.ErrDeadlineExceeded], which is reported as 504 Gateway Timeout.
+func EchoMiddleware(routes Routes) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			timeout := routes.timeout(req.Method + " " + c.Path())
+			if timeout <= 0 {
+				return next(c)
+			}
+			ctx, cancel := context.WithTimeout(req.Context(), timeout)
+			defer cancel()
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+			if err != nil && Exceeded(err) && !errors.Is(err, serviceerrors.ErrDeadlineExceeded) {
+				return serviceerrors.NewDeadlineExceededError(err)
+			}
+			if err == nil && !c.Response().Committed && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return serviceerrors.NewDeadlineExceededError("request did not complete within " + timeout.String())
+			}
+			return err
+		}
+	}
+}
+
+// UnaryInterceptor attaches the deadline of the method to calls whose caller did not set an earlier
+// one. Calls which exceed a deadline of the service fail with codes.Unavailable, so callers retry
+// them like the calls of an unavailable dependency.
+func UnaryInterceptor(routes Routes) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		timeout := routes.timeout(info.FullMethod)
+		if timeout > 0 {
+			if parent, ok := ctx.Deadline(); !ok || time.Until(parent) > timeout {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+		}
+		resp, err := handler(ctx, req)
+		if err != nil && Exceeded(err) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,382 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package emulator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/google/uuid"
+	cldapiclient "github.com/mikhail5545/media-service-go/internal/apiclients/cloudinary"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	cldtypes "github.com/mikhail5545/media-service-go/internal/models/cloudinary/types"
+	webhookmodel "github.com/mikhail5545/media-service-go/internal/models/webhook"
+)
+
+// CloudinaryUploadPath is the path of the emulated Cloudinary upload API relative to the API base
+// URL. It accepts the multipart form of a signed Cloudinary upload, without checking the signature.
+const CloudinaryUploadPath = "/dev/cloudinary/upload"
+
+// cloudinaryAPIKey is the API key reported by the emulator, the signed upload parameters carry it.
+const cloudinaryAPIKey = "dev"
+
+// Cloudinary emulates the Cloudinary API. Uploaded files are not kept, only their descriptions.
+type Cloudinary struct {
+	baseURL string
+
+	mu     sync.Mutex
+	assets map[string]*api.BriefAssetResult
+	// order keeps the upload order of the public IDs, which is the listing order.
+	order []string
+	// backups keeps every stored version of the public IDs, oldest first, also after the deletion.
+	backups map[string][]api.BriefAssetResult
+}
+
+var _ cldapiclient.APIClient = (*Cloudinary)(nil)
+
+func newCloudinary(baseURL string) *Cloudinary {
+	return &Cloudinary{
+		baseURL: baseURL,
+		assets:  make(map[string]*api.BriefAssetResult),
+		backups: make(map[string][]api.BriefAssetResult),
+	}
+}
+
+// Ping always succeeds.
+func (c *Cloudinary) Ping(context.Context) error {
+	return nil
+}
+
+// SignUploadParams returns a signature derived from the parameters, it is not verified by the
+// emulated upload API.
+func (c *Cloudinary) SignUploadParams(_ context.Context, params url.Values) (string, error) {
+	sum := sha256.Sum256([]byte(params.Encode()))
+	return hex.EncodeToString(sum[:20]), nil
+}
+
+// VerifyNotificationSignature accepts every notification.
+func (c *Cloudinary) VerifyNotificationSignature(context.Context, *cldapiclient.VerificationParams) bool {
+	return true
+}
+
+func (c *Cloudinary) GetApiKey(context.Context) string {
+	return cloudinaryAPIKey
+}
+
+func (c *Cloudinary) AddTags(_ context.Context, publicID, _ string, tags []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if asset, ok := c.assets[publicID]; ok {
+		for _, tag := range tags {
+			if !slices.Contains(asset.Tags, tag) {
+				asset.Tags = append(asset.Tags, tag)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Cloudinary) RemoveTags(_ context.Context, publicID, _ string, tags []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if asset, ok := c.assets[publicID]; ok {
+		asset.Tags = slices.DeleteFunc(asset.Tags, func(tag string) bool { return slices.Contains(tags, tag) })
+	}
+	return nil
+}
+
+func (c *Cloudinary) UpdateModeration(context.Context, string, string, string) error {
+	return nil
+}
+
+// DeleteAsset deletes the asset, deleting an unknown asset succeeds like in the Cloudinary API. The
+// stored versions are kept as the backup of the asset.
+func (c *Cloudinary) DeleteAsset(_ context.Context, publicID string, _ string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.assets, publicID)
+	c.order = slices.DeleteFunc(c.order, func(id string) bool { return id == publicID })
+	return nil
+}
+
+// ListAssets lists the assets of the resource type in upload order. The cursor is the offset of the
+// next page.
+func (c *Cloudinary) ListAssets(_ context.Context, resourceType string, maxResults int, nextCursor string) (*admin.AssetsResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if maxResults <= 0 {
+		maxResults = 10
+	}
+	offset := 0
+	if nextCursor != "" {
+		var err error
+		if offset, err = strconv.Atoi(nextCursor); err != nil {
+			return nil, fmt.Errorf("invalid cursor %q", nextCursor)
+		}
+	}
+	res := &admin.AssetsResult{}
+	for i := offset; i < len(c.order); i++ {
+		asset := c.assets[c.order[i]]
+		if asset.AssetType != resourceType {
+			continue
+		}
+		if len(res.Assets) == maxResults {
+			res.NextCursor = strconv.Itoa(i)
+			break
+		}
+		res.Assets = append(res.Assets, *asset)
+	}
+	return res, nil
+}
+
+// Upload stores the description of the file.
+func (c *Cloudinary) Upload(_ context.Context, file io.Reader, params *cldapiclient.UploadParams) (*uploader.UploadResult, error) {
+	asset, err := c.Store(params.PublicID, file)
+	if err != nil {
+		return nil, err
+	}
+	return &uploader.UploadResult{
+		AssetID:      asset.AssetID,
+		PublicID:     asset.PublicID,
+		DisplayName:  asset.DisplayName,
+		Version:      asset.Version,
+		Width:        asset.Width,
+		Height:       asset.Height,
+		Format:       asset.Format,
+		ResourceType: asset.AssetType,
+		CreatedAt:    asset.CreatedAt,
+		Bytes:        asset.Bytes,
+		Type:         asset.Type,
+		Etag:         deriveID("etag", asset.PublicID),
+		URL:          asset.URL,
+		SecureURL:    asset.SecureURL,
+		AccessMode:   asset.AccessMode,
+	}, nil
+}
+
+// Enrich returns fixed labels and colors.
+func (c *Cloudinary) Enrich(context.Context, string, string, *cldapiclient.EnrichParams) (*cldapiclient.EnrichResult, error) {
+	return &cldapiclient.EnrichResult{
+		Labels:         []string{"dev"},
+		DominantColors: []string{"#808080"},
+	}, nil
+}
+
+// ListBackupVersions lists the stored versions of the public ID, newest first.
+func (c *Cloudinary) ListBackupVersions(_ context.Context, publicID, _ string) ([]cldapiclient.BackupVersion, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	backups := c.backups[publicID]
+	versions := make([]cldapiclient.BackupVersion, 0, len(backups))
+	for i := len(backups) - 1; i >= 0; i-- {
+		versions = append(versions, cldapiclient.BackupVersion{
+			VersionID:  backupVersionID(&backups[i]),
+			Version:    int64(backups[i].Version),
+			Format:     backups[i].Format,
+			Bytes:      int64(backups[i].Bytes),
+			CreatedAt:  backups[i].CreatedAt,
+			Restorable: true,
+		})
+	}
+	return versions, nil
+}
+
+// RestoreAsset stores the version of the public ID again, the latest version if versionID is empty.
+func (c *Cloudinary) RestoreAsset(_ context.Context, publicID, _ string, versionID string) (*api.BriefAssetResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	backups := c.backups[publicID]
+	idx := len(backups) - 1
+	if versionID != "" {
+		idx = slices.IndexFunc(backups, func(b api.BriefAssetResult) bool { return backupVersionID(&b) == versionID })
+	}
+	if idx < 0 {
+		return nil, fmt.Errorf("failed to restore asset: no backup of %s", publicID)
+	}
+	asset := backups[idx]
+	if _, ok := c.assets[publicID]; !ok {
+		c.order = append(c.order, publicID)
+	}
+	c.assets[publicID] = &asset
+	a := asset
+	return &a, nil
+}
+
+// GetUsage reports the storage of the stored assets, the emulator does not account the bandwidth.
+func (c *Cloudinary) GetUsage(context.Context, time.Time) (*admin.UsageResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	res := &admin.UsageResult{Plan: "emulator"}
+	for _, asset := range c.assets {
+		res.Storage.Usage += int64(asset.Bytes)
+		res.Resources++
+	}
+	return res, nil
+}
+
+func backupVersionID(asset *api.BriefAssetResult) string {
+	return deriveID("version", asset.PublicID+"/"+strconv.Itoa(asset.Version))
+}
+
+// Store reads the file uploaded under the public ID and records its description. The resource type
+// and format are detected from the content. The asset ID and delivery URL are derived from the
+// public ID.
+func (c *Cloudinary) Store(publicID string, file io.Reader) (*api.BriefAssetResult, error) {
+	if publicID == "" {
+		return nil, fmt.Errorf("public ID is required")
+	}
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("failed to read the file: %w", err)
+	}
+	rest, err := io.Copy(io.Discard, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the file: %w", err)
+	}
+	resourceType, format := sniff(head[:n])
+
+	deliveryURL := fmt.Sprintf("%s/dev/cloudinary/%s/upload/v1/%s.%s", c.baseURL, resourceType, publicID, format)
+	asset := &api.BriefAssetResult{
+		AssetID:     deriveID("asset", publicID),
+		PublicID:    publicID,
+		DisplayName: publicID[strings.LastIndex(publicID, "/")+1:],
+		Format:      format,
+		AssetType:   resourceType,
+		Type:        "upload",
+		CreatedAt:   time.Now().UTC(),
+		Bytes:       n + int(rest),
+		AccessMode:  "public",
+		URL:         deliveryURL,
+		SecureURL:   deliveryURL,
+	}
+	if resourceType == "image" || resourceType == "video" {
+		asset.Width, asset.Height = 1920, 1080
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.assets[publicID]; !ok {
+		c.order = append(c.order, publicID)
+	}
+	asset.Version = len(c.backups[publicID]) + 1
+	asset.Backup = true
+	c.assets[publicID] = asset
+	c.backups[publicID] = append(c.backups[publicID], *asset)
+	a := *asset
+	return &a, nil
+}
+
+// Fire returns the notification of the event of the asset with the public ID. The created and ready
+// events both send the upload notification, the errored event rejects the asset by moderation.
+func (c *Cloudinary) Fire(publicID, event string) (Webhook, error) {
+	c.mu.Lock()
+	asset, ok := c.assets[publicID]
+	var a api.BriefAssetResult
+	if ok {
+		a = *asset
+	}
+	c.mu.Unlock()
+	if !ok {
+		return Webhook{}, fmt.Errorf("%w: Cloudinary asset %s", ErrNotFound, publicID)
+	}
+
+	now := time.Now().UTC()
+	var notification any
+	switch event {
+	case EventCreated, EventReady:
+		notification = &assetmodel.CloudinaryUploadWebhook{
+			NotificationType: "upload",
+			Timestamp:        now,
+			RequestID:        uuid.NewString(),
+			AssetID:          a.AssetID,
+			PublicID:         a.PublicID,
+			Width:            a.Width,
+			Height:           a.Height,
+			Bytes:            int64(a.Bytes),
+			Format:           a.Format,
+			ResourceType:     a.AssetType,
+			CreatedAt:        a.CreatedAt,
+			Tags:             a.Tags,
+			Url:              a.URL,
+			SecureUrl:        a.SecureURL,
+			DisplayName:      a.DisplayName,
+			Etag:             deriveID("etag", a.PublicID),
+			Phash:            deriveID("phash", a.PublicID),
+			ApiKey:           cloudinaryAPIKey,
+			NotificationContext: assetmodel.NotificationContext{
+				TriggeredAt: now,
+			},
+		}
+	case EventErrored:
+		notification = &cldtypes.CloudinaryModerationWebhook{
+			NotificationType:    "moderation",
+			Timestamp:           now,
+			RequestID:           uuid.NewString(),
+			AssetID:             a.AssetID,
+			PublicID:            a.PublicID,
+			ModerationKind:      "manual",
+			ModerationStatus:    "rejected",
+			ModerationUpdatedAt: now,
+		}
+	default:
+		return Webhook{}, fmt.Errorf("unknown event %q, expected %s, %s or %s", event, EventCreated, EventReady, EventErrored)
+	}
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return Webhook{}, fmt.Errorf("failed to encode Cloudinary notification: %w", err)
+	}
+	var head struct {
+		NotificationType string `json:"notification_type"`
+	}
+	_ = json.Unmarshal(payload, &head)
+	return Webhook{Provider: webhookmodel.ProviderCloudinary, Type: head.NotificationType, Payload: payload}, nil
+}
+
+// sniff returns the Cloudinary resource type and format of the content.
+func sniff(head []byte) (resourceType, format string) {
+	contentType, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	kind, subtype, _ := strings.Cut(contentType, "/")
+	switch {
+	case kind == "image":
+		if subtype == "jpeg" {
+			subtype = "jpg"
+		}
+		return "image", strings.TrimSuffix(subtype, "+xml")
+	case kind == "video":
+		return "video", subtype
+	case contentType == "application/pdf":
+		return "image", "pdf"
+	default:
+		return "raw", "bin"
+	}
+}
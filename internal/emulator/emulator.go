@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package emulator implements in-memory emulators of the MUX and Cloudinary APIs for the dev mode,
+// so the service runs without provider accounts. Uploads are received by the service itself, see
+// the dev handler, and the provider webhooks are synthesized and queued like verified ones.
+//
+// Provider identifiers are derived from the identifiers of the service, so the playback and
+// delivery URLs of an asset are the same on every run. The state is lost on restart.
+package emulator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	webhookmodel "github.com/mikhail5545/media-service-go/internal/models/webhook"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+// Events which can be fired for an emulated asset.
+const (
+	EventCreated = "asset.created"
+	EventReady   = "asset.ready"
+	EventErrored = "asset.errored"
+)
+
+// Config holds the configuration of the emulators.
+type Config struct {
+	// BaseURL is the public URL of the service, the emulated upload URLs point to it.
+	BaseURL string
+}
+
+// Emulators holds the emulators of all providers.
+type Emulators struct {
+	Mux        *Mux
+	Cloudinary *Cloudinary
+}
+
+func New(cfg Config) *Emulators {
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+	return &Emulators{
+		Mux:        newMux(baseURL),
+		Cloudinary: newCloudinary(baseURL),
+	}
+}
+
+// Webhook is a synthesized provider webhook, ready to be queued.
+type Webhook struct {
+	Provider webhookmodel.Provider
+	Type     string
+	Payload  []byte
+}
+
+// FireRequest fires a synthetic webhook for an emulated asset.
+type FireRequest struct {
+	// Provider is either mux or cloudinary.
+	Provider webhookmodel.Provider `json:"provider"`
+	// Event is one of asset.created, asset.ready and asset.errored.
+	Event string `json:"event"`
+	// ID is the MUX asset or upload ID, or the Cloudinary public ID.
+	ID string `json:"id"`
+}
+
+func (req FireRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Provider, validation.Required, validation.In(webhookmodel.ProviderMux, webhookmodel.ProviderCloudinary)),
+		validation.Field(&req.Event, validation.Required, validation.In(EventCreated, EventReady, EventErrored)),
+		validation.Field(&req.ID, validation.Required),
+	)
+}
+
+// FireResponse describes the queued synthetic webhook.
+type FireResponse struct {
+	Provider webhookmodel.Provider `json:"provider"`
+	Type     string                `json:"type"`
+}
+
+// MuxUploadRequest receives the file of an emulated MUX direct upload.
+type MuxUploadRequest struct {
+	ID string `param:"id"`
+}
+
+// CloudinaryUploadForm holds the form fields of an emulated Cloudinary upload read by the
+// emulator, the other fields of a signed upload are ignored.
+type CloudinaryUploadForm struct {
+	PublicID string `form:"public_id"`
+}
+
+// Fire returns the webhook of the event of the emulated asset.
+func (e *Emulators) Fire(req *FireRequest) (Webhook, error) {
+	switch req.Provider {
+	case webhookmodel.ProviderMux:
+		return e.Mux.Fire(req.ID, req.Event)
+	case webhookmodel.ProviderCloudinary:
+		return e.Cloudinary.Fire(req.ID, req.Event)
+	}
+	return Webhook{}, fmt.Errorf("provider %q is not emulated", req.Provider)
+}
+
+// deriveID returns an identifier derived from the seed, stable across runs.
+func deriveID(prefix, seed string) string {
+	sum := sha256.Sum256([]byte(prefix + ":" + seed))
+	return prefix + "-" + hex.EncodeToString(sum[:12])
+}
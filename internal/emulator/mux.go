@@ -0,0 +1,446 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package emulator
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	muxapiclient "github.com/mikhail5545/media-service-go/internal/apiclients/mux"
+	webhookmodel "github.com/mikhail5545/media-service-go/internal/models/webhook"
+	mux "github.com/muxinc/mux-go/v6"
+)
+
+// MuxUploadPath is the path of the emulated MUX direct uploads relative to the API base URL. The
+// file is uploaded with PUT to MuxUploadPath/{upload id}, like to a MUX upload URL.
+const MuxUploadPath = "/dev/mux/uploads"
+
+// ErrNotFound is returned for unknown uploads and assets. The MUX errors also match
+// [mux.NotFoundError], like the errors of the MUX API.
+var ErrNotFound = errors.New("not found")
+
+// ErrConflict is returned when an upload is not waiting for the file anymore.
+var ErrConflict = errors.New("conflict")
+
+// Mux emulates the MUX API. Uploaded assets become ready right away, with a fixed duration, aspect
+// ratio and resolution.
+type Mux struct {
+	baseURL string
+
+	mu      sync.Mutex
+	uploads map[string]*mux.Upload
+	assets  map[string]*mux.Asset
+	// order keeps the creation order of the assets, which is the listing order.
+	order []string
+	// restrictions are not enforced, the emulated playback URLs do not verify the tokens.
+	restrictions map[string]*mux.PlaybackRestriction
+	// signingKeys holds the IDs of the signing keys, the emulated tokens are not signed with them.
+	signingKeys map[string]struct{}
+}
+
+var _ muxapiclient.APIClient = (*Mux)(nil)
+
+func newMux(baseURL string) *Mux {
+	return &Mux{
+		baseURL: baseURL,
+		uploads: make(map[string]*mux.Upload),
+		assets:  make(map[string]*mux.Asset),
+
+		restrictions: make(map[string]*mux.PlaybackRestriction),
+		signingKeys:  make(map[string]struct{}),
+	}
+}
+
+func muxNotFound(kind, id string) error {
+	return fmt.Errorf("%w: MUX %s %s: %w", ErrNotFound, kind, id, mux.NotFoundError{})
+}
+
+// Ping always succeeds.
+func (m *Mux) Ping(context.Context) error {
+	return nil
+}
+
+// CreateDirectUploadURL creates a waiting upload whose URL points to the emulated upload endpoint.
+// The upload ID is derived from the external ID of the metadata.
+func (m *Mux) CreateDirectUploadURL(_ context.Context, meta *mux.AssetMetadata, drmConfigurationID string, _ []mux.InputSettings, encoding muxapiclient.EncodingSettings, policies ...mux.PlaybackPolicy) (*mux.UploadResponse, error) {
+	seed := uuid.NewString()
+	if meta != nil && meta.ExternalId != "" {
+		seed = meta.ExternalId
+	}
+	settings := mux.Asset{
+		IngestType:        "on_demand_direct_upload",
+		Test:              true,
+		MaxResolutionTier: encoding.MaxResolutionTier,
+		VideoQuality:      encoding.VideoQuality,
+		Mp4Support:        encoding.MP4Support,
+		NormalizeAudio:    encoding.NormalizeAudio,
+	}
+	if meta != nil {
+		settings.Meta = *meta
+	}
+	if drmConfigurationID != "" {
+		if len(policies) > 0 {
+			return nil, fmt.Errorf("playback policies cannot be combined with a DRM configuration")
+		}
+		settings.PlaybackIds = []mux.PlaybackId{{Policy: mux.DRM, DrmConfigurationId: drmConfigurationID}}
+	}
+	for _, policy := range policies {
+		settings.PlaybackIds = append(settings.PlaybackIds, mux.PlaybackId{Policy: policy})
+	}
+	upload := &mux.Upload{
+		Id:               deriveID("upload", seed),
+		Timeout:          3600,
+		Status:           "waiting",
+		NewAssetSettings: settings,
+		CorsOrigin:       "*",
+		Test:             true,
+	}
+	upload.Url = m.baseURL + MuxUploadPath + "/" + upload.Id
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.uploads[upload.Id] = upload
+	return &mux.UploadResponse{Data: *upload}, nil
+}
+
+func (m *Mux) GetDirectUpload(_ context.Context, uploadID string) (*mux.Upload, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	upload, ok := m.uploads[uploadID]
+	if !ok {
+		return nil, muxNotFound("upload", uploadID)
+	}
+	u := *upload
+	return &u, nil
+}
+
+func (m *Mux) CancelDirectUpload(_ context.Context, uploadID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	upload, ok := m.uploads[uploadID]
+	if !ok {
+		return muxNotFound("upload", uploadID)
+	}
+	upload.Status = "cancelled"
+	return nil
+}
+
+func (m *Mux) GetAsset(_ context.Context, assetID string) (*mux.Asset, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	asset, ok := m.assets[assetID]
+	if !ok {
+		return nil, muxNotFound("asset", assetID)
+	}
+	a := *asset
+	return &a, nil
+}
+
+func (m *Mux) DeleteAsset(_ context.Context, assetID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.assets[assetID]; !ok {
+		return muxNotFound("asset", assetID)
+	}
+	delete(m.assets, assetID)
+	m.order = slices.DeleteFunc(m.order, func(id string) bool { return id == assetID })
+	return nil
+}
+
+func (m *Mux) UpdateAsset(_ context.Context, assetID string, update *mux.UpdateAssetRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	asset, ok := m.assets[assetID]
+	if !ok {
+		return muxNotFound("asset", assetID)
+	}
+	if update.Passthrough != "" {
+		asset.Passthrough = update.Passthrough
+	}
+	if update.Meta != (mux.AssetMetadata{}) {
+		asset.Meta = update.Meta
+	}
+	return nil
+}
+
+// CreatePlaybackID adds a playback ID derived from the asset ID and the policy.
+func (m *Mux) CreatePlaybackID(_ context.Context, assetID string, policy mux.PlaybackPolicy) (*mux.PlaybackId, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	asset, ok := m.assets[assetID]
+	if !ok {
+		return nil, muxNotFound("asset", assetID)
+	}
+	id := mux.PlaybackId{Id: deriveID("playback", assetID+"/"+string(policy)+"/"+strconv.Itoa(len(asset.PlaybackIds))), Policy: policy}
+	asset.PlaybackIds = append(asset.PlaybackIds, id)
+	return &id, nil
+}
+
+func (m *Mux) DeletePlaybackID(_ context.Context, assetID, playbackID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	asset, ok := m.assets[assetID]
+	if !ok {
+		return muxNotFound("asset", assetID)
+	}
+	asset.PlaybackIds = slices.DeleteFunc(asset.PlaybackIds, func(p mux.PlaybackId) bool { return p.Id == playbackID })
+	return nil
+}
+
+// ListAssets lists the assets in creation order, page is 1-based like in the MUX API.
+func (m *Mux) ListAssets(_ context.Context, limit, page int32) ([]mux.Asset, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if limit <= 0 {
+		limit = 25
+	}
+	start := int(max(page-1, 0) * limit)
+	if start >= len(m.order) {
+		return nil, nil
+	}
+	end := min(start+int(limit), len(m.order))
+	assets := make([]mux.Asset, 0, end-start)
+	for _, id := range m.order[start:end] {
+		assets = append(assets, *m.assets[id])
+	}
+	return assets, nil
+}
+
+// ListAssetViews returns no views, the emulator does not collect MUX Data.
+func (m *Mux) ListAssetViews(context.Context, time.Time, time.Time, int32, int32) ([]mux.BreakdownValue, error) {
+	return nil, nil
+}
+
+// GetTranscript returns a fixed transcript.
+func (m *Mux) GetTranscript(_ context.Context, playbackID, trackID string) (string, error) {
+	return fmt.Sprintf("Transcript of the emulated track %s of %s.", trackID, playbackID), nil
+}
+
+// CreatePlaybackRestriction stores a playback restriction with a random ID.
+func (m *Mux) CreatePlaybackRestriction(_ context.Context, referrer mux.ReferrerDomainRestriction) (*mux.PlaybackRestriction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	restriction := &mux.PlaybackRestriction{Id: uuid.NewString(), Referrer: referrer}
+	m.restrictions[restriction.Id] = restriction
+	return restriction, nil
+}
+
+func (m *Mux) UpdatePlaybackRestrictionReferrer(_ context.Context, restrictionID string, referrer mux.ReferrerDomainRestriction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	restriction, ok := m.restrictions[restrictionID]
+	if !ok {
+		return muxNotFound("playback restriction", restrictionID)
+	}
+	restriction.Referrer = referrer
+	return nil
+}
+
+func (m *Mux) DeletePlaybackRestriction(_ context.Context, restrictionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.restrictions[restrictionID]; !ok {
+		return muxNotFound("playback restriction", restrictionID)
+	}
+	delete(m.restrictions, restrictionID)
+	return nil
+}
+
+// CreateSigningKey generates an RSA signing key with a random ID, its private key is returned base64
+// encoded like by MUX.
+func (m *Mux) CreateSigningKey(context.Context) (*mux.SigningKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	signingKey := &mux.SigningKey{
+		Id:         uuid.NewString(),
+		CreatedAt:  strconv.FormatInt(time.Now().Unix(), 10),
+		PrivateKey: base64.StdEncoding.EncodeToString(privateKey),
+	}
+	m.signingKeys[signingKey.Id] = struct{}{}
+	return signingKey, nil
+}
+
+func (m *Mux) DeleteSigningKey(_ context.Context, keyID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.signingKeys[keyID]; !ok {
+		return muxNotFound("signing key", keyID)
+	}
+	delete(m.signingKeys, keyID)
+	return nil
+}
+
+// GeneratePlaybackJWTToken returns an unsigned token naming the playback ID, the emulated
+// playback URLs do not verify it.
+func (m *Mux) GeneratePlaybackJWTToken(_ context.Context, opts muxapiclient.GeneratePlaybackTokenOptions) (string, error) {
+	return "dev-playback." + opts.PlaybackID, nil
+}
+
+func (m *Mux) GenerateDRMLicenseJWTToken(_ context.Context, opts muxapiclient.GeneratePlaybackTokenOptions) (string, error) {
+	return "dev-drm-license." + opts.PlaybackID, nil
+}
+
+func (m *Mux) GenerateThumbnailJWTToken(_ context.Context, opts muxapiclient.GeneratePlaybackTokenOptions) (string, error) {
+	return "dev-thumbnail." + opts.PlaybackID, nil
+}
+
+// VerifiesWebhooks reports false, the emulated webhooks are queued without going through the
+// webhook endpoint.
+func (m *Mux) VerifiesWebhooks() bool {
+	return false
+}
+
+func (m *Mux) VerifyWebhookSignature([]byte, string) error {
+	return nil
+}
+
+// CompleteUpload receives the file of a waiting upload, creates its asset and returns the
+// webhooks of the asset being created and becoming ready.
+func (m *Mux) CompleteUpload(uploadID string) ([]Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	upload, ok := m.uploads[uploadID]
+	if !ok {
+		return nil, muxNotFound("upload", uploadID)
+	}
+	if upload.Status != "waiting" {
+		return nil, fmt.Errorf("%w: MUX upload %s is %s", ErrConflict, uploadID, upload.Status)
+	}
+	asset := m.createAsset(upload)
+	created, err := m.webhook(asset, EventCreated)
+	if err != nil {
+		return nil, err
+	}
+	ready, err := m.webhook(asset, EventReady)
+	if err != nil {
+		return nil, err
+	}
+	return []Webhook{created, ready}, nil
+}
+
+// Fire returns the webhook of the event of the asset or the upload with the ID. Firing a created or
+// ready event for a waiting upload creates its asset as if the file was uploaded.
+func (m *Mux) Fire(id, event string) (Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	asset, ok := m.assets[id]
+	if !ok {
+		upload, ok := m.uploads[id]
+		switch {
+		case !ok:
+			return Webhook{}, muxNotFound("asset or upload", id)
+		case upload.AssetId != "":
+			asset = m.assets[upload.AssetId]
+		case event == EventErrored:
+			// MUX reports the uploads which could not be ingested without an asset.
+			asset = &mux.Asset{UploadId: upload.Id, Meta: upload.NewAssetSettings.Meta, IngestType: upload.NewAssetSettings.IngestType}
+			upload.Status = "errored"
+		default:
+			asset = m.createAsset(upload)
+		}
+		if asset == nil {
+			return Webhook{}, muxNotFound("asset", upload.AssetId)
+		}
+	}
+	return m.webhook(asset, event)
+}
+
+// createAsset creates the preparing asset of the upload. The caller must hold m.mu.
+func (m *Mux) createAsset(upload *mux.Upload) *mux.Asset {
+	seed := upload.NewAssetSettings.Meta.ExternalId
+	if seed == "" {
+		seed = upload.Id
+	}
+	asset := upload.NewAssetSettings
+	asset.Id = deriveID("asset", seed)
+	asset.CreatedAt = strconv.FormatInt(time.Now().Unix(), 10)
+	asset.Status = "preparing"
+	asset.UploadId = upload.Id
+	asset.PlaybackIds = make([]mux.PlaybackId, len(upload.NewAssetSettings.PlaybackIds))
+	for i, p := range upload.NewAssetSettings.PlaybackIds {
+		p.Id = deriveID("playback", asset.Id+"/"+string(p.Policy))
+		asset.PlaybackIds[i] = p
+	}
+
+	upload.Status = "asset_created"
+	upload.AssetId = asset.Id
+	if _, ok := m.assets[asset.Id]; !ok {
+		m.order = append(m.order, asset.Id)
+	}
+	m.assets[asset.Id] = &asset
+	return &asset
+}
+
+// webhook applies the event to the asset and returns its webhook. The caller must hold m.mu.
+func (m *Mux) webhook(asset *mux.Asset, event string) (Webhook, error) {
+	var eventType string
+	switch event {
+	case EventCreated:
+		eventType = "video.asset.created"
+	case EventReady:
+		eventType = "video.asset.ready"
+		asset.Status = "ready"
+		asset.Duration = 10
+		asset.AspectRatio = "16:9"
+		asset.ResolutionTier = "1080p"
+		asset.MaxResolutionTier = "1080p"
+		asset.MaxStoredResolution = "HD"
+		asset.VideoQuality = "basic"
+		asset.Tracks = []mux.Track{
+			{Id: deriveID("track", asset.Id+"/video"), Type: "video", Duration: 10, MaxWidth: 1920, MaxHeight: 1080, MaxFrameRate: 30},
+			{Id: deriveID("track", asset.Id+"/audio"), Type: "audio", Duration: 10, MaxChannels: 2, MaxChannelLayout: "stereo"},
+		}
+	case EventErrored:
+		eventType = "video.asset.errored"
+		asset.Status = "errored"
+		asset.Errors = mux.AssetErrors{Type: "invalid_input", Messages: []string{"The emulated asset was marked as errored."}}
+	default:
+		return Webhook{}, fmt.Errorf("unknown event %q, expected %s, %s or %s", event, EventCreated, EventReady, EventErrored)
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"type":        eventType,
+		"id":          uuid.NewString(),
+		"created_at":  time.Now().UTC(),
+		"object":      map[string]string{"type": "asset", "id": asset.Id},
+		"environment": map[string]string{"name": "Development", "id": ""},
+		"data":        asset,
+	})
+	if err != nil {
+		return Webhook{}, fmt.Errorf("failed to encode MUX webhook: %w", err)
+	}
+	return Webhook{Provider: webhookmodel.ProviderMux, Type: eventType, Payload: payload}, nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package entitlement decides whether end users may access the assets of their owners, e.g. whether
+// a user may play the video of a course.
+package entitlement
+
+import (
+	"context"
+	"slices"
+
+	"github.com/mikhail5545/media-service-go/internal/auth"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+)
+
+// Owner is an owner of an asset.
+type Owner struct {
+	Type string
+	ID   string
+}
+
+// Checker reports whether the user may access an asset owned by the owners. Assets without owners
+// are never accessible to end users.
+type Checker interface {
+	CanAccess(ctx context.Context, userID string, owners []Owner) (bool, error)
+}
+
+// UserID returns the ID of the end user authenticated for the request.
+func UserID(ctx context.Context) (string, error) {
+	p, ok := auth.PrincipalFromContext(ctx)
+	if !ok || p.Subject == "" {
+		return "", serviceerrors.NewUnauthenticatedError("user is not authenticated")
+	}
+	return p.Subject, nil
+}
+
+// Check returns a permission denied error unless the checker grants the user access to an asset
+// owned by the owners. A nil checker grants access to nobody.
+func Check(ctx context.Context, checker Checker, userID string, owners []Owner) error {
+	if checker == nil || len(owners) == 0 {
+		return serviceerrors.NewPermissionDeniedError("user may not access the asset")
+	}
+	allowed, err := checker.CanAccess(ctx, userID, owners)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return serviceerrors.NewPermissionDeniedError("user may not access the asset")
+	}
+	return nil
+}
+
+// UserOwners grants access to the users who own the asset themselves: the owner types it is
+// created with identify users, e.g. "profile", and the owner ID is the user ID.
+type UserOwners struct {
+	types []string
+}
+
+var _ Checker = (*UserOwners)(nil)
+
+// NewUserOwners creates a checker treating the owners of ownerTypes as users.
+func NewUserOwners(ownerTypes ...string) *UserOwners {
+	return &UserOwners{types: ownerTypes}
+}
+
+// CanAccess reports whether the user is one of the owners of a user owner type.
+func (c *UserOwners) CanAccess(_ context.Context, userID string, owners []Owner) (bool, error) {
+	for _, owner := range owners {
+		if owner.ID == userID && slices.Contains(c.types, owner.Type) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package environment carries the tenant environment (e.g. staging or production) a request
+// operates in. Transport middlewares store it in the context, the repositories scope their
+// queries by it and the provider API clients pick the environment's credentials.
+//
+// A context without an environment is not scoped. It is used by the background workers, which
+// operate on the assets of every environment.
+package environment
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Default is the environment of requests which do not name one and of the assets created
+	// before environments were introduced.
+	Default = "default"
+	// Header is the HTTP header naming the environment of a request.
+	Header = "X-Media-Environment"
+	// MetadataKey is the gRPC metadata key naming the environment of a call.
+	MetadataKey = "x-media-environment"
+)
+
+type envKey struct{}
+
+// WithContext returns a copy of ctx carrying the environment name.
+func WithContext(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, envKey{}, name)
+}
+
+// FromContext returns the environment stored in ctx and whether there is one.
+func FromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(envKey{}).(string)
+	return name, ok && name != ""
+}
+
+// Name returns the environment stored in ctx, or [Default].
+func Name(ctx context.Context) string {
+	if name, ok := FromContext(ctx); ok {
+		return name
+	}
+	return Default
+}
+
+// Matches reports whether a record of the named environment is visible in ctx, which is the case
+// when ctx carries the same environment or none at all.
+func Matches(ctx context.Context, name string) bool {
+	env, ok := FromContext(ctx)
+	return !ok || env == name
+}
+
+// EchoMiddleware stores the environment named by the [Header] in the request context, falling
+// back to [Default]. Requests naming an environment outside of known are rejected.
+func EchoMiddleware(known []string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			name := c.Request().Header.Get(Header)
+			if name == "" {
+				name = Default
+			}
+			if !slices.Contains(known, name) {
+				return echo.NewHTTPError(http.StatusBadRequest, "unknown environment "+name)
+			}
+			req := c.Request()
+			c.SetRequest(req.WithContext(WithContext(req.Context(), name)))
+			return next(c)
+		}
+	}
+}
+
+// UnaryInterceptor stores the environment named by the [MetadataKey] in the handler context,
+// falling back to [Default]. Calls naming an environment outside of known are rejected.
+func UnaryInterceptor(known []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		name := Default
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(MetadataKey); len(values) > 0 && values[0] != "" {
+				name = values[0]
+			}
+		}
+		if !slices.Contains(known, name) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown environment %s", name)
+		}
+		return handler(WithContext(ctx, name), req)
+	}
+}
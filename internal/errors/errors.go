@@ -16,26 +16,49 @@ var (
 	ErrUnimplemented    = errors.New("unimplemented")       // ErrUnimplemented functionality is not implemented error.
 	ErrCanceled         = errors.New("context canceled")    // ErrCanceled request context cancelled error.
 	ErrUnavailable      = errors.New("service unavailable") // ErrUnavailable external service error.
+	ErrUnauthenticated  = errors.New("unauthenticated")     // ErrUnauthenticated caller credentials are missing or invalid error.
+	// ErrDeadlineExceeded the request deadline, or the deadline of an external call made by it, was exceeded error.
+	ErrDeadlineExceeded = errors.New("deadline exceeded")
+	// ErrPayloadTooLarge the request body exceeds the size limit of the endpoint error.
+	ErrPayloadTooLarge = errors.New("payload too large")
+	// ErrUnsupportedMediaType the content type of the request body is not accepted by the endpoint error.
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+	// ErrOwnerHasAsset the owner is already associated with the asset error. It is returned along with ErrAlreadyExists.
+	ErrOwnerHasAsset = errors.New("owner already has the asset")
+	// ErrRevisionMismatch the expected revision of the resource is not the current one error. It is returned along with ErrConflict.
+	ErrRevisionMismatch = errors.New("revision mismatch")
 )
 
 var ErrorAliases = map[error]string{
-	ErrInvalidArgument:  "INVALID_ARGUMENT",
-	ErrValidationFailed: "VALIDATION_FAILED",
-	ErrNotFound:         "NOT_FOUND",
-	ErrConflict:         "CONFLICT",
-	ErrAlreadyExists:    "ALREADY_EXISTS",
-	ErrPermissionDenied: "PERMISSION_DENIED",
-	ErrTooManyRequests:  "TOO_MANY_REQUESTS",
-	ErrUnimplemented:    "UNIMPLEMENTED",
-	ErrCanceled:         "CANCELED",
-	ErrUnavailable:      "UNAVAILABLE",
+	ErrInvalidArgument:      "INVALID_ARGUMENT",
+	ErrValidationFailed:     "VALIDATION_FAILED",
+	ErrNotFound:             "NOT_FOUND",
+	ErrConflict:             "CONFLICT",
+	ErrAlreadyExists:        "ALREADY_EXISTS",
+	ErrPermissionDenied:     "PERMISSION_DENIED",
+	ErrTooManyRequests:      "TOO_MANY_REQUESTS",
+	ErrUnimplemented:        "UNIMPLEMENTED",
+	ErrCanceled:             "CANCELED",
+	ErrUnavailable:          "UNAVAILABLE",
+	ErrUnauthenticated:      "UNAUTHENTICATED",
+	ErrDeadlineExceeded:     "DEADLINE_EXCEEDED",
+	ErrPayloadTooLarge:      "PAYLOAD_TOO_LARGE",
+	ErrUnsupportedMediaType: "UNSUPPORTED_MEDIA_TYPE",
+	ErrOwnerHasAsset:        "OWNER_HAS_ASSET",
+	ErrRevisionMismatch:     "REVISION_MISMATCH",
 }
 
 func NewInvalidArgumentError(v any) error {
 	return fmt.Errorf("%w: %v", ErrInvalidArgument, v)
 }
 
+// NewValidationFailedError wraps v with ErrValidationFailed. When v is an error it stays in the
+// chain, so that the field errors of a failed validation can be reported to gRPC callers.
 func NewValidationFailedError(v any) error {
+	if err, ok := v.(error); ok {
+		return fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
 	return fmt.Errorf("%w: %v", ErrValidationFailed, v)
 }
 
@@ -55,6 +78,31 @@ func NewAlreadyExistsError(v any) error {
 	return fmt.Errorf("%w: %v", ErrAlreadyExists, v)
 }
 
+// NewOwnerHasAssetError wraps v with both ErrAlreadyExists and ErrOwnerHasAsset.
+func NewOwnerHasAssetError(v any) error {
+	return fmt.Errorf("%w: %w: %v", ErrAlreadyExists, ErrOwnerHasAsset, v)
+}
+
+// RevisionMismatchError is returned when a change expects another revision of the resource than the
+// current one. It matches both ErrConflict and ErrRevisionMismatch.
+type RevisionMismatchError struct {
+	Expected int64
+	Current  int64
+}
+
+func (e *RevisionMismatchError) Error() string {
+	return fmt.Sprintf("%v: %v: expected revision %d, current revision is %d", ErrConflict, ErrRevisionMismatch, e.Expected, e.Current)
+}
+
+func (e *RevisionMismatchError) Unwrap() []error {
+	return []error{ErrConflict, ErrRevisionMismatch}
+}
+
+// NewRevisionMismatchError creates a [RevisionMismatchError].
+func NewRevisionMismatchError(expected, current int64) error {
+	return &RevisionMismatchError{Expected: expected, Current: current}
+}
+
 func NewPermissionDeniedError(v any) error {
 	return fmt.Errorf("%w: %v", ErrPermissionDenied, v)
 }
@@ -74,3 +122,19 @@ func NewCanceledError(v any) error {
 func NewUnavailableError(v any) error {
 	return fmt.Errorf("%w: %v", ErrUnavailable, v)
 }
+
+func NewDeadlineExceededError(v any) error {
+	return fmt.Errorf("%w: %v", ErrDeadlineExceeded, v)
+}
+
+func NewPayloadTooLargeError(v any) error {
+	return fmt.Errorf("%w: %v", ErrPayloadTooLarge, v)
+}
+
+func NewUnsupportedMediaTypeError(v any) error {
+	return fmt.Errorf("%w: %v", ErrUnsupportedMediaType, v)
+}
+
+func NewUnauthenticatedError(v any) error {
+	return fmt.Errorf("%w: %v", ErrUnauthenticated, v)
+}
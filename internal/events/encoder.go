@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Encoding identifies the event payload encoding.
+type Encoding string
+
+const (
+	EncodingJSON     Encoding = "json"
+	EncodingProtobuf Encoding = "protobuf"
+)
+
+// Encoder encodes events into broker message payloads.
+type Encoder interface {
+	Encode(event *Event) ([]byte, error)
+	// ContentType returns the MIME type of the encoded payload, attached to the message headers.
+	ContentType() string
+}
+
+// NewEncoder returns the encoder for the specified encoding.
+func NewEncoder(encoding Encoding) (Encoder, error) {
+	switch encoding {
+	case EncodingJSON, "":
+		return JSONEncoder{}, nil
+	case EncodingProtobuf:
+		return ProtobufEncoder{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported event encoding %q", encoding)
+	}
+}
+
+// JSONEncoder encodes events as JSON documents.
+type JSONEncoder struct{}
+
+var _ Encoder = JSONEncoder{}
+
+func (JSONEncoder) Encode(event *Event) ([]byte, error) {
+	return json.Marshal(event)
+}
+
+func (JSONEncoder) ContentType() string {
+	return "application/json"
+}
+
+// ProtobufEncoder encodes events as [structpb.Struct] protobuf messages, so consumers can decode
+// them without sharing a dedicated schema.
+type ProtobufEncoder struct{}
+
+var _ Encoder = ProtobufEncoder{}
+
+func (ProtobufEncoder) Encode(event *Event) ([]byte, error) {
+	owners := make([]any, len(event.Owners))
+	for i, owner := range event.Owners {
+		owners[i] = map[string]any{
+			"owner_id":   owner.OwnerID,
+			"owner_type": owner.OwnerType,
+		}
+	}
+	data := make(map[string]any, len(event.Data))
+	for k, v := range event.Data {
+		data[k] = v
+	}
+
+	msg, err := structpb.NewStruct(map[string]any{
+		"id":          event.ID.String(),
+		"type":        string(event.Type),
+		"provider":    string(event.Provider),
+		"asset_id":    event.AssetID.String(),
+		"occurred_at": event.OccurredAt.Format(time.RFC3339Nano),
+		"external_id": event.ExternalID,
+		"owners":      owners,
+		"data":        data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build protobuf event: %w", err)
+	}
+	return proto.Marshal(msg)
+}
+
+func (ProtobufEncoder) ContentType() string {
+	return "application/x-protobuf"
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package events provides the asset event publisher, which notifies other services about asset lifecycle
+// changes through a message broker (NATS or Kafka).
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Type identifies the asset lifecycle event.
+type Type string
+
+const (
+	TypeAssetCreated       Type = "asset.created"
+	TypeAssetReady         Type = "asset.ready"
+	TypeAssetErrored       Type = "asset.errored"
+	TypeAssetDeleted       Type = "asset.deleted"
+	TypeAssetOwnersChanged Type = "asset.owners_changed"
+	// TypeAssetUpdated is published when the provider changes asset details, such as tags or derived versions.
+	TypeAssetUpdated Type = "asset.updated"
+	// TypeAssetPublished and TypeAssetUnpublished are published when an asset becomes deliverable to end
+	// users or stops being deliverable, following its publication schedule.
+	TypeAssetPublished   Type = "asset.published"
+	TypeAssetUnpublished Type = "asset.unpublished"
+)
+
+// Types lists all supported event types.
+var Types = []Type{
+	TypeAssetCreated,
+	TypeAssetReady,
+	TypeAssetErrored,
+	TypeAssetDeleted,
+	TypeAssetOwnersChanged,
+	TypeAssetUpdated,
+	TypeAssetPublished,
+	TypeAssetUnpublished,
+}
+
+// Provider identifies the external asset provider the event relates to.
+type Provider string
+
+const (
+	ProviderMux        Provider = "mux"
+	ProviderCloudinary Provider = "cloudinary"
+)
+
+// Owner represents an external asset owner in the event payload.
+type Owner struct {
+	OwnerID   string `json:"owner_id"`
+	OwnerType string `json:"owner_type"`
+}
+
+// Event represents a single asset lifecycle event.
+type Event struct {
+	ID         uuid.UUID `json:"id"` // UUIDv7
+	Type       Type      `json:"type"`
+	Provider   Provider  `json:"provider"`
+	AssetID    uuid.UUID `json:"asset_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// ExternalID is the provider identifier of the asset (MUX asset ID or Cloudinary public ID), if known.
+	ExternalID string `json:"external_id,omitempty"`
+	// Owners holds the current asset owners. Populated for [TypeAssetOwnersChanged] events.
+	Owners []Owner `json:"owners,omitempty"`
+	// Data holds additional event-specific attributes.
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// Option sets an optional attribute of an event built by [NewEvent].
+type Option func(*Event)
+
+// WithExternalID sets the provider identifier of the asset.
+func WithExternalID(externalID string) Option {
+	return func(e *Event) {
+		e.ExternalID = externalID
+	}
+}
+
+// WithOwners sets the owners of the asset.
+func WithOwners(owners ...Owner) Option {
+	return func(e *Event) {
+		e.Owners = append(make([]Owner, 0, len(owners)), owners...)
+	}
+}
+
+// WithData adds an event-specific attribute.
+func WithData(key, value string) Option {
+	return func(e *Event) {
+		if e.Data == nil {
+			e.Data = make(map[string]string)
+		}
+		e.Data[key] = value
+	}
+}
+
+// NewEvent creates a new event of the specified type for the asset.
+func NewEvent(eventType Type, provider Provider, assetID uuid.UUID, opts ...Option) *Event {
+	id, err := uuid.NewV7()
+	if err != nil {
+		id = uuid.New()
+	}
+	event := &Event{
+		ID:         id,
+		Type:       eventType,
+		Provider:   provider,
+		AssetID:    assetID,
+		OccurredAt: time.Now().UTC(),
+	}
+	for _, opt := range opts {
+		opt(event)
+	}
+	return event
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// The number of events buffered for a subscriber, further events are dropped until the subscriber
+// catches up. Subscribers of all assets receive bursts of events, e.g. on bulk operations.
+const (
+	subscriptionBuffer    = 16
+	allSubscriptionBuffer = 256
+)
+
+// Hub delivers the events it handles to the in-process subscribers of their asset and of all assets.
+// Subscribers only receive the events published by this
+// instance, so they must not rely on receiving every event.
+type Hub struct {
+	mu sync.Mutex
+	// subscribers are keyed by asset ID, the subscribers of all assets by [uuid.Nil].
+	subscribers map[uuid.UUID]map[*Subscription]struct{}
+}
+
+// NewHub returns a hub without subscribers.
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[uuid.UUID]map[*Subscription]struct{}),
+	}
+}
+
+// Subscription receives the events of a single asset or of all assets until it is closed.
+type Subscription struct {
+	// C receives the events. It is never closed.
+	C <-chan *Event
+
+	ch  chan *Event
+	hub *Hub
+	key uuid.UUID
+}
+
+// Subscribe subscribes to the events of the asset. The subscription must be closed when it is no longer used.
+func (h *Hub) Subscribe(assetID uuid.UUID) *Subscription {
+	return h.subscribe(assetID, subscriptionBuffer)
+}
+
+// SubscribeAll subscribes to the events of all assets. The subscription must be closed when it is no
+// longer used.
+func (h *Hub) SubscribeAll() *Subscription {
+	return h.subscribe(uuid.Nil, allSubscriptionBuffer)
+}
+
+func (h *Hub) subscribe(key uuid.UUID, buffer int) *Subscription {
+	ch := make(chan *Event, buffer)
+	sub := &Subscription{C: ch, ch: ch, hub: h, key: key}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers[key] == nil {
+		h.subscribers[key] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[key][sub] = struct{}{}
+	return sub
+}
+
+// Close unsubscribes from the events. It is safe to call it more than once.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	subs := s.hub.subscribers[s.key]
+	delete(subs, s)
+	if len(subs) == 0 {
+		delete(s.hub.subscribers, s.key)
+	}
+}
+
+// Handle delivers the event to the subscribers without blocking, it never fails.
+func (h *Hub) Handle(_ context.Context, event *Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, key := range []uuid.UUID{event.AssetID, uuid.Nil} {
+		for sub := range h.subscribers[key] {
+			select {
+			case sub.ch <- event:
+			default:
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// KafkaPublisher publishes events to Kafka topics. Messages are keyed by the asset ID,
+// so all events of the same asset are delivered in order.
+type KafkaPublisher struct {
+	cfg     Config
+	writer  *kafka.Writer
+	encoder Encoder
+	logger  *zap.Logger
+}
+
+var _ Publisher = (*KafkaPublisher)(nil)
+
+// The writer is synchronous, so every Publish waits for its batch to be flushed. The default
+// batch timeout of one second would delay every event, the messages of concurrent publishers
+// are batched within a few milliseconds instead.
+const (
+	kafkaBatchTimeout = 5 * time.Millisecond
+	kafkaBatchSize    = 100
+)
+
+func newKafkaPublisher(cfg Config, encoder Encoder, logger *zap.Logger) *KafkaPublisher {
+	return &KafkaPublisher{
+		cfg: cfg,
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(cfg.URLs...),
+			Balancer:               &kafka.Hash{},
+			BatchTimeout:           kafkaBatchTimeout,
+			BatchSize:              kafkaBatchSize,
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: false,
+		},
+		encoder: encoder,
+		logger:  logger,
+	}
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, event *Event) error {
+	topic, ok := p.cfg.topic(event.Type)
+	if !ok {
+		return nil
+	}
+	payload, err := p.encoder.Encode(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	if err := p.writer.WriteMessages(ctx, kafka.Message{
+		Topic: topic,
+		Key:   []byte(event.AssetID.String()),
+		Value: payload,
+		Headers: []kafka.Header{
+			{Key: "Event-ID", Value: []byte(event.ID.String())},
+			{Key: "Event-Type", Value: []byte(event.Type)},
+			{Key: "Content-Type", Value: []byte(p.encoder.ContentType())},
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to publish event to Kafka: %w", err)
+	}
+	return nil
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
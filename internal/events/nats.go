@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+// NATSPublisher publishes events to NATS subjects.
+type NATSPublisher struct {
+	cfg     Config
+	conn    *nats.Conn
+	encoder Encoder
+	logger  *zap.Logger
+}
+
+var _ Publisher = (*NATSPublisher)(nil)
+
+func newNATSPublisher(cfg Config, encoder Encoder, logger *zap.Logger) (*NATSPublisher, error) {
+	conn, err := nats.Connect(
+		strings.Join(cfg.URLs, ","),
+		nats.Name("media-service"),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				logger.Warn("disconnected from NATS", zap.Error(err))
+			}
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			logger.Info("reconnected to NATS", zap.String("url", conn.ConnectedUrl()))
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	return &NATSPublisher{
+		cfg:     cfg,
+		conn:    conn,
+		encoder: encoder,
+		logger:  logger,
+	}, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, event *Event) error {
+	subject, ok := p.cfg.topic(event.Type)
+	if !ok {
+		return nil
+	}
+	payload, err := p.encoder.Encode(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	msg := nats.NewMsg(subject)
+	msg.Data = payload
+	msg.Header.Set(nats.MsgIdHdr, event.ID.String())
+	msg.Header.Set("Content-Type", p.encoder.ContentType())
+	msg.Header.Set("Event-Type", string(event.Type))
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := p.conn.PublishMsg(msg); err != nil {
+		return fmt.Errorf("failed to publish event to NATS: %w", err)
+	}
+	return nil
+}
+
+func (p *NATSPublisher) Close() error {
+	return p.conn.Drain()
+}
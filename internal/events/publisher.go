@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Publisher publishes asset events to the message broker.
+type Publisher interface {
+	// Publish publishes the event to the topic configured for its type.
+	Publish(ctx context.Context, event *Event) error
+	// Close flushes pending messages and releases broker connection.
+	Close() error
+}
+
+// Broker identifies the message broker implementation.
+type Broker string
+
+const (
+	BrokerNone  Broker = "none"
+	BrokerNATS  Broker = "nats"
+	BrokerKafka Broker = "kafka"
+)
+
+// Config holds event publisher configuration.
+type Config struct {
+	Broker Broker
+	// URLs holds broker addresses (NATS server URLs or Kafka bootstrap brokers).
+	URLs     []string
+	Encoding Encoding
+	// TopicPrefix is prepended to the event type to build the default topic (NATS subject) name,
+	// e.g. "media." + "asset.created".
+	TopicPrefix string
+	// Topics overrides topic names per event type. Events with an empty topic are not published.
+	Topics map[Type]string
+}
+
+// topic returns the topic for the specified event type and whether the event should be published.
+func (c Config) topic(eventType Type) (string, bool) {
+	if topic, ok := c.Topics[eventType]; ok {
+		return topic, topic != ""
+	}
+	return c.TopicPrefix + string(eventType), true
+}
+
+// New creates the event publisher for the configured broker.
+// If no broker is configured, a no-op publisher is returned.
+func New(cfg Config, logger *zap.Logger) (Publisher, error) {
+	logger = logger.With(zap.String("layer", "events"), zap.String("broker", string(cfg.Broker)))
+
+	broker := Broker(strings.ToLower(string(cfg.Broker)))
+	if broker == "" || broker == BrokerNone {
+		return NoopPublisher{}, nil
+	}
+	if len(cfg.URLs) == 0 {
+		return nil, fmt.Errorf("event broker urls must be provided")
+	}
+	encoder, err := NewEncoder(cfg.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	switch broker {
+	case BrokerNATS:
+		return newNATSPublisher(cfg, encoder, logger)
+	case BrokerKafka:
+		return newKafkaPublisher(cfg, encoder, logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported event broker %q", cfg.Broker)
+	}
+}
+
+// NoopPublisher discards all events. It is used when no broker is configured.
+type NoopPublisher struct{}
+
+var _ Publisher = NoopPublisher{}
+
+func (NoopPublisher) Publish(context.Context, *Event) error { return nil }
+
+func (NoopPublisher) Close() error { return nil }
@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package gateway transcodes JSON/REST requests into calls of the v1 gRPC services, so their RPCs
+// can be used over HTTP. Messages are encoded with protojson using the proto field names, except for
+// bytes fields, which hold UUIDs in the v1 API and are written and read as canonical UUID strings
+// instead of base64.
+package gateway
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	unmarshalOptions = protojson.UnmarshalOptions{}
+)
+
+// Marshal encodes m as JSON with its bytes fields written as UUID strings.
+func Marshal(m proto.Message) ([]byte, error) {
+	raw, err := marshalOptions.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := decodeObject(raw)
+	if err != nil {
+		return nil, err
+	}
+	rewriteBytes(obj, m.ProtoReflect().Descriptor(), bytesToUUID)
+	return json.Marshal(obj)
+}
+
+// Unmarshal decodes the JSON data into m. Bytes fields accept UUID strings as well as base64.
+func Unmarshal(data []byte, m proto.Message) error {
+	obj := make(map[string]any)
+	if len(bytes.TrimSpace(data)) > 0 {
+		var err error
+		if obj, err = decodeObject(data); err != nil {
+			return err
+		}
+	}
+	return unmarshalObject(obj, m)
+}
+
+// unmarshalObject decodes the decoded JSON object obj into m.
+func unmarshalObject(obj map[string]any, m proto.Message) error {
+	rewriteBytes(obj, m.ProtoReflect().Descriptor(), uuidToBytes)
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return unmarshalOptions.Unmarshal(raw, m)
+}
+
+// decodeObject decodes a JSON object, keeping numbers as json.Number so 64-bit values are not rounded.
+func decodeObject(data []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		obj = make(map[string]any)
+	}
+	return obj, nil
+}
+
+// rewriteBytes applies fn to the values of the bytes fields of the JSON object obj holding a message
+// described by md, including the fields of nested messages.
+func rewriteBytes(obj map[string]any, md protoreflect.MessageDescriptor, fn func(string) string) {
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		key := string(fd.Name())
+		v, ok := obj[key]
+		if !ok {
+			key = fd.JSONName()
+			if v, ok = obj[key]; !ok {
+				continue
+			}
+		}
+		obj[key] = rewriteValue(v, fd, fn)
+	}
+}
+
+func rewriteValue(v any, fd protoreflect.FieldDescriptor, fn func(string) string) any {
+	switch {
+	case fd.IsMap():
+		if m, ok := v.(map[string]any); ok {
+			for k, e := range m {
+				m[k] = rewriteSingular(e, fd.MapValue(), fn)
+			}
+		}
+	case fd.IsList():
+		if l, ok := v.([]any); ok {
+			for i, e := range l {
+				l[i] = rewriteSingular(e, fd, fn)
+			}
+		}
+	default:
+		return rewriteSingular(v, fd, fn)
+	}
+	return v
+}
+
+func rewriteSingular(v any, fd protoreflect.FieldDescriptor, fn func(string) string) any {
+	switch fd.Kind() {
+	case protoreflect.BytesKind:
+		if s, ok := v.(string); ok {
+			return fn(s)
+		}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		// Well-known types have their own JSON representation without bytes fields.
+		if obj, ok := v.(map[string]any); ok && fd.Message().ParentFile().Package() != "google.protobuf" {
+			rewriteBytes(obj, fd.Message(), fn)
+		}
+	}
+	return v
+}
+
+// bytesToUUID converts the base64 encoding of a 16 byte value to a UUID string. Other values are
+// returned unchanged.
+func bytesToUUID(s string) string {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(b) != 16 {
+		return s
+	}
+	return uuid.UUID(b).String()
+}
+
+// uuidToBytes converts a UUID string to the base64 encoding of its bytes. Other values, e.g. base64
+// sent by clients of the protojson encoding, are returned unchanged.
+func uuidToBytes(s string) string {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return s
+	}
+	return base64.StdEncoding.EncodeToString(id[:])
+}
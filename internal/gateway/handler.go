@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/grpc/common"
+	"github.com/mikhail5545/media-service-go/internal/payload"
+	mediaerrors "github.com/mikhail5545/media-service-go/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Unary returns an Echo handler calling the RPC fn. The request message is built from the JSON
+// body, the query parameters and the path parameters, in increasing precedence. Parameters are
+// matched to the top-level fields of the request by their proto or JSON name, unknown query
+// parameters are ignored. The "fields" query parameter is forwarded as the field mask of the call,
+// see common.DetailsMask. The response message is written as JSON with status 200.
+func Unary[Req any, PReq interface {
+	*Req
+	proto.Message
+}, Res proto.Message](fn func(context.Context, PReq) (Res, error)) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := PReq(new(Req))
+		if err := decodeRequest(c, req); err != nil {
+			if errors.Is(err, serviceerrors.ErrPayloadTooLarge) {
+				return err
+			}
+			return serviceerrors.NewInvalidArgumentError(err)
+		}
+		res, err := fn(withFieldMask(c), req)
+		if err != nil {
+			return serviceError(err)
+		}
+		body, err := Marshal(res)
+		if err != nil {
+			return err
+		}
+		return c.JSONBlob(http.StatusOK, body)
+	}
+}
+
+func decodeRequest(c echo.Context, m proto.Message) error {
+	obj := make(map[string]any)
+	if req := c.Request(); req.Body != nil && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return payload.BindError(err, "invalid request body")
+		}
+		if len(body) > 0 {
+			if obj, err = decodeObject(body); err != nil {
+				return fmt.Errorf("invalid request body: %w", err)
+			}
+		}
+	}
+
+	fields := m.ProtoReflect().Descriptor().Fields()
+	for name, values := range c.QueryParams() {
+		fd := lookupField(fields, name)
+		if fd == nil || len(values) == 0 {
+			continue
+		}
+		if fd.Message() != nil {
+			return fmt.Errorf("query parameter %q: message fields must be sent in the body", name)
+		}
+		if fd.IsList() {
+			list := make([]any, len(values))
+			for i, v := range values {
+				list[i] = paramValue(fd, v)
+			}
+			obj[string(fd.Name())] = list
+			continue
+		}
+		obj[string(fd.Name())] = paramValue(fd, values[len(values)-1])
+	}
+	for i, name := range c.ParamNames() {
+		if fd := lookupField(fields, name); fd != nil {
+			obj[string(fd.Name())] = paramValue(fd, c.ParamValues()[i])
+		}
+	}
+	return unmarshalObject(obj, m)
+}
+
+// withFieldMask returns the request context carrying the "fields" query parameter in the incoming
+// metadata the servers read the field mask from.
+func withFieldMask(c echo.Context) context.Context {
+	ctx := c.Request().Context()
+	fields := c.QueryParams()["fields"]
+	if len(fields) == 0 {
+		return ctx
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	md.Append(common.FieldMaskPathsKey, fields...)
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+func lookupField(fields protoreflect.FieldDescriptors, name string) protoreflect.FieldDescriptor {
+	if fd := fields.ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+	return fields.ByJSONName(name)
+}
+
+// paramValue converts a path or query parameter to the JSON value protojson expects for fd. Numbers
+// are accepted as strings by protojson, booleans and enum numbers are not.
+func paramValue(fd protoreflect.FieldDescriptor, s string) any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	case protoreflect.EnumKind:
+		if _, err := strconv.ParseInt(s, 10, 32); err == nil {
+			return json.Number(s)
+		}
+	}
+	return s
+}
+
+// statusErrors maps gRPC status codes to the service errors the servers convert to them, see
+// errutil.ToGRPCCode.
+var statusErrors = map[codes.Code]error{
+	codes.InvalidArgument:    serviceerrors.ErrInvalidArgument,
+	codes.FailedPrecondition: serviceerrors.ErrValidationFailed,
+	codes.NotFound:           serviceerrors.ErrNotFound,
+	codes.Aborted:            serviceerrors.ErrConflict,
+	codes.AlreadyExists:      serviceerrors.ErrAlreadyExists,
+	codes.PermissionDenied:   serviceerrors.ErrPermissionDenied,
+	codes.Unauthenticated:    serviceerrors.ErrUnauthenticated,
+	codes.ResourceExhausted:  serviceerrors.ErrTooManyRequests,
+	codes.Unimplemented:      serviceerrors.ErrUnimplemented,
+	codes.Canceled:           serviceerrors.ErrCanceled,
+	codes.DeadlineExceeded:   serviceerrors.ErrCanceled,
+	codes.Unavailable:        serviceerrors.ErrUnavailable,
+}
+
+// serviceError converts the status error returned by an RPC back to a service error, so the HTTP
+// error handler responds with the same status, code and field errors as for the Echo handlers.
+func serviceError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	sentinel, ok := statusErrors[st.Code()]
+	if !ok {
+		return err
+	}
+
+	var reason string
+	fieldErrs := validation.Errors{}
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if d.GetDomain() == mediaerrors.Domain {
+				reason = d.GetReason()
+			}
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				fieldErrs[v.GetField()] = errors.New(v.GetDescription())
+			}
+		}
+	}
+	switch {
+	case reason == serviceerrors.ErrorAliases[serviceerrors.ErrOwnerHasAsset]:
+		return serviceerrors.NewOwnerHasAssetError(st.Message())
+	case len(fieldErrs) > 0:
+		return fmt.Errorf("%w: %w", sentinel, fieldErrs)
+	default:
+		return fmt.Errorf("%w: %s", sentinel, st.Message())
+	}
+}
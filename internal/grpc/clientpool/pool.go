@@ -0,0 +1,183 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package clientpool manages the outgoing gRPC connections to downstream services. Every connection
+// is monitored: its connectivity state is reported to an observer and idle connections are
+// reconnected eagerly, so calls do not pay for the reconnect after the downstream service restarted.
+// Calls made through a pooled connection get a per-call deadline and the configured WaitForReady
+// behaviour unless the caller overrides them.
+package clientpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mikhail5545/media-service-go/internal/deadline"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// Target configures the connection to a single downstream service.
+type Target struct {
+	Address string
+	// Timeout is the deadline of calls whose context has no earlier deadline, 0 disables it.
+	Timeout time.Duration
+	// Reserve is the part of the caller's deadline kept back from the calls, so the caller can still
+	// clean up and respond when a call times out.
+	Reserve time.Duration
+	// WaitForReady makes calls wait until the connection is ready instead of failing immediately
+	// while the service is unreachable. The wait is bounded by the call deadline.
+	WaitForReady bool
+}
+
+// StateObserver is notified about every connectivity state change of a pooled connection.
+type StateObserver func(name string, state connectivity.State)
+
+// Pool owns the connections to the downstream services. It is safe for concurrent use.
+type Pool struct {
+	dialOpts []grpc.DialOption
+	observer StateObserver
+	logger   *zap.Logger
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a pool dialing every connection with dialOpts. observer is optional.
+func New(logger *zap.Logger, observer StateObserver, dialOpts ...grpc.DialOption) *Pool {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Pool{
+		dialOpts: dialOpts,
+		observer: observer,
+		logger:   logger.With(zap.String("component", "grpc_client_pool")),
+		conns:    make(map[string]*grpc.ClientConn),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Dial creates the connection to the named downstream service and starts monitoring it.
+// The connection is established in the background, Dial does not wait for it.
+func (p *Pool) Dial(name string, target Target) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.conns[name]; ok {
+		return nil, fmt.Errorf("connection %q already exists", name)
+	}
+	if p.ctx.Err() != nil {
+		return nil, fmt.Errorf("pool is closed")
+	}
+	opts := append([]grpc.DialOption{grpc.WithChainUnaryInterceptor(callDefaults(target))}, p.dialOpts...)
+	conn, err := grpc.NewClient(target.Address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s connection: %w", name, err)
+	}
+	p.conns[name] = conn
+
+	p.wg.Add(1)
+	go p.watch(name, conn)
+	return conn, nil
+}
+
+// State returns the connectivity state of the named connection.
+func (p *Pool) State(name string) (connectivity.State, bool) {
+	p.mu.Lock()
+	conn, ok := p.conns[name]
+	p.mu.Unlock()
+	if !ok {
+		return connectivity.Shutdown, false
+	}
+	return conn.GetState(), true
+}
+
+// Probe returns a health probe failing unless the named connection is ready.
+func (p *Pool) Probe(name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		state, ok := p.State(name)
+		if !ok {
+			return fmt.Errorf("connection %q does not exist", name)
+		}
+		if state != connectivity.Ready {
+			return fmt.Errorf("connection %q is %s", name, state)
+		}
+		return nil
+	}
+}
+
+// Close stops the monitoring and closes all connections.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	p.cancel()
+	conns := p.conns
+	p.conns = make(map[string]*grpc.ClientConn)
+	p.mu.Unlock()
+
+	var errs []error
+	for name, conn := range conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close %s connection: %w", name, err))
+		}
+	}
+	p.wg.Wait()
+	return errors.Join(errs...)
+}
+
+// watch reports the state changes of the connection until the pool is closed. Idle connections are
+// reconnected immediately, otherwise gRPC would only reconnect on the next call.
+func (p *Pool) watch(name string, conn *grpc.ClientConn) {
+	defer p.wg.Done()
+
+	logger := p.logger.With(zap.String("connection", name))
+	state := conn.GetState()
+	for {
+		if p.observer != nil {
+			p.observer(name, state)
+		}
+		switch state {
+		case connectivity.Idle:
+			conn.Connect()
+		case connectivity.Ready:
+			logger.Info("grpc connection ready")
+		case connectivity.TransientFailure:
+			logger.Warn("grpc connection failed, reconnecting")
+		}
+		if !conn.WaitForStateChange(p.ctx, state) {
+			return
+		}
+		state = conn.GetState()
+	}
+}
+
+// callDefaults applies the deadline and the WaitForReady behaviour of the target to every call.
+// Call options passed by the caller take precedence.
+func callDefaults(target Target) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, cancel := deadline.Sub(ctx, target.Timeout, target.Reserve)
+		defer cancel()
+		opts = append([]grpc.CallOption{grpc.WaitForReady(target.WaitForReady)}, opts...)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik
+ *
+ * This program is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU Affero General Public License as published
+ *  by the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ *  along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mikhail5545/media-service-go/internal/util/fieldmask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const (
+	// FieldMaskKey is the metadata key of a serialized google.protobuf.FieldMask sent with a Get or List call.
+	FieldMaskKey = "field-mask-bin"
+	// FieldMaskPathsKey is the metadata key of comma separated field mask paths, for clients that cannot
+	// send binary metadata easily, e.g. grpcurl.
+	FieldMaskPathsKey = "field-mask"
+)
+
+// DetailsMask is the field mask sent with a Get or List call. The request messages do not have a
+// field mask field, so the mask is sent in the call metadata. Its paths are relative to the Details
+// message of the response, e.g. "asset.status" or "asset_metadata.title". A nil DetailsMask selects
+// everything.
+type DetailsMask struct {
+	paths []string
+	tree  maskTree
+}
+
+// DetailsMaskFromContext returns the field mask sent with the call, validated against the details
+// message. It returns nil if no mask was sent.
+func DetailsMaskFromContext(ctx context.Context, details proto.Message) (*DetailsMask, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	mask := &fieldmaskpb.FieldMask{}
+	for _, value := range md.Get(FieldMaskKey) {
+		var sent fieldmaskpb.FieldMask
+		if err := proto.Unmarshal([]byte(value), &sent); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid field mask: %v", err)
+		}
+		mask.Paths = append(mask.Paths, sent.GetPaths()...)
+	}
+	for _, value := range md.Get(FieldMaskPathsKey) {
+		for _, path := range strings.Split(value, ",") {
+			if path = strings.TrimSpace(path); path != "" {
+				mask.Paths = append(mask.Paths, path)
+			}
+		}
+	}
+	if len(mask.Paths) == 0 {
+		return nil, nil
+	}
+	if !mask.IsValid(details) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid field mask %v for %s", mask.Paths, details.ProtoReflect().Descriptor().FullName())
+	}
+	mask.Normalize()
+	return &DetailsMask{paths: mask.Paths, tree: newMaskTree(mask.Paths)}, nil
+}
+
+// Fields returns the mask in the paths of the service requests, see the fieldmask package. Nested
+// paths are widened to their top-level field, the response is pruned to the exact mask by Apply.
+func (m *DetailsMask) Fields() []string {
+	if m == nil {
+		return nil
+	}
+	fields := make([]string, 0, len(m.paths))
+	for _, path := range m.paths {
+		part, field, _ := strings.Cut(path, ".")
+		field, _, _ = strings.Cut(field, ".")
+		switch part {
+		case "asset":
+			if field == "uuid" {
+				field = "id"
+			}
+			part = fieldmask.PartAsset
+		case "asset_metadata":
+			if field == "key" {
+				field = "_id"
+			}
+			part = fieldmask.PartMetadata
+		}
+		if field != "" {
+			part += "." + field
+		}
+		fields = append(fields, part)
+	}
+	return fields
+}
+
+// Apply clears the fields of the details that are not selected by the mask.
+func (m *DetailsMask) Apply(details proto.Message) {
+	if m == nil || details == nil || !details.ProtoReflect().IsValid() {
+		return
+	}
+	m.tree.prune(details.ProtoReflect())
+}
+
+// maskTree is a normalized field mask split into path segments, a leaf selects the whole field.
+type maskTree map[protoreflect.Name]maskTree
+
+func newMaskTree(paths []string) maskTree {
+	root := maskTree{}
+	for _, path := range paths {
+		node := root
+		for _, name := range strings.Split(path, ".") {
+			child, ok := node[protoreflect.Name(name)]
+			if !ok {
+				child = maskTree{}
+				node[protoreflect.Name(name)] = child
+			}
+			node = child
+		}
+	}
+	return root
+}
+
+func (t maskTree) prune(m protoreflect.Message) {
+	var cleared []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		sub, ok := t[fd.Name()]
+		switch {
+		case !ok:
+			cleared = append(cleared, fd)
+		case len(sub) > 0 && fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			sub.prune(v.Message())
+		}
+		return true
+	})
+	for _, fd := range cleared {
+		m.Clear(fd)
+	}
+}
@@ -124,14 +124,14 @@ func (c *Converter) DetailsToProto(details *assetmodel.Details) (*cldassetpbv1.D
 	if err != nil {
 		return nil, err
 	}
-	meta, err := c.metaConverter.ToProto(details.Metadata)
-	if err != nil {
-		return nil, err
+	pb := &cldassetpbv1.Details{Asset: asset}
+	// The metadata is not loaded by reads whose field mask does not select it.
+	if details.Metadata != nil {
+		if pb.AssetMetadata, err = c.metaConverter.ToProto(details.Metadata); err != nil {
+			return nil, err
+		}
 	}
-	return &cldassetpbv1.Details{
-		Asset:         asset,
-		AssetMetadata: meta,
-	}, nil
+	return pb, nil
 }
 
 func (c *Converter) DetailsToProtoList(detailsList []*assetmodel.Details) ([]*cldassetpbv1.Details, error) {
@@ -260,14 +260,14 @@ func (c *Converter) DetailsToProto(details *assetmodel.Details) (*muxassetpbv1.D
 	if err != nil {
 		return nil, err
 	}
-	meta, err := c.metaConverter.ToProto(details.Metadata)
-	if err != nil {
-		return nil, err
+	pb := &muxassetpbv1.Details{Asset: asset}
+	// The metadata is not loaded by reads whose field mask does not select it.
+	if details.Metadata != nil {
+		if pb.AssetMetadata, err = c.metaConverter.ToProto(details.Metadata); err != nil {
+			return nil, err
+		}
 	}
-	return &muxassetpbv1.Details{
-		Asset:         asset,
-		AssetMetadata: meta,
-	}, nil
+	return pb, nil
 }
 
 func (c *Converter) DetailsToProtoList(details []*assetmodel.Details) ([]*muxassetpbv1.Details, error) {
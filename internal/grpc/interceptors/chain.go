@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package interceptors provides the standard gRPC server interceptor chain used by the
+// media service asset servers: request ID propagation, panic recovery, request logging and
+// per-method metrics.
+package interceptors
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// Options controls which interceptors are installed by [ServerOptions].
+type Options struct {
+	// RequestID enables request ID propagation. It should stay enabled when Logging is on,
+	// so log entries can be correlated with the caller.
+	RequestID bool
+	// Recovery converts panics in handlers into codes.Internal errors.
+	Recovery bool
+	// Logging enables per-request zap logging.
+	Logging bool
+	// Environment, if not nil, stores the tenant environment of the call in the handler context.
+	Environment grpc.UnaryServerInterceptor
+	// Metrics, if not nil, collects per-method latency and error counts.
+	Metrics MetricsRecorder
+	// Auth, if not nil, authenticates and authorizes calls. It runs after logging, so
+	// rejected calls are still logged and counted.
+	Auth grpc.UnaryServerInterceptor
+	// Deadline, if not nil, attaches the method deadline to calls. It runs after logging, so the
+	// calls which exceed it are logged and counted with the reported code.
+	Deadline grpc.UnaryServerInterceptor
+}
+
+// MetricsRecorder provides an interceptor recording per-method metrics. It is implemented by the
+// in-memory [Metrics] collector and by the Prometheus collectors of the metrics package.
+type MetricsRecorder interface {
+	UnaryInterceptor() grpc.UnaryServerInterceptor
+}
+
+// DefaultOptions returns options with all interceptors enabled and a fresh metrics collector.
+func DefaultOptions() Options {
+	return Options{
+		RequestID: true,
+		Recovery:  true,
+		Logging:   true,
+		Metrics:   NewMetrics(),
+	}
+}
+
+// ServerOptions builds the interceptor chain described by opts. The request source used by the
+// audit log is always recorded. The order is fixed:
+// request ID first (so every following interceptor sees it), then the environment, then metrics and logging
+// (so they observe recovered panics and rejected calls as errors), then auth, then the deadline,
+// and recovery last, closest to the handler.
+func ServerOptions(opts Options, logger *zap.Logger) []grpc.ServerOption {
+	chain := []grpc.UnaryServerInterceptor{UnarySource()}
+	if opts.RequestID {
+		chain = append(chain, UnaryRequestID())
+	}
+	if opts.Environment != nil {
+		chain = append(chain, opts.Environment)
+	}
+	if opts.Metrics != nil {
+		chain = append(chain, opts.Metrics.UnaryInterceptor())
+	}
+	if opts.Logging {
+		chain = append(chain, UnaryLogging(logger))
+	}
+	if opts.Auth != nil {
+		chain = append(chain, opts.Auth)
+	}
+	if opts.Deadline != nil {
+		chain = append(chain, opts.Deadline)
+	}
+	if opts.Recovery {
+		chain = append(chain, UnaryRecovery(logger))
+	}
+	return []grpc.ServerOption{grpc.ChainUnaryInterceptor(chain...)}
+}
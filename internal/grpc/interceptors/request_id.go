@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/mikhail5545/media-service-go/internal/logging"
+)
+
+var (
+	// RequestIDHeader is the metadata key used to receive and return request IDs.
+	RequestIDHeader = strings.ToLower(logging.RequestIDHeader)
+	// CorrelationIDHeader is the metadata key used to receive and return correlation IDs.
+	CorrelationIDHeader = strings.ToLower(logging.CorrelationIDHeader)
+)
+
+// RequestIDFromContext returns the request ID stored in ctx by [UnaryRequestID], or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	return logging.RequestID(ctx)
+}
+
+// ContextWithRequestID returns a copy of ctx carrying the given request ID.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return logging.WithRequestID(ctx, id)
+}
+
+// UnaryRequestID reads the request and correlation IDs from incoming metadata, generating a new
+// request ID if the caller did not send one and defaulting the correlation ID to it. Both are
+// stored in the handler context and echoed back in the response header.
+func UnaryRequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var id, correlationID string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(RequestIDHeader); len(values) > 0 {
+				id = values[0]
+			}
+			if values := md.Get(CorrelationIDHeader); len(values) > 0 {
+				correlationID = values[0]
+			}
+		}
+		if id == "" {
+			id = uuid.NewString()
+		}
+		if correlationID == "" {
+			correlationID = id
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, id, CorrelationIDHeader, correlationID))
+		ctx = logging.WithCorrelationID(logging.WithRequestID(ctx, id), correlationID)
+		return handler(ctx, req)
+	}
+}
+
+// UnaryClientRequestID forwards the request and correlation IDs stored in ctx to outgoing calls,
+// so downstream services log the same IDs.
+func UnaryClientRequestID() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if id := logging.RequestID(ctx); id != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, RequestIDHeader, id)
+		}
+		if id := logging.CorrelationID(ctx); id != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, CorrelationIDHeader, id)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
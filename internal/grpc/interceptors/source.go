@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package interceptors
+
+import (
+	"context"
+
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	"google.golang.org/grpc"
+)
+
+// UnarySource marks the handler context with [auditmodel.SourceGRPC], so audit log entries
+// record the transport the mutation was requested through.
+func UnarySource() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(audit.WithSource(ctx, auditmodel.SourceGRPC), req)
+	}
+}
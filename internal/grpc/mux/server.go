@@ -23,6 +23,7 @@ import (
 
 	"github.com/mikhail5545/media-service-go/internal/grpc/common"
 	muxconv "github.com/mikhail5545/media-service-go/internal/grpc/conversion/mux"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
 	muxservice "github.com/mikhail5545/media-service-go/internal/services/mux"
 	errutil "github.com/mikhail5545/media-service-go/internal/util/errors"
 	muxassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/asset/v1"
@@ -58,27 +59,87 @@ func (s *Server) Ping(ctx context.Context, req *muxassetpbv1.PingRequest) (*muxa
 }
 
 func (s *Server) Get(ctx context.Context, req *muxassetpbv1.GetRequest) (*muxassetpbv1.GetResponse, error) {
-	return common.Handle(ctx, s.converter.ConvertGetRequest, s.converter.ConvertGetResponse, s.service.Get, req)
+	mask, err := common.DetailsMaskFromContext(ctx, &muxassetpbv1.Details{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := common.Handle(ctx, maskGetFilter(s.converter.ConvertGetRequest, mask), s.converter.ConvertGetResponse, s.service.Get, req)
+	if err != nil {
+		return nil, err
+	}
+	mask.Apply(res.GetDetails())
+	return res, nil
 }
 
 func (s *Server) GetWithArchived(ctx context.Context, req *muxassetpbv1.GetWithArchivedRequest) (*muxassetpbv1.GetWithArchivedResponse, error) {
-	return common.Handle(ctx, s.converter.ConvertGetRequest, s.converter.ConvertGetWithArchivedResponse, s.service.GetWithArchived, req)
+	mask, err := common.DetailsMaskFromContext(ctx, &muxassetpbv1.Details{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := common.Handle(ctx, maskGetFilter(s.converter.ConvertGetRequest, mask), s.converter.ConvertGetWithArchivedResponse, s.service.GetWithArchived, req)
+	if err != nil {
+		return nil, err
+	}
+	mask.Apply(res.GetDetails())
+	return res, nil
 }
 
 func (s *Server) GetWithBroken(ctx context.Context, req *muxassetpbv1.GetWithBrokenRequest) (*muxassetpbv1.GetWithBrokenResponse, error) {
-	return common.Handle(ctx, s.converter.ConvertGetRequest, s.converter.ConvertGetWithBrokenResponse, s.service.GetWithBroken, req)
+	mask, err := common.DetailsMaskFromContext(ctx, &muxassetpbv1.Details{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := common.Handle(ctx, maskGetFilter(s.converter.ConvertGetRequest, mask), s.converter.ConvertGetWithBrokenResponse, s.service.GetWithBroken, req)
+	if err != nil {
+		return nil, err
+	}
+	mask.Apply(res.GetDetails())
+	return res, nil
 }
 
 func (s *Server) List(ctx context.Context, req *muxassetpbv1.ListRequest) (*muxassetpbv1.ListResponse, error) {
-	return common.HandleList(ctx, s.converter.ConvertListRequest, s.converter.ConvertListResponse, s.service.List, req)
+	mask, err := common.DetailsMaskFromContext(ctx, &muxassetpbv1.Details{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := common.HandleList(ctx, maskListRequest(s.converter.ConvertListRequest, mask), s.converter.ConvertListResponse, s.service.List, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, details := range res.GetDetails() {
+		mask.Apply(details)
+	}
+	return res, nil
 }
 
 func (s *Server) ListArchived(ctx context.Context, req *muxassetpbv1.ListArchivedRequest) (*muxassetpbv1.ListArchivedResponse, error) {
-	return common.HandleList(ctx, s.converter.ConvertListRequest, s.converter.ConvertListArchivedResponse, s.service.ListArchived, req)
+	mask, err := common.DetailsMaskFromContext(ctx, &muxassetpbv1.Details{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := common.HandleList(ctx, maskListRequest(s.converter.ConvertListRequest, mask), s.converter.ConvertListArchivedResponse, s.service.ListArchived, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, details := range res.GetDetails() {
+		mask.Apply(details)
+	}
+	return res, nil
 }
 
 func (s *Server) ListBroken(ctx context.Context, req *muxassetpbv1.ListBrokenRequest) (*muxassetpbv1.ListBrokenResponse, error) {
-	return common.HandleList(ctx, s.converter.ConvertListRequest, s.converter.ConvertListBrokenResponse, s.service.ListBroken, req)
+	mask, err := common.DetailsMaskFromContext(ctx, &muxassetpbv1.Details{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := common.HandleList(ctx, maskListRequest(s.converter.ConvertListRequest, mask), s.converter.ConvertListBrokenResponse, s.service.ListBroken, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, details := range res.GetDetails() {
+		mask.Apply(details)
+	}
+	return res, nil
 }
 
 func (s *Server) CreateUploadURL(ctx context.Context, req *muxassetpbv1.CreateUploadURLRequest) (*muxassetpbv1.CreateUploadURLResponse, error) {
@@ -122,3 +183,27 @@ func (s *Server) GeneratePlaybackToken(ctx context.Context, req *muxassetpbv1.Ge
 		Token: token,
 	}, nil
 }
+
+// maskGetFilter wraps the converter of a Get request to set the fields of the mask sent with the call.
+func maskGetFilter[Req any](convert func(Req) (*assetmodel.GetFilter, error), mask *common.DetailsMask) func(Req) (*assetmodel.GetFilter, error) {
+	return func(req Req) (*assetmodel.GetFilter, error) {
+		filter, err := convert(req)
+		if err != nil {
+			return nil, err
+		}
+		filter.Fields = mask.Fields()
+		return filter, nil
+	}
+}
+
+// maskListRequest wraps the converter of a List request to set the fields of the mask sent with the call.
+func maskListRequest[Req any](convert func(Req) (*assetmodel.ListRequest, error), mask *common.DetailsMask) func(Req) (*assetmodel.ListRequest, error) {
+	return func(req Req) (*assetmodel.ListRequest, error) {
+		listReq, err := convert(req)
+		if err != nil {
+			return nil, err
+		}
+		listReq.Fields = mask.Fields()
+		return listReq, nil
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package assetimport
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	assetimportservice "github.com/mikhail5545/media-service-go/internal/services/assetimport"
+)
+
+type Handler interface {
+	Create(c echo.Context) error
+	Get(c echo.Context) error
+	List(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *assetimportservice.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *assetimportservice.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the import routes under /import.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	imports := group.Group("/import", m...)
+	{
+		imports.POST("", h.Create)
+		imports.GET("", h.List)
+		imports.GET("/:id", h.Get)
+	}
+}
+
+// Create queues an import job, the job is run in the background.
+func (h *AdminHandler) Create(c echo.Context) error {
+	return generic.Handle(c, h.service.Create, http.StatusAccepted, "job")
+}
+
+func (h *AdminHandler) Get(c echo.Context) error {
+	return generic.Handle(c, h.service.Get, http.StatusOK, "job")
+}
+
+func (h *AdminHandler) List(c echo.Context) error {
+	return generic.Handle(c, h.service.List, http.StatusOK, "jobs")
+}
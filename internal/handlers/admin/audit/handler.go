@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	auditservice "github.com/mikhail5545/media-service-go/internal/services/audit"
+)
+
+type Handler interface {
+	List(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *auditservice.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *auditservice.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the audit log route under /audit.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	group.GET("/audit", h.List, m...)
+}
+
+func (h *AdminHandler) List(c echo.Context) error {
+	return generic.HandleList(c, h.service.List, "entries")
+}
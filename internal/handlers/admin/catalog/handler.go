@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package catalog
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	catalogservice "github.com/mikhail5545/media-service-go/internal/services/catalog"
+)
+
+type Handler interface {
+	ListMedia(c echo.Context) error
+	ListProviders(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *catalogservice.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *catalogservice.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the catalog routes under /catalog.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	catalog := group.Group("/catalog", m...)
+	{
+		catalog.GET("/providers", h.ListProviders)
+		catalog.GET("/media", h.ListMedia)
+	}
+}
+
+func (h *AdminHandler) ListMedia(c echo.Context) error {
+	return generic.HandleList(c, h.service.ListMedia, "media")
+}
+
+func (h *AdminHandler) ListProviders(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]any{"providers": h.service.Providers()})
+}
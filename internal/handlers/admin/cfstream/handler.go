@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cfstream
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	cfstreamprovider "github.com/mikhail5545/media-service-go/internal/mediaprovider/cfstream"
+	"github.com/mikhail5545/media-service-go/internal/routers"
+	cfstreamservice "github.com/mikhail5545/media-service-go/internal/services/cfstream"
+)
+
+// Provider names the provider in the paths of the routes.
+const Provider = cfstreamprovider.Name
+
+type Handler interface {
+	CreateUploadURL(c echo.Context) error
+	GetPlaybackURL(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *cfstreamservice.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *cfstreamservice.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the routes specific to Cloudflare Stream under /cfstream, the common asset
+// routes are served under /media/cfstream.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	assets := routers.ProviderGroup(group, Provider, m...).Group("/assets")
+	{
+		assets.POST("/upload-url", h.CreateUploadURL)
+		assets.GET("/:id/playback-url", h.GetPlaybackURL)
+	}
+}
+
+func (h *AdminHandler) CreateUploadURL(c echo.Context) error {
+	return generic.Handle(c, h.service.CreateUploadURL, http.StatusCreated, "data")
+}
+
+func (h *AdminHandler) GetPlaybackURL(c echo.Context) error {
+	return generic.Handle(c, h.service.GetPlaybackURL, http.StatusOK, "playback")
+}
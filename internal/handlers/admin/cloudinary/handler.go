@@ -18,13 +18,21 @@
 package cloudinary
 
 import (
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	"github.com/mikhail5545/media-service-go/internal/payload"
+	"github.com/mikhail5545/media-service-go/internal/routers"
 	cldservice "github.com/mikhail5545/media-service-go/internal/services/cloudinary"
 )
 
+// Provider names the provider in the paths of the routes.
+const Provider = "cloudinary"
+
 type Handler interface {
 	Get(c echo.Context) error
 	GetWithArchived(c echo.Context) error
@@ -32,13 +40,36 @@ type Handler interface {
 	List(c echo.Context) error
 	ListArchived(c echo.Context) error
 	ListBroken(c echo.Context) error
+	GetByOwner(c echo.Context) error
+	ListVersions(c echo.Context) error
+	RollbackVersion(c echo.Context) error
+	ValidateAssets(c echo.Context) error
+	ListByOwner(c echo.Context) error
+	ListOwnerTypes(c echo.Context) error
+	ListByTag(c echo.Context) error
+	Search(c echo.Context) error
+	ListByCollection(c echo.Context) error
+	Upload(c echo.Context) error
+	GetHistory(c echo.Context) error
 	CreateSignedUploadURL(c echo.Context) error
 	Archive(c echo.Context) error
 	Restore(c echo.Context) error
+	RestoreWithOwners(c echo.Context) error
+	RestoreFromBackup(c echo.Context) error
 	Delete(c echo.Context) error
+	ListStuckDeletions(c echo.Context) error
+	FindDuplicates(c echo.Context) error
 	MarkAsBroken(c echo.Context) error
+	ApproveModeration(c echo.Context) error
+	RejectModeration(c echo.Context) error
+	UpdateMetadata(c echo.Context) error
+	AddTags(c echo.Context) error
+	RemoveTags(c echo.Context) error
+	SchedulePublishing(c echo.Context) error
+	CancelPublishing(c echo.Context) error
 	AddOwner(c echo.Context) error
 	RemoveOwner(c echo.Context) error
+	UpdateOwners(c echo.Context) error
 }
 
 type AdminHandler struct {
@@ -53,6 +84,53 @@ func New(svc *cldservice.Service) *AdminHandler {
 	}
 }
 
+// Register registers the Cloudinary asset routes under /cloudinary.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	cldGroup := routers.ProviderGroup(group, Provider, m...)
+	{
+		cldGroup.GET("/owner-types", h.ListOwnerTypes)
+
+		assets := cldGroup.Group("/assets")
+		{
+			assets.GET("/:id", h.Get)
+			assets.GET("/archived/:id", h.GetWithArchived)
+			assets.GET("/broken/:id", h.GetWithBroken)
+			assets.GET("", h.List)
+			assets.GET("/archived", h.ListArchived)
+			assets.GET("/broken", h.ListBroken)
+			assets.GET("/by-owner", h.ListByOwner)
+			assets.GET("/by-owner/:owner_type/:owner_id", h.GetByOwner)
+			assets.GET("/by-owner/:owner_type/:owner_id/versions", h.ListVersions)
+			assets.POST("/by-owner/:owner_type/:owner_id/versions/:number/rollback", h.RollbackVersion)
+			assets.POST("/validate", h.ValidateAssets)
+			assets.GET("/by-tag", h.ListByTag)
+			assets.GET("/search", h.Search)
+			assets.GET("/by-collection", h.ListByCollection)
+			assets.GET("/:id/history", h.GetHistory)
+			assets.GET("/deletions/stuck", h.ListStuckDeletions)
+			assets.GET("/duplicates", h.FindDuplicates)
+			assets.POST("/upload/url-gen", h.CreateSignedUploadURL)
+			assets.POST("/upload", h.Upload)
+			assets.DELETE("/archive/:id", h.Archive)
+			assets.POST("/restore/:id", h.Restore)
+			assets.POST("/restore/:id/owners", h.RestoreWithOwners)
+			assets.POST("/restore/:id/backup", h.RestoreFromBackup)
+			assets.DELETE("/:id", h.Delete)
+			assets.POST("/broken/:id", h.MarkAsBroken)
+			assets.POST("/moderation/approve/:id", h.ApproveModeration)
+			assets.POST("/moderation/reject/:id", h.RejectModeration)
+			assets.PATCH("/:id/metadata", h.UpdateMetadata)
+			assets.POST("/:id/tags", h.AddTags)
+			assets.DELETE("/:id/tags", h.RemoveTags)
+			assets.PUT("/:id/publishing", h.SchedulePublishing)
+			assets.DELETE("/:id/publishing", h.CancelPublishing)
+			assets.POST("/:id/owners", h.AddOwner)
+			assets.DELETE("/:id/owners", h.RemoveOwner)
+			assets.PUT("/:id/owners", h.UpdateOwners)
+		}
+	}
+}
+
 func (h *AdminHandler) Get(c echo.Context) error {
 	return generic.Handle(c, h.service.Get, http.StatusOK, "asset")
 }
@@ -66,15 +144,40 @@ func (h *AdminHandler) GetWithBroken(c echo.Context) error {
 }
 
 func (h *AdminHandler) List(c echo.Context) error {
-	return generic.HandleList(c, h.service.List, "assets")
+	return generic.HandleListWithTotal(c, h.service.List, h.service.ListTotal, "assets")
 }
 
 func (h *AdminHandler) ListArchived(c echo.Context) error {
-	return generic.HandleList(c, h.service.List, "assets")
+	return generic.HandleListWithTotal(c, h.service.ListArchived, h.service.ListArchivedTotal, "assets")
 }
 
 func (h *AdminHandler) ListBroken(c echo.Context) error {
-	return generic.HandleList(c, h.service.List, "assets")
+	return generic.HandleListWithTotal(c, h.service.ListBroken, h.service.ListBrokenTotal, "assets")
+}
+
+func (h *AdminHandler) GetByOwner(c echo.Context) error {
+	return generic.Handle(c, h.service.GetByOwner, http.StatusOK, "asset")
+}
+
+func (h *AdminHandler) ListVersions(c echo.Context) error {
+	return generic.Handle(c, h.service.ListVersions, http.StatusOK, "versions")
+}
+
+func (h *AdminHandler) RollbackVersion(c echo.Context) error {
+	return generic.Handle(c, h.service.RollbackVersion, http.StatusOK, "association")
+}
+
+// ValidateAssets reports whether the assets referenced by another service exist and are healthy.
+func (h *AdminHandler) ValidateAssets(c echo.Context) error {
+	return generic.Handle(c, h.service.ValidateAssets, http.StatusOK, "assets")
+}
+
+func (h *AdminHandler) ListByOwner(c echo.Context) error {
+	return generic.HandleList(c, h.service.ListByOwner, "assets")
+}
+
+func (h *AdminHandler) ListOwnerTypes(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]any{"owner_types": h.service.ListOwnerTypes(c.Request().Context())})
 }
 
 func (h *AdminHandler) CreateSignedUploadURL(c echo.Context) error {
@@ -89,18 +192,148 @@ func (h *AdminHandler) Restore(c echo.Context) error {
 	return generic.HandleVoid(c, h.service.Restore, http.StatusOK)
 }
 
+// RestoreWithOwners restores an archived asset and re-associates its former owners.
+func (h *AdminHandler) RestoreWithOwners(c echo.Context) error {
+	return generic.Handle(c, h.service.RestoreWithOwners, http.StatusOK, "metadata")
+}
+
+// RestoreFromBackup restores the file of an archived image destroyed in Cloudinary from its backup.
+func (h *AdminHandler) RestoreFromBackup(c echo.Context) error {
+	return generic.Handle(c, h.service.RestoreFromBackup, http.StatusOK, "asset")
+}
+
 func (h *AdminHandler) Delete(c echo.Context) error {
-	return generic.HandleVoid(c, h.service.Delete, http.StatusNoContent)
+	return generic.HandleVoid(c, h.service.Delete, http.StatusAccepted)
+}
+
+func (h *AdminHandler) ListStuckDeletions(c echo.Context) error {
+	return generic.Handle(c, h.service.ListStuckDeletions, http.StatusOK, "assets")
+}
+
+// FindDuplicates lists the groups of probable duplicate assets.
+func (h *AdminHandler) FindDuplicates(c echo.Context) error {
+	return generic.Handle(c, h.service.FindDuplicates, http.StatusOK, "groups")
 }
 
 func (h *AdminHandler) MarkAsBroken(c echo.Context) error {
 	return generic.HandleVoid(c, h.service.MarkAsBroken, http.StatusOK)
 }
 
+func (h *AdminHandler) ApproveModeration(c echo.Context) error {
+	return generic.HandleVoid(c, h.service.ApproveModeration, http.StatusOK)
+}
+
+func (h *AdminHandler) RejectModeration(c echo.Context) error {
+	return generic.HandleVoid(c, h.service.RejectModeration, http.StatusOK)
+}
+
+func (h *AdminHandler) ListByTag(c echo.Context) error {
+	return generic.HandleList(c, h.service.ListByTag, "assets")
+}
+
+func (h *AdminHandler) Search(c echo.Context) error {
+	return generic.HandleList(c, h.service.Search, "assets")
+}
+
+func (h *AdminHandler) ListByCollection(c echo.Context) error {
+	return generic.HandleList(c, h.service.ListByCollection, "assets")
+}
+
+func (h *AdminHandler) GetHistory(c echo.Context) error {
+	return generic.Handle(c, h.service.GetHistory, http.StatusOK, "events")
+}
+
+// maxUploadFieldSize limits the size of the form fields preceding the file of an upload.
+const maxUploadFieldSize = 4 << 10
+
+// Upload streams a multipart/form-data image upload to Cloudinary. The public_id, admin_id,
+// admin_name and note fields must precede the "file" part, so the file is relayed as it is
+// received instead of being buffered.
+func (h *AdminHandler) Upload(c echo.Context) error {
+	reader, err := c.Request().MultipartReader()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "request body must be multipart/form-data")
+	}
+	req := &assetmodel.UploadRequest{}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return echo.NewHTTPError(http.StatusBadRequest, "file part is missing")
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid multipart body")
+		}
+		if part.FormName() == "file" {
+			asset, err := h.service.Upload(c.Request().Context(), req, part)
+			if err != nil {
+				return err
+			}
+			return c.JSON(http.StatusCreated, map[string]any{"asset": asset})
+		}
+
+		value, err := io.ReadAll(io.LimitReader(part, maxUploadFieldSize))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid multipart body")
+		}
+		switch part.FormName() {
+		case "public_id":
+			req.PublicID = string(value)
+		case "admin_id":
+			req.AdminID = string(value)
+		case "admin_name":
+			req.AdminName = string(value)
+		case "note":
+			req.Note = string(value)
+		}
+	}
+}
+
+func (h *AdminHandler) AddTags(c echo.Context) error {
+	return generic.Handle(c, h.service.AddTags, http.StatusOK, "tags")
+}
+
+func (h *AdminHandler) RemoveTags(c echo.Context) error {
+	return generic.Handle(c, h.service.RemoveTags, http.StatusOK, "tags")
+}
+
+func (h *AdminHandler) SchedulePublishing(c echo.Context) error {
+	return generic.Handle(c, h.service.SchedulePublishing, http.StatusOK, "publishing")
+}
+
+func (h *AdminHandler) CancelPublishing(c echo.Context) error {
+	return generic.Handle(c, h.service.CancelPublishing, http.StatusOK, "publishing")
+}
+
+func (h *AdminHandler) UpdateMetadata(c echo.Context) error {
+	return generic.Handle(c, h.service.UpdateMetadata, http.StatusOK, "metadata")
+}
+
 func (h *AdminHandler) AddOwner(c echo.Context) error {
-	return generic.HandleVoid(c, h.service.AddOwner, http.StatusCreated)
+	return generic.Handle(c, h.service.AssociateOwner, http.StatusCreated, "association")
 }
 
 func (h *AdminHandler) RemoveOwner(c echo.Context) error {
 	return generic.HandleVoid(c, h.service.RemoveOwner, http.StatusNoContent)
 }
+
+// UpdateOwners replaces the owners of an asset. The expected revision of the asset metadata is taken
+// from the If-Match header if it is set, the new revision is returned in the ETag header.
+func (h *AdminHandler) UpdateOwners(c echo.Context) error {
+	req := new(assetmodel.UpdateOwnersRequest)
+	if err := c.Bind(req); err != nil {
+		return payload.BindError(err, "invalid request body")
+	}
+	revision, err := generic.IfMatchRevision(c)
+	if err != nil {
+		return err
+	}
+	if revision != nil {
+		req.Revision = revision
+	}
+	metadata, err := h.service.UpdateOwners(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+	generic.SetRevisionETag(c, metadata.Revision)
+	return c.JSON(http.StatusOK, map[string]any{"owners": metadata.Owners})
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package collection
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	collectionservice "github.com/mikhail5545/media-service-go/internal/services/collection"
+)
+
+type Handler interface {
+	Get(c echo.Context) error
+	List(c echo.Context) error
+	Create(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+	AddAssets(c echo.Context) error
+	RemoveAssets(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *collectionservice.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *collectionservice.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the collection routes under /collections.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	collections := group.Group("/collections", m...)
+	{
+		collections.GET("/:id", h.Get)
+		collections.GET("", h.List)
+		collections.POST("", h.Create)
+		collections.PATCH("/:id", h.Update)
+		collections.DELETE("/:id", h.Delete)
+		collections.POST("/:id/assets", h.AddAssets)
+		collections.DELETE("/:id/assets", h.RemoveAssets)
+	}
+}
+
+func (h *AdminHandler) Get(c echo.Context) error {
+	return generic.Handle(c, h.service.Get, http.StatusOK, "collection")
+}
+
+func (h *AdminHandler) List(c echo.Context) error {
+	return generic.HandleList(c, h.service.List, "collections")
+}
+
+func (h *AdminHandler) Create(c echo.Context) error {
+	return generic.Handle(c, h.service.Create, http.StatusCreated, "collection")
+}
+
+func (h *AdminHandler) Update(c echo.Context) error {
+	return generic.Handle(c, h.service.Update, http.StatusOK, "collection")
+}
+
+func (h *AdminHandler) Delete(c echo.Context) error {
+	return generic.HandleVoid(c, h.service.Delete, http.StatusNoContent)
+}
+
+func (h *AdminHandler) AddAssets(c echo.Context) error {
+	return generic.HandleVoid(c, h.service.AddAssets, http.StatusNoContent)
+}
+
+func (h *AdminHandler) RemoveAssets(c echo.Context) error {
+	return generic.HandleVoid(c, h.service.RemoveAssets, http.StatusNoContent)
+}
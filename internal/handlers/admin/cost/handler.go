@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package cost
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	costmodel "github.com/mikhail5545/media-service-go/internal/models/cost"
+	"github.com/mikhail5545/media-service-go/internal/payload"
+	costservice "github.com/mikhail5545/media-service-go/internal/services/cost"
+)
+
+type Handler interface {
+	Report(c echo.Context) error
+	Export(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *costservice.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *costservice.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the cost report routes under /costs.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	costs := group.Group("/costs", m...)
+	{
+		costs.GET("", h.Report)
+		costs.GET("/export", h.Export)
+	}
+}
+
+func (h *AdminHandler) Report(c echo.Context) error {
+	return generic.Handle(c, h.service.Report, http.StatusOK, "report")
+}
+
+// Export serves the cost report of the month as a CSV attachment.
+func (h *AdminHandler) Export(c echo.Context) error {
+	req := new(costmodel.ReportRequest)
+	if err := c.Bind(req); err != nil {
+		return payload.BindError(err, "invalid request parameters")
+	}
+	report, err := h.service.Report(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", "costs-"+report.Month+".csv"))
+	res.WriteHeader(http.StatusOK)
+	return costservice.WriteCSV(res, report)
+}
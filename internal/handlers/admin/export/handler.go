@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package export
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	exportmodel "github.com/mikhail5545/media-service-go/internal/models/export"
+	exportservice "github.com/mikhail5545/media-service-go/internal/services/export"
+)
+
+type Handler interface {
+	Create(c echo.Context) error
+	Get(c echo.Context) error
+	List(c echo.Context) error
+	Download(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *exportservice.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *exportservice.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the export routes under /export.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	exports := group.Group("/export", m...)
+	{
+		exports.POST("", h.Create)
+		exports.GET("", h.List)
+		exports.GET("/:id", h.Get)
+		exports.GET("/:id/download", h.Download)
+	}
+}
+
+// Create queues an export job, the job is run in the background.
+func (h *AdminHandler) Create(c echo.Context) error {
+	return generic.Handle(c, h.service.Create, http.StatusAccepted, "job")
+}
+
+func (h *AdminHandler) Get(c echo.Context) error {
+	return generic.Handle(c, h.service.Get, http.StatusOK, "job")
+}
+
+func (h *AdminHandler) List(c echo.Context) error {
+	return generic.Handle(c, h.service.List, http.StatusOK, "jobs")
+}
+
+// Download serves the file of a completed export job as an attachment.
+func (h *AdminHandler) Download(c echo.Context) error {
+	file, err := h.service.GetFile(c.Request().Context(), &exportmodel.GetJobRequest{ID: c.Param("id")})
+	if err != nil {
+		return err
+	}
+	c.Response().Header().Set(echo.HeaderContentType, file.ContentType)
+	return c.Attachment(file.Path, file.Name)
+}
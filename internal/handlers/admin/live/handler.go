@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	livemodel "github.com/mikhail5545/media-service-go/internal/models/live"
+	"github.com/mikhail5545/media-service-go/internal/payload"
+	"golang.org/x/net/websocket"
+)
+
+// Subprotocol is the WebSocket subprotocol of the stream. Browser clients send it along with the
+// bearer token, see [auth.WebSocketTokenPrefix].
+const Subprotocol = "media-events.v1"
+
+const (
+	// heartbeatInterval is the interval of the pings sent to the connections.
+	heartbeatInterval = 30 * time.Second
+	// maxMessageBytes limits the size of the filters sent by the clients.
+	maxMessageBytes = 4 << 10
+)
+
+type Handler interface {
+	Stream(c echo.Context) error
+}
+
+type AdminHandler struct {
+	hub *events.Hub
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(hub *events.Hub) *AdminHandler {
+	return &AdminHandler{
+		hub: hub,
+	}
+}
+
+// Register registers the live asset event stream under /ws.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	group.GET("/ws", h.Stream, m...)
+}
+
+// Stream upgrades the request to a WebSocket connection and sends the asset lifecycle events published
+// by this instance to it, limited by the filter of the connection.
+func (h *AdminHandler) Stream(c echo.Context) error {
+	filter := new(livemodel.Filter)
+	if err := c.Bind(filter); err != nil {
+		return payload.BindError(err, "invalid filter")
+	}
+	if err := filter.Validate(); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	ctx := c.Request().Context()
+	server := websocket.Server{
+		Handshake: selectSubprotocol,
+		Handler: func(conn *websocket.Conn) {
+			h.serve(ctx, conn, filter)
+		},
+	}
+	server.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
+// selectSubprotocol answers the handshake with [Subprotocol] if the client offered it, the offered
+// token must not be echoed back.
+func selectSubprotocol(cfg *websocket.Config, _ *http.Request) error {
+	if slices.Contains(cfg.Protocol, Subprotocol) {
+		cfg.Protocol = []string{Subprotocol}
+	} else {
+		cfg.Protocol = nil
+	}
+	return nil
+}
+
+func (h *AdminHandler) serve(ctx context.Context, conn *websocket.Conn, filter *livemodel.Filter) {
+	defer conn.Close()
+	conn.MaxPayloadBytes = maxMessageBytes
+
+	sub := h.hub.SubscribeAll()
+	defer sub.Close()
+
+	filters := make(chan *livemodel.Filter)
+	done := make(chan struct{})
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		defer close(done)
+		receiveFilters(conn, filters, stop)
+	}()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		var msg *livemodel.Message
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case filter = <-filters:
+			msg = &livemodel.Message{Type: livemodel.MessageFilter, Filter: filter}
+		case event := <-sub.C:
+			if !filter.Matches(event) {
+				continue
+			}
+			msg = &livemodel.Message{Type: livemodel.MessageEvent, Event: event}
+		case <-heartbeat.C:
+			msg = &livemodel.Message{Type: livemodel.MessagePing}
+		}
+		if err := websocket.JSON.Send(conn, msg); err != nil {
+			return
+		}
+	}
+}
+
+// receiveFilters reads the filters sent by the client until the connection fails or stop is closed.
+// Invalid filters are answered with an error message.
+func receiveFilters(conn *websocket.Conn, filters chan<- *livemodel.Filter, stop <-chan struct{}) {
+	for {
+		var raw []byte
+		if err := websocket.Message.Receive(conn, &raw); err != nil {
+			return
+		}
+		filter := new(livemodel.Filter)
+		err := json.Unmarshal(raw, filter)
+		if err == nil {
+			err = filter.Validate()
+		}
+		if err != nil {
+			if err := websocket.JSON.Send(conn, &livemodel.Message{Type: livemodel.MessageError, Error: err.Error()}); err != nil {
+				return
+			}
+			continue
+		}
+		select {
+		case filters <- filter:
+		case <-stop:
+			return
+		}
+	}
+}
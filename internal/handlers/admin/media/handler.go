@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package media serves the admin routes of the storage backends built on the shared asset core. The
+// backend is selected by the provider path parameter.
+package media
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	"github.com/mikhail5545/media-service-go/internal/services/mediacore"
+)
+
+type Handler interface {
+	ListProviders(c echo.Context) error
+	Get(c echo.Context) error
+	GetWithArchived(c echo.Context) error
+	List(c echo.Context) error
+	ListArchived(c echo.Context) error
+	ListByOwner(c echo.Context) error
+	Archive(c echo.Context) error
+	Restore(c echo.Context) error
+	Delete(c echo.Context) error
+	ListStuckDeletions(c echo.Context) error
+	AddOwner(c echo.Context) error
+	RemoveOwner(c echo.Context) error
+}
+
+type AdminHandler struct {
+	registry *mediacore.Registry
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(registry *mediacore.Registry) *AdminHandler {
+	return &AdminHandler{
+		registry: registry,
+	}
+}
+
+// Register registers the routes shared by the backends built on the asset core under /media/:provider.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	mediaGroup := group.Group("/media", m...)
+	{
+		mediaGroup.GET("/providers", h.ListProviders)
+
+		assets := mediaGroup.Group("/:provider/assets")
+		{
+			assets.GET("/:id", h.Get)
+			assets.GET("/archived/:id", h.GetWithArchived)
+			assets.GET("", h.List)
+			assets.GET("/archived", h.ListArchived)
+			assets.GET("/by-owner", h.ListByOwner)
+			assets.GET("/deletions/stuck", h.ListStuckDeletions)
+			assets.DELETE("/archive/:id", h.Archive)
+			assets.POST("/restore/:id", h.Restore)
+			assets.DELETE("/:id", h.Delete)
+			assets.POST("/:id/owners", h.AddOwner)
+			assets.DELETE("/:id/owners", h.RemoveOwner)
+		}
+	}
+}
+
+// service resolves the service of the provider requested by the provider path parameter.
+func (h *AdminHandler) service(c echo.Context) (*mediacore.Service, error) {
+	svc, ok := h.registry.Get(c.Param("provider"))
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "unknown media provider")
+	}
+	return svc, nil
+}
+
+func (h *AdminHandler) ListProviders(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]any{"providers": h.registry.Names()})
+}
+
+func (h *AdminHandler) Get(c echo.Context) error {
+	svc, err := h.service(c)
+	if err != nil {
+		return err
+	}
+	return generic.Handle(c, svc.Get, http.StatusOK, "asset")
+}
+
+func (h *AdminHandler) GetWithArchived(c echo.Context) error {
+	svc, err := h.service(c)
+	if err != nil {
+		return err
+	}
+	return generic.Handle(c, svc.GetWithArchived, http.StatusOK, "asset")
+}
+
+func (h *AdminHandler) List(c echo.Context) error {
+	svc, err := h.service(c)
+	if err != nil {
+		return err
+	}
+	return generic.HandleList(c, svc.List, "assets")
+}
+
+func (h *AdminHandler) ListArchived(c echo.Context) error {
+	svc, err := h.service(c)
+	if err != nil {
+		return err
+	}
+	return generic.HandleList(c, svc.ListArchived, "assets")
+}
+
+func (h *AdminHandler) ListByOwner(c echo.Context) error {
+	svc, err := h.service(c)
+	if err != nil {
+		return err
+	}
+	return generic.HandleList(c, svc.ListByOwner, "assets")
+}
+
+func (h *AdminHandler) Archive(c echo.Context) error {
+	svc, err := h.service(c)
+	if err != nil {
+		return err
+	}
+	return generic.HandleVoid(c, svc.Archive, http.StatusNoContent)
+}
+
+func (h *AdminHandler) Restore(c echo.Context) error {
+	svc, err := h.service(c)
+	if err != nil {
+		return err
+	}
+	return generic.HandleVoid(c, svc.Restore, http.StatusOK)
+}
+
+func (h *AdminHandler) Delete(c echo.Context) error {
+	svc, err := h.service(c)
+	if err != nil {
+		return err
+	}
+	return generic.HandleVoid(c, svc.Delete, http.StatusAccepted)
+}
+
+func (h *AdminHandler) ListStuckDeletions(c echo.Context) error {
+	svc, err := h.service(c)
+	if err != nil {
+		return err
+	}
+	return generic.Handle(c, svc.ListStuckDeletions, http.StatusOK, "assets")
+}
+
+func (h *AdminHandler) AddOwner(c echo.Context) error {
+	svc, err := h.service(c)
+	if err != nil {
+		return err
+	}
+	return generic.Handle(c, svc.AddOwner, http.StatusCreated, "metadata")
+}
+
+func (h *AdminHandler) RemoveOwner(c echo.Context) error {
+	svc, err := h.service(c)
+	if err != nil {
+		return err
+	}
+	return generic.Handle(c, svc.RemoveOwner, http.StatusOK, "metadata")
+}
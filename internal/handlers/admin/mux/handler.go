@@ -18,13 +18,21 @@
 package mux
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	"github.com/mikhail5545/media-service-go/internal/payload"
+	"github.com/mikhail5545/media-service-go/internal/routers"
 	muxservice "github.com/mikhail5545/media-service-go/internal/services/mux"
 )
 
+// Provider names the provider in the paths of the routes.
+const Provider = "mux"
+
 type Handler interface {
 	Get(c echo.Context) error
 	GetWithArchived(c echo.Context) error
@@ -32,13 +40,48 @@ type Handler interface {
 	List(c echo.Context) error
 	ListArchived(c echo.Context) error
 	ListBroken(c echo.Context) error
+	GetByOwner(c echo.Context) error
+	ListVersions(c echo.Context) error
+	RollbackVersion(c echo.Context) error
+	WatchAsset(c echo.Context) error
+	ValidateAssets(c echo.Context) error
+	ListByOwner(c echo.Context) error
+	ListOwnerTypes(c echo.Context) error
+	ListByTag(c echo.Context) error
+	Search(c echo.Context) error
+	ListByCollection(c echo.Context) error
+	GetHistory(c echo.Context) error
 	CreateUploadURL(c echo.Context) error
 	Archive(c echo.Context) error
 	Restore(c echo.Context) error
+	RestoreWithOwners(c echo.Context) error
 	Delete(c echo.Context) error
+	ListStuckDeletions(c echo.Context) error
+	FindDuplicates(c echo.Context) error
 	MarkAsBroken(c echo.Context) error
+	UpdateMetadata(c echo.Context) error
+	AddTags(c echo.Context) error
+	RemoveTags(c echo.Context) error
+	SchedulePublishing(c echo.Context) error
+	CancelPublishing(c echo.Context) error
 	AddOwner(c echo.Context) error
 	RemoveOwner(c echo.Context) error
+	UpdateOwners(c echo.Context) error
+	AddPlaybackID(c echo.Context) error
+	RemovePlaybackID(c echo.Context) error
+	RotatePlaybackID(c echo.Context) error
+	SetPoster(c echo.Context) error
+	ResetPoster(c echo.Context) error
+	CountErrors(c echo.Context) error
+	ListStuckAssets(c echo.Context) error
+	ReprocessAsset(c echo.Context) error
+	ListLanguages(c echo.Context) error
+	UpdateTrack(c echo.Context) error
+	ListChapters(c echo.Context) error
+	AddChapter(c echo.Context) error
+	UpdateChapter(c echo.Context) error
+	DeleteChapter(c echo.Context) error
+	SyncPlaybackRestrictions(c echo.Context) error
 }
 
 type AdminHandler struct {
@@ -53,6 +96,65 @@ func New(svc *muxservice.Service) *AdminHandler {
 	}
 }
 
+// Register registers the MUX asset routes under /mux.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	muxGroup := routers.ProviderGroup(group, Provider, m...)
+	{
+		muxGroup.GET("/owner-types", h.ListOwnerTypes)
+		muxGroup.POST("/playback-restrictions/sync", h.SyncPlaybackRestrictions)
+
+		assets := muxGroup.Group("/assets")
+		{
+			assets.GET("/:id", h.Get)
+			assets.GET("/archived/:id", h.GetWithArchived)
+			assets.GET("/broken/:id", h.GetWithBroken)
+			assets.GET("", h.List)
+			assets.GET("/archived", h.ListArchived)
+			assets.GET("/broken", h.ListBroken)
+			assets.GET("/by-owner", h.ListByOwner)
+			assets.GET("/by-owner/:owner_type/:owner_id", h.GetByOwner)
+			assets.GET("/by-owner/:owner_type/:owner_id/versions", h.ListVersions)
+			assets.POST("/by-owner/:owner_type/:owner_id/versions/:number/rollback", h.RollbackVersion)
+			assets.POST("/validate", h.ValidateAssets)
+			assets.GET("/by-tag", h.ListByTag)
+			assets.GET("/search", h.Search)
+			assets.GET("/by-collection", h.ListByCollection)
+			assets.GET("/:id/history", h.GetHistory)
+			assets.GET("/:id/watch", h.WatchAsset)
+			assets.GET("/deletions/stuck", h.ListStuckDeletions)
+			assets.GET("/duplicates", h.FindDuplicates)
+			assets.GET("/triage/errors", h.CountErrors)
+			assets.GET("/triage/stuck", h.ListStuckAssets)
+			assets.POST("/:id/reprocess", h.ReprocessAsset)
+			assets.POST("/upload-url", h.CreateUploadURL)
+			assets.DELETE("/archive/:id", h.Archive)
+			assets.POST("/restore/:id", h.Restore)
+			assets.POST("/restore/:id/owners", h.RestoreWithOwners)
+			assets.DELETE("/:id", h.Delete)
+			assets.POST("/broken/:id", h.MarkAsBroken)
+			assets.PATCH("/:id/metadata", h.UpdateMetadata)
+			assets.POST("/:id/tags", h.AddTags)
+			assets.DELETE("/:id/tags", h.RemoveTags)
+			assets.PUT("/:id/publishing", h.SchedulePublishing)
+			assets.DELETE("/:id/publishing", h.CancelPublishing)
+			assets.POST("/:id/owners", h.AddOwner)
+			assets.DELETE("/:id/owners", h.RemoveOwner)
+			assets.PUT("/:id/owners", h.UpdateOwners)
+			assets.POST("/:id/playback-ids", h.AddPlaybackID)
+			assets.DELETE("/:id/playback-ids/:playback_id", h.RemovePlaybackID)
+			assets.POST("/:id/playback-ids/rotate", h.RotatePlaybackID)
+			assets.PUT("/:id/poster", h.SetPoster)
+			assets.DELETE("/:id/poster", h.ResetPoster)
+			assets.GET("/:id/languages", h.ListLanguages)
+			assets.PATCH("/:id/tracks/:track_id", h.UpdateTrack)
+			assets.GET("/:id/chapters", h.ListChapters)
+			assets.POST("/:id/chapters", h.AddChapter)
+			assets.PATCH("/:id/chapters/:chapter_id", h.UpdateChapter)
+			assets.DELETE("/:id/chapters/:chapter_id", h.DeleteChapter)
+		}
+	}
+}
+
 func (h *AdminHandler) Get(c echo.Context) error {
 	return generic.Handle(c, h.service.Get, http.StatusOK, "asset")
 }
@@ -66,15 +168,93 @@ func (h *AdminHandler) GetWithBroken(c echo.Context) error {
 }
 
 func (h *AdminHandler) List(c echo.Context) error {
-	return generic.HandleList(c, h.service.List, "assets")
+	return generic.HandleListWithTotal(c, h.service.List, h.service.ListTotal, "assets")
 }
 
 func (h *AdminHandler) ListArchived(c echo.Context) error {
-	return generic.HandleList(c, h.service.ListArchived, "assets")
+	return generic.HandleListWithTotal(c, h.service.ListArchived, h.service.ListArchivedTotal, "assets")
 }
 
 func (h *AdminHandler) ListBroken(c echo.Context) error {
-	return generic.HandleList(c, h.service.ListBroken, "assets")
+	return generic.HandleListWithTotal(c, h.service.ListBroken, h.service.ListBrokenTotal, "assets")
+}
+
+func (h *AdminHandler) GetByOwner(c echo.Context) error {
+	return generic.Handle(c, h.service.GetByOwner, http.StatusOK, "asset")
+}
+
+func (h *AdminHandler) ListVersions(c echo.Context) error {
+	return generic.Handle(c, h.service.ListVersions, http.StatusOK, "versions")
+}
+
+func (h *AdminHandler) RollbackVersion(c echo.Context) error {
+	return generic.Handle(c, h.service.RollbackVersion, http.StatusOK, "association")
+}
+
+// WatchAsset streams the status updates of an asset as server-sent events until it is ready, errored
+// or deleted. Each update is sent as a "status" event. A watch which times out before ends with a
+// "timeout" event, a failure after the stream started ends with an "error" event.
+func (h *AdminHandler) WatchAsset(c echo.Context) error {
+	req := new(assetmodel.WatchAssetRequest)
+	if err := c.Bind(req); err != nil {
+		return payload.BindError(err, "invalid request parameters")
+	}
+	res := c.Response()
+	var last *assetmodel.StatusUpdate
+	err := h.service.WatchAsset(c.Request().Context(), req, func(update *assetmodel.StatusUpdate) error {
+		if last == nil {
+			res.Header().Set(echo.HeaderContentType, "text/event-stream")
+			res.Header().Set(echo.HeaderCacheControl, "no-cache")
+			res.Header().Set(echo.HeaderConnection, "keep-alive")
+			res.WriteHeader(http.StatusOK)
+		}
+		last = update
+		return writeEvent(res, "status", update)
+	})
+	switch {
+	case last == nil:
+		// Nothing was streamed yet, the error is returned as a regular response.
+		return err
+	case err != nil:
+		if c.Request().Context().Err() != nil {
+			return nil
+		}
+		return writeEvent(res, "error", map[string]string{"message": "failed to watch asset status"})
+	case !last.Final:
+		return writeEvent(res, "timeout", last)
+	default:
+		return nil
+	}
+}
+
+// writeEvent writes a server-sent event with the JSON encoded data and flushes it to the client.
+func writeEvent(res *echo.Response, event string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event, b); err != nil {
+		return err
+	}
+	res.Flush()
+	return nil
+}
+
+// ValidateAssets reports whether the assets referenced by another service exist and are healthy.
+func (h *AdminHandler) ValidateAssets(c echo.Context) error {
+	return generic.Handle(c, h.service.ValidateAssets, http.StatusOK, "assets")
+}
+
+func (h *AdminHandler) ListByOwner(c echo.Context) error {
+	return generic.HandleList(c, h.service.ListByOwner, "assets")
+}
+
+func (h *AdminHandler) ListOwnerTypes(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]any{"owner_types": h.service.ListOwnerTypes(c.Request().Context())})
+}
+
+func (h *AdminHandler) SyncPlaybackRestrictions(c echo.Context) error {
+	return generic.Handle(c, h.service.SyncPlaybackRestrictions, http.StatusOK, "sync")
 }
 
 func (h *AdminHandler) CreateUploadURL(c echo.Context) error {
@@ -89,18 +269,146 @@ func (h *AdminHandler) Restore(c echo.Context) error {
 	return generic.HandleVoid(c, h.service.Restore, http.StatusOK)
 }
 
+// RestoreWithOwners restores an archived asset and re-associates its former owners.
+func (h *AdminHandler) RestoreWithOwners(c echo.Context) error {
+	return generic.Handle(c, h.service.RestoreWithOwners, http.StatusOK, "metadata")
+}
+
 func (h *AdminHandler) Delete(c echo.Context) error {
-	return generic.HandleVoid(c, h.service.Delete, http.StatusNoContent)
+	return generic.HandleVoid(c, h.service.Delete, http.StatusAccepted)
+}
+
+func (h *AdminHandler) ListStuckDeletions(c echo.Context) error {
+	return generic.Handle(c, h.service.ListStuckDeletions, http.StatusOK, "assets")
+}
+
+// FindDuplicates lists the groups of probable duplicate assets.
+func (h *AdminHandler) FindDuplicates(c echo.Context) error {
+	return generic.Handle(c, h.service.FindDuplicates, http.StatusOK, "groups")
 }
 
 func (h *AdminHandler) MarkAsBroken(c echo.Context) error {
 	return generic.HandleVoid(c, h.service.MarkAsBroken, http.StatusOK)
 }
 
+func (h *AdminHandler) ListByTag(c echo.Context) error {
+	return generic.HandleList(c, h.service.ListByTag, "assets")
+}
+
+func (h *AdminHandler) Search(c echo.Context) error {
+	return generic.HandleList(c, h.service.Search, "assets")
+}
+
+func (h *AdminHandler) ListByCollection(c echo.Context) error {
+	return generic.HandleList(c, h.service.ListByCollection, "assets")
+}
+
+func (h *AdminHandler) GetHistory(c echo.Context) error {
+	return generic.Handle(c, h.service.GetHistory, http.StatusOK, "events")
+}
+
+func (h *AdminHandler) AddTags(c echo.Context) error {
+	return generic.Handle(c, h.service.AddTags, http.StatusOK, "tags")
+}
+
+func (h *AdminHandler) RemoveTags(c echo.Context) error {
+	return generic.Handle(c, h.service.RemoveTags, http.StatusOK, "tags")
+}
+
+func (h *AdminHandler) SchedulePublishing(c echo.Context) error {
+	return generic.Handle(c, h.service.SchedulePublishing, http.StatusOK, "publishing")
+}
+
+func (h *AdminHandler) CancelPublishing(c echo.Context) error {
+	return generic.Handle(c, h.service.CancelPublishing, http.StatusOK, "publishing")
+}
+
+func (h *AdminHandler) UpdateMetadata(c echo.Context) error {
+	return generic.Handle(c, h.service.UpdateMetadata, http.StatusOK, "metadata")
+}
+
 func (h *AdminHandler) AddOwner(c echo.Context) error {
-	return generic.HandleVoid(c, h.service.AddOwner, http.StatusCreated)
+	return generic.Handle(c, h.service.AssociateOwner, http.StatusCreated, "association")
 }
 
 func (h *AdminHandler) RemoveOwner(c echo.Context) error {
 	return generic.HandleVoid(c, h.service.RemoveOwner, http.StatusNoContent)
 }
+
+// UpdateOwners replaces the owners of an asset. The expected revision of the asset metadata is taken
+// from the If-Match header if it is set, the new revision is returned in the ETag header.
+func (h *AdminHandler) UpdateOwners(c echo.Context) error {
+	req := new(assetmodel.UpdateOwnersRequest)
+	if err := c.Bind(req); err != nil {
+		return payload.BindError(err, "invalid request body")
+	}
+	revision, err := generic.IfMatchRevision(c)
+	if err != nil {
+		return err
+	}
+	if revision != nil {
+		req.Revision = revision
+	}
+	metadata, err := h.service.UpdateOwners(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+	generic.SetRevisionETag(c, metadata.Revision)
+	return c.JSON(http.StatusOK, map[string]any{"owners": metadata.Owners})
+}
+
+func (h *AdminHandler) AddPlaybackID(c echo.Context) error {
+	return generic.Handle(c, h.service.AddPlaybackID, http.StatusCreated, "playback_ids")
+}
+
+func (h *AdminHandler) RemovePlaybackID(c echo.Context) error {
+	return generic.Handle(c, h.service.RemovePlaybackID, http.StatusOK, "playback_ids")
+}
+
+func (h *AdminHandler) RotatePlaybackID(c echo.Context) error {
+	return generic.Handle(c, h.service.RotatePlaybackID, http.StatusOK, "playback_ids")
+}
+
+func (h *AdminHandler) SetPoster(c echo.Context) error {
+	return generic.Handle(c, h.service.SetPoster, http.StatusOK, "poster")
+}
+
+func (h *AdminHandler) ResetPoster(c echo.Context) error {
+	return generic.Handle(c, h.service.ResetPoster, http.StatusOK, "poster")
+}
+
+func (h *AdminHandler) CountErrors(c echo.Context) error {
+	return generic.Handle(c, h.service.CountErrors, http.StatusOK, "errors")
+}
+
+func (h *AdminHandler) ListStuckAssets(c echo.Context) error {
+	return generic.Handle(c, h.service.ListStuckAssets, http.StatusOK, "assets")
+}
+
+func (h *AdminHandler) ReprocessAsset(c echo.Context) error {
+	return generic.Handle(c, h.service.ReprocessAsset, http.StatusOK, "asset")
+}
+
+func (h *AdminHandler) ListLanguages(c echo.Context) error {
+	return generic.Handle(c, h.service.ListLanguages, http.StatusOK, "languages")
+}
+
+func (h *AdminHandler) UpdateTrack(c echo.Context) error {
+	return generic.Handle(c, h.service.UpdateTrack, http.StatusOK, "languages")
+}
+
+func (h *AdminHandler) ListChapters(c echo.Context) error {
+	return generic.Handle(c, h.service.ListChapters, http.StatusOK, "chapters")
+}
+
+func (h *AdminHandler) AddChapter(c echo.Context) error {
+	return generic.Handle(c, h.service.AddChapter, http.StatusCreated, "chapters")
+}
+
+func (h *AdminHandler) UpdateChapter(c echo.Context) error {
+	return generic.Handle(c, h.service.UpdateChapter, http.StatusOK, "chapters")
+}
+
+func (h *AdminHandler) DeleteChapter(c echo.Context) error {
+	return generic.Handle(c, h.service.DeleteChapter, http.StatusOK, "chapters")
+}
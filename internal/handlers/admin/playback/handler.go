@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package playback
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	playbackservice "github.com/mikhail5545/media-service-go/internal/services/playback"
+)
+
+type Handler interface {
+	Revoke(c echo.Context) error
+	Validate(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *playbackservice.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *playbackservice.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the playback session routes under /playback-sessions.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	sessions := group.Group("/playback-sessions", m...)
+	{
+		sessions.POST("/revoke", h.Revoke)
+		sessions.POST("/validate", h.Validate)
+	}
+}
+
+func (h *AdminHandler) Revoke(c echo.Context) error {
+	return generic.Handle(c, h.service.Revoke, http.StatusOK, "result")
+}
+
+func (h *AdminHandler) Validate(c echo.Context) error {
+	return generic.Handle(c, h.service.Validate, http.StatusOK, "validation")
+}
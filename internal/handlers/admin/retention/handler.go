@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package retention
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	retentionservice "github.com/mikhail5545/media-service-go/internal/services/retention"
+)
+
+type Handler interface {
+	Run(c echo.Context) error
+}
+
+type AdminHandler struct {
+	worker *retentionservice.Worker
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(worker *retentionservice.Worker) *AdminHandler {
+	return &AdminHandler{
+		worker: worker,
+	}
+}
+
+// Register registers the route triggering a retention run under /retention/run.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	group.POST("/retention/run", h.Run, m...)
+}
+
+func (h *AdminHandler) Run(c echo.Context) error {
+	return generic.Handle(c, h.worker.Trigger, http.StatusOK, "records")
+}
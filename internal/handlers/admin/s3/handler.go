@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package s3
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	s3provider "github.com/mikhail5545/media-service-go/internal/mediaprovider/s3"
+	"github.com/mikhail5545/media-service-go/internal/routers"
+	s3service "github.com/mikhail5545/media-service-go/internal/services/s3"
+)
+
+// Provider names the provider in the paths of the routes.
+const Provider = s3provider.Name
+
+type Handler interface {
+	CreateUploadURL(c echo.Context) error
+	ConfirmUpload(c echo.Context) error
+	GetDownloadURL(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *s3service.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *s3service.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the routes specific to the object storage under /s3, the common asset
+// routes are served under /media/s3.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	assets := routers.ProviderGroup(group, Provider, m...).Group("/assets")
+	{
+		assets.POST("/upload-url", h.CreateUploadURL)
+		assets.POST("/:id/confirm", h.ConfirmUpload)
+		assets.GET("/:id/download-url", h.GetDownloadURL)
+	}
+}
+
+func (h *AdminHandler) CreateUploadURL(c echo.Context) error {
+	return generic.Handle(c, h.service.CreateUploadURL, http.StatusCreated, "data")
+}
+
+func (h *AdminHandler) ConfirmUpload(c echo.Context) error {
+	return generic.Handle(c, h.service.ConfirmUpload, http.StatusOK, "asset")
+}
+
+func (h *AdminHandler) GetDownloadURL(c echo.Context) error {
+	return generic.Handle(c, h.service.GetDownloadURL, http.StatusOK, "download")
+}
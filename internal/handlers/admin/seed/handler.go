@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package seed
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	"github.com/mikhail5545/media-service-go/internal/seed"
+)
+
+type Handler interface {
+	Load(c echo.Context) error
+	Wipe(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *seed.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *seed.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the routes loading and wiping the fixtures under /seed.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	group.POST("/seed", h.Load, m...)
+	group.DELETE("/seed", h.Wipe, m...)
+}
+
+func (h *AdminHandler) Load(c echo.Context) error {
+	return generic.Handle(c, h.service.Load, http.StatusOK, "report")
+}
+
+func (h *AdminHandler) Wipe(c echo.Context) error {
+	return generic.Handle(c, h.service.Wipe, http.StatusOK, "report")
+}
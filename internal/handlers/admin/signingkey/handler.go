@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package signingkey
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	signingkeyservice "github.com/mikhail5545/media-service-go/internal/services/signingkey"
+)
+
+type Handler interface {
+	List(c echo.Context) error
+	Rotate(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *signingkeyservice.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *signingkeyservice.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the MUX signing key routes under /signing-keys.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	keys := group.Group("/signing-keys", m...)
+	{
+		keys.GET("", h.List)
+		keys.POST("/rotate", h.Rotate)
+	}
+}
+
+func (h *AdminHandler) List(c echo.Context) error {
+	return generic.Handle(c, h.service.List, http.StatusOK, "key_set")
+}
+
+func (h *AdminHandler) Rotate(c echo.Context) error {
+	return generic.Handle(c, h.service.Rotate, http.StatusOK, "result")
+}
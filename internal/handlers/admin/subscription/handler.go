@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package subscription
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	subscriptionservice "github.com/mikhail5545/media-service-go/internal/services/subscription"
+)
+
+type Handler interface {
+	Get(c echo.Context) error
+	List(c echo.Context) error
+	Create(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+	ListDeliveries(c echo.Context) error
+	Test(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *subscriptionservice.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *subscriptionservice.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the outgoing webhook subscription routes under /webhook-subscriptions.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	subscriptions := group.Group("/webhook-subscriptions", m...)
+	{
+		subscriptions.GET("", h.List)
+		subscriptions.POST("", h.Create)
+		subscriptions.GET("/:id", h.Get)
+		subscriptions.PATCH("/:id", h.Update)
+		subscriptions.DELETE("/:id", h.Delete)
+		subscriptions.GET("/:id/deliveries", h.ListDeliveries)
+		subscriptions.POST("/:id/test", h.Test)
+	}
+}
+
+func (h *AdminHandler) Get(c echo.Context) error {
+	return generic.Handle(c, h.service.Get, http.StatusOK, "subscription")
+}
+
+func (h *AdminHandler) List(c echo.Context) error {
+	return generic.HandleList(c, h.service.List, "subscriptions")
+}
+
+func (h *AdminHandler) Create(c echo.Context) error {
+	return generic.Handle(c, h.service.Create, http.StatusCreated, "subscription")
+}
+
+func (h *AdminHandler) Update(c echo.Context) error {
+	return generic.Handle(c, h.service.Update, http.StatusOK, "subscription")
+}
+
+func (h *AdminHandler) Delete(c echo.Context) error {
+	return generic.HandleVoid(c, h.service.Delete, http.StatusNoContent)
+}
+
+func (h *AdminHandler) ListDeliveries(c echo.Context) error {
+	return generic.HandleList(c, h.service.ListDeliveries, "deliveries")
+}
+
+func (h *AdminHandler) Test(c echo.Context) error {
+	return generic.Handle(c, h.service.Test, http.StatusOK, "delivery")
+}
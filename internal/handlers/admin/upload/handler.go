@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package upload implements the tus resumable upload protocol (core, creation and termination)
+// on top of the upload proxy.
+package upload
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	uploadproxymodel "github.com/mikhail5545/media-service-go/internal/models/uploadproxy"
+	uploadproxyservice "github.com/mikhail5545/media-service-go/internal/services/uploadproxy"
+)
+
+const (
+	tusVersion     = "1.0.0"
+	tusExtensions  = "creation,termination"
+	tusContentType = "application/offset+octet-stream"
+)
+
+type Handler interface {
+	Options(c echo.Context) error
+	Create(c echo.Context) error
+	Head(c echo.Context) error
+	Get(c echo.Context) error
+	Append(c echo.Context) error
+	Delete(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *uploadproxyservice.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *uploadproxyservice.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the resumable upload routes under /uploads.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	uploads := group.Group("/uploads", m...)
+	{
+		uploads.OPTIONS("", h.Options)
+		uploads.POST("", h.Create)
+		uploads.HEAD("/:id", h.Head)
+		uploads.GET("/:id", h.Get)
+		uploads.PATCH("/:id", h.Append)
+		uploads.DELETE("/:id", h.Delete)
+	}
+}
+
+// Options reports the supported protocol version, extensions and the maximum upload size.
+func (h *AdminHandler) Options(c echo.Context) error {
+	header := c.Response().Header()
+	header.Set("Tus-Resumable", tusVersion)
+	header.Set("Tus-Version", tusVersion)
+	header.Set("Tus-Extension", tusExtensions)
+	header.Set("Tus-Max-Size", strconv.FormatInt(h.service.MaxFileSize(), 10))
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Create starts an upload. The size is taken from the Upload-Length header, the provider, title
+// and admin from the "provider", "title", "admin_id" and "admin_name" keys of the Upload-Metadata header.
+func (h *AdminHandler) Create(c echo.Context) error {
+	size, err := strconv.ParseInt(c.Request().Header.Get("Upload-Length"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid Upload-Length header")
+	}
+	metadata, err := parseMetadata(c.Request().Header.Get("Upload-Metadata"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid Upload-Metadata header")
+	}
+
+	session, err := h.service.Create(c.Request().Context(), &uploadproxymodel.CreateRequest{
+		Provider:  uploadproxymodel.Provider(metadata["provider"]),
+		Size:      size,
+		Title:     metadata["title"],
+		AdminID:   metadata["admin_id"],
+		AdminName: metadata["admin_name"],
+	})
+	if err != nil {
+		return err
+	}
+	header := c.Response().Header()
+	header.Set("Tus-Resumable", tusVersion)
+	header.Set("Location", strings.TrimSuffix(c.Request().URL.Path, "/")+"/"+session.ID.String())
+	return c.JSON(http.StatusCreated, map[string]any{"upload": session})
+}
+
+// Head reports the current offset of an upload, so the client can resume it.
+func (h *AdminHandler) Head(c echo.Context) error {
+	session, err := h.service.Get(c.Request().Context(), &uploadproxymodel.GetRequest{ID: c.Param("id")})
+	if err != nil {
+		return err
+	}
+	header := c.Response().Header()
+	header.Set("Tus-Resumable", tusVersion)
+	header.Set("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	header.Set("Upload-Length", strconv.FormatInt(session.Size, 10))
+	header.Set("Cache-Control", "no-store")
+	return c.NoContent(http.StatusOK)
+}
+
+// Get returns the progress of an upload, including the bytes already relayed to the provider.
+func (h *AdminHandler) Get(c echo.Context) error {
+	return generic.Handle(c, h.service.Get, http.StatusOK, "upload")
+}
+
+// Append appends the request body at the offset given by the Upload-Offset header.
+func (h *AdminHandler) Append(c echo.Context) error {
+	if c.Request().Header.Get("Content-Type") != tusContentType {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, "content type must be "+tusContentType)
+	}
+	offset, err := strconv.ParseInt(c.Request().Header.Get("Upload-Offset"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid Upload-Offset header")
+	}
+
+	session, err := h.service.Append(c.Request().Context(), &uploadproxymodel.AppendRequest{
+		ID:     c.Param("id"),
+		Offset: offset,
+	}, c.Request().Body)
+	if err != nil {
+		return err
+	}
+	header := c.Response().Header()
+	header.Set("Tus-Resumable", tusVersion)
+	header.Set("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *AdminHandler) Delete(c echo.Context) error {
+	c.Response().Header().Set("Tus-Resumable", tusVersion)
+	return generic.HandleVoid(c, h.service.Delete, http.StatusNoContent)
+}
+
+// parseMetadata decodes the Upload-Metadata header: comma separated pairs of a key and a base64
+// encoded value, separated by a space. The value may be omitted.
+func parseMetadata(raw string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, encoded, _ := strings.Cut(pair, " ")
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, err
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
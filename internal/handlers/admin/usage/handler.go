@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usage
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	usageservice "github.com/mikhail5545/media-service-go/internal/services/usage"
+)
+
+type Handler interface {
+	Report(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *usageservice.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *usageservice.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the usage report route under /usage.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	group.GET("/usage", h.Report, m...)
+}
+
+func (h *AdminHandler) Report(c echo.Context) error {
+	return generic.Handle(c, h.service.Report, http.StatusOK, "report")
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package video serves the endpoints shared by the video backends, selecting the backend per request.
+package video
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	cfstreamprovider "github.com/mikhail5545/media-service-go/internal/mediaprovider/cfstream"
+	cfstreammodel "github.com/mikhail5545/media-service-go/internal/models/cfstream"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	"github.com/mikhail5545/media-service-go/internal/payload"
+	cfstreamservice "github.com/mikhail5545/media-service-go/internal/services/cfstream"
+	muxservice "github.com/mikhail5545/media-service-go/internal/services/mux"
+)
+
+// ProviderMux is the name of the MUX video backend, the default backend of new videos.
+const ProviderMux = "mux"
+
+type Handler interface {
+	CreateUploadURL(c echo.Context) error
+}
+
+type AdminHandler struct {
+	muxService *muxservice.Service
+	// cfStreamService is nil unless Cloudflare Stream is enabled.
+	cfStreamService *cfstreamservice.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(muxSvc *muxservice.Service, cfStreamSvc *cfstreamservice.Service) *AdminHandler {
+	return &AdminHandler{
+		muxService:      muxSvc,
+		cfStreamService: cfStreamSvc,
+	}
+}
+
+// Register registers the routes shared by the video backends under /videos.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	videos := group.Group("/videos", m...)
+	{
+		videos.POST("/upload-url", h.CreateUploadURL)
+	}
+}
+
+// CreateUploadURLRequest holds the fields of the upload URL requests of all video backends. Fields
+// of other backends than the selected one are ignored.
+type CreateUploadURLRequest struct {
+	// Provider selects the backend storing the video, MUX is used when it is omitted.
+	Provider           string  `json:"provider"`
+	Title              string  `json:"title"`
+	AdminID            string  `json:"admin_id"`
+	AdminName          string  `json:"admin_name"`
+	DRMConfigurationID *string `json:"drm_configuration_id"`
+	MaxDurationSeconds int     `json:"max_duration_seconds"`
+}
+
+// CreateUploadURL creates a pending video asset in the requested backend and returns its upload URL.
+func (h *AdminHandler) CreateUploadURL(c echo.Context) error {
+	var req CreateUploadURLRequest
+	if err := c.Bind(&req); err != nil {
+		return payload.BindError(err, "invalid request body")
+	}
+	ctx := c.Request().Context()
+
+	var (
+		res any
+		err error
+	)
+	switch req.Provider {
+	case "", ProviderMux:
+		req.Provider = ProviderMux
+		res, err = h.muxService.CreateUploadURL(ctx, &assetmodel.CreateUploadURLRequest{
+			Title:              req.Title,
+			AdminID:            req.AdminID,
+			AdminName:          req.AdminName,
+			DRMConfigurationID: req.DRMConfigurationID,
+		})
+	case cfstreamprovider.Name:
+		if h.cfStreamService == nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "video provider is not enabled")
+		}
+		res, err = h.cfStreamService.CreateUploadURL(ctx, &cfstreammodel.CreateUploadURLRequest{
+			Title:              req.Title,
+			MaxDurationSeconds: req.MaxDurationSeconds,
+			AdminID:            req.AdminID,
+			AdminName:          req.AdminName,
+		})
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "unknown video provider")
+	}
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, map[string]any{"provider": req.Provider, "data": res})
+}
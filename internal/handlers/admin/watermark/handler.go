@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package watermark
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	watermarkservice "github.com/mikhail5545/media-service-go/internal/services/watermark"
+)
+
+type Handler interface {
+	Get(c echo.Context) error
+	List(c echo.Context) error
+	Set(c echo.Context) error
+	Delete(c echo.Context) error
+}
+
+type AdminHandler struct {
+	service *watermarkservice.Service
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(svc *watermarkservice.Service) *AdminHandler {
+	return &AdminHandler{
+		service: svc,
+	}
+}
+
+// Register registers the watermark routes under /watermarks.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	watermarks := group.Group("/watermarks", m...)
+	{
+		watermarks.GET("", h.List)
+		watermarks.GET("/:scope/:key", h.Get)
+		watermarks.PUT("/:scope/:key", h.Set)
+		watermarks.DELETE("/:scope/:key", h.Delete)
+	}
+}
+
+func (h *AdminHandler) Get(c echo.Context) error {
+	return generic.Handle(c, h.service.Get, http.StatusOK, "watermark")
+}
+
+func (h *AdminHandler) List(c echo.Context) error {
+	return generic.Handle(c, h.service.List, http.StatusOK, "watermarks")
+}
+
+func (h *AdminHandler) Set(c echo.Context) error {
+	return generic.Handle(c, h.service.Set, http.StatusOK, "watermark")
+}
+
+func (h *AdminHandler) Delete(c echo.Context) error {
+	return generic.HandleVoid(c, h.service.Delete, http.StatusNoContent)
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	webhookservice "github.com/mikhail5545/media-service-go/internal/services/webhook"
+)
+
+type Handler interface {
+	ListErrors(c echo.Context) error
+	Replay(c echo.Context) error
+}
+
+type AdminHandler struct {
+	queue *webhookservice.Queue
+}
+
+var _ Handler = (*AdminHandler)(nil)
+
+func New(queue *webhookservice.Queue) *AdminHandler {
+	return &AdminHandler{
+		queue: queue,
+	}
+}
+
+// Register registers the routes listing the failed incoming webhooks under /webhooks/errors and
+// replaying a stored webhook under /webhooks/:id/replay.
+func (h *AdminHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	group.GET("/webhooks/errors", h.ListErrors, m...)
+	group.POST("/webhooks/:id/replay", h.Replay, m...)
+}
+
+func (h *AdminHandler) ListErrors(c echo.Context) error {
+	return generic.Handle(c, h.queue.ListErrors, http.StatusOK, "events")
+}
+
+func (h *AdminHandler) Replay(c echo.Context) error {
+	return generic.HandleVoid(c, h.queue.Replay, http.StatusAccepted)
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package delivery
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/handlers/generic"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	"github.com/mikhail5545/media-service-go/internal/payload"
+	cldservice "github.com/mikhail5545/media-service-go/internal/services/cloudinary"
+	muxservice "github.com/mikhail5545/media-service-go/internal/services/mux"
+)
+
+type Handler interface {
+	VideoPlayback(c echo.Context) error
+	VideoDRMPlayback(c echo.Context) error
+	Image(c echo.Context) error
+}
+
+// TokenDelivery configures how the playback tokens are handed to the players which cannot append them
+// to the segment requests. The CDN in front of MUX reads the token from the cookie or the header and
+// passes it on as the token query parameter.
+type TokenDelivery struct {
+	CookieName   string
+	CookieDomain string
+	// CookiePath may contain the {playback_id} placeholder.
+	CookiePath string
+	Header     string
+}
+
+// UserHandler serves the assets to the authenticated end users.
+type UserHandler struct {
+	muxService *muxservice.Service
+	cldService *cldservice.Service
+	tokens     TokenDelivery
+}
+
+var _ Handler = (*UserHandler)(nil)
+
+func New(muxSvc *muxservice.Service, cldSvc *cldservice.Service, tokens TokenDelivery) *UserHandler {
+	return &UserHandler{
+		muxService: muxSvc,
+		cldService: cldSvc,
+		tokens:     tokens,
+	}
+}
+
+// Register registers the end user routes under /media.
+func (h *UserHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	media := group.Group("/media", m...)
+	{
+		media.GET("/video/:id/playback", h.VideoPlayback)
+		media.GET("/video/:id/drm", h.VideoDRMPlayback)
+		media.GET("/image/:id", h.Image)
+	}
+}
+
+// VideoPlayback returns the playback details of a video. Tokens delivered as a cookie are set as a
+// Secure, HttpOnly cookie expiring with the token and left out of the response body.
+func (h *UserHandler) VideoPlayback(c echo.Context) error {
+	req := new(assetmodel.PlaybackInfoRequest)
+	if err := c.Bind(req); err != nil {
+		return payload.BindError(err, "invalid request body")
+	}
+	info, err := h.muxService.PlaybackInfo(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+	switch info.TokenDelivery {
+	case assetmodel.TokenDeliveryCookie:
+		c.SetCookie(h.tokenCookie(info))
+		info.Token = ""
+		// The response sets a per-user credential.
+		c.Response().Header().Set("Cache-Control", "no-store")
+	case assetmodel.TokenDeliveryHeader:
+		info.TokenHeader = h.tokens.Header
+	}
+	return c.JSON(http.StatusOK, map[string]any{"playback": info})
+}
+
+// tokenCookie returns the cookie carrying the playback token of info. It is sent cross-site, the CDN
+// serving the streams is usually on another site than the player.
+func (h *UserHandler) tokenCookie(info *assetmodel.PlaybackInfo) *http.Cookie {
+	path := strings.ReplaceAll(h.tokens.CookiePath, "{playback_id}", info.PlaybackID)
+	return &http.Cookie{
+		Name:     h.tokens.CookieName,
+		Value:    info.Token,
+		Domain:   h.tokens.CookieDomain,
+		Path:     path,
+		Expires:  info.ExpiresAt,
+		MaxAge:   max(int(time.Until(info.ExpiresAt).Seconds()), 1),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteNoneMode,
+	}
+}
+
+// VideoDRMPlayback returns the playback and license tokens of a DRM protected video.
+func (h *UserHandler) VideoDRMPlayback(c echo.Context) error {
+	// The response carries per-user credentials.
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return generic.Handle(c, h.muxService.DRMPlayback, http.StatusOK, "drm")
+}
+
+func (h *UserHandler) Image(c echo.Context) error {
+	return generic.Handle(c, h.cldService.ImageDelivery, http.StatusOK, "image")
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package dev serves the emulated provider endpoints of the dev mode: the upload URLs handed out by
+// the emulators and an endpoint firing synthetic webhooks. It must never be registered in production.
+package dev
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/emulator"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/payload"
+	"github.com/mikhail5545/media-service-go/internal/services/webhook"
+)
+
+// WebhooksPath is the path of the route firing synthetic webhooks relative to the API base URL.
+const WebhooksPath = "/dev/webhooks"
+
+type Handler struct {
+	emulators *emulator.Emulators
+	queue     *webhook.Queue
+}
+
+func New(emulators *emulator.Emulators, queue *webhook.Queue) *Handler {
+	return &Handler{
+		emulators: emulators,
+		queue:     queue,
+	}
+}
+
+// Register registers the emulated upload routes and the synthetic webhook route under /dev.
+func (h *Handler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	group.PUT(emulator.MuxUploadPath+"/:id", h.MuxUpload, m...)
+	group.POST(emulator.CloudinaryUploadPath, h.CloudinaryUpload, m...)
+	group.POST(WebhooksPath, h.FireWebhook, m...)
+}
+
+// MuxUpload receives the file of a MUX direct upload. Like the MUX API, the file is accepted
+// whatever its content, the asset becomes ready right away.
+func (h *Handler) MuxUpload(c echo.Context) error {
+	if _, err := io.Copy(io.Discard, c.Request().Body); err != nil {
+		return payload.BindError(err, "failed to read the uploaded file")
+	}
+	webhooks, err := h.emulators.Mux.CompleteUpload(c.Param("id"))
+	if err != nil {
+		return emulatorError(err)
+	}
+	if err := h.enqueue(c.Request().Context(), webhooks...); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// CloudinaryUpload receives the multipart form of a signed Cloudinary upload and responds with the
+// upload result, the signature is not checked.
+func (h *Handler) CloudinaryUpload(c echo.Context) error {
+	form := new(emulator.CloudinaryUploadForm)
+	if err := c.Bind(form); err != nil {
+		return payload.BindError(err, "invalid upload form")
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return payload.BindError(err, "file is required")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "failed to open the uploaded file")
+	}
+	defer func() { _ = file.Close() }()
+
+	asset, err := h.emulators.Cloudinary.Store(form.PublicID, file)
+	if err != nil {
+		return serviceerrors.NewInvalidArgumentError(err)
+	}
+	notification, err := h.emulators.Cloudinary.Fire(asset.PublicID, emulator.EventReady)
+	if err != nil {
+		return emulatorError(err)
+	}
+	if err := h.enqueue(c.Request().Context(), notification); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, asset)
+}
+
+// FireWebhook queues a synthetic webhook of an emulated asset.
+func (h *Handler) FireWebhook(c echo.Context) error {
+	req := new(emulator.FireRequest)
+	if err := c.Bind(req); err != nil {
+		return payload.BindError(err, "invalid request body")
+	}
+	if err := req.Validate(); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	w, err := h.emulators.Fire(req)
+	if err != nil {
+		return emulatorError(err)
+	}
+	if err := h.enqueue(c.Request().Context(), w); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusAccepted, map[string]any{"webhook": &emulator.FireResponse{Provider: w.Provider, Type: w.Type}})
+}
+
+func (h *Handler) enqueue(ctx context.Context, webhooks ...emulator.Webhook) error {
+	for _, w := range webhooks {
+		if err := h.queue.Enqueue(ctx, w.Provider, w.Type, w.Payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func emulatorError(err error) error {
+	switch {
+	case errors.Is(err, emulator.ErrNotFound):
+		return serviceerrors.NewNotFoundError(err)
+	case errors.Is(err, emulator.ErrConflict):
+		return serviceerrors.NewConflictError(err)
+	}
+	return err
+}
@@ -45,6 +45,10 @@ func HTTPErrorHandler(err error, c echo.Context) {
 			internalCode = serviceerrors.ErrorAliases[serviceerrors.ErrInvalidArgument]
 		case http.StatusBadRequest:
 			internalCode = serviceerrors.ErrorAliases[serviceerrors.ErrInvalidArgument]
+		case http.StatusRequestEntityTooLarge:
+			internalCode = serviceerrors.ErrorAliases[serviceerrors.ErrPayloadTooLarge]
+		case http.StatusUnsupportedMediaType:
+			internalCode = serviceerrors.ErrorAliases[serviceerrors.ErrUnsupportedMediaType]
 		}
 
 		resp := errutil.ErrorResponse{}
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package generic
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/auth"
+)
+
+// AdminRequest is implemented by requests which record the acting admin in the audit trail of an asset.
+type AdminRequest interface {
+	SetAdmin(id, name string)
+}
+
+// bindAdmin sets the admin of req to the principal which authenticated the request, overriding the
+// admin sent in the body. The body is kept for API key callers, which act on behalf of an admin, and
+// for principals whose subject is not a UUID.
+func bindAdmin(c echo.Context, req any) {
+	r, ok := req.(AdminRequest)
+	if !ok {
+		return
+	}
+	p, ok := auth.PrincipalFromContext(c.Request().Context())
+	if !ok || p.Service {
+		return
+	}
+	if _, err := uuid.Parse(p.Subject); err != nil {
+		return
+	}
+	name := p.Name
+	if name == "" {
+		name = p.Subject
+	}
+	r.SetAdmin(p.Subject, name)
+}
@@ -20,8 +20,12 @@ package generic
 import (
 	"context"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/database/postgres/pagination"
+	"github.com/mikhail5545/media-service-go/internal/payload"
 )
 
 // HandleGet abstracts pattern of 'bind request with custom binder -> call service method -> return response'.
@@ -51,7 +55,7 @@ func HandleList[Req any, Res any](
 ) error {
 	req := new(Req)
 	if err := c.Bind(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body payload")
+		return payload.BindError(err, "invalid request body payload")
 	}
 	res, nextPageToken, err := fn(c.Request().Context(), req)
 	if err != nil {
@@ -63,6 +67,34 @@ func HandleList[Req any, Res any](
 	})
 }
 
+// HandleListWithTotal is HandleList for listings that also report the total of the matching records.
+// total_is_estimate is set if the total is a planner estimate or a cached count that may be stale.
+func HandleListWithTotal[Req any, Res any](
+	c echo.Context,
+	fn func(context.Context, *Req) ([]*Res, string, error),
+	total func(context.Context, *Req) (*pagination.Total, error),
+	responseKey string,
+) error {
+	req := new(Req)
+	if err := c.Bind(req); err != nil {
+		return payload.BindError(err, "invalid request body payload")
+	}
+	res, nextPageToken, err := fn(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+	t, err := total(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, map[string]any{
+		responseKey:         res,
+		"next_page_token":   nextPageToken,
+		"total":             t.Count,
+		"total_is_estimate": t.IsEstimate,
+	})
+}
+
 // Handle abstracts the pattern: 'bind request -> call service operation -> return JSON response'.
 func Handle[Req any, Res any](
 	c echo.Context,
@@ -72,8 +104,9 @@ func Handle[Req any, Res any](
 ) error {
 	req := new(Req)
 	if err := c.Bind(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+		return payload.BindError(err, "invalid request body")
 	}
+	bindAdmin(c, req)
 	res, err := fn(c.Request().Context(), req)
 	if err != nil {
 		return err
@@ -89,10 +122,30 @@ func HandleVoid[Req any](
 ) error {
 	req := new(Req)
 	if err := c.Bind(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+		return payload.BindError(err, "invalid request body")
 	}
+	bindAdmin(c, req)
 	if err := op(c.Request().Context(), req); err != nil {
 		return err
 	}
 	return c.NoContent(status)
 }
+
+// IfMatchRevision parses the If-Match header as the expected revision of a resource, e.g. "3" or W/"3".
+// It returns nil if the header is not set.
+func IfMatchRevision(c echo.Context) (*int64, error) {
+	header := c.Request().Header.Get("If-Match")
+	if header == "" {
+		return nil, nil
+	}
+	revision, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(header, "W/"), `"`), 10, 64)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "invalid If-Match header")
+	}
+	return &revision, nil
+}
+
+// SetRevisionETag sets the ETag header of the response to the revision of the returned resource.
+func SetRevisionETag(c echo.Context, revision int64) {
+	c.Response().Header().Set("ETag", strconv.Quote(strconv.FormatInt(revision, 10)))
+}
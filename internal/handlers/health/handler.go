@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package health
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/health"
+)
+
+type Handler struct {
+	checker *health.Checker
+}
+
+func New(checker *health.Checker) *Handler {
+	return &Handler{checker: checker}
+}
+
+// Liveness reports that the process is up. It does not check any dependency.
+func (h *Handler) Liveness(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]any{"status": health.StatusOK})
+}
+
+// Readiness runs the dependency checks. It responds with 503 when a critical dependency is failing
+// and with 200 otherwise, including the degraded state where only non-critical checks fail.
+func (h *Handler) Readiness(c echo.Context) error {
+	report := h.checker.Run(c.Request().Context())
+	code := http.StatusOK
+	if report.Status == health.StatusUnavailable {
+		code = http.StatusServiceUnavailable
+	}
+	return c.JSON(code, report)
+}
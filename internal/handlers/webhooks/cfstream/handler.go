@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cfstream
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	cfstreamapi "github.com/mikhail5545/media-service-go/internal/apiclients/cfstream"
+	"github.com/mikhail5545/media-service-go/internal/metrics"
+	webhookmodel "github.com/mikhail5545/media-service-go/internal/models/webhook"
+	"github.com/mikhail5545/media-service-go/internal/payload"
+	"github.com/mikhail5545/media-service-go/internal/routers"
+	cfstreamservice "github.com/mikhail5545/media-service-go/internal/services/cfstream"
+	"github.com/mikhail5545/media-service-go/internal/services/webhook"
+)
+
+type WebhookHandler struct {
+	service *cfstreamservice.Service
+	queue   *webhook.Queue
+	metrics *metrics.Metrics
+}
+
+func New(svc *cfstreamservice.Service, queue *webhook.Queue, m *metrics.Metrics) *WebhookHandler {
+	return &WebhookHandler{
+		service: svc,
+		queue:   queue,
+		metrics: m,
+	}
+}
+
+// Register registers the webhook route under /cfstream.
+func (h *WebhookHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	routers.ProviderGroup(group, string(webhookmodel.ProviderCfStream), m...).POST("", h.Handle)
+}
+
+// Handle verifies and queues the webhook, it is acknowledged once persisted and processed
+// asynchronously by the webhook queue.
+func (h *WebhookHandler) Handle(c echo.Context) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if len(body) == 0 || err != nil {
+		err = payload.BindError(err, "invalid request body")
+		h.metrics.ObserveWebhook("cfstream", "", err)
+		return err
+	}
+	if err := h.service.VerifyWebhook(c.Request().Context(), body, c.Request().Header.Get("Webhook-Signature")); err != nil {
+		h.metrics.ObserveWebhook("cfstream", "", err)
+		return err
+	}
+
+	var payload cfstreamapi.Video
+	if err := json.Unmarshal(body, &payload); err != nil {
+		h.metrics.ObserveWebhook("cfstream", "", err)
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := h.queue.Enqueue(c.Request().Context(), webhookmodel.ProviderCfStream, payload.Status.State, body); err != nil {
+		h.metrics.ObserveWebhook("cfstream", payload.Status.State, err)
+		return err
+	}
+	return c.NoContent(http.StatusAccepted)
+}
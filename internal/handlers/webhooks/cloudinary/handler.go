@@ -18,27 +18,46 @@
 package cloudinary
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/metrics"
+	webhookmodel "github.com/mikhail5545/media-service-go/internal/models/webhook"
+	"github.com/mikhail5545/media-service-go/internal/payload"
+	"github.com/mikhail5545/media-service-go/internal/routers"
 	cldservice "github.com/mikhail5545/media-service-go/internal/services/cloudinary"
+	"github.com/mikhail5545/media-service-go/internal/services/webhook"
 )
 
 type WebhookHandler struct {
 	service *cldservice.Service
+	queue   *webhook.Queue
+	metrics *metrics.Metrics
 }
 
-func New(svc *cldservice.Service) *WebhookHandler {
+func New(svc *cldservice.Service, queue *webhook.Queue, m *metrics.Metrics) *WebhookHandler {
 	return &WebhookHandler{
 		service: svc,
+		queue:   queue,
+		metrics: m,
 	}
 }
 
+// Register registers the webhook route under /cloudinary.
+func (h *WebhookHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	routers.ProviderGroup(group, string(webhookmodel.ProviderCloudinary), m...).POST("", h.Handle)
+}
+
+// Handle verifies and queues the notification, it is acknowledged once persisted and processed
+// asynchronously by the webhook queue.
 func (h *WebhookHandler) Handle(c echo.Context) error {
-	var body []byte
-	n, err := c.Request().Body.Read(body)
-	if n == 0 || err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	body, err := io.ReadAll(c.Request().Body)
+	if len(body) == 0 || err != nil {
+		err = payload.BindError(err, "invalid request body")
+		h.metrics.ObserveWebhook("cloudinary", "", err)
+		return err
 	}
 
 	timestamp := c.Request().Header.Get("X-Cld-Timestamp")
@@ -50,5 +69,26 @@ func (h *WebhookHandler) Handle(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusForbidden, "missing X-Cld-Signature header")
 	}
 
-	return h.service.HandleWebhook(c.Request().Context(), body, timestamp, signature)
+	env, err := h.service.VerifyWebhook(c.Request().Context(), body, timestamp, signature)
+	if err != nil {
+		h.metrics.ObserveWebhook("cloudinary", "", err)
+		return err
+	}
+
+	// The notification type labels the queued event and the public ID orders the notifications of an
+	// asset, the payload itself is decoded when processed.
+	var head struct {
+		NotificationType string `json:"notification_type"`
+		PublicID         string `json:"public_id"`
+	}
+	if err := json.Unmarshal(body, &head); err != nil {
+		h.metrics.ObserveWebhook("cloudinary", "", err)
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := h.queue.Enqueue(c.Request().Context(), webhookmodel.ProviderCloudinary, head.NotificationType, body,
+		webhook.InEnvironment(env), webhook.ForObject(head.PublicID)); err != nil {
+		h.metrics.ObserveWebhook("cloudinary", head.NotificationType, err)
+		return err
+	}
+	return c.NoContent(http.StatusAccepted)
 }
@@ -18,27 +18,61 @@
 package mux
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/metrics"
 	muxtypes "github.com/mikhail5545/media-service-go/internal/models/mux/types"
+	webhookmodel "github.com/mikhail5545/media-service-go/internal/models/webhook"
+	"github.com/mikhail5545/media-service-go/internal/payload"
+	"github.com/mikhail5545/media-service-go/internal/routers"
 	muxservice "github.com/mikhail5545/media-service-go/internal/services/mux"
+	"github.com/mikhail5545/media-service-go/internal/services/webhook"
 )
 
 type WebhookHandler struct {
 	service *muxservice.Service
+	queue   *webhook.Queue
+	metrics *metrics.Metrics
 }
 
-func New(svc *muxservice.Service) *WebhookHandler {
+func New(svc *muxservice.Service, queue *webhook.Queue, m *metrics.Metrics) *WebhookHandler {
 	return &WebhookHandler{
 		service: svc,
+		queue:   queue,
+		metrics: m,
 	}
 }
 
+// Register registers the webhook route under /mux.
+func (h *WebhookHandler) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	routers.ProviderGroup(group, string(webhookmodel.ProviderMux), m...).POST("", h.Handle)
+}
+
+// Handle verifies and queues the webhook, it is acknowledged once persisted and processed
+// asynchronously by the webhook queue.
 func (h *WebhookHandler) Handle(c echo.Context) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if len(body) == 0 || err != nil {
+		err = payload.BindError(err, "invalid request body")
+		h.metrics.ObserveWebhook("mux", "", err)
+		return err
+	}
+	if err := h.service.VerifyWebhook(c.Request().Context(), body, c.Request().Header.Get("Mux-Signature")); err != nil {
+		h.metrics.ObserveWebhook("mux", "", err)
+		return err
+	}
+
 	var payload muxtypes.MuxWebhook
-	if err := c.Bind(&payload); err != nil {
+	if err := json.Unmarshal(body, &payload); err != nil {
+		h.metrics.ObserveWebhook("mux", "", err)
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
-	return h.service.HandleAssetWebhook(c.Request().Context(), &payload)
+	if err := h.queue.Enqueue(c.Request().Context(), webhookmodel.ProviderMux, payload.Type, body, webhook.ForObject(payload.Object.ID)); err != nil {
+		h.metrics.ObserveWebhook("mux", payload.Type, err)
+		return err
+	}
+	return c.NoContent(http.StatusAccepted)
 }
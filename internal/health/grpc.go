@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package health
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Watch runs the checks every interval and publishes the result to the gRPC health server for the
+// overall ("") service and each of the given services. Degraded reports keep the services SERVING,
+// unavailable reports switch them to NOT_SERVING. When ctx is done all services are marked NOT_SERVING.
+func (c *Checker) Watch(ctx context.Context, srv *grpchealth.Server, interval time.Duration, services ...string) {
+	services = append([]string{""}, services...)
+	set := func(status healthpb.HealthCheckResponse_ServingStatus) {
+		for _, svc := range services {
+			srv.SetServingStatus(svc, status)
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		report := c.Run(ctx)
+		if report.Status == StatusUnavailable {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if status != last {
+			c.logger.Info("gRPC serving status changed",
+				zap.String("status", status.String()),
+				zap.Strings("failing", report.Failing),
+			)
+			last = status
+		}
+		set(status)
+
+		select {
+		case <-ctx.Done():
+			srv.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
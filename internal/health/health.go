@@ -0,0 +1,193 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package health runs dependency checks for the liveness/readiness endpoints and the gRPC health service.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultTimeout is applied to checks that do not set their own timeout.
+const DefaultTimeout = 2 * time.Second
+
+// Status is the outcome of a single check or of the whole report.
+type Status string
+
+const (
+	// StatusOK means all checks passed.
+	StatusOK Status = "ok"
+	// StatusDegraded means only non-critical checks failed; the service keeps serving traffic.
+	StatusDegraded Status = "degraded"
+	// StatusUnavailable means at least one critical check failed; the service is not ready.
+	StatusUnavailable Status = "unavailable"
+	// StatusFailing is reported for an individual failing check.
+	StatusFailing Status = "failing"
+)
+
+// Probe verifies a single dependency.
+type Probe func(ctx context.Context) error
+
+// Check describes a dependency check.
+type Check struct {
+	// Name identifies the dependency in the report, e.g. "postgres".
+	Name string
+	// Probe is called with a context limited by Timeout.
+	Probe Probe
+	// Timeout bounds a single probe run. Defaults to [DefaultTimeout].
+	Timeout time.Duration
+	// Critical checks make the service unavailable when failing, other checks only degrade it.
+	Critical bool
+	// CacheFor reuses the result of the last probe for the duration, so frequent readiness probes do
+	// not call rate limited provider APIs. Results are not cached if it is zero.
+	CacheFor time.Duration
+}
+
+// CheckResult is the outcome of a single check.
+type CheckResult struct {
+	Status   Status `json:"status"`
+	Critical bool   `json:"critical"`
+	Latency  string `json:"latency"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Report aggregates the results of all checks.
+type Report struct {
+	Status Status                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+	// Failing lists the names of failing dependencies.
+	Failing []string `json:"failing,omitempty"`
+}
+
+// Checker runs a fixed set of checks concurrently.
+type Checker struct {
+	checks []Check
+	// cached holds the last result of each check, by the index of the check.
+	cached []cachedResult
+	logger *zap.Logger
+}
+
+// cachedResult is the last result of a check with CacheFor. The mutex is held while probing, so
+// concurrent runs wait for the probe instead of starting their own.
+type cachedResult struct {
+	mu     sync.Mutex
+	result CheckResult
+	at     time.Time
+}
+
+// New creates a Checker for the given checks. Checks without a name or probe are rejected.
+func New(logger *zap.Logger, checks ...Check) (*Checker, error) {
+	seen := make(map[string]struct{}, len(checks))
+	for i, c := range checks {
+		if c.Name == "" {
+			return nil, fmt.Errorf("check %d: name is required", i)
+		}
+		if c.Probe == nil {
+			return nil, fmt.Errorf("check %q: probe is required", c.Name)
+		}
+		if _, ok := seen[c.Name]; ok {
+			return nil, fmt.Errorf("check %q: duplicate name", c.Name)
+		}
+		seen[c.Name] = struct{}{}
+	}
+	return &Checker{
+		checks: checks,
+		cached: make([]cachedResult, len(checks)),
+		logger: logger.With(zap.String("component", "health")),
+	}, nil
+}
+
+// Run executes all checks concurrently and aggregates the results.
+func (c *Checker) Run(ctx context.Context) *Report {
+	results := make([]CheckResult, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.result(ctx, i, check)
+		}()
+	}
+	wg.Wait()
+
+	report := &Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(c.checks))}
+	for i, check := range c.checks {
+		res := results[i]
+		report.Checks[check.Name] = res
+		if res.Status == StatusOK {
+			continue
+		}
+		report.Failing = append(report.Failing, check.Name)
+		if check.Critical {
+			report.Status = StatusUnavailable
+		} else if report.Status == StatusOK {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+// result returns the cached result of the check while it is fresh, otherwise it runs the probe.
+func (c *Checker) result(ctx context.Context, i int, check Check) CheckResult {
+	if check.CacheFor <= 0 {
+		return c.run(ctx, check)
+	}
+	cached := &c.cached[i]
+	cached.mu.Lock()
+	defer cached.mu.Unlock()
+	if !cached.at.IsZero() && time.Since(cached.at) < check.CacheFor {
+		return cached.result
+	}
+	res := c.run(ctx, check)
+	// A probe cut short by the caller says nothing about the dependency.
+	if ctx.Err() == nil {
+		cached.result, cached.at = res, time.Now()
+	}
+	return res
+}
+
+func (c *Checker) run(ctx context.Context, check Check) CheckResult {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Probe(ctx)
+	res := CheckResult{Status: StatusOK, Critical: check.Critical, Latency: time.Since(start).String()}
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+			err = fmt.Errorf("timed out after %s: %w", timeout, err)
+		}
+		res.Status = StatusFailing
+		res.Error = err.Error()
+		c.logger.Warn("health check failed",
+			zap.String("check", check.Name),
+			zap.Bool("critical", check.Critical),
+			zap.Error(err),
+		)
+	}
+	return res
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestRunCachesProbeResults(t *testing.T) {
+	var calls atomic.Int32
+	probe := func(context.Context) error {
+		calls.Add(1)
+		return errors.New("unreachable")
+	}
+	checker, err := New(zap.NewNop(),
+		Check{Name: "cached", Probe: probe, CacheFor: time.Hour},
+		Check{Name: "uncached", Probe: probe},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 3 {
+		report := checker.Run(context.Background())
+		if report.Status != StatusDegraded {
+			t.Fatalf("status = %s, want %s", report.Status, StatusDegraded)
+		}
+		if res := report.Checks["cached"]; res.Status != StatusFailing || res.Error == "" {
+			t.Fatalf("cached check = %+v, want the failing result", res)
+		}
+	}
+	// The cached check probes once, the uncached one on every run.
+	if n := calls.Load(); n != 4 {
+		t.Errorf("probes = %d, want 4", n)
+	}
+}
+
+func TestRunRefreshesExpiredResults(t *testing.T) {
+	var calls atomic.Int32
+	checker, err := New(zap.NewNop(), Check{
+		Name:     "cached",
+		CacheFor: time.Millisecond,
+		Probe: func(context.Context) error {
+			calls.Add(1)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checker.Run(context.Background())
+	time.Sleep(5 * time.Millisecond)
+	checker.Run(context.Background())
+	if n := calls.Load(); n != 2 {
+		t.Errorf("probes = %d, want 2", n)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package lifecycle coordinates the startup and ordered shutdown of long-running components.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultShutdownTimeout bounds the whole shutdown when no timeout is configured.
+const DefaultShutdownTimeout = 15 * time.Second
+
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Manager runs components until a shutdown signal is received or one of them fails, then runs the
+// registered shutdown hooks in reverse registration order within the shutdown timeout.
+//
+// Register hooks in startup order: resources first, servers last. On shutdown servers stop
+// accepting requests and drain first, and the resources they depend on are released last.
+type Manager struct {
+	timeout time.Duration
+	logger  *zap.Logger
+
+	mu           sync.Mutex
+	hooks        []hook
+	shuttingDown bool
+
+	wg       sync.WaitGroup
+	errs     chan error
+	shutdown sync.Once
+	err      error
+}
+
+// New creates a Manager with the given shutdown timeout.
+func New(timeout time.Duration, logger *zap.Logger) *Manager {
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	return &Manager{
+		timeout: timeout,
+		logger:  logger.With(zap.String("component", "lifecycle")),
+		errs:    make(chan error, 1),
+	}
+}
+
+// Go runs a blocking component, e.g. a server's Serve method, in a new goroutine. If it returns
+// an error before shutdown has started, the manager shuts down. Errors returned after shutdown
+// has started are expected and ignored.
+func (m *Manager) Go(name string, fn func() error) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		err := fn()
+		m.mu.Lock()
+		shuttingDown := m.shuttingDown
+		m.mu.Unlock()
+		if shuttingDown {
+			return
+		}
+		if err == nil {
+			err = errors.New("stopped unexpectedly")
+		}
+		select {
+		case m.errs <- fmt.Errorf("%s: %w", name, err):
+		default:
+		}
+	}()
+}
+
+// OnShutdown registers a shutdown hook. Hooks run sequentially in reverse registration order and
+// share the shutdown deadline.
+func (m *Manager) OnShutdown(name string, fn func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook{name: name, fn: fn})
+}
+
+// Run blocks until SIGINT or SIGTERM is received, ctx is done or a component fails, then shuts down.
+// It returns the component failure, if any, joined with the shutdown errors.
+func (m *Manager) Run(ctx context.Context) error {
+	sigCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var runErr error
+	select {
+	case <-sigCtx.Done():
+		m.logger.Info("received shutdown signal")
+	case err := <-m.errs:
+		m.logger.Error("component failed, shutting down", zap.Error(err))
+		runErr = err
+	}
+	return errors.Join(runErr, m.Shutdown())
+}
+
+// Shutdown runs the shutdown hooks and waits for the components started with [Manager.Go] to return.
+// It is safe to call multiple times; only the first call has an effect.
+func (m *Manager) Shutdown() error {
+	m.shutdown.Do(func() {
+		m.mu.Lock()
+		m.shuttingDown = true
+		hooks := m.hooks
+		m.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		start := time.Now()
+		var errs []error
+		for i := len(hooks) - 1; i >= 0; i-- {
+			h := hooks[i]
+			hookStart := time.Now()
+			if err := h.fn(ctx); err != nil {
+				m.logger.Error("shutdown hook failed", zap.String("hook", h.name), zap.Error(err))
+				errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+				continue
+			}
+			m.logger.Info("shutdown hook completed", zap.String("hook", h.name), zap.Duration("duration", time.Since(hookStart)))
+		}
+
+		done := make(chan struct{})
+		go func() {
+			m.wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			errs = append(errs, errors.New("timed out waiting for components to stop"))
+		}
+
+		m.err = errors.Join(errs...)
+		m.logger.Info("shutdown completed", zap.Duration("duration", time.Since(start)), zap.Error(m.err))
+	})
+	return m.err
+}
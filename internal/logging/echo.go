@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package logging
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// EchoMiddleware extracts or generates the request ID, extracts the correlation ID (defaulting to
+// the request ID), echoes both in the response headers, stores them in the request context and
+// logs every completed request.
+func EchoMiddleware(logger *zap.Logger) echo.MiddlewareFunc {
+	logger = logger.With(zap.String("component", "http"))
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			start := time.Now()
+
+			requestID := req.Header.Get(RequestIDHeader)
+			if requestID == "" {
+				requestID = uuid.NewString()
+			}
+			correlationID := req.Header.Get(CorrelationIDHeader)
+			if correlationID == "" {
+				correlationID = requestID
+			}
+			c.Response().Header().Set(RequestIDHeader, requestID)
+			c.Response().Header().Set(CorrelationIDHeader, correlationID)
+
+			ctx := WithCorrelationID(WithRequestID(req.Context(), requestID), correlationID)
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+			if err != nil {
+				// Let the error handler write the response, so the logged status is final.
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			level := zapcore.InfoLevel
+			switch {
+			case status >= 500:
+				level = zapcore.ErrorLevel
+			case status >= 400:
+				level = zapcore.WarnLevel
+			}
+			fields := []zap.Field{
+				zap.String("method", req.Method),
+				zap.String("uri", req.RequestURI),
+				zap.String("route", c.Path()),
+				zap.Int("status", status),
+				zap.Duration("latency", time.Since(start)),
+				zap.String("remote_ip", c.RealIP()),
+			}
+			if err != nil {
+				fields = append(fields, zap.Error(err))
+			}
+			FromContext(c.Request().Context(), logger).Log(level, "HTTP request handled", fields...)
+			return nil
+		}
+	}
+}
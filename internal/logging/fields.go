@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package logging
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// AssetID returns the field identifying an asset.
+func AssetID(id fmt.Stringer) zap.Field {
+	return zap.Stringer("asset_id", id)
+}
+
+// OwnerID returns the field identifying an asset owner.
+func OwnerID(id fmt.Stringer) zap.Field {
+	return zap.Stringer("owner_id", id)
+}
+
+// OwnerType returns the field holding an asset owner type.
+func OwnerType(t string) zap.Field {
+	return zap.String("owner_type", t)
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package logging provides request-scoped zap logging. Transport middlewares store the request and
+// correlation IDs in the context, and code deeper in the call chain derives a logger carrying them
+// with [FromContext].
+package logging
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// RequestIDHeader is the HTTP header and gRPC metadata key carrying the request ID.
+	RequestIDHeader = "X-Request-ID"
+	// CorrelationIDHeader is the HTTP header and gRPC metadata key carrying the correlation ID,
+	// which stays the same across all requests triggered by a single user action.
+	CorrelationIDHeader = "X-Correlation-ID"
+)
+
+type (
+	requestIDKey     struct{}
+	correlationIDKey struct{}
+	fieldsKey        struct{}
+)
+
+// WithRequestID returns a copy of ctx carrying the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID stored in ctx, or an empty string.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithCorrelationID returns a copy of ctx carrying the correlation ID.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID stored in ctx, or an empty string.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// WithFields returns a copy of ctx carrying additional fields attached to every logger derived
+// from it, e.g. the asset being processed.
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	existing, _ := ctx.Value(fieldsKey{}).([]zap.Field)
+	merged := make([]zap.Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// FromContext returns base enriched with the request ID, correlation ID, trace ID and fields stored in ctx.
+func FromContext(ctx context.Context, base *zap.Logger) *zap.Logger {
+	if ctx == nil {
+		return base
+	}
+	var fields []zap.Field
+	if id := RequestID(ctx); id != "" {
+		fields = append(fields, zap.String("request_id", id))
+	}
+	if id := CorrelationID(ctx); id != "" {
+		fields = append(fields, zap.String("correlation_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		fields = append(fields, zap.String("trace_id", sc.TraceID().String()))
+	}
+	if extra, ok := ctx.Value(fieldsKey{}).([]zap.Field); ok {
+		fields = append(fields, extra...)
+	}
+	if len(fields) == 0 {
+		return base
+	}
+	return base.With(fields...)
+}
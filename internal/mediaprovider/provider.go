@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package mediaprovider defines the adapters of the storage backends built on the shared asset core.
+//
+// The core (see package services/mediacore) implements everything backends have in common: asset
+// records, listing, archiving, ownership, auditing, events and the two-phase permanent deletion. An
+// adapter only implements the calls to its backend, so a new backend is a new adapter package plus
+// its provider-specific endpoints, e.g. upload URL generation.
+package mediaprovider
+
+import (
+	"context"
+	"regexp"
+
+	mediamodel "github.com/mikhail5545/media-service-go/internal/models/media"
+)
+
+// Provider is the adapter of a storage backend.
+type Provider interface {
+	// Name identifies the backend, e.g. "s3". It is stored with every asset of the backend and used
+	// in the admin routes, audit entries and events, so it must never change. See [ValidName].
+	Name() string
+	// DeleteRemote deletes the stored content of the asset from the backend. It is called by the
+	// second phase of the permanent deletion outside of any database transaction and retried until it
+	// succeeds, so deleting content that does not exist anymore must succeed.
+	DeleteRemote(ctx context.Context, asset *mediamodel.Asset) error
+}
+
+// Pinger is implemented by providers that can check the reachability of their backend.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,31}$`)
+
+// ValidName reports whether name can be used as a provider name: 2 to 32 lowercase letters, digits
+// and underscores, starting with a letter.
+func ValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
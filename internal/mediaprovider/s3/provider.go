@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package s3 is the adapter of the S3-compatible object storage of raw file assets.
+package s3
+
+import (
+	"context"
+
+	s3api "github.com/mikhail5545/media-service-go/internal/apiclients/s3"
+	"github.com/mikhail5545/media-service-go/internal/mediaprovider"
+	mediamodel "github.com/mikhail5545/media-service-go/internal/models/media"
+)
+
+// Name is the provider name of the file assets.
+const Name = "s3"
+
+type Provider struct {
+	client *s3api.Client
+}
+
+var (
+	_ mediaprovider.Provider = (*Provider)(nil)
+	_ mediaprovider.Pinger   = (*Provider)(nil)
+)
+
+func New(client *s3api.Client) *Provider {
+	return &Provider{client: client}
+}
+
+func (p *Provider) Name() string {
+	return Name
+}
+
+// DeleteRemote deletes the object of the asset. The external ID of file assets is the object key.
+func (p *Provider) DeleteRemote(ctx context.Context, asset *mediamodel.Asset) error {
+	return p.client.DeleteObject(ctx, asset.ExternalID)
+}
+
+func (p *Provider) Ping(ctx context.Context) error {
+	return p.client.Ping(ctx)
+}
@@ -0,0 +1,218 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package metrics defines the Prometheus collectors of the media service and the helpers
+// instrumenting HTTP, gRPC, webhook processing, provider API calls and database queries.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mikhail5545/media-service-go/internal/apiclients/resilience"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/connectivity"
+)
+
+const namespace = "media_service"
+
+// Metrics holds the service collectors registered in a dedicated registry.
+// The Observe* and Set* methods are safe to call on a nil *Metrics, which makes instrumentation
+// of services and handlers optional.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests  *prometheus.CounterVec
+	httpDuration  *prometheus.HistogramVec
+	grpcRequests  *prometheus.CounterVec
+	grpcDuration  *prometheus.HistogramVec
+	webhookEvents *prometheus.CounterVec
+	apiDuration   *prometheus.HistogramVec
+	apiRetries    *prometheus.CounterVec
+	breakerState  *prometheus.GaugeVec
+	grpcConnState *prometheus.GaugeVec
+	dbDuration    *prometheus.HistogramVec
+	assets        *prometheus.GaugeVec
+}
+
+// New creates the collectors and registers them together with the Go runtime and process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of handled HTTP requests by route, method and status code.",
+		}, []string{"method", "route", "code"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by route and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "Number of handled gRPC calls by method and status code.",
+		}, []string{"method", "code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "gRPC call latency by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		webhookEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "webhook",
+			Name:      "events_total",
+			Help:      "Number of processed provider webhooks by provider, event type and result.",
+		}, []string{"provider", "type", "result"}),
+		apiDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "provider_api",
+			Name:      "call_duration_seconds",
+			Help:      "Mux and Cloudinary API call latency by provider, operation and result.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"provider", "operation", "result"}),
+		apiRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "provider_api",
+			Name:      "retries_total",
+			Help:      "Number of retried Mux and Cloudinary API calls by provider and operation.",
+		}, []string{"provider", "operation"}),
+		breakerState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "provider_api",
+			Name:      "circuit_breaker_state",
+			Help:      "State of the provider API circuit breaker: 0 closed, 1 half-open, 2 open.",
+		}, []string{"provider"}),
+		grpcConnState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "grpc_client",
+			Name:      "connection_state",
+			Help:      "Connectivity state of outgoing gRPC connections: 0 idle, 1 connecting, 2 ready, 3 transient failure, 4 shutdown.",
+		}, []string{"connection"}),
+		dbDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "Postgres query latency by operation and table.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+		assets: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "assets",
+			Help:      "Number of assets by provider and state (unowned, archived, broken, pending_delete), refreshed periodically.",
+		}, []string{"provider", "state"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration,
+		m.grpcRequests, m.grpcDuration,
+		m.webhookEvents, m.apiDuration, m.apiRetries, m.breakerState, m.grpcConnState, m.dbDuration, m.assets,
+	)
+	return m
+}
+
+// Handler returns the HTTP handler exposing the registry in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Registry returns the underlying registry, e.g. to register additional collectors.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// ObserveWebhook counts a processed webhook.
+func (m *Metrics) ObserveWebhook(provider, eventType string, err error) {
+	if m == nil {
+		return
+	}
+	if eventType == "" {
+		eventType = "unknown"
+	}
+	m.webhookEvents.WithLabelValues(provider, eventType, result(err)).Inc()
+}
+
+// ObserveAPICall records the latency of a provider API call.
+func (m *Metrics) ObserveAPICall(provider, operation string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.apiDuration.WithLabelValues(provider, operation, result(err)).Observe(duration.Seconds())
+}
+
+// APICallObserver returns a callback recording provider API calls for the given provider, suitable
+// for the API client observer options.
+func (m *Metrics) APICallObserver(provider string) func(operation string, duration time.Duration, err error) {
+	return func(operation string, duration time.Duration, err error) {
+		m.ObserveAPICall(provider, operation, duration, err)
+	}
+}
+
+// APIResilienceHooks returns hooks counting the retried API calls of the given provider and exposing
+// the state of its circuit breaker, suitable for the API client resilience options.
+func (m *Metrics) APIResilienceHooks(provider string) resilience.Hooks {
+	return resilience.Hooks{
+		OnRetry: func(operation string) {
+			if m == nil {
+				return
+			}
+			m.apiRetries.WithLabelValues(provider, operation).Inc()
+		},
+		OnStateChange: func(state resilience.State) {
+			if m == nil {
+				return
+			}
+			m.breakerState.WithLabelValues(provider).Set(float64(state))
+		},
+	}
+}
+
+// GRPCConnStateObserver returns a callback exposing the state of outgoing gRPC connections,
+// suitable for the client pool observer.
+func (m *Metrics) GRPCConnStateObserver() func(name string, state connectivity.State) {
+	return func(name string, state connectivity.State) {
+		if m == nil {
+			return
+		}
+		m.grpcConnState.WithLabelValues(name).Set(float64(state))
+	}
+}
+
+// SetAssetCount sets the number of assets of the provider in the given state.
+func (m *Metrics) SetAssetCount(provider, state string, count int64) {
+	if m == nil {
+		return
+	}
+	m.assets.WithLabelValues(provider, state).Set(float64(count))
+}
+
+func result(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package assetimport
+
+// CreateJobRequest starts an import of the assets of the providers, all providers are imported
+// when Providers is empty.
+type CreateJobRequest struct {
+	Providers []Provider `json:"providers"`
+	DryRun    bool       `json:"dry_run"`
+	AdminID   string     `json:"admin_id"`
+	AdminName string     `json:"admin_name"`
+}
+
+type GetJobRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+// ListJobsRequest lists the import jobs of the instance, newest first. Status restricts the
+// listing to jobs in that state.
+type ListJobsRequest struct {
+	Status Status `query:"status"`
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package assetimport provides models for the asset imports, which create the local records of the
+// provider assets created before the service, or outside of it.
+package assetimport
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Provider identifies the provider assets are imported from.
+type Provider string
+
+const (
+	ProviderMux        Provider = "mux"
+	ProviderCloudinary Provider = "cloudinary"
+)
+
+// Status is the state of an import job.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// Outcome is the result of the import of a single provider asset.
+type Outcome string
+
+const (
+	OutcomeCreated Outcome = "created"
+	// OutcomeSkipped assets already have a local record.
+	OutcomeSkipped Outcome = "skipped"
+	OutcomeFailed  Outcome = "failed"
+)
+
+// MaxItemErrors is the number of asset errors kept in a job, later errors are only counted.
+const MaxItemErrors = 100
+
+// Options are passed to the provider importers.
+type Options struct {
+	// DryRun reports the assets which would be created without creating them.
+	DryRun bool
+	// AdminID and AdminName are recorded in the audit log entries of the created assets.
+	AdminID   string
+	AdminName string
+}
+
+// Result is the result of the import of a single provider asset.
+type Result struct {
+	// ExternalID identifies the asset in the provider, e.g. the MUX asset ID or the Cloudinary public ID.
+	ExternalID string
+	Outcome    Outcome
+	// AssetID is the local asset, it is set for created and skipped assets.
+	AssetID uuid.UUID
+	// Owners is the number of owners matched for a created asset.
+	Owners int
+	Err    error
+}
+
+// ItemError describes a provider asset which could not be imported.
+type ItemError struct {
+	Provider   Provider `json:"provider"`
+	ExternalID string   `json:"external_id"`
+	Error      string   `json:"error"`
+}
+
+// Counts are the numbers of the imported assets of a provider.
+type Counts struct {
+	Created int64 `json:"created"`
+	Skipped int64 `json:"skipped"`
+	Failed  int64 `json:"failed"`
+	// Owners is the number of owners matched for the created assets.
+	Owners int64 `json:"owners"`
+}
+
+// Job describes an import. Jobs are kept in memory of the instance which created them.
+type Job struct {
+	ID        uuid.UUID  `json:"id"`
+	Status    Status     `json:"status"`
+	Providers []Provider `json:"providers"`
+	DryRun    bool       `json:"dry_run"`
+	AdminID   string     `json:"admin_id"`
+	AdminName string     `json:"admin_name"`
+	// Counts holds the progress of each provider.
+	Counts map[Provider]*Counts `json:"counts"`
+	// Errors holds the first [MaxItemErrors] asset errors.
+	Errors []ItemError `json:"errors"`
+	// Error describes why a failed job failed.
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// ExpiresAt is the moment the job is discarded, it is set once the job finishes.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Clone returns a deep copy of the job.
+func (j *Job) Clone() *Job {
+	clone := *j
+	clone.Counts = make(map[Provider]*Counts, len(j.Counts))
+	for provider, counts := range j.Counts {
+		c := *counts
+		clone.Counts[provider] = &c
+	}
+	clone.Errors = append([]ItemError{}, j.Errors...)
+	return &clone
+}
+
+// Owner is an owner matched for an imported asset.
+type Owner struct {
+	OwnerID   string
+	OwnerType string
+}
+
+// ParseOwners extracts the owners referenced by a provider value, e.g. the passthrough of a MUX asset
+// or the external_id context of a Cloudinary asset. Owners are comma separated "owner_type:owner_id"
+// pairs, pairs with an unregistered owner type or an owner ID which is not a UUID are ignored.
+func ParseOwners(value string, registered func(ownerType string) bool) []Owner {
+	var owners []Owner
+	for _, pair := range strings.Split(value, ",") {
+		ownerType, ownerID, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || !registered(ownerType) {
+			continue
+		}
+		if _, err := uuid.Parse(ownerID); err != nil {
+			continue
+		}
+		owners = append(owners, Owner{OwnerID: ownerID, OwnerType: ownerType})
+	}
+	return owners
+}
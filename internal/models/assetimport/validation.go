@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package assetimport
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+func (req CreateJobRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Providers, validation.Each(validation.In(ProviderMux, ProviderCloudinary))),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+	)
+}
+
+func (req GetJobRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
+
+func (req ListJobsRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Status, validation.In(StatusPending, StatusRunning, StatusCompleted, StatusFailed)),
+	)
+}
@@ -0,0 +1,38 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+// ListRequest lists audit log entries, newest first. All filters are optional.
+type ListRequest struct {
+	Provider Provider `query:"provider"`
+	AssetID  string   `query:"asset_id"`
+	AdminID  string   `query:"admin_id"`
+	Actions  []Action `query:"action"`
+	Source   Source   `query:"source"`
+	// From and To limit the entries to the time range, formatted as RFC 3339.
+	From string `query:"from"`
+	To   string `query:"to"`
+
+	PageSize  int    `query:"page_size"`
+	PageToken string `query:"page_token"`
+}
+
+// GetHistoryRequest retrieves the timeline of an asset.
+type GetHistoryRequest struct {
+	ID string `param:"id" json:"-"`
+}
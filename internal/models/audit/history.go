@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+// HistoryKind classifies the events of an asset timeline.
+type HistoryKind string
+
+const (
+	// HistoryKindState is a state transition caused by a provider webhook.
+	HistoryKindState HistoryKind = "state"
+	// HistoryKindAdmin is an admin action, e.g. archive, restore or mark as broken.
+	HistoryKindAdmin HistoryKind = "admin"
+	// HistoryKindOwnership is an owner added to or removed from the asset.
+	HistoryKindOwnership HistoryKind = "ownership"
+)
+
+// HistoryEvent is a single event of an asset timeline.
+type HistoryEvent struct {
+	Kind HistoryKind `json:"kind"`
+	*Entry
+}
+
+// NewHistory converts audit log entries, oldest first, into the asset timeline.
+func NewHistory(entries []*Entry) []*HistoryEvent {
+	events := make([]*HistoryEvent, 0, len(entries))
+	for _, entry := range entries {
+		events = append(events, &HistoryEvent{Kind: historyKind(entry), Entry: entry})
+	}
+	return events
+}
+
+func historyKind(entry *Entry) HistoryKind {
+	switch {
+	case entry.Source == SourceWebhook:
+		return HistoryKindState
+	case entry.Action == ActionAddOwner, entry.Action == ActionRemoveOwner:
+		return HistoryKindOwnership
+	default:
+		return HistoryKindAdmin
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package audit provides models for the audit log, which records every admin mutation of
+// MUX and Cloudinary assets along with the admin who made it, and the webhook-driven
+// state transitions of the assets.
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Provider identifies the asset type the audited action was applied to.
+type Provider string
+
+const (
+	ProviderMux        Provider = "mux"
+	ProviderCloudinary Provider = "cloudinary"
+)
+
+// Action identifies the audited admin mutation.
+type Action string
+
+const (
+	ActionCreateUploadURL Action = "create_upload_url"
+	// ActionCreate is recorded when an asset of a backend built on the shared asset core is created.
+	ActionCreate         Action = "create"
+	ActionArchive        Action = "archive"
+	ActionRestore        Action = "restore"
+	ActionMarkAsBroken   Action = "mark_as_broken"
+	ActionDelete         Action = "delete"
+	ActionAddOwner       Action = "add_owner"
+	ActionRemoveOwner    Action = "remove_owner"
+	ActionUpdateOwners   Action = "update_owners"
+	ActionUpdateMetadata Action = "update_metadata"
+	ActionAddTags        Action = "add_tags"
+	ActionRemoveTags     Action = "remove_tags"
+	// ActionAddPlaybackID, ActionRemovePlaybackID and ActionRotatePlaybackID are recorded for playback ID management of MUX assets.
+	ActionAddPlaybackID    Action = "add_playback_id"
+	ActionRemovePlaybackID Action = "remove_playback_id"
+	ActionRotatePlaybackID Action = "rotate_playback_id"
+	// ActionSetPoster is recorded when the poster of a MUX asset is set or reset.
+	ActionSetPoster Action = "set_poster"
+	// ActionUpdateChapters is recorded when a chapter of a MUX asset is added, changed or removed.
+	ActionUpdateChapters Action = "update_chapters"
+	// ActionUpdateTrack is recorded when a track of a MUX asset is renamed or made the default.
+	ActionUpdateTrack Action = "update_track"
+	// ActionApproveModeration and ActionRejectModeration are recorded for manual moderation decisions.
+	ActionApproveModeration Action = "approve_moderation"
+	ActionRejectModeration  Action = "reject_moderation"
+	// ActionStateChanged is recorded when a provider webhook changes the asset state.
+	ActionStateChanged Action = "state_changed"
+	// ActionImport is recorded when the local record of an existing provider asset is created by an import.
+	ActionImport Action = "import"
+	// ActionRestoreFromBackup is recorded when the file of a Cloudinary image is restored from its backup.
+	ActionRestoreFromBackup Action = "restore_from_backup"
+	// ActionSchedulePublishing and ActionCancelPublishing are recorded when an admin changes the publication schedule.
+	ActionSchedulePublishing Action = "schedule_publishing"
+	ActionCancelPublishing   Action = "cancel_publishing"
+	// ActionPublish and ActionUnpublish are recorded when the publishing worker publishes or unpublishes a scheduled asset.
+	ActionPublish   Action = "publish"
+	ActionUnpublish Action = "unpublish"
+)
+
+// Source identifies the transport the audited action was requested through.
+type Source string
+
+const (
+	SourceHTTP Source = "http"
+	SourceGRPC Source = "grpc"
+	// SourceSystem is used for actions which were not requested through a transport, e.g. by background workers.
+	SourceSystem Source = "system"
+	// SourceWebhook is used for changes caused by provider webhooks.
+	SourceWebhook Source = "webhook"
+)
+
+// Entry represents a single audit log record. Entries are persisted in the same database
+// transaction as the mutation they describe.
+type Entry struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid" json:"id"` // UUIDv7
+	CreatedAt time.Time `gorm:"index:idx_audit_log_created_at" json:"created_at"`
+
+	Provider Provider  `gorm:"type:varchar(32);not null" json:"provider"`
+	AssetID  uuid.UUID `gorm:"type:uuid;not null;index" json:"asset_id"`
+	Action   Action    `gorm:"type:varchar(64);not null;index" json:"action"`
+
+	// AdminID and AdminName identify the admin who made the change. They are taken from the
+	// request when it carries them, otherwise from the authenticated caller.
+	AdminID   *uuid.UUID `gorm:"type:uuid;null;index" json:"admin_id,omitempty"`
+	AdminName *string    `gorm:"type:varchar(128);null" json:"admin_name,omitempty"`
+	Source    Source     `gorm:"type:varchar(16);not null" json:"source"`
+	RequestID *string    `gorm:"type:varchar(128);null" json:"request_id,omitempty"`
+	Note      *string    `gorm:"type:varchar(512);null" json:"note,omitempty"`
+	// EventType and EventID identify the provider webhook which caused the change.
+	EventType *string `gorm:"type:varchar(128);null" json:"event_type,omitempty"`
+	EventID   *string `gorm:"type:varchar(256);null" json:"event_id,omitempty"`
+
+	// Before and After are JSON snapshots of the changed asset fields.
+	Before json.RawMessage `gorm:"type:jsonb;null" json:"before,omitempty"`
+	After  json.RawMessage `gorm:"type:jsonb;null" json:"after,omitempty"`
+}
+
+func (*Entry) TableName() string {
+	return "audit_log"
+}
+
+func (e *Entry) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID, err = uuid.NewV7()
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+func (req ListRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Provider, validation.In(ProviderMux, ProviderCloudinary)),
+		validation.Field(&req.AssetID, validationutil.UUIDRule(false)...),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(false)...),
+		validation.Field(&req.Actions, validation.Each(validation.In(
+			ActionCreateUploadURL,
+			ActionArchive,
+			ActionRestore,
+			ActionMarkAsBroken,
+			ActionDelete,
+			ActionAddOwner,
+			ActionRemoveOwner,
+			ActionUpdateMetadata,
+			ActionAddTags,
+			ActionRemoveTags,
+			ActionApproveModeration,
+			ActionRejectModeration,
+			ActionStateChanged,
+			ActionImport,
+		))),
+		validation.Field(&req.Source, validation.In(SourceHTTP, SourceGRPC, SourceSystem, SourceWebhook)),
+		validation.Field(&req.From, validation.Date(time.RFC3339)),
+		validation.Field(&req.To, validation.Date(time.RFC3339)),
+		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
+		validation.Field(&req.PageToken, validation.Length(1, 2048)),
+	)
+}
+
+func (req GetHistoryRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package catalog provides the provider-independent view of the assets of all backends.
+package catalog
+
+import "time"
+
+// Owner is an external entity a media item is associated with, e.g. a lesson.
+type Owner struct {
+	OwnerID   string `json:"owner_id"`
+	OwnerType string `json:"owner_type"`
+}
+
+// MediaItem is an asset of any backend in the common catalog shape.
+type MediaItem struct {
+	ID       string `json:"id"`
+	Provider string `json:"provider"`
+	// Kind is the kind of the media, e.g. "video", "image" or "file".
+	Kind  string `json:"kind"`
+	Title string `json:"title,omitempty"`
+	// Thumbnail is a publicly accessible preview image, it is empty when the backend has none.
+	Thumbnail string    `json:"thumbnail,omitempty"`
+	Status    string    `json:"status"`
+	Owners    []Owner   `json:"owners"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListMediaRequest lists the active assets of all backends, newest first. Providers restricts the
+// listing to the named backends, all backends are listed when it is empty.
+type ListMediaRequest struct {
+	Providers []string `query:"providers"`
+	PageSize  int      `query:"page_size"`
+	PageToken string   `query:"page_token"`
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package cfstream holds the requests of the endpoints specific to the Cloudflare Stream video
+// backend. The assets themselves are [media.Asset] records of the "cfstream" provider.
+package cfstream
+
+import "time"
+
+// KindVideo is the kind of the assets stored in Cloudflare Stream.
+const KindVideo = "video"
+
+// Asset attributes recorded once Cloudflare Stream has processed the video.
+const (
+	AttributeDuration  = "duration"
+	AttributeWidth     = "width"
+	AttributeHeight    = "height"
+	AttributeHLS       = "hls"
+	AttributeDASH      = "dash"
+	AttributeThumbnail = "thumbnail"
+	// AttributeSignedURLs is "true" when the video can only be played with a signed token.
+	AttributeSignedURLs = "signed_urls"
+)
+
+// MetaAssetID is the key of the video meta holding the asset ID, it is sent back in the webhooks.
+const MetaAssetID = "asset_id"
+
+// CreateUploadURLRequest creates a pending video asset and a one-time direct upload URL of its content.
+type CreateUploadURLRequest struct {
+	Title string `json:"title"`
+	// MaxDurationSeconds overrides the configured maximum duration of the video.
+	MaxDurationSeconds int    `json:"max_duration_seconds"`
+	AdminID            string `json:"admin_id"`
+	AdminName          string `json:"admin_name"`
+}
+
+type UploadURL struct {
+	AssetID   string    `json:"asset_id"`
+	UploadURL string    `json:"upload_url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// PlaybackURLRequest requests the playback URLs of an active video asset. Videos requiring signed
+// URLs are played with a token valid for Expiration seconds, the configured default is used if it
+// is zero.
+type PlaybackURLRequest struct {
+	ID         string `param:"id" json:"-"`
+	Expiration int64  `query:"expiration"`
+}
+
+// PlaybackURL holds the manifest URLs of a video. The URLs embed the signed token, if any.
+type PlaybackURL struct {
+	HLS       string     `json:"hls"`
+	DASH      string     `json:"dash"`
+	Thumbnail string     `json:"thumbnail,omitempty"`
+	Signed    bool       `json:"signed"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cfstream
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+// MaxDurationSeconds is the longest video accepted by Cloudflare Stream.
+const MaxDurationSeconds = 6 * 60 * 60
+
+// MaxPlaybackExpiration bounds the validity of signed playback tokens in seconds.
+const MaxPlaybackExpiration = 24 * 60 * 60
+
+func (req CreateUploadURLRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Title, validation.Length(1, 255)),
+		validation.Field(&req.MaxDurationSeconds, validation.Min(0), validation.Max(MaxDurationSeconds)),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+	)
+}
+
+func (req PlaybackURLRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Expiration, validation.Min(int64(0)), validation.Max(int64(MaxPlaybackExpiration))),
+	)
+}
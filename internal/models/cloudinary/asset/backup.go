@@ -0,0 +1,29 @@
+package asset
+
+import "time"
+
+// BackupVersion is a version of a destroyed image kept by the Cloudinary backup.
+type BackupVersion struct {
+	VersionID  string    `json:"version_id"`
+	Version    int64     `json:"version"`
+	Format     string    `json:"format"`
+	Bytes      int64     `json:"bytes"`
+	CreatedAt  time.Time `json:"created_at"`
+	Restorable bool      `json:"restorable"`
+}
+
+// RestoreFromBackupRequest restores the file of an image destroyed in Cloudinary from its backup.
+type RestoreFromBackupRequest struct {
+	ID string `param:"id" json:"-"`
+	// VersionID is one of the backup versions of the asset details, the latest version is restored if it is empty.
+	VersionID string `json:"version_id"`
+	AdminID   string `json:"admin_id"`
+	AdminName string `json:"admin_name"`
+	Note      string `json:"note"`
+}
+
+// SetAdmin sets the admin recorded in the audit trail of the restore.
+func (req *RestoreFromBackupRequest) SetAdmin(id, name string) {
+	req.AdminID = id
+	req.AdminName = name
+}
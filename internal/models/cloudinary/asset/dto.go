@@ -26,10 +26,15 @@ const (
 type Details struct {
 	Asset    *Asset
 	Metadata *metamodel.AssetMetadata
+	// BackupVersions lists the restorable versions of an image destroyed in Cloudinary, it is only
+	// loaded for admins.
+	BackupVersions []*BackupVersion `json:",omitempty"`
 }
 
 type GetFilter struct {
 	ID string `param:"id" json:"-"`
+	// Fields is the field mask of the read, see the fieldmask package. Everything is loaded if it is empty.
+	Fields []string `query:"fields" json:"-"`
 }
 
 type ListRequest struct {
@@ -39,18 +44,136 @@ type ListRequest struct {
 
 	ResourceTypes []string `query:"resource_types" json:"-"`
 	Formats       []string `query:"formats" json:"-"`
+	// Tags matches assets having all the tags.
+	Tags []string `query:"tags" json:"-"`
+
+	// Query, CreatorID and Owned filter on the listing read model, assets written before it was
+	// populated are not matched. Query matches titles containing it, case-insensitively.
+	Query     string `query:"q" json:"-"`
+	CreatorID string `query:"creator_id" json:"-"`
+	// Owned matches the assets with owners if true and the assets without owners if false.
+	Owned *bool `query:"owned" json:"-"`
 
 	OrderDir   OrderDirection `query:"order_dir" json:"-"`
 	OrderField OrderField     `query:"order_field" json:"-"`
+	// Fields is the field mask of the read, see the fieldmask package. Everything is loaded if it is empty.
+	// Masks selecting only the metadata fields of [ListingFields] are served from the listing read model.
+	Fields []string `query:"fields" json:"-"`
+
+	PageSize  int    `query:"page_size" json:"-"`
+	PageToken string `query:"page_token" json:"-"`
+}
+
+// GetByOwnerRequest looks up the asset associated with an owner, e.g. the asset a product service
+// stored as the media of one of its entities.
+type GetByOwnerRequest struct {
+	OwnerType string `param:"owner_type" json:"-"`
+	OwnerID   string `param:"owner_id" json:"-"`
+}
+
+// ListByOwnerRequest lists the assets associated with a single owner, e.g. all images of a product.
+type ListByOwnerRequest struct {
+	OwnerID   string `query:"owner_id" json:"-"`
+	OwnerType string `query:"owner_type" json:"-"`
+
+	PageSize  int    `query:"page_size" json:"-"`
+	PageToken string `query:"page_token" json:"-"`
+}
+
+// ImageDeliveryRequest requests the delivery details of an image for the authenticated end user.
+type ImageDeliveryRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+// ImageDelivery holds the delivery URL of an image along with its dimensions.
+type ImageDelivery struct {
+	URL    string `json:"url"`
+	Format string `json:"format"`
+	Width  *int   `json:"width,omitempty"`
+	Height *int   `json:"height,omitempty"`
+}
+
+// ManageTagsRequest adds tags to or removes tags from an asset.
+type ManageTagsRequest struct {
+	ID   string   `param:"id" json:"-"`
+	Tags []string `json:"tags"`
+}
+
+// ListByCollectionRequest lists the assets of a collection.
+type ListByCollectionRequest struct {
+	CollectionID string `query:"collection_id" json:"-"`
+
+	PageSize  int    `query:"page_size" json:"-"`
+	PageToken string `query:"page_token" json:"-"`
+}
+
+// ListByTagRequest lists the active assets having a tag.
+type ListByTagRequest struct {
+	Tag string `query:"tag" json:"-"`
 
 	PageSize  int    `query:"page_size" json:"-"`
 	PageToken string `query:"page_token" json:"-"`
 }
 
+// SearchRequest searches the assets by the title and the enrichment data. Query matches a substring
+// of the title, the labels, or the extracted texts, Label matches a label exactly.
+type SearchRequest struct {
+	Query string `query:"q" json:"-"`
+	Label string `query:"label" json:"-"`
+
+	PageSize  int    `query:"page_size" json:"-"`
+	PageToken string `query:"page_token" json:"-"`
+}
+
+// UpdateMetadataRequest changes the title or the creator of an asset. Omitted fields are left unchanged.
+type UpdateMetadataRequest struct {
+	ID        string  `param:"id" json:"-"`
+	Title     *string `json:"title"`
+	CreatorID *string `json:"creator_id"`
+}
+
+// ManageOwnerRequest associates an owner with an asset or disassociates it.
+//
+// When adding an owner, ReplaceExisting moves the owner to the asset instead of failing if the
+// ownership policy allows it fewer assets: the owner is removed from all other assets it is associated
+// with first. ArchiveReplaced additionally archives the replaced assets left without owners, on behalf
+// of the admin identified by AdminID and AdminName.
 type ManageOwnerRequest struct {
 	ID        string `param:"id" json:"-"`
 	OwnerID   string `json:"owner_id"`
 	OwnerType string `json:"owner_type"`
+
+	ReplaceExisting bool   `json:"replace_existing"`
+	ArchiveReplaced bool   `json:"archive_replaced"`
+	AdminID         string `json:"admin_id"`
+	AdminName       string `json:"admin_name"`
+}
+
+// AssociateResult is the result of associating an owner with an asset. ReplacedAssetIDs lists the
+// assets the owner was removed from, ArchivedAssetIDs the replaced assets that were archived.
+type AssociateResult struct {
+	AssetID          string   `json:"asset_id"`
+	ReplacedAssetIDs []string `json:"replaced_asset_ids"`
+	ArchivedAssetIDs []string `json:"archived_asset_ids"`
+}
+
+// MaxOwnersPerRequest is the maximum number of owners an asset can be given in a single [UpdateOwnersRequest].
+const MaxOwnersPerRequest = 100
+
+// OwnerRef identifies an owner of an asset.
+type OwnerRef struct {
+	OwnerID   string `json:"owner_id"`
+	OwnerType string `json:"owner_type"`
+}
+
+// UpdateOwnersRequest replaces the owners of an asset. Owners missing from the list are removed,
+// new ones are added, an empty list removes all owners. Revision is the revision of the asset metadata
+// the owners were read at, the update is rejected if the metadata was changed since. Over HTTP it is
+// also taken from the If-Match header.
+type UpdateOwnersRequest struct {
+	ID       string      `param:"id" json:"-"`
+	Owners   []*OwnerRef `json:"owners"`
+	Revision *int64      `json:"revision"`
 }
 
 type CreateSignedUploadURLRequest struct {
@@ -60,6 +183,22 @@ type CreateSignedUploadURLRequest struct {
 	AdminID   string  `json:"admin_id"`
 	AdminName string  `json:"admin_name"`
 	Note      string  `json:"note"`
+	// OwnerType is the type of the owner the image is uploaded for, it selects the allowed formats.
+	OwnerType string `json:"owner_type"`
+	// Format, Bytes, Width and Height describe the file to upload. They are checked against the upload
+	// policy before the parameters are signed.
+	Format string `json:"format"`
+	Bytes  int64  `json:"bytes"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// UploadRequest uploads an image through the service. The file itself is streamed separately.
+type UploadRequest struct {
+	PublicID  string `form:"public_id"`
+	AdminID   string `form:"admin_id"`
+	AdminName string `form:"admin_name"`
+	Note      string `form:"note"`
 }
 
 type GeneratedSignedParams struct {
@@ -69,6 +208,13 @@ type GeneratedSignedParams struct {
 	Eager        *string `json:"eager,omitempty"`
 	PublicID     string  `json:"public_id"`
 	ResourceType string  `json:"resource_type,omitempty"`
+	// Moderation is the signed moderation parameter, which must be sent along with the upload.
+	Moderation *string `json:"moderation,omitempty"`
+	// Phash is the signed perceptual hash parameter, which must be sent along with the upload.
+	Phash bool `json:"phash"`
+	// AllowedFormats is the signed comma separated list of accepted formats, which must be sent along
+	// with the upload.
+	AllowedFormats *string `json:"allowed_formats,omitempty"`
 }
 
 type ChangeStateRequest struct {
@@ -78,6 +224,12 @@ type ChangeStateRequest struct {
 	Note      string `json:"note"`
 }
 
+// SetAdmin sets the admin recorded in the audit trail of the state change.
+func (req *ChangeStateRequest) SetAdmin(id, name string) {
+	req.AdminID = id
+	req.AdminName = name
+}
+
 // CloudinaryUploadWebhook represents Cloudinary API webhook triggered by an asset upload.
 type CloudinaryUploadWebhook struct {
 	NotificationType    string              `json:"notification_type"`
@@ -87,6 +239,7 @@ type CloudinaryUploadWebhook struct {
 	PublicID            string              `json:"public_id"`
 	Width               int                 `json:"width"`
 	Height              int                 `json:"height"`
+	Bytes               int64               `json:"bytes"`
 	Format              string              `json:"format"`
 	ResourceType        string              `json:"resource_type"`
 	CreatedAt           time.Time           `json:"created_at"`
@@ -95,6 +248,8 @@ type CloudinaryUploadWebhook struct {
 	SecureUrl           string              `json:"secure_url"`
 	AssetFolder         string              `json:"asset_folder"`
 	DisplayName         string              `json:"display_name"`
+	Etag                string              `json:"etag"`
+	Phash               string              `json:"phash"`
 	ApiKey              string              `json:"api_key"`
 	Context             *Context            `json:"context,omitempty"`
 	NotificationContext NotificationContext `json:"notification_context"`
@@ -159,3 +314,14 @@ type CloudinaryDeleteWebhook struct {
 	NotificationType string     `json:"notification_type"`
 	Resources        []Resource `json:"resources"`
 }
+
+// MaxStuckDeletionsLimit is the maximum number of assets reported by a single [ListStuckDeletionsRequest].
+const MaxStuckDeletionsLimit = 1000
+
+// ListStuckDeletionsRequest lists the assets pending deletion for longer than OlderThanMinutes.
+type ListStuckDeletionsRequest struct {
+	// OlderThanMinutes defaults to 60.
+	OlderThanMinutes int `query:"older_than_minutes"`
+	// Limit defaults to 100.
+	Limit int `query:"limit"`
+}
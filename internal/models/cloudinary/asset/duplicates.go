@@ -0,0 +1,33 @@
+package asset
+
+import "github.com/google/uuid"
+
+// DuplicateKind is the content hash duplicate images are grouped by.
+type DuplicateKind string
+
+const (
+	// DuplicateKindEtag groups byte-identical files by the MD5 checksum Cloudinary reports as etag.
+	DuplicateKindEtag DuplicateKind = "etag"
+	// DuplicateKindPhash groups visually identical images by their perceptual hash, e.g. the same
+	// photo uploaded again in another format or quality.
+	DuplicateKindPhash DuplicateKind = "phash"
+)
+
+// MaxDuplicateGroupsLimit is the maximum number of groups reported by a single [FindDuplicatesRequest].
+const MaxDuplicateGroupsLimit = 1000
+
+// FindDuplicatesRequest lists the groups of active assets sharing a content hash.
+type FindDuplicatesRequest struct {
+	// Kind defaults to etag.
+	Kind DuplicateKind `query:"kind"`
+	// Limit defaults to 100.
+	Limit int `query:"limit"`
+}
+
+// DuplicateGroup is a group of probable duplicates. The oldest asset goes first, it is usually the
+// one to keep.
+type DuplicateGroup struct {
+	Kind     DuplicateKind `json:"kind"`
+	Hash     string        `json:"hash"`
+	AssetIDs []uuid.UUID   `json:"asset_ids"`
+}
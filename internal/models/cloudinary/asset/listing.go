@@ -0,0 +1,58 @@
+package asset
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/models/cloudinary/metadata"
+)
+
+// Listing is the read model of the admin listings. It copies the metadata fields shown in the
+// listings next to the asset rows, so that they are loaded and filtered together with the assets
+// without a metadata round trip. The service updates it on every metadata write.
+type Listing struct {
+	AssetID    uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Title      string    `gorm:"type:varchar(256);not null"`
+	CreatorID  string    `gorm:"type:varchar(64);not null"`
+	OwnerCount int       `gorm:"not null"`
+	SyncedAt   time.Time `gorm:"not null"`
+}
+
+func (*Listing) TableName() string {
+	return "cloudinary_asset_listings"
+}
+
+// ListingFields are the metadata fields held by the listing. Listings selecting only these fields
+// are served from the read model.
+var ListingFields = []string{"title", "creator_id", "owner_count"}
+
+// NewListing returns the listing of the asset described by the metadata. The owner count is taken
+// from the owners if they were loaded.
+func NewListing(m *metadata.AssetMetadata) (*Listing, error) {
+	assetID, err := uuid.Parse(m.Key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata key %q: %w", m.Key, err)
+	}
+	ownerCount := m.OwnerCount
+	if m.Owners != nil {
+		ownerCount = len(m.Owners)
+	}
+	return &Listing{
+		AssetID:    assetID,
+		Title:      m.Title,
+		CreatorID:  m.CreatorID,
+		OwnerCount: ownerCount,
+		SyncedAt:   time.Now(),
+	}, nil
+}
+
+// Metadata returns the metadata fields held by the listing.
+func (l *Listing) Metadata() *metadata.AssetMetadata {
+	return &metadata.AssetMetadata{
+		Key:        l.AssetID.String(),
+		Title:      l.Title,
+		CreatorID:  l.CreatorID,
+		OwnerCount: l.OwnerCount,
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/models/publishing"
+	"github.com/mikhail5545/media-service-go/internal/tags"
 	"gorm.io/gorm"
 )
 
@@ -14,6 +16,18 @@ const (
 	StatusActive             Status = "active"
 	StatusArchived           Status = "archived"
 	StatusBroken             Status = "broken"
+	// StatusPendingDelete assets were permanently deleted by an admin, but the deletion from Cloudinary
+	// has not completed yet. They are not visible under any scope.
+	StatusPendingDelete Status = "pending_delete"
+)
+
+// ModerationStatus is the status of an image moderated by a Cloudinary moderation add-on or a reviewer.
+type ModerationStatus string
+
+const (
+	ModerationPending  ModerationStatus = "pending"
+	ModerationApproved ModerationStatus = "approved"
+	ModerationRejected ModerationStatus = "rejected"
 )
 
 type Asset struct {
@@ -22,23 +36,43 @@ type Asset struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 
+	// Tenant environment, stamped from the request context on creation. The Cloudinary identifiers are
+	// only unique within the environment, each environment is a separate product environment.
+	Environment string `gorm:"type:varchar(64);not null;default:'default';index;uniqueIndex:idx_cloudinary_assets_environment_cloudinary_asset_id,priority:1;uniqueIndex:idx_cloudinary_assets_environment_url,priority:1;uniqueIndex:idx_cloudinary_assets_environment_secure_url,priority:1;uniqueIndex:idx_cloudinary_assets_environment_cloudinary_public_id,priority:1" json:"environment"`
+
 	Status Status `gorm:"type:varchar(32);default:'active'" json:"status"`
 
-	CloudinaryAssetID  string   `gorm:"not null;uniqueIndex" json:"cloudinary_asset_id"` // External ID (Cloudinary ID), parsed from webhooks
-	URL                string   `gorm:"uniqueIndex" json:"url"`
-	SecureURL          string   `gorm:"uniqueIndex" json:"secure_url"`
-	CloudinaryPublicID string   `gorm:"type:varchar(512);not null;uniqueIndex" json:"cloudinary_public_id"` // External ID (Cloudinary public ID for asset), used in most of Cloudinary API interactions
-	ResourceType       string   `gorm:"type:varchar(128)" json:"resource_type"`
-	Format             string   `gorm:"type:varchar(32)" json:"format"`   // Asset format (png, jpeg, jpc, etc.), parsed from webhooks
-	Width              *int     `gorm:"null" json:"width"`                // Width for images, parsed from webhooks
-	Height             *int     `gorm:"null" json:"height"`               // Height for images, parsed from webhooks
-	Tags               []string `gorm:"type:varchar(128)[]" json:"tags"`  // Tags, generated by Cloudinary
-	AssetFolder        string   `gorm:"varchar(128)" json:"asset_folder"` // Asset folder in the Cloudinary, parsed from webhooks
-	DisplayName        string   `gorm:"varchar(255)" json:"display_name"` // Asset's display name, parsed from webhooks
+	CloudinaryAssetID  string     `gorm:"not null;uniqueIndex:idx_cloudinary_assets_environment_cloudinary_asset_id,priority:2" json:"cloudinary_asset_id"` // External ID (Cloudinary ID), parsed from webhooks
+	URL                string     `gorm:"uniqueIndex:idx_cloudinary_assets_environment_url,priority:2" json:"url"`
+	SecureURL          string     `gorm:"uniqueIndex:idx_cloudinary_assets_environment_secure_url,priority:2" json:"secure_url"`
+	CloudinaryPublicID string     `gorm:"type:varchar(512);not null;uniqueIndex:idx_cloudinary_assets_environment_cloudinary_public_id,priority:2" json:"cloudinary_public_id"` // External ID (Cloudinary public ID for asset), used in most of Cloudinary API interactions
+	ResourceType       string     `gorm:"type:varchar(128)" json:"resource_type"`
+	Format             string     `gorm:"type:varchar(32)" json:"format"`   // Asset format (png, jpeg, jpc, etc.), parsed from webhooks
+	Width              *int       `gorm:"null" json:"width"`                // Width for images, parsed from webhooks
+	Height             *int       `gorm:"null" json:"height"`               // Height for images, parsed from webhooks
+	Bytes              *int64     `gorm:"null" json:"bytes"`                // Size of the original in bytes, parsed from webhooks
+	Tags               tags.Array `gorm:"type:varchar(128)[]" json:"tags"`  // Tags, generated by Cloudinary
+	AssetFolder        string     `gorm:"varchar(128)" json:"asset_folder"` // Asset folder in the Cloudinary, parsed from webhooks
+	DisplayName        string     `gorm:"varchar(255)" json:"display_name"` // Asset's display name, parsed from webhooks
+
+	Etag  *string `gorm:"type:varchar(64);null;index" json:"etag,omitempty"`  // MD5 checksum of the stored file, parsed from webhooks
+	Phash *string `gorm:"type:varchar(64);null;index" json:"phash,omitempty"` // Perceptual hash of the image, parsed from webhooks
+
+	ModerationStatus *ModerationStatus `gorm:"type:varchar(16);null;index" json:"moderation_status"` // Null when the image is not moderated
+	ModerationKind   *string           `gorm:"type:varchar(64);null" json:"moderation_kind"`         // Moderation add-on, e.g. manual or aws_rek
 
 	Note          *string `gorm:"type:varchar(512);null" json:"note"`           // Optional note about the asset
 	ArchiveReason *string `gorm:"type:varchar(512);null" json:"archive_reason"` // Optional reason for archiving the asset
 
+	DeleteRequestedAt *time.Time `gorm:"null" json:"delete_requested_at"` // Moment the asset was marked as pending deletion
+
+	CloudinaryDestroyedAt *time.Time `gorm:"null" json:"cloudinary_destroyed_at"` // Moment the file was destroyed in Cloudinary, parsed from delete webhooks
+	BackupAvailable       *bool      `gorm:"null" json:"backup_available"`        // Whether Cloudinary keeps a backup of the destroyed file, null until checked
+
+	PublishingState publishing.State `gorm:"type:varchar(16);not null;default:'published';index" json:"publishing_state"` // Updated by the publishing worker, the delivery checks the schedule itself
+	PublishAt       *time.Time       `gorm:"null" json:"publish_at"`                                                      // Time the image becomes deliverable to end users, null if not scheduled
+	UnpublishAt     *time.Time       `gorm:"null" json:"unpublish_at"`                                                    // Time the image stops being deliverable to end users, null if not scheduled
+
 	CreatedBy        *uuid.UUID `gorm:"type:uuid;null" json:"created_by"`          // Admin ID who created the asset
 	ArchivedBy       *uuid.UUID `gorm:"type:uuid;null" json:"archived_by"`         // Admin ID who archived the asset
 	MarkedAsBrokenBy *uuid.UUID `gorm:"type:uuid;null" json:"marked_as_broken_by"` // Admin ID who marked the asset as broken
@@ -50,6 +84,13 @@ type Asset struct {
 	RestoredByName       *string `gorm:"type:varchar(128);null" json:"restored_by_name"`         // Admin name who restored the asset
 
 	ArchiveNotificationContextID *string `gorm:"type:varchar(256);null" json:"archive_notification_context_id"` // Notification context ID from webhook when asset was archived
+
+	// OwnershipSnapshot holds the owners the asset had before they were removed, e.g. when the image
+	// was rejected in moderation or deleted in Cloudinary. It is cleared when the owners are restored.
+	OwnershipSnapshot *OwnershipSnapshot `gorm:"type:jsonb;null" json:"ownership_snapshot,omitempty"`
+
+	// Listing is only loaded by listings served from the read model.
+	Listing *Listing `gorm:"foreignKey:AssetID" json:"-"`
 }
 
 func (*Asset) TableName() string {
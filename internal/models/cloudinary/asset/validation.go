@@ -6,19 +6,27 @@ import (
 	"sync"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	metamodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/metadata"
+	"github.com/mikhail5545/media-service-go/internal/ownertypes"
+	"github.com/mikhail5545/media-service-go/internal/tags"
 	"github.com/mikhail5545/media-service-go/internal/util/formatting"
 	"github.com/mikhail5545/media-service-go/internal/util/parsing"
 	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
 )
 
+// OwnerTypes is the registry of owner types Cloudinary assets can be associated with. It is replaced
+// with the configured owner types on startup.
+var OwnerTypes = ownertypes.NewRegistry("product")
+
 func (req GetFilter) Validate() error {
-	return validation.ValidateStruct(&req,
+	return validationutil.ValidateStruct(&req,
 		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Fields, fieldsRule),
 	)
 }
 
 func (req ListRequest) Validate() error {
-	return validation.ValidateStruct(&req,
+	return validationutil.ValidateStruct(&req,
 		validation.Field(&req.IDs, validation.Each(validationutil.UUIDRule(false)...)),
 		validation.Field(&req.CloudinaryAssetIDs, validation.Each(validation.Length(1, 255))),
 		validation.Field(&req.CloudinaryPublicIDs, validation.Each(validation.Length(1, 255))),
@@ -28,15 +36,27 @@ func (req ListRequest) Validate() error {
 		validation.Field(&req.Formats, validation.Each(validation.Length(2, 20),
 			validation.In("img", "jpg", "png", "mp4", "mov", "pdf", "docx", "zip")),
 		),
+		validation.Field(&req.Tags, validation.Each(validation.Length(1, tags.MaxLength))),
+		validation.Field(&req.Query, validation.Length(1, 256)),
+		validation.Field(&req.CreatorID, validationutil.UUIDRule(false)...),
 		validation.Field(&req.OrderField, validation.In(OrderCreatedAt, OrderUpdatedAt, OrderFormat, OrderResourceType)),
 		validation.Field(&req.OrderDir, validation.In(OrderAscending, OrderDescending)),
+		validation.Field(&req.Fields, fieldsRule),
 		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
 		validation.Field(&req.PageToken, validation.Length(1, 2048)),
 	)
 }
 
+func (req ListByCollectionRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.CollectionID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
+		validation.Field(&req.PageToken, validationutil.UUIDRule(false)...),
+	)
+}
+
 func (req ChangeStateRequest) Validate() error {
-	return validation.ValidateStruct(&req,
+	return validationutil.ValidateStruct(&req,
 		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
 		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
 		validation.Field(&req.AdminName, validation.Length(1, 128)),
@@ -44,22 +64,125 @@ func (req ChangeStateRequest) Validate() error {
 	)
 }
 
+func (req GetByOwnerRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.OwnerID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.OwnerType, validation.Required, validation.Length(1, 50), OwnerTypes.Rule()),
+	)
+}
+
+func (req ListByOwnerRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.OwnerID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.OwnerType, validation.Required, validation.Length(1, 50), OwnerTypes.Rule()),
+		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
+		validation.Field(&req.PageToken, validationutil.UUIDRule(false)...),
+	)
+}
+
+func (req ManageTagsRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Tags, validation.Required, validation.Length(1, tags.MaxPerAsset), tags.Rule()),
+	)
+}
+
+func (req ImageDeliveryRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
+
+func (req ListByTagRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Tag, validation.Required, validation.Length(1, tags.MaxLength)),
+		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
+		validation.Field(&req.PageToken, validation.Length(1, 2048)),
+	)
+}
+
+func (req SearchRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Query, validation.Required.When(req.Label == "").Error("q or label is required"), validation.Length(2, 256)),
+		validation.Field(&req.Label, validation.Length(1, 128)),
+		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
+		validation.Field(&req.PageToken, validation.Length(1, 2048)),
+	)
+}
+
+func (req UpdateMetadataRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Title, validation.Required.When(req.CreatorID == nil).Error("title or creator_id is required"), validation.Length(1, 256)),
+		validation.Field(&req.CreatorID, validationutil.UUIDRule(false)...),
+	)
+}
+
 func (req ManageOwnerRequest) Validate() error {
-	return validation.ValidateStruct(&req,
+	return validationutil.ValidateStruct(&req,
 		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
 		validation.Field(&req.OwnerID, validationutil.UUIDRule(true)...),
-		validation.Field(&req.OwnerType, validation.Required, validation.Length(1, 50), validation.In("product")),
+		validation.Field(&req.OwnerType, validation.Required, validation.Length(1, 50), OwnerTypes.Rule()),
+		validation.Field(&req.ArchiveReplaced, validation.When(!req.ReplaceExisting, validation.Empty.Error("requires replace_existing"))),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(req.ArchiveReplaced)...),
+		validation.Field(&req.AdminName, validation.When(req.ArchiveReplaced, validation.Required), validation.Length(1, 128)),
 	)
 }
 
+func (ref OwnerRef) Validate() error {
+	return validationutil.ValidateStruct(&ref,
+		validation.Field(&ref.OwnerID, validationutil.UUIDRule(true)...),
+		validation.Field(&ref.OwnerType, validation.Required, validation.Length(1, 50), OwnerTypes.Rule()),
+	)
+}
+
+func (req UpdateOwnersRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Owners,
+			validation.Length(0, MaxOwnersPerRequest),
+			validation.Each(validation.NotNil),
+			validation.By(uniqueOwners),
+		),
+		validation.Field(&req.Revision, validation.NotNil, validation.Min(int64(0))),
+	)
+}
+
+// uniqueOwners rejects owner lists that mention the same owner more than once.
+func uniqueOwners(value any) error {
+	owners, _ := value.([]*OwnerRef)
+	seen := make(map[OwnerRef]struct{}, len(owners))
+	for _, owner := range owners {
+		if _, ok := seen[*owner]; ok {
+			return validation.NewError("validation_owners_unique", "must not contain duplicate owners")
+		}
+		seen[*owner] = struct{}{}
+	}
+	return nil
+}
+
 func (req CreateSignedUploadURLRequest) Validate() error {
-	return validation.ValidateStruct(&req,
+	return validationutil.ValidateStruct(&req,
 		validation.Field(&req.File, validation.Required, validation.Length(3, 0)),
 		validation.Field(&req.PublicID, validation.Required, validation.Length(3, 1024)),
 		validation.Field(&req.Eager, validation.Length(0, 255)),
 		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
 		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
 		validation.Field(&req.Note, validation.Length(0, 512)),
+		validation.Field(&req.OwnerType, validation.Length(0, 50), OwnerTypes.Rule()),
+		validation.Field(&req.Format, validation.Length(0, 20)),
+		validation.Field(&req.Bytes, validation.Min(int64(0))),
+		validation.Field(&req.Width, validation.Min(0)),
+		validation.Field(&req.Height, validation.Min(0)),
+	)
+}
+
+func (req UploadRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.PublicID, validation.Required, validation.Length(3, 1024)),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+		validation.Field(&req.Note, validation.Length(0, 512)),
 	)
 }
 
@@ -71,11 +194,15 @@ var (
 // ValidFields returns all valid field names for the Asset struct.
 func ValidFields() map[string]bool {
 	validFieldsOnce.Do(func() {
-		validFields := make(map[string]bool)
+		validFields = make(map[string]bool)
 		t := reflect.TypeOf(Asset{})
 
 		for i := 0; i < t.NumField(); i++ {
 			field := t.Field(i)
+			// Relations are not columns of the asset record.
+			if field.Tag.Get("json") == "-" {
+				continue
+			}
 			if tag := field.Tag.Get("gorm"); tag != "" {
 				if col := parsing.ParseColumnTag(tag); col != "" {
 					validFields[col] = true
@@ -88,7 +215,87 @@ func ValidFields() map[string]bool {
 	return validFields
 }
 
+// fieldsRule validates the field masks of the Get and List requests.
+var fieldsRule = validationutil.FieldMaskRule(IsValidField, metamodel.IsValidField)
+
 // IsValidField checks if a field name is valid for selection.
 func IsValidField(field string) bool {
 	return ValidFields()[field]
 }
+
+func (req ListStuckDeletionsRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.OlderThanMinutes, validation.Min(0), validation.Max(7*24*60)),
+		validation.Field(&req.Limit, validation.Min(0), validation.Max(MaxStuckDeletionsLimit)),
+	)
+}
+
+func (req FindDuplicatesRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Kind, validation.In(DuplicateKindEtag, DuplicateKindPhash)),
+		validation.Field(&req.Limit, validation.Min(0), validation.Max(MaxDuplicateGroupsLimit)),
+	)
+}
+
+func (req ValidateAssetsRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.IDs,
+			validation.Required,
+			validation.Length(1, MaxValidateAssets),
+			validation.Each(validationutil.UUIDRule(true)...),
+		),
+	)
+}
+
+func (req RestoreFromBackupRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.VersionID, validation.Length(1, 128)),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Length(1, 128)),
+		validation.Field(&req.Note, validation.Length(10, 512)),
+	)
+}
+
+func (req ListVersionsRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.OwnerID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.OwnerType, validation.Required, OwnerTypes.Rule()),
+	)
+}
+
+func (req RollbackVersionRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.OwnerID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.OwnerType, validation.Required, OwnerTypes.Rule()),
+		validation.Field(&req.Number, validation.Required, validation.Min(1)),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(req.ArchiveCurrent)...),
+		validation.Field(&req.AdminName, validation.When(req.ArchiveCurrent, validation.Required), validation.Length(1, 128)),
+	)
+}
+
+func (req SchedulePublishingRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.PublishAt, validation.When(req.UnpublishAt == nil, validation.Required.Error("publish_at or unpublish_at is required"))),
+		validation.Field(&req.UnpublishAt, validation.When(req.PublishAt != nil && req.UnpublishAt != nil,
+			validation.By(func(any) error {
+				if !req.UnpublishAt.After(*req.PublishAt) {
+					return validation.NewError("validation_unpublish_at_order", "must be after publish_at")
+				}
+				return nil
+			}))),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+		validation.Field(&req.Note, validation.Length(0, 512)),
+	)
+}
+
+func (req CancelPublishingRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+		validation.Field(&req.Note, validation.Length(0, 512)),
+	)
+}
@@ -0,0 +1,52 @@
+package asset
+
+import (
+	"github.com/google/uuid"
+)
+
+// MaxValidateAssets is the maximum number of assets checked by a single [ValidateAssetsRequest].
+const MaxValidateAssets = 500
+
+// Validity is the state of an asset referenced by another service.
+type Validity string
+
+const (
+	ValidityActive Validity = "active"
+	// ValidityPending assets have an upload URL but were never uploaded.
+	ValidityPending  Validity = "pending"
+	ValidityArchived Validity = "archived"
+	ValidityBroken   Validity = "broken"
+	// ValidityDeleted assets were permanently deleted, but the deletion has not completed yet.
+	ValidityDeleted Validity = "deleted"
+	// ValidityMissing assets do not exist, they were never created or their deletion has completed.
+	ValidityMissing Validity = "missing"
+)
+
+// ValidateAssetsRequest checks that the assets referenced by another service still exist and are healthy.
+type ValidateAssetsRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// AssetValidity is the state of a single asset of a [ValidateAssetsRequest].
+type AssetValidity struct {
+	ID       uuid.UUID `json:"id"`
+	Validity Validity  `json:"validity"`
+}
+
+// ValidityOf returns the validity of an existing asset with the status.
+func ValidityOf(status Status) Validity {
+	switch status {
+	case StatusActive:
+		return ValidityActive
+	case StatusUploadURLGenerated:
+		return ValidityPending
+	case StatusArchived:
+		return ValidityArchived
+	case StatusBroken:
+		return ValidityBroken
+	case StatusPendingDelete:
+		return ValidityDeleted
+	default:
+		return ValidityMissing
+	}
+}
@@ -1,14 +1,52 @@
 package metadata
 
-// AssetMetadata represents the metadata for a Cloudinary asset stored in ArangoDB.
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/mikhail5545/media-service-go/internal/models/enrichment"
+)
+
+// AssetMetadata represents the metadata for a Cloudinary asset stored in MongoDB.
 type AssetMetadata struct {
 	// The _key field will be internal asset ID from PostgreSQL database.
-	Key    string   `json:"_key,omitempty"`
-	Owners []*Owner `json:"owners"`
+	Key       string   `bson:"_id,omitempty" json:"_key,omitempty"`
+	Title     string   `bson:"title,omitempty" json:"title,omitempty"`
+	CreatorID string   `bson:"creator_id,omitempty" json:"creator_id,omitempty"`
+	Owners    []*Owner `bson:"owners" json:"owners"`
+	// Enrichment is set by the enrichment pipeline once the image is uploaded.
+	Enrichment *enrichment.Enrichment `bson:"enrichment,omitempty" json:"enrichment,omitempty"`
+	// Revision is incremented by every change of the metadata, it guards the owners against concurrent
+	// updates. Metadata written before revisions were introduced has revision 0.
+	Revision int64 `bson:"revision" json:"revision"`
+	// OwnerCount is the number of owners. It is maintained by the repository on every write, so the
+	// unowned assets can be looked up by index.
+	OwnerCount int `bson:"owner_count" json:"owner_count"`
 }
 
 // Owner represents an entity that is associated with an asset.
 type Owner struct {
-	OwnerID   string `json:"owner_id"`
-	OwnerType string `json:"owner_type"`
+	OwnerID   string `bson:"owner_id" json:"owner_id"`
+	OwnerType string `bson:"owner_type" json:"owner_type"`
+}
+
+var (
+	validFields     map[string]bool
+	validFieldsOnce sync.Once
+)
+
+// IsValidField checks if a field name is valid for projection. Nested fields are checked by their
+// first segment, e.g. "owners.owner_type".
+func IsValidField(field string) bool {
+	validFieldsOnce.Do(func() {
+		validFields = make(map[string]bool)
+		t := reflect.TypeOf(AssetMetadata{})
+		for i := 0; i < t.NumField(); i++ {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("bson"), ",")
+			validFields[name] = true
+		}
+	})
+	name, _, _ := strings.Cut(field, ".")
+	return validFields[name]
 }
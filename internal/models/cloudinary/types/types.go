@@ -61,3 +61,71 @@ type CloudinaryRenameWebhook struct {
 	NotificationContext NotificationContext `json:"notification_context"`
 	SignatureKey        string              `json:"signature_key"`
 }
+
+// EagerTransformation represents a derived asset generated by an eager transformation.
+type EagerTransformation struct {
+	Transformation string `json:"transformation"`
+	Width          int    `json:"width"`
+	Height         int    `json:"height"`
+	Bytes          int64  `json:"bytes"`
+	URL            string `json:"url"`
+	SecureURL      string `json:"secure_url"`
+}
+
+// CloudinaryEagerWebhook represents Cloudinary API webhook triggered when asynchronous eager transformations are completed.
+type CloudinaryEagerWebhook struct {
+	NotificationType    string                `json:"notification_type"`
+	Timestamp           time.Time             `json:"timestamp"`
+	RequestID           string                `json:"request_id"`
+	BatchID             string                `json:"batch_id"`
+	AssetID             string                `json:"asset_id"`
+	PublicID            string                `json:"public_id"`
+	Eager               []EagerTransformation `json:"eager"`
+	NotificationContext NotificationContext   `json:"notification_context"`
+	SignatureKey        string                `json:"signature_key"`
+}
+
+// CloudinaryModerationWebhook represents Cloudinary API webhook triggered when the moderation status of an asset changes.
+type CloudinaryModerationWebhook struct {
+	NotificationType    string              `json:"notification_type"`
+	Timestamp           time.Time           `json:"timestamp"`
+	RequestID           string              `json:"request_id"`
+	AssetID             string              `json:"asset_id"`
+	PublicID            string              `json:"public_id"`
+	ModerationKind      string              `json:"moderation_kind"`
+	ModerationStatus    string              `json:"moderation_status"`
+	ModerationUpdatedAt time.Time           `json:"moderation_updated_at"`
+	NotificationContext NotificationContext `json:"notification_context"`
+	SignatureKey        string              `json:"signature_key"`
+}
+
+// TagsChange represents tags change of a single asset in [CloudinaryTagsChangedWebhook].
+type TagsChange struct {
+	Resource
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// CloudinaryTagsChangedWebhook represents Cloudinary API webhook triggered by adding or removing asset tags.
+type CloudinaryTagsChangedWebhook struct {
+	NotificationType    string              `json:"notification_type"`
+	Source              string              `json:"source"`
+	Resources           []TagsChange        `json:"resources"`
+	NotificationContext NotificationContext `json:"notification_context"`
+	SignatureKey        string              `json:"signature_key"`
+}
+
+// DisplayNameChange represents display name change of a single asset in [CloudinaryDisplayNameChangedWebhook].
+type DisplayNameChange struct {
+	Resource
+	NewDisplayName string `json:"new_display_name"`
+}
+
+// CloudinaryDisplayNameChangedWebhook represents Cloudinary API webhook triggered by an asset display name change.
+type CloudinaryDisplayNameChangedWebhook struct {
+	NotificationType    string              `json:"notification_type"`
+	Source              string              `json:"source"`
+	Resources           []DisplayNameChange `json:"resources"`
+	NotificationContext NotificationContext `json:"notification_context"`
+	SignatureKey        string              `json:"signature_key"`
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package collection
+
+// GetRequest retrieves a single collection.
+type GetRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+// ListRequest lists collections, newest first.
+type ListRequest struct {
+	PageSize  int    `query:"page_size"`
+	PageToken string `query:"page_token"`
+}
+
+// CreateRequest creates a new collection. Collection names are unique.
+type CreateRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+}
+
+// UpdateRequest changes the name or the description of a collection. Omitted fields are left unchanged.
+type UpdateRequest struct {
+	ID          string  `param:"id" json:"-"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+// DeleteRequest deletes a collection. The assets of the collection are left untouched.
+type DeleteRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+// ManageAssetsRequest adds assets of a single provider to a collection or removes them from it.
+type ManageAssetsRequest struct {
+	ID       string   `param:"id" json:"-"`
+	Provider Provider `json:"provider"`
+	AssetIDs []string `json:"asset_ids"`
+}
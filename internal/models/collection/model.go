@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package collection provides models for named collections which group MUX and Cloudinary assets,
+// e.g. all media of a course.
+package collection
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Provider identifies the asset type of a collection member.
+type Provider string
+
+const (
+	ProviderMux        Provider = "mux"
+	ProviderCloudinary Provider = "cloudinary"
+)
+
+// Collection is a named group of assets. An asset may belong to any number of collections.
+type Collection struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid" json:"id"` // UUIDv7
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Name        string  `gorm:"type:varchar(255);not null;uniqueIndex" json:"name"`
+	Description *string `gorm:"type:varchar(1024);null" json:"description,omitempty"`
+}
+
+func (*Collection) TableName() string {
+	return "collections"
+}
+
+func (c *Collection) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID, err = uuid.NewV7()
+	}
+	return err
+}
+
+// Member links an asset of the provider to a collection.
+type Member struct {
+	CollectionID uuid.UUID `gorm:"primaryKey;type:uuid" json:"collection_id"`
+	Provider     Provider  `gorm:"primaryKey;type:varchar(32)" json:"provider"`
+	AssetID      uuid.UUID `gorm:"primaryKey;type:uuid" json:"asset_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (*Member) TableName() string {
+	return "collection_assets"
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package collection
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+// MaxAssetsPerRequest limits the number of assets added to or removed from a collection at once.
+const MaxAssetsPerRequest = 100
+
+func (req GetRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
+
+func (req ListRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
+		validation.Field(&req.PageToken, validation.Length(1, 2048)),
+	)
+}
+
+func (req CreateRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&req.Description, validation.Length(1, 1024)),
+	)
+}
+
+func (req UpdateRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Name, validation.Required.When(req.Description == nil).Error("name or description is required"), validation.Length(1, 255)),
+		validation.Field(&req.Description, validation.Length(0, 1024)),
+	)
+}
+
+func (req DeleteRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
+
+func (req ManageAssetsRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Provider, validation.Required, validation.In(ProviderMux, ProviderCloudinary)),
+		validation.Field(&req.AssetIDs,
+			validation.Required,
+			validation.Length(1, MaxAssetsPerRequest),
+			validation.Each(validationutil.UUIDRule(true)...),
+		),
+	)
+}
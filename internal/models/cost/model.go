@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+// Package cost provides models for the monthly cost estimates of the assets, attributed to their
+// creators and owner types.
+package cost
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	usagemodel "github.com/mikhail5545/media-service-go/internal/models/usage"
+)
+
+// Rates are the prices of the providers in the currency of the report.
+type Rates struct {
+	// MuxEncodingPerMinute is charged once for each minute of video encoded in the month.
+	MuxEncodingPerMinute float64
+	// MuxStoragePerMinute is charged for each minute of video stored for a whole month.
+	MuxStoragePerMinute float64
+	// MuxStreamingPerMinute is charged for each minute of video played.
+	MuxStreamingPerMinute float64
+	// MuxResolutionMultipliers scale the MUX rates of the videos by their resolution tier, e.g.
+	// "2160p": 2. Tiers without a multiplier are charged the rates.
+	MuxResolutionMultipliers map[string]float64
+	// CloudinaryStoragePerGB is charged for each GB stored for a whole month.
+	CloudinaryStoragePerGB float64
+	// CloudinaryBandwidthPerGB is charged for each GB delivered.
+	CloudinaryBandwidthPerGB float64
+}
+
+// MuxMultiplier returns the multiplier of the MUX rates of a video with the resolution tier.
+func (r *Rates) MuxMultiplier(resolutionTier *string) float64 {
+	if resolutionTier == nil {
+		return 1
+	}
+	if multiplier, ok := r.MuxResolutionMultipliers[*resolutionTier]; ok {
+		return multiplier
+	}
+	return 1
+}
+
+// Cost is the estimated cost of a group of assets in a month. The minutes are only accounted for MUX
+// videos, the GB only for Cloudinary images.
+type Cost struct {
+	// CreatorID is nil for assets created before the creator was recorded.
+	CreatorID *uuid.UUID `json:"creator_id,omitempty"`
+	OwnerType string     `json:"owner_type,omitempty"`
+	Assets    int64      `json:"assets"`
+
+	// EncodedMinutes are the minutes of the videos created in the month.
+	EncodedMinutes float64 `json:"encoded_minutes"`
+	// StoredMinutes are the minutes of video stored, prorated by the part of the month they were stored for.
+	StoredMinutes float64 `json:"stored_minutes"`
+	// StreamedMinutes are the minutes played in the month, as reported by MUX Data.
+	StreamedMinutes float64 `json:"streamed_minutes"`
+	// StoredGB are the GB of images stored, prorated by the part of the month they were stored for.
+	StoredGB float64 `json:"stored_gb"`
+	// DeliveredGB is the share of the Cloudinary bandwidth of the month attributed to the images.
+	DeliveredGB float64 `json:"delivered_gb"`
+
+	Encoding float64 `json:"encoding"`
+	Storage  float64 `json:"storage"`
+	// Delivery is the cost of the MUX streaming or the Cloudinary bandwidth.
+	Delivery float64 `json:"delivery"`
+	Total    float64 `json:"total"`
+}
+
+// Add adds the usage and the costs of o.
+func (c *Cost) Add(o *Cost) {
+	c.Assets += o.Assets
+	c.EncodedMinutes += o.EncodedMinutes
+	c.StoredMinutes += o.StoredMinutes
+	c.StreamedMinutes += o.StreamedMinutes
+	c.StoredGB += o.StoredGB
+	c.DeliveredGB += o.DeliveredGB
+	c.Encoding += o.Encoding
+	c.Storage += o.Storage
+	c.Delivery += o.Delivery
+	c.Total += o.Total
+}
+
+// AssetUsage is what a single asset is charged for. MuxAssetID, Duration and ResolutionTier are only
+// set for MUX videos, Bytes only for Cloudinary images. DeletedAt is set once the asset is archived or deleted.
+type AssetUsage struct {
+	ID             uuid.UUID
+	CreatorID      *uuid.UUID
+	CreatedAt      time.Time
+	DeletedAt      *time.Time
+	MuxAssetID     *string
+	ResolutionTier *string
+	// Duration is the duration in seconds.
+	Duration float64
+	Bytes    int64
+}
+
+// ProviderReport is the estimated cost of the assets of a provider aggregated by creator and by owner type.
+type ProviderReport struct {
+	Provider    usagemodel.Provider `json:"provider"`
+	Total       *Cost               `json:"total"`
+	ByCreator   []*Cost             `json:"by_creator"`
+	ByOwnerType []*Cost             `json:"by_owner_type"`
+}
+
+// Report is the estimated cost of a month. Costs are estimated from the stored asset details and the
+// analytics of the providers with the configured rates, they are not the invoiced amounts.
+type Report struct {
+	// Month is the reported month, e.g. "2026-09".
+	Month       string            `json:"month"`
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	Currency    string            `json:"currency"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	Total       *Cost             `json:"total"`
+	Providers   []*ProviderReport `json:"providers"`
+	// Warnings name the costs which could not be estimated, e.g. because the analytics of a provider
+	// were not available.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ReportRequest requests the cost report of a month.
+type ReportRequest struct {
+	// Month is the reported month in the YYYY-MM format, e.g. "2026-09".
+	Month string `query:"month"`
+}
+
+// MonthLayout is the layout of [ReportRequest.Month].
+const MonthLayout = "2006-01"
+
+// Timeframe returns the start of the month and the start of the next one in UTC. The request must be valid.
+func (req *ReportRequest) Timeframe() (time.Time, time.Time) {
+	from, _ := time.Parse(MonthLayout, req.Month)
+	return from, from.AddDate(0, 1, 0)
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package enrichment provides the model of the labels and texts derived from the asset content by the
+// provider, which are stored in the asset metadata and used by the asset search.
+package enrichment
+
+import "time"
+
+// Enrichment holds the data derived from the asset content. Images get labels, dominant colors and
+// OCR text, videos get the transcript of the generated subtitles.
+type Enrichment struct {
+	Labels []string `bson:"labels,omitempty" json:"labels,omitempty"`
+	// DominantColors are hex color codes ordered by their share of the image.
+	DominantColors []string  `bson:"dominant_colors,omitempty" json:"dominant_colors,omitempty"`
+	OCRText        string    `bson:"ocr_text,omitempty" json:"ocr_text,omitempty"`
+	Transcript     string    `bson:"transcript,omitempty" json:"transcript,omitempty"`
+	EnrichedAt     time.Time `bson:"enriched_at" json:"enriched_at"`
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package export
+
+// CreateJobRequest starts an export. Columns default to [Columns] and Providers to all backends.
+// Archived assets are written only when IncludeDeleted is set.
+type CreateJobRequest struct {
+	Format         Format   `json:"format"`
+	Columns        []string `json:"columns"`
+	Providers      []string `json:"providers"`
+	IncludeDeleted bool     `json:"include_deleted"`
+}
+
+type GetJobRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+// ListJobsRequest lists the export jobs of the instance, newest first. Status restricts the
+// listing to jobs in that state.
+type ListJobsRequest struct {
+	Status Status `query:"status"`
+}
+
+// File is the completed file of an export job.
+type File struct {
+	// Path is the location of the file on the local disk.
+	Path        string
+	Name        string
+	ContentType string
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package export provides models for the asset inventory exports, which write the assets of all
+// backends to a downloadable CSV or NDJSON file.
+package export
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Format is the file format of an export.
+type Format string
+
+const (
+	FormatCSV Format = "csv"
+	// FormatNDJSON writes one JSON object per line.
+	FormatNDJSON Format = "ndjson"
+)
+
+// Extension returns the file name extension of the format.
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	if f == FormatNDJSON {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+// Status is the state of an export job.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// Columns of an export, in the order they are written by default.
+const (
+	ColumnID         = "id"
+	ColumnProvider   = "provider"
+	ColumnKind       = "kind"
+	ColumnStatus     = "status"
+	ColumnTitle      = "title"
+	ColumnExternalID = "external_id"
+	ColumnOwners     = "owners"
+	ColumnTags       = "tags"
+	ColumnCreatedAt  = "created_at"
+	ColumnUpdatedAt  = "updated_at"
+	ColumnDeletedAt  = "deleted_at"
+)
+
+// Columns lists all export columns in the default order.
+var Columns = []string{
+	ColumnID,
+	ColumnProvider,
+	ColumnKind,
+	ColumnStatus,
+	ColumnTitle,
+	ColumnExternalID,
+	ColumnOwners,
+	ColumnTags,
+	ColumnCreatedAt,
+	ColumnUpdatedAt,
+	ColumnDeletedAt,
+}
+
+// Job describes an export. Jobs are kept in memory of the instance which created them.
+type Job struct {
+	ID             uuid.UUID `json:"id"`
+	Status         Status    `json:"status"`
+	Format         Format    `json:"format"`
+	Columns        []string  `json:"columns"`
+	Providers      []string  `json:"providers"`
+	IncludeDeleted bool      `json:"include_deleted"`
+	// Rows is the number of assets written so far.
+	Rows int64 `json:"rows"`
+	// Size is the size of the completed file in bytes.
+	Size int64 `json:"size,omitempty"`
+	// Error describes why a failed job failed.
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// ExpiresAt is the moment the job and its file are discarded, it is set once the job finishes.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// FileName returns the name the file of the job is downloaded as.
+func (j *Job) FileName() string {
+	return "assets-" + j.ID.String() + j.Format.Extension()
+}
+
+// Owner is an external entity an exported asset is associated with, e.g. a lesson.
+type Owner struct {
+	OwnerID   string `json:"owner_id"`
+	OwnerType string `json:"owner_type"`
+}
+
+// Row is an asset of any backend in the export shape.
+type Row struct {
+	ID       string
+	Provider string
+	// Kind is the kind of the media, e.g. "video", "image" or "file".
+	Kind   string
+	Status string
+	Title  string
+	// ExternalID identifies the asset in the backend, e.g. the MUX asset ID or the Cloudinary public ID.
+	ExternalID string
+	Owners     []Owner
+	Tags       []string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	// DeletedAt is set for archived assets.
+	DeletedAt *time.Time
+}
+
+// Value returns the value of a column, nil for unknown columns.
+func (r *Row) Value(column string) any {
+	switch column {
+	case ColumnID:
+		return r.ID
+	case ColumnProvider:
+		return r.Provider
+	case ColumnKind:
+		return r.Kind
+	case ColumnStatus:
+		return r.Status
+	case ColumnTitle:
+		return r.Title
+	case ColumnExternalID:
+		return r.ExternalID
+	case ColumnOwners:
+		return r.Owners
+	case ColumnTags:
+		return r.Tags
+	case ColumnCreatedAt:
+		return r.CreatedAt
+	case ColumnUpdatedAt:
+		return r.UpdatedAt
+	case ColumnDeletedAt:
+		return r.DeletedAt
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package export
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+func (req CreateJobRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Format, validation.Required, validation.In(FormatCSV, FormatNDJSON)),
+		validation.Field(&req.Columns, validation.Each(validation.In(anySlice(Columns)...))),
+		validation.Field(&req.Providers, validation.Each(validation.Length(1, 32))),
+	)
+}
+
+func (req GetJobRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
+
+func (req ListJobsRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Status, validation.In(StatusPending, StatusRunning, StatusCompleted, StatusFailed)),
+	)
+}
+
+func anySlice(values []string) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
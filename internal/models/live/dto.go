@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package live contains the models of the live asset event stream of the admin UI.
+package live
+
+import (
+	"slices"
+
+	"github.com/mikhail5545/media-service-go/internal/events"
+)
+
+// Providers lists the asset providers a stream can filter.
+var Providers = []string{"mux", "cloudinary", "s3", "cfstream"}
+
+// Filter selects the events sent to a connection. It is read from the query of the handshake, the
+// client replaces it by sending a new filter as a JSON message.
+type Filter struct {
+	// Providers limits the events to the providers, events of all providers are sent if it is empty.
+	Providers []string `query:"providers" json:"providers"`
+	// EventTypes limits the events to the types, events of all types are sent if it is empty.
+	EventTypes []string `query:"event_types" json:"event_types"`
+}
+
+// Matches reports whether the event passes the filter.
+func (f *Filter) Matches(event *events.Event) bool {
+	if len(f.Providers) > 0 && !slices.Contains(f.Providers, string(event.Provider)) {
+		return false
+	}
+	return len(f.EventTypes) == 0 || slices.Contains(f.EventTypes, string(event.Type))
+}
+
+// MessageType identifies a message sent to a connection.
+type MessageType string
+
+const (
+	// MessageEvent carries an asset event.
+	MessageEvent MessageType = "event"
+	// MessageFilter confirms the filter applied to the connection.
+	MessageFilter MessageType = "filter"
+	// MessageError reports a rejected filter, the previous filter stays applied.
+	MessageError MessageType = "error"
+	// MessagePing keeps idle connections open through proxies.
+	MessagePing MessageType = "ping"
+)
+
+// Message is a message sent to a connection.
+type Message struct {
+	Type   MessageType   `json:"type"`
+	Event  *events.Event `json:"event,omitempty"`
+	Filter *Filter       `json:"filter,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package live
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+func (f Filter) Validate() error {
+	eventTypes := make([]any, len(events.Types))
+	for i, t := range events.Types {
+		eventTypes[i] = string(t)
+	}
+	providers := make([]any, len(Providers))
+	for i, p := range Providers {
+		providers[i] = p
+	}
+	return validationutil.ValidateStruct(&f,
+		validation.Field(&f.Providers, validation.Each(validation.In(providers...))),
+		validation.Field(&f.EventTypes, validation.Each(validation.In(eventTypes...))),
+	)
+}
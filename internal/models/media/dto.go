@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package media
+
+// GetRequest retrieves a single asset.
+type GetRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+// ListRequest lists assets of a provider, newest first.
+type ListRequest struct {
+	PageSize  int    `query:"page_size"`
+	PageToken string `query:"page_token"`
+}
+
+// ListByOwnerRequest lists the assets of a provider associated with a single owner. The owner type is
+// checked by the service like in [ManageOwnerRequest].
+type ListByOwnerRequest struct {
+	OwnerID   string `query:"owner_id"`
+	OwnerType string `query:"owner_type"`
+
+	PageSize  int    `query:"page_size"`
+	PageToken string `query:"page_token"`
+}
+
+// Details combines the asset with its metadata.
+type Details struct {
+	Asset    *Asset    `json:"asset"`
+	Metadata *Metadata `json:"metadata"`
+}
+
+type ChangeStateRequest struct {
+	ID        string `param:"id" json:"-"`
+	AdminID   string `json:"admin_id"`
+	AdminName string `json:"admin_name"`
+	Note      string `json:"note"`
+}
+
+// SetAdmin sets the admin recorded in the audit trail of the state change.
+func (req *ChangeStateRequest) SetAdmin(id, name string) {
+	req.AdminID = id
+	req.AdminName = name
+}
+
+// ManageOwnerRequest adds an owner to or removes an owner from an asset. Owner types are checked
+// against the registry of the provider by the service, the request only checks their format.
+type ManageOwnerRequest struct {
+	ID        string `param:"id" json:"-"`
+	OwnerID   string `json:"owner_id"`
+	OwnerType string `json:"owner_type"`
+}
+
+// MaxStuckDeletionsLimit is the maximum number of assets reported by a single [ListStuckDeletionsRequest].
+const MaxStuckDeletionsLimit = 1000
+
+// ListStuckDeletionsRequest lists the assets pending deletion for longer than OlderThanMinutes.
+type ListStuckDeletionsRequest struct {
+	// OlderThanMinutes defaults to 60.
+	OlderThanMinutes int `query:"older_than_minutes"`
+	// Limit defaults to 100.
+	Limit int `query:"limit"`
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package media provides models of the assets stored by the backends built on the shared asset core,
+// see [github.com/mikhail5545/media-service-go/internal/mediaprovider].
+package media
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Status represents the internal status of the asset.
+type Status string
+
+const (
+	// StatusPending assets were created, but the backend has not confirmed the upload yet.
+	StatusPending  Status = "pending"
+	StatusActive   Status = "active"
+	StatusArchived Status = "archived"
+	// StatusPendingDelete assets were permanently deleted by an admin, but the deletion from the
+	// backend has not completed yet.
+	StatusPendingDelete Status = "pending_delete"
+	// StatusErrored assets could not be processed by the backend, e.g. because the uploaded video
+	// could not be transcoded. The reason is stored in the "error" attribute.
+	StatusErrored Status = "errored"
+)
+
+// AttributeError is the asset attribute holding the reason an errored asset failed.
+const AttributeError = "error"
+
+// Asset is an asset stored by one of the backends built on the shared asset core. Assets of all such
+// backends share a single table, the provider column tells them apart.
+type Asset struct {
+	ID        uuid.UUID      `gorm:"primaryKey;type:uuid" json:"id"` // UUIDv7
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	// Provider is the name of the backend storing the asset, e.g. "s3".
+	Provider string `gorm:"type:varchar(32);not null;index" json:"provider"`
+	// Kind is the kind of the media, e.g. "video", "image" or "file".
+	Kind   string `gorm:"type:varchar(32);not null" json:"kind"`
+	Status Status `gorm:"type:varchar(32);not null;default:'pending'" json:"status"`
+	// ExternalID identifies the asset in the backend, e.g. the object key or the video ID.
+	ExternalID string `gorm:"type:varchar(512);not null" json:"external_id"`
+
+	Title       *string `gorm:"type:varchar(255);null" json:"title,omitempty"`
+	ContentType *string `gorm:"type:varchar(255);null" json:"content_type,omitempty"`
+	Bytes       *int64  `gorm:"null" json:"bytes,omitempty"`
+	// Checksum is the checksum of the stored content reported by the backend, if any.
+	Checksum *string `gorm:"type:varchar(128);null" json:"checksum,omitempty"`
+	// Attributes hold provider-specific values that do not deserve their own column.
+	Attributes map[string]string `gorm:"type:jsonb;serializer:json" json:"attributes,omitempty"`
+
+	// --- Audit fields ---
+
+	CreatedBy      *uuid.UUID `gorm:"type:uuid;null;index" json:"created_by,omitempty"`
+	ArchivedBy     *uuid.UUID `gorm:"type:uuid;null" json:"archived_by,omitempty"`
+	RestoredBy     *uuid.UUID `gorm:"type:uuid;null" json:"restored_by,omitempty"`
+	CreatedByName  *string    `gorm:"type:varchar(128);null" json:"created_by_name,omitempty"`
+	ArchivedByName *string    `gorm:"type:varchar(128);null" json:"archived_by_name,omitempty"`
+	RestoredByName *string    `gorm:"type:varchar(128);null" json:"restored_by_name,omitempty"`
+	Note           *string    `gorm:"type:varchar(512);null" json:"note,omitempty"`
+	// DeleteRequestedAt is the moment the asset was marked as pending deletion.
+	DeleteRequestedAt *time.Time `gorm:"null" json:"delete_requested_at,omitempty"`
+}
+
+func (*Asset) TableName() string {
+	return "media_assets"
+}
+
+func (a *Asset) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID, err = uuid.NewV7()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Owner is an external entity the asset is associated with, e.g. a lesson.
+type Owner struct {
+	OwnerID   string `bson:"owner_id" json:"owner_id"`
+	OwnerType string `bson:"owner_type" json:"owner_type"`
+}
+
+// Equal reports whether both owners refer to the same entity.
+func (o *Owner) Equal(other *Owner) bool {
+	return o.OwnerID == other.OwnerID && o.OwnerType == other.OwnerType
+}
+
+// Metadata holds the owners of the asset. It is stored in MongoDB, keyed by the asset ID.
+type Metadata struct {
+	Key      string   `bson:"_id" json:"key"`
+	Provider string   `bson:"provider" json:"provider"`
+	Owners   []*Owner `bson:"owners" json:"owners"`
+}
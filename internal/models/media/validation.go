@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package media
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+func (req GetRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
+
+func (req ListRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
+		validation.Field(&req.PageToken, validationutil.UUIDRule(false)...),
+	)
+}
+
+func (req ListByOwnerRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.OwnerID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.OwnerType, validation.Required, validation.Length(1, 50)),
+		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
+		validation.Field(&req.PageToken, validationutil.UUIDRule(false)...),
+	)
+}
+
+func (req ChangeStateRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+		validation.Field(&req.Note, validation.Required, validation.Length(10, 512)),
+	)
+}
+
+func (req ManageOwnerRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.OwnerID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.OwnerType, validation.Required, validation.Length(1, 50)),
+	)
+}
+
+func (req ListStuckDeletionsRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.OlderThanMinutes, validation.Min(0), validation.Max(7*24*60)),
+		validation.Field(&req.Limit, validation.Min(0), validation.Max(MaxStuckDeletionsLimit)),
+	)
+}
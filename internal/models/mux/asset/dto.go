@@ -1,8 +1,12 @@
 package asset
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	"github.com/mikhail5545/media-service-go/internal/models/mux/types"
+	"github.com/mikhail5545/media-service-go/internal/viewer"
 )
 
 type OrderField string
@@ -23,6 +27,8 @@ const (
 type GetFilter struct {
 	ID           string       `param:"id" json:"-"`
 	UploadStatus UploadStatus `query:"upload_status" json:"upload_status"`
+	// Fields is the field mask of the read, see the fieldmask package. Everything is loaded if it is empty.
+	Fields []string `query:"fields" json:"-"`
 }
 
 type ListRequest struct {
@@ -34,10 +40,23 @@ type ListRequest struct {
 	ResolutionTiers []string       `query:"resolution_tiers"`
 	IngestTypes     []IngestType   `query:"ingest_types"`
 	UploadStatuses  []UploadStatus `query:"upload_statuses"`
+	// Tags matches assets having all the tags.
+	Tags []string `query:"tags"`
+
+	// Query, CreatorID and Owned filter on the listing read model, assets written before it was
+	// populated are not matched. Query matches titles containing it, case-insensitively.
+	Query     string `query:"q"`
+	CreatorID string `query:"creator_id"`
+	// Owned matches the assets with owners if true and the assets without owners if false.
+	Owned *bool `query:"owned"`
 
 	OrderBy  OrderField     `query:"order_by"`
 	OrderDir OrderDirection `query:"order_dir"`
 
+	// Fields is the field mask of the read, see the fieldmask package. Everything is loaded if it is empty.
+	// Masks selecting only the metadata fields of [ListingFields] are served from the listing read model.
+	Fields []string `query:"fields"`
+
 	PageSize  int    `query:"page_size"`
 	PageToken string `query:"page_token"`
 }
@@ -48,10 +67,35 @@ type Details struct {
 	Metadata *metadata.AssetMetadata
 }
 
+// GetByOwnerRequest looks up the asset associated with an owner, e.g. the asset a product service
+// stored as the media of one of its entities.
+type GetByOwnerRequest struct {
+	OwnerType string `param:"owner_type"`
+	OwnerID   string `param:"owner_id"`
+}
+
+// ListByOwnerRequest lists the assets associated with a single owner, e.g. all videos of a lesson.
+type ListByOwnerRequest struct {
+	OwnerID   string `query:"owner_id"`
+	OwnerType string `query:"owner_type"`
+
+	PageSize  int    `query:"page_size"`
+	PageToken string `query:"page_token"`
+}
+
 type CreateUploadURLRequest struct {
 	Title     string `json:"title"`
 	AdminID   string `json:"admin_id"`
 	AdminName string `json:"admin_name"`
+	// DRMConfigurationID selects the MUX DRM configuration protecting the asset. The configured default
+	// is used when it is omitted.
+	DRMConfigurationID *string `json:"drm_configuration_id"`
+	// OwnerType is the owner type the video is uploaded for, e.g. "lesson". It selects the watermark
+	// burned into the video when the creator has none. Optional, it does not add an owner.
+	OwnerType string `json:"owner_type"`
+	// Encoding overrides the encoding settings of the owner type and the defaults. Optional, the
+	// resolved settings must be allowed by the encoding policy.
+	Encoding *EncodingSettings `json:"encoding"`
 }
 
 type ChangeStateRequest struct {
@@ -61,10 +105,128 @@ type ChangeStateRequest struct {
 	Note      string `json:"note"`
 }
 
+// SetAdmin sets the admin recorded in the audit trail of the state change.
+func (req *ChangeStateRequest) SetAdmin(id, name string) {
+	req.AdminID = id
+	req.AdminName = name
+}
+
+// ManageTagsRequest adds tags to or removes tags from an asset.
+type ManageTagsRequest struct {
+	ID   string   `param:"id" json:"-"`
+	Tags []string `json:"tags"`
+}
+
+// ListByCollectionRequest lists the assets of a collection.
+type ListByCollectionRequest struct {
+	CollectionID string `query:"collection_id"`
+
+	PageSize  int    `query:"page_size"`
+	PageToken string `query:"page_token"`
+}
+
+// ListByTagRequest lists the active assets having a tag.
+type ListByTagRequest struct {
+	Tag string `query:"tag"`
+
+	PageSize  int    `query:"page_size"`
+	PageToken string `query:"page_token"`
+}
+
+// SearchRequest searches the assets by the title and the enrichment data. Query matches a substring
+// of the title, the labels, or the extracted texts, Label matches a label exactly.
+type SearchRequest struct {
+	Query string `query:"q"`
+	Label string `query:"label"`
+
+	PageSize  int    `query:"page_size"`
+	PageToken string `query:"page_token"`
+}
+
+// UpdateMetadataRequest changes the title or the creator of an asset. Omitted fields are left unchanged.
+type UpdateMetadataRequest struct {
+	ID        string  `param:"id" json:"-"`
+	Title     *string `json:"title"`
+	CreatorID *string `json:"creator_id"`
+}
+
+// ManageOwnerRequest associates an owner with an asset or disassociates it.
+//
+// When adding an owner, ReplaceExisting moves the owner to the asset instead of failing if the
+// ownership policy allows it fewer assets: the owner is removed from all other assets it is associated
+// with first. ArchiveReplaced additionally archives the replaced assets left without owners, on behalf
+// of the admin identified by AdminID and AdminName.
 type ManageOwnerRequest struct {
 	ID        string `param:"id" json:"-"`
 	OwnerID   string `json:"owner_id"`
 	OwnerType string `json:"owner_type"`
+
+	ReplaceExisting bool   `json:"replace_existing"`
+	ArchiveReplaced bool   `json:"archive_replaced"`
+	AdminID         string `json:"admin_id"`
+	AdminName       string `json:"admin_name"`
+}
+
+// AssociateResult is the result of associating an owner with an asset. ReplacedAssetIDs lists the
+// assets the owner was removed from, ArchivedAssetIDs the replaced assets that were archived.
+type AssociateResult struct {
+	AssetID          string   `json:"asset_id"`
+	ReplacedAssetIDs []string `json:"replaced_asset_ids"`
+	ArchivedAssetIDs []string `json:"archived_asset_ids"`
+}
+
+// MaxOwnersPerRequest is the maximum number of owners an asset can be given in a single [UpdateOwnersRequest].
+const MaxOwnersPerRequest = 100
+
+// OwnerRef identifies an owner of an asset.
+type OwnerRef struct {
+	OwnerID   string `json:"owner_id"`
+	OwnerType string `json:"owner_type"`
+}
+
+// UpdateOwnersRequest replaces the owners of an asset. Owners missing from the list are removed,
+// new ones are added, an empty list removes all owners. Revision is the revision of the asset metadata
+// the owners were read at, the update is rejected if the metadata was changed since. Over HTTP it is
+// also taken from the If-Match header.
+type UpdateOwnersRequest struct {
+	ID       string      `param:"id" json:"-"`
+	Owners   []*OwnerRef `json:"owners"`
+	Revision *int64      `json:"revision"`
+}
+
+// AddPlaybackIDRequest adds a playback ID with the policy to an asset.
+type AddPlaybackIDRequest struct {
+	ID string `param:"id" json:"-"`
+	// Policy is either "public" or "signed".
+	Policy    string `json:"policy"`
+	AdminID   string `json:"admin_id"`
+	AdminName string `json:"admin_name"`
+}
+
+// RemovePlaybackIDRequest removes a playback ID from an asset.
+type RemovePlaybackIDRequest struct {
+	ID         string `param:"id" json:"-"`
+	PlaybackID string `param:"playback_id" json:"-"`
+	AdminID    string `json:"admin_id"`
+	AdminName  string `json:"admin_name"`
+}
+
+// RotatePlaybackIDRequest replaces the primary playback ID of the policy with a new one,
+// for example when a signed playback ID has leaked.
+type RotatePlaybackIDRequest struct {
+	ID string `param:"id" json:"-"`
+	// Policy is either "public" or "signed".
+	Policy    string `json:"policy"`
+	AdminID   string `json:"admin_id"`
+	AdminName string `json:"admin_name"`
+}
+
+// PlaybackIDs lists the playback IDs of an asset after a playback policy change.
+type PlaybackIDs struct {
+	PrimarySignedPlaybackID *string                       `json:"primary_signed_playback_id,omitempty"`
+	PrimaryPublicPlaybackID *string                       `json:"primary_public_playback_id,omitempty"`
+	PrimaryDRMPlaybackID    *string                       `json:"primary_drm_playback_id,omitempty"`
+	PlaybackIDs             []*types.MuxWebhookPlaybackID `json:"playback_ids"`
 }
 
 // GeneratePlaybackTokenRequest represents a request to generate a playback token for a MUX asset.
@@ -75,4 +237,184 @@ type GeneratePlaybackTokenRequest struct {
 	Expiration int64      // in seconds
 	SessionID  *uuid.UUID // optional
 	UserAgent  *string    // optional
+	// Country is the ISO 3166-1 alpha-2 code of the country of the viewer. It is required if the
+	// playback restriction policy of the asset limits the countries.
+	Country *string // optional
+}
+
+// Viewer returns the viewer the token is requested for, checked against the playback restrictions.
+func (req *GeneratePlaybackTokenRequest) Viewer() viewer.Viewer {
+	var v viewer.Viewer
+	if req.Country != nil {
+		v.Country = viewer.NormalizeCountry(*req.Country)
+	}
+	return v
+}
+
+// Ways the playback token is handed to the player, see [PlaybackInfoRequest].
+const (
+	// TokenDeliveryQuery returns the token to be appended to the stream URL as the token query parameter.
+	TokenDeliveryQuery = "query"
+	// TokenDeliveryCookie sets the token as a cookie sent along with the segment requests to the CDN in
+	// front of MUX.
+	TokenDeliveryCookie = "cookie"
+	// TokenDeliveryHeader returns the token to be sent in a request header to the CDN in front of MUX.
+	TokenDeliveryHeader = "header"
+)
+
+// PlaybackInfoRequest requests the playback details of an asset for the authenticated end user.
+type PlaybackInfoRequest struct {
+	ID string `param:"id" json:"-"`
+	// TokenDelivery is one of the TokenDelivery constants, it defaults to [TokenDeliveryQuery].
+	TokenDelivery string `query:"token_delivery" json:"-"`
+}
+
+// DRMPlaybackRequest requests the DRM playback tokens of an asset for the authenticated end user.
+type DRMPlaybackRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+// PlaybackInfo holds everything an end user player needs to play an asset.
+type PlaybackInfo struct {
+	PlaybackID string     `json:"playback_id"`
+	PosterURL  string     `json:"poster_url"`
+	Duration   *float32   `json:"duration,omitempty"`
+	Languages  *Languages `json:"languages"`
+	// Token signs the stream URL of the playback ID, it expires at ExpiresAt. It is omitted if the token
+	// is delivered as a cookie.
+	Token     string    `json:"token,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// TokenDelivery is the way the token is handed to the player.
+	TokenDelivery string `json:"token_delivery"`
+	// TokenHeader is the request header the token has to be sent in if it is delivered as a header.
+	TokenHeader string `json:"token_header,omitempty"`
+}
+
+// SetPosterRequest sets the poster of a video to the frame at Time or to the Cloudinary image ImageID.
+// Exactly one of them is required.
+type SetPosterRequest struct {
+	ID string `param:"id" json:"-"`
+	// Time is the time of the frame in seconds.
+	Time      *float64 `json:"time"`
+	ImageID   *string  `json:"image_id"`
+	AdminID   string   `json:"admin_id"`
+	AdminName string   `json:"admin_name"`
+}
+
+// ResetPosterRequest resets the poster of a video, MUX picks the frame in the middle of the video.
+type ResetPosterRequest struct {
+	ID        string `param:"id" json:"-"`
+	AdminID   string `json:"admin_id"`
+	AdminName string `json:"admin_name"`
+}
+
+// ListLanguagesRequest lists the audio and subtitle languages of a video.
+type ListLanguagesRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+// UpdateTrackRequest renames an audio or subtitle track or makes an audio track the default.
+// Omitted fields are left unchanged, an empty Name restores the name reported by MUX and a false
+// Default on the default track restores the primary audio track as the default.
+type UpdateTrackRequest struct {
+	ID        string  `param:"id" json:"-"`
+	TrackID   string  `param:"track_id" json:"-"`
+	Name      *string `json:"name"`
+	Default   *bool   `json:"default"`
+	AdminID   string  `json:"admin_id"`
+	AdminName string  `json:"admin_name"`
+}
+
+// ListChaptersRequest lists the chapters of a video, ordered by their start time.
+type ListChaptersRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+// AddChapterRequest adds a chapter marker to a video.
+type AddChapterRequest struct {
+	ID            string   `param:"id" json:"-"`
+	Title         string   `json:"title"`
+	StartTime     float64  `json:"start_time"`
+	ThumbnailTime *float64 `json:"thumbnail_time"`
+	AdminID       string   `json:"admin_id"`
+	AdminName     string   `json:"admin_name"`
+}
+
+// UpdateChapterRequest changes a chapter of a video. Omitted fields are left unchanged.
+type UpdateChapterRequest struct {
+	ID            string   `param:"id" json:"-"`
+	ChapterID     string   `param:"chapter_id" json:"-"`
+	Title         *string  `json:"title"`
+	StartTime     *float64 `json:"start_time"`
+	ThumbnailTime *float64 `json:"thumbnail_time"`
+	AdminID       string   `json:"admin_id"`
+	AdminName     string   `json:"admin_name"`
+}
+
+// DeleteChapterRequest removes a chapter from a video.
+type DeleteChapterRequest struct {
+	ID        string `param:"id" json:"-"`
+	ChapterID string `param:"chapter_id" json:"-"`
+	AdminID   string `json:"admin_id"`
+	AdminName string `json:"admin_name"`
+}
+
+// Poster is the poster of a video, either a frame of the video or a Cloudinary image. Both are
+// empty if MUX picks the frame.
+type Poster struct {
+	Time     *float64   `json:"time,omitempty"`
+	ImageID  *uuid.UUID `json:"image_id,omitempty"`
+	ImageURL *string    `json:"image_url,omitempty"`
+}
+
+// DRMPlaybackTokens holds everything a player needs to play a DRM protected MUX asset.
+type DRMPlaybackTokens struct {
+	PlaybackID string `json:"playback_id"`
+	// PlaybackToken signs the stream URL of the DRM playback ID.
+	PlaybackToken string `json:"playback_token"`
+	// LicenseToken signs the Widevine and FairPlay license requests and the FairPlay certificate request.
+	LicenseToken           string    `json:"license_token"`
+	WidevineLicenseURL     string    `json:"widevine_license_url"`
+	FairPlayLicenseURL     string    `json:"fairplay_license_url"`
+	FairPlayCertificateURL string    `json:"fairplay_certificate_url"`
+	ExpiresAt              time.Time `json:"expires_at"`
+}
+
+// MaxStuckDeletionsLimit is the maximum number of assets reported by a single [ListStuckDeletionsRequest].
+const MaxStuckDeletionsLimit = 1000
+
+// MaxStuckAssetsLimit is the maximum number of assets [ListStuckAssetsRequest] lists.
+const MaxStuckAssetsLimit = 1000
+
+// ListStuckAssetsRequest lists the assets which have been waiting for an upload or for MUX to finish
+// processing for longer than OlderThanHours.
+type ListStuckAssetsRequest struct {
+	// OlderThanHours defaults to 6.
+	OlderThanHours int `query:"older_than_hours"`
+	// Limit defaults to 100.
+	Limit int `query:"limit"`
+}
+
+// CountErrorsRequest counts the broken and errored assets.
+type CountErrorsRequest struct{}
+
+// ErrorCount is the number of assets in Status which failed with the MUX error ErrorType. ErrorType
+// is empty for assets without a MUX error, e.g. assets marked as broken by an admin.
+type ErrorCount struct {
+	Status    Status `json:"status"`
+	ErrorType string `json:"error_type"`
+	Assets    int64  `json:"assets"`
+}
+
+// ReprocessAssetRequest re-fetches the state of an asset from MUX.
+type ReprocessAssetRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+// ListStuckDeletionsRequest lists the assets pending deletion for longer than OlderThanMinutes.
+type ListStuckDeletionsRequest struct {
+	// OlderThanMinutes defaults to 60.
+	OlderThanMinutes int `query:"older_than_minutes"`
+	// Limit defaults to 100.
+	Limit int `query:"limit"`
 }
@@ -0,0 +1,30 @@
+package asset
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// MaxDuplicateGroupsLimit is the maximum number of groups reported by a single [FindDuplicatesRequest].
+const MaxDuplicateGroupsLimit = 1000
+
+// FindDuplicatesRequest lists the groups of active assets sharing a fingerprint.
+type FindDuplicatesRequest struct {
+	// Limit defaults to 100.
+	Limit int `query:"limit"`
+}
+
+// DuplicateGroup is a group of probable duplicates. The oldest asset goes first, it is usually the
+// one to keep.
+type DuplicateGroup struct {
+	Fingerprint string      `json:"fingerprint"`
+	AssetIDs    []uuid.UUID `json:"asset_ids"`
+}
+
+// Fingerprint returns the fingerprint of a video with the aspect ratio and the duration in seconds,
+// e.g. "16:9/63.4". The duration is rounded to tenths of a second, so re-encoded copies of the same
+// video usually share the fingerprint.
+func Fingerprint(aspectRatio string, duration float32) string {
+	return fmt.Sprintf("%s/%.1f", aspectRatio, duration)
+}
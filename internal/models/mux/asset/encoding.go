@@ -0,0 +1,140 @@
+// github.com/mikhail5545/media-service-go
+// microservice for vitianmove project family
+// Copyright (C) 2025  Mikhail Kulik
+
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package asset
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+// The options of the MUX new asset settings the encoding of an upload can be controlled with.
+var (
+	MaxResolutionTiers = []string{"1080p", "1440p", "2160p"}
+	VideoQualities     = []string{"basic", "plus", "premium"}
+	MP4Supports        = []string{"none", "capped-1080p", "audio-only", "audio-only,capped-1080p"}
+	PlaybackPolicies   = []string{"public", "signed"}
+)
+
+// DefaultEncoding are the settings of the uploads the policies and the request leave unset.
+var DefaultEncoding = EncodingSettings{
+	VideoQuality:     "basic",
+	PlaybackPolicies: []string{"signed", "public"},
+}
+
+// EncodingSettings are the MUX new asset settings an upload is created with. Empty fields are left to
+// the defaults of MUX, e.g. the 1080p resolution tier. They are recorded on the asset for audit.
+type EncodingSettings struct {
+	MaxResolutionTier string `json:"max_resolution_tier,omitempty"`
+	VideoQuality      string `json:"video_quality,omitempty"`
+	MP4Support        string `json:"mp4_support,omitempty"`
+	NormalizeAudio    *bool  `json:"normalize_audio,omitempty"`
+	// PlaybackPolicies are the policies of the playback IDs the asset is created with.
+	PlaybackPolicies []string `json:"playback_policies,omitempty"`
+}
+
+func (s EncodingSettings) Validate() error {
+	return validationutil.ValidateStruct(&s,
+		validation.Field(&s.MaxResolutionTier, validation.In(anySlice(MaxResolutionTiers)...)),
+		validation.Field(&s.VideoQuality, validation.In(anySlice(VideoQualities)...)),
+		validation.Field(&s.MP4Support, validation.In(anySlice(MP4Supports)...)),
+		validation.Field(&s.PlaybackPolicies, validation.Each(validation.In(anySlice(PlaybackPolicies)...))),
+	)
+}
+
+func anySlice(values []string) []any {
+	converted := make([]any, len(values))
+	for i, v := range values {
+		converted[i] = v
+	}
+	return converted
+}
+
+// Override returns the settings with the fields set in o replacing their own.
+func (s EncodingSettings) Override(o *EncodingSettings) EncodingSettings {
+	if o == nil {
+		return s
+	}
+	if o.MaxResolutionTier != "" {
+		s.MaxResolutionTier = o.MaxResolutionTier
+	}
+	if o.VideoQuality != "" {
+		s.VideoQuality = o.VideoQuality
+	}
+	if o.MP4Support != "" {
+		s.MP4Support = o.MP4Support
+	}
+	if o.NormalizeAudio != nil {
+		s.NormalizeAudio = o.NormalizeAudio
+	}
+	if len(o.PlaybackPolicies) > 0 {
+		s.PlaybackPolicies = slices.Clone(o.PlaybackPolicies)
+	}
+	return s
+}
+
+// EncodingAllowlist lists the settings uploads may be created with. An empty list allows any option.
+type EncodingAllowlist struct {
+	MaxResolutionTiers []string
+	VideoQualities     []string
+	MP4Supports        []string
+	PlaybackPolicies   []string
+}
+
+// EncodingPolicy holds the default settings of the uploads, the settings overriding them for the
+// uploads of owner types and the allowlist the resolved settings are checked against.
+type EncodingPolicy struct {
+	Default EncodingSettings
+	// OwnerTypes holds the overrides keyed by the owner type.
+	OwnerTypes map[string]EncodingSettings
+	Allowed    EncodingAllowlist
+}
+
+// Resolve returns the settings of an upload for the owner type. The built-in defaults are overridden
+// by the default settings of the policy, then by those of the owner type and last by the requested
+// ones. A nil policy allows any requested settings.
+func (p *EncodingPolicy) Resolve(ownerType string, requested *EncodingSettings) (EncodingSettings, error) {
+	if p == nil {
+		return DefaultEncoding.Override(requested), nil
+	}
+	settings := DefaultEncoding.Override(&p.Default)
+	if override, ok := p.OwnerTypes[ownerType]; ok {
+		settings = settings.Override(&override)
+	}
+	settings = settings.Override(requested)
+
+	errs := validation.Errors{}
+	allowed := func(field, value string, options []string) {
+		if value != "" && len(options) > 0 && !slices.Contains(options, value) {
+			errs[field] = fmt.Errorf("%q is not allowed, allowed are %s", value, strings.Join(options, ", "))
+		}
+	}
+	allowed("max_resolution_tier", settings.MaxResolutionTier, p.Allowed.MaxResolutionTiers)
+	allowed("video_quality", settings.VideoQuality, p.Allowed.VideoQualities)
+	allowed("mp4_support", settings.MP4Support, p.Allowed.MP4Supports)
+	for _, policy := range settings.PlaybackPolicies {
+		allowed("playback_policies", policy, p.Allowed.PlaybackPolicies)
+	}
+	if len(errs) > 0 {
+		return EncodingSettings{}, validation.Errors{"encoding": errs}
+	}
+	return settings, nil
+}
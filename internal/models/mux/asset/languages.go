@@ -0,0 +1,77 @@
+package asset
+
+import (
+	"github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	"github.com/mikhail5545/media-service-go/internal/models/mux/types"
+)
+
+// LanguageFields are the metadata fields required by [NewLanguages].
+var LanguageFields = []string{"tracks", "track_names", "default_audio_track_id"}
+
+// Language is an audio or subtitle track of a video.
+type Language struct {
+	TrackID string `json:"track_id"`
+	// Code is the BCP 47 language code of the track, empty if MUX did not report one.
+	Code string `json:"code"`
+	// Name is the name set by an admin, the name reported by MUX or the language code, in that order.
+	Name string `json:"name"`
+	// ClosedCaptions is set for subtitles for the deaf or hard-of-hearing.
+	ClosedCaptions bool `json:"closed_captions,omitempty"`
+	// Default is set for the audio track players should select first.
+	Default bool `json:"default,omitempty"`
+}
+
+// Languages are the audio and subtitle languages of a video.
+type Languages struct {
+	Audio     []*Language `json:"audio"`
+	Subtitles []*Language `json:"subtitles"`
+}
+
+// NewLanguages returns the languages of the tracks in the metadata. Subtitle tracks which are not
+// ready are left out.
+func NewLanguages(m *metadata.AssetMetadata) *Languages {
+	languages := &Languages{Audio: []*Language{}, Subtitles: []*Language{}}
+	var primary *Language
+	for _, track := range m.Tracks {
+		if track == nil {
+			continue
+		}
+		switch track.Type {
+		case "audio":
+			language := newLanguage(m, track)
+			if m.DefaultAudioTrackID != nil {
+				language.Default = track.ID == *m.DefaultAudioTrackID
+			} else if track.Primary != nil && *track.Primary {
+				primary = language
+			}
+			languages.Audio = append(languages.Audio, language)
+		case "text":
+			if track.Status != nil && *track.Status != "ready" {
+				continue
+			}
+			language := newLanguage(m, track)
+			language.ClosedCaptions = track.ClosedCaptions != nil && *track.ClosedCaptions
+			languages.Subtitles = append(languages.Subtitles, language)
+		}
+	}
+	if primary != nil {
+		primary.Default = true
+	}
+	return languages
+}
+
+func newLanguage(m *metadata.AssetMetadata, track *types.MuxWebhookTrack) *Language {
+	language := &Language{TrackID: track.ID}
+	if track.LanguageCode != nil {
+		language.Code = *track.LanguageCode
+	}
+	switch {
+	case m.TrackNames[track.ID] != "":
+		language.Name = m.TrackNames[track.ID]
+	case track.Name != nil && *track.Name != "":
+		language.Name = *track.Name
+	default:
+		language.Name = language.Code
+	}
+	return language
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/mikhail5545/media-service-go/internal/models/mux/types"
+	"github.com/mikhail5545/media-service-go/internal/models/publishing"
+	"github.com/mikhail5545/media-service-go/internal/tags"
 	"gorm.io/gorm"
 )
 
@@ -27,6 +29,9 @@ const (
 	StatusActive             Status = "active"
 	StatusArchived           Status = "archived"
 	StatusBroken             Status = "broken"
+	// StatusPendingDelete assets were permanently deleted by an admin, but the deletion from MUX
+	// has not completed yet. They are not visible under any scope.
+	StatusPendingDelete Status = "pending_delete"
 )
 
 type State string
@@ -56,6 +61,9 @@ type Asset struct {
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	// Tenant environment (e.g. staging or production) the asset belongs to. It is stamped from
+	// the request context when the asset is created.
+	Environment string `gorm:"type:varchar(64);not null;default:'default';index" json:"environment"`
 	// Unique identifier for the direct upload (External mux API id). This field is
 	// populated from the mux webhooks.
 	MuxUploadID *string `gorm:"null" json:"mux_upload_id,omitempty"`
@@ -78,7 +86,10 @@ type Asset struct {
 	// The aspect ratio of the asset.
 	//
 	// 	"width:height" -> "16:9"
-	AspectRatio    *string    `gorm:"null" json:"aspect_ratio,omitempty"`
+	AspectRatio *string `gorm:"null" json:"aspect_ratio,omitempty"`
+	// Fingerprint identifies probable duplicates of the asset, see [Fingerprint]. It is set once both
+	// the aspect ratio and the duration are known.
+	Fingerprint    *string    `gorm:"type:varchar(64);null;index" json:"fingerprint,omitempty"`
 	AssetCreatedAt *time.Time `gorm:"null" json:"asset_created_at,omitempty"`
 	// The resolution tier that the asset was ingested at, affecting billing for ingest & storage.
 	// The asset may be delivered at lower resolutions depending on the device and bandwidth, however
@@ -90,6 +101,9 @@ type Asset struct {
 	//
 	//	"on_demand_url", "on_demand_direct_upload", "on_demand_clip", "live_rtmp", "live_srt"
 	IngestType IngestType `gorm:"null" json:"ingest_type,omitempty"`
+	// Tags are managed with the AddTags and RemoveTags service methods and mirrored to the
+	// passthrough of the MUX asset.
+	Tags tags.Array `gorm:"type:varchar(128)[]" json:"tags,omitempty"`
 
 	// --- PlaybackIDs ---
 
@@ -99,6 +113,33 @@ type Asset struct {
 	// PrimaryPublicPlaybackID facilitates quick lookup for public playback without querying Mongo.
 	// Populated from the first 'public' policy playbackID found in the webhook metadata.
 	PrimaryPublicPlaybackID *string `gorm:"type:varchar(255);null;index" json:"primary_public_playback_id,omitempty"`
+	// PrimaryDRMPlaybackID facilitates quick lookup for DRM token generation without querying Mongo.
+	// Populated from the first 'drm' policy playbackID found in the webhook metadata.
+	PrimaryDRMPlaybackID *string `gorm:"type:varchar(255);null;index" json:"primary_drm_playback_id,omitempty"`
+	// DRMConfigurationID is the MUX DRM configuration selected when the upload URL was created.
+	DRMConfigurationID *string `gorm:"type:varchar(255);null" json:"drm_configuration_id,omitempty"`
+	// Encoding records the MUX new asset settings the upload URL was created with.
+	Encoding *EncodingSettings `gorm:"type:jsonb;serializer:json;null" json:"encoding,omitempty"`
+
+	// --- Poster ---
+
+	// PosterTime is the time in seconds of the video frame used as the poster and the default thumbnail.
+	// MUX picks the frame in the middle of the video if neither it nor PosterImageID is set.
+	PosterTime *float64 `gorm:"null" json:"poster_time,omitempty"`
+	// PosterImageID is the Cloudinary image used as the poster instead of a video frame.
+	PosterImageID *uuid.UUID `gorm:"type:uuid;null" json:"poster_image_id,omitempty"`
+	// PosterImageURL is the delivery URL of the poster image, copied when the poster is set.
+	PosterImageURL *string `gorm:"type:varchar(2048);null" json:"poster_image_url,omitempty"`
+
+	// --- Publishing ---
+
+	// PublishingState is updated by the publishing worker once the publish or unpublish time passes,
+	// the delivery checks the schedule itself with [Asset.PublishingStateAt].
+	PublishingState publishing.State `gorm:"type:varchar(16);not null;default:'published';index" json:"publishing_state"`
+	// PublishAt is the time the asset becomes deliverable to end users, nil if it is not scheduled.
+	PublishAt *time.Time `gorm:"null" json:"publish_at,omitempty"`
+	// UnpublishAt is the time the asset stops being deliverable to end users, nil if it is not scheduled.
+	UnpublishAt *time.Time `gorm:"null" json:"unpublish_at,omitempty"`
 
 	// --- Audit fields ---
 
@@ -114,11 +155,22 @@ type Asset struct {
 
 	Note          *string `gorm:"type:varchar(512)" json:"note,omitempty"`
 	ArchiveReason *string `gorm:"type:varchar(512)" json:"archive_reason,omitempty"`
+	// DeleteRequestedAt is the moment the asset was marked as pending deletion.
+	DeleteRequestedAt *time.Time `gorm:"null" json:"delete_requested_at,omitempty"`
 
 	// ArchiveEventID is the MUX webhook event ID that caused the asset to be archived.
 	// Used for idempotency to avoid archiving the same asset multiple times on repeated webhooks.
 	ArchiveEventID *string                `gorm:"type:varchar(255);null" json:"archive_event_id,omitempty"`
 	MuxError       *types.MuxWebhookError `gorm:"type:jsonb;null" json:"mux_error,omitempty"`
+	// LastWebhookAt is the creation time of the last MUX webhook applied to the asset. MUX does not
+	// deliver the webhooks in order, older ones are ignored.
+	LastWebhookAt *time.Time `gorm:"null" json:"last_webhook_at,omitempty"`
+	// OwnershipSnapshot holds the owners the asset had before they were removed, e.g. when the asset
+	// was marked as broken or deleted in MUX. It is cleared when the owners are restored.
+	OwnershipSnapshot *OwnershipSnapshot `gorm:"type:jsonb;null" json:"ownership_snapshot,omitempty"`
+
+	// Listing is only loaded by listings served from the read model.
+	Listing *Listing `gorm:"foreignKey:AssetID" json:"-"`
 }
 
 func (*Asset) TableName() string {
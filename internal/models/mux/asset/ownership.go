@@ -0,0 +1,50 @@
+package asset
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+)
+
+// SnapshotReason is the operation which removed the owners of an asset.
+type SnapshotReason string
+
+const (
+	// SnapshotReasonBroken is used when the asset is marked as broken.
+	SnapshotReasonBroken SnapshotReason = "broken"
+	// SnapshotReasonDeleted is used when the asset is deleted in MUX and archived locally.
+	SnapshotReasonDeleted SnapshotReason = "deleted"
+	// SnapshotReasonReplaced is used when the owner was moved to another asset and the asset is archived.
+	SnapshotReasonReplaced SnapshotReason = "replaced"
+)
+
+// OwnershipSnapshot is the ownership of an asset taken when the asset lost its owners. It is stored
+// with the asset rather than in the metadata, as the metadata of an asset deleted in MUX is removed.
+type OwnershipSnapshot struct {
+	Owners  []*metadata.Owner `json:"owners"`
+	Reason  SnapshotReason    `json:"reason"`
+	TakenAt time.Time         `json:"taken_at"`
+}
+
+// Value implements [driver.Valuer], the snapshot is stored as JSON and a nil snapshot as NULL.
+func (s *OwnershipSnapshot) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// Scan implements [sql.Scanner].
+func (s *OwnershipSnapshot) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("unsupported ownership snapshot type %T", value)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// ThumbnailURL returns the MUX thumbnail URL of the playback ID with the query, e.g. the token of a
+// signed playback ID.
+func ThumbnailURL(playbackID string, query url.Values) string {
+	thumbnailURL := "https://image.mux.com/" + playbackID + "/thumbnail.jpg"
+	if len(query) > 0 {
+		thumbnailURL += "?" + query.Encode()
+	}
+	return thumbnailURL
+}
+
+// PosterTimeParam formats the poster time as the value of the MUX thumbnail time parameter.
+func PosterTimeParam(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', -1, 64)
+}
+
+// PublicPosterURL returns the poster URL that works without a token: the poster image, or the
+// thumbnail of the public playback ID at the poster time. It is empty if the asset has neither.
+func (a *Asset) PublicPosterURL() string {
+	if a.PosterImageURL != nil {
+		return *a.PosterImageURL
+	}
+	if a.PrimaryPublicPlaybackID == nil {
+		return ""
+	}
+	query := url.Values{}
+	if a.PosterTime != nil {
+		query.Set("time", PosterTimeParam(*a.PosterTime))
+	}
+	return ThumbnailURL(*a.PrimaryPublicPlaybackID, query)
+}
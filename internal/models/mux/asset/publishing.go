@@ -0,0 +1,53 @@
+package asset
+
+import (
+	"time"
+
+	"github.com/mikhail5545/media-service-go/internal/models/publishing"
+)
+
+// SchedulePublishingRequest sets the publication schedule of an asset. The video is delivered to end
+// users only between PublishAt and UnpublishAt, a nil time removes that end of the schedule.
+type SchedulePublishingRequest struct {
+	ID          string     `param:"id" json:"-"`
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+	AdminID     string     `json:"admin_id"`
+	AdminName   string     `json:"admin_name"`
+	Note        string     `json:"note"`
+}
+
+// SetAdmin sets the admin recorded in the audit trail of the schedule change.
+func (req *SchedulePublishingRequest) SetAdmin(id, name string) {
+	req.AdminID = id
+	req.AdminName = name
+}
+
+// CancelPublishingRequest removes the publication schedule of an asset. A scheduled video stays
+// unpublished, a published one is no longer unpublished.
+type CancelPublishingRequest struct {
+	ID        string `param:"id" json:"-"`
+	AdminID   string `json:"admin_id"`
+	AdminName string `json:"admin_name"`
+	Note      string `json:"note"`
+}
+
+// SetAdmin sets the admin recorded in the audit trail of the cancellation.
+func (req *CancelPublishingRequest) SetAdmin(id, name string) {
+	req.AdminID = id
+	req.AdminName = name
+}
+
+// PublishingStateAt returns the publishing state of the asset at the moment now, see [publishing.StateAt].
+func (a *Asset) PublishingStateAt(now time.Time) publishing.State {
+	return publishing.StateAt(a.PublishingState, a.PublishAt, a.UnpublishAt, now)
+}
+
+// Schedule returns the publication schedule of the asset.
+func (a *Asset) Schedule() *publishing.Schedule {
+	return &publishing.Schedule{
+		State:       a.PublishingState,
+		PublishAt:   a.PublishAt,
+		UnpublishAt: a.UnpublishAt,
+	}
+}
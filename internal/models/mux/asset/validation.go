@@ -19,22 +19,34 @@ package asset
 
 import (
 	"reflect"
+	"regexp"
 	"sync"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	"github.com/mikhail5545/media-service-go/internal/ownertypes"
+	"github.com/mikhail5545/media-service-go/internal/tags"
 	"github.com/mikhail5545/media-service-go/internal/util/formatting"
 	"github.com/mikhail5545/media-service-go/internal/util/parsing"
 	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
 )
 
+// OwnerTypes is the registry of owner types MUX assets can be associated with. It is replaced with
+// the configured owner types on startup.
+var OwnerTypes = ownertypes.NewRegistry("lesson")
+
+var countryPattern = regexp.MustCompile(`^[A-Za-z]{2}$`)
+
 func (req GetFilter) Validate() error {
-	return validation.ValidateStruct(&req,
+	return validationutil.ValidateStruct(&req,
 		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Fields, fieldsRule),
 	)
 }
 
 func (req ListRequest) Validate() error {
-	return validation.ValidateStruct(&req,
+	return validationutil.ValidateStruct(&req,
 		validation.Field(&req.IDs, validationutil.UUIDRule(false)...),
 		validation.Field(&req.MuxUploadIDs, validation.Length(1, 255)),
 		validation.Field(&req.MuxAssetIDs, validation.Length(1, 255)),
@@ -47,15 +59,43 @@ func (req ListRequest) Validate() error {
 			IngestTypeLiveSRT,
 			IngestTypeOnDemandURL,
 		))),
+		validation.Field(&req.Tags, validation.Each(validation.Length(1, tags.MaxLength))),
+		validation.Field(&req.Query, validation.Length(1, 256)),
+		validation.Field(&req.CreatorID, validationutil.UUIDRule(false)...),
 		validation.Field(&req.OrderBy, validation.In(OrderCreatedAt, OrderUpdatedAt, OrderIngestType)),
 		validation.Field(&req.OrderDir, validation.In(OrderAscending, OrderDescending)),
+		validation.Field(&req.Fields, fieldsRule),
 		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
 		validation.Field(&req.PageToken, validation.Length(1, 2048)),
 	)
 }
 
+func (req GetByOwnerRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.OwnerID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.OwnerType, validation.Required, OwnerTypes.Rule()),
+	)
+}
+
+func (req ListByOwnerRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.OwnerID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.OwnerType, validation.Required, OwnerTypes.Rule()),
+		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
+		validation.Field(&req.PageToken, validationutil.UUIDRule(false)...),
+	)
+}
+
+func (req ListByCollectionRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.CollectionID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
+		validation.Field(&req.PageToken, validationutil.UUIDRule(false)...),
+	)
+}
+
 func (req ChangeStateRequest) Validate() error {
-	return validation.ValidateStruct(&req,
+	return validationutil.ValidateStruct(&req,
 		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
 		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
 		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
@@ -64,28 +104,224 @@ func (req ChangeStateRequest) Validate() error {
 }
 
 func (req CreateUploadURLRequest) Validate() error {
-	return validation.ValidateStruct(
+	return validationutil.ValidateStruct(&req,
 		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
 		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
 		validation.Field(&req.Title, validation.Length(1, 256)),
+		validation.Field(&req.DRMConfigurationID, validation.NilOrNotEmpty, validation.Length(1, 255)),
+		validation.Field(&req.OwnerType, OwnerTypes.Rule()),
+		validation.Field(&req.Encoding),
+	)
+}
+
+func (req AddPlaybackIDRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Policy, validation.Required, validation.In("public", "signed")),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+	)
+}
+
+func (req RemovePlaybackIDRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.PlaybackID, validation.Required, validation.Length(1, 255)),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+	)
+}
+
+func (req RotatePlaybackIDRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Policy, validation.Required, validation.In("public", "signed")),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+	)
+}
+
+func (req ManageTagsRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Tags, validation.Required, validation.Length(1, tags.MaxPerAsset), tags.Rule()),
+	)
+}
+
+func (req ListByTagRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Tag, validation.Required, validation.Length(1, tags.MaxLength)),
+		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
+		validation.Field(&req.PageToken, validation.Length(1, 2048)),
+	)
+}
+
+func (req SearchRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Query, validation.Required.When(req.Label == "").Error("q or label is required"), validation.Length(2, 256)),
+		validation.Field(&req.Label, validation.Length(1, 128)),
+		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
+		validation.Field(&req.PageToken, validation.Length(1, 2048)),
+	)
+}
+
+func (req UpdateMetadataRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Title, validation.Required.When(req.CreatorID == nil).Error("title or creator_id is required"), validation.Length(1, 256)),
+		validation.Field(&req.CreatorID, validationutil.UUIDRule(false)...),
 	)
 }
 
 func (req ManageOwnerRequest) Validate() error {
-	return validation.ValidateStruct(&req,
+	return validationutil.ValidateStruct(&req,
 		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
 		validation.Field(&req.OwnerID, validationutil.UUIDRule(true)...),
-		validation.Field(&req.OwnerType, validation.In("lesson")),
+		validation.Field(&req.OwnerType, OwnerTypes.Rule()),
+		validation.Field(&req.ArchiveReplaced, validation.When(!req.ReplaceExisting, validation.Empty.Error("requires replace_existing"))),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(req.ArchiveReplaced)...),
+		validation.Field(&req.AdminName, validation.When(req.ArchiveReplaced, validation.Required), validation.Length(1, 128)),
+	)
+}
+
+func (ref OwnerRef) Validate() error {
+	return validationutil.ValidateStruct(&ref,
+		validation.Field(&ref.OwnerID, validationutil.UUIDRule(true)...),
+		validation.Field(&ref.OwnerType, OwnerTypes.Rule()),
+	)
+}
+
+func (req UpdateOwnersRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Owners,
+			validation.Length(0, MaxOwnersPerRequest),
+			validation.Each(validation.NotNil),
+			validation.By(uniqueOwners),
+		),
+		validation.Field(&req.Revision, validation.NotNil, validation.Min(int64(0))),
 	)
 }
 
+// uniqueOwners rejects owner lists that mention the same owner more than once.
+func uniqueOwners(value any) error {
+	owners, _ := value.([]*OwnerRef)
+	seen := make(map[OwnerRef]struct{}, len(owners))
+	for _, owner := range owners {
+		if _, ok := seen[*owner]; ok {
+			return validation.NewError("validation_owners_unique", "must not contain duplicate owners")
+		}
+		seen[*owner] = struct{}{}
+	}
+	return nil
+}
+
 func (req GeneratePlaybackTokenRequest) Validate() error {
-	return validation.ValidateStruct(&req,
+	return validationutil.ValidateStruct(&req,
 		validation.Field(&req.AssetID, validationutil.UUIDRule(true)...),
 		validation.Field(&req.UserID, validationutil.UUIDRule(true)...),
 		validation.Field(&req.Expiration, validation.Required, validation.Min(int64(15*60))),
 		validation.Field(&req.UserAgent, validation.Length(1, 256)),
 		validation.Field(&req.SessionID, validationutil.UUIDRule(false)...),
+		validation.Field(&req.Country, validation.Match(countryPattern).Error("must be an ISO 3166-1 alpha-2 country code")),
+	)
+}
+
+func (req ListLanguagesRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
+
+func (req UpdateTrackRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.TrackID, validation.Required, validation.Length(1, 255)),
+		validation.Field(&req.Name,
+			validation.When(req.Default == nil, validation.NotNil.Error("name or default is required")),
+			validation.Length(0, 128),
+		),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+	)
+}
+
+// MaxChapters is the maximum number of chapters of a video.
+const MaxChapters = 100
+
+func (req ListChaptersRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
+
+func (req AddChapterRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Title, validation.Required, validation.Length(1, 256)),
+		validation.Field(&req.StartTime, validation.Min(float64(0))),
+		validation.Field(&req.ThumbnailTime, validation.Min(float64(0))),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+	)
+}
+
+func (req UpdateChapterRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.ChapterID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Title,
+			validation.Required.When(req.StartTime == nil && req.ThumbnailTime == nil).Error("title, start_time or thumbnail_time is required"),
+			validation.Length(1, 256),
+		),
+		validation.Field(&req.StartTime, validation.Min(float64(0))),
+		validation.Field(&req.ThumbnailTime, validation.Min(float64(0))),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+	)
+}
+
+func (req DeleteChapterRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.ChapterID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+	)
+}
+
+func (req SetPosterRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Time,
+			validation.Required.When(req.ImageID == nil).Error("time or image_id is required"),
+			validation.When(req.ImageID != nil, validation.Nil.Error("time and image_id are mutually exclusive")),
+			validation.Min(float64(0)),
+		),
+		validation.Field(&req.ImageID, validationutil.UUIDRule(false)...),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+	)
+}
+
+func (req ResetPosterRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+	)
+}
+
+func (req PlaybackInfoRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.TokenDelivery, validation.In(TokenDeliveryQuery, TokenDeliveryCookie, TokenDeliveryHeader)),
+	)
+}
+
+func (req DRMPlaybackRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
 	)
 }
 
@@ -97,11 +333,15 @@ var (
 // ValidFields returns all valid field names for the Asset struct.
 func ValidFields() map[string]bool {
 	validFieldsOnce.Do(func() {
-		validFields := make(map[string]bool)
+		validFields = make(map[string]bool)
 		t := reflect.TypeOf(Asset{})
 
 		for i := 0; i < t.NumField(); i++ {
 			field := t.Field(i)
+			// Relations are not columns of the asset record.
+			if field.Tag.Get("json") == "-" {
+				continue
+			}
 			if tag := field.Tag.Get("gorm"); tag != "" {
 				if col := parsing.ParseColumnTag(tag); col != "" {
 					validFields[col] = true
@@ -114,7 +354,96 @@ func ValidFields() map[string]bool {
 	return validFields
 }
 
+// fieldsRule validates the field masks of the Get and List requests.
+var fieldsRule = validationutil.FieldMaskRule(IsValidField, metadata.IsValidField)
+
 // IsValidField checks if a field name is valid for selection.
 func IsValidField(field string) bool {
 	return ValidFields()[field]
 }
+
+func (req ListStuckDeletionsRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.OlderThanMinutes, validation.Min(0), validation.Max(7*24*60)),
+		validation.Field(&req.Limit, validation.Min(0), validation.Max(MaxStuckDeletionsLimit)),
+	)
+}
+
+func (req ListStuckAssetsRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.OlderThanHours, validation.Min(0), validation.Max(30*24)),
+		validation.Field(&req.Limit, validation.Min(0), validation.Max(MaxStuckAssetsLimit)),
+	)
+}
+
+func (req ReprocessAssetRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
+
+func (req FindDuplicatesRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Limit, validation.Min(0), validation.Max(MaxDuplicateGroupsLimit)),
+	)
+}
+
+func (req ValidateAssetsRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.IDs,
+			validation.Required,
+			validation.Length(1, MaxValidateAssets),
+			validation.Each(validationutil.UUIDRule(true)...),
+		),
+	)
+}
+
+func (req WatchAssetRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.TimeoutSeconds, validation.Min(0), validation.Max(int(MaxWatchTimeout/time.Second))),
+	)
+}
+
+func (req ListVersionsRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.OwnerID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.OwnerType, validation.Required, OwnerTypes.Rule()),
+	)
+}
+
+func (req RollbackVersionRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.OwnerID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.OwnerType, validation.Required, OwnerTypes.Rule()),
+		validation.Field(&req.Number, validation.Required, validation.Min(1)),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(req.ArchiveCurrent)...),
+		validation.Field(&req.AdminName, validation.When(req.ArchiveCurrent, validation.Required), validation.Length(1, 128)),
+	)
+}
+
+func (req SchedulePublishingRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.PublishAt, validation.When(req.UnpublishAt == nil, validation.Required.Error("publish_at or unpublish_at is required"))),
+		validation.Field(&req.UnpublishAt, validation.When(req.PublishAt != nil && req.UnpublishAt != nil,
+			validation.By(func(any) error {
+				if !req.UnpublishAt.After(*req.PublishAt) {
+					return validation.NewError("validation_unpublish_at_order", "must be after publish_at")
+				}
+				return nil
+			}))),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+		validation.Field(&req.Note, validation.Length(0, 512)),
+	)
+}
+
+func (req CancelPublishingRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+		validation.Field(&req.Note, validation.Length(0, 512)),
+	)
+}
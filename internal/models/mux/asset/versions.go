@@ -0,0 +1,26 @@
+package asset
+
+// ListVersionsRequest lists the version history of the videos of an owner, oldest first.
+type ListVersionsRequest struct {
+	OwnerType string `param:"owner_type" json:"-"`
+	OwnerID   string `param:"owner_id" json:"-"`
+}
+
+// RollbackVersionRequest makes the asset of an earlier version of an owner current again. The owner is
+// associated with the asset and removed from its current asset by a single saga. ArchiveCurrent archives
+// the current asset if it is left without owners, on behalf of the admin identified by AdminID and AdminName.
+type RollbackVersionRequest struct {
+	OwnerType string `param:"owner_type" json:"-"`
+	OwnerID   string `param:"owner_id" json:"-"`
+	Number    int    `param:"number" json:"-"`
+
+	ArchiveCurrent bool   `json:"archive_current"`
+	AdminID        string `json:"admin_id"`
+	AdminName      string `json:"admin_name"`
+}
+
+// SetAdmin sets the admin recorded with the rollback.
+func (req *RollbackVersionRequest) SetAdmin(id, name string) {
+	req.AdminID = id
+	req.AdminName = name
+}
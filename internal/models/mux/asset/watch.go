@@ -0,0 +1,53 @@
+package asset
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultWatchTimeout is the duration of a watch that does not specify a timeout.
+	DefaultWatchTimeout = 5 * time.Minute
+	// MaxWatchTimeout is the longest duration of a single watch.
+	MaxWatchTimeout = 30 * time.Minute
+)
+
+// WatchAssetRequest watches the status of an asset until it is ready or errored.
+type WatchAssetRequest struct {
+	ID string `param:"id" json:"-"`
+	// TimeoutSeconds ends the watch if the asset has not finished processing in time,
+	// [DefaultWatchTimeout] is used if it is zero.
+	TimeoutSeconds int `query:"timeout_seconds" json:"-"`
+}
+
+// StatusUpdate is the status of a watched asset, it is sent when the watch starts and on every change.
+type StatusUpdate struct {
+	AssetID      uuid.UUID    `json:"asset_id"`
+	Status       Status       `json:"status"`
+	UploadStatus UploadStatus `json:"upload_status,omitempty"`
+	// Final is set on the last update of the watch, when the asset is ready, errored or deleted.
+	Final     bool      `json:"final"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Timeout returns the duration of the watch.
+func (req WatchAssetRequest) Timeout() time.Duration {
+	if req.TimeoutSeconds == 0 {
+		return DefaultWatchTimeout
+	}
+	return time.Duration(req.TimeoutSeconds) * time.Second
+}
+
+// IsFinal reports whether the asset with the status and upload status does not change anymore
+// without an admin action.
+func IsFinal(status Status, uploadStatus UploadStatus) bool {
+	switch {
+	case status == StatusBroken, status == StatusPendingDelete:
+		return true
+	case uploadStatus == UploadStatusReady, uploadStatus == UploadStatusErrored, uploadStatus == UploadStatusDeleted:
+		return true
+	default:
+		return false
+	}
+}
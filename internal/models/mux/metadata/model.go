@@ -1,6 +1,13 @@
 package metadata
 
-import "github.com/mikhail5545/media-service-go/internal/models/mux/types"
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/mikhail5545/media-service-go/internal/models/enrichment"
+	"github.com/mikhail5545/media-service-go/internal/models/mux/types"
+)
 
 // AssetMetadata represents the metadata for a MUX asset stored in MongoDB.
 type AssetMetadata struct {
@@ -11,6 +18,22 @@ type AssetMetadata struct {
 	Owners      []*Owner                      `bson:"owners" json:"owners"`
 	Tracks      []*types.MuxWebhookTrack      `bson:"tracks" json:"tracks"`
 	PlaybackIDs []*types.MuxWebhookPlaybackID `bson:"playback_ids" json:"playback_ids"`
+	// TrackNames are the display names set by admins for audio and text tracks, keyed by track ID.
+	// They take precedence over the names reported by MUX.
+	TrackNames map[string]string `bson:"track_names,omitempty" json:"track_names,omitempty"`
+	// DefaultAudioTrackID is the audio track players should select first. The primary audio track is
+	// the default if it is nil.
+	DefaultAudioTrackID *string `bson:"default_audio_track_id,omitempty" json:"default_audio_track_id,omitempty"`
+	// Chapters are the chapter markers of the video, ordered by their start time.
+	Chapters []*Chapter `bson:"chapters,omitempty" json:"chapters,omitempty"`
+	// Enrichment is set by the enrichment pipeline once the asset is ready.
+	Enrichment *enrichment.Enrichment `bson:"enrichment,omitempty" json:"enrichment,omitempty"`
+	// Revision is incremented by every change of the metadata, it guards the owners against concurrent
+	// updates. Metadata written before revisions were introduced has revision 0.
+	Revision int64 `bson:"revision" json:"revision"`
+	// OwnerCount is the number of owners. It is maintained by the repository on every write, so the
+	// unowned assets can be looked up by index.
+	OwnerCount int `bson:"owner_count" json:"owner_count"`
 }
 
 // Owner represents an entity that is associated with an asset.
@@ -18,3 +41,34 @@ type Owner struct {
 	OwnerID   string `bson:"owner_id" json:"owner_id"`
 	OwnerType string `bson:"owner_type" json:"owner_type"`
 }
+
+// Chapter is a chapter marker on the timeline of a video.
+type Chapter struct {
+	ID    string `bson:"id" json:"id"`
+	Title string `bson:"title" json:"title"`
+	// StartTime is the start of the chapter in seconds.
+	StartTime float64 `bson:"start_time" json:"start_time"`
+	// ThumbnailTime is the time in seconds of the frame used as the chapter thumbnail. The frame at
+	// StartTime is used if it is nil.
+	ThumbnailTime *float64 `bson:"thumbnail_time,omitempty" json:"thumbnail_time,omitempty"`
+}
+
+var (
+	validFields     map[string]bool
+	validFieldsOnce sync.Once
+)
+
+// IsValidField checks if a field name is valid for projection. Nested fields are checked by their
+// first segment, e.g. "owners.owner_type".
+func IsValidField(field string) bool {
+	validFieldsOnce.Do(func() {
+		validFields = make(map[string]bool)
+		t := reflect.TypeOf(AssetMetadata{})
+		for i := 0; i < t.NumField(); i++ {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("bson"), ",")
+			validFields[name] = true
+		}
+	})
+	name, _, _ := strings.Cut(field, ".")
+	return validFields[name]
+}
@@ -1,6 +1,12 @@
 package types
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
 
 // MuxWebhook represents the mux webhook payload.
 // See mux API [webhook reference] for more details.
@@ -34,7 +40,8 @@ type MuxWebhookEnvironment struct {
 // MuxWebhookData represents the mux webhook data object.
 type MuxWebhookData struct {
 	// Unique identifier for the asset. Max 255 characters.
-	ID        string    `json:"id"`
+	ID string `json:"id"`
+	// Time the asset was created. MUX sends it as unix seconds, see [MuxWebhookData.UnmarshalJSON].
 	CreatedAt time.Time `json:"created_at"`
 	// The status of the asset
 	//
@@ -58,7 +65,7 @@ type MuxWebhookData struct {
 	// at lower frame rates depending on the device and bandwidth, however it cannot be delivered at a higher
 	// value than is stored. This field may return `-1` if the frame rate of the input cannot be reliably
 	// determined.
-	MaxStoredFrameRate *string `json:"max_stored_frame_rate,omitempty"`
+	MaxStoredFrameRate *float64 `json:"max_stored_frame_rate,omitempty"`
 	// The aspect ratio of the asset.
 	//
 	// 	"width:height" -> "16:9"
@@ -90,6 +97,41 @@ type MuxWebhookData struct {
 	Progress MuxWebhookProgress `json:"progress"`
 }
 
+// UnmarshalJSON decodes the data object. MUX sends `created_at` as unix seconds, either as a number
+// or as a numeric string, RFC 3339 timestamps are accepted as well.
+func (d *MuxWebhookData) UnmarshalJSON(b []byte) error {
+	type plain MuxWebhookData
+	aux := struct {
+		*plain
+		CreatedAt json.RawMessage `json:"created_at"`
+	}{plain: (*plain)(d)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	createdAt, err := parseTimestamp(aux.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("invalid created_at: %w", err)
+	}
+	d.CreatedAt = createdAt
+	return nil
+}
+
+func parseTimestamp(raw json.RawMessage) (time.Time, error) {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return time.Time{}, nil
+	}
+	s := string(raw)
+	if raw[0] == '"' {
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return time.Time{}, err
+		}
+	}
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(secs, 0).UTC(), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
 // MuxWebhookMeta represents mux webhook meta object.
 // Customer provided metadata about this asset.
 //
@@ -0,0 +1,268 @@
+package types
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// persistedFields lists the payload fields the MUX service stores, with their JSON type. Paths
+// descend into arrays with "[]".
+var persistedFields = []struct {
+	path string
+	kind string
+}{
+	{"type", "string"},
+	{"id", "string"},
+	{"data.id", "string"},
+	{"data.created_at", "number"},
+	{"data.status", "string"},
+	{"data.duration", "number"},
+	{"data.resolution_tier", "string"},
+	{"data.aspect_ratio", "string"},
+	{"data.ingest_type", "string"},
+	{"data.upload_id", "string"},
+	{"data.progress.state", "string"},
+	{"data.playback_ids[].id", "string"},
+	{"data.playback_ids[].policy", "string"},
+	{"data.errors.type", "string"},
+	{"data.errors.messages", "array"},
+	{"data.meta.title", "string"},
+	{"data.meta.creator_id", "string"},
+	{"data.meta.external_id", "string"},
+	{"data.tracks[].id", "string"},
+	{"data.tracks[].type", "string"},
+}
+
+type fixture struct {
+	name string
+	raw  []byte
+}
+
+func loadFixtures(t *testing.T) []fixture {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join("testdata", "webhooks", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no webhook fixtures found")
+	}
+	fixtures := make([]fixture, 0, len(paths))
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fixtures = append(fixtures, fixture{name: filepath.Base(path), raw: raw})
+	}
+	return fixtures
+}
+
+func TestMuxWebhookFixturesRoundTrip(t *testing.T) {
+	for _, f := range loadFixtures(t) {
+		t.Run(f.name, func(t *testing.T) {
+			var decoded MuxWebhook
+			if err := json.Unmarshal(f.raw, &decoded); err != nil {
+				t.Fatalf("failed to decode fixture: %v", err)
+			}
+			if !strings.HasPrefix(f.name, decoded.Type+".") {
+				t.Errorf("fixture %s holds a %q event", f.name, decoded.Type)
+			}
+			if decoded.Data.CreatedAt.IsZero() || decoded.Data.CreatedAt.Before(time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("data.created_at decoded to %v", decoded.Data.CreatedAt)
+			}
+
+			encoded, err := json.Marshal(&decoded)
+			if err != nil {
+				t.Fatalf("failed to encode webhook: %v", err)
+			}
+			var again MuxWebhook
+			if err := json.Unmarshal(encoded, &again); err != nil {
+				t.Fatalf("failed to decode encoded webhook: %v", err)
+			}
+			reencoded, err := json.Marshal(&again)
+			if err != nil {
+				t.Fatalf("failed to encode webhook: %v", err)
+			}
+			if string(encoded) != string(reencoded) {
+				t.Errorf("webhook changed in round trip:\n got %s\nwant %s", reencoded, encoded)
+			}
+		})
+	}
+}
+
+func TestMuxWebhookPersistedFields(t *testing.T) {
+	fixtures := loadFixtures(t)
+	for _, field := range persistedFields {
+		t.Run(field.path, func(t *testing.T) {
+			if err := checkStructField(reflect.TypeOf(MuxWebhook{}), strings.Split(field.path, "."), field.kind); err != "" {
+				t.Fatalf("MuxWebhook: %s", err)
+			}
+
+			seen := false
+			for _, f := range fixtures {
+				var raw map[string]any
+				if err := json.Unmarshal(f.raw, &raw); err != nil {
+					t.Fatalf("%s: %v", f.name, err)
+				}
+				var decoded MuxWebhook
+				if err := json.Unmarshal(f.raw, &decoded); err != nil {
+					t.Fatalf("%s: %v", f.name, err)
+				}
+				rawValues := lookupJSON(raw, strings.Split(field.path, "."))
+				decodedValues := lookupStruct(reflect.ValueOf(decoded), strings.Split(field.path, "."))
+				for _, v := range rawValues {
+					seen = true
+					if kind := jsonKind(v); kind != field.kind {
+						t.Errorf("%s: %s is a %s, want %s", f.name, field.path, kind, field.kind)
+					}
+				}
+				if len(rawValues) == 0 {
+					continue
+				}
+				if len(rawValues) != len(decodedValues) {
+					t.Errorf("%s: %s has %d values in the payload, %d decoded", f.name, field.path, len(rawValues), len(decodedValues))
+				}
+				for _, v := range decodedValues {
+					if v.IsZero() {
+						t.Errorf("%s: %s decoded to the zero value", f.name, field.path)
+					}
+				}
+			}
+			if !seen {
+				t.Errorf("%s is not present in any fixture", field.path)
+			}
+		})
+	}
+}
+
+// lookupJSON returns the non-null values at path.
+func lookupJSON(v any, path []string) []any {
+	if v == nil {
+		return nil
+	}
+	if len(path) == 0 {
+		return []any{v}
+	}
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+	name, isArray := strings.CutSuffix(path[0], "[]")
+	child := obj[name]
+	if !isArray {
+		return lookupJSON(child, path[1:])
+	}
+	items, _ := child.([]any)
+	var values []any
+	for _, item := range items {
+		values = append(values, lookupJSON(item, path[1:])...)
+	}
+	return values
+}
+
+// lookupStruct returns the non-nil values at path, following json tags.
+func lookupStruct(v reflect.Value, path []string) []reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if len(path) == 0 {
+		return []reflect.Value{v}
+	}
+	name, isArray := strings.CutSuffix(path[0], "[]")
+	field, ok := fieldByTag(v.Type(), name)
+	if !ok {
+		return nil
+	}
+	child := v.FieldByIndex(field.Index)
+	if !isArray {
+		return lookupStruct(child, path[1:])
+	}
+	var values []reflect.Value
+	for i := range child.Len() {
+		values = append(values, lookupStruct(child.Index(i), path[1:])...)
+	}
+	return values
+}
+
+// checkStructField reports why the field at path can't hold a JSON value of kind.
+func checkStructField(t reflect.Type, path []string, kind string) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if len(path) == 0 {
+		if !goKindMatches(t, kind) {
+			return "field of type " + t.String() + " can't hold a JSON " + kind
+		}
+		return ""
+	}
+	name, isArray := strings.CutSuffix(path[0], "[]")
+	field, ok := fieldByTag(t, name)
+	if !ok {
+		return "no field tagged " + name + " in " + t.Name()
+	}
+	ft := field.Type
+	if isArray {
+		if ft.Kind() != reflect.Slice {
+			return name + " is not a slice"
+		}
+		ft = ft.Elem()
+	}
+	return checkStructField(ft, path[1:], kind)
+}
+
+func fieldByTag(t reflect.Type, name string) (reflect.StructField, bool) {
+	if t.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func goKindMatches(t reflect.Type, kind string) bool {
+	switch kind {
+	case "string":
+		return t.Kind() == reflect.String
+	case "number":
+		switch t.Kind() {
+		case reflect.Int, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+			return true
+		}
+		// created_at is decoded from unix seconds by MuxWebhookData.UnmarshalJSON.
+		return t == reflect.TypeOf(time.Time{})
+	case "array":
+		return t.Kind() == reflect.Slice
+	case "object":
+		return t.Kind() == reflect.Struct || t.Kind() == reflect.Map
+	}
+	return false
+}
+
+func jsonKind(v any) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case bool:
+		return "bool"
+	}
+	return "null"
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package outbox provides models for the transactional outbox, which is used to deliver
+// notifications to external services (product-service) reliably after the local transaction commits.
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EventType identifies the notification that should be delivered to the external service.
+type EventType string
+
+const (
+	// EventVideoBroken notifies product-service that the video asset is broken.
+	EventVideoBroken EventType = "video.broken"
+	// EventVideoForceDeleted notifies product-service that the video asset was deleted and all associations must be removed.
+	EventVideoForceDeleted EventType = "video.force_deleted"
+	// EventImageBroken notifies product-service that the image asset is broken.
+	EventImageBroken EventType = "image.broken"
+	// EventImageDeleted notifies product-service that the image asset was deleted.
+	EventImageDeleted EventType = "image.deleted"
+	// EventImagesForceDeleted notifies product-service that the image assets were deleted and all associations must be removed.
+	EventImagesForceDeleted EventType = "image.force_deleted_batch"
+	// EventImageEnrich schedules the enrichment of the uploaded image, it is handled by the service itself.
+	EventImageEnrich EventType = "image.enrich"
+	// EventVideoEnrich schedules the enrichment of the ready video, it is handled by the service itself.
+	EventVideoEnrich EventType = "video.enrich"
+	// EventVideoDeleteRemote completes the permanent deletion of the video asset by deleting it from MUX
+	// and the databases, it is handled by the service itself.
+	EventVideoDeleteRemote EventType = "video.delete_remote"
+	// EventImageDeleteRemote completes the permanent deletion of the image asset by deleting it from
+	// Cloudinary and the databases, it is handled by the service itself.
+	EventImageDeleteRemote EventType = "image.delete_remote"
+)
+
+// Status represents the delivery status of the outbox event.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusDelivered Status = "delivered"
+	StatusFailed    Status = "failed"
+)
+
+// Event represents a single outbox record. Events are persisted in the same database transaction
+// as the state change they describe and are delivered asynchronously by the outbox dispatcher.
+type Event struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid" json:"id"` // UUIDv7
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Type    EventType `gorm:"type:varchar(64);not null;index" json:"type"`
+	Payload []byte    `gorm:"type:jsonb;not null" json:"payload"`
+	Status  Status    `gorm:"type:varchar(32);not null;default:'pending';index:idx_outbox_status_next_attempt" json:"status"`
+
+	// Attempts is the number of delivery attempts made so far.
+	Attempts int `gorm:"not null;default:0" json:"attempts"`
+	// NextAttemptAt is the earliest moment the dispatcher may try to deliver the event.
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_outbox_status_next_attempt" json:"next_attempt_at"`
+	LastError     *string    `gorm:"type:varchar(1024);null" json:"last_error,omitempty"`
+	DeliveredAt   *time.Time `gorm:"null" json:"delivered_at,omitempty"`
+}
+
+func (*Event) TableName() string {
+	return "outbox_events"
+}
+
+func (e *Event) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID, err = uuid.NewV7()
+		if err != nil {
+			return err
+		}
+	}
+	if e.Status == "" {
+		e.Status = StatusPending
+	}
+	if e.NextAttemptAt.IsZero() {
+		e.NextAttemptAt = time.Now()
+	}
+	return nil
+}
+
+// NewEvent creates a new pending outbox event with JSON encoded payload.
+func NewEvent(eventType EventType, payload any) (*Event, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode outbox event payload: %w", err)
+	}
+	return &Event{
+		Type:    eventType,
+		Payload: b,
+		Status:  StatusPending,
+	}, nil
+}
+
+// BrokenPayload is the payload for [EventVideoBroken] and [EventImageBroken] events.
+type BrokenPayload struct {
+	AssetID   uuid.UUID `json:"asset_id"`
+	AdminID   uuid.UUID `json:"admin_id"`
+	AdminName string    `json:"admin_name"`
+	Reason    string    `json:"reason"`
+}
+
+// EnrichPayload is the payload for [EventImageEnrich] and [EventVideoEnrich] events.
+type EnrichPayload struct {
+	AssetID uuid.UUID `json:"asset_id"`
+}
+
+// DeleteRemotePayload is the payload for [EventVideoDeleteRemote] and [EventImageDeleteRemote] events.
+type DeleteRemotePayload struct {
+	AssetID uuid.UUID `json:"asset_id"`
+}
+
+// DeletePayload is the payload for [EventVideoForceDeleted], [EventImageDeleted] and [EventImagesForceDeleted] events.
+type DeletePayload struct {
+	AssetIDs uuid.UUIDs `json:"asset_ids"`
+}
@@ -0,0 +1,38 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package playback
+
+// RevokeRequest revokes the active playback tokens of a user, or only those of one of the user's sessions.
+type RevokeRequest struct {
+	UserID    string  `json:"user_id"`
+	SessionID *string `json:"session_id"`
+}
+
+// ValidateRequest checks that a playback token is still allowed. The optional session fields are compared
+// with the ones the token was issued for.
+type ValidateRequest struct {
+	Token     string  `json:"token"`
+	UserID    *string `json:"user_id"`
+	SessionID *string `json:"session_id"`
+	UserAgent *string `json:"user_agent"`
+}
+
+// RevokeResult reports how many tokens were revoked.
+type RevokeResult struct {
+	Revoked int64 `json:"revoked"`
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package playback provides models for playback sessions, which record the playback tokens issued to users
+// so they can be revoked and validated.
+package playback
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Session records a playback token issued to a user. Tokens issued with the same session ID belong to
+// the same playback session, tokens issued without one are sessions of their own.
+type Session struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid" json:"id"` // UUIDv7
+	CreatedAt time.Time `json:"created_at"`
+
+	AssetID   uuid.UUID  `gorm:"type:uuid;not null" json:"asset_id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	SessionID *uuid.UUID `gorm:"type:uuid;null;index" json:"session_id,omitempty"`
+	UserAgent *string    `gorm:"type:varchar(256);null" json:"user_agent,omitempty"`
+	// TokenHash is the hex encoded SHA-256 hash of the token, tokens themselves are never stored.
+	TokenHash string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null;index" json:"expires_at"`
+	RevokedAt *time.Time `gorm:"null" json:"revoked_at,omitempty"`
+}
+
+func (*Session) TableName() string {
+	return "playback_sessions"
+}
+
+func (s *Session) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID, err = uuid.NewV7()
+	}
+	return err
+}
+
+// Grant describes the tokens issued for a single playback of an asset.
+type Grant struct {
+	AssetID   uuid.UUID
+	UserID    uuid.UUID
+	SessionID *uuid.UUID
+	UserAgent *string
+	ExpiresAt time.Time
+	Tokens    []string
+}
+
+// Reasons a token is no longer allowed, see [Validation].
+const (
+	ReasonUnknown  = "unknown"
+	ReasonExpired  = "expired"
+	ReasonRevoked  = "revoked"
+	ReasonMismatch = "session_mismatch"
+)
+
+// Validation is the result of a token validation.
+type Validation struct {
+	Valid bool `json:"valid"`
+	// Reason is set if the token is not valid.
+	Reason  string   `json:"reason,omitempty"`
+	Session *Session `json:"session,omitempty"`
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package playback
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+func (req RevokeRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.UserID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.SessionID, validationutil.UUIDRule(false)...),
+	)
+}
+
+func (req ValidateRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Token, validation.Required, validation.Length(1, 4096)),
+		validation.Field(&req.UserID, validationutil.UUIDRule(false)...),
+		validation.Field(&req.SessionID, validationutil.UUIDRule(false)...),
+		validation.Field(&req.UserAgent, validation.Length(1, 256)),
+	)
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package publishing provides models for the publication schedule of assets, which makes assets uploaded
+// ahead of time deliverable to end users only between their publish and unpublish times.
+package publishing
+
+import "time"
+
+// State is the publishing state of an asset. Only published assets are delivered to end users.
+type State string
+
+const (
+	// StatePublished assets are delivered to end users. Assets without a schedule are published.
+	StatePublished State = "published"
+	// StateScheduled assets are published once their publish time passes.
+	StateScheduled State = "scheduled"
+	// StateUnpublished assets were unpublished at their unpublish time or had their scheduled
+	// publication cancelled.
+	StateUnpublished State = "unpublished"
+)
+
+// Provider identifies the asset type whose publishing states are transitioned.
+type Provider string
+
+const (
+	ProviderMux        Provider = "mux"
+	ProviderCloudinary Provider = "cloudinary"
+)
+
+// StateAt returns the publishing state of an asset with the stored state and schedule at the moment now.
+// The schedule takes precedence over the stored state, which may lag behind until the transition worker runs.
+func StateAt(state State, publishAt, unpublishAt *time.Time, now time.Time) State {
+	if unpublishAt != nil && !now.Before(*unpublishAt) {
+		return StateUnpublished
+	}
+	if publishAt != nil {
+		if now.Before(*publishAt) {
+			return StateScheduled
+		}
+		return StatePublished
+	}
+	// A scheduled asset whose publish time was removed is not published.
+	if state == StateScheduled {
+		return StateUnpublished
+	}
+	return state
+}
+
+// Schedule is the publication schedule of an asset.
+type Schedule struct {
+	State       State      `json:"state"`
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+}
+
+// TransitionOptions configure a single run of the transition worker.
+type TransitionOptions struct {
+	// Now is the moment the due transitions are computed for.
+	Now time.Time
+	// BatchSize limits the number of assets transitioned per provider in a single run.
+	BatchSize int
+}
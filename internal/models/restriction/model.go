@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package restriction provides models for the playback restrictions, which limit the countries and the
+// referrer domains the playback tokens of assets may be used from. Countries are checked when a token
+// is issued, referrer domains are enforced by MUX through the playback restriction objects the
+// policies are synchronized to.
+package restriction
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultPolicy names the policy of the assets none of whose owner types has a policy of its own.
+const DefaultPolicy = "default"
+
+// OwnerTypePolicy returns the name of the policy overriding the default one for an owner type.
+func OwnerTypePolicy(ownerType string) string {
+	return "owner_type:" + ownerType
+}
+
+// Policy restricts where the playback tokens of an asset may be used. The zero value allows any use.
+type Policy struct {
+	// AllowedCountries are the ISO 3166-1 alpha-2 codes of the countries tokens may be issued to,
+	// empty allows every country.
+	AllowedCountries []string `json:"allowed_countries,omitempty"`
+	// AllowedReferrers are the domains the player may be embedded in, e.g. "example.com" or
+	// "*.example.com" for its subdomains. Empty does not restrict the referrer.
+	AllowedReferrers []string `json:"allowed_referrers,omitempty"`
+	// AllowNoReferrer lets requests without a Referer header through, e.g. of native apps. It only
+	// applies if AllowedReferrers restricts the referrer.
+	AllowNoReferrer bool `json:"allow_no_referrer"`
+}
+
+// RestrictsReferrer reports whether the policy needs a MUX playback restriction.
+func (p Policy) RestrictsReferrer() bool {
+	return len(p.AllowedReferrers) > 0
+}
+
+// AllowsCountry reports whether tokens may be issued to a viewer in the country, an empty country is
+// only allowed if the policy does not restrict countries.
+func (p Policy) AllowsCountry(country string) bool {
+	if len(p.AllowedCountries) == 0 {
+		return true
+	}
+	return country != "" && slices.ContainsFunc(p.AllowedCountries, func(allowed string) bool {
+		return strings.EqualFold(allowed, country)
+	})
+}
+
+// AllowsReferrer reports whether the referrer URL, or a bare host name, matches one of the allowed
+// domains. An empty referrer is allowed if AllowNoReferrer is set.
+func (p Policy) AllowsReferrer(referrer string) bool {
+	if !p.RestrictsReferrer() {
+		return true
+	}
+	if referrer == "" {
+		return p.AllowNoReferrer
+	}
+	host := referrer
+	if u, err := url.Parse(referrer); err == nil && u.Host != "" {
+		host = u.Hostname()
+	}
+	host = strings.ToLower(host)
+	for _, allowed := range p.AllowedReferrers {
+		allowed = strings.ToLower(allowed)
+		switch {
+		case allowed == "*":
+			return true
+		case strings.HasPrefix(allowed, "*."):
+			if strings.HasSuffix(host, allowed[1:]) {
+				return true
+			}
+		case host == allowed:
+			return true
+		}
+	}
+	return false
+}
+
+// Policies holds the default policy and the policies overriding it for the assets of owner types.
+type Policies struct {
+	Default Policy
+	// OwnerTypes holds the overrides keyed by the owner type.
+	OwnerTypes map[string]Policy
+}
+
+// Resolve returns the name and the policy of an asset whose owners have the owner types. The first
+// owner type with a policy of its own wins, assets without one get the default policy.
+func (p *Policies) Resolve(ownerTypes []string) (string, Policy) {
+	if p == nil {
+		return DefaultPolicy, Policy{}
+	}
+	for _, ownerType := range ownerTypes {
+		if policy, ok := p.OwnerTypes[ownerType]; ok {
+			return OwnerTypePolicy(ownerType), policy
+		}
+	}
+	return DefaultPolicy, p.Default
+}
+
+// Named returns every policy keyed by its name.
+func (p *Policies) Named() map[string]Policy {
+	if p == nil {
+		return nil
+	}
+	named := make(map[string]Policy, len(p.OwnerTypes)+1)
+	named[DefaultPolicy] = p.Default
+	for ownerType, policy := range p.OwnerTypes {
+		named[OwnerTypePolicy(ownerType)] = policy
+	}
+	return named
+}
+
+// MuxRestriction records the MUX playback restriction a policy was synchronized to in an environment.
+// The referrer rules are stored as they were sent to MUX, so changed policies can be detected.
+type MuxRestriction struct {
+	Environment string    `gorm:"primaryKey;type:varchar(64)" json:"environment"`
+	Policy      string    `gorm:"primaryKey;type:varchar(128)" json:"policy"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	RestrictionID   string   `gorm:"type:varchar(255);not null" json:"restriction_id"`
+	AllowedDomains  []string `gorm:"type:jsonb;serializer:json;not null" json:"allowed_domains"`
+	AllowNoReferrer bool     `gorm:"not null" json:"allow_no_referrer"`
+}
+
+func (*MuxRestriction) TableName() string {
+	return "mux_playback_restrictions"
+}
+
+// Matches reports whether the restriction enforces the referrer rules of the policy.
+func (r *MuxRestriction) Matches(policy Policy) bool {
+	return slices.Equal(r.AllowedDomains, policy.AllowedReferrers) && r.AllowNoReferrer == policy.AllowNoReferrer
+}
+
+// SyncRequest synchronizes the policies with the MUX playback restrictions of the request environment.
+type SyncRequest struct{}
+
+// SyncResult lists the MUX playback restrictions of the environment after a synchronization.
+type SyncResult struct {
+	Restrictions []*MuxRestriction `json:"restrictions"`
+	Created      int               `json:"created"`
+	Updated      int               `json:"updated"`
+	Deleted      int               `json:"deleted"`
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package retention provides models for the retention policy subsystem, which permanently
+// removes soft-deleted (archived) assets after the configured TTL.
+package retention
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Provider identifies the external asset provider the purged asset belonged to.
+type Provider string
+
+const (
+	ProviderMux        Provider = "mux"
+	ProviderCloudinary Provider = "cloudinary"
+)
+
+// PurgeRecord represents a single entry of the purge audit log.
+// A record is written for every asset processed by the retention worker, including dry runs
+// and failed attempts.
+type PurgeRecord struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid" json:"id"` // UUIDv7
+	CreatedAt time.Time `json:"created_at"`
+	// RunID groups all records produced by a single retention worker run.
+	RunID    uuid.UUID `gorm:"type:uuid;not null;index" json:"run_id"`
+	Provider Provider  `gorm:"type:varchar(32);not null;index" json:"provider"`
+	// AssetID is the internal identifier of the purged asset.
+	AssetID uuid.UUID `gorm:"type:uuid;not null;index" json:"asset_id"`
+	// ExternalID is the provider identifier of the asset (MUX asset ID or Cloudinary public ID).
+	ExternalID *string `gorm:"type:varchar(512);null" json:"external_id,omitempty"`
+	// ArchivedAt is the moment the asset was soft-deleted.
+	ArchivedAt time.Time `json:"archived_at"`
+	// DryRun indicates that the asset was only selected for purge, but nothing was deleted.
+	DryRun bool `gorm:"not null;default:false" json:"dry_run"`
+	// Succeeded indicates that the asset was deleted. It is always false for dry runs.
+	Succeeded bool    `gorm:"not null;default:false" json:"succeeded"`
+	Error     *string `gorm:"type:varchar(1024);null" json:"error,omitempty"`
+}
+
+// maxErrorLength is the length of the error column in bytes.
+const maxErrorLength = 1024
+
+// Fail records the error of a failed purge. The message is truncated to the length of the error
+// column without splitting a multi-byte rune, since Postgres rejects text that is not valid UTF-8.
+func (r *PurgeRecord) Fail(err error) {
+	msg := err.Error()
+	if n := maxErrorLength; len(msg) > n {
+		for n > 0 && !utf8.RuneStart(msg[n]) {
+			n--
+		}
+		msg = msg[:n]
+	}
+	r.Error = &msg
+}
+
+func (*PurgeRecord) TableName() string {
+	return "purge_audit_log"
+}
+
+func (r *PurgeRecord) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID, err = uuid.NewV7()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PurgeOptions holds parameters for a single purge pass of an asset provider.
+type PurgeOptions struct {
+	RunID uuid.UUID
+	// Cutoff is the moment before which soft-deleted assets are considered expired.
+	Cutoff time.Time
+	// RetryAfter skips assets whose purge failed after this moment, so that assets failing repeatedly
+	// cannot fill every batch. A zero value retries them on every pass.
+	RetryAfter time.Time
+	// BatchSize limits the number of assets processed in a single pass.
+	BatchSize int
+	// DryRun only reports assets that would be purged without deleting anything.
+	DryRun bool
+}
+
+// RunRequest triggers a retention run outside the worker schedule.
+type RunRequest struct {
+	// DryRun only reports the assets that would be purged. A worker configured for dry runs never
+	// deletes anything, whatever the request says.
+	DryRun bool `json:"dry_run"`
+}
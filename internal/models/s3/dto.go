@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package s3 holds the requests of the endpoints specific to the S3-compatible object storage of raw
+// file assets. The assets themselves are [media.Asset] records of the "s3" provider.
+package s3
+
+import (
+	"time"
+
+	s3api "github.com/mikhail5545/media-service-go/internal/apiclients/s3"
+)
+
+// KindFile is the kind of the assets stored in the object storage.
+const KindFile = "file"
+
+// AttributeFilename is the asset attribute holding the original name of the uploaded file.
+const AttributeFilename = "filename"
+
+// CreateUploadURLRequest creates a pending file asset and a presigned upload of its content. The
+// declared size, content type and checksum are signed, the object storage rejects uploads that do
+// not match them.
+type CreateUploadURLRequest struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Bytes       int64  `json:"bytes"`
+	// ChecksumSHA256 is the optional base64 encoded SHA-256 of the file.
+	ChecksumSHA256 string `json:"checksum_sha256"`
+	Title          string `json:"title"`
+	AdminID        string `json:"admin_id"`
+	AdminName      string `json:"admin_name"`
+}
+
+type UploadURL struct {
+	AssetID string                  `json:"asset_id"`
+	Upload  *s3api.PresignedRequest `json:"upload"`
+}
+
+// ConfirmUploadRequest activates a pending file asset once its content was uploaded.
+type ConfirmUploadRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+type DownloadURLRequest struct {
+	ID string `param:"id" json:"-"`
+	// Filename overrides the name browsers save the file under, the original file name is used
+	// when it is empty.
+	Filename string `query:"filename"`
+}
+
+// DownloadURL is a presigned download of the content of an active file asset.
+type DownloadURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package s3
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+// sha256Rule accepts base64 encoded SHA-256 checksums.
+var sha256Rule = validation.By(func(value any) error {
+	checksum, _ := value.(string)
+	if checksum == "" {
+		return nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(checksum)
+	if err != nil || len(decoded) != sha256.Size {
+		return errors.New("must be a base64 encoded SHA-256 checksum")
+	}
+	return nil
+})
+
+func (req CreateUploadURLRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Filename, validation.Required, validation.Length(1, 255)),
+		validation.Field(&req.ContentType, validation.Required, validation.Length(3, 255)),
+		validation.Field(&req.Bytes, validation.Required, validation.Min(int64(1))),
+		validation.Field(&req.ChecksumSHA256, sha256Rule),
+		validation.Field(&req.Title, validation.Length(1, 255)),
+		validation.Field(&req.AdminID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.AdminName, validation.Required, validation.Length(1, 128)),
+	)
+}
+
+func (req ConfirmUploadRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
+
+func (req DownloadURLRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Filename, validation.Length(1, 255)),
+	)
+}
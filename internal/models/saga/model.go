@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package saga provides models for the persisted sagas, which apply a change spanning stores that
+// cannot share a transaction step by step and undo the applied steps if a later one fails.
+package saga
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Type identifies the saga definition the steps of the saga are executed with.
+type Type string
+
+const (
+	// TypeMuxUpdateOwners replaces the owners of a MUX asset.
+	TypeMuxUpdateOwners Type = "mux.update_owners"
+	// TypeCloudinaryUpdateOwners replaces the owners of a Cloudinary asset.
+	TypeCloudinaryUpdateOwners Type = "cloudinary.update_owners"
+)
+
+// Actions of the ownership sagas, their payload is [OwnerPayload].
+const (
+	ActionAddOwner    = "add_owner"
+	ActionRemoveOwner = "remove_owner"
+)
+
+// Status is the state of the saga.
+type Status string
+
+const (
+	// StatusRunning sagas apply their pending steps.
+	StatusRunning Status = "running"
+	// StatusCompensating sagas undo their applied steps after a step failed.
+	StatusCompensating Status = "compensating"
+	// StatusCompleted sagas applied all steps.
+	StatusCompleted Status = "completed"
+	// StatusCompensated sagas undid all applied steps.
+	StatusCompensated Status = "compensated"
+	// StatusFailed sagas could not be completed nor compensated and need manual intervention.
+	StatusFailed Status = "failed"
+)
+
+// StepStatus is the state of a single step.
+type StepStatus string
+
+const (
+	StepPending     StepStatus = "pending"
+	StepApplied     StepStatus = "applied"
+	StepFailed      StepStatus = "failed"
+	StepCompensated StepStatus = "compensated"
+)
+
+// Step is a single change of the saga. Applying and compensating a step must be idempotent,
+// because a saga resumed after a restart repeats the step that was in progress.
+type Step struct {
+	Action  string          `json:"action"`
+	Payload json.RawMessage `json:"payload"`
+	Status  StepStatus      `json:"status"`
+}
+
+// Saga is a persisted saga. The state is saved after every step, so a saga interrupted by a restart
+// is resumed by the saga worker once its lease expires.
+type Saga struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid" json:"id"` // UUIDv7
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Type    Type      `gorm:"type:varchar(64);not null" json:"type"`
+	AssetID uuid.UUID `gorm:"type:uuid;not null;index" json:"asset_id"`
+	Status  Status    `gorm:"type:varchar(32);not null;index:idx_sagas_status_next_attempt" json:"status"`
+	Steps   []*Step   `gorm:"type:jsonb;serializer:json;not null" json:"steps"`
+
+	// AdminID and AdminName identify the admin who started the saga.
+	AdminID   *uuid.UUID `gorm:"type:uuid;null" json:"admin_id,omitempty"`
+	AdminName *string    `gorm:"type:varchar(256);null" json:"admin_name,omitempty"`
+
+	// Attempts is the number of times the saga was resumed.
+	Attempts int `gorm:"not null;default:0" json:"attempts"`
+	// NextAttemptAt is the moment the lease of the executing instance expires and the saga may be resumed.
+	NextAttemptAt time.Time `gorm:"not null;index:idx_sagas_status_next_attempt" json:"next_attempt_at"`
+	LastError     *string   `gorm:"type:varchar(1024);null" json:"last_error,omitempty"`
+}
+
+func (*Saga) TableName() string {
+	return "sagas"
+}
+
+func (s *Saga) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID, err = uuid.NewV7()
+		if err != nil {
+			return err
+		}
+	}
+	if s.Status == "" {
+		s.Status = StatusRunning
+	}
+	return nil
+}
+
+// Done reports whether the saga reached a final status.
+func (s *Saga) Done() bool {
+	return s.Status == StatusCompleted || s.Status == StatusCompensated || s.Status == StatusFailed
+}
+
+// NewStep creates a pending step with JSON encoded payload.
+func NewStep(action string, payload any) (*Step, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode saga step payload: %w", err)
+	}
+	return &Step{Action: action, Payload: b, Status: StepPending}, nil
+}
+
+// OwnerPayload is the payload of the [ActionAddOwner] and [ActionRemoveOwner] steps.
+type OwnerPayload struct {
+	OwnerID   string `json:"owner_id"`
+	OwnerType string `json:"owner_type"`
+	// AssetID is the asset the step changes, if it is not the asset of the saga. It is set when
+	// an owner is moved from other assets to the asset of the saga.
+	AssetID *uuid.UUID `json:"asset_id,omitempty"`
+}
+
+// Target returns the asset the step with the payload changes within the saga.
+func (p OwnerPayload) Target(saga *Saga) uuid.UUID {
+	if p.AssetID != nil {
+		return *p.AssetID
+	}
+	return saga.AssetID
+}
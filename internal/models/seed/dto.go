@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package seed
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+const (
+	// MaxFixtures is the maximum number of fixtures of a provider, a wipe removes the fixtures up to it.
+	MaxFixtures = 500
+
+	DefaultMuxAssets        = 12
+	DefaultCloudinaryImages = 8
+)
+
+// LoadRequest loads the fixture set. Fixtures loaded by a previous run are kept, so a load can be
+// repeated with larger counts to extend the set.
+type LoadRequest struct {
+	// MuxAssets is the number of MUX assets, in various states. It defaults to [DefaultMuxAssets].
+	MuxAssets int `json:"mux_assets"`
+	// CloudinaryImages is the number of Cloudinary images. It defaults to [DefaultCloudinaryImages].
+	CloudinaryImages int `json:"cloudinary_images"`
+}
+
+func (req LoadRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.MuxAssets, validation.Min(0), validation.Max(MaxFixtures)),
+		validation.Field(&req.CloudinaryImages, validation.Min(0), validation.Max(MaxFixtures)),
+	)
+}
+
+// WipeRequest permanently deletes every loaded fixture with its metadata.
+type WipeRequest struct{}
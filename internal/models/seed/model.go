@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package seed provides models for the fixture sets loaded by the seeding subsystem, for demos and
+// integration tests.
+package seed
+
+import (
+	"github.com/google/uuid"
+)
+
+// Provider identifies the backend fixtures are loaded into.
+type Provider string
+
+const (
+	ProviderMux        Provider = "mux"
+	ProviderCloudinary Provider = "cloudinary"
+)
+
+// State is the lifecycle state a fixture is created in.
+type State string
+
+const (
+	// StateReady fixtures are active and ready to be played or delivered.
+	StateReady State = "ready"
+	// StateProcessing fixtures are active but still being processed by the provider.
+	StateProcessing State = "processing"
+	// StateUploadPending fixtures have an upload URL but no uploaded file yet.
+	StateUploadPending State = "upload_pending"
+	StateErrored       State = "errored"
+	StateArchived      State = "archived"
+)
+
+// Outcome is the result of loading a single fixture.
+type Outcome string
+
+const (
+	OutcomeCreated Outcome = "created"
+	// OutcomeSkipped fixtures were loaded by a previous run.
+	OutcomeSkipped Outcome = "skipped"
+	OutcomeFailed  Outcome = "failed"
+)
+
+// Owner is an owner of a fixture.
+type Owner struct {
+	OwnerID   string
+	OwnerType string
+}
+
+// Fixture is an asset of a fixture set. Its ID is derived from the provider and the index, so every
+// run loads the same assets.
+type Fixture struct {
+	ID        uuid.UUID
+	Index     int
+	Title     string
+	CreatorID string
+	State     State
+	Owners    []Owner
+	Tags      []string
+}
+
+// Result is the result of loading a single fixture.
+type Result struct {
+	AssetID uuid.UUID
+	Outcome Outcome
+	Err     error
+}
+
+// ItemError describes a fixture which could not be loaded.
+type ItemError struct {
+	Provider Provider  `json:"provider"`
+	AssetID  uuid.UUID `json:"asset_id"`
+	Error    string    `json:"error"`
+}
+
+// Counts are the numbers of the fixtures of a provider.
+type Counts struct {
+	Created int64 `json:"created"`
+	Skipped int64 `json:"skipped"`
+	Failed  int64 `json:"failed"`
+	// Deleted is the number of fixtures removed by a wipe.
+	Deleted int64 `json:"deleted"`
+}
+
+// Report describes a load or a wipe of the fixtures.
+type Report struct {
+	Counts map[Provider]*Counts `json:"counts"`
+	Errors []ItemError          `json:"errors"`
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package signingkey provides models for the rotated MUX URL signing keys. The newest active key of an
+// environment signs the playback tokens, superseded keys keep verifying the tokens they signed until
+// they are revoked.
+package signingkey
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Status string
+
+const (
+	// StatusActive keys sign the playback tokens, only the newest one is used.
+	StatusActive Status = "active"
+	// StatusRetiring keys were superseded, the tokens they signed are still accepted by MUX.
+	StatusRetiring Status = "retiring"
+	// StatusRevoked keys were deleted from MUX, their private key is discarded.
+	StatusRevoked Status = "revoked"
+)
+
+// Key is a MUX URL signing key created by the rotation.
+type Key struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid" json:"id"` // UUIDv7
+	Environment string    `gorm:"type:varchar(64);not null" json:"environment"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// KeyID is the ID of the MUX signing key, the kid of the tokens signed with it.
+	KeyID string `gorm:"type:varchar(255);not null;uniqueIndex" json:"key_id"`
+	// PrivateKey is the PEM encoded private key sealed with the encryption key of the keyring.
+	PrivateKey []byte     `gorm:"type:bytea" json:"-"`
+	Status     Status     `gorm:"type:varchar(16);not null" json:"status"`
+	RetiringAt *time.Time `gorm:"null" json:"retiring_at,omitempty"`
+	RevokedAt  *time.Time `gorm:"null" json:"revoked_at,omitempty"`
+}
+
+func (*Key) TableName() string {
+	return "mux_signing_keys"
+}
+
+func (k *Key) BeforeCreate(tx *gorm.DB) (err error) {
+	if k.ID == uuid.Nil {
+		k.ID, err = uuid.NewV7()
+	}
+	return err
+}
+
+// PublishedKey is a signing key as published to the parties verifying the playback tokens, e.g. a CDN.
+type PublishedKey struct {
+	KeyID     string     `json:"key_id"`
+	Status    Status     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// PublicKey is the PEM encoded public key the tokens signed with the key are verified with.
+	PublicKey string `json:"public_key"`
+}
+
+// KeySet lists the signing keys of an environment whose tokens are accepted.
+type KeySet struct {
+	Environment string `json:"environment"`
+	// CurrentKeyID is the key the new tokens are signed with, empty while the configured signing key
+	// is used.
+	CurrentKeyID string          `json:"current_key_id,omitempty"`
+	Keys         []*PublishedKey `json:"keys"`
+}
+
+// RotateResult reports the keys created and revoked by a rotation.
+type RotateResult struct {
+	Created *PublishedKey `json:"created,omitempty"`
+	Retired []string      `json:"retired"`
+	Revoked []string      `json:"revoked"`
+}
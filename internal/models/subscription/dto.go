@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package subscription
+
+// GetRequest retrieves an outgoing webhook.
+type GetRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+// ListRequest lists the outgoing webhooks, newest first.
+type ListRequest struct {
+	PageSize  int    `query:"page_size"`
+	PageToken string `query:"page_token"`
+}
+
+// CreateRequest registers an outgoing webhook.
+type CreateRequest struct {
+	URL string `json:"url"`
+	// Secret is the HMAC key the deliveries are signed with, at least 16 characters long.
+	Secret      string   `json:"secret"`
+	EventTypes  []string `json:"event_types"`
+	Providers   []string `json:"providers"`
+	Description *string  `json:"description"`
+}
+
+// UpdateRequest changes an outgoing webhook. Omitted fields are left unchanged, empty event types or
+// providers remove the filter.
+type UpdateRequest struct {
+	ID          string    `param:"id" json:"-"`
+	URL         *string   `json:"url"`
+	Secret      *string   `json:"secret"`
+	EventTypes  *[]string `json:"event_types"`
+	Providers   *[]string `json:"providers"`
+	Description *string   `json:"description"`
+	Active      *bool     `json:"active"`
+}
+
+// DeleteRequest deletes an outgoing webhook together with its delivery log.
+type DeleteRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+// ListDeliveriesRequest lists the deliveries of an outgoing webhook, newest first.
+type ListDeliveriesRequest struct {
+	ID string `param:"id" json:"-"`
+	// Status limits the deliveries to one status, all deliveries are listed if it is empty.
+	Status    DeliveryStatus `query:"status"`
+	PageSize  int            `query:"page_size"`
+	PageToken string         `query:"page_token"`
+}
+
+// TestRequest sends a test event to an outgoing webhook. The test delivery is attempted once.
+type TestRequest struct {
+	ID string `param:"id" json:"-"`
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package subscription provides models for the outgoing webhooks, HTTP callbacks third-party
+// integrations register to receive the asset lifecycle events.
+package subscription
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TestEventType is the event type of the deliveries sent by the test-delivery endpoint.
+const TestEventType = "webhook.test"
+
+var (
+	// EventTypes lists the asset event types a subscription can filter.
+	EventTypes = []string{
+		"asset.created", "asset.ready", "asset.errored", "asset.deleted", "asset.owners_changed", "asset.updated",
+		"asset.published", "asset.unpublished",
+	}
+	// Providers lists the asset providers a subscription can filter.
+	Providers = []string{"mux", "cloudinary", "s3", "cfstream"}
+)
+
+// Subscription is an outgoing webhook. The asset events matching its filters are delivered to its URL.
+type Subscription struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid" json:"id"` // UUIDv7
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	URL string `gorm:"type:varchar(2048);not null" json:"url"`
+	// Secret is the HMAC key the deliveries are signed with. It is never returned by the API.
+	Secret string `gorm:"type:varchar(256);not null" json:"-"`
+	// EventTypes limits the delivered events to the types, all events are delivered if it is empty.
+	EventTypes []string `gorm:"type:jsonb;serializer:json;not null" json:"event_types"`
+	// Providers limits the delivered events to the providers, events of all providers are delivered if it is empty.
+	Providers   []string `gorm:"type:jsonb;serializer:json;not null" json:"providers"`
+	Description *string  `gorm:"type:varchar(1024);null" json:"description,omitempty"`
+	// Active is unset to pause the deliveries, events published in the meantime are not delivered.
+	Active bool `gorm:"not null;default:true" json:"active"`
+}
+
+func (*Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+func (s *Subscription) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID, err = uuid.NewV7()
+	}
+	return err
+}
+
+// DeliveryStatus represents the status of a delivery.
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// Delivery is a single event sent to a subscription. It is retried with exponential backoff until
+// the endpoint accepts it or the attempts are exhausted, and it is kept as the delivery log afterwards.
+type Delivery struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid" json:"id"` // UUIDv7
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	SubscriptionID uuid.UUID       `gorm:"type:uuid;not null;index" json:"subscription_id"`
+	EventID        uuid.UUID       `gorm:"type:uuid;not null" json:"event_id"`
+	EventType      string          `gorm:"type:varchar(64);not null" json:"event_type"`
+	Payload        json.RawMessage `gorm:"type:jsonb;not null" json:"payload"`
+	Status         DeliveryStatus  `gorm:"type:varchar(32);not null;default:'pending'" json:"status"`
+
+	// Attempts is the number of delivery attempts made so far.
+	Attempts int `gorm:"not null;default:0" json:"attempts"`
+	// NextAttemptAt is the earliest moment the dispatcher may try to deliver the event.
+	NextAttemptAt time.Time `gorm:"not null" json:"next_attempt_at"`
+	// ResponseStatus is the HTTP status of the last attempt, nil if the endpoint was not reached.
+	ResponseStatus *int       `gorm:"null" json:"response_status,omitempty"`
+	LastError      *string    `gorm:"type:varchar(1024);null" json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `gorm:"null" json:"delivered_at,omitempty"`
+}
+
+func (*Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (d *Delivery) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == uuid.Nil {
+		d.ID, err = uuid.NewV7()
+		if err != nil {
+			return err
+		}
+	}
+	if d.Status == "" {
+		d.Status = DeliveryStatusPending
+	}
+	if d.NextAttemptAt.IsZero() {
+		d.NextAttemptAt = time.Now()
+	}
+	return nil
+}
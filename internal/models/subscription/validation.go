@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package subscription
+
+import (
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+var (
+	urlRule        = validation.Match(regexp.MustCompile(`^https?://\S+$`)).Error("must be an http or https URL")
+	eventTypesRule = validation.Each(validation.In(toAny(EventTypes)...))
+	providersRule  = validation.Each(validation.In(toAny(Providers)...))
+	statusRule     = validation.In(DeliveryStatusPending, DeliveryStatusDelivered, DeliveryStatusFailed)
+)
+
+// optional applies the rules to the slice of an update request, if it is set.
+func optional(rules ...validation.Rule) validation.Rule {
+	return validation.By(func(value any) error {
+		if p, _ := value.(*[]string); p != nil {
+			return validation.Validate(*p, rules...)
+		}
+		return nil
+	})
+}
+
+func toAny(values []string) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+func (req GetRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
+
+func (req ListRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
+	)
+}
+
+func (req CreateRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.URL, validation.Required, validation.Length(1, 2048), urlRule),
+		validation.Field(&req.Secret, validation.Required, validation.Length(16, 256)),
+		validation.Field(&req.EventTypes, eventTypesRule),
+		validation.Field(&req.Providers, providersRule),
+		validation.Field(&req.Description, validation.Length(0, 1024)),
+	)
+}
+
+func (req UpdateRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.URL, validation.NilOrNotEmpty, validation.Length(1, 2048), urlRule),
+		validation.Field(&req.Secret, validation.NilOrNotEmpty, validation.Length(16, 256)),
+		validation.Field(&req.EventTypes, optional(eventTypesRule)),
+		validation.Field(&req.Providers, optional(providersRule)),
+		validation.Field(&req.Description, validation.Length(0, 1024)),
+	)
+}
+
+func (req DeleteRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
+
+func (req ListDeliveriesRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+		validation.Field(&req.Status, statusRule),
+		validation.Field(&req.PageSize, validation.Min(1), validation.Max(1000)),
+	)
+}
+
+func (req TestRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
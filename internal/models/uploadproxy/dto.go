@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package uploadproxy
+
+// CreateRequest creates an upload session. Title, AdminID and AdminName are passed to the
+// provider asset created for the upload.
+type CreateRequest struct {
+	Provider  Provider
+	Size      int64
+	Title     string
+	AdminID   string
+	AdminName string
+}
+
+type GetRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+// AppendRequest appends the request body to the upload at Offset, which must match the
+// number of bytes received so far.
+type AppendRequest struct {
+	ID     string `param:"id" json:"-"`
+	Offset int64
+}
+
+type DeleteRequest struct {
+	ID string `param:"id" json:"-"`
+}
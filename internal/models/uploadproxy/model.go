@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package uploadproxy provides models for upload sessions of the upload proxy, which accepts
+// resumable uploads and relays them to the provider.
+package uploadproxy
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Provider identifies the asset type an upload is relayed to.
+type Provider string
+
+const (
+	ProviderMux Provider = "mux"
+)
+
+// Target is the provider upload destination created for an upload session.
+type Target struct {
+	// AssetID is the local asset created for the upload.
+	AssetID uuid.UUID
+	// UploadURL is the provider resumable upload URL the file is relayed to.
+	UploadURL string
+}
+
+// Session describes the progress of a proxied upload. Sessions are kept in memory of the
+// instance which created them.
+type Session struct {
+	ID       uuid.UUID `json:"id"`
+	Provider Provider  `json:"provider"`
+	AssetID  uuid.UUID `json:"asset_id"`
+	// Size is the declared size of the file in bytes.
+	Size int64 `json:"size"`
+	// Offset is the number of bytes received from the client.
+	Offset int64 `json:"offset"`
+	// Relayed is the number of bytes accepted by the provider. It lags behind Offset by the
+	// buffered part of the current chunk.
+	Relayed   int64     `json:"relayed"`
+	Completed bool      `json:"completed"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package usage provides models for the storage accounting of the assets created by each creator.
+package usage
+
+import (
+	"github.com/google/uuid"
+)
+
+// Provider identifies the provider the usage was accounted for.
+type Provider string
+
+const (
+	ProviderMux        Provider = "mux"
+	ProviderCloudinary Provider = "cloudinary"
+)
+
+// Unowned is the owner type the usage of assets without owners is reported under.
+const Unowned = "unowned"
+
+// Usage is the storage used by a group of assets. Bytes are only accounted for Cloudinary images,
+// Duration only for MUX videos, because MUX does not report the stored size.
+type Usage struct {
+	// CreatorID is nil for assets created before the creator was recorded.
+	CreatorID *uuid.UUID `json:"creator_id,omitempty"`
+	OwnerType string     `json:"owner_type,omitempty"`
+	Assets    int64      `json:"assets"`
+	Bytes     int64      `json:"bytes"`
+	// Duration is the total duration in seconds.
+	Duration float64 `json:"duration"`
+}
+
+// AssetUsage is the storage used by a single asset.
+type AssetUsage struct {
+	ID        uuid.UUID
+	CreatorID *uuid.UUID
+	Bytes     int64
+	Duration  float64
+}
+
+// Report is the usage of a provider aggregated by creator and by owner type.
+type Report struct {
+	Provider    Provider `json:"provider"`
+	Total       *Usage   `json:"total"`
+	ByCreator   []*Usage `json:"by_creator"`
+	ByOwnerType []*Usage `json:"by_owner_type"`
+}
+
+// ReportRequest requests the usage report of a provider.
+type ReportRequest struct {
+	Provider Provider `query:"provider"`
+}
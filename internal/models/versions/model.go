@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package versions provides models for the version history of the media of an owner. Every time the
+// asset of an owner is replaced by another one, a version linked to the replaced asset is recorded, so
+// the previous assets of the owner can be listed and the owner rolled back to one of them.
+package versions
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Provider identifies the asset type of the versions.
+type Provider string
+
+const (
+	ProviderMux        Provider = "mux"
+	ProviderCloudinary Provider = "cloudinary"
+)
+
+// Kind is the change which made the asset of a version current.
+type Kind string
+
+const (
+	// KindInitial versions hold the asset the owner had when it was first replaced.
+	KindInitial Kind = "initial"
+	// KindReplace versions were created by associating the owner with another asset.
+	KindReplace Kind = "replace"
+	// KindRollback versions were created by rolling the owner back to the asset of an earlier version.
+	KindRollback Kind = "rollback"
+)
+
+// Version is a single asset of an owner in its version chain. Versions are numbered from 1 per
+// provider and owner, the version with the highest number is current.
+type Version struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid" json:"id"` // UUIDv7
+	CreatedAt time.Time `json:"created_at"`
+
+	Provider  Provider  `gorm:"type:varchar(32);not null;uniqueIndex:idx_asset_versions_owner_number,priority:1" json:"provider"`
+	OwnerType string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_asset_versions_owner_number,priority:2" json:"owner_type"`
+	OwnerID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_asset_versions_owner_number,priority:3" json:"owner_id"`
+	Number    int       `gorm:"not null;uniqueIndex:idx_asset_versions_owner_number,priority:4" json:"number"`
+
+	Kind    Kind      `gorm:"type:varchar(16);not null" json:"kind"`
+	AssetID uuid.UUID `gorm:"type:uuid;not null;index" json:"asset_id"`
+	// PredecessorID is the asset the version replaced, nil for the first version.
+	PredecessorID *uuid.UUID `gorm:"type:uuid;null" json:"predecessor_id,omitempty"`
+	// SuccessorID is the asset which replaced the version, nil for the current version.
+	SuccessorID  *uuid.UUID `gorm:"type:uuid;null" json:"successor_id,omitempty"`
+	SupersededAt *time.Time `gorm:"null" json:"superseded_at,omitempty"`
+
+	AdminID   *uuid.UUID `gorm:"type:uuid;null" json:"admin_id,omitempty"`
+	AdminName *string    `gorm:"type:varchar(128);null" json:"admin_name,omitempty"`
+}
+
+func (*Version) TableName() string {
+	return "asset_versions"
+}
+
+func (v *Version) BeforeCreate(tx *gorm.DB) (err error) {
+	if v.ID == uuid.Nil {
+		v.ID, err = uuid.NewV7()
+	}
+	return err
+}
+
+// Current reports whether the version is the current asset of the owner.
+func (v *Version) Current() bool {
+	return v.SuccessorID == nil
+}
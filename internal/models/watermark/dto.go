@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package watermark
+
+// GetRequest retrieves the watermark of a creator or an owner type.
+type GetRequest struct {
+	Scope Scope  `param:"scope" json:"-"`
+	Key   string `param:"key" json:"-"`
+}
+
+// ListRequest lists the watermarks, optionally of a single scope.
+type ListRequest struct {
+	Scope Scope `query:"scope"`
+}
+
+// SetRequest creates or replaces the watermark of a creator or an owner type.
+type SetRequest struct {
+	Scope              Scope    `param:"scope" json:"-"`
+	Key                string   `param:"key" json:"-"`
+	CloudinaryPublicID *string  `json:"cloudinary_public_id"`
+	ImageURL           *string  `json:"image_url"`
+	Position           Position `json:"position"`
+	// Margin defaults to 0.
+	Margin int `json:"margin"`
+	// Width defaults to 20.
+	Width int `json:"width"`
+	// Opacity defaults to 100.
+	Opacity int `json:"opacity"`
+}
+
+// DeleteRequest removes the watermark of a creator or an owner type. Videos already watermarked
+// by MUX keep their watermark.
+type DeleteRequest struct {
+	Scope Scope  `param:"scope" json:"-"`
+	Key   string `param:"key" json:"-"`
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package watermark provides models for the watermarks applied to the delivered images and to the
+// MUX videos of a creator or of an owner type.
+package watermark
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Scope is what a watermark is configured for.
+type Scope string
+
+const (
+	// ScopeCreator watermarks the assets of a creator, the key is the creator ID.
+	ScopeCreator Scope = "creator"
+	// ScopeOwnerType watermarks the assets of an owner type, the key is the owner type, e.g. "course".
+	ScopeOwnerType Scope = "owner_type"
+)
+
+// Position is the corner, or the center, of the image or the video the watermark is placed at.
+type Position string
+
+const (
+	PositionTopLeft     Position = "top_left"
+	PositionTopRight    Position = "top_right"
+	PositionBottomLeft  Position = "bottom_left"
+	PositionBottomRight Position = "bottom_right"
+	PositionCenter      Position = "center"
+)
+
+// Watermark is the overlay applied to the assets of a creator or of an owner type. A creator
+// watermark takes precedence over the owner type ones.
+type Watermark struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid" json:"id"` // UUIDv7
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Scope Scope  `gorm:"type:varchar(32);not null;uniqueIndex:idx_watermarks_scope_key" json:"scope"`
+	Key   string `gorm:"type:varchar(128);not null;uniqueIndex:idx_watermarks_scope_key" json:"key"`
+
+	// CloudinaryPublicID is the public ID of the overlay image applied to the delivered images.
+	// Images are not watermarked if it is nil.
+	CloudinaryPublicID *string `gorm:"type:varchar(512);null" json:"cloudinary_public_id,omitempty"`
+	// ImageURL is the URL of the overlay image MUX burns into the videos created for the creator or
+	// the owner type. Videos are not watermarked if it is nil.
+	ImageURL *string `gorm:"type:varchar(2048);null" json:"image_url,omitempty"`
+
+	Position Position `gorm:"type:varchar(16);not null" json:"position"`
+	// Margin is the distance of the overlay from the edges, in percent of the width and the height.
+	Margin int `gorm:"not null" json:"margin"`
+	// Width is the width of the overlay in percent of the width of the image or the video.
+	Width int `gorm:"not null" json:"width"`
+	// Opacity is the opacity of the overlay in percent.
+	Opacity int `gorm:"not null" json:"opacity"`
+}
+
+func (*Watermark) TableName() string {
+	return "watermarks"
+}
+
+func (w *Watermark) BeforeCreate(tx *gorm.DB) (err error) {
+	if w.ID == uuid.Nil {
+		w.ID, err = uuid.NewV7()
+	}
+	return err
+}
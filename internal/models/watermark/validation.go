@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package watermark
+
+import (
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+var (
+	scopeRule    = validation.In(ScopeCreator, ScopeOwnerType)
+	positionRule = validation.In(PositionTopLeft, PositionTopRight, PositionBottomLeft, PositionBottomRight, PositionCenter)
+	// MUX downloads the overlay image, so it must be served over HTTPS.
+	imageURLRule = validation.Match(regexp.MustCompile(`^https://\S+$`)).Error("must be an https URL")
+)
+
+// keyRules validates the key of the scope, creators are identified by their UUID.
+func keyRules(scope Scope) []validation.Rule {
+	if scope == ScopeCreator {
+		return validationutil.UUIDRule(true)
+	}
+	return []validation.Rule{validation.Required, validation.Length(1, 50)}
+}
+
+func (req GetRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Scope, validation.Required, scopeRule),
+		validation.Field(&req.Key, keyRules(req.Scope)...),
+	)
+}
+
+func (req ListRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Scope, scopeRule),
+	)
+}
+
+func (req SetRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Scope, validation.Required, scopeRule),
+		validation.Field(&req.Key, keyRules(req.Scope)...),
+		validation.Field(&req.CloudinaryPublicID,
+			validation.Required.When(req.ImageURL == nil).Error("cloudinary_public_id or image_url is required"),
+			validation.Length(1, 512),
+		),
+		validation.Field(&req.ImageURL, validation.Length(1, 2048), imageURLRule),
+		validation.Field(&req.Position, validation.Required, positionRule),
+		validation.Field(&req.Margin, validation.Min(0), validation.Max(40)),
+		validation.Field(&req.Width, validation.Min(0), validation.Max(100)),
+		validation.Field(&req.Opacity, validation.Min(0), validation.Max(100)),
+	)
+}
+
+func (req DeleteRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Scope, validation.Required, scopeRule),
+		validation.Field(&req.Key, keyRules(req.Scope)...),
+	)
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package webhook
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+)
+
+// MaxListErrorsLimit is the maximum number of events [ListErrorsRequest] lists.
+const MaxListErrorsLimit = 500
+
+// ListErrorsRequest lists the most recent webhook events whose processing failed, including events
+// which are still retried.
+type ListErrorsRequest struct {
+	// Provider limits the events to one provider, all providers are listed if it is empty.
+	Provider Provider `query:"provider"`
+	// Limit defaults to 50.
+	Limit int `query:"limit"`
+}
+
+func (req ListErrorsRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.Provider, validation.In(ProviderMux, ProviderCloudinary, ProviderCfStream)),
+		validation.Field(&req.Limit, validation.Min(0), validation.Max(MaxListErrorsLimit)),
+	)
+}
+
+// ReplayRequest schedules a stored webhook event for another processing attempt.
+type ReplayRequest struct {
+	ID string `param:"id" json:"-"`
+}
+
+func (req ReplayRequest) Validate() error {
+	return validationutil.ValidateStruct(&req,
+		validation.Field(&req.ID, validationutil.UUIDRule(true)...),
+	)
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package webhook provides models for the webhook ingestion queue. Provider notifications are
+// persisted once verified and processed asynchronously, so providers are acknowledged right away.
+package webhook
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Provider identifies the provider which sent the webhook.
+type Provider string
+
+const (
+	ProviderMux        Provider = "mux"
+	ProviderCloudinary Provider = "cloudinary"
+	ProviderCfStream   Provider = "cfstream"
+)
+
+// Status represents the processing status of the webhook event.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusProcessed Status = "processed"
+	StatusFailed    Status = "failed"
+)
+
+// Event represents a single received webhook. Events are persisted by the webhook endpoints after the
+// signature was verified and are processed asynchronously by the webhook queue.
+type Event struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid" json:"id"` // UUIDv7
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Provider Provider `gorm:"type:varchar(32);not null" json:"provider"`
+	// Type is the notification type reported by the provider, e.g. "video.asset.ready".
+	Type    string `gorm:"type:varchar(128);not null" json:"type"`
+	Payload []byte `gorm:"type:jsonb;not null" json:"payload,omitempty"`
+	Status  Status `gorm:"type:varchar(32);not null;default:'pending';index:idx_webhook_status_next_attempt" json:"status"`
+
+	// Attempts is the number of processing attempts made so far.
+	Attempts int `gorm:"not null;default:0" json:"attempts"`
+	// NextAttemptAt is the earliest moment the queue may try to process the event.
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_webhook_status_next_attempt" json:"next_attempt_at"`
+	LastError     *string    `gorm:"type:varchar(1024);null" json:"last_error,omitempty"`
+	ProcessedAt   *time.Time `gorm:"null" json:"processed_at,omitempty"`
+
+	// SourceEnvironment is the environment the webhook was verified in, the event is processed in it.
+	// It is nil if the provider names the environment in the payload. The field is not named
+	// Environment, so the events are not scoped by the environment of the request.
+	SourceEnvironment *string `gorm:"column:environment;type:varchar(64);null" json:"environment,omitempty"`
+	// ObjectID is the provider ID of the object the webhook is about, e.g. the MUX asset ID. The events
+	// of an object are processed one at a time in the order they were received.
+	ObjectID *string `gorm:"type:varchar(255);null" json:"object_id,omitempty"`
+}
+
+func (*Event) TableName() string {
+	return "webhook_events"
+}
+
+func (e *Event) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID, err = uuid.NewV7()
+		if err != nil {
+			return err
+		}
+	}
+	if e.Status == "" {
+		e.Status = StatusPending
+	}
+	if e.NextAttemptAt.IsZero() {
+		e.NextAttemptAt = time.Now()
+	}
+	return nil
+}
+
+// NewEvent creates a new pending webhook event with the raw payload received from the provider.
+func NewEvent(provider Provider, eventType string, payload []byte) *Event {
+	return &Event{
+		Provider: provider,
+		Type:     eventType,
+		Payload:  payload,
+		Status:   StatusPending,
+	}
+}
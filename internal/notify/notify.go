@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package notify sends notifications about asset lifecycle events to operations, e.g. when an asset
+// errored. The notifier subscribes to the event bus, so every event published by the services, from
+// the webhook handlers as well as from the background jobs, is matched against the notification rules
+// and delivered to the sinks of the matching rules.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"go.uber.org/zap"
+)
+
+// Sink delivers notifications to a destination, e.g. a Slack channel.
+type Sink interface {
+	Send(ctx context.Context, event *events.Event) error
+}
+
+// Rule selects the events delivered to its sinks. Empty filters match every event.
+type Rule struct {
+	EventTypes []events.Type
+	Providers  []events.Provider
+	// OwnerTypes matches events of assets owned by at least one owner of the types.
+	OwnerTypes []string
+	// Sinks are the names of the sinks the matching events are delivered to.
+	Sinks []string
+}
+
+// OwnerResolver returns the owners of an asset, for events which do not carry them.
+type OwnerResolver func(ctx context.Context, provider events.Provider, assetID uuid.UUID) ([]events.Owner, error)
+
+// Config holds the sinks by name and the rules of the notifier.
+type Config struct {
+	Sinks map[string]Sink
+	Rules []Rule
+	// Timeout limits the delivery of a single notification to a sink.
+	Timeout time.Duration
+	// Owners is optional, rules filtering owner types never match events without owners if it is nil.
+	Owners OwnerResolver
+}
+
+// Notifier sends notifications for the events matching its rules. It is meant to be an asynchronous
+// subscriber of the event bus, a failed delivery is logged and does not affect the publishing.
+type Notifier struct {
+	cfg    Config
+	logger *zap.Logger
+}
+
+// New returns a notifier sending the notifications of the rules of cfg.
+func New(cfg Config, logger *zap.Logger) (*Notifier, error) {
+	for i, rule := range cfg.Rules {
+		if len(rule.Sinks) == 0 {
+			return nil, fmt.Errorf("notification rule %d has no sinks", i)
+		}
+		for _, name := range rule.Sinks {
+			if _, ok := cfg.Sinks[name]; !ok {
+				return nil, fmt.Errorf("notification rule %d refers to unknown sink %q", i, name)
+			}
+		}
+	}
+	return &Notifier{
+		cfg:    cfg,
+		logger: logger.With(zap.String("layer", "notify")),
+	}, nil
+}
+
+// Handle sends the notifications of the event. Failed deliveries are only logged, it never fails.
+func (n *Notifier) Handle(ctx context.Context, event *events.Event) error {
+	n.notify(ctx, event)
+	return nil
+}
+
+// notify sends the event to the sinks of the matching rules, each sink at most once.
+func (n *Notifier) notify(ctx context.Context, event *events.Event) {
+	var sinks []string
+	owners := event.Owners
+	resolved := false
+	for _, rule := range n.cfg.Rules {
+		if len(rule.EventTypes) > 0 && !slices.Contains(rule.EventTypes, event.Type) {
+			continue
+		}
+		if len(rule.Providers) > 0 && !slices.Contains(rule.Providers, event.Provider) {
+			continue
+		}
+		if len(rule.OwnerTypes) > 0 {
+			if len(owners) == 0 && !resolved {
+				owners = n.resolveOwners(ctx, event)
+				resolved = true
+			}
+			if !slices.ContainsFunc(owners, func(o events.Owner) bool { return slices.Contains(rule.OwnerTypes, o.OwnerType) }) {
+				continue
+			}
+		}
+		for _, name := range rule.Sinks {
+			if !slices.Contains(sinks, name) {
+				sinks = append(sinks, name)
+			}
+		}
+	}
+
+	for _, name := range sinks {
+		sendCtx, cancel := context.WithTimeout(ctx, n.cfg.Timeout)
+		if err := n.cfg.Sinks[name].Send(sendCtx, event); err != nil {
+			n.logger.Warn("failed to send notification",
+				zap.Error(err),
+				zap.String("sink", name),
+				zap.String("event_type", string(event.Type)),
+				zap.String("asset_id", event.AssetID.String()),
+			)
+		}
+		cancel()
+	}
+}
+
+func (n *Notifier) resolveOwners(ctx context.Context, event *events.Event) []events.Owner {
+	if n.cfg.Owners == nil {
+		return nil
+	}
+	owners, err := n.cfg.Owners(ctx, event.Provider, event.AssetID)
+	if err != nil {
+		n.logger.Warn("failed to resolve asset owners for notification",
+			zap.Error(err),
+			zap.String("provider", string(event.Provider)),
+			zap.String("asset_id", event.AssetID.String()),
+		)
+		return nil
+	}
+	return owners
+}
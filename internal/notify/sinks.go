@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"net/smtp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mikhail5545/media-service-go/internal/events"
+)
+
+// SignatureHeader holds the hex encoded HMAC-SHA256 of the webhook request body, keyed with the sink secret.
+const SignatureHeader = "X-Media-Signature"
+
+// Summary returns the human-readable one-line description of the event.
+func Summary(event *events.Event) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s asset %s", event.Type, event.Provider, event.AssetID)
+	if event.ExternalID != "" {
+		fmt.Fprintf(&b, " (%s)", event.ExternalID)
+	}
+	for _, key := range slices.Sorted(maps.Keys(event.Data)) {
+		fmt.Fprintf(&b, " %s=%s", key, event.Data[key])
+	}
+	return b.String()
+}
+
+// SlackSink posts notifications to a Slack incoming webhook.
+type SlackSink struct {
+	URL    string
+	Client *http.Client
+}
+
+var _ Sink = (*SlackSink)(nil)
+
+func (s *SlackSink) Send(ctx context.Context, event *events.Event) error {
+	body, err := json.Marshal(map[string]string{"text": Summary(event)})
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.Client, s.URL, body, nil)
+}
+
+// WebhookSink posts the JSON encoded events to an HTTP endpoint. Requests are signed with
+// [SignatureHeader] if the secret is set.
+type WebhookSink struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+var _ Sink = (*WebhookSink)(nil)
+
+func (s *WebhookSink) Send(ctx context.Context, event *events.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	var headers map[string]string
+	if s.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(s.Secret))
+		mac.Write(body)
+		headers = map[string]string{SignatureHeader: hex.EncodeToString(mac.Sum(nil))}
+	}
+	return post(ctx, s.Client, s.URL, body, headers)
+}
+
+func post(ctx context.Context, client *http.Client, url string, body []byte, headers map[string]string) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SMTPSink emails notifications. PLAIN authentication is used if the username is set.
+type SMTPSink struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+var _ Sink = (*SMTPSink)(nil)
+
+func (s *SMTPSink) Send(ctx context.Context, event *events.Event) error {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&msg, "Subject: [media-service] %s %s asset %s\r\n", event.Type, event.Provider, event.AssetID)
+	fmt.Fprintf(&msg, "Date: %s\r\n", event.OccurredAt.Format(time.RFC1123Z))
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(Summary(event))
+	msg.WriteString("\r\n")
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+
+	// net/smtp does not accept a context, the delivery is abandoned instead when it is done.
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(addr, auth, s.From, s.To, msg.Bytes())
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
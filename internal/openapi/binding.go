@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Binding describes the request and the successful response of an operation. Bindings of the
+// handlers built on the generic helpers of internal/handlers/generic are derived from the service
+// methods they wrap, so the document changes along with the method signatures.
+type Binding struct {
+	// request is the type the handler binds the path, query and body into, nil when nothing is bound.
+	request reflect.Type
+	// protoRequest is the request message of a gateway route, used instead of request.
+	protoRequest protoreflect.MessageDescriptor
+	// bodies are the accepted JSON request body types, used instead of request for raw bodies.
+	bodies []reflect.Type
+	// contentType of a raw request body without schema, e.g. "application/offset+octet-stream".
+	contentType string
+	// formFile names the file part of a multipart/form-data request with the form fields of request.
+	formFile string
+	headers  []headerParam
+	// queries are query parameters read by the handler besides the bound ones.
+	queries []*Parameter
+
+	status      int
+	description string
+	// response returns the response body schema, nil for responses without content.
+	response func(r *schemaRegistry) *Schema
+	// responseType of a non-JSON response body, e.g. "text/plain".
+	responseType    string
+	responseHeaders []string
+}
+
+type headerParam struct {
+	name        string
+	description string
+	required    bool
+}
+
+// Handle binds a handler built on generic.Handle, the result is returned under key.
+func Handle[S, Req, Res any](_ func(S, context.Context, *Req) (Res, error), status int, key string) Binding {
+	return JSON[Req, Res](status, key)
+}
+
+// HandleList binds a handler built on generic.HandleList, the page is returned under key along
+// with the token of the next page.
+func HandleList[S, Req, Res any](_ func(S, context.Context, *Req) ([]*Res, string, error), key string) Binding {
+	return Binding{
+		request: reflect.TypeFor[Req](),
+		status:  http.StatusOK,
+		response: func(r *schemaRegistry) *Schema {
+			return &Schema{Type: "object", Properties: map[string]*Schema{
+				key:               {Type: "array", Items: r.schemaOf(reflect.TypeFor[*Res]())},
+				"next_page_token": {Type: "string", Description: "Token of the next page, empty on the last page."},
+			}}
+		},
+	}
+}
+
+// HandleListWithTotal binds a handler built on generic.HandleListWithTotal, which adds the total of
+// the matching records to the page.
+func HandleListWithTotal[S, Req, Res any](fn func(S, context.Context, *Req) ([]*Res, string, error), key string) Binding {
+	binding := HandleList(fn, key)
+	list := binding.response
+	binding.response = func(r *schemaRegistry) *Schema {
+		schema := list(r)
+		schema.Properties["total"] = &Schema{Type: "integer", Description: "Total of the records matching the filters."}
+		schema.Properties["total_is_estimate"] = &Schema{Type: "boolean", Description: "Whether total is a planner estimate or a cached count that may be stale."}
+		return schema
+	}
+	return binding
+}
+
+// HandleVoid binds a handler built on generic.HandleVoid, which responds without content.
+func HandleVoid[S, Req any](_ func(S, context.Context, *Req) error, status int) Binding {
+	return Empty[Req](status)
+}
+
+// JSON binds a handler binding Req and returning Res under key.
+func JSON[Req, Res any](status int, key string) Binding {
+	return Binding{
+		request: reflect.TypeFor[Req](),
+		status:  status,
+		response: func(r *schemaRegistry) *Schema {
+			return &Schema{Type: "object", Properties: map[string]*Schema{key: r.schemaOf(reflect.TypeFor[Res]())}}
+		},
+	}
+}
+
+// Unary binds a gateway route transcoded to the RPC fn, see internal/gateway.
+func Unary[S any, Req, Res proto.Message](_ func(S, context.Context, Req) (Res, error)) Binding {
+	var (
+		req Req
+		res Res
+	)
+	return Binding{
+		protoRequest: req.ProtoReflect().Descriptor(),
+		status:       http.StatusOK,
+		response: func(r *schemaRegistry) *Schema {
+			return r.protoRef(res.ProtoReflect().Descriptor())
+		},
+	}
+}
+
+// Raw binds a handler binding Req and returning Res as the whole response body.
+func Raw[Req, Res any](status int) Binding {
+	return Binding{
+		request: reflect.TypeFor[Req](),
+		status:  status,
+		response: func(r *schemaRegistry) *Schema {
+			return r.schemaOf(reflect.TypeFor[Res]())
+		},
+	}
+}
+
+// Empty binds a handler binding Req and responding without content.
+func Empty[Req any](status int) Binding {
+	return Binding{request: reflect.TypeFor[Req](), status: status}
+}
+
+// EventStream binds a handler binding Req and streaming server-sent events with Res encoded as JSON
+// in their data.
+func EventStream[Req, Res any]() Binding {
+	b := Raw[Req, Res](http.StatusOK)
+	b.responseType = "text/event-stream"
+	return b
+}
+
+// Text binds a handler responding with a plain text body.
+func Text(status int) Binding {
+	return Binding{
+		status:       status,
+		responseType: "text/plain",
+		response:     func(*schemaRegistry) *Schema { return &Schema{Type: "string"} },
+	}
+}
+
+// Path sets the type the path parameters are bound into, for handlers reading a raw body.
+func (b Binding) Path(req any) Binding {
+	b.request = reflect.TypeOf(req)
+	return b
+}
+
+// Body documents a JSON request body accepting any of the given sample values.
+func (b Binding) Body(samples ...any) Binding {
+	for _, sample := range samples {
+		b.bodies = append(b.bodies, reflect.TypeOf(sample))
+	}
+	return b
+}
+
+// RawBody documents a request body of contentType without schema.
+func (b Binding) RawBody(contentType string) Binding {
+	b.contentType = contentType
+	return b
+}
+
+// Multipart documents a multipart/form-data request with the form fields of the request type,
+// followed by the file part named file.
+func (b Binding) Multipart(file string) Binding {
+	b.formFile = file
+	return b
+}
+
+// Header documents a request header read by the handler.
+func (b Binding) Header(name, description string, required bool) Binding {
+	b.headers = append(b.headers, headerParam{name: name, description: description, required: required})
+	return b
+}
+
+// Query documents a query parameter read by the handler besides the bound ones.
+func (b Binding) Query(name, description string, schema *Schema) Binding {
+	b.queries = append(b.queries, &Parameter{Name: name, In: "query", Description: description, Schema: schema})
+	return b
+}
+
+// ResponseHeaders documents headers set on the successful response.
+func (b Binding) ResponseHeaders(names ...string) Binding {
+	b.responseHeaders = append(b.responseHeaders, names...)
+	return b
+}
+
+// Describe sets the description of the successful response.
+func (b Binding) Describe(description string) Binding {
+	b.description = description
+	return b
+}
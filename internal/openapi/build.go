@@ -0,0 +1,280 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/labstack/echo/v4"
+	errutil "github.com/mikhail5545/media-service-go/internal/util/errors"
+)
+
+const bearerAuth = "bearerAuth"
+
+// Options configures the generated document.
+type Options struct {
+	Info Info
+	// BasePath is the path the admin, webhook and gateway routes are mounted at, e.g. "/api/v1".
+	BasePath string
+	// Skip lists the paths left out of the document, e.g. the metrics endpoint.
+	Skip []string
+}
+
+// Build generates the document of the registered Echo routes using the documentation of [Routes].
+// Registered routes without documentation are still listed, without schemas, and their
+// "METHOD path" is returned so the drift can be reported. Documented routes which are not
+// registered, like the routes of disabled backends, are left out.
+func Build(registered []*echo.Route, opts Options) (*Document, []string) {
+	documented := make(map[string]Route)
+	for _, route := range Routes(opts.BasePath) {
+		documented[route.Method+" "+route.Path] = route
+	}
+
+	reg := newSchemaRegistry()
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    opts.Info,
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			SecuritySchemes: map[string]*SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", Description: "Admin JWT or API key."},
+			},
+		},
+		Security: []SecurityRequirement{{bearerAuth: {}}},
+	}
+
+	var (
+		undocumented []string
+		usedTags     = make(map[string]bool)
+		seen         = make(map[string]bool)
+	)
+	for _, r := range registered {
+		key := r.Method + " " + r.Path
+		if seen[key] || !isDocumentedMethod(r.Method) || strings.Contains(r.Path, "*") || slices.Contains(opts.Skip, r.Path) {
+			continue
+		}
+		seen[key] = true
+
+		route, ok := documented[key]
+		if !ok {
+			undocumented = append(undocumented, key)
+			route = Route{Method: r.Method, Path: r.Path, Summary: r.Name, Binding: Binding{status: http.StatusOK}}
+		}
+		if route.Tag != "" {
+			usedTags[route.Tag] = true
+		}
+		doc.pathItem(toOpenAPIPath(r.Path)).set(r.Method, buildOperation(reg, route, opts.BasePath))
+	}
+
+	for _, tag := range tags {
+		if usedTags[tag.Name] {
+			doc.Tags = append(doc.Tags, tag)
+		}
+	}
+	doc.Components.Schemas = reg.schemas
+	slices.Sort(undocumented)
+	return doc, undocumented
+}
+
+func buildOperation(reg *schemaRegistry, route Route, basePath string) *Operation {
+	b := route.Binding
+	op := &Operation{
+		Summary:     route.Summary,
+		OperationID: operationID(route.Method, strings.TrimPrefix(route.Path, basePath)),
+		Responses: map[string]*Response{
+			"default": {Description: "Error response.", Content: map[string]*MediaType{
+				echo.MIMEApplicationJSON: {Schema: reg.schemaOf(reflect.TypeFor[errutil.ErrorResponse]())},
+			}},
+		},
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+	if route.Public {
+		op.Security = []SecurityRequirement{{}}
+	}
+
+	op.Parameters = pathParameters(reg, route, b)
+	for _, h := range b.headers {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name: h.name, In: "header", Description: h.description, Required: h.required, Schema: &Schema{Type: "string"},
+		})
+	}
+	op.Parameters = append(op.Parameters, b.queries...)
+	op.RequestBody = requestBody(reg, route.Method, b)
+
+	res := &Response{Description: b.description}
+	if res.Description == "" {
+		res.Description = http.StatusText(b.status)
+	}
+	if b.response != nil {
+		contentType := b.responseType
+		if contentType == "" {
+			contentType = echo.MIMEApplicationJSON
+		}
+		res.Content = map[string]*MediaType{contentType: {Schema: b.response(reg)}}
+	}
+	for _, name := range b.responseHeaders {
+		if res.Headers == nil {
+			res.Headers = make(map[string]*Header)
+		}
+		res.Headers[name] = &Header{Schema: &Schema{Type: "string"}}
+	}
+	op.Responses[strconv.Itoa(b.status)] = res
+	return op
+}
+
+// pathParameters returns the parameters bound from the request type, keeping only the path
+// parameters of the route and adding the ones the request type does not bind.
+func pathParameters(reg *schemaRegistry, route Route, b Binding) []*Parameter {
+	if b.protoRequest != nil {
+		return reg.protoParameters(b.protoRequest, route.Method, pathParamNames(route.Path))
+	}
+	var bound []*Parameter
+	if b.request != nil && b.request.Kind() == reflect.Struct {
+		bound = reg.parameters(b.request, route.Method)
+	}
+	names := pathParamNames(route.Path)
+
+	params := make([]*Parameter, 0, len(bound)+len(names))
+	for _, name := range names {
+		idx := slices.IndexFunc(bound, func(p *Parameter) bool { return p.In == "path" && p.Name == name })
+		if idx >= 0 {
+			params = append(params, bound[idx])
+			continue
+		}
+		params = append(params, &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	for _, p := range bound {
+		if p.In != "path" {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+func requestBody(reg *schemaRegistry, method string, b Binding) *RequestBody {
+	if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
+		return nil
+	}
+	body := &RequestBody{Required: method != http.MethodDelete}
+	switch {
+	case b.formFile != "":
+		body.Content = map[string]*MediaType{echo.MIMEMultipartForm: {Schema: reg.formSchema(b.request, b.formFile)}}
+	case len(b.bodies) == 1:
+		body.Content = map[string]*MediaType{echo.MIMEApplicationJSON: {Schema: reg.schemaOf(b.bodies[0])}}
+	case len(b.bodies) > 1:
+		schema := &Schema{}
+		for _, t := range b.bodies {
+			schema.OneOf = append(schema.OneOf, reg.schemaOf(t))
+		}
+		body.Content = map[string]*MediaType{echo.MIMEApplicationJSON: {Schema: schema}}
+	case b.protoRequest != nil:
+		// All fields of the message are accepted in the body, path parameters take precedence.
+		body.Required = false
+		body.Content = map[string]*MediaType{echo.MIMEApplicationJSON: {Schema: reg.protoRef(b.protoRequest)}}
+	case b.contentType != "":
+		body.Content = map[string]*MediaType{b.contentType: {Schema: &Schema{Type: "string", Format: "binary"}}}
+	case b.request != nil && hasBody(b.request):
+		body.Content = map[string]*MediaType{echo.MIMEApplicationJSON: {Schema: reg.schemaOf(b.request)}}
+	default:
+		return nil
+	}
+	return body
+}
+
+func (d *Document) pathItem(path string) *PathItem {
+	item, ok := d.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		d.Paths[path] = item
+	}
+	return item
+}
+
+func (p *PathItem) set(method string, op *Operation) {
+	switch method {
+	case http.MethodGet:
+		p.Get = op
+	case http.MethodPut:
+		p.Put = op
+	case http.MethodPost:
+		p.Post = op
+	case http.MethodDelete:
+		p.Delete = op
+	case http.MethodOptions:
+		p.Options = op
+	case http.MethodHead:
+		p.Head = op
+	case http.MethodPatch:
+		p.Patch = op
+	}
+}
+
+func isDocumentedMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+		http.MethodOptions, http.MethodHead, http.MethodPatch:
+		return true
+	}
+	return false
+}
+
+// toOpenAPIPath converts the Echo path parameters of path to the OpenAPI notation, e.g.
+// "/assets/:id" to "/assets/{id}".
+func toOpenAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if name, ok := strings.CutPrefix(s, ":"); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func pathParamNames(path string) []string {
+	var names []string
+	for _, s := range strings.Split(path, "/") {
+		if name, ok := strings.CutPrefix(s, ":"); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// operationID derives a unique operation ID from the method and path, e.g.
+// "getAdminMuxAssetsById" for "GET /admin/mux/assets/:id".
+func operationID(method, path string) string {
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(method))
+	for _, s := range strings.Split(path, "/") {
+		if name, ok := strings.CutPrefix(s, ":"); ok {
+			sb.WriteString("By")
+			s = name
+		}
+		for _, word := range strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+			sb.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// DocumentPath serves the OpenAPI document.
+	DocumentPath = "/openapi.json"
+	// UIPath serves Swagger UI rendering the document.
+	UIPath = "/docs"
+)
+
+// swaggerUI loads Swagger UI from a CDN, so the assets are not bundled into the binary.
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Media service API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({url: "` + DocumentPath + `", dom_id: "#swagger-ui", persistAuthorization: true});
+    };
+  </script>
+</body>
+</html>`
+
+// Register serves doc at [DocumentPath] and Swagger UI at [UIPath]. The document is encoded once,
+// routes registered afterwards are not part of it.
+func Register(e *echo.Echo, doc *Document) error {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	e.GET(DocumentPath, func(c echo.Context) error {
+		return c.JSONBlob(http.StatusOK, body)
+	})
+	e.GET(UIPath, func(c echo.Context) error {
+		return c.HTML(http.StatusOK, swaggerUI)
+	})
+	return nil
+}
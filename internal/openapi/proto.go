@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openapi
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// wellKnownTypes holds the schemas of the well-known types with a special JSON encoding.
+var wellKnownTypes = map[protoreflect.FullName]func() *Schema{
+	"google.protobuf.Timestamp": func() *Schema { return &Schema{Type: "string", Format: "date-time"} },
+	"google.protobuf.Duration": func() *Schema {
+		return &Schema{Type: "string", Description: "Duration in seconds with an \"s\" suffix, e.g. \"1.5s\"."}
+	},
+	"google.protobuf.FieldMask":   func() *Schema { return &Schema{Type: "string"} },
+	"google.protobuf.Struct":      func() *Schema { return &Schema{Type: "object"} },
+	"google.protobuf.Value":       func() *Schema { return &Schema{} },
+	"google.protobuf.ListValue":   func() *Schema { return &Schema{Type: "array", Items: &Schema{}} },
+	"google.protobuf.Empty":       func() *Schema { return &Schema{Type: "object"} },
+	"google.protobuf.Any":         func() *Schema { return &Schema{Type: "object"} },
+	"google.protobuf.BoolValue":   func() *Schema { return &Schema{Type: "boolean", Nullable: true} },
+	"google.protobuf.StringValue": func() *Schema { return &Schema{Type: "string", Nullable: true} },
+	"google.protobuf.BytesValue":  func() *Schema { return &Schema{Type: "string", Format: "byte", Nullable: true} },
+	"google.protobuf.Int32Value":  func() *Schema { return &Schema{Type: "integer", Format: "int32", Nullable: true} },
+	"google.protobuf.UInt32Value": func() *Schema { return &Schema{Type: "integer", Format: "int64", Nullable: true} },
+	"google.protobuf.Int64Value":  func() *Schema { return &Schema{Type: "string", Format: "int64", Nullable: true} },
+	"google.protobuf.UInt64Value": func() *Schema { return &Schema{Type: "string", Format: "uint64", Nullable: true} },
+	"google.protobuf.FloatValue":  func() *Schema { return &Schema{Type: "number", Format: "float", Nullable: true} },
+	"google.protobuf.DoubleValue": func() *Schema { return &Schema{Type: "number", Format: "double", Nullable: true} },
+}
+
+// protoRef adds the message described by md to the components under its full name and returns a
+// reference to it. The schema follows the gateway encoding: protojson with the proto field names
+// and bytes fields as UUID strings.
+func (r *schemaRegistry) protoRef(md protoreflect.MessageDescriptor) *Schema {
+	if known, ok := wellKnownTypes[md.FullName()]; ok {
+		return known()
+	}
+	name := string(md.FullName())
+	if _, ok := r.schemas[name]; !ok {
+		// Registered before the fields are generated, so recursive messages terminate.
+		r.schemas[name] = &Schema{}
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		fields := md.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			s.Properties[string(fd.Name())] = r.protoField(fd)
+		}
+		*r.schemas[name] = *s
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (r *schemaRegistry) protoField(fd protoreflect.FieldDescriptor) *Schema {
+	switch {
+	case fd.IsMap():
+		return &Schema{Type: "object", AdditionalProperties: r.protoSingular(fd.MapValue())}
+	case fd.IsList():
+		return &Schema{Type: "array", Items: r.protoSingular(fd)}
+	default:
+		return r.protoSingular(fd)
+	}
+}
+
+func (r *schemaRegistry) protoSingular(fd protoreflect.FieldDescriptor) *Schema {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return &Schema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &Schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &Schema{Type: "integer", Format: "int64"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return &Schema{Type: "string", Format: "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &Schema{Type: "string", Format: "uint64"}
+	case protoreflect.FloatKind:
+		return &Schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &Schema{Type: "number", Format: "double"}
+	case protoreflect.StringKind:
+		return &Schema{Type: "string"}
+	case protoreflect.BytesKind:
+		// Bytes fields hold UUIDs in the v1 API and are encoded as UUID strings by the gateway.
+		return &Schema{Type: "string", Format: "uuid"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		s := &Schema{Type: "string", Enum: make([]string, 0, values.Len())}
+		for i := 0; i < values.Len(); i++ {
+			s.Enum = append(s.Enum, string(values.Get(i).Name()))
+		}
+		return s
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return r.protoRef(fd.Message())
+	default:
+		return &Schema{}
+	}
+}
+
+// protoParameters returns the path parameters of a gateway route and, for GET routes, the scalar
+// fields of the request message not bound from the path as query parameters.
+func (r *schemaRegistry) protoParameters(md protoreflect.MessageDescriptor, method string, pathParams []string) []*Parameter {
+	fields := md.Fields()
+	params := make([]*Parameter, 0, len(pathParams))
+	inPath := make(map[protoreflect.Name]bool)
+	for _, name := range pathParams {
+		p := &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}}
+		if fd := fields.ByName(protoreflect.Name(name)); fd != nil {
+			p.Schema = r.protoSingular(fd)
+			inPath[fd.Name()] = true
+		}
+		params = append(params, p)
+	}
+	if method != "GET" {
+		return params
+	}
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if inPath[fd.Name()] || fd.Message() != nil {
+			continue
+		}
+		params = append(params, &Parameter{Name: string(fd.Name()), In: "query", Schema: r.protoField(fd)})
+	}
+	return params
+}
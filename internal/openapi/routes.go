@@ -0,0 +1,621 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	cfstreamapi "github.com/mikhail5545/media-service-go/internal/apiclients/cfstream"
+	"github.com/mikhail5545/media-service-go/internal/emulator"
+	videohandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/video"
+	devhandler "github.com/mikhail5545/media-service-go/internal/handlers/dev"
+	"github.com/mikhail5545/media-service-go/internal/health"
+	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	cldmetadatamodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/metadata"
+	cldtypes "github.com/mikhail5545/media-service-go/internal/models/cloudinary/types"
+	costmodel "github.com/mikhail5545/media-service-go/internal/models/cost"
+	exportmodel "github.com/mikhail5545/media-service-go/internal/models/export"
+	livemodel "github.com/mikhail5545/media-service-go/internal/models/live"
+	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	muxmetadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	muxtypes "github.com/mikhail5545/media-service-go/internal/models/mux/types"
+	uploadproxymodel "github.com/mikhail5545/media-service-go/internal/models/uploadproxy"
+	"github.com/mikhail5545/media-service-go/internal/seed"
+	assetimportservice "github.com/mikhail5545/media-service-go/internal/services/assetimport"
+	auditservice "github.com/mikhail5545/media-service-go/internal/services/audit"
+	catalogservice "github.com/mikhail5545/media-service-go/internal/services/catalog"
+	cfstreamservice "github.com/mikhail5545/media-service-go/internal/services/cfstream"
+	cldservice "github.com/mikhail5545/media-service-go/internal/services/cloudinary"
+	collectionservice "github.com/mikhail5545/media-service-go/internal/services/collection"
+	costservice "github.com/mikhail5545/media-service-go/internal/services/cost"
+	exportservice "github.com/mikhail5545/media-service-go/internal/services/export"
+	"github.com/mikhail5545/media-service-go/internal/services/mediacore"
+	muxservice "github.com/mikhail5545/media-service-go/internal/services/mux"
+	playbackservice "github.com/mikhail5545/media-service-go/internal/services/playback"
+	retentionservice "github.com/mikhail5545/media-service-go/internal/services/retention"
+	s3service "github.com/mikhail5545/media-service-go/internal/services/s3"
+	signingkeyservice "github.com/mikhail5545/media-service-go/internal/services/signingkey"
+	subscriptionservice "github.com/mikhail5545/media-service-go/internal/services/subscription"
+	uploadproxyservice "github.com/mikhail5545/media-service-go/internal/services/uploadproxy"
+	usageservice "github.com/mikhail5545/media-service-go/internal/services/usage"
+	watermarkservice "github.com/mikhail5545/media-service-go/internal/services/watermark"
+	webhookservice "github.com/mikhail5545/media-service-go/internal/services/webhook"
+	cldassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/asset/v1"
+	muxassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/asset/v1"
+)
+
+// Route documents an Echo route. Path uses the Echo notation, e.g. "/api/v1/admin/mux/assets/:id".
+type Route struct {
+	Method  string
+	Path    string
+	Tag     string
+	Summary string
+	// Public marks routes served without authentication.
+	Public  bool
+	Binding Binding
+}
+
+// tags describes the tags of the documented routes, in the order they are listed.
+var tags = []Tag{
+	{Name: "mux", Description: "MUX video assets."},
+	{Name: "cloudinary", Description: "Cloudinary image assets."},
+	{Name: "media", Description: "Assets of the backends built on the shared asset core."},
+	{Name: "catalog", Description: "Media of all backends."},
+	{Name: "videos", Description: "Provider agnostic video uploads."},
+	{Name: "s3", Description: "Files stored in the object storage."},
+	{Name: "cfstream", Description: "Cloudflare Stream video assets."},
+	{Name: "uploads", Description: "Resumable uploads following the tus protocol."},
+	{Name: "collections", Description: "Asset collections."},
+	{Name: "watermarks", Description: "Watermarks of the creators and the owner types."},
+	{Name: "playback", Description: "Playback sessions."},
+	{Name: "signing-keys", Description: "Rotated MUX URL signing keys the playback tokens are signed with."},
+	{Name: "audit", Description: "Audit log."},
+	{Name: "usage", Description: "Storage and delivery usage."},
+	{Name: "costs", Description: "Monthly cost estimates attributed to the creators and the owner types."},
+	{Name: "live", Description: "Live asset events of the admin UI."},
+	{Name: "retention", Description: "Permanent deletion of the expired archived assets."},
+	{Name: "export", Description: "Asset inventory exports."},
+	{Name: "seed", Description: "Demo and integration test fixtures."},
+	{Name: "import", Description: "Imports of the assets created outside of the service."},
+	{Name: "delivery", Description: "Playback and delivery of the assets to the authenticated end users."},
+	{Name: "webhooks", Description: "Provider notifications."},
+	{Name: "webhook-subscriptions", Description: "Outgoing webhooks delivering the asset events to third-party integrations."},
+	{Name: "gateway", Description: "The v1 gRPC services transcoded to JSON/REST. Bytes fields are UUID strings."},
+	{Name: "health", Description: "Liveness and readiness probes."},
+	{Name: "dev", Description: "Emulated provider endpoints of the local development mode."},
+}
+
+// videoUploadURL is the response of the provider agnostic video upload URL endpoint.
+type videoUploadURL struct {
+	Provider string `json:"provider"`
+	// Data holds the upload URL response of the selected provider.
+	Data any `json:"data"`
+}
+
+// Routes returns the documentation of every route the HTTP server may register, with the admin, end user,
+// webhook and gateway routes mounted under basePath. Routes of disabled backends are left out of the
+// document by [Build], as they are not registered.
+func Routes(basePath string) []Route {
+	routes := []Route{
+		{Method: http.MethodGet, Path: "/healthz", Tag: "health", Summary: "Liveness probe", Public: true,
+			Binding: JSON[struct{}, health.Status](http.StatusOK, "status")},
+		{Method: http.MethodGet, Path: "/readyz", Tag: "health", Summary: "Readiness probe, responds with 503 when a critical dependency fails", Public: true,
+			Binding: Raw[struct{}, health.Report](http.StatusOK)},
+		{Method: http.MethodGet, Path: basePath + "/admin/health", Tag: "health", Summary: "Admin API health check", Public: true,
+			Binding: Text(http.StatusOK)},
+	}
+	routes = append(routes, muxRoutes(basePath+"/admin/mux")...)
+	routes = append(routes, cloudinaryRoutes(basePath+"/admin/cloudinary")...)
+	routes = append(routes, mediaRoutes(basePath+"/admin/media")...)
+	routes = append(routes, adminRoutes(basePath+"/admin")...)
+	routes = append(routes, deliveryRoutes(basePath+"/media")...)
+	routes = append(routes, webhookRoutes(basePath+"/webhooks")...)
+	routes = append(routes, gatewayRoutes(basePath+"/gateway")...)
+	routes = append(routes, devRoutes(basePath)...)
+	return routes
+}
+
+func muxRoutes(prefix string) []Route {
+	type svc = *muxservice.Service
+	assets := prefix + "/assets"
+	return tagged("mux", []Route{
+		{Method: http.MethodGet, Path: prefix + "/owner-types", Summary: "List the supported owner types",
+			Binding: JSON[struct{}, []string](http.StatusOK, "owner_types")},
+		{Method: http.MethodPost, Path: prefix + "/playback-restrictions/sync", Summary: "Synchronize the playback restriction policies with the MUX playback restrictions",
+			Binding: Handle(svc.SyncPlaybackRestrictions, http.StatusOK, "sync")},
+		{Method: http.MethodGet, Path: assets + "/:id", Summary: "Get an active asset",
+			Binding: Handle(svc.Get, http.StatusOK, "asset")},
+		{Method: http.MethodGet, Path: assets + "/archived/:id", Summary: "Get an asset including archived ones",
+			Binding: Handle(svc.GetWithArchived, http.StatusOK, "asset")},
+		{Method: http.MethodGet, Path: assets + "/broken/:id", Summary: "Get an asset including broken ones",
+			Binding: Handle(svc.GetWithBroken, http.StatusOK, "asset")},
+		{Method: http.MethodGet, Path: assets, Summary: "List active assets",
+			Binding: HandleListWithTotal(svc.List, "assets")},
+		{Method: http.MethodGet, Path: assets + "/archived", Summary: "List archived assets",
+			Binding: HandleListWithTotal(svc.ListArchived, "assets")},
+		{Method: http.MethodGet, Path: assets + "/broken", Summary: "List broken assets",
+			Binding: HandleListWithTotal(svc.ListBroken, "assets")},
+		{Method: http.MethodGet, Path: assets + "/by-owner", Summary: "List the assets of an owner",
+			Binding: HandleList(svc.ListByOwner, "assets")},
+		{Method: http.MethodGet, Path: assets + "/by-owner/:owner_type/:owner_id", Summary: "Get the asset of an owner",
+			Binding: Handle(svc.GetByOwner, http.StatusOK, "asset")},
+		{Method: http.MethodGet, Path: assets + "/by-owner/:owner_type/:owner_id/versions", Summary: "List the asset versions of an owner",
+			Binding: Handle(svc.ListVersions, http.StatusOK, "versions")},
+		{Method: http.MethodPost, Path: assets + "/by-owner/:owner_type/:owner_id/versions/:number/rollback", Summary: "Roll an owner back to the asset of an earlier version",
+			Binding: Handle(svc.RollbackVersion, http.StatusOK, "association")},
+		{Method: http.MethodPost, Path: assets + "/validate", Summary: "Check that assets exist and are healthy",
+			Binding: Handle(svc.ValidateAssets, http.StatusOK, "assets")},
+		{Method: http.MethodGet, Path: assets + "/by-tag", Summary: "List the assets with a tag",
+			Binding: HandleList(svc.ListByTag, "assets")},
+		{Method: http.MethodGet, Path: assets + "/search", Summary: "Search assets",
+			Binding: HandleList(svc.Search, "assets")},
+		{Method: http.MethodGet, Path: assets + "/by-collection", Summary: "List the assets of a collection",
+			Binding: HandleList(svc.ListByCollection, "assets")},
+		{Method: http.MethodGet, Path: assets + "/:id/history", Summary: "Get the change history of an asset",
+			Binding: Handle(svc.GetHistory, http.StatusOK, "events")},
+		{Method: http.MethodGet, Path: assets + "/:id/watch", Summary: "Watch the status of an asset until it is processed",
+			Binding: EventStream[muxassetmodel.WatchAssetRequest, muxassetmodel.StatusUpdate]().
+				Describe("Streams server-sent events: a \"status\" event with the current status and on every change, " +
+					"then a \"timeout\" event if the asset is not ready, errored or deleted in time.")},
+		{Method: http.MethodGet, Path: assets + "/deletions/stuck", Summary: "List assets stuck in deletion",
+			Binding: Handle(svc.ListStuckDeletions, http.StatusOK, "assets")},
+		{Method: http.MethodGet, Path: assets + "/duplicates", Summary: "List groups of probable duplicate assets",
+			Binding: Handle(svc.FindDuplicates, http.StatusOK, "groups")},
+		{Method: http.MethodGet, Path: assets + "/triage/errors", Summary: "Count the broken and errored assets by error type",
+			Binding: Handle(svc.CountErrors, http.StatusOK, "errors")},
+		{Method: http.MethodGet, Path: assets + "/triage/stuck", Summary: "List assets stuck waiting for an upload or for processing",
+			Binding: Handle(svc.ListStuckAssets, http.StatusOK, "assets")},
+		{Method: http.MethodPost, Path: assets + "/:id/reprocess", Summary: "Re-fetch the state of an asset from MUX",
+			Binding: Handle(svc.ReprocessAsset, http.StatusOK, "asset")},
+		{Method: http.MethodPost, Path: assets + "/upload-url", Summary: "Create a direct upload URL",
+			Binding: Handle(svc.CreateUploadURL, http.StatusCreated, "data")},
+		{Method: http.MethodDelete, Path: assets + "/archive/:id", Summary: "Archive an asset",
+			Binding: HandleVoid(svc.Archive, http.StatusNoContent)},
+		{Method: http.MethodPost, Path: assets + "/restore/:id", Summary: "Restore an archived asset",
+			Binding: HandleVoid(svc.Restore, http.StatusOK)},
+		{Method: http.MethodPost, Path: assets + "/restore/:id/owners", Summary: "Restore an archived asset with its former owners",
+			Binding: Handle(svc.RestoreWithOwners, http.StatusOK, "metadata")},
+		{Method: http.MethodDelete, Path: assets + "/:id", Summary: "Delete an archived asset",
+			Binding: HandleVoid(svc.Delete, http.StatusAccepted)},
+		{Method: http.MethodPost, Path: assets + "/broken/:id", Summary: "Mark an asset as broken",
+			Binding: HandleVoid(svc.MarkAsBroken, http.StatusOK)},
+		{Method: http.MethodPatch, Path: assets + "/:id/metadata", Summary: "Update the metadata of an asset",
+			Binding: Handle(svc.UpdateMetadata, http.StatusOK, "metadata")},
+		{Method: http.MethodPost, Path: assets + "/:id/tags", Summary: "Add tags to an asset",
+			Binding: Handle(svc.AddTags, http.StatusOK, "tags")},
+		{Method: http.MethodDelete, Path: assets + "/:id/tags", Summary: "Remove tags from an asset",
+			Binding: Handle(svc.RemoveTags, http.StatusOK, "tags")},
+		{Method: http.MethodPut, Path: assets + "/:id/publishing", Summary: "Schedule the publication of an asset",
+			Binding: Handle(svc.SchedulePublishing, http.StatusOK, "publishing")},
+		{Method: http.MethodDelete, Path: assets + "/:id/publishing", Summary: "Cancel the publication schedule of an asset",
+			Binding: Handle(svc.CancelPublishing, http.StatusOK, "publishing")},
+		{Method: http.MethodPost, Path: assets + "/:id/owners", Summary: "Add an owner to an asset, optionally moving it from its other assets",
+			Binding: Handle(svc.AssociateOwner, http.StatusCreated, "association")},
+		{Method: http.MethodDelete, Path: assets + "/:id/owners", Summary: "Remove an owner from an asset",
+			Binding: HandleVoid(svc.RemoveOwner, http.StatusNoContent)},
+		{Method: http.MethodPut, Path: assets + "/:id/owners", Summary: "Replace the owners of an asset",
+			Binding: JSON[muxassetmodel.UpdateOwnersRequest, []*muxmetadatamodel.Owner](http.StatusOK, "owners").
+				Header("If-Match", "Expected revision of the asset metadata, overrides the revision of the body.", false).
+				ResponseHeaders("ETag")},
+		{Method: http.MethodPost, Path: assets + "/:id/playback-ids", Summary: "Add a playback ID",
+			Binding: Handle(svc.AddPlaybackID, http.StatusCreated, "playback_ids")},
+		{Method: http.MethodDelete, Path: assets + "/:id/playback-ids/:playback_id", Summary: "Remove a playback ID",
+			Binding: Handle(svc.RemovePlaybackID, http.StatusOK, "playback_ids")},
+		{Method: http.MethodPost, Path: assets + "/:id/playback-ids/rotate", Summary: "Rotate the playback IDs",
+			Binding: Handle(svc.RotatePlaybackID, http.StatusOK, "playback_ids")},
+		{Method: http.MethodPut, Path: assets + "/:id/poster", Summary: "Set the poster to a frame of the video or to a Cloudinary image",
+			Binding: Handle(svc.SetPoster, http.StatusOK, "poster")},
+		{Method: http.MethodDelete, Path: assets + "/:id/poster", Summary: "Reset the poster to the frame picked by MUX",
+			Binding: Handle(svc.ResetPoster, http.StatusOK, "poster")},
+		{Method: http.MethodGet, Path: assets + "/:id/languages", Summary: "List the audio and subtitle languages of a video",
+			Binding: Handle(svc.ListLanguages, http.StatusOK, "languages")},
+		{Method: http.MethodPatch, Path: assets + "/:id/tracks/:track_id", Summary: "Rename a track or make an audio track the default",
+			Binding: Handle(svc.UpdateTrack, http.StatusOK, "languages")},
+		{Method: http.MethodGet, Path: assets + "/:id/chapters", Summary: "List the chapters of a video",
+			Binding: Handle(svc.ListChapters, http.StatusOK, "chapters")},
+		{Method: http.MethodPost, Path: assets + "/:id/chapters", Summary: "Add a chapter to a video",
+			Binding: Handle(svc.AddChapter, http.StatusCreated, "chapters")},
+		{Method: http.MethodPatch, Path: assets + "/:id/chapters/:chapter_id", Summary: "Change a chapter",
+			Binding: Handle(svc.UpdateChapter, http.StatusOK, "chapters")},
+		{Method: http.MethodDelete, Path: assets + "/:id/chapters/:chapter_id", Summary: "Remove a chapter",
+			Binding: Handle(svc.DeleteChapter, http.StatusOK, "chapters")},
+	})
+}
+
+func cloudinaryRoutes(prefix string) []Route {
+	type svc = *cldservice.Service
+	assets := prefix + "/assets"
+	return tagged("cloudinary", []Route{
+		{Method: http.MethodGet, Path: prefix + "/owner-types", Summary: "List the supported owner types",
+			Binding: JSON[struct{}, []string](http.StatusOK, "owner_types")},
+		{Method: http.MethodGet, Path: assets + "/:id", Summary: "Get an active asset",
+			Binding: Handle(svc.Get, http.StatusOK, "asset")},
+		{Method: http.MethodGet, Path: assets + "/archived/:id", Summary: "Get an asset including archived ones",
+			Binding: Handle(svc.GetWithArchived, http.StatusOK, "asset")},
+		{Method: http.MethodGet, Path: assets + "/broken/:id", Summary: "Get an asset including broken ones",
+			Binding: Handle(svc.GetWithBroken, http.StatusOK, "asset")},
+		{Method: http.MethodGet, Path: assets, Summary: "List active assets",
+			Binding: HandleListWithTotal(svc.List, "assets")},
+		{Method: http.MethodGet, Path: assets + "/archived", Summary: "List archived assets",
+			Binding: HandleListWithTotal(svc.ListArchived, "assets")},
+		{Method: http.MethodGet, Path: assets + "/broken", Summary: "List broken assets",
+			Binding: HandleListWithTotal(svc.ListBroken, "assets")},
+		{Method: http.MethodGet, Path: assets + "/by-owner", Summary: "List the assets of an owner",
+			Binding: HandleList(svc.ListByOwner, "assets")},
+		{Method: http.MethodGet, Path: assets + "/by-owner/:owner_type/:owner_id", Summary: "Get the asset of an owner",
+			Binding: Handle(svc.GetByOwner, http.StatusOK, "asset")},
+		{Method: http.MethodGet, Path: assets + "/by-owner/:owner_type/:owner_id/versions", Summary: "List the asset versions of an owner",
+			Binding: Handle(svc.ListVersions, http.StatusOK, "versions")},
+		{Method: http.MethodPost, Path: assets + "/by-owner/:owner_type/:owner_id/versions/:number/rollback", Summary: "Roll an owner back to the asset of an earlier version",
+			Binding: Handle(svc.RollbackVersion, http.StatusOK, "association")},
+		{Method: http.MethodPost, Path: assets + "/validate", Summary: "Check that assets exist and are healthy",
+			Binding: Handle(svc.ValidateAssets, http.StatusOK, "assets")},
+		{Method: http.MethodGet, Path: assets + "/by-tag", Summary: "List the assets with a tag",
+			Binding: HandleList(svc.ListByTag, "assets")},
+		{Method: http.MethodGet, Path: assets + "/search", Summary: "Search assets",
+			Binding: HandleList(svc.Search, "assets")},
+		{Method: http.MethodGet, Path: assets + "/by-collection", Summary: "List the assets of a collection",
+			Binding: HandleList(svc.ListByCollection, "assets")},
+		{Method: http.MethodGet, Path: assets + "/:id/history", Summary: "Get the change history of an asset",
+			Binding: Handle(svc.GetHistory, http.StatusOK, "events")},
+		{Method: http.MethodGet, Path: assets + "/deletions/stuck", Summary: "List assets stuck in deletion",
+			Binding: Handle(svc.ListStuckDeletions, http.StatusOK, "assets")},
+		{Method: http.MethodGet, Path: assets + "/duplicates", Summary: "List groups of probable duplicate assets",
+			Binding: Handle(svc.FindDuplicates, http.StatusOK, "groups")},
+		{Method: http.MethodPost, Path: assets + "/upload/url-gen", Summary: "Generate signed upload parameters",
+			Binding: Handle(svc.CreateSignedUploadURL, http.StatusOK, "generated")},
+		{Method: http.MethodPost, Path: assets + "/upload", Summary: "Upload an image through the service",
+			Binding: JSON[cldassetmodel.UploadRequest, *cldassetmodel.Asset](http.StatusCreated, "asset").Multipart("file")},
+		{Method: http.MethodDelete, Path: assets + "/archive/:id", Summary: "Archive an asset",
+			Binding: HandleVoid(svc.Archive, http.StatusNoContent)},
+		{Method: http.MethodPost, Path: assets + "/restore/:id", Summary: "Restore an archived asset",
+			Binding: HandleVoid(svc.Restore, http.StatusOK)},
+		{Method: http.MethodPost, Path: assets + "/restore/:id/owners", Summary: "Restore an archived asset with its former owners",
+			Binding: Handle(svc.RestoreWithOwners, http.StatusOK, "metadata")},
+		{Method: http.MethodPost, Path: assets + "/restore/:id/backup", Summary: "Restore the file of an image destroyed in Cloudinary from its backup",
+			Binding: Handle(svc.RestoreFromBackup, http.StatusOK, "asset")},
+		{Method: http.MethodDelete, Path: assets + "/:id", Summary: "Delete an archived asset",
+			Binding: HandleVoid(svc.Delete, http.StatusAccepted)},
+		{Method: http.MethodPost, Path: assets + "/broken/:id", Summary: "Mark an asset as broken",
+			Binding: HandleVoid(svc.MarkAsBroken, http.StatusOK)},
+		{Method: http.MethodPost, Path: assets + "/moderation/approve/:id", Summary: "Approve a pending asset",
+			Binding: HandleVoid(svc.ApproveModeration, http.StatusOK)},
+		{Method: http.MethodPost, Path: assets + "/moderation/reject/:id", Summary: "Reject a pending asset",
+			Binding: HandleVoid(svc.RejectModeration, http.StatusOK)},
+		{Method: http.MethodPatch, Path: assets + "/:id/metadata", Summary: "Update the metadata of an asset",
+			Binding: Handle(svc.UpdateMetadata, http.StatusOK, "metadata")},
+		{Method: http.MethodPost, Path: assets + "/:id/tags", Summary: "Add tags to an asset",
+			Binding: Handle(svc.AddTags, http.StatusOK, "tags")},
+		{Method: http.MethodDelete, Path: assets + "/:id/tags", Summary: "Remove tags from an asset",
+			Binding: Handle(svc.RemoveTags, http.StatusOK, "tags")},
+		{Method: http.MethodPut, Path: assets + "/:id/publishing", Summary: "Schedule the publication of an asset",
+			Binding: Handle(svc.SchedulePublishing, http.StatusOK, "publishing")},
+		{Method: http.MethodDelete, Path: assets + "/:id/publishing", Summary: "Cancel the publication schedule of an asset",
+			Binding: Handle(svc.CancelPublishing, http.StatusOK, "publishing")},
+		{Method: http.MethodPost, Path: assets + "/:id/owners", Summary: "Add an owner to an asset, optionally moving it from its other assets",
+			Binding: Handle(svc.AssociateOwner, http.StatusCreated, "association")},
+		{Method: http.MethodDelete, Path: assets + "/:id/owners", Summary: "Remove an owner from an asset",
+			Binding: HandleVoid(svc.RemoveOwner, http.StatusNoContent)},
+		{Method: http.MethodPut, Path: assets + "/:id/owners", Summary: "Replace the owners of an asset",
+			Binding: JSON[cldassetmodel.UpdateOwnersRequest, []*cldmetadatamodel.Owner](http.StatusOK, "owners").
+				Header("If-Match", "Expected revision of the asset metadata, overrides the revision of the body.", false).
+				ResponseHeaders("ETag")},
+	})
+}
+
+func mediaRoutes(prefix string) []Route {
+	type svc = *mediacore.Service
+	// The provider path parameter is resolved by the handler and added by Build.
+	assets := prefix + "/:provider/assets"
+	return tagged("media", []Route{
+		{Method: http.MethodGet, Path: prefix + "/providers", Summary: "List the providers built on the asset core",
+			Binding: JSON[struct{}, []string](http.StatusOK, "providers")},
+		{Method: http.MethodGet, Path: assets + "/:id", Summary: "Get an active asset",
+			Binding: Handle(svc.Get, http.StatusOK, "asset")},
+		{Method: http.MethodGet, Path: assets + "/archived/:id", Summary: "Get an asset including archived ones",
+			Binding: Handle(svc.GetWithArchived, http.StatusOK, "asset")},
+		{Method: http.MethodGet, Path: assets, Summary: "List active assets",
+			Binding: HandleList(svc.List, "assets")},
+		{Method: http.MethodGet, Path: assets + "/archived", Summary: "List archived assets",
+			Binding: HandleList(svc.ListArchived, "assets")},
+		{Method: http.MethodGet, Path: assets + "/by-owner", Summary: "List the assets of an owner",
+			Binding: HandleList(svc.ListByOwner, "assets")},
+		{Method: http.MethodGet, Path: assets + "/deletions/stuck", Summary: "List assets stuck in deletion",
+			Binding: Handle(svc.ListStuckDeletions, http.StatusOK, "assets")},
+		{Method: http.MethodDelete, Path: assets + "/archive/:id", Summary: "Archive an asset",
+			Binding: HandleVoid(svc.Archive, http.StatusNoContent)},
+		{Method: http.MethodPost, Path: assets + "/restore/:id", Summary: "Restore an archived asset",
+			Binding: HandleVoid(svc.Restore, http.StatusOK)},
+		{Method: http.MethodDelete, Path: assets + "/:id", Summary: "Delete an archived asset",
+			Binding: HandleVoid(svc.Delete, http.StatusAccepted)},
+		{Method: http.MethodPost, Path: assets + "/:id/owners", Summary: "Add an owner to an asset",
+			Binding: Handle(svc.AddOwner, http.StatusCreated, "metadata")},
+		{Method: http.MethodDelete, Path: assets + "/:id/owners", Summary: "Remove an owner from an asset",
+			Binding: Handle(svc.RemoveOwner, http.StatusOK, "metadata")},
+	})
+}
+
+func adminRoutes(prefix string) []Route {
+	type (
+		catalogSvc      = *catalogservice.Service
+		collectionSvc   = *collectionservice.Service
+		watermarkSvc    = *watermarkservice.Service
+		s3Svc           = *s3service.Service
+		cfStreamSvc     = *cfstreamservice.Service
+		uploadSvc       = *uploadproxyservice.Service
+		exportSvc       = *exportservice.Service
+		importSvc       = *assetimportservice.Service
+		subscriptionSvc = *subscriptionservice.Service
+	)
+	uploads := prefix + "/uploads"
+	exports := prefix + "/export"
+	imports := prefix + "/import"
+	subscriptions := prefix + "/webhook-subscriptions"
+	var routes []Route
+	routes = append(routes, tagged("catalog", []Route{
+		{Method: http.MethodGet, Path: prefix + "/catalog/providers", Summary: "List the providers of the catalog",
+			Binding: JSON[struct{}, []string](http.StatusOK, "providers")},
+		{Method: http.MethodGet, Path: prefix + "/catalog/media", Summary: "List the media of all providers",
+			Binding: HandleList(catalogSvc.ListMedia, "media")},
+	})...)
+	routes = append(routes, tagged("videos", []Route{
+		{Method: http.MethodPost, Path: prefix + "/videos/upload-url", Summary: "Create an upload URL in the selected video provider",
+			Binding: Raw[videohandler.CreateUploadURLRequest, videoUploadURL](http.StatusCreated)},
+	})...)
+	routes = append(routes, tagged("s3", []Route{
+		{Method: http.MethodPost, Path: prefix + "/s3/assets/upload-url", Summary: "Create a presigned upload URL",
+			Binding: Handle(s3Svc.CreateUploadURL, http.StatusCreated, "data")},
+		{Method: http.MethodPost, Path: prefix + "/s3/assets/:id/confirm", Summary: "Confirm a finished upload",
+			Binding: Handle(s3Svc.ConfirmUpload, http.StatusOK, "asset")},
+		{Method: http.MethodGet, Path: prefix + "/s3/assets/:id/download-url", Summary: "Create a presigned download URL",
+			Binding: Handle(s3Svc.GetDownloadURL, http.StatusOK, "download")},
+	})...)
+	routes = append(routes, tagged("cfstream", []Route{
+		{Method: http.MethodPost, Path: prefix + "/cfstream/assets/upload-url", Summary: "Create a direct upload URL",
+			Binding: Handle(cfStreamSvc.CreateUploadURL, http.StatusCreated, "data")},
+		{Method: http.MethodGet, Path: prefix + "/cfstream/assets/:id/playback-url", Summary: "Get the playback URL of a video",
+			Binding: Handle(cfStreamSvc.GetPlaybackURL, http.StatusOK, "playback")},
+	})...)
+	routes = append(routes, tagged("uploads", []Route{
+		{Method: http.MethodOptions, Path: uploads, Summary: "Report the supported tus version, extensions and maximum size",
+			Binding: Empty[struct{}](http.StatusNoContent).ResponseHeaders("Tus-Resumable", "Tus-Version", "Tus-Extension", "Tus-Max-Size")},
+		{Method: http.MethodPost, Path: uploads, Summary: "Start an upload",
+			Binding: JSON[struct{}, *uploadproxymodel.Session](http.StatusCreated, "upload").
+				Header("Upload-Length", "Size of the upload in bytes.", true).
+				Header("Upload-Metadata", "Comma separated \"key base64(value)\" pairs of provider, title, admin_id and admin_name.", true).
+				ResponseHeaders("Tus-Resumable", "Location")},
+		{Method: http.MethodHead, Path: uploads + "/:id", Summary: "Get the offset of an upload",
+			Binding: Empty[uploadproxymodel.GetRequest](http.StatusOK).ResponseHeaders("Tus-Resumable", "Upload-Offset", "Upload-Length")},
+		{Method: http.MethodGet, Path: uploads + "/:id", Summary: "Get the progress of an upload",
+			Binding: Handle(uploadSvc.Get, http.StatusOK, "upload")},
+		{Method: http.MethodPatch, Path: uploads + "/:id", Summary: "Append a chunk to an upload",
+			Binding: Empty[uploadproxymodel.AppendRequest](http.StatusNoContent).
+				RawBody("application/offset+octet-stream").
+				Header("Upload-Offset", "Offset the chunk is written at.", true).
+				ResponseHeaders("Tus-Resumable", "Upload-Offset")},
+		{Method: http.MethodDelete, Path: uploads + "/:id", Summary: "Cancel an upload",
+			Binding: HandleVoid(uploadSvc.Delete, http.StatusNoContent).ResponseHeaders("Tus-Resumable")},
+	})...)
+	routes = append(routes, tagged("collections", []Route{
+		{Method: http.MethodGet, Path: prefix + "/collections/:id", Summary: "Get a collection",
+			Binding: Handle(collectionSvc.Get, http.StatusOK, "collection")},
+		{Method: http.MethodGet, Path: prefix + "/collections", Summary: "List collections",
+			Binding: HandleList(collectionSvc.List, "collections")},
+		{Method: http.MethodPost, Path: prefix + "/collections", Summary: "Create a collection",
+			Binding: Handle(collectionSvc.Create, http.StatusCreated, "collection")},
+		{Method: http.MethodPatch, Path: prefix + "/collections/:id", Summary: "Update a collection",
+			Binding: Handle(collectionSvc.Update, http.StatusOK, "collection")},
+		{Method: http.MethodDelete, Path: prefix + "/collections/:id", Summary: "Delete a collection",
+			Binding: HandleVoid(collectionSvc.Delete, http.StatusNoContent)},
+		{Method: http.MethodPost, Path: prefix + "/collections/:id/assets", Summary: "Add assets to a collection",
+			Binding: HandleVoid(collectionSvc.AddAssets, http.StatusNoContent)},
+		{Method: http.MethodDelete, Path: prefix + "/collections/:id/assets", Summary: "Remove assets from a collection",
+			Binding: HandleVoid(collectionSvc.RemoveAssets, http.StatusNoContent)},
+	})...)
+	routes = append(routes, tagged("watermarks", []Route{
+		{Method: http.MethodGet, Path: prefix + "/watermarks", Summary: "List watermarks",
+			Binding: Handle(watermarkSvc.List, http.StatusOK, "watermarks")},
+		{Method: http.MethodGet, Path: prefix + "/watermarks/:scope/:key", Summary: "Get the watermark of a creator or an owner type",
+			Binding: Handle(watermarkSvc.Get, http.StatusOK, "watermark")},
+		{Method: http.MethodPut, Path: prefix + "/watermarks/:scope/:key", Summary: "Set the watermark of a creator or an owner type",
+			Binding: Handle(watermarkSvc.Set, http.StatusOK, "watermark")},
+		{Method: http.MethodDelete, Path: prefix + "/watermarks/:scope/:key", Summary: "Delete the watermark of a creator or an owner type",
+			Binding: HandleVoid(watermarkSvc.Delete, http.StatusNoContent)},
+	})...)
+	routes = append(routes,
+		Route{Method: http.MethodGet, Path: prefix + "/audit", Tag: "audit", Summary: "List audit log entries",
+			Binding: HandleList((*auditservice.Service).List, "entries")},
+		Route{Method: http.MethodGet, Path: prefix + "/webhooks/errors", Tag: "webhooks", Summary: "List the webhooks whose processing failed",
+			Binding: Handle((*webhookservice.Queue).ListErrors, http.StatusOK, "events")},
+		Route{Method: http.MethodPost, Path: prefix + "/webhooks/:id/replay", Tag: "webhooks", Summary: "Replay a stored webhook",
+			Binding: HandleVoid((*webhookservice.Queue).Replay, http.StatusAccepted)},
+		Route{Method: http.MethodPost, Path: prefix + "/retention/run", Tag: "retention", Summary: "Purge the expired archived assets now",
+			Binding: Handle((*retentionservice.Worker).Trigger, http.StatusOK, "records")},
+		Route{Method: http.MethodPost, Path: prefix + "/seed", Tag: "seed", Summary: "Load the fixtures which are not loaded yet",
+			Binding: Handle((*seed.Service).Load, http.StatusOK, "report")},
+		Route{Method: http.MethodDelete, Path: prefix + "/seed", Tag: "seed", Summary: "Permanently delete every fixture, only allowed in dev mode",
+			Binding: Handle((*seed.Service).Wipe, http.StatusOK, "report")},
+		Route{Method: http.MethodPost, Path: prefix + "/playback-sessions/revoke", Tag: "playback", Summary: "Revoke playback sessions",
+			Binding: Handle((*playbackservice.Service).Revoke, http.StatusOK, "result")},
+		Route{Method: http.MethodPost, Path: prefix + "/playback-sessions/validate", Tag: "playback", Summary: "Validate a playback token",
+			Binding: Handle((*playbackservice.Service).Validate, http.StatusOK, "validation")},
+		Route{Method: http.MethodGet, Path: prefix + "/signing-keys", Tag: "signing-keys", Summary: "List the signing keys whose playback tokens are accepted, with their public keys",
+			Binding: Handle((*signingkeyservice.Service).List, http.StatusOK, "key_set")},
+		Route{Method: http.MethodPost, Path: prefix + "/signing-keys/rotate", Tag: "signing-keys", Summary: "Rotate the signing key if it is due or forced and revoke the expired keys",
+			Binding: Handle((*signingkeyservice.Service).Rotate, http.StatusOK, "result")},
+		Route{Method: http.MethodGet, Path: prefix + "/usage", Tag: "usage", Summary: "Get the usage report",
+			Binding: Handle((*usageservice.Service).Report, http.StatusOK, "report")},
+		Route{Method: http.MethodGet, Path: prefix + "/costs", Tag: "costs", Summary: "Get the estimated cost of a month",
+			Binding: Handle((*costservice.Service).Report, http.StatusOK, "report")},
+		Route{Method: http.MethodGet, Path: prefix + "/costs/export", Tag: "costs", Summary: "Export the estimated cost of a month as CSV",
+			Binding: Empty[costmodel.ReportRequest](http.StatusOK).
+				ResponseHeaders("Content-Disposition").
+				Describe("Responds with the CSV file of the report, one row for each provider total, creator and owner type.")},
+		Route{Method: http.MethodGet, Path: prefix + "/ws", Tag: "live", Summary: "Stream the asset events over a WebSocket",
+			Binding: Empty[livemodel.Filter](http.StatusSwitchingProtocols).
+				Describe("Sends the asset events published by the instance as JSON messages. The client replaces the " +
+					"filter by sending a new one. Browsers authenticate with the \"bearer.<token>\" subprotocol " +
+					"next to the \"media-events.v1\" subprotocol.")},
+	)
+	routes = append(routes, tagged("export", []Route{
+		{Method: http.MethodPost, Path: exports, Summary: "Start an export of all assets",
+			Binding: Handle(exportSvc.Create, http.StatusAccepted, "job")},
+		{Method: http.MethodGet, Path: exports, Summary: "List the export jobs of the instance",
+			Binding: Handle(exportSvc.List, http.StatusOK, "jobs")},
+		{Method: http.MethodGet, Path: exports + "/:id", Summary: "Get the status of an export job",
+			Binding: Handle(exportSvc.Get, http.StatusOK, "job")},
+		{Method: http.MethodGet, Path: exports + "/:id/download", Summary: "Download the file of a completed export job",
+			Binding: Empty[exportmodel.GetJobRequest](http.StatusOK).
+				ResponseHeaders("Content-Disposition").
+				Describe("Responds with the CSV or NDJSON file, or 409 while the job is not completed.")},
+	})...)
+	routes = append(routes, tagged("import", []Route{
+		{Method: http.MethodPost, Path: imports, Summary: "Start an import of the unknown MUX and Cloudinary assets",
+			Binding: Handle(importSvc.Create, http.StatusAccepted, "job")},
+		{Method: http.MethodGet, Path: imports, Summary: "List the import jobs of the instance",
+			Binding: Handle(importSvc.List, http.StatusOK, "jobs")},
+		{Method: http.MethodGet, Path: imports + "/:id", Summary: "Get the status of an import job",
+			Binding: Handle(importSvc.Get, http.StatusOK, "job")},
+	})...)
+	routes = append(routes, tagged("webhook-subscriptions", []Route{
+		{Method: http.MethodGet, Path: subscriptions, Summary: "List the outgoing webhooks",
+			Binding: HandleList(subscriptionSvc.List, "subscriptions")},
+		{Method: http.MethodPost, Path: subscriptions, Summary: "Register an outgoing webhook",
+			Binding: Handle(subscriptionSvc.Create, http.StatusCreated, "subscription")},
+		{Method: http.MethodGet, Path: subscriptions + "/:id", Summary: "Get an outgoing webhook",
+			Binding: Handle(subscriptionSvc.Get, http.StatusOK, "subscription")},
+		{Method: http.MethodPatch, Path: subscriptions + "/:id", Summary: "Update an outgoing webhook",
+			Binding: Handle(subscriptionSvc.Update, http.StatusOK, "subscription")},
+		{Method: http.MethodDelete, Path: subscriptions + "/:id", Summary: "Delete an outgoing webhook and its delivery log",
+			Binding: HandleVoid(subscriptionSvc.Delete, http.StatusNoContent)},
+		{Method: http.MethodGet, Path: subscriptions + "/:id/deliveries", Summary: "List the deliveries of an outgoing webhook",
+			Binding: HandleList(subscriptionSvc.ListDeliveries, "deliveries")},
+		{Method: http.MethodPost, Path: subscriptions + "/:id/test", Summary: "Send a test event to an outgoing webhook",
+			Binding: Handle(subscriptionSvc.Test, http.StatusOK, "delivery")},
+	})...)
+	return routes
+}
+
+func deliveryRoutes(prefix string) []Route {
+	return tagged("delivery", []Route{
+		{Method: http.MethodGet, Path: prefix + "/video/:id/playback", Summary: "Get the playback ID, poster and a short-lived playback token of a video the user may access",
+			Binding: Handle((*muxservice.Service).PlaybackInfo, http.StatusOK, "playback").
+				ResponseHeaders("Set-Cookie").
+				Describe("With token_delivery=cookie the token is set as a Secure, HttpOnly cookie for the CDN serving " +
+					"the streams instead of being returned. With token_delivery=header the response names the header " +
+					"the token has to be sent in.")},
+		{Method: http.MethodGet, Path: prefix + "/video/:id/drm", Summary: "Get the playback and license tokens of a DRM protected video the user may access",
+			Binding: Handle((*muxservice.Service).DRMPlayback, http.StatusOK, "drm")},
+		{Method: http.MethodGet, Path: prefix + "/image/:id", Summary: "Get the delivery URL of an image the user may access",
+			Binding: Handle((*cldservice.Service).ImageDelivery, http.StatusOK, "image")},
+	})
+}
+
+func webhookRoutes(prefix string) []Route {
+	return tagged("webhooks", []Route{
+		{Method: http.MethodPost, Path: prefix + "/mux", Summary: "Receive MUX notifications, they are processed asynchronously", Public: true,
+			Binding: Empty[struct{}](http.StatusAccepted).
+				Body(muxtypes.MuxWebhook{}).
+				Header("Mux-Signature", "Signature of the payload, required when webhook verification is configured.", false)},
+		{Method: http.MethodPost, Path: prefix + "/cloudinary", Summary: "Receive Cloudinary notifications, they are processed asynchronously", Public: true,
+			Binding: Empty[struct{}](http.StatusAccepted).
+				Body(
+					cldassetmodel.CloudinaryUploadWebhook{},
+					cldtypes.CloudinaryRenameWebhook{},
+					cldtypes.CloudinaryDeleteWebhook{},
+					cldtypes.CloudinaryEagerWebhook{},
+					cldtypes.CloudinaryModerationWebhook{},
+					cldtypes.CloudinaryTagsChangedWebhook{},
+					cldtypes.CloudinaryDisplayNameChangedWebhook{},
+				).
+				Header("X-Cld-Timestamp", "Time the notification was signed at.", true).
+				Header("X-Cld-Signature", "Signature of the payload and timestamp.", true)},
+		{Method: http.MethodPost, Path: prefix + "/cfstream", Summary: "Receive Cloudflare Stream notifications, they are processed asynchronously", Public: true,
+			Binding: Empty[struct{}](http.StatusAccepted).
+				Body(cfstreamapi.Video{}).
+				Header("Webhook-Signature", "Signature of the payload.", true)},
+	})
+}
+
+func devRoutes(prefix string) []Route {
+	return tagged("dev", []Route{
+		{Method: http.MethodPut, Path: prefix + emulator.MuxUploadPath + "/:id", Summary: "Receive the file of an emulated MUX direct upload", Public: true,
+			Binding: Empty[emulator.MuxUploadRequest](http.StatusOK).RawBody("application/octet-stream")},
+		{Method: http.MethodPost, Path: prefix + emulator.CloudinaryUploadPath, Summary: "Receive an emulated signed Cloudinary upload", Public: true,
+			Binding: Raw[emulator.CloudinaryUploadForm, api.BriefAssetResult](http.StatusOK).Multipart("file")},
+		{Method: http.MethodPost, Path: prefix + devhandler.WebhooksPath, Summary: "Queue a synthetic webhook of an emulated asset", Public: true,
+			Binding: JSON[emulator.FireRequest, emulator.FireResponse](http.StatusAccepted, "webhook")},
+	})
+}
+
+func gatewayRoutes(prefix string) []Route {
+	type (
+		muxSrv = muxassetpbv1.AssetServiceServer
+		cldSrv = cldassetpbv1.AssetServiceServer
+	)
+	mux, cld := prefix+"/mux", prefix+"/cloudinary"
+	return tagged("gateway", []Route{
+		{Method: http.MethodGet, Path: mux + "/ping", Summary: "MUX AssetService.Ping", Binding: Unary(muxSrv.Ping)},
+		{Method: http.MethodGet, Path: mux + "/assets/:uuid", Summary: "MUX AssetService.Get", Binding: masked(Unary(muxSrv.Get))},
+		{Method: http.MethodGet, Path: mux + "/assets/archived/:uuid", Summary: "MUX AssetService.GetWithArchived", Binding: masked(Unary(muxSrv.GetWithArchived))},
+		{Method: http.MethodGet, Path: mux + "/assets/broken/:uuid", Summary: "MUX AssetService.GetWithBroken", Binding: masked(Unary(muxSrv.GetWithBroken))},
+		{Method: http.MethodGet, Path: mux + "/assets", Summary: "MUX AssetService.List", Binding: masked(Unary(muxSrv.List))},
+		{Method: http.MethodGet, Path: mux + "/assets/archived", Summary: "MUX AssetService.ListArchived", Binding: masked(Unary(muxSrv.ListArchived))},
+		{Method: http.MethodGet, Path: mux + "/assets/broken", Summary: "MUX AssetService.ListBroken", Binding: masked(Unary(muxSrv.ListBroken))},
+		{Method: http.MethodPost, Path: mux + "/assets/upload-url", Summary: "MUX AssetService.CreateUploadURL", Binding: Unary(muxSrv.CreateUploadURL)},
+		{Method: http.MethodDelete, Path: mux + "/assets/archive/:uuid", Summary: "MUX AssetService.Archive", Binding: Unary(muxSrv.Archive)},
+		{Method: http.MethodPost, Path: mux + "/assets/restore/:uuid", Summary: "MUX AssetService.Restore", Binding: Unary(muxSrv.Restore)},
+		{Method: http.MethodDelete, Path: mux + "/assets/:uuid", Summary: "MUX AssetService.Delete", Binding: Unary(muxSrv.Delete)},
+		{Method: http.MethodPost, Path: mux + "/assets/broken/:uuid", Summary: "MUX AssetService.MarkAsBroken", Binding: Unary(muxSrv.MarkAsBroken)},
+		{Method: http.MethodPost, Path: mux + "/assets/:uuid/owners", Summary: "MUX AssetService.AddOwner", Binding: Unary(muxSrv.AddOwner)},
+		{Method: http.MethodDelete, Path: mux + "/assets/:uuid/owners", Summary: "MUX AssetService.RemoveOwner", Binding: Unary(muxSrv.RemoveOwner)},
+		{Method: http.MethodPost, Path: mux + "/assets/:asset_uuid/playback-token", Summary: "MUX AssetService.GeneratePlaybackToken", Binding: Unary(muxSrv.GeneratePlaybackToken)},
+
+		{Method: http.MethodGet, Path: cld + "/ping", Summary: "Cloudinary AssetService.Ping", Binding: Unary(cldSrv.Ping)},
+		{Method: http.MethodGet, Path: cld + "/assets/:uuid", Summary: "Cloudinary AssetService.Get", Binding: masked(Unary(cldSrv.Get))},
+		{Method: http.MethodGet, Path: cld + "/assets/archived/:uuid", Summary: "Cloudinary AssetService.GetWithArchived", Binding: masked(Unary(cldSrv.GetWithArchived))},
+		{Method: http.MethodGet, Path: cld + "/assets/broken/:uuid", Summary: "Cloudinary AssetService.GetWithBroken", Binding: masked(Unary(cldSrv.GetWithBroken))},
+		{Method: http.MethodGet, Path: cld + "/assets", Summary: "Cloudinary AssetService.List", Binding: masked(Unary(cldSrv.List))},
+		{Method: http.MethodGet, Path: cld + "/assets/archived", Summary: "Cloudinary AssetService.ListArchived", Binding: masked(Unary(cldSrv.ListArchived))},
+		{Method: http.MethodGet, Path: cld + "/assets/broken", Summary: "Cloudinary AssetService.ListBroken", Binding: masked(Unary(cldSrv.ListBroken))},
+		{Method: http.MethodPost, Path: cld + "/assets/upload/url-gen", Summary: "Cloudinary AssetService.CreateSignedUploadURL", Binding: Unary(cldSrv.CreateSignedUploadURL)},
+		{Method: http.MethodDelete, Path: cld + "/assets/archive/:uuid", Summary: "Cloudinary AssetService.Archive", Binding: Unary(cldSrv.Archive)},
+		{Method: http.MethodPost, Path: cld + "/assets/restore/:uuid", Summary: "Cloudinary AssetService.Restore", Binding: Unary(cldSrv.Restore)},
+		{Method: http.MethodDelete, Path: cld + "/assets/:uuid", Summary: "Cloudinary AssetService.Delete", Binding: Unary(cldSrv.Delete)},
+		{Method: http.MethodPost, Path: cld + "/assets/broken/:uuid", Summary: "Cloudinary AssetService.MarkAsBroken", Binding: Unary(cldSrv.MarkAsBroken)},
+		{Method: http.MethodPost, Path: cld + "/assets/:uuid/owners", Summary: "Cloudinary AssetService.AddOwner", Binding: Unary(cldSrv.AddOwner)},
+		{Method: http.MethodDelete, Path: cld + "/assets/:uuid/owners", Summary: "Cloudinary AssetService.RemoveOwner", Binding: Unary(cldSrv.RemoveOwner)},
+	})
+}
+
+// masked documents the field mask of a gateway read, see common.DetailsMask.
+func masked(b Binding) Binding {
+	return b.Query("fields",
+		"Field mask of the read, comma separated paths of the Details message, e.g. asset.status,asset_metadata.title. "+
+			"Everything is returned if it is empty.",
+		&Schema{Type: "array", Items: &Schema{Type: "string"}})
+}
+
+// tagged sets tag on routes.
+func tagged(tag string, routes []Route) []Route {
+	for i := range routes {
+		routes[i].Tag = tag
+	}
+	return routes
+}
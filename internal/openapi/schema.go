@@ -0,0 +1,280 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const modulePath = "github.com/mikhail5545/media-service-go"
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+	invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+	majorVersion     = regexp.MustCompile(`^v[0-9]+$`)
+)
+
+// knownTypes holds the schemas of types with custom JSON encoding, keyed by the package path
+// and name of the type.
+var knownTypes = map[string]func() *Schema{
+	"time.Time": func() *Schema { return &Schema{Type: "string", Format: "date-time"} },
+	"time.Duration": func() *Schema {
+		return &Schema{Type: "integer", Format: "int64", Description: "Duration in nanoseconds."}
+	},
+	"encoding/json.RawMessage":                     func() *Schema { return &Schema{} },
+	"github.com/google/uuid.UUID":                  func() *Schema { return &Schema{Type: "string", Format: "uuid"} },
+	"github.com/google/uuid.NullUUID":              func() *Schema { return &Schema{Type: "string", Format: "uuid", Nullable: true} },
+	"gorm.io/gorm.DeletedAt":                       func() *Schema { return &Schema{Type: "string", Format: "date-time", Nullable: true} },
+	"database/sql.NullTime":                        func() *Schema { return &Schema{Type: "string", Format: "date-time", Nullable: true} },
+	"database/sql.NullString":                      func() *Schema { return &Schema{Type: "string", Nullable: true} },
+	"go.mongodb.org/mongo-driver/v2/bson.ObjectID": func() *Schema { return &Schema{Type: "string"} },
+}
+
+// schemaRegistry generates schemas from Go types. Named struct types are stored as components and
+// referenced, so every model appears once in the document.
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schemaOf returns the schema of values of t as encoded by encoding/json.
+func (r *schemaRegistry) schemaOf(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		nullable = true
+	}
+	s := r.valueSchema(t)
+	// Siblings of $ref are ignored in OpenAPI 3.0, pointers to structs are documented as the struct.
+	if nullable && s.Ref == "" && s.Type != "" {
+		s.Nullable = true
+	}
+	return s
+}
+
+func (r *schemaRegistry) valueSchema(t reflect.Type) *Schema {
+	if known, ok := knownTypes[t.PkgPath()+"."+t.Name()]; ok {
+		return known()
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		// The encoding is not known, any value is accepted.
+		return &Schema{}
+	}
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Array:
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.objectSchema(t)
+		}
+		return r.ref(t)
+	default:
+		// Interfaces and other kinds accept any value.
+		return &Schema{}
+	}
+}
+
+// ref adds the named struct type t to the components and returns a reference to it.
+func (r *schemaRegistry) ref(t reflect.Type) *Schema {
+	name, ok := r.names[t]
+	if !ok {
+		name = r.componentName(t)
+		r.names[t] = name
+		// Registered before the properties are generated, so recursive types terminate.
+		r.schemas[name] = &Schema{}
+		*r.schemas[name] = *r.objectSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// componentName derives a unique component name from the package and name of t, e.g.
+// "mux.asset.Details" for the Details type of the internal/models/mux/asset package.
+func (r *schemaRegistry) componentName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	switch {
+	case strings.HasPrefix(pkg, modulePath+"/internal/models/"):
+		pkg = strings.TrimPrefix(pkg, modulePath+"/internal/models/")
+	case strings.HasPrefix(pkg, modulePath+"/"):
+		pkg = path.Base(pkg)
+	default:
+		base := path.Base(pkg)
+		if majorVersion.MatchString(base) {
+			base = path.Base(path.Dir(pkg))
+		}
+		pkg = base
+	}
+	name := invalidNameChars.ReplaceAllString(strings.ReplaceAll(pkg, "/", ".")+"."+t.Name(), "_")
+
+	unique := name
+	for i := 2; ; i++ {
+		if _, taken := r.schemas[unique]; !taken {
+			return unique
+		}
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+}
+
+// objectSchema returns the inline object schema of the struct type t.
+func (r *schemaRegistry) objectSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, f := range jsonFields(t) {
+		fs := r.schemaOf(f.Type)
+		if f.asString {
+			fs = &Schema{Type: "string"}
+		}
+		s.Properties[f.name] = fs
+	}
+	return s
+}
+
+// field is a struct field encoded by encoding/json.
+type field struct {
+	reflect.StructField
+	name     string
+	asString bool
+}
+
+// jsonFields returns the fields of the struct type t encoded by encoding/json, including the
+// fields of embedded structs. Fields bound from the path or query without a json tag are left out,
+// they are not part of request bodies.
+func jsonFields(t reflect.Type) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, hasTag := sf.Tag.Lookup("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if sf.Anonymous && name == "" {
+			et := sf.Type
+			if et.Kind() == reflect.Pointer {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				fields = append(fields, jsonFields(et)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if !hasTag && (sf.Tag.Get("param") != "" || sf.Tag.Get("query") != "") {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, field{
+			StructField: sf,
+			name:        name,
+			asString:    strings.Contains(","+opts+",", ",string,"),
+		})
+	}
+	return fields
+}
+
+// parameters returns the path parameters and, for methods Echo binds the query for, the query
+// parameters of the request type t.
+func (r *schemaRegistry) parameters(t reflect.Type, method string) []*Parameter {
+	var params []*Parameter
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			params = append(params, r.parameters(sf.Type, method)...)
+			continue
+		}
+		if name := sf.Tag.Get("param"); name != "" {
+			params = append(params, &Parameter{Name: name, In: "path", Required: true, Schema: r.schemaOf(sf.Type)})
+			continue
+		}
+		if name := sf.Tag.Get("query"); name != "" && bindsQuery(method) {
+			params = append(params, &Parameter{Name: name, In: "query", Schema: r.schemaOf(sf.Type)})
+		}
+	}
+	return params
+}
+
+// formSchema returns the multipart/form-data schema of the form fields of t, followed by the file
+// part named fileField.
+func (r *schemaRegistry) formSchema(t reflect.Type, fileField string) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if name := sf.Tag.Get("form"); name != "" && name != "-" {
+			s.Properties[name] = r.schemaOf(sf.Type)
+		}
+	}
+	s.Properties[fileField] = &Schema{Type: "string", Format: "binary"}
+	s.Required = []string{fileField}
+	return s
+}
+
+// bindsQuery reports whether the Echo default binder binds query parameters for method.
+func bindsQuery(method string) bool {
+	switch method {
+	case "GET", "DELETE", "HEAD":
+		return true
+	}
+	return false
+}
+
+// hasBody reports whether the struct type t has fields bound from a JSON request body.
+func hasBody(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && len(jsonFields(t)) > 0
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package ownertypes
+
+import (
+	"context"
+	"fmt"
+
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+)
+
+// Rule names the ownership rule violated by an association.
+type Rule string
+
+const (
+	RuleMaxAssetsPerOwner Rule = "max_assets_per_owner"
+	RuleMaxOwnersPerAsset Rule = "max_owners_per_asset"
+	RuleExclusive         Rule = "exclusive"
+)
+
+// PolicyError is returned when an association violates an ownership policy. It wraps
+// [serviceerrors.ErrConflict].
+type PolicyError struct {
+	Rule      Rule
+	OwnerType string
+	Limit     int
+}
+
+func (e *PolicyError) Error() string {
+	switch e.Rule {
+	case RuleMaxAssetsPerOwner:
+		return fmt.Sprintf("owners of type %q may own at most %d assets", e.OwnerType, e.Limit)
+	case RuleMaxOwnersPerAsset:
+		return fmt.Sprintf("an asset may have at most %d owners", e.Limit)
+	case RuleExclusive:
+		return fmt.Sprintf("assets owned by an owner of type %q can not be shared with other owners", e.OwnerType)
+	default:
+		return fmt.Sprintf("ownership policy %q violated", e.Rule)
+	}
+}
+
+func (e *PolicyError) Unwrap() error {
+	return serviceerrors.ErrConflict
+}
+
+// Policy restricts the associations of a single owner type.
+type Policy struct {
+	// MaxAssets is the maximum number of assets one owner may own, 0 means unlimited.
+	MaxAssets int
+	// Exclusive assets of an owner of this type can not have any other owner.
+	Exclusive bool
+}
+
+// Policies holds the ownership policies of a provider. The zero value imposes no restrictions.
+type Policies struct {
+	// MaxOwnersPerAsset is the maximum number of owners of a single asset, 0 means unlimited.
+	MaxOwnersPerAsset int
+	// ByType holds the policies of individual owner types. Owner types without a policy are
+	// restricted only by MaxOwnersPerAsset.
+	ByType map[string]Policy
+}
+
+// Policy returns the policy of the owner type.
+func (p *Policies) Policy(ownerType string) Policy {
+	if p == nil {
+		return Policy{}
+	}
+	return p.ByType[ownerType]
+}
+
+// Check reports whether an owner of ownerType may be added to an asset whose current owners have
+// the assetOwnerTypes types. ownerAssets is the number of assets the owner already owns, it is only
+// consulted when the policy of the owner type limits it.
+func (p *Policies) Check(ownerType string, assetOwnerTypes []string, ownerAssets int64) error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxOwnersPerAsset > 0 && len(assetOwnerTypes) >= p.MaxOwnersPerAsset {
+		return &PolicyError{Rule: RuleMaxOwnersPerAsset, OwnerType: ownerType, Limit: p.MaxOwnersPerAsset}
+	}
+
+	policy := p.Policy(ownerType)
+	if policy.Exclusive && len(assetOwnerTypes) > 0 {
+		return &PolicyError{Rule: RuleExclusive, OwnerType: ownerType}
+	}
+	for _, existing := range assetOwnerTypes {
+		if p.Policy(existing).Exclusive {
+			return &PolicyError{Rule: RuleExclusive, OwnerType: existing}
+		}
+	}
+	if policy.MaxAssets > 0 && ownerAssets >= int64(policy.MaxAssets) {
+		return &PolicyError{Rule: RuleMaxAssetsPerOwner, OwnerType: ownerType, Limit: policy.MaxAssets}
+	}
+	return nil
+}
+
+// CheckOwner reports whether an owner of ownerType may be added to an asset whose current owners have
+// the assetOwnerTypes types. countAssets returns the number of assets the owner already owns, it is
+// only called when the policy of the owner type limits it.
+func (p *Policies) CheckOwner(ctx context.Context, ownerType string, assetOwnerTypes []string, countAssets func(context.Context) (int64, error)) error {
+	var ownerAssets int64
+	if p.Policy(ownerType).MaxAssets > 0 {
+		count, err := countAssets(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to count assets of owner: %w", err)
+		}
+		ownerAssets = count
+	}
+	return p.Check(ownerType, assetOwnerTypes, ownerAssets)
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package ownertypes holds the registries of owner types assets can be associated with. Owner types
+// used to be free-form strings, so a typo silently created a relation no owner service would ever
+// resolve.
+package ownertypes
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// UnregisteredError is returned by the registry validation rule for an unknown owner type.
+type UnregisteredError struct {
+	Type string
+}
+
+func (e *UnregisteredError) Error() string {
+	return fmt.Sprintf("owner type %q is not registered", e.Type)
+}
+
+// Registry is a concurrency safe set of owner types.
+type Registry struct {
+	mu    sync.RWMutex
+	types map[string]struct{}
+}
+
+func NewRegistry(types ...string) *Registry {
+	r := &Registry{}
+	r.Set(types...)
+	return r
+}
+
+// Set replaces the registered owner types.
+func (r *Registry) Set(types ...string) {
+	registered := make(map[string]struct{}, len(types))
+	for _, t := range types {
+		registered[t] = struct{}{}
+	}
+	r.mu.Lock()
+	r.types = registered
+	r.mu.Unlock()
+}
+
+// IsRegistered reports whether ownerType is registered.
+func (r *Registry) IsRegistered(ownerType string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.types[ownerType]
+	return ok
+}
+
+// List returns the registered owner types in alphabetical order.
+func (r *Registry) List() []string {
+	r.mu.RLock()
+	types := make([]string, 0, len(r.types))
+	for t := range r.types {
+		types = append(types, t)
+	}
+	r.mu.RUnlock()
+	slices.Sort(types)
+	return types
+}
+
+// Rule returns the ozzo-validation rule accepting registered owner types. Empty values are
+// considered valid, combine it with validation.Required where the owner type is mandatory.
+func (r *Registry) Rule() validation.Rule {
+	return validation.By(func(value any) error {
+		ownerType, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be a string")
+		}
+		if ownerType == "" || r.IsRegistered(ownerType) {
+			return nil
+		}
+		return &UnregisteredError{Type: ownerType}
+	})
+}
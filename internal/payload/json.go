@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package payload
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+)
+
+// JSONSerializer decodes the JSON request bodies of the HTTP server. It rejects bodies nested deeper
+// than MaxDepth, and bodies with unknown fields on the routes of a strict [Rule].
+type JSONSerializer struct {
+	echo.DefaultJSONSerializer
+	// MaxDepth is the maximum nesting of arrays and objects, 0 disables the check.
+	MaxDepth int
+}
+
+func (s JSONSerializer) Deserialize(c echo.Context, i any) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return err
+	}
+	if err := checkDepth(body, s.MaxDepth); err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if rule, ok := c.Get(ruleKey).(Rule); ok && rule.Strict {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(i); err != nil {
+		return serviceerrors.NewInvalidArgumentError("invalid JSON body: " + strings.TrimPrefix(err.Error(), "json: "))
+	}
+	return nil
+}
+
+// checkDepth returns an error if the arrays and objects of the JSON document are nested deeper than max.
+// The document is not validated otherwise, that is left to the decoder.
+func checkDepth(data []byte, max int) error {
+	if max <= 0 {
+		return nil
+	}
+	depth := 0
+	inString, escaped := false, false
+	for _, b := range data {
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			switch b {
+			case '\\':
+				escaped = true
+			case '"':
+				inString = false
+			}
+		case b == '"':
+			inString = true
+		case b == '{' || b == '[':
+			depth++
+			if depth > max {
+				return serviceerrors.NewInvalidArgumentError(fmt.Sprintf("JSON body is nested deeper than %d levels", max))
+			}
+		case b == '}' || b == ']':
+			depth--
+		}
+	}
+	return nil
+}
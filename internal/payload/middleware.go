@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package payload
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+)
+
+// ruleKey is the echo.Context key of the rule resolved for the request.
+const ruleKey = "payload.rule"
+
+// EchoMiddleware rejects the request bodies the policy does not accept: a body of another content
+// type with 415, and a body over the size limit with 413. A body without Content-Length is
+// limited while it is read, see [BindError].
+func EchoMiddleware(policy *Policy) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.ContentLength == 0 || req.Body == nil || req.Body == http.NoBody {
+				return next(c)
+			}
+
+			rule := policy.Resolve(req.Method, req.URL.Path)
+			mediaType, _, err := mime.ParseMediaType(req.Header.Get(echo.HeaderContentType))
+			if err != nil || !rule.accepts(mediaType) {
+				return serviceerrors.NewUnsupportedMediaTypeError(fmt.Sprintf("request body must be one of %v", rule.ContentTypes))
+			}
+			if rule.MaxBodySize > 0 {
+				if req.ContentLength > rule.MaxBodySize {
+					return tooLarge(rule.MaxBodySize)
+				}
+				req.Body = http.MaxBytesReader(c.Response(), req.Body, rule.MaxBodySize)
+			}
+			c.Set(ruleKey, rule)
+			return next(c)
+		}
+	}
+}
+
+// BindError returns the error to respond with when the request body could not be read or bound.
+// Bodies over the size limit and rejected JSON keep their structured errors, any other error is
+// reported as a bad request with the message.
+func BindError(err error, message string) error {
+	var he *echo.HTTPError
+	if errors.As(err, &he) && he.Internal != nil {
+		err = he.Internal
+	}
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return tooLarge(maxBytesErr.Limit)
+	case errors.Is(err, serviceerrors.ErrInvalidArgument), errors.Is(err, serviceerrors.ErrUnsupportedMediaType):
+		return err
+	}
+	return echo.NewHTTPError(http.StatusBadRequest, message)
+}
+
+func tooLarge(limit int64) error {
+	return serviceerrors.NewPayloadTooLargeError(fmt.Sprintf("request body exceeds the limit of %d bytes", limit))
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package payload enforces the size and the content type of HTTP request bodies, and the shape of
+// their JSON.
+package payload
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// tusContentType is the content type of the chunks of a resumable upload.
+const tusContentType = "application/offset+octet-stream"
+
+// AnyContentType accepts bodies of every media type.
+const AnyContentType = "*/*"
+
+// Rule sets the bodies accepted by the requests matching its method and path prefix.
+type Rule struct {
+	// Method optionally restricts the rule to a single HTTP method, e.g. "POST".
+	Method string
+	// Prefix is matched against the request path.
+	Prefix string
+	// MaxBodySize is the maximum size of the body in bytes, 0 leaves the limit to the handler.
+	MaxBodySize int64
+	// ContentTypes are the media types a non-empty body may have, [AnyContentType] accepts all.
+	ContentTypes []string
+	// Strict rejects JSON bodies with fields the request does not have.
+	Strict bool
+}
+
+// accepts reports whether a body of the media type may be sent to the route.
+func (r Rule) accepts(mediaType string) bool {
+	for _, ct := range r.ContentTypes {
+		if ct == AnyContentType || strings.EqualFold(ct, mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Policy resolves the rule of a request. The most specific matching rule applies: the longest prefix
+// wins, and a rule restricted to the method wins over one with the same prefix which is not.
+type Policy struct {
+	Rules   []Rule
+	Default Rule
+}
+
+// NewPolicy creates a Policy which applies def to the requests no rule matches.
+func NewPolicy(def Rule, rules []Rule) *Policy {
+	return &Policy{Rules: rules, Default: def}
+}
+
+// Resolve returns the rule of the given method and path.
+func (p *Policy) Resolve(method, path string) Rule {
+	var (
+		best  *Rule
+		score = -1
+	)
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Method != "" && !strings.EqualFold(rule.Method, method) {
+			continue
+		}
+		if !strings.HasPrefix(path, rule.Prefix) {
+			continue
+		}
+		s := len(rule.Prefix) * 2
+		if rule.Method != "" {
+			s++
+		}
+		if s > score {
+			best, score = rule, s
+		}
+	}
+	if best == nil {
+		return p.Default
+	}
+	return *best
+}
+
+// Limits are the body sizes of [DefaultRules].
+type Limits struct {
+	// MaxBodySize limits the JSON bodies of the API.
+	MaxBodySize int64
+	// WebhookMaxBodySize limits the provider webhooks.
+	WebhookMaxBodySize int64
+}
+
+// DefaultRules accepts JSON bodies up to the limits under basePath and rejects unknown fields on the
+// admin routes. Streamed uploads are left unlimited, their handlers enforce the file size limits.
+func DefaultRules(basePath string, limits Limits) []Rule {
+	json := []string{echo.MIMEApplicationJSON}
+	return []Rule{
+		{Method: "POST", Prefix: basePath + "/webhooks", MaxBodySize: limits.WebhookMaxBodySize, ContentTypes: json},
+		{Prefix: basePath + "/admin", MaxBodySize: limits.MaxBodySize, ContentTypes: json, Strict: true},
+		{Method: "POST", Prefix: basePath + "/admin/cloudinary/assets/upload", ContentTypes: []string{echo.MIMEMultipartForm}},
+		// The upload prefix above also matches the signed upload URL routes, e.g. /upload/url-gen.
+		{Method: "POST", Prefix: basePath + "/admin/cloudinary/assets/upload/", MaxBodySize: limits.MaxBodySize, ContentTypes: json, Strict: true},
+		{Method: "PATCH", Prefix: basePath + "/admin/uploads", ContentTypes: []string{tusContentType}},
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package quota enforces the per-creator storage quotas checked before new uploads.
+package quota
+
+import (
+	"fmt"
+	"time"
+
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	usagemodel "github.com/mikhail5545/media-service-go/internal/models/usage"
+)
+
+// Resource names the quota exceeded by a creator.
+type Resource string
+
+const (
+	ResourceAssets   Resource = "assets"
+	ResourceBytes    Resource = "bytes"
+	ResourceDuration Resource = "duration"
+)
+
+// Error is returned when a creator exceeded a quota. It wraps [serviceerrors.ErrTooManyRequests].
+type Error struct {
+	Resource Resource
+	Limit    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("creator quota of %s exceeded, the limit is %s", e.Resource, e.Limit)
+}
+
+func (e *Error) Unwrap() error {
+	return serviceerrors.ErrTooManyRequests
+}
+
+// Limits are the quotas of a single creator. Zero limits mean unlimited, the zero value imposes no restrictions.
+type Limits struct {
+	MaxAssets   int64
+	MaxBytes    int64
+	MaxDuration time.Duration
+}
+
+// Enabled reports whether any limit is set.
+func (l Limits) Enabled() bool {
+	return l.MaxAssets > 0 || l.MaxBytes > 0 || l.MaxDuration > 0
+}
+
+// Check reports whether a creator with the usage may upload another asset. The size of the new
+// asset is not known before the upload, so only the current usage is compared with the limits.
+func (l Limits) Check(usage *usagemodel.Usage) error {
+	if l.MaxAssets > 0 && usage.Assets >= l.MaxAssets {
+		return &Error{Resource: ResourceAssets, Limit: fmt.Sprint(l.MaxAssets)}
+	}
+	if l.MaxBytes > 0 && usage.Bytes >= l.MaxBytes {
+		return &Error{Resource: ResourceBytes, Limit: fmt.Sprint(l.MaxBytes)}
+	}
+	if l.MaxDuration > 0 && usage.Duration >= l.MaxDuration.Seconds() {
+		return &Error{Resource: ResourceDuration, Limit: l.MaxDuration.String()}
+	}
+	return nil
+}
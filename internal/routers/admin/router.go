@@ -19,16 +19,91 @@ package admin
 
 import (
 	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	assetimporthandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/assetimport"
+	audithandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/audit"
+	cataloghandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/catalog"
+	cfstreamhandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/cfstream"
 	cldhandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/cloudinary"
+	collectionhandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/collection"
+	costhandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/cost"
+	exporthandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/export"
+	livehandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/live"
+	mediahandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/media"
 	muxhandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/mux"
+	playbackhandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/playback"
+	retentionhandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/retention"
+	s3handler "github.com/mikhail5545/media-service-go/internal/handlers/admin/s3"
+	seedhandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/seed"
+	signingkeyhandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/signingkey"
+	subscriptionhandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/subscription"
+	uploadhandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/upload"
+	usagehandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/usage"
+	videohandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/video"
+	watermarkhandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/watermark"
+	webhookhandler "github.com/mikhail5545/media-service-go/internal/handlers/admin/webhook"
 	"github.com/mikhail5545/media-service-go/internal/routers"
+	"github.com/mikhail5545/media-service-go/internal/seed"
+	assetimportservice "github.com/mikhail5545/media-service-go/internal/services/assetimport"
+	auditservice "github.com/mikhail5545/media-service-go/internal/services/audit"
+	catalogservice "github.com/mikhail5545/media-service-go/internal/services/catalog"
+	cfstreamservice "github.com/mikhail5545/media-service-go/internal/services/cfstream"
 	cldservice "github.com/mikhail5545/media-service-go/internal/services/cloudinary"
+	collectionservice "github.com/mikhail5545/media-service-go/internal/services/collection"
+	costservice "github.com/mikhail5545/media-service-go/internal/services/cost"
+	exportservice "github.com/mikhail5545/media-service-go/internal/services/export"
+	"github.com/mikhail5545/media-service-go/internal/services/mediacore"
 	muxservice "github.com/mikhail5545/media-service-go/internal/services/mux"
+	playbackservice "github.com/mikhail5545/media-service-go/internal/services/playback"
+	retentionservice "github.com/mikhail5545/media-service-go/internal/services/retention"
+	s3service "github.com/mikhail5545/media-service-go/internal/services/s3"
+	signingkeyservice "github.com/mikhail5545/media-service-go/internal/services/signingkey"
+	subscriptionservice "github.com/mikhail5545/media-service-go/internal/services/subscription"
+	uploadproxyservice "github.com/mikhail5545/media-service-go/internal/services/uploadproxy"
+	usageservice "github.com/mikhail5545/media-service-go/internal/services/usage"
+	watermarkservice "github.com/mikhail5545/media-service-go/internal/services/watermark"
+	webhookservice "github.com/mikhail5545/media-service-go/internal/services/webhook"
 )
 
 type Dependencies struct {
-	MuxSvc *muxservice.Service
-	CldSvc *cldservice.Service
+	MuxSvc        *muxservice.Service
+	CldSvc        *cldservice.Service
+	CollectionSvc *collectionservice.Service
+	WatermarkSvc  *watermarkservice.Service
+	AuditSvc      *auditservice.Service
+	PlaybackSvc   *playbackservice.Service
+	UsageSvc      *usageservice.Service
+	CostSvc       *costservice.Service
+	// MediaRegistry holds the services of the backends built on the shared asset core.
+	MediaRegistry *mediacore.Registry
+	// CatalogSvc lists the assets of all backends.
+	CatalogSvc *catalogservice.Service
+	// S3Svc is nil unless the object storage is enabled, the s3 routes are not registered then.
+	S3Svc *s3service.Service
+	// CfStreamSvc is nil unless Cloudflare Stream is enabled, the cfstream routes are not registered
+	// then and new videos can only be stored in MUX.
+	CfStreamSvc *cfstreamservice.Service
+	// UploadProxySvc is nil unless the upload proxy is enabled, the upload routes are not registered then.
+	UploadProxySvc *uploadproxyservice.Service
+	// ExportSvc is nil unless exports are enabled, the export routes are not registered then.
+	ExportSvc *exportservice.Service
+	// ImportSvc is nil unless imports are enabled, the import routes are not registered then.
+	ImportSvc *assetimportservice.Service
+	// WebhookQueue lists and replays the webhooks whose processing failed.
+	WebhookQueue *webhookservice.Queue
+	// RetentionWorker is nil unless the retention policy is enabled, the retention routes are not
+	// registered then.
+	RetentionWorker *retentionservice.Worker
+	// SubscriptionSvc is nil unless outgoing webhooks are enabled, the webhook subscription routes are
+	// not registered then.
+	SubscriptionSvc *subscriptionservice.Service
+	// SigningKeySvc is nil unless the MUX signing key rotation is enabled, the signing key routes are not
+	// registered then.
+	SigningKeySvc *signingkeyservice.Service
+	// SeedSvc is nil unless seeding is enabled, the fixture routes are not registered then.
+	SeedSvc *seed.Service
+	// Hub delivers the published asset events to the live event stream of the admin UI.
+	Hub *events.Hub
 }
 
 type RouterImpl struct {
@@ -41,64 +116,53 @@ func New(d Dependencies) *RouterImpl {
 	return &RouterImpl{deps: d}
 }
 
-func (r *RouterImpl) Setup(group *echo.Group) {
-	admin := group.Group("/admin")
+// Register registers the admin routes under /admin. The routes of the optional services are only
+// registered when the service is set.
+func (r *RouterImpl) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	d := r.deps
+	admin := group.Group("/admin", m...)
 
-	r.setupHealthRoutes(admin)
-	r.setupMuxRoutes(admin)
-	r.setupCloudinaryRoutes(admin)
-}
-
-func (r *RouterImpl) setupHealthRoutes(group *echo.Group) {
-	group.GET("/health", func(c echo.Context) error {
+	admin.GET("/health", func(c echo.Context) error {
 		return c.String(200, "OK")
 	})
-}
-
-func (r *RouterImpl) setupMuxRoutes(group *echo.Group) {
-	handler := muxhandler.New(r.deps.MuxSvc)
-
-	muxGroup := group.Group("/mux")
-	{
-		assets := muxGroup.Group("/assets")
-		{
-			assets.GET("/:id", handler.Get)
-			assets.GET("/archived/:id", handler.GetWithArchived)
-			assets.GET("/broken/:id", handler.GetWithBroken)
-			assets.GET("", handler.List)
-			assets.GET("/archived", handler.ListArchived)
-			assets.GET("/broken", handler.ListBroken)
-			assets.POST("/upload-url", handler.CreateUploadURL)
-			assets.DELETE("/archive/:id", handler.Archive)
-			assets.POST("/restore/:id", handler.Restore)
-			assets.DELETE("/:id", handler.Delete)
-			assets.POST("/broken/:id", handler.MarkAsBroken)
-			assets.POST("/:id/owners", handler.AddOwner)
-			assets.DELETE("/:id/owners", handler.RemoveOwner)
-		}
+	muxhandler.New(d.MuxSvc).Register(admin)
+	cldhandler.New(d.CldSvc).Register(admin)
+	mediahandler.New(d.MediaRegistry).Register(admin)
+	cataloghandler.New(d.CatalogSvc).Register(admin)
+	if d.S3Svc != nil {
+		s3handler.New(d.S3Svc).Register(admin)
 	}
-}
-
-func (r *RouterImpl) setupCloudinaryRoutes(group *echo.Group) {
-	handler := cldhandler.New(r.deps.CldSvc)
-
-	cldGroup := group.Group("/cloudinary")
-	{
-		assets := cldGroup.Group("/assets")
-		{
-			assets.GET("/:id", handler.Get)
-			assets.GET("/archived/:id", handler.GetWithArchived)
-			assets.GET("/broken/:id", handler.GetWithBroken)
-			assets.GET("", handler.List)
-			assets.GET("/archived", handler.ListArchived)
-			assets.GET("/broken", handler.ListBroken)
-			assets.POST("/upload/url-gen", handler.CreateSignedUploadURL)
-			assets.DELETE("/archive/:id", handler.Archive)
-			assets.POST("/restore/:id", handler.Restore)
-			assets.DELETE("/:id", handler.Delete)
-			assets.POST("/broken/:id", handler.MarkAsBroken)
-			assets.POST("/:id/owners", handler.AddOwner)
-			assets.DELETE("/:id/owners", handler.RemoveOwner)
-		}
+	if d.CfStreamSvc != nil {
+		cfstreamhandler.New(d.CfStreamSvc).Register(admin)
+	}
+	videohandler.New(d.MuxSvc, d.CfStreamSvc).Register(admin)
+	collectionhandler.New(d.CollectionSvc).Register(admin)
+	watermarkhandler.New(d.WatermarkSvc).Register(admin)
+	audithandler.New(d.AuditSvc).Register(admin)
+	webhookhandler.New(d.WebhookQueue).Register(admin)
+	if d.RetentionWorker != nil {
+		retentionhandler.New(d.RetentionWorker).Register(admin)
+	}
+	if d.SubscriptionSvc != nil {
+		subscriptionhandler.New(d.SubscriptionSvc).Register(admin)
+	}
+	if d.SeedSvc != nil {
+		seedhandler.New(d.SeedSvc).Register(admin)
+	}
+	playbackhandler.New(d.PlaybackSvc).Register(admin)
+	if d.SigningKeySvc != nil {
+		signingkeyhandler.New(d.SigningKeySvc).Register(admin)
+	}
+	usagehandler.New(d.UsageSvc).Register(admin)
+	costhandler.New(d.CostSvc).Register(admin)
+	if d.UploadProxySvc != nil {
+		uploadhandler.New(d.UploadProxySvc).Register(admin)
+	}
+	if d.ExportSvc != nil {
+		exporthandler.New(d.ExportSvc).Register(admin)
+	}
+	if d.ImportSvc != nil {
+		assetimporthandler.New(d.ImportSvc).Register(admin)
 	}
+	livehandler.New(d.Hub).Register(admin)
 }
@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package delivery
+
+import (
+	"github.com/labstack/echo/v4"
+	deliveryhandler "github.com/mikhail5545/media-service-go/internal/handlers/delivery"
+	"github.com/mikhail5545/media-service-go/internal/routers"
+	cldservice "github.com/mikhail5545/media-service-go/internal/services/cloudinary"
+	muxservice "github.com/mikhail5545/media-service-go/internal/services/mux"
+)
+
+type Dependencies struct {
+	MuxSvc *muxservice.Service
+	CldSvc *cldservice.Service
+	// Tokens configures the playback tokens delivered as a cookie or a header.
+	Tokens deliveryhandler.TokenDelivery
+}
+
+type RouterImpl struct {
+	deps Dependencies
+}
+
+var _ routers.Router = (*RouterImpl)(nil)
+
+func New(d Dependencies) *RouterImpl {
+	return &RouterImpl{deps: d}
+}
+
+// Register registers the end user routes under /media. The auth middleware only lets authenticated
+// users through, the services check that the user may access the requested asset.
+func (r *RouterImpl) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	deliveryhandler.New(r.deps.MuxSvc, r.deps.CldSvc, r.deps.Tokens).Register(group, m...)
+}
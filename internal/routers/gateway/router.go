@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package gateway
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mikhail5545/media-service-go/internal/gateway"
+	"github.com/mikhail5545/media-service-go/internal/routers"
+	cldassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/asset/v1"
+	muxassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/asset/v1"
+)
+
+// Dependencies holds the gRPC service implementations the REST routes are transcoded to. The calls
+// are made in process, authentication is done by the HTTP middleware.
+type Dependencies struct {
+	MuxServer muxassetpbv1.AssetServiceServer
+	CldServer cldassetpbv1.AssetServiceServer
+}
+
+type RouterImpl struct {
+	deps Dependencies
+}
+
+var _ routers.Router = (*RouterImpl)(nil)
+
+func New(d Dependencies) *RouterImpl {
+	return &RouterImpl{deps: d}
+}
+
+// Register registers the v1 RPCs under /gateway. The paths follow the admin API, with the request
+// fields named after the proto fields, e.g. GET /gateway/mux/assets/:uuid for AssetService.Get.
+func (r *RouterImpl) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	gw := group.Group("/gateway", m...)
+
+	r.setupMuxRoutes(gw)
+	r.setupCloudinaryRoutes(gw)
+}
+
+func (r *RouterImpl) setupMuxRoutes(group *echo.Group) {
+	srv := r.deps.MuxServer
+	muxGroup := routers.ProviderGroup(group, "mux")
+	{
+		muxGroup.GET("/ping", gateway.Unary(srv.Ping))
+
+		assets := muxGroup.Group("/assets")
+		{
+			assets.GET("/:uuid", gateway.Unary(srv.Get))
+			assets.GET("/archived/:uuid", gateway.Unary(srv.GetWithArchived))
+			assets.GET("/broken/:uuid", gateway.Unary(srv.GetWithBroken))
+			assets.GET("", gateway.Unary(srv.List))
+			assets.GET("/archived", gateway.Unary(srv.ListArchived))
+			assets.GET("/broken", gateway.Unary(srv.ListBroken))
+			assets.POST("/upload-url", gateway.Unary(srv.CreateUploadURL))
+			assets.DELETE("/archive/:uuid", gateway.Unary(srv.Archive))
+			assets.POST("/restore/:uuid", gateway.Unary(srv.Restore))
+			assets.DELETE("/:uuid", gateway.Unary(srv.Delete))
+			assets.POST("/broken/:uuid", gateway.Unary(srv.MarkAsBroken))
+			assets.POST("/:uuid/owners", gateway.Unary(srv.AddOwner))
+			assets.DELETE("/:uuid/owners", gateway.Unary(srv.RemoveOwner))
+			assets.POST("/:asset_uuid/playback-token", gateway.Unary(srv.GeneratePlaybackToken))
+		}
+	}
+}
+
+func (r *RouterImpl) setupCloudinaryRoutes(group *echo.Group) {
+	srv := r.deps.CldServer
+	cldGroup := routers.ProviderGroup(group, "cloudinary")
+	{
+		cldGroup.GET("/ping", gateway.Unary(srv.Ping))
+
+		assets := cldGroup.Group("/assets")
+		{
+			assets.GET("/:uuid", gateway.Unary(srv.Get))
+			assets.GET("/archived/:uuid", gateway.Unary(srv.GetWithArchived))
+			assets.GET("/broken/:uuid", gateway.Unary(srv.GetWithBroken))
+			assets.GET("", gateway.Unary(srv.List))
+			assets.GET("/archived", gateway.Unary(srv.ListArchived))
+			assets.GET("/broken", gateway.Unary(srv.ListBroken))
+			assets.POST("/upload/url-gen", gateway.Unary(srv.CreateSignedUploadURL))
+			assets.DELETE("/archive/:uuid", gateway.Unary(srv.Archive))
+			assets.POST("/restore/:uuid", gateway.Unary(srv.Restore))
+			assets.DELETE("/:uuid", gateway.Unary(srv.Delete))
+			assets.POST("/broken/:uuid", gateway.Unary(srv.MarkAsBroken))
+			assets.POST("/:uuid/owners", gateway.Unary(srv.AddOwner))
+			assets.DELETE("/:uuid/owners", gateway.Unary(srv.RemoveOwner))
+		}
+	}
+}
@@ -23,17 +23,25 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// Router registers a part of the API, e.g. the admin routes, under the versioned group. The
+// middleware only applies to the routes of the router, on top of the middleware of [Config.Use].
 type Router interface {
-	Setup(group *echo.Group)
+	Register(group *echo.Group, m ...echo.MiddlewareFunc)
 }
 
 type Config struct {
 	Api              string                // API prefix, e.g., /api
 	Ver              string                // API version, e.g., /v1
-	Use              []echo.MiddlewareFunc // Middlewares to use
+	Use              []echo.MiddlewareFunc // Middlewares of all routes
 	HTTPErrorHandler echo.HTTPErrorHandler
 }
 
+// ProviderGroup returns the group of the routes of a media provider under group, e.g. /admin/mux
+// or /webhooks/mux. The routes of a provider are always laid out under its name.
+func ProviderGroup(group *echo.Group, provider string, m ...echo.MiddlewareFunc) *echo.Group {
+	return group.Group("/"+provider, m...)
+}
+
 // Init initializes the Echo router with the given configuration and returns the versioned group.
 func Init(e *echo.Echo, config Config) *echo.Group {
 	if config.HTTPErrorHandler != nil {
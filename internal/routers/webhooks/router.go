@@ -19,16 +19,26 @@ package webhooks
 
 import (
 	"github.com/labstack/echo/v4"
+	cfstreamhandler "github.com/mikhail5545/media-service-go/internal/handlers/webhooks/cfstream"
 	cldhandler "github.com/mikhail5545/media-service-go/internal/handlers/webhooks/cloudinary"
 	muxhandler "github.com/mikhail5545/media-service-go/internal/handlers/webhooks/mux"
+	"github.com/mikhail5545/media-service-go/internal/metrics"
 	"github.com/mikhail5545/media-service-go/internal/routers"
+	cfstreamservice "github.com/mikhail5545/media-service-go/internal/services/cfstream"
 	cldservice "github.com/mikhail5545/media-service-go/internal/services/cloudinary"
 	muxservice "github.com/mikhail5545/media-service-go/internal/services/mux"
+	"github.com/mikhail5545/media-service-go/internal/services/webhook"
 )
 
 type Dependencies struct {
 	MuxSvc *muxservice.Service
 	CldSvc *cldservice.Service
+	// CfStreamSvc is nil unless Cloudflare Stream is enabled, its webhook route is not registered then.
+	CfStreamSvc *cfstreamservice.Service
+	// Queue persists the verified webhooks, they are processed asynchronously.
+	Queue *webhook.Queue
+	// Metrics is optional, webhook processing is not counted when nil.
+	Metrics *metrics.Metrics
 }
 
 type RouterImpl struct {
@@ -41,21 +51,15 @@ func New(d Dependencies) *RouterImpl {
 	return &RouterImpl{deps: d}
 }
 
-func (r *RouterImpl) Setup(group *echo.Group) {
-	webhooks := group.Group("/webhooks")
+// Register registers the provider webhook routes under /webhooks. The Cloudflare Stream route is
+// only registered when the service is set.
+func (r *RouterImpl) Register(group *echo.Group, m ...echo.MiddlewareFunc) {
+	d := r.deps
+	webhooks := group.Group("/webhooks", m...)
 
-	r.setupCloudinaryRoutes(webhooks)
-	r.setupMuxRoutes(webhooks)
-}
-
-func (r *RouterImpl) setupCloudinaryRoutes(group *echo.Group) {
-	cldGroup := group.Group("/cloudinary")
-	handler := cldhandler.New(r.deps.CldSvc)
-	cldGroup.POST("", handler.Handle)
-}
-
-func (r *RouterImpl) setupMuxRoutes(group *echo.Group) {
-	muxGroup := group.Group("/mux")
-	handler := muxhandler.New(r.deps.MuxSvc)
-	muxGroup.POST("", handler.Handle)
+	cldhandler.New(d.CldSvc, d.Queue, d.Metrics).Register(webhooks)
+	muxhandler.New(d.MuxSvc, d.Queue, d.Metrics).Register(webhooks)
+	if d.CfStreamSvc != nil {
+		cfstreamhandler.New(d.CfStreamSvc, d.Queue, d.Metrics).Register(webhooks)
+	}
 }
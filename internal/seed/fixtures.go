@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package seed
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	seedmodel "github.com/mikhail5545/media-service-go/internal/models/seed"
+)
+
+// namespace derives the fixture IDs, it must never change or wipes would miss the loaded fixtures.
+var namespace = uuid.MustParse("6f1d3c2e-6b0a-5d4e-9a57-3f0c1b7e2a91")
+
+// FixtureTag is set on every fixture, so they can be told apart from real assets in the listings.
+const FixtureTag = "fixture"
+
+// creators are the number of distinct creators of the fixtures.
+const creators = 3
+
+// states are the states the fixtures of a provider cycle through, the first fixtures are ready.
+var states = map[seedmodel.Provider][]seedmodel.State{
+	seedmodel.ProviderMux: {
+		seedmodel.StateReady,
+		seedmodel.StateReady,
+		seedmodel.StateProcessing,
+		seedmodel.StateUploadPending,
+		seedmodel.StateReady,
+		seedmodel.StateErrored,
+		seedmodel.StateArchived,
+	},
+	seedmodel.ProviderCloudinary: {
+		seedmodel.StateReady,
+		seedmodel.StateReady,
+		seedmodel.StateReady,
+		seedmodel.StateErrored,
+		seedmodel.StateArchived,
+	},
+}
+
+// epoch is the timestamp of the fixture IDs, so fixtures are listed before the real assets.
+var epoch = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// FixtureID returns the ID of the fixture of the provider with the index.
+func FixtureID(provider seedmodel.Provider, index int) uuid.UUID {
+	return fixtureUUID(fmt.Sprintf("%s/asset/%d", provider, index))
+}
+
+// fixtureUUID derives a UUIDv7 from the name, the API only accepts UUIDv7 IDs. The timestamp is
+// [epoch] and the random bits are taken from the SHA-1 name-based UUID.
+func fixtureUUID(name string) uuid.UUID {
+	id := uuid.NewSHA1(namespace, []byte(name))
+	ms := uint64(epoch.UnixMilli())
+	for i := range 6 {
+		id[i] = byte(ms >> (40 - 8*i))
+	}
+	id[6] = id[6]&0x0f | 0x70
+	return id
+}
+
+// Fixtures returns the first n fixtures of the provider. The fixture at an index is the same on every
+// call. Fixtures have up to two owners of the owner types, and some have none.
+func Fixtures(provider seedmodel.Provider, n int, ownerTypes []string) []*seedmodel.Fixture {
+	cycle := states[provider]
+	fixtures := make([]*seedmodel.Fixture, 0, n)
+	for i := range n {
+		f := &seedmodel.Fixture{
+			ID:        FixtureID(provider, i),
+			Index:     i,
+			Title:     fmt.Sprintf("Fixture %s %03d", provider, i),
+			CreatorID: fixtureUUID(fmt.Sprintf("creator/%d", i%creators)).String(),
+			State:     cycle[i%len(cycle)],
+			Owners:    []seedmodel.Owner{},
+			Tags:      []string{FixtureTag, string(provider)},
+		}
+		if len(ownerTypes) > 0 {
+			for j := range i % 3 {
+				f.Owners = append(f.Owners, seedmodel.Owner{
+					OwnerID:   fixtureUUID(fmt.Sprintf("owner/%d", i+j)).String(),
+					OwnerType: ownerTypes[(i+j)%len(ownerTypes)],
+				})
+			}
+		}
+		fixtures = append(fixtures, f)
+	}
+	return fixtures
+}
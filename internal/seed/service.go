@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package seed loads a fixture set of MUX assets and Cloudinary images, with their owners and
+// metadata, into the databases for demos and integration tests.
+//
+// Fixture IDs are derived from the provider and the index of the fixture, so a load can be repeated
+// without creating duplicates, and a wipe only removes fixtures. Nothing is created in the providers.
+package seed
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	seedmodel "github.com/mikhail5545/media-service-go/internal/models/seed"
+	"go.uber.org/zap"
+)
+
+// maxItemErrors is the number of fixture errors kept in a report, later errors are only counted.
+const maxItemErrors = 100
+
+// Loader loads the fixtures of a provider. It calls report with the result of every fixture and
+// returns an error only when the fixtures can not be loaded at all.
+type Loader interface {
+	SeedAssets(ctx context.Context, fixtures []*seedmodel.Fixture, report func(*seedmodel.Result)) error
+	// WipeAssets permanently deletes the fixtures with the IDs and returns the number of deleted ones.
+	WipeAssets(ctx context.Context, ids uuid.UUIDs) (int64, error)
+}
+
+// Set builds the fixtures of a provider.
+type Set struct {
+	Loader Loader
+	// OwnerTypes are the owner types the fixtures are assigned to.
+	OwnerTypes func() []string
+}
+
+type Config struct {
+	// AllowWipe allows the fixtures to be wiped. It must only be set outside of production.
+	AllowWipe bool
+}
+
+type Service struct {
+	cfg    Config
+	sets   map[seedmodel.Provider]Set
+	logger *zap.Logger
+}
+
+type NewParams struct {
+	Config Config
+	Sets   map[seedmodel.Provider]Set
+}
+
+func New(params *NewParams, logger *zap.Logger) *Service {
+	return &Service{
+		cfg:    params.Config,
+		sets:   params.Sets,
+		logger: logger.With(zap.String("layer", "service"), zap.String("service", "seed")),
+	}
+}
+
+// Load loads the fixtures which were not loaded yet.
+func (s *Service) Load(ctx context.Context, req *seedmodel.LoadRequest) (*seedmodel.Report, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	counts := map[seedmodel.Provider]int{
+		seedmodel.ProviderMux:        withDefault(req.MuxAssets, seedmodel.DefaultMuxAssets),
+		seedmodel.ProviderCloudinary: withDefault(req.CloudinaryImages, seedmodel.DefaultCloudinaryImages),
+	}
+
+	report := newReport()
+	for _, provider := range s.providers() {
+		set := s.sets[provider]
+		fixtures := Fixtures(provider, counts[provider], set.OwnerTypes())
+		err := set.Loader.SeedAssets(ctx, fixtures, func(result *seedmodel.Result) {
+			record(report, provider, result)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s fixtures: %w", provider, err)
+		}
+	}
+	s.logger.Info("loaded fixtures", zap.Any("counts", report.Counts), zap.Int("errors", len(report.Errors)))
+	return report, nil
+}
+
+// Wipe permanently deletes every fixture, it is refused unless wiping is allowed.
+func (s *Service) Wipe(ctx context.Context, _ *seedmodel.WipeRequest) (*seedmodel.Report, error) {
+	if !s.cfg.AllowWipe {
+		return nil, serviceerrors.NewPermissionDeniedError("wiping the fixtures is only allowed in dev mode")
+	}
+	report := newReport()
+	for _, provider := range s.providers() {
+		ids := make(uuid.UUIDs, 0, seedmodel.MaxFixtures)
+		for i := range seedmodel.MaxFixtures {
+			ids = append(ids, FixtureID(provider, i))
+		}
+		deleted, err := s.sets[provider].Loader.WipeAssets(ctx, ids)
+		report.Counts[provider].Deleted = deleted
+		if err != nil {
+			return nil, fmt.Errorf("failed to wipe %s fixtures: %w", provider, err)
+		}
+	}
+	s.logger.Warn("wiped fixtures", zap.Any("counts", report.Counts))
+	return report, nil
+}
+
+// providers returns the providers with a fixture set, in a stable order.
+func (s *Service) providers() []seedmodel.Provider {
+	providers := make([]seedmodel.Provider, 0, len(s.sets))
+	for provider := range s.sets {
+		providers = append(providers, provider)
+	}
+	slices.Sort(providers)
+	return providers
+}
+
+func newReport() *seedmodel.Report {
+	return &seedmodel.Report{
+		Counts: map[seedmodel.Provider]*seedmodel.Counts{
+			seedmodel.ProviderMux:        {},
+			seedmodel.ProviderCloudinary: {},
+		},
+		Errors: []seedmodel.ItemError{},
+	}
+}
+
+func record(report *seedmodel.Report, provider seedmodel.Provider, result *seedmodel.Result) {
+	counts := report.Counts[provider]
+	switch result.Outcome {
+	case seedmodel.OutcomeCreated:
+		counts.Created++
+	case seedmodel.OutcomeSkipped:
+		counts.Skipped++
+	case seedmodel.OutcomeFailed:
+		counts.Failed++
+		if len(report.Errors) < maxItemErrors {
+			report.Errors = append(report.Errors, seedmodel.ItemError{
+				Provider: provider,
+				AssetID:  result.AssetID,
+				Error:    result.Err.Error(),
+			})
+		}
+	}
+}
+
+func withDefault(n, def int) int {
+	if n == 0 {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,288 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package assetimport implements the asset imports. An import job pages through the assets of the
+// selected providers and creates the local records of the assets unknown to the service, e.g. the
+// assets created before the service was deployed.
+//
+// Jobs are kept in memory, so the status of a job can only be retrieved from the instance which
+// created it. Finished jobs are discarded after the retention period.
+package assetimport
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	assetimportmodel "github.com/mikhail5545/media-service-go/internal/models/assetimport"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+)
+
+// sweepInterval is the interval between removals of expired jobs.
+const sweepInterval = time.Minute
+
+// Importer imports the assets of a provider. It calls report with the result of every provider
+// asset and returns an error only when the provider assets can not be listed.
+type Importer interface {
+	ImportAssets(ctx context.Context, opts *assetimportmodel.Options, report func(*assetimportmodel.Result)) error
+}
+
+type Config struct {
+	// Retention is the time a finished job is kept.
+	Retention time.Duration
+	// MaxPending is the maximum number of jobs waiting to be run.
+	MaxPending int
+}
+
+type Service struct {
+	cfg Config
+	// importers are run in the order of providers.
+	importers map[assetimportmodel.Provider]Importer
+	providers []assetimportmodel.Provider
+	logger    *zap.Logger
+	queue     chan uuid.UUID
+
+	mu   sync.Mutex
+	jobs map[uuid.UUID]*assetimportmodel.Job
+}
+
+type NewParams struct {
+	Config Config
+	// Importers maps providers to the services importing their assets.
+	Importers map[assetimportmodel.Provider]Importer
+}
+
+func New(params *NewParams, logger *zap.Logger) (*Service, error) {
+	if params.Config.Retention <= 0 {
+		return nil, fmt.Errorf("import retention must be positive")
+	}
+	if params.Config.MaxPending <= 0 {
+		return nil, fmt.Errorf("import max pending jobs must be positive")
+	}
+	providers := make([]assetimportmodel.Provider, 0, len(params.Importers))
+	for provider := range params.Importers {
+		providers = append(providers, provider)
+	}
+	slices.Sort(providers)
+	return &Service{
+		cfg:       params.Config,
+		importers: params.Importers,
+		providers: providers,
+		logger:    logger.With(zap.String("layer", "service"), zap.String("service", "asset_import")),
+		queue:     make(chan uuid.UUID, params.Config.MaxPending),
+		jobs:      make(map[uuid.UUID]*assetimportmodel.Job),
+	}, nil
+}
+
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// Create queues a new import job. The job is run in the background, its progress can be followed
+// with [Service.Get].
+func (s *Service) Create(ctx context.Context, req *assetimportmodel.CreateJobRequest) (*assetimportmodel.Job, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	providers := req.Providers
+	if len(providers) == 0 {
+		providers = s.providers
+	}
+	counts := make(map[assetimportmodel.Provider]*assetimportmodel.Counts, len(providers))
+	for _, provider := range providers {
+		if _, ok := s.importers[provider]; !ok {
+			return nil, serviceerrors.NewInvalidArgumentError(fmt.Sprintf("provider %q does not support imports", provider))
+		}
+		counts[provider] = &assetimportmodel.Counts{}
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate import job id: %w", err)
+	}
+	job := &assetimportmodel.Job{
+		ID:        id,
+		Status:    assetimportmodel.StatusPending,
+		Providers: slices.Clone(providers),
+		DryRun:    req.DryRun,
+		AdminID:   req.AdminID,
+		AdminName: req.AdminName,
+		Counts:    counts,
+		Errors:    []assetimportmodel.ItemError{},
+		CreatedAt: time.Now(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case s.queue <- id:
+	default:
+		return nil, serviceerrors.NewTooManyRequestsError("too many pending imports, try again later")
+	}
+	s.jobs[id] = job
+
+	s.log(ctx).Info("queued import job",
+		zap.String("job_id", id.String()),
+		zap.Any("providers", job.Providers),
+		zap.Bool("dry_run", job.DryRun),
+	)
+	return job.Clone(), nil
+}
+
+// Get retrieves the status of an import job.
+func (s *Service) Get(ctx context.Context, req *assetimportmodel.GetJobRequest) (*assetimportmodel.Job, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	id, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, serviceerrors.NewNotFoundError("import job not found")
+	}
+	return job.Clone(), nil
+}
+
+// List retrieves the import jobs of this instance, newest first.
+func (s *Service) List(ctx context.Context, req *assetimportmodel.ListJobsRequest) ([]*assetimportmodel.Job, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	s.mu.Lock()
+	jobs := make([]*assetimportmodel.Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		if req.Status != "" && job.Status != req.Status {
+			continue
+		}
+		jobs = append(jobs, job.Clone())
+	}
+	s.mu.Unlock()
+
+	// Job IDs are UUIDv7, so they sort by creation time.
+	slices.SortFunc(jobs, func(a, b *assetimportmodel.Job) int {
+		return strings.Compare(b.ID.String(), a.ID.String())
+	})
+	return jobs, nil
+}
+
+// Run runs the queued jobs one at a time and periodically discards the expired jobs. It blocks
+// until the provided context is cancelled.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweep(time.Now())
+		case id := <-s.queue:
+			s.run(ctx, id)
+		}
+	}
+}
+
+func (s *Service) run(ctx context.Context, id uuid.UUID) {
+	s.mu.Lock()
+	job, ok := s.jobs[id]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	startedAt := time.Now()
+	job.Status = assetimportmodel.StatusRunning
+	job.StartedAt = &startedAt
+	providers := job.Providers
+	opts := &assetimportmodel.Options{DryRun: job.DryRun, AdminID: job.AdminID, AdminName: job.AdminName}
+	s.mu.Unlock()
+
+	logger := s.logger.With(zap.String("job_id", id.String()))
+	logger.Info("started import job")
+
+	var err error
+	for _, provider := range providers {
+		err = s.importers[provider].ImportAssets(ctx, opts, func(result *assetimportmodel.Result) {
+			s.record(job, provider, result)
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to import %s assets: %w", provider, err)
+			break
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	finishedAt := time.Now()
+	expiresAt := finishedAt.Add(s.cfg.Retention)
+	job.CompletedAt = &finishedAt
+	job.ExpiresAt = &expiresAt
+	if err != nil {
+		job.Status = assetimportmodel.StatusFailed
+		job.Error = err.Error()
+		logger.Error("import job failed", zap.Error(err), zap.Any("counts", job.Counts))
+		return
+	}
+	job.Status = assetimportmodel.StatusCompleted
+	logger.Info("completed import job", zap.Any("counts", job.Counts), zap.Duration("duration", finishedAt.Sub(startedAt)))
+}
+
+// record adds the result of a provider asset to the job counts.
+func (s *Service) record(job *assetimportmodel.Job, provider assetimportmodel.Provider, result *assetimportmodel.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := job.Counts[provider]
+	switch result.Outcome {
+	case assetimportmodel.OutcomeCreated:
+		counts.Created++
+		counts.Owners += int64(result.Owners)
+	case assetimportmodel.OutcomeSkipped:
+		counts.Skipped++
+	case assetimportmodel.OutcomeFailed:
+		counts.Failed++
+		if len(job.Errors) < assetimportmodel.MaxItemErrors {
+			job.Errors = append(job.Errors, assetimportmodel.ItemError{
+				Provider:   provider,
+				ExternalID: result.ExternalID,
+				Error:      result.Err.Error(),
+			})
+		}
+	}
+}
+
+// sweep discards the expired jobs.
+func (s *Service) sweep(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, job := range s.jobs {
+		if job.ExpiresAt != nil && now.After(*job.ExpiresAt) {
+			delete(s.jobs, id)
+			s.logger.Info("discarded expired import job", zap.String("job_id", id.String()), zap.String("status", string(job.Status)))
+		}
+	}
+}
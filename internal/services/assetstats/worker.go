@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package assetstats implements the worker periodically refreshing asset count gauges
+// (unowned, archived, broken and pending deletion assets per provider).
+package assetstats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mikhail5545/media-service-go/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// Source counts assets of a single provider. It returns counts keyed by state, e.g. "unowned".
+type Source func(ctx context.Context) (map[string]int64, error)
+
+// Worker periodically refreshes asset count gauges of all registered providers.
+type Worker struct {
+	interval time.Duration
+	sources  map[string]Source
+	metrics  *metrics.Metrics
+	logger   *zap.Logger
+}
+
+type NewParams struct {
+	Interval time.Duration
+	// Sources maps provider names to their counting functions.
+	Sources map[string]Source
+	Metrics *metrics.Metrics
+}
+
+func New(params *NewParams, logger *zap.Logger) (*Worker, error) {
+	if params.Interval <= 0 {
+		return nil, fmt.Errorf("asset stats interval must be positive")
+	}
+	if params.Metrics == nil {
+		return nil, fmt.Errorf("metrics must be provided")
+	}
+	return &Worker{
+		interval: params.Interval,
+		sources:  params.Sources,
+		metrics:  params.Metrics,
+		logger:   logger.With(zap.String("layer", "worker"), zap.String("worker", "asset_stats")),
+	}, nil
+}
+
+// Run refreshes the gauges immediately and then on every interval. It blocks until the provided context is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	w.logger.Info("asset stats worker started", zap.Duration("interval", w.interval))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			w.logger.Info("asset stats worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce refreshes the gauges of all providers. Failing providers keep their previous values.
+func (w *Worker) RunOnce(ctx context.Context) {
+	for provider, source := range w.sources {
+		counts, err := source(ctx)
+		if err != nil {
+			w.logger.Error("failed to count assets", zap.Error(err), zap.String("provider", provider))
+			continue
+		}
+		for state, count := range counts {
+			w.metrics.SetAssetCount(provider, state, count)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package audit provides the service for querying the audit log of admin mutations.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	auditrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/audit"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+)
+
+// AuditService defines the interface for querying the audit log.
+type AuditService interface {
+	// List retrieves a page of audit log entries matching the request filters, newest first.
+	List(ctx context.Context, req *auditmodel.ListRequest) ([]*auditmodel.Entry, string, error)
+}
+
+// Service implements the AuditService interface.
+type Service struct {
+	repo   *auditrepo.Repository
+	logger *zap.Logger
+}
+
+// defaultListPageSize is used by List when the request does not specify a page size.
+const defaultListPageSize = 50
+
+var _ AuditService = (*Service)(nil)
+
+func New(repo *auditrepo.Repository, logger *zap.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		logger: logger.With(zap.String("layer", "service"), zap.String("service", "audit")),
+	}
+}
+
+// log returns the service logger enriched with the request-scoped fields stored in ctx.
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// List retrieves a page of audit log entries matching the request filters, newest first.
+func (s *Service) List(ctx context.Context, req *auditmodel.ListRequest) ([]*auditmodel.Entry, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	filter, err := toFilter(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries, nextPageToken, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.log(ctx).Error("failed to list audit log entries", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to list audit log entries: %w", err)
+	}
+	return entries, nextPageToken, nil
+}
+
+func toFilter(req *auditmodel.ListRequest) (*auditrepo.Filter, error) {
+	filter := &auditrepo.Filter{
+		Provider:  req.Provider,
+		Actions:   req.Actions,
+		Source:    req.Source,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	}
+	if filter.PageSize == 0 {
+		filter.PageSize = defaultListPageSize
+	}
+
+	var err error
+	if filter.AssetID, err = parsing.StrToUUID(req.AssetID); err != nil {
+		return nil, err
+	}
+	if filter.AdminID, err = parsing.StrToUUID(req.AdminID); err != nil {
+		return nil, err
+	}
+	// The time range is validated as RFC 3339 already.
+	if req.From != "" {
+		filter.From, _ = time.Parse(time.RFC3339, req.From)
+	}
+	if req.To != "" {
+		filter.To, _ = time.Parse(time.RFC3339, req.To)
+	}
+	return filter, nil
+}
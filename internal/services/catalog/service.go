@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package catalog aggregates the assets of all backends into a single media catalog, so clients can
+// render a media library without querying every backend separately.
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/database/postgres/pagination"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	catalogmodel "github.com/mikhail5545/media-service-go/internal/models/catalog"
+	"go.uber.org/zap"
+)
+
+// defaultPageSize is used by ListMedia when the request does not specify a page size.
+const defaultPageSize = 50
+
+type Service struct {
+	sources []Source
+	logger  *zap.Logger
+}
+
+func New(sources []Source, logger *zap.Logger) *Service {
+	return &Service{
+		sources: sources,
+		logger:  logger.With(zap.String("layer", "service"), zap.String("service", "catalog")),
+	}
+}
+
+// log returns the service logger enriched with the request-scoped fields stored in ctx.
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// Providers returns the names of the listed backends.
+func (s *Service) Providers() []string {
+	names := make([]string, 0, len(s.sources))
+	for _, src := range s.sources {
+		names = append(names, src.Name())
+	}
+	return names
+}
+
+// ListMedia lists a page of the active assets of all selected backends, newest first. Every backend
+// is asked for a full page after the same cursor and the pages are merged, so the returned page
+// token positions all backends at once.
+func (s *Service) ListMedia(ctx context.Context, req *catalogmodel.ListMediaRequest) ([]*catalogmodel.MediaItem, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	sources, err := s.selectSources(req.Providers)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, _, err := pagination.DecodePageToken(req.PageToken); err != nil {
+		return nil, "", serviceerrors.NewInvalidArgumentError("invalid page token")
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	type result struct {
+		items []*catalogmodel.MediaItem
+		more  bool
+		err   error
+	}
+	results := make([]result, len(sources))
+	var wg sync.WaitGroup
+	for i, src := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items, more, err := src.ListItems(ctx, pageSize, req.PageToken)
+			results[i] = result{items: items, more: more, err: err}
+		}()
+	}
+	wg.Wait()
+
+	var items []*catalogmodel.MediaItem
+	more := false
+	for i, res := range results {
+		if res.err != nil {
+			s.log(ctx).Error("failed to list media of provider", zap.Error(res.err), zap.String("provider", sources[i].Name()))
+			return nil, "", fmt.Errorf("failed to list media of provider %s: %w", sources[i].Name(), res.err)
+		}
+		items = append(items, res.items...)
+		more = more || res.more
+	}
+
+	slices.SortFunc(items, func(a, b *catalogmodel.MediaItem) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+	if len(items) > pageSize {
+		items = items[:pageSize]
+		more = true
+	}
+	var nextToken string
+	if more && len(items) > 0 {
+		last := items[len(items)-1]
+		nextToken = pagination.EncodePageToken(last.CreatedAt, uuid.MustParse(last.ID))
+	}
+	return items, nextToken, nil
+}
+
+// selectSources returns the sources of the named providers, or all sources if names is empty.
+func (s *Service) selectSources(names []string) ([]Source, error) {
+	if len(names) == 0 {
+		return s.sources, nil
+	}
+	selected := make([]Source, 0, len(names))
+	for _, name := range names {
+		idx := slices.IndexFunc(s.sources, func(src Source) bool { return src.Name() == name })
+		if idx < 0 {
+			return nil, serviceerrors.NewInvalidArgumentError(fmt.Sprintf("unknown media provider %q", name))
+		}
+		if !slices.Contains(selected, s.sources[idx]) {
+			selected = append(selected, s.sources[idx])
+		}
+	}
+	return selected, nil
+}
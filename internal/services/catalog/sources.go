@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package catalog
+
+import (
+	"context"
+
+	catalogmodel "github.com/mikhail5545/media-service-go/internal/models/catalog"
+	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	cldservice "github.com/mikhail5545/media-service-go/internal/services/cloudinary"
+	"github.com/mikhail5545/media-service-go/internal/services/mediacore"
+	muxservice "github.com/mikhail5545/media-service-go/internal/services/mux"
+)
+
+// Provider names of the backends with their own services.
+const (
+	ProviderMux        = "mux"
+	ProviderCloudinary = "cloudinary"
+)
+
+// Source lists the active assets of one backend as media items, newest first. Sources page with the
+// creation-ordered page tokens of [pagination.EncodePageToken], so a single token positions all of them.
+type Source interface {
+	Name() string
+	// ListItems returns at most pageSize items created before the page token, and whether there
+	// are more items.
+	ListItems(ctx context.Context, pageSize int, pageToken string) ([]*catalogmodel.MediaItem, bool, error)
+}
+
+type muxSource struct {
+	svc *muxservice.Service
+}
+
+// MuxSource lists the MUX videos.
+func MuxSource(svc *muxservice.Service) Source {
+	return &muxSource{svc: svc}
+}
+
+func (s *muxSource) Name() string {
+	return ProviderMux
+}
+
+func (s *muxSource) ListItems(ctx context.Context, pageSize int, pageToken string) ([]*catalogmodel.MediaItem, bool, error) {
+	details, nextToken, err := s.svc.List(ctx, &muxassetmodel.ListRequest{
+		OrderBy:   muxassetmodel.OrderCreatedAt,
+		OrderDir:  muxassetmodel.OrderDescending,
+		PageSize:  pageSize,
+		PageToken: pageToken,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	items := make([]*catalogmodel.MediaItem, 0, len(details))
+	for _, d := range details {
+		item := &catalogmodel.MediaItem{
+			ID:        d.Asset.ID.String(),
+			Provider:  ProviderMux,
+			Kind:      "video",
+			Status:    string(d.Asset.Status),
+			Owners:    []catalogmodel.Owner{},
+			CreatedAt: d.Asset.CreatedAt,
+		}
+		// Signed playback IDs need a token, only public ones or poster images make a shareable thumbnail.
+		item.Thumbnail = d.Asset.PublicPosterURL()
+		if d.Metadata != nil {
+			item.Title = d.Metadata.Title
+			for _, o := range d.Metadata.Owners {
+				item.Owners = append(item.Owners, catalogmodel.Owner{OwnerID: o.OwnerID, OwnerType: o.OwnerType})
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nextToken != "", nil
+}
+
+type cloudinarySource struct {
+	svc *cldservice.Service
+}
+
+// CloudinarySource lists the Cloudinary assets.
+func CloudinarySource(svc *cldservice.Service) Source {
+	return &cloudinarySource{svc: svc}
+}
+
+func (s *cloudinarySource) Name() string {
+	return ProviderCloudinary
+}
+
+func (s *cloudinarySource) ListItems(ctx context.Context, pageSize int, pageToken string) ([]*catalogmodel.MediaItem, bool, error) {
+	details, nextToken, err := s.svc.List(ctx, &cldassetmodel.ListRequest{
+		OrderField: cldassetmodel.OrderCreatedAt,
+		OrderDir:   cldassetmodel.OrderDescending,
+		PageSize:   pageSize,
+		PageToken:  pageToken,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	items := make([]*catalogmodel.MediaItem, 0, len(details))
+	for _, d := range details {
+		item := &catalogmodel.MediaItem{
+			ID:        d.Asset.ID.String(),
+			Provider:  ProviderCloudinary,
+			Kind:      d.Asset.ResourceType,
+			Title:     d.Asset.DisplayName,
+			Status:    string(d.Asset.Status),
+			Owners:    []catalogmodel.Owner{},
+			CreatedAt: d.Asset.CreatedAt,
+		}
+		if d.Asset.ResourceType == "image" {
+			item.Thumbnail = d.Asset.SecureURL
+		}
+		if d.Metadata != nil {
+			if d.Metadata.Title != "" {
+				item.Title = d.Metadata.Title
+			}
+			for _, o := range d.Metadata.Owners {
+				item.Owners = append(item.Owners, catalogmodel.Owner{OwnerID: o.OwnerID, OwnerType: o.OwnerType})
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nextToken != "", nil
+}
+
+// thumbnailAttribute is the asset attribute the backends built on the shared asset core store a
+// preview image in, if they have one.
+const thumbnailAttribute = "thumbnail"
+
+type coreSource struct {
+	svc *mediacore.Service
+}
+
+// CoreSource lists the assets of a backend built on the shared asset core.
+func CoreSource(svc *mediacore.Service) Source {
+	return &coreSource{svc: svc}
+}
+
+func (s *coreSource) Name() string {
+	return s.svc.Name()
+}
+
+func (s *coreSource) ListItems(ctx context.Context, pageSize int, pageToken string) ([]*catalogmodel.MediaItem, bool, error) {
+	details, nextToken, err := s.svc.ListByCreation(ctx, pageSize, pageToken)
+	if err != nil {
+		return nil, false, err
+	}
+	items := make([]*catalogmodel.MediaItem, 0, len(details))
+	for _, d := range details {
+		item := &catalogmodel.MediaItem{
+			ID:        d.Asset.ID.String(),
+			Provider:  d.Asset.Provider,
+			Kind:      d.Asset.Kind,
+			Thumbnail: d.Asset.Attributes[thumbnailAttribute],
+			Status:    string(d.Asset.Status),
+			Owners:    []catalogmodel.Owner{},
+			CreatedAt: d.Asset.CreatedAt,
+		}
+		if d.Asset.Title != nil {
+			item.Title = *d.Asset.Title
+		}
+		if d.Metadata != nil {
+			for _, o := range d.Metadata.Owners {
+				item.Owners = append(item.Owners, catalogmodel.Owner{OwnerID: o.OwnerID, OwnerType: o.OwnerType})
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nextToken != "", nil
+}
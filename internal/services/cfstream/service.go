@@ -0,0 +1,271 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package cfstream implements the endpoints specific to the Cloudflare Stream video backend: direct
+// uploads, webhooks and playback URLs. Everything else is served by the shared asset core.
+package cfstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	cfstreamapi "github.com/mikhail5545/media-service-go/internal/apiclients/cfstream"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	cfstreammodel "github.com/mikhail5545/media-service-go/internal/models/cfstream"
+	mediamodel "github.com/mikhail5545/media-service-go/internal/models/media"
+	webhookmodel "github.com/mikhail5545/media-service-go/internal/models/webhook"
+	"github.com/mikhail5545/media-service-go/internal/services/mediacore"
+	"github.com/mikhail5545/media-service-go/internal/services/webhook"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+)
+
+// Config controls the uploads and the playback of the videos.
+type Config struct {
+	// MaxDurationSeconds is the default maximum duration of uploaded videos, Cloudflare applies its
+	// own limit if it is zero.
+	MaxDurationSeconds int
+	// RequireSignedURLs makes new videos playable only with a signed token.
+	RequireSignedURLs bool
+	UploadURLTTL      time.Duration
+	// PlaybackTokenTTL is the default validity of signed playback tokens.
+	PlaybackTokenTTL time.Duration
+}
+
+type Service struct {
+	core      *mediacore.Service
+	apiClient cfstreamapi.APIClient
+	cfg       Config
+	logger    *zap.Logger
+}
+
+type NewParams struct {
+	// Core is the asset service of the cfstream provider.
+	Core      *mediacore.Service
+	ApiClient cfstreamapi.APIClient
+	Config    Config
+}
+
+func New(params *NewParams, logger *zap.Logger) *Service {
+	return &Service{
+		core:      params.Core,
+		apiClient: params.ApiClient,
+		cfg:       params.Config,
+		logger:    logger.With(zap.String("layer", "service"), zap.String("service", "cfstream")),
+	}
+}
+
+// log returns the service logger enriched with the request-scoped fields stored in ctx.
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// CreateUploadURL creates a pending video asset and returns the one-time URL its content is uploaded
+// to. The asset is activated by the webhook sent once Cloudflare Stream has processed the video.
+func (s *Service) CreateUploadURL(ctx context.Context, req *cfstreammodel.CreateUploadURLRequest) (*cfstreammodel.UploadURL, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	adminID, err := parsing.StrToUUID(req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	assetID, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate asset ID: %w", err)
+	}
+
+	maxDuration := req.MaxDurationSeconds
+	if maxDuration == 0 {
+		maxDuration = s.cfg.MaxDurationSeconds
+	}
+	meta := map[string]string{cfstreammodel.MetaAssetID: assetID.String()}
+	if req.Title != "" {
+		meta["name"] = req.Title
+	}
+	upload, err := s.apiClient.CreateDirectUpload(ctx, cfstreamapi.DirectUploadOptions{
+		MaxDurationSeconds: maxDuration,
+		RequireSignedURLs:  s.cfg.RequireSignedURLs,
+		Meta:               meta,
+		Expires:            s.cfg.UploadURLTTL,
+	})
+	if err != nil {
+		s.log(ctx).Error("failed to create direct upload", zap.Error(err), logging.AssetID(assetID))
+		return nil, fmt.Errorf("failed to create direct upload: %w", err)
+	}
+
+	asset := &mediamodel.Asset{
+		ID:            assetID,
+		Kind:          cfstreammodel.KindVideo,
+		Status:        mediamodel.StatusPending,
+		ExternalID:    upload.UID,
+		Attributes:    map[string]string{cfstreammodel.AttributeSignedURLs: strconv.FormatBool(s.cfg.RequireSignedURLs)},
+		CreatedBy:     &adminID,
+		CreatedByName: &req.AdminName,
+	}
+	if req.Title != "" {
+		asset.Title = &req.Title
+	}
+	if err := s.core.Create(ctx, asset); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(30 * time.Minute)
+	if s.cfg.UploadURLTTL > 0 {
+		expiresAt = time.Now().Add(s.cfg.UploadURLTTL)
+	}
+	return &cfstreammodel.UploadURL{AssetID: assetID.String(), UploadURL: upload.UploadURL, ExpiresAt: expiresAt.UTC()}, nil
+}
+
+// VerifyWebhook checks the signature of a webhook against its raw payload.
+func (s *Service) VerifyWebhook(ctx context.Context, payload []byte, signature string) error {
+	if err := s.apiClient.VerifyWebhookSignature(payload, signature); err != nil {
+		s.log(ctx).Warn("received webhook with invalid signature", zap.Error(err))
+		return serviceerrors.NewPermissionDeniedError("invalid signature")
+	}
+	return nil
+}
+
+// WebhookHandlers returns the handler processing the Cloudflare Stream webhooks queued by the webhook
+// endpoint.
+func (s *Service) WebhookHandlers() map[webhookmodel.Provider]webhook.Handler {
+	return map[webhookmodel.Provider]webhook.Handler{
+		webhookmodel.ProviderCfStream: func(ctx context.Context, payload []byte) error {
+			var video cfstreamapi.Video
+			if err := json.Unmarshal(payload, &video); err != nil {
+				return serviceerrors.NewValidationFailedError(err)
+			}
+			return s.HandleWebhook(ctx, &video)
+		},
+	}
+}
+
+// HandleWebhook applies the state of the video reported by Cloudflare Stream to its asset. Ready
+// videos activate the asset, failed videos mark it as errored and all other states are ignored.
+// Webhooks of unknown videos or of assets in a conflicting state are ignored as well, so that
+// Cloudflare does not retry them.
+func (s *Service) HandleWebhook(ctx context.Context, video *cfstreamapi.Video) error {
+	if video.UID == "" {
+		return serviceerrors.NewInvalidArgumentError("webhook is missing the video ID")
+	}
+	asset, err := s.core.GetByExternalID(ctx, video.UID)
+	if err != nil {
+		if errors.Is(err, serviceerrors.ErrNotFound) {
+			s.log(ctx).Warn("received webhook of unknown video", zap.String("video_id", video.UID))
+			return nil
+		}
+		return err
+	}
+
+	switch {
+	case video.ReadyToStream:
+		_, err = s.core.Activate(ctx, asset.ID, videoUpdates(video))
+	case video.Status.State == cfstreamapi.StateError:
+		reason := video.Status.ErrorReasonText
+		if reason == "" {
+			reason = video.Status.ErrorReasonCode
+		}
+		_, err = s.core.MarkErrored(ctx, asset.ID, reason)
+	default:
+		return nil
+	}
+	if errors.Is(err, serviceerrors.ErrConflict) {
+		s.log(ctx).Warn("ignoring webhook of asset in conflicting state",
+			zap.Error(err),
+			logging.AssetID(asset.ID),
+			zap.String("state", video.Status.State),
+		)
+		return nil
+	}
+	return err
+}
+
+// videoUpdates returns the asset updates describing the processed video.
+func videoUpdates(video *cfstreamapi.Video) map[string]any {
+	attrs := map[string]string{
+		cfstreammodel.AttributeHLS:        video.Playback.HLS,
+		cfstreammodel.AttributeDASH:       video.Playback.DASH,
+		cfstreammodel.AttributeThumbnail:  video.Thumbnail,
+		cfstreammodel.AttributeSignedURLs: strconv.FormatBool(video.RequireSignedURLs),
+	}
+	if video.Duration > 0 {
+		attrs[cfstreammodel.AttributeDuration] = strconv.FormatFloat(video.Duration, 'f', -1, 64)
+	}
+	if video.Input.Width > 0 && video.Input.Height > 0 {
+		attrs[cfstreammodel.AttributeWidth] = strconv.Itoa(video.Input.Width)
+		attrs[cfstreammodel.AttributeHeight] = strconv.Itoa(video.Input.Height)
+	}
+	updates := map[string]any{"attributes": attrs}
+	if video.Size > 0 {
+		updates["bytes"] = video.Size
+	}
+	return updates
+}
+
+// GetPlaybackURL returns the manifest URLs of an active video asset. The video ID in the URLs of
+// videos requiring signed URLs is replaced with a signed token.
+func (s *Service) GetPlaybackURL(ctx context.Context, req *cfstreammodel.PlaybackURLRequest) (*cfstreammodel.PlaybackURL, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	details, err := s.core.Get(ctx, &mediamodel.GetRequest{ID: req.ID})
+	if err != nil {
+		return nil, err
+	}
+	asset := details.Asset
+	playback := &cfstreammodel.PlaybackURL{
+		HLS:       asset.Attributes[cfstreammodel.AttributeHLS],
+		DASH:      asset.Attributes[cfstreammodel.AttributeDASH],
+		Thumbnail: asset.Attributes[cfstreammodel.AttributeThumbnail],
+	}
+	if playback.HLS == "" && playback.DASH == "" {
+		return nil, serviceerrors.NewConflictError("asset does not have playback URLs yet")
+	}
+	if asset.Attributes[cfstreammodel.AttributeSignedURLs] != "true" {
+		return playback, nil
+	}
+
+	ttl := s.cfg.PlaybackTokenTTL
+	if req.Expiration > 0 {
+		ttl = time.Duration(req.Expiration) * time.Second
+	}
+	expiresAt := time.Now().Add(ttl).UTC()
+	token, err := s.apiClient.GeneratePlaybackToken(cfstreamapi.PlaybackTokenOptions{
+		UID:        asset.ExternalID,
+		Expiration: expiresAt.Unix(),
+	})
+	if err != nil {
+		s.log(ctx).Error("failed to sign playback token", zap.Error(err), logging.AssetID(asset.ID))
+		return nil, fmt.Errorf("failed to sign playback token: %w", err)
+	}
+	replace := func(u string) string {
+		return strings.Replace(u, "/"+asset.ExternalID+"/", "/"+token+"/", 1)
+	}
+	playback.HLS = replace(playback.HLS)
+	playback.DASH = replace(playback.DASH)
+	playback.Thumbnail = replace(playback.Thumbnail)
+	playback.Signed = true
+	playback.ExpiresAt = &expiresAt
+	return playback, nil
+}
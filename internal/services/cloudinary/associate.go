@@ -0,0 +1,211 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
+	"github.com/mikhail5545/media-service-go/internal/database/types"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/metadata"
+	outboxmodel "github.com/mikhail5545/media-service-go/internal/models/outbox"
+	versionmodel "github.com/mikhail5545/media-service-go/internal/models/versions"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ownerAssetsPageSize is the page size used to list all assets of an owner being replaced.
+const ownerAssetsPageSize = 100
+
+// AssociateOwner associates an owner with an asset and returns the assets the owner was moved from.
+// Without ReplaceExisting it behaves like AddOwner and fails if the owner already has the asset or
+// the ownership policy does not allow the owner another asset.
+//
+// With ReplaceExisting the owner is removed from all other assets it is associated with and added to
+// the asset by a single saga, so either the owner is moved or nothing changes. The ownership policy is
+// checked as if the owner had no assets. With ArchiveReplaced the replaced assets left without owners
+// are archived afterwards, keeping the removed owner in the ownership snapshot, so the replacement can
+// be undone by restoring the asset with its owners. An asset that fails to be archived is logged and
+// left out of ArchivedAssetIDs, the owner is moved either way.
+func (s *Service) AssociateOwner(ctx context.Context, req *assetmodel.ManageOwnerRequest) (*assetmodel.AssociateResult, error) {
+	if req.ReplaceExisting {
+		return s.replaceOwner(ctx, req, versionmodel.KindReplace)
+	}
+	if err := s.AddOwner(ctx, req); err != nil {
+		return nil, err
+	}
+	return &assetmodel.AssociateResult{AssetID: req.ID, ReplacedAssetIDs: []string{}, ArchivedAssetIDs: []string{}}, nil
+}
+
+// replaceOwner moves the owner to the asset and records the asset as a version of the kind in the
+// version chain of the owner.
+func (s *Service) replaceOwner(ctx context.Context, req *assetmodel.ManageOwnerRequest, kind versionmodel.Kind) (*assetmodel.AssociateResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	defer s.invalidateByID(ctx, req.ID)
+
+	asset, err := s.getInTx(ctx, s.repo, req.ID, []string{"id", "status", "moderation_status"})
+	if err != nil {
+		return nil, err
+	}
+	if err := validateBeforeAddOwner(asset); err != nil {
+		return nil, err
+	}
+	metadata, err := s.getAssetMetadata(ctx, asset.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := &metadatamodel.Owner{OwnerID: req.OwnerID, OwnerType: req.OwnerType}
+	if err := s.checkOwnership(ctx, owner, asset.ID); err != nil {
+		return nil, err
+	}
+	ownerTypes := make([]string, 0, len(metadata.Owners))
+	for _, existing := range metadata.Owners {
+		ownerTypes = append(ownerTypes, existing.OwnerType)
+	}
+	if err := s.ownership.Check(owner.OwnerType, ownerTypes, 0); err != nil {
+		return nil, err
+	}
+
+	replaced, err := s.listOwnerAssets(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	replacedIDs := make([]uuid.UUID, 0, len(replaced))
+	for _, m := range replaced {
+		id, err := uuid.Parse(m.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset metadata key %q: %w", m.Key, err)
+		}
+		replacedIDs = append(replacedIDs, id)
+	}
+	defer s.invalidate(ctx, replacedIDs...)
+
+	saga, err := newReplaceOwnerSaga(asset.ID, owner, replacedIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sagas.Execute(ctx, saga); err != nil {
+		if errors.Is(err, dbmetadata.ErrNotFound) {
+			return nil, serviceerrors.NewNotFoundError(err)
+		}
+		s.log(ctx).Error("failed to replace owner assets", zap.Error(err), logging.AssetID(asset.ID), zap.String("saga_id", saga.ID.String()))
+		return nil, fmt.Errorf("failed to replace owner assets: %w", err)
+	}
+
+	// The owner changes were recorded and published by the completion of the saga.
+	if err := s.recordVersion(ctx, s.repo.DB(), req, kind, asset.ID, replacedIDs); err != nil {
+		return nil, err
+	}
+
+	result := &assetmodel.AssociateResult{
+		AssetID:          asset.ID.String(),
+		ReplacedAssetIDs: make([]string, 0, len(replacedIDs)),
+		ArchivedAssetIDs: []string{},
+	}
+	for i, id := range replacedIDs {
+		result.ReplacedAssetIDs = append(result.ReplacedAssetIDs, id.String())
+		remaining := slices.DeleteFunc(slices.Clone(replaced[i].Owners), func(o *metadatamodel.Owner) bool { return *o == *owner })
+		if !req.ArchiveReplaced || len(remaining) > 0 {
+			continue
+		}
+		if err := s.archiveReplaced(ctx, req, id, asset.ID, owner); err != nil {
+			s.log(ctx).Warn("failed to archive replaced asset", zap.Error(err), logging.AssetID(id))
+			continue
+		}
+		result.ArchivedAssetIDs = append(result.ArchivedAssetIDs, id.String())
+	}
+	return result, nil
+}
+
+// listOwnerAssets returns the metadata of all assets associated with the owner.
+func (s *Service) listOwnerAssets(ctx context.Context, owner *metadatamodel.Owner) ([]*metadatamodel.AssetMetadata, error) {
+	var all []*metadatamodel.AssetMetadata
+	afterKey := ""
+	for {
+		page, nextKey, err := s.metadataRepo.ListByOwner(ctx, owner, ownerAssetsPageSize, afterKey)
+		if err != nil {
+			s.log(ctx).Error(
+				"failed to list assets of owner",
+				zap.Error(err),
+				zap.String("owner_id", owner.OwnerID),
+				zap.String("owner_type", owner.OwnerType),
+			)
+			return nil, fmt.Errorf("failed to list assets of owner: %w", err)
+		}
+		all = append(all, page...)
+		if nextKey == "" {
+			return all, nil
+		}
+		afterKey = nextKey
+	}
+}
+
+// archiveReplaced archives an image whose only owner was moved to another asset. The owner is kept in
+// the ownership snapshot of the asset and product-service is notified as on Archive.
+func (s *Service) archiveReplaced(ctx context.Context, req *assetmodel.ManageOwnerRequest, assetID, replacedBy uuid.UUID, owner *metadatamodel.Owner) error {
+	adminID, err := parsing.StrToUUID(req.AdminID)
+	if err != nil {
+		return err
+	}
+	note := fmt.Sprintf("Owner %s (%s) moved to asset %s.", owner.OwnerID, owner.OwnerType, replacedBy)
+	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		asset, err := s.getInTx(ctx, txRepo, assetID.String(), []string{"id", "status"})
+		if err != nil {
+			return err
+		}
+		if asset.Status == assetmodel.StatusArchived {
+			return serviceerrors.NewConflictError("asset is already archived")
+		}
+		if err := s.snapshotOwners(ctx, txRepo, asset.ID, []*metadatamodel.Owner{owner}, assetmodel.SnapshotReasonReplaced); err != nil {
+			return err
+		}
+		if _, err := txRepo.Archive(ctx, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}, &types.AuditTrailOptions{
+			AdminID:   adminID,
+			AdminName: req.AdminName,
+			Note:      note,
+		}); err != nil {
+			s.log(ctx).Error("failed to archive asset", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to archive asset: %w", err)
+		}
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionArchive, asset.ID,
+			statusSnapshot(asset.Status), statusSnapshot(assetmodel.StatusArchived),
+			audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(note),
+		); err != nil {
+			return err
+		}
+		return s.enqueueEvent(ctx, s.outboxRepo.WithTx(tx), outboxmodel.EventImageDeleted, &outboxmodel.DeletePayload{
+			AssetIDs: uuid.UUIDs{asset.ID},
+		})
+	})
+}
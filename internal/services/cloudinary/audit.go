@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/metadata"
+	"github.com/mikhail5545/media-service-go/internal/models/publishing"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// recordAudit persists an audit log entry of the admin mutation within tx.
+func (s *Service) recordAudit(
+	ctx context.Context,
+	tx *gorm.DB,
+	action auditmodel.Action,
+	assetID uuid.UUID,
+	before, after any,
+	opts ...audit.EntryOption,
+) error {
+	entry, err := audit.NewEntry(ctx, auditmodel.ProviderCloudinary, action, assetID, before, after, opts...)
+	if err != nil {
+		return err
+	}
+	if err := s.auditRepo.WithTx(tx).Create(ctx, entry); err != nil {
+		s.log(ctx).Error("failed to record audit log entry", zap.Error(err), logging.AssetID(assetID), zap.String("action", string(action)))
+		return fmt.Errorf("failed to record audit log entry: %w", err)
+	}
+	return nil
+}
+
+func statusSnapshot(status assetmodel.Status) map[string]any {
+	return map[string]any{"status": status}
+}
+
+func publishingSnapshot(state publishing.State) map[string]any {
+	return map[string]any{"publishing_state": state}
+}
+
+// ownersSnapshot copies the owners, so the snapshot is not affected by later changes of the slice.
+func ownersSnapshot(owners []*metadatamodel.Owner) map[string]any {
+	return map[string]any{"owners": slices.Clone(owners)}
+}
+
+func metadataSnapshot(metadata *metadatamodel.AssetMetadata) map[string]any {
+	return map[string]any{
+		"title":      metadata.Title,
+		"creator_id": metadata.CreatorID,
+	}
+}
+
+func tagsSnapshot(assetTags []string) map[string]any {
+	return map[string]any{"tags": slices.Clone(assetTags)}
+}
+
+func moderationSnapshot(status *assetmodel.ModerationStatus) map[string]any {
+	return map[string]any{"moderation_status": status}
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/metadata"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// RestoreFromBackup restores the file of an archived image destroyed in Cloudinary from the Cloudinary
+// backup and returns the updated asset. The asset stays archived, Restore or RestoreWithOwners makes it
+// active again. The metadata removed with the file is recreated without owners.
+func (s *Service) RestoreFromBackup(ctx context.Context, req *assetmodel.RestoreFromBackupRequest) (*assetmodel.Asset, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	assetID, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	asset, err := s.getAsset(ctx, assetID, []assetrepo.Scope{assetrepo.ScopeArchived})
+	if err != nil {
+		return nil, err
+	}
+	if asset.CloudinaryDestroyedAt == nil {
+		return nil, serviceerrors.NewConflictError("only images destroyed in Cloudinary can be restored from backup")
+	}
+
+	// Cloudinary is called outside of the transaction, restoring the same version again is harmless.
+	restored, err := s.apiClient.RestoreAsset(ctx, asset.CloudinaryPublicID, asset.ResourceType, req.VersionID)
+	if err != nil {
+		s.log(ctx).Error("failed to restore asset from Cloudinary backup", zap.Error(err), logging.AssetID(asset.ID))
+		return nil, fmt.Errorf("failed to restore asset from Cloudinary backup: %w", err)
+	}
+
+	defer s.invalidate(ctx, asset.ID)
+	err = crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		updates := restoredFileUpdates(restored)
+		affected, err := s.repo.WithTx(tx).MarkRestoredFromBackup(ctx, asset.ID, updates)
+		if err != nil {
+			s.log(ctx).Error("failed to update asset restored from backup", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to update asset restored from backup: %w", err)
+		}
+		if affected == 0 {
+			return serviceerrors.NewConflictError("asset was already restored from backup")
+		}
+		if err := s.recreateMetadata(ctx, asset); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, tx, auditmodel.ActionRestoreFromBackup, asset.ID,
+			map[string]any{"cloudinary_destroyed_at": asset.CloudinaryDestroyedAt}, updates,
+			audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note),
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.getAsset(ctx, asset.ID, []assetrepo.Scope{assetrepo.ScopeArchived})
+}
+
+// restoredFileUpdates returns the updates of the file details of the restored version.
+func restoredFileUpdates(restored *api.BriefAssetResult) map[string]any {
+	updates := make(map[string]any)
+	if restored.URL != "" {
+		updates["url"] = restored.URL
+	}
+	if restored.SecureURL != "" {
+		updates["secure_url"] = restored.SecureURL
+	}
+	if restored.Format != "" {
+		updates["format"] = restored.Format
+	}
+	if restored.Bytes > 0 {
+		updates["bytes"] = int64(restored.Bytes)
+	}
+	if restored.Width > 0 && restored.Height > 0 {
+		updates["width"] = restored.Width
+		updates["height"] = restored.Height
+	}
+	return updates
+}
+
+// recreateMetadata creates the metadata of a restored asset if it was removed when the file was destroyed.
+func (s *Service) recreateMetadata(ctx context.Context, asset *assetmodel.Asset) error {
+	_, err := s.getAssetMetadata(ctx, asset.ID)
+	if err == nil || !errors.Is(err, serviceerrors.ErrNotFound) {
+		return err
+	}
+	return s.createMetadata(ctx, &metadatamodel.AssetMetadata{
+		Key:    asset.ID.String(),
+		Title:  asset.DisplayName,
+		Owners: []*metadatamodel.Owner{},
+	})
+}
+
+// backupVersions lists the restorable backup versions of a destroyed image. The versions are only
+// informative, so a failed lookup is logged and no versions are returned.
+func (s *Service) backupVersions(ctx context.Context, asset *assetmodel.Asset) []*assetmodel.BackupVersion {
+	versions, err := s.apiClient.ListBackupVersions(ctx, asset.CloudinaryPublicID, asset.ResourceType)
+	if err != nil {
+		s.log(ctx).Warn("failed to list backup versions", zap.Error(err), logging.AssetID(asset.ID))
+		return nil
+	}
+	restorable := make([]*assetmodel.BackupVersion, 0, len(versions))
+	for _, v := range versions {
+		if !v.Restorable {
+			continue
+		}
+		restorable = append(restorable, &assetmodel.BackupVersion{
+			VersionID:  v.VersionID,
+			Version:    v.Version,
+			Format:     v.Format,
+			Bytes:      v.Bytes,
+			CreatedAt:  v.CreatedAt,
+			Restorable: v.Restorable,
+		})
+	}
+	return restorable
+}
+
+// checkBackups records whether Cloudinary keeps a backup of the destroyed files of the archived assets
+// with the public IDs. A failed check leaves the availability unknown.
+func (s *Service) checkBackups(ctx context.Context, publicIDs []string) {
+	assets, err := s.listByPublicIDs(ctx, s.repo, publicIDs, assetrepo.ScopeArchived)
+	if err != nil {
+		s.log(ctx).Warn("failed to list destroyed assets", zap.Error(err))
+		return
+	}
+	for _, asset := range assets {
+		if asset.CloudinaryDestroyedAt == nil {
+			continue
+		}
+		versions, err := s.apiClient.ListBackupVersions(ctx, asset.CloudinaryPublicID, asset.ResourceType)
+		if err != nil {
+			s.log(ctx).Warn("failed to check asset backup", zap.Error(err), logging.AssetID(asset.ID))
+			continue
+		}
+		available := false
+		for _, v := range versions {
+			available = available || v.Restorable
+		}
+		if err := s.repo.SaveBackupAvailability(ctx, asset.ID, available); err != nil {
+			s.log(ctx).Warn("failed to save asset backup availability", zap.Error(err), logging.AssetID(asset.ID))
+			continue
+		}
+		s.invalidate(ctx, asset.ID)
+	}
+}
+
+// markDestroyed records that the files with the public IDs were destroyed in Cloudinary.
+func (s *Service) markDestroyed(ctx context.Context, txRepo *assetrepo.Repository, publicIDs []string) error {
+	if _, err := txRepo.MarkDestroyed(ctx, publicIDs, time.Now()); err != nil {
+		s.log(ctx).Error("failed to mark assets as destroyed", zap.Error(err), zap.Strings("public_ids", publicIDs))
+		return fmt.Errorf("failed to mark assets as destroyed: %w", err)
+	}
+	return nil
+}
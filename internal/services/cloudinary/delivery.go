@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
+	"github.com/mikhail5545/media-service-go/internal/entitlement"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/metadata"
+	"go.uber.org/zap"
+)
+
+// ImageDelivery returns the delivery URL of an active image to the authenticated end user. The user
+// must be allowed to access the image by one of its owners, images rejected by moderation or outside of
+// their publication schedule are not delivered.
+// The URL overlays the watermark of the creator or of the owner types of the image, if one is configured.
+func (s *Service) ImageDelivery(ctx context.Context, req *assetmodel.ImageDeliveryRequest) (*assetmodel.ImageDelivery, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	userID, err := entitlement.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assetID := uuid.MustParse(req.ID)
+
+	metadata, err := s.getDeliveryMetadata(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	if err := entitlement.Check(ctx, s.entitlements, userID, entitlementOwners(metadata.Owners)); err != nil {
+		return nil, err
+	}
+	asset, err := s.getAsset(ctx, assetID, []assetrepo.Scope{assetrepo.ScopeActive},
+		"id", "secure_url", "format", "width", "height", "moderation_status", "publishing_state", "publish_at", "unpublish_at")
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPublished(asset); err != nil {
+		return nil, err
+	}
+	if asset.ModerationStatus != nil && *asset.ModerationStatus == assetmodel.ModerationRejected {
+		return nil, serviceerrors.NewConflictError("image was rejected by moderation")
+	}
+	deliveryURL, err := s.watermarkURL(ctx, asset.SecureURL, metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &assetmodel.ImageDelivery{
+		URL:    deliveryURL,
+		Format: asset.Format,
+		Width:  asset.Width,
+		Height: asset.Height,
+	}, nil
+}
+
+// getDeliveryMetadata retrieves the owners and the creator of an asset, which decide the access to
+// the image and its watermark.
+func (s *Service) getDeliveryMetadata(ctx context.Context, assetID uuid.UUID) (*metadatamodel.AssetMetadata, error) {
+	metadata, err := s.metadataRepo.Get(ctx, assetID.String(), "owners", "creator_id")
+	if err != nil {
+		if errors.Is(err, dbmetadata.ErrNotFound) {
+			return nil, serviceerrors.NewNotFoundError(err)
+		}
+		s.log(ctx).Error("failed to retrieve asset owners", zap.Error(err), logging.AssetID(assetID))
+		return nil, fmt.Errorf("failed to retrieve asset owners: %w", err)
+	}
+	return metadata, nil
+}
+
+func entitlementOwners(owners []*metadatamodel.Owner) []entitlement.Owner {
+	res := make([]entitlement.Owner, 0, len(owners))
+	for _, owner := range owners {
+		res = append(res, entitlement.Owner{Type: owner.OwnerType, ID: owner.OwnerID})
+	}
+	return res
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
+	"github.com/mikhail5545/media-service-go/internal/database/types"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const defaultDuplicateGroupsLimit = 100
+
+// errDuplicateUpload is returned by completeUpload when duplicates are blocked and the uploaded file
+// is identical to an active asset.
+var errDuplicateUpload = errors.New("uploaded image is a duplicate")
+
+// FindDuplicates lists the groups of active assets sharing a content hash, largest groups first.
+// Groups by etag hold byte-identical files, groups by phash hold images which only look the same,
+// editors have to compare them before archiving any.
+func (s *Service) FindDuplicates(ctx context.Context, req *assetmodel.FindDuplicatesRequest) ([]*assetmodel.DuplicateGroup, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	kind := req.Kind
+	if kind == "" {
+		kind = assetmodel.DuplicateKindEtag
+	}
+	limit := defaultDuplicateGroupsLimit
+	if req.Limit > 0 {
+		limit = req.Limit
+	}
+
+	groups, err := s.repo.ListDuplicates(ctx, kind, limit)
+	if err != nil {
+		s.log(ctx).Error("failed to list duplicate assets", zap.Error(err), zap.String("kind", string(kind)))
+		return nil, fmt.Errorf("failed to list duplicate assets: %w", err)
+	}
+	return groups, nil
+}
+
+// findDuplicate returns the active asset the file uploaded for the asset is identical to. It returns nil
+// if duplicates are allowed, the etag is unknown or the asset is not waiting for its upload, so
+// repeated upload notifications do not reject assets which are already in use.
+func (s *Service) findDuplicate(ctx context.Context, txRepo *assetrepo.Repository, asset *assetmodel.Asset, etag string) (*assetmodel.Asset, error) {
+	if !s.blockDuplicates || etag == "" || asset.Status != assetmodel.StatusUploadURLGenerated {
+		return nil, nil
+	}
+	duplicate, err := txRepo.GetByEtag(ctx, etag, asset.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		s.log(ctx).Error("failed to look up duplicate asset", zap.Error(err), logging.AssetID(asset.ID), zap.String("etag", etag))
+		return nil, fmt.Errorf("failed to look up duplicate asset: %w", err)
+	}
+	return duplicate, nil
+}
+
+// rejectDuplicate marks the asset of a duplicate upload as broken on behalf of the admin who created it.
+func (s *Service) rejectDuplicate(ctx context.Context, tx *gorm.DB, asset, duplicate *assetmodel.Asset, opts ...audit.EntryOption) error {
+	note := fmt.Sprintf("Uploaded image is a duplicate of asset %s", duplicate.ID)
+	auditOpts := &types.AuditTrailOptions{Note: note}
+	if asset.CreatedBy != nil {
+		auditOpts.AdminID = *asset.CreatedBy
+	}
+	if asset.CreatedByName != nil {
+		auditOpts.AdminName = *asset.CreatedByName
+	}
+	if _, err := s.repo.WithTx(tx).MarkAsBroken(ctx, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}, auditOpts); err != nil {
+		s.log(ctx).Error("failed to mark duplicate asset as broken", zap.Error(err), logging.AssetID(asset.ID))
+		return fmt.Errorf("failed to mark duplicate asset as broken: %w", err)
+	}
+	return s.recordAudit(ctx, tx, auditmodel.ActionMarkAsBroken, asset.ID,
+		statusSnapshot(asset.Status), statusSnapshot(assetmodel.StatusBroken),
+		append(opts, audit.WithNote(note))...,
+	)
+}
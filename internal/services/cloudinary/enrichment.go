@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
+	"github.com/mikhail5545/media-service-go/internal/environment"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	"github.com/mikhail5545/media-service-go/internal/models/enrichment"
+	"go.uber.org/zap"
+)
+
+// Search searches the assets by the title, the labels and the OCR text of the images.
+func (s *Service) Search(ctx context.Context, req *assetmodel.SearchRequest) ([]*assetmodel.Details, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultListByOwnerPageSize
+	}
+
+	metadataPage, nextPageToken, err := s.metadataRepo.Search(ctx, req.Query, req.Label, pageSize, req.PageToken)
+	if err != nil {
+		s.log(ctx).Error("failed to search asset metadata", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to search asset metadata: %w", err)
+	}
+	details, err := s.joinAssets(ctx, metadataPage)
+	if err != nil {
+		return nil, "", err
+	}
+	return details, nextPageToken, nil
+}
+
+// enrichImage runs the configured Cloudinary add-ons on the uploaded image and stores the derived data
+// in the asset metadata. It is called by the outbox dispatcher, so failed attempts are retried.
+func (s *Service) enrichImage(ctx context.Context, assetID uuid.UUID) error {
+	if s.enrichment == nil {
+		return nil
+	}
+	// Assets which were archived or broken in the meantime are not enriched
+	asset, err := s.getAsset(ctx, assetID, []assetrepo.Scope{assetrepo.ScopeActive})
+	if err != nil {
+		return err
+	}
+	ctx = environment.WithContext(ctx, asset.Environment)
+
+	result, err := s.apiClient.Enrich(ctx, asset.CloudinaryPublicID, asset.ResourceType, s.enrichment)
+	if err != nil {
+		s.log(ctx).Warn("failed to enrich image", zap.Error(err), logging.AssetID(asset.ID))
+		return fmt.Errorf("failed to enrich image: %w", err)
+	}
+	if err := s.metadataRepo.SetEnrichment(ctx, asset.ID.String(), &enrichment.Enrichment{
+		Labels:         result.Labels,
+		DominantColors: result.DominantColors,
+		OCRText:        result.OCRText,
+		EnrichedAt:     time.Now(),
+	}); err != nil {
+		s.log(ctx).Error("failed to store image enrichment", zap.Error(err), logging.AssetID(asset.ID))
+		return fmt.Errorf("failed to store image enrichment: %w", err)
+	}
+	s.invalidate(ctx, asset.ID)
+	s.publishEvent(ctx, events.TypeAssetUpdated, asset.ID, withExternalID(&asset.CloudinaryPublicID), events.WithData("enriched", "true"))
+	return nil
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/metadata"
+	"go.uber.org/zap"
+)
+
+// publishEvent publishes asset lifecycle event. Publishing is best effort, failures are only logged.
+func (s *Service) publishEvent(ctx context.Context, eventType events.Type, assetID uuid.UUID, opts ...events.Option) {
+	event := events.NewEvent(eventType, events.ProviderCloudinary, assetID, opts...)
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.log(ctx).Warn("failed to publish asset event",
+			zap.Error(err),
+			zap.String("event_type", string(eventType)),
+			logging.AssetID(assetID),
+		)
+	}
+}
+
+func withExternalID(externalID *string) events.Option {
+	if externalID == nil {
+		return func(*events.Event) {}
+	}
+	return events.WithExternalID(*externalID)
+}
+
+func withOwners(owners []*metadatamodel.Owner) events.Option {
+	converted := make([]events.Owner, 0, len(owners))
+	for _, owner := range owners {
+		converted = append(converted, events.Owner{OwnerID: owner.OwnerID, OwnerType: owner.OwnerType})
+	}
+	return events.WithOwners(converted...)
+}
@@ -19,33 +19,34 @@ package cloudinary
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/google/uuid"
-	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	outboxmodel "github.com/mikhail5545/media-service-go/internal/models/outbox"
 	bytesutil "github.com/mikhail5545/media-service-go/internal/util/bytes"
+	errutil "github.com/mikhail5545/media-service-go/internal/util/errors"
 	imagepbv1 "github.com/mikhail5545/product-service-client/pb/product_service/image/v1"
 	"go.uber.org/zap"
 )
 
-func (s *Service) grpcMarkAsBroken(ctx context.Context, assetID, adminID *uuid.UUID, req *assetmodel.ChangeStateRequest) error {
-	bytes, err := bytesutil.UUIDToBytes(assetID)
+func (s *Service) grpcMarkAsBroken(ctx context.Context, payload *outboxmodel.BrokenPayload) error {
+	bytes, err := bytesutil.UUIDToBytes(&payload.AssetID)
 	if err != nil {
 		return err
 	}
-	adminIDBytes, err := bytesutil.UUIDToBytes(adminID)
+	adminIDBytes, err := bytesutil.UUIDToBytes(&payload.AdminID)
 	if err != nil {
 		return err
 	}
 	grpcReq := &imagepbv1.BrokenImageRequest{
 		MediaServiceUuid: bytes,
 		AdminUuid:        adminIDBytes,
-		Reason:           req.Note,
-		AdminName:        req.AdminName,
+		Reason:           payload.Reason,
+		AdminName:        payload.AdminName,
 	}
 	if _, err := s.imageServiceClient.BrokenImage(ctx, grpcReq); err != nil {
-		s.logger.Error("failed to mark asset as broken via gRPC", zap.Error(err), zap.String("asset_id", assetID.String()))
-		return fmt.Errorf("failed to mark asset as broken via gRPC: %w", err)
+		s.log(ctx).Error("failed to mark asset as broken via gRPC", zap.Error(err), logging.AssetID(payload.AssetID))
+		return errutil.HandleRPCError(err)
 	}
 	return nil
 }
@@ -59,8 +60,22 @@ func (s *Service) grpcDelete(ctx context.Context, assetID *uuid.UUID) error {
 		MediaServiceUuid: bytes,
 	}
 	if _, err := s.imageServiceClient.Delete(ctx, grpcReq); err != nil {
-		s.logger.Error("failed to delete asset via gRPC", zap.Error(err), zap.String("asset_id", assetID.String()))
-		return fmt.Errorf("failed to delete asset via gRPC: %w", err)
+		s.log(ctx).Error("failed to delete asset via gRPC", zap.Error(err), logging.AssetID(assetID))
+		return errutil.HandleRPCError(err)
+	}
+	return nil
+}
+
+func (s *Service) grpcForceDeleteBatch(ctx context.Context, assetIDs uuid.UUIDs) error {
+	assetIDsBytes, err := bytesutil.SliceStringsToUUIDBytes(assetIDs.Strings())
+	if err != nil {
+		return err
+	}
+	if _, err := s.imageServiceClient.ForceDeleteBatch(ctx, &imagepbv1.ForceDeleteBatchRequest{
+		MediaServiceUuids: assetIDsBytes,
+	}); err != nil {
+		s.log(ctx).Error("failed to force delete images via gRPC", zap.Error(err), zap.Int("count", len(assetIDs)))
+		return errutil.HandleRPCError(err)
 	}
 	return nil
 }
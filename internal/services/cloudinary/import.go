@@ -0,0 +1,192 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	assetimportmodel "github.com/mikhail5545/media-service-go/internal/models/assetimport"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/metadata"
+	"github.com/mikhail5545/media-service-go/internal/tags"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// importPageSize is the number of Cloudinary assets listed at once by ImportAssets.
+const importPageSize = 500
+
+// importResourceTypes are the Cloudinary resource types ImportAssets lists.
+var importResourceTypes = []string{"image", "video", "raw"}
+
+// ImportAssets pages through the uploaded assets of the Cloudinary account and creates the local
+// records of the assets unknown to the service. Assets are known when their Cloudinary asset ID or
+// their public ID matches a local asset in any status.
+//
+// Owners are matched from the "external_id" context value of the Cloudinary asset, see
+// [assetimportmodel.ParseOwners], the "caption" context value becomes the title.
+func (s *Service) ImportAssets(ctx context.Context, opts *assetimportmodel.Options, report func(*assetimportmodel.Result)) error {
+	for _, resourceType := range importResourceTypes {
+		nextCursor := ""
+		for {
+			res, err := s.apiClient.ListAssets(ctx, resourceType, importPageSize, nextCursor)
+			if err != nil {
+				s.log(ctx).Error("failed to list cloudinary assets for import", zap.Error(err), zap.String("resource_type", resourceType))
+				return err
+			}
+
+			assetIDs := make([]string, 0, len(res.Assets))
+			publicIDs := make([]string, 0, len(res.Assets))
+			for _, a := range res.Assets {
+				assetIDs = append(assetIDs, a.AssetID)
+				publicIDs = append(publicIDs, a.PublicID)
+			}
+			known, err := s.repo.ListKnown(ctx, assetIDs, publicIDs)
+			if err != nil {
+				s.log(ctx).Error("failed to list known cloudinary assets", zap.Error(err))
+				return fmt.Errorf("failed to list known cloudinary assets: %w", err)
+			}
+			knownIDs := make(map[string]uuid.UUID, 2*len(known))
+			for _, a := range known {
+				knownIDs[a.CloudinaryAssetID] = a.ID
+				knownIDs[a.CloudinaryPublicID] = a.ID
+			}
+
+			for i := range res.Assets {
+				a := &res.Assets[i]
+				if id, ok := knownIDs[a.AssetID]; ok {
+					report(&assetimportmodel.Result{ExternalID: a.PublicID, Outcome: assetimportmodel.OutcomeSkipped, AssetID: id})
+					continue
+				}
+				if id, ok := knownIDs[a.PublicID]; ok {
+					report(&assetimportmodel.Result{ExternalID: a.PublicID, Outcome: assetimportmodel.OutcomeSkipped, AssetID: id})
+					continue
+				}
+				report(s.importAsset(ctx, opts, a))
+			}
+			if res.NextCursor == "" {
+				break
+			}
+			nextCursor = res.NextCursor
+		}
+	}
+	return nil
+}
+
+// importAsset creates the local record and the metadata of a Cloudinary asset.
+func (s *Service) importAsset(ctx context.Context, opts *assetimportmodel.Options, remote *api.BriefAssetResult) *assetimportmodel.Result {
+	result := &assetimportmodel.Result{ExternalID: remote.PublicID, Outcome: assetimportmodel.OutcomeCreated}
+
+	newAsset, metadata, err := importedAsset(remote)
+	if err != nil {
+		result.Outcome = assetimportmodel.OutcomeFailed
+		result.Err = err
+		return result
+	}
+	result.AssetID = newAsset.ID
+	result.Owners = len(metadata.Owners)
+	if opts.DryRun {
+		return result
+	}
+	if creatorID, err := uuid.Parse(opts.AdminID); err == nil {
+		newAsset.CreatedBy = &creatorID
+		newAsset.CreatedByName = &opts.AdminName
+	}
+
+	err = crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		if err := s.repo.WithTx(tx).Create(ctx, newAsset); err != nil {
+			return fmt.Errorf("failed to create cloudinary asset record: %w", err)
+		}
+		if err := s.createMetadata(ctx, metadata); err != nil {
+			return fmt.Errorf("failed to create asset metadata: %w", err)
+		}
+		return s.recordAudit(ctx, tx, auditmodel.ActionImport, newAsset.ID, nil, newAsset,
+			audit.WithAdmin(opts.AdminID, opts.AdminName),
+		)
+	})
+	if err != nil {
+		s.log(ctx).Error("failed to import cloudinary asset", zap.Error(err), zap.String("public_id", remote.PublicID))
+		result.Outcome = assetimportmodel.OutcomeFailed
+		result.Err = err
+		return result
+	}
+
+	s.log(ctx).Info("imported cloudinary asset", logging.AssetID(newAsset.ID), zap.String("public_id", remote.PublicID), zap.Int("owners", len(metadata.Owners)))
+	s.publishEvent(ctx, events.TypeAssetCreated, newAsset.ID, withExternalID(&remote.PublicID), withOwners(metadata.Owners), events.WithData("imported", "true"))
+	return result
+}
+
+// importedAsset builds the local record and the metadata of a Cloudinary asset.
+func importedAsset(remote *api.BriefAssetResult) (*assetmodel.Asset, *metadatamodel.AssetMetadata, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate new asset id: %w", err)
+	}
+	asset := &assetmodel.Asset{
+		ID:                 id,
+		Status:             assetmodel.StatusActive,
+		CloudinaryAssetID:  remote.AssetID,
+		CloudinaryPublicID: remote.PublicID,
+		URL:                remote.URL,
+		SecureURL:          remote.SecureURL,
+		ResourceType:       remote.AssetType,
+		Format:             remote.Format,
+		Tags:               tags.Normalize(remote.Tags),
+		AssetFolder:        remote.AssetFolder,
+		DisplayName:        remote.DisplayName,
+	}
+	if remote.Width > 0 {
+		asset.Width = &remote.Width
+	}
+	if remote.Height > 0 {
+		asset.Height = &remote.Height
+	}
+	if remote.Bytes > 0 {
+		bytes := int64(remote.Bytes)
+		asset.Bytes = &bytes
+	}
+
+	metadata := &metadatamodel.AssetMetadata{
+		Key:    id.String(),
+		Title:  contextValue(remote.Context, "caption"),
+		Owners: []*metadatamodel.Owner{},
+	}
+	for _, o := range assetimportmodel.ParseOwners(contextValue(remote.Context, "external_id"), assetmodel.OwnerTypes.IsRegistered) {
+		metadata.Owners = append(metadata.Owners, &metadatamodel.Owner{OwnerID: o.OwnerID, OwnerType: o.OwnerType})
+	}
+	return asset, metadata, nil
+}
+
+// contextValue returns a contextual metadata value of a Cloudinary asset. The Admin API nests the
+// values under "custom".
+func contextValue(assetContext api.Metadata, key string) string {
+	values := map[string]any(assetContext)
+	if custom, ok := values["custom"].(map[string]any); ok {
+		values = custom
+	}
+	value, _ := values[key].(string)
+	return value
+}
@@ -0,0 +1,259 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
+	"github.com/mikhail5545/media-service-go/internal/database/types"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/metadata"
+	cldtypes "github.com/mikhail5545/media-service-go/internal/models/cloudinary/types"
+	outboxmodel "github.com/mikhail5545/media-service-go/internal/models/outbox"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ApproveModeration approves an image pending moderation, both in Cloudinary and locally.
+func (s *Service) ApproveModeration(ctx context.Context, req *assetmodel.ChangeStateRequest) error {
+	if err := req.Validate(); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	defer s.invalidateByID(ctx, req.ID)
+
+	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		asset, err := s.getPendingModeration(ctx, txRepo, req.ID)
+		if err != nil {
+			return err
+		}
+		approved := assetmodel.ModerationApproved
+		if err := s.setModerationStatus(ctx, txRepo, asset, approved, asset.ModerationKind); err != nil {
+			return err
+		}
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionApproveModeration, asset.ID,
+			moderationSnapshot(asset.ModerationStatus), moderationSnapshot(&approved),
+			audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note),
+		); err != nil {
+			return err
+		}
+		return s.pushModerationStatus(ctx, asset, assetmodel.ModerationApproved)
+	})
+}
+
+// RejectModeration rejects an image pending moderation, both in Cloudinary and locally.
+// The rejected image is marked as broken and, if it has owners, they are notified about the broken
+// image via transactional outbox.
+func (s *Service) RejectModeration(ctx context.Context, req *assetmodel.ChangeStateRequest) error {
+	if err := req.Validate(); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	defer s.invalidateByID(ctx, req.ID)
+
+	var metadataToClear *metadatamodel.AssetMetadata
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		asset, err := s.getPendingModeration(ctx, txRepo, req.ID)
+		if err != nil {
+			return err
+		}
+		adminID, err := parsing.StrToUUID(req.AdminID)
+		if err != nil {
+			return err
+		}
+		metadata, err := s.rejectImage(ctx, tx, asset, asset.ModerationKind, &types.AuditTrailOptions{
+			AdminID:   adminID,
+			AdminName: req.AdminName,
+			Note:      req.Note,
+		}, auditmodel.ActionRejectModeration, audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note))
+		if err != nil {
+			return err
+		}
+		metadataToClear = metadata
+		return s.pushModerationStatus(ctx, asset, assetmodel.ModerationRejected)
+	})
+	if err != nil {
+		return err
+	}
+	if metadataToClear != nil {
+		return s.clearOwners(ctx, metadataToClear)
+	}
+	return nil
+}
+
+// handleModerationWebhook processes incoming webhook notifications from Cloudinary about moderation results.
+// The moderation status of the asset is updated, rejected images are additionally marked as broken and
+// their owners are notified. Results of the manual moderation made through the service are already applied.
+func (s *Service) handleModerationWebhook(ctx context.Context, payload []byte) error {
+	var data cldtypes.CloudinaryModerationWebhook
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	if data.PublicID == "" {
+		return serviceerrors.NewInvalidArgumentError("public ID is empty")
+	}
+	status := assetmodel.ModerationStatus(data.ModerationStatus)
+	eventOpt := audit.WithEvent(data.NotificationType, data.RequestID)
+
+	asset, err := s.getByPublicID(ctx, s.repo, data.PublicID)
+	if err != nil {
+		return ignoreUnknownAsset(err)
+	}
+	if asset.ModerationStatus != nil && *asset.ModerationStatus == status {
+		return nil
+	}
+	defer s.invalidate(ctx, asset.ID)
+
+	var metadataToClear *metadatamodel.AssetMetadata
+	err = crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		if status == assetmodel.ModerationRejected {
+			note := fmt.Sprintf("Rejected by %s moderation", data.ModerationKind)
+			metadata, err := s.rejectImage(ctx, tx, asset, &data.ModerationKind, &types.AuditTrailOptions{
+				AdminName: "system",
+				Note:      note,
+				EventID:   data.RequestID,
+			}, auditmodel.ActionStateChanged, eventOpt, audit.WithNote(note))
+			metadataToClear = metadata
+			return err
+		}
+		if err := s.setModerationStatus(ctx, txRepo, asset, status, &data.ModerationKind); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, tx, auditmodel.ActionStateChanged, asset.ID,
+			moderationSnapshot(asset.ModerationStatus), moderationSnapshot(&status), eventOpt,
+		)
+	})
+	if err != nil {
+		return err
+	}
+	if metadataToClear != nil {
+		return s.clearOwners(ctx, metadataToClear)
+	}
+	return nil
+}
+
+// getPendingModeration retrieves the asset for a manual moderation decision.
+func (s *Service) getPendingModeration(ctx context.Context, txRepo *assetrepo.Repository, id string) (*assetmodel.Asset, error) {
+	asset, err := s.getInTx(ctx, txRepo, id, []string{
+		"id", "status", "cloudinary_public_id", "resource_type", "moderation_status", "moderation_kind",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if asset.ModerationStatus == nil || *asset.ModerationStatus != assetmodel.ModerationPending {
+		return nil, serviceerrors.NewConflictError("asset is not pending moderation")
+	}
+	return asset, nil
+}
+
+// setModerationStatus updates the moderation status of the asset.
+func (s *Service) setModerationStatus(ctx context.Context, txRepo *assetrepo.Repository, asset *assetmodel.Asset, status assetmodel.ModerationStatus, kind *string) error {
+	if _, err := txRepo.Update(ctx, map[string]any{
+		"moderation_status": status,
+		"moderation_kind":   kind,
+	}, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}); err != nil {
+		s.log(ctx).Error("failed to update asset moderation status", zap.Error(err), logging.AssetID(asset.ID))
+		return fmt.Errorf("failed to update asset moderation status: %w", err)
+	}
+	return nil
+}
+
+// rejectImage sets the rejected moderation status of the asset and marks it as broken within tx, unless
+// the asset is already broken or archived. If the asset has owners, they are notified via transactional
+// outbox and the returned metadata must have its owners cleared after the transaction commits.
+func (s *Service) rejectImage(
+	ctx context.Context,
+	tx *gorm.DB,
+	asset *assetmodel.Asset,
+	kind *string,
+	trail *types.AuditTrailOptions,
+	action auditmodel.Action,
+	opts ...audit.EntryOption,
+) (*metadatamodel.AssetMetadata, error) {
+	txRepo := s.repo.WithTx(tx)
+	rejected := assetmodel.ModerationRejected
+
+	if err := s.setModerationStatus(ctx, txRepo, asset, rejected, kind); err != nil {
+		return nil, err
+	}
+	before, after := moderationSnapshot(asset.ModerationStatus), moderationSnapshot(&rejected)
+	if asset.Status == assetmodel.StatusBroken || asset.Status == assetmodel.StatusArchived {
+		return nil, s.recordAudit(ctx, tx, action, asset.ID, before, after, opts...)
+	}
+
+	if _, err := txRepo.MarkAsBroken(ctx, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}, trail); err != nil {
+		s.log(ctx).Error("failed to mark rejected asset as broken", zap.Error(err), logging.AssetID(asset.ID))
+		return nil, fmt.Errorf("failed to mark rejected asset as broken: %w", err)
+	}
+	before["status"], after["status"] = asset.Status, assetmodel.StatusBroken
+	if err := s.recordAudit(ctx, tx, action, asset.ID, before, after, opts...); err != nil {
+		return nil, err
+	}
+
+	metadata, err := s.getAssetMetadata(ctx, asset.ID)
+	if err != nil {
+		return nil, ignoreUnknownAsset(err)
+	}
+	if len(metadata.Owners) == 0 {
+		return nil, nil
+	}
+	if err := s.snapshotOwners(ctx, txRepo, asset.ID, metadata.Owners, assetmodel.SnapshotReasonRejected); err != nil {
+		return nil, err
+	}
+	if err := s.enqueueEvent(ctx, s.outboxRepo.WithTx(tx), outboxmodel.EventImageBroken, &outboxmodel.BrokenPayload{
+		AssetID:   asset.ID,
+		AdminID:   trail.AdminID,
+		AdminName: trail.AdminName,
+		Reason:    trail.Note,
+	}); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// pushModerationStatus applies the manual moderation decision to the Cloudinary asset.
+func (s *Service) pushModerationStatus(ctx context.Context, asset *assetmodel.Asset, status assetmodel.ModerationStatus) error {
+	if err := s.apiClient.UpdateModeration(ctx, asset.CloudinaryPublicID, asset.ResourceType, string(status)); err != nil {
+		s.log(ctx).Error("failed to update cloudinary moderation status", zap.Error(err), logging.AssetID(asset.ID))
+		return serviceerrors.NewUnavailableError(err)
+	}
+	return nil
+}
+
+// moderationStatus returns the initial moderation status of uploaded images, nil if images are not moderated.
+func (s *Service) moderationStatus() (*assetmodel.ModerationStatus, *string) {
+	if s.moderation == "" {
+		return nil, nil
+	}
+	status, kind := assetmodel.ModerationPending, s.moderation
+	return &status, &kind
+}
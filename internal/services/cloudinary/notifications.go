@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	cldtypes "github.com/mikhail5545/media-service-go/internal/models/cloudinary/types"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// handleEagerWebhook processes incoming webhook notifications from Cloudinary about completed
+// asynchronous eager transformations. The derived versions are recorded in the asset history and
+// announced to the subscribers.
+func (s *Service) handleEagerWebhook(ctx context.Context, payload []byte) error {
+	var data cldtypes.CloudinaryEagerWebhook
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	if data.PublicID == "" {
+		return serviceerrors.NewInvalidArgumentError("public ID is empty")
+	}
+
+	asset, err := s.getByPublicID(ctx, s.repo, data.PublicID)
+	if err != nil {
+		return ignoreUnknownAsset(err)
+	}
+	transformations := make([]string, 0, len(data.Eager))
+	for i := range data.Eager {
+		transformations = append(transformations, data.Eager[i].Transformation)
+	}
+	if err := s.recordAudit(ctx, s.repo.DB(), auditmodel.ActionStateChanged, asset.ID,
+		nil, map[string]any{"eager": transformations},
+		audit.WithEvent(data.NotificationType, data.BatchID),
+	); err != nil {
+		return err
+	}
+	s.invalidate(ctx, asset.ID)
+	s.publishEvent(ctx, events.TypeAssetUpdated, asset.ID,
+		withExternalID(&asset.CloudinaryPublicID), events.WithData("eager", strings.Join(transformations, "|")),
+	)
+	return nil
+}
+
+// handleTagsChangedWebhook processes incoming webhook notifications from Cloudinary about tags added or
+// removed outside the service, e.g. in the Cloudinary console.
+func (s *Service) handleTagsChangedWebhook(ctx context.Context, payload []byte) error {
+	var data cldtypes.CloudinaryTagsChangedWebhook
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+
+	changes := make(map[string]*cldtypes.TagsChange, len(data.Resources))
+	for i := range data.Resources {
+		changes[data.Resources[i].PublicID] = &data.Resources[i]
+	}
+	return syncResources(ctx, s, data.NotificationType, data.NotificationContext.TriggeredBy.ID, changes,
+		func(asset *assetmodel.Asset, change *cldtypes.TagsChange) (map[string]any, map[string]any) {
+			result := slices.DeleteFunc(slices.Clone(asset.Tags), func(tag string) bool {
+				return slices.Contains(change.Removed, tag)
+			})
+			for _, tag := range change.Added {
+				if !slices.Contains(result, tag) {
+					result = append(result, tag)
+				}
+			}
+			// Tags changed through the service are already applied locally
+			if slices.Equal(result, asset.Tags) {
+				return nil, nil
+			}
+			return tagsSnapshot(asset.Tags), map[string]any{"tags": result}
+		},
+	)
+}
+
+// handleDisplayNameChangedWebhook processes incoming webhook notifications from Cloudinary about asset
+// display name changes.
+func (s *Service) handleDisplayNameChangedWebhook(ctx context.Context, payload []byte) error {
+	var data cldtypes.CloudinaryDisplayNameChangedWebhook
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+
+	changes := make(map[string]*cldtypes.DisplayNameChange, len(data.Resources))
+	for i := range data.Resources {
+		changes[data.Resources[i].PublicID] = &data.Resources[i]
+	}
+	return syncResources(ctx, s, data.NotificationType, data.NotificationContext.TriggeredBy.ID, changes,
+		func(asset *assetmodel.Asset, change *cldtypes.DisplayNameChange) (map[string]any, map[string]any) {
+			if asset.DisplayName == change.NewDisplayName {
+				return nil, nil
+			}
+			return map[string]any{"display_name": asset.DisplayName}, map[string]any{"display_name": change.NewDisplayName}
+		},
+	)
+}
+
+// syncResources applies the changes reported by a multi-resource webhook to the local assets, keyed by
+// Cloudinary public ID. build returns the previous values and the updates of an asset, no updates means
+// that the asset is already in sync. Assets unknown to the service and archived assets are skipped.
+func syncResources[T any](
+	ctx context.Context,
+	s *Service,
+	eventType, eventID string,
+	changes map[string]*T,
+	build func(asset *assetmodel.Asset, change *T) (map[string]any, map[string]any),
+) error {
+	var changed []*assetmodel.Asset
+	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		pubIDs := make([]string, 0, len(changes))
+		for pubID := range changes {
+			pubIDs = append(pubIDs, pubID)
+		}
+		assets, err := s.listByPublicIDs(ctx, txRepo, pubIDs, assetrepo.ScopeUploadURLGenerated, assetrepo.ScopeActive, assetrepo.ScopeBroken)
+		if err != nil {
+			return err
+		}
+		for _, asset := range assets {
+			before, updates := build(asset, changes[asset.CloudinaryPublicID])
+			if len(updates) == 0 {
+				continue
+			}
+			if _, err := txRepo.Update(ctx, updates, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}); err != nil {
+				s.log(ctx).Error("failed to update asset from webhook", zap.Error(err), logging.AssetID(asset.ID), zap.String("notification_type", eventType))
+				return fmt.Errorf("failed to update asset from webhook: %w", err)
+			}
+			if err := s.recordAudit(ctx, tx, auditmodel.ActionStateChanged, asset.ID, before, updates,
+				audit.WithEvent(eventType, eventID),
+			); err != nil {
+				return err
+			}
+			changed = append(changed, asset)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, asset := range changed {
+		s.invalidate(ctx, asset.ID)
+		s.publishEvent(ctx, events.TypeAssetUpdated, asset.ID, withExternalID(&asset.CloudinaryPublicID))
+	}
+	return nil
+}
+
+// ignoreUnknownAsset drops the not found error of an asset (or its metadata) unknown to the service, as
+// Cloudinary notifies about every asset in the product environment.
+func ignoreUnknownAsset(err error) error {
+	if errors.Is(err, serviceerrors.ErrNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	"github.com/mikhail5545/media-service-go/internal/models/publishing"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SchedulePublishing sets the publication schedule of an asset and returns it. The image is only delivered
+// to end users between the publish and the unpublish time. The asset can be scheduled before its upload
+// is finished, archived and broken assets cannot be scheduled.
+func (s *Service) SchedulePublishing(ctx context.Context, req *assetmodel.SchedulePublishingRequest) (*publishing.Schedule, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	return s.changePublishing(ctx, req.ID, auditmodel.ActionSchedulePublishing,
+		func(asset *assetmodel.Asset, now time.Time) *publishing.Schedule {
+			return &publishing.Schedule{
+				State:       publishing.StateAt(asset.PublishingStateAt(now), req.PublishAt, req.UnpublishAt, now),
+				PublishAt:   req.PublishAt,
+				UnpublishAt: req.UnpublishAt,
+			}
+		},
+		audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note))
+}
+
+// CancelPublishing removes the publication schedule of an asset and returns the resulting schedule.
+// An asset waiting for its publish time stays unpublished, a published asset stays published.
+func (s *Service) CancelPublishing(ctx context.Context, req *assetmodel.CancelPublishingRequest) (*publishing.Schedule, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	return s.changePublishing(ctx, req.ID, auditmodel.ActionCancelPublishing,
+		func(asset *assetmodel.Asset, now time.Time) *publishing.Schedule {
+			return &publishing.Schedule{State: publishing.StateAt(asset.PublishingStateAt(now), nil, nil, now)}
+		},
+		audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note))
+}
+
+// changePublishing stores the schedule computed from the current one and publishes an event if the
+// asset became deliverable or stopped being deliverable.
+func (s *Service) changePublishing(
+	ctx context.Context,
+	id string,
+	action auditmodel.Action,
+	schedule func(asset *assetmodel.Asset, now time.Time) *publishing.Schedule,
+	opts ...audit.EntryOption,
+) (*publishing.Schedule, error) {
+	assetID, err := parsing.StrToUUID(id)
+	if err != nil {
+		return nil, err
+	}
+	defer s.invalidate(ctx, assetID)
+
+	now := time.Now()
+	var before, after *publishing.Schedule
+	err = s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		asset, err := s.getInTx(ctx, txRepo, id, []string{"id", "status", "publishing_state", "publish_at", "unpublish_at"})
+		if err != nil {
+			return err
+		}
+		if asset.Status == assetmodel.StatusArchived || asset.Status == assetmodel.StatusBroken {
+			return serviceerrors.NewConflictError("cannot change publishing of archived or broken asset")
+		}
+
+		before = asset.Schedule()
+		before.State = asset.PublishingStateAt(now)
+		after = schedule(asset, now)
+		if _, err := txRepo.SavePublishing(ctx, asset.ID, after); err != nil {
+			s.log(ctx).Error("failed to save asset publishing", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to save asset publishing: %w", err)
+		}
+		return s.recordAudit(ctx, tx, action, asset.ID, before, after, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishPublishingEvent(ctx, assetID, before.State, after.State)
+	return after, nil
+}
+
+// TransitionPublishing publishes and unpublishes the assets whose publish or unpublish time passed and
+// returns the number of transitioned assets. It is called by the publishing worker.
+func (s *Service) TransitionPublishing(ctx context.Context, opts *publishing.TransitionOptions) (int, error) {
+	assets, err := s.repo.ListDuePublishing(ctx, opts.Now, opts.BatchSize)
+	if err != nil {
+		s.log(ctx).Error("failed to list assets due for publishing", zap.Error(err))
+		return 0, fmt.Errorf("failed to list assets due for publishing: %w", err)
+	}
+
+	transitioned := 0
+	for _, asset := range assets {
+		to := asset.PublishingStateAt(opts.Now)
+		if to == asset.PublishingState {
+			continue
+		}
+		ok, err := s.transitionPublishing(ctx, asset, to)
+		if err != nil {
+			s.log(ctx).Warn("failed to transition asset publishing", zap.Error(err), logging.AssetID(asset.ID))
+			continue
+		}
+		if ok {
+			transitioned++
+		}
+	}
+	return transitioned, nil
+}
+
+// transitionPublishing changes the publishing state of the asset, it reports false if the state was
+// changed concurrently.
+func (s *Service) transitionPublishing(ctx context.Context, asset *assetmodel.Asset, to publishing.State) (bool, error) {
+	defer s.invalidate(ctx, asset.ID)
+
+	action := auditmodel.ActionUnpublish
+	if to == publishing.StatePublished {
+		action = auditmodel.ActionPublish
+	}
+	var changed bool
+	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		rows, err := s.repo.WithTx(tx).TransitionPublishing(ctx, asset.ID, asset.PublishingState, to)
+		if err != nil {
+			return fmt.Errorf("failed to transition asset publishing: %w", err)
+		}
+		if rows == 0 {
+			return nil
+		}
+		changed = true
+		return s.recordAudit(ctx, tx, action, asset.ID, publishingSnapshot(asset.PublishingState), publishingSnapshot(to))
+	})
+	if err != nil || !changed {
+		return false, err
+	}
+	s.publishPublishingEvent(ctx, asset.ID, asset.PublishingState, to)
+	return true, nil
+}
+
+// publishPublishingEvent publishes the event of an asset whose publishing state changed from before to after.
+func (s *Service) publishPublishingEvent(ctx context.Context, assetID uuid.UUID, before, after publishing.State) {
+	eventType := events.TypeAssetUpdated
+	switch {
+	case before != publishing.StatePublished && after == publishing.StatePublished:
+		eventType = events.TypeAssetPublished
+	case before == publishing.StatePublished && after != publishing.StatePublished:
+		eventType = events.TypeAssetUnpublished
+	}
+	s.publishEvent(ctx, eventType, assetID, events.WithData("publishing_state", string(after)))
+}
+
+// checkPublished returns a conflict error unless the asset is published at the moment.
+func checkPublished(asset *assetmodel.Asset) error {
+	if asset.PublishingStateAt(time.Now()) != publishing.StatePublished {
+		return serviceerrors.NewConflictError("image is not published")
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/metadata"
+	seedmodel "github.com/mikhail5545/media-service-go/internal/models/seed"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// fixtureCloudName is the cloud of the delivery URLs of the fixtures.
+const fixtureCloudName = "fixtures"
+
+// SeedAssets creates the local records and the metadata of the fixtures which were not loaded yet.
+// Fixtures are neither audited nor published, and nothing is uploaded to Cloudinary.
+func (s *Service) SeedAssets(ctx context.Context, fixtures []*seedmodel.Fixture, report func(*seedmodel.Result)) error {
+	ids := make(uuid.UUIDs, 0, len(fixtures))
+	for _, f := range fixtures {
+		ids = append(ids, f.ID)
+	}
+	existing, err := s.repo.ListExistingIDs(ctx, ids)
+	if err != nil {
+		s.log(ctx).Error("failed to list existing cloudinary fixtures", zap.Error(err))
+		return fmt.Errorf("failed to list existing cloudinary fixtures: %w", err)
+	}
+	loaded := make(map[uuid.UUID]bool, len(existing))
+	for _, id := range existing {
+		loaded[id] = true
+	}
+
+	for _, f := range fixtures {
+		if loaded[f.ID] {
+			report(&seedmodel.Result{AssetID: f.ID, Outcome: seedmodel.OutcomeSkipped})
+			continue
+		}
+		report(s.seedAsset(ctx, f))
+	}
+	return nil
+}
+
+func (s *Service) seedAsset(ctx context.Context, f *seedmodel.Fixture) *seedmodel.Result {
+	result := &seedmodel.Result{AssetID: f.ID, Outcome: seedmodel.OutcomeCreated}
+	asset, metadata := fixtureAsset(f)
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		if err := s.repo.WithTx(tx).Create(ctx, asset); err != nil {
+			return fmt.Errorf("failed to create cloudinary asset record: %w", err)
+		}
+		if err := s.createMetadata(ctx, metadata); err != nil {
+			return fmt.Errorf("failed to create asset metadata: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.log(ctx).Error("failed to seed cloudinary asset", zap.Error(err), logging.AssetID(f.ID))
+		result.Outcome = seedmodel.OutcomeFailed
+		result.Err = err
+	}
+	return result
+}
+
+// WipeAssets permanently deletes the local records and the metadata of the fixtures with the IDs,
+// whatever their status. It returns the number of deleted records.
+func (s *Service) WipeAssets(ctx context.Context, ids uuid.UUIDs) (int64, error) {
+	existing, err := s.repo.ListExistingIDs(ctx, ids)
+	if err != nil {
+		s.log(ctx).Error("failed to list existing cloudinary fixtures", zap.Error(err))
+		return 0, fmt.Errorf("failed to list existing cloudinary fixtures: %w", err)
+	}
+	if len(existing) == 0 {
+		return 0, nil
+	}
+	deleted, err := s.repo.DeleteFixtures(ctx, existing)
+	if err != nil {
+		s.log(ctx).Error("failed to delete cloudinary fixtures", zap.Error(err))
+		return 0, fmt.Errorf("failed to delete cloudinary fixtures: %w", err)
+	}
+	s.invalidate(ctx, existing...)
+	for _, id := range existing {
+		if err := s.deleteAssetMetadata(ctx, id); err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// fixtureAsset builds the local record and the metadata of a fixture. Provider IDs and URLs are
+// derived from the index, they do not exist in Cloudinary.
+func fixtureAsset(f *seedmodel.Fixture) (*assetmodel.Asset, *metadatamodel.AssetMetadata) {
+	publicID := fmt.Sprintf("fixtures/image-%03d", f.Index)
+	url := fmt.Sprintf("res.cloudinary.com/%s/image/upload/v1/%s.jpg", fixtureCloudName, publicID)
+	width, height := 1200, 800
+	if f.Index%2 == 1 {
+		width, height = 800, 800
+	}
+	bytes := int64(50_000 + 1_000*f.Index)
+	asset := &assetmodel.Asset{
+		ID:                 f.ID,
+		Status:             assetmodel.StatusActive,
+		CloudinaryAssetID:  fmt.Sprintf("fixture-%03d", f.Index),
+		CloudinaryPublicID: publicID,
+		URL:                "http://" + url,
+		SecureURL:          "https://" + url,
+		ResourceType:       "image",
+		Format:             "jpg",
+		Width:              &width,
+		Height:             &height,
+		Bytes:              &bytes,
+		Tags:               f.Tags,
+		AssetFolder:        "fixtures",
+		DisplayName:        f.Title,
+	}
+	if creatorID, err := uuid.Parse(f.CreatorID); err == nil {
+		asset.CreatedBy = &creatorID
+	}
+	switch f.State {
+	case seedmodel.StateErrored:
+		rejected, kind := assetmodel.ModerationRejected, "manual"
+		asset.Status = assetmodel.StatusBroken
+		asset.ModerationStatus = &rejected
+		asset.ModerationKind = &kind
+	case seedmodel.StateArchived:
+		asset.Status = assetmodel.StatusArchived
+		asset.DeletedAt = gorm.DeletedAt{Time: time.Now().UTC(), Valid: true}
+	}
+
+	metadata := &metadatamodel.AssetMetadata{
+		Key:       f.ID.String(),
+		Title:     f.Title,
+		CreatorID: f.CreatorID,
+		Owners:    make([]*metadatamodel.Owner, 0, len(f.Owners)),
+	}
+	for _, o := range f.Owners {
+		metadata.Owners = append(metadata.Owners, &metadatamodel.Owner{OwnerID: o.OwnerID, OwnerType: o.OwnerType})
+	}
+	return asset, metadata
+}
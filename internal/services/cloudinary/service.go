@@ -21,21 +21,43 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	apiclient "github.com/mikhail5545/media-service-go/internal/apiclients/cloudinary"
-	metadatarepo "github.com/mikhail5545/media-service-go/internal/database/mongo/cloudinary/metadata"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	"github.com/mikhail5545/media-service-go/internal/cache"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	auditrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/audit"
 	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
+	collectionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/collection"
+	outboxrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/outbox"
+	"github.com/mikhail5545/media-service-go/internal/database/postgres/pagination"
+	versionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/versions"
 	"github.com/mikhail5545/media-service-go/internal/database/types"
+	"github.com/mikhail5545/media-service-go/internal/entitlement"
+	"github.com/mikhail5545/media-service-go/internal/environment"
 	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
 	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
 	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/metadata"
+	outboxmodel "github.com/mikhail5545/media-service-go/internal/models/outbox"
+	"github.com/mikhail5545/media-service-go/internal/models/publishing"
+	retentionmodel "github.com/mikhail5545/media-service-go/internal/models/retention"
+	versionmodel "github.com/mikhail5545/media-service-go/internal/models/versions"
+	"github.com/mikhail5545/media-service-go/internal/ownertypes"
+	"github.com/mikhail5545/media-service-go/internal/quota"
+	sagaservice "github.com/mikhail5545/media-service-go/internal/services/saga"
+	watermarkservice "github.com/mikhail5545/media-service-go/internal/services/watermark"
 	"github.com/mikhail5545/media-service-go/internal/util/parsing"
-	"github.com/mikhail5545/product-service-client/client"
-	"go.mongodb.org/mongo-driver/v2/mongo"
+	imagepbv1 "github.com/mikhail5545/product-service-client/pb/product_service/image/v1"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -45,6 +67,9 @@ type AssetService interface {
 	Get(ctx context.Context, filter *assetmodel.GetFilter) (*assetmodel.Details, error)
 	// GetWithArchived retrieves an asset that can be either active or archived based on the provided filter.
 	GetWithArchived(ctx context.Context, filter *assetmodel.GetFilter) (*assetmodel.Details, error)
+	// ImageDelivery returns the delivery URL of an active image to the authenticated end user.
+	// The user must be allowed to access the image by one of its owners.
+	ImageDelivery(ctx context.Context, req *assetmodel.ImageDeliveryRequest) (*assetmodel.ImageDelivery, error)
 	// GetWithBroken retrieves an asset that can be active, archived, or broken based on the provided filter.
 	GetWithBroken(ctx context.Context, filter *assetmodel.GetFilter) (*assetmodel.Details, error)
 	// List retrieves a list of active assets based on the provided request.
@@ -53,6 +78,20 @@ type AssetService interface {
 	ListArchived(ctx context.Context, req *assetmodel.ListRequest) ([]*assetmodel.Details, string, error)
 	// ListBroken retrieves a list of broken assets based on the provided request.
 	ListBroken(ctx context.Context, req *assetmodel.ListRequest) ([]*assetmodel.Details, string, error)
+	// ListTotal returns the total of the assets listed by List, computed with the count strategy of the endpoint.
+	ListTotal(ctx context.Context, req *assetmodel.ListRequest) (*pagination.Total, error)
+	// ListArchivedTotal returns the total of the assets listed by ListArchived.
+	ListArchivedTotal(ctx context.Context, req *assetmodel.ListRequest) (*pagination.Total, error)
+	// ListBrokenTotal returns the total of the assets listed by ListBroken.
+	ListBrokenTotal(ctx context.Context, req *assetmodel.ListRequest) (*pagination.Total, error)
+	// ValidateAssets reports whether the assets with the IDs exist and are healthy.
+	ValidateAssets(ctx context.Context, req *assetmodel.ValidateAssetsRequest) ([]*assetmodel.AssetValidity, error)
+	// GetByOwner retrieves the asset associated with the owner, the one with the lowest ID if there are several.
+	GetByOwner(ctx context.Context, req *assetmodel.GetByOwnerRequest) (*assetmodel.Details, error)
+	// ListByOwner retrieves a page of assets associated with a single owner, e.g. all images of a product.
+	ListByOwner(ctx context.Context, req *assetmodel.ListByOwnerRequest) ([]*assetmodel.Details, string, error)
+	// ListOwnerTypes returns the owner types assets can be associated with.
+	ListOwnerTypes(ctx context.Context) []string
 	// CreateSignedUploadURL generates a signed URL for uploading an asset to Cloudinary.
 	// It returns the signed parameters required for the upload, end client must build signed upload
 	// URL using generated parameters.
@@ -65,52 +104,222 @@ type AssetService interface {
 	//
 	// [gRPC client]: https://github.com/mikhail5545/product-service-client
 	MarkAsBroken(ctx context.Context, req *assetmodel.ChangeStateRequest) error
+	// Search searches the assets by the title, the labels and the OCR text of the images.
+	Search(ctx context.Context, req *assetmodel.SearchRequest) ([]*assetmodel.Details, string, error)
+	// ApproveModeration approves an image pending moderation.
+	ApproveModeration(ctx context.Context, req *assetmodel.ChangeStateRequest) error
+	// RejectModeration rejects an image pending moderation and marks it as broken.
+	RejectModeration(ctx context.Context, req *assetmodel.ChangeStateRequest) error
+	// AddTags adds tags to an asset and returns the resulting tags of the asset.
+	// The tags are mirrored to the Cloudinary asset.
+	AddTags(ctx context.Context, req *assetmodel.ManageTagsRequest) ([]string, error)
+	// RemoveTags removes tags from an asset and returns the remaining tags of the asset.
+	// The tags are mirrored to the Cloudinary asset.
+	RemoveTags(ctx context.Context, req *assetmodel.ManageTagsRequest) ([]string, error)
+	// ListByTag retrieves a list of active assets having the tag.
+	ListByTag(ctx context.Context, req *assetmodel.ListByTagRequest) ([]*assetmodel.Details, string, error)
+	// ListByCollection retrieves a page of assets which belong to a collection.
+	ListByCollection(ctx context.Context, req *assetmodel.ListByCollectionRequest) ([]*assetmodel.Details, string, error)
+	// GetHistory retrieves the timeline of an asset, oldest first. It combines the state transitions
+	// caused by webhooks, admin actions and ownership changes.
+	GetHistory(ctx context.Context, req *auditmodel.GetHistoryRequest) ([]*auditmodel.HistoryEvent, error)
+	// UpdateMetadata changes the title or the creator of an asset.
+	UpdateMetadata(ctx context.Context, req *assetmodel.UpdateMetadataRequest) (*metadatamodel.AssetMetadata, error)
 	// AddOwner associates an external owner with an asset.
 	// It updates the asset metadata in MongoDB to include the new owner.
 	// Broken or archived assets cannot have owners added.
 	AddOwner(ctx context.Context, req *assetmodel.ManageOwnerRequest) error
+	// AssociateOwner associates an owner with an asset and returns the assets the owner was moved from.
+	// With ReplaceExisting the owner is removed from its other assets and added to the asset by a saga.
+	AssociateOwner(ctx context.Context, req *assetmodel.ManageOwnerRequest) (*assetmodel.AssociateResult, error)
+	// ListVersions retrieves the version chain of an owner, oldest first.
+	ListVersions(ctx context.Context, req *assetmodel.ListVersionsRequest) ([]*versionmodel.Version, error)
+	// RollbackVersion moves the owner back to the asset of an earlier version and records the rollback
+	// as a new version.
+	RollbackVersion(ctx context.Context, req *assetmodel.RollbackVersionRequest) (*assetmodel.AssociateResult, error)
 	// RemoveOwner disassociates an external owner from an asset.
 	// It updates the asset metadata in MongoDB to remove the specified owner.
 	RemoveOwner(ctx context.Context, req *assetmodel.ManageOwnerRequest) error
+	// UpdateOwners replaces the owners of an asset and returns the updated metadata.
+	// The changes are applied by a saga and undone if any of them fails. The update is rejected
+	// if the metadata was changed since the requested revision.
+	UpdateOwners(ctx context.Context, req *assetmodel.UpdateOwnersRequest) (*metadatamodel.AssetMetadata, error)
 	// Restore restores an archived asset back to active status.
 	// Only archived assets can be restored.
 	Restore(ctx context.Context, req *assetmodel.ChangeStateRequest) error
+	// RestoreWithOwners restores an archived asset back to active status and re-associates the owners
+	// the asset had when they were cleared. Only archived assets can be restored.
+	RestoreWithOwners(ctx context.Context, req *assetmodel.ChangeStateRequest) (*metadatamodel.AssetMetadata, error)
+	// RestoreFromBackup restores the file of an archived image destroyed in Cloudinary from the Cloudinary
+	// backup. The asset stays archived until it is restored.
+	RestoreFromBackup(ctx context.Context, req *assetmodel.RestoreFromBackupRequest) (*assetmodel.Asset, error)
 	// Delete permanently deletes an archived asset along with its metadata.
-	// It also deletes the asset from Cloudinary.
+	// The asset is marked as pending deletion and deleted from Cloudinary and the databases asynchronously.
 	// Note that only currently soft-deleted (archived) assets can be permanently deleted.
 	Delete(ctx context.Context, req *assetmodel.ChangeStateRequest) error
-	// HandleWebhook processes incoming webhook notifications from Cloudinary.
-	// It validates the signature and routes the webhook to the appropriate handler based on its type.
-	HandleWebhook(ctx context.Context, payload []byte, timestamp, signature string) error
+	// ListStuckDeletions reports the assets that have been pending deletion for longer than requested.
+	ListStuckDeletions(ctx context.Context, req *assetmodel.ListStuckDeletionsRequest) ([]*assetmodel.Asset, error)
+	// FindDuplicates lists the groups of active assets sharing a content hash, largest groups first.
+	FindDuplicates(ctx context.Context, req *assetmodel.FindDuplicatesRequest) ([]*assetmodel.DuplicateGroup, error)
+	// VerifyWebhook verifies the signature of a Cloudinary notification against its raw payload and
+	// timestamp and returns the environment whose secret signed it.
+	VerifyWebhook(ctx context.Context, payload []byte, timestamp, signature string) (string, error)
+	// HandleWebhook processes a verified webhook notification from Cloudinary.
+	// It routes the webhook to the appropriate handler based on its type.
+	HandleWebhook(ctx context.Context, payload []byte) error
+	// Upload streams the file to Cloudinary with the admin credentials and applies the upload
+	// details to the created asset.
+	Upload(ctx context.Context, req *assetmodel.UploadRequest, file io.Reader) (*assetmodel.Asset, error)
+	// SchedulePublishing sets the publication schedule of an asset. The image is only delivered to end users
+	// between the publish and the unpublish time.
+	SchedulePublishing(ctx context.Context, req *assetmodel.SchedulePublishingRequest) (*publishing.Schedule, error)
+	// CancelPublishing removes the publication schedule of an asset, a scheduled asset stays unpublished.
+	CancelPublishing(ctx context.Context, req *assetmodel.CancelPublishingRequest) (*publishing.Schedule, error)
+	// TransitionPublishing publishes and unpublishes the assets whose publish or unpublish time passed.
+	TransitionPublishing(ctx context.Context, opts *publishing.TransitionOptions) (int, error)
+	// Purge permanently deletes archived assets that were soft-deleted before the provided cutoff.
+	// Each asset is deleted from Cloudinary, Postgres and MongoDB. A purge record is returned for every processed asset.
+	// If dry run is requested, assets are only reported and nothing is deleted.
+	Purge(ctx context.Context, opts *retentionmodel.PurgeOptions) ([]*retentionmodel.PurgeRecord, error)
 }
 
 type Service struct {
-	repo               *assetrepo.Repository
-	metadataRepo       *metadatarepo.Repository
-	imageServiceClient *client.ImageServiceClient
-	apiClient          *apiclient.Client
-	logger             *zap.Logger
+	repo         *assetrepo.Repository
+	metadataRepo dbmetadata.Repository[metadatamodel.AssetMetadata, metadatamodel.Owner]
+	outboxRepo   *outboxrepo.Repository
+	// collectionRepo is used to list the assets of collections and to drop memberships of deleted assets.
+	collectionRepo     *collectionrepo.Repository
+	auditRepo          *auditrepo.Repository
+	versionRepo        *versionrepo.Repository
+	imageServiceClient imagepbv1.ImageServiceClient
+	apiClient          apiclient.APIClient
+	publisher          events.Publisher
+	cache              cache.Cache
+	cacheTTL           cache.TTL
+	ownership          *ownertypes.Policies
+	// upload is nil unless images can be uploaded through the service.
+	upload *UploadConfig
+	// uploadPolicy holds the preflight rules of uploads, it is never nil.
+	uploadPolicy *UploadPolicy
+	// moderation is the moderation add-on requested for uploaded images, empty if images are not moderated.
+	moderation string
+	// enrichment is nil unless uploaded images are enriched.
+	enrichment *apiclient.EnrichParams
+	// blockDuplicates rejects uploaded images identical to an active asset.
+	blockDuplicates bool
+	// sagas executes the owner updates.
+	sagas *sagaservice.Executor
+	// counters compute the list totals, totals are exact if it is nil.
+	counters *pagination.Counters
+	// quota limits the assets of each creator.
+	quota quota.Limits
+	// entitlements decides which end users may view the images, end users may view none if it is nil.
+	entitlements entitlement.Checker
+	// watermarks resolves the watermarks overlaid on the delivered images, images are not watermarked if it is nil.
+	watermarks *watermarkservice.Service
+	// environments are the names of the environments the notifications are verified against.
+	environments []string
+	logger       *zap.Logger
 }
 
+// defaultListByOwnerPageSize is used by ListByOwner and ListByCollection when the request does not specify a page size.
+const defaultListByOwnerPageSize = 50
+
 var _ AssetService = (*Service)(nil)
 
 type NewParams struct {
 	Repo               *assetrepo.Repository
-	MetadataRepo       *metadatarepo.Repository
-	ImageServiceClient *client.ImageServiceClient
-	ApiClient          *apiclient.Client
+	MetadataRepo       dbmetadata.Repository[metadatamodel.AssetMetadata, metadatamodel.Owner]
+	OutboxRepo         *outboxrepo.Repository
+	CollectionRepo     *collectionrepo.Repository
+	AuditRepo          *auditrepo.Repository
+	VersionRepo        *versionrepo.Repository
+	ImageServiceClient imagepbv1.ImageServiceClient
+	ApiClient          apiclient.APIClient
+	// Publisher is optional, events are discarded if it is not provided.
+	Publisher events.Publisher
+	// Cache is optional, lookups always hit the databases if it is not provided.
+	Cache    cache.Cache
+	CacheTTL cache.TTL
+	// Ownership is optional, owners are only limited by the owner type registry if it is not provided.
+	Ownership *ownertypes.Policies
+	// Upload is optional, images can not be uploaded through the service if it is not provided.
+	Upload *UploadConfig
+	// UploadPolicy is optional, any public ID and declared file is accepted if it is not provided.
+	UploadPolicy *UploadPolicy
+	// Moderation is the Cloudinary moderation add-on requested for uploaded images, e.g. "manual" or
+	// "aws_rek". Images are not moderated if it is empty.
+	Moderation string
+	// Enrichment is optional, uploaded images are not enriched if it is not provided.
+	Enrichment *apiclient.EnrichParams
+	// BlockDuplicates rejects uploaded images byte-identical to an active asset. Duplicates are only
+	// reported by FindDuplicates if it is not set.
+	BlockDuplicates bool
+	// Sagas executes the owner updates, the saga definitions of the service must be registered with it.
+	Sagas *sagaservice.Executor
+	// Counters is optional, list totals are always counted exactly if it is not provided.
+	Counters *pagination.Counters
+	// Quota is optional, the zero value does not limit creators.
+	Quota quota.Limits
+	// Entitlements is optional, end users may not view any image if it is not provided.
+	Entitlements entitlement.Checker
+	// Watermarks is optional, delivered images are not watermarked if it is not provided.
+	Watermarks *watermarkservice.Service
+	// Environments are the names of the environments served by the API client. It is optional, the
+	// notifications are only verified in the default environment if it is not provided.
+	Environments []string
 }
 
 func New(params *NewParams, logger *zap.Logger) *Service {
+	publisher := params.Publisher
+	if publisher == nil {
+		publisher = events.NoopPublisher{}
+	}
+	assetCache := params.Cache
+	if assetCache == nil {
+		assetCache = cache.Noop{}
+	}
+	uploadPolicy := params.UploadPolicy
+	if uploadPolicy == nil {
+		uploadPolicy = &UploadPolicy{}
+	}
+	environments := params.Environments
+	if len(environments) == 0 {
+		environments = []string{environment.Default}
+	}
 	return &Service{
 		repo:               params.Repo,
 		metadataRepo:       params.MetadataRepo,
+		outboxRepo:         params.OutboxRepo,
+		collectionRepo:     params.CollectionRepo,
+		auditRepo:          params.AuditRepo,
+		versionRepo:        params.VersionRepo,
 		imageServiceClient: params.ImageServiceClient,
 		apiClient:          params.ApiClient,
+		publisher:          publisher,
+		cache:              assetCache,
+		cacheTTL:           params.CacheTTL,
+		ownership:          params.Ownership,
+		upload:             params.Upload,
+		uploadPolicy:       uploadPolicy,
+		moderation:         params.Moderation,
+		enrichment:         params.Enrichment,
+		blockDuplicates:    params.BlockDuplicates,
+		sagas:              params.Sagas,
+		counters:           params.Counters,
+		quota:              params.Quota,
+		entitlements:       params.Entitlements,
+		watermarks:         params.Watermarks,
+		environments:       environments,
 		logger:             logger.With(zap.String("layer", "service"), zap.String("service", "Cloudinary")),
 	}
 }
 
+// log returns the service logger enriched with the request-scoped fields stored in ctx.
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
 // Get retrieves an active asset based on the provided filter.
 func (s *Service) Get(ctx context.Context, filter *assetmodel.GetFilter) (*assetmodel.Details, error) {
 	return s.get(ctx, filter, []assetrepo.Scope{
@@ -119,6 +328,8 @@ func (s *Service) Get(ctx context.Context, filter *assetmodel.GetFilter) (*asset
 }
 
 // GetWithArchived retrieves an asset that can be either active or archived based on the provided filter.
+// An archived asset carries the ownership snapshot taken when it lost its owners. Its metadata is nil
+// if the asset was deleted in Cloudinary, the details list the versions it can be restored from instead.
 func (s *Service) GetWithArchived(ctx context.Context, filter *assetmodel.GetFilter) (*assetmodel.Details, error) {
 	return s.get(ctx, filter, []assetrepo.Scope{
 		assetrepo.ScopeActive,
@@ -143,6 +354,8 @@ func (s *Service) List(ctx context.Context, req *assetmodel.ListRequest) ([]*ass
 }
 
 // ListArchived retrieves a list of archived assets based on the provided request.
+// The assets carry who archived them and when, and the ownership snapshot taken when they lost their
+// owners, which RestoreWithOwners re-associates.
 func (s *Service) ListArchived(ctx context.Context, req *assetmodel.ListRequest) ([]*assetmodel.Details, string, error) {
 	return s.list(ctx, req, []assetrepo.Scope{
 		assetrepo.ScopeArchived,
@@ -156,70 +369,191 @@ func (s *Service) ListBroken(ctx context.Context, req *assetmodel.ListRequest) (
 	})
 }
 
+// ListTotal returns the total of the assets listed by List, computed with the count strategy of the endpoint.
+func (s *Service) ListTotal(ctx context.Context, req *assetmodel.ListRequest) (*pagination.Total, error) {
+	return s.total(ctx, req, "cloudinary.list", []assetrepo.Scope{
+		assetrepo.ScopeActive,
+	})
+}
+
+// ListArchivedTotal returns the total of the assets listed by ListArchived.
+func (s *Service) ListArchivedTotal(ctx context.Context, req *assetmodel.ListRequest) (*pagination.Total, error) {
+	return s.total(ctx, req, "cloudinary.list_archived", []assetrepo.Scope{
+		assetrepo.ScopeArchived,
+	})
+}
+
+// ListBrokenTotal returns the total of the assets listed by ListBroken.
+func (s *Service) ListBrokenTotal(ctx context.Context, req *assetmodel.ListRequest) (*pagination.Total, error) {
+	return s.total(ctx, req, "cloudinary.list_broken", []assetrepo.Scope{
+		assetrepo.ScopeBroken,
+	})
+}
+
+// ListByOwner retrieves a page of assets associated with a single owner, e.g. all images of a product.
+// Assets are ordered by ID, the returned page token is the last asset ID of the page.
+func (s *Service) ListByOwner(ctx context.Context, req *assetmodel.ListByOwnerRequest) ([]*assetmodel.Details, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultListByOwnerPageSize
+	}
+
+	metadataPage, nextPageToken, err := s.metadataRepo.ListByOwner(ctx, &metadatamodel.Owner{
+		OwnerID:   req.OwnerID,
+		OwnerType: req.OwnerType,
+	}, pageSize, req.PageToken)
+	if err != nil {
+		s.log(ctx).Error("failed to list asset metadata by owner", zap.Error(err), logging.OwnerType(req.OwnerType), zap.String("owner_id", req.OwnerID))
+		return nil, "", fmt.Errorf("failed to list asset metadata by owner: %w", err)
+	}
+	details, err := s.joinAssets(ctx, metadataPage)
+	if err != nil {
+		return nil, "", err
+	}
+	return details, nextPageToken, nil
+}
+
+// GetByOwner retrieves the asset associated with the owner, so owner services can resolve their
+// media without storing the asset ID. The lookup uses the owner index of the metadata. If the owner
+// has several assets the one with the lowest ID is returned, ListByOwner returns all of them.
+func (s *Service) GetByOwner(ctx context.Context, req *assetmodel.GetByOwnerRequest) (*assetmodel.Details, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	metadataPage, _, err := s.metadataRepo.ListByOwner(ctx, &metadatamodel.Owner{
+		OwnerID:   req.OwnerID,
+		OwnerType: req.OwnerType,
+	}, 1, "")
+	if err != nil {
+		s.log(ctx).Error("failed to get asset metadata by owner", zap.Error(err), logging.OwnerType(req.OwnerType), zap.String("owner_id", req.OwnerID))
+		return nil, fmt.Errorf("failed to get asset metadata by owner: %w", err)
+	}
+	details, err := s.joinAssets(ctx, metadataPage)
+	if err != nil {
+		return nil, err
+	}
+	if len(details) == 0 {
+		return nil, serviceerrors.NewNotFoundError("no asset is associated with the owner")
+	}
+	return details[0], nil
+}
+
+// ListOwnerTypes returns the owner types assets can be associated with.
+func (s *Service) ListOwnerTypes(_ context.Context) []string {
+	return assetmodel.OwnerTypes.List()
+}
+
 // CreateSignedUploadURL generates a signed URL for uploading an asset to Cloudinary.
 // It returns the signed parameters required for the upload, end client must build signed upload
 // URL using generated parameters.
+//
+// The declared file and the public ID are checked against the upload policy first. The public ID
+// is normalized and prefixed with the creator folder, clients must upload with the returned one.
 func (s *Service) CreateSignedUploadURL(ctx context.Context, req *assetmodel.CreateSignedUploadURLRequest) (*assetmodel.GeneratedSignedParams, error) {
 	if err := req.Validate(); err != nil {
 		return nil, serviceerrors.NewValidationFailedError(err)
 	}
+	publicID, formats, err := s.uploadPolicy.preflight(req)
+	if err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	if _, err := s.createPendingAsset(ctx, publicID, req.AdminID, req.AdminName, req.Note); err != nil {
+		return nil, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	params := make(url.Values)
+
+	if req.Eager != nil {
+		params.Set("eager", *req.Eager)
+	}
+	var moderation *string
+	if s.moderation != "" {
+		moderation = &s.moderation
+		params.Set("moderation", s.moderation)
+	}
+	// The perceptual hash is stored with the asset to find visually identical images.
+	params.Set("phash", "true")
+	var allowedFormats *string
+	if len(formats) > 0 {
+		joined := strings.Join(formats, ",")
+		allowedFormats = &joined
+		params.Set("allowed_formats", joined)
+	}
+	params.Set("timestamp", timestamp)
+	params.Set("public_id", publicID)
 
+	signature, err := s.apiClient.SignUploadParams(ctx, params)
+	if err != nil {
+		s.log(ctx).Error("failed to sign upload params",
+			zap.String("timestamp", timestamp),
+			zap.String("public_id", publicID),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to sign upload params: %w", err)
+	}
+
+	return &assetmodel.GeneratedSignedParams{
+		Signature:      signature,
+		ApiKey:         s.apiClient.GetApiKey(ctx),
+		PublicID:       publicID,
+		Timestamp:      timestamp,
+		Eager:          req.Eager,
+		Moderation:     moderation,
+		Phash:          true,
+		AllowedFormats: allowedFormats,
+	}, nil
+}
+
+// createPendingAsset creates the record of an asset which is about to be uploaded.
+func (s *Service) createPendingAsset(ctx context.Context, publicID, adminID, adminName, note string) (*assetmodel.Asset, error) {
+	var createdAsset *assetmodel.Asset
 	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
-		adminID, err := parsing.StrToUUID(req.AdminID)
+		parsedAdminID, err := parsing.StrToUUID(adminID)
 		if err != nil {
 			return err
 		}
 		asset := &assetmodel.Asset{
-			CloudinaryPublicID: req.PublicID,
+			CloudinaryPublicID: publicID,
 			Status:             assetmodel.StatusUploadURLGenerated,
-			CreatedByName:      &req.AdminName,
-			CreatedBy:          &adminID,
+			CreatedByName:      &adminName,
+			CreatedBy:          &parsedAdminID,
 		}
+		asset.ModerationStatus, asset.ModerationKind = s.moderationStatus()
 
+		if err := s.checkQuota(ctx, txRepo, parsedAdminID); err != nil {
+			return err
+		}
 		if err := txRepo.Create(ctx, asset); err != nil {
 			if errors.Is(err, gorm.ErrDuplicatedKey) {
 				return serviceerrors.NewAlreadyExistsError("asset with the given public ID already exists")
 			}
-			s.logger.Error("failed to create asset record for signed upload URL",
-				zap.String("public_id", req.PublicID),
-				zap.String("admin_id", req.AdminID),
-				zap.String("admin_name", req.AdminName),
+			s.log(ctx).Error("failed to create asset record for upload",
+				zap.String("public_id", publicID),
+				zap.String("admin_id", adminID),
+				zap.String("admin_name", adminName),
 				zap.Error(err),
 			)
-			return fmt.Errorf("failed to create asset record for signed upload URL: %w", err)
+			return fmt.Errorf("failed to create asset record for upload: %w", err)
 		}
-
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionCreateUploadURL, asset.ID, nil, asset,
+			audit.WithAdmin(adminID, adminName), audit.WithNote(note),
+		); err != nil {
+			return err
+		}
+		createdAsset = asset
 		return nil
 	})
-
-	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
-	params := make(url.Values)
-
-	if req.Eager != nil {
-		params.Set("eager", *req.Eager)
-	}
-	params.Set("timestamp", timestamp)
-	params.Set("public_id", req.PublicID)
-
-	signature, err := s.apiClient.SignUploadParams(ctx, params)
 	if err != nil {
-		s.logger.Error("failed to sign upload params",
-			zap.String("timestamp", timestamp),
-			zap.String("public_id", req.PublicID),
-			zap.Error(err),
-		)
-		return nil, fmt.Errorf("failed to sign upload params: %w", err)
+		return nil, err
 	}
-
-	return &assetmodel.GeneratedSignedParams{
-		Signature: signature,
-		ApiKey:    s.apiClient.GetApiKey(),
-		PublicID:  req.PublicID,
-		Timestamp: timestamp,
-		Eager:     req.Eager,
-	}, nil
+	s.publishEvent(ctx, events.TypeAssetCreated, createdAsset.ID, withExternalID(&createdAsset.CloudinaryPublicID))
+	return createdAsset, nil
 }
 
 // Archive marks an asset as archived.
@@ -228,9 +562,9 @@ func (s *Service) Archive(ctx context.Context, req *assetmodel.ChangeStateReques
 	if err := req.Validate(); err != nil {
 		return serviceerrors.NewValidationFailedError(err)
 	}
+	defer s.invalidateByID(ctx, req.ID)
 
-	var toDelete *uuid.UUID
-	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
 		asset, err := s.getInTx(ctx, txRepo, req.ID, []string{"id", "status"})
@@ -249,17 +583,17 @@ func (s *Service) Archive(ctx context.Context, req *assetmodel.ChangeStateReques
 		if len(metadata.Owners) > 0 {
 			return serviceerrors.NewConflictError("cannot archive asset with owners")
 		}
-		toDelete = &asset.ID
-		return nil
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionArchive, asset.ID,
+			statusSnapshot(asset.Status), statusSnapshot(assetmodel.StatusArchived),
+			audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note),
+		); err != nil {
+			return err
+		}
+		// gRPC relations are deleted asynchronously after the transaction commits
+		return s.enqueueEvent(ctx, s.outboxRepo.WithTx(tx), outboxmodel.EventImageDeleted, &outboxmodel.DeletePayload{
+			AssetIDs: uuid.UUIDs{asset.ID},
+		})
 	})
-	if err != nil {
-		return err
-	}
-	// If transaction commits successfully, delete gRPC relations outside transaction
-	if toDelete != nil {
-		return s.grpcDelete(ctx, toDelete)
-	}
-	return nil
 }
 
 // MarkAsBroken marks an asset as broken.
@@ -270,10 +604,10 @@ func (s *Service) MarkAsBroken(ctx context.Context, req *assetmodel.ChangeStateR
 	if err := req.Validate(); err != nil {
 		return serviceerrors.NewValidationFailedError(err)
 	}
-	var toDelete *uuid.UUID
-	var metadataToDelete *metadatamodel.AssetMetadata
+	defer s.invalidateByID(ctx, req.ID)
+	var metadataToClear *metadatamodel.AssetMetadata
 
-	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
 		asset, err := s.getInTx(ctx, txRepo, req.ID, []string{"id", "status"})
@@ -293,9 +627,15 @@ func (s *Service) MarkAsBroken(ctx context.Context, req *assetmodel.ChangeStateR
 			AdminName: req.AdminName,
 			Note:      req.Note,
 		}); err != nil {
-			s.logger.Error("failed to mark asset as broken", zap.Error(err), zap.String("asset_id", asset.ID.String()))
+			s.log(ctx).Error("failed to mark asset as broken", zap.Error(err), logging.AssetID(asset.ID))
 			return fmt.Errorf("failed to mark asset as broken: %w", err)
 		}
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionMarkAsBroken, asset.ID,
+			statusSnapshot(asset.Status), statusSnapshot(assetmodel.StatusBroken),
+			audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note),
+		); err != nil {
+			return err
+		}
 
 		metadata, err := s.getAssetMetadata(ctx, asset.ID)
 		if err != nil {
@@ -303,8 +643,19 @@ func (s *Service) MarkAsBroken(ctx context.Context, req *assetmodel.ChangeStateR
 		}
 
 		if len(metadata.Owners) > 0 {
-			metadataToDelete = metadata
-			toDelete = &asset.ID
+			if err := s.snapshotOwners(ctx, txRepo, asset.ID, metadata.Owners, assetmodel.SnapshotReasonBroken); err != nil {
+				return err
+			}
+			// gRPC relations are marked as broken asynchronously after the transaction commits
+			if err := s.enqueueEvent(ctx, s.outboxRepo.WithTx(tx), outboxmodel.EventImageBroken, &outboxmodel.BrokenPayload{
+				AssetID:   asset.ID,
+				AdminID:   adminID,
+				AdminName: req.AdminName,
+				Reason:    req.Note,
+			}); err != nil {
+				return err
+			}
+			metadataToClear = metadata
 		}
 
 		return nil
@@ -312,34 +663,80 @@ func (s *Service) MarkAsBroken(ctx context.Context, req *assetmodel.ChangeStateR
 	if err != nil {
 		return err
 	}
-	// If transaction commits successfully, delete gRPC relations outside transaction
-	if toDelete != nil {
-		return s.markAsBrokenAndClearOwners(ctx, toDelete, metadataToDelete, req)
+	if metadataToClear != nil {
+		return s.clearOwners(ctx, metadataToClear)
 	}
 	return nil
 }
 
+// UpdateMetadata changes the title or the creator of an asset.
+// The new values are stored in the asset metadata in MongoDB.
+// Broken or archived assets cannot be updated.
+func (s *Service) UpdateMetadata(ctx context.Context, req *assetmodel.UpdateMetadataRequest) (*metadatamodel.AssetMetadata, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	defer s.invalidateByID(ctx, req.ID)
+
+	var metadata *metadatamodel.AssetMetadata
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		asset, err := s.getInTx(ctx, txRepo, req.ID, []string{"id", "status"})
+		if err != nil {
+			return err
+		}
+		if asset.Status == assetmodel.StatusArchived || asset.Status == assetmodel.StatusBroken {
+			return serviceerrors.NewConflictError("cannot update metadata of archived or broken asset")
+		}
+
+		metadata, err = s.updateMetadata(ctx, tx, asset.ID, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
 // AddOwner associates an external owner with an asset.
 // It updates the asset metadata in MongoDB to include the new owner.
 // Broken or archived assets cannot have owners added.
+// If ReplaceExisting is set, the owner is moved to the asset as described in [Service.AssociateOwner].
 func (s *Service) AddOwner(ctx context.Context, req *assetmodel.ManageOwnerRequest) error {
 	if err := req.Validate(); err != nil {
 		return serviceerrors.NewValidationFailedError(err)
 	}
+	if req.ReplaceExisting {
+		_, err := s.replaceOwner(ctx, req, versionmodel.KindReplace)
+		return err
+	}
+	defer s.invalidateByID(ctx, req.ID)
 
-	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+	var ownerMetadata *metadatamodel.AssetMetadata
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
-		asset, err := s.getInTx(ctx, txRepo, req.ID, []string{"id", "status"})
+		asset, err := s.getInTx(ctx, txRepo, req.ID, []string{"id", "status", "moderation_status"})
 		if err != nil {
 			return err
 		}
-		if asset.Status == assetmodel.StatusArchived || asset.Status == assetmodel.StatusBroken {
-			return serviceerrors.NewConflictError("cannot add owner to archived or broken asset")
+		if err := validateBeforeAddOwner(asset); err != nil {
+			return err
 		}
 
-		return s.addOwner(ctx, asset.ID, req)
+		metadata, err := s.addOwner(ctx, tx, asset.ID, req)
+		if err != nil {
+			return err
+		}
+		ownerMetadata = metadata
+		return nil
 	})
+	if err != nil {
+		return err
+	}
+	s.publishEvent(ctx, events.TypeAssetOwnersChanged, uuid.MustParse(ownerMetadata.Key), withOwners(ownerMetadata.Owners))
+	return nil
 }
 
 // RemoveOwner disassociates an external owner from an asset.
@@ -348,7 +745,9 @@ func (s *Service) RemoveOwner(ctx context.Context, req *assetmodel.ManageOwnerRe
 	if err := req.Validate(); err != nil {
 		return serviceerrors.NewValidationFailedError(err)
 	}
-	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+	defer s.invalidateByID(ctx, req.ID)
+	var ownerMetadata *metadatamodel.AssetMetadata
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
 		asset, err := s.getInTx(ctx, txRepo, req.ID, []string{"id", "status"})
@@ -362,16 +761,25 @@ func (s *Service) RemoveOwner(ctx context.Context, req *assetmodel.ManageOwnerRe
 		}
 		metadata, err := s.metadataRepo.GetByOwner(ctx, asset.ID.String(), &toRemove)
 		if err != nil {
-			if errors.Is(err, mongo.ErrNoDocuments) {
+			if errors.Is(err, dbmetadata.ErrNotFound) {
 				return serviceerrors.NewNotFoundError(err)
 			}
-			s.logger.Error("failed to retrieve asset metadata for removing owner",
+			s.log(ctx).Error("failed to retrieve asset metadata for removing owner",
 				zap.Error(err), zap.String("owner_id", req.OwnerID), zap.String("owner_type", req.OwnerType),
 			)
 			return fmt.Errorf("failed to retrieve asset metadata for removing owner: %w", err)
 		}
-		return s.removeOwner(ctx, metadata, req)
+		if err := s.removeOwner(ctx, tx, metadata, req); err != nil {
+			return err
+		}
+		ownerMetadata = metadata
+		return nil
 	})
+	if err != nil {
+		return err
+	}
+	s.publishEvent(ctx, events.TypeAssetOwnersChanged, uuid.MustParse(ownerMetadata.Key), withOwners(ownerMetadata.Owners))
+	return nil
 }
 
 // Restore restores an archived asset back to active status.
@@ -380,47 +788,85 @@ func (s *Service) Restore(ctx context.Context, req *assetmodel.ChangeStateReques
 	if err := req.Validate(); err != nil {
 		return serviceerrors.NewValidationFailedError(err)
 	}
+	defer s.invalidateByID(ctx, req.ID)
 
 	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
-		txRepo := s.repo.WithTx(tx)
+		_, err := s.restore(ctx, tx, req)
+		return err
+	})
+}
 
-		asset, err := s.getInTx(ctx, txRepo, req.ID, []string{"id", "status"})
-		if err != nil {
-			return err
-		}
-		if asset.Status != assetmodel.StatusArchived {
-			return serviceerrors.NewConflictError("asset is not archived")
-		}
+// RestoreWithOwners restores an archived asset back to active status and re-associates the owners
+// the asset had when they were cleared, e.g. when the asset was marked as broken.
+// The owners are taken from the ownership snapshot of the asset. Only archived assets can be restored.
+func (s *Service) RestoreWithOwners(ctx context.Context, req *assetmodel.ChangeStateRequest) (*metadatamodel.AssetMetadata, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	defer s.invalidateByID(ctx, req.ID)
 
-		adminID, err := parsing.StrToUUID(req.AdminID)
+	var metadata *metadatamodel.AssetMetadata
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		asset, err := s.restore(ctx, tx, req)
 		if err != nil {
 			return err
 		}
-		if _, err := txRepo.Restore(ctx, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}, &types.AuditTrailOptions{
-			AdminID:   adminID,
-			AdminName: req.AdminName,
-			Note:      req.Note,
-		}); err != nil {
-			s.logger.Error("failed to restore archived asset", zap.Error(err), zap.String("asset_id", asset.ID.String()))
-			return fmt.Errorf("failed to restore archived asset: %w", err)
-		}
-
-		return nil
+		metadata, err = s.restoreOwners(ctx, tx, asset)
+		return err
 	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishEvent(ctx, events.TypeAssetOwnersChanged, uuid.MustParse(metadata.Key), withOwners(metadata.Owners))
+	return metadata, nil
+}
+
+func (s *Service) restore(ctx context.Context, tx *gorm.DB, req *assetmodel.ChangeStateRequest) (*assetmodel.Asset, error) {
+	txRepo := s.repo.WithTx(tx)
+
+	asset, err := s.getInTx(ctx, txRepo, req.ID, []string{"id", "status", "ownership_snapshot", "cloudinary_destroyed_at"})
+	if err != nil {
+		return nil, err
+	}
+	if asset.Status != assetmodel.StatusArchived {
+		return nil, serviceerrors.NewConflictError("asset is not archived")
+	}
+	if asset.CloudinaryDestroyedAt != nil {
+		return nil, serviceerrors.NewConflictError("asset file was destroyed in Cloudinary, it must be restored from backup first")
+	}
+
+	adminID, err := parsing.StrToUUID(req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := txRepo.Restore(ctx, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}, &types.AuditTrailOptions{
+		AdminID:   adminID,
+		AdminName: req.AdminName,
+		Note:      req.Note,
+	}); err != nil {
+		s.log(ctx).Error("failed to restore archived asset", zap.Error(err), logging.AssetID(asset.ID))
+		return nil, fmt.Errorf("failed to restore archived asset: %w", err)
+	}
+
+	return asset, s.recordAudit(ctx, tx, auditmodel.ActionRestore, asset.ID,
+		statusSnapshot(asset.Status), statusSnapshot(assetmodel.StatusActive),
+		audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note),
+	)
 }
 
 // Delete permanently deletes an archived asset along with its metadata.
-// It also deletes the asset from Cloudinary.
+// The asset is marked as pending deletion and deleted from Cloudinary and the databases asynchronously,
+// so no row locks are held while Cloudinary is called.
 // Note that only currently soft-deleted (archived) assets can be permanently deleted.
 func (s *Service) Delete(ctx context.Context, req *assetmodel.ChangeStateRequest) error {
 	if err := req.Validate(); err != nil {
 		return serviceerrors.NewValidationFailedError(err)
 	}
-	var toDelete *uuid.UUID
-	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+	defer s.invalidateByID(ctx, req.ID)
+	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
-		asset, err := s.getInTx(ctx, txRepo, req.ID, []string{"id", "status", "cloudinary_public_id", "recourse_type"})
+		asset, err := s.getInTx(ctx, txRepo, req.ID, []string{"id", "status", "cloudinary_public_id", "resource_type"})
 		if err != nil {
 			return err
 		}
@@ -428,27 +874,20 @@ func (s *Service) Delete(ctx context.Context, req *assetmodel.ChangeStateRequest
 			return serviceerrors.NewConflictError("only archived assets can be deleted")
 		}
 
-		if asset.CloudinaryPublicID != "" {
-			// Delete asset from Cloudinary
-			if err := s.apiClient.DeleteAsset(ctx, asset.CloudinaryPublicID, asset.ResourceType); err != nil {
-				s.logger.Error("failed to delete asset from Cloudinary", zap.Error(err), zap.String("asset_id", asset.ID.String()))
-				return fmt.Errorf("failed to delete asset from Cloudinary: %w", err)
-			}
+		if _, err := txRepo.MarkPendingDelete(ctx, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}); err != nil {
+			s.log(ctx).Error("failed to mark asset as pending deletion", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to mark asset as pending deletion: %w", err)
 		}
-
-		// Delete asset record from Postgres
-		if _, err := txRepo.Delete(ctx, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}); err != nil {
-			s.logger.Error("failed to delete asset record from Postgres", zap.Error(err), zap.String("asset_id", asset.ID.String()))
-			return fmt.Errorf("failed to delete asset record from Postgres: %w", err)
+		if err := s.removeFromCollections(ctx, tx, asset.ID); err != nil {
+			return err
 		}
-		toDelete = &asset.ID
-		return nil
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionDelete, asset.ID, asset, nil,
+			audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note),
+		); err != nil {
+			return err
+		}
+		return s.enqueueEvent(ctx, s.outboxRepo.WithTx(tx), outboxmodel.EventImageDeleteRemote, &outboxmodel.DeleteRemotePayload{
+			AssetID: asset.ID,
+		})
 	})
-	if err != nil {
-		return err
-	}
-	if toDelete != nil {
-		return s.deleteAssetMetadata(ctx, *toDelete)
-	}
-	return nil
 }
@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	"github.com/mikhail5545/media-service-go/internal/tags"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// AddTags adds tags to an asset and returns the resulting tags of the asset.
+// The tags are mirrored to the Cloudinary asset.
+func (s *Service) AddTags(ctx context.Context, req *assetmodel.ManageTagsRequest) ([]string, error) {
+	return s.changeTags(ctx, req, auditmodel.ActionAddTags, tags.Add)
+}
+
+// RemoveTags removes tags from an asset and returns the remaining tags of the asset.
+// The tags are mirrored to the Cloudinary asset.
+func (s *Service) RemoveTags(ctx context.Context, req *assetmodel.ManageTagsRequest) ([]string, error) {
+	return s.changeTags(ctx, req, auditmodel.ActionRemoveTags, tags.Remove)
+}
+
+// ListByTag retrieves a list of active assets having the tag.
+func (s *Service) ListByTag(ctx context.Context, req *assetmodel.ListByTagRequest) ([]*assetmodel.Details, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	return s.list(ctx, &assetmodel.ListRequest{
+		Tags:      []string{req.Tag},
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	}, []assetrepo.Scope{
+		assetrepo.ScopeActive,
+	})
+}
+
+func (s *Service) changeTags(
+	ctx context.Context,
+	req *assetmodel.ManageTagsRequest,
+	action auditmodel.Action,
+	change func(existing, changed []string) []string,
+) ([]string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	defer s.invalidateByID(ctx, req.ID)
+
+	var result []string
+	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		asset, err := s.getInTx(ctx, txRepo, req.ID, []string{
+			"id", "status", "cloudinary_public_id", "resource_type", "tags",
+		})
+		if err != nil {
+			return err
+		}
+		if asset.Status == assetmodel.StatusArchived || asset.Status == assetmodel.StatusBroken {
+			return serviceerrors.NewConflictError("cannot change tags of archived or broken asset")
+		}
+
+		result = change(asset.Tags, req.Tags)
+		if len(result) > tags.MaxPerAsset {
+			return serviceerrors.NewValidationFailedError(fmt.Sprintf("an asset may have at most %d tags", tags.MaxPerAsset))
+		}
+		if _, err := txRepo.Update(ctx, map[string]any{"tags": result}, assetrepo.StateOperationOptions{
+			IDs: uuid.UUIDs{asset.ID},
+		}); err != nil {
+			s.log(ctx).Error("failed to update asset tags", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to update asset tags: %w", err)
+		}
+		if err := s.recordAudit(ctx, tx, action, asset.ID, tagsSnapshot(asset.Tags), tagsSnapshot(result)); err != nil {
+			return err
+		}
+		return s.pushTags(ctx, asset, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// pushTags applies the difference between the current tags of the asset and the new tags to the
+// Cloudinary asset.
+func (s *Service) pushTags(ctx context.Context, asset *assetmodel.Asset, newTags []string) error {
+	added := slices.DeleteFunc(slices.Clone(newTags), func(tag string) bool {
+		return slices.Contains(asset.Tags, tag)
+	})
+	removed := slices.DeleteFunc(slices.Clone(asset.Tags), func(tag string) bool {
+		return slices.Contains(newTags, tag)
+	})
+
+	if len(added) > 0 {
+		if err := s.apiClient.AddTags(ctx, asset.CloudinaryPublicID, asset.ResourceType, added); err != nil {
+			s.log(ctx).Error("failed to add tags to cloudinary asset", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to add tags to cloudinary asset: %w", err)
+		}
+	}
+	if len(removed) > 0 {
+		if err := s.apiClient.RemoveTags(ctx, asset.CloudinaryPublicID, asset.ResourceType, removed); err != nil {
+			s.log(ctx).Error("failed to remove tags from cloudinary asset", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to remove tags from cloudinary asset: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	apiclient "github.com/mikhail5545/media-service-go/internal/apiclients/cloudinary"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
+	"github.com/mikhail5545/media-service-go/internal/database/types"
+	"github.com/mikhail5545/media-service-go/internal/deadline"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// UploadConfig configures images uploaded through the service.
+type UploadConfig struct {
+	// MaxFileSize is the maximum size of a single image in bytes.
+	MaxFileSize int64
+	// Transformation is the incoming transformation applied before the image is stored.
+	Transformation string
+	// Eager lists the derived transformations generated on upload, separated by "|".
+	Eager string
+}
+
+// errFileTooLarge is returned by [limitedReader] once the file exceeds the size limit.
+var errFileTooLarge = errors.New("file exceeds the size limit")
+
+// limitedReader fails the read once more than limit bytes are read, so oversized files are
+// rejected instead of being truncated.
+type limitedReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n, errFileTooLarge
+	}
+	return n, err
+}
+
+// Upload streams the file to Cloudinary with the admin credentials, so that clients never get signed
+// upload parameters. The configured incoming transformation is applied, then the asset is updated
+// with the upload details the same way as on the upload webhook.
+//
+// The public ID is normalized and checked against the folders of the upload policy the same way as for
+// signed uploads. If Cloudinary rejects the file, the created asset is marked as broken. So is the asset of a file
+// identical to an active asset when duplicates are blocked, a conflict error is returned then.
+func (s *Service) Upload(ctx context.Context, req *assetmodel.UploadRequest, file io.Reader) (*assetmodel.Asset, error) {
+	if s.upload == nil {
+		return nil, serviceerrors.NewUnimplementedError("image uploads through the service are disabled")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	publicID, err := s.uploadPolicy.publicID(req.PublicID, req.AdminID)
+	if err != nil {
+		return nil, serviceerrors.NewValidationFailedError(validation.Errors{"public_id": err})
+	}
+	req.PublicID = publicID
+
+	asset, err := s.createPendingAsset(ctx, req.PublicID, req.AdminID, req.AdminName, req.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := &limitedReader{r: file, limit: s.upload.MaxFileSize}
+	result, err := s.apiClient.Upload(ctx, reader, &apiclient.UploadParams{
+		PublicID:       req.PublicID,
+		Transformation: s.upload.Transformation,
+		Eager:          s.upload.Eager,
+		Moderation:     s.moderation,
+	})
+	if err != nil {
+		s.log(ctx).Error("failed to upload asset to Cloudinary", zap.Error(err), logging.AssetID(asset.ID), zap.String("public_id", req.PublicID))
+		// The upload may have failed on the request deadline, so the asset is marked with a context of its own.
+		cleanupCtx, cancel := deadline.Cleanup(ctx)
+		defer cancel()
+		if markErr := s.markUploadFailed(cleanupCtx, asset, req, err); markErr != nil {
+			return nil, markErr
+		}
+		if errors.Is(err, errFileTooLarge) {
+			return nil, serviceerrors.NewInvalidArgumentError(fmt.Sprintf("file exceeds the limit of %d bytes", s.upload.MaxFileSize))
+		}
+		if deadline.Exceeded(err) {
+			return nil, serviceerrors.NewDeadlineExceededError(err)
+		}
+		return nil, serviceerrors.NewUnavailableError("failed to upload asset to Cloudinary")
+	}
+
+	if err := s.completeUpload(ctx, uploadResultToWebhook(result),
+		audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note),
+	); err != nil {
+		if errors.Is(err, errDuplicateUpload) {
+			return nil, serviceerrors.NewConflictError(err.Error())
+		}
+		return nil, err
+	}
+	return s.getAsset(ctx, asset.ID, []assetrepo.Scope{assetrepo.ScopeUploadURLGenerated, assetrepo.ScopeActive})
+}
+
+// markUploadFailed marks the asset of a failed upload as broken, so its public ID is not reported as uploaded.
+func (s *Service) markUploadFailed(ctx context.Context, asset *assetmodel.Asset, req *assetmodel.UploadRequest, cause error) error {
+	note := "Upload through the service failed: " + cause.Error()
+	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		auditOpts := &types.AuditTrailOptions{AdminName: req.AdminName, Note: note}
+		if asset.CreatedBy != nil {
+			auditOpts.AdminID = *asset.CreatedBy
+		}
+		if _, err := txRepo.MarkAsBroken(ctx, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}, auditOpts); err != nil {
+			s.log(ctx).Error("failed to mark asset of failed upload as broken", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to mark asset of failed upload as broken: %w", err)
+		}
+		return s.recordAudit(ctx, tx, auditmodel.ActionMarkAsBroken, asset.ID,
+			statusSnapshot(asset.Status), statusSnapshot(assetmodel.StatusBroken),
+			audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(note),
+		)
+	})
+	s.invalidate(ctx, asset.ID)
+	return err
+}
+
+// uploadResultToWebhook converts the upload API result to the upload webhook payload, which carries
+// the same asset details.
+func uploadResultToWebhook(result *uploader.UploadResult) *assetmodel.CloudinaryUploadWebhook {
+	return &assetmodel.CloudinaryUploadWebhook{
+		AssetID:      result.AssetID,
+		PublicID:     result.PublicID,
+		Width:        result.Width,
+		Height:       result.Height,
+		Bytes:        int64(result.Bytes),
+		Format:       result.Format,
+		ResourceType: result.ResourceType,
+		CreatedAt:    result.CreatedAt,
+		Tags:         result.Tags,
+		Url:          result.URL,
+		SecureUrl:    result.SecureURL,
+		AssetFolder:  result.AssetFolder,
+		DisplayName:  result.DisplayName,
+		Etag:         result.Etag,
+		Phash:        result.Phash,
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+)
+
+// creatorPlaceholder is replaced with the ID of the uploading admin in [UploadPolicy.CreatorFolder].
+const creatorPlaceholder = "{creator}"
+
+// UploadPolicy holds the preflight rules of image uploads. Zero limits and empty lists allow any upload.
+type UploadPolicy struct {
+	// Folders lists the folders public IDs may be placed in, along with their subfolders. An empty
+	// entry allows the root folder.
+	Folders []string
+	// CreatorFolder is prefixed to the public IDs, "{creator}" is replaced with the ID of the uploading
+	// admin. Folders are checked before the prefix is added.
+	CreatorFolder string
+	// MaxFileSize, MaxWidth and MaxHeight limit the declared file of a signed upload.
+	MaxFileSize int64
+	MaxWidth    int
+	MaxHeight   int
+	// Formats lists the allowed image formats.
+	Formats []string
+	// OwnerTypeFormats overrides Formats for the uploads of an owner type.
+	OwnerTypeFormats map[string][]string
+}
+
+// preflight checks the declared file of a signed upload against the policy. It returns the normalized
+// public ID and the formats to sign along with the upload, or the field errors of the request.
+func (p *UploadPolicy) preflight(req *assetmodel.CreateSignedUploadURLRequest) (string, []string, error) {
+	errs := validation.Errors{}
+
+	publicID, err := p.publicID(req.PublicID, req.AdminID)
+	if err != nil {
+		errs["public_id"] = err
+	}
+	formats := p.formats(req.OwnerType)
+	if req.Format != "" && len(formats) > 0 && !slices.Contains(formats, strings.ToLower(req.Format)) {
+		errs["format"] = fmt.Errorf("must be one of %s", strings.Join(formats, ", "))
+	}
+	if p.MaxFileSize > 0 && req.Bytes > p.MaxFileSize {
+		errs["bytes"] = fmt.Errorf("must be no greater than %d", p.MaxFileSize)
+	}
+	if p.MaxWidth > 0 && req.Width > p.MaxWidth {
+		errs["width"] = fmt.Errorf("must be no greater than %d", p.MaxWidth)
+	}
+	if p.MaxHeight > 0 && req.Height > p.MaxHeight {
+		errs["height"] = fmt.Errorf("must be no greater than %d", p.MaxHeight)
+	}
+	if len(errs) > 0 {
+		return "", nil, errs
+	}
+	return publicID, formats, nil
+}
+
+// publicID normalizes the public ID, checks its folder and prefixes it with the creator folder.
+// Public IDs which already start with the creator folder are not prefixed again.
+func (p *UploadPolicy) publicID(publicID, creatorID string) (string, error) {
+	segments, err := splitPath(publicID)
+	if err != nil {
+		return "", err
+	}
+	if len(segments) == 0 {
+		return "", errors.New("must not be empty")
+	}
+
+	creatorFolder := strings.Trim(strings.ReplaceAll(p.CreatorFolder, creatorPlaceholder, creatorID), "/")
+	normalized := strings.Join(segments, "/")
+	if creatorFolder != "" && strings.HasPrefix(normalized, creatorFolder+"/") {
+		segments = segments[strings.Count(creatorFolder, "/")+1:]
+	}
+
+	if folder := strings.Join(segments[:len(segments)-1], "/"); !p.folderAllowed(folder) {
+		return "", fmt.Errorf("folder %q is not allowed", folder)
+	}
+	if creatorFolder == "" {
+		return strings.Join(segments, "/"), nil
+	}
+	return creatorFolder + "/" + strings.Join(segments, "/"), nil
+}
+
+func (p *UploadPolicy) folderAllowed(folder string) bool {
+	if len(p.Folders) == 0 {
+		return true
+	}
+	for _, allowed := range p.Folders {
+		allowed = strings.Trim(allowed, "/")
+		if folder == allowed || (allowed != "" && strings.HasPrefix(folder, allowed+"/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// formats returns the allowed formats of the uploads of the owner type, nil if any format is allowed.
+func (p *UploadPolicy) formats(ownerType string) []string {
+	if formats, ok := p.OwnerTypeFormats[ownerType]; ok && ownerType != "" {
+		return formats
+	}
+	return p.Formats
+}
+
+// splitPath splits the public ID into its segments, dropping empty ones and surrounding whitespace.
+func splitPath(publicID string) ([]string, error) {
+	var segments []string
+	for _, segment := range strings.Split(publicID, "/") {
+		segment = strings.TrimSpace(segment)
+		switch segment {
+		case "":
+			continue
+		case ".", "..":
+			return nil, errors.New("must not contain relative path segments")
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
@@ -18,7 +18,7 @@
 package cloudinary
 
 import (
-	"reflect"
+	"slices"
 
 	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
 	"github.com/mikhail5545/media-service-go/internal/util/patch"
@@ -34,9 +34,16 @@ func buildUpdatesFromWebhook(existing *assetmodel.Asset, webhook *assetmodel.Clo
 	patch.UpdateIfChanged(updates, "format", &webhook.Format, &existing.Format)
 	patch.UpdateIfChanged(updates, "width", &webhook.Width, existing.Width)
 	patch.UpdateIfChanged(updates, "height", &webhook.Height, existing.Height)
+	patch.UpdateIfChanged(updates, "bytes", &webhook.Bytes, existing.Bytes)
 	patch.UpdateIfChanged(updates, "resource_type", &webhook.ResourceType, &existing.ResourceType)
+	if webhook.Etag != "" {
+		patch.UpdateIfChanged(updates, "etag", &webhook.Etag, existing.Etag)
+	}
+	if webhook.Phash != "" {
+		patch.UpdateIfChanged(updates, "phash", &webhook.Phash, existing.Phash)
+	}
 
-	if len(webhook.Tags) > 0 && !reflect.DeepEqual(webhook.Tags, existing.Tags) {
+	if len(webhook.Tags) > 0 && !slices.Equal(webhook.Tags, existing.Tags) {
 		updates["tags"] = webhook.Tags
 	}
 	return updates
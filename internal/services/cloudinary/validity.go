@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	"go.uber.org/zap"
+)
+
+// ValidateAssets reports the validity of each requested asset, so other services can check that the
+// asset IDs they store still exist and are healthy. The states are read with a single query, the
+// results are in the order of the requested IDs and a repeated ID is reported once.
+func (s *Service) ValidateAssets(ctx context.Context, req *assetmodel.ValidateAssetsRequest) ([]*assetmodel.AssetValidity, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	ids := make(uuid.UUIDs, 0, len(req.IDs))
+	seen := make(map[uuid.UUID]bool, len(req.IDs))
+	for _, raw := range req.IDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, serviceerrors.NewValidationFailedError(err)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	assets, err := s.repo.ListStatuses(ctx, ids)
+	if err != nil {
+		s.log(ctx).Error("failed to list asset statuses", zap.Error(err), zap.Int("count", len(ids)))
+		return nil, fmt.Errorf("failed to list asset statuses: %w", err)
+	}
+	statuses := make(map[uuid.UUID]assetmodel.Status, len(assets))
+	for _, asset := range assets {
+		statuses[asset.ID] = asset.Status
+	}
+
+	results := make([]*assetmodel.AssetValidity, 0, len(ids))
+	for _, id := range ids {
+		validity := assetmodel.ValidityMissing
+		if status, ok := statuses[id]; ok {
+			validity = assetmodel.ValidityOf(status)
+		}
+		results = append(results, &assetmodel.AssetValidity{ID: id, Validity: validity})
+	}
+	return results, nil
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cloudinary
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/metadata"
+	watermarkmodel "github.com/mikhail5545/media-service-go/internal/models/watermark"
+	"go.uber.org/zap"
+)
+
+// gravities maps the watermark positions to the Cloudinary gravities of the overlay.
+var gravities = map[watermarkmodel.Position]string{
+	watermarkmodel.PositionTopLeft:     "north_west",
+	watermarkmodel.PositionTopRight:    "north_east",
+	watermarkmodel.PositionBottomLeft:  "south_west",
+	watermarkmodel.PositionBottomRight: "south_east",
+	watermarkmodel.PositionCenter:      "center",
+}
+
+// watermarkURL returns the delivery URL of the image with the overlay of the watermark configured
+// for its creator or owner types. The URL is returned unchanged if no image watermark is configured.
+func (s *Service) watermarkURL(ctx context.Context, secureURL string, metadata *metadatamodel.AssetMetadata) (string, error) {
+	if s.watermarks == nil {
+		return secureURL, nil
+	}
+	ownerTypes := make([]string, 0, len(metadata.Owners))
+	for _, owner := range metadata.Owners {
+		ownerTypes = append(ownerTypes, owner.OwnerType)
+	}
+	watermark, err := s.watermarks.Resolve(ctx, metadata.CreatorID, ownerTypes)
+	if err != nil {
+		return "", err
+	}
+	if watermark == nil || watermark.CloudinaryPublicID == nil {
+		return secureURL, nil
+	}
+	// Transformations go right after the delivery type, e.g. .../image/upload/<transformation>/v1/sample.jpg.
+	prefix, rest, ok := strings.Cut(secureURL, "/upload/")
+	if !ok {
+		s.log(ctx).Warn("cannot apply watermark to delivery URL", zap.String("secure_url", secureURL))
+		return secureURL, nil
+	}
+	return prefix + "/upload/" + watermarkTransformation(watermark) + "/" + rest, nil
+}
+
+// watermarkTransformation builds the overlay transformation of the watermark. The width and the
+// offsets are relative to the delivered image.
+func watermarkTransformation(watermark *watermarkmodel.Watermark) string {
+	layer := []string{"l_" + strings.ReplaceAll(*watermark.CloudinaryPublicID, "/", ":")}
+	if watermark.Width > 0 {
+		layer = append(layer, "w_"+ratio(watermark.Width), "fl_relative")
+	}
+	layer = append(layer, fmt.Sprintf("o_%d", watermark.Opacity))
+
+	apply := []string{"fl_layer_apply", "g_" + gravities[watermark.Position]}
+	if watermark.Position != watermarkmodel.PositionCenter && watermark.Margin > 0 {
+		apply = append(apply, "x_"+ratio(watermark.Margin), "y_"+ratio(watermark.Margin))
+	}
+	return strings.Join(layer, ",") + "/" + strings.Join(apply, ",")
+}
+
+// ratio converts a percentage to the decimal Cloudinary treats as relative to the image, e.g. 5 to 0.05.
+func ratio(percent int) string {
+	return strconv.FormatFloat(float64(percent)/100, 'f', -1, 64)
+}
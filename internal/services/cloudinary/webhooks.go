@@ -20,16 +20,25 @@ package cloudinary
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	apiclient "github.com/mikhail5545/media-service-go/internal/apiclients/cloudinary"
+	"github.com/mikhail5545/media-service-go/internal/audit"
 	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
 	"github.com/mikhail5545/media-service-go/internal/database/types"
+	"github.com/mikhail5545/media-service-go/internal/environment"
 	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
 	assetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
 	cldtypes "github.com/mikhail5545/media-service-go/internal/models/cloudinary/types"
+	outboxmodel "github.com/mikhail5545/media-service-go/internal/models/outbox"
+	webhookmodel "github.com/mikhail5545/media-service-go/internal/models/webhook"
+	"github.com/mikhail5545/media-service-go/internal/services/webhook"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -38,40 +47,68 @@ type genericData struct {
 	NotificationType string `json:"notification_type"`
 }
 
-// HandleWebhook processes incoming webhook notifications from Cloudinary.
-// It validates the signature and routes the webhook to the appropriate handler based on its type.
-func (s *Service) HandleWebhook(ctx context.Context, payload []byte, timestamp, signature string) error {
-	// First, validate signature
+// VerifyWebhook verifies the signature of a Cloudinary notification against its raw payload and
+// timestamp and returns the environment whose secret signed it. The notification must only be
+// processed in that environment.
+func (s *Service) VerifyWebhook(ctx context.Context, payload []byte, timestamp, signature string) (string, error) {
 	timestampInt64, err := time.Parse(time.RFC3339, timestamp)
 	if err != nil {
-		return serviceerrors.NewInvalidArgumentError(err)
+		return "", serviceerrors.NewInvalidArgumentError(err)
 	}
-	if !s.apiClient.VerifyNotificationSignature(ctx, &apiclient.VerificationParams{
+	params := &apiclient.VerificationParams{
 		Payload:           string(payload),
 		ReceivedSignature: signature,
 		Timestamp:         timestampInt64.Unix(),
 		ValidFor:          7200, // validFor as two hours
-	}) {
-		s.logger.Warn("received webhook with invalid signature")
-		return serviceerrors.NewPermissionDeniedError("invalid signature")
 	}
+	for _, env := range s.environments {
+		if s.apiClient.VerifyNotificationSignature(environment.WithContext(ctx, env), params) {
+			return env, nil
+		}
+	}
+	s.log(ctx).Warn("received webhook with invalid signature")
+	return "", serviceerrors.NewPermissionDeniedError("invalid signature")
+}
 
-	// Determine webhook type
+// WebhookHandlers returns the handler processing the Cloudinary notifications queued by the webhook
+// endpoint. The queue processes each notification in the environment it was verified in.
+func (s *Service) WebhookHandlers() map[webhookmodel.Provider]webhook.Handler {
+	return map[webhookmodel.Provider]webhook.Handler{
+		webhookmodel.ProviderCloudinary: s.HandleWebhook,
+	}
+}
+
+// HandleWebhook processes a verified webhook notification from Cloudinary.
+// It routes the webhook to the appropriate handler based on its type.
+func (s *Service) HandleWebhook(ctx context.Context, payload []byte) error {
 	var generic genericData
 	if err := json.Unmarshal(payload, &generic); err != nil {
 		return serviceerrors.NewValidationFailedError(err)
 	}
 
-	switch generic.NotificationType {
-	case "upload":
-		return s.handleUploadWebhook(ctx, payload)
-	case "rename":
-		return s.handleRenameWebhook(ctx, payload)
-	case "delete":
-		return s.handleDeleteWebhook(ctx, payload)
-	default:
+	handler, ok := s.webhookHandlers()[generic.NotificationType]
+	if !ok {
+		s.log(ctx).Debug("ignoring unsupported Cloudinary notification", zap.String("webhook_notification_type", generic.NotificationType))
 		return nil
 	}
+	return handler(ctx, payload)
+}
+
+// webhookHandler processes the payload of a verified Cloudinary notification.
+type webhookHandler func(ctx context.Context, payload []byte) error
+
+// webhookHandlers maps Cloudinary notification types to their handlers.
+// Notification types missing from the map are acknowledged and ignored.
+func (s *Service) webhookHandlers() map[string]webhookHandler {
+	return map[string]webhookHandler{
+		"upload":                        s.handleUploadWebhook,
+		"rename":                        s.handleRenameWebhook,
+		"delete":                        s.handleDeleteWebhook,
+		"eager":                         s.handleEagerWebhook,
+		"moderation":                    s.handleModerationWebhook,
+		"resource_tags_changed":         s.handleTagsChangedWebhook,
+		"resource_display_name_changed": s.handleDisplayNameChangedWebhook,
+	}
 }
 
 // HandleUploadWebhook processes incoming webhook notifications from Cloudinary regarding asset uploads.
@@ -81,8 +118,24 @@ func (s *Service) handleUploadWebhook(ctx context.Context, payload []byte) error
 	if err := json.Unmarshal(payload, &data); err != nil {
 		return serviceerrors.NewValidationFailedError(err)
 	}
+	err := s.completeUpload(ctx, &data, audit.WithEvent(data.NotificationType, data.RequestID))
+	if errors.Is(err, errDuplicateUpload) {
+		// The duplicate is already rejected, Cloudinary must not retry the notification.
+		s.log(ctx).Info("rejected duplicate upload", zap.Error(err), zap.String("public_id", data.PublicID))
+		return nil
+	}
+	return err
+}
 
-	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+// completeUpload updates the uploaded asset with the upload details and publishes the ready event.
+// Uploads which were already applied are ignored, so uploads made through the service are not
+// applied again when their webhook arrives.
+//
+// If duplicates are blocked and the file is identical to an active asset, the asset is marked as
+// broken, the file is deleted from Cloudinary and errDuplicateUpload is returned.
+func (s *Service) completeUpload(ctx context.Context, data *assetmodel.CloudinaryUploadWebhook, opts ...audit.EntryOption) error {
+	var readyAsset, rejectedAsset, duplicate *assetmodel.Asset
+	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
 		if data.PublicID == "" {
@@ -93,17 +146,51 @@ func (s *Service) handleUploadWebhook(ctx context.Context, payload []byte) error
 			return err
 		}
 
-		updates := buildUpdatesFromWebhook(asset, &data)
+		if duplicate, err = s.findDuplicate(ctx, txRepo, asset, data.Etag); err != nil {
+			return err
+		}
+		if duplicate != nil {
+			rejectedAsset = asset
+			return s.rejectDuplicate(ctx, tx, asset, duplicate, opts...)
+		}
+
+		updates := buildUpdatesFromWebhook(asset, data)
 		if len(updates) == 0 {
 			return nil
 		}
 
 		if _, err := txRepo.Update(ctx, updates, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}); err != nil {
-			s.logger.Error("failed to update asset from webhook", zap.Error(err), zap.String("asset_id", asset.ID.String()), zap.String("public_id", data.PublicID))
-			return fmt.Errorf("failed to update asset from webhook: %w", err)
+			s.log(ctx).Error("failed to update uploaded asset", zap.Error(err), logging.AssetID(asset.ID), zap.String("public_id", data.PublicID))
+			return fmt.Errorf("failed to update uploaded asset: %w", err)
+		}
+		// There is no previous state to capture, the asset only gets the uploaded file details.
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionStateChanged, asset.ID, nil, updates, opts...); err != nil {
+			return err
+		}
+		if s.enrichment != nil {
+			if err := s.enqueueEvent(ctx, s.outboxRepo.WithTx(tx), outboxmodel.EventImageEnrich, &outboxmodel.EnrichPayload{
+				AssetID: asset.ID,
+			}); err != nil {
+				return err
+			}
 		}
+		readyAsset = asset
 		return nil
 	})
+	if err == nil && rejectedAsset != nil {
+		s.invalidate(ctx, rejectedAsset.ID)
+		// The asset stays broken even if the file can not be deleted, it is never served.
+		if err := s.apiClient.DeleteAsset(ctx, data.PublicID, data.ResourceType); err != nil {
+			s.log(ctx).Warn("failed to delete duplicate upload from Cloudinary", zap.Error(err),
+				logging.AssetID(rejectedAsset.ID), zap.String("public_id", data.PublicID))
+		}
+		return fmt.Errorf("%w of asset %s", errDuplicateUpload, duplicate.ID)
+	}
+	if err == nil && readyAsset != nil {
+		s.invalidate(ctx, readyAsset.ID)
+		s.publishEvent(ctx, events.TypeAssetReady, readyAsset.ID, withExternalID(&data.PublicID))
+	}
+	return err
 }
 
 // handleRenameWebhook processes incoming webhook notifications from Cloudinary regarding asset renames.
@@ -114,20 +201,25 @@ func (s *Service) handleRenameWebhook(ctx context.Context, payload []byte) error
 		return serviceerrors.NewValidationFailedError(err)
 	}
 
-	logger := s.logger.With(
+	logger := s.log(ctx).With(
 		zap.String("webhook_notification_type", data.NotificationType),
 		zap.String("triggered_by.source", data.NotificationContext.TriggeredBy.Source),
 		zap.String("triggered_by.id", data.NotificationContext.TriggeredBy.ID),
 	)
-	logger.Info("received Cloudinary delete webhook")
+	logger.Info("received Cloudinary rename webhook")
 
-	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+	var renamedID uuid.UUID
+	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
 		asset, err := s.getByPublicID(ctx, txRepo, data.FromPublicID)
 		if err != nil {
-			logger.Error("failed to retrieve asset by old Cloudinary Public ID", zap.Error(err), zap.String("from_public_id", data.FromPublicID))
-			return nil
+			if errors.Is(err, serviceerrors.ErrNotFound) {
+				// The asset is not tracked by the system, there is nothing to rename
+				logger.Warn("asset with old Cloudinary Public ID not found", zap.String("from_public_id", data.FromPublicID))
+				return nil
+			}
+			return err
 		}
 
 		updates := map[string]any{
@@ -135,11 +227,20 @@ func (s *Service) handleRenameWebhook(ctx context.Context, payload []byte) error
 		}
 
 		if _, err := txRepo.Update(ctx, updates, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}); err != nil {
-			logger.Error("failed to update asset Cloudinary Public ID from webhook", zap.Error(err), zap.String("asset_id", asset.ID.String()), zap.String("from_public_id", data.FromPublicID), zap.String("to_public_id", data.ToPublicID))
-			return nil
+			logger.Error("failed to update asset Cloudinary Public ID from webhook", zap.Error(err), logging.AssetID(asset.ID), zap.String("from_public_id", data.FromPublicID), zap.String("to_public_id", data.ToPublicID))
+			return err
+		}
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionStateChanged, asset.ID,
+			map[string]any{"cloudinary_public_id": data.FromPublicID}, updates,
+			audit.WithEvent(data.NotificationType, data.RequestID),
+		); err != nil {
+			return err
 		}
+		renamedID = asset.ID
 		return nil
 	})
+	s.invalidate(ctx, renamedID)
+	return err
 }
 
 // handleDeleteWebhook processes incoming webhook notifications from Cloudinary regarding asset deletions.
@@ -151,21 +252,26 @@ func (s *Service) handleDeleteWebhook(ctx context.Context, payload []byte) error
 	}
 
 	// Use dedicated logger for webhook processing
-	logger := s.logger.With(
+	logger := s.log(ctx).With(
 		zap.String("webhook_notification_type", data.NotificationType),
 		zap.String("triggered_by.source", data.NotificationContext.TriggeredBy.Source),
 		zap.String("triggered_by.id", data.NotificationContext.TriggeredBy.ID),
 	)
 	logger.Info("received Cloudinary delete webhook")
 
+	// This webhook may contain multiple public IDs
+	pubIDs := make([]string, 0, len(data.Resources))
+	for i := range data.Resources {
+		pubIDs = append(pubIDs, data.Resources[i].PublicID)
+	}
+
 	var toDelete []*assetmodel.Asset
 	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
-		// This webhook may contain multiple public IDs
-		pubIDs := make([]string, 0, len(data.Resources))
-		for i := range data.Resources {
-			pubIDs = append(pubIDs, data.Resources[i].PublicID)
+		// The files are gone in both cases below, they can only be restored from the Cloudinary backup.
+		if err := s.markDestroyed(ctx, txRepo, pubIDs); err != nil {
+			return err
 		}
 		// There is two cases here:
 		// 1. Asset was already archived in our system (deleted locally and from Cloudinary, we received webhook about this) - in this case we do nothing.
@@ -173,9 +279,33 @@ func (s *Service) handleDeleteWebhook(ctx context.Context, payload []byte) error
 		affected, assets, err := s.archiveOnDeleteWebhook(ctx, txRepo, pubIDs, data.NotificationContext)
 		if err != nil {
 			logger.Error("failed to archive assets on Cloudinary delete webhook", zap.Error(err))
-			return nil
+			return err
 		}
 		logger.Info("archived assets on Cloudinary delete webhook", zap.Int64("affected_assets", affected))
+		if len(assets) == 0 {
+			return nil
+		}
+		for _, asset := range assets {
+			if err := s.recordAudit(ctx, tx, auditmodel.ActionArchive, asset.ID,
+				statusSnapshot(asset.Status), statusSnapshot(assetmodel.StatusArchived),
+				audit.WithEvent(data.NotificationType, data.NotificationContext.TriggeredBy.ID),
+			); err != nil {
+				return err
+			}
+		}
+
+		owned, err := s.snapshotOwnedAssets(ctx, txRepo, assets, assetmodel.SnapshotReasonDeleted)
+		if err != nil {
+			return err
+		}
+		if len(owned) > 0 {
+			// Owners must be notified about the deletion, which is delivered via transactional outbox
+			if err := s.enqueueEvent(ctx, s.outboxRepo.WithTx(tx), outboxmodel.EventImagesForceDeleted, &outboxmodel.DeletePayload{
+				AssetIDs: owned,
+			}); err != nil {
+				return err
+			}
+		}
 		toDelete = assets
 		return nil
 	})
@@ -190,14 +320,21 @@ func (s *Service) handleDeleteWebhook(ctx context.Context, payload []byte) error
 		} else {
 			logger.Info("deleted asset metadata after Cloudinary delete webhook", zap.Int64("deleted_metadata_records", deleted))
 		}
+		for _, asset := range toDelete {
+			s.invalidate(ctx, asset.ID)
+			s.publishEvent(ctx, events.TypeAssetDeleted, asset.ID, withExternalID(&asset.CloudinaryPublicID), events.WithData("permanent", "false"))
+		}
 	}
-	return nil
+	if err == nil {
+		s.checkBackups(ctx, pubIDs)
+	}
+	return err
 }
 
 func (s *Service) archiveOnDeleteWebhook(ctx context.Context, txRepo *assetrepo.Repository, pubIDs []string, notificationContext cldtypes.NotificationContext) (int64, []*assetmodel.Asset, error) {
 	assets, err := s.listByPublicIDs(ctx, txRepo, pubIDs, assetrepo.ScopeUploadURLGenerated, assetrepo.ScopeActive, assetrepo.ScopeBroken) // only non archived assets
 	if err != nil {
-		return 0, nil, nil
+		return 0, nil, err
 	}
 	if len(assets) == 0 {
 		return 0, nil, nil
@@ -0,0 +1,345 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package collection provides the service for managing named collections of MUX and Cloudinary assets.
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	cldassetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
+	collectionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/collection"
+	muxassetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	collectionmodel "github.com/mikhail5545/media-service-go/internal/models/collection"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// CollectionService defines the interface for managing asset collections.
+type CollectionService interface {
+	// Get retrieves a single collection.
+	Get(ctx context.Context, req *collectionmodel.GetRequest) (*collectionmodel.Collection, error)
+	// List retrieves a page of collections, newest first.
+	List(ctx context.Context, req *collectionmodel.ListRequest) ([]*collectionmodel.Collection, string, error)
+	// Create creates a new collection. Collection names are unique.
+	Create(ctx context.Context, req *collectionmodel.CreateRequest) (*collectionmodel.Collection, error)
+	// Update changes the name or the description of a collection.
+	Update(ctx context.Context, req *collectionmodel.UpdateRequest) (*collectionmodel.Collection, error)
+	// Delete deletes a collection. The assets of the collection are left untouched.
+	Delete(ctx context.Context, req *collectionmodel.DeleteRequest) error
+	// AddAssets adds assets to a collection. Only active assets, or MUX assets awaiting upload, can be added.
+	// Assets which already belong to the collection are skipped.
+	AddAssets(ctx context.Context, req *collectionmodel.ManageAssetsRequest) error
+	// RemoveAssets removes assets from a collection.
+	RemoveAssets(ctx context.Context, req *collectionmodel.ManageAssetsRequest) error
+}
+
+// Service implements the CollectionService interface.
+type Service struct {
+	repo    *collectionrepo.Repository
+	muxRepo *muxassetrepo.Repository
+	cldRepo *cldassetrepo.Repository
+	logger  *zap.Logger
+}
+
+// defaultListPageSize is used by List when the request does not specify a page size.
+const defaultListPageSize = 50
+
+var _ CollectionService = (*Service)(nil)
+
+type NewParams struct {
+	Repo *collectionrepo.Repository
+	// MuxRepo and CldRepo are used to check that assets exist before they are added to a collection.
+	MuxRepo *muxassetrepo.Repository
+	CldRepo *cldassetrepo.Repository
+}
+
+func New(params *NewParams, logger *zap.Logger) *Service {
+	return &Service{
+		repo:    params.Repo,
+		muxRepo: params.MuxRepo,
+		cldRepo: params.CldRepo,
+		logger:  logger.With(zap.String("layer", "service"), zap.String("service", "collection")),
+	}
+}
+
+// log returns the service logger enriched with the request-scoped fields stored in ctx.
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// Get retrieves a single collection.
+func (s *Service) Get(ctx context.Context, req *collectionmodel.GetRequest) (*collectionmodel.Collection, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	id, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.get(ctx, s.repo, id)
+}
+
+// List retrieves a page of collections, newest first.
+func (s *Service) List(ctx context.Context, req *collectionmodel.ListRequest) ([]*collectionmodel.Collection, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultListPageSize
+	}
+	collections, nextPageToken, err := s.repo.List(ctx, pageSize, req.PageToken)
+	if err != nil {
+		s.log(ctx).Error("failed to list collections", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to list collections: %w", err)
+	}
+	return collections, nextPageToken, nil
+}
+
+// Create creates a new collection. Collection names are unique.
+func (s *Service) Create(ctx context.Context, req *collectionmodel.CreateRequest) (*collectionmodel.Collection, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+
+	collection := &collectionmodel.Collection{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		if err := s.checkNameAvailable(ctx, txRepo, req.Name, uuid.Nil); err != nil {
+			return err
+		}
+		if err := txRepo.Create(ctx, collection); err != nil {
+			s.log(ctx).Error("failed to create collection", zap.Error(err), zap.String("name", req.Name))
+			return fmt.Errorf("failed to create collection: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// Update changes the name or the description of a collection. An empty description clears it.
+func (s *Service) Update(ctx context.Context, req *collectionmodel.UpdateRequest) (*collectionmodel.Collection, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	id, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var collection *collectionmodel.Collection
+	err = s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		updates := make(map[string]any, 2)
+		if req.Name != nil {
+			if err := s.checkNameAvailable(ctx, txRepo, *req.Name, id); err != nil {
+				return err
+			}
+			updates["name"] = *req.Name
+		}
+		if req.Description != nil {
+			if *req.Description == "" {
+				updates["description"] = nil
+			} else {
+				updates["description"] = *req.Description
+			}
+		}
+
+		updated, err := txRepo.Update(ctx, id, updates)
+		if err != nil {
+			s.log(ctx).Error("failed to update collection", zap.Error(err), zap.String("collection_id", req.ID))
+			return fmt.Errorf("failed to update collection: %w", err)
+		}
+		if updated == 0 {
+			return serviceerrors.NewNotFoundError("collection not found")
+		}
+
+		collection, err = s.get(ctx, txRepo, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// Delete deletes a collection. The assets of the collection are left untouched.
+func (s *Service) Delete(ctx context.Context, req *collectionmodel.DeleteRequest) error {
+	if err := req.Validate(); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	id, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return err
+	}
+	deleted, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		s.log(ctx).Error("failed to delete collection", zap.Error(err), zap.String("collection_id", req.ID))
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+	if deleted == 0 {
+		return serviceerrors.NewNotFoundError("collection not found")
+	}
+	return nil
+}
+
+// AddAssets adds assets to a collection. Only active assets, or MUX assets awaiting upload, can be added.
+// Assets which already belong to the collection are skipped.
+func (s *Service) AddAssets(ctx context.Context, req *collectionmodel.ManageAssetsRequest) error {
+	if err := req.Validate(); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	id, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return err
+	}
+	assetIDs := uniqueIDs(parsing.StrToUUIDs(req.AssetIDs))
+
+	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		if _, err := s.get(ctx, txRepo, id); err != nil {
+			return err
+		}
+		if err := s.checkAssetsExist(ctx, req.Provider, assetIDs); err != nil {
+			return err
+		}
+		if err := txRepo.AddAssets(ctx, id, req.Provider, assetIDs); err != nil {
+			s.log(ctx).Error("failed to add assets to collection", zap.Error(err), zap.String("collection_id", req.ID))
+			return fmt.Errorf("failed to add assets to collection: %w", err)
+		}
+		return nil
+	})
+}
+
+// RemoveAssets removes assets from a collection.
+func (s *Service) RemoveAssets(ctx context.Context, req *collectionmodel.ManageAssetsRequest) error {
+	if err := req.Validate(); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	id, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return err
+	}
+	assetIDs := uniqueIDs(parsing.StrToUUIDs(req.AssetIDs))
+
+	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		if _, err := s.get(ctx, txRepo, id); err != nil {
+			return err
+		}
+		if _, err := txRepo.RemoveAssets(ctx, id, req.Provider, assetIDs); err != nil {
+			s.log(ctx).Error("failed to remove assets from collection", zap.Error(err), zap.String("collection_id", req.ID))
+			return fmt.Errorf("failed to remove assets from collection: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *Service) get(ctx context.Context, repo *collectionrepo.Repository, id uuid.UUID) (*collectionmodel.Collection, error) {
+	collection, err := repo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, serviceerrors.NewNotFoundError("collection not found")
+		}
+		s.log(ctx).Error("failed to get collection", zap.Error(err), zap.String("collection_id", id.String()))
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	return collection, nil
+}
+
+func (s *Service) checkNameAvailable(ctx context.Context, repo *collectionrepo.Repository, name string, excludeID uuid.UUID) error {
+	count, err := repo.CountByName(ctx, name, excludeID)
+	if err != nil {
+		s.log(ctx).Error("failed to check collection name", zap.Error(err), zap.String("name", name))
+		return fmt.Errorf("failed to check collection name: %w", err)
+	}
+	if count > 0 {
+		return serviceerrors.NewAlreadyExistsError("collection with the given name already exists")
+	}
+	return nil
+}
+
+// checkAssetsExist verifies that every asset of the provider exists and can be added to a collection.
+func (s *Service) checkAssetsExist(ctx context.Context, provider collectionmodel.Provider, assetIDs uuid.UUIDs) error {
+	var (
+		found int
+		err   error
+	)
+	switch provider {
+	case collectionmodel.ProviderMux:
+		found, err = s.countMuxAssets(ctx, assetIDs)
+	case collectionmodel.ProviderCloudinary:
+		found, err = s.countCloudinaryAssets(ctx, assetIDs)
+	default:
+		return serviceerrors.NewInvalidArgumentError(fmt.Sprintf("unknown provider %q", provider))
+	}
+	if err != nil {
+		s.log(ctx).Error("failed to check collection assets", zap.Error(err), zap.String("provider", string(provider)))
+		return fmt.Errorf("failed to check collection assets: %w", err)
+	}
+	if found != len(assetIDs) {
+		return serviceerrors.NewNotFoundError("some assets were not found or are archived or broken")
+	}
+	return nil
+}
+
+// uniqueIDs returns the IDs without duplicates, preserving their order.
+func uniqueIDs(ids uuid.UUIDs) uuid.UUIDs {
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	unique := make(uuid.UUIDs, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+func (s *Service) countMuxAssets(ctx context.Context, assetIDs uuid.UUIDs) (int, error) {
+	assets, err := s.muxRepo.ListAll(ctx, muxassetrepo.ListAllOptions{
+		IDs:    assetIDs,
+		Fields: []string{"id"},
+	}, muxassetrepo.ScopeActive, muxassetrepo.ScopeUploadURLGenerated)
+	return len(assets), err
+}
+
+func (s *Service) countCloudinaryAssets(ctx context.Context, assetIDs uuid.UUIDs) (int, error) {
+	assets, err := s.cldRepo.ListAll(ctx, cldassetrepo.ListAllOptions{
+		IDs:    assetIDs,
+		Fields: []string{"id"},
+	}, cldassetrepo.ScopeActive)
+	return len(assets), err
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package cost
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	costmodel "github.com/mikhail5545/media-service-go/internal/models/cost"
+)
+
+// csvColumns are the columns of the CSV export of a report. The group is "total", "creator" or
+// "owner_type", the overall total is reported under the "all" provider.
+var csvColumns = []string{
+	"month", "currency", "provider", "group", "creator_id", "owner_type", "assets",
+	"encoded_minutes", "stored_minutes", "streamed_minutes", "stored_gb", "delivered_gb",
+	"encoding", "storage", "delivery", "total",
+}
+
+// WriteCSV writes the report as CSV, one row for the total and for each group of each provider
+// followed by the overall total.
+func WriteCSV(w io.Writer, report *costmodel.Report) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvColumns); err != nil {
+		return fmt.Errorf("failed to write cost report header: %w", err)
+	}
+	write := func(provider, group string, cost *costmodel.Cost) error {
+		creatorID := ""
+		if cost.CreatorID != nil {
+			creatorID = cost.CreatorID.String()
+		}
+		return cw.Write([]string{
+			report.Month, report.Currency, provider, group, creatorID, cost.OwnerType,
+			strconv.FormatInt(cost.Assets, 10),
+			formatFloat(cost.EncodedMinutes), formatFloat(cost.StoredMinutes), formatFloat(cost.StreamedMinutes),
+			formatFloat(cost.StoredGB), formatFloat(cost.DeliveredGB),
+			formatFloat(cost.Encoding), formatFloat(cost.Storage), formatFloat(cost.Delivery), formatFloat(cost.Total),
+		})
+	}
+	for _, provider := range report.Providers {
+		name := string(provider.Provider)
+		if err := write(name, "total", provider.Total); err != nil {
+			return fmt.Errorf("failed to write cost report row: %w", err)
+		}
+		for _, cost := range provider.ByCreator {
+			if err := write(name, "creator", cost); err != nil {
+				return fmt.Errorf("failed to write cost report row: %w", err)
+			}
+		}
+		for _, cost := range provider.ByOwnerType {
+			if err := write(name, "owner_type", cost); err != nil {
+				return fmt.Errorf("failed to write cost report row: %w", err)
+			}
+		}
+	}
+	if err := write("all", "total", report.Total); err != nil {
+		return fmt.Errorf("failed to write cost report row: %w", err)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,425 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+// Package cost provides the service estimating the monthly cost of the assets of each provider,
+// attributed to their creators and owner types.
+package cost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	cldapiclient "github.com/mikhail5545/media-service-go/internal/apiclients/cloudinary"
+	muxapiclient "github.com/mikhail5545/media-service-go/internal/apiclients/mux"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	costmodel "github.com/mikhail5545/media-service-go/internal/models/cost"
+	usagemodel "github.com/mikhail5545/media-service-go/internal/models/usage"
+	"go.uber.org/zap"
+)
+
+// Source provides the assets of a single provider.
+type Source struct {
+	// List returns the usage of up to limit assets in any status created before the time with IDs
+	// greater than afterID, ordered by ID.
+	List func(ctx context.Context, createdBefore time.Time, afterID uuid.UUID, limit int) ([]*costmodel.AssetUsage, error)
+	// OwnerTypes returns the distinct owner types of the assets, keyed by asset ID.
+	OwnerTypes func(ctx context.Context, ids []string) (map[string][]string, error)
+}
+
+// CostService defines the interface for estimating the cost of the assets.
+type CostService interface {
+	// Report returns the estimated cost of a month aggregated by provider, creator and owner type.
+	Report(ctx context.Context, req *costmodel.ReportRequest) (*costmodel.Report, error)
+}
+
+// Service implements the CostService interface.
+type Service struct {
+	mux        Source
+	cloudinary Source
+	// muxClient reports the MUX Data views, streaming is not estimated if it is nil.
+	muxClient muxapiclient.APIClient
+	// cldClient reports the Cloudinary bandwidth, the bandwidth is not estimated if it is nil.
+	cldClient cldapiclient.APIClient
+	rates     costmodel.Rates
+	currency  string
+	logger    *zap.Logger
+}
+
+var _ CostService = (*Service)(nil)
+
+const (
+	// reportBatchSize limits the number of assets loaded at once while estimating the costs.
+	reportBatchSize = 500
+	// viewsPageSize is the page size of the MUX Data views.
+	viewsPageSize = 100
+)
+
+type NewParams struct {
+	Mux        Source
+	Cloudinary Source
+	// MuxClient is optional, streaming is not estimated if it is not provided.
+	MuxClient muxapiclient.APIClient
+	// CloudinaryClient is optional, the bandwidth is not estimated if it is not provided.
+	CloudinaryClient cldapiclient.APIClient
+	Rates            costmodel.Rates
+	Currency         string
+}
+
+func New(params *NewParams, logger *zap.Logger) *Service {
+	return &Service{
+		mux:        params.Mux,
+		cloudinary: params.Cloudinary,
+		muxClient:  params.MuxClient,
+		cldClient:  params.CloudinaryClient,
+		rates:      params.Rates,
+		currency:   params.Currency,
+		logger:     logger.With(zap.String("layer", "service"), zap.String("service", "cost")),
+	}
+}
+
+// log returns the service logger enriched with the request-scoped fields stored in ctx.
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// Report returns the estimated cost of a month. Storage is prorated by the part of the month the assets
+// existed for until they were archived or deleted, the current month is estimated up to now. An asset with several owner types is counted
+// once for each of them, assets without owners are reported under [usagemodel.Unowned]. Assets which
+// were purged are not accounted for.
+//
+// MUX streaming is estimated from the playing time reported by MUX Data. Cloudinary does not report
+// the bandwidth of single images, the bandwidth of the product environment is attributed to the
+// images by their share of the storage. A report lacking either of them lists the reason in its warnings.
+func (s *Service) Report(ctx context.Context, req *costmodel.ReportRequest) (*costmodel.Report, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	from, to := req.Timeframe()
+	now := time.Now().UTC()
+	if !from.Before(now) {
+		return nil, serviceerrors.NewValidationFailedError(validation.Errors{"month": errors.New("must not be in the future")})
+	}
+	end := to
+	if now.Before(end) {
+		end = now
+	}
+	p := &period{from: from, to: to, end: end}
+
+	report := &costmodel.Report{
+		Month:       req.Month,
+		From:        from,
+		To:          to,
+		Currency:    s.currency,
+		GeneratedAt: now,
+		Total:       &costmodel.Cost{},
+	}
+	muxReport, err := s.muxReport(ctx, p, report)
+	if err != nil {
+		return nil, err
+	}
+	cldReport, err := s.cloudinaryReport(ctx, p, report)
+	if err != nil {
+		return nil, err
+	}
+	report.Providers = []*costmodel.ProviderReport{muxReport, cldReport}
+	for _, provider := range report.Providers {
+		report.Total.Add(provider.Total)
+	}
+	round(report.Total)
+	return report, nil
+}
+
+// period is the reported month, end is the end of the month or now for the current month.
+type period struct {
+	from, to, end time.Time
+}
+
+// stored returns the part of the month an asset was stored for, from its creation until it was archived
+// or deleted.
+func (p *period) stored(asset *costmodel.AssetUsage) float64 {
+	start, end := asset.CreatedAt, p.end
+	if start.Before(p.from) {
+		start = p.from
+	}
+	if asset.DeletedAt != nil && asset.DeletedAt.Before(end) {
+		end = *asset.DeletedAt
+	}
+	if !start.Before(end) {
+		return 0
+	}
+	return end.Sub(start).Seconds() / p.to.Sub(p.from).Seconds()
+}
+
+// encoded reports whether an asset created at the time was encoded in the month.
+func (p *period) encoded(createdAt time.Time) bool {
+	return !createdAt.Before(p.from) && createdAt.Before(p.end)
+}
+
+func (s *Service) muxReport(ctx context.Context, p *period, report *costmodel.Report) (*costmodel.ProviderReport, error) {
+	streamed, err := s.streamedMinutes(ctx, p)
+	if err != nil {
+		s.log(ctx).Warn("failed to retrieve the mux data views, streaming is not estimated", zap.Error(err))
+		report.Warnings = append(report.Warnings, fmt.Sprintf("MUX streaming is not estimated: %v", err))
+	}
+
+	agg := newAggregator()
+	err = s.each(ctx, s.mux, p, func(asset *costmodel.AssetUsage, ownerTypes []string) {
+		minutes := asset.Duration / 60
+		multiplier := s.rates.MuxMultiplier(asset.ResolutionTier)
+		cost := &costmodel.Cost{Assets: 1, StoredMinutes: minutes * p.stored(asset)}
+		if p.encoded(asset.CreatedAt) {
+			cost.EncodedMinutes = minutes
+		}
+		if asset.MuxAssetID != nil {
+			cost.StreamedMinutes = streamed[*asset.MuxAssetID]
+		}
+		cost.Encoding = cost.EncodedMinutes * s.rates.MuxEncodingPerMinute * multiplier
+		cost.Storage = cost.StoredMinutes * s.rates.MuxStoragePerMinute * multiplier
+		cost.Delivery = cost.StreamedMinutes * s.rates.MuxStreamingPerMinute * multiplier
+		cost.Total = cost.Encoding + cost.Storage + cost.Delivery
+		agg.add(asset, ownerTypes, cost)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate mux costs: %w", err)
+	}
+	return agg.report(usagemodel.ProviderMux), nil
+}
+
+// streamedMinutes returns the minutes played in the month keyed by the MUX asset ID.
+func (s *Service) streamedMinutes(ctx context.Context, p *period) (map[string]float64, error) {
+	minutes := make(map[string]float64)
+	if s.muxClient == nil {
+		return minutes, errors.New("the MUX client is not configured")
+	}
+	for page := int32(1); ; page++ {
+		views, err := s.muxClient.ListAssetViews(ctx, p.from, p.end, viewsPageSize, page)
+		if err != nil {
+			return minutes, err
+		}
+		for _, view := range views {
+			// The playing time is reported in milliseconds.
+			minutes[view.Field] += float64(view.TotalPlayingTime) / float64(time.Minute/time.Millisecond)
+		}
+		if len(views) < viewsPageSize {
+			return minutes, nil
+		}
+	}
+}
+
+func (s *Service) cloudinaryReport(ctx context.Context, p *period, report *costmodel.Report) (*costmodel.ProviderReport, error) {
+	agg := newAggregator()
+	err := s.each(ctx, s.cloudinary, p, func(asset *costmodel.AssetUsage, ownerTypes []string) {
+		cost := &costmodel.Cost{Assets: 1, StoredGB: float64(asset.Bytes) / gigabyte * p.stored(asset)}
+		cost.Storage = cost.StoredGB * s.rates.CloudinaryStoragePerGB
+		cost.Total = cost.Storage
+		agg.add(asset, ownerTypes, cost)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate cloudinary costs: %w", err)
+	}
+
+	bandwidth, err := s.bandwidth(ctx, p)
+	if err != nil {
+		s.log(ctx).Warn("failed to retrieve the cloudinary bandwidth, the bandwidth is not estimated", zap.Error(err))
+		report.Warnings = append(report.Warnings, fmt.Sprintf("Cloudinary bandwidth is not estimated: %v", err))
+	} else {
+		agg.attributeDelivery(float64(bandwidth)/gigabyte, s.rates.CloudinaryBandwidthPerGB)
+	}
+	return agg.report(usagemodel.ProviderCloudinary), nil
+}
+
+const gigabyte = 1 << 30
+
+// bandwidthWindow is the period the bandwidth of a Cloudinary usage report covers, it reports the
+// rolling usage of the last 30 days as of its date.
+const bandwidthWindow = 30 * 24 * time.Hour
+
+// bandwidth returns the bandwidth of the month in bytes. It is estimated from a single usage report as
+// of the last day of the period, scaled from the rolling window to the length of the period.
+func (s *Service) bandwidth(ctx context.Context, p *period) (int64, error) {
+	if s.cldClient == nil {
+		return 0, errors.New("the Cloudinary client is not configured")
+	}
+	// The end of a complete month is midnight of the next one, the report of its last day is requested.
+	usage, err := s.cldClient.GetUsage(ctx, p.end.Add(-time.Second))
+	if err != nil {
+		return 0, err
+	}
+	scale := float64(p.end.Sub(p.from)) / float64(bandwidthWindow)
+	return int64(float64(usage.Bandwidth.Usage) * scale), nil
+}
+
+// each pages through the assets of the source created before the end of the period and calls fn with
+// every asset and its owner types. Assets archived or deleted before the period are skipped.
+func (s *Service) each(ctx context.Context, source Source, p *period, fn func(asset *costmodel.AssetUsage, ownerTypes []string)) error {
+	afterID := uuid.Nil
+	for {
+		assets, err := source.List(ctx, p.end, afterID, reportBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list asset usage: %w", err)
+		}
+		if len(assets) == 0 {
+			return nil
+		}
+		ids := make([]string, len(assets))
+		for i, asset := range assets {
+			ids[i] = asset.ID.String()
+		}
+		ownerTypes, err := source.OwnerTypes(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve asset owners: %w", err)
+		}
+		for _, asset := range assets {
+			if asset.DeletedAt != nil && !asset.DeletedAt.After(p.from) {
+				continue
+			}
+			fn(asset, ownerTypes[asset.ID.String()])
+		}
+		if len(assets) < reportBatchSize {
+			return nil
+		}
+		afterID = assets[len(assets)-1].ID
+	}
+}
+
+// aggregator sums the costs of the assets of a provider by creator and by owner type.
+type aggregator struct {
+	total       *costmodel.Cost
+	byCreator   map[uuid.UUID]*costmodel.Cost
+	byOwnerType map[string]*costmodel.Cost
+}
+
+func newAggregator() *aggregator {
+	return &aggregator{
+		total:       &costmodel.Cost{},
+		byCreator:   make(map[uuid.UUID]*costmodel.Cost),
+		byOwnerType: make(map[string]*costmodel.Cost),
+	}
+}
+
+func (a *aggregator) add(asset *costmodel.AssetUsage, ownerTypes []string, cost *costmodel.Cost) {
+	a.total.Add(cost)
+
+	// Assets without a recorded creator are grouped under the nil UUID.
+	creatorID := uuid.Nil
+	if asset.CreatorID != nil {
+		creatorID = *asset.CreatorID
+	}
+	creator, ok := a.byCreator[creatorID]
+	if !ok {
+		creator = &costmodel.Cost{CreatorID: asset.CreatorID}
+		a.byCreator[creatorID] = creator
+	}
+	creator.Add(cost)
+
+	if len(ownerTypes) == 0 {
+		ownerTypes = []string{usagemodel.Unowned}
+	}
+	for _, ownerType := range ownerTypes {
+		group, ok := a.byOwnerType[ownerType]
+		if !ok {
+			group = &costmodel.Cost{OwnerType: ownerType}
+			a.byOwnerType[ownerType] = group
+		}
+		group.Add(cost)
+	}
+}
+
+// attributeDelivery attributes the delivered GB to the groups by their share of the stored GB.
+func (a *aggregator) attributeDelivery(gb, ratePerGB float64) {
+	if a.total.StoredGB == 0 {
+		return
+	}
+	attribute := func(cost *costmodel.Cost) {
+		cost.DeliveredGB = gb * cost.StoredGB / a.total.StoredGB
+		cost.Delivery = cost.DeliveredGB * ratePerGB
+		cost.Total += cost.Delivery
+	}
+	// The total is attributed last, the shares of the groups are computed from its storage.
+	for _, cost := range a.byCreator {
+		attribute(cost)
+	}
+	for _, cost := range a.byOwnerType {
+		attribute(cost)
+	}
+	attribute(a.total)
+}
+
+// report returns the rounded costs, the most expensive groups first.
+func (a *aggregator) report(provider usagemodel.Provider) *costmodel.ProviderReport {
+	byCreator := make([]*costmodel.Cost, 0, len(a.byCreator))
+	for _, cost := range a.byCreator {
+		round(cost)
+		byCreator = append(byCreator, cost)
+	}
+	sort.Slice(byCreator, func(i, j int) bool {
+		if byCreator[i].Total != byCreator[j].Total {
+			return byCreator[i].Total > byCreator[j].Total
+		}
+		return creatorKey(byCreator[i]) < creatorKey(byCreator[j])
+	})
+	byOwnerType := make([]*costmodel.Cost, 0, len(a.byOwnerType))
+	for _, cost := range a.byOwnerType {
+		round(cost)
+		byOwnerType = append(byOwnerType, cost)
+	}
+	sort.Slice(byOwnerType, func(i, j int) bool {
+		if byOwnerType[i].Total != byOwnerType[j].Total {
+			return byOwnerType[i].Total > byOwnerType[j].Total
+		}
+		return byOwnerType[i].OwnerType < byOwnerType[j].OwnerType
+	})
+	round(a.total)
+	return &costmodel.ProviderReport{
+		Provider:    provider,
+		Total:       a.total,
+		ByCreator:   byCreator,
+		ByOwnerType: byOwnerType,
+	}
+}
+
+func creatorKey(cost *costmodel.Cost) string {
+	if cost.CreatorID == nil {
+		return ""
+	}
+	return cost.CreatorID.String()
+}
+
+// round rounds the usage to two decimals and the GB and the amounts to four decimals, which keeps the
+// costs of single small images visible.
+func round(cost *costmodel.Cost) {
+	cost.EncodedMinutes = roundTo(cost.EncodedMinutes, 2)
+	cost.StoredMinutes = roundTo(cost.StoredMinutes, 2)
+	cost.StreamedMinutes = roundTo(cost.StreamedMinutes, 2)
+	cost.StoredGB = roundTo(cost.StoredGB, 4)
+	cost.DeliveredGB = roundTo(cost.DeliveredGB, 4)
+	cost.Encoding = roundTo(cost.Encoding, 4)
+	cost.Storage = roundTo(cost.Storage, 4)
+	cost.Delivery = roundTo(cost.Delivery, 4)
+	cost.Total = roundTo(cost.Total, 4)
+}
+
+func roundTo(v float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return math.Round(v*scale) / scale
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/google/uuid"
+	costmodel "github.com/mikhail5545/media-service-go/internal/models/cost"
+	"github.com/mikhail5545/media-service-go/internal/testutil"
+	"go.uber.org/zap"
+)
+
+// staticSource serves the assets in a single page and reports no owners.
+func staticSource(assets ...*costmodel.AssetUsage) Source {
+	return Source{
+		List: func(_ context.Context, _ time.Time, afterID uuid.UUID, _ int) ([]*costmodel.AssetUsage, error) {
+			if afterID != uuid.Nil {
+				return nil, nil
+			}
+			return assets, nil
+		},
+		OwnerTypes: func(context.Context, []string) (map[string][]string, error) {
+			return nil, nil
+		},
+	}
+}
+
+func usageClient(bandwidth int64) *testutil.CloudinaryClient {
+	return &testutil.CloudinaryClient{
+		GetUsageFunc: func(context.Context, time.Time) (*admin.UsageResult, error) {
+			res := &admin.UsageResult{}
+			res.Bandwidth.Usage = bandwidth
+			return res, nil
+		},
+	}
+}
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestBandwidth(t *testing.T) {
+	tests := []struct {
+		month   string
+		usage   int64
+		wantDay time.Time
+		want    int64
+	}{
+		{month: "2026-06", usage: 30 * gigabyte, wantDay: date("2026-06-30"), want: 30 * gigabyte},
+		{month: "2026-07", usage: 30 * gigabyte, wantDay: date("2026-07-31"), want: 31 * gigabyte},
+		{month: "2026-02", usage: 30 * gigabyte, wantDay: date("2026-02-28"), want: 28 * gigabyte},
+	}
+	for _, tt := range tests {
+		t.Run(tt.month, func(t *testing.T) {
+			client := usageClient(tt.usage)
+			svc := New(&NewParams{CloudinaryClient: client}, zap.NewNop())
+			from, to := (&costmodel.ReportRequest{Month: tt.month}).Timeframe()
+
+			got, err := svc.bandwidth(context.Background(), &period{from: from, to: to, end: to})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("bandwidth = %d, want %d", got, tt.want)
+			}
+			calls := client.CallsTo("GetUsage")
+			if len(calls) != 1 {
+				t.Fatalf("GetUsage called %d times, want 1", len(calls))
+			}
+			day := calls[0].Args[0].(time.Time).Truncate(24 * time.Hour)
+			if !day.Equal(tt.wantDay) {
+				t.Errorf("GetUsage date = %s, want %s", day.Format(time.DateOnly), tt.wantDay.Format(time.DateOnly))
+			}
+		})
+	}
+}
+
+func TestReportCloudinaryBandwidth(t *testing.T) {
+	client := usageClient(30 * gigabyte)
+	svc := New(&NewParams{
+		Mux: staticSource(),
+		Cloudinary: staticSource(
+			&costmodel.AssetUsage{ID: uuid.New(), CreatedAt: date("2026-01-01"), Bytes: gigabyte},
+			&costmodel.AssetUsage{ID: uuid.New(), CreatedAt: date("2026-01-01"), Bytes: 3 * gigabyte},
+		),
+		CloudinaryClient: client,
+		Rates:            costmodel.Rates{CloudinaryBandwidthPerGB: 0.1},
+	}, zap.NewNop())
+
+	report, err := svc.Report(context.Background(), &costmodel.ReportRequest{Month: "2026-06"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(client.CallsTo("GetUsage")); n != 1 {
+		t.Errorf("GetUsage called %d times, want 1", n)
+	}
+	total := report.Providers[1].Total
+	if total.DeliveredGB != 30 {
+		t.Errorf("delivered = %v GB, want 30", total.DeliveredGB)
+	}
+	if total.Delivery != 3 {
+		t.Errorf("delivery = %v, want 3", total.Delivery)
+	}
+}
+
+func TestStored(t *testing.T) {
+	from, to := (&costmodel.ReportRequest{Month: "2026-06"}).Timeframe()
+	p := &period{from: from, to: to, end: to}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		deletedAt *time.Time
+		want      float64
+	}{
+		{name: "whole month", createdAt: date("2026-05-01"), want: 1},
+		{name: "created during month", createdAt: date("2026-06-16"), want: 0.5},
+		{name: "archived during month", createdAt: date("2026-05-01"), deletedAt: ptr(date("2026-06-07")), want: 0.2},
+		{name: "created and archived during month", createdAt: date("2026-06-04"), deletedAt: ptr(date("2026-06-10")), want: 0.2},
+		{name: "archived before month", createdAt: date("2026-04-01"), deletedAt: ptr(date("2026-05-20")), want: 0},
+		{name: "archived after month", createdAt: date("2026-05-01"), deletedAt: ptr(date("2026-07-02")), want: 1},
+		{name: "created after month", createdAt: date("2026-07-01"), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.stored(&costmodel.AssetUsage{CreatedAt: tt.createdAt, DeletedAt: tt.deletedAt})
+			if roundTo(got, 6) != tt.want {
+				t.Errorf("stored = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportSkipsAssetsArchivedBeforeMonth(t *testing.T) {
+	archivedAt := date("2026-05-20")
+	svc := New(&NewParams{
+		Mux: staticSource(),
+		Cloudinary: staticSource(
+			&costmodel.AssetUsage{ID: uuid.New(), CreatedAt: date("2026-01-01"), Bytes: gigabyte},
+			&costmodel.AssetUsage{ID: uuid.New(), CreatedAt: date("2026-01-01"), DeletedAt: &archivedAt, Bytes: gigabyte},
+		),
+		CloudinaryClient: usageClient(0),
+	}, zap.NewNop())
+
+	report, err := svc.Report(context.Background(), &costmodel.ReportRequest{Month: "2026-06"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := report.Providers[1].Total
+	if total.Assets != 1 {
+		t.Errorf("assets = %d, want 1", total.Assets)
+	}
+	if total.StoredGB != 1 {
+		t.Errorf("stored = %v GB, want 1", total.StoredGB)
+	}
+}
@@ -0,0 +1,375 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package export implements the asset inventory exports. An export job writes the assets of the
+// selected backends to a CSV or NDJSON file, which can be downloaded once the job is completed.
+//
+// Jobs are kept in memory and their files on the local disk, so the status and the file of a job
+// can only be retrieved from the instance which created it. Finished jobs are discarded together
+// with their files after the retention period.
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	exportmodel "github.com/mikhail5545/media-service-go/internal/models/export"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+)
+
+// sweepInterval is the interval between removals of expired jobs.
+const sweepInterval = time.Minute
+
+// filePrefix is the prefix of the names of all files written by exports.
+const filePrefix = "export-"
+
+type Config struct {
+	// Dir is the directory the export files are written to.
+	Dir string
+	// Retention is the time a finished job and its file are kept.
+	Retention time.Duration
+	// MaxPending is the maximum number of jobs waiting to be run.
+	MaxPending int
+}
+
+type Service struct {
+	cfg     Config
+	sources []Source
+	logger  *zap.Logger
+	queue   chan uuid.UUID
+
+	mu   sync.Mutex
+	jobs map[uuid.UUID]*exportmodel.Job
+}
+
+type NewParams struct {
+	Config Config
+	// Sources are the backends assets are exported from, in the order they are written.
+	Sources []Source
+}
+
+func New(params *NewParams, logger *zap.Logger) (*Service, error) {
+	if params.Config.Dir == "" {
+		return nil, fmt.Errorf("export directory must be set")
+	}
+	if params.Config.Retention <= 0 {
+		return nil, fmt.Errorf("export retention must be positive")
+	}
+	if params.Config.MaxPending <= 0 {
+		return nil, fmt.Errorf("export max pending jobs must be positive")
+	}
+	if err := os.MkdirAll(params.Config.Dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	return &Service{
+		cfg:     params.Config,
+		sources: params.Sources,
+		logger:  logger.With(zap.String("layer", "service"), zap.String("service", "export")),
+		queue:   make(chan uuid.UUID, params.Config.MaxPending),
+		jobs:    make(map[uuid.UUID]*exportmodel.Job),
+	}, nil
+}
+
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// Create queues a new export job. The job is run in the background, its progress can be followed
+// with [Service.Get].
+func (s *Service) Create(ctx context.Context, req *exportmodel.CreateJobRequest) (*exportmodel.Job, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	providers := req.Providers
+	if len(providers) == 0 {
+		for _, src := range s.sources {
+			providers = append(providers, src.Name())
+		}
+	}
+	for _, name := range providers {
+		if !slices.ContainsFunc(s.sources, func(src Source) bool { return src.Name() == name }) {
+			return nil, serviceerrors.NewInvalidArgumentError(fmt.Sprintf("unknown provider %q", name))
+		}
+	}
+	columns := req.Columns
+	if len(columns) == 0 {
+		columns = exportmodel.Columns
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate export job id: %w", err)
+	}
+	job := &exportmodel.Job{
+		ID:             id,
+		Status:         exportmodel.StatusPending,
+		Format:         req.Format,
+		Columns:        slices.Clone(columns),
+		Providers:      slices.Clone(providers),
+		IncludeDeleted: req.IncludeDeleted,
+		CreatedAt:      time.Now(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case s.queue <- id:
+	default:
+		return nil, serviceerrors.NewTooManyRequestsError("too many pending exports, try again later")
+	}
+	s.jobs[id] = job
+
+	s.log(ctx).Info("queued export job",
+		zap.String("job_id", id.String()),
+		zap.String("format", string(job.Format)),
+		zap.Strings("providers", job.Providers),
+		zap.Bool("include_deleted", job.IncludeDeleted),
+	)
+	info := *job
+	return &info, nil
+}
+
+// Get retrieves the status of an export job.
+func (s *Service) Get(ctx context.Context, req *exportmodel.GetJobRequest) (*exportmodel.Job, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	id, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, serviceerrors.NewNotFoundError("export job not found")
+	}
+	info := *job
+	return &info, nil
+}
+
+// List retrieves the export jobs of this instance, newest first.
+func (s *Service) List(ctx context.Context, req *exportmodel.ListJobsRequest) ([]*exportmodel.Job, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	s.mu.Lock()
+	jobs := make([]*exportmodel.Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		if req.Status != "" && job.Status != req.Status {
+			continue
+		}
+		info := *job
+		jobs = append(jobs, &info)
+	}
+	s.mu.Unlock()
+
+	// Job IDs are UUIDv7, so they sort by creation time.
+	slices.SortFunc(jobs, func(a, b *exportmodel.Job) int {
+		return strings.Compare(b.ID.String(), a.ID.String())
+	})
+	return jobs, nil
+}
+
+// GetFile retrieves the file of a completed export job.
+func (s *Service) GetFile(ctx context.Context, req *exportmodel.GetJobRequest) (*exportmodel.File, error) {
+	job, err := s.Get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != exportmodel.StatusCompleted {
+		return nil, serviceerrors.NewConflictError(fmt.Sprintf("export job is %s", job.Status))
+	}
+	return &exportmodel.File{
+		Path:        s.path(job),
+		Name:        job.FileName(),
+		ContentType: job.Format.ContentType(),
+	}, nil
+}
+
+// Run runs the queued jobs one at a time and periodically discards the expired jobs with their
+// files. It blocks until the provided context is cancelled.
+func (s *Service) Run(ctx context.Context) {
+	// Files of the jobs of previous runs are not referenced by any job anymore.
+	s.sweep(time.Now())
+
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweep(time.Now())
+		case id := <-s.queue:
+			s.run(ctx, id)
+		}
+	}
+}
+
+func (s *Service) run(ctx context.Context, id uuid.UUID) {
+	s.mu.Lock()
+	job, ok := s.jobs[id]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	startedAt := time.Now()
+	job.Status = exportmodel.StatusRunning
+	job.StartedAt = &startedAt
+	info := *job
+	s.mu.Unlock()
+
+	logger := s.logger.With(zap.String("job_id", id.String()))
+	logger.Info("started export job")
+
+	size, err := s.write(ctx, &info, func(rows int64) {
+		s.mu.Lock()
+		job.Rows = rows
+		s.mu.Unlock()
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	finishedAt := time.Now()
+	expiresAt := finishedAt.Add(s.cfg.Retention)
+	job.CompletedAt = &finishedAt
+	job.ExpiresAt = &expiresAt
+	if err != nil {
+		job.Status = exportmodel.StatusFailed
+		job.Error = err.Error()
+		logger.Error("export job failed", zap.Error(err), zap.Int64("rows", job.Rows))
+		return
+	}
+	job.Status = exportmodel.StatusCompleted
+	job.Size = size
+	logger.Info("completed export job", zap.Int64("rows", job.Rows), zap.Int64("size", size), zap.Duration("duration", finishedAt.Sub(startedAt)))
+}
+
+// write writes the assets of the job to a temporary file and moves it to the job path once all
+// sources are exported, so incomplete files are never served. It returns the size of the file.
+func (s *Service) write(ctx context.Context, job *exportmodel.Job, progress func(rows int64)) (int64, error) {
+	file, err := os.CreateTemp(s.cfg.Dir, filePrefix+"*.tmp")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create export file: %w", err)
+	}
+	tmpPath := file.Name()
+	defer func() {
+		// The temporary file is gone once it was renamed.
+		_ = os.Remove(tmpPath)
+	}()
+
+	w, err := newRowWriter(file, job.Format, job.Columns)
+	if err != nil {
+		_ = file.Close()
+		return 0, err
+	}
+	var rows int64
+	for _, src := range s.sources {
+		if !slices.Contains(job.Providers, src.Name()) {
+			continue
+		}
+		err := src.Export(ctx, job.IncludeDeleted, func(row *exportmodel.Row) error {
+			if err := w.Write(row); err != nil {
+				return err
+			}
+			rows++
+			if rows%1000 == 0 {
+				progress(rows)
+			}
+			return nil
+		})
+		if err != nil {
+			_ = file.Close()
+			return 0, fmt.Errorf("failed to export %s assets: %w", src.Name(), err)
+		}
+	}
+	progress(rows)
+
+	if err := w.Flush(); err != nil {
+		_ = file.Close()
+		return 0, fmt.Errorf("failed to write export file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return 0, fmt.Errorf("failed to write export file: %w", err)
+	}
+	path := s.path(job)
+	if err := os.Rename(tmpPath, path); err != nil {
+		return 0, fmt.Errorf("failed to move export file: %w", err)
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat export file: %w", err)
+	}
+	return stat.Size(), nil
+}
+
+// sweep discards the expired jobs with their files, and the files older than the retention period
+// which belong to no job, e.g. the files of the jobs of a previous run.
+func (s *Service) sweep(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	known := make(map[string]bool, len(s.jobs))
+	for id, job := range s.jobs {
+		if job.ExpiresAt != nil && now.After(*job.ExpiresAt) {
+			delete(s.jobs, id)
+			if err := os.Remove(s.path(job)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				s.logger.Warn("failed to remove export file", zap.Error(err), zap.String("job_id", id.String()))
+			}
+			s.logger.Info("discarded expired export job", zap.String("job_id", id.String()), zap.String("status", string(job.Status)))
+			continue
+		}
+		known[filepath.Base(s.path(job))] = true
+	}
+
+	entries, err := os.ReadDir(s.cfg.Dir)
+	if err != nil {
+		s.logger.Warn("failed to read export directory", zap.Error(err))
+		return
+	}
+	for _, entry := range entries {
+		// Temporary files of running jobs are recent, so they are kept.
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), filePrefix) || known[entry.Name()] {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) <= s.cfg.Retention {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.cfg.Dir, entry.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.logger.Warn("failed to remove stale export file", zap.Error(err), zap.String("file", entry.Name()))
+		}
+	}
+}
+
+// path returns the location of the file of a job.
+func (s *Service) path(job *exportmodel.Job) string {
+	return filepath.Join(s.cfg.Dir, filePrefix+job.ID.String()+job.Format.Extension())
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package export
+
+import (
+	"context"
+
+	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	exportmodel "github.com/mikhail5545/media-service-go/internal/models/export"
+	mediamodel "github.com/mikhail5545/media-service-go/internal/models/media"
+	muxassetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	cldservice "github.com/mikhail5545/media-service-go/internal/services/cloudinary"
+	"github.com/mikhail5545/media-service-go/internal/services/mediacore"
+	muxservice "github.com/mikhail5545/media-service-go/internal/services/mux"
+)
+
+// Provider names of the backends with their own services.
+const (
+	ProviderMux        = "mux"
+	ProviderCloudinary = "cloudinary"
+)
+
+// pageSize is the number of assets read from a backend at once.
+const pageSize = 500
+
+// Source exports the assets of one backend.
+type Source interface {
+	Name() string
+	// Export calls emit for every active and broken asset of the backend, and for the archived
+	// ones when includeDeleted is set. It stops at the first error returned by emit.
+	Export(ctx context.Context, includeDeleted bool, emit func(*exportmodel.Row) error) error
+}
+
+// lister lists a page of the assets of a backend in one state.
+type lister[Req, D any] func(ctx context.Context, req *Req) ([]*D, string, error)
+
+// exportAll pages through the assets returned by list, newRequest creates the request of a page.
+func exportAll[Req, D any](
+	ctx context.Context,
+	list lister[Req, D],
+	newRequest func(pageToken string) *Req,
+	toRow func(*D) *exportmodel.Row,
+	emit func(*exportmodel.Row) error,
+) error {
+	pageToken := ""
+	for {
+		details, nextToken, err := list(ctx, newRequest(pageToken))
+		if err != nil {
+			return err
+		}
+		for _, d := range details {
+			if err := emit(toRow(d)); err != nil {
+				return err
+			}
+		}
+		if nextToken == "" {
+			return nil
+		}
+		pageToken = nextToken
+	}
+}
+
+type muxSource struct {
+	svc *muxservice.Service
+}
+
+// MuxSource exports the MUX videos.
+func MuxSource(svc *muxservice.Service) Source {
+	return &muxSource{svc: svc}
+}
+
+func (s *muxSource) Name() string {
+	return ProviderMux
+}
+
+func (s *muxSource) Export(ctx context.Context, includeDeleted bool, emit func(*exportmodel.Row) error) error {
+	listers := []lister[muxassetmodel.ListRequest, muxassetmodel.Details]{s.svc.List, s.svc.ListBroken}
+	if includeDeleted {
+		listers = append(listers, s.svc.ListArchived)
+	}
+	newRequest := func(pageToken string) *muxassetmodel.ListRequest {
+		return &muxassetmodel.ListRequest{
+			OrderBy:   muxassetmodel.OrderCreatedAt,
+			OrderDir:  muxassetmodel.OrderAscending,
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		}
+	}
+	for _, list := range listers {
+		if err := exportAll(ctx, list, newRequest, muxRow, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func muxRow(d *muxassetmodel.Details) *exportmodel.Row {
+	row := &exportmodel.Row{
+		ID:        d.Asset.ID.String(),
+		Provider:  ProviderMux,
+		Kind:      "video",
+		Status:    string(d.Asset.Status),
+		Owners:    []exportmodel.Owner{},
+		Tags:      d.Asset.Tags,
+		CreatedAt: d.Asset.CreatedAt,
+		UpdatedAt: d.Asset.UpdatedAt,
+	}
+	if d.Asset.MuxAssetID != nil {
+		row.ExternalID = *d.Asset.MuxAssetID
+	}
+	if d.Asset.DeletedAt.Valid {
+		row.DeletedAt = &d.Asset.DeletedAt.Time
+	}
+	if d.Metadata != nil {
+		row.Title = d.Metadata.Title
+		for _, o := range d.Metadata.Owners {
+			row.Owners = append(row.Owners, exportmodel.Owner{OwnerID: o.OwnerID, OwnerType: o.OwnerType})
+		}
+	}
+	return row
+}
+
+type cloudinarySource struct {
+	svc *cldservice.Service
+}
+
+// CloudinarySource exports the Cloudinary assets.
+func CloudinarySource(svc *cldservice.Service) Source {
+	return &cloudinarySource{svc: svc}
+}
+
+func (s *cloudinarySource) Name() string {
+	return ProviderCloudinary
+}
+
+func (s *cloudinarySource) Export(ctx context.Context, includeDeleted bool, emit func(*exportmodel.Row) error) error {
+	listers := []lister[cldassetmodel.ListRequest, cldassetmodel.Details]{s.svc.List, s.svc.ListBroken}
+	if includeDeleted {
+		listers = append(listers, s.svc.ListArchived)
+	}
+	newRequest := func(pageToken string) *cldassetmodel.ListRequest {
+		return &cldassetmodel.ListRequest{
+			OrderField: cldassetmodel.OrderCreatedAt,
+			OrderDir:   cldassetmodel.OrderAscending,
+			PageSize:   pageSize,
+			PageToken:  pageToken,
+		}
+	}
+	for _, list := range listers {
+		if err := exportAll(ctx, list, newRequest, cloudinaryRow, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func cloudinaryRow(d *cldassetmodel.Details) *exportmodel.Row {
+	row := &exportmodel.Row{
+		ID:         d.Asset.ID.String(),
+		Provider:   ProviderCloudinary,
+		Kind:       d.Asset.ResourceType,
+		Status:     string(d.Asset.Status),
+		Title:      d.Asset.DisplayName,
+		ExternalID: d.Asset.CloudinaryPublicID,
+		Owners:     []exportmodel.Owner{},
+		Tags:       d.Asset.Tags,
+		CreatedAt:  d.Asset.CreatedAt,
+		UpdatedAt:  d.Asset.UpdatedAt,
+	}
+	if d.Asset.DeletedAt.Valid {
+		row.DeletedAt = &d.Asset.DeletedAt.Time
+	}
+	if d.Metadata != nil {
+		if d.Metadata.Title != "" {
+			row.Title = d.Metadata.Title
+		}
+		for _, o := range d.Metadata.Owners {
+			row.Owners = append(row.Owners, exportmodel.Owner{OwnerID: o.OwnerID, OwnerType: o.OwnerType})
+		}
+	}
+	return row
+}
+
+type coreSource struct {
+	svc *mediacore.Service
+}
+
+// CoreSource exports the assets of a backend built on the shared asset core.
+func CoreSource(svc *mediacore.Service) Source {
+	return &coreSource{svc: svc}
+}
+
+func (s *coreSource) Name() string {
+	return s.svc.Name()
+}
+
+func (s *coreSource) Export(ctx context.Context, includeDeleted bool, emit func(*exportmodel.Row) error) error {
+	listers := []lister[mediamodel.ListRequest, mediamodel.Details]{s.svc.List}
+	if includeDeleted {
+		listers = append(listers, s.svc.ListArchived)
+	}
+	newRequest := func(pageToken string) *mediamodel.ListRequest {
+		return &mediamodel.ListRequest{PageSize: pageSize, PageToken: pageToken}
+	}
+	for _, list := range listers {
+		if err := exportAll(ctx, list, newRequest, coreRow, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func coreRow(d *mediamodel.Details) *exportmodel.Row {
+	row := &exportmodel.Row{
+		ID:         d.Asset.ID.String(),
+		Provider:   d.Asset.Provider,
+		Kind:       d.Asset.Kind,
+		Status:     string(d.Asset.Status),
+		ExternalID: d.Asset.ExternalID,
+		Owners:     []exportmodel.Owner{},
+		CreatedAt:  d.Asset.CreatedAt,
+		UpdatedAt:  d.Asset.UpdatedAt,
+	}
+	if d.Asset.Title != nil {
+		row.Title = *d.Asset.Title
+	}
+	if d.Asset.DeletedAt.Valid {
+		row.DeletedAt = &d.Asset.DeletedAt.Time
+	}
+	if d.Metadata != nil {
+		for _, o := range d.Metadata.Owners {
+			row.Owners = append(row.Owners, exportmodel.Owner{OwnerID: o.OwnerID, OwnerType: o.OwnerType})
+		}
+	}
+	return row
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	exportmodel "github.com/mikhail5545/media-service-go/internal/models/export"
+)
+
+// rowWriter writes the selected columns of the exported rows.
+type rowWriter interface {
+	Write(row *exportmodel.Row) error
+	Flush() error
+}
+
+func newRowWriter(w io.Writer, format exportmodel.Format, columns []string) (rowWriter, error) {
+	switch format {
+	case exportmodel.FormatCSV:
+		cw := csv.NewWriter(w)
+		// The header is written right away, so an export without assets still names its columns.
+		if err := cw.Write(columns); err != nil {
+			return nil, fmt.Errorf("failed to write export header: %w", err)
+		}
+		return &csvWriter{w: cw, columns: columns}, nil
+	case exportmodel.FormatNDJSON:
+		bw := bufio.NewWriter(w)
+		return &ndjsonWriter{w: bw, enc: json.NewEncoder(bw), columns: columns}, nil
+	}
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+type csvWriter struct {
+	w       *csv.Writer
+	columns []string
+}
+
+func (w *csvWriter) Write(row *exportmodel.Row) error {
+	record := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		record[i] = csvValue(row.Value(column))
+	}
+	return w.w.Write(record)
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// csvValue formats a column value for a CSV cell. Lists are joined with semicolons, owners are
+// written as "type:id".
+func csvValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ";")
+	case []exportmodel.Owner:
+		owners := make([]string, len(v))
+		for i, o := range v {
+			owners[i] = o.OwnerType + ":" + o.OwnerID
+		}
+		return strings.Join(owners, ";")
+	}
+	return ""
+}
+
+type ndjsonWriter struct {
+	w       *bufio.Writer
+	enc     *json.Encoder
+	columns []string
+}
+
+func (w *ndjsonWriter) Write(row *exportmodel.Row) error {
+	object := make(map[string]any, len(w.columns))
+	for _, column := range w.columns {
+		object[column] = row.Value(column)
+	}
+	return w.enc.Encode(object)
+}
+
+func (w *ndjsonWriter) Flush() error {
+	return w.w.Flush()
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mediacore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	mediamodel "github.com/mikhail5545/media-service-go/internal/models/media"
+	outboxmodel "github.com/mikhail5545/media-service-go/internal/models/outbox"
+	"github.com/mikhail5545/media-service-go/internal/services/outbox"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultStuckDeletionAge   = time.Hour
+	defaultStuckDeletionLimit = 100
+)
+
+// DeleteRemoteEvent returns the outbox event type of the second deletion phase of the provider.
+func DeleteRemoteEvent(provider string) outboxmodel.EventType {
+	return outboxmodel.EventType("media." + provider + ".delete_remote")
+}
+
+// OutboxHandlers returns delivery handlers for all outbox event types produced by the service.
+func (s *Service) OutboxHandlers() map[outboxmodel.EventType]outbox.Handler {
+	return map[outboxmodel.EventType]outbox.Handler{
+		DeleteRemoteEvent(s.Name()): func(ctx context.Context, payload []byte) error {
+			var data outboxmodel.DeleteRemotePayload
+			if err := json.Unmarshal(payload, &data); err != nil {
+				return fmt.Errorf("failed to decode outbox event payload: %w", err)
+			}
+			return s.completeDelete(ctx, data.AssetID)
+		},
+	}
+}
+
+func (s *Service) enqueueDeleteRemote(ctx context.Context, tx *gorm.DB, assetID uuid.UUID) error {
+	return s.enqueueEvent(ctx, s.outboxRepo.WithTx(tx), DeleteRemoteEvent(s.Name()), outboxmodel.DeleteRemotePayload{AssetID: assetID})
+}
+
+// completeDelete is the second phase of Delete, executed by the outbox dispatcher after the asset was
+// marked as pending deletion. The content is deleted from the backend outside of any database
+// transaction, then the metadata and the record of the asset are deleted. Every step can be repeated,
+// so a failed attempt is retried by the dispatcher.
+func (s *Service) completeDelete(ctx context.Context, assetID uuid.UUID) error {
+	asset, err := s.repo.Get(ctx, s.Name(), assetID, mediamodel.StatusPendingDelete)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Already deleted by a previous attempt
+			return nil
+		}
+		s.log(ctx).Error("failed to retrieve asset pending deletion", zap.Error(err), logging.AssetID(assetID))
+		return fmt.Errorf("failed to retrieve asset pending deletion: %w", err)
+	}
+
+	if err := s.provider.DeleteRemote(ctx, asset); err != nil {
+		s.log(ctx).Error("failed to delete asset from the backend", zap.Error(err), logging.AssetID(asset.ID))
+		return fmt.Errorf("failed to delete asset from the backend: %w", err)
+	}
+	// The metadata goes first, a retry does not find the asset once the record is deleted.
+	if err := s.metadataRepo.Delete(ctx, asset.ID.String()); err != nil {
+		s.log(ctx).Error("failed to delete asset metadata", zap.Error(err), logging.AssetID(asset.ID))
+		return fmt.Errorf("failed to delete asset metadata: %w", err)
+	}
+	if _, err := s.repo.Delete(ctx, s.Name(), asset.ID); err != nil {
+		s.log(ctx).Error("failed to delete asset record", zap.Error(err), logging.AssetID(asset.ID))
+		return fmt.Errorf("failed to delete asset record: %w", err)
+	}
+	s.publishEvent(ctx, events.TypeAssetDeleted, asset.ID, events.WithExternalID(asset.ExternalID), events.WithData("permanent", "true"))
+	return nil
+}
+
+// ListStuckDeletions reports the assets that have been pending deletion for longer than requested.
+// Such assets usually point to the backend rejecting the deletion, the outbox event of the asset holds the last error.
+func (s *Service) ListStuckDeletions(ctx context.Context, req *mediamodel.ListStuckDeletionsRequest) ([]*mediamodel.Asset, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	age := defaultStuckDeletionAge
+	if req.OlderThanMinutes > 0 {
+		age = time.Duration(req.OlderThanMinutes) * time.Minute
+	}
+	limit := defaultStuckDeletionLimit
+	if req.Limit > 0 {
+		limit = req.Limit
+	}
+
+	assets, err := s.repo.ListPendingDelete(ctx, s.Name(), time.Now().Add(-age), limit)
+	if err != nil {
+		s.log(ctx).Error("failed to list assets pending deletion", zap.Error(err))
+		return nil, fmt.Errorf("failed to list assets pending deletion: %w", err)
+	}
+	return assets, nil
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mediacore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	outboxrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/outbox"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	mediamodel "github.com/mikhail5545/media-service-go/internal/models/media"
+	outboxmodel "github.com/mikhail5545/media-service-go/internal/models/outbox"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (s *Service) getAsset(ctx context.Context, id uuid.UUID, statuses ...mediamodel.Status) (*mediamodel.Asset, error) {
+	asset, err := s.repo.Get(ctx, s.Name(), id, statuses...)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, serviceerrors.NewNotFoundError(err)
+		}
+		s.log(ctx).Error("failed to retrieve asset", zap.Error(err), logging.AssetID(id))
+		return nil, fmt.Errorf("failed to retrieve asset: %w", err)
+	}
+	return asset, nil
+}
+
+func (s *Service) getMetadata(ctx context.Context, id uuid.UUID) (*mediamodel.Metadata, error) {
+	metadata, err := s.metadataRepo.Get(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, dbmetadata.ErrNotFound) {
+			return nil, serviceerrors.NewNotFoundError(err)
+		}
+		s.log(ctx).Error("failed to retrieve asset metadata", zap.Error(err), logging.AssetID(id))
+		return nil, fmt.Errorf("failed to retrieve asset metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// withMetadata joins the assets with their metadata, keeping the order of assets. Assets without
+// metadata are returned with empty metadata.
+func (s *Service) withMetadata(ctx context.Context, assets []*mediamodel.Asset) ([]*mediamodel.Details, error) {
+	keys := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		keys = append(keys, asset.ID.String())
+	}
+	metadata, err := s.metadataRepo.ListByKeys(ctx, keys)
+	if err != nil {
+		s.log(ctx).Error("failed to list asset metadata", zap.Error(err))
+		return nil, fmt.Errorf("failed to list asset metadata: %w", err)
+	}
+	details := make([]*mediamodel.Details, 0, len(assets))
+	for _, asset := range assets {
+		md, ok := metadata[asset.ID.String()]
+		if !ok {
+			md = &mediamodel.Metadata{Key: asset.ID.String(), Provider: s.Name(), Owners: []*mediamodel.Owner{}}
+		}
+		details = append(details, &mediamodel.Details{Asset: asset, Metadata: md})
+	}
+	return details, nil
+}
+
+func (s *Service) recordAudit(
+	ctx context.Context,
+	tx *gorm.DB,
+	action auditmodel.Action,
+	assetID uuid.UUID,
+	before, after any,
+	opts ...audit.EntryOption,
+) error {
+	entry, err := audit.NewEntry(ctx, auditmodel.Provider(s.Name()), action, assetID, before, after, opts...)
+	if err != nil {
+		return err
+	}
+	if err := s.auditRepo.WithTx(tx).Create(ctx, entry); err != nil {
+		s.log(ctx).Error("failed to record audit log entry", zap.Error(err), logging.AssetID(assetID), zap.String("action", string(action)))
+		return fmt.Errorf("failed to record audit log entry: %w", err)
+	}
+	return nil
+}
+
+func statusSnapshot(status mediamodel.Status) map[string]any {
+	return map[string]any{"status": status}
+}
+
+func ownersSnapshot(owners []*mediamodel.Owner) map[string]any {
+	return map[string]any{"owners": slices.Clone(owners)}
+}
+
+func (s *Service) publishEvent(ctx context.Context, eventType events.Type, assetID uuid.UUID, opts ...events.Option) {
+	event := events.NewEvent(eventType, events.Provider(s.Name()), assetID, opts...)
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.log(ctx).Warn("failed to publish asset event",
+			zap.Error(err),
+			zap.String("event_type", string(eventType)),
+			logging.AssetID(assetID),
+		)
+	}
+}
+
+func withOwners(owners []*mediamodel.Owner) events.Option {
+	converted := make([]events.Owner, 0, len(owners))
+	for _, owner := range owners {
+		converted = append(converted, events.Owner{OwnerID: owner.OwnerID, OwnerType: owner.OwnerType})
+	}
+	return events.WithOwners(converted...)
+}
+
+// enqueueEvent persists a new outbox event using the transactional outbox repository.
+func (s *Service) enqueueEvent(ctx context.Context, txOutbox *outboxrepo.Repository, eventType outboxmodel.EventType, payload any) error {
+	event, err := outboxmodel.NewEvent(eventType, payload)
+	if err != nil {
+		return err
+	}
+	if err := txOutbox.Enqueue(ctx, event); err != nil {
+		s.log(ctx).Error("failed to enqueue outbox event", zap.Error(err), zap.String("event_type", string(eventType)))
+		return fmt.Errorf("failed to enqueue outbox event: %w", err)
+	}
+	return nil
+}
+
+// mergeAttributes returns the update expression merging attrs into the attributes of an asset.
+func mergeAttributes(attrs map[string]string) (clause.Expr, error) {
+	raw, err := json.Marshal(attrs)
+	if err != nil {
+		return clause.Expr{}, fmt.Errorf("failed to encode asset attributes: %w", err)
+	}
+	return gorm.Expr("COALESCE(attributes, '{}'::jsonb) || ?::jsonb", string(raw)), nil
+}
+
+// nullableString converts empty strings to NULL column values.
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
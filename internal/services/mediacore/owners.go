@@ -0,0 +1,243 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mediacore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	mediamodel "github.com/mikhail5545/media-service-go/internal/models/media"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ListByOwner retrieves a page of active assets associated with the owner.
+func (s *Service) ListByOwner(ctx context.Context, req *mediamodel.ListByOwnerRequest) ([]*mediamodel.Details, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	if err := s.validateOwnerType(req.OwnerType); err != nil {
+		return nil, "", err
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	owner := &mediamodel.Owner{OwnerID: req.OwnerID, OwnerType: req.OwnerType}
+
+	page, nextToken, err := s.metadataRepo.ListByOwner(ctx, s.Name(), owner, pageSize, req.PageToken)
+	if err != nil {
+		s.log(ctx).Error("failed to list asset metadata by owner", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to list asset metadata by owner: %w", err)
+	}
+	ids := make(uuid.UUIDs, 0, len(page))
+	for _, metadata := range page {
+		if id, err := uuid.Parse(metadata.Key); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	assets, err := s.repo.ListByIDs(ctx, s.Name(), ids, mediamodel.StatusActive)
+	if err != nil {
+		s.log(ctx).Error("failed to list assets by owner", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to list assets by owner: %w", err)
+	}
+	byID := make(map[string]*mediamodel.Asset, len(assets))
+	for _, asset := range assets {
+		byID[asset.ID.String()] = asset
+	}
+
+	// Archived owned assets are skipped, so a page can be shorter than requested.
+	details := make([]*mediamodel.Details, 0, len(assets))
+	for _, metadata := range page {
+		if asset, ok := byID[metadata.Key]; ok {
+			details = append(details, &mediamodel.Details{Asset: asset, Metadata: metadata})
+		}
+	}
+	return details, nextToken, nil
+}
+
+// AddOwner associates an owner with a pending or active asset.
+func (s *Service) AddOwner(ctx context.Context, req *mediamodel.ManageOwnerRequest) (*mediamodel.Metadata, error) {
+	return s.changeOwners(ctx, req, auditmodel.ActionAddOwner)
+}
+
+// RemoveOwner disassociates an owner from a pending or active asset.
+func (s *Service) RemoveOwner(ctx context.Context, req *mediamodel.ManageOwnerRequest) (*mediamodel.Metadata, error) {
+	return s.changeOwners(ctx, req, auditmodel.ActionRemoveOwner)
+}
+
+func (s *Service) changeOwners(ctx context.Context, req *mediamodel.ManageOwnerRequest, action auditmodel.Action) (*mediamodel.Metadata, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	if err := s.validateOwnerType(req.OwnerType); err != nil {
+		return nil, err
+	}
+	assetID, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	owner := &mediamodel.Owner{OwnerID: req.OwnerID, OwnerType: req.OwnerType}
+
+	var updated *mediamodel.Metadata
+	err = crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		if _, err := s.repo.WithTx(tx).Get(ctx, s.Name(), assetID, mediamodel.StatusPending, mediamodel.StatusActive); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return serviceerrors.NewNotFoundError(err)
+			}
+			s.log(ctx).Error("failed to retrieve asset in transaction", zap.Error(err), logging.AssetID(assetID))
+			return fmt.Errorf("failed to retrieve asset in transaction: %w", err)
+		}
+		metadata, err := s.getMetadata(ctx, assetID)
+		if err != nil {
+			return err
+		}
+		before := ownersSnapshot(metadata.Owners)
+
+		if action == auditmodel.ActionAddOwner {
+			updated, err = s.addOwner(ctx, tx, metadata, owner)
+		} else {
+			updated, err = s.removeOwner(ctx, metadata, owner)
+		}
+		if err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, tx, action, assetID, before, ownersSnapshot(updated.Owners))
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishEvent(ctx, events.TypeAssetOwnersChanged, assetID, withOwners(updated.Owners))
+	return updated, nil
+}
+
+func (s *Service) addOwner(ctx context.Context, tx *gorm.DB, metadata *mediamodel.Metadata, owner *mediamodel.Owner) (*mediamodel.Metadata, error) {
+	if slices.ContainsFunc(metadata.Owners, owner.Equal) {
+		return nil, serviceerrors.NewOwnerHasAssetError("owner already exists for this asset")
+	}
+	if err := s.checkOwnershipPolicy(ctx, tx, metadata, owner); err != nil {
+		return nil, err
+	}
+	if err := crossstore.Apply(ctx,
+		func(ctx context.Context) error { return s.metadataRepo.AddOwner(ctx, metadata.Key, owner) },
+		func(ctx context.Context) error {
+			_, err := s.metadataRepo.RemoveOwner(ctx, metadata.Key, owner)
+			return err
+		},
+	); err != nil {
+		if errors.Is(err, dbmetadata.ErrNotFound) {
+			return nil, serviceerrors.NewNotFoundError(err)
+		}
+		s.log(ctx).Error("failed to add owner to asset metadata", zap.Error(err), zap.String("asset_id", metadata.Key))
+		return nil, fmt.Errorf("failed to add owner to asset metadata: %w", err)
+	}
+	metadata.Owners = append(metadata.Owners, owner)
+	return metadata, nil
+}
+
+func (s *Service) removeOwner(ctx context.Context, metadata *mediamodel.Metadata, owner *mediamodel.Owner) (*mediamodel.Metadata, error) {
+	var removed bool
+	err := crossstore.Apply(ctx,
+		func(ctx context.Context) (err error) {
+			removed, err = s.metadataRepo.RemoveOwner(ctx, metadata.Key, owner)
+			return err
+		},
+		func(ctx context.Context) error {
+			if !removed {
+				return nil
+			}
+			return s.metadataRepo.AddOwner(ctx, metadata.Key, owner)
+		},
+	)
+	if err != nil {
+		if errors.Is(err, dbmetadata.ErrNotFound) {
+			return nil, serviceerrors.NewNotFoundError(err)
+		}
+		s.log(ctx).Error("failed to remove owner from asset metadata", zap.Error(err), zap.String("asset_id", metadata.Key))
+		return nil, fmt.Errorf("failed to remove owner from asset metadata: %w", err)
+	}
+	if !removed {
+		return nil, serviceerrors.NewNotFoundError("owner is not associated with this asset")
+	}
+	metadata.Owners = slices.DeleteFunc(metadata.Owners, owner.Equal)
+	return metadata, nil
+}
+
+// checkOwnershipPolicy enforces the ownership policies on a new owner of the asset described by metadata.
+// The owner stays locked until tx ends, so the owner can not pass the limit of its assets through a
+// concurrent association.
+func (s *Service) checkOwnershipPolicy(ctx context.Context, tx *gorm.DB, metadata *mediamodel.Metadata, owner *mediamodel.Owner) error {
+	ownerTypes := make([]string, 0, len(metadata.Owners))
+	for _, existing := range metadata.Owners {
+		ownerTypes = append(ownerTypes, existing.OwnerType)
+	}
+	return s.ownership.CheckOwner(ctx, owner.OwnerType, ownerTypes, func(ctx context.Context) (int64, error) {
+		count, err := s.countOwnerAssets(ctx, tx, owner, metadata.Key)
+		if err != nil {
+			s.log(ctx).Error(
+				"failed to count assets of owner",
+				zap.Error(err),
+				zap.String("owner_id", owner.OwnerID),
+				zap.String("owner_type", owner.OwnerType),
+			)
+		}
+		return count, err
+	})
+}
+
+// countOwnerAssets locks the owner within tx and counts the active assets associated with it, other
+// than the asset with the excluded key. Archived assets do not count towards the limit of the owner.
+func (s *Service) countOwnerAssets(ctx context.Context, tx *gorm.DB, owner *mediamodel.Owner, excludeKey string) (int64, error) {
+	txRepo := s.repo.WithTx(tx)
+	if err := txRepo.LockOwner(ctx, s.Name(), owner.OwnerType, owner.OwnerID); err != nil {
+		return 0, fmt.Errorf("failed to lock owner: %w", err)
+	}
+	keys, err := s.metadataRepo.ListKeysByOwner(ctx, s.Name(), owner)
+	if err != nil {
+		return 0, err
+	}
+	ids := make(uuid.UUIDs, 0, len(keys))
+	for _, key := range keys {
+		if id, err := uuid.Parse(key); err == nil && key != excludeKey {
+			ids = append(ids, id)
+		}
+	}
+	return txRepo.CountByIDs(ctx, s.Name(), ids, mediamodel.StatusActive)
+}
+
+// validateOwnerType checks the owner type against the registry of the service, if any.
+func (s *Service) validateOwnerType(ownerType string) error {
+	if s.ownerTypes == nil {
+		return nil
+	}
+	if err := validation.Validate(ownerType, s.ownerTypes.Rule()); err != nil {
+		return serviceerrors.NewValidationFailedError(validation.Errors{"owner_type": err})
+	}
+	return nil
+}
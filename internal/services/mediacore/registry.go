@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mediacore
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	outboxmodel "github.com/mikhail5545/media-service-go/internal/models/outbox"
+	"github.com/mikhail5545/media-service-go/internal/services/outbox"
+)
+
+// Registry holds the services of the storage backends built on the shared asset core, by provider name.
+type Registry struct {
+	mu       sync.RWMutex
+	services map[string]*Service
+}
+
+func NewRegistry() *Registry {
+	return &Registry{services: make(map[string]*Service)}
+}
+
+// Register adds the services to the registry. Provider names must be unique.
+func (r *Registry) Register(services ...*Service) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, svc := range services {
+		if _, ok := r.services[svc.Name()]; ok {
+			return fmt.Errorf("media provider %q is already registered", svc.Name())
+		}
+		r.services[svc.Name()] = svc
+	}
+	return nil
+}
+
+// Get returns the service of the provider.
+func (r *Registry) Get(name string) (*Service, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	svc, ok := r.services[name]
+	return svc, ok
+}
+
+// Names returns the registered provider names in alphabetical order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.services))
+	for name := range r.services {
+		names = append(names, name)
+	}
+	r.mu.RUnlock()
+	slices.Sort(names)
+	return names
+}
+
+// OutboxHandlers returns delivery handlers for the outbox event types produced by all registered
+// services. Services must be registered before the outbox dispatcher is created.
+func (r *Registry) OutboxHandlers() map[outboxmodel.EventType]outbox.Handler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	handlers := make(map[outboxmodel.EventType]outbox.Handler)
+	for _, svc := range r.services {
+		for eventType, handler := range svc.OutboxHandlers() {
+			handlers[eventType] = handler
+		}
+	}
+	return handlers
+}
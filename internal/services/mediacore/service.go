@@ -0,0 +1,425 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package mediacore implements the asset service shared by the storage backends built on
+// [mediaprovider.Provider] adapters: asset records, listing, archiving, ownership, auditing,
+// events and the two-phase permanent deletion.
+//
+// MUX and Cloudinary are not built on adapters, their webhooks, playback and moderation need their
+// own records. They share the event options of [events] and the ownership checks of [ownertypes]
+// with this package instead of keeping copies.
+package mediacore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	metadatarepo "github.com/mikhail5545/media-service-go/internal/database/mongo/media/metadata"
+	auditrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/audit"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/media/asset"
+	outboxrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/outbox"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	"github.com/mikhail5545/media-service-go/internal/mediaprovider"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	mediamodel "github.com/mikhail5545/media-service-go/internal/models/media"
+	"github.com/mikhail5545/media-service-go/internal/ownertypes"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// defaultPageSize is used by List and ListByOwner when the request does not specify a page size.
+const defaultPageSize = 50
+
+// Service is the asset service of a single provider.
+type Service struct {
+	provider     mediaprovider.Provider
+	repo         *assetrepo.Repository
+	metadataRepo *metadatarepo.Repository
+	outboxRepo   *outboxrepo.Repository
+	auditRepo    *auditrepo.Repository
+	publisher    events.Publisher
+	ownerTypes   *ownertypes.Registry
+	ownership    *ownertypes.Policies
+	logger       *zap.Logger
+}
+
+type NewParams struct {
+	Provider     mediaprovider.Provider
+	Repo         *assetrepo.Repository
+	MetadataRepo *metadatarepo.Repository
+	OutboxRepo   *outboxrepo.Repository
+	AuditRepo    *auditrepo.Repository
+	// Publisher is optional, events are discarded if it is not provided.
+	Publisher events.Publisher
+	// OwnerTypes is optional, owner types are not restricted if it is not provided.
+	OwnerTypes *ownertypes.Registry
+	// Ownership is optional, owners are only limited by OwnerTypes if it is not provided.
+	Ownership *ownertypes.Policies
+}
+
+func New(params *NewParams, logger *zap.Logger) (*Service, error) {
+	if params.Provider == nil {
+		return nil, errors.New("media provider is required")
+	}
+	name := params.Provider.Name()
+	if !mediaprovider.ValidName(name) {
+		return nil, fmt.Errorf("invalid media provider name %q", name)
+	}
+	publisher := params.Publisher
+	if publisher == nil {
+		publisher = events.NoopPublisher{}
+	}
+	return &Service{
+		provider:     params.Provider,
+		repo:         params.Repo,
+		metadataRepo: params.MetadataRepo,
+		outboxRepo:   params.OutboxRepo,
+		auditRepo:    params.AuditRepo,
+		publisher:    publisher,
+		ownerTypes:   params.OwnerTypes,
+		ownership:    params.Ownership,
+		logger:       logger.With(zap.String("layer", "service"), zap.String("service", "media"), zap.String("provider", name)),
+	}, nil
+}
+
+// log returns the service logger enriched with the request-scoped fields stored in ctx.
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// Name returns the name of the provider.
+func (s *Service) Name() string {
+	return s.provider.Name()
+}
+
+// Provider returns the adapter of the backend.
+func (s *Service) Provider() mediaprovider.Provider {
+	return s.provider
+}
+
+// Create persists a new asset of the provider with empty metadata. It is called by the adapters once
+// the backend accepted the asset, e.g. when an upload URL was issued. The provider of the asset is
+// set by the service, the status defaults to pending.
+func (s *Service) Create(ctx context.Context, asset *mediamodel.Asset) error {
+	asset.Provider = s.Name()
+	if asset.Status == "" {
+		asset.Status = mediamodel.StatusPending
+	}
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		if err := s.repo.WithTx(tx).Create(ctx, asset); err != nil {
+			s.log(ctx).Error("failed to create asset record", zap.Error(err))
+			return fmt.Errorf("failed to create asset record: %w", err)
+		}
+		metadata := &mediamodel.Metadata{
+			Key:      asset.ID.String(),
+			Provider: s.Name(),
+			Owners:   []*mediamodel.Owner{},
+		}
+		if err := crossstore.Apply(ctx,
+			func(ctx context.Context) error { return s.metadataRepo.Create(ctx, metadata) },
+			func(ctx context.Context) error { return s.metadataRepo.Delete(ctx, metadata.Key) },
+		); err != nil {
+			s.log(ctx).Error("failed to create asset metadata", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to create asset metadata: %w", err)
+		}
+		return s.recordAudit(ctx, tx, auditmodel.ActionCreate, asset.ID, nil, asset)
+	})
+	if err != nil {
+		return err
+	}
+	s.publishEvent(ctx, events.TypeAssetCreated, asset.ID, events.WithExternalID(asset.ExternalID))
+	return nil
+}
+
+// Activate marks the pending asset as active, applying the updates reported by the backend, e.g. the
+// size or the checksum of the uploaded content. Activating an active asset only applies the updates.
+// An "attributes" update of type map[string]string is merged into the existing attributes.
+func (s *Service) Activate(ctx context.Context, assetID uuid.UUID, updates map[string]any) (*mediamodel.Asset, error) {
+	values := make(map[string]any, len(updates)+1)
+	for k, v := range updates {
+		values[k] = v
+	}
+	if attrs, ok := values["attributes"].(map[string]string); ok {
+		expr, err := mergeAttributes(attrs)
+		if err != nil {
+			return nil, err
+		}
+		values["attributes"] = expr
+	}
+	values["status"] = mediamodel.StatusActive
+
+	affected, err := s.repo.Update(ctx, s.Name(), assetID, values, mediamodel.StatusPending, mediamodel.StatusActive)
+	if err != nil {
+		s.log(ctx).Error("failed to activate asset", zap.Error(err), logging.AssetID(assetID))
+		return nil, fmt.Errorf("failed to activate asset: %w", err)
+	}
+	if affected == 0 {
+		return nil, serviceerrors.NewConflictError("only pending or active assets can be activated")
+	}
+	asset, err := s.getAsset(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	s.publishEvent(ctx, events.TypeAssetReady, asset.ID, events.WithExternalID(asset.ExternalID))
+	return asset, nil
+}
+
+// MarkErrored marks the pending asset as errored after the backend failed to process it. The reason
+// is kept in the [mediamodel.AttributeError] attribute. Marking an errored asset again only replaces
+// the reason.
+func (s *Service) MarkErrored(ctx context.Context, assetID uuid.UUID, reason string) (*mediamodel.Asset, error) {
+	expr, err := mergeAttributes(map[string]string{mediamodel.AttributeError: reason})
+	if err != nil {
+		return nil, err
+	}
+	affected, err := s.repo.Update(ctx, s.Name(), assetID, map[string]any{
+		"status":     mediamodel.StatusErrored,
+		"attributes": expr,
+	}, mediamodel.StatusPending, mediamodel.StatusErrored)
+	if err != nil {
+		s.log(ctx).Error("failed to mark asset as errored", zap.Error(err), logging.AssetID(assetID))
+		return nil, fmt.Errorf("failed to mark asset as errored: %w", err)
+	}
+	if affected == 0 {
+		return nil, serviceerrors.NewConflictError("only pending assets can be marked as errored")
+	}
+	asset, err := s.getAsset(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	s.publishEvent(ctx, events.TypeAssetErrored, asset.ID, events.WithExternalID(asset.ExternalID), events.WithData(mediamodel.AttributeError, reason))
+	return asset, nil
+}
+
+// GetByExternalID retrieves an asset of the provider in any state by its ID in the backend. It is
+// used to match backend notifications with assets.
+func (s *Service) GetByExternalID(ctx context.Context, externalID string) (*mediamodel.Asset, error) {
+	asset, err := s.repo.GetByExternalID(ctx, s.Name(), externalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, serviceerrors.NewNotFoundError(err)
+		}
+		s.log(ctx).Error("failed to retrieve asset by external ID", zap.Error(err), zap.String("external_id", externalID))
+		return nil, fmt.Errorf("failed to retrieve asset: %w", err)
+	}
+	return asset, nil
+}
+
+// Get retrieves an active asset.
+func (s *Service) Get(ctx context.Context, req *mediamodel.GetRequest) (*mediamodel.Details, error) {
+	return s.get(ctx, req, mediamodel.StatusActive)
+}
+
+// GetWithArchived retrieves an asset that can be pending, active, errored or archived.
+func (s *Service) GetWithArchived(ctx context.Context, req *mediamodel.GetRequest) (*mediamodel.Details, error) {
+	return s.get(ctx, req, mediamodel.StatusPending, mediamodel.StatusActive, mediamodel.StatusErrored, mediamodel.StatusArchived)
+}
+
+// List retrieves a page of active assets, newest first.
+func (s *Service) List(ctx context.Context, req *mediamodel.ListRequest) ([]*mediamodel.Details, string, error) {
+	return s.list(ctx, req, mediamodel.StatusActive)
+}
+
+// ListArchived retrieves a page of archived assets, newest first.
+func (s *Service) ListArchived(ctx context.Context, req *mediamodel.ListRequest) ([]*mediamodel.Details, string, error) {
+	return s.list(ctx, req, mediamodel.StatusArchived)
+}
+
+// Archive soft-deletes an asset. Owners are kept, so a restored asset is associated with them again.
+func (s *Service) Archive(ctx context.Context, req *mediamodel.ChangeStateRequest) error {
+	return s.changeState(ctx, req, auditmodel.ActionArchive, "archived_by", []mediamodel.Status{mediamodel.StatusPending, mediamodel.StatusActive}, map[string]any{
+		"status":     mediamodel.StatusArchived,
+		"deleted_at": time.Now(),
+	}, "only pending or active assets can be archived")
+}
+
+// Restore restores an archived asset back to active status.
+func (s *Service) Restore(ctx context.Context, req *mediamodel.ChangeStateRequest) error {
+	return s.changeState(ctx, req, auditmodel.ActionRestore, "restored_by", []mediamodel.Status{mediamodel.StatusArchived}, map[string]any{
+		"status":     mediamodel.StatusActive,
+		"deleted_at": nil,
+	}, "only archived assets can be restored")
+}
+
+// Delete permanently deletes an archived or errored asset along with its metadata. Errored assets
+// are deleted directly, as restoring them would make them active. The asset is marked as pending
+// deletion and deleted from the backend and the databases asynchronously by the outbox dispatcher.
+func (s *Service) Delete(ctx context.Context, req *mediamodel.ChangeStateRequest) error {
+	if err := req.Validate(); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		asset, err := s.transition(ctx, tx, req, []mediamodel.Status{mediamodel.StatusArchived, mediamodel.StatusErrored}, map[string]any{
+			"status":              mediamodel.StatusPendingDelete,
+			"delete_requested_at": time.Now(),
+			"note":                nullableString(req.Note),
+		}, "only archived or errored assets can be deleted")
+		if err != nil {
+			return err
+		}
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionDelete, asset.ID, statusSnapshot(asset.Status), statusSnapshot(mediamodel.StatusPendingDelete),
+			audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note),
+		); err != nil {
+			return err
+		}
+		return s.enqueueDeleteRemote(ctx, tx, asset.ID)
+	})
+}
+
+func (s *Service) get(ctx context.Context, req *mediamodel.GetRequest, statuses ...mediamodel.Status) (*mediamodel.Details, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	assetID, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	asset, err := s.getAsset(ctx, assetID, statuses...)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.getMetadata(ctx, asset.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &mediamodel.Details{Asset: asset, Metadata: metadata}, nil
+}
+
+func (s *Service) list(ctx context.Context, req *mediamodel.ListRequest, statuses ...mediamodel.Status) ([]*mediamodel.Details, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	var afterID uuid.UUID
+	if req.PageToken != "" {
+		id, err := parsing.StrToUUID(req.PageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		afterID = id
+	}
+
+	assets, next, err := s.repo.List(ctx, s.Name(), statuses, pageSize, afterID)
+	if err != nil {
+		s.log(ctx).Error("failed to list assets", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to list assets: %w", err)
+	}
+	details, err := s.withMetadata(ctx, assets)
+	if err != nil {
+		return nil, "", err
+	}
+	var nextToken string
+	if next != uuid.Nil {
+		nextToken = next.String()
+	}
+	return details, nextToken, nil
+}
+
+// ListByCreation retrieves a page of active assets ordered by the creation time, newest first. The
+// page token is shared with the creation-ordered listings of the other providers, which lets the
+// catalog page through all providers with a single token.
+func (s *Service) ListByCreation(ctx context.Context, pageSize int, pageToken string) ([]*mediamodel.Details, string, error) {
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	assets, nextToken, err := s.repo.ListByCreation(ctx, s.Name(), []mediamodel.Status{mediamodel.StatusActive}, pageSize, pageToken)
+	if err != nil {
+		s.log(ctx).Error("failed to list assets by creation time", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to list assets: %w", err)
+	}
+	details, err := s.withMetadata(ctx, assets)
+	if err != nil {
+		return nil, "", err
+	}
+	return details, nextToken, nil
+}
+
+func (s *Service) changeState(
+	ctx context.Context,
+	req *mediamodel.ChangeStateRequest,
+	action auditmodel.Action,
+	adminColumn string,
+	from []mediamodel.Status,
+	updates map[string]any,
+	conflict string,
+) error {
+	if err := req.Validate(); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	adminID, err := parsing.StrToUUID(req.AdminID)
+	if err != nil {
+		return err
+	}
+	updates[adminColumn] = adminID
+	updates[adminColumn+"_name"] = req.AdminName
+	updates["note"] = nullableString(req.Note)
+
+	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		asset, err := s.transition(ctx, tx, req, from, updates, conflict)
+		if err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, tx, action, asset.ID, statusSnapshot(asset.Status), statusSnapshot(updates["status"].(mediamodel.Status)),
+			audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note),
+		)
+	})
+}
+
+// transition applies the updates to the asset within tx if it is in one of the from statuses and
+// returns the asset as it was before the updates.
+func (s *Service) transition(
+	ctx context.Context,
+	tx *gorm.DB,
+	req *mediamodel.ChangeStateRequest,
+	from []mediamodel.Status,
+	updates map[string]any,
+	conflict string,
+) (*mediamodel.Asset, error) {
+	assetID, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	txRepo := s.repo.WithTx(tx)
+	asset, err := txRepo.Get(ctx, s.Name(), assetID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, serviceerrors.NewNotFoundError(err)
+		}
+		s.log(ctx).Error("failed to retrieve asset in transaction", zap.Error(err), logging.AssetID(assetID))
+		return nil, fmt.Errorf("failed to retrieve asset in transaction: %w", err)
+	}
+	affected, err := txRepo.Update(ctx, s.Name(), asset.ID, updates, from...)
+	if err != nil {
+		s.log(ctx).Error("failed to update asset state", zap.Error(err), logging.AssetID(asset.ID))
+		return nil, fmt.Errorf("failed to update asset state: %w", err)
+	}
+	if affected == 0 {
+		return nil, serviceerrors.NewConflictError(conflict)
+	}
+	return asset, nil
+}
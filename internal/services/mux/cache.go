@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/cache"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	"github.com/mikhail5545/media-service-go/internal/environment"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func assetCacheKey(id uuid.UUID) string {
+	return cache.Key("mux", "asset", id.String())
+}
+
+func metadataCacheKey(id uuid.UUID) string {
+	return cache.Key("mux", "metadata", id.String())
+}
+
+// cachedAsset returns the asset from the cache or loads it from Postgres. The cached asset is matched
+// against the scopes in memory, so a single entry serves Get, GetWithArchived and GetWithBroken.
+func (s *Service) cachedAsset(ctx context.Context, id uuid.UUID, scopes []assetrepo.Scope) (*assetmodel.Asset, error) {
+	var cached assetmodel.Asset
+	if s.cacheGet(ctx, assetCacheKey(id), &cached) {
+		// The cache is shared by the environments, the cached asset has to be scoped like the queries
+		if !assetrepo.ScopesInclude(scopes, cached.Status) || !environment.Matches(ctx, cached.Environment) {
+			return nil, serviceerrors.NewNotFoundError(gorm.ErrRecordNotFound)
+		}
+		return &cached, nil
+	}
+	asset, err := s.getAsset(ctx, id, scopes)
+	if err != nil {
+		return nil, err
+	}
+	s.cacheSet(ctx, assetCacheKey(id), asset, s.cacheTTL.Asset)
+	return asset, nil
+}
+
+// cachedAssetMetadata returns the asset metadata from the cache or loads it from MongoDB.
+func (s *Service) cachedAssetMetadata(ctx context.Context, id uuid.UUID) (*metadatamodel.AssetMetadata, error) {
+	var cached metadatamodel.AssetMetadata
+	if s.cacheGet(ctx, metadataCacheKey(id), &cached) {
+		return &cached, nil
+	}
+	metadata, err := s.getAssetMetadata(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.cacheSet(ctx, metadataCacheKey(id), metadata, s.cacheTTL.Metadata)
+	return metadata, nil
+}
+
+// invalidate drops the cached asset and metadata. It is called after every write, a failed write
+// only costs a cache miss.
+func (s *Service) invalidate(ctx context.Context, ids ...uuid.UUID) {
+	keys := make([]string, 0, 2*len(ids))
+	for _, id := range ids {
+		if id == uuid.Nil {
+			continue
+		}
+		keys = append(keys, assetCacheKey(id), metadataCacheKey(id))
+	}
+	if len(keys) == 0 {
+		return
+	}
+	if err := s.cache.Delete(ctx, keys...); err != nil {
+		s.log(ctx).Warn("failed to invalidate cached asset", zap.Error(err), zap.Strings("keys", keys))
+	}
+}
+
+// invalidateByID is [Service.invalidate] for request IDs, malformed IDs are ignored.
+func (s *Service) invalidateByID(ctx context.Context, id string) {
+	if assetID, err := uuid.Parse(id); err == nil {
+		s.invalidate(ctx, assetID)
+	}
+}
+
+// cacheGet reads the key into dst. Cache errors are logged and reported as a miss, so that an
+// unavailable Redis only slows reads down.
+func (s *Service) cacheGet(ctx context.Context, key string, dst any) bool {
+	found, err := s.cache.Get(ctx, key, dst)
+	if err != nil {
+		s.log(ctx).Warn("failed to read from cache", zap.Error(err), zap.String("key", key))
+		return false
+	}
+	return found
+}
+
+func (s *Service) cacheSet(ctx context.Context, key string, value any, ttl time.Duration) {
+	if err := s.cache.Set(ctx, key, value, ttl); err != nil {
+		s.log(ctx).Warn("failed to write to cache", zap.Error(err), zap.String("key", key))
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"gorm.io/gorm"
+)
+
+// ListChapters lists the chapters of a video, ordered by their start time.
+func (s *Service) ListChapters(ctx context.Context, req *assetmodel.ListChaptersRequest) ([]*metadatamodel.Chapter, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	assetID, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.getAssetMetadata(ctx, assetID, "chapters")
+	if err != nil {
+		return nil, err
+	}
+	if metadata.Chapters == nil {
+		return []*metadatamodel.Chapter{}, nil
+	}
+	return metadata.Chapters, nil
+}
+
+// AddChapter adds a chapter marker to a video. Chapters cannot start past the end of the video and
+// two chapters cannot start at the same time.
+func (s *Service) AddChapter(ctx context.Context, req *assetmodel.AddChapterRequest) ([]*metadatamodel.Chapter, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	chapterID, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate chapter ID: %w", err)
+	}
+	return s.changeChapters(ctx, req.ID, func(chapters []*metadatamodel.Chapter) ([]*metadatamodel.Chapter, error) {
+		if len(chapters) >= assetmodel.MaxChapters {
+			return nil, serviceerrors.NewConflictError(fmt.Sprintf("asset already has %d chapters", assetmodel.MaxChapters))
+		}
+		return append(chapters, &metadatamodel.Chapter{
+			ID:            chapterID.String(),
+			Title:         req.Title,
+			StartTime:     req.StartTime,
+			ThumbnailTime: req.ThumbnailTime,
+		}), nil
+	}, audit.WithAdmin(req.AdminID, req.AdminName))
+}
+
+// UpdateChapter changes the title, the start time or the thumbnail time of a chapter.
+func (s *Service) UpdateChapter(ctx context.Context, req *assetmodel.UpdateChapterRequest) ([]*metadatamodel.Chapter, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	return s.changeChapters(ctx, req.ID, func(chapters []*metadatamodel.Chapter) ([]*metadatamodel.Chapter, error) {
+		i := slices.IndexFunc(chapters, func(c *metadatamodel.Chapter) bool { return c.ID == req.ChapterID })
+		if i < 0 {
+			return nil, serviceerrors.NewNotFoundError(fmt.Errorf("chapter %s not found", req.ChapterID))
+		}
+		// The chapter is replaced rather than changed, the previous chapters are kept for the rollback
+		// and the audit entry.
+		chapter := *chapters[i]
+		if req.Title != nil {
+			chapter.Title = *req.Title
+		}
+		if req.StartTime != nil {
+			chapter.StartTime = *req.StartTime
+		}
+		if req.ThumbnailTime != nil {
+			chapter.ThumbnailTime = req.ThumbnailTime
+		}
+		chapters[i] = &chapter
+		return chapters, nil
+	}, audit.WithAdmin(req.AdminID, req.AdminName))
+}
+
+// DeleteChapter removes a chapter from a video.
+func (s *Service) DeleteChapter(ctx context.Context, req *assetmodel.DeleteChapterRequest) ([]*metadatamodel.Chapter, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	return s.changeChapters(ctx, req.ID, func(chapters []*metadatamodel.Chapter) ([]*metadatamodel.Chapter, error) {
+		i := slices.IndexFunc(chapters, func(c *metadatamodel.Chapter) bool { return c.ID == req.ChapterID })
+		if i < 0 {
+			return nil, serviceerrors.NewNotFoundError(fmt.Errorf("chapter %s not found", req.ChapterID))
+		}
+		return slices.Delete(chapters, i, i+1), nil
+	}, audit.WithAdmin(req.AdminID, req.AdminName))
+}
+
+// changeChapters applies change to a copy of the chapters of the asset, validates the result against
+// the duration of the video and stores the chapters ordered by their start time.
+func (s *Service) changeChapters(
+	ctx context.Context,
+	id string,
+	change func([]*metadatamodel.Chapter) ([]*metadatamodel.Chapter, error),
+	admin audit.EntryOption,
+) ([]*metadatamodel.Chapter, error) {
+	defer s.invalidateByID(ctx, id)
+
+	var (
+		changed  *assetmodel.Asset
+		metadata *metadatamodel.AssetMetadata
+	)
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		asset, err := s.getInTx(ctx, txRepo, []string{
+			"id", "status", "mux_asset_id", "duration",
+		}, assetSearchOptions{
+			AssetID: id,
+		})
+		if err != nil {
+			return err
+		}
+		if asset.Status == assetmodel.StatusArchived || asset.Status == assetmodel.StatusBroken {
+			return serviceerrors.NewConflictError("cannot change chapters of archived or broken asset")
+		}
+
+		metadata, err = s.getAssetMetadata(ctx, asset.ID)
+		if err != nil {
+			return err
+		}
+		previous := metadataCopy(metadata)
+
+		chapters, err := change(slices.Clone(metadata.Chapters))
+		if err != nil {
+			return err
+		}
+		if err := validateChapters(chapters, asset.Duration); err != nil {
+			return err
+		}
+		slices.SortFunc(chapters, func(a, b *metadatamodel.Chapter) int { return cmp.Compare(a.StartTime, b.StartTime) })
+		metadata.Chapters = chapters
+
+		if err := s.saveMetadata(ctx, previous, metadata); err != nil {
+			return err
+		}
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionUpdateChapters, asset.ID,
+			chaptersSnapshot(previous.Chapters), chaptersSnapshot(metadata.Chapters), admin,
+		); err != nil {
+			return err
+		}
+		changed = asset
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishEvent(ctx, events.TypeAssetUpdated, changed.ID, withExternalID(changed.MuxAssetID))
+	if metadata.Chapters == nil {
+		return []*metadatamodel.Chapter{}, nil
+	}
+	return metadata.Chapters, nil
+}
+
+// validateChapters checks that no two chapters start at the same time and, once the duration of the
+// video is known, that chapters and their thumbnails are within the video.
+func validateChapters(chapters []*metadatamodel.Chapter, duration *float32) error {
+	starts := make(map[float64]struct{}, len(chapters))
+	for _, chapter := range chapters {
+		if _, ok := starts[chapter.StartTime]; ok {
+			return serviceerrors.NewConflictError(fmt.Sprintf("another chapter starts at %.2fs", chapter.StartTime))
+		}
+		starts[chapter.StartTime] = struct{}{}
+
+		if duration == nil {
+			continue
+		}
+		if chapter.StartTime >= float64(*duration) {
+			return serviceerrors.NewValidationFailedError(fmt.Errorf("start_time: must be before the end of the video at %.2fs", *duration))
+		}
+		if chapter.ThumbnailTime != nil && *chapter.ThumbnailTime > float64(*duration) {
+			return serviceerrors.NewValidationFailedError(fmt.Errorf("thumbnail_time: must not exceed the duration of %.2fs", *duration))
+		}
+	}
+	return nil
+}
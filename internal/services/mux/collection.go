@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	collectionmodel "github.com/mikhail5545/media-service-go/internal/models/collection"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ListByCollection retrieves a page of assets which belong to a collection.
+// Assets are ordered by ID, the returned page token is the last asset ID of the page.
+func (s *Service) ListByCollection(ctx context.Context, req *assetmodel.ListByCollectionRequest) ([]*assetmodel.Details, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	collectionID, err := parsing.StrToUUID(req.CollectionID)
+	if err != nil {
+		return nil, "", err
+	}
+	afterID, err := parsing.StrToUUID(req.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultListByOwnerPageSize
+	}
+
+	if _, err := s.collectionRepo.Get(ctx, collectionID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", serviceerrors.NewNotFoundError("collection not found")
+		}
+		s.log(ctx).Error("failed to get collection", zap.Error(err), zap.String("collection_id", req.CollectionID))
+		return nil, "", fmt.Errorf("failed to get collection: %w", err)
+	}
+
+	// Fetch one extra ID to check for the next page.
+	ids, err := s.collectionRepo.ListAssetIDs(ctx, collectionID, collectionmodel.ProviderMux, pageSize+1, afterID)
+	if err != nil {
+		s.log(ctx).Error("failed to list collection assets", zap.Error(err), zap.String("collection_id", req.CollectionID))
+		return nil, "", fmt.Errorf("failed to list collection assets: %w", err)
+	}
+	var nextPageToken string
+	if len(ids) > pageSize {
+		ids = ids[:pageSize]
+		nextPageToken = ids[pageSize-1].String()
+	}
+	if len(ids) == 0 {
+		return []*assetmodel.Details{}, "", nil
+	}
+
+	keys := make([]string, len(ids))
+	for i := range ids {
+		keys[i] = ids[i].String()
+	}
+	metadataMap, err := s.metadataRepo.ListByKeys(ctx, keys)
+	if err != nil {
+		s.log(ctx).Error("failed to list asset metadata", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to list asset metadata: %w", err)
+	}
+	metadataPage := make([]*metadatamodel.AssetMetadata, 0, len(keys))
+	for _, key := range keys {
+		if metadata, ok := metadataMap[key]; ok {
+			metadataPage = append(metadataPage, metadata)
+		}
+	}
+
+	details, err := s.joinAssets(ctx, metadataPage)
+	if err != nil {
+		return nil, "", err
+	}
+	return details, nextPageToken, nil
+}
+
+// removeFromCollections drops the collection memberships of a permanently deleted asset within tx.
+func (s *Service) removeFromCollections(ctx context.Context, tx *gorm.DB, assetID uuid.UUID) error {
+	if err := s.collectionRepo.WithTx(tx).RemoveAssetFromAll(ctx, collectionmodel.ProviderMux, assetID); err != nil {
+		s.log(ctx).Error("failed to remove asset from collections", zap.Error(err), logging.AssetID(assetID))
+		return fmt.Errorf("failed to remove asset from collections: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	"github.com/mikhail5545/media-service-go/internal/environment"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	muxgo "github.com/muxinc/mux-go/v6"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultStuckDeletionAge   = time.Hour
+	defaultStuckDeletionLimit = 100
+)
+
+// completeDelete is the second phase of Delete, executed by the outbox dispatcher after the asset was
+// marked as pending deletion. The asset is deleted from MUX outside of any database transaction, then
+// its metadata and its record are deleted. Every step can be repeated, so a failed attempt is retried
+// by the dispatcher.
+func (s *Service) completeDelete(ctx context.Context, assetID uuid.UUID) error {
+	asset, err := s.repo.GetPendingDelete(ctx, assetID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Already deleted by a previous attempt
+			return nil
+		}
+		s.log(ctx).Error("failed to retrieve mux asset pending deletion", zap.Error(err), logging.AssetID(assetID))
+		return fmt.Errorf("failed to retrieve mux asset pending deletion: %w", err)
+	}
+	defer s.invalidate(ctx, asset.ID)
+	ctx = environment.WithContext(ctx, asset.Environment)
+
+	if asset.MuxAssetID != nil && *asset.MuxAssetID != "" {
+		if err := s.apiClient.DeleteAsset(ctx, *asset.MuxAssetID); err != nil {
+			// Asset may be already deleted from MUX (e.g. by a previous attempt)
+			var notFound muxgo.NotFoundError
+			if !errors.As(err, &notFound) {
+				s.log(ctx).Error("failed to delete mux asset", zap.Error(err), logging.AssetID(asset.ID))
+				return fmt.Errorf("failed to delete mux asset: %w", err)
+			}
+		}
+	}
+	// The metadata goes first, a retry does not find the asset once the record is deleted.
+	if err := s.deleteAssetMetadata(ctx, asset.ID); err != nil {
+		return err
+	}
+	if _, err := s.repo.Delete(ctx, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}); err != nil {
+		s.log(ctx).Error("failed to delete mux asset record", zap.Error(err), logging.AssetID(asset.ID))
+		return fmt.Errorf("failed to delete mux asset record: %w", err)
+	}
+	s.publishEvent(ctx, events.TypeAssetDeleted, asset.ID, withExternalID(asset.MuxAssetID), events.WithData("permanent", "true"))
+	return nil
+}
+
+// ListStuckDeletions reports the assets that have been pending deletion for longer than requested.
+// Such assets usually point to MUX rejecting the deletion, the outbox event of the asset holds the last error.
+func (s *Service) ListStuckDeletions(ctx context.Context, req *assetmodel.ListStuckDeletionsRequest) ([]*assetmodel.Asset, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	age := defaultStuckDeletionAge
+	if req.OlderThanMinutes > 0 {
+		age = time.Duration(req.OlderThanMinutes) * time.Minute
+	}
+	limit := defaultStuckDeletionLimit
+	if req.Limit > 0 {
+		limit = req.Limit
+	}
+
+	assets, err := s.repo.ListPendingDelete(ctx, time.Now().Add(-age), limit)
+	if err != nil {
+		s.log(ctx).Error("failed to list mux assets pending deletion", zap.Error(err))
+		return nil, fmt.Errorf("failed to list mux assets pending deletion: %w", err)
+	}
+	return assets, nil
+}
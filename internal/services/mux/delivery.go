@@ -0,0 +1,197 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	apiclient "github.com/mikhail5545/media-service-go/internal/apiclients/mux"
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	"github.com/mikhail5545/media-service-go/internal/entitlement"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	"github.com/mikhail5545/media-service-go/internal/viewer"
+	"go.uber.org/zap"
+)
+
+// PlaybackInfo returns the playback details of an asset to the authenticated end user, with a
+// playback token valid for the configured delivery token TTL. The user must be allowed to access the
+// asset by one of its owners and by the playback restriction policy of the asset, the issued token is
+// recorded as a playback session of the user. Setting the token as a cookie or naming its header is
+// left to the transport.
+func (s *Service) PlaybackInfo(ctx context.Context, req *assetmodel.PlaybackInfoRequest) (*assetmodel.PlaybackInfo, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	assetID := uuid.MustParse(req.ID)
+	userUUID, metadata, err := s.authorizeDelivery(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	asset, err := s.getPlayableAsset(ctx, assetID, "primary_signed_playback_id", "primary_drm_playback_id", "duration", "poster_time", "poster_image_url")
+	if err != nil {
+		return nil, err
+	}
+	if asset.PrimarySignedPlaybackID == nil {
+		if asset.PrimaryDRMPlaybackID != nil {
+			return nil, serviceerrors.NewConflictError("asset is DRM protected, request its DRM playback tokens instead")
+		}
+		return nil, serviceerrors.NewConflictError("asset does not have a signed playback ID")
+	}
+
+	v, _ := viewer.FromContext(ctx)
+	restrictionID, err := s.playbackRestriction(ctx, metadata.Owners, v)
+	if err != nil {
+		return nil, err
+	}
+
+	signingKey, err := s.signingKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(s.deliveryTokenTTL)
+	tokenReq := &assetmodel.GeneratePlaybackTokenRequest{
+		AssetID:    assetID,
+		UserID:     userUUID,
+		Expiration: expiresAt.Unix(),
+	}
+	opts := apiclient.GeneratePlaybackTokenOptions{
+		UserID:                userUUID,
+		PlaybackID:            *asset.PrimarySignedPlaybackID,
+		Expiration:            tokenReq.Expiration,
+		PlaybackRestrictionID: restrictionID,
+		SigningKey:            signingKey,
+	}
+	token, err := s.apiClient.GeneratePlaybackJWTToken(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	posterURL, err := s.signedPosterURL(ctx, asset, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordTokens(ctx, tokenReq, token); err != nil {
+		return nil, err
+	}
+	return &assetmodel.PlaybackInfo{
+		PlaybackID: opts.PlaybackID,
+		PosterURL:  posterURL,
+		Duration:   asset.Duration,
+		Languages:  assetmodel.NewLanguages(metadata),
+		Token:      token,
+		ExpiresAt:  expiresAt,
+
+		TokenDelivery: cmp.Or(req.TokenDelivery, assetmodel.TokenDeliveryQuery),
+	}, nil
+}
+
+// DRMPlayback returns the playback and license tokens of a DRM protected asset to the authenticated end
+// user. The user is authorized like in [Service.PlaybackInfo], the tokens expire after the configured
+// delivery token TTL and are recorded as a playback session of the user.
+func (s *Service) DRMPlayback(ctx context.Context, req *assetmodel.DRMPlaybackRequest) (*assetmodel.DRMPlaybackTokens, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	assetID := uuid.MustParse(req.ID)
+	userUUID, metadata, err := s.authorizeDelivery(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	asset, err := s.getPlayableAsset(ctx, assetID, "primary_drm_playback_id")
+	if err != nil {
+		return nil, err
+	}
+	if asset.PrimaryDRMPlaybackID == nil {
+		return nil, serviceerrors.NewConflictError("asset is not DRM protected")
+	}
+
+	v, _ := viewer.FromContext(ctx)
+	restrictionID, err := s.playbackRestriction(ctx, metadata.Owners, v)
+	if err != nil {
+		return nil, err
+	}
+	return s.drmTokens(ctx, &assetmodel.GeneratePlaybackTokenRequest{
+		AssetID:    assetID,
+		UserID:     userUUID,
+		Expiration: time.Now().Add(s.deliveryTokenTTL).Unix(),
+	}, *asset.PrimaryDRMPlaybackID, restrictionID)
+}
+
+// authorizeDelivery returns the UUID of the authenticated end user and the metadata of the asset if the
+// user may access it.
+func (s *Service) authorizeDelivery(ctx context.Context, assetID uuid.UUID) (uuid.UUID, *metadatamodel.AssetMetadata, error) {
+	userID, err := entitlement.UserID(ctx)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	// Playback sessions are recorded per user ID.
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, nil, serviceerrors.NewPermissionDeniedError("user ID must be a UUID")
+	}
+	metadata, err := s.checkEntitlement(ctx, assetID, userID)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	return userUUID, metadata, nil
+}
+
+// signedPosterURL returns the poster image of the asset, or the thumbnail of the signed playback ID at
+// the poster time.
+func (s *Service) signedPosterURL(ctx context.Context, asset *assetmodel.Asset, opts apiclient.GeneratePlaybackTokenOptions) (string, error) {
+	if asset.PosterImageURL != nil {
+		return *asset.PosterImageURL, nil
+	}
+	if asset.PosterTime != nil {
+		opts.Params = map[string]string{"time": assetmodel.PosterTimeParam(*asset.PosterTime)}
+	}
+	token, err := s.apiClient.GenerateThumbnailJWTToken(ctx, opts)
+	if err != nil {
+		return "", err
+	}
+	return assetmodel.ThumbnailURL(opts.PlaybackID, url.Values{"token": {token}}), nil
+}
+
+// checkEntitlement returns a permission denied error unless the user may access the asset through
+// one of its owners. The returned metadata holds the owners and the language fields.
+func (s *Service) checkEntitlement(ctx context.Context, assetID uuid.UUID, userID string) (*metadatamodel.AssetMetadata, error) {
+	metadata, err := s.metadataRepo.Get(ctx, assetID.String(), append([]string{"owners"}, assetmodel.LanguageFields...)...)
+	if err != nil {
+		if errors.Is(err, dbmetadata.ErrNotFound) {
+			return nil, serviceerrors.NewNotFoundError(err)
+		}
+		s.log(ctx).Error("failed to retrieve asset owners", zap.Error(err), logging.AssetID(assetID))
+		return nil, fmt.Errorf("failed to retrieve asset owners: %w", err)
+	}
+	owners := make([]entitlement.Owner, 0, len(metadata.Owners))
+	for _, owner := range metadata.Owners {
+		owners = append(owners, entitlement.Owner{Type: owner.OwnerType, ID: owner.OwnerID})
+	}
+	if err := entitlement.Check(ctx, s.entitlements, userID, owners); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
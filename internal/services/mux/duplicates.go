@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"fmt"
+
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	"go.uber.org/zap"
+)
+
+const defaultDuplicateGroupsLimit = 100
+
+// FindDuplicates lists the groups of active assets sharing a fingerprint, largest groups first.
+// Videos with the same aspect ratio and duration are only probable duplicates, editors have to
+// compare them before archiving any.
+func (s *Service) FindDuplicates(ctx context.Context, req *assetmodel.FindDuplicatesRequest) ([]*assetmodel.DuplicateGroup, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	limit := defaultDuplicateGroupsLimit
+	if req.Limit > 0 {
+		limit = req.Limit
+	}
+
+	groups, err := s.repo.ListDuplicates(ctx, limit)
+	if err != nil {
+		s.log(ctx).Error("failed to list duplicate assets", zap.Error(err))
+		return nil, fmt.Errorf("failed to list duplicate assets: %w", err)
+	}
+	return groups, nil
+}
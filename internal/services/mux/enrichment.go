@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	"github.com/mikhail5545/media-service-go/internal/environment"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	"github.com/mikhail5545/media-service-go/internal/models/enrichment"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	muxgo "github.com/muxinc/mux-go/v6"
+	"go.uber.org/zap"
+)
+
+// errTranscriptPending is returned while the generated subtitles of the video are being prepared,
+// so the outbox dispatcher retries the enrichment later.
+var errTranscriptPending = errors.New("generated subtitles are not ready yet")
+
+// Search searches the assets by the title and the transcript of the videos.
+func (s *Service) Search(ctx context.Context, req *assetmodel.SearchRequest) ([]*assetmodel.Details, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultListByOwnerPageSize
+	}
+
+	metadataPage, nextPageToken, err := s.metadataRepo.Search(ctx, req.Query, req.Label, pageSize, req.PageToken)
+	if err != nil {
+		s.log(ctx).Error("failed to search asset metadata", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to search asset metadata: %w", err)
+	}
+	details, err := s.joinAssets(ctx, metadataPage)
+	if err != nil {
+		return nil, "", err
+	}
+	return details, nextPageToken, nil
+}
+
+// enrichVideo stores the transcript of the subtitles generated by MUX in the asset metadata. Videos
+// without generated subtitles are left as is. It is called by the outbox dispatcher, so failed attempts
+// are retried.
+func (s *Service) enrichVideo(ctx context.Context, assetID uuid.UUID) error {
+	if !s.enrichment {
+		return nil
+	}
+	asset, err := s.getAsset(ctx, assetID, []assetrepo.Scope{assetrepo.ScopeActive})
+	if err != nil {
+		return err
+	}
+	if asset.MuxAssetID == nil {
+		return nil
+	}
+	ctx = environment.WithContext(ctx, asset.Environment)
+
+	muxAsset, err := s.apiClient.GetAsset(ctx, *asset.MuxAssetID)
+	if err != nil {
+		s.log(ctx).Warn("failed to retrieve MUX asset for enrichment", zap.Error(err), logging.AssetID(asset.ID))
+		return fmt.Errorf("failed to retrieve MUX asset for enrichment: %w", err)
+	}
+	track := generatedTextTrack(muxAsset.Tracks)
+	if track == nil {
+		return nil
+	}
+	if track.Status == "preparing" {
+		return errTranscriptPending
+	}
+	playbackID := publicPlaybackID(muxAsset.PlaybackIds)
+	if playbackID == "" {
+		s.log(ctx).Info("skipping enrichment of video without public playback ID", logging.AssetID(asset.ID))
+		return nil
+	}
+
+	transcript, err := s.apiClient.GetTranscript(ctx, playbackID, track.Id)
+	if err != nil {
+		s.log(ctx).Warn("failed to retrieve video transcript", zap.Error(err), logging.AssetID(asset.ID))
+		return fmt.Errorf("failed to retrieve video transcript: %w", err)
+	}
+	if err := s.metadataRepo.SetEnrichment(ctx, asset.ID.String(), &enrichment.Enrichment{
+		Transcript: transcript,
+		EnrichedAt: time.Now(),
+	}); err != nil {
+		s.log(ctx).Error("failed to store video enrichment", zap.Error(err), logging.AssetID(asset.ID))
+		return fmt.Errorf("failed to store video enrichment: %w", err)
+	}
+	s.invalidate(ctx, asset.ID)
+	s.publishEvent(ctx, events.TypeAssetUpdated, asset.ID, withExternalID(asset.MuxAssetID), events.WithData("enriched", "true"))
+	return nil
+}
+
+// generatedTextTrack returns the text track generated by MUX, nil if subtitles were not generated.
+func generatedTextTrack(tracks []muxgo.Track) *muxgo.Track {
+	for i := range tracks {
+		if tracks[i].Type == "text" && tracks[i].TextSource == "generated_vod" &&
+			(tracks[i].Status == "preparing" || tracks[i].Status == "ready") {
+			return &tracks[i]
+		}
+	}
+	return nil
+}
+
+func publicPlaybackID(playbackIDs []muxgo.PlaybackId) string {
+	for _, playbackID := range playbackIDs {
+		if playbackID.Policy == muxgo.PUBLIC {
+			return playbackID.Id
+		}
+	}
+	return ""
+}
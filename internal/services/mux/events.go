@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/bus"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// publishEvent publishes asset lifecycle event. Publishing is best effort, failures are only logged.
+func (s *Service) publishEvent(ctx context.Context, eventType events.Type, assetID uuid.UUID, opts ...events.Option) {
+	event := events.NewEvent(eventType, events.ProviderMux, assetID, opts...)
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.log(ctx).Warn("failed to publish asset event",
+			zap.Error(err),
+			zap.String("event_type", string(eventType)),
+			logging.AssetID(assetID),
+		)
+	}
+}
+
+// publishEventInTx publishes asset lifecycle event in the transaction tx of a [crossstore.Transaction].
+// The synchronous subscribers of the bus take part in the transaction, their errors are returned so
+// that it rolls back. The event is delivered to the other subscribers once the transaction committed.
+func (s *Service) publishEventInTx(ctx context.Context, tx *gorm.DB, eventType events.Type, assetID uuid.UUID, opts ...events.Option) error {
+	event := events.NewEvent(eventType, events.ProviderMux, assetID, opts...)
+	if err := s.publisher.Publish(bus.ContextWithTx(ctx, tx), event); err != nil {
+		s.log(ctx).Error("failed to publish asset event",
+			zap.Error(err),
+			zap.String("event_type", string(eventType)),
+			logging.AssetID(assetID),
+		)
+		return fmt.Errorf("failed to publish asset event: %w", err)
+	}
+	return nil
+}
+
+func withExternalID(externalID *string) events.Option {
+	if externalID == nil {
+		return func(*events.Event) {}
+	}
+	return events.WithExternalID(*externalID)
+}
+
+func withOwners(owners []*metadatamodel.Owner) events.Option {
+	converted := make([]events.Owner, 0, len(owners))
+	for _, owner := range owners {
+		converted = append(converted, events.Owner{OwnerID: owner.OwnerID, OwnerType: owner.OwnerType})
+	}
+	return events.WithOwners(converted...)
+}
@@ -21,29 +21,30 @@ import (
 	"context"
 
 	"github.com/google/uuid"
-	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	outboxmodel "github.com/mikhail5545/media-service-go/internal/models/outbox"
 	bytesutil "github.com/mikhail5545/media-service-go/internal/util/bytes"
 	errutil "github.com/mikhail5545/media-service-go/internal/util/errors"
 	videopbv1 "github.com/mikhail5545/product-service-client/pb/product_service/video/v1"
 	"go.uber.org/zap"
 )
 
-func (s *Service) grpcMarkAsBroken(ctx context.Context, assetID, adminID *uuid.UUID, req *assetmodel.ChangeStateRequest) error {
-	assetIDBytes, err := bytesutil.UUIDToBytes(assetID)
+func (s *Service) grpcMarkAsBroken(ctx context.Context, payload *outboxmodel.BrokenPayload) error {
+	assetIDBytes, err := bytesutil.UUIDToBytes(&payload.AssetID)
 	if err != nil {
 		return err
 	}
-	adminIDBytes, err := bytesutil.UUIDToBytes(adminID)
+	adminIDBytes, err := bytesutil.UUIDToBytes(&payload.AdminID)
 	if err != nil {
 		return err
 	}
 	if _, err := s.videoClient.BrokenVideo(ctx, &videopbv1.BrokenVideoRequest{
 		MediaServiceUuid: assetIDBytes,
 		AdminUuid:        adminIDBytes,
-		AdminName:        req.AdminName,
-		Reason:           req.Note,
+		AdminName:        payload.AdminName,
+		Reason:           payload.Reason,
 	}); err != nil {
-		s.logger.Error("failed to mark asset as broken via gRPC", zap.Error(err), zap.String("asset_id", req.ID))
+		s.log(ctx).Error("failed to mark asset as broken via gRPC", zap.Error(err), logging.AssetID(payload.AssetID))
 		return errutil.HandleRPCError(err)
 	}
 	return nil
@@ -57,7 +58,7 @@ func (s *Service) grpcForceDelete(ctx context.Context, assetID *uuid.UUID) error
 	if _, err := s.videoClient.ForceDelete(ctx, &videopbv1.ForceDeleteRequest{
 		MediaServiceUuid: assetIDBytes,
 	}); err != nil {
-		s.logger.Error("failed to force delete asset via gRPC", zap.Error(err), zap.String("asset_id", assetID.String()))
+		s.log(ctx).Error("failed to force delete asset via gRPC", zap.Error(err), logging.AssetID(assetID))
 		return errutil.HandleRPCError(err)
 	}
 	return nil
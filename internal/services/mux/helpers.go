@@ -21,41 +21,48 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
 	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	"github.com/mikhail5545/media-service-go/internal/database/postgres/pagination"
 	"github.com/mikhail5545/media-service-go/internal/database/types"
 	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
 	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
 	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
 	muxtypes "github.com/mikhail5545/media-service-go/internal/models/mux/types"
+	"github.com/mikhail5545/media-service-go/internal/tags"
+	"github.com/mikhail5545/media-service-go/internal/util/fieldmask"
 	"github.com/mikhail5545/media-service-go/internal/util/parsing"
-	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
-func (s *Service) getAsset(ctx context.Context, id uuid.UUID, scopes []assetrepo.Scope) (*assetmodel.Asset, error) {
+func (s *Service) getAsset(ctx context.Context, id uuid.UUID, scopes []assetrepo.Scope, fields ...string) (*assetmodel.Asset, error) {
 	asset, err := s.repo.Get(ctx, assetrepo.GetOptions{
-		ID: id,
+		ID:     id,
+		Fields: fields,
 	}, scopes...)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, serviceerrors.NewNotFoundError(err)
 		}
-		s.logger.Error("failed to retrieve asset", zap.Error(err), zap.String("asset_id", id.String()))
+		s.log(ctx).Error("failed to retrieve asset", zap.Error(err), logging.AssetID(id))
 		return nil, fmt.Errorf("failed to retrieve asset: %w", err)
 	}
 	return asset, nil
 }
 
-func (s *Service) getAssetMetadata(ctx context.Context, id uuid.UUID) (*metadatamodel.AssetMetadata, error) {
-	metadata, err := s.metadataRepo.Get(ctx, id.String())
+func (s *Service) getAssetMetadata(ctx context.Context, id uuid.UUID, fields ...string) (*metadatamodel.AssetMetadata, error) {
+	metadata, err := s.metadataRepo.Get(ctx, id.String(), fields...)
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
+		if errors.Is(err, dbmetadata.ErrNotFound) {
 			return nil, serviceerrors.NewNotFoundError(err)
 		}
-		s.logger.Error("failed to retrieve asset metadata", zap.Error(err), zap.String("asset_id", id.String()))
+		s.log(ctx).Error("failed to retrieve asset metadata", zap.Error(err), logging.AssetID(id))
 		return nil, fmt.Errorf("failed to retrieve asset metadata: %w", err)
 	}
 	return metadata, nil
@@ -69,20 +76,47 @@ func (s *Service) get(ctx context.Context, filter *assetmodel.GetFilter, scopes
 	if err != nil {
 		return nil, err
 	}
-	asset, err := s.getAsset(ctx, assetID, scopes)
+	mask, err := fieldmask.Parse(filter.Fields)
 	if err != nil {
-		return nil, err
+		return nil, serviceerrors.NewValidationFailedError(err)
 	}
-	metadata, err := s.getAssetMetadata(ctx, assetID)
+	if !mask.IsAll() {
+		return s.getProjected(ctx, assetID, mask, scopes)
+	}
+	asset, err := s.cachedAsset(ctx, assetID, scopes)
 	if err != nil {
 		return nil, err
 	}
+	metadata, err := s.cachedAssetMetadata(ctx, assetID)
+	if err != nil {
+		// The metadata of an asset deleted in MUX is removed when the asset is archived, the asset
+		// and its ownership snapshot are still returned.
+		if asset.Status != assetmodel.StatusArchived || !errors.Is(err, serviceerrors.ErrNotFound) {
+			return nil, err
+		}
+	}
 	return &assetmodel.Details{
 		Asset:    asset,
 		Metadata: metadata,
 	}, nil
 }
 
+// getProjected loads the parts of the details selected by the mask, the metadata is left nil if it is
+// not selected. It bypasses the cache, which holds whole records only.
+func (s *Service) getProjected(ctx context.Context, id uuid.UUID, mask fieldmask.Mask, scopes []assetrepo.Scope) (*assetmodel.Details, error) {
+	asset, err := s.getAsset(ctx, id, scopes, mask.Asset.Columns("id")...)
+	if err != nil {
+		return nil, err
+	}
+	details := &assetmodel.Details{Asset: asset}
+	if mask.Metadata.Selected {
+		if details.Metadata, err = s.getAssetMetadata(ctx, id, mask.Metadata.Fields...); err != nil {
+			return nil, err
+		}
+	}
+	return details, nil
+}
+
 type assetSearchOptions struct {
 	AssetID    string
 	AssetUUID  *uuid.UUID
@@ -109,18 +143,59 @@ func (s *Service) getAssetFromWebhook(ctx context.Context, txRepo *assetrepo.Rep
 			},
 		}
 	} else {
-		s.logger.Warn("received webhook with no identifiable asset information", zap.String("event_type", payload.Type), zap.String("event_id", payload.ID))
+		s.log(ctx).Warn("received webhook with no identifiable asset information", zap.String("event_type", payload.Type), zap.String("event_id", payload.ID))
 		return nil
 	}
 
 	asset, err := s.getInTx(ctx, txRepo, []string{}, searchOpt)
 	if err != nil {
-		s.logger.Error("failed to get asset from webhook", zap.Error(err), zap.String("event_type", payload.Type), zap.String("event_id", payload.ID))
+		s.log(ctx).Error("failed to get asset from webhook", zap.Error(err), zap.String("event_type", payload.Type), zap.String("event_id", payload.ID))
 		return nil
 	}
 	return asset
 }
 
+// joinAssets loads the assets of a metadata page and combines them in the order of the page. Metadata
+// without a matching asset record is skipped.
+func (s *Service) joinAssets(ctx context.Context, metadataPage []*metadatamodel.AssetMetadata) ([]*assetmodel.Details, error) {
+	ids := make(uuid.UUIDs, 0, len(metadataPage))
+	for _, metadata := range metadataPage {
+		id, err := uuid.Parse(metadata.Key)
+		if err != nil {
+			s.log(ctx).Warn("invalid asset metadata key", zap.String("key", metadata.Key))
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return []*assetmodel.Details{}, nil
+	}
+
+	assets, err := s.repo.ListAll(ctx, assetrepo.ListAllOptions{IDs: ids}, assetrepo.ScopeAll)
+	if err != nil {
+		s.log(ctx).Error("failed to list assets", zap.Error(err))
+		return nil, fmt.Errorf("failed to list assets: %w", err)
+	}
+	assetMap := make(map[string]*assetmodel.Asset, len(assets))
+	for _, asset := range assets {
+		assetMap[asset.ID.String()] = asset
+	}
+
+	response := make([]*assetmodel.Details, 0, len(metadataPage))
+	for _, metadata := range metadataPage {
+		asset, ok := assetMap[metadata.Key]
+		if !ok {
+			s.log(ctx).Warn("asset not found for metadata", zap.String("key", metadata.Key))
+			continue
+		}
+		response = append(response, &assetmodel.Details{
+			Asset:    asset,
+			Metadata: metadata,
+		})
+	}
+	return response, nil
+}
+
 func (s *Service) getInTx(ctx context.Context, txRepo *assetrepo.Repository, fields []string, opt assetSearchOptions) (*assetmodel.Asset, error) {
 	getOpt, err := retrieveAssetID(opt)
 	if err != nil {
@@ -128,25 +203,19 @@ func (s *Service) getInTx(ctx context.Context, txRepo *assetrepo.Repository, fie
 	}
 	getOpt.Fields = fields
 
-	asset, err := txRepo.Get(ctx, *getOpt)
+	// Callers check the status themselves, restoring and deleting archived assets must find them
+	asset, err := txRepo.Get(ctx, *getOpt, assetrepo.ScopeAll)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, serviceerrors.NewNotFoundError(err)
 		}
-		s.logger.Error("failed to retrieve asset in transaction", zap.Error(err), zap.String("asset_id", getOpt.ID.String()))
+		s.log(ctx).Error("failed to retrieve asset in transaction", zap.Error(err), logging.AssetID(getOpt.ID))
 		return nil, fmt.Errorf("failed to retrieve asset in transaction: %w", err)
 	}
 	return asset, nil
 }
 
-func (s *Service) list(
-	ctx context.Context,
-	req *assetmodel.ListRequest,
-	scopes []assetrepo.Scope,
-) ([]*assetmodel.Details, string, error) {
-	if err := req.Validate(); err != nil {
-		return nil, "", serviceerrors.NewValidationFailedError(err)
-	}
+func newListOptions(req *assetmodel.ListRequest) assetrepo.ListOptions {
 	listOptions := assetrepo.ListOptions{
 		MuxUploadIDs:    req.MuxUploadIDs,
 		MuxAssetIDs:     req.MuxAssetIDs,
@@ -158,31 +227,87 @@ func (s *Service) list(
 		PageSize:        req.PageSize,
 		PageToken:       req.PageToken,
 		UploadStatuses:  req.UploadStatuses,
+		Tags:            tags.Normalize(req.Tags),
 	}
 	listOptions.IDs = parsing.StrToUUIDs(req.MuxAssetIDs)
+	listOptions.ListingFilter = assetrepo.ListingFilter{
+		Query:     req.Query,
+		CreatorID: req.CreatorID,
+		Owned:     req.Owned,
+	}
+	return listOptions
+}
+
+func (s *Service) total(ctx context.Context, req *assetmodel.ListRequest, endpoint string, scopes []assetrepo.Scope) (*pagination.Total, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	total, err := s.repo.Total(ctx, s.counters.For(endpoint), newListOptions(req), scopes...)
+	if err != nil {
+		s.log(ctx).Error("failed to count assets", zap.Error(err), zap.String("endpoint", endpoint))
+		return nil, fmt.Errorf("failed to count assets: %w", err)
+	}
+	return &total, nil
+}
+
+func (s *Service) list(
+	ctx context.Context,
+	req *assetmodel.ListRequest,
+	scopes []assetrepo.Scope,
+) ([]*assetmodel.Details, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	mask, err := fieldmask.Parse(req.Fields)
+	if err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	listOptions := newListOptions(req)
+	listOptions.Fields = mask.Asset.Columns("id")
+	listOptions.WithListing = servedByListing(mask)
 
 	assets, nextPageToken, err := s.repo.List(ctx, listOptions, scopes...)
 	if err != nil {
-		s.logger.Error("failed to list assets", zap.Error(err))
+		s.log(ctx).Error("failed to list assets", zap.Error(err))
 		return nil, "", fmt.Errorf("failed to list assets: %w", err)
 	}
 
+	if !mask.Metadata.Selected {
+		response := make([]*assetmodel.Details, len(assets))
+		for i := range assets {
+			response[i] = &assetmodel.Details{Asset: assets[i]}
+		}
+		return response, nextPageToken, nil
+	}
+	if listOptions.WithListing {
+		response := make([]*assetmodel.Details, len(assets))
+		for i := range assets {
+			response[i] = &assetmodel.Details{Asset: assets[i]}
+			// Assets without a listing were not written since the read model was introduced.
+			if assets[i].Listing != nil {
+				response[i].Metadata = assets[i].Listing.Metadata()
+			}
+		}
+		return response, nextPageToken, nil
+	}
+
 	assetIDs := make([]string, len(assets))
 	for i := range assets {
 		assetIDs[i] = assets[i].ID.String()
 	}
 
-	metadataMap, err := s.metadataRepo.ListByKeys(ctx, assetIDs)
+	metadataMap, err := s.metadataRepo.ListByKeys(ctx, assetIDs, mask.Metadata.Fields...)
 	if err != nil {
-		s.logger.Error("failed to list asset metadata", zap.Error(err))
+		s.log(ctx).Error("failed to list asset metadata", zap.Error(err))
 		return nil, "", fmt.Errorf("failed to list asset metadata: %w", err)
 	}
 
 	response := make([]*assetmodel.Details, 0, len(assets))
 	for i := range assets {
 		metadata, ok := metadataMap[assets[i].ID.String()]
-		if !ok {
-			s.logger.Warn("metadata not found for asset", zap.String("asset_id", assets[i].ID.String()))
+		// Archived assets deleted in MUX have no metadata, they are listed for their ownership snapshot.
+		if !ok && assets[i].Status != assetmodel.StatusArchived {
+			s.log(ctx).Warn("metadata not found for asset", logging.AssetID(assets[i].ID))
 			continue
 		}
 		response = append(response, &assetmodel.Details{
@@ -193,6 +318,16 @@ func (s *Service) list(
 	return response, nextPageToken, nil
 }
 
+func validateBeforeAddOwner(asset *assetmodel.Asset) error {
+	if asset.Status == assetmodel.StatusArchived || asset.Status == assetmodel.StatusBroken {
+		return serviceerrors.NewConflictError("cannot add owner to archived or broken asset")
+	}
+	if asset.UploadStatus == assetmodel.UploadStatusErrored || asset.UploadStatus == assetmodel.UploadStatusDeleted {
+		return serviceerrors.NewConflictError("cannot add owner to asset with errored or deleted upload status")
+	}
+	return nil
+}
+
 func validateBeforeArchive(asset *assetmodel.Asset) error {
 	if asset.Status == assetmodel.StatusArchived {
 		return serviceerrors.NewConflictError("asset is already archived")
@@ -219,7 +354,7 @@ func (s *Service) archiveAsset(ctx context.Context, txRepo *assetrepo.Repository
 		AdminName: req.AdminName,
 		Note:      req.Note,
 	}); err != nil {
-		s.logger.Error("failed to archive asset", zap.Error(err), zap.String("asset_id", req.ID))
+		s.log(ctx).Error("failed to archive asset", zap.Error(err), zap.String("asset_id", req.ID))
 		return fmt.Errorf("failed to archive asset: %w", err)
 	}
 	return nil
@@ -228,13 +363,13 @@ func (s *Service) archiveAsset(ctx context.Context, txRepo *assetrepo.Repository
 func (s *Service) checkOwnership(ctx context.Context, owner *metadatamodel.Owner, assetID uuid.UUID) error {
 	_, findErr := s.metadataRepo.GetByOwner(ctx, assetID.String(), owner)
 	if findErr == nil {
-		return serviceerrors.NewAlreadyExistsError("owner already exists for this asset")
+		return serviceerrors.NewOwnerHasAssetError("owner already exists for this asset")
 	}
-	if !errors.Is(findErr, mongo.ErrNoDocuments) {
-		s.logger.Error(
+	if !errors.Is(findErr, dbmetadata.ErrNotFound) {
+		s.log(ctx).Error(
 			"failed to check existing owner in asset metadata",
 			zap.Error(findErr),
-			zap.String("asset_id", assetID.String()),
+			logging.AssetID(assetID),
 			zap.String("owner_id", owner.OwnerID),
 			zap.String("owner_type", owner.OwnerType),
 		)
@@ -243,6 +378,54 @@ func (s *Service) checkOwnership(ctx context.Context, owner *metadatamodel.Owner
 	return nil
 }
 
+// checkOwnershipPolicy enforces the ownership policies on a new owner of the asset described by metadata.
+// The owner stays locked until tx ends, so the owner can not pass the limit of its assets through a
+// concurrent association. A nil tx checks the policies without locking the owner.
+func (s *Service) checkOwnershipPolicy(ctx context.Context, tx *gorm.DB, metadata *metadatamodel.AssetMetadata, owner *metadatamodel.Owner) error {
+	ownerTypes := make([]string, 0, len(metadata.Owners))
+	for _, existing := range metadata.Owners {
+		ownerTypes = append(ownerTypes, existing.OwnerType)
+	}
+	return s.ownership.CheckOwner(ctx, owner.OwnerType, ownerTypes, func(ctx context.Context) (int64, error) {
+		count, err := s.countOwnerAssets(ctx, tx, owner, metadata.Key)
+		if err != nil {
+			s.log(ctx).Error(
+				"failed to count assets of owner",
+				zap.Error(err),
+				zap.String("owner_id", owner.OwnerID),
+				zap.String("owner_type", owner.OwnerType),
+			)
+		}
+		return count, err
+	})
+}
+
+// countOwnerAssets locks the owner within tx and counts the active assets associated with it, other
+// than the asset with the excluded key. Archived assets do not count towards the limit of the owner.
+func (s *Service) countOwnerAssets(ctx context.Context, tx *gorm.DB, owner *metadatamodel.Owner, excludeKey string) (int64, error) {
+	repo := s.repo
+	if tx != nil {
+		repo = s.repo.WithTx(tx)
+		if err := repo.LockOwner(ctx, owner.OwnerType, owner.OwnerID); err != nil {
+			return 0, fmt.Errorf("failed to lock owner: %w", err)
+		}
+	}
+	keys, err := s.metadataRepo.ListKeysByOwner(ctx, owner)
+	if err != nil {
+		return 0, err
+	}
+	ids := make(uuid.UUIDs, 0, len(keys))
+	for _, key := range keys {
+		if id, err := uuid.Parse(key); err == nil && key != excludeKey {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return repo.Count(ctx, assetrepo.ListOptions{IDs: ids}, assetrepo.ScopeActive)
+}
+
 func (s *Service) archiveAssetOnWebhook(ctx context.Context, txRepo *assetrepo.Repository, asset *assetmodel.Asset, eventID string) error {
 	if asset.ArchiveEventID != nil && *asset.ArchiveEventID == eventID {
 		// Already archived for this event
@@ -254,10 +437,10 @@ func (s *Service) archiveAssetOnWebhook(ctx context.Context, txRepo *assetrepo.R
 		Note: "Received 'video.asset.deleted' webhook from MUX. " +
 			"Archiving asset in the system to keep consistency with MUX.",
 	}); err != nil {
-		s.logger.Warn(
+		s.log(ctx).Warn(
 			"failed to archive asset from webhook",
 			zap.Error(err),
-			zap.String("asset_id", asset.ID.String()),
+			logging.AssetID(asset.ID),
 			zap.String("event_id", eventID),
 		)
 		return err
@@ -265,29 +448,56 @@ func (s *Service) archiveAssetOnWebhook(ctx context.Context, txRepo *assetrepo.R
 	return nil
 }
 
-func (s *Service) deleteMetadataAndMuxAsset(ctx context.Context, assetID *uuid.UUID, muxAssetID *string) error {
-	switch {
-	case assetID != nil:
-		if err := s.deleteAssetMetadata(ctx, *assetID); err != nil {
-			return err
-		}
-	case muxAssetID != nil:
-		if err := s.apiClient.DeleteAsset(ctx, *muxAssetID); err != nil {
-			s.logger.Error("failed to delete mux asset", zap.Error(err), zap.String("mux_asset_id", *muxAssetID))
-			return fmt.Errorf("failed to delete mux asset: %w", err)
-		}
+// snapshotOwners stores the owners of an asset with the asset before they are removed, so the
+// ownership can still be inspected and restored once the asset is archived.
+func (s *Service) snapshotOwners(ctx context.Context, txRepo *assetrepo.Repository, assetID uuid.UUID, owners []*metadatamodel.Owner, reason assetmodel.SnapshotReason) error {
+	if len(owners) == 0 {
+		return nil
+	}
+	if err := txRepo.SaveOwnershipSnapshot(ctx, assetID, &assetmodel.OwnershipSnapshot{
+		Owners:  owners,
+		Reason:  reason,
+		TakenAt: time.Now(),
+	}); err != nil {
+		s.log(ctx).Error("failed to save ownership snapshot", zap.Error(err), logging.AssetID(assetID))
+		return fmt.Errorf("failed to save ownership snapshot: %w", err)
 	}
 	return nil
 }
 
-func (s *Service) markAsBrokenAndClearOwners(ctx context.Context, assetID *uuid.UUID, metadata *metadatamodel.AssetMetadata, req *assetmodel.ChangeStateRequest) error {
-	adminID, err := parsing.StrToUUID(req.AdminID)
+func (s *Service) clearOwners(ctx context.Context, metadata *metadatamodel.AssetMetadata) error {
+	previous := metadataCopy(metadata)
+	metadata.Owners = []*metadatamodel.Owner{}
+	if err := s.saveMetadata(ctx, previous, metadata); err != nil {
+		s.log(ctx).Error("failed to clear asset owners", zap.Error(err), zap.String("asset_id", metadata.Key))
+		return fmt.Errorf("failed to clear asset owners: %w", err)
+	}
+	return nil
+}
+
+// checkQuota enforces the creator quotas before a new asset of the creator is created.
+func (s *Service) checkQuota(ctx context.Context, txRepo *assetrepo.Repository, creatorID uuid.UUID) error {
+	if !s.quota.Enabled() {
+		return nil
+	}
+	usage, err := txRepo.CreatorUsage(ctx, creatorID)
 	if err != nil {
-		return err
+		s.log(ctx).Error("failed to retrieve creator usage", zap.Error(err), zap.String("creator_id", creatorID.String()))
+		return fmt.Errorf("failed to retrieve creator usage: %w", err)
 	}
-	if err := s.grpcMarkAsBroken(ctx, assetID, &adminID, req); err != nil {
-		return err
+	return s.quota.Check(usage)
+}
+
+// servedByListing reports whether the metadata fields selected by the mask are all held by the
+// listing read model, so that the metadata round trip of a listing can be skipped.
+func servedByListing(mask fieldmask.Mask) bool {
+	if !mask.Metadata.Selected || mask.Metadata.Whole() {
+		return false
 	}
-	metadata.Owners = []*metadatamodel.Owner{}
-	return s.metadataRepo.Update(ctx, metadata.Key, metadata)
+	for _, field := range mask.Metadata.Fields {
+		if !slices.Contains(assetmodel.ListingFields, field) {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestGetInTxFindsArchivedAssets(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=media_service"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stmt *gorm.Statement
+	if err := db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		stmt = tx.Statement
+	}); err != nil {
+		t.Fatal(err)
+	}
+	id, err := uuid.NewV7()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := assetrepo.New(db)
+	svc := &Service{repo: repo, logger: zap.NewNop()}
+
+	if _, err := svc.getInTx(context.Background(), repo, []string{"id", "status"}, assetSearchOptions{AssetID: id.String()}); err != nil {
+		t.Fatal(err)
+	}
+	if stmt == nil {
+		t.Fatal("no query was built")
+	}
+	var statuses []assetmodel.Status
+	for _, v := range stmt.Vars {
+		if status, ok := v.(assetmodel.Status); ok {
+			statuses = append(statuses, status)
+		}
+	}
+	if !slices.Contains(statuses, assetmodel.StatusArchived) {
+		t.Errorf("query filters on statuses %v, want archived assets included", statuses)
+	}
+	if strings.Contains(stmt.SQL.String(), `"deleted_at" IS NULL`) {
+		t.Errorf("query %q excludes soft-deleted assets", stmt.SQL.String())
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"fmt"
+
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+)
+
+// maxHistoryEvents limits the asset timeline to the latest events.
+const maxHistoryEvents = 1000
+
+// GetHistory retrieves the timeline of an asset, oldest first. It combines the state transitions
+// caused by webhooks, admin actions and ownership changes recorded in the audit log.
+// The history is kept after the asset is deleted, so the asset is not required to exist.
+func (s *Service) GetHistory(ctx context.Context, req *auditmodel.GetHistoryRequest) ([]*auditmodel.HistoryEvent, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	assetID, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.auditRepo.ListByAsset(ctx, auditmodel.ProviderMux, assetID, maxHistoryEvents)
+	if err != nil {
+		s.log(ctx).Error("failed to list asset history", zap.Error(err), logging.AssetID(assetID))
+		return nil, fmt.Errorf("failed to list asset history: %w", err)
+	}
+	return auditmodel.NewHistory(entries), nil
+}
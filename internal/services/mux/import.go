@@ -0,0 +1,234 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	assetimportmodel "github.com/mikhail5545/media-service-go/internal/models/assetimport"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	muxtypes "github.com/mikhail5545/media-service-go/internal/models/mux/types"
+	"github.com/mikhail5545/media-service-go/internal/tags"
+	muxgo "github.com/muxinc/mux-go/v6"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// importPageSize is the number of MUX assets listed at once by ImportAssets.
+const importPageSize = 100
+
+// ImportAssets pages through the assets of the MUX account and creates the local records of the
+// assets unknown to the service. Assets are known when their MUX asset ID or their external ID,
+// which is the local asset ID for uploads made through the service, matches a local asset in any
+// status.
+//
+// Owners are matched from the passthrough and the external ID of the MUX asset, see
+// [assetimportmodel.ParseOwners]. A passthrough written by the service holds the asset tags instead.
+func (s *Service) ImportAssets(ctx context.Context, opts *assetimportmodel.Options, report func(*assetimportmodel.Result)) error {
+	for page := int32(1); ; page++ {
+		remote, err := s.apiClient.ListAssets(ctx, importPageSize, page)
+		if err != nil {
+			s.log(ctx).Error("failed to list mux assets for import", zap.Error(err), zap.Int32("page", page))
+			return err
+		}
+
+		muxAssetIDs := make([]string, 0, len(remote))
+		var externalIDs uuid.UUIDs
+		for _, a := range remote {
+			muxAssetIDs = append(muxAssetIDs, a.Id)
+			if id, err := uuid.Parse(a.Meta.ExternalId); err == nil {
+				externalIDs = append(externalIDs, id)
+			}
+		}
+		known, err := s.repo.ListKnown(ctx, muxAssetIDs, externalIDs)
+		if err != nil {
+			s.log(ctx).Error("failed to list known mux assets", zap.Error(err))
+			return fmt.Errorf("failed to list known mux assets: %w", err)
+		}
+		knownIDs := make(map[string]uuid.UUID, 2*len(known))
+		for _, a := range known {
+			knownIDs[a.ID.String()] = a.ID
+			if a.MuxAssetID != nil && *a.MuxAssetID != "" {
+				knownIDs[*a.MuxAssetID] = a.ID
+			}
+		}
+
+		for i := range remote {
+			a := &remote[i]
+			if id, ok := knownIDs[a.Id]; ok {
+				report(&assetimportmodel.Result{ExternalID: a.Id, Outcome: assetimportmodel.OutcomeSkipped, AssetID: id})
+				continue
+			}
+			if id, ok := knownIDs[a.Meta.ExternalId]; ok {
+				report(&assetimportmodel.Result{ExternalID: a.Id, Outcome: assetimportmodel.OutcomeSkipped, AssetID: id})
+				continue
+			}
+			report(s.importAsset(ctx, opts, a))
+		}
+		if len(remote) < importPageSize {
+			return nil
+		}
+	}
+}
+
+// importAsset creates the local record and the metadata of a MUX asset.
+func (s *Service) importAsset(ctx context.Context, opts *assetimportmodel.Options, remote *muxgo.Asset) *assetimportmodel.Result {
+	result := &assetimportmodel.Result{ExternalID: remote.Id, Outcome: assetimportmodel.OutcomeCreated}
+
+	newAsset, metadata, err := importedAsset(remote)
+	if err != nil {
+		result.Outcome = assetimportmodel.OutcomeFailed
+		result.Err = err
+		return result
+	}
+	result.AssetID = newAsset.ID
+	result.Owners = len(metadata.Owners)
+	if opts.DryRun {
+		return result
+	}
+	if creatorID, err := uuid.Parse(opts.AdminID); err == nil {
+		newAsset.CreatedBy = &creatorID
+		newAsset.CreatedByName = &opts.AdminName
+	}
+
+	err = crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		if err := s.repo.WithTx(tx).Create(ctx, newAsset); err != nil {
+			return fmt.Errorf("failed to create mux asset record: %w", err)
+		}
+		if err := s.createMetadata(ctx, metadata); err != nil {
+			return fmt.Errorf("failed to create asset metadata: %w", err)
+		}
+		return s.recordAudit(ctx, tx, auditmodel.ActionImport, newAsset.ID, nil, newAsset,
+			audit.WithAdmin(opts.AdminID, opts.AdminName),
+		)
+	})
+	if err != nil {
+		s.log(ctx).Error("failed to import mux asset", zap.Error(err), zap.String("mux_asset_id", remote.Id))
+		result.Outcome = assetimportmodel.OutcomeFailed
+		result.Err = err
+		return result
+	}
+
+	s.log(ctx).Info("imported mux asset", logging.AssetID(newAsset.ID), zap.String("mux_asset_id", remote.Id), zap.Int("owners", len(metadata.Owners)))
+	s.publishEvent(ctx, events.TypeAssetCreated, newAsset.ID, withExternalID(&remote.Id), withOwners(metadata.Owners), events.WithData("imported", "true"))
+	return result
+}
+
+// importedAsset builds the local record and the metadata of a MUX asset.
+func importedAsset(remote *muxgo.Asset) (*assetmodel.Asset, *metadatamodel.AssetMetadata, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate new asset id: %w", err)
+	}
+	asset := &assetmodel.Asset{
+		ID:           id,
+		MuxAssetID:   &remote.Id,
+		Status:       assetmodel.StatusActive,
+		UploadStatus: assetmodel.UploadStatus(remote.Status),
+		IngestType:   assetmodel.IngestType(remote.IngestType),
+	}
+	switch remote.Status {
+	case "ready":
+		asset.State = assetmodel.StateCompleted
+	case "errored":
+		asset.State = assetmodel.StateErrored
+		asset.Status = assetmodel.StatusBroken
+	default:
+		asset.State = assetmodel.StateTranscoding
+	}
+	if remote.UploadId != "" {
+		asset.MuxUploadID = &remote.UploadId
+	}
+	if remote.Duration > 0 {
+		duration := float32(remote.Duration)
+		asset.Duration = &duration
+	}
+	if remote.AspectRatio != "" {
+		asset.AspectRatio = &remote.AspectRatio
+	}
+	if asset.Duration != nil && asset.AspectRatio != nil {
+		fingerprint := assetmodel.Fingerprint(*asset.AspectRatio, *asset.Duration)
+		asset.Fingerprint = &fingerprint
+	}
+	if remote.ResolutionTier != "" {
+		asset.ResolutionTier = &remote.ResolutionTier
+	}
+	if seconds, err := strconv.ParseInt(remote.CreatedAt, 10, 64); err == nil {
+		createdAt := time.Unix(seconds, 0).UTC()
+		asset.AssetCreatedAt = &createdAt
+	}
+
+	metadata := &metadatamodel.AssetMetadata{
+		Key:         id.String(),
+		Title:       remote.Meta.Title,
+		CreatorID:   remote.Meta.CreatorId,
+		Owners:      []*metadatamodel.Owner{},
+		Tracks:      []*muxtypes.MuxWebhookTrack{},
+		PlaybackIDs: []*muxtypes.MuxWebhookPlaybackID{},
+	}
+	for _, p := range remote.PlaybackIds {
+		metadata.PlaybackIDs = append(metadata.PlaybackIDs, &muxtypes.MuxWebhookPlaybackID{ID: p.Id, Policy: string(p.Policy)})
+		switch p.Policy {
+		case muxgo.PUBLIC:
+			if asset.PrimaryPublicPlaybackID == nil {
+				asset.PrimaryPublicPlaybackID = &p.Id
+			}
+		case muxgo.SIGNED:
+			if asset.PrimarySignedPlaybackID == nil {
+				asset.PrimarySignedPlaybackID = &p.Id
+			}
+		case muxgo.DRM:
+			if asset.PrimaryDRMPlaybackID == nil {
+				asset.PrimaryDRMPlaybackID = &p.Id
+			}
+		}
+	}
+
+	if rawTags, ok := strings.CutPrefix(remote.Passthrough, passthroughTagsPrefix); ok {
+		asset.Tags = tags.Normalize(strings.Split(rawTags, ","))
+	} else {
+		for _, o := range assetimportmodel.ParseOwners(remote.Passthrough, assetmodel.OwnerTypes.IsRegistered) {
+			metadata.Owners = appendOwner(metadata.Owners, o)
+		}
+	}
+	for _, o := range assetimportmodel.ParseOwners(remote.Meta.ExternalId, assetmodel.OwnerTypes.IsRegistered) {
+		metadata.Owners = appendOwner(metadata.Owners, o)
+	}
+	return asset, metadata, nil
+}
+
+// appendOwner appends the owner unless it is already present.
+func appendOwner(owners []*metadatamodel.Owner, o assetimportmodel.Owner) []*metadatamodel.Owner {
+	for _, existing := range owners {
+		if existing.OwnerID == o.OwnerID && existing.OwnerType == o.OwnerType {
+			return owners
+		}
+	}
+	return append(owners, &metadatamodel.Owner{OwnerID: o.OwnerID, OwnerType: o.OwnerType})
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	muxtypes "github.com/mikhail5545/media-service-go/internal/models/mux/types"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"gorm.io/gorm"
+)
+
+// ListLanguages lists the audio and subtitle languages of a video, derived from the tracks reported
+// by MUX and the names and the default audio track set by admins.
+func (s *Service) ListLanguages(ctx context.Context, req *assetmodel.ListLanguagesRequest) (*assetmodel.Languages, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	assetID, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.getAssetMetadata(ctx, assetID, assetmodel.LanguageFields...)
+	if err != nil {
+		return nil, err
+	}
+	return assetmodel.NewLanguages(metadata), nil
+}
+
+// UpdateTrack renames an audio or subtitle track or makes an audio track the default.
+//
+// MUX does not allow changing the tracks of an asset, so the names and the default audio track are
+// stored in the asset metadata and served by [Service.ListLanguages] and [Service.PlaybackInfo].
+func (s *Service) UpdateTrack(ctx context.Context, req *assetmodel.UpdateTrackRequest) (*assetmodel.Languages, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	defer s.invalidateByID(ctx, req.ID)
+
+	var (
+		changed  *assetmodel.Asset
+		metadata *metadatamodel.AssetMetadata
+	)
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		asset, err := s.getInTx(ctx, txRepo, []string{
+			"id", "status", "mux_asset_id",
+		}, assetSearchOptions{
+			AssetID: req.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if asset.Status == assetmodel.StatusArchived || asset.Status == assetmodel.StatusBroken {
+			return serviceerrors.NewConflictError("cannot update tracks of archived or broken asset")
+		}
+
+		metadata, err = s.getAssetMetadata(ctx, asset.ID)
+		if err != nil {
+			return err
+		}
+		previous := metadataCopy(metadata)
+		if err := applyTrackUpdate(metadata, req); err != nil {
+			return err
+		}
+
+		if err := s.saveMetadata(ctx, previous, metadata); err != nil {
+			return err
+		}
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionUpdateTrack, asset.ID,
+			trackSnapshot(previous, req.TrackID), trackSnapshot(metadata, req.TrackID),
+			audit.WithAdmin(req.AdminID, req.AdminName),
+		); err != nil {
+			return err
+		}
+		changed = asset
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishEvent(ctx, events.TypeAssetUpdated, changed.ID, withExternalID(changed.MuxAssetID))
+	return assetmodel.NewLanguages(metadata), nil
+}
+
+// applyTrackUpdate applies req to the track names and the default audio track of metadata. Only audio
+// tracks can be the default.
+func applyTrackUpdate(metadata *metadatamodel.AssetMetadata, req *assetmodel.UpdateTrackRequest) error {
+	track := findTrack(metadata.Tracks, req.TrackID)
+	if track == nil || (track.Type != "audio" && track.Type != "text") {
+		return serviceerrors.NewNotFoundError(fmt.Errorf("audio or text track %s not found", req.TrackID))
+	}
+	if req.Default != nil && *req.Default && track.Type != "audio" {
+		return serviceerrors.NewValidationFailedError(fmt.Errorf("default: only audio tracks can be the default"))
+	}
+
+	if req.Name != nil {
+		names := maps.Clone(metadata.TrackNames)
+		if names == nil {
+			names = make(map[string]string)
+		}
+		if *req.Name == "" {
+			delete(names, track.ID)
+		} else {
+			names[track.ID] = *req.Name
+		}
+		metadata.TrackNames = names
+	}
+	if req.Default != nil {
+		switch {
+		case *req.Default:
+			metadata.DefaultAudioTrackID = &track.ID
+		case metadata.DefaultAudioTrackID != nil && *metadata.DefaultAudioTrackID == track.ID:
+			metadata.DefaultAudioTrackID = nil
+		}
+	}
+	return nil
+}
+
+func findTrack(tracks []*muxtypes.MuxWebhookTrack, trackID string) *muxtypes.MuxWebhookTrack {
+	for _, track := range tracks {
+		if track != nil && track.ID == trackID {
+			return track
+		}
+	}
+	return nil
+}
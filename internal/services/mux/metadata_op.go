@@ -20,18 +20,86 @@ package mux
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
 	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
 	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
 	muxtypes "github.com/mikhail5545/media-service-go/internal/models/mux/types"
 	"github.com/mikhail5545/media-service-go/internal/util/memory"
+	muxgo "github.com/muxinc/mux-go/v6"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
+// createMetadata creates the metadata of a new asset. Inside a cross-store transaction the metadata
+// is deleted again if the transaction rolls back.
+func (s *Service) createMetadata(ctx context.Context, metadata *metadatamodel.AssetMetadata) error {
+	if err := crossstore.Apply(ctx,
+		func(ctx context.Context) error { return s.metadataRepo.Create(ctx, metadata) },
+		func(ctx context.Context) error { return s.metadataRepo.Delete(ctx, metadata.Key) },
+	); err != nil {
+		return err
+	}
+	s.syncListing(ctx, metadata)
+	return nil
+}
+
+// saveMetadata updates the metadata of an asset to metadata. Inside a cross-store transaction the
+// previous metadata is written back if the transaction rolls back.
+func (s *Service) saveMetadata(ctx context.Context, previous, metadata *metadatamodel.AssetMetadata) error {
+	if err := crossstore.Apply(ctx,
+		func(ctx context.Context) error { return s.metadataRepo.Update(ctx, metadata.Key, metadata) },
+		func(ctx context.Context) error { return s.metadataRepo.Update(ctx, previous.Key, previous) },
+	); err != nil {
+		return err
+	}
+	s.syncListing(ctx, metadata)
+	return nil
+}
+
+// syncListing updates the listing read model of the asset to the metadata once the transaction of ctx
+// committed. The listing only serves the admin listings, a failed update is logged and left to the
+// next write or to "migrate listings".
+func (s *Service) syncListing(ctx context.Context, metadata *metadatamodel.AssetMetadata) {
+	listing, err := assetmodel.NewListing(metadata)
+	if err != nil {
+		s.log(ctx).Warn("failed to build asset listing", zap.Error(err))
+		return
+	}
+	_ = crossstore.AfterCommit(ctx, func(ctx context.Context) error {
+		if err := s.repo.SaveListings(ctx, listing); err != nil {
+			s.log(ctx).Warn("failed to update asset listing", zap.Error(err), logging.AssetID(listing.AssetID))
+		}
+		return nil
+	})
+}
+
+// refreshListing updates the listing read model of the asset from its stored metadata, after the
+// metadata was changed without loading it.
+func (s *Service) refreshListing(ctx context.Context, assetID uuid.UUID) {
+	metadata, err := s.metadataRepo.Get(ctx, assetID.String(), assetmodel.ListingFields...)
+	if err != nil {
+		s.log(ctx).Warn("failed to retrieve asset metadata for listing", zap.Error(err), logging.AssetID(assetID))
+		return
+	}
+	s.syncListing(ctx, metadata)
+}
+
+// metadataCopy returns a copy of metadata which is not affected by changes to the owners of metadata.
+func metadataCopy(metadata *metadatamodel.AssetMetadata) *metadatamodel.AssetMetadata {
+	previous := *metadata
+	previous.Owners = slices.Clone(metadata.Owners)
+	previous.Chapters = slices.Clone(metadata.Chapters)
+	return &previous
+}
+
 func (s *Service) deleteAssetMetadata(ctx context.Context, assetID uuid.UUID) error {
 	if err := s.metadataRepo.Delete(ctx, assetID.String()); err != nil {
-		s.logger.Error("failed to delete asset metadata", zap.Error(err), zap.String("asset_id", assetID.String()))
+		s.log(ctx).Error("failed to delete asset metadata", zap.Error(err), logging.AssetID(assetID))
 		return fmt.Errorf("failed to delete asset metadata: %w", err)
 	}
 	return nil
@@ -48,16 +116,16 @@ func (s *Service) updateMetadataFromWebhook(ctx context.Context, assetID uuid.UU
 
 	metadata.Tracks = memory.SlicePtr(data.Tracks...)
 	if err := s.metadataRepo.Update(ctx, assetID.String(), metadata); err != nil {
-		s.logger.Error("failed to update asset metadata from webhook", zap.Error(err), zap.String("asset_id", assetID.String()))
+		s.log(ctx).Error("failed to update asset metadata from webhook", zap.Error(err), logging.AssetID(assetID))
 		return fmt.Errorf("failed to update asset metadata from webhook: %w", err)
 	}
 	return nil
 }
 
-func (s *Service) addOwner(ctx context.Context, assetID uuid.UUID, req *assetmodel.ManageOwnerRequest) error {
+func (s *Service) addOwner(ctx context.Context, tx *gorm.DB, assetID uuid.UUID, req *assetmodel.ManageOwnerRequest) (*metadatamodel.AssetMetadata, error) {
 	metadata, err := s.getAssetMetadata(ctx, assetID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	newOwner := metadatamodel.Owner{
@@ -65,18 +133,101 @@ func (s *Service) addOwner(ctx context.Context, assetID uuid.UUID, req *assetmod
 		OwnerType: req.OwnerType,
 	}
 	if err := s.checkOwnership(ctx, &newOwner, assetID); err != nil {
-		return err
+		return nil, err
+	}
+	if err := s.checkOwnershipPolicy(ctx, tx, metadata, &newOwner); err != nil {
+		return nil, err
 	}
+	before := ownersSnapshot(metadata.Owners)
+	previous := metadataCopy(metadata)
 	metadata.Owners = append(metadata.Owners, &newOwner)
 
-	if err := s.metadataRepo.Update(ctx, assetID.String(), metadata); err != nil {
-		s.logger.Error("failed to add owner to asset metadata", zap.Error(err), zap.String("asset_id", assetID.String()))
-		return fmt.Errorf("failed to add owner to asset metadata: %w", err)
+	if err := s.saveMetadata(ctx, previous, metadata); err != nil {
+		s.log(ctx).Error("failed to add owner to asset metadata", zap.Error(err), logging.AssetID(assetID))
+		return nil, fmt.Errorf("failed to add owner to asset metadata: %w", err)
 	}
-	return nil
+	if err := s.recordAudit(ctx, tx, auditmodel.ActionAddOwner, assetID, before, ownersSnapshot(metadata.Owners)); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+func (s *Service) updateMetadata(ctx context.Context, tx *gorm.DB, asset *assetmodel.Asset, req *assetmodel.UpdateMetadataRequest) (*metadatamodel.AssetMetadata, error) {
+	metadata, err := s.getAssetMetadata(ctx, asset.ID)
+	if err != nil {
+		return nil, err
+	}
+	before := metadataSnapshot(metadata)
+	previous := metadataCopy(metadata)
+	if req.Title != nil {
+		metadata.Title = *req.Title
+	}
+	if req.CreatorID != nil {
+		metadata.CreatorID = *req.CreatorID
+	}
+
+	// The asset ID is only known to MUX after the upload finished.
+	if asset.MuxAssetID != nil && *asset.MuxAssetID != "" {
+		if err := s.apiClient.UpdateAsset(ctx, *asset.MuxAssetID, &muxgo.UpdateAssetRequest{
+			Meta:        muxMeta(metadata),
+			Passthrough: tagsPassthrough(asset.Tags),
+		}); err != nil {
+			s.log(ctx).Error("failed to update mux asset meta", zap.Error(err), logging.AssetID(asset.ID))
+			return nil, fmt.Errorf("failed to update mux asset meta: %w", err)
+		}
+	}
+
+	if err := s.saveMetadata(ctx, previous, metadata); err != nil {
+		s.log(ctx).Error("failed to update asset metadata", zap.Error(err), logging.AssetID(asset.ID))
+		return nil, fmt.Errorf("failed to update asset metadata: %w", err)
+	}
+	if err := s.recordAudit(ctx, tx, auditmodel.ActionUpdateMetadata, asset.ID, before, metadataSnapshot(metadata)); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// restoreOwners re-associates the owners of the ownership snapshot of an asset and clears the
+// snapshot. The ownership policies are enforced on every owner, as other assets may have been
+// associated with the owner in the meantime.
+func (s *Service) restoreOwners(ctx context.Context, tx *gorm.DB, asset *assetmodel.Asset) (*metadatamodel.AssetMetadata, error) {
+	metadata, err := s.getAssetMetadata(ctx, asset.ID)
+	if err != nil {
+		return nil, err
+	}
+	if asset.OwnershipSnapshot == nil {
+		return metadata, nil
+	}
+
+	before := ownersSnapshot(metadata.Owners)
+	previous := metadataCopy(metadata)
+	for _, owner := range asset.OwnershipSnapshot.Owners {
+		if slices.ContainsFunc(metadata.Owners, func(existing *metadatamodel.Owner) bool { return *existing == *owner }) {
+			continue
+		}
+		if err := s.checkOwnershipPolicy(ctx, tx, metadata, owner); err != nil {
+			return nil, err
+		}
+		metadata.Owners = append(metadata.Owners, owner)
+	}
+
+	if err := s.repo.WithTx(tx).SaveOwnershipSnapshot(ctx, asset.ID, nil); err != nil {
+		s.log(ctx).Error("failed to clear ownership snapshot", zap.Error(err), logging.AssetID(asset.ID))
+		return nil, fmt.Errorf("failed to clear ownership snapshot: %w", err)
+	}
+	if err := s.saveMetadata(ctx, previous, metadata); err != nil {
+		s.log(ctx).Error("failed to restore asset owners", zap.Error(err), logging.AssetID(asset.ID))
+		return nil, fmt.Errorf("failed to restore asset owners: %w", err)
+	}
+	if err := s.recordAudit(ctx, tx, auditmodel.ActionAddOwner, asset.ID, before, ownersSnapshot(metadata.Owners)); err != nil {
+		return nil, err
+	}
+	return metadata, nil
 }
 
-func (s *Service) removeOwner(ctx context.Context, metadata *metadatamodel.AssetMetadata, req *assetmodel.ManageOwnerRequest) error {
+func (s *Service) removeOwner(ctx context.Context, tx *gorm.DB, metadata *metadatamodel.AssetMetadata, req *assetmodel.ManageOwnerRequest) error {
+	before := ownersSnapshot(metadata.Owners)
+	previous := metadataCopy(metadata)
 	currentOwners := metadata.Owners
 	for i, owner := range currentOwners {
 		if owner.OwnerID == req.OwnerID && owner.OwnerType == req.OwnerType {
@@ -85,30 +236,21 @@ func (s *Service) removeOwner(ctx context.Context, metadata *metadatamodel.Asset
 			break
 		}
 	}
-	if err := s.metadataRepo.Update(ctx, metadata.Key, metadata); err != nil {
-		s.logger.Error("failed to remove owner from asset metadata",
+	if err := s.saveMetadata(ctx, previous, metadata); err != nil {
+		s.log(ctx).Error("failed to remove owner from asset metadata",
 			zap.Error(err), zap.String("owner_id", req.OwnerID), zap.String("owner_type", req.OwnerType),
 		)
 		return fmt.Errorf("failed to remove owner from asset metadata: %w", err)
 	}
-	return nil
+	return s.recordAudit(ctx, tx, auditmodel.ActionRemoveOwner, uuid.MustParse(metadata.Key), before, ownersSnapshot(metadata.Owners))
 }
 
 func (s *Service) deleteMetadataOnWebhook(ctx context.Context, assetID uuid.UUID, payload *muxtypes.MuxWebhook) error {
-	metadata, err := s.getAssetMetadata(ctx, assetID)
-	if err != nil {
-		return err
-	}
-	if len(metadata.Owners) > 0 {
-		if err := s.grpcForceDelete(ctx, &assetID); err != nil {
-			return err
-		}
-	}
 	if err := s.deleteAssetMetadata(ctx, assetID); err != nil {
-		s.logger.Warn(
+		s.log(ctx).Warn(
 			"failed to delete asset metadata from webhook",
 			zap.Error(err),
-			zap.String("asset_id", assetID.String()),
+			logging.AssetID(assetID),
 			zap.String("event_id", payload.ID),
 		)
 		return err
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	outboxrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/outbox"
+	outboxmodel "github.com/mikhail5545/media-service-go/internal/models/outbox"
+	"github.com/mikhail5545/media-service-go/internal/services/outbox"
+	"go.uber.org/zap"
+)
+
+// OutboxHandlers returns delivery handlers for all outbox event types produced by the service.
+func (s *Service) OutboxHandlers() map[outboxmodel.EventType]outbox.Handler {
+	return map[outboxmodel.EventType]outbox.Handler{
+		outboxmodel.EventVideoBroken: func(ctx context.Context, payload []byte) error {
+			var data outboxmodel.BrokenPayload
+			if err := json.Unmarshal(payload, &data); err != nil {
+				return fmt.Errorf("failed to decode outbox event payload: %w", err)
+			}
+			return s.grpcMarkAsBroken(ctx, &data)
+		},
+		outboxmodel.EventVideoForceDeleted: func(ctx context.Context, payload []byte) error {
+			var data outboxmodel.DeletePayload
+			if err := json.Unmarshal(payload, &data); err != nil {
+				return fmt.Errorf("failed to decode outbox event payload: %w", err)
+			}
+			for i := range data.AssetIDs {
+				if err := s.grpcForceDelete(ctx, &data.AssetIDs[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		outboxmodel.EventVideoEnrich: func(ctx context.Context, payload []byte) error {
+			var data outboxmodel.EnrichPayload
+			if err := json.Unmarshal(payload, &data); err != nil {
+				return fmt.Errorf("failed to decode outbox event payload: %w", err)
+			}
+			return s.enrichVideo(ctx, data.AssetID)
+		},
+		outboxmodel.EventVideoDeleteRemote: func(ctx context.Context, payload []byte) error {
+			var data outboxmodel.DeleteRemotePayload
+			if err := json.Unmarshal(payload, &data); err != nil {
+				return fmt.Errorf("failed to decode outbox event payload: %w", err)
+			}
+			return s.completeDelete(ctx, data.AssetID)
+		},
+	}
+}
+
+// enqueueEvent persists a new outbox event using the transactional outbox repository.
+func (s *Service) enqueueEvent(ctx context.Context, txOutbox *outboxrepo.Repository, eventType outboxmodel.EventType, payload any) error {
+	event, err := outboxmodel.NewEvent(eventType, payload)
+	if err != nil {
+		return err
+	}
+	if err := txOutbox.Enqueue(ctx, event); err != nil {
+		s.log(ctx).Error("failed to enqueue outbox event", zap.Error(err), zap.String("event_type", string(eventType)))
+		return fmt.Errorf("failed to enqueue outbox event: %w", err)
+	}
+	return nil
+}
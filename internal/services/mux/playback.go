@@ -0,0 +1,210 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	muxtypes "github.com/mikhail5545/media-service-go/internal/models/mux/types"
+	muxgo "github.com/muxinc/mux-go/v6"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// playbackIDsChange changes the playback IDs of the MUX asset, current holds its playback IDs before the change.
+type playbackIDsChange func(ctx context.Context, muxAssetID string, current []muxgo.PlaybackId) error
+
+// AddPlaybackID adds a playback ID with the requested policy to an asset and returns the resulting playback IDs.
+// The primary playback ID of the policy only changes if the asset did not have one. DRM protected assets
+// cannot get other playback IDs, they would bypass the license.
+func (s *Service) AddPlaybackID(ctx context.Context, req *assetmodel.AddPlaybackIDRequest) (*assetmodel.PlaybackIDs, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	return s.changePlaybackIDs(ctx, req.ID, auditmodel.ActionAddPlaybackID, audit.WithAdmin(req.AdminID, req.AdminName),
+		func(ctx context.Context, muxAssetID string, current []muxgo.PlaybackId) error {
+			for _, id := range current {
+				if id.Policy == muxgo.DRM {
+					return serviceerrors.NewConflictError("cannot add playback IDs to a DRM protected asset")
+				}
+			}
+			_, err := s.apiClient.CreatePlaybackID(ctx, muxAssetID, muxgo.PlaybackPolicy(req.Policy))
+			return err
+		})
+}
+
+// RemovePlaybackID removes a playback ID from an asset and returns the resulting playback IDs.
+// Players using the removed playback ID stop working immediately.
+func (s *Service) RemovePlaybackID(ctx context.Context, req *assetmodel.RemovePlaybackIDRequest) (*assetmodel.PlaybackIDs, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	return s.changePlaybackIDs(ctx, req.ID, auditmodel.ActionRemovePlaybackID, audit.WithAdmin(req.AdminID, req.AdminName),
+		func(ctx context.Context, muxAssetID string, current []muxgo.PlaybackId) error {
+			for _, id := range current {
+				if id.Id == req.PlaybackID {
+					return s.apiClient.DeletePlaybackID(ctx, muxAssetID, req.PlaybackID)
+				}
+			}
+			return serviceerrors.NewNotFoundError(fmt.Errorf("playback ID %s does not belong to the asset", req.PlaybackID))
+		})
+}
+
+// RotatePlaybackID replaces the primary playback ID of the requested policy with a new one and returns
+// the resulting playback IDs. It is meant for playback IDs that have leaked.
+func (s *Service) RotatePlaybackID(ctx context.Context, req *assetmodel.RotatePlaybackIDRequest) (*assetmodel.PlaybackIDs, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	policy := muxgo.PlaybackPolicy(req.Policy)
+	return s.changePlaybackIDs(ctx, req.ID, auditmodel.ActionRotatePlaybackID, audit.WithAdmin(req.AdminID, req.AdminName),
+		func(ctx context.Context, muxAssetID string, current []muxgo.PlaybackId) error {
+			var primary string
+			for _, id := range current {
+				if id.Policy == policy {
+					primary = id.Id
+					break
+				}
+			}
+			if primary == "" {
+				return serviceerrors.NewConflictError(fmt.Sprintf("asset does not have a %s playback ID to rotate", req.Policy))
+			}
+			// The new playback ID is created first, so the asset never ends up without one.
+			if _, err := s.apiClient.CreatePlaybackID(ctx, muxAssetID, policy); err != nil {
+				return err
+			}
+			return s.apiClient.DeletePlaybackID(ctx, muxAssetID, primary)
+		})
+}
+
+// changePlaybackIDs applies the change to the MUX asset and stores the resulting primary playback IDs.
+func (s *Service) changePlaybackIDs(
+	ctx context.Context,
+	id string,
+	action auditmodel.Action,
+	admin audit.EntryOption,
+	change playbackIDsChange,
+) (*assetmodel.PlaybackIDs, error) {
+	defer s.invalidateByID(ctx, id)
+
+	var result *assetmodel.PlaybackIDs
+	var changed *assetmodel.Asset
+	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		asset, err := s.getInTx(ctx, txRepo, []string{
+			"id", "status", "mux_asset_id", "primary_signed_playback_id", "primary_public_playback_id", "primary_drm_playback_id",
+		}, assetSearchOptions{
+			AssetID: id,
+		})
+		if err != nil {
+			return err
+		}
+		if asset.Status == assetmodel.StatusArchived || asset.Status == assetmodel.StatusBroken {
+			return serviceerrors.NewConflictError("cannot change playback IDs of archived or broken asset")
+		}
+		// The asset ID is only known to MUX after the upload finished.
+		if asset.MuxAssetID == nil || *asset.MuxAssetID == "" {
+			return serviceerrors.NewConflictError("cannot change playback IDs of asset before the upload is finished")
+		}
+
+		current, err := s.apiClient.GetAsset(ctx, *asset.MuxAssetID)
+		if err != nil {
+			s.log(ctx).Error("failed to retrieve mux asset", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to retrieve mux asset: %w", err)
+		}
+		if err := change(ctx, *asset.MuxAssetID, current.PlaybackIds); err != nil {
+			s.log(ctx).Error("failed to change mux playback ids", zap.Error(err), logging.AssetID(asset.ID))
+			return err
+		}
+		updated, err := s.apiClient.GetAsset(ctx, *asset.MuxAssetID)
+		if err != nil {
+			s.log(ctx).Error("failed to retrieve mux asset", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to retrieve mux asset: %w", err)
+		}
+
+		result = newPlaybackIDs(updated.PlaybackIds)
+		if _, err := txRepo.Update(ctx, map[string]any{
+			"primary_signed_playback_id": result.PrimarySignedPlaybackID,
+			"primary_public_playback_id": result.PrimaryPublicPlaybackID,
+			"primary_drm_playback_id":    result.PrimaryDRMPlaybackID,
+		}, assetrepo.StateOperationOptions{
+			IDs: uuid.UUIDs{asset.ID},
+		}); err != nil {
+			s.log(ctx).Error("failed to update asset playback ids", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to update asset playback ids: %w", err)
+		}
+		if err := s.recordAudit(ctx, tx, action, asset.ID,
+			playbackIDsSnapshot(asset.PrimarySignedPlaybackID, asset.PrimaryPublicPlaybackID, asset.PrimaryDRMPlaybackID),
+			playbackIDsSnapshot(result.PrimarySignedPlaybackID, result.PrimaryPublicPlaybackID, result.PrimaryDRMPlaybackID),
+			admin,
+		); err != nil {
+			return err
+		}
+		changed = asset
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishEvent(ctx, events.TypeAssetUpdated, changed.ID, withExternalID(changed.MuxAssetID))
+	return result, nil
+}
+
+// newPlaybackIDs lists the playback IDs of the MUX asset. The first playback ID of each policy is the primary one,
+// as for the playback IDs received with webhooks.
+func newPlaybackIDs(ids []muxgo.PlaybackId) *assetmodel.PlaybackIDs {
+	result := &assetmodel.PlaybackIDs{
+		PlaybackIDs: make([]*muxtypes.MuxWebhookPlaybackID, 0, len(ids)),
+	}
+	for _, id := range ids {
+		playbackID := &muxtypes.MuxWebhookPlaybackID{
+			ID:     id.Id,
+			Policy: string(id.Policy),
+		}
+		if id.DrmConfigurationId != "" {
+			playbackID.DrmConfigurationID = &id.DrmConfigurationId
+		}
+		result.PlaybackIDs = append(result.PlaybackIDs, playbackID)
+
+		switch id.Policy {
+		case muxgo.PUBLIC:
+			if result.PrimaryPublicPlaybackID == nil {
+				result.PrimaryPublicPlaybackID = &playbackID.ID
+			}
+		case muxgo.SIGNED:
+			if result.PrimarySignedPlaybackID == nil {
+				result.PrimarySignedPlaybackID = &playbackID.ID
+			}
+		case muxgo.DRM:
+			if result.PrimaryDRMPlaybackID == nil {
+				result.PrimaryDRMPlaybackID = &playbackID.ID
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	cldassetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	cldassetmodel "github.com/mikhail5545/media-service-go/internal/models/cloudinary/asset"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SetPoster sets the poster of a video to a frame of the video or to a Cloudinary image. The frame is
+// used by the thumbnail URLs generated by the service, the image replaces them.
+func (s *Service) SetPoster(ctx context.Context, req *assetmodel.SetPosterRequest) (*assetmodel.Poster, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	poster := &assetmodel.Poster{Time: req.Time}
+	if req.ImageID != nil {
+		var err error
+		if poster, err = s.posterImage(ctx, uuid.MustParse(*req.ImageID)); err != nil {
+			return nil, err
+		}
+	}
+	return s.changePoster(ctx, req.ID, poster, audit.WithAdmin(req.AdminID, req.AdminName))
+}
+
+// ResetPoster removes the poster of a video, MUX picks the frame in the middle of the video.
+func (s *Service) ResetPoster(ctx context.Context, req *assetmodel.ResetPosterRequest) (*assetmodel.Poster, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	return s.changePoster(ctx, req.ID, &assetmodel.Poster{}, audit.WithAdmin(req.AdminID, req.AdminName))
+}
+
+// posterImage returns the poster of the active Cloudinary image. Images rejected by moderation
+// cannot be used.
+func (s *Service) posterImage(ctx context.Context, imageID uuid.UUID) (*assetmodel.Poster, error) {
+	if s.imageRepo == nil {
+		return nil, serviceerrors.NewConflictError("poster images are not available")
+	}
+	image, err := s.imageRepo.Get(ctx, cldassetrepo.GetOptions{
+		ID:     imageID,
+		Fields: []string{"id", "secure_url", "moderation_status"},
+	}, cldassetrepo.ScopeActive)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, serviceerrors.NewNotFoundError(fmt.Errorf("poster image: %w", err))
+		}
+		s.log(ctx).Error("failed to retrieve poster image", zap.Error(err), zap.String("image_id", imageID.String()))
+		return nil, fmt.Errorf("failed to retrieve poster image: %w", err)
+	}
+	if image.ModerationStatus != nil && *image.ModerationStatus == cldassetmodel.ModerationRejected {
+		return nil, serviceerrors.NewConflictError("poster image was rejected by moderation")
+	}
+	return &assetmodel.Poster{ImageID: &image.ID, ImageURL: &image.SecureURL}, nil
+}
+
+// changePoster stores the poster of the asset. A poster time past the end of the video is rejected
+// once the duration is known.
+func (s *Service) changePoster(ctx context.Context, id string, poster *assetmodel.Poster, admin audit.EntryOption) (*assetmodel.Poster, error) {
+	defer s.invalidateByID(ctx, id)
+
+	var changed *assetmodel.Asset
+	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		asset, err := s.getInTx(ctx, txRepo, []string{
+			"id", "status", "mux_asset_id", "duration", "poster_time", "poster_image_id", "poster_image_url",
+		}, assetSearchOptions{
+			AssetID: id,
+		})
+		if err != nil {
+			return err
+		}
+		if asset.Status == assetmodel.StatusArchived || asset.Status == assetmodel.StatusBroken {
+			return serviceerrors.NewConflictError("cannot change poster of archived or broken asset")
+		}
+		if poster.Time != nil && asset.Duration != nil && *poster.Time > float64(*asset.Duration) {
+			return serviceerrors.NewValidationFailedError(fmt.Errorf("time: must not exceed the duration of %.2fs", *asset.Duration))
+		}
+
+		if _, err := txRepo.Update(ctx, map[string]any{
+			"poster_time":      poster.Time,
+			"poster_image_id":  poster.ImageID,
+			"poster_image_url": poster.ImageURL,
+		}, assetrepo.StateOperationOptions{
+			IDs: uuid.UUIDs{asset.ID},
+		}); err != nil {
+			s.log(ctx).Error("failed to update asset poster", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to update asset poster: %w", err)
+		}
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionSetPoster, asset.ID,
+			posterSnapshot(&assetmodel.Poster{Time: asset.PosterTime, ImageID: asset.PosterImageID, ImageURL: asset.PosterImageURL}),
+			posterSnapshot(poster),
+			admin,
+		); err != nil {
+			return err
+		}
+		changed = asset
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishEvent(ctx, events.TypeAssetUpdated, changed.ID, withExternalID(changed.MuxAssetID))
+	return poster, nil
+}
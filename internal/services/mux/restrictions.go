@@ -0,0 +1,220 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/google/uuid"
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	"github.com/mikhail5545/media-service-go/internal/environment"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	restrictionmodel "github.com/mikhail5545/media-service-go/internal/models/restriction"
+	"github.com/mikhail5545/media-service-go/internal/viewer"
+	muxgo "github.com/muxinc/mux-go/v6"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// restrictionSync is what the synchronization of a policy did to its MUX playback restriction.
+type restrictionSync int
+
+const (
+	restrictionUnchanged restrictionSync = iota
+	restrictionCreated
+	restrictionUpdated
+)
+
+// SyncPlaybackRestrictions creates, updates and deletes the MUX playback restrictions of the request
+// environment, so every policy restricting the referrer has one enforcing its current rules. Tokens are
+// issued with the restrictions synchronized on demand as well, the synchronization additionally removes
+// the restrictions of the policies which were dropped or no longer restrict the referrer.
+func (s *Service) SyncPlaybackRestrictions(ctx context.Context, _ *restrictionmodel.SyncRequest) (*restrictionmodel.SyncResult, error) {
+	env := environment.Name(ctx)
+	stored, err := s.restrictionRepo.List(ctx, env)
+	if err != nil {
+		s.log(ctx).Error("failed to list mux playback restrictions", zap.Error(err))
+		return nil, fmt.Errorf("failed to list mux playback restrictions: %w", err)
+	}
+	stale := make(map[string]*restrictionmodel.MuxRestriction, len(stored))
+	for _, restriction := range stored {
+		stale[restriction.Policy] = restriction
+	}
+
+	result := &restrictionmodel.SyncResult{Restrictions: make([]*restrictionmodel.MuxRestriction, 0)}
+	policies := s.restrictions.Named()
+	for _, name := range slices.Sorted(maps.Keys(policies)) {
+		policy := policies[name]
+		if !policy.RestrictsReferrer() {
+			continue
+		}
+		restriction, action, err := s.syncMuxRestriction(ctx, env, name, policy, stale[name])
+		if err != nil {
+			return nil, err
+		}
+		delete(stale, name)
+		switch action {
+		case restrictionCreated:
+			result.Created++
+		case restrictionUpdated:
+			result.Updated++
+		}
+		result.Restrictions = append(result.Restrictions, restriction)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(stale)) {
+		if err := s.deleteMuxRestriction(ctx, stale[name]); err != nil {
+			return nil, err
+		}
+		result.Deleted++
+	}
+	return result, nil
+}
+
+// playbackRestriction checks the viewer against the restriction policy of an asset owned by the owners.
+// It returns the MUX playback restriction the issued tokens must be subject to, empty if the policy does
+// not restrict the referrer. The referrer of the viewer is only checked if it was sent, MUX enforces the
+// referrer of the playback requests.
+func (s *Service) playbackRestriction(ctx context.Context, owners []*metadatamodel.Owner, v viewer.Viewer) (string, error) {
+	if s.restrictions == nil {
+		return "", nil
+	}
+	ownerTypes := make([]string, 0, len(owners))
+	for _, owner := range owners {
+		ownerTypes = append(ownerTypes, owner.OwnerType)
+	}
+	name, policy := s.restrictions.Resolve(ownerTypes)
+	if !policy.AllowsCountry(v.Country) {
+		return "", serviceerrors.NewPermissionDeniedError("playback is not available in the country of the viewer")
+	}
+	if v.Referrer != "" && !policy.AllowsReferrer(v.Referrer) {
+		return "", serviceerrors.NewPermissionDeniedError("playback is not allowed from the referrer")
+	}
+	if !policy.RestrictsReferrer() {
+		return "", nil
+	}
+
+	env := environment.Name(ctx)
+	stored, err := s.restrictionRepo.Get(ctx, env, name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log(ctx).Error("failed to retrieve mux playback restriction", zap.Error(err), zap.String("policy", name))
+		return "", fmt.Errorf("failed to retrieve mux playback restriction: %w", err)
+	}
+	restriction, _, err := s.syncMuxRestriction(ctx, env, name, policy, stored)
+	if err != nil {
+		return "", err
+	}
+	return restriction.RestrictionID, nil
+}
+
+// assetPlaybackRestriction is [Service.playbackRestriction] for an asset whose owners were not
+// retrieved yet.
+func (s *Service) assetPlaybackRestriction(ctx context.Context, assetID uuid.UUID, v viewer.Viewer) (string, error) {
+	if s.restrictions == nil {
+		return "", nil
+	}
+	metadata, err := s.metadataRepo.Get(ctx, assetID.String(), "owners")
+	if err != nil {
+		if errors.Is(err, dbmetadata.ErrNotFound) {
+			return "", serviceerrors.NewNotFoundError(err)
+		}
+		s.log(ctx).Error("failed to retrieve asset owners", zap.Error(err), logging.AssetID(assetID))
+		return "", fmt.Errorf("failed to retrieve asset owners: %w", err)
+	}
+	return s.playbackRestriction(ctx, metadata.Owners, v)
+}
+
+// syncMuxRestriction makes the stored MUX playback restriction of the policy enforce its referrer rules.
+// A restriction is created if stored is nil. If another instance creates the restriction of the policy
+// concurrently, the one created here is deleted and the other one returned.
+func (s *Service) syncMuxRestriction(ctx context.Context, env, name string, policy restrictionmodel.Policy, stored *restrictionmodel.MuxRestriction) (*restrictionmodel.MuxRestriction, restrictionSync, error) {
+	referrer := muxgo.ReferrerDomainRestriction{
+		AllowedDomains:  policy.AllowedReferrers,
+		AllowNoReferrer: policy.AllowNoReferrer,
+	}
+	if stored != nil {
+		if stored.Matches(policy) {
+			return stored, restrictionUnchanged, nil
+		}
+		if err := s.apiClient.UpdatePlaybackRestrictionReferrer(ctx, stored.RestrictionID, referrer); err != nil {
+			s.log(ctx).Error("failed to update mux playback restriction", zap.Error(err), zap.String("policy", name))
+			return nil, restrictionUnchanged, err
+		}
+		stored.AllowedDomains = slices.Clone(policy.AllowedReferrers)
+		stored.AllowNoReferrer = policy.AllowNoReferrer
+		if err := s.restrictionRepo.Update(ctx, stored); err != nil {
+			s.log(ctx).Error("failed to update mux playback restriction record", zap.Error(err), zap.String("policy", name))
+			return nil, restrictionUnchanged, fmt.Errorf("failed to update mux playback restriction record: %w", err)
+		}
+		return stored, restrictionUpdated, nil
+	}
+
+	created, err := s.apiClient.CreatePlaybackRestriction(ctx, referrer)
+	if err != nil {
+		s.log(ctx).Error("failed to create mux playback restriction", zap.Error(err), zap.String("policy", name))
+		return nil, restrictionUnchanged, err
+	}
+	restriction := &restrictionmodel.MuxRestriction{
+		Environment:     env,
+		Policy:          name,
+		RestrictionID:   created.Id,
+		AllowedDomains:  slices.Clone(policy.AllowedReferrers),
+		AllowNoReferrer: policy.AllowNoReferrer,
+	}
+	ok, err := s.restrictionRepo.Create(ctx, restriction)
+	if err != nil {
+		s.log(ctx).Error("failed to create mux playback restriction record", zap.Error(err), zap.String("policy", name))
+		return nil, restrictionUnchanged, fmt.Errorf("failed to create mux playback restriction record: %w", err)
+	}
+	if ok {
+		return restriction, restrictionCreated, nil
+	}
+
+	if err := s.apiClient.DeletePlaybackRestriction(ctx, created.Id); err != nil {
+		s.log(ctx).Warn("failed to delete duplicate mux playback restriction", zap.Error(err), zap.String("restriction_id", created.Id))
+	}
+	stored, err = s.restrictionRepo.Get(ctx, env, name)
+	if err != nil {
+		s.log(ctx).Error("failed to retrieve mux playback restriction", zap.Error(err), zap.String("policy", name))
+		return nil, restrictionUnchanged, fmt.Errorf("failed to retrieve mux playback restriction: %w", err)
+	}
+	return s.syncMuxRestriction(ctx, env, name, policy, stored)
+}
+
+// deleteMuxRestriction deletes the MUX playback restriction of a dropped policy and its record.
+// Restrictions already deleted in MUX are only dropped from the database.
+func (s *Service) deleteMuxRestriction(ctx context.Context, restriction *restrictionmodel.MuxRestriction) error {
+	if err := s.apiClient.DeletePlaybackRestriction(ctx, restriction.RestrictionID); err != nil {
+		var notFound muxgo.NotFoundError
+		if !errors.As(err, &notFound) {
+			s.log(ctx).Error("failed to delete mux playback restriction", zap.Error(err), zap.String("policy", restriction.Policy))
+			return err
+		}
+	}
+	if _, err := s.restrictionRepo.Delete(ctx, restriction.Environment, restriction.Policy); err != nil {
+		s.log(ctx).Error("failed to delete mux playback restriction record", zap.Error(err), zap.String("policy", restriction.Policy))
+		return fmt.Errorf("failed to delete mux playback restriction record: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	"github.com/mikhail5545/media-service-go/internal/environment"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	retentionmodel "github.com/mikhail5545/media-service-go/internal/models/retention"
+	muxgo "github.com/muxinc/mux-go/v6"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Purge permanently deletes archived assets that were soft-deleted before the provided cutoff.
+// Each asset is deleted from MUX, Postgres and MongoDB. A purge record is returned for every processed asset.
+// If dry run is requested, assets are only reported and nothing is deleted.
+func (s *Service) Purge(ctx context.Context, opts *retentionmodel.PurgeOptions) ([]*retentionmodel.PurgeRecord, error) {
+	assets, err := s.repo.ListExpired(ctx, opts.Cutoff, opts.RetryAfter, opts.BatchSize)
+	if err != nil {
+		s.log(ctx).Error("failed to list expired assets", zap.Error(err), zap.Time("cutoff", opts.Cutoff))
+		return nil, fmt.Errorf("failed to list expired assets: %w", err)
+	}
+
+	records := make([]*retentionmodel.PurgeRecord, 0, len(assets))
+	for _, asset := range assets {
+		record := &retentionmodel.PurgeRecord{
+			RunID:      opts.RunID,
+			Provider:   retentionmodel.ProviderMux,
+			AssetID:    asset.ID,
+			ExternalID: asset.MuxAssetID,
+			ArchivedAt: asset.DeletedAt.Time,
+			DryRun:     opts.DryRun,
+		}
+		if opts.DryRun {
+			// Nothing is deleted, so the record is neither a success nor a failure.
+			records = append(records, record)
+			continue
+		}
+		if err := s.purgeAsset(ctx, asset); err != nil {
+			record.Fail(err)
+			records = append(records, record)
+			continue
+		}
+		record.Succeeded = true
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (s *Service) purgeAsset(ctx context.Context, asset *assetmodel.Asset) error {
+	defer s.invalidate(ctx, asset.ID)
+	ctx = environment.WithContext(ctx, asset.Environment)
+	if asset.MuxAssetID != nil && *asset.MuxAssetID != "" {
+		if err := s.apiClient.DeleteAsset(ctx, *asset.MuxAssetID); err != nil {
+			// Asset may be already deleted from MUX (e.g. archived on 'video.asset.deleted' webhook)
+			var notFound muxgo.NotFoundError
+			if !errors.As(err, &notFound) {
+				s.log(ctx).Error("failed to purge mux asset", zap.Error(err), logging.AssetID(asset.ID))
+				return fmt.Errorf("failed to purge mux asset: %w", err)
+			}
+		}
+	}
+
+	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+		if _, err := txRepo.Delete(ctx, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}); err != nil {
+			s.log(ctx).Error("failed to purge mux asset record", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to purge mux asset record: %w", err)
+		}
+		return s.removeFromCollections(ctx, tx, asset.ID)
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.deleteAssetMetadata(ctx, asset.ID); err != nil {
+		return err
+	}
+	s.publishEvent(ctx, events.TypeAssetDeleted, asset.ID, withExternalID(asset.MuxAssetID), events.WithData("permanent", "true"))
+	return nil
+}
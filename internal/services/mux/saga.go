@@ -0,0 +1,382 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	sagamodel "github.com/mikhail5545/media-service-go/internal/models/saga"
+	sagaservice "github.com/mikhail5545/media-service-go/internal/services/saga"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var _ sagaservice.DefinitionProvider = (*Service)(nil)
+
+// UpdateOwners replaces the owners of an asset and returns the updated metadata.
+// The owners live in MongoDB and the audit log in PostgreSQL, so the change is executed as a saga:
+// owners are removed and added one by one and the applied changes are undone if a later one fails.
+// Broken or archived assets cannot have owners added, but their owners can still be removed.
+//
+// The update is rejected with a [serviceerrors.RevisionMismatchError] if the revision of the metadata
+// is not the requested one. The revision is incremented before the saga starts, so of concurrent
+// updates expecting the same revision only one is executed.
+func (s *Service) UpdateOwners(ctx context.Context, req *assetmodel.UpdateOwnersRequest) (*metadatamodel.AssetMetadata, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	defer s.invalidateByID(ctx, req.ID)
+
+	asset, err := s.getInTx(ctx, s.repo, []string{
+		"id", "status", "upload_status",
+	}, assetSearchOptions{
+		AssetID: req.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.getAssetMetadata(ctx, asset.ID)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.Revision != *req.Revision {
+		return nil, serviceerrors.NewRevisionMismatchError(*req.Revision, metadata.Revision)
+	}
+
+	toRemove, toAdd := diffOwners(metadata.Owners, req.Owners)
+	if len(toRemove) == 0 && len(toAdd) == 0 {
+		return metadata, nil
+	}
+	if len(toAdd) > 0 {
+		if err := validateBeforeAddOwner(asset); err != nil {
+			return nil, err
+		}
+		if err := s.checkOwnersPolicy(ctx, metadata, toRemove, toAdd); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.claimRevision(ctx, asset.ID, *req.Revision); err != nil {
+		return nil, err
+	}
+	saga, err := newOwnersSaga(asset.ID, toRemove, toAdd)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sagas.Execute(ctx, saga); err != nil {
+		if errors.Is(err, dbmetadata.ErrNotFound) {
+			return nil, serviceerrors.NewNotFoundError(err)
+		}
+		s.log(ctx).Error("failed to update asset owners", zap.Error(err), logging.AssetID(asset.ID), zap.String("saga_id", saga.ID.String()))
+		return nil, fmt.Errorf("failed to update asset owners: %w", err)
+	}
+	// The changes were recorded and published by the completion of the saga.
+	return s.getAssetMetadata(ctx, asset.ID)
+}
+
+// claimRevision increments the revision of the asset metadata if it is still the expected one.
+func (s *Service) claimRevision(ctx context.Context, assetID uuid.UUID, expected int64) error {
+	current, ok, err := s.metadataRepo.IncrementRevision(ctx, assetID.String(), expected)
+	if err != nil {
+		if errors.Is(err, dbmetadata.ErrNotFound) {
+			return serviceerrors.NewNotFoundError(err)
+		}
+		s.log(ctx).Error("failed to increment asset metadata revision", zap.Error(err), logging.AssetID(assetID))
+		return fmt.Errorf("failed to increment asset metadata revision: %w", err)
+	}
+	if !ok {
+		return serviceerrors.NewRevisionMismatchError(expected, current)
+	}
+	return nil
+}
+
+// SagaDefinitions returns the definition of the saga executed by UpdateOwners and by the owner replacements.
+func (s *Service) SagaDefinitions() map[sagamodel.Type]sagaservice.Definition {
+	return map[sagamodel.Type]sagaservice.Definition{
+		sagamodel.TypeMuxUpdateOwners: {
+			Actions: map[string]sagaservice.Action{
+				sagamodel.ActionAddOwner:    {Apply: s.addOwnerStep, Compensate: s.removeOwnerStep},
+				sagamodel.ActionRemoveOwner: {Apply: s.removeOwnerStep, Compensate: s.restoreOwnerStep},
+			},
+			Complete: s.completeOwnersSaga,
+		},
+	}
+}
+
+// ownerChange holds the owners an ownership saga added to and removed from a single asset.
+type ownerChange struct {
+	added, removed []*metadatamodel.Owner
+}
+
+// completeOwnersSaga records the owner changes of the saga in the audit log, publishes them and
+// invalidates the cached assets. The executor runs it both when the saga is executed and when it is
+// resumed after a restart, so the owners are read back from the metadata. Owners moved from other
+// assets are recorded as removed from them and added to the asset of the saga.
+func (s *Service) completeOwnersSaga(ctx context.Context, saga *sagamodel.Saga) error {
+	var ids []uuid.UUID
+	changes := make(map[uuid.UUID]*ownerChange)
+	moved := false
+	for _, step := range saga.Steps {
+		var p sagamodel.OwnerPayload
+		if err := json.Unmarshal(step.Payload, &p); err != nil {
+			return fmt.Errorf("failed to decode owner step payload: %w", err)
+		}
+		moved = moved || p.AssetID != nil
+		id := p.Target(saga)
+		change, ok := changes[id]
+		if !ok {
+			change = &ownerChange{}
+			changes[id] = change
+			ids = append(ids, id)
+		}
+		owner := &metadatamodel.Owner{OwnerID: p.OwnerID, OwnerType: p.OwnerType}
+		if step.Action == sagamodel.ActionAddOwner {
+			change.added = append(change.added, owner)
+		} else {
+			change.removed = append(change.removed, owner)
+		}
+	}
+
+	owners := make(map[uuid.UUID][]*metadatamodel.Owner, len(ids))
+	for _, id := range ids {
+		metadata, err := s.metadataRepo.Get(ctx, id.String(), "owners")
+		if err != nil {
+			if errors.Is(err, dbmetadata.ErrNotFound) {
+				// The asset was deleted meanwhile, there is nothing left to record.
+				continue
+			}
+			return fmt.Errorf("failed to retrieve asset metadata: %w", err)
+		}
+		owners[id] = metadata.Owners
+	}
+
+	// A resumed saga has no principal in the context, the admin is taken from the saga.
+	var adminID, adminName string
+	if saga.AdminID != nil {
+		adminID = saga.AdminID.String()
+	}
+	if saga.AdminName != nil {
+		adminName = *saga.AdminName
+	}
+	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		for _, id := range ids {
+			after, ok := owners[id]
+			if !ok {
+				continue
+			}
+			change := changes[id]
+			before := slices.DeleteFunc(slices.Clone(after), func(o *metadatamodel.Owner) bool {
+				return containsOwner(change.added, o)
+			})
+			before = append(before, change.removed...)
+
+			action := auditmodel.ActionUpdateOwners
+			if moved && id == saga.AssetID {
+				action = auditmodel.ActionAddOwner
+			} else if moved {
+				action = auditmodel.ActionRemoveOwner
+			}
+			if err := s.recordAudit(ctx, tx, action, id, ownersSnapshot(before), ownersSnapshot(after),
+				audit.WithAdmin(adminID, adminName),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		s.invalidate(ctx, id)
+		if after, ok := owners[id]; ok {
+			s.publishEvent(ctx, events.TypeAssetOwnersChanged, id, withOwners(after))
+		}
+	}
+	return nil
+}
+
+// addOwnerStep checks the asset limit of the owner again with the owner locked, the policies are only
+// checked without the lock before the saga starts.
+func (s *Service) addOwnerStep(ctx context.Context, saga *sagamodel.Saga, payload []byte) error {
+	assetID, owner, err := decodeOwnerPayload(saga, payload)
+	if err != nil {
+		return err
+	}
+	err = s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		if err := s.ownership.CheckOwner(ctx, owner.OwnerType, nil, func(ctx context.Context) (int64, error) {
+			return s.countOwnerAssets(ctx, tx, owner, assetID.String())
+		}); err != nil {
+			return err
+		}
+		return s.metadataRepo.AddOwner(ctx, assetID.String(), owner)
+	})
+	if err != nil {
+		return err
+	}
+	s.refreshListing(ctx, assetID)
+	return nil
+}
+
+// restoreOwnerStep compensates a removed owner. The owner was associated with the asset before the
+// saga, so the ownership policies are not checked again.
+func (s *Service) restoreOwnerStep(ctx context.Context, saga *sagamodel.Saga, payload []byte) error {
+	assetID, owner, err := decodeOwnerPayload(saga, payload)
+	if err != nil {
+		return err
+	}
+	if err := s.metadataRepo.AddOwner(ctx, assetID.String(), owner); err != nil {
+		return err
+	}
+	s.refreshListing(ctx, assetID)
+	return nil
+}
+
+// removeOwnerStep succeeds if the asset metadata does not exist, the owner is not associated with the asset either way.
+func (s *Service) removeOwnerStep(ctx context.Context, saga *sagamodel.Saga, payload []byte) error {
+	assetID, owner, err := decodeOwnerPayload(saga, payload)
+	if err != nil {
+		return err
+	}
+	if err := s.metadataRepo.RemoveOwner(ctx, assetID.String(), owner); err != nil {
+		if errors.Is(err, dbmetadata.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	s.refreshListing(ctx, assetID)
+	return nil
+}
+
+// checkOwnersPolicy enforces the ownership policies on the owners added to the asset, as if the removed
+// owners were already gone and the added owners were added one after another.
+func (s *Service) checkOwnersPolicy(ctx context.Context, metadata *metadatamodel.AssetMetadata, toRemove, toAdd []*metadatamodel.Owner) error {
+	simulated := *metadata
+	simulated.Owners = make([]*metadatamodel.Owner, 0, len(metadata.Owners)+len(toAdd))
+	for _, owner := range metadata.Owners {
+		if !containsOwner(toRemove, owner) {
+			simulated.Owners = append(simulated.Owners, owner)
+		}
+	}
+	for _, owner := range toAdd {
+		if err := s.checkOwnershipPolicy(ctx, nil, &simulated, owner); err != nil {
+			return err
+		}
+		simulated.Owners = append(simulated.Owners, owner)
+	}
+	return nil
+}
+
+// diffOwners returns the current owners missing from the requested ones and the requested owners missing from the current ones.
+func diffOwners(current []*metadatamodel.Owner, requested []*assetmodel.OwnerRef) (toRemove, toAdd []*metadatamodel.Owner) {
+	wanted := make([]*metadatamodel.Owner, len(requested))
+	for i, ref := range requested {
+		wanted[i] = &metadatamodel.Owner{OwnerID: ref.OwnerID, OwnerType: ref.OwnerType}
+	}
+	for _, owner := range current {
+		if !containsOwner(wanted, owner) {
+			toRemove = append(toRemove, owner)
+		}
+	}
+	for _, owner := range wanted {
+		if !containsOwner(current, owner) {
+			toAdd = append(toAdd, owner)
+		}
+	}
+	return toRemove, toAdd
+}
+
+func containsOwner(owners []*metadatamodel.Owner, owner *metadatamodel.Owner) bool {
+	for _, o := range owners {
+		if o.OwnerID == owner.OwnerID && o.OwnerType == owner.OwnerType {
+			return true
+		}
+	}
+	return false
+}
+
+// newOwnersSaga creates the saga removing the owners first, so the policies are never exceeded in between.
+func newOwnersSaga(assetID uuid.UUID, toRemove, toAdd []*metadatamodel.Owner) (*sagamodel.Saga, error) {
+	saga := &sagamodel.Saga{
+		Type:    sagamodel.TypeMuxUpdateOwners,
+		AssetID: assetID,
+		Steps:   make([]*sagamodel.Step, 0, len(toRemove)+len(toAdd)),
+	}
+	if err := appendOwnerSteps(saga, sagamodel.ActionRemoveOwner, toRemove); err != nil {
+		return nil, err
+	}
+	if err := appendOwnerSteps(saga, sagamodel.ActionAddOwner, toAdd); err != nil {
+		return nil, err
+	}
+	return saga, nil
+}
+
+// newReplaceOwnerSaga creates the saga removing the owner from the replaced assets and adding it to the asset.
+func newReplaceOwnerSaga(assetID uuid.UUID, owner *metadatamodel.Owner, replaced []uuid.UUID) (*sagamodel.Saga, error) {
+	saga := &sagamodel.Saga{
+		Type:    sagamodel.TypeMuxUpdateOwners,
+		AssetID: assetID,
+		Steps:   make([]*sagamodel.Step, 0, len(replaced)+1),
+	}
+	for _, id := range replaced {
+		step, err := sagamodel.NewStep(sagamodel.ActionRemoveOwner, sagamodel.OwnerPayload{
+			OwnerID: owner.OwnerID, OwnerType: owner.OwnerType, AssetID: &id,
+		})
+		if err != nil {
+			return nil, err
+		}
+		saga.Steps = append(saga.Steps, step)
+	}
+	if err := appendOwnerSteps(saga, sagamodel.ActionAddOwner, []*metadatamodel.Owner{owner}); err != nil {
+		return nil, err
+	}
+	return saga, nil
+}
+
+func appendOwnerSteps(saga *sagamodel.Saga, action string, owners []*metadatamodel.Owner) error {
+	for _, owner := range owners {
+		step, err := sagamodel.NewStep(action, sagamodel.OwnerPayload{OwnerID: owner.OwnerID, OwnerType: owner.OwnerType})
+		if err != nil {
+			return err
+		}
+		saga.Steps = append(saga.Steps, step)
+	}
+	return nil
+}
+
+// decodeOwnerPayload returns the asset changed by the step and the owner added to or removed from it.
+func decodeOwnerPayload(saga *sagamodel.Saga, payload []byte) (uuid.UUID, *metadatamodel.Owner, error) {
+	var p sagamodel.OwnerPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return uuid.Nil, nil, fmt.Errorf("failed to decode owner step payload: %w", err)
+	}
+	return p.Target(saga), &metadatamodel.Owner{OwnerID: p.OwnerID, OwnerType: p.OwnerType}, nil
+}
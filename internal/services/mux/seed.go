@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	muxtypes "github.com/mikhail5545/media-service-go/internal/models/mux/types"
+	seedmodel "github.com/mikhail5545/media-service-go/internal/models/seed"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SeedAssets creates the local records and the metadata of the fixtures which were not loaded yet.
+// Fixtures are neither audited nor published, and nothing is created in MUX.
+func (s *Service) SeedAssets(ctx context.Context, fixtures []*seedmodel.Fixture, report func(*seedmodel.Result)) error {
+	ids := make(uuid.UUIDs, 0, len(fixtures))
+	for _, f := range fixtures {
+		ids = append(ids, f.ID)
+	}
+	existing, err := s.repo.ListExistingIDs(ctx, ids)
+	if err != nil {
+		s.log(ctx).Error("failed to list existing mux fixtures", zap.Error(err))
+		return fmt.Errorf("failed to list existing mux fixtures: %w", err)
+	}
+	loaded := make(map[uuid.UUID]bool, len(existing))
+	for _, id := range existing {
+		loaded[id] = true
+	}
+
+	for _, f := range fixtures {
+		if loaded[f.ID] {
+			report(&seedmodel.Result{AssetID: f.ID, Outcome: seedmodel.OutcomeSkipped})
+			continue
+		}
+		report(s.seedAsset(ctx, f))
+	}
+	return nil
+}
+
+func (s *Service) seedAsset(ctx context.Context, f *seedmodel.Fixture) *seedmodel.Result {
+	result := &seedmodel.Result{AssetID: f.ID, Outcome: seedmodel.OutcomeCreated}
+	asset, metadata := fixtureAsset(f)
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		if err := s.repo.WithTx(tx).Create(ctx, asset); err != nil {
+			return fmt.Errorf("failed to create mux asset record: %w", err)
+		}
+		if err := s.createMetadata(ctx, metadata); err != nil {
+			return fmt.Errorf("failed to create asset metadata: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.log(ctx).Error("failed to seed mux asset", zap.Error(err), logging.AssetID(f.ID))
+		result.Outcome = seedmodel.OutcomeFailed
+		result.Err = err
+	}
+	return result
+}
+
+// WipeAssets permanently deletes the local records and the metadata of the fixtures with the IDs,
+// whatever their status. It returns the number of deleted records.
+func (s *Service) WipeAssets(ctx context.Context, ids uuid.UUIDs) (int64, error) {
+	existing, err := s.repo.ListExistingIDs(ctx, ids)
+	if err != nil {
+		s.log(ctx).Error("failed to list existing mux fixtures", zap.Error(err))
+		return 0, fmt.Errorf("failed to list existing mux fixtures: %w", err)
+	}
+	if len(existing) == 0 {
+		return 0, nil
+	}
+	deleted, err := s.repo.DeleteFixtures(ctx, existing)
+	if err != nil {
+		s.log(ctx).Error("failed to delete mux fixtures", zap.Error(err))
+		return 0, fmt.Errorf("failed to delete mux fixtures: %w", err)
+	}
+	s.invalidate(ctx, existing...)
+	for _, id := range existing {
+		if err := s.deleteAssetMetadata(ctx, id); err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// fixtureAsset builds the local record and the metadata of a fixture. Provider IDs are derived from
+// the index, they do not exist in MUX.
+func fixtureAsset(f *seedmodel.Fixture) (*assetmodel.Asset, *metadatamodel.AssetMetadata) {
+	uploadID := fmt.Sprintf("fixture-upload-%03d", f.Index)
+	asset := &assetmodel.Asset{
+		ID:          f.ID,
+		Status:      assetmodel.StatusActive,
+		MuxUploadID: &uploadID,
+		IngestType:  assetmodel.IngestTypeOnDemandDirectUpload,
+		Tags:        f.Tags,
+	}
+	metadata := &metadatamodel.AssetMetadata{
+		Key:         f.ID.String(),
+		Title:       f.Title,
+		CreatorID:   f.CreatorID,
+		Owners:      make([]*metadatamodel.Owner, 0, len(f.Owners)),
+		Tracks:      []*muxtypes.MuxWebhookTrack{},
+		PlaybackIDs: []*muxtypes.MuxWebhookPlaybackID{},
+	}
+	for _, o := range f.Owners {
+		metadata.Owners = append(metadata.Owners, &metadatamodel.Owner{OwnerID: o.OwnerID, OwnerType: o.OwnerType})
+	}
+	if creatorID, err := uuid.Parse(f.CreatorID); err == nil {
+		asset.CreatedBy = &creatorID
+	}
+
+	if f.State == seedmodel.StateUploadPending {
+		asset.Status = assetmodel.StatusUploadURLGenerated
+		asset.UploadStatus = assetmodel.UploadStatusPreparing
+		return asset, metadata
+	}
+	muxAssetID := fmt.Sprintf("fixture-asset-%03d", f.Index)
+	createdAt := time.Now().UTC()
+	asset.MuxAssetID = &muxAssetID
+	asset.AssetCreatedAt = &createdAt
+
+	switch f.State {
+	case seedmodel.StateProcessing:
+		asset.State = assetmodel.StateTranscoding
+		asset.UploadStatus = assetmodel.UploadStatusPreparing
+	case seedmodel.StateErrored:
+		asset.Status = assetmodel.StatusBroken
+		asset.State = assetmodel.StateErrored
+		asset.UploadStatus = assetmodel.UploadStatusErrored
+	default:
+		duration := float32(30 + 15*(f.Index%8))
+		aspectRatio, resolutionTier := "16:9", "1080p"
+		fingerprint := assetmodel.Fingerprint(aspectRatio, duration)
+		publicID := fmt.Sprintf("fixture-public-%03d", f.Index)
+		signedID := fmt.Sprintf("fixture-signed-%03d", f.Index)
+		asset.State = assetmodel.StateCompleted
+		asset.UploadStatus = assetmodel.UploadStatusReady
+		asset.Duration = &duration
+		asset.AspectRatio = &aspectRatio
+		asset.ResolutionTier = &resolutionTier
+		asset.Fingerprint = &fingerprint
+		asset.PrimaryPublicPlaybackID = &publicID
+		asset.PrimarySignedPlaybackID = &signedID
+		metadata.PlaybackIDs = append(metadata.PlaybackIDs,
+			&muxtypes.MuxWebhookPlaybackID{ID: publicID, Policy: "public"},
+			&muxtypes.MuxWebhookPlaybackID{ID: signedID, Policy: "signed"},
+		)
+		if f.State == seedmodel.StateArchived {
+			asset.Status = assetmodel.StatusArchived
+			asset.DeletedAt = gorm.DeletedAt{Time: createdAt, Valid: true}
+		}
+	}
+	return asset, metadata
+}
@@ -4,20 +4,47 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	apiclient "github.com/mikhail5545/media-service-go/internal/apiclients/mux"
-	assetmetadatarepo "github.com/mikhail5545/media-service-go/internal/database/mongo/mux/metadata"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	"github.com/mikhail5545/media-service-go/internal/cache"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	dbmetadata "github.com/mikhail5545/media-service-go/internal/database/metadata"
+	auditrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/audit"
+	cldassetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/cloudinary/asset"
+	collectionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/collection"
 	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	outboxrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/outbox"
+	"github.com/mikhail5545/media-service-go/internal/database/postgres/pagination"
+	restrictionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/restriction"
+	versionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/versions"
 	"github.com/mikhail5545/media-service-go/internal/database/types"
+	"github.com/mikhail5545/media-service-go/internal/deadline"
+	"github.com/mikhail5545/media-service-go/internal/entitlement"
 	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
 	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
 	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
 	muxtypes "github.com/mikhail5545/media-service-go/internal/models/mux/types"
+	outboxmodel "github.com/mikhail5545/media-service-go/internal/models/outbox"
+	playbackmodel "github.com/mikhail5545/media-service-go/internal/models/playback"
+	"github.com/mikhail5545/media-service-go/internal/models/publishing"
+	restrictionmodel "github.com/mikhail5545/media-service-go/internal/models/restriction"
+	retentionmodel "github.com/mikhail5545/media-service-go/internal/models/retention"
+	versionmodel "github.com/mikhail5545/media-service-go/internal/models/versions"
+	"github.com/mikhail5545/media-service-go/internal/ownertypes"
+	"github.com/mikhail5545/media-service-go/internal/quota"
+	playbackservice "github.com/mikhail5545/media-service-go/internal/services/playback"
+	sagaservice "github.com/mikhail5545/media-service-go/internal/services/saga"
+	signingkeyservice "github.com/mikhail5545/media-service-go/internal/services/signingkey"
+	watermarkservice "github.com/mikhail5545/media-service-go/internal/services/watermark"
 	"github.com/mikhail5545/media-service-go/internal/util/parsing"
-	"github.com/mikhail5545/product-service-client/client"
+	videopbv1 "github.com/mikhail5545/product-service-client/pb/product_service/video/v1"
 	muxgo "github.com/muxinc/mux-go/v6"
-	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -36,6 +63,21 @@ type AssetService interface {
 	ListArchived(ctx context.Context, req *assetmodel.ListRequest) ([]*assetmodel.Details, string, error)
 	// ListBroken retrieves a list of broken assets based on the provided request.
 	ListBroken(ctx context.Context, req *assetmodel.ListRequest) ([]*assetmodel.Details, string, error)
+	// ListTotal returns the total of the assets listed by List, computed with the count strategy of the endpoint.
+	ListTotal(ctx context.Context, req *assetmodel.ListRequest) (*pagination.Total, error)
+	// ListArchivedTotal returns the total of the assets listed by ListArchived.
+	ListArchivedTotal(ctx context.Context, req *assetmodel.ListRequest) (*pagination.Total, error)
+	// ListBrokenTotal returns the total of the assets listed by ListBroken.
+	ListBrokenTotal(ctx context.Context, req *assetmodel.ListRequest) (*pagination.Total, error)
+	// ValidateAssets reports whether the assets with the IDs exist and are healthy.
+	ValidateAssets(ctx context.Context, req *assetmodel.ValidateAssetsRequest) ([]*assetmodel.AssetValidity, error)
+	// GetByOwner retrieves the asset associated with the owner, the one with the lowest ID if there are several.
+	GetByOwner(ctx context.Context, req *assetmodel.GetByOwnerRequest) (*assetmodel.Details, error)
+	WatchAsset(ctx context.Context, req *assetmodel.WatchAssetRequest, send func(*assetmodel.StatusUpdate) error) error
+	// ListByOwner retrieves a page of assets associated with a single owner, e.g. all videos of a lesson.
+	ListByOwner(ctx context.Context, req *assetmodel.ListByOwnerRequest) ([]*assetmodel.Details, string, error)
+	// ListOwnerTypes returns the owner types assets can be associated with.
+	ListOwnerTypes(ctx context.Context) []string
 	// CreateUploadURL generates a new upload URL for new MUX Direct Upload and creates a new asset.
 	// After this step, the rest of the asset information will be populated via incoming MUX webhooks.
 	CreateUploadURL(ctx context.Context, req *assetmodel.CreateUploadURLRequest) (*muxgo.UploadResponse, error)
@@ -44,62 +86,284 @@ type AssetService interface {
 	Archive(ctx context.Context, req *assetmodel.ChangeStateRequest) error
 	// MarkAsBroken marks an asset as broken.
 	// If the asset has owners, it notifies the product-service about the broken asset via [gRPC client].
+	// The notification is persisted in the transactional outbox and delivered asynchronously.
 	//
 	// [gRPC client]: https://github.com/mikhail5545/product-service-client
 	MarkAsBroken(ctx context.Context, req *assetmodel.ChangeStateRequest) error
 	// Delete permanently deletes an archived asset along with its metadata.
-	// It also deletes the asset from MUX.
+	// The asset is marked as pending deletion and deleted from MUX and the databases asynchronously.
 	// Note that only currently soft-deleted (archived) assets can be permanently deleted.
 	Delete(ctx context.Context, req *assetmodel.ChangeStateRequest) error
+	// ListStuckDeletions reports the assets that have been pending deletion for longer than requested.
+	ListStuckDeletions(ctx context.Context, req *assetmodel.ListStuckDeletionsRequest) ([]*assetmodel.Asset, error)
+	// FindDuplicates lists the groups of active assets sharing a fingerprint, largest groups first.
+	FindDuplicates(ctx context.Context, req *assetmodel.FindDuplicatesRequest) ([]*assetmodel.DuplicateGroup, error)
 	// HandleAssetWebhook processes incoming MUX asset webhooks based on their type.
 	// It routes the webhook to the appropriate handler function.
 	// It does not return any error, as we want to avoid retrying the webhook processing in case of failure.
 	HandleAssetWebhook(ctx context.Context, payload *muxtypes.MuxWebhook) error
+	// VerifyWebhook verifies the Mux-Signature header against the raw webhook payload.
+	VerifyWebhook(ctx context.Context, payload []byte, signature string) error
+	// AddTags adds tags to an asset and returns the resulting tags of the asset.
+	// The tags are mirrored to the passthrough of the MUX asset.
+	AddTags(ctx context.Context, req *assetmodel.ManageTagsRequest) ([]string, error)
+	// RemoveTags removes tags from an asset and returns the remaining tags of the asset.
+	// The tags are mirrored to the passthrough of the MUX asset.
+	RemoveTags(ctx context.Context, req *assetmodel.ManageTagsRequest) ([]string, error)
+	// ListByTag retrieves a list of active assets having the tag.
+	ListByTag(ctx context.Context, req *assetmodel.ListByTagRequest) ([]*assetmodel.Details, string, error)
+	// Search searches the assets by the title and the transcript of the videos.
+	Search(ctx context.Context, req *assetmodel.SearchRequest) ([]*assetmodel.Details, string, error)
+	// ListByCollection retrieves a page of assets which belong to a collection.
+	ListByCollection(ctx context.Context, req *assetmodel.ListByCollectionRequest) ([]*assetmodel.Details, string, error)
+	// GetHistory retrieves the timeline of an asset, oldest first. It combines the state transitions
+	// caused by webhooks, admin actions and ownership changes.
+	GetHistory(ctx context.Context, req *auditmodel.GetHistoryRequest) ([]*auditmodel.HistoryEvent, error)
+	// UpdateMetadata changes the title or the creator of an asset.
+	// For MUX assets the new values are also pushed to the meta object of the MUX asset.
+	UpdateMetadata(ctx context.Context, req *assetmodel.UpdateMetadataRequest) (*metadatamodel.AssetMetadata, error)
 	// AddOwner associates an external owner with an asset.
 	// It updates the asset metadata in MongoDB to include the new owner.
 	// Broken or archived assets cannot have owners added.
 	AddOwner(ctx context.Context, req *assetmodel.ManageOwnerRequest) error
+	// AssociateOwner associates an owner with an asset and returns the assets the owner was moved from.
+	// With ReplaceExisting the owner is removed from its other assets and added to the asset by a saga.
+	AssociateOwner(ctx context.Context, req *assetmodel.ManageOwnerRequest) (*assetmodel.AssociateResult, error)
+	// ListVersions retrieves the version chain of an owner, oldest first.
+	ListVersions(ctx context.Context, req *assetmodel.ListVersionsRequest) ([]*versionmodel.Version, error)
+	// RollbackVersion moves the owner back to the asset of an earlier version and records the rollback
+	// as a new version.
+	RollbackVersion(ctx context.Context, req *assetmodel.RollbackVersionRequest) (*assetmodel.AssociateResult, error)
 	// RemoveOwner disassociates an external owner from an asset.
 	// It updates the asset metadata in MongoDB to remove the specified owner.
 	RemoveOwner(ctx context.Context, req *assetmodel.ManageOwnerRequest) error
+	// UpdateOwners replaces the owners of an asset and returns the updated metadata.
+	// The changes are applied by a saga and undone if any of them fails. The update is rejected
+	// if the metadata was changed since the requested revision.
+	UpdateOwners(ctx context.Context, req *assetmodel.UpdateOwnersRequest) (*metadatamodel.AssetMetadata, error)
 	// Restore restores an archived asset back to active status.
 	// Only archived assets can be restored.
 	Restore(ctx context.Context, req *assetmodel.ChangeStateRequest) error
-	// GeneratePlaybackToken generates a signed JWT playback token for secure video playback.
+	// RestoreWithOwners restores an archived asset back to active status and re-associates the owners
+	// the asset had when they were cleared. Only archived assets can be restored.
+	RestoreWithOwners(ctx context.Context, req *assetmodel.ChangeStateRequest) (*metadatamodel.AssetMetadata, error)
+	// PlaybackInfo returns the playback details of an asset to the authenticated end user, with a
+	// short-lived playback token. The user must be allowed to access the asset by one of its owners.
+	PlaybackInfo(ctx context.Context, req *assetmodel.PlaybackInfoRequest) (*assetmodel.PlaybackInfo, error)
+	// DRMPlayback returns the playback and license tokens of a DRM protected asset to the authenticated
+	// end user, who must be allowed to access the asset like in PlaybackInfo.
+	DRMPlayback(ctx context.Context, req *assetmodel.DRMPlaybackRequest) (*assetmodel.DRMPlaybackTokens, error)
+	// SetPoster sets the poster of a video to a frame of the video or to a Cloudinary image.
+	SetPoster(ctx context.Context, req *assetmodel.SetPosterRequest) (*assetmodel.Poster, error)
+	// ResetPoster removes the poster of a video, MUX picks the frame in the middle of the video.
+	ResetPoster(ctx context.Context, req *assetmodel.ResetPosterRequest) (*assetmodel.Poster, error)
+	// CountErrors counts the broken and errored assets by status and MUX error type.
+	CountErrors(ctx context.Context, req *assetmodel.CountErrorsRequest) ([]*assetmodel.ErrorCount, error)
+	// ListStuckAssets reports the assets waiting for an upload or for MUX processing for too long.
+	ListStuckAssets(ctx context.Context, req *assetmodel.ListStuckAssetsRequest) ([]*assetmodel.Asset, error)
+	// ReprocessAsset re-fetches the state of an asset from the MUX API.
+	ReprocessAsset(ctx context.Context, req *assetmodel.ReprocessAssetRequest) (*assetmodel.Asset, error)
+	// ListLanguages lists the audio and subtitle languages of a video.
+	ListLanguages(ctx context.Context, req *assetmodel.ListLanguagesRequest) (*assetmodel.Languages, error)
+	// UpdateTrack renames an audio or subtitle track or makes an audio track the default.
+	UpdateTrack(ctx context.Context, req *assetmodel.UpdateTrackRequest) (*assetmodel.Languages, error)
+	// ListChapters lists the chapters of a video, ordered by their start time.
+	ListChapters(ctx context.Context, req *assetmodel.ListChaptersRequest) ([]*metadatamodel.Chapter, error)
+	// AddChapter adds a chapter marker to a video.
+	AddChapter(ctx context.Context, req *assetmodel.AddChapterRequest) ([]*metadatamodel.Chapter, error)
+	// UpdateChapter changes the title, the start time or the thumbnail time of a chapter.
+	UpdateChapter(ctx context.Context, req *assetmodel.UpdateChapterRequest) ([]*metadatamodel.Chapter, error)
+	// DeleteChapter removes a chapter from a video.
+	DeleteChapter(ctx context.Context, req *assetmodel.DeleteChapterRequest) ([]*metadatamodel.Chapter, error)
+	// GeneratePlaybackToken generates a signed JWT playback token for secure video playback. The token is
+	// subject to the playback restriction policy of the asset.
 	GeneratePlaybackToken(ctx context.Context, req *assetmodel.GeneratePlaybackTokenRequest) (string, error)
+	// AddPlaybackID adds a playback ID with the requested policy to an asset via the MUX API.
+	AddPlaybackID(ctx context.Context, req *assetmodel.AddPlaybackIDRequest) (*assetmodel.PlaybackIDs, error)
+	// RemovePlaybackID removes a playback ID from an asset via the MUX API.
+	RemovePlaybackID(ctx context.Context, req *assetmodel.RemovePlaybackIDRequest) (*assetmodel.PlaybackIDs, error)
+	// RotatePlaybackID replaces the primary playback ID of the requested policy with a new one.
+	RotatePlaybackID(ctx context.Context, req *assetmodel.RotatePlaybackIDRequest) (*assetmodel.PlaybackIDs, error)
+	// GenerateDRMPlaybackTokens generates the playback and license tokens for Widevine and FairPlay playback
+	// of an asset created with a DRM configuration.
+	GenerateDRMPlaybackTokens(ctx context.Context, req *assetmodel.GeneratePlaybackTokenRequest) (*assetmodel.DRMPlaybackTokens, error)
+	// SchedulePublishing sets the publication schedule of an asset. Playback tokens are only issued
+	// between the publish and the unpublish time.
+	SchedulePublishing(ctx context.Context, req *assetmodel.SchedulePublishingRequest) (*publishing.Schedule, error)
+	// CancelPublishing removes the publication schedule of an asset, a scheduled asset stays unpublished.
+	CancelPublishing(ctx context.Context, req *assetmodel.CancelPublishingRequest) (*publishing.Schedule, error)
+	// TransitionPublishing publishes and unpublishes the assets whose publish or unpublish time passed.
+	TransitionPublishing(ctx context.Context, opts *publishing.TransitionOptions) (int, error)
+	// SyncPlaybackRestrictions synchronizes the playback restriction policies with the MUX playback
+	// restrictions of the request environment.
+	SyncPlaybackRestrictions(ctx context.Context, req *restrictionmodel.SyncRequest) (*restrictionmodel.SyncResult, error)
+	// Purge permanently deletes archived assets that were soft-deleted before the provided cutoff.
+	// Each asset is deleted from MUX, Postgres and MongoDB. A purge record is returned for every processed asset.
+	// If dry run is requested, assets are only reported and nothing is deleted.
+	Purge(ctx context.Context, opts *retentionmodel.PurgeOptions) ([]*retentionmodel.PurgeRecord, error)
 }
 
 // Service implements the AssetService interface for managing MUX assets.
 type Service struct {
 	repo         *assetrepo.Repository
-	metadataRepo *assetmetadatarepo.Repository
-	videoClient  *client.VideoServiceClient
-	apiClient    *apiclient.Client
-	logger       *zap.Logger
+	metadataRepo dbmetadata.Repository[metadatamodel.AssetMetadata, metadatamodel.Owner]
+	outboxRepo   *outboxrepo.Repository
+	// collectionRepo is used to list the assets of collections and to drop memberships of deleted assets.
+	collectionRepo *collectionrepo.Repository
+	auditRepo      *auditrepo.Repository
+	versionRepo    *versionrepo.Repository
+	videoClient    videopbv1.VideoServiceClient
+	apiClient      apiclient.APIClient
+	publisher      events.Publisher
+	cache          cache.Cache
+	cacheTTL       cache.TTL
+	ownership      *ownertypes.Policies
+	// enrichment enables storing the transcripts of ready videos.
+	enrichment bool
+	// encoding resolves the encoding settings of the uploads, any requested settings are allowed if it is nil.
+	encoding *assetmodel.EncodingPolicy
+	// drmConfigurationID is the DRM configuration of uploads that do not select one.
+	drmConfigurationID string
+	// sessions records the issued playback tokens, tokens are not recorded if it is nil.
+	sessions *playbackservice.Service
+	// signingKeys provides the rotated signing keys, tokens are signed with the key of the API client if it is nil.
+	signingKeys *signingkeyservice.Service
+	// sagas executes the owner updates.
+	sagas *sagaservice.Executor
+	// counters compute the list totals, totals are exact if it is nil.
+	counters *pagination.Counters
+	// quota limits the assets of each creator.
+	quota quota.Limits
+	// entitlements decides which end users may play the assets, end users may play none if it is nil.
+	entitlements entitlement.Checker
+	// deliveryTokenTTL is the validity of the playback tokens issued to end users.
+	deliveryTokenTTL time.Duration
+	// restrictions limit where the playback tokens may be used, tokens are not restricted if it is nil.
+	restrictions *restrictionmodel.Policies
+	// restrictionRepo stores the MUX playback restrictions the policies are synchronized to.
+	restrictionRepo *restrictionrepo.Repository
+	// watermarks resolves the watermarks burned into new videos, videos are not watermarked if it is nil.
+	watermarks *watermarkservice.Service
+	// imageRepo looks up the Cloudinary images used as posters, posters can only be video frames if it is nil.
+	imageRepo *cldassetrepo.Repository
+	// environments maps the MUX environment IDs to the environment names the webhooks are processed in.
+	environments map[string]string
+	// watches delivers the asset events to the status watches, watches only poll the database if it is nil.
+	watches *events.Hub
+	logger  *zap.Logger
 }
 
+// defaultListByOwnerPageSize is used by ListByOwner and ListByCollection when the request does not specify a page size.
+const defaultListByOwnerPageSize = 50
+
 var _ AssetService = (*Service)(nil)
 
 type NewParams struct {
-	Repo         *assetrepo.Repository
-	MetadataRepo *assetmetadatarepo.Repository
-	VideoClient  *client.VideoServiceClient
-	ApiClient    *apiclient.Client
+	Repo           *assetrepo.Repository
+	MetadataRepo   dbmetadata.Repository[metadatamodel.AssetMetadata, metadatamodel.Owner]
+	OutboxRepo     *outboxrepo.Repository
+	CollectionRepo *collectionrepo.Repository
+	AuditRepo      *auditrepo.Repository
+	VersionRepo    *versionrepo.Repository
+	VideoClient    videopbv1.VideoServiceClient
+	ApiClient      apiclient.APIClient
+	// Publisher is optional, events are discarded if it is not provided. The outbox events and the cache
+	// invalidation of the events are carried out by the subscribers of [Service.Subscribe], they must be
+	// registered with the bus passed as Publisher.
+	Publisher events.Publisher
+	// Cache is optional, lookups always hit the databases if it is not provided.
+	Cache    cache.Cache
+	CacheTTL cache.TTL
+	// Ownership is optional, owners are only limited by the owner type registry if it is not provided.
+	Ownership *ownertypes.Policies
+	// Enrichment enables storing the transcripts of the subtitles generated by MUX for ready videos.
+	Enrichment bool
+	// Encoding is optional, uploads are created with the requested or the default encoding settings if it
+	// is not provided.
+	Encoding *assetmodel.EncodingPolicy
+	// DRMConfigurationID is optional, uploads that do not select a DRM configuration are not DRM protected if it is empty.
+	DRMConfigurationID string
+	// Sessions is optional, issued playback tokens are neither recorded nor limited if it is not provided.
+	Sessions *playbackservice.Service
+	// SigningKeys is optional, playback tokens are signed with the signing key of the API client if it is not provided.
+	SigningKeys *signingkeyservice.Service
+	// Sagas executes the owner updates, the saga definitions of the service must be registered with it.
+	Sagas *sagaservice.Executor
+	// Counters is optional, list totals are always counted exactly if it is not provided.
+	Counters *pagination.Counters
+	// Quota is optional, the zero value does not limit creators.
+	Quota quota.Limits
+	// Entitlements is optional, end users may not play any asset if it is not provided.
+	Entitlements entitlement.Checker
+	// DeliveryTokenTTL is the validity of the playback tokens issued to end users.
+	DeliveryTokenTTL time.Duration
+	// Restrictions is optional, playback tokens are only subject to the playback restriction of
+	// the API client if it is not provided.
+	Restrictions *restrictionmodel.Policies
+	// RestrictionRepo stores the MUX playback restrictions of the Restrictions policies.
+	RestrictionRepo *restrictionrepo.Repository
+	// Watermarks is optional, new videos are not watermarked if it is not provided.
+	Watermarks *watermarkservice.Service
+	// ImageRepo is optional, posters can only be set to a frame of the video if it is not provided.
+	ImageRepo *cldassetrepo.Repository
+	// Environments maps the MUX environment IDs to the environment names. It is optional, webhooks
+	// of unmapped MUX environments are processed in the default environment.
+	Environments map[string]string
+	// Watches is optional, status watches only poll the database if it is not provided. It must be
+	// subscribed to the Publisher.
+	Watches *events.Hub
 }
 
 func New(
 	params *NewParams,
 	logger *zap.Logger,
 ) *Service {
+	publisher := params.Publisher
+	if publisher == nil {
+		publisher = events.NoopPublisher{}
+	}
+	assetCache := params.Cache
+	if assetCache == nil {
+		assetCache = cache.Noop{}
+	}
 	return &Service{
-		repo:         params.Repo,
-		videoClient:  params.VideoClient,
-		metadataRepo: params.MetadataRepo,
-		apiClient:    params.ApiClient,
-		logger:       logger.With(zap.String("layer", "service"), zap.String("service", "mux")),
+		repo:               params.Repo,
+		videoClient:        params.VideoClient,
+		metadataRepo:       params.MetadataRepo,
+		outboxRepo:         params.OutboxRepo,
+		collectionRepo:     params.CollectionRepo,
+		auditRepo:          params.AuditRepo,
+		versionRepo:        params.VersionRepo,
+		restrictionRepo:    params.RestrictionRepo,
+		apiClient:          params.ApiClient,
+		publisher:          publisher,
+		cache:              assetCache,
+		cacheTTL:           params.CacheTTL,
+		ownership:          params.Ownership,
+		enrichment:         params.Enrichment,
+		encoding:           params.Encoding,
+		drmConfigurationID: params.DRMConfigurationID,
+		sessions:           params.Sessions,
+		signingKeys:        params.SigningKeys,
+		sagas:              params.Sagas,
+		counters:           params.Counters,
+		quota:              params.Quota,
+		entitlements:       params.Entitlements,
+		deliveryTokenTTL:   params.DeliveryTokenTTL,
+		restrictions:       params.Restrictions,
+		watermarks:         params.Watermarks,
+		imageRepo:          params.ImageRepo,
+		environments:       params.Environments,
+		watches:            params.Watches,
+		logger:             logger.With(zap.String("layer", "service"), zap.String("service", "mux")),
 	}
 }
 
+// log returns the service logger enriched with the request-scoped fields stored in ctx.
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
 // Get retrieves an active asset based on the provided filter.
 func (s *Service) Get(ctx context.Context, filter *assetmodel.GetFilter) (*assetmodel.Details, error) {
 	return s.get(ctx, filter, []assetrepo.Scope{
@@ -109,6 +373,8 @@ func (s *Service) Get(ctx context.Context, filter *assetmodel.GetFilter) (*asset
 }
 
 // GetWithArchived retrieves an asset that can be either active or archived based on the provided filter.
+// An archived asset carries the ownership snapshot taken when it lost its owners. Its metadata is nil
+// if the asset was deleted in MUX.
 func (s *Service) GetWithArchived(ctx context.Context, filter *assetmodel.GetFilter) (*assetmodel.Details, error) {
 	return s.get(ctx, filter, []assetrepo.Scope{
 		assetrepo.ScopeActive,
@@ -133,6 +399,8 @@ func (s *Service) List(ctx context.Context, req *assetmodel.ListRequest) ([]*ass
 }
 
 // ListArchived retrieves a list of archived assets based on the provided request.
+// The assets carry who archived them and when, and the ownership snapshot taken when they lost their
+// owners, which RestoreWithOwners re-associates.
 func (s *Service) ListArchived(ctx context.Context, req *assetmodel.ListRequest) ([]*assetmodel.Details, string, error) {
 	return s.list(ctx, req, []assetrepo.Scope{
 		assetrepo.ScopeArchived,
@@ -146,37 +414,157 @@ func (s *Service) ListBroken(ctx context.Context, req *assetmodel.ListRequest) (
 	})
 }
 
+// ListTotal returns the total of the assets listed by List, computed with the count strategy of the endpoint.
+func (s *Service) ListTotal(ctx context.Context, req *assetmodel.ListRequest) (*pagination.Total, error) {
+	return s.total(ctx, req, "mux.list", []assetrepo.Scope{
+		assetrepo.ScopeActive,
+	})
+}
+
+// ListArchivedTotal returns the total of the assets listed by ListArchived.
+func (s *Service) ListArchivedTotal(ctx context.Context, req *assetmodel.ListRequest) (*pagination.Total, error) {
+	return s.total(ctx, req, "mux.list_archived", []assetrepo.Scope{
+		assetrepo.ScopeArchived,
+	})
+}
+
+// ListBrokenTotal returns the total of the assets listed by ListBroken.
+func (s *Service) ListBrokenTotal(ctx context.Context, req *assetmodel.ListRequest) (*pagination.Total, error) {
+	return s.total(ctx, req, "mux.list_broken", []assetrepo.Scope{
+		assetrepo.ScopeBroken,
+	})
+}
+
+// ListByOwner retrieves a page of assets associated with a single owner, e.g. all videos of a lesson.
+// Assets are ordered by ID, the returned page token is the last asset ID of the page.
+func (s *Service) ListByOwner(ctx context.Context, req *assetmodel.ListByOwnerRequest) ([]*assetmodel.Details, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultListByOwnerPageSize
+	}
+
+	metadataPage, nextPageToken, err := s.metadataRepo.ListByOwner(ctx, &metadatamodel.Owner{
+		OwnerID:   req.OwnerID,
+		OwnerType: req.OwnerType,
+	}, pageSize, req.PageToken)
+	if err != nil {
+		s.log(ctx).Error("failed to list asset metadata by owner", zap.Error(err), logging.OwnerType(req.OwnerType), zap.String("owner_id", req.OwnerID))
+		return nil, "", fmt.Errorf("failed to list asset metadata by owner: %w", err)
+	}
+	details, err := s.joinAssets(ctx, metadataPage)
+	if err != nil {
+		return nil, "", err
+	}
+	return details, nextPageToken, nil
+}
+
+// GetByOwner retrieves the asset associated with the owner, so owner services can resolve their
+// media without storing the asset ID. The lookup uses the owner index of the metadata. If the owner
+// has several assets the one with the lowest ID is returned, ListByOwner returns all of them.
+func (s *Service) GetByOwner(ctx context.Context, req *assetmodel.GetByOwnerRequest) (*assetmodel.Details, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	metadataPage, _, err := s.metadataRepo.ListByOwner(ctx, &metadatamodel.Owner{
+		OwnerID:   req.OwnerID,
+		OwnerType: req.OwnerType,
+	}, 1, "")
+	if err != nil {
+		s.log(ctx).Error("failed to get asset metadata by owner", zap.Error(err), logging.OwnerType(req.OwnerType), zap.String("owner_id", req.OwnerID))
+		return nil, fmt.Errorf("failed to get asset metadata by owner: %w", err)
+	}
+	details, err := s.joinAssets(ctx, metadataPage)
+	if err != nil {
+		return nil, err
+	}
+	if len(details) == 0 {
+		return nil, serviceerrors.NewNotFoundError("no asset is associated with the owner")
+	}
+	return details[0], nil
+}
+
+// ListOwnerTypes returns the owner types assets can be associated with.
+func (s *Service) ListOwnerTypes(_ context.Context) []string {
+	return assetmodel.OwnerTypes.List()
+}
+
 // CreateUploadURL generates a new upload URL for new MUX Direct Upload and creates a new asset.
 // After this step, the rest of the asset information will be populated via incoming MUX webhooks.
 func (s *Service) CreateUploadURL(ctx context.Context, req *assetmodel.CreateUploadURLRequest) (*muxgo.UploadResponse, error) {
 	if err := req.Validate(); err != nil {
 		return nil, serviceerrors.NewValidationFailedError(err)
 	}
+	resp, _, err := s.createUploadURL(ctx, req)
+	return resp, err
+}
 
+// createUploadURL creates a new asset with a MUX Direct Upload URL. It returns the upload and the local asset ID.
+func (s *Service) createUploadURL(ctx context.Context, req *assetmodel.CreateUploadURLRequest) (*muxgo.UploadResponse, uuid.UUID, error) {
 	var resp *muxgo.UploadResponse
-	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+	var createdAssetID uuid.UUID
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
+		creatorID, err := parsing.StrToUUID(req.AdminID)
+		if err != nil {
+			return err
+		}
+		if err := s.checkQuota(ctx, txRepo, creatorID); err != nil {
+			return err
+		}
+
 		newAssetID, err := uuid.NewV7()
 		if err != nil {
 			return fmt.Errorf("failed to generate new asset id: %w", err)
 		}
 		newAsset := &assetmodel.Asset{
-			ID:           newAssetID,
-			Status:       assetmodel.StatusUploadURLGenerated,
-			UploadStatus: assetmodel.UploadStatusPreparing,
+			ID:            newAssetID,
+			Status:        assetmodel.StatusUploadURLGenerated,
+			UploadStatus:  assetmodel.UploadStatusPreparing,
+			CreatedBy:     &creatorID,
+			CreatedByName: &req.AdminName,
+		}
+		drmConfigurationID := s.drmConfigurationID
+		if req.DRMConfigurationID != nil {
+			drmConfigurationID = *req.DRMConfigurationID
 		}
+		if drmConfigurationID != "" {
+			newAsset.DRMConfigurationID = &drmConfigurationID
+		}
+		encoding, err := s.encoding.Resolve(req.OwnerType, req.Encoding)
+		if err != nil {
+			return serviceerrors.NewValidationFailedError(err)
+		}
+		newAsset.Encoding = &encoding
 
-		s.logger.Info("generating upload url", zap.String("asset_id", newAssetID.String()))
+		overlays, err := s.watermarkOverlays(ctx, req.AdminID, req.OwnerType)
+		if err != nil {
+			return err
+		}
+
+		s.log(ctx).Info("generating upload url", logging.AssetID(newAssetID), zap.Bool("watermarked", len(overlays) > 0))
 
 		muxMeta := &muxgo.AssetMetadata{
 			Title:      req.Title,
 			CreatorId:  req.AdminID,
 			ExternalId: newAssetID.String(),
 		}
-		resp, err = s.apiClient.CreateDirectUploadURL(ctx, muxMeta, muxgo.SIGNED, muxgo.PUBLIC)
+		// A DRM protected asset only gets the DRM playback ID, any other one would bypass the license.
+		policies := playbackPolicies(encoding.PlaybackPolicies)
+		if drmConfigurationID != "" {
+			policies = nil
+		}
+		resp, err = s.apiClient.CreateDirectUploadURL(ctx, muxMeta, drmConfigurationID, overlays, apiclient.EncodingSettings{
+			MaxResolutionTier: encoding.MaxResolutionTier,
+			VideoQuality:      encoding.VideoQuality,
+			MP4Support:        encoding.MP4Support,
+			NormalizeAudio:    encoding.NormalizeAudio != nil && *encoding.NormalizeAudio,
+		}, policies...)
 		if err != nil {
-			s.logger.Error("failed to create direct upload url", zap.Error(err), zap.String("asset_id", newAssetID.String()))
+			s.log(ctx).Error("failed to create direct upload url", zap.Error(err), logging.AssetID(newAssetID))
 			return fmt.Errorf("failed to create direct upload url: %w", err)
 		}
 
@@ -184,11 +572,11 @@ func (s *Service) CreateUploadURL(ctx context.Context, req *assetmodel.CreateUpl
 		newAsset.MuxAssetID = &resp.Data.AssetId
 
 		if err := txRepo.Create(ctx, newAsset); err != nil {
-			s.logger.Error("failed to create mux asset record", zap.Error(err), zap.String("asset_id", newAssetID.String()))
+			s.log(ctx).Error("failed to create mux asset record", zap.Error(err), logging.AssetID(newAssetID))
 			return fmt.Errorf("failed to create mux asset record: %w", err)
 		}
 
-		s.logger.Info("successfully generated upload url", zap.String("asset_id", newAssetID.String()), zap.String("upload_url", resp.Data.Url))
+		s.log(ctx).Info("successfully generated upload url", logging.AssetID(newAssetID), zap.String("upload_url", resp.Data.Url))
 
 		metadata := &metadatamodel.AssetMetadata{
 			Key:       newAssetID.String(),
@@ -198,16 +586,49 @@ func (s *Service) CreateUploadURL(ctx context.Context, req *assetmodel.CreateUpl
 			Tracks:    []*muxtypes.MuxWebhookTrack{}, // initialize empty tracks slice
 		}
 
-		if err := s.metadataRepo.Create(ctx, metadata); err != nil {
-			s.logger.Error("failed to create asset metadata", zap.Error(err), zap.String("asset_id", newAssetID.String()))
+		if err := s.createMetadata(ctx, metadata); err != nil {
+			s.log(ctx).Error("failed to create asset metadata", zap.Error(err), logging.AssetID(newAssetID))
 			return fmt.Errorf("failed to create asset metadata: %w", err)
 		}
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionCreateUploadURL, newAssetID, nil, newAsset,
+			audit.WithAdmin(req.AdminID, req.AdminName),
+		); err != nil {
+			return err
+		}
+		createdAssetID = newAssetID
 		return nil
 	})
 	if err != nil {
-		return nil, err
+		if resp != nil {
+			// The upload was created but the asset was not, so the upload is cancelled before anything is uploaded to it.
+			s.cancelDirectUpload(ctx, resp.Data.Id)
+		}
+		if deadline.Exceeded(err) {
+			return nil, uuid.Nil, serviceerrors.NewDeadlineExceededError(err)
+		}
+		return nil, uuid.Nil, err
+	}
+	s.publishEvent(ctx, events.TypeAssetCreated, createdAssetID)
+	return resp, createdAssetID, nil
+}
+
+// playbackPolicies converts the playback policies of the encoding settings.
+func playbackPolicies(policies []string) []muxgo.PlaybackPolicy {
+	converted := make([]muxgo.PlaybackPolicy, 0, len(policies))
+	for _, policy := range policies {
+		converted = append(converted, muxgo.PlaybackPolicy(policy))
+	}
+	return converted
+}
+
+// cancelDirectUpload cancels a direct upload left behind by a failed operation. It runs detached from the
+// operation context, which may already be past its deadline.
+func (s *Service) cancelDirectUpload(ctx context.Context, uploadID string) {
+	ctx, cancel := deadline.Cleanup(ctx)
+	defer cancel()
+	if err := s.apiClient.CancelDirectUpload(ctx, uploadID); err != nil {
+		s.log(ctx).Warn("failed to cancel orphaned direct upload", zap.Error(err), zap.String("upload_id", uploadID))
 	}
-	return resp, nil
 }
 
 // Archive marks an asset as archived.
@@ -216,6 +637,7 @@ func (s *Service) Archive(ctx context.Context, req *assetmodel.ChangeStateReques
 	if err := req.Validate(); err != nil {
 		return serviceerrors.NewValidationFailedError(err)
 	}
+	defer s.invalidateByID(ctx, req.ID)
 
 	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
@@ -229,7 +651,7 @@ func (s *Service) Archive(ctx context.Context, req *assetmodel.ChangeStateReques
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return serviceerrors.NewNotFoundError(err)
 			}
-			s.logger.Error("failed to retrieve asset for archiving", zap.Error(err), zap.String("asset_id", req.ID))
+			s.log(ctx).Error("failed to retrieve asset for archiving", zap.Error(err), zap.String("asset_id", req.ID))
 			return fmt.Errorf("failed to retrieve asset for archiving: %w", err)
 		}
 
@@ -245,22 +667,29 @@ func (s *Service) Archive(ctx context.Context, req *assetmodel.ChangeStateReques
 			return serviceerrors.NewConflictError("cannot archive asset that is associated with owners")
 		}
 
-		return s.archiveAsset(ctx, txRepo, req, assetID)
+		if err := s.archiveAsset(ctx, txRepo, req, assetID); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, tx, auditmodel.ActionArchive, assetID,
+			statusSnapshot(asset.Status), statusSnapshot(assetmodel.StatusArchived),
+			audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note),
+		)
 	})
 }
 
 // MarkAsBroken marks an asset as broken.
 // If the asset has owners, it notifies the product-service about the broken asset via [gRPC client].
+// The notification is persisted in the transactional outbox and delivered asynchronously.
 //
 // [gRPC client]: https://github.com/mikhail5545/product-service-client
 func (s *Service) MarkAsBroken(ctx context.Context, req *assetmodel.ChangeStateRequest) error {
 	if err := req.Validate(); err != nil {
 		return serviceerrors.NewValidationFailedError(err)
 	}
+	defer s.invalidateByID(ctx, req.ID)
 
-	var toMarkAsBroken *uuid.UUID
-	var metadataToDelete *metadatamodel.AssetMetadata
-	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+	var metadataToClear *metadatamodel.AssetMetadata
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
 		asset, err := s.getInTx(ctx, txRepo, []string{
@@ -268,6 +697,9 @@ func (s *Service) MarkAsBroken(ctx context.Context, req *assetmodel.ChangeStateR
 		}, assetSearchOptions{
 			AssetID: req.ID,
 		})
+		if err != nil {
+			return err
+		}
 		if asset.Status == assetmodel.StatusBroken {
 			return serviceerrors.NewConflictError("asset is already marked as broken")
 		}
@@ -281,9 +713,15 @@ func (s *Service) MarkAsBroken(ctx context.Context, req *assetmodel.ChangeStateR
 			AdminName: req.AdminName,
 			Note:      req.Note,
 		}); err != nil {
-			s.logger.Error("failed to mark asset as broken", zap.Error(err), zap.String("asset_id", asset.ID.String()))
+			s.log(ctx).Error("failed to mark asset as broken", zap.Error(err), logging.AssetID(asset.ID))
 			return fmt.Errorf("failed to mark asset as broken: %w", err)
 		}
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionMarkAsBroken, asset.ID,
+			statusSnapshot(asset.Status), statusSnapshot(assetmodel.StatusBroken),
+			audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note),
+		); err != nil {
+			return err
+		}
 
 		metadata, err := s.getAssetMetadata(ctx, asset.ID)
 		if err != nil {
@@ -291,36 +729,44 @@ func (s *Service) MarkAsBroken(ctx context.Context, req *assetmodel.ChangeStateR
 		}
 
 		if len(metadata.Owners) > 0 {
-			toMarkAsBroken = &asset.ID
-			metadataToDelete = metadata
+			if err := s.snapshotOwners(ctx, txRepo, asset.ID, metadata.Owners, assetmodel.SnapshotReasonBroken); err != nil {
+				return err
+			}
+			if err := s.enqueueEvent(ctx, s.outboxRepo.WithTx(tx), outboxmodel.EventVideoBroken, &outboxmodel.BrokenPayload{
+				AssetID:   asset.ID,
+				AdminID:   adminID,
+				AdminName: req.AdminName,
+				Reason:    req.Note,
+			}); err != nil {
+				return err
+			}
+			metadataToClear = metadata
 		}
 		return nil
 	})
 	if err != nil {
 		return err
 	}
-	if toMarkAsBroken != nil {
-		if err := s.markAsBrokenAndClearOwners(ctx, toMarkAsBroken, metadataToDelete, req); err != nil {
-			return err
-		}
+	if metadataToClear != nil {
+		return s.clearOwners(ctx, metadataToClear)
 	}
 	return nil
 }
 
 // Delete permanently deletes an archived asset along with its metadata.
-// It also deletes the asset from MUX.
+// The asset is marked as pending deletion and deleted from MUX and the databases asynchronously,
+// so no row locks are held while MUX is called.
 // Note that only currently soft-deleted (archived) assets can be permanently deleted.
 func (s *Service) Delete(ctx context.Context, req *assetmodel.ChangeStateRequest) error {
 	if err := req.Validate(); err != nil {
 		return serviceerrors.NewValidationFailedError(err)
 	}
-	var assetIDtoDelete *uuid.UUID
-	var muxAssetIDtoDelete *string
-	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+	defer s.invalidateByID(ctx, req.ID)
+	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
 		asset, err := s.getInTx(ctx, txRepo, []string{
-			"id", "status", "upload_status",
+			"id", "status", "upload_status", "mux_asset_id",
 		}, assetSearchOptions{
 			AssetID: req.ID,
 		})
@@ -331,33 +777,73 @@ func (s *Service) Delete(ctx context.Context, req *assetmodel.ChangeStateRequest
 			return serviceerrors.NewConflictError("only archived assets can be deleted")
 		}
 
-		// Delete asset record from Postgres
-		if _, err := txRepo.Delete(ctx, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}); err != nil {
-			s.logger.Error("failed to delete mux asset record", zap.Error(err), zap.String("asset_id", asset.ID.String()))
-			return fmt.Errorf("failed to delete mux asset record: %w", err)
+		if _, err := txRepo.MarkPendingDelete(ctx, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}); err != nil {
+			s.log(ctx).Error("failed to mark mux asset as pending deletion", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to mark mux asset as pending deletion: %w", err)
 		}
-		assetIDtoDelete = &asset.ID
-		muxAssetIDtoDelete = asset.MuxAssetID
-		return nil
+		if err := s.removeFromCollections(ctx, tx, asset.ID); err != nil {
+			return err
+		}
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionDelete, asset.ID, asset, nil,
+			audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note),
+		); err != nil {
+			return err
+		}
+		return s.enqueueEvent(ctx, s.outboxRepo.WithTx(tx), outboxmodel.EventVideoDeleteRemote, &outboxmodel.DeleteRemotePayload{
+			AssetID: asset.ID,
+		})
 	})
-	if err != nil {
-		return err
+}
+
+// UpdateMetadata changes the title or the creator of an asset.
+// The new values are stored in the asset metadata in MongoDB and pushed to the meta object of the MUX asset.
+// Broken or archived assets cannot be updated.
+func (s *Service) UpdateMetadata(ctx context.Context, req *assetmodel.UpdateMetadataRequest) (*metadatamodel.AssetMetadata, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
 	}
-	if err := s.deleteMetadataAndMuxAsset(ctx, assetIDtoDelete, muxAssetIDtoDelete); err != nil {
+	defer s.invalidateByID(ctx, req.ID)
+
+	var metadata *metadatamodel.AssetMetadata
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		asset, err := s.getInTx(ctx, txRepo, []string{
+			"id", "status", "mux_asset_id", "tags",
+		}, assetSearchOptions{
+			AssetID: req.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if asset.Status == assetmodel.StatusArchived || asset.Status == assetmodel.StatusBroken {
+			return serviceerrors.NewConflictError("cannot update metadata of archived or broken asset")
+		}
+
+		metadata, err = s.updateMetadata(ctx, tx, asset, req)
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return metadata, nil
 }
 
 // AddOwner associates an external owner with an asset.
 // It updates the asset metadata in MongoDB to include the new owner.
 // Broken or archived assets cannot have owners added.
+// If ReplaceExisting is set, the owner is moved to the asset as described in [Service.AssociateOwner].
 func (s *Service) AddOwner(ctx context.Context, req *assetmodel.ManageOwnerRequest) error {
 	if err := req.Validate(); err != nil {
 		return serviceerrors.NewValidationFailedError(err)
 	}
+	if req.ReplaceExisting {
+		_, err := s.replaceOwner(ctx, req, versionmodel.KindReplace)
+		return err
+	}
+	defer s.invalidateByID(ctx, req.ID)
 
-	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+	return crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
 		asset, err := s.getInTx(ctx, txRepo, []string{
@@ -368,14 +854,15 @@ func (s *Service) AddOwner(ctx context.Context, req *assetmodel.ManageOwnerReque
 		if err != nil {
 			return err
 		}
-		if asset.Status == assetmodel.StatusArchived || asset.Status == assetmodel.StatusBroken {
-			return serviceerrors.NewConflictError("cannot add owner to archived or broken asset")
-		}
-		if asset.UploadStatus == assetmodel.UploadStatusErrored || asset.UploadStatus == assetmodel.UploadStatusDeleted {
-			return serviceerrors.NewConflictError("cannot add owner to asset with errored or deleted upload status")
+		if err := validateBeforeAddOwner(asset); err != nil {
+			return err
 		}
 
-		return s.addOwner(ctx, asset.ID, req)
+		metadata, err := s.addOwner(ctx, tx, asset.ID, req)
+		if err != nil {
+			return err
+		}
+		return s.publishEventInTx(ctx, tx, events.TypeAssetOwnersChanged, asset.ID, withOwners(metadata.Owners))
 	})
 }
 
@@ -385,8 +872,9 @@ func (s *Service) RemoveOwner(ctx context.Context, req *assetmodel.ManageOwnerRe
 	if err := req.Validate(); err != nil {
 		return serviceerrors.NewValidationFailedError(err)
 	}
+	defer s.invalidateByID(ctx, req.ID)
 
-	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+	return crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
 		asset, err := s.getInTx(ctx, txRepo, []string{
@@ -404,15 +892,18 @@ func (s *Service) RemoveOwner(ctx context.Context, req *assetmodel.ManageOwnerRe
 		}
 		metadata, err := s.metadataRepo.GetByOwner(ctx, asset.ID.String(), &toRemove)
 		if err != nil {
-			if errors.Is(err, mongo.ErrNoDocuments) {
+			if errors.Is(err, dbmetadata.ErrNotFound) {
 				return serviceerrors.NewNotFoundError(err)
 			}
-			s.logger.Error("failed to retrieve asset metadata for removing owner",
+			s.log(ctx).Error("failed to retrieve asset metadata for removing owner",
 				zap.Error(err), zap.String("owner_id", req.OwnerID), zap.String("owner_type", req.OwnerType),
 			)
 			return fmt.Errorf("failed to retrieve asset metadata for removing owner: %w", err)
 		}
-		return s.removeOwner(ctx, metadata, req)
+		if err := s.removeOwner(ctx, tx, metadata, req); err != nil {
+			return err
+		}
+		return s.publishEventInTx(ctx, tx, events.TypeAssetOwnersChanged, asset.ID, withOwners(metadata.Owners))
 	})
 }
 
@@ -422,37 +913,73 @@ func (s *Service) Restore(ctx context.Context, req *assetmodel.ChangeStateReques
 	if err := req.Validate(); err != nil {
 		return serviceerrors.NewValidationFailedError(err)
 	}
+	defer s.invalidateByID(ctx, req.ID)
 
 	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
-		txRepo := s.repo.WithTx(tx)
+		_, err := s.restore(ctx, tx, req)
+		return err
+	})
+}
 
-		asset, err := s.getInTx(ctx, txRepo, []string{
-			"id", "status", "upload_status",
-		}, assetSearchOptions{
-			AssetID: req.ID,
-		})
+// RestoreWithOwners restores an archived asset back to active status and re-associates the owners
+// the asset had when they were cleared, e.g. when the asset was marked as broken.
+// The owners are taken from the ownership snapshot of the asset. Only archived assets can be restored.
+func (s *Service) RestoreWithOwners(ctx context.Context, req *assetmodel.ChangeStateRequest) (*metadatamodel.AssetMetadata, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	defer s.invalidateByID(ctx, req.ID)
+
+	var metadata *metadatamodel.AssetMetadata
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
+		asset, err := s.restore(ctx, tx, req)
 		if err != nil {
 			return err
 		}
-		if asset.Status != assetmodel.StatusArchived {
-			return serviceerrors.NewConflictError("only archived assets can be restored")
-		}
-
-		adminID, err := parsing.StrToUUID(req.AdminID)
+		metadata, err = s.restoreOwners(ctx, tx, asset)
 		if err != nil {
 			return err
 		}
+		return s.publishEventInTx(ctx, tx, events.TypeAssetOwnersChanged, asset.ID, withOwners(metadata.Owners))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
 
-		if _, err := txRepo.Restore(ctx, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}, types.AuditTrailOptions{
-			AdminID:   adminID,
-			AdminName: req.AdminName,
-			Note:      req.Note,
-		}); err != nil {
-			s.logger.Error("failed to restore asset", zap.Error(err), zap.String("asset_id", req.ID))
-			return fmt.Errorf("failed to restore asset: %w", err)
-		}
-		return nil
+func (s *Service) restore(ctx context.Context, tx *gorm.DB, req *assetmodel.ChangeStateRequest) (*assetmodel.Asset, error) {
+	txRepo := s.repo.WithTx(tx)
+
+	asset, err := s.getInTx(ctx, txRepo, []string{
+		"id", "status", "upload_status", "ownership_snapshot",
+	}, assetSearchOptions{
+		AssetID: req.ID,
 	})
+	if err != nil {
+		return nil, err
+	}
+	if asset.Status != assetmodel.StatusArchived {
+		return nil, serviceerrors.NewConflictError("only archived assets can be restored")
+	}
+
+	adminID, err := parsing.StrToUUID(req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := txRepo.Restore(ctx, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}, types.AuditTrailOptions{
+		AdminID:   adminID,
+		AdminName: req.AdminName,
+		Note:      req.Note,
+	}); err != nil {
+		s.log(ctx).Error("failed to restore asset", zap.Error(err), zap.String("asset_id", req.ID))
+		return nil, fmt.Errorf("failed to restore asset: %w", err)
+	}
+	return asset, s.recordAudit(ctx, tx, auditmodel.ActionRestore, asset.ID,
+		statusSnapshot(asset.Status), statusSnapshot(assetmodel.StatusActive),
+		audit.WithAdmin(req.AdminID, req.AdminName), audit.WithNote(req.Note),
+	)
 }
 
 // GeneratePlaybackToken generates a signed JWT playback token for secure video playback.
@@ -460,34 +987,148 @@ func (s *Service) GeneratePlaybackToken(ctx context.Context, req *assetmodel.Gen
 	if err := req.Validate(); err != nil {
 		return "", serviceerrors.NewValidationFailedError(err)
 	}
+	asset, err := s.getPlayableAsset(ctx, req.AssetID, "primary_signed_playback_id")
+	if err != nil {
+		return "", err
+	}
+	if asset.PrimarySignedPlaybackID == nil {
+		s.log(ctx).Error("asset does not have a signed playback ID for token generation", logging.AssetID(req.AssetID))
+		return "", serviceerrors.NewConflictError("asset does not have a signed playback ID for token generation")
+	}
+	restrictionID, err := s.assetPlaybackRestriction(ctx, req.AssetID, req.Viewer())
+	if err != nil {
+		return "", err
+	}
+	signingKey, err := s.signingKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	token, err := s.apiClient.GeneratePlaybackJWTToken(ctx, apiclient.GeneratePlaybackTokenOptions{
+		UserID:                req.UserID,
+		PlaybackID:            *asset.PrimarySignedPlaybackID,
+		Expiration:            req.Expiration,
+		UserAgent:             req.UserAgent,
+		SessionID:             req.SessionID,
+		PlaybackRestrictionID: restrictionID,
+		SigningKey:            signingKey,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := s.recordTokens(ctx, req, token); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// GenerateDRMPlaybackTokens generates the playback and license tokens for Widevine and FairPlay playback
+// of an asset created with a DRM configuration.
+func (s *Service) GenerateDRMPlaybackTokens(ctx context.Context, req *assetmodel.GeneratePlaybackTokenRequest) (*assetmodel.DRMPlaybackTokens, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	asset, err := s.getPlayableAsset(ctx, req.AssetID, "primary_drm_playback_id")
+	if err != nil {
+		return nil, err
+	}
+	if asset.PrimaryDRMPlaybackID == nil {
+		return nil, serviceerrors.NewConflictError("asset does not have a DRM playback ID for token generation")
+	}
+	restrictionID, err := s.assetPlaybackRestriction(ctx, req.AssetID, req.Viewer())
+	if err != nil {
+		return nil, err
+	}
+	return s.drmTokens(ctx, req, *asset.PrimaryDRMPlaybackID, restrictionID)
+}
+
+// drmTokens generates and records the playback and license tokens of the DRM playback ID.
+func (s *Service) drmTokens(ctx context.Context, req *assetmodel.GeneratePlaybackTokenRequest, playbackID, restrictionID string) (*assetmodel.DRMPlaybackTokens, error) {
+	signingKey, err := s.signingKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := apiclient.GeneratePlaybackTokenOptions{
+		UserID:                req.UserID,
+		PlaybackID:            playbackID,
+		Expiration:            req.Expiration,
+		UserAgent:             req.UserAgent,
+		SessionID:             req.SessionID,
+		PlaybackRestrictionID: restrictionID,
+		SigningKey:            signingKey,
+	}
+	playbackToken, err := s.apiClient.GeneratePlaybackJWTToken(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	licenseToken, err := s.apiClient.GenerateDRMLicenseJWTToken(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordTokens(ctx, req, playbackToken, licenseToken); err != nil {
+		return nil, err
+	}
+	return &assetmodel.DRMPlaybackTokens{
+		PlaybackID:             playbackID,
+		PlaybackToken:          playbackToken,
+		LicenseToken:           licenseToken,
+		WidevineLicenseURL:     fmt.Sprintf("https://license.mux.com/license/widevine/%s?token=%s", playbackID, licenseToken),
+		FairPlayLicenseURL:     fmt.Sprintf("https://license.mux.com/license/fairplay/%s?token=%s", playbackID, licenseToken),
+		FairPlayCertificateURL: fmt.Sprintf("https://license.mux.com/appcert/fairplay/%s?token=%s", playbackID, licenseToken),
+		ExpiresAt:              time.Unix(req.Expiration, 0),
+	}, nil
+}
+
+// signingKey returns the rotated signing key of the context environment, nil if the tokens are signed
+// with the signing key of the API client.
+func (s *Service) signingKey(ctx context.Context) (*apiclient.SigningKey, error) {
+	if s.signingKeys == nil {
+		return nil, nil
+	}
+	return s.signingKeys.Current(ctx)
+}
+
+// recordTokens records the tokens issued for the request as a playback session of the user.
+// The tokens must not be handed out if it fails, e.g. because the user has too many concurrent sessions.
+func (s *Service) recordTokens(ctx context.Context, req *assetmodel.GeneratePlaybackTokenRequest, tokens ...string) error {
+	if s.sessions == nil {
+		return nil
+	}
+	sessionID := req.SessionID
+	if sessionID != nil && *sessionID == uuid.Nil {
+		sessionID = nil
+	}
+	return s.sessions.Issue(ctx, &playbackmodel.Grant{
+		AssetID:   req.AssetID,
+		UserID:    req.UserID,
+		SessionID: sessionID,
+		UserAgent: req.UserAgent,
+		ExpiresAt: time.Unix(req.Expiration, 0),
+		Tokens:    tokens,
+	})
+}
+
+// getPlayableAsset retrieves an asset tokens can be generated for, along with the requested playback ID field.
+// Assets outside of their publication schedule are not playable.
+func (s *Service) getPlayableAsset(ctx context.Context, id uuid.UUID, fields ...string) (*assetmodel.Asset, error) {
 	asset, err := s.repo.Get(ctx, assetrepo.GetOptions{
-		ID: req.AssetID,
-		Fields: []string{
-			"id", "status", "upload_status", "primary_signed_playback_id",
-		},
+		ID:     id,
+		Fields: append([]string{"id", "status", "upload_status", "publishing_state", "publish_at", "unpublish_at"}, fields...),
 	}, assetrepo.ScopeAll)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "", serviceerrors.NewNotFoundError(err)
+			return nil, serviceerrors.NewNotFoundError(err)
 		}
-		s.logger.Error("failed to retrieve asset for playback token generation", zap.Error(err), zap.String("asset_id", req.AssetID.String()))
-		return "", fmt.Errorf("failed to retrieve asset for playback token generation: %w", err)
+		s.log(ctx).Error("failed to retrieve asset for playback token generation", zap.Error(err), logging.AssetID(id))
+		return nil, fmt.Errorf("failed to retrieve asset for playback token generation: %w", err)
 	}
 	if asset.Status != assetmodel.StatusActive {
-		return "", serviceerrors.NewConflictError("playback token can only be generated for active assets")
+		return nil, serviceerrors.NewConflictError("playback token can only be generated for active assets")
 	}
 	if asset.UploadStatus != assetmodel.UploadStatusReady {
-		return "", serviceerrors.NewConflictError("playback token can only be generated for assets with ready upload status")
+		return nil, serviceerrors.NewConflictError("playback token can only be generated for assets with ready upload status")
 	}
-	if asset.PrimarySignedPlaybackID == nil {
-		s.logger.Error("asset does not have a signed playback ID for token generation", zap.String("asset_id", req.AssetID.String()))
-		return "", serviceerrors.NewConflictError("asset does not have a signed playback ID for token generation")
+	if err := checkPublished(asset); err != nil {
+		return nil, err
 	}
-	return s.apiClient.GeneratePlaybackJWTToken(apiclient.GeneratePlaybackTokenOptions{
-		UserID:     req.UserID,
-		PlaybackID: *asset.PrimarySignedPlaybackID,
-		Expiration: req.Expiration,
-		UserAgent:  req.UserAgent,
-		SessionID:  req.SessionID,
-	})
+	return asset, nil
 }
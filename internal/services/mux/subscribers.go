@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/bus"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	outboxmodel "github.com/mikhail5545/media-service-go/internal/models/outbox"
+)
+
+// Subscribe registers the subscribers carrying out the side effects of the events of MUX assets: the
+// outbox events, which are enqueued in the transaction the event is published in, and the cache
+// invalidation, which follows the commit.
+func (s *Service) Subscribe(b *bus.Bus) {
+	b.Subscribe("mux.outbox", s.enqueueOutboxEvents, events.TypeAssetReady, events.TypeAssetDeleted)
+	b.Subscribe("mux.cache", s.invalidateOnEvent)
+}
+
+// enqueueOutboxEvents enqueues the enrichment of ready videos and notifies the owners of the videos
+// deleted in MUX.
+func (s *Service) enqueueOutboxEvents(ctx context.Context, event *events.Event) error {
+	if event.Provider != events.ProviderMux {
+		return nil
+	}
+	txOutbox := s.outboxRepo
+	if tx, ok := bus.TxFromContext(ctx); ok {
+		txOutbox = s.outboxRepo.WithTx(tx)
+	}
+	switch event.Type {
+	case events.TypeAssetReady:
+		if !s.enrichment {
+			return nil
+		}
+		return s.enqueueEvent(ctx, txOutbox, outboxmodel.EventVideoEnrich, &outboxmodel.EnrichPayload{
+			AssetID: event.AssetID,
+		})
+	case events.TypeAssetDeleted:
+		// Permanently deleted assets lost their owners when they were archived.
+		if event.Data["permanent"] == "true" || len(event.Owners) == 0 {
+			return nil
+		}
+		return s.enqueueEvent(ctx, txOutbox, outboxmodel.EventVideoForceDeleted, &outboxmodel.DeletePayload{
+			AssetIDs: uuid.UUIDs{event.AssetID},
+		})
+	default:
+		return nil
+	}
+}
+
+// invalidateOnEvent removes the cached records of the asset once the transaction the event is
+// published in committed.
+func (s *Service) invalidateOnEvent(ctx context.Context, event *events.Event) error {
+	if event.Provider != events.ProviderMux {
+		return nil
+	}
+	return crossstore.AfterCommit(ctx, func(ctx context.Context) error {
+		s.invalidate(ctx, event.AssetID)
+		return nil
+	})
+}
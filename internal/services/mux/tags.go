@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
+	"github.com/mikhail5545/media-service-go/internal/tags"
+	muxgo "github.com/muxinc/mux-go/v6"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// passthroughTagsPrefix marks the passthrough of MUX assets as a tag list.
+	passthroughTagsPrefix = "tags:"
+	// maxPassthroughLength is the maximum passthrough length accepted by the MUX API.
+	maxPassthroughLength = 255
+)
+
+// AddTags adds tags to an asset and returns the resulting tags of the asset.
+// The tags are mirrored to the passthrough of the MUX asset.
+func (s *Service) AddTags(ctx context.Context, req *assetmodel.ManageTagsRequest) ([]string, error) {
+	return s.changeTags(ctx, req, auditmodel.ActionAddTags, tags.Add)
+}
+
+// RemoveTags removes tags from an asset and returns the remaining tags of the asset.
+// The tags are mirrored to the passthrough of the MUX asset.
+func (s *Service) RemoveTags(ctx context.Context, req *assetmodel.ManageTagsRequest) ([]string, error) {
+	return s.changeTags(ctx, req, auditmodel.ActionRemoveTags, tags.Remove)
+}
+
+// ListByTag retrieves a list of active assets having the tag.
+func (s *Service) ListByTag(ctx context.Context, req *assetmodel.ListByTagRequest) ([]*assetmodel.Details, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	return s.list(ctx, &assetmodel.ListRequest{
+		Tags:      []string{req.Tag},
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	}, []assetrepo.Scope{
+		assetrepo.ScopeActive,
+	})
+}
+
+func (s *Service) changeTags(
+	ctx context.Context,
+	req *assetmodel.ManageTagsRequest,
+	action auditmodel.Action,
+	change func(existing, changed []string) []string,
+) ([]string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	defer s.invalidateByID(ctx, req.ID)
+
+	var result []string
+	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		asset, err := s.getInTx(ctx, txRepo, []string{
+			"id", "status", "mux_asset_id", "tags",
+		}, assetSearchOptions{
+			AssetID: req.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if asset.Status == assetmodel.StatusArchived || asset.Status == assetmodel.StatusBroken {
+			return serviceerrors.NewConflictError("cannot change tags of archived or broken asset")
+		}
+
+		result = change(asset.Tags, req.Tags)
+		if len(result) > tags.MaxPerAsset {
+			return serviceerrors.NewValidationFailedError(fmt.Sprintf("an asset may have at most %d tags", tags.MaxPerAsset))
+		}
+		if _, err := txRepo.Update(ctx, map[string]any{"tags": result}, assetrepo.StateOperationOptions{
+			IDs: uuid.UUIDs{asset.ID},
+		}); err != nil {
+			s.log(ctx).Error("failed to update asset tags", zap.Error(err), logging.AssetID(asset.ID))
+			return fmt.Errorf("failed to update asset tags: %w", err)
+		}
+
+		if err := s.recordAudit(ctx, tx, action, asset.ID, tagsSnapshot(asset.Tags), tagsSnapshot(result)); err != nil {
+			return err
+		}
+		asset.Tags = result
+		return s.pushTags(ctx, asset)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// pushTags mirrors the asset tags to the passthrough of the MUX asset. The meta object is sent
+// along, because the MUX API replaces it on every update.
+func (s *Service) pushTags(ctx context.Context, asset *assetmodel.Asset) error {
+	// The asset ID is only known to MUX after the upload finished.
+	if asset.MuxAssetID == nil || *asset.MuxAssetID == "" {
+		return nil
+	}
+	metadata, err := s.getAssetMetadata(ctx, asset.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.apiClient.UpdateAsset(ctx, *asset.MuxAssetID, &muxgo.UpdateAssetRequest{
+		Meta:        muxMeta(metadata),
+		Passthrough: tagsPassthrough(asset.Tags),
+	}); err != nil {
+		s.log(ctx).Error("failed to update mux asset passthrough", zap.Error(err), logging.AssetID(asset.ID))
+		return fmt.Errorf("failed to update mux asset passthrough: %w", err)
+	}
+	return nil
+}
+
+func muxMeta(metadata *metadatamodel.AssetMetadata) muxgo.AssetMetadata {
+	return muxgo.AssetMetadata{
+		Title:      metadata.Title,
+		CreatorId:  metadata.CreatorID,
+		ExternalId: metadata.Key,
+	}
+}
+
+// tagsPassthrough encodes the tags as the passthrough of a MUX asset. Tags that do not fit into the
+// passthrough length limit are left out.
+func tagsPassthrough(assetTags []string) string {
+	var b strings.Builder
+	b.WriteString(passthroughTagsPrefix)
+	for i, tag := range assetTags {
+		sep := ""
+		if i > 0 {
+			sep = ","
+		}
+		if b.Len()+len(sep)+len(tag) > maxPassthroughLength {
+			break
+		}
+		b.WriteString(sep)
+		b.WriteString(tag)
+	}
+	return b.String()
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	muxtypes "github.com/mikhail5545/media-service-go/internal/models/mux/types"
+	muxgo "github.com/muxinc/mux-go/v6"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultStuckAssetAge   = 6 * time.Hour
+	defaultStuckAssetLimit = 100
+)
+
+// reprocessScopes are the assets whose state can be re-fetched from MUX. Archived assets are left as
+// they are.
+var reprocessScopes = []assetrepo.Scope{assetrepo.ScopeActive, assetrepo.ScopeUploadURLGenerated, assetrepo.ScopeBroken}
+
+// CountErrors counts the broken assets and the assets whose upload errored, grouped by status and by
+// the type of the error reported by MUX.
+func (s *Service) CountErrors(ctx context.Context, _ *assetmodel.CountErrorsRequest) ([]*assetmodel.ErrorCount, error) {
+	counts, err := s.repo.CountErrors(ctx)
+	if err != nil {
+		s.log(ctx).Error("failed to count mux asset errors", zap.Error(err))
+		return nil, fmt.Errorf("failed to count mux asset errors: %w", err)
+	}
+	return counts, nil
+}
+
+// ListStuckAssets reports the assets which have been waiting for an upload or for MUX to finish
+// processing for longer than requested. Such assets usually missed a webhook, [Service.ReprocessAsset]
+// catches them up.
+func (s *Service) ListStuckAssets(ctx context.Context, req *assetmodel.ListStuckAssetsRequest) ([]*assetmodel.Asset, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	age := defaultStuckAssetAge
+	if req.OlderThanHours > 0 {
+		age = time.Duration(req.OlderThanHours) * time.Hour
+	}
+	limit := defaultStuckAssetLimit
+	if req.Limit > 0 {
+		limit = req.Limit
+	}
+
+	assets, err := s.repo.ListStuck(ctx, time.Now().Add(-age), limit)
+	if err != nil {
+		s.log(ctx).Error("failed to list stuck mux assets", zap.Error(err))
+		return nil, fmt.Errorf("failed to list stuck mux assets: %w", err)
+	}
+	return assets, nil
+}
+
+// ReprocessAsset re-fetches the state of an asset from the MUX API and applies it as if MUX had sent a
+// webhook for it. Assets still waiting for their upload are looked up through the direct upload.
+// Assets broken by a MUX error become active again once MUX no longer reports the error, assets marked
+// as broken by an admin stay broken.
+func (s *Service) ReprocessAsset(ctx context.Context, req *assetmodel.ReprocessAssetRequest) (*assetmodel.Asset, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	assetID := uuid.MustParse(req.ID)
+
+	asset, err := s.getAsset(ctx, assetID, reprocessScopes, "id", "status", "mux_upload_id", "mux_asset_id", "marked_as_broken_by")
+	if err != nil {
+		return nil, err
+	}
+	muxAssetID, err := s.remoteAssetID(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := s.apiClient.GetAsset(ctx, muxAssetID)
+	if err != nil {
+		s.log(ctx).Warn("failed to retrieve MUX asset for reprocessing", zap.Error(err), logging.AssetID(asset.ID))
+		return nil, fmt.Errorf("failed to retrieve MUX asset: %w", err)
+	}
+
+	// Webhooks only update active assets and do not link the local record to the MUX asset, both are
+	// done before the webhook is applied.
+	updates := map[string]any{}
+	if asset.MuxAssetID == nil {
+		updates["mux_asset_id"] = remote.Id
+	}
+	switch {
+	case asset.Status == assetmodel.StatusUploadURLGenerated:
+		updates["status"] = assetmodel.StatusActive
+	case asset.Status == assetmodel.StatusBroken && asset.MarkedAsBrokenBy == nil && remote.Status != "errored":
+		updates["status"] = assetmodel.StatusActive
+		updates["mux_error"] = nil
+	}
+	if len(updates) > 0 {
+		if _, err := s.repo.Update(ctx, updates, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}); err != nil {
+			s.log(ctx).Error("failed to prepare asset for reprocessing", zap.Error(err), logging.AssetID(asset.ID))
+			return nil, fmt.Errorf("failed to prepare asset for reprocessing: %w", err)
+		}
+	}
+
+	payload, err := reprocessWebhook(asset.ID, remote)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.HandleAssetWebhook(ctx, payload); err != nil {
+		return nil, err
+	}
+	s.log(ctx).Info("reprocessed mux asset", logging.AssetID(asset.ID), zap.String("mux_status", remote.Status))
+	return s.getAsset(ctx, asset.ID, reprocessScopes)
+}
+
+// remoteAssetID returns the ID of the MUX asset of asset. A conflict error is returned while the
+// direct upload has not created an asset yet.
+func (s *Service) remoteAssetID(ctx context.Context, asset *assetmodel.Asset) (string, error) {
+	if asset.MuxAssetID != nil && *asset.MuxAssetID != "" {
+		return *asset.MuxAssetID, nil
+	}
+	if asset.MuxUploadID == nil {
+		return "", serviceerrors.NewConflictError("asset has neither a MUX asset nor a MUX upload")
+	}
+	upload, err := s.apiClient.GetDirectUpload(ctx, *asset.MuxUploadID)
+	if err != nil {
+		s.log(ctx).Warn("failed to retrieve MUX upload for reprocessing", zap.Error(err), logging.AssetID(asset.ID))
+		return "", fmt.Errorf("failed to retrieve MUX upload: %w", err)
+	}
+	if upload.AssetId == "" {
+		return "", serviceerrors.NewConflictError(fmt.Sprintf("MUX upload is %s and has not created an asset", upload.Status))
+	}
+	return upload.AssetId, nil
+}
+
+// reprocessWebhook builds the webhook MUX would send for the current state of the remote asset.
+func reprocessWebhook(assetID uuid.UUID, remote *muxgo.Asset) (*muxtypes.MuxWebhook, error) {
+	raw, err := json.Marshal(remote)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode MUX asset: %w", err)
+	}
+	payload := &muxtypes.MuxWebhook{CreatedAt: time.Now()}
+	if err := json.Unmarshal(raw, &payload.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode MUX asset: %w", err)
+	}
+
+	externalID := assetID.String()
+	if payload.Data.Meta == nil {
+		payload.Data.Meta = &muxtypes.MuxWebhookMeta{}
+	}
+	payload.Data.Meta.ExternalID = &externalID
+	if payload.Data.Errors != nil && payload.Data.Errors.Type == "" {
+		payload.Data.Errors = nil
+	}
+	// The asset object of the API has no progress, the state is derived from the status.
+	switch remote.Status {
+	case "ready":
+		payload.Type = "video.asset.ready"
+		payload.Data.Progress.State = string(assetmodel.StateCompleted)
+	case "errored":
+		payload.Type = "video.asset.errored"
+		payload.Data.Progress.State = string(assetmodel.StateErrored)
+	default:
+		payload.Type = "video.asset.updated"
+		payload.Data.Progress.State = string(assetmodel.StateTranscoding)
+	}
+	return payload, nil
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	uploadproxymodel "github.com/mikhail5545/media-service-go/internal/models/uploadproxy"
+)
+
+// CreateUploadTarget creates a new asset with a MUX Direct Upload URL the upload proxy relays
+// the file to. The rest of the asset information is populated via incoming MUX webhooks, as for
+// uploads made by the clients directly.
+func (s *Service) CreateUploadTarget(ctx context.Context, req *uploadproxymodel.CreateRequest) (*uploadproxymodel.Target, error) {
+	uploadReq := &assetmodel.CreateUploadURLRequest{
+		Title:     req.Title,
+		AdminID:   req.AdminID,
+		AdminName: req.AdminName,
+	}
+	if err := uploadReq.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	resp, assetID, err := s.createUploadURL(ctx, uploadReq)
+	if err != nil {
+		return nil, err
+	}
+	return &uploadproxymodel.Target{AssetID: assetID, UploadURL: resp.Data.Url}, nil
+}
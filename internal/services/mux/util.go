@@ -64,6 +64,17 @@ func buildAssetUpdatesFromWebhook(existing *assetmodel.Asset, data *muxtypes.Mux
 	patch.UpdateIfChanged(updates, "aspect_ratio", data.AspectRatio, existing.AspectRatio)
 	patch.UpdateIfChanged(updates, "ingest_type", data.IngestType, memory.MakePtr(string(existing.IngestType)))
 
+	duration, aspectRatio := existing.Duration, existing.AspectRatio
+	if data.Duration != nil {
+		duration = data.Duration
+	}
+	if data.AspectRatio != nil {
+		aspectRatio = data.AspectRatio
+	}
+	if duration != nil && aspectRatio != nil {
+		patch.UpdateIfChanged(updates, "fingerprint", memory.MakePtr(assetmodel.Fingerprint(*aspectRatio, *duration)), existing.Fingerprint)
+	}
+
 	if len(data.PlaybackIDs) > 0 && !reflect.DeepEqual(data.PlaybackIDs, existing.MuxPlaybackIDs) {
 		updates["mux_playback_ids"] = data.PlaybackIDs
 	}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	versionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/versions"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	versionmodel "github.com/mikhail5545/media-service-go/internal/models/versions"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ListVersions retrieves the version chain of an owner, oldest first. A version is recorded each time
+// the owner is moved to another asset with ReplaceExisting or rolled back to an earlier version.
+func (s *Service) ListVersions(ctx context.Context, req *assetmodel.ListVersionsRequest) ([]*versionmodel.Version, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	owner, err := versionOwner(req.OwnerType, req.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.versionRepo.List(ctx, owner)
+	if err != nil {
+		s.log(ctx).Error("failed to list asset versions", zap.Error(err), zap.String("owner_id", req.OwnerID), zap.String("owner_type", req.OwnerType))
+		return nil, fmt.Errorf("failed to list asset versions: %w", err)
+	}
+	return versions, nil
+}
+
+// RollbackVersion moves the owner back to the asset of an earlier version. The owner is removed from
+// its current asset and added to the asset of the version by the same saga as [Service.AssociateOwner],
+// and the rollback is recorded as a new version. The asset of the version must not be archived.
+func (s *Service) RollbackVersion(ctx context.Context, req *assetmodel.RollbackVersionRequest) (*assetmodel.AssociateResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	owner, err := versionOwner(req.OwnerType, req.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.versionRepo.Get(ctx, owner, req.Number)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, serviceerrors.NewNotFoundError(err)
+		}
+		s.log(ctx).Error("failed to get asset version", zap.Error(err), zap.String("owner_id", req.OwnerID), zap.Int("number", req.Number))
+		return nil, fmt.Errorf("failed to get asset version: %w", err)
+	}
+	if version.Current() {
+		return nil, serviceerrors.NewConflictError("version is already current")
+	}
+
+	return s.replaceOwner(ctx, &assetmodel.ManageOwnerRequest{
+		ID:              version.AssetID.String(),
+		OwnerID:         req.OwnerID,
+		OwnerType:       req.OwnerType,
+		ReplaceExisting: true,
+		ArchiveReplaced: req.ArchiveCurrent,
+		AdminID:         req.AdminID,
+		AdminName:       req.AdminName,
+	}, versionmodel.KindRollback)
+}
+
+// recordVersion appends the asset to the version chain of the owner in the transaction. When the
+// owner is replaced for the first time, the replaced asset is recorded as the initial version.
+// Associating the owner with the asset of the current version again does not create a version.
+func (s *Service) recordVersion(
+	ctx context.Context,
+	tx *gorm.DB,
+	req *assetmodel.ManageOwnerRequest,
+	kind versionmodel.Kind,
+	assetID uuid.UUID,
+	replacedIDs []uuid.UUID,
+) error {
+	owner, err := versionOwner(req.OwnerType, req.OwnerID)
+	if err != nil {
+		return err
+	}
+	txRepo := s.versionRepo.WithTx(tx)
+
+	latest, err := txRepo.LatestForUpdate(ctx, owner)
+	if err != nil {
+		s.log(ctx).Error("failed to get latest asset version", zap.Error(err), zap.String("owner_id", req.OwnerID))
+		return fmt.Errorf("failed to get latest asset version: %w", err)
+	}
+	if latest != nil && latest.AssetID == assetID {
+		return nil
+	}
+
+	now := time.Now()
+	version := &versionmodel.Version{
+		Provider:  owner.Provider,
+		OwnerType: owner.OwnerType,
+		OwnerID:   owner.OwnerID,
+		Number:    1,
+		Kind:      kind,
+		AssetID:   assetID,
+	}
+	if adminID, err := uuid.Parse(req.AdminID); err == nil {
+		version.AdminID = &adminID
+	}
+	if req.AdminName != "" {
+		version.AdminName = &req.AdminName
+	}
+
+	var versions []*versionmodel.Version
+	switch {
+	case latest != nil:
+		version.Number = latest.Number + 1
+		version.PredecessorID = &latest.AssetID
+		if len(replacedIDs) > 0 {
+			version.PredecessorID = &replacedIDs[0]
+		}
+		if err := txRepo.Supersede(ctx, latest.ID, assetID, now); err != nil {
+			s.log(ctx).Error("failed to supersede asset version", zap.Error(err), zap.String("version_id", latest.ID.String()))
+			return fmt.Errorf("failed to supersede asset version: %w", err)
+		}
+	case len(replacedIDs) > 0:
+		initial := &versionmodel.Version{
+			CreatedAt:    now,
+			Provider:     owner.Provider,
+			OwnerType:    owner.OwnerType,
+			OwnerID:      owner.OwnerID,
+			Number:       1,
+			Kind:         versionmodel.KindInitial,
+			AssetID:      replacedIDs[0],
+			SuccessorID:  &assetID,
+			SupersededAt: &now,
+		}
+		versions = append(versions, initial)
+		version.Number = 2
+		version.PredecessorID = &initial.AssetID
+	}
+	versions = append(versions, version)
+
+	if err := txRepo.Create(ctx, versions...); err != nil {
+		s.log(ctx).Error("failed to record asset version", zap.Error(err), zap.String("owner_id", req.OwnerID))
+		return fmt.Errorf("failed to record asset version: %w", err)
+	}
+	return nil
+}
+
+func versionOwner(ownerType, ownerID string) (versionrepo.Owner, error) {
+	id, err := parsing.StrToUUID(ownerID)
+	if err != nil {
+		return versionrepo.Owner{}, err
+	}
+	return versionrepo.Owner{Provider: versionmodel.ProviderMux, OwnerType: ownerType, OwnerID: id}, nil
+}
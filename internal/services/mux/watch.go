@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+)
+
+// watchPollInterval is the interval the status of a watched asset is reloaded at, so the changes
+// processed by other instances are sent too.
+const watchPollInterval = 5 * time.Second
+
+// WatchAsset sends the status of the asset with send, first the current one and then every change,
+// until the asset is ready, errored or deleted, the watch times out or ctx is done. The changes are
+// sent as the webhooks are processed. A timed out watch returns nil, the last update is not final then.
+//
+// It returns an error if the asset is not found or send fails.
+func (s *Service) WatchAsset(ctx context.Context, req *assetmodel.WatchAssetRequest, send func(*assetmodel.StatusUpdate) error) error {
+	if err := req.Validate(); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	assetID, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return err
+	}
+
+	// Subscribed before the current status is loaded, so no change is missed in between.
+	var changes <-chan *events.Event
+	if s.watches != nil {
+		sub := s.watches.Subscribe(assetID)
+		defer sub.Close()
+		changes = sub.C
+	}
+
+	update, err := s.statusUpdate(ctx, assetID, nil)
+	if err != nil {
+		return err
+	}
+	if err := send(update); err != nil {
+		return err
+	}
+
+	timeout := time.NewTimer(req.Timeout())
+	defer timeout.Stop()
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	for !update.Final {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return nil
+		case <-changes:
+		case <-ticker.C:
+		}
+		next, err := s.statusUpdate(ctx, assetID, update)
+		if err != nil {
+			return err
+		}
+		if next.Status == update.Status && next.UploadStatus == update.UploadStatus && !next.Final {
+			continue
+		}
+		update = next
+		if err := send(update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statusUpdate loads the status of the asset. An asset which is no longer found after a previous
+// update was deleted, which ends the watch.
+func (s *Service) statusUpdate(ctx context.Context, id uuid.UUID, previous *assetmodel.StatusUpdate) (*assetmodel.StatusUpdate, error) {
+	asset, err := s.getAsset(ctx, id, []assetrepo.Scope{assetrepo.ScopeAll}, "id", "status", "upload_status", "updated_at")
+	if err != nil {
+		if previous != nil && errors.Is(err, serviceerrors.ErrNotFound) {
+			return &assetmodel.StatusUpdate{
+				AssetID:      id,
+				Status:       assetmodel.StatusPendingDelete,
+				UploadStatus: assetmodel.UploadStatusDeleted,
+				Final:        true,
+				UpdatedAt:    time.Now().UTC(),
+			}, nil
+		}
+		return nil, err
+	}
+	return &assetmodel.StatusUpdate{
+		AssetID:      asset.ID,
+		Status:       asset.Status,
+		UploadStatus: asset.UploadStatus,
+		Final:        assetmodel.IsFinal(asset.Status, asset.UploadStatus),
+		UpdatedAt:    asset.UpdatedAt,
+	}, nil
+}
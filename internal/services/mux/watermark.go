@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mux
+
+import (
+	"context"
+	"fmt"
+
+	watermarkmodel "github.com/mikhail5545/media-service-go/internal/models/watermark"
+	muxgo "github.com/muxinc/mux-go/v6"
+)
+
+// overlayAlignments maps the watermark positions to the MUX vertical and horizontal alignments.
+var overlayAlignments = map[watermarkmodel.Position][2]string{
+	watermarkmodel.PositionTopLeft:     {"top", "left"},
+	watermarkmodel.PositionTopRight:    {"top", "right"},
+	watermarkmodel.PositionBottomLeft:  {"bottom", "left"},
+	watermarkmodel.PositionBottomRight: {"bottom", "right"},
+	watermarkmodel.PositionCenter:      {"middle", "center"},
+}
+
+// watermarkOverlays returns the overlay inputs burning the watermark of the creator, or of the owner
+// type, into a new video. It returns nil if no video watermark is configured.
+func (s *Service) watermarkOverlays(ctx context.Context, creatorID, ownerType string) ([]muxgo.InputSettings, error) {
+	if s.watermarks == nil {
+		return nil, nil
+	}
+	var ownerTypes []string
+	if ownerType != "" {
+		ownerTypes = []string{ownerType}
+	}
+	watermark, err := s.watermarks.Resolve(ctx, creatorID, ownerTypes)
+	if err != nil {
+		return nil, err
+	}
+	if watermark == nil || watermark.ImageURL == nil {
+		return nil, nil
+	}
+	alignment := overlayAlignments[watermark.Position]
+	settings := muxgo.InputSettingsOverlaySettings{
+		VerticalAlign:   alignment[0],
+		HorizontalAlign: alignment[1],
+		Opacity:         fmt.Sprintf("%d%%", watermark.Opacity),
+	}
+	if watermark.Width > 0 {
+		settings.Width = fmt.Sprintf("%d%%", watermark.Width)
+	}
+	if watermark.Position != watermarkmodel.PositionCenter && watermark.Margin > 0 {
+		settings.VerticalMargin = fmt.Sprintf("%d%%", watermark.Margin)
+		settings.HorizontalMargin = fmt.Sprintf("%d%%", watermark.Margin)
+	}
+	return []muxgo.InputSettings{{Url: *watermark.ImageURL, OverlaySettings: settings}}, nil
+}
@@ -19,16 +19,61 @@ package mux
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/audit"
+	"github.com/mikhail5545/media-service-go/internal/database/crossstore"
 	assetrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/mux/asset"
+	"github.com/mikhail5545/media-service-go/internal/environment"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	auditmodel "github.com/mikhail5545/media-service-go/internal/models/audit"
 	assetmodel "github.com/mikhail5545/media-service-go/internal/models/mux/asset"
+	metadatamodel "github.com/mikhail5545/media-service-go/internal/models/mux/metadata"
 	muxtypes "github.com/mikhail5545/media-service-go/internal/models/mux/types"
+	webhookmodel "github.com/mikhail5545/media-service-go/internal/models/webhook"
+	"github.com/mikhail5545/media-service-go/internal/services/webhook"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+// VerifyWebhook verifies the Mux-Signature header against the raw webhook payload.
+// Verification is skipped when no webhook secret is configured.
+func (s *Service) VerifyWebhook(ctx context.Context, payload []byte, signature string) error {
+	if err := s.apiClient.VerifyWebhookSignature(payload, signature); err != nil {
+		s.log(ctx).Warn("received webhook with invalid signature", zap.Error(err))
+		return serviceerrors.NewPermissionDeniedError("invalid signature")
+	}
+	return nil
+}
+
+// WebhookHandlers returns the handler processing the MUX webhooks queued by the webhook endpoint.
+// Each webhook is processed in the environment of the MUX environment it was sent from.
+func (s *Service) WebhookHandlers() map[webhookmodel.Provider]webhook.Handler {
+	return map[webhookmodel.Provider]webhook.Handler{
+		webhookmodel.ProviderMux: func(ctx context.Context, payload []byte) error {
+			var data muxtypes.MuxWebhook
+			if err := json.Unmarshal(payload, &data); err != nil {
+				return serviceerrors.NewValidationFailedError(err)
+			}
+			return s.HandleAssetWebhook(environment.WithContext(ctx, s.webhookEnvironment(&data)), &data)
+		},
+	}
+}
+
+// webhookEnvironment returns the environment name of the MUX environment the webhook was sent from,
+// or [environment.Default] if it is not mapped.
+func (s *Service) webhookEnvironment(payload *muxtypes.MuxWebhook) string {
+	if name, ok := s.environments[payload.Environment.ID]; ok {
+		return name
+	}
+	return environment.Default
+}
+
 // HandleAssetWebhook processes incoming MUX asset webhooks based on their type.
 // It routes the webhook to the appropriate handler function.
 // It does not return any error, as we want to avoid retrying the webhook processing in case of failure.
@@ -41,7 +86,7 @@ func (s *Service) HandleAssetWebhook(ctx context.Context, payload *muxtypes.MuxW
 	case "video.asset.deleted":
 		return s.handleAssetDeletedWebhook(ctx, payload)
 	default:
-		s.logger.Warn(
+		s.log(ctx).Warn(
 			"received unsupported webhook type",
 			zap.String("type", payload.Type),
 			zap.String("event_id", payload.ID),
@@ -56,54 +101,74 @@ func (s *Service) HandleAssetWebhook(ctx context.Context, payload *muxtypes.MuxW
 // It does not return any error, as we want to avoid retrying the webhook processing in case of failure.
 // This includes 'video.asset.created', 'video.asset.ready', and 'video.asset.updated' types.
 func (s *Service) handleDataRichWebhook(ctx context.Context, payload *muxtypes.MuxWebhook) error {
-	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+	var updatedID uuid.UUID
+	err := crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
 		asset := s.getAssetFromWebhook(ctx, txRepo, payload)
 		if asset == nil {
 			return nil
 		}
+		if s.staleWebhook(ctx, asset, payload) {
+			return nil
+		}
+		updatedID = asset.ID
 
 		updates := buildAssetUpdatesFromWebhook(asset, &payload.Data)
+		setLastWebhookAt(updates, payload)
 		// explicitly extract playback IDs hot paths
 		if err := extractPlaybackIDs(updates, &payload.Data); err != nil {
-			s.logger.Warn(
+			s.log(ctx).Warn(
 				"failed to extract playback IDs from webhook",
 				zap.Error(err),
-				zap.String("asset_id", asset.ID.String()),
+				logging.AssetID(asset.ID),
 				zap.String("event_id", payload.ID),
 			)
 			return nil
 		}
 		if len(updates) > 0 {
 			if _, err := txRepo.Update(ctx, updates, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}); err != nil {
-				s.logger.Warn(
+				s.log(ctx).Warn(
 					"failed to update asset from webhook",
 					zap.Error(err),
-					zap.String("asset_id", asset.ID.String()),
+					logging.AssetID(asset.ID),
 					zap.String("event_id", payload.ID),
 				)
 				return nil
 			}
 		}
+		if stateChanged(updates) {
+			if err := s.recordAudit(ctx, tx, auditmodel.ActionStateChanged, asset.ID,
+				stateSnapshot(asset, nil), stateSnapshot(asset, updates),
+				audit.WithEvent(payload.Type, payload.ID),
+			); err != nil {
+				return err
+			}
+		}
 
 		if err := s.updateMetadataFromWebhook(ctx, asset.ID, &payload.Data); err != nil {
-			s.logger.Warn(
+			s.log(ctx).Warn(
 				"failed to update asset metadata from webhook",
 				zap.Error(err),
-				zap.String("asset_id", asset.ID.String()),
+				logging.AssetID(asset.ID),
 				zap.String("event_id", payload.ID),
 			)
 			return nil
 		}
+		if payload.Type == "video.asset.ready" {
+			// The enrichment of the video is enqueued by the outbox subscriber of the event.
+			return s.publishEventInTx(ctx, tx, events.TypeAssetReady, asset.ID, withExternalID(&payload.Data.ID))
+		}
 		return nil
 	})
+	s.invalidate(ctx, updatedID)
+	return err
 }
 
 // handleAssetErroredWebhook processes 'video.asset.errored' type webhooks specifically.
 // It does not return any error, as we want to avoid retrying the webhook processing in case of failure.
 func (s *Service) handleAssetErroredWebhook(ctx context.Context, payload *muxtypes.MuxWebhook) error {
-	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+	return crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
 		asset := s.getAssetFromWebhook(ctx, txRepo, payload)
@@ -111,24 +176,38 @@ func (s *Service) handleAssetErroredWebhook(ctx context.Context, payload *muxtyp
 			// getAssetFromWebhook already logs the missing asset case
 			return nil
 		}
+		if asset.Status == assetmodel.StatusArchived || s.staleWebhook(ctx, asset, payload) {
+			return nil
+		}
 		// In case of errored webhook, we only update the status to 'errored'.
 		updates := map[string]any{
 			"upload_status": "errored",
 			"status":        assetmodel.StatusBroken,
 		}
+		setLastWebhookAt(updates, payload)
 		if payload.Data.Errors != nil {
 			updates["mux_error"] = payload.Data.Errors
 		}
 		if _, err := txRepo.Update(ctx, updates, assetrepo.StateOperationOptions{IDs: uuid.UUIDs{asset.ID}}); err != nil {
-			s.logger.Warn(
+			s.log(ctx).Warn(
 				"failed to update asset to errored from webhook",
 				zap.Error(err),
-				zap.String("asset_id", asset.ID.String()),
+				logging.AssetID(asset.ID),
 				zap.String("event_id", payload.ID),
 			)
 			return nil
 		}
-		return nil
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionStateChanged, asset.ID,
+			stateSnapshot(asset, nil), stateSnapshot(asset, updates),
+			audit.WithEvent(payload.Type, payload.ID),
+		); err != nil {
+			return err
+		}
+		opts := []events.Option{withExternalID(&payload.Data.ID)}
+		if payload.Data.Errors != nil {
+			opts = append(opts, events.WithData("error_type", payload.Data.Errors.Type))
+		}
+		return s.publishEventInTx(ctx, tx, events.TypeAssetErrored, asset.ID, opts...)
 	})
 }
 
@@ -136,8 +215,7 @@ func (s *Service) handleAssetErroredWebhook(ctx context.Context, payload *muxtyp
 // It archives the asset locally if it was not already archived.
 // It does not return any error, as we want to avoid retrying the webhook processing in case of failure.
 func (s *Service) handleAssetDeletedWebhook(ctx context.Context, payload *muxtypes.MuxWebhook) error {
-	var assetIDtoDelete *uuid.UUID
-	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+	return crossstore.Transaction(ctx, s.repo.DB(), func(ctx context.Context, tx *gorm.DB) error {
 		txRepo := s.repo.WithTx(tx)
 
 		asset := s.getAssetFromWebhook(ctx, txRepo, payload)
@@ -153,22 +231,80 @@ func (s *Service) handleAssetDeletedWebhook(ctx context.Context, payload *muxtyp
 			return nil
 		}
 		if err := s.archiveAssetOnWebhook(ctx, txRepo, asset, payload.ID); err != nil {
-			return nil
+			return err
 		}
-		assetIDtoDelete = &asset.ID
-		return nil
+		if err := s.recordAudit(ctx, tx, auditmodel.ActionArchive, asset.ID,
+			statusSnapshot(asset.Status), statusSnapshot(assetmodel.StatusArchived),
+			audit.WithEvent(payload.Type, payload.ID),
+		); err != nil {
+			return err
+		}
+
+		// An asset without metadata has no owners to notify, any other error must redeliver the webhook.
+		var owners []*metadatamodel.Owner
+		metadata, err := s.getAssetMetadata(ctx, asset.ID)
+		switch {
+		case err == nil:
+			owners = metadata.Owners
+		case !errors.Is(err, serviceerrors.ErrNotFound):
+			return err
+		}
+		if err := s.snapshotOwners(ctx, txRepo, asset.ID, owners, assetmodel.SnapshotReasonDeleted); err != nil {
+			return err
+		}
+		// Staged before the event is published, so the metadata is deleted before the cache is invalidated.
+		_ = crossstore.AfterCommit(ctx, func(ctx context.Context) error {
+			if err := s.deleteMetadataOnWebhook(ctx, asset.ID, payload); err != nil {
+				s.log(ctx).Warn(
+					"failed to delete asset metadata on deleted webhook",
+					zap.Error(err),
+					logging.AssetID(asset.ID),
+					zap.String("event_id", payload.ID),
+				)
+			}
+			return nil
+		})
+		// Owners must be notified about the deletion, which is enqueued in the transactional outbox by
+		// the outbox subscriber of the event.
+		return s.publishEventInTx(ctx, tx, events.TypeAssetDeleted, asset.ID,
+			withExternalID(&payload.Data.ID), withOwners(owners), events.WithData("permanent", "false"),
+		)
 	})
-	if err == nil && assetIDtoDelete != nil {
-		if err := s.deleteMetadataOnWebhook(ctx, *assetIDtoDelete, payload); err != nil {
-			s.logger.Warn(
-				"failed to delete asset metadata on deleted webhook",
-				zap.Error(err),
-				zap.String("asset_id", assetIDtoDelete.String()),
-				zap.String("event_id", payload.ID),
-			)
+}
+
+// staleWebhook reports whether a webhook created after this one was already applied to the asset.
+// MUX does not deliver the webhooks in order, a late 'video.asset.created' must not overwrite the
+// state of a ready asset.
+func (s *Service) staleWebhook(ctx context.Context, asset *assetmodel.Asset, payload *muxtypes.MuxWebhook) bool {
+	if asset.LastWebhookAt == nil || payload.CreatedAt.IsZero() || !payload.CreatedAt.Before(*asset.LastWebhookAt) {
+		return false
+	}
+	s.log(ctx).Info(
+		"ignoring webhook older than the last applied one",
+		logging.AssetID(asset.ID),
+		zap.String("event_id", payload.ID),
+		zap.String("type", payload.Type),
+		zap.Time("created_at", payload.CreatedAt),
+		zap.Time("last_webhook_at", *asset.LastWebhookAt),
+	)
+	return true
+}
+
+// setLastWebhookAt records the creation time of the applied webhook, see [Service.staleWebhook].
+func setLastWebhookAt(updates map[string]any, payload *muxtypes.MuxWebhook) {
+	if !payload.CreatedAt.IsZero() {
+		updates["last_webhook_at"] = payload.CreatedAt
+	}
+}
+
+// stateChanged reports whether the webhook updates change any of the asset state fields.
+func stateChanged(updates map[string]any) bool {
+	for _, key := range []string{"status", "state", "upload_status"} {
+		if _, ok := updates[key]; ok {
+			return true
 		}
 	}
-	return err
+	return false
 }
 
 func extractPlaybackIDs(updates map[string]any, data *muxtypes.MuxWebhookData) error {
@@ -185,6 +321,10 @@ func extractPlaybackIDs(updates map[string]any, data *muxtypes.MuxWebhookData) e
 			if _, exists := updates["primary_signed_playback_id"]; !exists {
 				updates["primary_signed_playback_id"] = id.ID
 			}
+		case "drm":
+			if _, exists := updates["primary_drm_playback_id"]; !exists {
+				updates["primary_drm_playback_id"] = id.ID
+			}
 		default:
 			return fmt.Errorf("encountered unknown playback ID policy: %s", id.Policy)
 		}
@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package outbox implements the transactional outbox dispatcher. It delivers events persisted by the asset
+// services to external services asynchronously, retrying failed deliveries with exponential backoff.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	outboxrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/outbox"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	outboxmodel "github.com/mikhail5545/media-service-go/internal/models/outbox"
+	"go.uber.org/zap"
+)
+
+// Handler delivers a single outbox event with the provided JSON encoded payload.
+type Handler func(ctx context.Context, payload []byte) error
+
+// HandlerProvider is implemented by services that produce outbox events.
+type HandlerProvider interface {
+	// OutboxHandlers returns delivery handlers for all outbox event types produced by the service.
+	OutboxHandlers() map[outboxmodel.EventType]Handler
+}
+
+// Config holds outbox dispatcher configuration.
+type Config struct {
+	// PollInterval is the time between two consecutive polls for due events.
+	PollInterval time.Duration
+	// BatchSize limits the number of events claimed in a single poll.
+	BatchSize int
+	// MaxAttempts is the number of delivery attempts after which the event is marked as failed.
+	MaxAttempts int
+	// BaseBackoff is the delay before the first retry. Each next retry doubles the delay.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+	// DeliveryTimeout limits the duration of a single delivery attempt.
+	DeliveryTimeout time.Duration
+}
+
+// DefaultConfig returns the default outbox dispatcher configuration.
+func DefaultConfig() Config {
+	return Config{
+		PollInterval:    time.Second,
+		BatchSize:       50,
+		MaxAttempts:     10,
+		BaseBackoff:     time.Second,
+		MaxBackoff:      10 * time.Minute,
+		DeliveryTimeout: 10 * time.Second,
+	}
+}
+
+// Dispatcher claims due outbox events and delivers them using the registered handlers.
+type Dispatcher struct {
+	cfg      Config
+	repo     *outboxrepo.Repository
+	handlers map[outboxmodel.EventType]Handler
+	logger   *zap.Logger
+}
+
+type NewParams struct {
+	Config    Config
+	Repo      *outboxrepo.Repository
+	Providers []HandlerProvider
+}
+
+func New(params *NewParams, logger *zap.Logger) (*Dispatcher, error) {
+	handlers := make(map[outboxmodel.EventType]Handler)
+	for _, provider := range params.Providers {
+		for eventType, handler := range provider.OutboxHandlers() {
+			if _, exists := handlers[eventType]; exists {
+				return nil, fmt.Errorf("duplicate outbox handler for event type %q", eventType)
+			}
+			handlers[eventType] = handler
+		}
+	}
+	return &Dispatcher{
+		cfg:      params.Config,
+		repo:     params.Repo,
+		handlers: handlers,
+		logger:   logger.With(zap.String("layer", "worker"), zap.String("worker", "outbox")),
+	}, nil
+}
+
+// Run starts the dispatcher loop. It blocks until the provided context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	d.logger.Info("outbox dispatcher started", zap.Duration("poll_interval", d.cfg.PollInterval))
+
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Info("outbox dispatcher stopped")
+			return
+		case <-ticker.C:
+			if err := d.DispatchOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				d.logger.Error("failed to dispatch outbox events", zap.Error(err))
+			}
+		}
+	}
+}
+
+// DispatchOnce claims one batch of due events and attempts to deliver them.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) error {
+	_, err := d.dispatchBatch(ctx)
+	return err
+}
+
+// Flush dispatches batches until no more events are due or ctx is done. It is used on shutdown to
+// deliver the events enqueued by the last in-flight requests.
+func (d *Dispatcher) Flush(ctx context.Context) error {
+	total := 0
+	for {
+		n, err := d.dispatchBatch(ctx)
+		total += n
+		if err != nil {
+			return err
+		}
+		if n < d.cfg.BatchSize {
+			d.logger.Info("outbox flushed", zap.Int("events", total))
+			return nil
+		}
+	}
+}
+
+// dispatchBatch claims one batch of due events, delivers them and returns the number of claimed events.
+func (d *Dispatcher) dispatchBatch(ctx context.Context) (int, error) {
+	// Lease claimed events for the whole batch duration, so they are not picked up by another
+	// dispatcher instance while this one is still delivering them.
+	lease := time.Duration(d.cfg.BatchSize) * d.cfg.DeliveryTimeout
+	events, err := d.repo.ClaimDue(ctx, d.cfg.BatchSize, lease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	for _, event := range events {
+		if ctx.Err() != nil {
+			return len(events), ctx.Err()
+		}
+		d.deliver(ctx, event)
+	}
+	return len(events), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, event *outboxmodel.Event) {
+	logger := d.logger.With(
+		zap.String("event_id", event.ID.String()),
+		zap.String("event_type", string(event.Type)),
+		zap.Int("attempt", event.Attempts+1),
+	)
+
+	handler, ok := d.handlers[event.Type]
+	if !ok {
+		logger.Error("no outbox handler registered for event type")
+		d.markFailed(ctx, logger, event, "no handler registered for event type")
+		return
+	}
+
+	deliveryCtx, cancel := context.WithTimeout(ctx, d.cfg.DeliveryTimeout)
+	err := handler(deliveryCtx, event.Payload)
+	cancel()
+
+	switch {
+	case err == nil, errors.Is(err, serviceerrors.ErrNotFound):
+		// Remote resource is already gone, nothing to notify about
+		if err := d.repo.MarkDelivered(ctx, event.ID); err != nil {
+			logger.Error("failed to mark outbox event as delivered", zap.Error(err))
+		}
+	case isPermanent(err) || event.Attempts+1 >= d.cfg.MaxAttempts:
+		logger.Error("outbox event delivery failed permanently", zap.Error(err))
+		d.markFailed(ctx, logger, event, err.Error())
+	default:
+		next := time.Now().Add(d.backoff(event.Attempts + 1))
+		logger.Warn("outbox event delivery failed, scheduling retry", zap.Error(err), zap.Time("next_attempt_at", next))
+		if err := d.repo.MarkRetry(ctx, event.ID, next, err.Error()); err != nil {
+			logger.Error("failed to schedule outbox event retry", zap.Error(err))
+		}
+	}
+}
+
+func (d *Dispatcher) markFailed(ctx context.Context, logger *zap.Logger, event *outboxmodel.Event, reason string) {
+	if err := d.repo.MarkFailed(ctx, event.ID, reason); err != nil {
+		logger.Error("failed to mark outbox event as failed", zap.Error(err))
+	}
+}
+
+// backoff returns the exponential delay before the specified attempt, capped by MaxBackoff.
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := d.cfg.BaseBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= d.cfg.MaxBackoff {
+			return d.cfg.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// isPermanent reports whether the delivery error cannot be fixed by retrying.
+func isPermanent(err error) bool {
+	return errors.Is(err, serviceerrors.ErrValidationFailed) ||
+		errors.Is(err, serviceerrors.ErrInvalidArgument) ||
+		errors.Is(err, serviceerrors.ErrPermissionDenied)
+}
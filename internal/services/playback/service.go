@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package playback provides the playback session service, which records the issued playback tokens,
+// limits the concurrent playback sessions of users and lets other services check that a token is still allowed.
+package playback
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	playbackrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/playback"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	playbackmodel "github.com/mikhail5545/media-service-go/internal/models/playback"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SessionService defines the interface for managing playback sessions.
+type SessionService interface {
+	// Issue records the tokens of the grant. It fails with a conflict if the grant opens a new session
+	// and the user already has the maximum number of concurrent sessions.
+	Issue(ctx context.Context, grant *playbackmodel.Grant) error
+	// Revoke revokes the active tokens of a user or of one of the user's sessions.
+	Revoke(ctx context.Context, req *playbackmodel.RevokeRequest) (*playbackmodel.RevokeResult, error)
+	// Validate checks that a token was issued by the service and is neither expired nor revoked.
+	Validate(ctx context.Context, req *playbackmodel.ValidateRequest) (*playbackmodel.Validation, error)
+}
+
+// Service implements the SessionService interface.
+type Service struct {
+	repo *playbackrepo.Repository
+	// maxPerUser limits the concurrent sessions of a user, 0 means unlimited.
+	maxPerUser int
+	logger     *zap.Logger
+}
+
+var _ SessionService = (*Service)(nil)
+
+const (
+	// sweepInterval is the interval expired tokens are deleted at.
+	sweepInterval = time.Hour
+	// sweepBatchSize limits the number of expired tokens deleted at once.
+	sweepBatchSize = 1000
+)
+
+type NewParams struct {
+	Repo *playbackrepo.Repository
+	// MaxSessionsPerUser limits the concurrent sessions of a user, 0 means unlimited.
+	MaxSessionsPerUser int
+}
+
+func New(params *NewParams, logger *zap.Logger) *Service {
+	return &Service{
+		repo:       params.Repo,
+		maxPerUser: params.MaxSessionsPerUser,
+		logger:     logger.With(zap.String("layer", "service"), zap.String("service", "playback")),
+	}
+}
+
+// log returns the service logger enriched with the request-scoped fields stored in ctx.
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// Issue records the tokens of the grant. It fails with a conflict if the grant opens a new session
+// and the user already has the maximum number of concurrent sessions.
+func (s *Service) Issue(ctx context.Context, grant *playbackmodel.Grant) error {
+	now := time.Now()
+	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		if s.maxPerUser > 0 {
+			if err := txRepo.LockUser(ctx, grant.UserID); err != nil {
+				s.log(ctx).Error("failed to lock playback sessions of user", zap.Error(err), zap.String("user_id", grant.UserID.String()))
+				return fmt.Errorf("failed to lock playback sessions of user: %w", err)
+			}
+			// Tokens of an already active session do not open a new one.
+			active, err := txRepo.CountActiveSessions(ctx, grant.UserID, grant.SessionID, now)
+			if err != nil {
+				s.log(ctx).Error("failed to count active playback sessions", zap.Error(err), zap.String("user_id", grant.UserID.String()))
+				return fmt.Errorf("failed to count active playback sessions: %w", err)
+			}
+			if active >= int64(s.maxPerUser) {
+				return serviceerrors.NewConflictError(fmt.Sprintf("user has reached the limit of %d concurrent playback sessions", s.maxPerUser))
+			}
+		}
+
+		sessions := make([]*playbackmodel.Session, len(grant.Tokens))
+		for i, token := range grant.Tokens {
+			sessions[i] = &playbackmodel.Session{
+				AssetID:   grant.AssetID,
+				UserID:    grant.UserID,
+				SessionID: grant.SessionID,
+				UserAgent: grant.UserAgent,
+				TokenHash: hashToken(token),
+				ExpiresAt: grant.ExpiresAt,
+			}
+		}
+		if err := txRepo.Create(ctx, sessions); err != nil {
+			s.log(ctx).Error("failed to record playback tokens", zap.Error(err), logging.AssetID(grant.AssetID))
+			return fmt.Errorf("failed to record playback tokens: %w", err)
+		}
+		return nil
+	})
+}
+
+// Revoke revokes the active tokens of a user or of one of the user's sessions.
+func (s *Service) Revoke(ctx context.Context, req *playbackmodel.RevokeRequest) (*playbackmodel.RevokeResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	userID, err := parsing.StrToUUID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	var sessionID *uuid.UUID
+	if req.SessionID != nil {
+		id, err := parsing.StrToUUID(*req.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		sessionID = &id
+	}
+
+	revoked, err := s.repo.Revoke(ctx, userID, sessionID, time.Now())
+	if err != nil {
+		s.log(ctx).Error("failed to revoke playback tokens", zap.Error(err), zap.String("user_id", req.UserID))
+		return nil, fmt.Errorf("failed to revoke playback tokens: %w", err)
+	}
+	s.log(ctx).Info("revoked playback tokens", zap.String("user_id", req.UserID), zap.Int64("revoked", revoked))
+	return &playbackmodel.RevokeResult{Revoked: revoked}, nil
+}
+
+// Validate checks that a token was issued by the service and is neither expired nor revoked. If the request
+// carries session fields, they must match the ones the token was issued for.
+func (s *Service) Validate(ctx context.Context, req *playbackmodel.ValidateRequest) (*playbackmodel.Validation, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	session, err := s.repo.GetByTokenHash(ctx, hashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &playbackmodel.Validation{Reason: playbackmodel.ReasonUnknown}, nil
+		}
+		s.log(ctx).Error("failed to retrieve playback token", zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve playback token: %w", err)
+	}
+
+	result := &playbackmodel.Validation{Session: session}
+	switch {
+	case session.RevokedAt != nil:
+		result.Reason = playbackmodel.ReasonRevoked
+	case !session.ExpiresAt.After(time.Now()):
+		result.Reason = playbackmodel.ReasonExpired
+	case !sessionMatches(session, req):
+		result.Reason = playbackmodel.ReasonMismatch
+	default:
+		result.Valid = true
+	}
+	return result, nil
+}
+
+// Run periodically deletes the expired tokens. It blocks until the provided context is cancelled.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweep(ctx, time.Now())
+		}
+	}
+}
+
+func (s *Service) sweep(ctx context.Context, now time.Time) {
+	for {
+		deleted, err := s.repo.DeleteExpired(ctx, now, sweepBatchSize)
+		if err != nil {
+			s.logger.Error("failed to delete expired playback tokens", zap.Error(err))
+			return
+		}
+		if deleted < sweepBatchSize {
+			return
+		}
+	}
+}
+
+func sessionMatches(session *playbackmodel.Session, req *playbackmodel.ValidateRequest) bool {
+	if req.UserID != nil && *req.UserID != session.UserID.String() {
+		return false
+	}
+	if req.SessionID != nil && (session.SessionID == nil || *req.SessionID != session.SessionID.String()) {
+		return false
+	}
+	if req.UserAgent != nil && session.UserAgent != nil && *req.UserAgent != *session.UserAgent {
+		return false
+	}
+	return true
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package publishing implements the worker which applies the publication schedules of assets. It publishes
+// the assets whose publish time passed and unpublishes the ones whose unpublish time passed.
+package publishing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	publishingmodel "github.com/mikhail5545/media-service-go/internal/models/publishing"
+	"go.uber.org/zap"
+)
+
+// Transitioner is implemented by asset services that support scheduled publishing.
+type Transitioner interface {
+	TransitionPublishing(ctx context.Context, opts *publishingmodel.TransitionOptions) (int, error)
+}
+
+// Config holds the publishing worker configuration.
+type Config struct {
+	// Interval is the time between two consecutive worker runs. It bounds the delay of the publishing
+	// events, the delivery itself follows the schedule immediately.
+	Interval time.Duration
+	// BatchSize limits the number of assets transitioned per provider in a single run.
+	BatchSize int
+}
+
+// Worker periodically applies the publication schedules of the assets of all registered providers.
+type Worker struct {
+	cfg           Config
+	transitioners map[publishingmodel.Provider]Transitioner
+	logger        *zap.Logger
+}
+
+type NewParams struct {
+	Config        Config
+	Transitioners map[publishingmodel.Provider]Transitioner
+}
+
+func New(params *NewParams, logger *zap.Logger) (*Worker, error) {
+	if params.Config.Interval <= 0 {
+		return nil, fmt.Errorf("publishing interval must be positive")
+	}
+	if params.Config.BatchSize <= 0 {
+		return nil, fmt.Errorf("publishing batch size must be positive")
+	}
+	return &Worker{
+		cfg:           params.Config,
+		transitioners: params.Transitioners,
+		logger:        logger.With(zap.String("layer", "worker"), zap.String("worker", "publishing")),
+	}, nil
+}
+
+// Run starts the publishing worker loop. It blocks until the provided context is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	w.logger.Info("publishing worker started",
+		zap.Duration("interval", w.cfg.Interval),
+		zap.Int("batch_size", w.cfg.BatchSize),
+	)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			w.logger.Info("publishing worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce transitions the due assets of all registered providers. A full batch is followed by
+// another one right away, so a backlog is worked off within a single run.
+func (w *Worker) RunOnce(ctx context.Context) {
+	for provider, transitioner := range w.transitioners {
+		total := 0
+		for ctx.Err() == nil {
+			n, err := transitioner.TransitionPublishing(ctx, &publishingmodel.TransitionOptions{
+				Now:       time.Now(),
+				BatchSize: w.cfg.BatchSize,
+			})
+			if err != nil {
+				w.logger.Error("failed to transition assets", zap.Error(err), zap.String("provider", string(provider)))
+				break
+			}
+			total += n
+			if n < w.cfg.BatchSize {
+				break
+			}
+		}
+		if total > 0 {
+			w.logger.Info("publishing run completed", zap.String("provider", string(provider)), zap.Int("transitioned", total))
+		}
+	}
+}
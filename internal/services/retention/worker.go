@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package retention implements the retention policy subsystem. It periodically purges soft-deleted (archived)
+// assets of all registered providers once their TTL expires and records every purge attempt in the purge audit log.
+package retention
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	retentionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/retention"
+	retentionmodel "github.com/mikhail5545/media-service-go/internal/models/retention"
+	"go.uber.org/zap"
+)
+
+// Purger is implemented by asset services that support permanent deletion of expired archived assets.
+type Purger interface {
+	Purge(ctx context.Context, opts *retentionmodel.PurgeOptions) ([]*retentionmodel.PurgeRecord, error)
+}
+
+// Config holds retention policy configuration.
+type Config struct {
+	Enabled bool
+	// TTL is the time after soft deletion when archived assets are permanently deleted.
+	TTL time.Duration
+	// Interval is the time between two consecutive retention worker runs.
+	Interval time.Duration
+	// BatchSize limits the number of assets purged per provider in a single run.
+	BatchSize int
+	// RetryBackoff is the time before an asset whose purge failed is selected again.
+	RetryBackoff time.Duration
+	// DryRun only records assets that would be purged without deleting anything.
+	DryRun bool
+}
+
+// Worker periodically purges expired archived assets of all registered providers.
+type Worker struct {
+	cfg     Config
+	purgers map[retentionmodel.Provider]Purger
+	repo    *retentionrepo.Repository
+	logger  *zap.Logger
+	// mu serializes the scheduled runs with the ones triggered by administrators.
+	mu sync.Mutex
+}
+
+type NewParams struct {
+	Config  Config
+	Purgers map[retentionmodel.Provider]Purger
+	Repo    *retentionrepo.Repository
+}
+
+func New(params *NewParams, logger *zap.Logger) (*Worker, error) {
+	if params.Config.TTL <= 0 {
+		return nil, fmt.Errorf("retention ttl must be positive")
+	}
+	if params.Config.Interval <= 0 {
+		return nil, fmt.Errorf("retention interval must be positive")
+	}
+	if params.Config.BatchSize <= 0 {
+		return nil, fmt.Errorf("retention batch size must be positive")
+	}
+	if params.Config.RetryBackoff <= 0 {
+		return nil, fmt.Errorf("retention retry backoff must be positive")
+	}
+	return &Worker{
+		cfg:     params.Config,
+		purgers: params.Purgers,
+		repo:    params.Repo,
+		logger:  logger.With(zap.String("layer", "worker"), zap.String("worker", "retention")),
+	}, nil
+}
+
+// Run starts the retention worker loop. It blocks until the provided context is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	w.logger.Info("retention worker started",
+		zap.Duration("ttl", w.cfg.TTL),
+		zap.Duration("interval", w.cfg.Interval),
+		zap.Int("batch_size", w.cfg.BatchSize),
+		zap.Duration("retry_backoff", w.cfg.RetryBackoff),
+		zap.Bool("dry_run", w.cfg.DryRun),
+	)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.RunOnce(ctx); err != nil {
+			w.logger.Error("retention run failed", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			w.logger.Info("retention worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce performs a single purge pass over all registered providers and persists the purge audit log.
+// It returns all purge records produced during the run.
+func (w *Worker) RunOnce(ctx context.Context) ([]*retentionmodel.PurgeRecord, error) {
+	return w.run(ctx, w.cfg.DryRun)
+}
+
+// Trigger performs a purge pass on demand, e.g. during an incident, without waiting for the next
+// scheduled run. It waits for a run in progress to complete first.
+func (w *Worker) Trigger(ctx context.Context, req *retentionmodel.RunRequest) ([]*retentionmodel.PurgeRecord, error) {
+	dryRun := w.cfg.DryRun || req.DryRun
+	w.logger.Info("retention run triggered", zap.Bool("dry_run", dryRun))
+	return w.run(ctx, dryRun)
+}
+
+func (w *Worker) run(ctx context.Context, dryRun bool) ([]*retentionmodel.PurgeRecord, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	runID, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate retention run id: %w", err)
+	}
+	now := time.Now()
+	opts := &retentionmodel.PurgeOptions{
+		RunID:      runID,
+		Cutoff:     now.Add(-w.cfg.TTL),
+		RetryAfter: now.Add(-w.cfg.RetryBackoff),
+		BatchSize:  w.cfg.BatchSize,
+		DryRun:     dryRun,
+	}
+
+	var all []*retentionmodel.PurgeRecord
+	for provider, purger := range w.purgers {
+		records, err := purger.Purge(ctx, opts)
+		if err != nil {
+			w.logger.Error("failed to purge expired assets", zap.Error(err), zap.String("provider", string(provider)))
+			continue
+		}
+		if err := w.repo.Create(ctx, records...); err != nil {
+			w.logger.Error("failed to write purge audit log", zap.Error(err), zap.String("provider", string(provider)))
+			return all, fmt.Errorf("failed to write purge audit log: %w", err)
+		}
+		all = append(all, records...)
+	}
+
+	if len(all) > 0 {
+		failed := 0
+		for _, record := range all {
+			if !record.DryRun && !record.Succeeded {
+				failed++
+			}
+		}
+		w.logger.Info("retention run completed",
+			zap.String("run_id", runID.String()),
+			zap.Int("processed", len(all)),
+			zap.Int("failed", failed),
+			zap.Bool("dry_run", dryRun),
+		)
+	}
+	return all, nil
+}
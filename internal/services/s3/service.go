@@ -0,0 +1,224 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package s3 implements the endpoints specific to the S3-compatible object storage of raw file
+// assets: presigned uploads and downloads. Everything else is served by the shared asset core.
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	s3api "github.com/mikhail5545/media-service-go/internal/apiclients/s3"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	mediamodel "github.com/mikhail5545/media-service-go/internal/models/media"
+	s3model "github.com/mikhail5545/media-service-go/internal/models/s3"
+	"github.com/mikhail5545/media-service-go/internal/services/mediacore"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+)
+
+// Config restricts the uploads and bounds the validity of the presigned requests.
+type Config struct {
+	// KeyPrefix is prepended to the keys of all objects, e.g. "media/".
+	KeyPrefix      string
+	UploadURLTTL   time.Duration
+	DownloadURLTTL time.Duration
+	// MaxFileSize is the maximum size of a single file in bytes.
+	MaxFileSize int64
+	// AllowedContentTypes lists the accepted content types, any content type is accepted if it is empty.
+	AllowedContentTypes []string
+}
+
+type Service struct {
+	core      *mediacore.Service
+	apiClient s3api.APIClient
+	cfg       Config
+	logger    *zap.Logger
+}
+
+type NewParams struct {
+	// Core is the asset service of the s3 provider.
+	Core      *mediacore.Service
+	ApiClient s3api.APIClient
+	Config    Config
+}
+
+func New(params *NewParams, logger *zap.Logger) *Service {
+	return &Service{
+		core:      params.Core,
+		apiClient: params.ApiClient,
+		cfg:       params.Config,
+		logger:    logger.With(zap.String("layer", "service"), zap.String("service", "s3")),
+	}
+}
+
+// log returns the service logger enriched with the request-scoped fields stored in ctx.
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// CreateUploadURL creates a pending file asset and returns a presigned upload of its content. The
+// asset stays pending until the upload is confirmed with ConfirmUpload.
+func (s *Service) CreateUploadURL(ctx context.Context, req *s3model.CreateUploadURLRequest) (*s3model.UploadURL, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	if s.cfg.MaxFileSize > 0 && req.Bytes > s.cfg.MaxFileSize {
+		return nil, serviceerrors.NewInvalidArgumentError(fmt.Sprintf("file exceeds the maximum size of %d bytes", s.cfg.MaxFileSize))
+	}
+	contentType := strings.ToLower(strings.TrimSpace(req.ContentType))
+	if len(s.cfg.AllowedContentTypes) > 0 && !slices.Contains(s.cfg.AllowedContentTypes, contentType) {
+		return nil, serviceerrors.NewInvalidArgumentError(fmt.Sprintf("content type %q is not allowed", contentType))
+	}
+	adminID, err := parsing.StrToUUID(req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	assetID, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate asset ID: %w", err)
+	}
+
+	key := s.objectKey(assetID, req.Filename)
+	upload, err := s.apiClient.PresignPutObject(key, s3api.PutOptions{
+		ContentType:    contentType,
+		ContentLength:  req.Bytes,
+		ChecksumSHA256: req.ChecksumSHA256,
+		Expires:        s.cfg.UploadURLTTL,
+	})
+	if err != nil {
+		s.log(ctx).Error("failed to presign object upload", zap.Error(err), logging.AssetID(assetID))
+		return nil, fmt.Errorf("failed to presign object upload: %w", err)
+	}
+
+	asset := &mediamodel.Asset{
+		ID:            assetID,
+		Kind:          s3model.KindFile,
+		Status:        mediamodel.StatusPending,
+		ExternalID:    key,
+		ContentType:   &contentType,
+		Bytes:         &req.Bytes,
+		Attributes:    map[string]string{s3model.AttributeFilename: req.Filename},
+		CreatedBy:     &adminID,
+		CreatedByName: &req.AdminName,
+	}
+	if req.Title != "" {
+		asset.Title = &req.Title
+	}
+	if req.ChecksumSHA256 != "" {
+		asset.Checksum = &req.ChecksumSHA256
+	}
+	if err := s.core.Create(ctx, asset); err != nil {
+		return nil, err
+	}
+	return &s3model.UploadURL{AssetID: assetID.String(), Upload: upload}, nil
+}
+
+// ConfirmUpload activates a pending file asset after checking that its content was uploaded. The
+// size, content type and checksum of the stored object are recorded. Confirming an active asset
+// returns it unchanged.
+func (s *Service) ConfirmUpload(ctx context.Context, req *s3model.ConfirmUploadRequest) (*mediamodel.Asset, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	details, err := s.core.GetWithArchived(ctx, &mediamodel.GetRequest{ID: req.ID})
+	if err != nil {
+		return nil, err
+	}
+	asset := details.Asset
+	switch asset.Status {
+	case mediamodel.StatusActive:
+		return asset, nil
+	case mediamodel.StatusPending:
+	default:
+		return nil, serviceerrors.NewConflictError("only pending assets can be confirmed")
+	}
+
+	info, err := s.apiClient.HeadObject(ctx, asset.ExternalID)
+	if err != nil {
+		if errors.Is(err, s3api.ErrNotFound) {
+			return nil, serviceerrors.NewConflictError("the file has not been uploaded yet")
+		}
+		s.log(ctx).Error("failed to inspect uploaded object", zap.Error(err), logging.AssetID(asset.ID))
+		return nil, fmt.Errorf("failed to inspect uploaded object: %w", err)
+	}
+	// The object store enforces the signed size and checksum, a mismatch means the object was
+	// replaced outside of the service.
+	if asset.Bytes != nil && *asset.Bytes != info.Size {
+		return nil, serviceerrors.NewConflictError("the uploaded file size does not match the declared size")
+	}
+	if asset.Checksum != nil && info.ChecksumSHA256 != "" && *asset.Checksum != info.ChecksumSHA256 {
+		return nil, serviceerrors.NewConflictError("the uploaded file checksum does not match the declared checksum")
+	}
+
+	updates := map[string]any{"bytes": info.Size}
+	if info.ContentType != "" {
+		updates["content_type"] = info.ContentType
+	}
+	if info.ChecksumSHA256 != "" {
+		updates["checksum"] = info.ChecksumSHA256
+	}
+	return s.core.Activate(ctx, asset.ID, updates)
+}
+
+// GetDownloadURL returns a presigned download of the content of an active file asset.
+func (s *Service) GetDownloadURL(ctx context.Context, req *s3model.DownloadURLRequest) (*s3model.DownloadURL, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	details, err := s.core.Get(ctx, &mediamodel.GetRequest{ID: req.ID})
+	if err != nil {
+		return nil, err
+	}
+	filename := req.Filename
+	if filename == "" {
+		filename = details.Asset.Attributes[s3model.AttributeFilename]
+	}
+	download, err := s.apiClient.PresignGetObject(details.Asset.ExternalID, s3api.GetOptions{
+		Filename: filename,
+		Expires:  s.cfg.DownloadURLTTL,
+	})
+	if err != nil {
+		s.log(ctx).Error("failed to presign object download", zap.Error(err), logging.AssetID(details.Asset.ID))
+		return nil, fmt.Errorf("failed to presign object download: %w", err)
+	}
+	return &s3model.DownloadURL{URL: download.URL, ExpiresAt: download.ExpiresAt}, nil
+}
+
+// objectKey returns the key of the object of the asset. The file name is kept readable, but reduced
+// to characters that are safe in URLs and file systems.
+func (s *Service) objectKey(assetID uuid.UUID, filename string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, filename)
+	if len(safe) > 128 {
+		safe = safe[len(safe)-128:]
+	}
+	return s.cfg.KeyPrefix + assetID.String() + "/" + safe
+}
@@ -0,0 +1,332 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package saga implements the saga executor. A saga applies its steps one by one and persists its
+// state after every step. When a step fails, the applied steps are compensated in reverse order.
+// Sagas interrupted by a restart are resumed by the executor worker once their lease expires:
+// running sagas continue with their pending steps, compensating sagas with their applied steps.
+// A saga whose steps were all applied runs the completion of its definition before it is completed.
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/auth"
+	sagarepo "github.com/mikhail5545/media-service-go/internal/database/postgres/saga"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	sagamodel "github.com/mikhail5545/media-service-go/internal/models/saga"
+	"go.uber.org/zap"
+)
+
+// Action applies and compensates a single kind of step. Both functions must be idempotent.
+type Action struct {
+	Apply      func(ctx context.Context, saga *sagamodel.Saga, payload []byte) error
+	Compensate func(ctx context.Context, saga *sagamodel.Saga, payload []byte) error
+}
+
+// Definition implements the steps of a saga type.
+type Definition struct {
+	// Actions maps the step actions to their implementations.
+	Actions map[string]Action
+	// Complete is optional, it runs once all steps were applied, both when the saga is executed and
+	// when it is resumed after a restart. The saga stays running until it succeeds, so it is retried
+	// by the worker and must be idempotent.
+	Complete func(ctx context.Context, saga *sagamodel.Saga) error
+}
+
+// DefinitionProvider is implemented by services that start sagas.
+type DefinitionProvider interface {
+	// SagaDefinitions returns the definitions of all saga types started by the service.
+	SagaDefinitions() map[sagamodel.Type]Definition
+}
+
+// Config holds saga executor configuration.
+type Config struct {
+	// Lease is the time a saga is reserved for the executing instance after every step. Sagas whose lease
+	// expired are considered interrupted and resumed by the worker.
+	Lease time.Duration
+	// PollInterval is the time between two polls for interrupted sagas.
+	PollInterval time.Duration
+	// BatchSize limits the number of sagas resumed in a single poll.
+	BatchSize int
+	// MaxAttempts is the number of resumptions after which the saga is marked as failed.
+	MaxAttempts int
+}
+
+// DefaultConfig returns the default saga executor configuration.
+func DefaultConfig() Config {
+	return Config{
+		Lease:        time.Minute,
+		PollInterval: 30 * time.Second,
+		BatchSize:    20,
+		MaxAttempts:  10,
+	}
+}
+
+// Executor executes sagas and resumes the interrupted ones.
+type Executor struct {
+	cfg         Config
+	repo        *sagarepo.Repository
+	definitions map[sagamodel.Type]Definition
+	logger      *zap.Logger
+}
+
+type NewParams struct {
+	Config Config
+	Repo   *sagarepo.Repository
+}
+
+func New(params *NewParams, logger *zap.Logger) *Executor {
+	return &Executor{
+		cfg:         params.Config,
+		repo:        params.Repo,
+		definitions: make(map[sagamodel.Type]Definition),
+		logger:      logger.With(zap.String("layer", "service"), zap.String("service", "saga")),
+	}
+}
+
+// Register registers the saga definitions of the providers. It must be called before sagas are executed,
+// the services starting sagas are created after the executor.
+func (e *Executor) Register(providers ...DefinitionProvider) error {
+	for _, provider := range providers {
+		for sagaType, definition := range provider.SagaDefinitions() {
+			if _, exists := e.definitions[sagaType]; exists {
+				return fmt.Errorf("duplicate saga definition for type %q", sagaType)
+			}
+			e.definitions[sagaType] = definition
+		}
+	}
+	return nil
+}
+
+// Execute persists the saga and executes it. It returns nil if all steps were applied. If a step failed,
+// the error of the step is returned after the applied steps were compensated. If the compensation
+// fails too, the saga is left compensating and resumed later by the worker. The admin who started
+// the saga is taken from the context.
+func (e *Executor) Execute(ctx context.Context, saga *sagamodel.Saga) error {
+	if _, ok := e.definitions[saga.Type]; !ok {
+		return fmt.Errorf("no saga definition registered for type %q", saga.Type)
+	}
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		if id, err := uuid.Parse(p.Subject); err == nil {
+			saga.AdminID = &id
+		}
+		if name := p.Name; name != "" {
+			saga.AdminName = &name
+		}
+	}
+	saga.Status = sagamodel.StatusRunning
+	saga.NextAttemptAt = time.Now().Add(e.cfg.Lease)
+	if err := e.repo.Create(ctx, saga); err != nil {
+		return fmt.Errorf("failed to create saga: %w", err)
+	}
+	return e.run(ctx, saga)
+}
+
+// run continues the saga from its persisted state.
+func (e *Executor) run(ctx context.Context, saga *sagamodel.Saga) error {
+	logger := logging.FromContext(ctx, e.logger).With(
+		zap.String("saga_id", saga.ID.String()),
+		zap.String("saga_type", string(saga.Type)),
+		logging.AssetID(saga.AssetID),
+	)
+	definition := e.definitions[saga.Type]
+
+	var stepErr error
+	if saga.Status == sagamodel.StatusRunning {
+		stepErr = e.apply(ctx, saga, definition)
+		if stepErr == nil {
+			if definition.Complete != nil {
+				if err := definition.Complete(ctx, saga); err != nil {
+					// The steps are applied, the saga is not compensated but completed by the worker.
+					logger.Warn("saga completion failed", zap.Error(err))
+					return fmt.Errorf("failed to complete saga: %w", err)
+				}
+			}
+			saga.Status = sagamodel.StatusCompleted
+			return e.save(ctx, saga)
+		}
+		if ctx.Err() != nil {
+			// The saga is resumed by the worker, the step was not necessarily rejected.
+			return stepErr
+		}
+		logger.Warn("saga step failed, compensating", zap.Error(stepErr))
+		saga.Status = sagamodel.StatusCompensating
+		saga.LastError = errorString(stepErr)
+		if err := e.save(ctx, saga); err != nil {
+			return errors.Join(stepErr, err)
+		}
+	}
+
+	if err := e.compensate(ctx, saga, definition); err != nil {
+		logger.Error("saga compensation failed", zap.Error(err))
+		saga.LastError = errorString(err)
+		return errors.Join(stepErr, fmt.Errorf("failed to compensate saga: %w", err), e.save(ctx, saga))
+	}
+	saga.Status = sagamodel.StatusCompensated
+	if err := e.save(ctx, saga); err != nil {
+		return errors.Join(stepErr, err)
+	}
+	logger.Info("saga compensated")
+	if stepErr == nil {
+		// A resumed saga reports the error of the step that failed before the restart.
+		stepErr = errors.New("saga was compensated")
+		if saga.LastError != nil {
+			stepErr = errors.New(*saga.LastError)
+		}
+	}
+	return stepErr
+}
+
+// apply applies the pending steps in order, saving the saga after every applied step.
+func (e *Executor) apply(ctx context.Context, saga *sagamodel.Saga, definition Definition) error {
+	for _, step := range saga.Steps {
+		if step.Status != sagamodel.StepPending {
+			continue
+		}
+		action, ok := definition.Actions[step.Action]
+		if !ok {
+			step.Status = sagamodel.StepFailed
+			return fmt.Errorf("unknown saga step action %q", step.Action)
+		}
+		if err := action.Apply(ctx, saga, step.Payload); err != nil {
+			if ctx.Err() == nil {
+				step.Status = sagamodel.StepFailed
+			}
+			return err
+		}
+		step.Status = sagamodel.StepApplied
+		if err := e.save(ctx, saga); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compensate compensates the applied steps in reverse order, saving the saga after every step. The failed
+// step is compensated too, because it may have been applied partially or before its result was lost.
+func (e *Executor) compensate(ctx context.Context, saga *sagamodel.Saga, definition Definition) error {
+	for i := len(saga.Steps) - 1; i >= 0; i-- {
+		step := saga.Steps[i]
+		if step.Status != sagamodel.StepApplied && step.Status != sagamodel.StepFailed {
+			continue
+		}
+		action, ok := definition.Actions[step.Action]
+		if !ok {
+			continue
+		}
+		if err := action.Compensate(ctx, saga, step.Payload); err != nil {
+			return err
+		}
+		step.Status = sagamodel.StepCompensated
+		if err := e.save(ctx, saga); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Executor) save(ctx context.Context, saga *sagamodel.Saga) error {
+	if err := e.repo.Save(ctx, saga, e.cfg.Lease); err != nil {
+		return fmt.Errorf("failed to save saga: %w", err)
+	}
+	return nil
+}
+
+// Run resumes the interrupted sagas on every poll interval. It blocks until the provided context is cancelled.
+func (e *Executor) Run(ctx context.Context) {
+	e.logger.Info("saga worker started", zap.Duration("poll_interval", e.cfg.PollInterval))
+
+	ticker := time.NewTicker(e.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.logger.Info("saga worker stopped")
+			return
+		case <-ticker.C:
+			if err := e.ResumeOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				e.logger.Error("failed to resume sagas", zap.Error(err))
+			}
+		}
+	}
+}
+
+// ResumeOnce claims one batch of interrupted sagas and continues them.
+func (e *Executor) ResumeOnce(ctx context.Context) error {
+	sagas, err := e.repo.ClaimResumable(ctx, e.cfg.BatchSize, e.cfg.Lease)
+	if err != nil {
+		return fmt.Errorf("failed to claim sagas: %w", err)
+	}
+	for _, saga := range sagas {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		e.resume(ctx, saga)
+	}
+	return nil
+}
+
+func (e *Executor) resume(ctx context.Context, saga *sagamodel.Saga) {
+	logger := e.logger.With(
+		zap.String("saga_id", saga.ID.String()),
+		zap.String("saga_type", string(saga.Type)),
+		zap.Int("attempt", saga.Attempts),
+	)
+	if _, ok := e.definitions[saga.Type]; !ok {
+		logger.Error("no saga definition registered for type")
+		e.markFailed(ctx, logger, saga, "no saga definition registered for type")
+		return
+	}
+	if saga.Attempts > e.cfg.MaxAttempts {
+		logger.Error("saga exceeded the maximum number of attempts")
+		e.markFailed(ctx, logger, saga, "maximum number of attempts exceeded")
+		return
+	}
+
+	logger.Info("resuming saga", zap.String("status", string(saga.Status)))
+	if err := e.run(ctx, saga); err != nil {
+		logger.Warn("resumed saga did not complete", zap.Error(err), zap.String("status", string(saga.Status)))
+	}
+}
+
+func (e *Executor) markFailed(ctx context.Context, logger *zap.Logger, saga *sagamodel.Saga, reason string) {
+	saga.Status = sagamodel.StatusFailed
+	saga.LastError = &reason
+	if err := e.save(ctx, saga); err != nil {
+		logger.Error("failed to mark saga as failed", zap.Error(err))
+	}
+}
+
+// errorString returns the error message shortened to the size of the last_error column. It does not
+// split a multi-byte rune, Postgres rejects text values that are not valid UTF-8.
+func errorString(err error) *string {
+	msg := err.Error()
+	if n := 1024; len(msg) > n {
+		for n > 0 && !utf8.RuneStart(msg[n]) {
+			n--
+		}
+		msg = msg[:n]
+	}
+	return &msg
+}
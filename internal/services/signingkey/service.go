@@ -0,0 +1,381 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package signingkey provides the rotation of the MUX URL signing keys. A new signing key is created per
+// environment on schedule and signs the playback tokens from then on. Superseded keys stay in MUX until
+// the tokens they signed expired and are deleted afterwards. The private keys are stored sealed with
+// AES-GCM.
+package signingkey
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	apiclient "github.com/mikhail5545/media-service-go/internal/apiclients/mux"
+	signingkeyrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/signingkey"
+	"github.com/mikhail5545/media-service-go/internal/environment"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	signingkeymodel "github.com/mikhail5545/media-service-go/internal/models/signingkey"
+	muxgo "github.com/muxinc/mux-go/v6"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// KeyService defines the interface for managing the MUX signing keys.
+type KeyService interface {
+	// Current returns the key the playback tokens of the context environment are signed with, nil if no
+	// key was rotated in yet and the configured signing key is used.
+	Current(ctx context.Context) (*apiclient.SigningKey, error)
+	// List returns the signing keys of the context environment whose tokens are accepted.
+	List(ctx context.Context, req *signingkeymodel.ListRequest) (*signingkeymodel.KeySet, error)
+	// Rotate creates a new signing key for the context environment if the current one is due for
+	// rotation, and revokes the superseded keys whose tokens expired.
+	Rotate(ctx context.Context, req *signingkeymodel.RotateRequest) (*signingkeymodel.RotateResult, error)
+}
+
+// Config holds the rotation schedule.
+type Config struct {
+	// RotationInterval is the age at which the current key is replaced.
+	RotationInterval time.Duration
+	// RetireAfter is how long a superseded key is kept in MUX, it must exceed the validity of the tokens.
+	RetireAfter time.Duration
+	// CheckInterval is the interval the keys are checked for rotation at.
+	CheckInterval time.Duration
+	// Environments are the environments whose keys are rotated.
+	Environments []string
+}
+
+// Service implements the KeyService interface.
+type Service struct {
+	cfg       Config
+	repo      *signingkeyrepo.Repository
+	apiClient apiclient.APIClient
+	aead      cipher.AEAD
+
+	mu sync.Mutex
+	// current caches the current key of the environments, other instances' rotations are picked up
+	// when the entries expire.
+	current map[string]cachedKey
+	logger  *zap.Logger
+}
+
+type cachedKey struct {
+	key       *apiclient.SigningKey
+	expiresAt time.Time
+}
+
+var _ KeyService = (*Service)(nil)
+
+// cacheTTL is the time the current key of an environment is cached for.
+const cacheTTL = time.Minute
+
+type NewParams struct {
+	Config    Config
+	Repo      *signingkeyrepo.Repository
+	APIClient apiclient.APIClient
+	// EncryptionKey is the AES-256 key the private keys are sealed with.
+	EncryptionKey []byte
+}
+
+func New(params *NewParams, logger *zap.Logger) (*Service, error) {
+	if params.Config.RotationInterval <= 0 || params.Config.RetireAfter <= 0 || params.Config.CheckInterval <= 0 {
+		return nil, fmt.Errorf("signing key rotation intervals must be positive")
+	}
+	if len(params.EncryptionKey) != 32 {
+		return nil, fmt.Errorf("signing key encryption key must be 32 bytes long")
+	}
+	block, err := aes.NewCipher(params.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signing key cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signing key cipher: %w", err)
+	}
+	return &Service{
+		cfg:       params.Config,
+		repo:      params.Repo,
+		apiClient: params.APIClient,
+		aead:      aead,
+		current:   make(map[string]cachedKey),
+		logger:    logger.With(zap.String("layer", "service"), zap.String("service", "signingkey")),
+	}, nil
+}
+
+// log returns the service logger enriched with the request-scoped fields stored in ctx.
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// scoped returns ctx scoped to its environment, contexts without one are scoped to the default
+// environment so the keys of other environments are never used.
+func scoped(ctx context.Context) (context.Context, string) {
+	env := environment.Name(ctx)
+	return environment.WithContext(ctx, env), env
+}
+
+// Current returns the key the playback tokens of the context environment are signed with, nil if no
+// key was rotated in yet and the configured signing key is used.
+func (s *Service) Current(ctx context.Context) (*apiclient.SigningKey, error) {
+	ctx, env := scoped(ctx)
+	now := time.Now()
+	s.mu.Lock()
+	cached, ok := s.current[env]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.key, nil
+	}
+
+	var signingKey *apiclient.SigningKey
+	key, err := s.repo.Current(ctx)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+	case err != nil:
+		s.log(ctx).Error("failed to retrieve current signing key", zap.Error(err))
+		return nil, fmt.Errorf("failed to retrieve current signing key: %w", err)
+	default:
+		privateKey, err := s.open(key.PrivateKey)
+		if err != nil {
+			s.log(ctx).Error("failed to open signing key", zap.Error(err), zap.String("key_id", key.KeyID))
+			return nil, err
+		}
+		signingKey = &apiclient.SigningKey{ID: key.KeyID, PrivateKey: privateKey}
+	}
+
+	s.mu.Lock()
+	s.current[env] = cachedKey{key: signingKey, expiresAt: now.Add(cacheTTL)}
+	s.mu.Unlock()
+	return signingKey, nil
+}
+
+// List returns the signing keys of the context environment whose tokens are accepted, with the public
+// keys the tokens can be verified with.
+func (s *Service) List(ctx context.Context, _ *signingkeymodel.ListRequest) (*signingkeymodel.KeySet, error) {
+	ctx, env := scoped(ctx)
+	keys, err := s.repo.List(ctx)
+	if err != nil {
+		s.log(ctx).Error("failed to list signing keys", zap.Error(err))
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	set := &signingkeymodel.KeySet{Environment: env, Keys: make([]*signingkeymodel.PublishedKey, 0, len(keys))}
+	for _, key := range keys {
+		privateKey, err := s.open(key.PrivateKey)
+		if err != nil {
+			s.log(ctx).Error("failed to open signing key", zap.Error(err), zap.String("key_id", key.KeyID))
+			return nil, err
+		}
+		published, err := s.publish(key, privateKey)
+		if err != nil {
+			return nil, err
+		}
+		// Keys are listed newest first.
+		if key.Status == signingkeymodel.StatusActive && set.CurrentKeyID == "" {
+			set.CurrentKeyID = key.KeyID
+		}
+		set.Keys = append(set.Keys, published)
+	}
+	return set, nil
+}
+
+// Rotate creates a new signing key for the context environment if the current one is due for rotation
+// or req.Force is set, and revokes the superseded keys whose tokens expired.
+func (s *Service) Rotate(ctx context.Context, req *signingkeymodel.RotateRequest) (*signingkeymodel.RotateResult, error) {
+	ctx, env := scoped(ctx)
+	return s.rotate(ctx, env, req.Force, time.Now())
+}
+
+// Run rotates the keys of the configured environments right away and then at the check interval. It
+// blocks until the provided context is cancelled.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, env := range s.cfg.Environments {
+			envCtx := environment.WithContext(ctx, env)
+			result, err := s.rotate(envCtx, env, false, time.Now())
+			if err != nil {
+				// Retried at the next check, the superseded keys keep working meanwhile.
+				s.log(envCtx).Error("failed to rotate mux signing keys", zap.Error(err), zap.String("environment", env))
+				continue
+			}
+			if result.Created != nil || len(result.Revoked) > 0 {
+				s.log(envCtx).Info("rotated mux signing keys",
+					zap.String("environment", env),
+					zap.Strings("retired", result.Retired),
+					zap.Strings("revoked", result.Revoked),
+				)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) rotate(ctx context.Context, env string, force bool, now time.Time) (*signingkeymodel.RotateResult, error) {
+	result := &signingkeymodel.RotateResult{Retired: []string{}, Revoked: []string{}}
+	var created *muxgo.SigningKey
+	err := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+		if err := txRepo.LockEnvironment(ctx, env); err != nil {
+			s.log(ctx).Error("failed to lock signing keys", zap.Error(err))
+			return fmt.Errorf("failed to lock signing keys: %w", err)
+		}
+		current, err := txRepo.Current(ctx)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log(ctx).Error("failed to retrieve current signing key", zap.Error(err))
+			return fmt.Errorf("failed to retrieve current signing key: %w", err)
+		}
+		if current != nil && !force && now.Sub(current.CreatedAt) < s.cfg.RotationInterval {
+			return nil
+		}
+
+		created, err = s.apiClient.CreateSigningKey(ctx)
+		if err != nil {
+			s.log(ctx).Error("failed to create mux signing key", zap.Error(err))
+			return err
+		}
+		privateKey, err := base64.StdEncoding.DecodeString(created.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("failed to decode mux signing key: %w", err)
+		}
+		key := &signingkeymodel.Key{KeyID: created.Id, Status: signingkeymodel.StatusActive}
+		if key.PrivateKey, err = s.seal(privateKey); err != nil {
+			return err
+		}
+		if err := txRepo.Create(ctx, key); err != nil {
+			s.log(ctx).Error("failed to create signing key", zap.Error(err), zap.String("key_id", key.KeyID))
+			return fmt.Errorf("failed to create signing key: %w", err)
+		}
+		if result.Created, err = s.publish(key, privateKey); err != nil {
+			return err
+		}
+		if _, err := txRepo.Retire(ctx, key.KeyID, now); err != nil {
+			s.log(ctx).Error("failed to retire signing keys", zap.Error(err))
+			return fmt.Errorf("failed to retire signing keys: %w", err)
+		}
+		if current != nil {
+			result.Retired = append(result.Retired, current.KeyID)
+		}
+		return nil
+	})
+	if err != nil {
+		// The key was not stored, so it would never be used nor deleted.
+		if created != nil {
+			if err := s.deleteMuxKey(ctx, created.Id); err != nil {
+				s.log(ctx).Error("failed to delete orphaned mux signing key", zap.Error(err), zap.String("key_id", created.Id))
+			}
+		}
+		return nil, err
+	}
+	if result.Created != nil {
+		s.invalidate(env)
+	}
+
+	expired, err := s.repo.ListRetired(ctx, now.Add(-s.cfg.RetireAfter))
+	if err != nil {
+		s.log(ctx).Error("failed to list retired signing keys", zap.Error(err))
+		return nil, fmt.Errorf("failed to list retired signing keys: %w", err)
+	}
+	for _, key := range expired {
+		if err := s.deleteMuxKey(ctx, key.KeyID); err != nil {
+			s.log(ctx).Error("failed to delete mux signing key", zap.Error(err), zap.String("key_id", key.KeyID))
+			return nil, err
+		}
+		if err := s.repo.Revoke(ctx, key.KeyID, now); err != nil {
+			s.log(ctx).Error("failed to revoke signing key", zap.Error(err), zap.String("key_id", key.KeyID))
+			return nil, fmt.Errorf("failed to revoke signing key: %w", err)
+		}
+		result.Revoked = append(result.Revoked, key.KeyID)
+	}
+	return result, nil
+}
+
+// deleteMuxKey deletes the MUX signing key, keys already deleted in MUX are ignored.
+func (s *Service) deleteMuxKey(ctx context.Context, keyID string) error {
+	if err := s.apiClient.DeleteSigningKey(ctx, keyID); err != nil {
+		var notFound muxgo.NotFoundError
+		if !errors.As(err, &notFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) invalidate(env string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.current, env)
+}
+
+// publish returns the key with the public key of its PEM encoded private key.
+func (s *Service) publish(key *signingkeymodel.Key, privateKey []byte) (*signingkeymodel.PublishedKey, error) {
+	rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %s: %w", key.KeyID, err)
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key of signing key %s: %w", key.KeyID, err)
+	}
+	published := &signingkeymodel.PublishedKey{
+		KeyID:     key.KeyID,
+		Status:    key.Status,
+		CreatedAt: key.CreatedAt,
+		PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})),
+	}
+	if key.RetiringAt != nil {
+		expiresAt := key.RetiringAt.Add(s.cfg.RetireAfter)
+		published.ExpiresAt = &expiresAt
+	}
+	return published, nil
+}
+
+// seal encrypts the private key, the nonce is prepended to the ciphertext.
+func (s *Service) seal(privateKey []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return s.aead.Seal(nonce, nonce, privateKey, nil), nil
+}
+
+// open decrypts a private key sealed by seal.
+func (s *Service) open(sealed []byte) ([]byte, error) {
+	if len(sealed) < s.aead.NonceSize() {
+		return nil, fmt.Errorf("sealed signing key is too short")
+	}
+	nonce, ciphertext := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+	privateKey, err := s.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open signing key: %w", err)
+	}
+	return privateKey, nil
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package subscription
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	subscriptionmodel "github.com/mikhail5545/media-service-go/internal/models/subscription"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// SignatureHeader holds the signature of the delivery in the "t=TIMESTAMP,v1=HEX" form, where HEX is
+	// the HMAC-SHA256 of "TIMESTAMP.BODY" keyed with the subscription secret.
+	SignatureHeader = "X-Media-Signature"
+	// EventTypeHeader holds the type of the delivered event.
+	EventTypeHeader = "X-Media-Event"
+	// DeliveryIDHeader holds the delivery ID, which stays the same across the retries of the delivery.
+	DeliveryIDHeader = "X-Media-Delivery"
+)
+
+// purgeInterval is the interval between purges of the finished deliveries.
+const purgeInterval = time.Hour
+
+// Run starts the dispatcher loop. It blocks until the provided context is cancelled.
+func (s *Service) Run(ctx context.Context) {
+	s.logger.Info("outgoing webhook dispatcher started", zap.Duration("poll_interval", s.cfg.PollInterval))
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+	purge := time.NewTicker(purgeInterval)
+	defer purge.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("outgoing webhook dispatcher stopped")
+			return
+		case <-ticker.C:
+			if err := s.dispatchBatch(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				s.logger.Error("failed to dispatch outgoing webhooks", zap.Error(err))
+			}
+		case <-purge.C:
+			s.purge(ctx)
+		}
+	}
+}
+
+// dispatchBatch claims one batch of due deliveries and attempts to send them.
+func (s *Service) dispatchBatch(ctx context.Context) error {
+	// Lease claimed deliveries for the whole batch duration, so they are not picked up by another
+	// instance while this one is still sending them.
+	lease := time.Duration(s.cfg.BatchSize) * s.cfg.Timeout
+	deliveries, err := s.repo.ClaimDue(ctx, s.cfg.BatchSize, lease)
+	if err != nil {
+		return fmt.Errorf("failed to claim outgoing webhook deliveries: %w", err)
+	}
+	// Deliveries of the same subscription are usually claimed together.
+	subscriptions := make(map[uuid.UUID]*subscriptionmodel.Subscription)
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = s.repo.Get(ctx, delivery.SubscriptionID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("failed to retrieve outgoing webhook: %w", err)
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+		if subscription == nil {
+			// Deleted in the meantime, the delivery is deleted with it.
+			continue
+		}
+		s.deliver(ctx, subscription, delivery)
+	}
+	return nil
+}
+
+func (s *Service) deliver(ctx context.Context, subscription *subscriptionmodel.Subscription, delivery *subscriptionmodel.Delivery) {
+	logger := s.logger.With(
+		zap.String("subscription_id", subscription.ID.String()),
+		zap.String("delivery_id", delivery.ID.String()),
+		zap.String("event_type", delivery.EventType),
+		zap.Int("attempt", delivery.Attempts+1),
+	)
+
+	sendCtx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	status, err := s.send(sendCtx, subscription, delivery)
+	cancel()
+
+	var responseStatus *int
+	if status != 0 {
+		responseStatus = &status
+	}
+	switch {
+	case err == nil:
+		if err := s.repo.MarkDelivered(ctx, delivery.ID, status); err != nil {
+			logger.Error("failed to mark outgoing webhook delivery as delivered", zap.Error(err))
+		}
+	case delivery.Attempts+1 >= s.cfg.MaxAttempts:
+		logger.Warn("outgoing webhook delivery failed permanently", zap.Error(err))
+		if err := s.repo.MarkFailed(ctx, delivery.ID, responseStatus, err.Error()); err != nil {
+			logger.Error("failed to mark outgoing webhook delivery as failed", zap.Error(err))
+		}
+	default:
+		next := time.Now().Add(s.backoff(delivery.Attempts + 1))
+		logger.Info("outgoing webhook delivery failed, scheduling retry", zap.Error(err), zap.Time("next_attempt_at", next))
+		if err := s.repo.MarkRetry(ctx, delivery.ID, next, responseStatus, err.Error()); err != nil {
+			logger.Error("failed to schedule outgoing webhook delivery retry", zap.Error(err))
+		}
+	}
+}
+
+// send posts the signed delivery payload to the subscription URL. It returns the response status, 0
+// if the endpoint was not reached, and an error unless the endpoint responded with a 2xx status.
+func (s *Service) send(ctx context.Context, subscription *subscriptionmodel.Subscription, delivery *subscriptionmodel.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventTypeHeader, delivery.EventType)
+	req.Header.Set(DeliveryIDHeader, delivery.ID.String())
+	req.Header.Set(SignatureHeader, sign(subscription.Secret, time.Now(), delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain a bounded part of the body, so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// sign returns the signature header value of the payload sent at the time.
+func sign(secret string, at time.Time, payload []byte) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns the exponential delay before the specified attempt, capped by MaxBackoff.
+func (s *Service) backoff(attempt int) time.Duration {
+	delay := s.cfg.BaseBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= s.cfg.MaxBackoff {
+			return s.cfg.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// purge deletes the finished deliveries older than the retention period.
+func (s *Service) purge(ctx context.Context) {
+	purged, err := s.repo.PurgeDeliveries(ctx, time.Now().Add(-s.cfg.Retention))
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			s.logger.Error("failed to purge outgoing webhook deliveries", zap.Error(err))
+		}
+		return
+	}
+	if purged > 0 {
+		s.logger.Info("outgoing webhook deliveries purged", zap.Int64("deliveries", purged))
+	}
+}
+
+// newDelivery returns the pending delivery of the event to the subscription.
+func newDelivery(subscriptionID uuid.UUID, event *events.Event) (*subscriptionmodel.Delivery, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode outgoing webhook payload: %w", err)
+	}
+	return &subscriptionmodel.Delivery{
+		SubscriptionID: subscriptionID,
+		EventID:        event.ID,
+		EventType:      string(event.Type),
+		Payload:        payload,
+	}, nil
+}
+
+// jsonArray returns the expression setting a jsonb column to the values.
+func jsonArray(values []string) clause.Expr {
+	// Encoding a string slice cannot fail.
+	raw, _ := json.Marshal(values)
+	return gorm.Expr("?::jsonb", string(raw))
+}
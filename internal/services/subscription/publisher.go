@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	subscriptionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/subscription"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	subscriptionmodel "github.com/mikhail5545/media-service-go/internal/models/subscription"
+	"go.uber.org/zap"
+)
+
+// Publisher is an [events.Publisher] which publishes the events with the wrapped publisher and
+// enqueues a delivery of every event for each active subscription matching it.
+type Publisher struct {
+	next   events.Publisher
+	repo   *subscriptionrepo.Repository
+	logger *zap.Logger
+}
+
+var _ events.Publisher = (*Publisher)(nil)
+
+// NewPublisher returns a publisher publishing the events with next.
+func NewPublisher(next events.Publisher, repo *subscriptionrepo.Repository, logger *zap.Logger) *Publisher {
+	return &Publisher{
+		next:   next,
+		repo:   repo,
+		logger: logger.With(zap.String("layer", "service"), zap.String("service", "subscription")),
+	}
+}
+
+func (p *Publisher) Publish(ctx context.Context, event *events.Event) error {
+	return errors.Join(p.next.Publish(ctx, event), p.enqueue(ctx, event))
+}
+
+func (p *Publisher) Close() error {
+	return p.next.Close()
+}
+
+func (p *Publisher) enqueue(ctx context.Context, event *events.Event) error {
+	subscriptions, err := p.repo.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list outgoing webhooks: %w", err)
+	}
+	var deliveries []*subscriptionmodel.Delivery
+	for _, subscription := range subscriptions {
+		if !matches(subscription, event) {
+			continue
+		}
+		delivery, err := newDelivery(subscription.ID, event)
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := p.repo.Enqueue(ctx, deliveries...); err != nil {
+		return fmt.Errorf("failed to enqueue outgoing webhook deliveries: %w", err)
+	}
+	if len(deliveries) > 0 {
+		p.logger.Debug("outgoing webhook deliveries enqueued",
+			zap.String("event_type", string(event.Type)),
+			zap.String("asset_id", event.AssetID.String()),
+			zap.Int("deliveries", len(deliveries)),
+		)
+	}
+	return nil
+}
+
+// matches reports whether the event passes the filters of the subscription.
+func matches(subscription *subscriptionmodel.Subscription, event *events.Event) bool {
+	if len(subscription.EventTypes) > 0 && !slices.Contains(subscription.EventTypes, string(event.Type)) {
+		return false
+	}
+	if len(subscription.Providers) > 0 && !slices.Contains(subscription.Providers, string(event.Provider)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,328 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package subscription implements the outgoing webhooks. Third-party integrations register an HTTP
+// endpoint with a secret and event filters; every asset event matching the filters is stored as a
+// pending delivery and sent to the endpoint by the dispatcher, signed with the secret. Failed
+// deliveries are retried with exponential backoff and kept as the delivery log.
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	subscriptionrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/subscription"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/events"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	subscriptionmodel "github.com/mikhail5545/media-service-go/internal/models/subscription"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SubscriptionService defines the interface for managing the outgoing webhooks.
+type SubscriptionService interface {
+	// Get retrieves an outgoing webhook.
+	Get(ctx context.Context, req *subscriptionmodel.GetRequest) (*subscriptionmodel.Subscription, error)
+	// List retrieves a page of outgoing webhooks, newest first.
+	List(ctx context.Context, req *subscriptionmodel.ListRequest) ([]*subscriptionmodel.Subscription, string, error)
+	// Create registers an outgoing webhook.
+	Create(ctx context.Context, req *subscriptionmodel.CreateRequest) (*subscriptionmodel.Subscription, error)
+	// Update changes an outgoing webhook.
+	Update(ctx context.Context, req *subscriptionmodel.UpdateRequest) (*subscriptionmodel.Subscription, error)
+	// Delete deletes an outgoing webhook together with its delivery log.
+	Delete(ctx context.Context, req *subscriptionmodel.DeleteRequest) error
+	// ListDeliveries retrieves a page of deliveries of an outgoing webhook, newest first.
+	ListDeliveries(ctx context.Context, req *subscriptionmodel.ListDeliveriesRequest) ([]*subscriptionmodel.Delivery, string, error)
+	// Test sends a test event to an outgoing webhook and returns the logged delivery.
+	Test(ctx context.Context, req *subscriptionmodel.TestRequest) (*subscriptionmodel.Delivery, error)
+}
+
+// defaultListPageSize is used by the list methods when the request does not specify a page size.
+const defaultListPageSize = 50
+
+type Config struct {
+	// PollInterval is the time between two consecutive polls for due deliveries.
+	PollInterval time.Duration
+	// BatchSize limits the number of deliveries claimed in a single poll.
+	BatchSize int
+	// Timeout limits the duration of a single delivery attempt.
+	Timeout time.Duration
+	// MaxAttempts is the number of delivery attempts after which the delivery is marked as failed.
+	MaxAttempts int
+	// BaseBackoff is the delay before the first retry. Each next retry doubles the delay.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+	// Retention is the time finished deliveries are kept in the delivery log.
+	Retention time.Duration
+}
+
+// Service implements the SubscriptionService interface and dispatches the pending deliveries.
+type Service struct {
+	cfg    Config
+	repo   *subscriptionrepo.Repository
+	client *http.Client
+	logger *zap.Logger
+}
+
+var _ SubscriptionService = (*Service)(nil)
+
+type NewParams struct {
+	Config Config
+	Repo   *subscriptionrepo.Repository
+}
+
+func New(params *NewParams, logger *zap.Logger) (*Service, error) {
+	cfg := params.Config
+	if cfg.PollInterval <= 0 || cfg.Timeout <= 0 || cfg.BaseBackoff <= 0 || cfg.MaxBackoff <= 0 || cfg.Retention <= 0 {
+		return nil, fmt.Errorf("outgoing webhook intervals must be positive")
+	}
+	if cfg.BatchSize <= 0 || cfg.MaxAttempts <= 0 {
+		return nil, fmt.Errorf("outgoing webhook batch size and max attempts must be positive")
+	}
+	return &Service{
+		cfg:  cfg,
+		repo: params.Repo,
+		client: &http.Client{
+			// Redirects are not followed, the registered URL must accept the deliveries itself.
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		logger: logger.With(zap.String("layer", "service"), zap.String("service", "subscription")),
+	}, nil
+}
+
+// log returns the service logger enriched with the request-scoped fields stored in ctx.
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// Get retrieves an outgoing webhook.
+func (s *Service) Get(ctx context.Context, req *subscriptionmodel.GetRequest) (*subscriptionmodel.Subscription, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	id, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.get(ctx, id)
+}
+
+func (s *Service) get(ctx context.Context, id uuid.UUID) (*subscriptionmodel.Subscription, error) {
+	subscription, err := s.repo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, serviceerrors.NewNotFoundError("outgoing webhook not found")
+		}
+		s.log(ctx).Error("failed to retrieve outgoing webhook", zap.Error(err), zap.String("subscription_id", id.String()))
+		return nil, fmt.Errorf("failed to retrieve outgoing webhook: %w", err)
+	}
+	return subscription, nil
+}
+
+// List retrieves a page of outgoing webhooks, newest first.
+func (s *Service) List(ctx context.Context, req *subscriptionmodel.ListRequest) ([]*subscriptionmodel.Subscription, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultListPageSize
+	}
+	subscriptions, nextPageToken, err := s.repo.List(ctx, pageSize, req.PageToken)
+	if err != nil {
+		s.log(ctx).Error("failed to list outgoing webhooks", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to list outgoing webhooks: %w", err)
+	}
+	return subscriptions, nextPageToken, nil
+}
+
+// Create registers an outgoing webhook. It receives the events published from now on.
+func (s *Service) Create(ctx context.Context, req *subscriptionmodel.CreateRequest) (*subscriptionmodel.Subscription, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	subscription := &subscriptionmodel.Subscription{
+		URL:         req.URL,
+		Secret:      req.Secret,
+		EventTypes:  nonNil(req.EventTypes),
+		Providers:   nonNil(req.Providers),
+		Description: req.Description,
+		Active:      true,
+	}
+	if err := s.repo.Create(ctx, subscription); err != nil {
+		s.log(ctx).Error("failed to create outgoing webhook", zap.Error(err))
+		return nil, fmt.Errorf("failed to create outgoing webhook: %w", err)
+	}
+	s.log(ctx).Info("outgoing webhook created", zap.String("subscription_id", subscription.ID.String()))
+	return subscription, nil
+}
+
+// Update changes an outgoing webhook. Pending deliveries are sent with the new URL and secret.
+func (s *Service) Update(ctx context.Context, req *subscriptionmodel.UpdateRequest) (*subscriptionmodel.Subscription, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	id, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]any, 6)
+	if req.URL != nil {
+		updates["url"] = *req.URL
+	}
+	if req.Secret != nil {
+		updates["secret"] = *req.Secret
+	}
+	if req.EventTypes != nil {
+		updates["event_types"] = jsonArray(nonNil(*req.EventTypes))
+	}
+	if req.Providers != nil {
+		updates["providers"] = jsonArray(nonNil(*req.Providers))
+	}
+	if req.Description != nil {
+		if *req.Description == "" {
+			updates["description"] = nil
+		} else {
+			updates["description"] = *req.Description
+		}
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+	}
+	if len(updates) == 0 {
+		return s.get(ctx, id)
+	}
+
+	updated, err := s.repo.Update(ctx, id, updates)
+	if err != nil {
+		s.log(ctx).Error("failed to update outgoing webhook", zap.Error(err), zap.String("subscription_id", req.ID))
+		return nil, fmt.Errorf("failed to update outgoing webhook: %w", err)
+	}
+	if updated == 0 {
+		return nil, serviceerrors.NewNotFoundError("outgoing webhook not found")
+	}
+	return s.get(ctx, id)
+}
+
+// Delete deletes an outgoing webhook together with its delivery log. Pending deliveries are dropped.
+func (s *Service) Delete(ctx context.Context, req *subscriptionmodel.DeleteRequest) error {
+	if err := req.Validate(); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	id, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return err
+	}
+	affected, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		s.log(ctx).Error("failed to delete outgoing webhook", zap.Error(err), zap.String("subscription_id", req.ID))
+		return fmt.Errorf("failed to delete outgoing webhook: %w", err)
+	}
+	if affected == 0 {
+		return serviceerrors.NewNotFoundError("outgoing webhook not found")
+	}
+	s.log(ctx).Info("outgoing webhook deleted", zap.String("subscription_id", req.ID))
+	return nil
+}
+
+// ListDeliveries retrieves a page of deliveries of an outgoing webhook, newest first.
+func (s *Service) ListDeliveries(ctx context.Context, req *subscriptionmodel.ListDeliveriesRequest) ([]*subscriptionmodel.Delivery, string, error) {
+	if err := req.Validate(); err != nil {
+		return nil, "", serviceerrors.NewValidationFailedError(err)
+	}
+	id, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := s.get(ctx, id); err != nil {
+		return nil, "", err
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultListPageSize
+	}
+	deliveries, nextPageToken, err := s.repo.ListDeliveries(ctx, id, req.Status, pageSize, req.PageToken)
+	if err != nil {
+		s.log(ctx).Error("failed to list outgoing webhook deliveries", zap.Error(err), zap.String("subscription_id", req.ID))
+		return nil, "", fmt.Errorf("failed to list outgoing webhook deliveries: %w", err)
+	}
+	return deliveries, nextPageToken, nil
+}
+
+// Test sends a test event to an outgoing webhook, also if it is inactive. The delivery is attempted
+// once and logged with its result; a failed test delivery is not an error of the request.
+func (s *Service) Test(ctx context.Context, req *subscriptionmodel.TestRequest) (*subscriptionmodel.Delivery, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	id, err := parsing.StrToUUID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	subscription, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	event := events.NewEvent(events.Type(subscriptionmodel.TestEventType), "", uuid.Nil)
+	delivery, err := newDelivery(subscription.ID, event)
+	if err != nil {
+		return nil, err
+	}
+	delivery.ID, err = uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	status, sendErr := s.send(sendCtx, subscription, delivery)
+	cancel()
+
+	delivery.Attempts = 1
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+	if sendErr != nil {
+		msg := sendErr.Error()
+		delivery.Status = subscriptionmodel.DeliveryStatusFailed
+		delivery.LastError = &msg
+	} else {
+		now := time.Now()
+		delivery.Status = subscriptionmodel.DeliveryStatusDelivered
+		delivery.DeliveredAt = &now
+	}
+	if err := s.repo.Enqueue(ctx, delivery); err != nil {
+		s.log(ctx).Error("failed to log outgoing webhook test delivery", zap.Error(err), zap.String("subscription_id", req.ID))
+		return nil, fmt.Errorf("failed to log outgoing webhook test delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,349 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package uploadproxy implements the upload proxy, which accepts resumable uploads from clients
+// that can not reach the provider directly and relays them to the provider upload URL.
+//
+// Upload sessions are kept in memory, so all requests of an upload must reach the instance
+// which created it.
+package uploadproxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	uploadproxymodel "github.com/mikhail5545/media-service-go/internal/models/uploadproxy"
+	"github.com/mikhail5545/media-service-go/internal/util/parsing"
+	"go.uber.org/zap"
+)
+
+// chunkGranularity is the granularity of the chunk sizes accepted by resumable provider uploads.
+const chunkGranularity = 256 << 10
+
+// sweepInterval is the interval between removals of expired upload sessions.
+const sweepInterval = time.Minute
+
+// TargetCreator creates the provider asset and the upload URL a proxied upload is relayed to.
+type TargetCreator interface {
+	CreateUploadTarget(ctx context.Context, req *uploadproxymodel.CreateRequest) (*uploadproxymodel.Target, error)
+}
+
+type Config struct {
+	// MaxFileSize is the maximum size of a single upload in bytes.
+	MaxFileSize int64
+	// ChunkSize is the size of the chunks relayed to the provider, a multiple of 256 KiB.
+	ChunkSize int64
+	// SessionTTL is the time an idle upload session is kept before it is discarded.
+	SessionTTL time.Duration
+}
+
+type Service struct {
+	cfg      Config
+	creators map[uploadproxymodel.Provider]TargetCreator
+	client   *http.Client
+	logger   *zap.Logger
+
+	mu       sync.Mutex
+	sessions map[uuid.UUID]*session
+}
+
+// session is an upload session. Its mutex is held for the whole append, so concurrent appends
+// of the same upload are rejected instead of interleaving.
+type session struct {
+	mu        sync.Mutex
+	uploadURL string
+	// pending holds the received bytes not relayed to the provider yet.
+	pending []byte
+	info    uploadproxymodel.Session
+}
+
+type NewParams struct {
+	Config Config
+	// Creators maps providers to the services creating their upload targets.
+	Creators map[uploadproxymodel.Provider]TargetCreator
+}
+
+func New(params *NewParams, logger *zap.Logger) (*Service, error) {
+	if params.Config.MaxFileSize <= 0 {
+		return nil, fmt.Errorf("upload proxy max file size must be positive")
+	}
+	if params.Config.ChunkSize <= 0 || params.Config.ChunkSize%chunkGranularity != 0 {
+		return nil, fmt.Errorf("upload proxy chunk size must be a positive multiple of %d bytes", chunkGranularity)
+	}
+	if params.Config.SessionTTL <= 0 {
+		return nil, fmt.Errorf("upload proxy session TTL must be positive")
+	}
+	return &Service{
+		cfg:      params.Config,
+		creators: params.Creators,
+		client: &http.Client{
+			// Resumable uploads answer intermediate chunks with 308, which is not a redirect.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger:   logger.With(zap.String("layer", "service"), zap.String("service", "upload_proxy")),
+		sessions: make(map[uuid.UUID]*session),
+	}, nil
+}
+
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// MaxFileSize returns the maximum size of a single upload in bytes.
+func (s *Service) MaxFileSize() int64 {
+	return s.cfg.MaxFileSize
+}
+
+// Create creates the provider asset for the upload and starts a new upload session.
+func (s *Service) Create(ctx context.Context, req *uploadproxymodel.CreateRequest) (*uploadproxymodel.Session, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	if req.Size > s.cfg.MaxFileSize {
+		return nil, serviceerrors.NewInvalidArgumentError(fmt.Sprintf("upload size exceeds the limit of %d bytes", s.cfg.MaxFileSize))
+	}
+	creator, ok := s.creators[req.Provider]
+	if !ok {
+		return nil, serviceerrors.NewInvalidArgumentError(fmt.Sprintf("provider %q does not support proxied uploads", req.Provider))
+	}
+
+	target, err := creator.CreateUploadTarget(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate upload session id: %w", err)
+	}
+	now := time.Now()
+	sess := &session{
+		uploadURL: target.UploadURL,
+		info: uploadproxymodel.Session{
+			ID:        id,
+			Provider:  req.Provider,
+			AssetID:   target.AssetID,
+			Size:      req.Size,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+	}
+
+	s.mu.Lock()
+	s.sessions[id] = sess
+	s.mu.Unlock()
+
+	s.log(ctx).Info("created upload session",
+		zap.String("upload_id", id.String()),
+		logging.AssetID(target.AssetID),
+		zap.Int64("size", req.Size),
+	)
+	info := sess.info
+	return &info, nil
+}
+
+// Get retrieves the progress of an upload.
+func (s *Service) Get(ctx context.Context, req *uploadproxymodel.GetRequest) (*uploadproxymodel.Session, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	sess, err := s.session(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	info := sess.info
+	return &info, nil
+}
+
+// Append reads the body and relays it to the provider in chunks of the configured size. The last
+// chunk is relayed once the whole file is received.
+//
+// When the body ends early, e.g. the client disconnected, the received bytes are kept and the upload
+// can be resumed from the returned offset. A chunk rejected by the provider stays buffered and is
+// relayed again by the next append.
+func (s *Service) Append(ctx context.Context, req *uploadproxymodel.AppendRequest, body io.Reader) (*uploadproxymodel.Session, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	sess, err := s.session(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !sess.mu.TryLock() {
+		return nil, serviceerrors.NewConflictError("upload is being appended by another request")
+	}
+	defer sess.mu.Unlock()
+
+	if sess.info.Completed {
+		return nil, serviceerrors.NewConflictError("upload is already completed")
+	}
+	if req.Offset != sess.info.Offset {
+		return nil, serviceerrors.NewConflictError(fmt.Sprintf("upload offset mismatch, expected %d", sess.info.Offset))
+	}
+
+	buf := make([]byte, 32<<10)
+	for {
+		n, readErr := body.Read(buf)
+		if n > 0 {
+			if sess.info.Offset+int64(n) > sess.info.Size {
+				return nil, serviceerrors.NewInvalidArgumentError("upload exceeds the declared size")
+			}
+			sess.pending = append(sess.pending, buf[:n]...)
+			sess.info.Offset += int64(n)
+			sess.info.UpdatedAt = time.Now()
+			for int64(len(sess.pending)) >= s.cfg.ChunkSize {
+				if err := s.relay(ctx, sess, s.cfg.ChunkSize); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			s.log(ctx).Warn("upload body ended early", zap.Error(readErr), zap.String("upload_id", req.ID), zap.Int64("offset", sess.info.Offset))
+			info := sess.info
+			return &info, nil
+		}
+	}
+
+	if sess.info.Offset == sess.info.Size {
+		if err := s.relay(ctx, sess, int64(len(sess.pending))); err != nil {
+			return nil, err
+		}
+		sess.info.Completed = true
+		s.log(ctx).Info("upload completed", zap.String("upload_id", req.ID), logging.AssetID(sess.info.AssetID), zap.Int64("size", sess.info.Size))
+	}
+	info := sess.info
+	return &info, nil
+}
+
+// Delete discards an upload session. The asset created for the upload is kept and can be archived
+// with the asset API.
+func (s *Service) Delete(ctx context.Context, req *uploadproxymodel.DeleteRequest) error {
+	if err := req.Validate(); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	sess, err := s.session(req.ID)
+	if err != nil {
+		return err
+	}
+	if !sess.mu.TryLock() {
+		return serviceerrors.NewConflictError("upload is being appended by another request")
+	}
+	defer sess.mu.Unlock()
+
+	s.mu.Lock()
+	delete(s.sessions, sess.info.ID)
+	s.mu.Unlock()
+
+	s.log(ctx).Info("deleted upload session", zap.String("upload_id", req.ID), logging.AssetID(sess.info.AssetID))
+	return nil
+}
+
+// Run periodically discards the sessions idle for longer than the session TTL. It blocks until
+// the provided context is cancelled.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweep(time.Now())
+		}
+	}
+}
+
+func (s *Service) sweep(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, sess := range s.sessions {
+		// Sessions being appended are in use.
+		if !sess.mu.TryLock() {
+			continue
+		}
+		if now.Sub(sess.info.UpdatedAt) > s.cfg.SessionTTL {
+			delete(s.sessions, id)
+			s.logger.Info("discarded expired upload session",
+				zap.String("upload_id", id.String()),
+				logging.AssetID(sess.info.AssetID),
+				zap.Bool("completed", sess.info.Completed),
+			)
+		}
+		sess.mu.Unlock()
+	}
+}
+
+func (s *Service) session(rawID string) (*session, error) {
+	id, err := parsing.StrToUUID(rawID)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[id]
+	if !ok {
+		return nil, serviceerrors.NewNotFoundError("upload not found")
+	}
+	return sess, nil
+}
+
+// relay sends the first n pending bytes to the provider upload URL. The caller must hold the session lock.
+func (s *Service) relay(ctx context.Context, sess *session, n int64) error {
+	if n == 0 {
+		return nil
+	}
+	start := sess.info.Relayed
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, sess.uploadURL, bytes.NewReader(sess.pending[:n]))
+	if err != nil {
+		return fmt.Errorf("failed to build upload chunk request: %w", err)
+	}
+	httpReq.ContentLength = n
+	httpReq.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, start+n-1, sess.info.Size))
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		s.log(ctx).Error("failed to relay upload chunk", zap.Error(err), zap.String("upload_id", sess.info.ID.String()), zap.Int64("offset", start))
+		return serviceerrors.NewUnavailableError("failed to relay upload chunk to the provider")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	// Intermediate chunks are acknowledged with 308, the last one with 200 or 201.
+	if resp.StatusCode != http.StatusPermanentRedirect && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+		s.log(ctx).Error("provider rejected upload chunk", zap.Int("status", resp.StatusCode), zap.String("upload_id", sess.info.ID.String()), zap.Int64("offset", start))
+		return serviceerrors.NewUnavailableError(fmt.Sprintf("provider rejected upload chunk with status %d", resp.StatusCode))
+	}
+	sess.pending = append(sess.pending[:0], sess.pending[n:]...)
+	sess.info.Relayed += n
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package usage provides the service reporting the storage used by the assets of each provider,
+// aggregated by creator and by owner type.
+package usage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	usagemodel "github.com/mikhail5545/media-service-go/internal/models/usage"
+	"go.uber.org/zap"
+)
+
+// Source provides the usage of the assets of a single provider.
+type Source struct {
+	// ByCreator returns the usage grouped by creator.
+	ByCreator func(ctx context.Context) ([]*usagemodel.Usage, error)
+	// List returns the usage of up to limit assets with IDs greater than afterID, ordered by ID.
+	List func(ctx context.Context, afterID uuid.UUID, limit int) ([]*usagemodel.AssetUsage, error)
+	// OwnerTypes returns the distinct owner types of the assets, keyed by asset ID.
+	OwnerTypes func(ctx context.Context, ids []string) (map[string][]string, error)
+}
+
+// UsageService defines the interface for reporting the asset usage.
+type UsageService interface {
+	// Report returns the usage of a provider aggregated by creator and by owner type.
+	Report(ctx context.Context, req *usagemodel.ReportRequest) (*usagemodel.Report, error)
+}
+
+// Service implements the UsageService interface.
+type Service struct {
+	sources map[usagemodel.Provider]Source
+	logger  *zap.Logger
+}
+
+var _ UsageService = (*Service)(nil)
+
+// reportBatchSize limits the number of assets loaded at once while aggregating the usage by owner type.
+const reportBatchSize = 500
+
+type NewParams struct {
+	// Sources maps providers to their usage sources.
+	Sources map[usagemodel.Provider]Source
+}
+
+func New(params *NewParams, logger *zap.Logger) *Service {
+	return &Service{
+		sources: params.Sources,
+		logger:  logger.With(zap.String("layer", "service"), zap.String("service", "usage")),
+	}
+}
+
+// Report returns the usage of a provider aggregated by creator and by owner type. An asset with several
+// owner types is counted once for each of them, assets without owners are reported under [usagemodel.Unowned].
+func (s *Service) Report(ctx context.Context, req *usagemodel.ReportRequest) (*usagemodel.Report, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	source, ok := s.sources[req.Provider]
+	if !ok {
+		return nil, serviceerrors.NewNotFoundError(fmt.Errorf("usage of provider %q is not accounted", req.Provider))
+	}
+
+	byCreator, err := source.ByCreator(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve usage by creator: %w", err)
+	}
+	total := &usagemodel.Usage{}
+	for _, usage := range byCreator {
+		total.Assets += usage.Assets
+		total.Bytes += usage.Bytes
+		total.Duration += usage.Duration
+	}
+
+	byOwnerType, err := s.usageByOwnerType(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	return &usagemodel.Report{
+		Provider:    req.Provider,
+		Total:       total,
+		ByCreator:   byCreator,
+		ByOwnerType: byOwnerType,
+	}, nil
+}
+
+// usageByOwnerType pages through all assets of the source and aggregates their usage by owner type.
+func (s *Service) usageByOwnerType(ctx context.Context, source Source) ([]*usagemodel.Usage, error) {
+	usages := make(map[string]*usagemodel.Usage)
+	add := func(ownerType string, asset *usagemodel.AssetUsage) {
+		usage, ok := usages[ownerType]
+		if !ok {
+			usage = &usagemodel.Usage{OwnerType: ownerType}
+			usages[ownerType] = usage
+		}
+		usage.Assets++
+		usage.Bytes += asset.Bytes
+		usage.Duration += asset.Duration
+	}
+
+	afterID := uuid.Nil
+	for {
+		assets, err := source.List(ctx, afterID, reportBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list asset usage: %w", err)
+		}
+		if len(assets) == 0 {
+			break
+		}
+		ids := make([]string, len(assets))
+		for i, asset := range assets {
+			ids[i] = asset.ID.String()
+		}
+		ownerTypes, err := source.OwnerTypes(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve asset owners: %w", err)
+		}
+		for _, asset := range assets {
+			types := ownerTypes[asset.ID.String()]
+			if len(types) == 0 {
+				add(usagemodel.Unowned, asset)
+				continue
+			}
+			for _, ownerType := range types {
+				add(ownerType, asset)
+			}
+		}
+		if len(assets) < reportBatchSize {
+			break
+		}
+		afterID = assets[len(assets)-1].ID
+	}
+
+	result := make([]*usagemodel.Usage, 0, len(usages))
+	for _, usage := range usages {
+		result = append(result, usage)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Assets != result[j].Assets {
+			return result[i].Assets > result[j].Assets
+		}
+		return result[i].OwnerType < result[j].OwnerType
+	})
+	return result, nil
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package watermark provides the service for managing the watermarks of creators and owner types.
+package watermark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	watermarkrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/watermark"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/logging"
+	watermarkmodel "github.com/mikhail5545/media-service-go/internal/models/watermark"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// WatermarkService defines the interface for managing watermarks.
+type WatermarkService interface {
+	// Get retrieves the watermark of a creator or an owner type.
+	Get(ctx context.Context, req *watermarkmodel.GetRequest) (*watermarkmodel.Watermark, error)
+	// List retrieves the watermarks, optionally of a single scope.
+	List(ctx context.Context, req *watermarkmodel.ListRequest) ([]*watermarkmodel.Watermark, error)
+	// Set creates or replaces the watermark of a creator or an owner type.
+	Set(ctx context.Context, req *watermarkmodel.SetRequest) (*watermarkmodel.Watermark, error)
+	// Delete removes the watermark of a creator or an owner type.
+	Delete(ctx context.Context, req *watermarkmodel.DeleteRequest) error
+	// Resolve returns the watermark applied to an asset of the creator and the owner types, nil if none is configured.
+	Resolve(ctx context.Context, creatorID string, ownerTypes []string) (*watermarkmodel.Watermark, error)
+}
+
+// Service implements the WatermarkService interface.
+type Service struct {
+	repo   *watermarkrepo.Repository
+	logger *zap.Logger
+}
+
+var _ WatermarkService = (*Service)(nil)
+
+const (
+	defaultWidth   = 20
+	defaultOpacity = 100
+)
+
+func New(repo *watermarkrepo.Repository, logger *zap.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		logger: logger.With(zap.String("layer", "service"), zap.String("service", "watermark")),
+	}
+}
+
+// log returns the service logger enriched with the request-scoped fields stored in ctx.
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// Get retrieves the watermark of a creator or an owner type.
+func (s *Service) Get(ctx context.Context, req *watermarkmodel.GetRequest) (*watermarkmodel.Watermark, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	watermark, err := s.repo.Get(ctx, req.Scope, req.Key)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, serviceerrors.NewNotFoundError(err)
+		}
+		s.log(ctx).Error("failed to retrieve watermark", zap.Error(err), zap.String("scope", string(req.Scope)), zap.String("key", req.Key))
+		return nil, fmt.Errorf("failed to retrieve watermark: %w", err)
+	}
+	return watermark, nil
+}
+
+// List retrieves the watermarks, optionally of a single scope.
+func (s *Service) List(ctx context.Context, req *watermarkmodel.ListRequest) ([]*watermarkmodel.Watermark, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	watermarks, err := s.repo.List(ctx, req.Scope)
+	if err != nil {
+		s.log(ctx).Error("failed to list watermarks", zap.Error(err))
+		return nil, fmt.Errorf("failed to list watermarks: %w", err)
+	}
+	return watermarks, nil
+}
+
+// Set creates or replaces the watermark of a creator or an owner type. Images are watermarked on
+// their next delivery, MUX videos only when they are created.
+func (s *Service) Set(ctx context.Context, req *watermarkmodel.SetRequest) (*watermarkmodel.Watermark, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	watermark := &watermarkmodel.Watermark{
+		Scope:              req.Scope,
+		Key:                req.Key,
+		CloudinaryPublicID: req.CloudinaryPublicID,
+		ImageURL:           req.ImageURL,
+		Position:           req.Position,
+		Margin:             req.Margin,
+		Width:              req.Width,
+		Opacity:            req.Opacity,
+	}
+	if watermark.Width == 0 {
+		watermark.Width = defaultWidth
+	}
+	if watermark.Opacity == 0 {
+		watermark.Opacity = defaultOpacity
+	}
+	if err := s.repo.Upsert(ctx, watermark); err != nil {
+		s.log(ctx).Error("failed to save watermark", zap.Error(err), zap.String("scope", string(req.Scope)), zap.String("key", req.Key))
+		return nil, fmt.Errorf("failed to save watermark: %w", err)
+	}
+	s.log(ctx).Info("watermark saved", zap.String("scope", string(req.Scope)), zap.String("key", req.Key))
+	return s.Get(ctx, &watermarkmodel.GetRequest{Scope: req.Scope, Key: req.Key})
+}
+
+// Delete removes the watermark of a creator or an owner type. MUX videos already watermarked keep
+// their watermark.
+func (s *Service) Delete(ctx context.Context, req *watermarkmodel.DeleteRequest) error {
+	if err := req.Validate(); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	affected, err := s.repo.Delete(ctx, req.Scope, req.Key)
+	if err != nil {
+		s.log(ctx).Error("failed to delete watermark", zap.Error(err), zap.String("scope", string(req.Scope)), zap.String("key", req.Key))
+		return fmt.Errorf("failed to delete watermark: %w", err)
+	}
+	if affected == 0 {
+		return serviceerrors.NewNotFoundError("watermark not found")
+	}
+	return nil
+}
+
+// Resolve returns the watermark applied to an asset of the creator and the owner types, nil if none
+// is configured. The creator watermark takes precedence, then the owner types in the provided order.
+func (s *Service) Resolve(ctx context.Context, creatorID string, ownerTypes []string) (*watermarkmodel.Watermark, error) {
+	watermarks, err := s.repo.Find(ctx, creatorID, ownerTypes)
+	if err != nil {
+		s.log(ctx).Error("failed to find watermarks", zap.Error(err), zap.String("creator_id", creatorID))
+		return nil, fmt.Errorf("failed to find watermarks: %w", err)
+	}
+	byKey := make(map[watermarkmodel.Scope]map[string]*watermarkmodel.Watermark, 2)
+	for _, watermark := range watermarks {
+		if byKey[watermark.Scope] == nil {
+			byKey[watermark.Scope] = make(map[string]*watermarkmodel.Watermark)
+		}
+		byKey[watermark.Scope][watermark.Key] = watermark
+	}
+	if watermark, ok := byKey[watermarkmodel.ScopeCreator][creatorID]; ok {
+		return watermark, nil
+	}
+	for _, ownerType := range ownerTypes {
+		if watermark, ok := byKey[watermarkmodel.ScopeOwnerType][ownerType]; ok {
+			return watermark, nil
+		}
+	}
+	return nil, nil
+}
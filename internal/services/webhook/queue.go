@@ -0,0 +1,369 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package webhook implements the webhook ingestion queue. The webhook endpoints persist verified
+// provider notifications and acknowledge them right away, the queue processes them asynchronously
+// with a bounded number of workers and retries failed events according to their retry policy.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	webhookrepo "github.com/mikhail5545/media-service-go/internal/database/postgres/webhook"
+	"github.com/mikhail5545/media-service-go/internal/environment"
+	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	"github.com/mikhail5545/media-service-go/internal/metrics"
+	webhookmodel "github.com/mikhail5545/media-service-go/internal/models/webhook"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Handler processes the raw payload of a single verified webhook.
+type Handler func(ctx context.Context, payload []byte) error
+
+// HandlerProvider is implemented by services that receive provider webhooks.
+type HandlerProvider interface {
+	// WebhookHandlers returns processing handlers for the webhooks of all providers handled by the service.
+	WebhookHandlers() map[webhookmodel.Provider]Handler
+}
+
+// RetryPolicy controls how often and how fast failed webhook events are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the number of processing attempts after which the event is marked as failed.
+	MaxAttempts int
+	// BaseBackoff is the delay before the first retry. Each next retry doubles the delay.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+}
+
+// Config holds webhook queue configuration.
+type Config struct {
+	// PollInterval is the time between two consecutive polls for due events. Enqueued events are
+	// processed without waiting for the next poll.
+	PollInterval time.Duration
+	// BatchSize limits the number of events claimed in a single poll.
+	BatchSize int
+	// Concurrency limits the number of events processed at the same time.
+	Concurrency int
+	// ProcessTimeout limits the duration of a single processing attempt.
+	ProcessTimeout time.Duration
+	// Retry is the retry policy of the events without a policy in Policies.
+	Retry RetryPolicy
+	// Policies override the retry policy per provider ("mux") or per event type of a provider
+	// ("mux:video.asset.ready"). The event type policy takes precedence.
+	Policies map[string]RetryPolicy
+}
+
+// DefaultConfig returns the default webhook queue configuration.
+func DefaultConfig() Config {
+	return Config{
+		PollInterval:   time.Second,
+		BatchSize:      50,
+		Concurrency:    8,
+		ProcessTimeout: 30 * time.Second,
+		Retry: RetryPolicy{
+			MaxAttempts: 10,
+			BaseBackoff: time.Second,
+			MaxBackoff:  10 * time.Minute,
+		},
+	}
+}
+
+// Queue persists received webhooks and processes them using the registered handlers.
+type Queue struct {
+	cfg      Config
+	repo     *webhookrepo.Repository
+	handlers map[webhookmodel.Provider]Handler
+	metrics  *metrics.Metrics
+	// wake triggers a poll right after an event was enqueued.
+	wake   chan struct{}
+	logger *zap.Logger
+}
+
+type NewParams struct {
+	Config    Config
+	Repo      *webhookrepo.Repository
+	Providers []HandlerProvider
+	// Metrics is optional, processed webhooks are not counted when nil.
+	Metrics *metrics.Metrics
+}
+
+func New(params *NewParams, logger *zap.Logger) (*Queue, error) {
+	if params.Config.Concurrency <= 0 {
+		return nil, fmt.Errorf("webhook queue concurrency must be positive")
+	}
+	handlers := make(map[webhookmodel.Provider]Handler)
+	for _, provider := range params.Providers {
+		for p, handler := range provider.WebhookHandlers() {
+			if _, exists := handlers[p]; exists {
+				return nil, fmt.Errorf("duplicate webhook handler for provider %q", p)
+			}
+			handlers[p] = handler
+		}
+	}
+	return &Queue{
+		cfg:      params.Config,
+		repo:     params.Repo,
+		handlers: handlers,
+		metrics:  params.Metrics,
+		wake:     make(chan struct{}, 1),
+		logger:   logger.With(zap.String("layer", "worker"), zap.String("worker", "webhooks")),
+	}, nil
+}
+
+const defaultListErrorsLimit = 50
+
+// ListErrors lists the most recent webhook events whose processing failed, without their payloads.
+func (q *Queue) ListErrors(ctx context.Context, req *webhookmodel.ListErrorsRequest) ([]*webhookmodel.Event, error) {
+	if err := req.Validate(); err != nil {
+		return nil, serviceerrors.NewValidationFailedError(err)
+	}
+	limit := defaultListErrorsLimit
+	if req.Limit > 0 {
+		limit = req.Limit
+	}
+	events, err := q.repo.ListErrors(ctx, req.Provider, limit)
+	if err != nil {
+		q.logger.Error("failed to list webhook errors", zap.Error(err))
+		return nil, fmt.Errorf("failed to list webhook errors: %w", err)
+	}
+	return events, nil
+}
+
+// EnqueueOption sets optional fields of an enqueued event.
+type EnqueueOption func(event *webhookmodel.Event)
+
+// InEnvironment processes the event in the environment the webhook was verified in.
+func InEnvironment(name string) EnqueueOption {
+	return func(event *webhookmodel.Event) {
+		event.SourceEnvironment = &name
+	}
+}
+
+// ForObject processes the event after the earlier events of the object, e.g. of the same asset.
+func ForObject(id string) EnqueueOption {
+	return func(event *webhookmodel.Event) {
+		if id != "" {
+			event.ObjectID = &id
+		}
+	}
+}
+
+// Enqueue persists the verified webhook of the provider for asynchronous processing. Once it returns
+// nil, the webhook can be acknowledged.
+func (q *Queue) Enqueue(ctx context.Context, provider webhookmodel.Provider, eventType string, payload []byte, opts ...EnqueueOption) error {
+	if _, ok := q.handlers[provider]; !ok {
+		return fmt.Errorf("no webhook handler registered for provider %q", provider)
+	}
+	event := webhookmodel.NewEvent(provider, eventType, payload)
+	for _, opt := range opts {
+		opt(event)
+	}
+	if err := q.repo.Enqueue(ctx, event); err != nil {
+		return fmt.Errorf("failed to enqueue webhook: %w", err)
+	}
+	q.notify()
+	return nil
+}
+
+// Replay schedules a stored webhook event, typically a failed one, for immediate reprocessing with a
+// fresh attempt budget. The last error is kept until the next attempt completes.
+func (q *Queue) Replay(ctx context.Context, req *webhookmodel.ReplayRequest) error {
+	if err := req.Validate(); err != nil {
+		return serviceerrors.NewValidationFailedError(err)
+	}
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		return serviceerrors.NewInvalidArgumentError(err)
+	}
+	if err := q.repo.Requeue(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return serviceerrors.NewNotFoundError(fmt.Errorf("webhook event %s not found", id))
+		}
+		q.logger.Error("failed to requeue webhook event", zap.Error(err), zap.String("event_id", req.ID))
+		return fmt.Errorf("failed to requeue webhook event: %w", err)
+	}
+	q.logger.Info("webhook event requeued", zap.String("event_id", req.ID))
+	q.notify()
+	return nil
+}
+
+func (q *Queue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run starts the queue loop. It blocks until the provided context is cancelled and the events being
+// processed are done.
+func (q *Queue) Run(ctx context.Context) {
+	q.logger.Info("webhook queue started",
+		zap.Duration("poll_interval", q.cfg.PollInterval),
+		zap.Int("concurrency", q.cfg.Concurrency),
+	)
+
+	ticker := time.NewTicker(q.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			q.logger.Info("webhook queue stopped")
+			return
+		case <-ticker.C:
+		case <-q.wake:
+		}
+		if err := q.drain(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			q.logger.Error("failed to process webhook events", zap.Error(err))
+		}
+	}
+}
+
+// ProcessOnce claims one batch of due events and processes them.
+func (q *Queue) ProcessOnce(ctx context.Context) error {
+	_, err := q.processBatch(ctx)
+	return err
+}
+
+// drain processes batches until no more events are due, so a burst of webhooks does not wait for
+// the next poll after every batch.
+func (q *Queue) drain(ctx context.Context) error {
+	for {
+		n, err := q.processBatch(ctx)
+		if err != nil || n < q.cfg.BatchSize {
+			return err
+		}
+	}
+}
+
+// processBatch claims one batch of due events, processes them with at most Concurrency workers and
+// returns the number of claimed events.
+func (q *Queue) processBatch(ctx context.Context) (int, error) {
+	// Lease claimed events for the whole batch duration, so they are not picked up by another
+	// instance while this one is still processing them.
+	rounds := (q.cfg.BatchSize + q.cfg.Concurrency - 1) / q.cfg.Concurrency
+	lease := time.Duration(rounds) * q.cfg.ProcessTimeout
+	events, err := q.repo.ClaimDue(ctx, q.cfg.BatchSize, lease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook events: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, q.cfg.Concurrency)
+	for _, event := range events {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return len(events), ctx.Err()
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			q.process(ctx, event)
+		}()
+	}
+	wg.Wait()
+	return len(events), nil
+}
+
+func (q *Queue) process(ctx context.Context, event *webhookmodel.Event) {
+	logger := q.logger.With(
+		zap.String("event_id", event.ID.String()),
+		zap.String("provider", string(event.Provider)),
+		zap.String("event_type", event.Type),
+		zap.Int("attempt", event.Attempts+1),
+	)
+
+	handler, ok := q.handlers[event.Provider]
+	if !ok {
+		logger.Error("no webhook handler registered for provider")
+		q.markFailed(ctx, logger, event, "no handler registered for provider")
+		return
+	}
+
+	processCtx, cancel := context.WithTimeout(ctx, q.cfg.ProcessTimeout)
+	if event.SourceEnvironment != nil {
+		processCtx = environment.WithContext(processCtx, *event.SourceEnvironment)
+	}
+	err := handler(processCtx, event.Payload)
+	cancel()
+	q.metrics.ObserveWebhook(string(event.Provider), event.Type, err)
+
+	policy := q.policy(event)
+	switch {
+	case err == nil:
+		if err := q.repo.MarkProcessed(ctx, event.ID); err != nil {
+			logger.Error("failed to mark webhook event as processed", zap.Error(err))
+		}
+	case isPermanent(err) || event.Attempts+1 >= policy.MaxAttempts:
+		logger.Error("webhook event processing failed permanently", zap.Error(err))
+		q.markFailed(ctx, logger, event, err.Error())
+	default:
+		next := time.Now().Add(backoff(policy, event.Attempts+1))
+		logger.Warn("webhook event processing failed, scheduling retry", zap.Error(err), zap.Time("next_attempt_at", next))
+		if err := q.repo.MarkRetry(ctx, event.ID, next, err.Error()); err != nil {
+			logger.Error("failed to schedule webhook event retry", zap.Error(err))
+		}
+	}
+}
+
+func (q *Queue) markFailed(ctx context.Context, logger *zap.Logger, event *webhookmodel.Event, reason string) {
+	if err := q.repo.MarkFailed(ctx, event.ID, reason); err != nil {
+		logger.Error("failed to mark webhook event as failed", zap.Error(err))
+	}
+}
+
+// policy returns the retry policy of the event type, of the provider or the default one.
+func (q *Queue) policy(event *webhookmodel.Event) RetryPolicy {
+	if policy, ok := q.cfg.Policies[string(event.Provider)+":"+event.Type]; ok {
+		return policy
+	}
+	if policy, ok := q.cfg.Policies[string(event.Provider)]; ok {
+		return policy
+	}
+	return q.cfg.Retry
+}
+
+// backoff returns the exponential delay before the specified attempt, capped by MaxBackoff.
+func backoff(policy RetryPolicy, attempt int) time.Duration {
+	delay := policy.BaseBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= policy.MaxBackoff {
+			return policy.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// isPermanent reports whether the processing error cannot be fixed by retrying.
+func isPermanent(err error) bool {
+	return errors.Is(err, serviceerrors.ErrValidationFailed) ||
+		errors.Is(err, serviceerrors.ErrInvalidArgument) ||
+		errors.Is(err, serviceerrors.ErrPermissionDenied)
+}
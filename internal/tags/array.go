@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package tags
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Array is a list of tags stored in a Postgres varchar array column. The pgx database/sql driver
+// returns arrays in their text form, which a plain []string cannot be scanned from.
+type Array []string
+
+// Value implements [driver.Valuer], the tags are stored as an array literal and nil tags as NULL.
+func (a Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	buf, err := pgtype.NewMap().Encode(pgtype.VarcharArrayOID, pgtype.TextFormatCode, []string(a), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tags: %w", err)
+	}
+	return string(buf), nil
+}
+
+// Scan implements [sql.Scanner].
+func (a *Array) Scan(value any) error {
+	var tags []string
+	if err := pgtype.NewMap().SQLScanner(&tags).Scan(value); err != nil {
+		return fmt.Errorf("failed to scan tags: %w", err)
+	}
+	*a = tags
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package tags
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var roundTripCases = []struct {
+	name string
+	tags Array
+}{
+	{name: "nil", tags: nil},
+	{name: "empty", tags: Array{}},
+	{name: "single", tags: Array{"nature"}},
+	{name: "several", tags: Array{"nature", "sea:blue", "2026/summer"}},
+	// Cloudinary generated tags are stored as they are, they need quoting in the array literal.
+	{name: "quoted", tags: Array{"with space", `with "quotes"`, `back\slash`, "comma,separated", "NULL", "{braces}"}},
+	{name: "unicode", tags: Array{"море", "日本"}},
+}
+
+func TestArrayRoundTrip(t *testing.T) {
+	for _, tt := range roundTripCases {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.tags.Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The driver returns the text form either as a string or as bytes.
+			sources := []any{value}
+			if s, ok := value.(string); ok {
+				sources = append(sources, []byte(s))
+			}
+			for _, src := range sources {
+				var got Array
+				if err := got.Scan(src); err != nil {
+					t.Fatalf("Scan(%#v): %v", src, err)
+				}
+				if !slices.Equal(got, tt.tags) || (got == nil) != (tt.tags == nil) {
+					t.Errorf("Scan(%#v) = %#v, want %#v", src, got, tt.tags)
+				}
+			}
+		})
+	}
+}
+
+// TestArrayPostgresRoundTrip stores the tags in a Postgres varchar array column and reads them back
+// through the pgx database/sql driver GORM uses. It runs only if MEDIA_TEST_POSTGRES_DSN is set.
+func TestArrayPostgresRoundTrip(t *testing.T) {
+	dsn := os.Getenv("MEDIA_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("MEDIA_TEST_POSTGRES_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type row struct {
+		ID   int
+		Tags Array `gorm:"type:varchar(128)[]"`
+	}
+	for _, tt := range roundTripCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec("CREATE TEMPORARY TABLE tags_round_trip (id serial PRIMARY KEY, tags varchar(128)[]) ON COMMIT DROP").Error; err != nil {
+					return err
+				}
+				in := &row{Tags: tt.tags}
+				if err := tx.Table("tags_round_trip").Create(in).Error; err != nil {
+					return err
+				}
+				var out row
+				if err := tx.Table("tags_round_trip").Where("id = ?", in.ID).Take(&out).Error; err != nil {
+					return err
+				}
+				if !slices.Equal(out.Tags, tt.tags) || (out.Tags == nil) != (tt.tags == nil) {
+					t.Errorf("read %#v, want %#v", out.Tags, tt.tags)
+				}
+				// The tags must be stored as an array, not as the literal of one.
+				var count int
+				if err := tx.Raw("SELECT COALESCE(cardinality(tags), -1) FROM tags_round_trip WHERE id = ?", in.ID).Scan(&count).Error; err != nil {
+					return err
+				}
+				want := len(tt.tags)
+				if tt.tags == nil {
+					want = -1
+				}
+				if count != want {
+					t.Errorf("stored %d tags, want %d", count, want)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
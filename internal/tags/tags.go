@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package tags normalizes and validates the tags of media assets. Tags work the same way for every
+// provider: they are stored lower case, without surrounding whitespace and without duplicates.
+package tags
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	// MaxLength is the maximum length of a single tag, it matches the tags column type.
+	MaxLength = 128
+	// MaxPerAsset is the maximum number of tags of a single asset.
+	MaxPerAsset = 50
+)
+
+// pattern excludes commas, which separate tags in the provider APIs.
+var pattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_\-.:/]*$`)
+
+// Normalize returns the normalized, sorted and deduplicated tags.
+func Normalize(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = normalize(tag); tag != "" {
+			normalized = append(normalized, tag)
+		}
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// Add returns existing extended with the added tags.
+func Add(existing, added []string) []string {
+	return Normalize(append(slices.Clone(existing), added...))
+}
+
+// Remove returns existing without the removed tags.
+func Remove(existing, removed []string) []string {
+	removedSet := Normalize(removed)
+	return slices.DeleteFunc(Normalize(existing), func(tag string) bool {
+		_, found := slices.BinarySearch(removedSet, tag)
+		return found
+	})
+}
+
+// Rule returns the ozzo-validation rule for a list of tags.
+func Rule() validation.Rule {
+	return validation.Each(validation.By(isValidTag))
+}
+
+func isValidTag(value any) error {
+	tag, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("must be a string")
+	}
+	tag = normalize(tag)
+	if tag == "" {
+		return fmt.Errorf("must not be blank")
+	}
+	if len(tag) > MaxLength {
+		return fmt.Errorf("must be at most %d characters long", MaxLength)
+	}
+	if !pattern.MatchString(tag) {
+		return fmt.Errorf("must contain only letters, digits and the _ - . : / characters")
+	}
+	return nil
+}
+
+func normalize(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
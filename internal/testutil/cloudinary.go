@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package testutil
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/google/uuid"
+	apiclient "github.com/mikhail5545/media-service-go/internal/apiclients/cloudinary"
+)
+
+// CloudinaryAPIKey is the API key reported by CloudinaryClient.
+const CloudinaryAPIKey = "test-api-key"
+
+// CloudinaryClient is a fake of the Cloudinary API client.
+type CloudinaryClient struct {
+	Recorder
+
+	SignUploadParamsFunc            func(ctx context.Context, params url.Values) (string, error)
+	VerifyNotificationSignatureFunc func(ctx context.Context, params *apiclient.VerificationParams) bool
+	AddTagsFunc                     func(ctx context.Context, publicID, resourceType string, tags []string) error
+	RemoveTagsFunc                  func(ctx context.Context, publicID, resourceType string, tags []string) error
+	UpdateModerationFunc            func(ctx context.Context, publicID, resourceType, status string) error
+	DeleteAssetFunc                 func(ctx context.Context, publicID string, resourceType string) error
+	ListAssetsFunc                  func(ctx context.Context, resourceType string, maxResults int, nextCursor string) (*admin.AssetsResult, error)
+	UploadFunc                      func(ctx context.Context, file io.Reader, params *apiclient.UploadParams) (*uploader.UploadResult, error)
+	EnrichFunc                      func(ctx context.Context, publicID, resourceType string, params *apiclient.EnrichParams) (*apiclient.EnrichResult, error)
+	ListBackupVersionsFunc          func(ctx context.Context, publicID, resourceType string) ([]apiclient.BackupVersion, error)
+	RestoreAssetFunc                func(ctx context.Context, publicID, resourceType, versionID string) (*api.BriefAssetResult, error)
+	GetUsageFunc                    func(ctx context.Context, date time.Time) (*admin.UsageResult, error)
+}
+
+var _ apiclient.APIClient = (*CloudinaryClient)(nil)
+
+// SignUploadParams returns a fixed signature by default.
+func (c *CloudinaryClient) SignUploadParams(ctx context.Context, params url.Values) (string, error) {
+	c.record("SignUploadParams", params)
+	if c.SignUploadParamsFunc != nil {
+		return c.SignUploadParamsFunc(ctx, params)
+	}
+	return "test-signature", nil
+}
+
+// VerifyNotificationSignature accepts every notification by default.
+func (c *CloudinaryClient) VerifyNotificationSignature(ctx context.Context, params *apiclient.VerificationParams) bool {
+	c.record("VerifyNotificationSignature", params)
+	if c.VerifyNotificationSignatureFunc != nil {
+		return c.VerifyNotificationSignatureFunc(ctx, params)
+	}
+	return true
+}
+
+func (c *CloudinaryClient) GetApiKey(context.Context) string {
+	return CloudinaryAPIKey
+}
+
+func (c *CloudinaryClient) AddTags(ctx context.Context, publicID, resourceType string, tags []string) error {
+	c.record("AddTags", publicID, resourceType, tags)
+	if c.AddTagsFunc != nil {
+		return c.AddTagsFunc(ctx, publicID, resourceType, tags)
+	}
+	return nil
+}
+
+func (c *CloudinaryClient) RemoveTags(ctx context.Context, publicID, resourceType string, tags []string) error {
+	c.record("RemoveTags", publicID, resourceType, tags)
+	if c.RemoveTagsFunc != nil {
+		return c.RemoveTagsFunc(ctx, publicID, resourceType, tags)
+	}
+	return nil
+}
+
+func (c *CloudinaryClient) UpdateModeration(ctx context.Context, publicID, resourceType, status string) error {
+	c.record("UpdateModeration", publicID, resourceType, status)
+	if c.UpdateModerationFunc != nil {
+		return c.UpdateModerationFunc(ctx, publicID, resourceType, status)
+	}
+	return nil
+}
+
+func (c *CloudinaryClient) DeleteAsset(ctx context.Context, publicID string, resourceType string) error {
+	c.record("DeleteAsset", publicID, resourceType)
+	if c.DeleteAssetFunc != nil {
+		return c.DeleteAssetFunc(ctx, publicID, resourceType)
+	}
+	return nil
+}
+
+// ListAssets returns no assets by default.
+func (c *CloudinaryClient) ListAssets(ctx context.Context, resourceType string, maxResults int, nextCursor string) (*admin.AssetsResult, error) {
+	c.record("ListAssets", resourceType, maxResults, nextCursor)
+	if c.ListAssetsFunc != nil {
+		return c.ListAssetsFunc(ctx, resourceType, maxResults, nextCursor)
+	}
+	return &admin.AssetsResult{}, nil
+}
+
+// Upload consumes the file and returns an uploaded image with the requested public ID by default.
+func (c *CloudinaryClient) Upload(ctx context.Context, file io.Reader, params *apiclient.UploadParams) (*uploader.UploadResult, error) {
+	c.record("Upload", params)
+	if c.UploadFunc != nil {
+		return c.UploadFunc(ctx, file, params)
+	}
+	size, err := io.Copy(io.Discard, file)
+	if err != nil {
+		return nil, err
+	}
+	publicID := params.PublicID
+	if publicID == "" {
+		publicID = uuid.NewString()
+	}
+	path := "res.cloudinary.example.test/image/upload/" + strings.TrimPrefix(publicID, "/") + ".jpg"
+	return &uploader.UploadResult{
+		AssetID:      uuid.NewString(),
+		PublicID:     publicID,
+		ResourceType: "image",
+		Format:       "jpg",
+		Bytes:        int(size),
+		CreatedAt:    time.Now(),
+		URL:          "http://" + path,
+		SecureURL:    "https://" + path,
+	}, nil
+}
+
+// Enrich returns an empty result by default.
+func (c *CloudinaryClient) Enrich(ctx context.Context, publicID, resourceType string, params *apiclient.EnrichParams) (*apiclient.EnrichResult, error) {
+	c.record("Enrich", publicID, resourceType, params)
+	if c.EnrichFunc != nil {
+		return c.EnrichFunc(ctx, publicID, resourceType, params)
+	}
+	return &apiclient.EnrichResult{}, nil
+}
+
+// ListBackupVersions returns no versions by default.
+func (c *CloudinaryClient) ListBackupVersions(ctx context.Context, publicID, resourceType string) ([]apiclient.BackupVersion, error) {
+	c.record("ListBackupVersions", publicID, resourceType)
+	if c.ListBackupVersionsFunc != nil {
+		return c.ListBackupVersionsFunc(ctx, publicID, resourceType)
+	}
+	return nil, nil
+}
+
+// RestoreAsset returns the restored image with the public ID by default.
+func (c *CloudinaryClient) RestoreAsset(ctx context.Context, publicID, resourceType, versionID string) (*api.BriefAssetResult, error) {
+	c.record("RestoreAsset", publicID, resourceType, versionID)
+	if c.RestoreAssetFunc != nil {
+		return c.RestoreAssetFunc(ctx, publicID, resourceType, versionID)
+	}
+	return &api.BriefAssetResult{
+		PublicID:  publicID,
+		AssetType: resourceType,
+		Version:   1,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// GetUsage returns an empty usage report by default.
+func (c *CloudinaryClient) GetUsage(ctx context.Context, date time.Time) (*admin.UsageResult, error) {
+	c.record("GetUsage", date)
+	if c.GetUsageFunc != nil {
+		return c.GetUsageFunc(ctx, date)
+	}
+	return &admin.UsageResult{}, nil
+}
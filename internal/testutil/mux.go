@@ -0,0 +1,239 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package testutil
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	apiclient "github.com/mikhail5545/media-service-go/internal/apiclients/mux"
+	mux "github.com/muxinc/mux-go/v6"
+)
+
+// MuxClient is a fake of the MUX API client.
+type MuxClient struct {
+	Recorder
+
+	CreateDirectUploadURLFunc      func(ctx context.Context, meta *mux.AssetMetadata, drmConfigurationID string, overlays []mux.InputSettings, encoding apiclient.EncodingSettings, policies ...mux.PlaybackPolicy) (*mux.UploadResponse, error)
+	DeleteAssetFunc                func(ctx context.Context, assetID string) error
+	UpdateAssetFunc                func(ctx context.Context, assetID string, update *mux.UpdateAssetRequest) error
+	CreatePlaybackIDFunc           func(ctx context.Context, assetID string, policy mux.PlaybackPolicy) (*mux.PlaybackId, error)
+	DeletePlaybackIDFunc           func(ctx context.Context, assetID, playbackID string) error
+	GetAssetFunc                   func(ctx context.Context, assetID string) (*mux.Asset, error)
+	GetDirectUploadFunc            func(ctx context.Context, uploadID string) (*mux.Upload, error)
+	CancelDirectUploadFunc         func(ctx context.Context, uploadID string) error
+	ListAssetsFunc                 func(ctx context.Context, limit, page int32) ([]mux.Asset, error)
+	ListAssetViewsFunc             func(ctx context.Context, from, to time.Time, limit, page int32) ([]mux.BreakdownValue, error)
+	GetTranscriptFunc              func(ctx context.Context, playbackID, trackID string) (string, error)
+	CreatePlaybackRestrictionFunc  func(ctx context.Context, referrer mux.ReferrerDomainRestriction) (*mux.PlaybackRestriction, error)
+	UpdatePlaybackRestrictionFunc  func(ctx context.Context, restrictionID string, referrer mux.ReferrerDomainRestriction) error
+	DeletePlaybackRestrictionFunc  func(ctx context.Context, restrictionID string) error
+	CreateSigningKeyFunc           func(ctx context.Context) (*mux.SigningKey, error)
+	DeleteSigningKeyFunc           func(ctx context.Context, keyID string) error
+	GeneratePlaybackJWTTokenFunc   func(ctx context.Context, opts apiclient.GeneratePlaybackTokenOptions) (string, error)
+	GenerateDRMLicenseJWTTokenFunc func(ctx context.Context, opts apiclient.GeneratePlaybackTokenOptions) (string, error)
+	GenerateThumbnailJWTTokenFunc  func(ctx context.Context, opts apiclient.GeneratePlaybackTokenOptions) (string, error)
+	// VerifyWebhookSignatureFunc also makes VerifiesWebhooks report true.
+	VerifyWebhookSignatureFunc func(payload []byte, header string) error
+}
+
+var _ apiclient.APIClient = (*MuxClient)(nil)
+
+// CreateDirectUploadURL returns a waiting upload with a random id by default.
+func (c *MuxClient) CreateDirectUploadURL(ctx context.Context, meta *mux.AssetMetadata, drmConfigurationID string, overlays []mux.InputSettings, encoding apiclient.EncodingSettings, policies ...mux.PlaybackPolicy) (*mux.UploadResponse, error) {
+	c.record("CreateDirectUploadURL", meta, drmConfigurationID, overlays, encoding, policies)
+	if c.CreateDirectUploadURLFunc != nil {
+		return c.CreateDirectUploadURLFunc(ctx, meta, drmConfigurationID, overlays, encoding, policies...)
+	}
+	id := uuid.NewString()
+	return &mux.UploadResponse{Data: mux.Upload{
+		Id:         id,
+		Timeout:    3600,
+		Status:     "waiting",
+		CorsOrigin: "*",
+		Url:        "https://storage.example.test/mux/uploads/" + id,
+	}}, nil
+}
+
+func (c *MuxClient) DeleteAsset(ctx context.Context, assetID string) error {
+	c.record("DeleteAsset", assetID)
+	if c.DeleteAssetFunc != nil {
+		return c.DeleteAssetFunc(ctx, assetID)
+	}
+	return nil
+}
+
+func (c *MuxClient) UpdateAsset(ctx context.Context, assetID string, update *mux.UpdateAssetRequest) error {
+	c.record("UpdateAsset", assetID, update)
+	if c.UpdateAssetFunc != nil {
+		return c.UpdateAssetFunc(ctx, assetID, update)
+	}
+	return nil
+}
+
+// CreatePlaybackID returns a playback ID with a random id and the requested policy by default.
+func (c *MuxClient) CreatePlaybackID(ctx context.Context, assetID string, policy mux.PlaybackPolicy) (*mux.PlaybackId, error) {
+	c.record("CreatePlaybackID", assetID, policy)
+	if c.CreatePlaybackIDFunc != nil {
+		return c.CreatePlaybackIDFunc(ctx, assetID, policy)
+	}
+	return &mux.PlaybackId{Id: uuid.NewString(), Policy: policy}, nil
+}
+
+func (c *MuxClient) DeletePlaybackID(ctx context.Context, assetID, playbackID string) error {
+	c.record("DeletePlaybackID", assetID, playbackID)
+	if c.DeletePlaybackIDFunc != nil {
+		return c.DeletePlaybackIDFunc(ctx, assetID, playbackID)
+	}
+	return nil
+}
+
+// GetAsset returns a ready asset with the requested id by default.
+func (c *MuxClient) GetAsset(ctx context.Context, assetID string) (*mux.Asset, error) {
+	c.record("GetAsset", assetID)
+	if c.GetAssetFunc != nil {
+		return c.GetAssetFunc(ctx, assetID)
+	}
+	return &mux.Asset{Id: assetID, Status: "ready"}, nil
+}
+
+// GetDirectUpload returns a waiting upload with the requested id by default.
+func (c *MuxClient) GetDirectUpload(ctx context.Context, uploadID string) (*mux.Upload, error) {
+	c.record("GetDirectUpload", uploadID)
+	if c.GetDirectUploadFunc != nil {
+		return c.GetDirectUploadFunc(ctx, uploadID)
+	}
+	return &mux.Upload{Id: uploadID, Status: "waiting"}, nil
+}
+
+func (c *MuxClient) CancelDirectUpload(ctx context.Context, uploadID string) error {
+	c.record("CancelDirectUpload", uploadID)
+	if c.CancelDirectUploadFunc != nil {
+		return c.CancelDirectUploadFunc(ctx, uploadID)
+	}
+	return nil
+}
+
+// ListAssets returns no assets by default.
+func (c *MuxClient) ListAssets(ctx context.Context, limit, page int32) ([]mux.Asset, error) {
+	c.record("ListAssets", limit, page)
+	if c.ListAssetsFunc != nil {
+		return c.ListAssetsFunc(ctx, limit, page)
+	}
+	return nil, nil
+}
+
+// ListAssetViews returns no views by default.
+func (c *MuxClient) ListAssetViews(ctx context.Context, from, to time.Time, limit, page int32) ([]mux.BreakdownValue, error) {
+	c.record("ListAssetViews", from, to, limit, page)
+	if c.ListAssetViewsFunc != nil {
+		return c.ListAssetViewsFunc(ctx, from, to, limit, page)
+	}
+	return nil, nil
+}
+
+func (c *MuxClient) GetTranscript(ctx context.Context, playbackID, trackID string) (string, error) {
+	c.record("GetTranscript", playbackID, trackID)
+	if c.GetTranscriptFunc != nil {
+		return c.GetTranscriptFunc(ctx, playbackID, trackID)
+	}
+	return "", nil
+}
+
+// CreatePlaybackRestriction returns a playback restriction with a random id and the referrer rules by default.
+func (c *MuxClient) CreatePlaybackRestriction(ctx context.Context, referrer mux.ReferrerDomainRestriction) (*mux.PlaybackRestriction, error) {
+	c.record("CreatePlaybackRestriction", referrer)
+	if c.CreatePlaybackRestrictionFunc != nil {
+		return c.CreatePlaybackRestrictionFunc(ctx, referrer)
+	}
+	return &mux.PlaybackRestriction{Id: uuid.NewString(), Referrer: referrer}, nil
+}
+
+func (c *MuxClient) UpdatePlaybackRestrictionReferrer(ctx context.Context, restrictionID string, referrer mux.ReferrerDomainRestriction) error {
+	c.record("UpdatePlaybackRestrictionReferrer", restrictionID, referrer)
+	if c.UpdatePlaybackRestrictionFunc != nil {
+		return c.UpdatePlaybackRestrictionFunc(ctx, restrictionID, referrer)
+	}
+	return nil
+}
+
+func (c *MuxClient) DeletePlaybackRestriction(ctx context.Context, restrictionID string) error {
+	c.record("DeletePlaybackRestriction", restrictionID)
+	if c.DeletePlaybackRestrictionFunc != nil {
+		return c.DeletePlaybackRestrictionFunc(ctx, restrictionID)
+	}
+	return nil
+}
+
+// CreateSigningKey returns a signing key with a random id and no private key by default.
+func (c *MuxClient) CreateSigningKey(ctx context.Context) (*mux.SigningKey, error) {
+	c.record("CreateSigningKey")
+	if c.CreateSigningKeyFunc != nil {
+		return c.CreateSigningKeyFunc(ctx)
+	}
+	return &mux.SigningKey{Id: uuid.NewString()}, nil
+}
+
+func (c *MuxClient) DeleteSigningKey(ctx context.Context, keyID string) error {
+	c.record("DeleteSigningKey", keyID)
+	if c.DeleteSigningKeyFunc != nil {
+		return c.DeleteSigningKeyFunc(ctx, keyID)
+	}
+	return nil
+}
+
+// GeneratePlaybackJWTToken returns an unsigned token naming the playback ID by default.
+func (c *MuxClient) GeneratePlaybackJWTToken(ctx context.Context, opts apiclient.GeneratePlaybackTokenOptions) (string, error) {
+	c.record("GeneratePlaybackJWTToken", opts)
+	if c.GeneratePlaybackJWTTokenFunc != nil {
+		return c.GeneratePlaybackJWTTokenFunc(ctx, opts)
+	}
+	return "test-playback-token." + opts.PlaybackID, nil
+}
+
+// GenerateDRMLicenseJWTToken returns an unsigned token naming the playback ID by default.
+func (c *MuxClient) GenerateDRMLicenseJWTToken(ctx context.Context, opts apiclient.GeneratePlaybackTokenOptions) (string, error) {
+	c.record("GenerateDRMLicenseJWTToken", opts)
+	if c.GenerateDRMLicenseJWTTokenFunc != nil {
+		return c.GenerateDRMLicenseJWTTokenFunc(ctx, opts)
+	}
+	return "test-license-token." + opts.PlaybackID, nil
+}
+
+// GenerateThumbnailJWTToken returns an unsigned token naming the playback ID by default.
+func (c *MuxClient) GenerateThumbnailJWTToken(ctx context.Context, opts apiclient.GeneratePlaybackTokenOptions) (string, error) {
+	c.record("GenerateThumbnailJWTToken", opts)
+	if c.GenerateThumbnailJWTTokenFunc != nil {
+		return c.GenerateThumbnailJWTTokenFunc(ctx, opts)
+	}
+	return "test-thumbnail-token." + opts.PlaybackID, nil
+}
+
+func (c *MuxClient) VerifiesWebhooks() bool {
+	return c.VerifyWebhookSignatureFunc != nil
+}
+
+// VerifyWebhookSignature accepts every payload by default.
+func (c *MuxClient) VerifyWebhookSignature(payload []byte, header string) error {
+	c.record("VerifyWebhookSignature", payload, header)
+	if c.VerifyWebhookSignatureFunc != nil {
+		return c.VerifyWebhookSignatureFunc(payload, header)
+	}
+	return nil
+}
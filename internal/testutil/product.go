@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package testutil
+
+import (
+	"context"
+	"time"
+
+	imagepbv1 "github.com/mikhail5545/product-service-client/pb/product_service/image/v1"
+	videopbv1 "github.com/mikhail5545/product-service-client/pb/product_service/video/v1"
+	"google.golang.org/grpc"
+)
+
+// VideoServiceClient is a fake of the product service video client.
+type VideoServiceClient struct {
+	Recorder
+
+	PingFunc        func(ctx context.Context, in *videopbv1.PingRequest) (*videopbv1.PingResponse, error)
+	BrokenVideoFunc func(ctx context.Context, in *videopbv1.BrokenVideoRequest) (*videopbv1.BrokenVideoResponse, error)
+	DeleteFunc      func(ctx context.Context, in *videopbv1.DeleteRequest) (*videopbv1.DeleteResponse, error)
+	ForceDeleteFunc func(ctx context.Context, in *videopbv1.ForceDeleteRequest) (*videopbv1.ForceDeleteResponse, error)
+}
+
+var _ videopbv1.VideoServiceClient = (*VideoServiceClient)(nil)
+
+func (c *VideoServiceClient) Ping(ctx context.Context, in *videopbv1.PingRequest, _ ...grpc.CallOption) (*videopbv1.PingResponse, error) {
+	c.record("Ping", in)
+	if c.PingFunc != nil {
+		return c.PingFunc(ctx, in)
+	}
+	return &videopbv1.PingResponse{Timestamp: time.Now().Unix()}, nil
+}
+
+func (c *VideoServiceClient) BrokenVideo(ctx context.Context, in *videopbv1.BrokenVideoRequest, _ ...grpc.CallOption) (*videopbv1.BrokenVideoResponse, error) {
+	c.record("BrokenVideo", in)
+	if c.BrokenVideoFunc != nil {
+		return c.BrokenVideoFunc(ctx, in)
+	}
+	return &videopbv1.BrokenVideoResponse{}, nil
+}
+
+func (c *VideoServiceClient) Delete(ctx context.Context, in *videopbv1.DeleteRequest, _ ...grpc.CallOption) (*videopbv1.DeleteResponse, error) {
+	c.record("Delete", in)
+	if c.DeleteFunc != nil {
+		return c.DeleteFunc(ctx, in)
+	}
+	return &videopbv1.DeleteResponse{}, nil
+}
+
+func (c *VideoServiceClient) ForceDelete(ctx context.Context, in *videopbv1.ForceDeleteRequest, _ ...grpc.CallOption) (*videopbv1.ForceDeleteResponse, error) {
+	c.record("ForceDelete", in)
+	if c.ForceDeleteFunc != nil {
+		return c.ForceDeleteFunc(ctx, in)
+	}
+	return &videopbv1.ForceDeleteResponse{}, nil
+}
+
+// ImageServiceClient is a fake of the product service image client.
+type ImageServiceClient struct {
+	Recorder
+
+	PingFunc             func(ctx context.Context, in *imagepbv1.PingRequest) (*imagepbv1.PingResponse, error)
+	BrokenImageFunc      func(ctx context.Context, in *imagepbv1.BrokenImageRequest) (*imagepbv1.BrokenImageResponse, error)
+	DeleteFunc           func(ctx context.Context, in *imagepbv1.DeleteRequest) (*imagepbv1.DeleteResponse, error)
+	ForceDeleteFunc      func(ctx context.Context, in *imagepbv1.ForceDeleteRequest) (*imagepbv1.ForceDeleteResponse, error)
+	ForceDeleteBatchFunc func(ctx context.Context, in *imagepbv1.ForceDeleteBatchRequest) (*imagepbv1.ForceDeleteBatchResponse, error)
+}
+
+var _ imagepbv1.ImageServiceClient = (*ImageServiceClient)(nil)
+
+func (c *ImageServiceClient) Ping(ctx context.Context, in *imagepbv1.PingRequest, _ ...grpc.CallOption) (*imagepbv1.PingResponse, error) {
+	c.record("Ping", in)
+	if c.PingFunc != nil {
+		return c.PingFunc(ctx, in)
+	}
+	return &imagepbv1.PingResponse{Timestamp: time.Now().Unix()}, nil
+}
+
+func (c *ImageServiceClient) BrokenImage(ctx context.Context, in *imagepbv1.BrokenImageRequest, _ ...grpc.CallOption) (*imagepbv1.BrokenImageResponse, error) {
+	c.record("BrokenImage", in)
+	if c.BrokenImageFunc != nil {
+		return c.BrokenImageFunc(ctx, in)
+	}
+	return &imagepbv1.BrokenImageResponse{}, nil
+}
+
+func (c *ImageServiceClient) Delete(ctx context.Context, in *imagepbv1.DeleteRequest, _ ...grpc.CallOption) (*imagepbv1.DeleteResponse, error) {
+	c.record("Delete", in)
+	if c.DeleteFunc != nil {
+		return c.DeleteFunc(ctx, in)
+	}
+	return &imagepbv1.DeleteResponse{}, nil
+}
+
+func (c *ImageServiceClient) ForceDelete(ctx context.Context, in *imagepbv1.ForceDeleteRequest, _ ...grpc.CallOption) (*imagepbv1.ForceDeleteResponse, error) {
+	c.record("ForceDelete", in)
+	if c.ForceDeleteFunc != nil {
+		return c.ForceDeleteFunc(ctx, in)
+	}
+	return &imagepbv1.ForceDeleteResponse{}, nil
+}
+
+func (c *ImageServiceClient) ForceDeleteBatch(ctx context.Context, in *imagepbv1.ForceDeleteBatchRequest, _ ...grpc.CallOption) (*imagepbv1.ForceDeleteBatchResponse, error) {
+	c.record("ForceDeleteBatch", in)
+	if c.ForceDeleteBatchFunc != nil {
+		return c.ForceDeleteBatchFunc(ctx, in)
+	}
+	return &imagepbv1.ForceDeleteBatchResponse{}, nil
+}
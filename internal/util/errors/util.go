@@ -1,13 +1,19 @@
 package errors
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	serviceerrors "github.com/mikhail5545/media-service-go/internal/errors"
+	validationutil "github.com/mikhail5545/media-service-go/internal/util/validation"
+	mediaerrors "github.com/mikhail5545/media-service-go/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 )
 
 type ErrorResponse struct {
@@ -15,6 +21,10 @@ type ErrorResponse struct {
 		Code    string `json:"code"`
 		Message string `json:"message"`
 		Details any    `json:"details,omitempty"`
+		// Errors lists the invalid fields of a request which failed validation.
+		Errors []validationutil.Violation `json:"errors,omitempty"`
+		// CurrentRevision is the current revision of a resource changed with an outdated one.
+		CurrentRevision *int64 `json:"current_revision,omitempty"`
 	} `json:"error"`
 }
 
@@ -22,11 +32,30 @@ func MapServiceError(err error) (int, ErrorResponse) {
 	var resp ErrorResponse
 
 	switch {
+	case errors.Is(err, serviceerrors.ErrOwnerHasAsset):
+		resp.Error.Code = serviceerrors.ErrorAliases[serviceerrors.ErrOwnerHasAsset]
+		resp.Error.Message = "Owner already has the asset"
+		resp.Error.Details = err.Error()
+		return http.StatusConflict, resp
+	case errors.Is(err, serviceerrors.ErrRevisionMismatch):
+		resp.Error.Code = serviceerrors.ErrorAliases[serviceerrors.ErrRevisionMismatch]
+		resp.Error.Message = "Revision mismatch"
+		resp.Error.Details = err.Error()
+		var mismatch *serviceerrors.RevisionMismatchError
+		if errors.As(err, &mismatch) {
+			resp.Error.CurrentRevision = &mismatch.Current
+		}
+		return http.StatusConflict, resp
 	case errors.Is(err, serviceerrors.ErrAlreadyExists):
 		resp.Error.Code = serviceerrors.ErrorAliases[serviceerrors.ErrAlreadyExists]
 		resp.Error.Message = "Already exists"
 		resp.Error.Details = err.Error()
 		return http.StatusConflict, resp
+	case errors.Is(err, serviceerrors.ErrDeadlineExceeded), errors.Is(err, context.DeadlineExceeded):
+		resp.Error.Code = serviceerrors.ErrorAliases[serviceerrors.ErrDeadlineExceeded]
+		resp.Error.Message = "Deadline exceeded"
+		resp.Error.Details = err.Error()
+		return http.StatusGatewayTimeout, resp
 	case errors.Is(err, serviceerrors.ErrCanceled):
 		resp.Error.Code = serviceerrors.ErrorAliases[serviceerrors.ErrCanceled]
 		resp.Error.Message = "Context canceled"
@@ -52,11 +81,26 @@ func MapServiceError(err error) (int, ErrorResponse) {
 		resp.Error.Message = "Permission denied"
 		resp.Error.Details = err.Error()
 		return http.StatusForbidden, resp
+	case errors.Is(err, serviceerrors.ErrUnauthenticated):
+		resp.Error.Code = serviceerrors.ErrorAliases[serviceerrors.ErrUnauthenticated]
+		resp.Error.Message = "Unauthenticated"
+		resp.Error.Details = err.Error()
+		return http.StatusUnauthorized, resp
 	case errors.Is(err, serviceerrors.ErrTooManyRequests):
 		resp.Error.Code = serviceerrors.ErrorAliases[serviceerrors.ErrTooManyRequests]
 		resp.Error.Message = "Too many requests"
 		resp.Error.Details = err.Error()
 		return http.StatusTooManyRequests, resp
+	case errors.Is(err, serviceerrors.ErrPayloadTooLarge):
+		resp.Error.Code = serviceerrors.ErrorAliases[serviceerrors.ErrPayloadTooLarge]
+		resp.Error.Message = "Payload too large"
+		resp.Error.Details = err.Error()
+		return http.StatusRequestEntityTooLarge, resp
+	case errors.Is(err, serviceerrors.ErrUnsupportedMediaType):
+		resp.Error.Code = serviceerrors.ErrorAliases[serviceerrors.ErrUnsupportedMediaType]
+		resp.Error.Message = "Unsupported media type"
+		resp.Error.Details = err.Error()
+		return http.StatusUnsupportedMediaType, resp
 	case errors.Is(err, serviceerrors.ErrUnimplemented):
 		resp.Error.Code = serviceerrors.ErrorAliases[serviceerrors.ErrUnimplemented]
 		resp.Error.Message = "Unimplemented"
@@ -71,6 +115,7 @@ func MapServiceError(err error) (int, ErrorResponse) {
 		resp.Error.Code = serviceerrors.ErrorAliases[serviceerrors.ErrValidationFailed]
 		resp.Error.Message = "Validation failed"
 		resp.Error.Details = err.Error()
+		resp.Error.Errors = validationutil.Violations(err)
 		return http.StatusUnprocessableEntity, resp
 	default:
 		resp.Error.Code = "INTERNAL_SERVER_ERROR"
@@ -94,6 +139,12 @@ func HandleRPCError(err error) error {
 		return serviceerrors.NewAlreadyExistsError(err.Error())
 	case codes.PermissionDenied:
 		return serviceerrors.NewPermissionDeniedError(err.Error())
+	case codes.Unauthenticated:
+		return serviceerrors.NewUnauthenticatedError(err.Error())
+	case codes.DeadlineExceeded:
+		return serviceerrors.NewDeadlineExceededError(err.Error())
+	case codes.Unavailable:
+		return serviceerrors.NewUnavailableError(err.Error())
 	case codes.Internal:
 		fallthrough
 	default:
@@ -110,28 +161,100 @@ func ToGRPCCode(err error) error {
 		return err
 	}
 
+	var st *status.Status
 	switch {
 	case errors.Is(err, serviceerrors.ErrAlreadyExists):
-		return status.Error(codes.AlreadyExists, err.Error())
+		st = status.New(codes.AlreadyExists, err.Error())
+	// The deadline is exceeded by a slow dependency, so the call may be retried like an unavailable one
+	case errors.Is(err, serviceerrors.ErrDeadlineExceeded), errors.Is(err, context.DeadlineExceeded):
+		st = status.New(codes.Unavailable, err.Error())
 	case errors.Is(err, serviceerrors.ErrCanceled):
-		return status.Error(codes.Canceled, err.Error())
+		st = status.New(codes.Canceled, err.Error())
 	case errors.Is(err, serviceerrors.ErrConflict):
-		return status.Error(codes.Aborted, err.Error())
+		st = status.New(codes.Aborted, err.Error())
 	case errors.Is(err, serviceerrors.ErrInvalidArgument):
-		return status.Error(codes.InvalidArgument, err.Error())
+		st = status.New(codes.InvalidArgument, err.Error())
 	case errors.Is(err, serviceerrors.ErrNotFound):
-		return status.Error(codes.NotFound, err.Error())
+		st = status.New(codes.NotFound, err.Error())
 	case errors.Is(err, serviceerrors.ErrPermissionDenied):
-		return status.Error(codes.PermissionDenied, err.Error())
-	case errors.Is(err, serviceerrors.ErrTooManyRequests):
-		return status.Error(codes.ResourceExhausted, err.Error())
+		st = status.New(codes.PermissionDenied, err.Error())
+	case errors.Is(err, serviceerrors.ErrUnauthenticated):
+		st = status.New(codes.Unauthenticated, err.Error())
+	case errors.Is(err, serviceerrors.ErrTooManyRequests), errors.Is(err, serviceerrors.ErrPayloadTooLarge):
+		st = status.New(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, serviceerrors.ErrUnsupportedMediaType):
+		st = status.New(codes.InvalidArgument, err.Error())
 	case errors.Is(err, serviceerrors.ErrUnimplemented):
-		return status.Error(codes.Unimplemented, err.Error())
+		st = status.New(codes.Unimplemented, err.Error())
 	case errors.Is(err, serviceerrors.ErrUnavailable):
-		return status.Error(codes.Unavailable, err.Error())
+		st = status.New(codes.Unavailable, err.Error())
 	case errors.Is(err, serviceerrors.ErrValidationFailed):
-		return status.Error(codes.FailedPrecondition, err.Error())
+		st = status.New(codes.FailedPrecondition, err.Error())
 	default:
 		return status.Error(codes.Internal, err.Error())
 	}
+	return withDetails(st, err)
+}
+
+// reasonErrors lists the service errors in the order their reasons are looked up, errors wrapped
+// along with a more generic one go first.
+var reasonErrors = []error{
+	serviceerrors.ErrOwnerHasAsset,
+	serviceerrors.ErrRevisionMismatch,
+	serviceerrors.ErrAlreadyExists,
+	serviceerrors.ErrDeadlineExceeded,
+	serviceerrors.ErrCanceled,
+	serviceerrors.ErrConflict,
+	serviceerrors.ErrInvalidArgument,
+	serviceerrors.ErrNotFound,
+	serviceerrors.ErrPermissionDenied,
+	serviceerrors.ErrUnauthenticated,
+	serviceerrors.ErrTooManyRequests,
+	serviceerrors.ErrPayloadTooLarge,
+	serviceerrors.ErrUnsupportedMediaType,
+	serviceerrors.ErrUnimplemented,
+	serviceerrors.ErrUnavailable,
+	serviceerrors.ErrValidationFailed,
+}
+
+// Reason returns the machine-readable reason of the service error, e.g. "OWNER_HAS_ASSET", or an
+// empty string if err is not a service error.
+func Reason(err error) string {
+	for _, sentinel := range reasonErrors {
+		if errors.Is(err, sentinel) {
+			return serviceerrors.ErrorAliases[sentinel]
+		}
+	}
+	return ""
+}
+
+// withDetails attaches the reason of the service error as google.rpc.ErrorInfo, so clients can branch
+// on it, see the pkg/errors package. The current revision of a revision mismatch is attached as the
+// current_revision metadata of the info. Field errors of the request validation are attached as
+// google.rpc.BadRequest field violations, e.g. naming an unregistered owner type.
+func withDetails(st *status.Status, err error) error {
+	info := &errdetails.ErrorInfo{
+		Reason: Reason(err),
+		Domain: mediaerrors.Domain,
+	}
+	var mismatch *serviceerrors.RevisionMismatchError
+	if errors.As(err, &mismatch) {
+		info.Metadata = map[string]string{mediaerrors.MetadataCurrentRevision: strconv.FormatInt(mismatch.Current, 10)}
+	}
+	details := []protoadapt.MessageV1{info}
+	if violations := validationutil.Violations(err); len(violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	detailed, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
 }
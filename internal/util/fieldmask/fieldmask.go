@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package fieldmask parses the field masks of partial asset reads. A mask is a list of paths over
+// the asset details: "asset" and "metadata" select a whole part, "asset.<column>" selects a single
+// column of the asset record and "metadata.<field>" a single field of the metadata document. Nested
+// metadata fields are selected with dotted paths, e.g. "metadata.owners.owner_type".
+package fieldmask
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	PartAsset    = "asset"
+	PartMetadata = "metadata"
+)
+
+// Mask is a parsed field mask. The zero Mask selects nothing, use Parse to obtain one.
+type Mask struct {
+	Asset    Part
+	Metadata Part
+}
+
+// Part is the selection of a single part of the asset details.
+type Part struct {
+	// Selected reports whether anything of the part was requested.
+	Selected bool
+	// Fields are the requested fields of the part. It is empty when the whole part is requested.
+	Fields []string
+}
+
+// Parse parses the paths of a mask. Each value may hold several comma separated paths, so that both
+// "fields=a,b" and "fields=a&fields=b" are accepted. A mask without paths selects everything.
+func Parse(values []string) (Mask, error) {
+	var mask Mask
+	var paths int
+	for _, value := range values {
+		for _, path := range strings.Split(value, ",") {
+			path = strings.TrimSpace(path)
+			if path == "" {
+				continue
+			}
+			paths++
+			part, field, _ := strings.Cut(path, ".")
+			var p *Part
+			switch part {
+			case PartAsset:
+				p = &mask.Asset
+			case PartMetadata:
+				p = &mask.Metadata
+			default:
+				return Mask{}, fmt.Errorf("path %q must start with %q or %q", path, PartAsset, PartMetadata)
+			}
+			if strings.Contains(path, ".") && field == "" {
+				return Mask{}, fmt.Errorf("path %q has an empty field", path)
+			}
+			p.add(field)
+		}
+	}
+	if paths == 0 {
+		return All(), nil
+	}
+	return mask, nil
+}
+
+// All returns the mask selecting every part entirely.
+func All() Mask {
+	return Mask{
+		Asset:    Part{Selected: true},
+		Metadata: Part{Selected: true},
+	}
+}
+
+// IsAll reports whether the mask selects every part entirely.
+func (m Mask) IsAll() bool {
+	return m.Asset.Whole() && m.Metadata.Whole()
+}
+
+// Validate checks the fields of the parts with the validators of the asset columns and the metadata
+// fields.
+func (m Mask) Validate(assetField, metadataField func(string) bool) error {
+	for _, field := range m.Asset.Fields {
+		if !assetField(field) {
+			return fmt.Errorf("unknown asset field %q", field)
+		}
+	}
+	for _, field := range m.Metadata.Fields {
+		if !metadataField(field) {
+			return fmt.Errorf("unknown metadata field %q", field)
+		}
+	}
+	return nil
+}
+
+// Whole reports whether the whole part is requested.
+func (p Part) Whole() bool {
+	return p.Selected && len(p.Fields) == 0
+}
+
+// Columns returns the fields of the part to load together with the required ones, or nil when the
+// whole part is to be loaded.
+func (p Part) Columns(required ...string) []string {
+	if p.Whole() {
+		return nil
+	}
+	columns := slices.Clone(required)
+	for _, field := range p.Fields {
+		if !slices.Contains(columns, field) {
+			columns = append(columns, field)
+		}
+	}
+	return columns
+}
+
+func (p *Part) add(field string) {
+	if p.Whole() {
+		return
+	}
+	if field == "" {
+		p.Selected, p.Fields = true, nil
+		return
+	}
+	p.Selected = true
+	// A field covers its nested fields, MongoDB rejects projections containing both.
+	for _, existing := range p.Fields {
+		if existing == field || strings.HasPrefix(field, existing+".") {
+			return
+		}
+	}
+	p.Fields = slices.DeleteFunc(p.Fields, func(existing string) bool {
+		return strings.HasPrefix(existing, field+".")
+	})
+	p.Fields = append(p.Fields, field)
+}
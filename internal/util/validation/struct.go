@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// requestTags are the struct tags naming request fields, in the order they are looked up.
+var requestTags = []string{"json", "query", "param", "form"}
+
+// Violation is a single invalid field of a request.
+type Violation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidateStruct validates the struct like [validation.ValidateStruct], but names the field errors
+// after the request fields. ozzo only reads the json tag and falls back to the Go field name, e.g.
+// "PageSize" for a field bound from the query, so the query, param and form tags are used as well.
+func ValidateStruct(structPtr any, fields ...*validation.FieldRules) error {
+	err := validation.ValidateStruct(structPtr, fields...)
+	errs, ok := err.(validation.Errors)
+	if !ok {
+		return err
+	}
+	structType := reflect.Indirect(reflect.ValueOf(structPtr)).Type()
+	named := make(validation.Errors, len(errs))
+	for key, fieldErr := range errs {
+		named[requestFieldName(structType, key)] = fieldErr
+	}
+	return named
+}
+
+// requestFieldName returns the request name of the Go field. Keys which are not Go field names are
+// already request names and are returned unchanged.
+func requestFieldName(structType reflect.Type, key string) string {
+	field, ok := structType.FieldByName(key)
+	if !ok {
+		return key
+	}
+	for _, tag := range requestTags {
+		if name, _, _ := strings.Cut(field.Tag.Get(tag), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return key
+}
+
+// Violations returns the field violations carried by err, sorted by field. Errors of nested structs
+// and of slice elements are flattened to dotted paths, e.g. "owners.0.owner_id". It returns nil if
+// err carries no field errors.
+func Violations(err error) []Violation {
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		return nil
+	}
+	var violations []Violation
+	flatten("", errs, &violations)
+	sort.Slice(violations, func(i, j int) bool { return violations[i].Field < violations[j].Field })
+	return violations
+}
+
+func flatten(prefix string, errs validation.Errors, violations *[]Violation) {
+	for field, err := range errs {
+		if err == nil {
+			continue
+		}
+		if prefix != "" {
+			field = prefix + "." + field
+		}
+		if nested, ok := err.(validation.Errors); ok {
+			flatten(field, nested, violations)
+			continue
+		}
+		*violations = append(*violations, Violation{Field: field, Message: err.Error()})
+	}
+}
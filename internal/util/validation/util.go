@@ -6,6 +6,7 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
 	"github.com/gosimple/slug"
+	"github.com/mikhail5545/media-service-go/internal/util/fieldmask"
 )
 
 func composeRules(required bool, additionalRules ...validation.Rule) []validation.Rule {
@@ -101,6 +102,22 @@ func ValidateField(value any, validator fieldValidator) error {
 	return nil
 }
 
+// FieldMaskRule returns the ozzo-validation rule for the field mask paths of a partial asset read,
+// see the fieldmask package. The asset and metadata fields are checked with the validators.
+func FieldMaskRule(assetField, metadataField fieldValidator) validation.Rule {
+	return validation.By(func(value any) error {
+		var paths []string
+		if err := extractValue(&paths, value); err != nil {
+			return err
+		}
+		mask, err := fieldmask.Parse(paths)
+		if err != nil {
+			return err
+		}
+		return mask.Validate(assetField, metadataField)
+	})
+}
+
 // SlugRule returns the ozzo-validation rules for a slug.
 func SlugRule(required bool) []validation.Rule {
 	return composeRules(required, validation.Length(2, 255), validation.By(IsValidSlug))
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package viewer carries the end user a playback request is made for. The delivery middleware stores
+// the viewer in the request context, the services check it against the playback restrictions of the
+// requested asset.
+package viewer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// unknownCountry is sent by CDNs, e.g. Cloudflare, when the country of a client is not known.
+const unknownCountry = "XX"
+
+// Viewer describes where an end user plays the media from.
+type Viewer struct {
+	// Country is the ISO 3166-1 alpha-2 code of the country of the viewer, empty if unknown.
+	Country string
+	// Referrer is the Referer header of the request, empty if it was not sent.
+	Referrer string
+}
+
+type viewerKey struct{}
+
+// WithContext returns a copy of ctx carrying the viewer.
+func WithContext(ctx context.Context, v Viewer) context.Context {
+	return context.WithValue(ctx, viewerKey{}, v)
+}
+
+// FromContext returns the viewer stored in ctx and whether there is one.
+func FromContext(ctx context.Context) (Viewer, bool) {
+	v, ok := ctx.Value(viewerKey{}).(Viewer)
+	return v, ok
+}
+
+// EchoMiddleware stores the viewer of the request in the request context. The country is read from
+// countryHeader, which the CDN or the load balancer in front of the service sets, e.g. CF-IPCountry.
+// The country is unknown if countryHeader is empty.
+func EchoMiddleware(countryHeader string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			v := Viewer{Referrer: req.Referer()}
+			if countryHeader != "" {
+				v.Country = NormalizeCountry(req.Header.Get(countryHeader))
+			}
+			c.SetRequest(req.WithContext(WithContext(req.Context(), v)))
+			return next(c)
+		}
+	}
+}
+
+// NormalizeCountry upper-cases the country code, the unknown country of the CDNs is returned as empty.
+func NormalizeCountry(country string) string {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == unknownCountry {
+		return ""
+	}
+	return country
+}
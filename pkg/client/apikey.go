@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// APIKeyHeader is the metadata key carrying the API key of the calling service.
+const APIKeyHeader = "x-api-key"
+
+// APIKeyCredentials attaches a static API key to every call.
+type APIKeyCredentials struct {
+	key        string
+	requireTLS bool
+}
+
+var _ credentials.PerRPCCredentials = (*APIKeyCredentials)(nil)
+
+// NewAPIKeyCredentials creates per-RPC credentials sending the API key in the [APIKeyHeader] metadata.
+// requireTLS should only be disabled for local development over insecure connections.
+func NewAPIKeyCredentials(key string, requireTLS bool) *APIKeyCredentials {
+	return &APIKeyCredentials{key: key, requireTLS: requireTLS}
+}
+
+func (c *APIKeyCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{APIKeyHeader: c.key}, nil
+}
+
+func (c *APIKeyCredentials) RequireTransportSecurity() bool {
+	return c.requireTLS
+}
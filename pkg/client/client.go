@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package client contains connection and call options shared by the media service gRPC clients
+// in the mux and cloudinary subpackages. Errors returned by the calls can be converted to typed
+// errors with FromGRPC of the pkg/errors package.
+package client
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Config holds per-call settings of a client.
+type Config struct {
+	Timeout time.Duration
+	// MethodTimeouts override Timeout for single methods, keyed by full method name,
+	// e.g. "/media_service.mux.asset.v1.AssetService/List".
+	MethodTimeouts map[string]time.Duration
+}
+
+type Option func(*Config)
+
+// WithTimeout sets the timeout duration for client requests.
+func WithTimeout(timeout int64, size time.Duration) Option {
+	return func(c *Config) {
+		c.Timeout = size * time.Duration(timeout)
+	}
+}
+
+// WithMethodTimeout sets the timeout of the calls to a single method, given by its full name.
+func WithMethodTimeout(method string, timeout time.Duration) Option {
+	return func(c *Config) {
+		if c.MethodTimeouts == nil {
+			c.MethodTimeouts = make(map[string]time.Duration)
+		}
+		c.MethodTimeouts[method] = timeout
+	}
+}
+
+// WithDefaults sets default values for the client configuration.
+func WithDefaults() Option {
+	return func(c *Config) {
+		if c.Timeout == 0 {
+			c.Timeout = 5 * time.Second
+		}
+	}
+}
+
+// NewConfig applies opts to an empty configuration.
+func NewConfig(opts ...Option) *Config {
+	cfg := &Config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// timeoutInterceptor applies the configured timeout of the method to every call whose context has
+// no earlier deadline. Methods without a timeout are called with the context unchanged.
+func timeoutInterceptor(cfg *Config) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		timeout, ok := cfg.MethodTimeouts[method]
+		if !ok {
+			timeout = cfg.Timeout
+		}
+		if timeout <= 0 {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > timeout {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
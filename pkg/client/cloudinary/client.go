@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package cloudinary provides a gRPC client for the media service Cloudinary asset API.
+package cloudinary
+
+import (
+	"context"
+
+	cldassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/asset/v1"
+	"github.com/mikhail5545/media-service-go/pkg/client"
+	"google.golang.org/grpc"
+)
+
+// AssetServiceClient is a client for the Cloudinary asset service.
+type AssetServiceClient struct {
+	cldassetpbv1.AssetServiceClient
+	conn   *grpc.ClientConn
+	config *client.Config
+}
+
+// NewAssetServiceClient creates a new AssetServiceClient with the provided options.
+func NewAssetServiceClient(opts ...client.Option) (*AssetServiceClient, error) {
+	return &AssetServiceClient{config: client.NewConfig(opts...)}, nil
+}
+
+// Connect establishes a gRPC connection to the specified address and initializes the service client.
+// See client.WithInsecure, client.WithTransportCredentials, client.WithTLSConfig, client.WithTLSFromFiles,
+// client.WithSystemTLS, client.WithAPIKey, client.WithPerRPCCredentials, client.WithRetryPolicy,
+// client.WithKeepalive, client.WithUnaryInterceptors, client.WithStreamInterceptors and
+// client.WithExtraDialOpts for available connection options.
+func (c *AssetServiceClient) Connect(ctx context.Context, address string, opt ...client.ConnOption) (err error) {
+	c.conn, err = client.Dial(ctx, address, c.config, opt...)
+	if err != nil {
+		return err
+	}
+	c.AssetServiceClient = cldassetpbv1.NewAssetServiceClient(c.conn)
+	return nil
+}
+
+// Close closes the grpc.ClientConn and all underlying connections.
+func (c *AssetServiceClient) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
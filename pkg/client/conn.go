@@ -0,0 +1,203 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/mikhail5545/media-service-go/pkg/tlsconfig"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+type connOptions struct {
+	insecure             bool
+	transportCredentials credentials.TransportCredentials
+	perRPCCredentials    credentials.PerRPCCredentials
+	extraDialOpts        []grpc.DialOption
+	unaryInterceptors    []grpc.UnaryClientInterceptor
+	streamInterceptors   []grpc.StreamClientInterceptor
+	keepalive            *keepalive.ClientParameters
+	retryPolicy          *RetryPolicy
+	err                  error
+}
+
+type ConnOption func(*connOptions)
+
+// WithInsecure configures the gRPC connection to be insecure (no TLS).
+// Use with caution, as this transmits data in plaintext.
+func WithInsecure() ConnOption {
+	return func(co *connOptions) {
+		co.insecure = true
+	}
+}
+
+// WithTransportCredentials sets custom transport credentials for the gRPC connection.
+func WithTransportCredentials(tc credentials.TransportCredentials) ConnOption {
+	return func(co *connOptions) {
+		co.transportCredentials = tc
+	}
+}
+
+// WithTLSConfig sets up TLS transport credentials using the provided tls.Config.
+func WithTLSConfig(cfg *tls.Config) ConnOption {
+	return func(co *connOptions) {
+		co.transportCredentials = credentials.NewTLS(cfg)
+	}
+}
+
+// WithSystemTLS sets up TLS transport credentials verifying the server against the system root pool.
+// This is the default when no other transport option is given.
+func WithSystemTLS() ConnOption {
+	return WithTLSConfig(tlsconfig.Client(nil, ""))
+}
+
+// WithTLSFromFiles sets up TLS transport credentials from PEM files. caPath is used to verify the
+// server certificate, certPath and keyPath (optional) hold the client certificate for mutual TLS.
+// Rotated files are picked up by new connections without recreating the client.
+// Errors loading the files are returned by Connect.
+func WithTLSFromFiles(caPath, certPath, keyPath string) ConnOption {
+	return func(co *connOptions) {
+		r, err := tlsconfig.NewReloader(tlsconfig.Files{CAFile: caPath, CertFile: certPath, KeyFile: keyPath}, 0)
+		if err != nil {
+			co.err = fmt.Errorf("failed to load TLS files: %w", err)
+			return
+		}
+		co.transportCredentials = credentials.NewTLS(tlsconfig.Client(r, ""))
+	}
+}
+
+// WithPerRPCCredentials sets custom per-RPC credentials for the gRPC connection.
+func WithPerRPCCredentials(prc credentials.PerRPCCredentials) ConnOption {
+	return func(co *connOptions) {
+		co.perRPCCredentials = prc
+	}
+}
+
+// WithAPIKey attaches the API key to every call. The key is only sent over secure connections,
+// see [NewAPIKeyCredentials] to send it over an insecure connection in local environments.
+func WithAPIKey(key string) ConnOption {
+	return WithPerRPCCredentials(NewAPIKeyCredentials(key, true))
+}
+
+// WithUnaryInterceptors appends interceptors to the unary calls. They run in the order given, after
+// the timeout of the client [Config] is applied.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) ConnOption {
+	return func(co *connOptions) {
+		co.unaryInterceptors = append(co.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors appends interceptors to the streaming calls. They run in the order given.
+func WithStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) ConnOption {
+	return func(co *connOptions) {
+		co.streamInterceptors = append(co.streamInterceptors, interceptors...)
+	}
+}
+
+// WithKeepalive sends keepalive pings on idle connections, so broken connections are detected
+// before the next call. The server rejects pings more frequent than its enforcement policy allows.
+func WithKeepalive(params keepalive.ClientParameters) ConnOption {
+	return func(co *connOptions) {
+		co.keepalive = &params
+	}
+}
+
+// WithRetryPolicy retries failed calls of every method according to the policy, see
+// [DefaultRetryPolicy]. An invalid policy is reported by Connect.
+func WithRetryPolicy(policy RetryPolicy) ConnOption {
+	return func(co *connOptions) {
+		co.retryPolicy = &policy
+	}
+}
+
+// WithExtraDialOpts appends additional grpc.DialOption to the gRPC connection.
+func WithExtraDialOpts(opts ...grpc.DialOption) ConnOption {
+	return func(co *connOptions) {
+		co.extraDialOpts = append(co.extraDialOpts, opts...)
+	}
+}
+
+// Dial creates a gRPC client connection to addr using the client configuration and connection options.
+func Dial(_ context.Context, addr string, cfg *Config, opts ...ConnOption) (*grpc.ClientConn, error) {
+	co := &connOptions{}
+	for _, opt := range opts {
+		opt(co)
+	}
+
+	dialOpts, err := buildDialOptions(co)
+	if err != nil {
+		return nil, err
+	}
+	var unary []grpc.UnaryClientInterceptor
+	if cfg != nil && (cfg.Timeout > 0 || len(cfg.MethodTimeouts) > 0) {
+		unary = append(unary, timeoutInterceptor(cfg))
+	}
+	unary = append(unary, co.unaryInterceptors...)
+	if len(unary) > 0 {
+		dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(unary...))
+	}
+	if len(co.streamInterceptors) > 0 {
+		dialOpts = append(dialOpts, grpc.WithChainStreamInterceptor(co.streamInterceptors...))
+	}
+	return grpc.NewClient(addr, dialOpts...)
+}
+
+func buildDialOptions(co *connOptions) ([]grpc.DialOption, error) {
+	if co.err != nil {
+		return nil, co.err
+	}
+	var dialOpts []grpc.DialOption
+
+	if co.transportCredentials != nil {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(co.transportCredentials))
+	} else if co.insecure {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		// Default: require TLS via system root pool
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsconfig.Client(nil, ""))))
+	}
+
+	if co.perRPCCredentials != nil {
+		if co.perRPCCredentials.RequireTransportSecurity() && co.insecure {
+			return nil, fmt.Errorf("per-RPC credentials require transport security, but connection is set to insecure")
+		}
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(co.perRPCCredentials))
+	}
+	if co.keepalive != nil {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(*co.keepalive))
+	}
+	if co.retryPolicy != nil {
+		serviceConfig, err := co.retryPolicy.serviceConfig()
+		if err != nil {
+			return nil, err
+		}
+		dialOpts = append(dialOpts, grpc.WithDefaultServiceConfig(serviceConfig))
+	}
+
+	// Propagate trace context of the caller, spans are only recorded if the caller configured
+	// a global OpenTelemetry tracer provider.
+	dialOpts = append(dialOpts, grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
+	dialOpts = append(dialOpts, co.extraDialOpts...)
+	return dialOpts, nil
+}
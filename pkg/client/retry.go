@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// RetryPolicy configures the retries of failed calls, it is passed to gRPC as the default service
+// config of the connection. A service config published by the name resolver takes precedence.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first one, gRPC caps it at 5.
+	MaxAttempts int
+	// InitialBackoff is the upper bound of the first randomized backoff.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the backoff growth.
+	MaxBackoff time.Duration
+	// BackoffMultiplier grows the backoff after each attempt.
+	BackoffMultiplier float64
+	// RetryableStatusCodes are the codes a call is retried on. Only include codes returned before
+	// the server acted on the request, or codes of calls which are safe to repeat.
+	RetryableStatusCodes []codes.Code
+}
+
+// DefaultRetryPolicy retries calls failing with Unavailable up to three times.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:          4,
+		InitialBackoff:       100 * time.Millisecond,
+		MaxBackoff:           2 * time.Second,
+		BackoffMultiplier:    2,
+		RetryableStatusCodes: []codes.Code{codes.Unavailable},
+	}
+}
+
+func (p RetryPolicy) validate() error {
+	switch {
+	case p.MaxAttempts < 2:
+		return fmt.Errorf("retry policy needs at least 2 attempts, got %d", p.MaxAttempts)
+	case p.InitialBackoff <= 0 || p.MaxBackoff <= 0:
+		return fmt.Errorf("retry policy backoffs must be positive")
+	case p.BackoffMultiplier <= 0:
+		return fmt.Errorf("retry policy backoff multiplier must be positive")
+	case len(p.RetryableStatusCodes) == 0:
+		return fmt.Errorf("retry policy needs at least one retryable status code")
+	}
+	return nil
+}
+
+// serviceConfig returns the JSON service config applying the policy to every method.
+func (p RetryPolicy) serviceConfig() (string, error) {
+	if err := p.validate(); err != nil {
+		return "", err
+	}
+	statusCodes := make([]uint32, 0, len(p.RetryableStatusCodes))
+	for _, code := range p.RetryableStatusCodes {
+		statusCodes = append(statusCodes, uint32(code))
+	}
+	cfg := map[string]any{
+		"methodConfig": []map[string]any{{
+			// An empty name matches every method of every service.
+			"name": []map[string]string{{}},
+			"retryPolicy": map[string]any{
+				"maxAttempts":          p.MaxAttempts,
+				"initialBackoff":       seconds(p.InitialBackoff),
+				"maxBackoff":           seconds(p.MaxBackoff),
+				"backoffMultiplier":    p.BackoffMultiplier,
+				"retryableStatusCodes": statusCodes,
+			},
+		}},
+	}
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode retry policy: %w", err)
+	}
+	return string(b), nil
+}
+
+// seconds formats d as a service config duration, e.g. "0.1s".
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package errors provides typed errors of the media service for client consumers. FromGRPC converts the
+// status errors returned by the gRPC clients, so callers can branch with errors.Is instead of matching
+// status codes or messages:
+//
+//	_, err := client.AddOwner(ctx, req)
+//	if errors.Is(mediaerrors.FromGRPC(err), mediaerrors.ErrOwnerHasAsset) {
+//		// the owner is already associated with the asset
+//	}
+package errors
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is the domain of the google.rpc.ErrorInfo details attached to the media service errors.
+const Domain = "media-service.mikhail5545.github.com"
+
+// Reasons of the google.rpc.ErrorInfo details attached to the media service errors.
+const (
+	ReasonNotFound         = "NOT_FOUND"
+	ReasonInvalidArgument  = "INVALID_ARGUMENT"
+	ReasonValidationFailed = "VALIDATION_FAILED"
+	ReasonConflict         = "CONFLICT"
+	ReasonAlreadyExists    = "ALREADY_EXISTS"
+	ReasonOwnerHasAsset    = "OWNER_HAS_ASSET"
+	ReasonRevisionMismatch = "REVISION_MISMATCH"
+	ReasonUnavailable      = "UNAVAILABLE"
+	ReasonDeadlineExceeded = "DEADLINE_EXCEEDED"
+)
+
+// MetadataCurrentRevision is the google.rpc.ErrorInfo metadata key of the current revision of the
+// resource changed with an outdated one, see [ReasonRevisionMismatch].
+const MetadataCurrentRevision = "current_revision"
+
+var (
+	// ErrNotFound the requested asset or resource does not exist.
+	ErrNotFound = errors.New("media: not found")
+	// ErrInvalidArgument the request is malformed or fails validation, see [Error.Violations].
+	ErrInvalidArgument = errors.New("media: invalid argument")
+	// ErrConflict the request conflicts with the state of the asset, e.g. an archived asset is modified
+	// or an ownership policy is violated.
+	ErrConflict = errors.New("media: conflict")
+	// ErrOwnerHasAsset the owner is already associated with the asset. It also matches ErrConflict.
+	ErrOwnerHasAsset = errors.New("media: owner already has the asset")
+	// ErrRevisionMismatch the resource was changed since the revision the request expects, e.g. by
+	// another admin. The current revision is in [Error.Metadata]. It also matches ErrConflict.
+	ErrRevisionMismatch = errors.New("media: revision mismatch")
+	// ErrProviderUnavailable the media provider, e.g. MUX or Cloudinary, failed, timed out or its
+	// circuit breaker is open. The call can be retried later.
+	ErrProviderUnavailable = errors.New("media: provider unavailable")
+)
+
+// reasonErrors maps the reasons to the errors they match.
+var reasonErrors = map[string][]error{
+	ReasonNotFound:         {ErrNotFound},
+	ReasonInvalidArgument:  {ErrInvalidArgument},
+	ReasonValidationFailed: {ErrInvalidArgument},
+	ReasonConflict:         {ErrConflict},
+	ReasonAlreadyExists:    {ErrConflict},
+	ReasonOwnerHasAsset:    {ErrOwnerHasAsset, ErrConflict},
+	ReasonRevisionMismatch: {ErrRevisionMismatch, ErrConflict},
+	ReasonUnavailable:      {ErrProviderUnavailable},
+	ReasonDeadlineExceeded: {ErrProviderUnavailable},
+}
+
+// codeErrors maps the status codes to the errors they match when a status carries no reason, e.g.
+// one returned by an older server. Unavailable is left out, the connection to the media service
+// itself fails with it as well.
+var codeErrors = map[codes.Code][]error{
+	codes.NotFound:           {ErrNotFound},
+	codes.InvalidArgument:    {ErrInvalidArgument},
+	codes.FailedPrecondition: {ErrInvalidArgument},
+	codes.Aborted:            {ErrConflict},
+	codes.AlreadyExists:      {ErrConflict},
+}
+
+// FieldViolation is a single invalid field of a request.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Error is a media service error converted by FromGRPC. It matches the sentinel errors of its reason
+// with errors.Is, and is still a gRPC status for status.FromError.
+type Error struct {
+	// Code is the gRPC status code.
+	Code codes.Code
+	// Reason is the machine-readable reason of the error, e.g. [ReasonOwnerHasAsset]. It is empty if
+	// the server did not attach one.
+	Reason string
+	// Message is the status message.
+	Message string
+	// Violations lists the invalid fields of a request which failed validation.
+	Violations []FieldViolation
+	// Metadata is the metadata of the reason, e.g. [MetadataCurrentRevision].
+	Metadata map[string]string
+
+	status *status.Status
+	kinds  []error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the sentinel errors matched by the error.
+func (e *Error) Unwrap() []error {
+	return e.kinds
+}
+
+// GRPCStatus returns the original status.
+func (e *Error) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// FromGRPC converts an error returned by a media service client call to an [Error]. Errors which
+// are not gRPC statuses are returned unchanged, as is nil.
+func FromGRPC(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	converted := &Error{Code: st.Code(), Message: st.Message(), status: st}
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if d.GetDomain() == Domain {
+				converted.Reason = d.GetReason()
+				converted.Metadata = d.GetMetadata()
+			}
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				converted.Violations = append(converted.Violations, FieldViolation{
+					Field:       v.GetField(),
+					Description: v.GetDescription(),
+				})
+			}
+		}
+	}
+	if kinds, ok := reasonErrors[converted.Reason]; ok {
+		converted.kinds = kinds
+	} else {
+		converted.kinds = codeErrors[converted.Code]
+	}
+	return converted
+}
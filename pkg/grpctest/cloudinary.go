@@ -0,0 +1,269 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpctest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	cldassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/asset/v1"
+	cldmetapbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/metadata/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CloudinaryAssetServer is an in-memory fake of the Cloudinary asset service. Status transitions follow the real
+// service: only active assets can be archived or marked as broken, only archived assets can be
+// restored or deleted.
+type CloudinaryAssetServer struct {
+	cldassetpbv1.UnimplementedAssetServiceServer
+	assets *store[*cldassetpbv1.Details]
+}
+
+var _ cldassetpbv1.AssetServiceServer = (*CloudinaryAssetServer)(nil)
+
+// NewCloudinaryAssetServer creates a fake holding the given assets, see CloudinaryAsset for a fixture.
+func NewCloudinaryAssetServer(assets ...*cldassetpbv1.Details) *CloudinaryAssetServer {
+	s := &CloudinaryAssetServer{assets: newStore[*cldassetpbv1.Details]()}
+	s.Add(assets...)
+	return s
+}
+
+// Add stores the assets, replacing any asset with the same uuid.
+func (s *CloudinaryAssetServer) Add(assets ...*cldassetpbv1.Details) {
+	for _, d := range assets {
+		id, err := parseID(d.GetAsset().GetUuid())
+		if err != nil {
+			panic(fmt.Sprintf("grpctest: cloudinary asset fixture without a valid uuid: %v", err))
+		}
+		s.assets.put(id, d)
+	}
+}
+
+// Asset returns a copy of the stored asset, regardless of its status.
+func (s *CloudinaryAssetServer) Asset(id uuid.UUID) (*cldassetpbv1.Details, bool) {
+	return s.assets.get(id.String())
+}
+
+func (s *CloudinaryAssetServer) Ping(context.Context, *cldassetpbv1.PingRequest) (*cldassetpbv1.PingResponse, error) {
+	return &cldassetpbv1.PingResponse{Timestamp: time.Now().Unix()}, nil
+}
+
+func (s *CloudinaryAssetServer) Get(_ context.Context, req *cldassetpbv1.GetRequest) (*cldassetpbv1.GetResponse, error) {
+	d, err := s.getInStatus(req.GetUuid(), cldassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE)
+	if err != nil {
+		return nil, err
+	}
+	return &cldassetpbv1.GetResponse{Details: d}, nil
+}
+
+func (s *CloudinaryAssetServer) GetWithArchived(_ context.Context, req *cldassetpbv1.GetWithArchivedRequest) (*cldassetpbv1.GetWithArchivedResponse, error) {
+	d, err := s.getInStatus(req.GetUuid(), cldassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE, cldassetpbv1.AssetStatus_ASSET_STATUS_ARCHIVED)
+	if err != nil {
+		return nil, err
+	}
+	return &cldassetpbv1.GetWithArchivedResponse{Details: d}, nil
+}
+
+func (s *CloudinaryAssetServer) GetWithBroken(_ context.Context, req *cldassetpbv1.GetWithBrokenRequest) (*cldassetpbv1.GetWithBrokenResponse, error) {
+	d, err := s.getInStatus(req.GetUuid(), cldassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE, cldassetpbv1.AssetStatus_ASSET_STATUS_BROKEN)
+	if err != nil {
+		return nil, err
+	}
+	return &cldassetpbv1.GetWithBrokenResponse{Details: d}, nil
+}
+
+func (s *CloudinaryAssetServer) List(_ context.Context, req *cldassetpbv1.ListRequest) (*cldassetpbv1.ListResponse, error) {
+	details, next, err := s.list(req.GetUuids(), req.GetPageSize(), req.GetPageToken(), cldassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE)
+	if err != nil {
+		return nil, err
+	}
+	return &cldassetpbv1.ListResponse{Details: details, NextPageToken: next}, nil
+}
+
+func (s *CloudinaryAssetServer) ListArchived(_ context.Context, req *cldassetpbv1.ListArchivedRequest) (*cldassetpbv1.ListArchivedResponse, error) {
+	details, next, err := s.list(req.GetUuids(), req.GetPageSize(), req.GetPageToken(), cldassetpbv1.AssetStatus_ASSET_STATUS_ARCHIVED)
+	if err != nil {
+		return nil, err
+	}
+	return &cldassetpbv1.ListArchivedResponse{Details: details, NextPageToken: next}, nil
+}
+
+func (s *CloudinaryAssetServer) ListBroken(_ context.Context, req *cldassetpbv1.ListBrokenRequest) (*cldassetpbv1.ListBrokenResponse, error) {
+	details, next, err := s.list(req.GetUuids(), req.GetPageSize(), req.GetPageToken(), cldassetpbv1.AssetStatus_ASSET_STATUS_BROKEN)
+	if err != nil {
+		return nil, err
+	}
+	return &cldassetpbv1.ListBrokenResponse{Details: details, NextPageToken: next}, nil
+}
+
+// CreateSignedUploadURL stores an asset awaiting upload and returns a fixed signature.
+func (s *CloudinaryAssetServer) CreateSignedUploadURL(_ context.Context, req *cldassetpbv1.CreateSignedUploadURLRequest) (*cldassetpbv1.CreateSignedUploadURLResponse, error) {
+	if req.GetPublicId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "public_id is required")
+	}
+	id := uuid.New()
+	d := CloudinaryAsset(id, req.GetPublicId(), cldassetpbv1.AssetStatus_ASSET_STATUS_UPLOAD_URL_GENERATED)
+	d.Asset.CloudinaryAssetId, d.Asset.Url, d.Asset.SecureUrl = "", "", ""
+	d.Asset.CreatedBy = req.GetAdminUuid()
+	d.Asset.CreatedByName = optional(req.GetAdminName())
+	d.Asset.Note = optional(req.GetNote())
+	s.assets.put(id.String(), d)
+
+	return &cldassetpbv1.CreateSignedUploadURLResponse{
+		Signature:    "test-signature",
+		Timestamp:    strconv.FormatInt(time.Now().Unix(), 10),
+		ApiKey:       "test-api-key",
+		Eager:        req.Eager,
+		PublicId:     req.GetPublicId(),
+		ResourceType: d.Asset.ResourceType,
+	}, nil
+}
+
+func (s *CloudinaryAssetServer) Archive(_ context.Context, req *cldassetpbv1.ArchiveRequest) (*cldassetpbv1.ArchiveResponse, error) {
+	err := s.transition(req.GetUuid(), cldassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE, cldassetpbv1.AssetStatus_ASSET_STATUS_ARCHIVED, func(a *cldassetpbv1.Asset) {
+		a.ArchivedBy, a.ArchivedByName, a.Note = req.GetAdminUuid(), optional(req.GetAdminName()), optional(req.GetNote())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &cldassetpbv1.ArchiveResponse{}, nil
+}
+
+func (s *CloudinaryAssetServer) Restore(_ context.Context, req *cldassetpbv1.RestoreRequest) (*cldassetpbv1.RestoreResponse, error) {
+	err := s.transition(req.GetUuid(), cldassetpbv1.AssetStatus_ASSET_STATUS_ARCHIVED, cldassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE, func(a *cldassetpbv1.Asset) {
+		a.RestoredBy, a.RestoredByName, a.Note = req.GetAdminUuid(), optional(req.GetAdminName()), optional(req.GetNote())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &cldassetpbv1.RestoreResponse{}, nil
+}
+
+func (s *CloudinaryAssetServer) MarkAsBroken(_ context.Context, req *cldassetpbv1.MarkAsBrokenRequest) (*cldassetpbv1.MarkAsBrokenResponse, error) {
+	err := s.transition(req.GetUuid(), cldassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE, cldassetpbv1.AssetStatus_ASSET_STATUS_BROKEN, func(a *cldassetpbv1.Asset) {
+		a.MarkedAsBrokenBy, a.MarkedAsBrokenByName, a.Note = req.GetAdminUuid(), optional(req.GetAdminName()), optional(req.GetNote())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &cldassetpbv1.MarkAsBrokenResponse{}, nil
+}
+
+func (s *CloudinaryAssetServer) Delete(_ context.Context, req *cldassetpbv1.DeleteRequest) (*cldassetpbv1.DeleteResponse, error) {
+	id, err := parseID(req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.getInStatus(req.GetUuid(), cldassetpbv1.AssetStatus_ASSET_STATUS_ARCHIVED); err != nil {
+		return nil, err
+	}
+	s.assets.remove(id)
+	return &cldassetpbv1.DeleteResponse{}, nil
+}
+
+func (s *CloudinaryAssetServer) AddOwner(_ context.Context, req *cldassetpbv1.AddOwnerRequest) (*cldassetpbv1.AddOwnerResponse, error) {
+	id, err := parseID(req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := parseID(req.GetOwnerUuid()); err != nil {
+		return nil, err
+	}
+	err = s.assets.update(id, func(d *cldassetpbv1.Details) error {
+		if d.AssetMetadata == nil {
+			d.AssetMetadata = &cldmetapbv1.AssetMetadata{Key: id}
+		}
+		for _, o := range d.AssetMetadata.Owners {
+			if bytes.Equal(o.OwnerUuid, req.GetOwnerUuid()) && o.OwnerType == req.GetOwnerType() {
+				return status.Error(codes.AlreadyExists, "owner already associated with the asset")
+			}
+		}
+		d.AssetMetadata.Owners = append(d.AssetMetadata.Owners, &cldmetapbv1.Owner{OwnerUuid: req.GetOwnerUuid(), OwnerType: req.GetOwnerType()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &cldassetpbv1.AddOwnerResponse{}, nil
+}
+
+func (s *CloudinaryAssetServer) RemoveOwner(_ context.Context, req *cldassetpbv1.RemoveOwnerRequest) (*cldassetpbv1.RemoveOwnerResponse, error) {
+	id, err := parseID(req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	err = s.assets.update(id, func(d *cldassetpbv1.Details) error {
+		owners := d.GetAssetMetadata().GetOwners()
+		for i, o := range owners {
+			if bytes.Equal(o.OwnerUuid, req.GetOwnerUuid()) && o.OwnerType == req.GetOwnerType() {
+				d.AssetMetadata.Owners = append(owners[:i], owners[i+1:]...)
+				return nil
+			}
+		}
+		return status.Error(codes.NotFound, "owner not associated with the asset")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &cldassetpbv1.RemoveOwnerResponse{}, nil
+}
+
+func (s *CloudinaryAssetServer) getInStatus(rawID []byte, statuses ...cldassetpbv1.AssetStatus) (*cldassetpbv1.Details, error) {
+	id, err := parseID(rawID)
+	if err != nil {
+		return nil, err
+	}
+	d, ok := s.assets.get(id)
+	if !ok || !hasStatus(d.GetAsset().GetStatus(), statuses) {
+		return nil, status.Errorf(codes.NotFound, "asset %s not found", id)
+	}
+	return d, nil
+}
+
+func (s *CloudinaryAssetServer) list(ids [][]byte, pageSize int32, pageToken string, st cldassetpbv1.AssetStatus) ([]*cldassetpbv1.Details, string, error) {
+	match, err := idFilter(ids)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.assets.list(func(d *cldassetpbv1.Details) bool {
+		id, _ := parseID(d.GetAsset().GetUuid())
+		return d.GetAsset().GetStatus() == st && match(id)
+	}, pageSize, pageToken)
+}
+
+func (s *CloudinaryAssetServer) transition(rawID []byte, from, to cldassetpbv1.AssetStatus, fn func(*cldassetpbv1.Asset)) error {
+	id, err := parseID(rawID)
+	if err != nil {
+		return err
+	}
+	return s.assets.update(id, func(d *cldassetpbv1.Details) error {
+		if d.Asset.Status != from {
+			return status.Errorf(codes.NotFound, "asset %s not found", id)
+		}
+		d.Asset.Status = to
+		d.Asset.UpdatedAt = timestamppb.Now()
+		fn(d.Asset)
+		return nil
+	})
+}
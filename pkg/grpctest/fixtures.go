@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpctest
+
+import (
+	"github.com/google/uuid"
+	cldassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/asset/v1"
+	cldmetapbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/metadata/v1"
+	muxassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/asset/v1"
+	muxmetapbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/metadata/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MuxAsset returns a fixture of a ready MUX asset with public and signed playback ids derived from id.
+func MuxAsset(id uuid.UUID, title string, st muxassetpbv1.AssetStatus) *muxassetpbv1.Details {
+	now := timestamppb.Now()
+	muxAssetID := "mux-asset-" + id.String()
+	publicPlaybackID := "public-" + id.String()
+	signedPlaybackID := "signed-" + id.String()
+	duration := float32(60)
+	aspectRatio := "16:9"
+	resolutionTier := "1080p"
+	return &muxassetpbv1.Details{
+		Asset: &muxassetpbv1.Asset{
+			Uuid:                    id[:],
+			CreatedAt:               now,
+			UpdatedAt:               now,
+			MuxAssetId:              &muxAssetID,
+			State:                   muxassetpbv1.AssetState_ASSET_STATE_COMPLETED,
+			UploadStatus:            muxassetpbv1.AssetUploadStatus_ASSET_UPLOAD_STATUS_READY,
+			Duration:                &duration,
+			AspectRatio:             &aspectRatio,
+			AssetCreatedAt:          now,
+			ResolutionTier:          &resolutionTier,
+			PrimaryPublicPlaybackId: &publicPlaybackID,
+			PrimarySignedPlaybackId: &signedPlaybackID,
+			Status:                  st,
+		},
+		AssetMetadata: &muxmetapbv1.AssetMetadata{
+			Key:   id.String(),
+			Title: title,
+		},
+	}
+}
+
+// CloudinaryAsset returns a fixture of an uploaded Cloudinary image with the given public id.
+func CloudinaryAsset(id uuid.UUID, publicID string, st cldassetpbv1.AssetStatus) *cldassetpbv1.Details {
+	now := timestamppb.Now()
+	width, height := int32(1920), int32(1080)
+	url := "res.cloudinary.example.test/image/upload/" + publicID + ".jpg"
+	return &cldassetpbv1.Details{
+		Asset: &cldassetpbv1.Asset{
+			Uuid:               id[:],
+			CreatedAt:          now,
+			UpdatedAt:          now,
+			Status:             st,
+			CloudinaryAssetId:  "cld-asset-" + id.String(),
+			Url:                "http://" + url,
+			SecureUrl:          "https://" + url,
+			CloudinaryPublicId: publicID,
+			ResourceType:       "image",
+			Format:             "jpg",
+			Width:              &width,
+			Height:             &height,
+			DisplayName:        publicID,
+		},
+		AssetMetadata: &cldmetapbv1.AssetMetadata{
+			Key: id.String(),
+		},
+	}
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func hasStatus[S comparable](st S, statuses []S) bool {
+	for _, v := range statuses {
+		if v == st {
+			return true
+		}
+	}
+	return false
+}
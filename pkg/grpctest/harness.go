@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package grpctest runs media service gRPC servers over an in-memory buffered listener, so that
+// clients from pkg/client and server implementations can be tested end-to-end without network ports.
+package grpctest
+
+import (
+	"context"
+	"net"
+
+	cldassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/asset/v1"
+	muxassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/asset/v1"
+	"github.com/mikhail5545/media-service-go/pkg/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// DefaultBufferSize is the buffer size of the in-memory listener.
+const DefaultBufferSize = 1024 * 1024
+
+// Address is the target used to dial the harness. The passthrough resolver hands it to the
+// context dialer unchanged.
+const Address = "passthrough:///bufnet"
+
+type options struct {
+	bufSize    int
+	serverOpts []grpc.ServerOption
+	mux        muxassetpbv1.AssetServiceServer
+	cloudinary cldassetpbv1.AssetServiceServer
+}
+
+type Option func(*options)
+
+// WithBufferSize sets the buffer size of the in-memory listener.
+func WithBufferSize(size int) Option {
+	return func(o *options) {
+		o.bufSize = size
+	}
+}
+
+// WithServerOptions passes options to the gRPC server created by New, e.g. interceptors.
+func WithServerOptions(opts ...grpc.ServerOption) Option {
+	return func(o *options) {
+		o.serverOpts = append(o.serverOpts, opts...)
+	}
+}
+
+// WithMuxServer registers srv as the MUX asset service instead of a new MuxAssetServer.
+func WithMuxServer(srv muxassetpbv1.AssetServiceServer) Option {
+	return func(o *options) {
+		o.mux = srv
+	}
+}
+
+// WithCloudinaryServer registers srv as the Cloudinary asset service instead of a new CloudinaryAssetServer.
+func WithCloudinaryServer(srv cldassetpbv1.AssetServiceServer) Option {
+	return func(o *options) {
+		o.cloudinary = srv
+	}
+}
+
+// Harness serves a gRPC server on an in-memory listener.
+type Harness struct {
+	Server   *grpc.Server
+	listener *bufconn.Listener
+}
+
+// New starts a gRPC server with the MUX and Cloudinary asset services registered. Unless replaced
+// with WithMuxServer or WithCloudinaryServer, the services are empty in-memory fakes.
+func New(opts ...Option) *Harness {
+	o := &options{bufSize: DefaultBufferSize}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.mux == nil {
+		o.mux = NewMuxAssetServer()
+	}
+	if o.cloudinary == nil {
+		o.cloudinary = NewCloudinaryAssetServer()
+	}
+
+	server := grpc.NewServer(o.serverOpts...)
+	muxassetpbv1.RegisterAssetServiceServer(server, o.mux)
+	cldassetpbv1.RegisterAssetServiceServer(server, o.cloudinary)
+	return serve(server, o.bufSize)
+}
+
+// Serve starts an already configured server, e.g. the one built by the service itself, on an
+// in-memory listener.
+func Serve(server *grpc.Server, opts ...Option) *Harness {
+	o := &options{bufSize: DefaultBufferSize}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return serve(server, o.bufSize)
+}
+
+func serve(server *grpc.Server, bufSize int) *Harness {
+	h := &Harness{
+		Server:   server,
+		listener: bufconn.Listen(bufSize),
+	}
+	// Serve returns once the server is stopped by Close.
+	go func() { _ = server.Serve(h.listener) }()
+	return h
+}
+
+// Dialer returns a context dialer connecting to the in-memory listener.
+func (h *Harness) Dialer() func(context.Context, string) (net.Conn, error) {
+	return func(ctx context.Context, _ string) (net.Conn, error) {
+		return h.listener.DialContext(ctx)
+	}
+}
+
+// DialOptions returns the dial options needed to reach the harness with grpc.NewClient.
+func (h *Harness) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithContextDialer(h.Dialer()),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+}
+
+// ConnOptions returns the connection options needed to reach the harness with the Connect method
+// of the pkg/client clients, using Address as the address.
+func (h *Harness) ConnOptions() []client.ConnOption {
+	return []client.ConnOption{
+		client.WithInsecure(),
+		client.WithExtraDialOpts(grpc.WithContextDialer(h.Dialer())),
+	}
+}
+
+// Conn creates a client connection to the harness.
+func (h *Harness) Conn(opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	return grpc.NewClient(Address, append(h.DialOptions(), opts...)...)
+}
+
+// Close stops the server and closes the listener.
+func (h *Harness) Close() {
+	h.Server.Stop()
+	_ = h.listener.Close()
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpctest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	cldassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/asset/v1"
+	muxassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/asset/v1"
+	cldclient "github.com/mikhail5545/media-service-go/pkg/client/cloudinary"
+	muxclient "github.com/mikhail5545/media-service-go/pkg/client/mux"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMuxClient(t *testing.T) {
+	id := uuid.New()
+	h := New(WithMuxServer(NewMuxAssetServer(MuxAsset(id, "intro", muxassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE))))
+	t.Cleanup(h.Close)
+
+	ctx := context.Background()
+	c, err := muxclient.NewAssetServiceClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Connect(ctx, Address, h.ConnOptions()...); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+
+	res, err := c.Get(ctx, &muxassetpbv1.GetRequest{Uuid: id[:]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title := res.GetDetails().GetAssetMetadata().GetTitle(); title != "intro" {
+		t.Errorf("title = %q, want %q", title, "intro")
+	}
+
+	if _, err := c.Archive(ctx, &muxassetpbv1.ArchiveRequest{Uuid: id[:], Note: "outdated"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(ctx, &muxassetpbv1.GetRequest{Uuid: id[:]}); status.Code(err) != codes.NotFound {
+		t.Errorf("Get archived asset: code = %s, want %s", status.Code(err), codes.NotFound)
+	}
+	archived, err := c.GetWithArchived(ctx, &muxassetpbv1.GetWithArchivedRequest{Uuid: id[:]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if note := archived.GetDetails().GetAsset().GetNote(); note != "outdated" {
+		t.Errorf("note = %q, want %q", note, "outdated")
+	}
+}
+
+func TestCloudinaryClient(t *testing.T) {
+	id := uuid.New()
+	h := New(WithCloudinaryServer(NewCloudinaryAssetServer(CloudinaryAsset(id, "banner", cldassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE))))
+	t.Cleanup(h.Close)
+
+	ctx := context.Background()
+	c, err := cldclient.NewAssetServiceClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Connect(ctx, Address, h.ConnOptions()...); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+
+	res, err := c.Get(ctx, &cldassetpbv1.GetRequest{Uuid: id[:]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if publicID := res.GetDetails().GetAsset().GetCloudinaryPublicId(); publicID != "banner" {
+		t.Errorf("public id = %q, want %q", publicID, "banner")
+	}
+
+	if _, err := c.Get(ctx, &cldassetpbv1.GetRequest{Uuid: []byte("not a uuid")}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Get with invalid uuid: code = %s, want %s", status.Code(err), codes.InvalidArgument)
+	}
+}
@@ -0,0 +1,278 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpctest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	muxassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/asset/v1"
+	muxmetapbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/metadata/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MuxAssetServer is an in-memory fake of the MUX asset service. Status transitions follow the real
+// service: only active assets can be archived or marked as broken, only archived assets can be
+// restored or deleted.
+type MuxAssetServer struct {
+	muxassetpbv1.UnimplementedAssetServiceServer
+	assets *store[*muxassetpbv1.Details]
+}
+
+var _ muxassetpbv1.AssetServiceServer = (*MuxAssetServer)(nil)
+
+// NewMuxAssetServer creates a fake holding the given assets, see MuxAsset for a fixture.
+func NewMuxAssetServer(assets ...*muxassetpbv1.Details) *MuxAssetServer {
+	s := &MuxAssetServer{assets: newStore[*muxassetpbv1.Details]()}
+	s.Add(assets...)
+	return s
+}
+
+// Add stores the assets, replacing any asset with the same uuid.
+func (s *MuxAssetServer) Add(assets ...*muxassetpbv1.Details) {
+	for _, d := range assets {
+		id, err := parseID(d.GetAsset().GetUuid())
+		if err != nil {
+			panic(fmt.Sprintf("grpctest: mux asset fixture without a valid uuid: %v", err))
+		}
+		s.assets.put(id, d)
+	}
+}
+
+// Asset returns a copy of the stored asset, regardless of its status.
+func (s *MuxAssetServer) Asset(id uuid.UUID) (*muxassetpbv1.Details, bool) {
+	return s.assets.get(id.String())
+}
+
+func (s *MuxAssetServer) Ping(context.Context, *muxassetpbv1.PingRequest) (*muxassetpbv1.PingResponse, error) {
+	return &muxassetpbv1.PingResponse{Timestamp: time.Now().Unix()}, nil
+}
+
+func (s *MuxAssetServer) Get(_ context.Context, req *muxassetpbv1.GetRequest) (*muxassetpbv1.GetResponse, error) {
+	d, err := s.getInStatus(req.GetUuid(), muxassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE)
+	if err != nil {
+		return nil, err
+	}
+	return &muxassetpbv1.GetResponse{Details: d}, nil
+}
+
+func (s *MuxAssetServer) GetWithArchived(_ context.Context, req *muxassetpbv1.GetWithArchivedRequest) (*muxassetpbv1.GetWithArchivedResponse, error) {
+	d, err := s.getInStatus(req.GetUuid(), muxassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE, muxassetpbv1.AssetStatus_ASSET_STATUS_ARCHIVED)
+	if err != nil {
+		return nil, err
+	}
+	return &muxassetpbv1.GetWithArchivedResponse{Details: d}, nil
+}
+
+func (s *MuxAssetServer) GetWithBroken(_ context.Context, req *muxassetpbv1.GetWithBrokenRequest) (*muxassetpbv1.GetWithBrokenResponse, error) {
+	d, err := s.getInStatus(req.GetUuid(), muxassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE, muxassetpbv1.AssetStatus_ASSET_STATUS_BROKEN)
+	if err != nil {
+		return nil, err
+	}
+	return &muxassetpbv1.GetWithBrokenResponse{Details: d}, nil
+}
+
+func (s *MuxAssetServer) List(_ context.Context, req *muxassetpbv1.ListRequest) (*muxassetpbv1.ListResponse, error) {
+	details, next, err := s.list(req.GetUuids(), req.GetPageSize(), req.GetNextPageToken(), muxassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE)
+	if err != nil {
+		return nil, err
+	}
+	return &muxassetpbv1.ListResponse{Details: details, NextPageToken: next}, nil
+}
+
+func (s *MuxAssetServer) ListArchived(_ context.Context, req *muxassetpbv1.ListArchivedRequest) (*muxassetpbv1.ListArchivedResponse, error) {
+	details, next, err := s.list(req.GetUuids(), req.GetPageSize(), req.GetNextPageToken(), muxassetpbv1.AssetStatus_ASSET_STATUS_ARCHIVED)
+	if err != nil {
+		return nil, err
+	}
+	return &muxassetpbv1.ListArchivedResponse{Details: details, NextPageToken: next}, nil
+}
+
+func (s *MuxAssetServer) ListBroken(_ context.Context, req *muxassetpbv1.ListBrokenRequest) (*muxassetpbv1.ListBrokenResponse, error) {
+	details, next, err := s.list(req.GetUuids(), req.GetPageSize(), req.GetNextPageToken(), muxassetpbv1.AssetStatus_ASSET_STATUS_BROKEN)
+	if err != nil {
+		return nil, err
+	}
+	return &muxassetpbv1.ListBrokenResponse{Details: details, NextPageToken: next}, nil
+}
+
+func (s *MuxAssetServer) CreateUploadURL(_ context.Context, req *muxassetpbv1.CreateUploadURLRequest) (*muxassetpbv1.CreateUploadURLResponse, error) {
+	if req.GetTitle() == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+	id := uuid.New()
+	uploadID := "upload-" + id.String()
+	d := MuxAsset(id, req.GetTitle(), muxassetpbv1.AssetStatus_ASSET_STATUS_UPLOAD_URL_GENERATED)
+	d.Asset.MuxUploadId = &uploadID
+	d.Asset.MuxAssetId = nil
+	d.Asset.UploadStatus = muxassetpbv1.AssetUploadStatus_ASSET_UPLOAD_STATUS_PREPARING
+	d.Asset.CreatedBy = req.GetAdminUuid()
+	d.Asset.CreatedByName = optional(req.GetAdminName())
+	s.assets.put(id.String(), d)
+
+	return &muxassetpbv1.CreateUploadURLResponse{
+		Url:     "https://storage.example.test/mux/uploads/" + uploadID,
+		Timeout: 3600,
+		Status:  "waiting",
+		Id:      uploadID,
+	}, nil
+}
+
+func (s *MuxAssetServer) Archive(_ context.Context, req *muxassetpbv1.ArchiveRequest) (*muxassetpbv1.ArchiveResponse, error) {
+	err := s.transition(req.GetUuid(), muxassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE, muxassetpbv1.AssetStatus_ASSET_STATUS_ARCHIVED, func(a *muxassetpbv1.Asset) {
+		a.ArchivedBy, a.ArchivedByName, a.Note = req.GetAdminUuid(), optional(req.GetAdminName()), optional(req.GetNote())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &muxassetpbv1.ArchiveResponse{}, nil
+}
+
+func (s *MuxAssetServer) Restore(_ context.Context, req *muxassetpbv1.RestoreRequest) (*muxassetpbv1.RestoreResponse, error) {
+	err := s.transition(req.GetUuid(), muxassetpbv1.AssetStatus_ASSET_STATUS_ARCHIVED, muxassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE, func(a *muxassetpbv1.Asset) {
+		a.RestoredBy, a.RestoredByName, a.Note = req.GetAdminUuid(), optional(req.GetAdminName()), optional(req.GetNote())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &muxassetpbv1.RestoreResponse{}, nil
+}
+
+func (s *MuxAssetServer) MarkAsBroken(_ context.Context, req *muxassetpbv1.MarkAsBrokenRequest) (*muxassetpbv1.MarkAsBrokenResponse, error) {
+	err := s.transition(req.GetUuid(), muxassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE, muxassetpbv1.AssetStatus_ASSET_STATUS_BROKEN, func(a *muxassetpbv1.Asset) {
+		a.MarkedAsBrokenBy, a.MarkedAsBrokenByName, a.Note = req.GetAdminUuid(), optional(req.GetAdminName()), optional(req.GetNote())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &muxassetpbv1.MarkAsBrokenResponse{}, nil
+}
+
+func (s *MuxAssetServer) Delete(_ context.Context, req *muxassetpbv1.DeleteRequest) (*muxassetpbv1.DeleteResponse, error) {
+	id, err := parseID(req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.getInStatus(req.GetUuid(), muxassetpbv1.AssetStatus_ASSET_STATUS_ARCHIVED); err != nil {
+		return nil, err
+	}
+	s.assets.remove(id)
+	return &muxassetpbv1.DeleteResponse{}, nil
+}
+
+func (s *MuxAssetServer) AddOwner(_ context.Context, req *muxassetpbv1.AddOwnerRequest) (*muxassetpbv1.AddOwnerResponse, error) {
+	id, err := parseID(req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := parseID(req.GetOwnerUuid()); err != nil {
+		return nil, err
+	}
+	err = s.assets.update(id, func(d *muxassetpbv1.Details) error {
+		if d.AssetMetadata == nil {
+			d.AssetMetadata = &muxmetapbv1.AssetMetadata{Key: id}
+		}
+		for _, o := range d.AssetMetadata.Owners {
+			if bytes.Equal(o.OwnerUuid, req.GetOwnerUuid()) && o.OwnerType == req.GetOwnerType() {
+				return status.Error(codes.AlreadyExists, "owner already associated with the asset")
+			}
+		}
+		d.AssetMetadata.Owners = append(d.AssetMetadata.Owners, &muxmetapbv1.Owner{OwnerUuid: req.GetOwnerUuid(), OwnerType: req.GetOwnerType()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &muxassetpbv1.AddOwnerResponse{}, nil
+}
+
+func (s *MuxAssetServer) RemoveOwner(_ context.Context, req *muxassetpbv1.RemoveOwnerRequest) (*muxassetpbv1.RemoveOwnerResponse, error) {
+	id, err := parseID(req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	err = s.assets.update(id, func(d *muxassetpbv1.Details) error {
+		owners := d.GetAssetMetadata().GetOwners()
+		for i, o := range owners {
+			if bytes.Equal(o.OwnerUuid, req.GetOwnerUuid()) && o.OwnerType == req.GetOwnerType() {
+				d.AssetMetadata.Owners = append(owners[:i], owners[i+1:]...)
+				return nil
+			}
+		}
+		return status.Error(codes.NotFound, "owner not associated with the asset")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &muxassetpbv1.RemoveOwnerResponse{}, nil
+}
+
+// GeneratePlaybackToken returns a fixed, unsigned token for active assets.
+func (s *MuxAssetServer) GeneratePlaybackToken(_ context.Context, req *muxassetpbv1.GeneratePlaybackTokenRequest) (*muxassetpbv1.GeneratePlaybackTokenResponse, error) {
+	d, err := s.getInStatus(req.GetAssetUuid(), muxassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE)
+	if err != nil {
+		return nil, err
+	}
+	return &muxassetpbv1.GeneratePlaybackTokenResponse{
+		Token: "test-token." + d.GetAsset().GetPrimarySignedPlaybackId(),
+	}, nil
+}
+
+func (s *MuxAssetServer) getInStatus(rawID []byte, statuses ...muxassetpbv1.AssetStatus) (*muxassetpbv1.Details, error) {
+	id, err := parseID(rawID)
+	if err != nil {
+		return nil, err
+	}
+	d, ok := s.assets.get(id)
+	if !ok || !hasStatus(d.GetAsset().GetStatus(), statuses) {
+		return nil, status.Errorf(codes.NotFound, "asset %s not found", id)
+	}
+	return d, nil
+}
+
+func (s *MuxAssetServer) list(ids [][]byte, pageSize int32, pageToken string, st muxassetpbv1.AssetStatus) ([]*muxassetpbv1.Details, string, error) {
+	match, err := idFilter(ids)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.assets.list(func(d *muxassetpbv1.Details) bool {
+		id, _ := parseID(d.GetAsset().GetUuid())
+		return d.GetAsset().GetStatus() == st && match(id)
+	}, pageSize, pageToken)
+}
+
+func (s *MuxAssetServer) transition(rawID []byte, from, to muxassetpbv1.AssetStatus, fn func(*muxassetpbv1.Asset)) error {
+	id, err := parseID(rawID)
+	if err != nil {
+		return err
+	}
+	return s.assets.update(id, func(d *muxassetpbv1.Details) error {
+		if d.Asset.Status != from {
+			return status.Errorf(codes.NotFound, "asset %s not found", id)
+		}
+		d.Asset.Status = to
+		d.Asset.UpdatedAt = timestamppb.Now()
+		fn(d.Asset)
+		return nil
+	})
+}
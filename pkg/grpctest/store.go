@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpctest
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultPageSize = 50
+
+// store keeps messages in insertion order. Messages are cloned on the way in and out, so callers
+// never share them with the server goroutines.
+type store[T proto.Message] struct {
+	mu    sync.Mutex
+	items map[string]T
+	order []string
+}
+
+func newStore[T proto.Message]() *store[T] {
+	return &store[T]{items: make(map[string]T)}
+}
+
+func (s *store[T]) put(id string, msg T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.items[id]; !ok {
+		s.order = append(s.order, id)
+	}
+	s.items[id] = proto.Clone(msg).(T)
+}
+
+func (s *store[T]) get(id string) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg, ok := s.items[id]
+	if !ok {
+		return msg, false
+	}
+	return proto.Clone(msg).(T), true
+}
+
+// update runs fn on the stored message under the lock, fn's changes are kept unless it returns an error.
+func (s *store[T]) update(id string, fn func(T) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg, ok := s.items[id]
+	if !ok {
+		return status.Errorf(codes.NotFound, "asset %s not found", id)
+	}
+	updated := proto.Clone(msg).(T)
+	if err := fn(updated); err != nil {
+		return err
+	}
+	s.items[id] = updated
+	return nil
+}
+
+func (s *store[T]) remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.items[id]; !ok {
+		return
+	}
+	delete(s.items, id)
+	for i, v := range s.order {
+		if v == id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// list returns a page of the messages matching filter. The page token is the offset into the
+// filtered messages.
+func (s *store[T]) list(filter func(T) bool, pageSize int32, pageToken string) ([]T, string, error) {
+	offset := 0
+	if pageToken != "" {
+		var err error
+		if offset, err = strconv.Atoi(pageToken); err != nil || offset < 0 {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matched []T
+	for _, id := range s.order {
+		if msg := s.items[id]; filter(msg) {
+			matched = append(matched, msg)
+		}
+	}
+	if offset >= len(matched) {
+		return nil, "", nil
+	}
+	end := min(offset+int(pageSize), len(matched))
+	page := make([]T, 0, end-offset)
+	for _, msg := range matched[offset:end] {
+		page = append(page, proto.Clone(msg).(T))
+	}
+	next := ""
+	if end < len(matched) {
+		next = strconv.Itoa(end)
+	}
+	return page, next, nil
+}
+
+func parseID(b []byte) (string, error) {
+	id, err := uuid.FromBytes(b)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, "invalid uuid")
+	}
+	return id.String(), nil
+}
+
+// idFilter matches messages whose id is in ids, or every message if ids is empty.
+func idFilter(ids [][]byte) (func(string) bool, error) {
+	if len(ids) == 0 {
+		return func(string) bool { return true }, nil
+	}
+	set := make(map[string]struct{}, len(ids))
+	for _, b := range ids {
+		id, err := parseID(b)
+		if err != nil {
+			return nil, err
+		}
+		set[id] = struct{}{}
+	}
+	return func(id string) bool {
+		_, ok := set[id]
+		return ok
+	}, nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package media
+
+import (
+	"context"
+
+	cldassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/asset/v1"
+	mediaerrors "github.com/mikhail5545/media-service-go/pkg/errors"
+)
+
+// Images manages the Cloudinary image assets.
+type Images struct {
+	client cldassetpbv1.AssetServiceClient
+}
+
+// ListImagesOptions filters and orders a listing of the active images. Empty filters match every image.
+type ListImagesOptions struct {
+	IDs           []string
+	PublicIDs     []string
+	ResourceTypes []string
+	Formats       []string
+	// OrderBy is a column of the asset, e.g. "created_at", OrderDir is "asc" or "desc".
+	OrderBy  string
+	OrderDir string
+	// PageSize is the number of images fetched at once, the service default is used when it is 0.
+	PageSize int
+	// PageToken resumes a listing, see [Iterator.PageToken].
+	PageToken string
+}
+
+// Get retrieves an active image.
+func (i *Images) Get(ctx context.Context, id string) (*Image, error) {
+	b, err := uuidBytes("image id", id)
+	if err != nil {
+		return nil, err
+	}
+	res, err := i.client.Get(ctx, &cldassetpbv1.GetRequest{Uuid: b})
+	if err != nil {
+		return nil, mediaerrors.FromGRPC(err)
+	}
+	return imageFromProto(res.GetDetails()), nil
+}
+
+// List iterates over the active images matching the options.
+func (i *Images) List(ctx context.Context, opts ListImagesOptions) *Iterator[*Image] {
+	return newIterator(ctx, opts.PageToken, func(ctx context.Context, pageToken string) ([]*Image, string, error) {
+		ids, err := uuidsBytes("image id", opts.IDs)
+		if err != nil {
+			return nil, "", err
+		}
+		req := &cldassetpbv1.ListRequest{
+			Uuids:               ids,
+			CloudinaryPublicIds: opts.PublicIDs,
+			ResourceTypes:       opts.ResourceTypes,
+			Formats:             opts.Formats,
+			PageSize:            int32(opts.PageSize),
+			PageToken:           pageToken,
+		}
+		if opts.OrderBy != "" {
+			req.OrderBy = &opts.OrderBy
+		}
+		if opts.OrderDir != "" {
+			req.OrderDir = &opts.OrderDir
+		}
+		res, err := i.client.List(ctx, req)
+		if err != nil {
+			return nil, "", mediaerrors.FromGRPC(err)
+		}
+		images := make([]*Image, 0, len(res.GetDetails()))
+		for _, d := range res.GetDetails() {
+			images = append(images, imageFromProto(d))
+		}
+		return images, res.GetNextPageToken(), nil
+	})
+}
+
+// AddOwner associates the owner with the image.
+func (i *Images) AddOwner(ctx context.Context, id string, owner Owner) error {
+	assetID, ownerID, err := ownerRequestIDs(id, owner)
+	if err != nil {
+		return err
+	}
+	_, err = i.client.AddOwner(ctx, &cldassetpbv1.AddOwnerRequest{Uuid: assetID, OwnerUuid: ownerID, OwnerType: owner.Type})
+	return mediaerrors.FromGRPC(err)
+}
+
+// RemoveOwner removes the owner from the image.
+func (i *Images) RemoveOwner(ctx context.Context, id string, owner Owner) error {
+	assetID, ownerID, err := ownerRequestIDs(id, owner)
+	if err != nil {
+		return err
+	}
+	_, err = i.client.RemoveOwner(ctx, &cldassetpbv1.RemoveOwnerRequest{Uuid: assetID, OwnerUuid: ownerID, OwnerType: owner.Type})
+	return mediaerrors.FromGRPC(err)
+}
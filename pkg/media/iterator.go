@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package media
+
+import (
+	"context"
+)
+
+// Iterator pages through a listing, fetching the next page when the current one is consumed:
+//
+//	for it.Next() {
+//		item := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, pageToken string) ([]T, string, error)
+
+	page  []T
+	value T
+	token string
+	done  bool
+	err   error
+}
+
+func newIterator[T any](ctx context.Context, pageToken string, fetch func(ctx context.Context, pageToken string) ([]T, string, error)) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch, token: pageToken}
+}
+
+// Next advances to the next item and reports whether there is one. It returns false at the end of
+// the listing or on an error, see Err.
+func (it *Iterator[T]) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		page, next, err := it.fetch(it.ctx, it.token)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page, it.token, it.done = page, next, next == ""
+	}
+	it.value, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// PageToken returns the token of the page after the fetched ones, a listing started with it resumes
+// after the last fetched page. It is empty at the end of the listing.
+func (it *Iterator[T]) PageToken() string {
+	return it.token
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package media is a high-level client of the media service. It wraps the generated gRPC clients of
+// the MUX and Cloudinary asset services with plain Go types: IDs are UUID strings, statuses are typed
+// strings, listings are iterators and errors are the typed errors of the pkg/errors package.
+//
+//	c, err := media.Connect(ctx, "media-service:9090", media.WithAdmin(adminID, "Catalog sync"))
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//
+//	it := c.Videos.List(ctx, media.ListVideosOptions{PageSize: 100})
+//	for it.Next() {
+//		fmt.Println(it.Value().ID, it.Value().Title)
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+//
+// The raw clients of pkg/client/mux and pkg/client/cloudinary remain available for the calls this
+// package does not cover.
+package media
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	cldassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/asset/v1"
+	muxassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/asset/v1"
+	"github.com/mikhail5545/media-service-go/pkg/client"
+	"google.golang.org/grpc"
+)
+
+// defaultPollInterval is the interval between the status checks of an upload.
+const defaultPollInterval = 2 * time.Second
+
+type options struct {
+	adminID      string
+	adminName    string
+	pollInterval time.Duration
+	httpClient   *http.Client
+	callOpts     []client.Option
+	connOpts     []client.ConnOption
+}
+
+type Option func(*options)
+
+// WithAdmin sets the admin recorded as the creator of the uploads. It is required to create uploads.
+func WithAdmin(id, name string) Option {
+	return func(o *options) {
+		o.adminID = id
+		o.adminName = name
+	}
+}
+
+// WithPollInterval sets the interval between the status checks of an upload, it defaults to 2s.
+func WithPollInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.pollInterval = interval
+	}
+}
+
+// WithHTTPClient sets the HTTP client uploading the files, it defaults to [http.DefaultClient].
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = c
+	}
+}
+
+// WithCallOptions sets the per-call options of the connection opened by Connect, e.g. client.WithTimeout.
+func WithCallOptions(opts ...client.Option) Option {
+	return func(o *options) {
+		o.callOpts = append(o.callOpts, opts...)
+	}
+}
+
+// WithConnOptions sets the options of the connection opened by Connect, e.g. client.WithAPIKey.
+func WithConnOptions(opts ...client.ConnOption) Option {
+	return func(o *options) {
+		o.connOpts = append(o.connOpts, opts...)
+	}
+}
+
+// Client is a client of the MUX and Cloudinary asset services of one media service instance.
+type Client struct {
+	Videos *Videos
+	Images *Images
+
+	conn *grpc.ClientConn
+}
+
+// New creates a client using an existing connection, which is not closed by Close.
+func New(conn grpc.ClientConnInterface, opts ...Option) *Client {
+	o := &options{pollInterval: defaultPollInterval, httpClient: http.DefaultClient}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Client{
+		Videos: &Videos{client: muxassetpbv1.NewAssetServiceClient(conn), opts: o},
+		Images: &Images{client: cldassetpbv1.NewAssetServiceClient(conn)},
+	}
+}
+
+// Connect dials the media service at address and creates a client owning the connection.
+func Connect(ctx context.Context, address string, opts ...Option) (*Client, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	conn, err := client.Dial(ctx, address, client.NewConfig(o.callOpts...), o.connOpts...)
+	if err != nil {
+		return nil, err
+	}
+	c := New(conn, opts...)
+	c.conn = conn
+	return c, nil
+}
+
+// Close closes the connection opened by Connect.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
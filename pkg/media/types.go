@@ -0,0 +1,247 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package media
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	cldassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/asset/v1"
+	cldmetadatapbv1 "github.com/mikhail5545/media-service-go/pb/media_service/cloudinary/metadata/v1"
+	muxassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/asset/v1"
+	muxmetadatapbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/metadata/v1"
+	mediaerrors "github.com/mikhail5545/media-service-go/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Status is the lifecycle status of an asset.
+type Status string
+
+const (
+	StatusUnknown Status = ""
+	// StatusUploadURLGenerated assets have an upload URL but no uploaded file yet.
+	StatusUploadURLGenerated Status = "upload_url_generated"
+	StatusActive             Status = "active"
+	StatusArchived           Status = "archived"
+	StatusBroken             Status = "broken"
+)
+
+// UploadStatus is the status of the uploaded file of a video.
+type UploadStatus string
+
+const (
+	UploadStatusUnknown   UploadStatus = ""
+	UploadStatusPreparing UploadStatus = "preparing"
+	UploadStatusReady     UploadStatus = "ready"
+	UploadStatusErrored   UploadStatus = "errored"
+	UploadStatusDeleted   UploadStatus = "deleted"
+)
+
+// State is the processing state of a video in MUX.
+type State string
+
+const (
+	StateUnknown     State = ""
+	StateIngesting   State = "ingesting"
+	StateTranscoding State = "transcoding"
+	StateCompleted   State = "completed"
+	StateLive        State = "live"
+	StateErrored     State = "errored"
+)
+
+// Owner is an entity of another service an asset is associated with, e.g. a lesson or a product.
+type Owner struct {
+	ID   string
+	Type string
+}
+
+// Video is a MUX video asset with its metadata.
+type Video struct {
+	ID           string
+	Status       Status
+	UploadStatus UploadStatus
+	State        State
+	Title        string
+	CreatorID    string
+	Owners       []Owner
+
+	MuxUploadID      string
+	MuxAssetID       string
+	Duration         time.Duration
+	AspectRatio      string
+	ResolutionTier   string
+	PublicPlaybackID string
+	SignedPlaybackID string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Raw is the message the video was converted from.
+	Raw *muxassetpbv1.Details
+}
+
+// Image is a Cloudinary image asset with its owners.
+type Image struct {
+	ID                string
+	Status            Status
+	CloudinaryAssetID string
+	PublicID          string
+	URL               string
+	SecureURL         string
+	ResourceType      string
+	Format            string
+	Width             int
+	Height            int
+	Tags              []string
+	DisplayName       string
+	Owners            []Owner
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Raw is the message the image was converted from.
+	Raw *cldassetpbv1.Details
+}
+
+var muxStatuses = map[muxassetpbv1.AssetStatus]Status{
+	muxassetpbv1.AssetStatus_ASSET_STATUS_UPLOAD_URL_GENERATED: StatusUploadURLGenerated,
+	muxassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE:               StatusActive,
+	muxassetpbv1.AssetStatus_ASSET_STATUS_ARCHIVED:             StatusArchived,
+	muxassetpbv1.AssetStatus_ASSET_STATUS_BROKEN:               StatusBroken,
+}
+
+var cldStatuses = map[cldassetpbv1.AssetStatus]Status{
+	cldassetpbv1.AssetStatus_ASSET_STATUS_UPLOAD_URL_GENERATED: StatusUploadURLGenerated,
+	cldassetpbv1.AssetStatus_ASSET_STATUS_ACTIVE:               StatusActive,
+	cldassetpbv1.AssetStatus_ASSET_STATUS_ARCHIVED:             StatusArchived,
+	cldassetpbv1.AssetStatus_ASSET_STATUS_BROKEN:               StatusBroken,
+}
+
+var uploadStatuses = map[muxassetpbv1.AssetUploadStatus]UploadStatus{
+	muxassetpbv1.AssetUploadStatus_ASSET_UPLOAD_STATUS_PREPARING: UploadStatusPreparing,
+	muxassetpbv1.AssetUploadStatus_ASSET_UPLOAD_STATUS_READY:     UploadStatusReady,
+	muxassetpbv1.AssetUploadStatus_ASSET_UPLOAD_STATUS_ERRORED:   UploadStatusErrored,
+	muxassetpbv1.AssetUploadStatus_ASSET_UPLOAD_STATUS_DELETED:   UploadStatusDeleted,
+}
+
+var states = map[muxassetpbv1.AssetState]State{
+	muxassetpbv1.AssetState_ASSET_STATE_INGESTING:   StateIngesting,
+	muxassetpbv1.AssetState_ASSET_STATE_TRANSCODING: StateTranscoding,
+	muxassetpbv1.AssetState_ASSET_STATE_COMPLETED:   StateCompleted,
+	muxassetpbv1.AssetState_ASSET_STATE_LIVE:        StateLive,
+	muxassetpbv1.AssetState_ASSET_STATE_ERRORED:     StateErrored,
+}
+
+func videoFromProto(d *muxassetpbv1.Details) *Video {
+	a := d.GetAsset()
+	m := d.GetAssetMetadata()
+	v := &Video{
+		ID:               uuidString(a.GetUuid()),
+		Status:           muxStatuses[a.GetStatus()],
+		UploadStatus:     uploadStatuses[a.GetUploadStatus()],
+		State:            states[a.GetState()],
+		Title:            m.GetTitle(),
+		CreatorID:        m.GetCreatorId(),
+		Owners:           muxOwners(m.GetOwners()),
+		MuxUploadID:      a.GetMuxUploadId(),
+		MuxAssetID:       a.GetMuxAssetId(),
+		Duration:         time.Duration(float64(a.GetDuration()) * float64(time.Second)),
+		AspectRatio:      a.GetAspectRatio(),
+		ResolutionTier:   a.GetResolutionTier(),
+		PublicPlaybackID: a.GetPrimaryPublicPlaybackId(),
+		SignedPlaybackID: a.GetPrimarySignedPlaybackId(),
+		CreatedAt:        timeOf(a.GetCreatedAt()),
+		UpdatedAt:        timeOf(a.GetUpdatedAt()),
+		Raw:              d,
+	}
+	return v
+}
+
+func imageFromProto(d *cldassetpbv1.Details) *Image {
+	a := d.GetAsset()
+	return &Image{
+		ID:                uuidString(a.GetUuid()),
+		Status:            cldStatuses[a.GetStatus()],
+		CloudinaryAssetID: a.GetCloudinaryAssetId(),
+		PublicID:          a.GetCloudinaryPublicId(),
+		URL:               a.GetUrl(),
+		SecureURL:         a.GetSecureUrl(),
+		ResourceType:      a.GetResourceType(),
+		Format:            a.GetFormat(),
+		Width:             int(a.GetWidth()),
+		Height:            int(a.GetHeight()),
+		Tags:              a.GetTags(),
+		DisplayName:       a.GetDisplayName(),
+		Owners:            cldOwners(d.GetAssetMetadata().GetOwners()),
+		CreatedAt:         timeOf(a.GetCreatedAt()),
+		UpdatedAt:         timeOf(a.GetUpdatedAt()),
+		Raw:               d,
+	}
+}
+
+func muxOwners(owners []*muxmetadatapbv1.Owner) []Owner {
+	result := make([]Owner, 0, len(owners))
+	for _, o := range owners {
+		result = append(result, Owner{ID: uuidString(o.GetOwnerUuid()), Type: o.GetOwnerType()})
+	}
+	return result
+}
+
+func cldOwners(owners []*cldmetadatapbv1.Owner) []Owner {
+	result := make([]Owner, 0, len(owners))
+	for _, o := range owners {
+		result = append(result, Owner{ID: uuidString(o.GetOwnerUuid()), Type: o.GetOwnerType()})
+	}
+	return result
+}
+
+// uuidString returns the string form of a UUID sent as bytes, or an empty string if there is none.
+func uuidString(b []byte) string {
+	id, err := uuid.FromBytes(b)
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
+
+// uuidBytes parses the UUID string named name into the bytes sent over gRPC.
+func uuidBytes(name, s string) ([]byte, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid %s %q", mediaerrors.ErrInvalidArgument, name, s)
+	}
+	return id[:], nil
+}
+
+func uuidsBytes(name string, ids []string) ([][]byte, error) {
+	result := make([][]byte, 0, len(ids))
+	for _, s := range ids {
+		b, err := uuidBytes(name, s)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, b)
+	}
+	return result, nil
+}
+
+func timeOf(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
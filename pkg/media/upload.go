@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	muxassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/asset/v1"
+	mediaerrors "github.com/mikhail5545/media-service-go/pkg/errors"
+)
+
+// ErrUploadFailed the uploaded video could not be processed, the video is returned with the error.
+var ErrUploadFailed = errors.New("media: upload failed")
+
+// VideoUpload is a MUX direct upload created by [Videos.CreateUpload]. The file is uploaded to URL,
+// by Put or by a browser, and Wait returns the video once it is processed.
+type VideoUpload struct {
+	// URL receives the file with a PUT request.
+	URL string
+	// UploadID is the MUX upload ID, the video is found by it.
+	UploadID string
+	// Timeout is the time the URL accepts the file for.
+	Timeout time.Duration
+	// CORSOrigin is the origin browsers can upload the file from.
+	CORSOrigin string
+
+	videos *Videos
+	owner  *Owner
+}
+
+// CreateUpload creates a MUX direct upload URL for a new video. The admin set with [WithAdmin] is
+// recorded as the creator.
+func (v *Videos) CreateUpload(ctx context.Context, title string) (*VideoUpload, error) {
+	if v.opts.adminID == "" {
+		return nil, fmt.Errorf("%w: the admin creating the upload is required, see WithAdmin", mediaerrors.ErrInvalidArgument)
+	}
+	adminID, err := uuidBytes("admin id", v.opts.adminID)
+	if err != nil {
+		return nil, err
+	}
+	res, err := v.client.CreateUploadURL(ctx, &muxassetpbv1.CreateUploadURLRequest{
+		Title:     title,
+		AdminUuid: adminID,
+		AdminName: v.opts.adminName,
+	})
+	if err != nil {
+		return nil, mediaerrors.FromGRPC(err)
+	}
+	return &VideoUpload{
+		URL:        res.GetUrl(),
+		UploadID:   res.GetId(),
+		Timeout:    time.Duration(res.GetTimeout()) * time.Second,
+		CORSOrigin: res.GetCorsOrigin(),
+		videos:     v,
+	}, nil
+}
+
+// UploadVideoForOwner uploads the file as a new video, waits until it is processed and associates
+// it with the owner. Processing usually takes about as long as the video, set a deadline on ctx to
+// bound the wait.
+func (v *Videos) UploadVideoForOwner(ctx context.Context, owner Owner, title string, file io.Reader) (*Video, error) {
+	if _, err := uuidBytes("owner id", owner.ID); err != nil {
+		return nil, err
+	}
+	upload, err := v.CreateUpload(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+	upload.owner = &owner
+	if err := upload.Put(ctx, file); err != nil {
+		return nil, err
+	}
+	return upload.Wait(ctx)
+}
+
+// Put uploads the file to the upload URL.
+func (u *VideoUpload) Put(ctx context.Context, file io.Reader) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.URL, file)
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	res, err := u.videos.opts.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload video: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("failed to upload video: unexpected status %s", res.Status)
+	}
+	return nil
+}
+
+// Wait polls the service until the uploaded video is ready and returns it. A video which failed to
+// process is returned with [ErrUploadFailed]. An upload created by UploadVideoForOwner is associated
+// with the owner before it is returned.
+func (u *VideoUpload) Wait(ctx context.Context) (*Video, error) {
+	ticker := time.NewTicker(u.videos.opts.pollInterval)
+	defer ticker.Stop()
+	for {
+		video, err := u.find(ctx)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case video == nil:
+		case video.Status == StatusBroken || video.UploadStatus == UploadStatusErrored || video.State == StateErrored:
+			return video, ErrUploadFailed
+		case video.UploadStatus == UploadStatusReady:
+			return u.associate(ctx, video)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// find returns the video of the upload, or nil while it is not listed yet.
+func (u *VideoUpload) find(ctx context.Context) (*Video, error) {
+	it := u.videos.List(ctx, ListVideosOptions{MuxUploadIDs: []string{u.UploadID}, PageSize: 1})
+	if it.Next() {
+		return it.Value(), nil
+	}
+	return nil, it.Err()
+}
+
+func (u *VideoUpload) associate(ctx context.Context, video *Video) (*Video, error) {
+	if u.owner == nil {
+		return video, nil
+	}
+	if err := u.videos.AddOwner(ctx, video.ID, *u.owner); err != nil && !errors.Is(err, mediaerrors.ErrOwnerHasAsset) {
+		return video, fmt.Errorf("failed to associate the video with the owner: %w", err)
+	}
+	return u.videos.Get(ctx, video.ID)
+}
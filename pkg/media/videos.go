@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package media
+
+import (
+	"context"
+	"time"
+
+	muxassetpbv1 "github.com/mikhail5545/media-service-go/pb/media_service/mux/asset/v1"
+	mediaerrors "github.com/mikhail5545/media-service-go/pkg/errors"
+)
+
+// Videos manages the MUX video assets.
+type Videos struct {
+	client muxassetpbv1.AssetServiceClient
+	opts   *options
+}
+
+// ListVideosOptions filters and orders a listing of the active videos. Empty filters match every video.
+type ListVideosOptions struct {
+	IDs            []string
+	MuxUploadIDs   []string
+	MuxAssetIDs    []string
+	UploadStatuses []UploadStatus
+	// OrderBy is a column of the asset, e.g. "created_at", OrderDir is "asc" or "desc".
+	OrderBy  string
+	OrderDir string
+	// PageSize is the number of videos fetched at once, the service default is used when it is 0.
+	PageSize int
+	// PageToken resumes a listing, see [Iterator.PageToken].
+	PageToken string
+}
+
+// Get retrieves an active video.
+func (v *Videos) Get(ctx context.Context, id string) (*Video, error) {
+	b, err := uuidBytes("video id", id)
+	if err != nil {
+		return nil, err
+	}
+	res, err := v.client.Get(ctx, &muxassetpbv1.GetRequest{Uuid: b})
+	if err != nil {
+		return nil, mediaerrors.FromGRPC(err)
+	}
+	return videoFromProto(res.GetDetails()), nil
+}
+
+// List iterates over the active videos matching the options.
+func (v *Videos) List(ctx context.Context, opts ListVideosOptions) *Iterator[*Video] {
+	return newIterator(ctx, opts.PageToken, func(ctx context.Context, pageToken string) ([]*Video, string, error) {
+		req, err := opts.request()
+		if err != nil {
+			return nil, "", err
+		}
+		if pageToken != "" {
+			req.NextPageToken = &pageToken
+		}
+		res, err := v.client.List(ctx, req)
+		if err != nil {
+			return nil, "", mediaerrors.FromGRPC(err)
+		}
+		videos := make([]*Video, 0, len(res.GetDetails()))
+		for _, d := range res.GetDetails() {
+			videos = append(videos, videoFromProto(d))
+		}
+		return videos, res.GetNextPageToken(), nil
+	})
+}
+
+func (opts ListVideosOptions) request() (*muxassetpbv1.ListRequest, error) {
+	ids, err := uuidsBytes("video id", opts.IDs)
+	if err != nil {
+		return nil, err
+	}
+	req := &muxassetpbv1.ListRequest{
+		Uuids:        ids,
+		MuxUploadIds: opts.MuxUploadIDs,
+		MuxAssetIds:  opts.MuxAssetIDs,
+		PageSize:     int32(opts.PageSize),
+	}
+	for _, s := range opts.UploadStatuses {
+		for status, us := range uploadStatuses {
+			if us == s {
+				req.UploadStatuses = append(req.UploadStatuses, status)
+			}
+		}
+	}
+	if opts.OrderBy != "" {
+		req.OrderBy = &opts.OrderBy
+	}
+	if opts.OrderDir != "" {
+		req.OrderDir = &opts.OrderDir
+	}
+	return req, nil
+}
+
+// AddOwner associates the owner with the video.
+func (v *Videos) AddOwner(ctx context.Context, id string, owner Owner) error {
+	assetID, ownerID, err := ownerRequestIDs(id, owner)
+	if err != nil {
+		return err
+	}
+	_, err = v.client.AddOwner(ctx, &muxassetpbv1.AddOwnerRequest{Uuid: assetID, OwnerUuid: ownerID, OwnerType: owner.Type})
+	return mediaerrors.FromGRPC(err)
+}
+
+// RemoveOwner removes the owner from the video.
+func (v *Videos) RemoveOwner(ctx context.Context, id string, owner Owner) error {
+	assetID, ownerID, err := ownerRequestIDs(id, owner)
+	if err != nil {
+		return err
+	}
+	_, err = v.client.RemoveOwner(ctx, &muxassetpbv1.RemoveOwnerRequest{Uuid: assetID, OwnerUuid: ownerID, OwnerType: owner.Type})
+	return mediaerrors.FromGRPC(err)
+}
+
+// PlaybackToken generates a signed playback token of the video for the user, valid for ttl. The
+// service requires a ttl of at least 15 minutes.
+func (v *Videos) PlaybackToken(ctx context.Context, id, userID string, ttl time.Duration) (string, error) {
+	assetID, err := uuidBytes("video id", id)
+	if err != nil {
+		return "", err
+	}
+	user, err := uuidBytes("user id", userID)
+	if err != nil {
+		return "", err
+	}
+	res, err := v.client.GeneratePlaybackToken(ctx, &muxassetpbv1.GeneratePlaybackTokenRequest{
+		AssetUuid:  assetID,
+		UserUuid:   user,
+		Expiration: int64(ttl / time.Second),
+	})
+	if err != nil {
+		return "", mediaerrors.FromGRPC(err)
+	}
+	return res.GetToken(), nil
+}
+
+func ownerRequestIDs(id string, owner Owner) (assetID, ownerID []byte, err error) {
+	if assetID, err = uuidBytes("asset id", id); err != nil {
+		return nil, nil, err
+	}
+	if ownerID, err = uuidBytes("owner id", owner.ID); err != nil {
+		return nil, nil, err
+	}
+	return assetID, ownerID, nil
+}
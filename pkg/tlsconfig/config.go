@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+)
+
+// ClientAuth controls verification of client certificates by the server.
+type ClientAuth string
+
+const (
+	// ClientAuthNone does not request client certificates.
+	ClientAuthNone ClientAuth = "none"
+	// ClientAuthRequest verifies client certificates if presented.
+	ClientAuthRequest ClientAuth = "request"
+	// ClientAuthRequire requires and verifies client certificates (mutual TLS).
+	ClientAuthRequire ClientAuth = "require"
+)
+
+func (c ClientAuth) tlsType() (tls.ClientAuthType, error) {
+	switch c {
+	case "", ClientAuthNone:
+		return tls.NoClientCert, nil
+	case ClientAuthRequest:
+		return tls.VerifyClientCertIfGiven, nil
+	case ClientAuthRequire:
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return tls.NoClientCert, fmt.Errorf("unknown client auth mode %q", c)
+	}
+}
+
+// Server returns a server TLS configuration serving the reloader certificate. When clientAuth is
+// not [ClientAuthNone], client certificates are verified against the reloader CA pool.
+func Server(r *Reloader, clientAuth ClientAuth) (*tls.Config, error) {
+	if !r.HasCertificate() {
+		return nil, errors.New("server TLS requires a certificate and key")
+	}
+	authType, err := clientAuth.tlsType()
+	if err != nil {
+		return nil, err
+	}
+	if authType != tls.NoClientCert && !r.HasCA() {
+		return nil, errors.New("client certificate verification requires a CA file")
+	}
+
+	base := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: authType,
+	}
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		cfg := base.Clone()
+		cfg.GetConfigForClient = nil
+		cfg.Certificates = []tls.Certificate{*r.Certificate()}
+		cfg.ClientCAs = r.CAPool()
+		return cfg, nil
+	}
+	return base, nil
+}
+
+// Client returns a client TLS configuration. The reloader certificate, if any, is presented to
+// servers requesting one. Server certificates are verified against the reloader CA pool, or the
+// system pool when no CA file is configured. r may be nil to use system roots only.
+func Client(r *Reloader, serverName string) *tls.Config {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+	if r == nil {
+		return cfg
+	}
+	if r.HasCertificate() {
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.Certificate(), nil
+		}
+	}
+	if r.HasCA() {
+		// Standard verification reads RootCAs once, so it is replaced with a verification
+		// against the current pool to pick up rotated CA bundles.
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			return verifyServer(cs, r.CAPool())
+		}
+	}
+	return cfg
+}
+
+func verifyServer(cs tls.ConnectionState, roots *x509.CertPool) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("server did not present a certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       cs.ServerName,
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	return err
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright (c) 2026. Mikhail Kulik.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package tlsconfig builds server and client TLS configurations from PEM files and reloads
+// rotated certificates without restarting the process.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultReloadInterval is the minimum interval between checks of the files for changes.
+const DefaultReloadInterval = 30 * time.Second
+
+// Files points to PEM encoded certificate material.
+type Files struct {
+	// CertFile and KeyFile hold the local certificate chain and its private key. Both are optional
+	// for clients that do not present a certificate.
+	CertFile string
+	KeyFile  string
+	// CAFile holds the CA bundle used to verify the peer. When empty, servers do not verify client
+	// certificates and clients use the system root pool.
+	CAFile string
+}
+
+// Reloader holds the current certificate and CA pool loaded from Files. Files are re-read lazily
+// during TLS handshakes, at most once per reload interval, when their modification time changes,
+// so rotated certificates are picked up by new connections. If a reload fails, the previously
+// loaded material stays in use.
+type Reloader struct {
+	files    Files
+	interval time.Duration
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	pool      *x509.CertPool
+	modTimes  map[string]time.Time
+	lastCheck time.Time
+	lastErr   error
+}
+
+// NewReloader loads the files and returns a reloader. A zero interval uses [DefaultReloadInterval],
+// a negative one disables reloading.
+func NewReloader(files Files, interval time.Duration) (*Reloader, error) {
+	if (files.CertFile == "") != (files.KeyFile == "") {
+		return nil, errors.New("certificate and key files must be configured together")
+	}
+	if interval == 0 {
+		interval = DefaultReloadInterval
+	}
+	r := &Reloader{files: files, interval: interval}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// HasCertificate reports whether a local certificate is configured.
+func (r *Reloader) HasCertificate() bool {
+	return r.files.CertFile != ""
+}
+
+// HasCA reports whether a CA bundle is configured.
+func (r *Reloader) HasCA() bool {
+	return r.files.CAFile != ""
+}
+
+// LastError returns the error of the most recent failed reload, or nil.
+func (r *Reloader) LastError() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lastErr
+}
+
+// Certificate returns the current certificate, reloading it first if the files changed.
+func (r *Reloader) Certificate() *tls.Certificate {
+	r.maybeReload()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert
+}
+
+// CAPool returns the current CA pool, reloading it first if the files changed.
+// It returns nil if no CA file is configured.
+func (r *Reloader) CAPool() *x509.CertPool {
+	r.maybeReload()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.pool
+}
+
+func (r *Reloader) maybeReload() {
+	if r.interval < 0 {
+		return
+	}
+	r.mu.RLock()
+	due := time.Since(r.lastCheck) >= r.interval
+	r.mu.RUnlock()
+	if !due {
+		return
+	}
+	if !r.changed() {
+		r.mu.Lock()
+		r.lastCheck = time.Now()
+		r.mu.Unlock()
+		return
+	}
+	err := r.reload()
+	r.mu.Lock()
+	r.lastErr = err
+	r.lastCheck = time.Now()
+	r.mu.Unlock()
+}
+
+func (r *Reloader) paths() []string {
+	var paths []string
+	for _, p := range []string{r.files.CertFile, r.files.KeyFile, r.files.CAFile} {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+func (r *Reloader) changed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, p := range r.paths() {
+		info, err := os.Stat(p)
+		if err != nil {
+			// Files may briefly disappear while being replaced, try again on the next check.
+			return false
+		}
+		if !info.ModTime().Equal(r.modTimes[p]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) reload() error {
+	modTimes := make(map[string]time.Time)
+	for _, p := range r.paths() {
+		info, err := os.Stat(p)
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", p, err)
+		}
+		modTimes[p] = info.ModTime()
+	}
+
+	var cert *tls.Certificate
+	if r.files.CertFile != "" {
+		c, err := tls.LoadX509KeyPair(r.files.CertFile, r.files.KeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load key pair: %w", err)
+		}
+		cert = &c
+	}
+	var pool *x509.CertPool
+	if r.files.CAFile != "" {
+		caPEM, err := os.ReadFile(r.files.CAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return fmt.Errorf("no certificates found in CA file %s", r.files.CAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = cert
+	r.pool = pool
+	r.modTimes = modTimes
+	return nil
+}